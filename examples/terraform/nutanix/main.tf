@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+provider "nutanix" {
+  # credentials are read from the NUTANIX_ENDPOINT, NUTANIX_PORT,
+  # NUTANIX_USERNAME, NUTANIX_PASSWORD and NUTANIX_INSECURE variables
+  wait_timeout = 60
+}
+
+data "nutanix_cluster" "cluster" {
+  name = var.nutanix_cluster_name
+}
+
+data "nutanix_project" "project" {
+  count        = var.project_name != "" ? 1 : 0
+  project_name = var.project_name
+}
+
+data "nutanix_subnet" "subnet" {
+  subnet_name = var.subnet_name
+}
+
+data "nutanix_image" "image" {
+  image_name = var.image_name
+}
+
+locals {
+  cloud_init = <<-CLOUDINIT
+  #cloud-config
+  users:
+    - name: ${var.ssh_username}
+      sudo: ALL=(ALL) NOPASSWD:ALL
+      shell: /bin/bash
+      ssh_authorized_keys:
+        - ${trimspace(file(var.ssh_public_key_file))}
+  CLOUDINIT
+}
+
+resource "nutanix_virtual_machine" "control_plane" {
+  count = 3
+  name  = "${var.cluster_name}-control-plane-${count.index + 1}"
+
+  cluster_uuid         = data.nutanix_cluster.cluster.id
+  num_vcpus_per_socket = var.control_plane_vcpus
+  num_sockets          = 1
+  memory_size_mib      = var.control_plane_memory_size
+
+  project_reference = var.project_name != "" ? {
+    kind = "project"
+    uuid = data.nutanix_project.project[0].id
+  } : null
+
+  disk_list {
+    data_source_reference = {
+      kind = "image"
+      uuid = data.nutanix_image.image.id
+    }
+    disk_size_mib = var.control_plane_disk_size * 1024
+  }
+
+  nic_list {
+    subnet_uuid = data.nutanix_subnet.subnet.id
+  }
+
+  guest_customization_cloud_init_user_data = base64encode(local.cloud_init)
+
+  categories {
+    name  = "KubeOneCluster"
+    value = var.cluster_name
+  }
+}
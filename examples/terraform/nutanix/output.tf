@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+output "kubeone_api" {
+  description = "kube-apiserver virtual IP, announced by kube-vip"
+
+  value = {
+    endpoint = var.api_vip
+  }
+}
+
+output "ssh_commands" {
+  value = formatlist("ssh ${var.ssh_username}@%s", local.control_plane_ips)
+}
+
+locals {
+  control_plane_ips = [for vm in nutanix_virtual_machine.control_plane : vm.nic_list_status[0].ip_endpoint_list[0].ip]
+}
+
+output "kubeone_hosts" {
+  description = "Control plane endpoints to SSH to"
+
+  value = {
+    control_plane = {
+      hostnames            = nutanix_virtual_machine.control_plane.*.name
+      cluster_name         = var.cluster_name
+      cloud_provider       = "nutanix"
+      private_address      = local.control_plane_ips
+      public_address       = local.control_plane_ips
+      ssh_agent_socket     = var.ssh_agent_socket
+      ssh_port             = var.ssh_port
+      ssh_private_key_file = var.ssh_private_key_file
+      ssh_user             = var.ssh_username
+    }
+  }
+}
+
+output "kubeone_workers" {
+  description = "Workers definitions, that will be transformed into MachineDeployment object"
+
+  value = {
+    # following outputs will be parsed by kubeone and automatically merged into
+    # corresponding (by name) worker definition
+    "${var.cluster_name}-pool1" = {
+      replicas = var.workers_replicas
+      providerSpec = {
+        sshPublicKeys   = [file(var.ssh_public_key_file)]
+        operatingSystem = var.worker_os
+        operatingSystemSpec = {
+          distUpgradeOnBoot = false
+        }
+        cloudProviderSpec = {
+          # provider specific fields:
+          # see example under `cloudProviderSpec` section at:
+          # https://github.com/kubermatic/machine-controller/blob/master/examples/nutanix-machinedeployment.yaml
+          clusterName = var.nutanix_cluster_name
+          projectName = var.project_name
+          subnetName  = var.subnet_name
+          imageName   = var.image_name
+          cpus        = var.worker_vcpus
+          memoryMB    = var.worker_memory_size
+          diskSize    = var.worker_disk_size
+          categories = {
+            "KubeOneCluster" = var.cluster_name
+          }
+        }
+      }
+    }
+  }
+}
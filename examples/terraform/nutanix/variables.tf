@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+variable "cluster_name" {
+  description = "prefix for cloud resources"
+  type        = string
+}
+
+variable "worker_os" {
+  description = "OS to run on worker machines"
+
+  # valid choices are:
+  # * ubuntu
+  # * centos
+  default = "ubuntu"
+  type    = string
+}
+
+variable "ssh_public_key_file" {
+  description = "SSH public key file"
+  default     = "~/.ssh/id_rsa.pub"
+  type        = string
+}
+
+variable "ssh_port" {
+  description = "SSH port to be used to provision instances"
+  default     = 22
+  type        = number
+}
+
+variable "ssh_username" {
+  description = "SSH user, used only in output"
+  default     = "ubuntu"
+  type        = string
+}
+
+variable "ssh_private_key_file" {
+  description = "SSH private key file used to access instances"
+  default     = ""
+  type        = string
+}
+
+variable "ssh_agent_socket" {
+  description = "SSH Agent socket, default to grab from $SSH_AUTH_SOCK"
+  default     = "env:SSH_AUTH_SOCK"
+  type        = string
+}
+
+# Provider specific settings
+
+variable "nutanix_cluster_name" {
+  description = "name of the Nutanix cluster (Prism Element) to create VMs in"
+  type        = string
+}
+
+variable "project_name" {
+  description = "name of the Nutanix project to assign VMs to, leave empty to not use a project"
+  default     = ""
+  type        = string
+}
+
+variable "subnet_name" {
+  description = "name of the subnet to attach VMs to"
+  type        = string
+}
+
+variable "image_name" {
+  description = "name of the image to create VMs from"
+  default     = "ubuntu-20.04"
+  type        = string
+}
+
+variable "api_vip" {
+  description = "virtual IP address in the subnet to be used as the kube-apiserver endpoint"
+  type        = string
+}
+
+variable "control_plane_vcpus" {
+  default = 2
+  type    = number
+}
+
+variable "control_plane_memory_size" {
+  description = "memory size of control plane VMs in MiB"
+  default     = 4096
+  type        = number
+}
+
+variable "control_plane_disk_size" {
+  description = "disk size of control plane VMs in GiB"
+  default     = 50
+  type        = number
+}
+
+variable "worker_vcpus" {
+  default = 2
+  type    = number
+}
+
+variable "worker_memory_size" {
+  description = "memory size of worker VMs in MiB"
+  default     = 4096
+  type        = number
+}
+
+variable "worker_disk_size" {
+  description = "disk size of worker VMs in GiB"
+  default     = 50
+  type        = number
+}
+
+variable "workers_replicas" {
+  default = 1
+  type    = number
+}
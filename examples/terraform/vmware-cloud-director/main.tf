@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+provider "vcd" {
+  # credentials are read from the VCD_USER, VCD_PASSWORD, VCD_ORG, VCD_URL,
+  # VCD_VDC and VCD_ALLOW_UNVERIFIED_SSL variables
+}
+
+resource "vcd_vapp" "cluster" {
+  name        = var.cluster_name
+  description = "KubeOne cluster ${var.cluster_name}"
+}
+
+resource "vcd_vapp_org_network" "network" {
+  vapp_name        = vcd_vapp.cluster.name
+  org_network_name = var.network_name
+}
+
+resource "vcd_vapp_vm" "control_plane" {
+  count = 3
+
+  vapp_name     = vcd_vapp.cluster.name
+  name          = "${var.cluster_name}-control-plane-${count.index + 1}"
+  computer_name = "${var.cluster_name}-control-plane-${count.index + 1}"
+  catalog_name  = var.catalog_name
+  template_name = var.template_name
+  memory        = var.control_plane_memory
+  cpus          = var.control_plane_cpus
+  cpu_cores     = 1
+
+  guest_properties = {
+    "instance-id" = "${var.cluster_name}-control-plane-${count.index + 1}"
+    "hostname"    = "${var.cluster_name}-control-plane-${count.index + 1}"
+    "public-keys" = file(var.ssh_public_key_file)
+  }
+
+  metadata = {
+    "KubeOneCluster" = var.cluster_name
+    "role"           = "api"
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.network.org_network_name
+    ip_allocation_mode = "POOL"
+    is_primary         = true
+  }
+
+  override_template_disk {
+    bus_type    = "paravirtual"
+    size_in_mb  = var.control_plane_disk_size * 1024
+    bus_number  = 0
+    unit_number = 0
+  }
+}
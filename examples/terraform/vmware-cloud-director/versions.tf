@@ -0,0 +1,9 @@
+terraform {
+  required_version = ">= 1.0.0"
+  required_providers {
+    vcd = {
+      source  = "vmware/vcd"
+      version = "~> 3.5.0"
+    }
+  }
+}
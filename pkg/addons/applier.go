@@ -19,6 +19,7 @@ package addons
 import (
 	"io/fs"
 	"os"
+	"path/filepath"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/pkg/errors"
@@ -63,6 +64,7 @@ type templateData struct {
 	MachineControllerCredentialsEnvVars string
 	InternalImages                      *internalImages
 	Resources                           map[string]string
+	NodeLocalDNSVirtualIPs              []string
 	Params                              map[string]string
 }
 
@@ -70,12 +72,11 @@ func newAddonsApplier(s *state.State) (*applier, error) {
 	var localFS fs.FS
 
 	if s.Cluster.Addons.Enabled() {
-		addonsPath, err := s.Cluster.Addons.RelativePath(s.ManifestFilePath)
+		var err error
+		localFS, err = userAddonsFS(s)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to get addons path")
+			return nil, err
 		}
-
-		localFS = os.DirFS(addonsPath)
 	}
 
 	creds, err := credentials.Any(s.CredentialsFilePath)
@@ -162,8 +163,25 @@ func newAddonsApplier(s *state.State) (*applier, error) {
 			pauseImage: s.PauseImage,
 			resolver:   s.Images.Get,
 		},
-		Resources: resources.All(),
-		Params:    params,
+		Resources:              resources.All(),
+		NodeLocalDNSVirtualIPs: resources.NodeLocalDNSVirtualIPs(s.Cluster.ClusterNetwork.ServiceSubnets(), s.Cluster.Features.NodeLocalDNS),
+		Params:                 params,
+	}
+
+	// Certs for operating-system-manager-webhook
+	if s.Cluster.OperatingSystemManagerEnabled() {
+		osmCertsMap, err := certificate.NewSignedTLSCert(
+			resources.OperatingSystemManagerWebhookName,
+			resources.OperatingSystemManagerNamespace,
+			s.Cluster.ClusterNetwork.ServiceDomainName,
+			kubeCAPrivateKey,
+			kubeCACert,
+		)
+		if err != nil {
+			return nil, err
+		}
+		data.Certificates["OperatingSystemManagerWebhookCert"] = osmCertsMap[resources.TLSCertName]
+		data.Certificates["OperatingSystemManagerWebhookKey"] = osmCertsMap[resources.TLSKeyName]
 	}
 
 	// Certs for vsphere-csi-webhook (deployed only if CSIMigration is enabled)
@@ -189,6 +207,44 @@ func newAddonsApplier(s *state.State) (*applier, error) {
 	}, nil
 }
 
+// userAddonsFS returns the file system with the addons from the addons
+// directory merged with the addons fetched from the remote sources
+func userAddonsFS(s *state.State) (fs.FS, error) {
+	layers := mergedFS{}
+
+	if s.Cluster.Addons.Path != "" {
+		addonsPath, err := s.Cluster.Addons.RelativePath(s.ManifestFilePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get addons path")
+		}
+
+		layers = append(layers, os.DirFS(addonsPath))
+	}
+
+	if len(s.Cluster.Addons.Sources) > 0 {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get user cache directory")
+		}
+		cacheDir = filepath.Join(cacheDir, addonsCacheDir)
+
+		for i, src := range s.Cluster.Addons.Sources {
+			dir, err := fetchAddonSource(s.Context, s.Logger, cacheDir, src)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to fetch addons source %d", i)
+			}
+
+			layers = append(layers, os.DirFS(dir))
+		}
+	}
+
+	if len(layers) == 1 {
+		return layers[0], nil
+	}
+
+	return layers, nil
+}
+
 type internalImages struct {
 	pauseImage string
 	resolver   func(images.Resource, ...images.GetOpt) string
@@ -19,14 +19,22 @@ package addons
 import (
 	"fmt"
 	"io/fs"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clientutil"
+	"k8c.io/kubeone/pkg/scripts"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates/resources"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -38,20 +46,38 @@ var (
 	// embeddedAddons is a list of addons that are embedded in the KubeOne
 	// binary. Those addons are skipped when applying a user-provided addon with the same name.
 	embeddedAddons = map[string]string{
-		resources.AddonCCMAzure:           "",
-		resources.AddonCCMDigitalOcean:    "",
-		resources.AddonCCMHetzner:         "",
-		resources.AddonCCMOpenStack:       "",
-		resources.AddonCCMPacket:          "",
-		resources.AddonCCMVsphere:         "",
-		resources.AddonCNICanal:           "",
-		resources.AddonCNIWeavenet:        "",
-		resources.AddonCSIHetnzer:         "",
-		resources.AddonCSIOpenStackCinder: "",
-		resources.AddonCSIVsphere:         "",
-		resources.AddonMachineController:  "",
-		resources.AddonMetricsServer:      "",
-		resources.AddonNodeLocalDNS:       "",
+		resources.AddonCCMAws:                 "",
+		resources.AddonClusterAutoscaler:      "",
+		resources.AddonCCMAzure:               "",
+		resources.AddonCCMDigitalOcean:        "",
+		resources.AddonCCMHetzner:             "",
+		resources.AddonCCMKubeVirt:            "",
+		resources.AddonCCMNutanix:             "",
+		resources.AddonCCMOpenStack:           "",
+		resources.AddonCCMPacket:              "",
+		resources.AddonCCMVsphere:             "",
+		resources.AddonCCMVMwareCloudDirector: "",
+		resources.AddonCNICalico:              "",
+		resources.AddonCNICanal:               "",
+		resources.AddonCNICilium:              "",
+		resources.AddonCNIWeavenet:            "",
+		resources.AddonCSIAwsEBS:              "",
+		resources.AddonCSIAzureDisk:           "",
+		resources.AddonCSIAzureFile:           "",
+		resources.AddonCSIHetnzer:             "",
+		resources.AddonCSIKubeVirt:            "",
+		resources.AddonCSINutanix:             "",
+		resources.AddonCSIOpenStackCinder:     "",
+		resources.AddonCSIVsphere:             "",
+		resources.AddonCSIVMwareCloudDirector: "",
+		resources.AddonGatekeeper:             "",
+		resources.AddonGatekeeperLibrary:      "",
+		resources.AddonKonnectivityAgent:      "",
+		resources.AddonMachineController:      "",
+		resources.AddonMetricsServer:          "",
+		resources.AddonNodeLocalDNS:           "",
+		resources.AddonOperatingSystemManager: "",
+		resources.AddonSRIOV:                  "",
 	}
 )
 
@@ -75,7 +101,15 @@ func EnsureUserAddons(s *state.State) error {
 		return errors.Wrap(err, "failed to read addons directory")
 	}
 
+	helmAddons := map[string]string{}
+	for _, addon := range s.Cluster.Addons.Addons {
+		if addon.Helm != nil {
+			helmAddons[addon.Name] = ""
+		}
+	}
+
 	combinedAddons := map[string]string{}
+	addonConfigs := map[string]kubeoneapi.Addon{}
 	for _, useraddon := range customAddons {
 		if !useraddon.IsDir() {
 			continue
@@ -85,6 +119,11 @@ func EnsureUserAddons(s *state.State) error {
 			continue
 		}
 
+		// Helm addons take precedence over the addons directory with the same name
+		if _, ok := helmAddons[useraddon.Name()]; ok {
+			continue
+		}
+
 		if _, ok := combinedAddons[useraddon.Name()]; !ok {
 			combinedAddons[useraddon.Name()] = ""
 		}
@@ -96,23 +135,63 @@ func EnsureUserAddons(s *state.State) error {
 		}
 
 		if embeddedAddon.Delete {
+			proceed, err := s.ConfirmStep("delete the addon %q", embeddedAddon.Name)
+			if err != nil {
+				return err
+			}
+			if !proceed {
+				s.Logger.Warnf("Skipping deletion of addon %q...", embeddedAddon.Name)
+				continue
+			}
+
 			s.Logger.Infof("Deleting addon %q...", embeddedAddon.Name)
+			if embeddedAddon.Helm != nil {
+				if err := loadAndDeleteHelmAddon(s, embeddedAddon); err != nil {
+					return errors.Wrapf(err, "failed to load and delete the addon %q", embeddedAddon.Name)
+				}
+				continue
+			}
 			if err := applier.loadAndDeleteAddon(s, applier.EmbededFS, embeddedAddon.Name); err != nil {
 				return errors.Wrapf(err, "failed to load and delete the addon %q", embeddedAddon.Name)
 			}
 			continue
 		}
 
+		addonConfigs[embeddedAddon.Name] = embeddedAddon
 		if _, ok := combinedAddons[embeddedAddon.Name]; !ok {
 			combinedAddons[embeddedAddon.Name] = ""
 		}
 	}
 
+	addonNames := make([]string, 0, len(combinedAddons))
 	for addonName := range combinedAddons {
-		s.Logger.Infof("Applying addon %q...", addonName)
+		addonNames = append(addonNames, addonName)
+	}
 
-		if err := EnsureAddonByName(s, addonName); err != nil {
-			return errors.Wrapf(err, "failed to load and apply the addon %q", addonName)
+	sortedAddons, err := sortAddons(addonNames, addonConfigs)
+	if err != nil {
+		return err
+	}
+
+	for _, addonName := range sortedAddons {
+		addon := addonConfigs[addonName]
+
+		if addon.Helm != nil {
+			s.Logger.Infof("Applying addon %q from the helm chart %q...", addonName, addon.Helm.Chart)
+			if err := loadAndApplyHelmAddon(s, addon); err != nil {
+				return errors.Wrapf(err, "failed to load and apply the addon %q", addonName)
+			}
+		} else {
+			s.Logger.Infof("Applying addon %q...", addonName)
+			if err := EnsureAddonByName(s, addonName); err != nil {
+				return errors.Wrapf(err, "failed to load and apply the addon %q", addonName)
+			}
+		}
+
+		if addon.WaitReady {
+			if err := WaitForAddonReady(s, addonName); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -216,14 +295,19 @@ func (a *applier) loadAndDeleteAddon(s *state.State, fsys fs.FS, addonName strin
 
 // runKubectlApply runs kubectl apply command
 func runKubectlApply(s *state.State, manifest string, addonName string) error {
-	return s.RunTaskOnLeader(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	if s.DryRun() {
+		s.DryRunOutput.Add(dryRunManifestName(addonName, "apply"), manifest)
+		return nil
+	}
+
+	err := s.RunTaskOnLeader(func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
 		var (
 			cmd            = fmt.Sprintf(kubectlApplyScript, addonLabel, addonName)
 			stdin          = strings.NewReader(manifest)
 			stdout, stderr strings.Builder
 		)
 
-		_, err := conn.POpen(cmd, stdin, &stdout, &stderr)
+		_, err := conn.POpen(scripts.WithEnvironment(cmd, node.Env), stdin, &stdout, &stderr)
 		if s.Verbose {
 			fmt.Printf("+ %s\n", cmd)
 			fmt.Printf("%s", stderr.String())
@@ -232,18 +316,48 @@ func runKubectlApply(s *state.State, manifest string, addonName string) error {
 
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	s.RecordClusterEvent(state.EventReasonAddonApplied, "Applied %s", addonDescription(addonName))
+
+	return nil
+}
+
+// WaitForAddonReady waits for the CustomResourceDefinitions and the
+// workloads deployed by the addon to become ready
+func WaitForAddonReady(s *state.State, addonName string) error {
+	if s.DryRun() {
+		return nil
+	}
+
+	s.Logger.Infof("Waiting for addon %q to become ready...", addonName)
+
+	listOpts := dynclient.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{addonLabel: addonName}),
+	}
+
+	err := wait.PollImmediate(5*time.Second, 5*time.Minute, clientutil.AddonResourcesReadyCondition(s.Context, s.DynamicClient, listOpts))
+
+	return errors.Wrapf(err, "addon %q didn't become ready", addonName)
 }
 
 // runKubectlDelete runs kubectl delete command
 func runKubectlDelete(s *state.State, manifest string, addonName string) error {
-	return s.RunTaskOnLeader(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	if s.DryRun() {
+		s.DryRunOutput.Add(dryRunManifestName(addonName, "delete"), manifest)
+		return nil
+	}
+
+	err := s.RunTaskOnLeader(func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
 		var (
 			cmd            = fmt.Sprintf(kubectlDeleteScript, addonLabel, addonName)
 			stdin          = strings.NewReader(manifest)
 			stdout, stderr strings.Builder
 		)
 
-		_, err := conn.POpen(cmd, stdin, &stdout, &stderr)
+		_, err := conn.POpen(scripts.WithEnvironment(cmd, node.Env), stdin, &stdout, &stderr)
 		if s.Verbose {
 			fmt.Printf("+ %s\n", cmd)
 			fmt.Printf("%s", stderr.String())
@@ -252,4 +366,31 @@ func runKubectlDelete(s *state.State, manifest string, addonName string) error {
 
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	s.RecordClusterEvent(state.EventReasonAddonDeleted, "Deleted %s", addonDescription(addonName))
+
+	return nil
+}
+
+// dryRunManifestName returns the name under which the manifest of the addon
+// is recorded in the dry-run mode. Addons from the root of the addons
+// directory don't have a name.
+func dryRunManifestName(addonName, action string) string {
+	if addonName == "" {
+		addonName = "_root"
+	}
+
+	return path.Join("addons", action, addonName+".yaml")
+}
+
+// addonDescription describes the addon in the recorded Events
+func addonDescription(addonName string) string {
+	if addonName == "" {
+		return "addons from the root of the addons directory"
+	}
+
+	return fmt.Sprintf("addon %q", addonName)
 }
@@ -0,0 +1,291 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	orasauth "github.com/deislabs/oras/pkg/auth/docker"
+	orascontent "github.com/deislabs/oras/pkg/content"
+	"github.com/deislabs/oras/pkg/oras"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/state"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const ociChartPrefix = "oci://"
+
+// helmChartLayerMediaTypes are the media types of the chart archive layer
+// pushed by the different Helm versions
+var helmChartLayerMediaTypes = []string{
+	"application/tar+gzip",
+	"application/vnd.cncf.helm.chart.content.v1.tar+gzip",
+}
+
+// loadAndApplyHelmAddon renders the Helm chart of the addon and runs kubectl
+// apply, so the chart resources are pruned the same way as for the other
+// addons.
+func loadAndApplyHelmAddon(s *state.State, addon kubeoneapi.Addon) error {
+	manifest, err := renderHelmAddon(s, addon)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(
+		runKubectlApply(s, manifest, addon.Name),
+		"failed to apply addons",
+	)
+}
+
+// loadAndDeleteHelmAddon renders the Helm chart of the addon and runs
+// kubectl delete.
+func loadAndDeleteHelmAddon(s *state.State, addon kubeoneapi.Addon) error {
+	manifest, err := renderHelmAddon(s, addon)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(
+		runKubectlDelete(s, manifest, addon.Name),
+		"failed to delete addons",
+	)
+}
+
+// renderHelmAddon renders the Helm chart of the addon to the manifest
+// labeled with the addon label. The chart hooks are not run, and the
+// namespace of the release is created with the addon.
+func renderHelmAddon(s *state.State, addon kubeoneapi.Addon) (string, error) {
+	settings := cli.New()
+
+	chrt, err := loadHelmChart(s, addon.Helm, settings)
+	if err != nil {
+		return "", err
+	}
+
+	vals, err := helmValues(s, addon.Helm, settings)
+	if err != nil {
+		return "", err
+	}
+
+	namespace := addon.Helm.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceSystem
+	}
+
+	install := action.NewInstall(&action.Configuration{Log: s.Logger.Debugf})
+	install.DryRun = true
+	install.ClientOnly = true
+	install.Replace = true
+	install.IncludeCRDs = true
+	install.ReleaseName = addon.Name
+	install.Namespace = namespace
+
+	rel, err := install.Run(chrt, vals)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to render the helm chart %q", addon.Helm.Chart)
+	}
+
+	manifests, err := decodeManifests(bytes.NewBufferString(rel.Manifest), addon.Name)
+	if err != nil {
+		return "", err
+	}
+
+	manifests, err = ensureNamespaceOnResources(manifests, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	switch namespace {
+	case metav1.NamespaceDefault, metav1.NamespaceSystem, metav1.NamespacePublic:
+	default:
+		ns, nerr := yaml.Marshal(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]interface{}{"name": namespace},
+		})
+		if nerr != nil {
+			return "", errors.WithStack(nerr)
+		}
+		nsJSON, nerr := yaml.YAMLToJSON(ns)
+		if nerr != nil {
+			return "", errors.WithStack(nerr)
+		}
+		manifests = append([]runtime.RawExtension{{Raw: nsJSON}}, manifests...)
+	}
+
+	rawManifests, err := ensureAddonsLabelsOnResources(manifests, addon.Name)
+	if err != nil {
+		return "", err
+	}
+
+	return combineManifests(rawManifests).String(), nil
+}
+
+// loadHelmChart pulls the chart from the OCI registry or the chart
+// repository, or loads it from the local file system
+func loadHelmChart(s *state.State, h *kubeoneapi.HelmChart, settings *cli.EnvSettings) (*chart.Chart, error) {
+	if strings.HasPrefix(h.Chart, ociChartPrefix) {
+		return pullOCIHelmChart(h, settings)
+	}
+
+	name := h.Chart
+	if h.RepoURL == "" {
+		var err error
+		if name, err = relativeToManifest(s, name); err != nil {
+			return nil, err
+		}
+	}
+
+	pathOptions := action.ChartPathOptions{
+		RepoURL: h.RepoURL,
+		Version: h.Version,
+	}
+
+	chartPath, err := pathOptions.LocateChart(name, settings)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to locate the chart %q", h.Chart)
+	}
+
+	chrt, err := loader.Load(chartPath)
+
+	return chrt, errors.Wrapf(err, "failed to load the chart %q", h.Chart)
+}
+
+// pullOCIHelmChart pulls the chart archive from the OCI registry using the
+// credentials from the Helm registry config and the docker config
+func pullOCIHelmChart(h *kubeoneapi.HelmChart, settings *cli.EnvSettings) (*chart.Chart, error) {
+	ref := strings.TrimPrefix(h.Chart, ociChartPrefix) + ":" + h.Version
+
+	authClient, err := orasauth.NewClient(settings.RegistryConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize the registry client")
+	}
+
+	ctx := context.Background()
+	resolver, err := authClient.Resolver(ctx, http.DefaultClient, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize the registry resolver")
+	}
+
+	store := orascontent.NewMemoryStore()
+	_, layers, err := oras.Pull(ctx, resolver, ref, store,
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithAllowedMediaTypes(helmChartLayerMediaTypes),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to pull the chart %q", h.Chart)
+	}
+
+	for _, layer := range layers {
+		_, data, ok := store.Get(layer)
+		if !ok {
+			continue
+		}
+
+		chrt, err := loader.LoadArchive(bytes.NewReader(data))
+
+		return chrt, errors.Wrapf(err, "failed to load the chart %q", h.Chart)
+	}
+
+	return nil, errors.Errorf("the chart %q has no chart content layer", h.Chart)
+}
+
+// helmValues merges the values from the values files with the inline values
+func helmValues(s *state.State, h *kubeoneapi.HelmChart, settings *cli.EnvSettings) (map[string]interface{}, error) {
+	opts := values.Options{}
+	for _, valuesFile := range h.ValuesFiles {
+		valuesPath, err := relativeToManifest(s, valuesFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.ValueFiles = append(opts.ValueFiles, valuesPath)
+	}
+
+	vals, err := opts.MergeValues(getter.All(settings))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the values files")
+	}
+
+	if h.Values == "" {
+		return vals, nil
+	}
+
+	inline := map[string]interface{}{}
+	if err = yaml.Unmarshal([]byte(h.Values), &inline); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the values")
+	}
+
+	// inline values take precedence over the values files
+	return chartutil.CoalesceTables(inline, vals), nil
+}
+
+// ensureNamespaceOnResources sets the namespace on the resources without
+// one, as the charts commonly rely on Helm to set the release namespace.
+// The namespace set on the cluster-scoped resources is ignored by kubectl.
+func ensureNamespaceOnResources(manifests []runtime.RawExtension, namespace string) ([]runtime.RawExtension, error) {
+	for i, m := range manifests {
+		obj := &metav1unstructured.Unstructured{}
+		if _, _, err := metav1unstructured.UnstructuredJSONScheme.Decode(m.Raw, nil, obj); err != nil {
+			return nil, errors.Wrap(err, "failed to parse unstructured fields")
+		}
+
+		if obj.GetNamespace() != "" {
+			continue
+		}
+		obj.SetNamespace(namespace)
+
+		buf := &bytes.Buffer{}
+		if err := metav1unstructured.UnstructuredJSONScheme.Encode(obj, buf); err != nil {
+			return nil, errors.Wrap(err, "encoding json failed")
+		}
+		manifests[i].Raw = buf.Bytes()
+	}
+
+	return manifests, nil
+}
+
+// relativeToManifest returns the path relative to the KubeOneCluster
+// manifest file path
+func relativeToManifest(s *state.State, p string) (string, error) {
+	if filepath.IsAbs(p) || s.ManifestFilePath == "" {
+		return p, nil
+	}
+
+	manifestAbsPath, err := filepath.Abs(filepath.Dir(s.ManifestFilePath))
+	if err != nil {
+		return "", errors.Wrap(err, "unable to get absolute path to the cluster manifest")
+	}
+
+	return filepath.Join(manifestAbsPath, p), nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/state"
+)
+
+// l2LoadBalancerAddons are the addons announcing LoadBalancer IPs using ARP.
+// kube-proxy in the IPVS mode must run with strictARP for such addons to work.
+var l2LoadBalancerAddons = []string{"metallb", "kube-vip", "openelb"}
+
+// L2LoadBalancerAddon returns the name of the deployed addon announcing
+// LoadBalancer IPs using ARP, or an empty string if there is no such addon
+func L2LoadBalancerAddon(cluster *kubeoneapi.KubeOneCluster, manifestFilePath string) (string, error) {
+	if !cluster.Addons.Enabled() {
+		return "", nil
+	}
+
+	names := []string{}
+	for _, addon := range cluster.Addons.Addons {
+		if !addon.Delete {
+			names = append(names, addon.Name)
+		}
+	}
+
+	if cluster.Addons.Path != "" {
+		addonsPath, err := cluster.Addons.RelativePath(manifestFilePath)
+		if err != nil {
+			return "", err
+		}
+
+		entries, err := os.ReadDir(addonsPath)
+		if err != nil && !os.IsNotExist(err) {
+			return "", errors.Wrap(err, "failed to read addons directory")
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+
+	for _, name := range names {
+		for _, known := range l2LoadBalancerAddons {
+			if strings.Contains(strings.ToLower(name), known) {
+				return name, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// KubeProxyStrictARP returns whether kube-proxy in the IPVS mode should run
+// with strictARP. The explicitly configured value is used if set, otherwise
+// strictARP is enabled if an addon announcing LoadBalancer IPs using ARP is
+// deployed.
+func KubeProxyStrictARP(s *state.State) bool {
+	kubeProxy := s.Cluster.ClusterNetwork.KubeProxy
+	if kubeProxy == nil || kubeProxy.IPVS == nil {
+		return false
+	}
+
+	if kubeProxy.IPVS.StrictARP != nil {
+		return *kubeProxy.IPVS.StrictARP
+	}
+
+	addon, err := L2LoadBalancerAddon(s.Cluster, s.ManifestFilePath)
+	if err != nil {
+		s.Logger.Warnf("Unable to detect load balancer addons, strictARP is not enabled: %v", err)
+		return false
+	}
+
+	return addon != ""
+}
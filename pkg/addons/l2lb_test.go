@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestL2LoadBalancerAddon(t *testing.T) {
+	addonsDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(addonsDir, "metallb"), 0750); err != nil {
+		t.Fatalf("failed to create addon directory: %v", err)
+	}
+	emptyDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		addons   *kubeoneapi.Addons
+		expected string
+	}{
+		{
+			name:     "addons disabled",
+			addons:   &kubeoneapi.Addons{Path: addonsDir},
+			expected: "",
+		},
+		{
+			name:     "addon directory",
+			addons:   &kubeoneapi.Addons{Enable: true, Path: addonsDir},
+			expected: "metallb",
+		},
+		{
+			name: "named addon",
+			addons: &kubeoneapi.Addons{
+				Enable: true,
+				Path:   emptyDir,
+				Addons: []kubeoneapi.Addon{{Name: "kube-vip-cloud-provider"}},
+			},
+			expected: "kube-vip-cloud-provider",
+		},
+		{
+			name: "deleted addon",
+			addons: &kubeoneapi.Addons{
+				Enable: true,
+				Path:   emptyDir,
+				Addons: []kubeoneapi.Addon{{Name: "metallb", Delete: true}},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := L2LoadBalancerAddon(&kubeoneapi.KubeOneCluster{Addons: tc.addons}, "")
+			if err != nil {
+				t.Fatalf("L2LoadBalancerAddon() error = %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("L2LoadBalancerAddon() got = %q, expected %q", got, tc.expected)
+			}
+		})
+	}
+}
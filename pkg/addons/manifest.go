@@ -23,6 +23,7 @@ import (
 	"io"
 	"io/fs"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -31,8 +32,11 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/certificate/cabundle"
+	"k8c.io/kubeone/pkg/credentials"
 	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/resources"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -47,15 +51,20 @@ func (a *applier) getManifestsFromDirectory(s *state.State, fsys fs.FS, addonNam
 		overwriteRegistry = s.Cluster.RegistryConfiguration.OverwriteRegistry
 	}
 
-	var addonParams map[string]string
+	addonParams := map[string]string{}
 	if s.Cluster.Addons.Enabled() {
 		for _, addon := range s.Cluster.Addons.Addons {
 			if addon.Name == addonName {
-				addonParams = addon.Params
+				for k, v := range addon.Params {
+					addonParams[k] = v
+				}
 				break
 			}
 		}
 	}
+	for k, v := range cniAddonParams(s.Cluster.ClusterNetwork.CNI, addonName) {
+		addonParams[k] = v
+	}
 
 	manifests, err := a.loadAddonsManifests(fsys, addonName, addonParams, s.Logger, s.Verbose, overwriteRegistry)
 	if err != nil {
@@ -73,6 +82,27 @@ func (a *applier) getManifestsFromDirectory(s *state.State, fsys fs.FS, addonNam
 }
 
 // loadAddonsManifests loads all YAML files from a given directory and runs the templating logic
+// cniAddonParams returns the params configured in the CNI spec for the
+// embedded CNI addon, or nil if the addon is not the configured CNI addon
+func cniAddonParams(cni *kubeoneapi.CNI, addonName string) map[string]string {
+	if cni == nil {
+		return nil
+	}
+
+	switch {
+	case addonName == resources.AddonCNICanal && cni.Canal != nil:
+		return cni.Canal.Params
+	case addonName == resources.AddonCNICalico && cni.Calico != nil:
+		return cni.Calico.Params
+	case addonName == resources.AddonCNICilium && cni.Cilium != nil:
+		return cni.Cilium.Params
+	case addonName == resources.AddonCNIWeavenet && cni.WeaveNet != nil:
+		return cni.WeaveNet.Params
+	}
+
+	return nil
+}
+
 func (a *applier) loadAddonsManifests(
 	fsys fs.FS,
 	addonName string,
@@ -141,34 +171,47 @@ func (a *applier) loadAddonsManifests(
 			logger.Infof("Addons manifest '%s' is empty after parsing. Skipping.\n", file.Name())
 		}
 
-		reader := kyaml.NewYAMLReader(bufio.NewReader(buf))
-		for {
-			b, err := reader.Read()
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
-				return nil, errors.Wrapf(err, "failed reading from YAML reader for manifest %s", file.Name())
-			}
+		decoded, err := decodeManifests(buf, file.Name())
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, decoded...)
+	}
 
-			b = bytes.TrimSpace(b)
-			if len(b) == 0 {
-				continue
-			}
+	return manifests, nil
+}
 
-			decoder := kyaml.NewYAMLToJSONDecoder(bytes.NewBuffer(b))
-			raw := runtime.RawExtension{}
-			if err := decoder.Decode(&raw); err != nil {
-				return nil, errors.Wrapf(err, "failed to decode manifest %s", file.Name())
-			}
+// decodeManifests decodes the multi-document YAML manifest
+func decodeManifests(r io.Reader, manifestName string) ([]runtime.RawExtension, error) {
+	var manifests []runtime.RawExtension
 
-			if len(raw.Raw) == 0 {
-				// This can happen if the manifest contains only comments
-				continue
+	reader := kyaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		b, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
 			}
+			return nil, errors.Wrapf(err, "failed reading from YAML reader for manifest %s", manifestName)
+		}
+
+		b = bytes.TrimSpace(b)
+		if len(b) == 0 {
+			continue
+		}
 
-			manifests = append(manifests, raw)
+		decoder := kyaml.NewYAMLToJSONDecoder(bytes.NewBuffer(b))
+		raw := runtime.RawExtension{}
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode manifest %s", manifestName)
 		}
+
+		if len(raw.Raw) == 0 {
+			// This can happen if the manifest contains only comments
+			continue
+		}
+
+		manifests = append(manifests, raw)
 	}
 
 	return manifests, nil
@@ -233,6 +276,66 @@ type vsphereCSIWebhookConfigWrapper struct {
 	WebHookConfig vsphereCSIWebhookConfig `toml:"WebHookConfig"`
 }
 
+// packetCCMConfig is the configuration file of the Equinix Metal CCM
+type packetCCMConfig struct {
+	APIKey                  string `json:"apiKey"`
+	ProjectID               string `json:"projectID"`
+	Metro                   string `json:"metro,omitempty"`
+	LoadBalancer            string `json:"loadbalancer,omitempty"`
+	LocalASN                int    `json:"localASN,omitempty"`
+	BGPPass                 string `json:"bgpPass,omitempty"`
+	BGPNodeSelector         string `json:"bgpNodeSelector,omitempty"`
+	EIPTag                  string `json:"eipTag,omitempty"`
+	EIPHealthCheckUseHostIP bool   `json:"eipHealthCheckUseHostIP,omitempty"`
+	APIServerPort           int    `json:"apiServerPort,omitempty"`
+}
+
+// nutanixCCMConfig is the configuration file of the Nutanix CCM
+type nutanixCCMConfig struct {
+	PrismCentral struct {
+		Address       string `json:"address"`
+		Port          int    `json:"port"`
+		Insecure      bool   `json:"insecure"`
+		CredentialRef struct {
+			Kind string `json:"kind"`
+			Name string `json:"name"`
+		} `json:"credentialRef"`
+	} `json:"prismCentral"`
+	TopologyDiscovery struct {
+		Type string `json:"type"`
+	} `json:"topologyDiscovery"`
+}
+
+// nutanixCredentials are the Prism Central credentials referenced by the
+// Nutanix CCM configuration
+type nutanixCredentials struct {
+	Type string `json:"type"`
+	Data struct {
+		PrismCentral struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"prismCentral"`
+	} `json:"data"`
+}
+
+// openstackCloudConfigKeys maps the OpenStack credentials to the keys of the
+// [Global] section of the OpenStack CCM and CSI cloud-config
+var openstackCloudConfigKeys = []struct {
+	key        string
+	credential string
+	userOnly   bool
+}{
+	{key: "auth-url", credential: credentials.OpenStackAuthURL},
+	{key: "username", credential: credentials.OpenStackUserName, userOnly: true},
+	{key: "password", credential: credentials.OpenStackPassword, userOnly: true},
+	{key: "tenant-id", credential: credentials.OpenStackTenantID, userOnly: true},
+	{key: "tenant-name", credential: credentials.OpenStackTenantName, userOnly: true},
+	{key: "domain-name", credential: credentials.OpenStackDomainName, userOnly: true},
+	{key: "application-credential-id", credential: credentials.OpenStackApplicationCredentialID},
+	{key: "application-credential-secret", credential: credentials.OpenStackApplicationCredentialSecret},
+	{key: "region", credential: credentials.OpenStackRegionName},
+}
+
 func txtFuncMap(overwriteRegistry string) template.FuncMap {
 	funcs := sprig.TxtFuncMap()
 
@@ -271,6 +374,84 @@ func txtFuncMap(overwriteRegistry string) template.FuncMap {
 		return string(buf), err
 	}
 
+	funcs["PacketCCMConfig"] = func(apiKey, projectID, bgpPassword string, spec *kubeoneapi.PacketSpec, apiServerPort int) (string, error) {
+		cfg := packetCCMConfig{
+			APIKey:        apiKey,
+			ProjectID:     projectID,
+			APIServerPort: apiServerPort,
+		}
+		if spec != nil {
+			cfg.Metro = spec.Metro
+			cfg.LoadBalancer = spec.LoadBalancer
+			if spec.BGP != nil && spec.BGP.Enable {
+				cfg.LocalASN = spec.BGP.LocalASN
+				cfg.BGPPass = bgpPassword
+				cfg.BGPNodeSelector = spec.BGP.NodeSelector
+			}
+			if spec.ElasticIP != nil {
+				cfg.EIPTag = spec.ElasticIP.Tag
+				cfg.EIPHealthCheckUseHostIP = spec.ElasticIP.HealthCheckUseHostIP
+			}
+		}
+
+		buf, err := json.Marshal(cfg)
+		return string(buf), err
+	}
+
+	funcs["NutanixCCMConfig"] = func(endpoint, port, insecure string) (string, error) {
+		portNumber, err := strconv.Atoi(port)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid nutanix port %q", port)
+		}
+		skipVerify := false
+		if insecure != "" {
+			if skipVerify, err = strconv.ParseBool(insecure); err != nil {
+				return "", errors.Wrapf(err, "invalid nutanix insecure value %q", insecure)
+			}
+		}
+
+		cfg := nutanixCCMConfig{}
+		cfg.PrismCentral.Address = endpoint
+		cfg.PrismCentral.Port = portNumber
+		cfg.PrismCentral.Insecure = skipVerify
+		cfg.PrismCentral.CredentialRef.Kind = "secret"
+		cfg.PrismCentral.CredentialRef.Name = "nutanix-creds"
+		cfg.TopologyDiscovery.Type = "Prism"
+
+		buf, err := json.Marshal(cfg)
+		return string(buf), err
+	}
+
+	funcs["NutanixCredentials"] = func(username, password string) (string, error) {
+		creds := []nutanixCredentials{
+			{Type: "basic_auth"},
+		}
+		creds[0].Data.PrismCentral.Username = username
+		creds[0].Data.PrismCentral.Password = password
+
+		buf, err := json.Marshal(creds)
+		return string(buf), err
+	}
+
+	// OpenStackCloudConfig renders the cloud-config used by the OpenStack CCM
+	// and CSI from the credentials, if the cloud-config is not provided
+	funcs["OpenStackCloudConfig"] = func(creds map[string]string) string {
+		appCredentials := creds[credentials.OpenStackApplicationCredentialID] != ""
+
+		var buf strings.Builder
+		buf.WriteString("[Global]\n")
+		for _, k := range openstackCloudConfigKeys {
+			if k.userOnly && appCredentials {
+				continue
+			}
+			if v := creds[k.credential]; v != "" {
+				buf.WriteString(k.key + " = " + strconv.Quote(v) + "\n")
+			}
+		}
+
+		return buf.String()
+	}
+
 	funcs["vSphereCSIWebhookConfig"] = func() (string, error) {
 		cfg := vsphereCSIWebhookConfigWrapper{
 			WebHookConfig: vsphereCSIWebhookConfig{
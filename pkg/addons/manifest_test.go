@@ -22,11 +22,13 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"reflect"
 	"strings"
 	"testing"
 	"text/template"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/templates/resources"
 )
 
 var testManifests = []string{
@@ -232,6 +234,46 @@ func TestCombineManifests(t *testing.T) {
 	}
 }
 
+func TestCNIAddonParams(t *testing.T) {
+	cni := &kubeoneapi.CNI{
+		Canal: &kubeoneapi.CanalSpec{
+			Params: map[string]string{"FlannelIface": "eth1"},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		addonName string
+		expected  map[string]string
+	}{
+		{
+			name:      "configured cni addon",
+			addonName: resources.AddonCNICanal,
+			expected:  map[string]string{"FlannelIface": "eth1"},
+		},
+		{
+			name:      "other cni addon",
+			addonName: resources.AddonCNICilium,
+			expected:  nil,
+		},
+		{
+			name:      "non-cni addon",
+			addonName: resources.AddonMetricsServer,
+			expected:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			params := cniAddonParams(cni, tc.addonName)
+			if !reflect.DeepEqual(params, tc.expected) {
+				t.Errorf("expected params %v, but got %v", tc.expected, params)
+			}
+		})
+	}
+}
+
 func TestImageRegistryParsing(t *testing.T) {
 	testCases := []struct {
 		name                 string
@@ -331,3 +373,169 @@ func TestCABundleFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestNutanixFuncs(t *testing.T) {
+	tests := []struct {
+		name          string
+		template      string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "ccm config",
+			template: `{{ NutanixCCMConfig "prism.example.com" "9440" "true" }}`,
+			expected: `{"prismCentral":{"address":"prism.example.com","port":9440,"insecure":true,"credentialRef":{"kind":"secret","name":"nutanix-creds"}},"topologyDiscovery":{"type":"Prism"}}`,
+		},
+		{
+			name:     "ccm config without insecure",
+			template: `{{ NutanixCCMConfig "prism.example.com" "9440" "" }}`,
+			expected: `{"prismCentral":{"address":"prism.example.com","port":9440,"insecure":false,"credentialRef":{"kind":"secret","name":"nutanix-creds"}},"topologyDiscovery":{"type":"Prism"}}`,
+		},
+		{
+			name:          "ccm config with invalid port",
+			template:      `{{ NutanixCCMConfig "prism.example.com" "port" "" }}`,
+			expectedError: true,
+		},
+		{
+			name:     "credentials",
+			template: `{{ NutanixCredentials "admin" "secret" }}`,
+			expected: `[{"type":"basic_auth","data":{"prismCentral":{"username":"admin","password":"secret"}}}]`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tpl, err := template.New("addons-base").Funcs(txtFuncMap("")).Parse(tc.template)
+			if err != nil {
+				t.Fatalf("failed to parse template: %v", err)
+			}
+
+			var out strings.Builder
+			err = tpl.Execute(&out, nil)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, but got %v", tc.expectedError, err)
+			}
+			if !tc.expectedError && out.String() != tc.expected {
+				t.Errorf("expected %s, but got %s", tc.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestPacketCCMConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     *kubeoneapi.PacketSpec
+		expected string
+	}{
+		{
+			name:     "credentials only",
+			spec:     &kubeoneapi.PacketSpec{},
+			expected: `{"apiKey":"key","projectID":"project","apiServerPort":6443}`,
+		},
+		{
+			name: "BGP and Elastic IP",
+			spec: &kubeoneapi.PacketSpec{
+				Metro:        "am",
+				LoadBalancer: "kube-vip://",
+				BGP: &kubeoneapi.PacketBGP{
+					Enable:   true,
+					LocalASN: 65000,
+				},
+				ElasticIP: &kubeoneapi.PacketElasticIP{
+					Tag:                  "eip",
+					HealthCheckUseHostIP: true,
+				},
+			},
+			expected: `{"apiKey":"key","projectID":"project","metro":"am","loadbalancer":"kube-vip://","localASN":65000,"bgpPass":"secret","eipTag":"eip","eipHealthCheckUseHostIP":true,"apiServerPort":6443}`,
+		},
+		{
+			name: "BGP disabled",
+			spec: &kubeoneapi.PacketSpec{
+				BGP: &kubeoneapi.PacketBGP{
+					LocalASN: 65000,
+				},
+			},
+			expected: `{"apiKey":"key","projectID":"project","apiServerPort":6443}`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tpl, err := template.New("addons-base").Funcs(txtFuncMap("")).Parse(`{{ PacketCCMConfig "key" "project" "secret" .Spec 6443 }}`)
+			if err != nil {
+				t.Fatalf("failed to parse template: %v", err)
+			}
+
+			var out strings.Builder
+			if err := tpl.Execute(&out, struct{ Spec *kubeoneapi.PacketSpec }{Spec: tc.spec}); err != nil {
+				t.Fatalf("failed to execute template: %v", err)
+			}
+			if out.String() != tc.expected {
+				t.Errorf("expected %s, but got %s", tc.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestOpenStackCloudConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		creds    map[string]string
+		expected string
+	}{
+		{
+			name: "user credentials",
+			creds: map[string]string{
+				"OS_AUTH_URL":    "https://keystone.example.com:5000/v3",
+				"OS_USERNAME":    "user",
+				"OS_PASSWORD":    `pa"ss`,
+				"OS_TENANT_NAME": "project",
+				"OS_DOMAIN_NAME": "Default",
+				"OS_REGION_NAME": "RegionOne",
+			},
+			expected: "[Global]\n" +
+				"auth-url = \"https://keystone.example.com:5000/v3\"\n" +
+				"username = \"user\"\n" +
+				"password = \"pa\\\"ss\"\n" +
+				"tenant-name = \"project\"\n" +
+				"domain-name = \"Default\"\n" +
+				"region = \"RegionOne\"\n",
+		},
+		{
+			name: "application credentials",
+			creds: map[string]string{
+				"OS_AUTH_URL":                      "https://keystone.example.com:5000/v3",
+				"OS_USERNAME":                      "user",
+				"OS_APPLICATION_CREDENTIAL_ID":     "id",
+				"OS_APPLICATION_CREDENTIAL_SECRET": "secret",
+				"OS_REGION_NAME":                   "RegionOne",
+			},
+			expected: "[Global]\n" +
+				"auth-url = \"https://keystone.example.com:5000/v3\"\n" +
+				"application-credential-id = \"id\"\n" +
+				"application-credential-secret = \"secret\"\n" +
+				"region = \"RegionOne\"\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tpl, err := template.New("addons-base").Funcs(txtFuncMap("")).Parse(`{{ OpenStackCloudConfig .Credentials }}`)
+			if err != nil {
+				t.Fatalf("failed to parse template: %v", err)
+			}
+
+			var out strings.Builder
+			if err := tpl.Execute(&out, map[string]interface{}{"Credentials": tc.creds}); err != nil {
+				t.Fatalf("failed to execute template: %v", err)
+			}
+			if out.String() != tc.expected {
+				t.Errorf("expected %q, but got %q", tc.expected, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+// sortAddons returns the names of the addons in the order they should be
+// applied. Dependencies are applied before the addons depending on them, and
+// the addons that are ready to be applied are ordered by the priority and
+// the name. configs holds the configuration of the addons from the
+// KubeOneCluster, addons without the configuration have the priority 0.
+func sortAddons(names []string, configs map[string]kubeoneapi.Addon) ([]string, error) {
+	// pending holds the number of dependencies not yet applied per addon
+	pending := map[string]int{}
+	dependents := map[string][]string{}
+
+	for _, name := range names {
+		pending[name] = 0
+	}
+
+	for _, name := range names {
+		for _, dep := range configs[name].DependsOn {
+			// Embedded addons are always applied before the user addons
+			if _, ok := embeddedAddons[dep]; ok {
+				continue
+			}
+			if _, ok := pending[dep]; !ok {
+				return nil, errors.Errorf("addon %q depends on the addon %q, which is not applied", name, dep)
+			}
+
+			pending[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	ready := []string{}
+	for _, name := range names {
+		if pending[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool {
+			pi, pj := configs[ready[i]].Priority, configs[ready[j]].Priority
+			if pi != pj {
+				return pi < pj
+			}
+
+			return ready[i] < ready[j]
+		})
+
+		next := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, next)
+
+		for _, dependent := range dependents[next] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(sorted) != len(names) {
+		cyclic := []string{}
+		for _, name := range names {
+			if pending[name] > 0 {
+				cyclic = append(cyclic, name)
+			}
+		}
+		sort.Strings(cyclic)
+
+		return nil, errors.Errorf("addons %s have circular dependencies", strings.Join(cyclic, ", "))
+	}
+
+	return sorted, nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"reflect"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestSortAddons(t *testing.T) {
+	tests := []struct {
+		name          string
+		addons        []string
+		configs       map[string]kubeoneapi.Addon
+		expected      []string
+		expectedError bool
+	}{
+		{
+			name:     "alphabetical order without configuration",
+			addons:   []string{"metallb", "cert-manager", "ingress-nginx"},
+			expected: []string{"cert-manager", "ingress-nginx", "metallb"},
+		},
+		{
+			name:   "priority",
+			addons: []string{"metallb", "cert-manager", "ingress-nginx"},
+			configs: map[string]kubeoneapi.Addon{
+				"metallb":       {Name: "metallb", Priority: -1},
+				"ingress-nginx": {Name: "ingress-nginx", Priority: 10},
+			},
+			expected: []string{"metallb", "cert-manager", "ingress-nginx"},
+		},
+		{
+			name:   "dependencies take precedence over priority",
+			addons: []string{"issuers", "cert-manager", "metallb"},
+			configs: map[string]kubeoneapi.Addon{
+				"issuers":      {Name: "issuers", Priority: -10, DependsOn: []string{"cert-manager"}},
+				"cert-manager": {Name: "cert-manager", Priority: 5},
+			},
+			expected: []string{"metallb", "cert-manager", "issuers"},
+		},
+		{
+			name:   "dependency on the embedded addon",
+			addons: []string{"cilium-policies"},
+			configs: map[string]kubeoneapi.Addon{
+				"cilium-policies": {Name: "cilium-policies", DependsOn: []string{"cni-cilium"}},
+			},
+			expected: []string{"cilium-policies"},
+		},
+		{
+			name:   "missing dependency",
+			addons: []string{"issuers"},
+			configs: map[string]kubeoneapi.Addon{
+				"issuers": {Name: "issuers", DependsOn: []string{"cert-manager"}},
+			},
+			expectedError: true,
+		},
+		{
+			name:   "circular dependencies",
+			addons: []string{"a", "b", "c"},
+			configs: map[string]kubeoneapi.Addon{
+				"a": {Name: "a", DependsOn: []string{"b"}},
+				"b": {Name: "b", DependsOn: []string{"a"}},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sortAddons(tc.addons, tc.configs)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("sortAddons() error = %v, expectedError %v", err, tc.expectedError)
+			}
+			if !tc.expectedError && !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("sortAddons() got = %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,376 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+// addonsCacheDir is the directory, relative to the user cache directory,
+// where the remote addons sources are fetched to
+const addonsCacheDir = "kubeone/addons"
+
+var (
+	fetchedGitSourcesMu sync.Mutex
+	// fetchedGitSources holds directories of the git sources already fetched
+	// in this run, so repositories are not fetched again for every addon
+	fetchedGitSources = map[string]string{}
+)
+
+// fetchAddonSource fetches the remote addons source to the cache directory
+// and returns the local directory with the addons manifests
+func fetchAddonSource(ctx context.Context, logger logrus.FieldLogger, cacheDir string, src kubeoneapi.AddonSource) (string, error) {
+	var (
+		dir, subPath string
+		err          error
+	)
+
+	switch {
+	case src.Git != nil:
+		dir, err = fetchGitSource(ctx, logger, cacheDir, src.Git)
+		subPath = src.Git.Path
+	case src.HTTP != nil:
+		dir, err = fetchArchiveSource(ctx, logger, cacheDir, src.HTTP.URL, src.HTTP.SHA256, func(ctx context.Context) (io.ReadCloser, error) {
+			return openHTTPArchive(ctx, src.HTTP.URL)
+		})
+		subPath = src.HTTP.Path
+	case src.S3 != nil:
+		location := "s3://" + src.S3.Bucket + "/" + src.S3.Key
+		dir, err = fetchArchiveSource(ctx, logger, cacheDir, location, src.S3.SHA256, func(ctx context.Context) (io.ReadCloser, error) {
+			return openS3Archive(ctx, src.S3)
+		})
+		subPath = src.S3.Path
+	default:
+		return "", errors.New("addon source must specify one of git, http or s3")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(subPath)), nil
+}
+
+// fetchGitSource fetches the commit of the git repository using the git
+// binary and checks it out to the cache directory named after the repository
+// and the commit. The commit is fetched only if it's not checked out already.
+func fetchGitSource(ctx context.Context, logger logrus.FieldLogger, cacheDir string, src *kubeoneapi.GitAddonSource) (string, error) {
+	key := src.Repository + "@" + src.Commit
+
+	fetchedGitSourcesMu.Lock()
+	defer fetchedGitSourcesMu.Unlock()
+
+	if dir, ok := fetchedGitSources[key]; ok {
+		return dir, nil
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	dir := filepath.Join(cacheDir, "git", hex.EncodeToString(sum[:]))
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		if err = os.MkdirAll(dir, 0750); err != nil {
+			return "", errors.Wrap(err, "failed to create addons cache directory")
+		}
+		if _, err = runGit(ctx, dir, "init", "--quiet"); err != nil {
+			return "", err
+		}
+	}
+
+	if head, err := runGit(ctx, dir, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil || head != src.Commit {
+		logger.Infof("Fetching addons from %s...", key)
+
+		if _, err = runGit(ctx, dir, "fetch", "--quiet", "--depth=1", "--", src.Repository, src.Commit); err != nil {
+			return "", errors.Wrapf(err, "failed to fetch %s", key)
+		}
+		if _, err = runGit(ctx, dir, "checkout", "--quiet", "--force", "--detach", src.Commit, "--"); err != nil {
+			return "", errors.Wrapf(err, "failed to check out %s", key)
+		}
+	}
+	if _, err := runGit(ctx, dir, "clean", "--quiet", "--force", "-d", "-x"); err != nil {
+		return "", errors.Wrapf(err, "failed to clean %s", key)
+	}
+
+	fetchedGitSources[key] = dir
+
+	return dir, nil
+}
+
+// runGit runs the git command in the directory and returns its output. Only
+// the https and ssh transports are allowed and git never prompts for the
+// credentials.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_ALLOW_PROTOCOL=https:ssh", "GIT_TERMINAL_PROMPT=0")
+
+	out, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(out))
+
+	return output, errors.Wrapf(err, "git %s: %s", args[0], output)
+}
+
+// fetchArchiveSource downloads the .tar.gz archive, verifies it against the
+// checksum and extracts it to the cache directory named after the checksum.
+// Archives already extracted are not downloaded again.
+func fetchArchiveSource(ctx context.Context, logger logrus.FieldLogger, cacheDir, location, checksum string, open func(context.Context) (io.ReadCloser, error)) (string, error) {
+	checksum = strings.ToLower(checksum)
+	dir := filepath.Join(cacheDir, "archives", checksum)
+
+	if _, err := os.Stat(dir); err == nil {
+		return dir, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0750); err != nil {
+		return "", errors.Wrap(err, "failed to create addons cache directory")
+	}
+
+	logger.Infof("Fetching addons from %s...", location)
+
+	body, err := open(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	archive, err := ioutil.TempFile(filepath.Dir(dir), "archive-*.tar.gz")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create temporary archive file")
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(archive, hash), body); err != nil {
+		return "", errors.Wrapf(err, "failed to download %s", location)
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+		return "", errors.Errorf("checksum mismatch for %s: expected %s, got %s", location, checksum, actual)
+	}
+
+	if _, err = archive.Seek(0, io.SeekStart); err != nil {
+		return "", errors.Wrap(err, "failed to read downloaded archive")
+	}
+
+	// The archive is extracted to the temporary directory first, so a
+	// partially extracted archive is never picked up from the cache
+	tmpDir, err := ioutil.TempDir(filepath.Dir(dir), "extract-")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create temporary directory")
+	}
+
+	if err = extractTarGz(archive, tmpDir); err != nil {
+		_ = os.RemoveAll(tmpDir)
+
+		return "", errors.Wrapf(err, "failed to extract %s", location)
+	}
+
+	if err = os.Rename(tmpDir, dir); err != nil {
+		_ = os.RemoveAll(tmpDir)
+
+		return "", errors.Wrap(err, "failed to move extracted archive to the cache")
+	}
+
+	return dir, nil
+}
+
+func openHTTPArchive(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download %s", url)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+
+		return nil, errors.Errorf("failed to download %s: unexpected status %s", url, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+func openS3Archive(ctx context.Context, src *kubeoneapi.S3AddonSource) (io.ReadCloser, error) {
+	awsConfig := aws.Config{}
+	if src.Region != "" {
+		awsConfig.Region = aws.String(src.Region)
+	}
+	if src.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(src.Endpoint)
+		// S3-compatible storages usually don't support virtual-hosted buckets
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session")
+	}
+
+	out, err := s3.New(sess).GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(src.Bucket),
+		Key:    aws.String(src.Key),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get s3://%s/%s", src.Bucket, src.Key)
+	}
+
+	return out.Body, nil
+}
+
+// extractTarGz extracts directories and regular files from the .tar.gz
+// archive to the directory. Other entries, such as symlinks, are skipped.
+func extractTarGz(r io.Reader, dir string) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to open gzip stream")
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read archive")
+		}
+
+		name := path.Clean(hdr.Name)
+		if name == "." {
+			continue
+		}
+		if path.IsAbs(name) || !fs.ValidPath(name) {
+			return errors.Errorf("archive entry %q points outside of the archive", hdr.Name)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0750); err != nil {
+				return errors.Wrapf(err, "failed to create %s", name)
+			}
+		case tar.TypeReg:
+			if err = extractTarFile(tr, target); err != nil {
+				return errors.Wrapf(err, "failed to extract %s", name)
+			}
+		}
+	}
+}
+
+func extractTarFile(r io.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
+
+// mergedFS merges file systems. Files are opened from the first file system
+// they exist in, and directory listings are combined, with entries from the
+// earlier file systems taking precedence.
+type mergedFS []fs.FS
+
+func (m mergedFS) Open(name string) (fs.File, error) {
+	for _, fsys := range m {
+		f, err := fsys.Open(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		return f, err
+	}
+
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m mergedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	var (
+		entries []fs.DirEntry
+		found   bool
+		seen    = map[string]bool{}
+	)
+
+	for _, fsys := range m {
+		dirEntries, err := fs.ReadDir(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		found = true
+		for _, entry := range dirEntries {
+			if !seen[entry.Name()] {
+				seen[entry.Name()] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	return entries, nil
+}
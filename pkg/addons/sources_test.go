@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMergedFS(t *testing.T) {
+	fsys := mergedFS{
+		fstest.MapFS{
+			"metallb/metallb.yaml": {Data: []byte("local")},
+		},
+		fstest.MapFS{
+			"metallb/metallb.yaml":         {Data: []byte("remote")},
+			"metallb/config.yaml":          {Data: []byte("config")},
+			"ingress-nginx/ingress.yaml":   {Data: []byte("ingress")},
+			"ingress-nginx/configmap.yaml": {Data: []byte("configmap")},
+		},
+	}
+
+	b, err := fs.ReadFile(fsys, "metallb/metallb.yaml")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(b) != "local" {
+		t.Errorf("expected file from the first file system, but got %q", string(b))
+	}
+
+	entries, err := fs.ReadDir(fsys, "metallb")
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Name() != "config.yaml" || entries[1].Name() != "metallb.yaml" {
+		t.Errorf("expected merged directory listing, but got %v", entries)
+	}
+
+	if _, err = fs.ReadDir(fsys, "missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrNotExist for missing directory, but got %v", err)
+	}
+}
+
+func TestFetchArchiveSource(t *testing.T) {
+	archive := tarGz(t, map[string]string{
+		"addons/metallb/metallb.yaml": "kind: Namespace",
+	})
+	sum := sha256.Sum256(archive)
+	checksum := hex.EncodeToString(sum[:])
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	open := func(ctx context.Context) (io.ReadCloser, error) {
+		return openHTTPArchive(ctx, server.URL)
+	}
+	cacheDir := t.TempDir()
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	for i := 0; i < 2; i++ {
+		dir, err := fetchArchiveSource(context.Background(), logger, cacheDir, server.URL, checksum, open)
+		if err != nil {
+			t.Fatalf("fetchArchiveSource() error = %v", err)
+		}
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, "addons", "metallb", "metallb.yaml"))
+		if err != nil {
+			t.Fatalf("failed to read extracted file: %v", err)
+		}
+		if string(b) != "kind: Namespace" {
+			t.Errorf("expected extracted content %q, but got %q", "kind: Namespace", string(b))
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected the archive to be downloaded once, but it was downloaded %d times", requests)
+	}
+
+	badChecksum := hex.EncodeToString(make([]byte, sha256.Size))
+	if _, err := fetchArchiveSource(context.Background(), logger, cacheDir, server.URL, badChecksum, open); err == nil {
+		t.Error("expected checksum mismatch error, but got nil")
+	}
+}
+
+func TestExtractTarGzRejectsTraversal(t *testing.T) {
+	archive := tarGz(t, map[string]string{
+		"../outside.yaml": "kind: Namespace",
+	})
+
+	if err := extractTarGz(bytes.NewReader(archive), t.TempDir()); err == nil {
+		t.Error("expected error for entry outside of the archive, but got nil")
+	}
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	for name, content := range files {
+		hdr := &tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write tar content: %v", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	return buf.Bytes()
+}
@@ -33,7 +33,10 @@ import (
 	terraformv1alpha1 "k8c.io/kubeone/pkg/terraform/v1alpha1"
 	terraformv1beta1 "k8c.io/kubeone/pkg/terraform/v1beta1"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	kyaml "sigs.k8s.io/yaml"
 )
 
 const (
@@ -66,22 +69,36 @@ func LoadKubeOneCluster(clusterCfgPath, tfOutputPath, credentialsFilePath string
 		return nil, errors.Wrap(err, "unable to read the given cluster configuration file")
 	}
 
-	var tfOutput []byte
+	tfOutput, credentialsFile, err := readTerraformOutputAndCredentials(tfOutputPath, credentialsFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return BytesToKubeOneCluster(cluster, tfOutput, credentialsFile, logger)
+}
+
+// readTerraformOutputAndCredentials reads the Terraform output from stdin, a
+// Terraform directory or a file, and the credentials file if provided
+func readTerraformOutputAndCredentials(tfOutputPath, credentialsFilePath string) ([]byte, []byte, error) {
+	var (
+		tfOutput []byte
+		err      error
+	)
 
 	switch {
 	case tfOutputPath == "-":
 		if tfOutput, err = ioutil.ReadAll(os.Stdin); err != nil {
-			return nil, errors.Wrap(err, "unable to read terraform output from stdin")
+			return nil, nil, errors.Wrap(err, "unable to read terraform output from stdin")
 		}
 	case isDir(tfOutputPath):
 		cmd := exec.Command("terraform", "output", "-json")
 		cmd.Dir = tfOutputPath
 		if tfOutput, err = cmd.Output(); err != nil {
-			return nil, errors.Wrapf(err, "unable to read terraform output from the %q directory", tfOutputPath)
+			return nil, nil, errors.Wrapf(err, "unable to read terraform output from the %q directory", tfOutputPath)
 		}
 	case len(tfOutputPath) != 0:
 		if tfOutput, err = ioutil.ReadFile(tfOutputPath); err != nil {
-			return nil, errors.Wrap(err, "unable to read the given terraform output file")
+			return nil, nil, errors.Wrap(err, "unable to read the given terraform output file")
 		}
 	}
 
@@ -89,11 +106,11 @@ func LoadKubeOneCluster(clusterCfgPath, tfOutputPath, credentialsFilePath string
 	if len(credentialsFilePath) != 0 {
 		credentialsFile, err = ioutil.ReadFile(credentialsFilePath)
 		if err != nil {
-			return nil, errors.Wrap(err, "unable to read the given credentials file")
+			return nil, nil, errors.Wrap(err, "unable to read the given credentials file")
 		}
 	}
 
-	return BytesToKubeOneCluster(cluster, tfOutput, credentialsFile, logger)
+	return tfOutput, credentialsFile, nil
 }
 
 // BytesToKubeOneCluster parses the bytes of the versioned KubeOneCluster manifests
@@ -156,6 +173,11 @@ func DefaultedV1Alpha1KubeOneCluster(versionedCluster *kubeonev1alpha1.KubeOneCl
 		return nil, errors.Wrap(err, "failed to convert versioned cluster object to internal object")
 	}
 
+	// NodeLocalDNS can't be disabled in the v1alpha1 API
+	internalCluster.Features.NodeLocalDNS = &kubeoneapi.NodeLocalDNS{
+		Enable: true,
+	}
+
 	// Apply the dynamic defaults
 	err := SetKubeOneClusterDynamicDefaults(internalCluster, credentialsFile)
 	if err != nil {
@@ -219,9 +241,69 @@ func SetKubeOneClusterDynamicDefaults(cfg *kubeoneapi.KubeOneCluster, credential
 		cfg.CloudProvider.CloudConfig = cc
 	}
 
+	return setEncryptionProvidersKMSConfiguration(cfg.Features.EncryptionProviders)
+}
+
+// setEncryptionProvidersKMSConfiguration generates the EncryptionConfiguration
+// for the KMS provider. The generated configuration is used as the custom
+// one, so the KMS provider is enabled, updated and disabled the same way.
+func setEncryptionProvidersKMSConfiguration(ep *kubeoneapi.EncryptionProviders) error {
+	if ep == nil || ep.KMS == nil {
+		return nil
+	}
+	if ep.CustomEncryptionConfiguration != "" {
+		return errors.New("features.encryptionProviders.kms and features.encryptionProviders.customEncryptionConfiguration are mutually exclusive")
+	}
+
+	cacheSize := int32(ep.KMS.CacheSize)
+	config := &apiserverconfigv1.EncryptionConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apiserver.config.k8s.io/v1",
+			Kind:       "EncryptionConfiguration",
+		},
+		Resources: []apiserverconfigv1.ResourceConfiguration{
+			{
+				Resources: []string{"secrets"},
+				Providers: []apiserverconfigv1.ProviderConfiguration{
+					{
+						KMS: &apiserverconfigv1.KMSConfiguration{
+							Name:      ep.KMS.Name,
+							Endpoint:  "unix://" + ep.KMS.Endpoint,
+							CacheSize: &cacheSize,
+							Timeout:   &metav1.Duration{Duration: ep.KMS.Timeout.Duration},
+						},
+					},
+					{
+						Identity: &apiserverconfigv1.IdentityConfiguration{},
+					},
+				},
+			},
+		},
+	}
+
+	buf, err := kyaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal kms encryption configuration")
+	}
+	ep.CustomEncryptionConfiguration = string(buf)
+
 	return nil
 }
 
+// MarshalKubeOneCluster converts the internal representation of the KubeOneCluster
+// object to the v1beta1 version and returns it as YAML
+func MarshalKubeOneCluster(cluster *kubeoneapi.KubeOneCluster) ([]byte, error) {
+	versionedCluster := &kubeonev1beta1.KubeOneCluster{}
+	if err := kubeonescheme.Scheme.Convert(cluster, versionedCluster, nil); err != nil {
+		return nil, errors.Wrap(err, "failed to convert internal cluster object to versioned object")
+	}
+
+	versionedCluster.APIVersion = kubeonev1beta1.SchemeGroupVersion.String()
+	versionedCluster.Kind = KubeOneClusterKind
+
+	return kyaml.Marshal(versionedCluster)
+}
+
 func isDir(dirname string) bool {
 	stat, statErr := os.Stat(dirname)
 	return statErr == nil && stat.Mode().IsDir()
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+const (
+	// RedactedValue replaces values of sensitive fields in the printed manifests
+	RedactedValue = "<redacted>"
+
+	// envPrefix marks the values read from the environment variables, which
+	// are not secrets on their own
+	envPrefix = "env:"
+)
+
+// RedactKubeOneCluster returns a copy of the KubeOneCluster object with values
+// of the fields that can contain secrets replaced with RedactedValue
+func RedactKubeOneCluster(cluster *kubeoneapi.KubeOneCluster) *kubeoneapi.KubeOneCluster {
+	redacted := cluster.DeepCopy()
+
+	redactString(&redacted.CloudProvider.CloudConfig)
+	redactString(&redacted.CloudProvider.CSIConfig)
+
+	for i := range redacted.DynamicWorkers {
+		redactString(redacted.DynamicWorkers[i].Config.OverwriteCloudConfig)
+	}
+
+	if ep := redacted.Features.EncryptionProviders; ep != nil {
+		redactString(&ep.CustomEncryptionConfiguration)
+	}
+
+	if containerd := redacted.ContainerRuntime.Containerd; containerd != nil {
+		for _, registry := range containerd.Registries {
+			if registry.Auth != nil {
+				redactString(&registry.Auth.Password)
+			}
+		}
+	}
+
+	// The notification URLs, such as the Slack webhooks, embed the token
+	for i := range redacted.Notifications {
+		redactInlineSecret(&redacted.Notifications[i].URL)
+		redactHeaders(redacted.Notifications[i].Headers)
+	}
+
+	if hooks := redacted.Hooks; hooks != nil {
+		for _, list := range [][]kubeoneapi.Hook{
+			hooks.PreApply,
+			hooks.PostApply,
+			hooks.PreUpgrade,
+			hooks.PostUpgrade,
+			hooks.PreReset,
+			hooks.PostReset,
+			hooks.PreNodeUpgrade,
+			hooks.PostNodeUpgrade,
+		} {
+			for _, hook := range list {
+				// the webhook URLs can embed the token just like the
+				// notification URLs
+				if hook.Webhook != nil {
+					redactInlineSecret(&hook.Webhook.URL)
+					redactHeaders(hook.Webhook.Headers)
+				}
+			}
+		}
+	}
+
+	return redacted
+}
+
+// redactHeaders redacts the values of the HTTP headers, which can carry the
+// tokens, unless they are read from the environment
+func redactHeaders(headers map[string]string) {
+	for k, v := range headers {
+		redactInlineSecret(&v)
+		headers[k] = v
+	}
+}
+
+// redactInlineSecret redacts the value unless it's read from the environment
+func redactInlineSecret(s *string) {
+	if !strings.HasPrefix(*s, envPrefix) {
+		redactString(s)
+	}
+}
+
+func redactString(s *string) {
+	if s != nil && *s != "" {
+		*s = RedactedValue
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestRedactKubeOneCluster(t *testing.T) {
+	overwriteCloudConfig := "[Global]\npassword = secret"
+
+	cluster := &kubeoneapi.KubeOneCluster{
+		Name: "demo",
+		CloudProvider: kubeoneapi.CloudProviderSpec{
+			CloudConfig: "[Global]\npassword = secret",
+		},
+		DynamicWorkers: []kubeoneapi.DynamicWorkerConfig{
+			{
+				Name: "worker",
+				Config: kubeoneapi.ProviderSpec{
+					OverwriteCloudConfig: &overwriteCloudConfig,
+				},
+			},
+		},
+		Features: kubeoneapi.Features{
+			EncryptionProviders: &kubeoneapi.EncryptionProviders{
+				Enable:                        true,
+				CustomEncryptionConfiguration: "secret: key",
+			},
+		},
+		ContainerRuntime: kubeoneapi.ContainerRuntimeConfig{
+			Containerd: &kubeoneapi.ContainerRuntimeContainerd{
+				Registries: map[string]kubeoneapi.RegistryMirror{
+					"docker.io": {
+						Mirrors: []string{"https://mirror.example.com"},
+						Auth: &kubeoneapi.RegistryAuth{
+							Username: "kubeone",
+							Password: "secret",
+						},
+					},
+				},
+			},
+		},
+		Notifications: []kubeoneapi.Notification{
+			{
+				URL:     "https://hooks.slack.com/services/T000/B000/secret",
+				Headers: map[string]string{"Authorization": "Bearer secret"},
+			},
+			{
+				URL:     "env:SLACK_WEBHOOK_URL",
+				Headers: map[string]string{"Authorization": "env:NOTIFICATION_TOKEN"},
+			},
+		},
+		Hooks: &kubeoneapi.Hooks{
+			PreUpgrade: []kubeoneapi.Hook{
+				{
+					Webhook: &kubeoneapi.WebhookHook{
+						URL:     "https://hooks.slack.com/services/T000/B000/secret",
+						Headers: map[string]string{"Authorization": "Bearer secret"},
+					},
+				},
+			},
+			PostUpgrade: []kubeoneapi.Hook{
+				{
+					Webhook: &kubeoneapi.WebhookHook{
+						URL:     "env:ALERTMANAGER_URL",
+						Headers: map[string]string{"Authorization": "env:ALERTMANAGER_TOKEN"},
+					},
+				},
+			},
+		},
+	}
+
+	redacted := RedactKubeOneCluster(cluster)
+
+	if redacted.CloudProvider.CloudConfig != RedactedValue {
+		t.Errorf("cloudConfig is not redacted: %q", redacted.CloudProvider.CloudConfig)
+	}
+	if redacted.CloudProvider.CSIConfig != "" {
+		t.Errorf("empty csiConfig should stay empty, but got %q", redacted.CloudProvider.CSIConfig)
+	}
+	if *redacted.DynamicWorkers[0].Config.OverwriteCloudConfig != RedactedValue {
+		t.Errorf("overwriteCloudConfig is not redacted: %q", *redacted.DynamicWorkers[0].Config.OverwriteCloudConfig)
+	}
+	if redacted.Features.EncryptionProviders.CustomEncryptionConfiguration != RedactedValue {
+		t.Errorf("customEncryptionConfiguration is not redacted: %q", redacted.Features.EncryptionProviders.CustomEncryptionConfiguration)
+	}
+	if auth := redacted.ContainerRuntime.Containerd.Registries["docker.io"].Auth; auth.Password != RedactedValue || auth.Username != "kubeone" {
+		t.Errorf("only the registry mirror password must be redacted, but got username %q and password %q", auth.Username, auth.Password)
+	}
+	if n := redacted.Notifications[0]; n.URL != RedactedValue || n.Headers["Authorization"] != RedactedValue {
+		t.Errorf("inline notification url and headers are not redacted: %q, %q", n.URL, n.Headers["Authorization"])
+	}
+	if n := redacted.Notifications[1]; n.URL != "env:SLACK_WEBHOOK_URL" || n.Headers["Authorization"] != "env:NOTIFICATION_TOKEN" {
+		t.Errorf("the environment references must be preserved, but got %q, %q", n.URL, n.Headers["Authorization"])
+	}
+	if w := redacted.Hooks.PreUpgrade[0].Webhook; w.URL != RedactedValue || w.Headers["Authorization"] != RedactedValue {
+		t.Errorf("inline webhook hook url and headers are not redacted: %q, %q", w.URL, w.Headers["Authorization"])
+	}
+	if w := redacted.Hooks.PostUpgrade[0].Webhook; w.URL != "env:ALERTMANAGER_URL" || w.Headers["Authorization"] != "env:ALERTMANAGER_TOKEN" {
+		t.Errorf("the environment references must be preserved, but got %q, %q", w.URL, w.Headers["Authorization"])
+	}
+	if redacted.Name != cluster.Name {
+		t.Errorf("non-sensitive fields must be preserved, expected name %q, but got %q", cluster.Name, redacted.Name)
+	}
+
+	if cluster.CloudProvider.CloudConfig == RedactedValue || overwriteCloudConfig == RedactedValue ||
+		cluster.ContainerRuntime.Containerd.Registries["docker.io"].Auth.Password == RedactedValue ||
+		cluster.Notifications[0].Headers["Authorization"] == RedactedValue ||
+		cluster.Hooks.PreUpgrade[0].Webhook.URL == RedactedValue ||
+		cluster.Hooks.PreUpgrade[0].Webhook.Headers["Authorization"] == RedactedValue {
+		t.Errorf("the original object must not be modified")
+	}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// variableRegexp matches ${NAME} and ${NAME:-default} placeholders, as well as
+// the escaped $${NAME} form which is rendered as a literal ${NAME}
+var variableRegexp = regexp.MustCompile(`\$(\$?)\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// blockScalarRegexp matches lines opening a literal or folded block scalar,
+// e.g. "script: |" or "- >-"
+var blockScalarRegexp = regexp.MustCompile(`^\s*(-\s+)*([^#]*:\s+|-\s+)?[|>][0-9+-]*\s*(#.*)?$`)
+
+// sequenceIndicatorRegexp matches the sequence entry indicators at the start
+// of a line
+var sequenceIndicatorRegexp = regexp.MustCompile(`^\s*(-\s+)*`)
+
+// ParseVariables parses the list of NAME=value pairs, as given by the --set
+// flag, into a map
+func ParseVariables(pairs []string) (map[string]string, error) {
+	vars := map[string]string{}
+
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.Errorf("invalid variable %q, expected NAME=value", pair)
+		}
+		vars[kv[0]] = kv[1]
+	}
+
+	return vars, nil
+}
+
+// SubstituteVariables replaces ${NAME} placeholders in the manifest with the
+// given variables. ${NAME:-default} falls back to the default value if the
+// variable is not set, while $${NAME} is rendered as a literal ${NAME}.
+// Comments are left untouched, so the placeholders in them are neither
+// substituted nor required. An error is returned if any of the referenced
+// variables is not set and has no default value.
+//
+// Values are quoted as required by the place of the placeholder, so they
+// can't change the structure of the manifest. A placeholder making up a
+// whole unquoted value is replaced with the plain value if it's safe, so
+// numbers and booleans keep their type, and with a double-quoted string
+// otherwise. Values substituted in block scalars are indented as the line of
+// the placeholder.
+func SubstituteVariables(manifest []byte, vars map[string]string) ([]byte, error) {
+	missing := map[string]bool{}
+	var substituteErr error
+
+	lines := bytes.SplitAfter(manifest, []byte("\n"))
+	blockIndent := -1
+	for i, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+		indent := len(line) - len(bytes.TrimLeft(line, " \t"))
+
+		inBlock := false
+		if blockIndent >= 0 {
+			if len(trimmed) == 0 || indent > blockIndent {
+				inBlock = true
+			} else {
+				blockIndent = -1
+			}
+		}
+
+		if bytes.HasPrefix(trimmed, []byte("#")) {
+			continue
+		}
+
+		var out []byte
+		last := 0
+		for _, loc := range variableRegexp.FindAllSubmatchIndex(line, -1) {
+			start, end := loc[0], loc[1]
+			out = append(out, line[last:start]...)
+			last = end
+
+			match := line[start:end]
+			escaped, name := loc[3] > loc[2], string(line[loc[4]:loc[5]])
+
+			ctx, flow := contextPlainValue, false
+			if !inBlock {
+				ctx, flow = scalarContext(line, start, end)
+			}
+
+			if ctx == contextComment {
+				out = append(out, match...)
+				continue
+			}
+
+			if escaped {
+				out = append(out, match[1:]...)
+				continue
+			}
+
+			value, ok := vars[name]
+			if !ok && loc[6] >= 0 {
+				value, ok = string(line[loc[8]:loc[9]]), true
+			}
+			if !ok {
+				missing[name] = true
+				out = append(out, match...)
+				continue
+			}
+
+			if inBlock {
+				out = append(out, strings.ReplaceAll(value, "\n", "\n"+string(line[:indent]))...)
+				continue
+			}
+
+			quoted, err := quoteVariable(name, value, ctx, flow)
+			if err != nil && substituteErr == nil {
+				substituteErr = err
+			}
+			out = append(out, quoted...)
+		}
+		lines[i] = append(out, line[last:]...)
+
+		if !inBlock && blockScalarRegexp.Match(line) {
+			blockIndent = len(sequenceIndicatorRegexp.Find(line))
+		}
+	}
+	result := bytes.Join(lines, nil)
+
+	if len(missing) > 0 {
+		names := []string{}
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, errors.Errorf("variables referenced in the manifest are not set: %s", strings.Join(names, ", "))
+	}
+
+	if substituteErr != nil {
+		return nil, substituteErr
+	}
+
+	return result, nil
+}
+
+type variableContext int
+
+const (
+	// contextPlain is a placeholder embedded in an unquoted value
+	contextPlain variableContext = iota
+	// contextPlainValue is a placeholder making up a whole unquoted value
+	contextPlainValue
+	contextDoubleQuoted
+	contextSingleQuoted
+	contextComment
+)
+
+// scalarContext returns the context of the placeholder at line[start:end],
+// and whether it's in a flow collection
+func scalarContext(line []byte, start, end int) (variableContext, bool) {
+	var (
+		quote      byte
+		flowDepth  int
+		valueStart = true
+	)
+
+	for i := 0; i < start; i++ {
+		c := line[i]
+
+		switch quote {
+		case '"':
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				quote = 0
+			}
+
+			continue
+		case '\'':
+			if c == '\'' {
+				if i+1 < start && line[i+1] == '\'' {
+					i++
+				} else {
+					quote = 0
+				}
+			}
+
+			continue
+		}
+
+		switch {
+		case c == ' ' || c == '\t':
+			continue
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return contextComment, false
+		case valueStart && (c == '"' || c == '\''):
+			quote = c
+			valueStart = false
+		case c == ':' && (i+1 == len(line) || isSpace(line[i+1])):
+			valueStart = true
+		case c == '-' && valueStart && (i+1 == len(line) || isSpace(line[i+1])):
+			valueStart = true
+		case valueStart && (c == '[' || c == '{'):
+			flowDepth++
+		case flowDepth > 0 && (c == ']' || c == '}'):
+			flowDepth--
+			valueStart = false
+		case flowDepth > 0 && c == ',':
+			valueStart = true
+		default:
+			valueStart = false
+		}
+	}
+
+	flow := flowDepth > 0
+
+	switch quote {
+	case '"':
+		return contextDoubleQuoted, flow
+	case '\'':
+		return contextSingleQuoted, flow
+	}
+
+	if !valueStart {
+		return contextPlain, flow
+	}
+
+	rest := bytes.TrimLeft(line[end:], " \t")
+	rest = bytes.TrimRight(rest, "\r\n")
+	if len(rest) == 0 || rest[0] == '#' && len(rest) < len(bytes.TrimRight(line[end:], "\r\n")) {
+		return contextPlainValue, flow
+	}
+	if flow && (rest[0] == ',' || rest[0] == ']' || rest[0] == '}') {
+		return contextPlainValue, flow
+	}
+
+	return contextPlain, flow
+}
+
+// quoteVariable returns the value to be substituted in the given context
+func quoteVariable(name, value string, ctx variableContext, flow bool) (string, error) {
+	switch ctx {
+	case contextDoubleQuoted:
+		quoted := strconv.Quote(value)
+
+		return quoted[1 : len(quoted)-1], nil
+	case contextSingleQuoted:
+		if strings.ContainsAny(value, "\r\n") {
+			return "", errors.Errorf("value of the variable %s contains a newline and can't be substituted in a single-quoted string", name)
+		}
+
+		return strings.ReplaceAll(value, "'", "''"), nil
+	case contextPlainValue:
+		if isPlainScalar(value, flow) {
+			return value, nil
+		}
+
+		return strconv.Quote(value), nil
+	}
+
+	if !isPlainFragment(value, flow) {
+		return "", errors.Errorf("value of the variable %s can't be substituted in an unquoted string, quote the string in the manifest", name)
+	}
+
+	return value, nil
+}
+
+// isPlainFragment returns whether the value can be a part of an unquoted
+// value without changing its meaning
+func isPlainFragment(value string, flow bool) bool {
+	if flow && strings.ContainsAny(value, ",[]{}") {
+		return false
+	}
+
+	return !strings.ContainsAny(value, "\r\n") &&
+		!strings.Contains(value, ": ") &&
+		!strings.Contains(value, " #") &&
+		!strings.Contains(value, "\t#") &&
+		!strings.HasPrefix(value, "#") &&
+		!strings.HasSuffix(value, ":")
+}
+
+// isPlainScalar returns whether the value can be an unquoted value as it is
+func isPlainScalar(value string, flow bool) bool {
+	if value == "" || value != strings.TrimSpace(value) || !isPlainFragment(value, flow) {
+		return false
+	}
+
+	switch value[0] {
+	case '-', '?', ':':
+		return len(value) > 1 && !isSpace(value[1])
+	case '!', '&', '*', '|', '>', '\'', '"', '%', '@', '`', ',', '[', ']', '{', '}':
+		return false
+	}
+
+	return true
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubstituteVariables(t *testing.T) {
+	tests := []struct {
+		name          string
+		manifest      string
+		vars          map[string]string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "no variables",
+			manifest: "name: demo",
+			expected: "name: demo",
+		},
+		{
+			name:     "variable from --set",
+			manifest: "name: ${CLUSTER_NAME}",
+			vars:     map[string]string{"CLUSTER_NAME": "prod"},
+			expected: "name: prod",
+		},
+		{
+			name:          "variables are not looked up in the environment",
+			manifest:      "name: ${HOME}",
+			vars:          map[string]string{},
+			expectedError: true,
+		},
+		{
+			name:     "default value",
+			manifest: "kubernetes: ${KUBERNETES_VERSION:-1.22.2}",
+			expected: "kubernetes: 1.22.2",
+		},
+		{
+			name:     "empty default value",
+			manifest: "region: \"${REGION:-}\"",
+			expected: "region: \"\"",
+		},
+		{
+			name:     "escaped variable",
+			manifest: "script: echo $${HOME}",
+			expected: "script: echo ${HOME}",
+		},
+		{
+			name:     "shell variables without braces are untouched",
+			manifest: "script: echo $HOME",
+			expected: "script: echo $HOME",
+		},
+		{
+			name:     "variables in comment lines are untouched",
+			manifest: "# set ${KUBEONE_TEST_MISSING} to override the name\n  # or ${CLUSTER_NAME}\nname: ${CLUSTER_NAME}\n",
+			vars:     map[string]string{"CLUSTER_NAME": "prod"},
+			expected: "# set ${KUBEONE_TEST_MISSING} to override the name\n  # or ${CLUSTER_NAME}\nname: prod\n",
+		},
+		{
+			name:     "variables in trailing comments are untouched",
+			manifest: "name: ${CLUSTER_NAME} # or $${KUBEONE_TEST_MISSING}\n",
+			vars:     map[string]string{"CLUSTER_NAME": "prod"},
+			expected: "name: prod # or $${KUBEONE_TEST_MISSING}\n",
+		},
+		{
+			name:     "numbers and booleans keep their type",
+			manifest: "replicas: ${REPLICAS}\nenabled: ${ENABLED}\nport: ${PORT:-6443}",
+			vars:     map[string]string{"REPLICAS": "3", "ENABLED": "true"},
+			expected: "replicas: 3\nenabled: true\nport: 6443",
+		},
+		{
+			name:     "value changing the structure is quoted",
+			manifest: "name: ${CLUSTER_NAME}\nregion: ${REGION}\n",
+			vars:     map[string]string{"CLUSTER_NAME": "prod\nadmin: true", "REGION": "eu: west"},
+			expected: "name: \"prod\\nadmin: true\"\nregion: \"eu: west\"\n",
+		},
+		{
+			name:     "value with an indicator is quoted",
+			manifest: "- ${A}\n- ${B}\n- ${C}\n- ${D}",
+			vars:     map[string]string{"A": "*alias", "B": "-1", "C": "- item", "D": ""},
+			expected: "- \"*alias\"\n- -1\n- \"- item\"\n- \"\"",
+		},
+		{
+			name:     "value in a double-quoted string is escaped",
+			manifest: `name: "cluster-${SUFFIX}"`,
+			vars:     map[string]string{"SUFFIX": "a\"b\\c\nd"},
+			expected: `name: "cluster-a\"b\\c\nd"`,
+		},
+		{
+			name:     "value in a single-quoted string is escaped",
+			manifest: `name: 'cluster-${SUFFIX}'`,
+			vars:     map[string]string{"SUFFIX": "it's"},
+			expected: `name: 'cluster-it''s'`,
+		},
+		{
+			name:          "newline in a single-quoted string",
+			manifest:      `name: 'cluster-${SUFFIX}'`,
+			vars:          map[string]string{"SUFFIX": "a\nb"},
+			expectedError: true,
+		},
+		{
+			name:     "value embedded in an unquoted string",
+			manifest: "image: registry.local/${IMAGE}:v1",
+			vars:     map[string]string{"IMAGE": "etcd, pause"},
+			expected: "image: registry.local/etcd, pause:v1",
+		},
+		{
+			name:          "unsafe value embedded in an unquoted string",
+			manifest:      "script: echo ${MESSAGE} done",
+			vars:          map[string]string{"MESSAGE": "a: b"},
+			expectedError: true,
+		},
+		{
+			name:     "values in flow collections",
+			manifest: "hosts: [${FIRST}, '${SECOND}']",
+			vars:     map[string]string{"FIRST": "a,b", "SECOND": "c"},
+			expected: "hosts: [\"a,b\", 'c']",
+		},
+		{
+			name:     "multiline value in a block scalar is indented",
+			manifest: "hooks:\n  script: |\n    ${SCRIPT}\n    echo done\nname: ${CLUSTER_NAME}\n",
+			vars:     map[string]string{"SCRIPT": "set -e\necho ${HOME}: start", "CLUSTER_NAME": "prod"},
+			expected: "hooks:\n  script: |\n    set -e\n    echo ${HOME}: start\n    echo done\nname: prod\n",
+		},
+		{
+			name:     "block scalar in a sequence",
+			manifest: "- script: >-\n    echo ${A}\n  name: ${A}\n",
+			vars:     map[string]string{"A": "x: y"},
+			expected: "- script: >-\n    echo x: y\n  name: \"x: y\"\n",
+		},
+		{
+			name:          "missing variable",
+			manifest:      "name: ${KUBEONE_TEST_MISSING}",
+			vars:          map[string]string{},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SubstituteVariables([]byte(tc.manifest), tc.vars)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error = %v, but got: %v", tc.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if string(got) != tc.expected {
+				t.Errorf("expected %q, but got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseVariables(t *testing.T) {
+	tests := []struct {
+		name          string
+		pairs         []string
+		expected      map[string]string
+		expectedError bool
+	}{
+		{
+			name:     "valid pairs",
+			pairs:    []string{"A=1", "B=x=y", "C="},
+			expected: map[string]string{"A": "1", "B": "x=y", "C": ""},
+		},
+		{
+			name:          "missing value",
+			pairs:         []string{"A"},
+			expectedError: true,
+		},
+		{
+			name:          "missing name",
+			pairs:         []string{"=1"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseVariables(tc.pairs)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error = %v, but got: %v", tc.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, but got %v", tc.expected, got)
+			}
+		})
+	}
+}
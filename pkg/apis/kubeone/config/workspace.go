@@ -0,0 +1,333 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	kyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	// KubeOneClusterDefaultsKind is kind of the workspace document holding
+	// values shared by all KubeOneCluster objects in the workspace
+	KubeOneClusterDefaultsKind = "KubeOneClusterDefaults"
+)
+
+// Workspace is a set of KubeOneCluster manifests sourced from a single
+// multi-document file or from a directory of manifests
+type Workspace struct {
+	Clusters []WorkspaceCluster
+}
+
+// WorkspaceCluster is a single KubeOneCluster manifest found in the workspace
+type WorkspaceCluster struct {
+	// Name is the name of the KubeOneCluster object
+	Name string
+	// Path is the file the manifest was read from, used to resolve relative paths
+	Path string
+	// Manifest is the versioned KubeOneCluster manifest with the workspace
+	// defaults already merged in
+	Manifest []byte
+}
+
+type workspaceDocument struct {
+	path string
+	meta workspaceDocumentMeta
+	data []byte
+}
+
+type workspaceDocumentMeta struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+// LoadWorkspace reads the KubeOneCluster manifests from the given files and
+// directories. A file can contain multiple YAML documents. When a directory
+// is given, all *.yaml and *.yml files in it are read in lexical order.
+//
+// Documents with the same name are overlays of the same cluster and
+// are deep-merged in the order they're read, so values from later documents
+// take precedence. Maps are merged key by key, while lists and scalar values
+// are replaced. Documents without name are overlays of the only
+// cluster in the workspace, and they can omit apiVersion and kind. Relative
+// paths in the manifest are resolved against the first file defining the
+// cluster.
+//
+// KubeOneClusterDefaults documents are merged the same way, and the result
+// is deep-merged under every KubeOneCluster manifest.
+//
+// Variables referenced in the manifests are substituted using the given
+// variables, see SubstituteVariables. Substitution is opt-in, the manifests
+// are read as they are if vars is nil.
+func LoadWorkspace(paths []string, vars map[string]string) (*Workspace, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("cluster configuration path not provided")
+	}
+
+	files := []string{}
+	for _, path := range paths {
+		if len(path) == 0 {
+			return nil, errors.New("cluster configuration path not provided")
+		}
+
+		if !isDir(path) {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the %q workspace directory", path)
+		}
+
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	var (
+		defaultsDocs []workspaceDocument
+		clusterDocs  []workspaceDocument
+		names        []string
+		namesSeen    = map[string]bool{}
+	)
+
+	for _, file := range files {
+		docs, err := readYAMLDocuments(file, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range docs {
+			meta := workspaceDocumentMeta{}
+			if err := kyaml.Unmarshal(doc, &meta); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal typeMeta of a document in %q", file)
+			}
+
+			wd := workspaceDocument{path: file, meta: meta, data: doc}
+			if meta.Kind == KubeOneClusterDefaultsKind {
+				defaultsDocs = append(defaultsDocs, wd)
+				continue
+			}
+
+			clusterDocs = append(clusterDocs, wd)
+			if name := meta.Name; name != "" && !namesSeen[name] {
+				namesSeen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	if len(clusterDocs) == 0 {
+		return nil, errors.Errorf("no KubeOneCluster manifests found in %s", strings.Join(paths, ", "))
+	}
+
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	ws := &Workspace{}
+
+	for _, name := range names {
+		docs := []workspaceDocument{}
+		for _, doc := range clusterDocs {
+			if doc.meta.Name == name {
+				docs = append(docs, doc)
+				continue
+			}
+
+			if doc.meta.Name == "" {
+				if len(names) > 1 {
+					return nil, errors.Errorf("KubeOneCluster manifest in %q must have name set when the workspace contains multiple clusters", doc.path)
+				}
+				docs = append(docs, doc)
+			}
+		}
+
+		manifest, err := mergeWorkspaceDocuments(defaultsDocs, docs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to merge manifests of the cluster %q", name)
+		}
+
+		ws.Clusters = append(ws.Clusters, WorkspaceCluster{
+			Name:     name,
+			Path:     docs[0].path,
+			Manifest: manifest,
+		})
+	}
+
+	return ws, nil
+}
+
+// Cluster returns the cluster with the given name. Name can be omitted if the
+// workspace contains only one cluster.
+func (w *Workspace) Cluster(name string) (*WorkspaceCluster, error) {
+	if name == "" {
+		if len(w.Clusters) != 1 {
+			return nil, errors.Errorf("workspace contains %d clusters, select one of them with --cluster (%s)", len(w.Clusters), strings.Join(w.Names(), ", "))
+		}
+
+		return &w.Clusters[0], nil
+	}
+
+	for i := range w.Clusters {
+		if w.Clusters[i].Name == name {
+			return &w.Clusters[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("cluster %q not found in the workspace", name)
+}
+
+// Names returns names of all clusters in the workspace
+func (w *Workspace) Names() []string {
+	names := []string{}
+	for _, c := range w.Clusters {
+		names = append(names, c.Name)
+	}
+
+	return names
+}
+
+// LoadWorkspaceKubeOneCluster returns the internal representation of the
+// KubeOneCluster object parsed from the workspace cluster manifest, Terraform
+// output and credentials file
+func LoadWorkspaceKubeOneCluster(cluster *WorkspaceCluster, tfOutputPath, credentialsFilePath string, logger logrus.FieldLogger) (*kubeoneapi.KubeOneCluster, error) {
+	tfOutput, credentialsFile, err := readTerraformOutputAndCredentials(tfOutputPath, credentialsFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return BytesToKubeOneCluster(cluster.Manifest, tfOutput, credentialsFile, logger)
+}
+
+func readYAMLDocuments(path string, vars map[string]string) ([][]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the given cluster configuration file")
+	}
+
+	if vars != nil {
+		content, err = SubstituteVariables(content, vars)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to substitute variables in %q", path)
+		}
+	}
+
+	docs := [][]byte{}
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read YAML documents from %q", path)
+		}
+
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// mergeWorkspaceDocuments deep-merges the defaults and cluster documents in
+// order. A single cluster document without defaults is returned as it is.
+func mergeWorkspaceDocuments(defaults, docs []workspaceDocument) ([]byte, error) {
+	if len(defaults) == 0 && len(docs) == 1 {
+		return docs[0].data, nil
+	}
+
+	apiVersion := ""
+	for _, doc := range append(append([]workspaceDocument{}, defaults...), docs...) {
+		if doc.meta.APIVersion == "" {
+			continue
+		}
+		if apiVersion == "" {
+			apiVersion = doc.meta.APIVersion
+		}
+		if doc.meta.APIVersion != apiVersion {
+			return nil, errors.Errorf("apiVersion %q of the %s document in %q doesn't match apiVersion %q", doc.meta.APIVersion, doc.meta.Kind, doc.path, apiVersion)
+		}
+	}
+
+	merged := map[string]interface{}{}
+
+	for _, doc := range defaults {
+		m := map[string]interface{}{}
+		if err := kyaml.Unmarshal(doc.data, &m); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal workspace defaults in %q", doc.path)
+		}
+		delete(m, "kind")
+		delete(m, "name")
+
+		merged = deepMerge(merged, m)
+	}
+
+	for _, doc := range docs {
+		m := map[string]interface{}{}
+		if err := kyaml.Unmarshal(doc.data, &m); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal cluster manifest in %q", doc.path)
+		}
+
+		merged = deepMerge(merged, m)
+	}
+
+	return kyaml.Marshal(merged)
+}
+
+// deepMerge merges override into base recursively. Maps are merged key by
+// key, while all other values, including lists, are replaced.
+func deepMerge(base, override map[string]interface{}) map[string]interface{} {
+	for k, v := range override {
+		overrideMap, ok := v.(map[string]interface{})
+		if !ok {
+			base[k] = v
+			continue
+		}
+
+		baseMap, ok := base[k].(map[string]interface{})
+		if !ok {
+			base[k] = v
+			continue
+		}
+
+		base[k] = deepMerge(baseMap, overrideMap)
+	}
+
+	return base
+}
@@ -0,0 +1,453 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+
+	kyaml "sigs.k8s.io/yaml"
+)
+
+func TestLoadWorkspace(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedNames []string
+		expectedError bool
+	}{
+		{
+			name: "single cluster",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					versions:
+					  kubernetes: 1.22.2
+				`),
+			},
+			expectedNames: []string{""},
+		},
+		{
+			name: "multiple clusters in a single file",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					name: edge-1
+					---
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					name: edge-2
+				`),
+			},
+			expectedNames: []string{"edge-1", "edge-2"},
+		},
+		{
+			name: "multiple clusters in a directory",
+			files: map[string]string{
+				"b.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					name: edge-2
+				`),
+				"a.yml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					name: edge-1
+				`),
+				"README.md": "not a manifest",
+			},
+			expectedNames: []string{"edge-1", "edge-2"},
+		},
+		{
+			name: "overlays without names",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					---
+					versions:
+					  kubernetes: 1.22.2
+				`),
+			},
+			expectedNames: []string{""},
+		},
+		{
+			name: "overlay without name of a named cluster",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					name: edge-1
+					---
+					versions:
+					  kubernetes: 1.22.2
+				`),
+			},
+			expectedNames: []string{"edge-1"},
+		},
+		{
+			name: "overlay without name with multiple clusters",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					name: edge-1
+					---
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					name: edge-2
+					---
+					versions:
+					  kubernetes: 1.22.2
+				`),
+			},
+			expectedError: true,
+		},
+		{
+			name: "overlays with the same name",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					name: edge-1
+					---
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					name: edge-1
+				`),
+			},
+			expectedNames: []string{"edge-1"},
+		},
+		{
+			name: "overlay apiVersion mismatch",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+					---
+					apiVersion: kubeone.io/v1alpha1
+					kind: KubeOneCluster
+				`),
+			},
+			expectedError: true,
+		},
+		{
+			name: "multiple defaults documents",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneClusterDefaults
+					---
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneClusterDefaults
+					---
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+				`),
+			},
+			expectedNames: []string{""},
+		},
+		{
+			name: "defaults apiVersion mismatch",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1alpha1
+					kind: KubeOneClusterDefaults
+					---
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneCluster
+				`),
+			},
+			expectedError: true,
+		},
+		{
+			name: "only defaults",
+			files: map[string]string{
+				"kubeone.yaml": heredoc.Doc(`
+					apiVersion: kubeone.io/v1beta1
+					kind: KubeOneClusterDefaults
+				`),
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			path := dir
+			if len(tc.files) == 1 {
+				path = filepath.Join(dir, "kubeone.yaml")
+			}
+
+			ws, err := LoadWorkspace([]string{path}, nil)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error = %v, but got: %v", tc.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if names := ws.Names(); !reflect.DeepEqual(names, tc.expectedNames) {
+				t.Errorf("expected clusters %v, but got %v", tc.expectedNames, names)
+			}
+		})
+	}
+}
+
+func TestLoadWorkspaceDefaults(t *testing.T) {
+	manifest := heredoc.Doc(`
+		apiVersion: kubeone.io/v1beta1
+		kind: KubeOneClusterDefaults
+		name: ignored
+		versions:
+		  kubernetes: 1.22.2
+		cloudProvider:
+		  none: {}
+		clusterNetwork:
+		  podSubnet: 10.244.0.0/16
+		  serviceSubnet: 10.96.0.0/12
+		---
+		apiVersion: kubeone.io/v1beta1
+		kind: KubeOneCluster
+		name: edge-1
+		---
+		apiVersion: kubeone.io/v1beta1
+		kind: KubeOneCluster
+		name: edge-2
+		versions:
+		  kubernetes: 1.21.5
+		clusterNetwork:
+		  podSubnet: 10.100.0.0/16
+	`)
+
+	path := filepath.Join(t.TempDir(), "kubeone.yaml")
+	if err := os.WriteFile(path, []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := LoadWorkspace([]string{path}, nil)
+	if err != nil {
+		t.Fatalf("failed to load workspace: %v", err)
+	}
+
+	if _, err = ws.Cluster(""); err == nil {
+		t.Errorf("expected error when selecting a cluster without name from multiple clusters")
+	}
+
+	tests := []struct {
+		cluster  string
+		expected string
+	}{
+		{
+			cluster: "edge-1",
+			expected: heredoc.Doc(`
+				apiVersion: kubeone.io/v1beta1
+				kind: KubeOneCluster
+				name: edge-1
+				versions:
+				  kubernetes: 1.22.2
+				cloudProvider:
+				  none: {}
+				clusterNetwork:
+				  podSubnet: 10.244.0.0/16
+				  serviceSubnet: 10.96.0.0/12
+			`),
+		},
+		{
+			cluster: "edge-2",
+			expected: heredoc.Doc(`
+				apiVersion: kubeone.io/v1beta1
+				kind: KubeOneCluster
+				name: edge-2
+				versions:
+				  kubernetes: 1.21.5
+				cloudProvider:
+				  none: {}
+				clusterNetwork:
+				  podSubnet: 10.100.0.0/16
+				  serviceSubnet: 10.96.0.0/12
+			`),
+		},
+	}
+
+	for _, tc := range tests {
+		wc, err := ws.Cluster(tc.cluster)
+		if err != nil {
+			t.Fatalf("failed to get cluster %q: %v", tc.cluster, err)
+		}
+
+		got := map[string]interface{}{}
+		if err := kyaml.Unmarshal(wc.Manifest, &got); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := map[string]interface{}{}
+		if err := kyaml.Unmarshal([]byte(tc.expected), &expected); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("cluster %q: expected manifest\n%s\nbut got\n%s", tc.cluster, tc.expected, wc.Manifest)
+		}
+
+		if wc.Path != path {
+			t.Errorf("cluster %q: expected path %q, but got %q", tc.cluster, path, wc.Path)
+		}
+	}
+}
+
+func TestLoadWorkspaceOverlays(t *testing.T) {
+	dir := t.TempDir()
+
+	base := filepath.Join(dir, "base.yaml")
+	if err := os.WriteFile(base, []byte(heredoc.Doc(`
+		apiVersion: kubeone.io/v1beta1
+		kind: KubeOneCluster
+		name: demo
+		versions:
+		  kubernetes: 1.21.5
+		features:
+		  podSecurityPolicy:
+		    enable: true
+		  staticAuditLog:
+		    enable: true
+		    config:
+		      policyFilePath: audit-policy.yaml
+		addons:
+		  enable: true
+		  path: ./addons
+	`)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	overridesDir := filepath.Join(dir, "prod")
+	if err := os.Mkdir(overridesDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(overridesDir, "10-versions.yaml"), []byte(heredoc.Doc(`
+		versions:
+		  kubernetes: 1.22.2
+	`)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(overridesDir, "20-features.yaml"), []byte(heredoc.Doc(`
+		features:
+		  podSecurityPolicy:
+		    enable: false
+		addons:
+		  path: ./addons-prod
+	`)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := LoadWorkspace([]string{base, overridesDir}, nil)
+	if err != nil {
+		t.Fatalf("failed to load workspace: %v", err)
+	}
+
+	wc, err := ws.Cluster("")
+	if err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+
+	expected := heredoc.Doc(`
+		apiVersion: kubeone.io/v1beta1
+		kind: KubeOneCluster
+		name: demo
+		versions:
+		  kubernetes: 1.22.2
+		features:
+		  podSecurityPolicy:
+		    enable: false
+		  staticAuditLog:
+		    enable: true
+		    config:
+		      policyFilePath: audit-policy.yaml
+		addons:
+		  enable: true
+		  path: ./addons-prod
+	`)
+
+	got := map[string]interface{}{}
+	if err := kyaml.Unmarshal(wc.Manifest, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{}
+	if err := kyaml.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected manifest\n%s\nbut got\n%s", expected, wc.Manifest)
+	}
+
+	if wc.Path != base {
+		t.Errorf("expected path %q, but got %q", base, wc.Path)
+	}
+}
+
+func TestLoadWorkspaceWithoutVariables(t *testing.T) {
+	manifest := heredoc.Doc(`
+		apiVersion: kubeone.io/v1beta1
+		kind: KubeOneCluster
+		name: ${CLUSTER_NAME}
+		versions:
+		  kubernetes: 1.22.2
+		hooks:
+		  host:
+		    preProvision:
+		    - name: mirror
+		      inline: |
+		        echo "${HOME}: ${MIRROR:-default}" > "$${TARGET}"
+	`)
+
+	path := filepath.Join(t.TempDir(), "kubeone.yaml")
+	if err := os.WriteFile(path, []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := LoadWorkspace([]string{path}, nil)
+	if err != nil {
+		t.Fatalf("failed to load workspace: %v", err)
+	}
+
+	wc, err := ws.Cluster("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(wc.Manifest) != manifest {
+		t.Errorf("expected the manifest to be read as it is, but got\n%s", wc.Manifest)
+	}
+}
@@ -20,8 +20,11 @@ import (
 	"bytes"
 	"fmt"
 	"math/rand"
+	"net"
+	"net/url"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
@@ -69,6 +72,149 @@ func (c *KubeOneCluster) IsManagedNode(nodename string) bool {
 	return false
 }
 
+// LocalEtcd reports whether etcd is stacked on the control plane nodes and
+// managed by KubeOne, as opposed to the external etcd cluster
+func (c KubeOneCluster) LocalEtcd() bool {
+	return c.ExternalEtcd == nil
+}
+
+// OperatingSystemManagerEnabled returns whether operating-system-manager is
+// deployed and used by machine-controller to render the workers user-data
+func (c KubeOneCluster) OperatingSystemManagerEnabled() bool {
+	return c.OperatingSystemManager != nil && c.OperatingSystemManager.Deploy
+}
+
+// ClusterAutoscalerEnabled returns whether the cluster-autoscaler is deployed
+// because at least one of the dynamic workersets is autoscaled
+func (c KubeOneCluster) ClusterAutoscalerEnabled() bool {
+	if !c.MachineController.Deploy {
+		return false
+	}
+
+	for _, w := range c.DynamicWorkers {
+		if w.Autoscaling != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// KubeletConfigFlags are the kubelet flags configured by KubeletConfig
+var KubeletConfigFlags = []string{
+	"cgroup-driver",
+	"eviction-hard",
+	"kube-reserved",
+	"max-pods",
+	"system-reserved",
+}
+
+// HostKubeletConfig returns the kubelet configuration of the host, i.e. the
+// cluster-wide configuration overridden by the host configuration. The
+// resources and the eviction thresholds are overridden one by one.
+func (c KubeOneCluster) HostKubeletConfig(host HostConfig) KubeletConfig {
+	cfg := KubeletConfig{}
+
+	for _, kc := range []*KubeletConfig{c.KubeletConfig, host.KubeletConfig} {
+		if kc == nil {
+			continue
+		}
+		if kc.MaxPods != nil {
+			maxPods := *kc.MaxPods
+			cfg.MaxPods = &maxPods
+		}
+		if kc.CgroupDriver != "" {
+			cfg.CgroupDriver = kc.CgroupDriver
+		}
+		cfg.SystemReserved = mergeStringMaps(cfg.SystemReserved, kc.SystemReserved)
+		cfg.KubeReserved = mergeStringMaps(cfg.KubeReserved, kc.KubeReserved)
+		cfg.EvictionHard = mergeStringMaps(cfg.EvictionHard, kc.EvictionHard)
+	}
+
+	return cfg
+}
+
+// Flags returns the kubelet flags, without the leading dashes, for the
+// configured settings
+func (k KubeletConfig) Flags() map[string]string {
+	flags := map[string]string{}
+
+	if k.MaxPods != nil {
+		flags["max-pods"] = strconv.Itoa(int(*k.MaxPods))
+	}
+	if k.CgroupDriver != "" {
+		flags["cgroup-driver"] = k.CgroupDriver
+	}
+	if len(k.SystemReserved) > 0 {
+		flags["system-reserved"] = joinSortedMap(k.SystemReserved, "=")
+	}
+	if len(k.KubeReserved) > 0 {
+		flags["kube-reserved"] = joinSortedMap(k.KubeReserved, "=")
+	}
+	if len(k.EvictionHard) > 0 {
+		flags["eviction-hard"] = joinSortedMap(k.EvictionHard, "<")
+	}
+
+	return flags
+}
+
+func mergeStringMaps(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+
+	if dst == nil {
+		dst = map[string]string{}
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+
+	return dst
+}
+
+func joinSortedMap(m map[string]string, sep string) string {
+	pairs := []string{}
+	for k, v := range m {
+		pairs = append(pairs, k+sep+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// AssetCacheHost returns the host designated as the asset cache. The host is
+// matched by its private address, the only address the cache listens on.
+func (c KubeOneCluster) AssetCacheHost() (HostConfig, error) {
+	if c.AssetConfiguration.Cache == nil {
+		return HostConfig{}, errors.New("asset cache is not configured")
+	}
+
+	for _, host := range append(append([]HostConfig{}, c.ControlPlane.Hosts...), c.StaticWorkers.Hosts...) {
+		if host.PrivateAddress == c.AssetConfiguration.Cache.Host {
+			return host, nil
+		}
+	}
+
+	return HostConfig{}, errors.Errorf("asset cache host %q not found among the private addresses of the control plane and static worker hosts", c.AssetConfiguration.Cache.Host)
+}
+
+// URL returns the URL through which the given asset is downloaded from the
+// cache. URLs are returned as they are if the cache is not configured.
+func (c *AssetCache) URL(assetURL string) string {
+	if c == nil {
+		return assetURL
+	}
+
+	for _, scheme := range []string{"http", "https"} {
+		if rest := strings.TrimPrefix(assetURL, scheme+"://"); rest != assetURL {
+			return fmt.Sprintf("http://%s/%s/%s", net.JoinHostPort(c.Host, strconv.Itoa(c.Port)), scheme, rest)
+		}
+	}
+
+	return assetURL
+}
+
 // SetHostname sets the hostname for the given host
 func (h *HostConfig) SetHostname(hostname string) {
 	h.Hostname = hostname
@@ -84,10 +230,148 @@ func (h *HostConfig) SetLeader(leader bool) {
 	h.IsLeader = leader
 }
 
+// AdvertiseAddress returns the IP address kube-apiserver advertises on the host
+func (h HostConfig) AdvertiseAddress() string {
+	if h.NetworkOverrides != nil && h.NetworkOverrides.APIServerAdvertiseAddress != "" {
+		return h.NetworkOverrides.APIServerAdvertiseAddress
+	}
+
+	return h.defaultAddress()
+}
+
+// NodeIP returns the IP address kubelet reports as the node IP
+func (h HostConfig) NodeIP() string {
+	if h.NetworkOverrides != nil && h.NetworkOverrides.KubeletNodeIP != "" {
+		return h.NetworkOverrides.KubeletNodeIP
+	}
+
+	return h.defaultAddress()
+}
+
+// NodeIPs returns the comma-separated IP addresses kubelet reports as the
+// node IPs, one per IP family of the cluster network, ordered as the families
+func (h HostConfig) NodeIPs(c ClusterNetworkConfig) string {
+	if len(c.IPFamilies) == 0 {
+		return h.NodeIP()
+	}
+
+	var ips []string
+	for _, family := range c.IPFamilies {
+		switch family {
+		case IPFamilyIPv4:
+			ips = append(ips, h.NodeIP())
+		case IPFamilyIPv6:
+			ips = append(ips, h.NodeIPv6())
+		}
+	}
+
+	return strings.Join(ips, ",")
+}
+
+// NodeIPv6 returns the IP address kubelet reports as the node IP of the IPv6
+// family. It's the first of the IPv6Addresses, or the NodeIP if the host has
+// no IPv6 addresses.
+func (h HostConfig) NodeIPv6() string {
+	if len(h.IPv6Addresses) > 0 {
+		return h.IPv6Addresses[0]
+	}
+
+	return h.NodeIP()
+}
+
+func (h HostConfig) defaultAddress() string {
+	if h.PrivateAddress != "" {
+		return h.PrivateAddress
+	}
+
+	return h.PublicAddress
+}
+
+// Pool returns the static workers pool with the given name, or nil if there
+// is no such pool
+func (c StaticWorkersConfig) Pool(name string) *StaticWorkerPool {
+	if name == "" {
+		return nil
+	}
+
+	for i := range c.Pools {
+		if c.Pools[i].Name == name {
+			return &c.Pools[i]
+		}
+	}
+
+	return nil
+}
+
+// EtcdSettingsFlags are the etcd flags configured by the EtcdConfig
+var EtcdSettingsFlags = []string{
+	"quota-backend-bytes",
+	"snapshot-count",
+	"heartbeat-interval",
+	"election-timeout",
+	"cipher-suites",
+}
+
+// ExtraArgs returns the etcd flags for the configured settings. The intervals
+// are passed in milliseconds, as expected by etcd.
+func (c EtcdConfig) ExtraArgs() map[string]string {
+	args := map[string]string{}
+
+	if c.QuotaBackendBytes > 0 {
+		args["quota-backend-bytes"] = strconv.FormatInt(c.QuotaBackendBytes, 10)
+	}
+	if c.SnapshotCount > 0 {
+		args["snapshot-count"] = strconv.FormatUint(c.SnapshotCount, 10)
+	}
+	if c.HeartbeatInterval.Duration > 0 {
+		args["heartbeat-interval"] = strconv.FormatInt(c.HeartbeatInterval.Milliseconds(), 10)
+	}
+	if c.ElectionTimeout.Duration > 0 {
+		args["election-timeout"] = strconv.FormatInt(c.ElectionTimeout.Milliseconds(), 10)
+	}
+	if c.TLS != nil && len(c.TLS.CipherSuites) > 0 {
+		args["cipher-suites"] = strings.Join(c.TLS.CipherSuites, ",")
+	}
+
+	return args
+}
+
+// ServerCertSANs returns the additional SANs of the etcd server certificates
+func (c EtcdConfig) ServerCertSANs() []string {
+	if c.TLS == nil {
+		return nil
+	}
+
+	return c.TLS.ServerCertSANs
+}
+
+// FormatExtraArgs formats the flags as the sorted, comma-separated list of
+// key=value pairs, so the flags can be compared and printed
+func FormatExtraArgs(args map[string]string) string {
+	pairs := make([]string, 0, len(args))
+	for k, v := range args {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// EtcdURLsOverridden returns whether any of the etcd URLs is overridden
+func (o *HostNetworkOverrides) EtcdURLsOverridden() bool {
+	if o == nil {
+		return false
+	}
+
+	return len(o.EtcdListenClientURLs) > 0 || len(o.EtcdAdvertiseClientURLs) > 0 || len(o.EtcdListenPeerURLs) > 0
+}
+
 func (crc ContainerRuntimeConfig) String() string {
 	switch {
 	case crc.Containerd != nil:
 		return "containerd"
+	case crc.CRIO != nil:
+		return "cri-o"
 	case crc.Docker != nil:
 		return "docker"
 	}
@@ -101,6 +385,8 @@ func (crc *ContainerRuntimeConfig) UnmarshalText(text []byte) error {
 		*crc = ContainerRuntimeConfig{Docker: &ContainerRuntimeDocker{}}
 	case bytes.Equal(text, []byte("containerd")):
 		*crc = ContainerRuntimeConfig{Containerd: &ContainerRuntimeContainerd{}}
+	case bytes.Equal(text, []byte("cri-o")), bytes.Equal(text, []byte("crio")):
+		*crc = ContainerRuntimeConfig{CRIO: &ContainerRuntimeCRIO{}}
 	default:
 		return fmt.Errorf("unknown container runtime: %q", text)
 	}
@@ -112,6 +398,8 @@ func (crc ContainerRuntimeConfig) CRISocket() string {
 	switch {
 	case crc.Containerd != nil:
 		return "/run/containerd/containerd.sock"
+	case crc.CRIO != nil:
+		return "/var/run/crio/crio.sock"
 	case crc.Docker != nil:
 		return "/var/run/dockershim.sock"
 	}
@@ -119,6 +407,46 @@ func (crc ContainerRuntimeConfig) CRISocket() string {
 	return ""
 }
 
+// RegistryMirrors returns the mirrors of the registry configured for the
+// containerd container runtime
+func (crc ContainerRuntimeConfig) RegistryMirrors(registry string) []string {
+	if crc.Containerd == nil {
+		return nil
+	}
+
+	return crc.Containerd.Registries[registry].Mirrors
+}
+
+// NodeInsecureRegistries returns the insecure registry and the hosts of the
+// containerd registry mirrors with disabled TLS verification, sorted
+func (c KubeOneCluster) NodeInsecureRegistries() []string {
+	insecure := map[string]bool{}
+	if registry := c.RegistryConfiguration.InsecureRegistryAddress(); registry != "" {
+		insecure[registry] = true
+	}
+
+	if c.ContainerRuntime.Containerd != nil {
+		for _, mirror := range c.ContainerRuntime.Containerd.Registries {
+			if !mirror.InsecureSkipVerify {
+				continue
+			}
+			for _, m := range mirror.Mirrors {
+				if u, err := url.Parse(m); err == nil && u.Host != "" {
+					insecure[u.Host] = true
+				}
+			}
+		}
+	}
+
+	registries := make([]string, 0, len(insecure))
+	for registry := range insecure {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+
+	return registries
+}
+
 // CloudProviderName returns name of the cloud provider
 func (p CloudProviderSpec) CloudProviderName() string {
 	switch {
@@ -132,12 +460,18 @@ func (p CloudProviderSpec) CloudProviderName() string {
 		return "gce"
 	case p.Hetzner != nil:
 		return "hetzner"
+	case p.KubeVirt != nil:
+		return "kubevirt"
+	case p.Nutanix != nil:
+		return "nutanix"
 	case p.Openstack != nil:
 		return "openstack"
 	case p.Packet != nil:
 		return "packet"
 	case p.Vsphere != nil:
 		return "vsphere"
+	case p.VMwareCloudDirector != nil:
+		return "vmware-cloud-director"
 	case p.None != nil:
 		return "none"
 	}
@@ -148,9 +482,9 @@ func (p CloudProviderSpec) CloudProviderName() string {
 // CloudProviderInTree detects is there in-tree cloud provider implementation for specified provider.
 // List of in-tree provider can be found here: https://github.com/kubernetes/kubernetes/tree/master/pkg/cloudprovider
 func (p CloudProviderSpec) CloudProviderInTree() bool {
-	if p.Openstack != nil || p.Vsphere != nil {
+	if p.AWS != nil || p.Azure != nil || p.Openstack != nil || p.Vsphere != nil {
 		return !p.External
-	} else if p.AWS != nil || p.GCE != nil || p.Azure != nil {
+	} else if p.GCE != nil {
 		return true
 	}
 
@@ -161,7 +495,7 @@ func (p CloudProviderSpec) CloudProviderInTree() bool {
 // NB: The CSI migration can be supported only if KubeOne supports CSI plugin and driver
 // for the provider
 func (p CloudProviderSpec) CSIMigrationSupported() bool {
-	return p.External && (p.Openstack != nil || p.Vsphere != nil)
+	return p.External && (p.AWS != nil || p.Azure != nil || p.Openstack != nil || p.Vsphere != nil)
 }
 
 // CSIMigrationFeatureGates returns CSI migration feature gates in form of a map
@@ -172,56 +506,71 @@ func (p CloudProviderSpec) CSIMigrationSupported() bool {
 // (https://kubernetes.io/docs/reference/command-line-tools-reference/feature-gates/)
 // This is a KubeOneCluster function because feature gates are Kubernetes-version dependent.
 func (c KubeOneCluster) CSIMigrationFeatureGates(complete bool) (map[string]bool, string, error) {
+	var featureGates map[string]bool
+
 	switch {
+	case c.CloudProvider.AWS != nil:
+		featureGates = map[string]bool{
+			"CSIMigrationAWS": true,
+		}
+	case c.CloudProvider.Azure != nil:
+		featureGates = map[string]bool{
+			"CSIMigrationAzureDisk": true,
+			"CSIMigrationAzureFile": true,
+		}
 	case c.CloudProvider.Openstack != nil:
-		featureGates := map[string]bool{
+		featureGates = map[string]bool{
 			"CSIMigrationOpenStack": true,
 			"ExpandCSIVolumes":      true,
 		}
-
-		unregister := c.InTreePluginUnregisterFeatureGate()
-		if complete && unregister != "" {
-			featureGates[unregister] = true
-		}
-
-		return featureGates, marshalFeatureGates(featureGates), nil
 	case c.CloudProvider.Vsphere != nil:
-		featureGates := map[string]bool{
+		featureGates = map[string]bool{
 			"CSIMigrationvSphere": true,
 		}
+	default:
+		return nil, "", errors.New("csi migration is not supported for selected provider")
+	}
 
-		unregister := c.InTreePluginUnregisterFeatureGate()
-		if complete && unregister != "" {
+	if complete {
+		for _, unregister := range c.InTreePluginUnregisterFeatureGates() {
 			featureGates[unregister] = true
 		}
-
-		return featureGates, marshalFeatureGates(featureGates), nil
 	}
 
-	return nil, "", errors.New("csi migration is not supported for selected provider")
+	return featureGates, marshalFeatureGates(featureGates), nil
 }
 
-// CSIMigrationFeatureGates returns the name of the feature gate that's supposed to
-// unregister the in-tree cloud provider.
+// InTreePluginUnregisterFeatureGates returns the names of the feature gates that
+// are supposed to unregister the in-tree cloud provider.
 // NB: This is a KubeOneCluster function because feature gates are Kubernetes-version dependent.
-func (c KubeOneCluster) InTreePluginUnregisterFeatureGate() string {
+func (c KubeOneCluster) InTreePluginUnregisterFeatureGates() []string {
 	lessThan21, _ := semver.NewConstraint("< 1.21.0")
 	ver, _ := semver.NewVersion(c.Versions.Kubernetes)
 
 	switch {
+	case c.CloudProvider.AWS != nil:
+		if lessThan21.Check(ver) {
+			return []string{"CSIMigrationAWSComplete"}
+		}
+		return []string{"InTreePluginAWSUnregister"}
+	case c.CloudProvider.Azure != nil:
+		if lessThan21.Check(ver) {
+			return []string{"CSIMigrationAzureDiskComplete", "CSIMigrationAzureFileComplete"}
+		}
+		return []string{"InTreePluginAzureDiskUnregister", "InTreePluginAzureFileUnregister"}
 	case c.CloudProvider.Openstack != nil:
 		if lessThan21.Check(ver) {
-			return "CSIMigrationOpenStackComplete"
+			return []string{"CSIMigrationOpenStackComplete"}
 		}
-		return "InTreePluginOpenStackUnregister"
+		return []string{"InTreePluginOpenStackUnregister"}
 	case c.CloudProvider.Vsphere != nil:
 		if lessThan21.Check(ver) {
-			return "CSIMigrationvSphereComplete"
+			return []string{"CSIMigrationvSphereComplete"}
 		}
-		return "InTreePluginvSphereUnregister"
+		return []string{"InTreePluginvSphereUnregister"}
 	}
 
-	return ""
+	return nil
 }
 
 func marshalFeatureGates(fgm map[string]bool) string {
@@ -271,3 +620,217 @@ func (ads *Addons) RelativePath(manifestFilePath string) (string, error) {
 
 	return addonsPath, nil
 }
+
+// ParseNodePortRange returns the first and the last port of the NodePort
+// range in the kube-apiserver format, such as "30000-32767" or "30000+2767"
+func ParseNodePortRange(portRange string) (int, int, error) {
+	sep := "-"
+	if strings.Contains(portRange, "+") {
+		sep = "+"
+	}
+
+	parts := strings.Split(portRange, sep)
+	if len(parts) != 2 {
+		return 0, 0, errors.Errorf("invalid port range %q", portRange)
+	}
+
+	base, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid port range %q", portRange)
+	}
+
+	size, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid port range %q", portRange)
+	}
+
+	last := size
+	if sep == "+" {
+		last = base + size
+	}
+
+	if base < 1 || last > 65535 || last < base {
+		return 0, 0, errors.Errorf("invalid port range %q", portRange)
+	}
+
+	return base, last, nil
+}
+
+// CiliumReplacesKubeProxy returns whether Cilium replaces kube-proxy
+// completely, in which case kube-proxy is not deployed
+func (c ClusterNetworkConfig) CiliumReplacesKubeProxy() bool {
+	return c.CNI != nil && c.CNI.Cilium != nil && c.CNI.Cilium.KubeProxyReplacement == CiliumKubeProxyReplacementStrict
+}
+
+// HasIPFamily returns whether the cluster network uses the IP family. The
+// cluster network without the IPFamilies uses the IPv4 family.
+func (c ClusterNetworkConfig) HasIPFamily(family IPFamily) bool {
+	if len(c.IPFamilies) == 0 {
+		return family == IPFamilyIPv4
+	}
+
+	for _, f := range c.IPFamilies {
+		if f == family {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DualStack returns whether the cluster network uses both IP families
+func (c ClusterNetworkConfig) DualStack() bool {
+	return c.HasIPFamily(IPFamilyIPv4) && c.HasIPFamily(IPFamilyIPv6)
+}
+
+// PodSubnets returns the comma-separated pod subnets of the IP families,
+// ordered as the families. The subnet of the IPv4 family is the ClusterCIDR.
+func (c ClusterNetworkConfig) PodSubnets() string {
+	return c.subnets(c.ClusterCIDR(), c.PodSubnetIPv6)
+}
+
+// ServiceSubnets returns the comma-separated service subnets of the IP
+// families, ordered as the families
+func (c ClusterNetworkConfig) ServiceSubnets() string {
+	return c.subnets(c.ServiceSubnet, c.ServiceSubnetIPv6)
+}
+
+func (c ClusterNetworkConfig) subnets(ipv4, ipv6 string) string {
+	if len(c.IPFamilies) == 0 {
+		return ipv4
+	}
+
+	var subnets []string
+	for _, family := range c.IPFamilies {
+		switch family {
+		case IPFamilyIPv4:
+			subnets = append(subnets, ipv4)
+		case IPFamilyIPv6:
+			subnets = append(subnets, ipv6)
+		}
+	}
+
+	return strings.Join(subnets, ",")
+}
+
+// ClusterCIDR returns the subnet the pod subnets of the nodes are allocated
+// from. It's the PodSubnet, or the smallest subnet covering the PodSubnet and
+// the AdditionalPodSubnets.
+func (c ClusterNetworkConfig) ClusterCIDR() string {
+	if len(c.AdditionalPodSubnets) == 0 {
+		return c.PodSubnet
+	}
+
+	_, supernet, err := net.ParseCIDR(c.PodSubnet)
+	if err != nil {
+		return c.PodSubnet
+	}
+
+	for _, subnet := range c.AdditionalPodSubnets {
+		_, ipnet, err := net.ParseCIDR(subnet)
+		if err != nil || len(ipnet.IP) != len(supernet.IP) {
+			return c.PodSubnet
+		}
+
+		for !supernet.Contains(ipnet.IP) || !containsLast(supernet, ipnet) {
+			ones, bits := supernet.Mask.Size()
+			supernet.Mask = net.CIDRMask(ones-1, bits)
+			supernet.IP = supernet.IP.Mask(supernet.Mask)
+		}
+	}
+
+	return supernet.String()
+}
+
+// containsLast returns whether the outer subnet contains the last address of
+// the inner subnet
+func containsLast(outer, inner *net.IPNet) bool {
+	last := make(net.IP, len(inner.IP))
+	for i := range inner.IP {
+		last[i] = inner.IP[i] | ^inner.Mask[i]
+	}
+
+	return outer.Contains(last)
+}
+
+// Enabled returns whether kube-vip is deployed
+func (k *KubeVIP) Enabled() bool {
+	return k != nil && k.Enable
+}
+
+// Enabled returns whether the NodeLocalDNS cache is deployed
+func (n *NodeLocalDNS) Enabled() bool {
+	return n != nil && n.Enable
+}
+
+// ForceTCPEnabled returns whether TCP is forced for the queries forwarded by
+// the NodeLocalDNS cache, which is the default
+func (c NodeLocalDNSConfig) ForceTCPEnabled() bool {
+	return c.ForceTCP == nil || *c.ForceTCP
+}
+
+// Enabled returns whether Konnectivity is deployed
+func (k *Konnectivity) Enabled() bool {
+	return k != nil && k.Enable
+}
+
+// Enabled returns whether OPA Gatekeeper is deployed
+func (g *Gatekeeper) Enabled() bool {
+	return g != nil && g.Enable
+}
+
+// Enabled returns whether SR-IOV is configured
+func (s *SRIOV) Enabled() bool {
+	return s != nil && s.Enable
+}
+
+// HostConfig returns the SR-IOV configuration of the host, or nil if virtual
+// functions are not configured on the host
+func (s *SRIOV) HostConfig(host HostConfig) *SRIOVHost {
+	if !s.Enabled() {
+		return nil
+	}
+
+	for i := range s.Hosts {
+		if s.Hosts[i].Matches(host) {
+			return &s.Hosts[i]
+		}
+	}
+
+	return nil
+}
+
+// Resources returns the physical function interfaces advertised by the
+// device plugin, keyed by the resource name
+func (s *SRIOV) Resources() map[string][]string {
+	resources := map[string][]string{}
+	if !s.Enabled() {
+		return resources
+	}
+
+	for _, host := range s.Hosts {
+		for _, pf := range host.PhysicalFunctions {
+			found := false
+			for _, iface := range resources[pf.ResourceName] {
+				if iface == pf.Interface {
+					found = true
+					break
+				}
+			}
+			if !found {
+				resources[pf.ResourceName] = append(resources[pf.ResourceName], pf.Interface)
+			}
+		}
+	}
+
+	return resources
+}
+
+// Matches returns whether the SR-IOV configuration targets the host
+func (h SRIOVHost) Matches(host HostConfig) bool {
+	if h.Address == "" {
+		return false
+	}
+
+	return h.Address == host.PublicAddress || h.Address == host.PrivateAddress || h.Address == host.Hostname
+}
@@ -16,7 +16,13 @@ limitations under the License.
 
 package kubeone
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func TestFeatureGatesString(t *testing.T) {
 	t.Parallel()
@@ -70,3 +76,641 @@ func TestFeatureGatesString(t *testing.T) {
 		})
 	}
 }
+
+func TestCSIMigrationFeatureGates(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		cloudProvider CloudProviderSpec
+		version       string
+		complete      bool
+		expected      string
+		expectedErr   bool
+	}{
+		{
+			name:          "aws",
+			cloudProvider: CloudProviderSpec{AWS: &AWSSpec{}},
+			version:       "1.22.4",
+			expected:      "CSIMigrationAWS=true",
+		},
+		{
+			name:          "aws complete",
+			cloudProvider: CloudProviderSpec{AWS: &AWSSpec{}},
+			version:       "1.22.4",
+			complete:      true,
+			expected:      "CSIMigrationAWS=true,InTreePluginAWSUnregister=true",
+		},
+		{
+			name:          "azure",
+			cloudProvider: CloudProviderSpec{Azure: &AzureSpec{}},
+			version:       "1.22.4",
+			expected:      "CSIMigrationAzureDisk=true,CSIMigrationAzureFile=true",
+		},
+		{
+			name:          "azure complete",
+			cloudProvider: CloudProviderSpec{Azure: &AzureSpec{}},
+			version:       "1.22.4",
+			complete:      true,
+			expected:      "CSIMigrationAzureDisk=true,CSIMigrationAzureFile=true,InTreePluginAzureDiskUnregister=true,InTreePluginAzureFileUnregister=true",
+		},
+		{
+			name:          "openstack complete before 1.21",
+			cloudProvider: CloudProviderSpec{Openstack: &OpenstackSpec{}},
+			version:       "1.20.13",
+			complete:      true,
+			expected:      "CSIMigrationOpenStack=true,CSIMigrationOpenStackComplete=true,ExpandCSIVolumes=true",
+		},
+		{
+			name:          "hetzner",
+			cloudProvider: CloudProviderSpec{Hetzner: &HetznerSpec{}},
+			version:       "1.22.4",
+			expectedErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := KubeOneCluster{
+				CloudProvider: tc.cloudProvider,
+				Versions:      VersionConfig{Kubernetes: tc.version},
+			}
+
+			_, got, err := c.CSIMigrationFeatureGates(tc.complete)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("CSIMigrationFeatureGates() error = %v, expectedErr %v", err, tc.expectedErr)
+			}
+			if got != tc.expected {
+				t.Errorf("CSIMigrationFeatureGates() got = %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAssetCacheURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		cache    *AssetCache
+		url      string
+		expected string
+	}{
+		{
+			name:     "cache not configured",
+			url:      "https://github.com/containernetworking/plugins",
+			expected: "https://github.com/containernetworking/plugins",
+		},
+		{
+			name:     "https url",
+			cache:    &AssetCache{Host: "10.0.0.1", Port: 8008},
+			url:      "https://github.com/containernetworking/plugins",
+			expected: "http://10.0.0.1:8008/https/github.com/containernetworking/plugins",
+		},
+		{
+			name:     "http url",
+			cache:    &AssetCache{Host: "10.0.0.1", Port: 8008},
+			url:      "http://apt.kubernetes.io",
+			expected: "http://10.0.0.1:8008/http/apt.kubernetes.io",
+		},
+		{
+			name:     "ipv6 cache host",
+			cache:    &AssetCache{Host: "fd00::1", Port: 8008},
+			url:      "https://packages.cloud.google.com",
+			expected: "http://[fd00::1]:8008/https/packages.cloud.google.com",
+		},
+		{
+			name:     "unsupported scheme",
+			cache:    &AssetCache{Host: "10.0.0.1", Port: 8008},
+			url:      "ftp://example.com/file",
+			expected: "ftp://example.com/file",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.cache.URL(tc.url)
+			if got != tc.expected {
+				t.Errorf("AssetCache.URL() got = %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseNodePortRange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		portRange     string
+		expectedFirst int
+		expectedLast  int
+		expectedError bool
+	}{
+		{
+			name:          "default range",
+			portRange:     "30000-32767",
+			expectedFirst: 30000,
+			expectedLast:  32767,
+		},
+		{
+			name:          "base and offset",
+			portRange:     "30000+100",
+			expectedFirst: 30000,
+			expectedLast:  30100,
+		},
+		{
+			name:          "reversed range",
+			portRange:     "32767-30000",
+			expectedError: true,
+		},
+		{
+			name:          "out of bounds",
+			portRange:     "60000-70000",
+			expectedError: true,
+		},
+		{
+			name:          "single port",
+			portRange:     "30000",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			first, last, err := ParseNodePortRange(tc.portRange)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("ParseNodePortRange() error = %v, expected error %v", err, tc.expectedError)
+			}
+			if first != tc.expectedFirst || last != tc.expectedLast {
+				t.Errorf("ParseNodePortRange() got = %d-%d, expected %d-%d", first, last, tc.expectedFirst, tc.expectedLast)
+			}
+		})
+	}
+}
+
+func TestClusterCIDR(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		network  ClusterNetworkConfig
+		expected string
+	}{
+		{
+			name:     "no additional subnets",
+			network:  ClusterNetworkConfig{PodSubnet: "10.244.0.0/16"},
+			expected: "10.244.0.0/16",
+		},
+		{
+			name:     "adjacent subnet",
+			network:  ClusterNetworkConfig{PodSubnet: "10.244.0.0/16", AdditionalPodSubnets: []string{"10.245.0.0/16"}},
+			expected: "10.244.0.0/15",
+		},
+		{
+			name:     "subnet preceding the pod subnet",
+			network:  ClusterNetworkConfig{PodSubnet: "10.245.0.0/16", AdditionalPodSubnets: []string{"10.244.0.0/16"}},
+			expected: "10.244.0.0/15",
+		},
+		{
+			name:     "multiple subnets",
+			network:  ClusterNetworkConfig{PodSubnet: "10.244.0.0/16", AdditionalPodSubnets: []string{"10.245.0.0/16", "10.246.0.0/16"}},
+			expected: "10.244.0.0/14",
+		},
+		{
+			name:     "ipv6 subnet",
+			network:  ClusterNetworkConfig{PodSubnet: "fd00:10:244::/64", AdditionalPodSubnets: []string{"fd00:10:244:1::/64"}},
+			expected: "fd00:10:244::/63",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.network.ClusterCIDR()
+			if got != tc.expected {
+				t.Errorf("ClusterCIDR() got = %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestClusterNetworkSubnets(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		network          ClusterNetworkConfig
+		expectedPods     string
+		expectedServices string
+		expectedDual     bool
+	}{
+		{
+			name:             "single-stack without ip families",
+			network:          ClusterNetworkConfig{PodSubnet: "10.244.0.0/16", ServiceSubnet: "10.96.0.0/12", PodSubnetIPv6: "fd01::/48"},
+			expectedPods:     "10.244.0.0/16",
+			expectedServices: "10.96.0.0/12",
+		},
+		{
+			name: "dual-stack",
+			network: ClusterNetworkConfig{
+				IPFamilies:           []IPFamily{IPFamilyIPv4, IPFamilyIPv6},
+				PodSubnet:            "10.244.0.0/16",
+				AdditionalPodSubnets: []string{"10.245.0.0/16"},
+				ServiceSubnet:        "10.96.0.0/12",
+				PodSubnetIPv6:        "fd01::/48",
+				ServiceSubnetIPv6:    "fd02::/120",
+			},
+			expectedPods:     "10.244.0.0/15,fd01::/48",
+			expectedServices: "10.96.0.0/12,fd02::/120",
+			expectedDual:     true,
+		},
+		{
+			name: "dual-stack with primary ipv6",
+			network: ClusterNetworkConfig{
+				IPFamilies:        []IPFamily{IPFamilyIPv6, IPFamilyIPv4},
+				PodSubnet:         "10.244.0.0/16",
+				ServiceSubnet:     "10.96.0.0/12",
+				PodSubnetIPv6:     "fd01::/48",
+				ServiceSubnetIPv6: "fd02::/120",
+			},
+			expectedPods:     "fd01::/48,10.244.0.0/16",
+			expectedServices: "fd02::/120,10.96.0.0/12",
+			expectedDual:     true,
+		},
+		{
+			name: "single-stack ipv6",
+			network: ClusterNetworkConfig{
+				IPFamilies:        []IPFamily{IPFamilyIPv6},
+				PodSubnet:         "10.244.0.0/16",
+				ServiceSubnet:     "10.96.0.0/12",
+				PodSubnetIPv6:     "fd01::/48",
+				ServiceSubnetIPv6: "fd02::/120",
+			},
+			expectedPods:     "fd01::/48",
+			expectedServices: "fd02::/120",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.network.PodSubnets(); got != tc.expectedPods {
+				t.Errorf("PodSubnets() got = %v, expected %v", got, tc.expectedPods)
+			}
+			if got := tc.network.ServiceSubnets(); got != tc.expectedServices {
+				t.Errorf("ServiceSubnets() got = %v, expected %v", got, tc.expectedServices)
+			}
+			if got := tc.network.DualStack(); got != tc.expectedDual {
+				t.Errorf("DualStack() got = %v, expected %v", got, tc.expectedDual)
+			}
+		})
+	}
+}
+
+func TestSRIOVHostConfig(t *testing.T) {
+	t.Parallel()
+
+	sriov := &SRIOV{
+		Enable: true,
+		Hosts: []SRIOVHost{
+			{Address: "10.0.0.1"},
+			{Address: "worker-1"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		sriov    *SRIOV
+		host     HostConfig
+		expected string
+	}{
+		{
+			name:     "matched by private address",
+			sriov:    sriov,
+			host:     HostConfig{PublicAddress: "192.168.1.1", PrivateAddress: "10.0.0.1"},
+			expected: "10.0.0.1",
+		},
+		{
+			name:     "matched by hostname",
+			sriov:    sriov,
+			host:     HostConfig{PublicAddress: "192.168.1.2", Hostname: "worker-1"},
+			expected: "worker-1",
+		},
+		{
+			name:  "not matched",
+			sriov: sriov,
+			host:  HostConfig{PublicAddress: "192.168.1.3", PrivateAddress: "10.0.0.3"},
+		},
+		{
+			name: "disabled",
+			host: HostConfig{PrivateAddress: "10.0.0.1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.sriov.HostConfig(tc.host)
+			switch {
+			case tc.expected == "" && got != nil:
+				t.Errorf("HostConfig() got = %v, expected nil", got.Address)
+			case tc.expected != "" && (got == nil || got.Address != tc.expected):
+				t.Errorf("HostConfig() got = %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHostConfigAddresses(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		host              HostConfig
+		expectedAdvertise string
+		expectedNodeIP    string
+	}{
+		{
+			name:              "private address",
+			host:              HostConfig{PublicAddress: "192.168.1.1", PrivateAddress: "10.0.0.1"},
+			expectedAdvertise: "10.0.0.1",
+			expectedNodeIP:    "10.0.0.1",
+		},
+		{
+			name:              "public address fallback",
+			host:              HostConfig{PublicAddress: "192.168.1.1"},
+			expectedAdvertise: "192.168.1.1",
+			expectedNodeIP:    "192.168.1.1",
+		},
+		{
+			name: "overridden addresses",
+			host: HostConfig{
+				PublicAddress:  "192.168.1.1",
+				PrivateAddress: "10.0.0.1",
+				NetworkOverrides: &HostNetworkOverrides{
+					APIServerAdvertiseAddress: "10.1.0.1",
+					KubeletNodeIP:             "10.2.0.1",
+				},
+			},
+			expectedAdvertise: "10.1.0.1",
+			expectedNodeIP:    "10.2.0.1",
+		},
+		{
+			name: "only etcd overridden",
+			host: HostConfig{
+				PrivateAddress: "10.0.0.1",
+				NetworkOverrides: &HostNetworkOverrides{
+					EtcdListenPeerURLs: []string{"https://10.3.0.1:2380"},
+				},
+			},
+			expectedAdvertise: "10.0.0.1",
+			expectedNodeIP:    "10.0.0.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.host.AdvertiseAddress(); got != tc.expectedAdvertise {
+				t.Errorf("AdvertiseAddress() got = %v, expected %v", got, tc.expectedAdvertise)
+			}
+			if got := tc.host.NodeIP(); got != tc.expectedNodeIP {
+				t.Errorf("NodeIP() got = %v, expected %v", got, tc.expectedNodeIP)
+			}
+		})
+	}
+}
+
+func TestHostConfigNodeIPs(t *testing.T) {
+	t.Parallel()
+
+	host := HostConfig{
+		PublicAddress:  "192.168.1.1",
+		PrivateAddress: "10.0.0.1",
+		IPv6Addresses:  []string{"fd00::1", "fd00::2"},
+	}
+
+	testCases := []struct {
+		name     string
+		host     HostConfig
+		families []IPFamily
+		expected string
+	}{
+		{
+			name:     "without ip families",
+			host:     host,
+			expected: "10.0.0.1",
+		},
+		{
+			name:     "dual-stack",
+			host:     host,
+			families: []IPFamily{IPFamilyIPv4, IPFamilyIPv6},
+			expected: "10.0.0.1,fd00::1",
+		},
+		{
+			name:     "dual-stack with primary ipv6",
+			host:     host,
+			families: []IPFamily{IPFamilyIPv6, IPFamilyIPv4},
+			expected: "fd00::1,10.0.0.1",
+		},
+		{
+			name:     "ipv6 private address",
+			host:     HostConfig{PrivateAddress: "fd00::3"},
+			families: []IPFamily{IPFamilyIPv6},
+			expected: "fd00::3",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.host.NodeIPs(ClusterNetworkConfig{IPFamilies: tc.families})
+			if got != tc.expected {
+				t.Errorf("NodeIPs() got = %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestEtcdConfigExtraArgs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		config   EtcdConfig
+		expected string
+	}{
+		{
+			name:     "defaults",
+			config:   EtcdConfig{},
+			expected: "",
+		},
+		{
+			name: "all settings",
+			config: EtcdConfig{
+				QuotaBackendBytes: 8589934592,
+				SnapshotCount:     50000,
+				HeartbeatInterval: metav1.Duration{Duration: 250 * time.Millisecond},
+				ElectionTimeout:   metav1.Duration{Duration: 2500 * time.Millisecond},
+			},
+			expected: "election-timeout=2500,heartbeat-interval=250,quota-backend-bytes=8589934592,snapshot-count=50000",
+		},
+		{
+			name: "only heartbeat interval",
+			config: EtcdConfig{
+				HeartbeatInterval: metav1.Duration{Duration: time.Second},
+			},
+			expected: "heartbeat-interval=1000",
+		},
+		{
+			name: "cipher suites",
+			config: EtcdConfig{
+				TLS: &EtcdTLSConfig{
+					CipherSuites:   []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+					ServerCertSANs: []string{"etcd.example.com"},
+				},
+			},
+			expected: "cipher-suites=TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := FormatExtraArgs(tc.config.ExtraArgs()); got != tc.expected {
+				t.Errorf("ExtraArgs() got = %q, expected %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNodeInsecureRegistries(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		cluster  KubeOneCluster
+		expected []string
+	}{
+		{
+			name:     "none",
+			cluster:  KubeOneCluster{},
+			expected: []string{},
+		},
+		{
+			name: "insecure registry",
+			cluster: KubeOneCluster{
+				RegistryConfiguration: &RegistryConfiguration{OverwriteRegistry: "127.0.0.1:5000", InsecureRegistry: true},
+			},
+			expected: []string{"127.0.0.1:5000"},
+		},
+		{
+			name: "insecure registry and containerd mirrors",
+			cluster: KubeOneCluster{
+				RegistryConfiguration: &RegistryConfiguration{OverwriteRegistry: "127.0.0.1:5000", InsecureRegistry: true},
+				ContainerRuntime: ContainerRuntimeConfig{
+					Containerd: &ContainerRuntimeContainerd{
+						Registries: map[string]RegistryMirror{
+							"docker.io": {
+								Mirrors: []string{"https://mirror.example.com"},
+							},
+							"quay.io": {
+								Mirrors:            []string{"https://10.0.0.1:5000", "http://10.0.0.2"},
+								InsecureSkipVerify: true,
+							},
+						},
+					},
+				},
+			},
+			expected: []string{"10.0.0.1:5000", "10.0.0.2", "127.0.0.1:5000"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.cluster.NodeInsecureRegistries(); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("NodeInsecureRegistries() got = %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHostKubeletConfigFlags(t *testing.T) {
+	t.Parallel()
+
+	maxPods := int32(110)
+	hostMaxPods := int32(250)
+
+	testCases := []struct {
+		name     string
+		cluster  KubeOneCluster
+		host     HostConfig
+		expected map[string]string
+	}{
+		{
+			name:     "not configured",
+			cluster:  KubeOneCluster{},
+			host:     HostConfig{},
+			expected: map[string]string{},
+		},
+		{
+			name: "cluster-wide config",
+			cluster: KubeOneCluster{
+				KubeletConfig: &KubeletConfig{
+					MaxPods:        &maxPods,
+					SystemReserved: map[string]string{"memory": "500Mi", "cpu": "100m"},
+					EvictionHard:   map[string]string{"memory.available": "100Mi"},
+				},
+			},
+			host: HostConfig{},
+			expected: map[string]string{
+				"max-pods":        "110",
+				"system-reserved": "cpu=100m,memory=500Mi",
+				"eviction-hard":   "memory.available<100Mi",
+			},
+		},
+		{
+			name: "host overrides",
+			cluster: KubeOneCluster{
+				KubeletConfig: &KubeletConfig{
+					MaxPods:        &maxPods,
+					SystemReserved: map[string]string{"memory": "500Mi", "cpu": "100m"},
+				},
+			},
+			host: HostConfig{
+				KubeletConfig: &KubeletConfig{
+					MaxPods:        &hostMaxPods,
+					SystemReserved: map[string]string{"memory": "1Gi"},
+					KubeReserved:   map[string]string{"cpu": "200m"},
+					CgroupDriver:   "systemd",
+				},
+			},
+			expected: map[string]string{
+				"max-pods":        "250",
+				"system-reserved": "cpu=100m,memory=1Gi",
+				"kube-reserved":   "cpu=200m",
+				"cgroup-driver":   "systemd",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.cluster.HostKubeletConfig(tc.host).Flags(); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("HostKubeletConfig().Flags() got = %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -305,3 +305,8 @@ func Convert_kubeone_Features_To_v1alpha1_Features(in *kubeoneapi.Features, out
 func Convert_kubeone_Addons_To_v1alpha1_Addons(in *kubeoneapi.Addons, out *Addons, conv conversion.Scope) error {
 	return autoConvert_kubeone_Addons_To_v1alpha1_Addons(in, out, conv)
 }
+
+func Convert_kubeone_StaticAuditLogConfig_To_v1alpha1_StaticAuditLogConfig(in *kubeoneapi.StaticAuditLogConfig, out *StaticAuditLogConfig, s conversion.Scope) error {
+	// The DisableLogBackend and Webhook fields are not available in the v1alpha1 API.
+	return autoConvert_kubeone_StaticAuditLogConfig_To_v1alpha1_StaticAuditLogConfig(in, out, s)
+}
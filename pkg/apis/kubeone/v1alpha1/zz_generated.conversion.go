@@ -182,11 +182,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.StaticAuditLogConfig)(nil), (*StaticAuditLogConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_StaticAuditLogConfig_To_v1alpha1_StaticAuditLogConfig(a.(*kubeone.StaticAuditLogConfig), b.(*StaticAuditLogConfig), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*SystemPackages)(nil), (*kubeone.SystemPackages)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SystemPackages_To_kubeone_SystemPackages(a.(*SystemPackages), b.(*kubeone.SystemPackages), scope)
 	}); err != nil {
@@ -247,6 +242,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*kubeone.StaticAuditLogConfig)(nil), (*StaticAuditLogConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_StaticAuditLogConfig_To_v1alpha1_StaticAuditLogConfig(a.(*kubeone.StaticAuditLogConfig), b.(*StaticAuditLogConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*CNI)(nil), (*kubeone.CNI)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_CNI_To_kubeone_CNI(a.(*CNI), b.(*kubeone.CNI), scope)
 	}); err != nil {
@@ -316,6 +316,7 @@ func Convert_v1alpha1_Addons_To_kubeone_Addons(in *Addons, out *kubeone.Addons,
 func autoConvert_kubeone_Addons_To_v1alpha1_Addons(in *kubeone.Addons, out *Addons, s conversion.Scope) error {
 	out.Enable = in.Enable
 	out.Path = in.Path
+	// WARNING: in.Sources requires manual conversion: does not exist in peer-type
 	// WARNING: in.GlobalParams requires manual conversion: does not exist in peer-type
 	// WARNING: in.Addons requires manual conversion: does not exist in peer-type
 	return nil
@@ -330,6 +331,8 @@ func autoConvert_v1alpha1_CNI_To_kubeone_CNI(in *CNI, out *kubeone.CNI, s conver
 func autoConvert_kubeone_CNI_To_v1alpha1_CNI(in *kubeone.CNI, out *CNI, s conversion.Scope) error {
 	// WARNING: in.Canal requires manual conversion: does not exist in peer-type
 	// WARNING: in.WeaveNet requires manual conversion: does not exist in peer-type
+	// WARNING: in.Cilium requires manual conversion: does not exist in peer-type
+	// WARNING: in.Calico requires manual conversion: does not exist in peer-type
 	// WARNING: in.External requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -350,9 +353,12 @@ func autoConvert_kubeone_CloudProviderSpec_To_v1alpha1_CloudProviderSpec(in *kub
 	// WARNING: in.DigitalOcean requires manual conversion: does not exist in peer-type
 	// WARNING: in.GCE requires manual conversion: does not exist in peer-type
 	// WARNING: in.Hetzner requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeVirt requires manual conversion: does not exist in peer-type
+	// WARNING: in.Nutanix requires manual conversion: does not exist in peer-type
 	// WARNING: in.Openstack requires manual conversion: does not exist in peer-type
 	// WARNING: in.Packet requires manual conversion: does not exist in peer-type
 	// WARNING: in.Vsphere requires manual conversion: does not exist in peer-type
+	// WARNING: in.VMwareCloudDirector requires manual conversion: does not exist in peer-type
 	// WARNING: in.None requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -377,7 +383,11 @@ func autoConvert_v1alpha1_ClusterNetworkConfig_To_kubeone_ClusterNetworkConfig(i
 
 func autoConvert_kubeone_ClusterNetworkConfig_To_v1alpha1_ClusterNetworkConfig(in *kubeone.ClusterNetworkConfig, out *ClusterNetworkConfig, s conversion.Scope) error {
 	out.PodSubnet = in.PodSubnet
+	// WARNING: in.AdditionalPodSubnets requires manual conversion: does not exist in peer-type
 	out.ServiceSubnet = in.ServiceSubnet
+	// WARNING: in.IPFamilies requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnetIPv6 requires manual conversion: does not exist in peer-type
+	// WARNING: in.ServiceSubnetIPv6 requires manual conversion: does not exist in peer-type
 	out.ServiceDomainName = in.ServiceDomainName
 	out.NodePortRange = in.NodePortRange
 	if in.CNI != nil {
@@ -390,6 +400,7 @@ func autoConvert_kubeone_ClusterNetworkConfig_To_v1alpha1_ClusterNetworkConfig(i
 		out.CNI = nil
 	}
 	// WARNING: in.KubeProxy requires manual conversion: does not exist in peer-type
+	// WARNING: in.CoreDNS requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -437,7 +448,15 @@ func autoConvert_v1alpha1_Features_To_kubeone_Features(in *Features, out *kubeon
 	out.PodNodeSelector = (*kubeone.PodNodeSelector)(unsafe.Pointer(in.PodNodeSelector))
 	out.PodPresets = (*kubeone.PodPresets)(unsafe.Pointer(in.PodPresets))
 	out.PodSecurityPolicy = (*kubeone.PodSecurityPolicy)(unsafe.Pointer(in.PodSecurityPolicy))
-	out.StaticAuditLog = (*kubeone.StaticAuditLog)(unsafe.Pointer(in.StaticAuditLog))
+	if in.StaticAuditLog != nil {
+		in, out := &in.StaticAuditLog, &out.StaticAuditLog
+		*out = new(kubeone.StaticAuditLog)
+		if err := Convert_v1alpha1_StaticAuditLog_To_kubeone_StaticAuditLog(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StaticAuditLog = nil
+	}
 	out.DynamicAuditLog = (*kubeone.DynamicAuditLog)(unsafe.Pointer(in.DynamicAuditLog))
 	out.MetricsServer = (*kubeone.MetricsServer)(unsafe.Pointer(in.MetricsServer))
 	out.OpenIDConnect = (*kubeone.OpenIDConnect)(unsafe.Pointer(in.OpenIDConnect))
@@ -453,11 +472,35 @@ func autoConvert_kubeone_Features_To_v1alpha1_Features(in *kubeone.Features, out
 	out.PodNodeSelector = (*PodNodeSelector)(unsafe.Pointer(in.PodNodeSelector))
 	out.PodPresets = (*PodPresets)(unsafe.Pointer(in.PodPresets))
 	out.PodSecurityPolicy = (*PodSecurityPolicy)(unsafe.Pointer(in.PodSecurityPolicy))
-	out.StaticAuditLog = (*StaticAuditLog)(unsafe.Pointer(in.StaticAuditLog))
+	// WARNING: in.PodSecurityAdmission requires manual conversion: does not exist in peer-type
+	if in.StaticAuditLog != nil {
+		in, out := &in.StaticAuditLog, &out.StaticAuditLog
+		*out = new(StaticAuditLog)
+		if err := Convert_kubeone_StaticAuditLog_To_v1alpha1_StaticAuditLog(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StaticAuditLog = nil
+	}
 	out.DynamicAuditLog = (*DynamicAuditLog)(unsafe.Pointer(in.DynamicAuditLog))
 	out.MetricsServer = (*MetricsServer)(unsafe.Pointer(in.MetricsServer))
+	// WARNING: in.NodeLocalDNS requires manual conversion: does not exist in peer-type
 	out.OpenIDConnect = (*OpenIDConnect)(unsafe.Pointer(in.OpenIDConnect))
 	// WARNING: in.EncryptionProviders requires manual conversion: does not exist in peer-type
+	// WARNING: in.FIPS requires manual conversion: does not exist in peer-type
+	// WARNING: in.SELinux requires manual conversion: does not exist in peer-type
+	// WARNING: in.AppArmor requires manual conversion: does not exist in peer-type
+	// WARNING: in.SeccompDefault requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapRBAC requires manual conversion: does not exist in peer-type
+	// WARNING: in.SRIOV requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeVIP requires manual conversion: does not exist in peer-type
+	// WARNING: in.Konnectivity requires manual conversion: does not exist in peer-type
+	// WARNING: in.EventRateLimit requires manual conversion: does not exist in peer-type
+	// WARNING: in.AlwaysPullImages requires manual conversion: does not exist in peer-type
+	// WARNING: in.DenyServiceExternalIPs requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeRestriction requires manual conversion: does not exist in peer-type
+	// WARNING: in.Gatekeeper requires manual conversion: does not exist in peer-type
+	// WARNING: in.FeatureGates requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -483,16 +526,23 @@ func autoConvert_kubeone_HostConfig_To_v1alpha1_HostConfig(in *kubeone.HostConfi
 	out.ID = in.ID
 	out.PublicAddress = in.PublicAddress
 	out.PrivateAddress = in.PrivateAddress
+	// WARNING: in.IPv6Addresses requires manual conversion: does not exist in peer-type
 	out.SSHPort = in.SSHPort
 	out.SSHUsername = in.SSHUsername
 	out.SSHPrivateKeyFile = in.SSHPrivateKeyFile
 	out.SSHAgentSocket = in.SSHAgentSocket
+	// WARNING: in.SSHCertFile requires manual conversion: does not exist in peer-type
 	out.Bastion = in.Bastion
 	out.BastionPort = in.BastionPort
 	out.BastionUser = in.BastionUser
+	// WARNING: in.Bastions requires manual conversion: does not exist in peer-type
 	out.Hostname = in.Hostname
 	out.IsLeader = in.IsLeader
 	// WARNING: in.Taints requires manual conversion: does not exist in peer-type
+	// WARNING: in.Env requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkOverrides requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeletConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.Pool requires manual conversion: does not exist in peer-type
 	out.OperatingSystem = string(in.OperatingSystem)
 	return nil
 }
@@ -546,6 +596,10 @@ func autoConvert_v1alpha1_KubeOneCluster_To_kubeone_KubeOneCluster(in *KubeOneCl
 func autoConvert_kubeone_KubeOneCluster_To_v1alpha1_KubeOneCluster(in *kubeone.KubeOneCluster, out *KubeOneCluster, s conversion.Scope) error {
 	out.Name = in.Name
 	// WARNING: in.ControlPlane requires manual conversion: does not exist in peer-type
+	// WARNING: in.Etcd requires manual conversion: does not exist in peer-type
+	// WARNING: in.ExternalEtcd requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneComponents requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeletConfig requires manual conversion: does not exist in peer-type
 	if err := Convert_kubeone_APIEndpoint_To_v1alpha1_APIEndpoint(&in.APIEndpoint, &out.APIEndpoint, s); err != nil {
 		return err
 	}
@@ -573,6 +627,7 @@ func autoConvert_kubeone_KubeOneCluster_To_v1alpha1_KubeOneCluster(in *kubeone.K
 	} else {
 		out.MachineController = nil
 	}
+	// WARNING: in.OperatingSystemManager requires manual conversion: does not exist in peer-type
 	// WARNING: in.CABundle requires manual conversion: does not exist in peer-type
 	if err := Convert_kubeone_Features_To_v1alpha1_Features(&in.Features, &out.Features, s); err != nil {
 		return err
@@ -589,6 +644,12 @@ func autoConvert_kubeone_KubeOneCluster_To_v1alpha1_KubeOneCluster(in *kubeone.K
 	out.SystemPackages = (*SystemPackages)(unsafe.Pointer(in.SystemPackages))
 	// WARNING: in.AssetConfiguration requires manual conversion: does not exist in peer-type
 	// WARNING: in.RegistryConfiguration requires manual conversion: does not exist in peer-type
+	// WARNING: in.StateBackend requires manual conversion: does not exist in peer-type
+	// WARNING: in.Backups requires manual conversion: does not exist in peer-type
+	// WARNING: in.Hooks requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaxParallel requires manual conversion: does not exist in peer-type
+	// WARNING: in.CommandRetries requires manual conversion: does not exist in peer-type
+	// WARNING: in.Notifications requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -876,14 +937,11 @@ func autoConvert_kubeone_StaticAuditLogConfig_To_v1alpha1_StaticAuditLogConfig(i
 	out.LogMaxAge = in.LogMaxAge
 	out.LogMaxBackup = in.LogMaxBackup
 	out.LogMaxSize = in.LogMaxSize
+	// WARNING: in.DisableLogBackend requires manual conversion: does not exist in peer-type
+	// WARNING: in.Webhook requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_kubeone_StaticAuditLogConfig_To_v1alpha1_StaticAuditLogConfig is an autogenerated conversion function.
-func Convert_kubeone_StaticAuditLogConfig_To_v1alpha1_StaticAuditLogConfig(in *kubeone.StaticAuditLogConfig, out *StaticAuditLogConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_StaticAuditLogConfig_To_v1alpha1_StaticAuditLogConfig(in, out, s)
-}
-
 func autoConvert_v1alpha1_SystemPackages_To_kubeone_SystemPackages(in *SystemPackages, out *kubeone.SystemPackages, s conversion.Scope) error {
 	out.ConfigureRepositories = in.ConfigureRepositories
 	return nil
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 
@@ -30,6 +31,10 @@ const (
 	DefaultPodSubnet = "10.244.0.0/16"
 	// DefaultServiceSubnet defines the default subnet used by services
 	DefaultServiceSubnet = "10.96.0.0/12"
+	// DefaultPodSubnetIPv6 defines the default subnet used by pods of the IPv6 family
+	DefaultPodSubnetIPv6 = "fd01::/48"
+	// DefaultServiceSubnetIPv6 defines the default subnet used by services of the IPv6 family
+	DefaultServiceSubnetIPv6 = "fd02::/120"
 	// DefaultServiceDNS defines the default DNS domain name used by services
 	DefaultServiceDNS = "cluster.local"
 	// DefaultNodePortRange defines the default NodePort range
@@ -38,6 +43,8 @@ const (
 	DefaultStaticNoProxy = "127.0.0.1/8,localhost"
 	// DefaultVXLanMTU defines default VXLAN MTU for Canal CNI
 	DefaultCanalMTU = 1450
+	// DefaultAssetCachePort defines the default port of the asset cache
+	DefaultAssetCachePort = 8008
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -48,6 +55,7 @@ func SetDefaults_KubeOneCluster(obj *KubeOneCluster) {
 	SetDefaults_Hosts(obj)
 	SetDefaults_APIEndpoints(obj)
 	SetDefaults_Versions(obj)
+	SetDefaults_CloudProvider(obj)
 	SetDefaults_ContainerRuntime(obj)
 	SetDefaults_ClusterNetwork(obj)
 	SetDefaults_Proxy(obj)
@@ -56,6 +64,7 @@ func SetDefaults_KubeOneCluster(obj *KubeOneCluster) {
 	SetDefaults_AssetConfiguration(obj)
 	SetDefaults_Features(obj)
 	SetDefaults_Addons(obj)
+	SetDefaults_Notifications(obj)
 }
 
 func SetDefaults_Hosts(obj *KubeOneCluster) {
@@ -95,11 +104,29 @@ func SetDefaults_Hosts(obj *KubeOneCluster) {
 		obj.StaticWorkers.Hosts[idx].ID = idx + len(obj.ControlPlane.Hosts)
 		defaultHostConfig(&obj.StaticWorkers.Hosts[idx])
 		if obj.StaticWorkers.Hosts[idx].Taints == nil {
-			obj.StaticWorkers.Hosts[idx].Taints = []corev1.Taint{}
+			obj.StaticWorkers.Hosts[idx].Taints = staticWorkerPoolTaints(obj.StaticWorkers, obj.StaticWorkers.Hosts[idx].Pool)
 		}
 	}
 }
 
+// staticWorkerPoolTaints returns a copy of taints of the given static workers
+// pool, so hosts in the pool are tainted unless they have taints configured
+func staticWorkerPoolTaints(staticWorkers StaticWorkersConfig, poolName string) []corev1.Taint {
+	taints := []corev1.Taint{}
+
+	if poolName == "" {
+		return taints
+	}
+
+	for _, pool := range staticWorkers.Pools {
+		if pool.Name == poolName {
+			taints = append(taints, pool.Taints...)
+		}
+	}
+
+	return taints
+}
+
 func SetDefaults_APIEndpoints(obj *KubeOneCluster) {
 	// If no API endpoint is provided, assume the public address is an endpoint
 	if len(obj.APIEndpoint.Host) == 0 {
@@ -123,6 +150,8 @@ func SetDefaults_ContainerRuntime(obj *KubeOneCluster) {
 		return
 	case obj.ContainerRuntime.Containerd != nil:
 		return
+	case obj.ContainerRuntime.CRIO != nil:
+		return
 	}
 
 	actualVer, err := semver.NewVersion(obj.Versions.Kubernetes)
@@ -139,6 +168,12 @@ func SetDefaults_ContainerRuntime(obj *KubeOneCluster) {
 func SetDefaults_ClusterNetwork(obj *KubeOneCluster) {
 	obj.ClusterNetwork.PodSubnet = defaults(obj.ClusterNetwork.PodSubnet, DefaultPodSubnet)
 	obj.ClusterNetwork.ServiceSubnet = defaults(obj.ClusterNetwork.ServiceSubnet, DefaultServiceSubnet)
+	for _, family := range obj.ClusterNetwork.IPFamilies {
+		if family == IPFamilyIPv6 {
+			obj.ClusterNetwork.PodSubnetIPv6 = defaults(obj.ClusterNetwork.PodSubnetIPv6, DefaultPodSubnetIPv6)
+			obj.ClusterNetwork.ServiceSubnetIPv6 = defaults(obj.ClusterNetwork.ServiceSubnetIPv6, DefaultServiceSubnetIPv6)
+		}
+	}
 	obj.ClusterNetwork.ServiceDomainName = defaults(obj.ClusterNetwork.ServiceDomainName, DefaultServiceDNS)
 	obj.ClusterNetwork.NodePortRange = defaults(obj.ClusterNetwork.NodePortRange, DefaultNodePortRange)
 
@@ -162,6 +197,31 @@ func SetDefaults_ClusterNetwork(obj *KubeOneCluster) {
 	if obj.ClusterNetwork.CNI.Canal != nil && obj.ClusterNetwork.CNI.Canal.MTU == 0 {
 		obj.ClusterNetwork.CNI.Canal.MTU = defaultCanal.MTU
 	}
+	if calico := obj.ClusterNetwork.CNI.Calico; calico != nil {
+		calico.Mode = CalicoMode(defaults(string(calico.Mode), string(CalicoModeVXLAN)))
+	}
+	if cilium := obj.ClusterNetwork.CNI.Cilium; cilium != nil {
+		cilium.KubeProxyReplacement = CiliumKubeProxyReplacement(defaults(string(cilium.KubeProxyReplacement), string(CiliumKubeProxyReplacementDisabled)))
+		if cilium.Hubble != nil && cilium.Hubble.UI {
+			cilium.Hubble.UIServiceType = corev1.ServiceType(defaults(string(cilium.Hubble.UIServiceType), string(corev1.ServiceTypeClusterIP)))
+		}
+	}
+
+	if obj.ClusterNetwork.CoreDNS != nil {
+		for i := range obj.ClusterNetwork.CoreDNS.Rewrites {
+			rule := &obj.ClusterNetwork.CoreDNS.Rewrites[i]
+			rule.Field = defaults(rule.Field, "name")
+			if rule.Field == "name" {
+				rule.Match = defaults(rule.Match, "exact")
+			}
+		}
+	}
+}
+
+func SetDefaults_CloudProvider(obj *KubeOneCluster) {
+	if obj.CloudProvider.Packet != nil && obj.CloudProvider.Packet.BGP != nil {
+		obj.CloudProvider.Packet.BGP.LocalASN = defaulti(obj.CloudProvider.Packet.BGP.LocalASN, 65000)
+	}
 }
 
 func SetDefaults_Proxy(obj *KubeOneCluster) {
@@ -174,6 +234,12 @@ func SetDefaults_Proxy(obj *KubeOneCluster) {
 		obj.ClusterNetwork.PodSubnet,
 		obj.ClusterNetwork.ServiceSubnet,
 	}
+	if obj.ClusterNetwork.PodSubnetIPv6 != "" {
+		noproxy = append(noproxy, obj.ClusterNetwork.PodSubnetIPv6)
+	}
+	if obj.ClusterNetwork.ServiceSubnetIPv6 != "" {
+		noproxy = append(noproxy, obj.ClusterNetwork.ServiceSubnetIPv6)
+	}
 	if obj.Proxy.NoProxy != "" {
 		noproxy = append(noproxy, obj.Proxy.NoProxy)
 	}
@@ -197,6 +263,10 @@ func SetDefaults_SystemPackages(obj *KubeOneCluster) {
 }
 
 func SetDefaults_AssetConfiguration(obj *KubeOneCluster) {
+	if obj.AssetConfiguration.Cache != nil && obj.AssetConfiguration.Cache.Port == 0 {
+		obj.AssetConfiguration.Cache.Port = DefaultAssetCachePort
+	}
+
 	if obj.RegistryConfiguration == nil || obj.RegistryConfiguration.OverwriteRegistry == "" {
 		// We default AssetConfiguration only if RegistryConfiguration.OverwriteRegistry
 		// is used
@@ -227,12 +297,127 @@ func SetDefaults_Features(obj *KubeOneCluster) {
 			Enable: true,
 		}
 	}
+	if obj.Features.NodeLocalDNS == nil {
+		obj.Features.NodeLocalDNS = &NodeLocalDNS{
+			Enable: true,
+		}
+	}
+	if obj.Features.NodeLocalDNS.Enable {
+		defaultNodeLocalDNS(&obj.Features.NodeLocalDNS.Config)
+	}
 	if obj.Features.StaticAuditLog != nil && obj.Features.StaticAuditLog.Enable {
 		defaultStaticAuditLogConfig(&obj.Features.StaticAuditLog.Config)
 	}
 	if obj.Features.OpenIDConnect != nil && obj.Features.OpenIDConnect.Enable {
 		defaultOpenIDConnect(&obj.Features.OpenIDConnect.Config)
 	}
+	if obj.Features.SRIOV != nil && obj.Features.SRIOV.Enable {
+		defaultSRIOV(obj.Features.SRIOV)
+	}
+	if obj.Features.EventRateLimit != nil && obj.Features.EventRateLimit.Enable {
+		defaultEventRateLimit(&obj.Features.EventRateLimit.Config)
+	}
+	if obj.Features.EncryptionProviders != nil && obj.Features.EncryptionProviders.KMS != nil {
+		defaultEncryptionProvidersKMS(obj.Features.EncryptionProviders.KMS)
+	}
+	if obj.Features.Gatekeeper != nil && obj.Features.Gatekeeper.Enable {
+		defaultGatekeeper(&obj.Features.Gatekeeper.Config)
+	}
+	if obj.Features.Konnectivity != nil && obj.Features.Konnectivity.Enable {
+		defaultKonnectivity(&obj.Features.Konnectivity.Config, obj.APIEndpoint)
+	}
+	if obj.Features.PodSecurityAdmission != nil && obj.Features.PodSecurityAdmission.Enable {
+		defaultPodSecurityAdmission(&obj.Features.PodSecurityAdmission.Config)
+
+		// PodSecurity admission plugin is alpha in kubernetes 1.22
+		actualVer, err := semver.NewVersion(obj.Versions.Kubernetes)
+		kube122Condition, _ := semver.NewConstraint("1.22.x")
+		if err == nil && kube122Condition.Check(actualVer) {
+			if obj.Features.FeatureGates == nil {
+				obj.Features.FeatureGates = map[string]bool{}
+			}
+			if _, ok := obj.Features.FeatureGates["PodSecurity"]; !ok {
+				obj.Features.FeatureGates["PodSecurity"] = true
+			}
+		}
+	}
+}
+
+func defaultNodeLocalDNS(obj *NodeLocalDNSConfig) {
+	obj.VirtualIP = defaults(obj.VirtualIP, "169.254.20.10")
+	obj.VirtualIPv6 = defaults(obj.VirtualIPv6, "fd00::10")
+	if obj.ForceTCP == nil {
+		forceTCP := true
+		obj.ForceTCP = &forceTCP
+	}
+}
+
+func defaultGatekeeper(obj *GatekeeperConfig) {
+	obj.AuditInterval = defaulti(obj.AuditInterval, 60)
+}
+
+func defaultKonnectivity(obj *KonnectivityConfig, apiEndpoint APIEndpoint) {
+	obj.ServerAddress = defaults(obj.ServerAddress, apiEndpoint.Host)
+	obj.AgentPort = defaulti(obj.AgentPort, 8132)
+}
+
+func defaultPodSecurityAdmission(obj *PodSecurityAdmissionConfig) {
+	obj.Enforce = defaults(obj.Enforce, "privileged")
+	obj.EnforceVersion = defaults(obj.EnforceVersion, "latest")
+	obj.Audit = defaults(obj.Audit, "privileged")
+	obj.AuditVersion = defaults(obj.AuditVersion, "latest")
+	obj.Warn = defaults(obj.Warn, "privileged")
+	obj.WarnVersion = defaults(obj.WarnVersion, "latest")
+	if len(obj.Exemptions.Namespaces) == 0 {
+		obj.Exemptions.Namespaces = []string{"kube-system"}
+	}
+}
+
+func defaultEncryptionProvidersKMS(obj *EncryptionProvidersKMS) {
+	obj.Endpoint = defaults(obj.Endpoint, "/var/run/kmsplugin/socket.sock")
+	obj.CacheSize = defaulti(obj.CacheSize, 1000)
+	if obj.Timeout.Duration == 0 {
+		obj.Timeout.Duration = 3 * time.Second
+	}
+}
+
+func defaultEventRateLimit(obj *EventRateLimitConfig) {
+	if len(obj.Limits) > 0 {
+		return
+	}
+
+	obj.Limits = []EventRateLimitLimit{
+		{
+			Type:  EventRateLimitTypeServer,
+			QPS:   50,
+			Burst: 100,
+		},
+		{
+			Type:      EventRateLimitTypeNamespace,
+			QPS:       50,
+			Burst:     100,
+			CacheSize: 2000,
+		},
+	}
+}
+
+func defaultSRIOV(obj *SRIOV) {
+	if len(obj.KernelParameters) == 0 {
+		obj.KernelParameters = []string{"intel_iommu=on", "iommu=pt"}
+	}
+	for i := range obj.Hosts {
+		for j := range obj.Hosts[i].PhysicalFunctions {
+			pf := &obj.Hosts[i].PhysicalFunctions[j]
+			pf.ResourceName = defaults(pf.ResourceName, "sriov_"+strings.Map(func(r rune) rune {
+				// the device plugin allows only alphanumeric characters and
+				// underscores in resource names
+				if r == '-' || r == '.' {
+					return '_'
+				}
+				return r
+			}, pf.Interface))
+		}
+	}
 }
 
 func defaultOpenIDConnect(config *OpenIDConnectConfig) {
@@ -271,6 +456,18 @@ func defaultHostConfig(obj *HostConfig) {
 	obj.SSHPort = defaulti(obj.SSHPort, 22)
 	obj.BastionPort = defaulti(obj.BastionPort, 22)
 	obj.BastionUser = defaults(obj.BastionUser, obj.SSHUsername)
+	for i := range obj.Bastions {
+		obj.Bastions[i].Port = defaulti(obj.Bastions[i].Port, 22)
+		obj.Bastions[i].User = defaults(obj.Bastions[i].User, obj.SSHUsername)
+	}
+}
+
+func SetDefaults_Notifications(obj *KubeOneCluster) {
+	for i := range obj.Notifications {
+		if obj.Notifications[i].Type == "" {
+			obj.Notifications[i].Type = NotificationTypeWebhook
+		}
+	}
 }
 
 func defaults(input, defaultValue string) string {
@@ -34,12 +34,18 @@ func SetCloudProvider(cp *CloudProviderSpec, name string) error {
 		cp.GCE = &GCESpec{}
 	case "hetzner":
 		cp.Hetzner = &HetznerSpec{}
+	case "kubevirt":
+		cp.KubeVirt = &KubeVirtSpec{}
+	case "nutanix":
+		cp.Nutanix = &NutanixSpec{}
 	case "openstack":
 		cp.Openstack = &OpenstackSpec{}
 	case "packet":
 		cp.Packet = &PacketSpec{}
 	case "vsphere":
 		cp.Vsphere = &VsphereSpec{}
+	case "vmware-cloud-director":
+		cp.VMwareCloudDirector = &VMwareCloudDirectorSpec{}
 	case "none":
 		cp.None = &NoneSpec{}
 	default:
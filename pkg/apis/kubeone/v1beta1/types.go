@@ -33,6 +33,18 @@ type KubeOneCluster struct {
 	Name string `json:"name"`
 	// ControlPlane describes the control plane nodes and how to access them.
 	ControlPlane ControlPlaneConfig `json:"controlPlane"`
+	// Etcd configures the etcd cluster running on the control plane nodes.
+	Etcd EtcdConfig `json:"etcd,omitempty"`
+	// ExternalEtcd configures the control plane to use the etcd cluster
+	// managed outside of KubeOne instead of the etcd members stacked on the
+	// control plane nodes. Can't be changed once the cluster is provisioned.
+	ExternalEtcd *ExternalEtcdConfig `json:"externalEtcd,omitempty"`
+	// ControlPlaneComponents configures the Kubernetes control plane
+	// components running on the control plane nodes.
+	ControlPlaneComponents *ControlPlaneComponents `json:"controlPlaneComponents,omitempty"`
+	// KubeletConfig configures kubelet on all control plane and static
+	// worker nodes. The settings can be overridden for the specific host.
+	KubeletConfig *KubeletConfig `json:"kubeletConfig,omitempty"`
 	// APIEndpoint are pairs of address and port used to communicate with the Kubernetes API.
 	APIEndpoint APIEndpoint `json:"apiEndpoint"`
 	// CloudProvider configures the cloud provider specific features.
@@ -51,6 +63,8 @@ type KubeOneCluster struct {
 	DynamicWorkers []DynamicWorkerConfig `json:"dynamicWorkers,omitempty"`
 	// MachineController configures the Kubermatic machine-controller component.
 	MachineController *MachineControllerConfig `json:"machineController,omitempty"`
+	// OperatingSystemManager configures the Kubermatic operating-system-manager component.
+	OperatingSystemManager *OSMConfig `json:"operatingSystemManager,omitempty"`
 	// CABundle PEM encoded global CA
 	CABundle string `json:"caBundle,omitempty"`
 	// Features enables and configures additional cluster features.
@@ -63,19 +77,191 @@ type KubeOneCluster struct {
 	AssetConfiguration AssetConfiguration `json:"assetConfiguration,omitempty"`
 	// RegistryConfiguration configures how Docker images are pulled from an image registry
 	RegistryConfiguration *RegistryConfiguration `json:"registryConfiguration,omitempty"`
+	// StateBackend configures the remote storage where KubeOne keeps the
+	// rendered configuration files, the PKI backup and the apply checkpoints
+	StateBackend *StateBackend `json:"stateBackend,omitempty"`
+	// Backups configures the etcd snapshots taken by the
+	// `kubeone backup etcd` command
+	Backups *Backups `json:"backups,omitempty"`
+	// Hooks are the local commands and webhooks run before and after the
+	// KubeOne operations
+	Hooks *Hooks `json:"hooks,omitempty"`
+	// MaxParallel is the maximum number of hosts the tasks run on
+	// concurrently, when the task is allowed to run on multiple hosts at once.
+	// The --max-parallel flag takes precedence over this field.
+	// Default value is 0, i.e. the tasks run on all hosts at once.
+	MaxParallel int `json:"maxParallel,omitempty"`
+	// CommandRetries configures retrying the commands run on the hosts
+	// failing with transient errors, such as dropped SSH connections or
+	// package manager lock contention.
+	// Default value is nil, i.e. the commands are not retried, only the
+	// whole tasks are.
+	CommandRetries *CommandRetryPolicy `json:"commandRetries,omitempty"`
+	// Notifications are the endpoints notified with the summary of the
+	// apply, upgrade and reset operations when they finish
+	Notifications []Notification `json:"notifications,omitempty"`
+}
+
+// CommandRetryPolicy configures retrying the commands run on the hosts over
+// SSH. Commands are retried if the SSH session couldn't be opened to start
+// them, or if they fail with one of the retryable errors. Other failures are
+// retried by retrying the whole task.
+type CommandRetryPolicy struct {
+	// Attempts is the maximum number of attempts to run the command. Setting
+	// it to 1 disables retrying the commands.
+	// Default value is 3.
+	Attempts int `json:"attempts,omitempty"`
+	// Backoff is the delay before the first retry, doubled before every next
+	// retry.
+	// Default value is 5s.
+	Backoff metav1.Duration `json:"backoff,omitempty"`
+	// RetryableErrors are the regular expressions matched against the error
+	// and the stderr of the failed command, leaving out the commands traced
+	// by the scripts. The whole script is run again, so only the errors
+	// failing the script before it changes anything should be matched, such
+	// as the apt, dpkg, yum and dnf lock errors.
+	// Default value is empty, i.e. only the commands that couldn't be started
+	// are retried.
+	RetryableErrors []string `json:"retryableErrors,omitempty"`
+	// TaskAttempts overrides the number of attempts for the commands run by
+	// the given tasks. Tasks are referred to by their names with spaces
+	// replaced by dashes, e.g. install-prerequisites.
+	TaskAttempts map[string]int `json:"taskAttempts,omitempty"`
+}
+
+// NotificationType is the type of the notified endpoint
+type NotificationType string
+
+const (
+	// NotificationTypeWebhook endpoints receive the summary of the operation
+	// as the JSON body of the POST request
+	NotificationTypeWebhook NotificationType = "webhook"
+	// NotificationTypeSlack endpoints are the Slack incoming webhooks
+	NotificationTypeSlack NotificationType = "slack"
+	// NotificationTypeTeams endpoints are the Microsoft Teams incoming webhooks
+	NotificationTypeTeams NotificationType = "teams"
+)
+
+// Notification is the endpoint notified with the summary of the operation,
+// including the operation, the cluster name, the duration, the result and
+// the failed tasks. Failing to notify the endpoint doesn't fail the
+// operation.
+type Notification struct {
+	// Name of the notification, used in the logs
+	Name string `json:"name,omitempty"`
+	// Type of the endpoint, one of: webhook, slack, teams.
+	// Default value is "webhook".
+	Type NotificationType `json:"type,omitempty"`
+	// URL of the endpoint. Must be the http or https URL. The value prefixed
+	// with "env:" is read from the environment variable, such as
+	// "env:SLACK_WEBHOOK_URL".
+	URL string `json:"url"`
+	// Headers are the additional HTTP headers of the request. Values prefixed
+	// with "env:" are read from the environment variables.
+	Headers map[string]string `json:"headers,omitempty"`
+	// OnlyOnFailure notifies the endpoint only if the operation fails
+	OnlyOnFailure bool `json:"onlyOnFailure,omitempty"`
+}
+
+// ControlPlaneComponents configures the Kubernetes control plane components
+type ControlPlaneComponents struct {
+	// APIServer configures the Kubernetes API server
+	APIServer *APIServerConfig `json:"apiServer,omitempty"`
+	// ControllerManager configures the Kubernetes controller manager
+	ControllerManager *ControlPlaneComponentConfig `json:"controllerManager,omitempty"`
+	// Scheduler configures the Kubernetes scheduler
+	Scheduler *ControlPlaneComponentConfig `json:"scheduler,omitempty"`
+}
+
+// ControlPlaneComponentConfig configures the Kubernetes controller manager or
+// the Kubernetes scheduler. The flags set by KubeOne take precedence over
+// the ExtraArgs.
+type ControlPlaneComponentConfig struct {
+	// ExtraArgs are the additional flags passed to the component, without
+	// the leading dashes. The feature gates must be configured using the
+	// FeatureGates field.
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+	// FeatureGates are the component feature gates
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// ExtraVolumes are the host paths mounted into the component, e.g. to
+	// provide the custom configuration files referenced in ExtraArgs
+	ExtraVolumes []ControlPlaneComponentVolume `json:"extraVolumes,omitempty"`
+}
+
+// ControlPlaneComponentVolume is the host path mounted into the control plane
+// component. The host path must exist on all control plane nodes.
+type ControlPlaneComponentVolume struct {
+	// Name of the volume
+	Name string `json:"name"`
+	// HostPath is the absolute path on the control plane nodes
+	HostPath string `json:"hostPath"`
+	// MountPath is the absolute path in the component container
+	MountPath string `json:"mountPath"`
+	// ReadOnly controls whether the volume is mounted as read-only
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// APIServerConfig configures the Kubernetes API server. The settings are
+// merged with the flags set by KubeOne, and the flags set by KubeOne take
+// precedence, except for the explicitly disabled admission plugins.
+type APIServerConfig struct {
+	// ExtraArgs are the additional flags passed to the API server, without
+	// the leading dashes. The admission plugins and the feature gates must
+	// be configured using the dedicated fields.
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+	// EnableAdmissionPlugins are the admission plugins enabled in addition
+	// to the admission plugins enabled by KubeOne
+	EnableAdmissionPlugins []string `json:"enableAdmissionPlugins,omitempty"`
+	// DisableAdmissionPlugins are the admission plugins disabled, including
+	// the admission plugins enabled by default
+	DisableAdmissionPlugins []string `json:"disableAdmissionPlugins,omitempty"`
+	// FeatureGates are the API server feature gates
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
 }
 
 // ContainerRuntimeConfig
 type ContainerRuntimeConfig struct {
 	Docker     *ContainerRuntimeDocker     `json:"docker,omitempty"`
 	Containerd *ContainerRuntimeContainerd `json:"containerd,omitempty"`
+	CRIO       *ContainerRuntimeCRIO       `json:"crio,omitempty"`
 }
 
 // ContainerRuntimeDocker defines docker container runtime
 type ContainerRuntimeDocker struct{}
 
 // ContainerRuntimeContainerd defines docker container runtime
-type ContainerRuntimeContainerd struct{}
+type ContainerRuntimeContainerd struct {
+	// Registries configures the mirrors, credentials and TLS verification
+	// used to pull images from the registries, keyed by the registry host,
+	// e.g. docker.io. Each registry is rendered to its own hosts.toml file,
+	// which requires containerd 1.5 or newer. Registries are not supported
+	// on Flatcar Linux. The worker nodes created by machine-controller get
+	// only the docker.io mirrors and the mirrors with disabled TLS
+	// verification as insecure registries.
+	Registries map[string]RegistryMirror `json:"registries,omitempty"`
+}
+
+// RegistryMirror configures how images are pulled from a registry
+type RegistryMirror struct {
+	// Mirrors are the endpoints, e.g. https://mirror.example.com, tried in
+	// the given order before falling back to the registry itself
+	Mirrors []string `json:"mirrors,omitempty"`
+	// Auth are the credentials used to authenticate to the mirrors
+	Auth *RegistryAuth `json:"auth,omitempty"`
+	// InsecureSkipVerify disables the verification of the TLS certificates
+	// presented by the mirrors
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// RegistryAuth are the basic authentication credentials of a registry mirror
+type RegistryAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// ContainerRuntimeCRIO defines CRI-O container runtime. The CRI-O minor
+// version follows the Kubernetes minor version.
+type ContainerRuntimeCRIO struct{}
 
 // OperatingSystemName defines the operating system used on instances
 type OperatingSystemName string
@@ -98,6 +284,9 @@ type HostConfig struct {
 	PublicAddress string `json:"publicAddress"`
 	// PrivateAddress is internal RFC-1918 IP address.
 	PrivateAddress string `json:"privateAddress"`
+	// IPv6Addresses are the IPv6 addresses of the host. The first one is
+	// reported as the IPv6 node IP in clusters with the IPv6 IP family.
+	IPv6Addresses []string `json:"ipv6Addresses,omitempty"`
 	// SSHPort is port to connect ssh to.
 	// Default value is 22.
 	SSHPort int `json:"sshPort,omitempty"`
@@ -110,6 +299,11 @@ type HostConfig struct {
 	// SSHAgentSocket path (or reference to the environment) to the SSH agent unix domain socket.
 	// Default vaulue is "env:SSH_AUTH_SOCK".
 	SSHAgentSocket string `json:"sshAgentSocket,omitempty"`
+	// SSHCertFile is path to the file with the OpenSSH certificate signing the public key of
+	// SSHPrivateKeyFile or of a key held by the SSH agent, such as the certificates issued by
+	// Vault or step-ca SSH CAs. The certificate is used together with the matching private key.
+	// Default value is "".
+	SSHCertFile string `json:"sshCertFile,omitempty"`
 	// Bastion is an IP or hostname of the bastion (or jump) host to connect to.
 	// Default value is "".
 	Bastion string `json:"bastion,omitempty"`
@@ -119,6 +313,10 @@ type HostConfig struct {
 	// BastionUser is system login name to use when connecting to bastion host.
 	// Default value is "root".
 	BastionUser string `json:"bastionUser,omitempty"`
+	// Bastions are the bastion (or jump) hosts to connect through, in order, starting with the
+	// host reachable from the machine running KubeOne. Mutually exclusive with Bastion.
+	// Default value is empty.
+	Bastions []BastionConfig `json:"bastions,omitempty"`
 	// Hostname is the hostname(1) of the host.
 	// Default value is populated at the runtime via running `hostname -f` command over ssh.
 	Hostname string `json:"hostname,omitempty"`
@@ -129,10 +327,86 @@ type HostConfig struct {
 	// control plane nodes.
 	// Explicitly empty (i.e. []corev1.Taint{}) means no taints will be applied (this is default for worker nodes).
 	Taints []corev1.Taint `json:"taints,omitempty"`
+	// Env is a map of environment variables exported for all scripts run on the host, such as proxy settings,
+	// HTTP mirrors or paths to vendor tooling. The variables are preserved for commands run using sudo.
+	// Default value is empty.
+	Env map[string]string `json:"env,omitempty"`
+	// NetworkOverrides overrides the addresses Kubernetes components advertise and listen on,
+	// for hosts with multiple network interfaces.
+	// Default value is nil, i.e. PrivateAddress (or PublicAddress if PrivateAddress is empty) is used.
+	NetworkOverrides *HostNetworkOverrides `json:"networkOverrides,omitempty"`
+	// KubeletConfig overrides the cluster-wide kubelet settings on the host.
+	// Default value is nil, i.e. the cluster-wide settings are used.
+	KubeletConfig *KubeletConfig `json:"kubeletConfig,omitempty"`
+	// Pool is the name of the static workers pool the host belongs to, see StaticWorkersConfig.Pools.
+	// Allowed only for static workers.
+	// Default value is "".
+	Pool string `json:"pool,omitempty"`
 	// OperatingSystem information populated at the runtime.
 	OperatingSystem OperatingSystemName `json:"-"`
 }
 
+// BastionConfig describes a single bastion (or jump) host of the chain of
+// bastion hosts
+type BastionConfig struct {
+	// Host is an IP or hostname of the bastion host.
+	Host string `json:"host"`
+	// Port is SSH port to use when connecting to the bastion host.
+	// Default value is 22.
+	Port int `json:"port,omitempty"`
+	// User is system login name to use when connecting to the bastion host.
+	// Default value is the SSHUsername of the host.
+	User string `json:"user,omitempty"`
+	// SSHPrivateKeyFile is path to the file with PRIVATE AND CLEANTEXT ssh key used to authenticate
+	// to the bastion host, in addition to the SSH private key and agent socket of the host.
+	// Default value is "".
+	SSHPrivateKeyFile string `json:"sshPrivateKeyFile,omitempty"`
+}
+
+// KubeletConfig configures kubelet. The settings are passed to kubelet as the
+// flags, so they are reapplied to the existing nodes on every apply and upgrade.
+type KubeletConfig struct {
+	// MaxPods is the maximum number of pods running on the node.
+	// Default value is 110.
+	MaxPods *int32 `json:"maxPods,omitempty"`
+	// SystemReserved are the resources (cpu, memory, ephemeral-storage and
+	// pid) reserved for the system daemons, e.g. "memory: 500Mi".
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+	// KubeReserved are the resources (cpu, memory, ephemeral-storage and
+	// pid) reserved for the Kubernetes system daemons, e.g. "cpu: 200m".
+	KubeReserved map[string]string `json:"kubeReserved,omitempty"`
+	// EvictionHard are the hard eviction thresholds, e.g.
+	// "memory.available: 100Mi" or "nodefs.available: 10%".
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+	// CgroupDriver is the cgroup driver used by kubelet, systemd or cgroupfs.
+	// It must match the cgroup driver of the container runtime.
+	// Default value is systemd.
+	CgroupDriver string `json:"cgroupDriver,omitempty"`
+}
+
+// HostNetworkOverrides overrides the addresses detected for the host
+type HostNetworkOverrides struct {
+	// APIServerAdvertiseAddress is the IP address kube-apiserver advertises on the control plane host.
+	// kubeadm also uses this address for the etcd peer URL of the etcd member.
+	// Default value is PrivateAddress (or PublicAddress if PrivateAddress is empty).
+	APIServerAdvertiseAddress string `json:"apiServerAdvertiseAddress,omitempty"`
+	// EtcdListenClientURLs are the URLs etcd listens on for the client traffic on the control plane host.
+	// Requires Kubernetes 1.22 or newer.
+	// Default value is the loopback and the APIServerAdvertiseAddress URLs.
+	EtcdListenClientURLs []string `json:"etcdListenClientURLs,omitempty"`
+	// EtcdAdvertiseClientURLs are the client URLs etcd advertises to the rest of the cluster.
+	// Requires Kubernetes 1.22 or newer.
+	// Default value is the APIServerAdvertiseAddress URL.
+	EtcdAdvertiseClientURLs []string `json:"etcdAdvertiseClientURLs,omitempty"`
+	// EtcdListenPeerURLs are the URLs etcd listens on for the peer traffic on the control plane host.
+	// Requires Kubernetes 1.22 or newer.
+	// Default value is the APIServerAdvertiseAddress URL.
+	EtcdListenPeerURLs []string `json:"etcdListenPeerURLs,omitempty"`
+	// KubeletNodeIP is the IP address kubelet reports as the node IP.
+	// Default value is PrivateAddress (or PublicAddress if PrivateAddress is empty).
+	KubeletNodeIP string `json:"kubeletNodeIP,omitempty"`
+}
+
 // ControlPlaneConfig defines control plane nodes
 type ControlPlaneConfig struct {
 	// Hosts array of all control plane hosts.
@@ -143,6 +417,92 @@ type ControlPlaneConfig struct {
 type StaticWorkersConfig struct {
 	// Hosts
 	Hosts []HostConfig `json:"hosts,omitempty"`
+	// Pools are named groups of static workers sharing the kubelet configuration, taints and labels.
+	// Hosts are assigned to the pool using the HostConfig.Pool field.
+	Pools []StaticWorkerPool `json:"pools,omitempty"`
+}
+
+// StaticWorkerPool is a named group of static workers sharing the same settings
+type StaticWorkerPool struct {
+	// Name of the pool, used to assign hosts to the pool.
+	Name string `json:"name"`
+	// KubeletExtraArgs are extra flags passed to kubelet on hosts in the pool.
+	// The flags are applied when the host joins the cluster.
+	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
+	// FeatureGates are kubelet feature gates enabled or disabled on hosts in the pool.
+	// The feature gates are applied when the host joins the cluster.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// Taints are applied to hosts in the pool which don't have taints configured.
+	// The taints are applied when the host joins the cluster.
+	Taints []corev1.Taint `json:"taints,omitempty"`
+	// Labels are applied to nodes of hosts in the pool.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// EtcdConfig configures the etcd members deployed by kubeadm. The settings
+// are passed as flags to the etcd static pods, and are left to the etcd
+// defaults if not set.
+type EtcdConfig struct {
+	// QuotaBackendBytes is the size limit of the etcd database in bytes.
+	// etcd raises the NOSPACE alarm and rejects writes once the limit is
+	// reached.
+	// Default value is 2GiB.
+	QuotaBackendBytes int64 `json:"quotaBackendBytes,omitempty"`
+
+	// SnapshotCount is the number of committed transactions after which
+	// etcd takes a snapshot and compacts its log.
+	// Default value is 100000.
+	SnapshotCount uint64 `json:"snapshotCount,omitempty"`
+
+	// HeartbeatInterval is the interval of the leader heartbeats, such as
+	// "100ms". Should be around the round-trip time between the members.
+	// Default value is 100ms.
+	HeartbeatInterval metav1.Duration `json:"heartbeatInterval,omitempty"`
+
+	// ElectionTimeout is how long a follower waits for the heartbeat before
+	// starting the leader election, such as "1s". Must be at least five times
+	// the HeartbeatInterval.
+	// Default value is 1s.
+	ElectionTimeout metav1.Duration `json:"electionTimeout,omitempty"`
+
+	// TLS configures the etcd server certificates and the TLS settings of
+	// the etcd members.
+	TLS *EtcdTLSConfig `json:"tls,omitempty"`
+}
+
+// EtcdTLSConfig configures the etcd TLS settings. The client and the peer
+// certificate authentication is always enforced.
+type EtcdTLSConfig struct {
+	// CipherSuites are the TLS cipher suites allowed for the client and the
+	// peer connections, such as "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256".
+	// Can't be used along with the FIPS feature, which configures the cipher
+	// suites on its own.
+	// Default value is the Go default cipher suites.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+
+	// ServerCertSANs are the additional Subject Alternative Names, DNS names
+	// or IP addresses, of the etcd server certificates, such as the DNS name
+	// used by the external monitoring to reach etcd. The certificates are
+	// regenerated one member at a time when new SANs are added.
+	ServerCertSANs []string `json:"serverCertSANs,omitempty"`
+}
+
+// ExternalEtcdConfig configures the connection to the external etcd cluster.
+// The certificate files must be present on all control plane nodes, such as
+// provisioned by the pre-provision host scripts.
+type ExternalEtcdConfig struct {
+	// Endpoints are the client URLs of the etcd members, such as
+	// "https://10.0.0.10:2379"
+	Endpoints []string `json:"endpoints"`
+	// CAFile is the path to the CA certificate of the etcd cluster on the
+	// control plane nodes. Required if the endpoints use https.
+	CAFile string `json:"caFile,omitempty"`
+	// CertFile is the path to the client certificate kube-apiserver uses to
+	// authenticate to etcd. Required if the endpoints use https.
+	CertFile string `json:"certFile,omitempty"`
+	// KeyFile is the path to the private key of the client certificate.
+	// Required if the endpoints use https.
+	KeyFile string `json:"keyFile,omitempty"`
 }
 
 // APIEndpoint is the endpoint used to communicate with the Kubernetes API
@@ -173,12 +533,18 @@ type CloudProviderSpec struct {
 	GCE *GCESpec `json:"gce,omitempty"`
 	// Hetzner
 	Hetzner *HetznerSpec `json:"hetzner,omitempty"`
+	// KubeVirt
+	KubeVirt *KubeVirtSpec `json:"kubevirt,omitempty"`
+	// Nutanix
+	Nutanix *NutanixSpec `json:"nutanix,omitempty"`
 	// Openstack
 	Openstack *OpenstackSpec `json:"openstack,omitempty"`
 	// Packet
 	Packet *PacketSpec `json:"packet,omitempty"`
 	// Vsphere
 	Vsphere *VsphereSpec `json:"vsphere,omitempty"`
+	// VMwareCloudDirector
+	VMwareCloudDirector *VMwareCloudDirectorSpec `json:"vmwareCloudDirector,omitempty"`
 	// None
 	None *NoneSpec `json:"none,omitempty"`
 }
@@ -201,15 +567,82 @@ type HetznerSpec struct {
 	NetworkID string `json:"networkID,omitempty"`
 }
 
+// KubeVirtSpec defines the KubeVirt provider
+type KubeVirtSpec struct {
+	// InfraNamespace is the namespace of the infrastructure KubeVirt cluster
+	// in which the VirtualMachines of the cluster are created
+	InfraNamespace string `json:"infraNamespace"`
+}
+
+// NutanixSpec defines the Nutanix provider
+type NutanixSpec struct{}
+
 // OpenstackSpec defines the Openstack provider
 type OpenstackSpec struct{}
 
 // PacketSpec defines the Packet cloud provider
-type PacketSpec struct{}
+type PacketSpec struct {
+	// Metro is the Equinix Metal metro used by the CCM for the Elastic IP
+	// and the load balancer resources
+	// +optional
+	Metro string `json:"metro,omitempty"`
+	// LoadBalancer is the load balancer implementation the CCM configures
+	// for the LoadBalancer Services, e.g. "kube-vip://" or
+	// "metallb:///metallb-system?crdConfiguration=true"
+	// +optional
+	LoadBalancer string `json:"loadBalancer,omitempty"`
+	// NodeIPFromMetadata discovers the private IPv4 address of the hosts
+	// using the Equinix Metal metadata service, and uses it as the kubelet
+	// node IP unless .networkOverrides.kubeletNodeIP is set for the host
+	// +optional
+	NodeIPFromMetadata bool `json:"nodeIPFromMetadata,omitempty"`
+	// BGP configures the BGP peering of the nodes by the CCM
+	// +optional
+	BGP *PacketBGP `json:"bgp,omitempty"`
+	// ElasticIP configures the Elastic IP used as the control plane endpoint
+	// and managed by the CCM
+	// +optional
+	ElasticIP *PacketElasticIP `json:"elasticIP,omitempty"`
+}
+
+// PacketBGP configures the BGP peering of the nodes by the Equinix Metal CCM.
+// The BGP password is read from the PACKET_BGP_PASSWORD credential.
+type PacketBGP struct {
+	// Enable enables the BGP peering
+	Enable bool `json:"enable"`
+	// LocalASN is the local ASN of the nodes
+	// Default value is 65000
+	// +optional
+	LocalASN int `json:"localASN,omitempty"`
+	// NodeSelector is the label selector of the nodes to enable BGP on
+	// +optional
+	NodeSelector string `json:"nodeSelector,omitempty"`
+}
+
+// PacketElasticIP configures the Elastic IP managed by the Equinix Metal CCM
+type PacketElasticIP struct {
+	// Tag is the tag of the Elastic IP the CCM assigns to the control plane
+	// nodes
+	Tag string `json:"tag"`
+	// HealthCheckUseHostIP checks the kube-apiserver health using the host
+	// IP address instead of the Elastic IP
+	// +optional
+	HealthCheckUseHostIP bool `json:"healthCheckUseHostIP,omitempty"`
+}
 
 // VsphereSpec defines the vSphere provider
 type VsphereSpec struct{}
 
+// VMwareCloudDirectorSpec defines the VMware Cloud Director provider
+type VMwareCloudDirectorSpec struct {
+	// VApp is the name of the vApp the cluster VMs are placed in
+	VApp string `json:"vApp"`
+	// StorageProfile is the storage profile used by the default StorageClass
+	// of the CSI driver
+	// +optional
+	StorageProfile string `json:"storageProfile,omitempty"`
+}
+
 // NoneSpec defines a none provider
 type NoneSpec struct{}
 
@@ -223,9 +656,27 @@ type ClusterNetworkConfig struct {
 	// PodSubnet
 	// default value is "10.244.0.0/16"
 	PodSubnet string `json:"podSubnet,omitempty"`
+	// AdditionalPodSubnets are appended to the PodSubnet of the running cluster
+	// which exhausted it. Nodes are allocated the pod subnets from the smallest
+	// subnet covering the PodSubnet and the additional subnets, so the
+	// additional subnets should be adjacent to the PodSubnet.
+	AdditionalPodSubnets []string `json:"additionalPodSubnets,omitempty"`
 	// ServiceSubnet
 	// default value is "10.96.0.0/12"
 	ServiceSubnet string `json:"serviceSubnet,omitempty"`
+	// IPFamilies are the IP families of the cluster, the first one being the
+	// primary family. Two families deploy a dual-stack cluster. The IPv4
+	// family uses the PodSubnet and the ServiceSubnet, and the IPv6 family
+	// uses the PodSubnetIPv6 and the ServiceSubnetIPv6.
+	// Default value is empty, i.e. single-stack cluster using the PodSubnet
+	// and the ServiceSubnet.
+	IPFamilies []IPFamily `json:"ipFamilies,omitempty"`
+	// PodSubnetIPv6 is the pod subnet of the IPv6 family
+	// default value is "fd01::/48" if the IPv6 family is enabled
+	PodSubnetIPv6 string `json:"podSubnetIPv6,omitempty"`
+	// ServiceSubnetIPv6 is the service subnet of the IPv6 family
+	// default value is "fd02::/120" if the IPv6 family is enabled
+	ServiceSubnetIPv6 string `json:"serviceSubnetIPv6,omitempty"`
 	// ServiceDomainName
 	// default value is "cluster.local"
 	ServiceDomainName string `json:"serviceDomainName,omitempty"`
@@ -237,8 +688,20 @@ type ClusterNetworkConfig struct {
 	CNI *CNI `json:"cni,omitempty"`
 	// KubeProxy config
 	KubeProxy *KubeProxyConfig `json:"kubeProxy,omitempty"`
+	// CoreDNS configures the CoreDNS deployed by kubeadm
+	CoreDNS *CoreDNSConfig `json:"coreDNS,omitempty"`
 }
 
+// IPFamily is the IP family of the cluster network
+type IPFamily string
+
+const (
+	// IPFamilyIPv4 is the IPv4 family
+	IPFamilyIPv4 IPFamily = "IPv4"
+	// IPFamilyIPv6 is the IPv6 family
+	IPFamilyIPv6 IPFamily = "IPv6"
+)
+
 // KubeProxyConfig defines configured kube-proxy mode, default is iptables mode
 type KubeProxyConfig struct {
 	// IPVS config
@@ -246,6 +709,32 @@ type KubeProxyConfig struct {
 
 	// IPTables config
 	IPTables *IPTables `json:"iptables"`
+
+	// MetricsBindAddress is the address with the port the metrics server
+	// serves on, such as "0.0.0.0:10249".
+	// Default value is "127.0.0.1:10249".
+	MetricsBindAddress string `json:"metricsBindAddress,omitempty"`
+
+	// Conntrack configures the connection tracking table of the nodes
+	Conntrack *KubeProxyConntrack `json:"conntrack,omitempty"`
+
+	// SyncPeriod is the maximum interval of refreshing the iptables or IPVS
+	// rules, such as "30s".
+	// Default value is 30s.
+	SyncPeriod metav1.Duration `json:"syncPeriod,omitempty"`
+}
+
+// KubeProxyConntrack configures the size of the connection tracking table
+type KubeProxyConntrack struct {
+	// MaxPerCore is the maximum number of NAT connections to track per CPU
+	// core. 0 leaves the limit as-is.
+	// Default value is 32768.
+	MaxPerCore *int32 `json:"maxPerCore,omitempty"`
+
+	// Min is the minimum number of NAT connections to track, regardless of
+	// the MaxPerCore.
+	// Default value is 131072.
+	Min *int32 `json:"min,omitempty"`
 }
 
 // IPVSConfig contains different options to configure IPVS kube-proxy mode
@@ -265,8 +754,10 @@ type IPVSConfig struct {
 	ExcludeCIDRs []string `json:"excludeCIDRs"`
 
 	// strict ARP configure arp_ignore and arp_announce to avoid answering ARP queries
-	// from kube-ipvs0 interface
-	StrictARP bool `json:"strictARP"`
+	// from kube-ipvs0 interface.
+	// If not set, strict ARP is enabled automatically when an addon announcing
+	// LoadBalancer IPs using ARP, such as MetalLB, is deployed.
+	StrictARP *bool `json:"strictARP,omitempty"`
 
 	// tcpTimeout is the timeout value used for idle IPVS TCP sessions.
 	// The default value is 0, which preserves the current timeout value on the system.
@@ -284,12 +775,87 @@ type IPVSConfig struct {
 // IPTables
 type IPTables struct{}
 
+// CoreDNSConfig configures the kubeadm-managed CoreDNS. The Corefile
+// directives are rendered into the CoreDNS ConfigMap, and the replicas and
+// the PodDisruptionBudget are applied to the CoreDNS Deployment, on every
+// apply and upgrade.
+type CoreDNSConfig struct {
+	// Replicas is the number of CoreDNS replicas
+	// default value is the number of replicas deployed by kubeadm (2)
+	Replicas *int32 `json:"replicas,omitempty"`
+	// DeployPodDisruptionBudget deploys the PodDisruptionBudget allowing at
+	// most one CoreDNS replica to be unavailable while draining the nodes
+	DeployPodDisruptionBudget bool `json:"deployPodDisruptionBudget,omitempty"`
+	// Forwarders are the upstream DNS servers, in the IP[:port] format, the
+	// queries outside of the cluster domain are forwarded to. The
+	// nameservers from the /etc/resolv.conf of the node are used by default.
+	Forwarders []string `json:"forwarders,omitempty"`
+	// StubDomains are the DNS domains resolved by the dedicated DNS servers
+	StubDomains []CoreDNSStubDomain `json:"stubDomains,omitempty"`
+	// CorefileSnippet is appended to the Corefile as is, such as the
+	// additional server blocks
+	CorefileSnippet string `json:"corefileSnippet,omitempty"`
+	// Hosts are the static hosts entries served by the hosts plugin. Names not
+	// found in the entries fall through to the next plugins.
+	Hosts []CoreDNSHostEntry `json:"hosts,omitempty"`
+	// Rewrites are the rules of the rewrite plugin
+	Rewrites []CoreDNSRewriteRule `json:"rewrites,omitempty"`
+	// Plugins are the additional plugins enabled in the server block
+	Plugins []CoreDNSPlugin `json:"plugins,omitempty"`
+}
+
+// CoreDNSStubDomain is the DNS domain resolved by the dedicated DNS servers
+type CoreDNSStubDomain struct {
+	// Domain is the DNS domain, such as corp.example.com
+	Domain string `json:"domain"`
+	// Servers are the DNS servers, in the IP[:port] format, the queries for
+	// the domain are forwarded to
+	Servers []string `json:"servers"`
+}
+
+// CoreDNSHostEntry is the hosts-file style entry
+type CoreDNSHostEntry struct {
+	// IP address the hostnames resolve to
+	IP string `json:"ip"`
+	// Hostnames resolving to the IP address
+	Hostnames []string `json:"hostnames"`
+}
+
+// CoreDNSRewriteRule is the rule of the rewrite plugin
+type CoreDNSRewriteRule struct {
+	// Field of the request to rewrite, can be one of: name, type, class
+	// default value is "name"
+	Field string `json:"field,omitempty"`
+	// Match is the type of the name match, can be one of: exact, prefix, suffix, substring, regex
+	// It's used only when rewriting the name field.
+	// default value is "exact"
+	Match string `json:"match,omitempty"`
+	// From is the value to rewrite
+	From string `json:"from"`
+	// To is the value to rewrite to
+	To string `json:"to"`
+}
+
+// CoreDNSPlugin is the Corefile plugin directive
+type CoreDNSPlugin struct {
+	// Name of the plugin, such as log or template
+	Name string `json:"name"`
+	// Args are the arguments of the directive
+	Args []string `json:"args,omitempty"`
+	// Options are the lines of the directive block
+	Options []string `json:"options,omitempty"`
+}
+
 // CNI config. Only one CNI provider must be used at the single time.
 type CNI struct {
 	// Canal
 	Canal *CanalSpec `json:"canal,omitempty"`
 	// WeaveNet
 	WeaveNet *WeaveNetSpec `json:"weaveNet,omitempty"`
+	// Cilium
+	Cilium *CiliumSpec `json:"cilium,omitempty"`
+	// Calico
+	Calico *CalicoSpec `json:"calico,omitempty"`
 	// External
 	External *ExternalCNISpec `json:"external,omitempty"`
 }
@@ -299,12 +865,134 @@ type CanalSpec struct {
 	// MTU automatically detected based on the cloudProvider
 	// default value is 1450
 	MTU int `json:"mtu,omitempty"`
+	// Params are passed to the embedded Canal addon templates, taking
+	// precedence over the addons global params and the cni-canal addon
+	// params. Supported params are FlannelBackend, the flannel backend type
+	// (default "vxlan"), FlannelIface, the interface used for the traffic
+	// between the nodes (default is the interface of the node IP address),
+	// and the FELIX_* Felix configuration environment variables.
+	Params map[string]string `json:"params,omitempty"`
 }
 
 // WeaveNetSpec defines the WeaveNet CNI plugin
 type WeaveNetSpec struct {
 	// Encrypted
 	Encrypted bool `json:"encrypted,omitempty"`
+	// Params are passed to the embedded WeaveNet addon templates, taking
+	// precedence over the addons global params and the cni-weavenet addon
+	// params. Supported params are MTU, the MTU of the Weave network
+	// (default 1376), and ExtraArgs, the additional Weave router arguments.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// CiliumSpec defines the Cilium CNI plugin
+type CiliumSpec struct {
+	// KubeProxyReplacement defines whether Cilium replaces kube-proxy using
+	// eBPF. Possible values are "disabled", "probe", "partial" and "strict".
+	// kube-proxy is not deployed to the new clusters if set to "strict",
+	// which requires Kubernetes 1.22+.
+	// Default value is "disabled".
+	KubeProxyReplacement CiliumKubeProxyReplacement `json:"kubeProxyReplacement,omitempty"`
+	// Hubble enables the Hubble network observability on all nodes
+	Hubble *CiliumHubbleSpec `json:"hubble,omitempty"`
+	// Params are passed to the embedded Cilium addon templates, taking
+	// precedence over the addons global params and the cni-cilium addon
+	// params. Supported params are MTU, the MTU of the pod interfaces
+	// (detected automatically if not set), and Tunnel, the encapsulation
+	// protocol, one of "vxlan" and "geneve" (default "vxlan").
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// CiliumKubeProxyReplacement defines how Cilium replaces kube-proxy
+type CiliumKubeProxyReplacement string
+
+const (
+	// CiliumKubeProxyReplacementDisabled leaves the services to kube-proxy
+	CiliumKubeProxyReplacementDisabled CiliumKubeProxyReplacement = "disabled"
+	// CiliumKubeProxyReplacementProbe enables the features supported by the
+	// kernel, along with kube-proxy
+	CiliumKubeProxyReplacementProbe CiliumKubeProxyReplacement = "probe"
+	// CiliumKubeProxyReplacementPartial enables the selected features,
+	// failing if the kernel doesn't support them, along with kube-proxy
+	CiliumKubeProxyReplacementPartial CiliumKubeProxyReplacement = "partial"
+	// CiliumKubeProxyReplacementStrict replaces kube-proxy completely
+	CiliumKubeProxyReplacementStrict CiliumKubeProxyReplacement = "strict"
+)
+
+// CiliumHubbleSpec defines the Hubble components deployed along with Cilium
+type CiliumHubbleSpec struct {
+	// Relay deploys the Hubble Relay, aggregating the flows of all nodes
+	Relay bool `json:"relay,omitempty"`
+	// UI deploys the Hubble UI. Requires the Relay.
+	UI bool `json:"ui,omitempty"`
+	// UIServiceType is the type of the Service exposing the Hubble UI, such
+	// as NodePort or LoadBalancer.
+	// Default value is ClusterIP.
+	UIServiceType corev1.ServiceType `json:"uiServiceType,omitempty"`
+}
+
+// CalicoSpec defines the Calico CNI plugin, deployed without flannel
+type CalicoSpec struct {
+	// Mode is how the pod traffic is routed between the nodes. Possible
+	// values are "vxlan" and "ipip", encapsulating the traffic, and "bgp",
+	// routing the traffic unencapsulated using the routes advertised over BGP.
+	// Default value is "vxlan".
+	Mode CalicoMode `json:"mode,omitempty"`
+	// CrossSubnet encapsulates only the traffic crossing the subnet
+	// boundaries. Used only with the "vxlan" and "ipip" modes.
+	CrossSubnet bool `json:"crossSubnet,omitempty"`
+	// MTU of the pod interfaces and the tunnels. It's detected automatically
+	// if not set.
+	MTU int `json:"mtu,omitempty"`
+	// BGP configures the BGP peering. Used only with the "ipip" and "bgp"
+	// modes.
+	BGP *CalicoBGPSpec `json:"bgp,omitempty"`
+	// Params are passed to the embedded Calico addon templates, taking
+	// precedence over the addons global params and the cni-calico addon
+	// params. Supported params are IPAutodetectionMethod and
+	// IP6AutodetectionMethod, the methods used to detect the node addresses
+	// (default "first-found"), and the FELIX_* Felix configuration
+	// environment variables.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// CalicoMode defines how Calico routes the pod traffic
+type CalicoMode string
+
+const (
+	// CalicoModeVXLAN encapsulates the pod traffic using VXLAN
+	CalicoModeVXLAN CalicoMode = "vxlan"
+	// CalicoModeIPIP encapsulates the pod traffic using IP-in-IP
+	CalicoModeIPIP CalicoMode = "ipip"
+	// CalicoModeBGP routes the pod traffic without encapsulation
+	CalicoModeBGP CalicoMode = "bgp"
+)
+
+// CalicoBGPSpec defines the BGP peering of the nodes
+type CalicoBGPSpec struct {
+	// ASNumber is the autonomous system number of the nodes.
+	// Default value is 64512.
+	ASNumber uint32 `json:"asNumber,omitempty"`
+	// DisableNodeToNodeMesh disables the full mesh peering between all nodes,
+	// such as when the nodes peer with the route reflectors or the top of
+	// rack routers instead
+	DisableNodeToNodeMesh bool `json:"disableNodeToNodeMesh,omitempty"`
+	// Peers are the external BGP peers of the nodes
+	Peers []CalicoBGPPeer `json:"peers,omitempty"`
+}
+
+// CalicoBGPPeer defines the external BGP peer
+type CalicoBGPPeer struct {
+	// Name of the peer
+	Name string `json:"name"`
+	// PeerIP is the IP address of the peer
+	PeerIP string `json:"peerIP"`
+	// ASNumber is the autonomous system number of the peer
+	ASNumber uint32 `json:"asNumber"`
+	// NodeSelector selects the nodes peering with the peer, using the Calico
+	// selector syntax, such as "rack == 'rack-1'". All nodes peer with the
+	// peer if not set.
+	NodeSelector string `json:"nodeSelector,omitempty"`
 }
 
 // ExternalCNISpec defines the external CNI plugin.
@@ -327,10 +1015,23 @@ type DynamicWorkerConfig struct {
 	Name string `json:"name"`
 	// Replicas
 	Replicas *int `json:"replicas"`
+	// Autoscaling configures the cluster-autoscaler to scale the
+	// MachineDeployment between the given minimum and maximum number of
+	// replicas. The cluster-autoscaler is deployed if at least one
+	// workerset enables autoscaling.
+	Autoscaling *DynamicWorkerAutoscaling `json:"autoscaling,omitempty"`
 	// Config
 	Config ProviderSpec `json:"providerSpec"`
 }
 
+// DynamicWorkerAutoscaling configures the autoscaling of a set of worker machines
+type DynamicWorkerAutoscaling struct {
+	// MinReplicas is the minimum number of replicas, must be greater than zero
+	MinReplicas int `json:"minReplicas"`
+	// MaxReplicas is the maximum number of replicas
+	MaxReplicas int `json:"maxReplicas"`
+}
+
 // ProviderSpec describes a worker node
 type ProviderSpec struct {
 	// CloudProviderSpec
@@ -375,6 +1076,14 @@ type MachineControllerConfig struct {
 	Deploy bool `json:"deploy,omitempty"`
 }
 
+// OSMConfig configures kubermatic operating-system-manager deployment.
+// operating-system-manager renders the provisioning configs (user-data) of
+// the dynamic workers from the versioned OperatingSystemProfiles.
+type OSMConfig struct {
+	// Deploy
+	Deploy bool `json:"deploy,omitempty"`
+}
+
 // Features controls what features will be enabled on the cluster
 type Features struct {
 	// PodNodeSelector
@@ -384,16 +1093,52 @@ type Features struct {
 	PodPresets *PodPresets `json:"podPresets,omitempty"`
 	// PodSecurityPolicy
 	PodSecurityPolicy *PodSecurityPolicy `json:"podSecurityPolicy,omitempty"`
+	// PodSecurityAdmission
+	PodSecurityAdmission *PodSecurityAdmission `json:"podSecurityAdmission,omitempty"`
 	// StaticAuditLog
 	StaticAuditLog *StaticAuditLog `json:"staticAuditLog,omitempty"`
 	// DynamicAuditLog
 	DynamicAuditLog *DynamicAuditLog `json:"dynamicAuditLog,omitempty"`
 	// MetricsServer
 	MetricsServer *MetricsServer `json:"metricsServer,omitempty"`
+	// NodeLocalDNS
+	NodeLocalDNS *NodeLocalDNS `json:"nodeLocalDNS,omitempty"`
 	// OpenIDConnect
 	OpenIDConnect *OpenIDConnect `json:"openidConnect,omitempty"`
 	// Encryption Providers
 	EncryptionProviders *EncryptionProviders `json:"encryptionProviders,omitempty"`
+	// FIPS
+	FIPS *FIPS `json:"fips,omitempty"`
+	// SELinux
+	SELinux *SELinux `json:"selinux,omitempty"`
+	// AppArmor
+	AppArmor *AppArmor `json:"appArmor,omitempty"`
+	// SeccompDefault
+	SeccompDefault *SeccompDefault `json:"seccompDefault,omitempty"`
+	// BootstrapRBAC
+	BootstrapRBAC *BootstrapRBAC `json:"bootstrapRBAC,omitempty"`
+	// SRIOV
+	SRIOV *SRIOV `json:"sriov,omitempty"`
+
+	// KubeVIP
+	KubeVIP *KubeVIP `json:"kubeVIP,omitempty"`
+	// Konnectivity
+	Konnectivity *Konnectivity `json:"konnectivity,omitempty"`
+	// EventRateLimit
+	EventRateLimit *EventRateLimit `json:"eventRateLimit,omitempty"`
+	// AlwaysPullImages
+	AlwaysPullImages *AlwaysPullImages `json:"alwaysPullImages,omitempty"`
+	// DenyServiceExternalIPs
+	DenyServiceExternalIPs *DenyServiceExternalIPs `json:"denyServiceExternalIPs,omitempty"`
+	// NodeRestriction
+	NodeRestriction *NodeRestriction `json:"nodeRestriction,omitempty"`
+	// Gatekeeper
+	Gatekeeper *Gatekeeper `json:"gatekeeper,omitempty"`
+	// FeatureGates are the Kubernetes feature gates enabled or disabled on
+	// the API server, the controller manager, the scheduler, kube-proxy and
+	// kubelet. The feature gates configured by KubeOne and the feature gates
+	// configured for the specific component take precedence.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
 }
 
 // SystemPackages controls configurations of APT/YUM
@@ -454,6 +1199,27 @@ type AssetConfiguration struct {
 	// If not specified, kubelet package will be installed.
 	// Default: none
 	Kubectl BinaryAsset `json:"kubectl,omitempty"`
+	// Cache designates one of the cluster hosts as a pull-through cache for
+	// the binary assets and the Kubernetes packages, so the other hosts
+	// download them over the private network instead of each of them
+	// downloading from the internet. Container images are not cached, the
+	// containerd registry mirrors can be used for them instead.
+	// Default: none
+	Cache *AssetCache `json:"cache,omitempty"`
+}
+
+// AssetCache configures the host used as a pull-through cache for the
+// binary assets
+type AssetCache struct {
+	// Host is the private address of the control plane or static worker
+	// host used as the cache. The cache is deployed as nginx installed from
+	// the operating system packages, so Flatcar hosts can't be used as the
+	// cache. The cache listens only on the private address and proxies only
+	// the hosts the configured assets are downloaded from.
+	Host string `json:"host"`
+	// Port on which the cache is listening.
+	// Default: 8008
+	Port int `json:"port,omitempty"`
 }
 
 // ImageAsset is used to customize the image repository and the image tag
@@ -468,6 +1234,14 @@ type ImageAsset struct {
 type BinaryAsset struct {
 	// URL from where to download the binary
 	URL string `json:"url,omitempty"`
+	// Mirrors are the URLs from where the binary is downloaded if the
+	// download from the URL fails
+	Mirrors []string `json:"mirrors,omitempty"`
+	// SHA256 is the expected checksum of the binary. If not specified, the
+	// checksum is downloaded from the URL with the .sha256 suffix, never from
+	// the mirrors or the asset cache, and the download fails if the checksum
+	// can't be obtained.
+	SHA256 string `json:"sha256,omitempty"`
 }
 
 // RegistryConfiguration controls how images used for components deployed by
@@ -504,6 +1278,78 @@ type PodNodeSelectorConfig struct {
 	ConfigFilePath string `json:"configFilePath"`
 }
 
+// EventRateLimit feature flag
+type EventRateLimit struct {
+	// Enable
+	Enable bool `json:"enable,omitempty"`
+	// Config
+	Config EventRateLimitConfig `json:"config,omitempty"`
+}
+
+// EventRateLimitConfig config
+type EventRateLimitConfig struct {
+	// Limits are the rate limits of events accepted by the API server.
+	// Default value is the Server limit with 50 QPS and 100 burst, and the
+	// Namespace limit with 50 QPS, 100 burst and the cache size of 2000.
+	// More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#eventratelimit
+	Limits []EventRateLimitLimit `json:"limits,omitempty"`
+}
+
+// EventRateLimitType is the type of the events rate limit
+type EventRateLimitType string
+
+const (
+	// EventRateLimitTypeServer limits all events accepted by the API server
+	EventRateLimitTypeServer EventRateLimitType = "Server"
+	// EventRateLimitTypeNamespace limits events per namespace
+	EventRateLimitTypeNamespace EventRateLimitType = "Namespace"
+	// EventRateLimitTypeUser limits events per user
+	EventRateLimitTypeUser EventRateLimitType = "User"
+	// EventRateLimitTypeSourceAndObject limits events per source and involved object
+	EventRateLimitTypeSourceAndObject EventRateLimitType = "SourceAndObject"
+)
+
+// EventRateLimitLimit is the rate limit of events of the given type
+type EventRateLimitLimit struct {
+	// Type of the limit, one of Server, Namespace, User or SourceAndObject.
+	Type EventRateLimitType `json:"type"`
+	// QPS is the number of events per second accepted by the API server.
+	QPS int32 `json:"qps"`
+	// Burst is the number of events accepted by the API server at once.
+	Burst int32 `json:"burst"`
+	// CacheSize is the number of rate limited namespaces, users or objects kept in the cache.
+	// Not used for the Server limit type.
+	// Default value is 4096.
+	CacheSize int32 `json:"cacheSize,omitempty"`
+}
+
+// AlwaysPullImages feature flag
+// The AlwaysPullImages admission plugin forces pulling the image for every new
+// pod, so private images cached on the node can be used only by pods having
+// the credentials to pull them.
+type AlwaysPullImages struct {
+	// Enable
+	Enable bool `json:"enable,omitempty"`
+}
+
+// DenyServiceExternalIPs feature flag
+// The DenyServiceExternalIPs admission plugin rejects new usages of the
+// Service externalIPs field, which can be used to intercept the cluster traffic.
+// The DenyServiceExternalIPs feature requires Kubernetes 1.21 or newer.
+type DenyServiceExternalIPs struct {
+	// Enable
+	Enable bool `json:"enable,omitempty"`
+}
+
+// NodeRestriction feature flag
+// The NodeRestriction admission plugin limits kubelets to modifying only their
+// own Node object and pods bound to their node, and prevents them from setting
+// the node-restriction.kubernetes.io/ labels.
+type NodeRestriction struct {
+	// Enable
+	Enable bool `json:"enable,omitempty"`
+}
+
 // PodPresets feature flag
 // The PodPresets feature has been removed in Kubernetes 1.20.
 // This feature is deprecated and will be removed from the API once
@@ -519,6 +1365,51 @@ type PodSecurityPolicy struct {
 	Enable bool `json:"enable,omitempty"`
 }
 
+// PodSecurityAdmission feature flag
+type PodSecurityAdmission struct {
+	// Enable
+	Enable bool `json:"enable,omitempty"`
+	// Config
+	Config PodSecurityAdmissionConfig `json:"config,omitempty"`
+}
+
+// PodSecurityAdmissionConfig configures the cluster-wide defaults of the
+// PodSecurity admission plugin, used for namespaces without the
+// pod-security.kubernetes.io labels.
+// More info: https://kubernetes.io/docs/concepts/security/pod-security-admission/
+type PodSecurityAdmissionConfig struct {
+	// Enforce is the Pod Security Standards level enforced for pods, one of
+	// privileged, baseline or restricted. Default value is privileged.
+	Enforce string `json:"enforce,omitempty"`
+	// EnforceVersion is the version of the enforced level, in the v1.X
+	// format, or latest. Default value is latest.
+	EnforceVersion string `json:"enforceVersion,omitempty"`
+	// Audit is the level for which the violations are recorded in the audit
+	// log. Default value is privileged.
+	Audit string `json:"audit,omitempty"`
+	// AuditVersion is the version of the audit level. Default value is latest.
+	AuditVersion string `json:"auditVersion,omitempty"`
+	// Warn is the level for which the violations are returned to the user
+	// as warnings. Default value is privileged.
+	Warn string `json:"warn,omitempty"`
+	// WarnVersion is the version of the warn level. Default value is latest.
+	WarnVersion string `json:"warnVersion,omitempty"`
+	// Exemptions are the requests not evaluated by the PodSecurity admission
+	// plugin. Default value exempts the kube-system namespace.
+	Exemptions PodSecurityAdmissionExemptions `json:"exemptions,omitempty"`
+}
+
+// PodSecurityAdmissionExemptions are the requests exempted from the Pod
+// Security Standards evaluation
+type PodSecurityAdmissionExemptions struct {
+	// Usernames are the authenticated usernames to exempt
+	Usernames []string `json:"usernames,omitempty"`
+	// RuntimeClasses are the runtime class names to exempt
+	RuntimeClasses []string `json:"runtimeClasses,omitempty"`
+	// Namespaces are the namespaces to exempt
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
 // StaticAuditLog feature flag
 type StaticAuditLog struct {
 	// Enable
@@ -546,6 +1437,39 @@ type StaticAuditLogConfig struct {
 	// LogMaxSize is maximum size in megabytes of audit log file before it gets rotated.
 	// Default value is 100.
 	LogMaxSize int `json:"logMaxSize,omitempty"`
+	// DisableLogBackend disables writing the audit events to the log files
+	// on the control plane instances. Requires the webhook backend.
+	// Default value is false.
+	DisableLogBackend bool `json:"disableLogBackend,omitempty"`
+	// Webhook configures the audit webhook backend, which sends the audit
+	// events to an external collector.
+	// More info: https://kubernetes.io/docs/tasks/debug-application-cluster/audit/#webhook-backend
+	Webhook *AuditWebhookConfig `json:"webhook,omitempty"`
+}
+
+// AuditWebhookConfig configures the audit webhook backend
+type AuditWebhookConfig struct {
+	// ConfigFilePath is a path on local file system to the kubeconfig file
+	// describing the remote collector. The credentials must be embedded in
+	// the kubeconfig file.
+	// ConfigFilePath is a required field.
+	ConfigFilePath string `json:"configFilePath"`
+	// Mode is the strategy for sending the audit events, batch, blocking or
+	// blocking-strict.
+	// Default value is batch.
+	Mode string `json:"mode,omitempty"`
+	// InitialBackoff is the time to wait before retrying the first failed request.
+	// Default value is 10s.
+	InitialBackoff metav1.Duration `json:"initialBackoff,omitempty"`
+	// BatchBufferSize is the number of events buffered before batching.
+	// Used only in the batch mode. Default value is 10000.
+	BatchBufferSize int `json:"batchBufferSize,omitempty"`
+	// BatchMaxSize is the maximum number of events in a batch.
+	// Used only in the batch mode. Default value is 400.
+	BatchMaxSize int `json:"batchMaxSize,omitempty"`
+	// BatchMaxWait is the time to wait before sending a batch that is not full.
+	// Used only in the batch mode. Default value is 30s.
+	BatchMaxWait metav1.Duration `json:"batchMaxWait,omitempty"`
 }
 
 // DynamicAuditLog feature flag
@@ -562,6 +1486,40 @@ type MetricsServer struct {
 	Enable bool `json:"enable,omitempty"`
 }
 
+// NodeLocalDNS feature flag
+type NodeLocalDNS struct {
+	// Enable deployment of the NodeLocalDNS cache on all nodes. Kubelet is
+	// configured to use the cache instead of the cluster DNS service.
+	// Disabling the feature doesn't remove the cache from the existing
+	// clusters, and the kubelet configuration of the existing nodes is not
+	// changed until the nodes are upgraded.
+	// Default value is true.
+	Enable bool `json:"enable,omitempty"`
+	// Config
+	Config NodeLocalDNSConfig `json:"config,omitempty"`
+}
+
+// NodeLocalDNSConfig configures the NodeLocalDNS cache
+// More info: https://kubernetes.io/docs/tasks/administer-cluster/nodelocaldns/
+type NodeLocalDNSConfig struct {
+	// VirtualIP is the link-local IPv4 address the cache binds to on all
+	// nodes.
+	// Default value is "169.254.20.10".
+	VirtualIP string `json:"virtualIP,omitempty"`
+	// VirtualIPv6 is the IPv6 address the cache binds to on all nodes in
+	// clusters with the IPv6 service subnet.
+	// Default value is "fd00::10".
+	VirtualIPv6 string `json:"virtualIPv6,omitempty"`
+	// UpstreamServers are the resolvers the queries outside of the cluster
+	// domain are forwarded to, as IP addresses with the optional port.
+	// Default is the resolvers configured in /etc/resolv.conf of the nodes.
+	UpstreamServers []string `json:"upstreamServers,omitempty"`
+	// ForceTCP forces TCP for the queries forwarded to the cluster DNS
+	// service and the upstream servers.
+	// Default value is true.
+	ForceTCP *bool `json:"forceTCP,omitempty"`
+}
+
 // OpenIDConnect feature flag
 type OpenIDConnect struct {
 	// Enable
@@ -602,6 +1560,53 @@ type Addon struct {
 
 	// Delete flag to ensure the named addon with all its contents to be deleted
 	Delete bool `json:"delete,omitempty"`
+
+	// Priority orders the addons that don't depend on each other. Addons
+	// with the lower priority are applied first, and addons with the same
+	// priority are applied in the alphabetical order. Addons from the addons
+	// directory that are not configured here have the priority 0.
+	Priority int `json:"priority,omitempty"`
+
+	// DependsOn is a list of names of the addons that must be applied before
+	// this addon, such as the addon deploying CRDs used by this addon
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// WaitReady waits for the CustomResourceDefinitions, Deployments,
+	// StatefulSets and DaemonSets of the addon to become ready before
+	// applying the next addon
+	WaitReady bool `json:"waitReady,omitempty"`
+
+	// Helm deploys the addon from the Helm chart instead of the manifests in
+	// the addons directory. The chart is rendered by KubeOne and the rendered
+	// manifests are applied and pruned the same way as the other addons.
+	Helm *HelmChart `json:"helm,omitempty"`
+}
+
+// HelmChart describes the Helm chart to deploy the addon from
+type HelmChart struct {
+	// Chart is the name of the chart in the chart repository, the OCI
+	// reference of the chart (oci://registry/repository/chart), or the path
+	// to the chart on the local file system
+	Chart string `json:"chart"`
+
+	// RepoURL is the URL of the chart repository. Not used with OCI
+	// references and local charts.
+	RepoURL string `json:"repoURL,omitempty"`
+
+	// Version of the chart, the latest version is used if not set
+	Version string `json:"version,omitempty"`
+
+	// Namespace to deploy the chart to, defaults to kube-system
+	Namespace string `json:"namespace,omitempty"`
+
+	// ValuesFiles is a list of paths to the values files. In case when the
+	// relative path is provided, the path is relative to the KubeOne
+	// configuration file.
+	ValuesFiles []string `json:"valuesFiles,omitempty"`
+
+	// Values is the inline YAML with the values, overriding the values from
+	// the ValuesFiles
+	Values string `json:"values,omitempty"`
 }
 
 // Addons config
@@ -612,6 +1617,13 @@ type Addons struct {
 	// Path on the local file system to the directory with addons manifests.
 	Path string `json:"path,omitempty"`
 
+	// Sources is a list of remote sources of addons manifests, such as git
+	// repositories, HTTPS tarballs and S3 objects. Sources are downloaded to
+	// the local cache and merged with the directory from Path. If the same
+	// file exists in multiple places, the one from Path takes precedence,
+	// followed by the sources in the listed order.
+	Sources []AddonSource `json:"sources,omitempty"`
+
 	// GlobalParams to the addon, to render all addons using text/template
 	GlobalParams map[string]string `json:"globalParams,omitempty"`
 
@@ -619,10 +1631,482 @@ type Addons struct {
 	Addons []Addon `json:"addons,omitempty"`
 }
 
+// AddonSource describes the remote source of addons manifests. Exactly one
+// of Git, HTTP and S3 must be specified.
+type AddonSource struct {
+	// Git fetches addons manifests from the git repository
+	Git *GitAddonSource `json:"git,omitempty"`
+	// HTTP fetches addons manifests from the .tar.gz archive served over HTTPS
+	HTTP *HTTPAddonSource `json:"http,omitempty"`
+	// S3 fetches addons manifests from the .tar.gz archive stored in the AWS
+	// S3 (or S3-compatible) bucket. Credentials are sourced from the standard
+	// AWS environment variables, shared configuration files or the instance
+	// role.
+	S3 *S3AddonSource `json:"s3,omitempty"`
+}
+
+// GitAddonSource describes the git repository with addons manifests
+type GitAddonSource struct {
+	// Repository is the https:// or ssh:// URL of the git repository, cloned
+	// using the git binary available on the machine running KubeOne
+	Repository string `json:"repository"`
+	// Commit is the full SHA of the commit to check out. It pins the addons
+	// manifests the same way the checksum does for archives.
+	Commit string `json:"commit"`
+	// Path is the directory in the repository with addons manifests.
+	// Defaults to the repository root.
+	Path string `json:"path,omitempty"`
+}
+
+// HTTPAddonSource describes the .tar.gz archive with addons manifests
+// served over HTTPS
+type HTTPAddonSource struct {
+	// URL of the archive
+	URL string `json:"url"`
+	// SHA256 is the hex-encoded SHA256 checksum of the archive. The archive
+	// is verified against the checksum and cached under it, so it's
+	// downloaded only once.
+	SHA256 string `json:"sha256"`
+	// Path is the directory in the archive with addons manifests. Defaults
+	// to the archive root.
+	Path string `json:"path,omitempty"`
+}
+
+// S3AddonSource describes the .tar.gz archive with addons manifests stored
+// in the S3 bucket
+type S3AddonSource struct {
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket"`
+	// Key is the key of the archive object
+	Key string `json:"key"`
+	// Region is the region of the bucket
+	Region string `json:"region,omitempty"`
+	// Endpoint overrides the S3 endpoint, used for S3-compatible storages
+	Endpoint string `json:"endpoint,omitempty"`
+	// SHA256 is the hex-encoded SHA256 checksum of the archive. The archive
+	// is verified against the checksum and cached under it, so it's
+	// downloaded only once.
+	SHA256 string `json:"sha256"`
+	// Path is the directory in the archive with addons manifests. Defaults
+	// to the archive root.
+	Path string `json:"path,omitempty"`
+}
+
 // Encryption Providers feature flag
 type EncryptionProviders struct {
 	// Enable
 	Enable bool `json:"enable"`
 	// CustomEncryptionConfiguration
 	CustomEncryptionConfiguration string `json:"customEncryptionConfiguration"`
+	// KMS configures the KMS provider used to encrypt the Secrets. The
+	// EncryptionConfiguration is generated from it, so it's mutually
+	// exclusive with the CustomEncryptionConfiguration.
+	KMS *EncryptionProvidersKMS `json:"kms,omitempty"`
+}
+
+// EncryptionProvidersKMS configures the KMS provider
+type EncryptionProvidersKMS struct {
+	// Name is the name of the KMS plugin used in the EncryptionConfiguration
+	Name string `json:"name"`
+	// Endpoint is the path to the unix socket the KMS plugin listens on.
+	// Default value is /var/run/kmsplugin/socket.sock.
+	Endpoint string `json:"endpoint,omitempty"`
+	// CacheSize is the number of data encryption keys cached in the memory
+	// by kube-apiserver. Default value is 1000.
+	CacheSize int `json:"cacheSize,omitempty"`
+	// Timeout is the timeout for kube-apiserver calls to the KMS plugin.
+	// Default value is 3s.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// Plugin configures the KMS plugin deployed by KubeOne as a static pod on
+	// the control plane nodes. If not set, the KMS plugin listening on the
+	// Endpoint has to be deployed on the control plane nodes by the user.
+	Plugin *KMSPlugin `json:"plugin,omitempty"`
+}
+
+// KMSPlugin configures the KMS plugin deployed by KubeOne. Only one of
+// the providers can be set.
+type KMSPlugin struct {
+	// Image overrides the default image of the KMS plugin
+	Image string `json:"image,omitempty"`
+	// AWS deploys the AWS Encryption Provider
+	AWS *AWSKMSPlugin `json:"aws,omitempty"`
+	// Azure deploys the Azure Key Vault KMS plugin
+	Azure *AzureKMSPlugin `json:"azure,omitempty"`
+	// GCP deploys the Google Cloud KMS plugin
+	GCP *GCPKMSPlugin `json:"gcp,omitempty"`
+}
+
+// AWSKMSPlugin configures the AWS Encryption Provider. Credentials are
+// sourced from the instance profile of the control plane nodes.
+type AWSKMSPlugin struct {
+	// KeyARN is the ARN of the AWS KMS key
+	KeyARN string `json:"keyARN"`
+	// Region is the region of the AWS KMS key
+	Region string `json:"region"`
+}
+
+// AzureKMSPlugin configures the Azure Key Vault KMS plugin. Credentials are
+// sourced from the cloud-config, so the Azure cloud provider has to be used.
+type AzureKMSPlugin struct {
+	// KeyVaultName is the name of the Azure Key Vault
+	KeyVaultName string `json:"keyVaultName"`
+	// KeyName is the name of the key in the Azure Key Vault
+	KeyName string `json:"keyName"`
+	// KeyVersion is the version of the key in the Azure Key Vault
+	KeyVersion string `json:"keyVersion"`
+}
+
+// GCPKMSPlugin configures the Google Cloud KMS plugin. Credentials are
+// sourced from the service account of the control plane nodes.
+type GCPKMSPlugin struct {
+	// KeyURI is the resource ID of the Cloud KMS key, in the
+	// projects/<project>/locations/<location>/keyRings/<keyring>/cryptoKeys/<key>
+	// format
+	KeyURI string `json:"keyURI"`
+}
+
+// FIPS feature flag
+type FIPS struct {
+	// Enable FIPS-compliant deployment mode. When enabled, only FIPS-approved
+	// TLS versions and cipher suites are allowed for kube-apiserver, etcd and
+	// kubelet, and all hosts must be running a kernel booted in FIPS mode.
+	// The hosts are checked by the host preflight checks before anything is
+	// changed. KubeOne doesn't replace the Kubernetes images and binaries with
+	// FIPS-validated builds, which can be provided using the
+	// .assetConfiguration.
+	Enable bool `json:"enable,omitempty"`
+}
+
+// SELinux feature flag
+type SELinux struct {
+	// Enable keeps SELinux in the enforcing mode on RHEL-family hosts (CentOS
+	// and RHEL) instead of switching it to the permissive mode. container-selinux
+	// policies are installed and SELinux contexts are set for the kubelet, etcd
+	// and CNI directories.
+	Enable bool `json:"enable,omitempty"`
+}
+
+// AppArmor feature flag
+type AppArmor struct {
+	// Enable deployment of custom AppArmor profiles to all hosts
+	Enable bool `json:"enable,omitempty"`
+	// Profiles is a list of AppArmor profiles to be installed into
+	// /etc/apparmor.d and loaded on all hosts
+	Profiles []AppArmorProfile `json:"profiles,omitempty"`
+}
+
+// AppArmorProfile describes a single AppArmor profile
+type AppArmorProfile struct {
+	// Name of the file in /etc/apparmor.d the profile is installed to
+	Name string `json:"name"`
+	// ProfileFilePath is a path on the local file system to the AppArmor
+	// profile. The path is relative to the KubeOne configuration file.
+	ProfileFilePath string `json:"profileFilePath"`
+}
+
+// SeccompDefault feature flag
+type SeccompDefault struct {
+	// Enable the kubelet SeccompDefault setting on all nodes, so the
+	// RuntimeDefault seccomp profile is used by default for all workloads.
+	// Requires Kubernetes 1.22 or newer.
+	Enable bool `json:"enable,omitempty"`
+}
+
+// BootstrapRBAC feature flag
+type BootstrapRBAC struct {
+	// Enable deployment of an opinionated set of ClusterRoles and bindings
+	// mapped to the groups provided by the OpenIDConnect feature.
+	// Group names are prefixed with .features.openidConnect.config.groupsPrefix.
+	Enable bool `json:"enable,omitempty"`
+	// Viewers is a list of groups granted read-only access to the cluster
+	Viewers []string `json:"viewers,omitempty"`
+	// Operators is a list of groups granted permissions to manage workloads
+	// in all namespaces and to cordon and drain nodes
+	Operators []string `json:"operators,omitempty"`
+	// NamespaceAdmins is a list of groups granted admin permissions in the
+	// given namespaces
+	NamespaceAdmins []NamespaceAdmins `json:"namespaceAdmins,omitempty"`
+}
+
+// NamespaceAdmins grants admin permissions in a namespace to a list of groups
+type NamespaceAdmins struct {
+	// Namespace in which admin permissions are granted. The namespace is
+	// created if it doesn't exist.
+	Namespace string `json:"namespace"`
+	// Groups granted admin permissions in the namespace
+	Groups []string `json:"groups"`
+}
+
+// KubeVIP feature flag
+type KubeVIP struct {
+	// Enable deploys kube-vip as a static pod on the control plane nodes.
+	// kube-vip announces .apiEndpoint.host, which must be an unused IP
+	// address in the control plane nodes network, using ARP, so an external
+	// load balancer is not needed.
+	Enable bool `json:"enable,omitempty"`
+	// Interface is the network interface the virtual IP is announced on.
+	// Defaults to the interface of the default route.
+	Interface string `json:"interface,omitempty"`
+}
+
+// Konnectivity feature flag
+type Konnectivity struct {
+	// Enable deploys konnectivity-server as a static pod on the control plane
+	// nodes and konnectivity-agent on all nodes, and configures kube-apiserver
+	// to reach the nodes, pods and services through the tunnels opened by the
+	// agents. It's used when kube-apiserver can't reach the kubelets directly,
+	// e.g. when the nodes are behind NAT or a firewall.
+	Enable bool `json:"enable,omitempty"`
+	// Config
+	Config KonnectivityConfig `json:"config,omitempty"`
+}
+
+// KonnectivityConfig configures the Konnectivity deployment
+// More info: https://kubernetes.io/docs/tasks/extend-kubernetes/setup-konnectivity/
+type KonnectivityConfig struct {
+	// ServerAddress is the address konnectivity-agent connects to. It must
+	// forward the agent port to konnectivity-server on the control plane
+	// nodes, and it must be covered by the kube-apiserver certificate, e.g.
+	// by listing it in .apiEndpoint.alternativeNames.
+	// Default value is .apiEndpoint.host.
+	ServerAddress string `json:"serverAddress,omitempty"`
+	// AgentPort is the port konnectivity-server listens on for the agents.
+	// Default value is 8132.
+	AgentPort int `json:"agentPort,omitempty"`
+}
+
+// Gatekeeper feature flag
+type Gatekeeper struct {
+	// Enable deployment of the OPA Gatekeeper policy controller
+	Enable bool `json:"enable,omitempty"`
+	// Config
+	Config GatekeeperConfig `json:"config,omitempty"`
+}
+
+// GatekeeperConfig configures the OPA Gatekeeper deployment
+// More info: https://open-policy-agent.github.io/gatekeeper/website/docs/
+type GatekeeperConfig struct {
+	// AuditInterval is the interval in seconds between the audits of the
+	// existing resources against the constraints.
+	// Default value is 60.
+	AuditInterval int `json:"auditInterval,omitempty"`
+	// ConstraintLibrary deploys the ConstraintTemplates of the commonly used
+	// policies from the Gatekeeper policy library. Constraints using the
+	// templates must be created by the user.
+	ConstraintLibrary bool `json:"constraintLibrary,omitempty"`
+}
+
+// SRIOV feature flag
+type SRIOV struct {
+	// Enable configuration of SR-IOV virtual functions on the given hosts and
+	// deployment of the SR-IOV network device plugin and CNI plugin
+	Enable bool `json:"enable,omitempty"`
+	// KernelParameters are added to the kernel command line of the given
+	// hosts to enable IOMMU. Hosts must be rebooted for the parameters to
+	// take effect.
+	// Default value is ["intel_iommu=on", "iommu=pt"].
+	KernelParameters []string `json:"kernelParameters,omitempty"`
+	// Hosts is a list of hosts on which virtual functions are configured
+	Hosts []SRIOVHost `json:"hosts,omitempty"`
+}
+
+// SRIOVHost configures SR-IOV virtual functions on a single host
+type SRIOVHost struct {
+	// Address is the public address, the private address or the explicitly
+	// configured hostname of a control plane or static worker host
+	Address string `json:"address"`
+	// PhysicalFunctions is a list of physical functions to create virtual
+	// functions for
+	PhysicalFunctions []SRIOVPhysicalFunction `json:"physicalFunctions"`
+}
+
+// SRIOVPhysicalFunction configures virtual functions of a physical function
+type SRIOVPhysicalFunction struct {
+	// Interface is the name of the network interface of the physical function
+	Interface string `json:"interface"`
+	// NumVFs is the number of virtual functions to create
+	NumVFs int `json:"numVFs"`
+	// ResourceName is the name of the resource the virtual functions are
+	// advertised as by the device plugin, prefixed with "intel.com/".
+	// Default value is "sriov_<interface>".
+	ResourceName string `json:"resourceName,omitempty"`
+}
+
+// StateBackend configures the remote storage for the KubeOne state. Only one
+// storage can be configured.
+type StateBackend struct {
+	// S3 stores the state in the AWS S3 (or S3-compatible) bucket.
+	// Credentials are sourced from the standard AWS environment variables,
+	// shared configuration files or the instance role.
+	S3 *S3StateBackend `json:"s3,omitempty"`
+	// GCS stores the state in the Google Cloud Storage bucket. The OAuth2
+	// access token is sourced from the GOOGLE_OAUTH_ACCESS_TOKEN environment
+	// variable.
+	GCS *GCSStateBackend `json:"gcs,omitempty"`
+	// AzureBlob stores the state in the Azure Blob Storage container. The SAS
+	// token is sourced from the AZURE_STORAGE_SAS_TOKEN environment variable.
+	AzureBlob *AzureBlobStateBackend `json:"azureBlob,omitempty"`
+	// Local stores the state in the local directory, such as the shared
+	// filesystem mounted on all machines running KubeOne.
+	Local *LocalStateBackend `json:"local,omitempty"`
+	// KubernetesSecret stores the state in the Secrets in the cluster
+	// provisioned by KubeOne. The state is stored once the cluster is
+	// provisioned, and the lock and the apply progress are kept outside of
+	// the backend, as the cluster may not be reachable.
+	KubernetesSecret *KubernetesSecretStateBackend `json:"kubernetesSecret,omitempty"`
+}
+
+// Backups configures the etcd snapshots
+type Backups struct {
+	// Storage is the remote storage the etcd snapshots are uploaded to,
+	// under the etcd-snapshots prefix. Snapshots are only saved to the local
+	// directory if the storage is not configured.
+	Storage *StateBackend `json:"storage,omitempty"`
+}
+
+// S3StateBackend describes the S3 bucket storing the state
+type S3StateBackend struct {
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket"`
+	// Region is the region of the bucket
+	Region string `json:"region,omitempty"`
+	// Endpoint overrides the S3 endpoint, used for S3-compatible storages
+	Endpoint string `json:"endpoint,omitempty"`
+	// Prefix is prepended to the keys of all objects
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// GCSStateBackend describes the Google Cloud Storage bucket storing the state
+type GCSStateBackend struct {
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to the names of all objects
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// AzureBlobStateBackend describes the Azure Blob Storage container storing
+// the state
+type AzureBlobStateBackend struct {
+	// StorageAccount is the name of the storage account
+	StorageAccount string `json:"storageAccount"`
+	// Container is the name of the container
+	Container string `json:"container"`
+	// Prefix is prepended to the names of all blobs
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// LocalStateBackend describes the local directory storing the state
+type LocalStateBackend struct {
+	// Path is the directory storing the state. Relative paths are relative
+	// to the working directory of KubeOne.
+	Path string `json:"path"`
+}
+
+// KubernetesSecretStateBackend describes the Secrets storing the state in
+// the cluster provisioned by KubeOne
+type KubernetesSecretStateBackend struct {
+	// Namespace is the namespace of the Secrets.
+	// Default value is "kube-system".
+	Namespace string `json:"namespace,omitempty"`
+	// Prefix is prepended to the keys of all objects
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// Hooks are the local commands and webhooks run before and after the KubeOne
+// operations, such as to silence alerts or to notify a chat channel. Hooks
+// are run one by one in the order they are defined. The post hooks are run
+// even if the operation fails.
+type Hooks struct {
+	// PreApply hooks are run before reconciling the cluster with kubeone apply
+	PreApply []Hook `json:"preApply,omitempty"`
+	// PostApply hooks are run after reconciling the cluster with kubeone apply
+	PostApply []Hook `json:"postApply,omitempty"`
+	// PreUpgrade hooks are run before upgrading the cluster
+	PreUpgrade []Hook `json:"preUpgrade,omitempty"`
+	// PostUpgrade hooks are run after upgrading the cluster
+	PostUpgrade []Hook `json:"postUpgrade,omitempty"`
+	// PreReset hooks are run before resetting the cluster
+	PreReset []Hook `json:"preReset,omitempty"`
+	// PostReset hooks are run after resetting the cluster
+	PostReset []Hook `json:"postReset,omitempty"`
+	// PreNodeUpgrade hooks are run before upgrading each control plane and
+	// static worker node, such as to take the node out of the external load
+	// balancer
+	PreNodeUpgrade []Hook `json:"preNodeUpgrade,omitempty"`
+	// PostNodeUpgrade hooks are run after upgrading each control plane and
+	// static worker node
+	PostNodeUpgrade []Hook `json:"postNodeUpgrade,omitempty"`
+	// Host are the scripts run on the control plane and static worker nodes
+	// over SSH
+	Host *HostHooks `json:"host,omitempty"`
+}
+
+// Hook is the local command or the webhook. Only one of them can be set.
+//
+// The operation is passed to the command in the KUBEONE_HOOK_EVENT,
+// KUBEONE_CLUSTER_NAME, KUBEONE_NODE_HOSTNAME, KUBEONE_NODE_ADDRESS and
+// KUBEONE_ERROR environment variables, and to the webhook as the JSON body of
+// the POST request.
+type Hook struct {
+	// Name of the hook, used in the logs
+	Name string `json:"name,omitempty"`
+	// Command is run on the machine running KubeOne using /bin/sh
+	Command string `json:"command,omitempty"`
+	// Webhook is called with the POST request
+	Webhook *WebhookHook `json:"webhook,omitempty"`
+	// Timeout of the hook, such as "30s".
+	// Default value is 1m.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// IgnoreFailure logs the failure of the hook instead of failing the
+	// operation
+	IgnoreFailure bool `json:"ignoreFailure,omitempty"`
+}
+
+// WebhookHook describes the webhook called by the hook
+type WebhookHook struct {
+	// URL of the webhook. Must be the http or https URL. The value prefixed
+	// with "env:" is read from the environment variable, such as
+	// "env:ALERTMANAGER_URL".
+	URL string `json:"url"`
+	// Headers are the additional HTTP headers of the request. Values prefixed
+	// with "env:" are read from the environment variables, such as
+	// "env:ALERTMANAGER_TOKEN".
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// HostHooks are the scripts run on the control plane and static worker nodes
+// at the defined points of the node lifecycle. Scripts are run one by one in
+// the order they are defined, on each node separately.
+type HostHooks struct {
+	// PreProvision scripts are run before installing the prerequisites, such
+	// as to configure the internal package mirrors
+	PreProvision []HostScript `json:"preProvision,omitempty"`
+	// PostJoin scripts are run after the node is initialized or joined to the
+	// cluster with kubeadm
+	PostJoin []HostScript `json:"postJoin,omitempty"`
+	// PreDrain scripts are run before cordoning and draining the node when
+	// upgrading it
+	PreDrain []HostScript `json:"preDrain,omitempty"`
+	// PostUpgrade scripts are run after the node is upgraded
+	PostUpgrade []HostScript `json:"postUpgrade,omitempty"`
+}
+
+// HostScript is the script run on the node using the SSH user. Only one of
+// Inline and File can be set.
+//
+// The script is rendered as the Go template before running it. The
+// .CLUSTER_NAME, .KUBERNETES_VERSION, .HOSTNAME, .PUBLIC_ADDRESS,
+// .PRIVATE_ADDRESS, .NODE_ID, .CONTROL_PLANE and .LEADER variables describe
+// the node the script is run on.
+type HostScript struct {
+	// Name of the script, used in the logs
+	Name string `json:"name,omitempty"`
+	// Inline is the content of the script
+	Inline string `json:"inline,omitempty"`
+	// File is the path to the script. Relative path is relative to the
+	// KubeOne configuration file.
+	File string `json:"file,omitempty"`
+	// IgnoreFailure logs the failure of the script instead of failing the
+	// operation
+	IgnoreFailure bool `json:"ignoreFailure,omitempty"`
 }
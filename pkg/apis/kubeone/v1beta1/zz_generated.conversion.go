@@ -47,6 +47,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*APIServerConfig)(nil), (*kubeone.APIServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_APIServerConfig_To_kubeone_APIServerConfig(a.(*APIServerConfig), b.(*kubeone.APIServerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.APIServerConfig)(nil), (*APIServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_APIServerConfig_To_v1beta1_APIServerConfig(a.(*kubeone.APIServerConfig), b.(*APIServerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AWSKMSPlugin)(nil), (*kubeone.AWSKMSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AWSKMSPlugin_To_kubeone_AWSKMSPlugin(a.(*AWSKMSPlugin), b.(*kubeone.AWSKMSPlugin), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.AWSKMSPlugin)(nil), (*AWSKMSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AWSKMSPlugin_To_v1beta1_AWSKMSPlugin(a.(*kubeone.AWSKMSPlugin), b.(*AWSKMSPlugin), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AWSSpec)(nil), (*kubeone.AWSSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AWSSpec_To_kubeone_AWSSpec(a.(*AWSSpec), b.(*kubeone.AWSSpec), scope)
 	}); err != nil {
@@ -67,6 +87,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AddonSource)(nil), (*kubeone.AddonSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AddonSource_To_kubeone_AddonSource(a.(*AddonSource), b.(*kubeone.AddonSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.AddonSource)(nil), (*AddonSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AddonSource_To_v1beta1_AddonSource(a.(*kubeone.AddonSource), b.(*AddonSource), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Addons)(nil), (*kubeone.Addons)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Addons_To_kubeone_Addons(a.(*Addons), b.(*kubeone.Addons), scope)
 	}); err != nil {
@@ -77,438 +107,1228 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*AssetConfiguration)(nil), (*kubeone.AssetConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_AssetConfiguration_To_kubeone_AssetConfiguration(a.(*AssetConfiguration), b.(*kubeone.AssetConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*AlwaysPullImages)(nil), (*kubeone.AlwaysPullImages)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AlwaysPullImages_To_kubeone_AlwaysPullImages(a.(*AlwaysPullImages), b.(*kubeone.AlwaysPullImages), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.AssetConfiguration)(nil), (*AssetConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_AssetConfiguration_To_v1beta1_AssetConfiguration(a.(*kubeone.AssetConfiguration), b.(*AssetConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.AlwaysPullImages)(nil), (*AlwaysPullImages)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AlwaysPullImages_To_v1beta1_AlwaysPullImages(a.(*kubeone.AlwaysPullImages), b.(*AlwaysPullImages), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*AzureSpec)(nil), (*kubeone.AzureSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_AzureSpec_To_kubeone_AzureSpec(a.(*AzureSpec), b.(*kubeone.AzureSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*AppArmor)(nil), (*kubeone.AppArmor)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AppArmor_To_kubeone_AppArmor(a.(*AppArmor), b.(*kubeone.AppArmor), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.AzureSpec)(nil), (*AzureSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_AzureSpec_To_v1beta1_AzureSpec(a.(*kubeone.AzureSpec), b.(*AzureSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.AppArmor)(nil), (*AppArmor)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AppArmor_To_v1beta1_AppArmor(a.(*kubeone.AppArmor), b.(*AppArmor), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*BinaryAsset)(nil), (*kubeone.BinaryAsset)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_BinaryAsset_To_kubeone_BinaryAsset(a.(*BinaryAsset), b.(*kubeone.BinaryAsset), scope)
+	if err := s.AddGeneratedConversionFunc((*AppArmorProfile)(nil), (*kubeone.AppArmorProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AppArmorProfile_To_kubeone_AppArmorProfile(a.(*AppArmorProfile), b.(*kubeone.AppArmorProfile), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.BinaryAsset)(nil), (*BinaryAsset)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_BinaryAsset_To_v1beta1_BinaryAsset(a.(*kubeone.BinaryAsset), b.(*BinaryAsset), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.AppArmorProfile)(nil), (*AppArmorProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AppArmorProfile_To_v1beta1_AppArmorProfile(a.(*kubeone.AppArmorProfile), b.(*AppArmorProfile), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*CNI)(nil), (*kubeone.CNI)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_CNI_To_kubeone_CNI(a.(*CNI), b.(*kubeone.CNI), scope)
+	if err := s.AddGeneratedConversionFunc((*AssetCache)(nil), (*kubeone.AssetCache)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AssetCache_To_kubeone_AssetCache(a.(*AssetCache), b.(*kubeone.AssetCache), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.CNI)(nil), (*CNI)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_CNI_To_v1beta1_CNI(a.(*kubeone.CNI), b.(*CNI), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.AssetCache)(nil), (*AssetCache)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AssetCache_To_v1beta1_AssetCache(a.(*kubeone.AssetCache), b.(*AssetCache), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*CanalSpec)(nil), (*kubeone.CanalSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_CanalSpec_To_kubeone_CanalSpec(a.(*CanalSpec), b.(*kubeone.CanalSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*AssetConfiguration)(nil), (*kubeone.AssetConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AssetConfiguration_To_kubeone_AssetConfiguration(a.(*AssetConfiguration), b.(*kubeone.AssetConfiguration), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.CanalSpec)(nil), (*CanalSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_CanalSpec_To_v1beta1_CanalSpec(a.(*kubeone.CanalSpec), b.(*CanalSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.AssetConfiguration)(nil), (*AssetConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AssetConfiguration_To_v1beta1_AssetConfiguration(a.(*kubeone.AssetConfiguration), b.(*AssetConfiguration), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*CloudProviderSpec)(nil), (*kubeone.CloudProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_CloudProviderSpec_To_kubeone_CloudProviderSpec(a.(*CloudProviderSpec), b.(*kubeone.CloudProviderSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*AuditWebhookConfig)(nil), (*kubeone.AuditWebhookConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AuditWebhookConfig_To_kubeone_AuditWebhookConfig(a.(*AuditWebhookConfig), b.(*kubeone.AuditWebhookConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.CloudProviderSpec)(nil), (*CloudProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_CloudProviderSpec_To_v1beta1_CloudProviderSpec(a.(*kubeone.CloudProviderSpec), b.(*CloudProviderSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.AuditWebhookConfig)(nil), (*AuditWebhookConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AuditWebhookConfig_To_v1beta1_AuditWebhookConfig(a.(*kubeone.AuditWebhookConfig), b.(*AuditWebhookConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ClusterNetworkConfig)(nil), (*kubeone.ClusterNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ClusterNetworkConfig_To_kubeone_ClusterNetworkConfig(a.(*ClusterNetworkConfig), b.(*kubeone.ClusterNetworkConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*AzureBlobStateBackend)(nil), (*kubeone.AzureBlobStateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AzureBlobStateBackend_To_kubeone_AzureBlobStateBackend(a.(*AzureBlobStateBackend), b.(*kubeone.AzureBlobStateBackend), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ClusterNetworkConfig)(nil), (*ClusterNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(a.(*kubeone.ClusterNetworkConfig), b.(*ClusterNetworkConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.AzureBlobStateBackend)(nil), (*AzureBlobStateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AzureBlobStateBackend_To_v1beta1_AzureBlobStateBackend(a.(*kubeone.AzureBlobStateBackend), b.(*AzureBlobStateBackend), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ContainerRuntimeConfig)(nil), (*kubeone.ContainerRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ContainerRuntimeConfig_To_kubeone_ContainerRuntimeConfig(a.(*ContainerRuntimeConfig), b.(*kubeone.ContainerRuntimeConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*AzureKMSPlugin)(nil), (*kubeone.AzureKMSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AzureKMSPlugin_To_kubeone_AzureKMSPlugin(a.(*AzureKMSPlugin), b.(*kubeone.AzureKMSPlugin), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerRuntimeConfig)(nil), (*ContainerRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(a.(*kubeone.ContainerRuntimeConfig), b.(*ContainerRuntimeConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.AzureKMSPlugin)(nil), (*AzureKMSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AzureKMSPlugin_To_v1beta1_AzureKMSPlugin(a.(*kubeone.AzureKMSPlugin), b.(*AzureKMSPlugin), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ContainerRuntimeContainerd)(nil), (*kubeone.ContainerRuntimeContainerd)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeContainerd(a.(*ContainerRuntimeContainerd), b.(*kubeone.ContainerRuntimeContainerd), scope)
+	if err := s.AddGeneratedConversionFunc((*AzureSpec)(nil), (*kubeone.AzureSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AzureSpec_To_kubeone_AzureSpec(a.(*AzureSpec), b.(*kubeone.AzureSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerRuntimeContainerd)(nil), (*ContainerRuntimeContainerd)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(a.(*kubeone.ContainerRuntimeContainerd), b.(*ContainerRuntimeContainerd), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.AzureSpec)(nil), (*AzureSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_AzureSpec_To_v1beta1_AzureSpec(a.(*kubeone.AzureSpec), b.(*AzureSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ContainerRuntimeDocker)(nil), (*kubeone.ContainerRuntimeDocker)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(a.(*ContainerRuntimeDocker), b.(*kubeone.ContainerRuntimeDocker), scope)
+	if err := s.AddGeneratedConversionFunc((*Backups)(nil), (*kubeone.Backups)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Backups_To_kubeone_Backups(a.(*Backups), b.(*kubeone.Backups), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerRuntimeDocker)(nil), (*ContainerRuntimeDocker)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker(a.(*kubeone.ContainerRuntimeDocker), b.(*ContainerRuntimeDocker), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.Backups)(nil), (*Backups)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Backups_To_v1beta1_Backups(a.(*kubeone.Backups), b.(*Backups), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*kubeone.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*kubeone.ControlPlaneConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*BastionConfig)(nil), (*kubeone.BastionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BastionConfig_To_kubeone_BastionConfig(a.(*BastionConfig), b.(*kubeone.BastionConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ControlPlaneConfig)(nil), (*ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig(a.(*kubeone.ControlPlaneConfig), b.(*ControlPlaneConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.BastionConfig)(nil), (*BastionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_BastionConfig_To_v1beta1_BastionConfig(a.(*kubeone.BastionConfig), b.(*BastionConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*DNSConfig)(nil), (*kubeone.DNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_DNSConfig_To_kubeone_DNSConfig(a.(*DNSConfig), b.(*kubeone.DNSConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*BinaryAsset)(nil), (*kubeone.BinaryAsset)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BinaryAsset_To_kubeone_BinaryAsset(a.(*BinaryAsset), b.(*kubeone.BinaryAsset), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.DNSConfig)(nil), (*DNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_DNSConfig_To_v1beta1_DNSConfig(a.(*kubeone.DNSConfig), b.(*DNSConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.BinaryAsset)(nil), (*BinaryAsset)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_BinaryAsset_To_v1beta1_BinaryAsset(a.(*kubeone.BinaryAsset), b.(*BinaryAsset), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*DigitalOceanSpec)(nil), (*kubeone.DigitalOceanSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_DigitalOceanSpec_To_kubeone_DigitalOceanSpec(a.(*DigitalOceanSpec), b.(*kubeone.DigitalOceanSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*BootstrapRBAC)(nil), (*kubeone.BootstrapRBAC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BootstrapRBAC_To_kubeone_BootstrapRBAC(a.(*BootstrapRBAC), b.(*kubeone.BootstrapRBAC), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.DigitalOceanSpec)(nil), (*DigitalOceanSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_DigitalOceanSpec_To_v1beta1_DigitalOceanSpec(a.(*kubeone.DigitalOceanSpec), b.(*DigitalOceanSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.BootstrapRBAC)(nil), (*BootstrapRBAC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_BootstrapRBAC_To_v1beta1_BootstrapRBAC(a.(*kubeone.BootstrapRBAC), b.(*BootstrapRBAC), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*DynamicAuditLog)(nil), (*kubeone.DynamicAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_DynamicAuditLog_To_kubeone_DynamicAuditLog(a.(*DynamicAuditLog), b.(*kubeone.DynamicAuditLog), scope)
+	if err := s.AddGeneratedConversionFunc((*CNI)(nil), (*kubeone.CNI)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CNI_To_kubeone_CNI(a.(*CNI), b.(*kubeone.CNI), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.DynamicAuditLog)(nil), (*DynamicAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_DynamicAuditLog_To_v1beta1_DynamicAuditLog(a.(*kubeone.DynamicAuditLog), b.(*DynamicAuditLog), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CNI)(nil), (*CNI)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CNI_To_v1beta1_CNI(a.(*kubeone.CNI), b.(*CNI), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*DynamicWorkerConfig)(nil), (*kubeone.DynamicWorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_DynamicWorkerConfig_To_kubeone_DynamicWorkerConfig(a.(*DynamicWorkerConfig), b.(*kubeone.DynamicWorkerConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*CalicoBGPPeer)(nil), (*kubeone.CalicoBGPPeer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CalicoBGPPeer_To_kubeone_CalicoBGPPeer(a.(*CalicoBGPPeer), b.(*kubeone.CalicoBGPPeer), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.DynamicWorkerConfig)(nil), (*DynamicWorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(a.(*kubeone.DynamicWorkerConfig), b.(*DynamicWorkerConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CalicoBGPPeer)(nil), (*CalicoBGPPeer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CalicoBGPPeer_To_v1beta1_CalicoBGPPeer(a.(*kubeone.CalicoBGPPeer), b.(*CalicoBGPPeer), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*EncryptionProviders)(nil), (*kubeone.EncryptionProviders)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_EncryptionProviders_To_kubeone_EncryptionProviders(a.(*EncryptionProviders), b.(*kubeone.EncryptionProviders), scope)
+	if err := s.AddGeneratedConversionFunc((*CalicoBGPSpec)(nil), (*kubeone.CalicoBGPSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CalicoBGPSpec_To_kubeone_CalicoBGPSpec(a.(*CalicoBGPSpec), b.(*kubeone.CalicoBGPSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.EncryptionProviders)(nil), (*EncryptionProviders)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_EncryptionProviders_To_v1beta1_EncryptionProviders(a.(*kubeone.EncryptionProviders), b.(*EncryptionProviders), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CalicoBGPSpec)(nil), (*CalicoBGPSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CalicoBGPSpec_To_v1beta1_CalicoBGPSpec(a.(*kubeone.CalicoBGPSpec), b.(*CalicoBGPSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ExternalCNISpec)(nil), (*kubeone.ExternalCNISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ExternalCNISpec_To_kubeone_ExternalCNISpec(a.(*ExternalCNISpec), b.(*kubeone.ExternalCNISpec), scope)
+	if err := s.AddGeneratedConversionFunc((*CalicoSpec)(nil), (*kubeone.CalicoSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CalicoSpec_To_kubeone_CalicoSpec(a.(*CalicoSpec), b.(*kubeone.CalicoSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ExternalCNISpec)(nil), (*ExternalCNISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ExternalCNISpec_To_v1beta1_ExternalCNISpec(a.(*kubeone.ExternalCNISpec), b.(*ExternalCNISpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CalicoSpec)(nil), (*CalicoSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CalicoSpec_To_v1beta1_CalicoSpec(a.(*kubeone.CalicoSpec), b.(*CalicoSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*Features)(nil), (*kubeone.Features)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_Features_To_kubeone_Features(a.(*Features), b.(*kubeone.Features), scope)
+	if err := s.AddGeneratedConversionFunc((*CanalSpec)(nil), (*kubeone.CanalSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CanalSpec_To_kubeone_CanalSpec(a.(*CanalSpec), b.(*kubeone.CanalSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.Features)(nil), (*Features)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_Features_To_v1beta1_Features(a.(*kubeone.Features), b.(*Features), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CanalSpec)(nil), (*CanalSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CanalSpec_To_v1beta1_CanalSpec(a.(*kubeone.CanalSpec), b.(*CanalSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*GCESpec)(nil), (*kubeone.GCESpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_GCESpec_To_kubeone_GCESpec(a.(*GCESpec), b.(*kubeone.GCESpec), scope)
+	if err := s.AddGeneratedConversionFunc((*CiliumHubbleSpec)(nil), (*kubeone.CiliumHubbleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CiliumHubbleSpec_To_kubeone_CiliumHubbleSpec(a.(*CiliumHubbleSpec), b.(*kubeone.CiliumHubbleSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.GCESpec)(nil), (*GCESpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_GCESpec_To_v1beta1_GCESpec(a.(*kubeone.GCESpec), b.(*GCESpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CiliumHubbleSpec)(nil), (*CiliumHubbleSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CiliumHubbleSpec_To_v1beta1_CiliumHubbleSpec(a.(*kubeone.CiliumHubbleSpec), b.(*CiliumHubbleSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*HetznerSpec)(nil), (*kubeone.HetznerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec(a.(*HetznerSpec), b.(*kubeone.HetznerSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*CiliumSpec)(nil), (*kubeone.CiliumSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CiliumSpec_To_kubeone_CiliumSpec(a.(*CiliumSpec), b.(*kubeone.CiliumSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.HetznerSpec)(nil), (*HetznerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec(a.(*kubeone.HetznerSpec), b.(*HetznerSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CiliumSpec)(nil), (*CiliumSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CiliumSpec_To_v1beta1_CiliumSpec(a.(*kubeone.CiliumSpec), b.(*CiliumSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*HostConfig)(nil), (*kubeone.HostConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_HostConfig_To_kubeone_HostConfig(a.(*HostConfig), b.(*kubeone.HostConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*CloudProviderSpec)(nil), (*kubeone.CloudProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CloudProviderSpec_To_kubeone_CloudProviderSpec(a.(*CloudProviderSpec), b.(*kubeone.CloudProviderSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.HostConfig)(nil), (*HostConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_HostConfig_To_v1beta1_HostConfig(a.(*kubeone.HostConfig), b.(*HostConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CloudProviderSpec)(nil), (*CloudProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CloudProviderSpec_To_v1beta1_CloudProviderSpec(a.(*kubeone.CloudProviderSpec), b.(*CloudProviderSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*IPTables)(nil), (*kubeone.IPTables)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_IPTables_To_kubeone_IPTables(a.(*IPTables), b.(*kubeone.IPTables), scope)
+	if err := s.AddGeneratedConversionFunc((*ClusterNetworkConfig)(nil), (*kubeone.ClusterNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterNetworkConfig_To_kubeone_ClusterNetworkConfig(a.(*ClusterNetworkConfig), b.(*kubeone.ClusterNetworkConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.IPTables)(nil), (*IPTables)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_IPTables_To_v1beta1_IPTables(a.(*kubeone.IPTables), b.(*IPTables), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.ClusterNetworkConfig)(nil), (*ClusterNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(a.(*kubeone.ClusterNetworkConfig), b.(*ClusterNetworkConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*IPVSConfig)(nil), (*kubeone.IPVSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_IPVSConfig_To_kubeone_IPVSConfig(a.(*IPVSConfig), b.(*kubeone.IPVSConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*CommandRetryPolicy)(nil), (*kubeone.CommandRetryPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CommandRetryPolicy_To_kubeone_CommandRetryPolicy(a.(*CommandRetryPolicy), b.(*kubeone.CommandRetryPolicy), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.IPVSConfig)(nil), (*IPVSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_IPVSConfig_To_v1beta1_IPVSConfig(a.(*kubeone.IPVSConfig), b.(*IPVSConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CommandRetryPolicy)(nil), (*CommandRetryPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CommandRetryPolicy_To_v1beta1_CommandRetryPolicy(a.(*kubeone.CommandRetryPolicy), b.(*CommandRetryPolicy), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ImageAsset)(nil), (*kubeone.ImageAsset)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ImageAsset_To_kubeone_ImageAsset(a.(*ImageAsset), b.(*kubeone.ImageAsset), scope)
+	if err := s.AddGeneratedConversionFunc((*ContainerRuntimeCRIO)(nil), (*kubeone.ContainerRuntimeCRIO)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ContainerRuntimeCRIO_To_kubeone_ContainerRuntimeCRIO(a.(*ContainerRuntimeCRIO), b.(*kubeone.ContainerRuntimeCRIO), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ImageAsset)(nil), (*ImageAsset)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ImageAsset_To_v1beta1_ImageAsset(a.(*kubeone.ImageAsset), b.(*ImageAsset), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerRuntimeCRIO)(nil), (*ContainerRuntimeCRIO)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ContainerRuntimeCRIO_To_v1beta1_ContainerRuntimeCRIO(a.(*kubeone.ContainerRuntimeCRIO), b.(*ContainerRuntimeCRIO), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*KubeOneCluster)(nil), (*kubeone.KubeOneCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_KubeOneCluster_To_kubeone_KubeOneCluster(a.(*KubeOneCluster), b.(*kubeone.KubeOneCluster), scope)
+	if err := s.AddGeneratedConversionFunc((*ContainerRuntimeConfig)(nil), (*kubeone.ContainerRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ContainerRuntimeConfig_To_kubeone_ContainerRuntimeConfig(a.(*ContainerRuntimeConfig), b.(*kubeone.ContainerRuntimeConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.KubeOneCluster)(nil), (*KubeOneCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_KubeOneCluster_To_v1beta1_KubeOneCluster(a.(*kubeone.KubeOneCluster), b.(*KubeOneCluster), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerRuntimeConfig)(nil), (*ContainerRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(a.(*kubeone.ContainerRuntimeConfig), b.(*ContainerRuntimeConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*KubeProxyConfig)(nil), (*kubeone.KubeProxyConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_KubeProxyConfig_To_kubeone_KubeProxyConfig(a.(*KubeProxyConfig), b.(*kubeone.KubeProxyConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*ContainerRuntimeContainerd)(nil), (*kubeone.ContainerRuntimeContainerd)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeContainerd(a.(*ContainerRuntimeContainerd), b.(*kubeone.ContainerRuntimeContainerd), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.KubeProxyConfig)(nil), (*KubeProxyConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig(a.(*kubeone.KubeProxyConfig), b.(*KubeProxyConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerRuntimeContainerd)(nil), (*ContainerRuntimeContainerd)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(a.(*kubeone.ContainerRuntimeContainerd), b.(*ContainerRuntimeContainerd), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*MachineControllerConfig)(nil), (*kubeone.MachineControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig(a.(*MachineControllerConfig), b.(*kubeone.MachineControllerConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*ContainerRuntimeDocker)(nil), (*kubeone.ContainerRuntimeDocker)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(a.(*ContainerRuntimeDocker), b.(*kubeone.ContainerRuntimeDocker), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.MachineControllerConfig)(nil), (*MachineControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig(a.(*kubeone.MachineControllerConfig), b.(*MachineControllerConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.ContainerRuntimeDocker)(nil), (*ContainerRuntimeDocker)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker(a.(*kubeone.ContainerRuntimeDocker), b.(*ContainerRuntimeDocker), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*MetricsServer)(nil), (*kubeone.MetricsServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_MetricsServer_To_kubeone_MetricsServer(a.(*MetricsServer), b.(*kubeone.MetricsServer), scope)
+	if err := s.AddGeneratedConversionFunc((*ControlPlaneComponentConfig)(nil), (*kubeone.ControlPlaneComponentConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ControlPlaneComponentConfig_To_kubeone_ControlPlaneComponentConfig(a.(*ControlPlaneComponentConfig), b.(*kubeone.ControlPlaneComponentConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.MetricsServer)(nil), (*MetricsServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_MetricsServer_To_v1beta1_MetricsServer(a.(*kubeone.MetricsServer), b.(*MetricsServer), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.ControlPlaneComponentConfig)(nil), (*ControlPlaneComponentConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ControlPlaneComponentConfig_To_v1beta1_ControlPlaneComponentConfig(a.(*kubeone.ControlPlaneComponentConfig), b.(*ControlPlaneComponentConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*NoneSpec)(nil), (*kubeone.NoneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NoneSpec_To_kubeone_NoneSpec(a.(*NoneSpec), b.(*kubeone.NoneSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*ControlPlaneComponentVolume)(nil), (*kubeone.ControlPlaneComponentVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ControlPlaneComponentVolume_To_kubeone_ControlPlaneComponentVolume(a.(*ControlPlaneComponentVolume), b.(*kubeone.ControlPlaneComponentVolume), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.NoneSpec)(nil), (*NoneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_NoneSpec_To_v1beta1_NoneSpec(a.(*kubeone.NoneSpec), b.(*NoneSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.ControlPlaneComponentVolume)(nil), (*ControlPlaneComponentVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ControlPlaneComponentVolume_To_v1beta1_ControlPlaneComponentVolume(a.(*kubeone.ControlPlaneComponentVolume), b.(*ControlPlaneComponentVolume), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*OpenIDConnect)(nil), (*kubeone.OpenIDConnect)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_OpenIDConnect_To_kubeone_OpenIDConnect(a.(*OpenIDConnect), b.(*kubeone.OpenIDConnect), scope)
+	if err := s.AddGeneratedConversionFunc((*ControlPlaneComponents)(nil), (*kubeone.ControlPlaneComponents)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ControlPlaneComponents_To_kubeone_ControlPlaneComponents(a.(*ControlPlaneComponents), b.(*kubeone.ControlPlaneComponents), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.OpenIDConnect)(nil), (*OpenIDConnect)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_OpenIDConnect_To_v1beta1_OpenIDConnect(a.(*kubeone.OpenIDConnect), b.(*OpenIDConnect), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.ControlPlaneComponents)(nil), (*ControlPlaneComponents)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ControlPlaneComponents_To_v1beta1_ControlPlaneComponents(a.(*kubeone.ControlPlaneComponents), b.(*ControlPlaneComponents), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*OpenIDConnectConfig)(nil), (*kubeone.OpenIDConnectConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_OpenIDConnectConfig_To_kubeone_OpenIDConnectConfig(a.(*OpenIDConnectConfig), b.(*kubeone.OpenIDConnectConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*kubeone.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*kubeone.ControlPlaneConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.OpenIDConnectConfig)(nil), (*OpenIDConnectConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_OpenIDConnectConfig_To_v1beta1_OpenIDConnectConfig(a.(*kubeone.OpenIDConnectConfig), b.(*OpenIDConnectConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.ControlPlaneConfig)(nil), (*ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig(a.(*kubeone.ControlPlaneConfig), b.(*ControlPlaneConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*OpenstackSpec)(nil), (*kubeone.OpenstackSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_OpenstackSpec_To_kubeone_OpenstackSpec(a.(*OpenstackSpec), b.(*kubeone.OpenstackSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*CoreDNSConfig)(nil), (*kubeone.CoreDNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CoreDNSConfig_To_kubeone_CoreDNSConfig(a.(*CoreDNSConfig), b.(*kubeone.CoreDNSConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.OpenstackSpec)(nil), (*OpenstackSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_OpenstackSpec_To_v1beta1_OpenstackSpec(a.(*kubeone.OpenstackSpec), b.(*OpenstackSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CoreDNSConfig)(nil), (*CoreDNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CoreDNSConfig_To_v1beta1_CoreDNSConfig(a.(*kubeone.CoreDNSConfig), b.(*CoreDNSConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*PacketSpec)(nil), (*kubeone.PacketSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_PacketSpec_To_kubeone_PacketSpec(a.(*PacketSpec), b.(*kubeone.PacketSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*CoreDNSHostEntry)(nil), (*kubeone.CoreDNSHostEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CoreDNSHostEntry_To_kubeone_CoreDNSHostEntry(a.(*CoreDNSHostEntry), b.(*kubeone.CoreDNSHostEntry), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.PacketSpec)(nil), (*PacketSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_PacketSpec_To_v1beta1_PacketSpec(a.(*kubeone.PacketSpec), b.(*PacketSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CoreDNSHostEntry)(nil), (*CoreDNSHostEntry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CoreDNSHostEntry_To_v1beta1_CoreDNSHostEntry(a.(*kubeone.CoreDNSHostEntry), b.(*CoreDNSHostEntry), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*PodNodeSelector)(nil), (*kubeone.PodNodeSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_PodNodeSelector_To_kubeone_PodNodeSelector(a.(*PodNodeSelector), b.(*kubeone.PodNodeSelector), scope)
+	if err := s.AddGeneratedConversionFunc((*CoreDNSPlugin)(nil), (*kubeone.CoreDNSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CoreDNSPlugin_To_kubeone_CoreDNSPlugin(a.(*CoreDNSPlugin), b.(*kubeone.CoreDNSPlugin), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.PodNodeSelector)(nil), (*PodNodeSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_PodNodeSelector_To_v1beta1_PodNodeSelector(a.(*kubeone.PodNodeSelector), b.(*PodNodeSelector), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CoreDNSPlugin)(nil), (*CoreDNSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CoreDNSPlugin_To_v1beta1_CoreDNSPlugin(a.(*kubeone.CoreDNSPlugin), b.(*CoreDNSPlugin), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*PodNodeSelectorConfig)(nil), (*kubeone.PodNodeSelectorConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_PodNodeSelectorConfig_To_kubeone_PodNodeSelectorConfig(a.(*PodNodeSelectorConfig), b.(*kubeone.PodNodeSelectorConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*CoreDNSRewriteRule)(nil), (*kubeone.CoreDNSRewriteRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CoreDNSRewriteRule_To_kubeone_CoreDNSRewriteRule(a.(*CoreDNSRewriteRule), b.(*kubeone.CoreDNSRewriteRule), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.PodNodeSelectorConfig)(nil), (*PodNodeSelectorConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_PodNodeSelectorConfig_To_v1beta1_PodNodeSelectorConfig(a.(*kubeone.PodNodeSelectorConfig), b.(*PodNodeSelectorConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CoreDNSRewriteRule)(nil), (*CoreDNSRewriteRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CoreDNSRewriteRule_To_v1beta1_CoreDNSRewriteRule(a.(*kubeone.CoreDNSRewriteRule), b.(*CoreDNSRewriteRule), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*PodPresets)(nil), (*kubeone.PodPresets)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_PodPresets_To_kubeone_PodPresets(a.(*PodPresets), b.(*kubeone.PodPresets), scope)
+	if err := s.AddGeneratedConversionFunc((*CoreDNSStubDomain)(nil), (*kubeone.CoreDNSStubDomain)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CoreDNSStubDomain_To_kubeone_CoreDNSStubDomain(a.(*CoreDNSStubDomain), b.(*kubeone.CoreDNSStubDomain), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.PodPresets)(nil), (*PodPresets)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_PodPresets_To_v1beta1_PodPresets(a.(*kubeone.PodPresets), b.(*PodPresets), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.CoreDNSStubDomain)(nil), (*CoreDNSStubDomain)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_CoreDNSStubDomain_To_v1beta1_CoreDNSStubDomain(a.(*kubeone.CoreDNSStubDomain), b.(*CoreDNSStubDomain), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*PodSecurityPolicy)(nil), (*kubeone.PodSecurityPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_PodSecurityPolicy_To_kubeone_PodSecurityPolicy(a.(*PodSecurityPolicy), b.(*kubeone.PodSecurityPolicy), scope)
+	if err := s.AddGeneratedConversionFunc((*DNSConfig)(nil), (*kubeone.DNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DNSConfig_To_kubeone_DNSConfig(a.(*DNSConfig), b.(*kubeone.DNSConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.PodSecurityPolicy)(nil), (*PodSecurityPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_PodSecurityPolicy_To_v1beta1_PodSecurityPolicy(a.(*kubeone.PodSecurityPolicy), b.(*PodSecurityPolicy), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.DNSConfig)(nil), (*DNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DNSConfig_To_v1beta1_DNSConfig(a.(*kubeone.DNSConfig), b.(*DNSConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ProviderSpec)(nil), (*kubeone.ProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ProviderSpec_To_kubeone_ProviderSpec(a.(*ProviderSpec), b.(*kubeone.ProviderSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*DenyServiceExternalIPs)(nil), (*kubeone.DenyServiceExternalIPs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DenyServiceExternalIPs_To_kubeone_DenyServiceExternalIPs(a.(*DenyServiceExternalIPs), b.(*kubeone.DenyServiceExternalIPs), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ProviderSpec)(nil), (*ProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ProviderSpec_To_v1beta1_ProviderSpec(a.(*kubeone.ProviderSpec), b.(*ProviderSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.DenyServiceExternalIPs)(nil), (*DenyServiceExternalIPs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DenyServiceExternalIPs_To_v1beta1_DenyServiceExternalIPs(a.(*kubeone.DenyServiceExternalIPs), b.(*DenyServiceExternalIPs), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ProviderStaticNetworkConfig)(nil), (*kubeone.ProviderStaticNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ProviderStaticNetworkConfig_To_kubeone_ProviderStaticNetworkConfig(a.(*ProviderStaticNetworkConfig), b.(*kubeone.ProviderStaticNetworkConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*DigitalOceanSpec)(nil), (*kubeone.DigitalOceanSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DigitalOceanSpec_To_kubeone_DigitalOceanSpec(a.(*DigitalOceanSpec), b.(*kubeone.DigitalOceanSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ProviderStaticNetworkConfig)(nil), (*ProviderStaticNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ProviderStaticNetworkConfig_To_v1beta1_ProviderStaticNetworkConfig(a.(*kubeone.ProviderStaticNetworkConfig), b.(*ProviderStaticNetworkConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.DigitalOceanSpec)(nil), (*DigitalOceanSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DigitalOceanSpec_To_v1beta1_DigitalOceanSpec(a.(*kubeone.DigitalOceanSpec), b.(*DigitalOceanSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ProxyConfig)(nil), (*kubeone.ProxyConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ProxyConfig_To_kubeone_ProxyConfig(a.(*ProxyConfig), b.(*kubeone.ProxyConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*DynamicAuditLog)(nil), (*kubeone.DynamicAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DynamicAuditLog_To_kubeone_DynamicAuditLog(a.(*DynamicAuditLog), b.(*kubeone.DynamicAuditLog), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.ProxyConfig)(nil), (*ProxyConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_ProxyConfig_To_v1beta1_ProxyConfig(a.(*kubeone.ProxyConfig), b.(*ProxyConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.DynamicAuditLog)(nil), (*DynamicAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DynamicAuditLog_To_v1beta1_DynamicAuditLog(a.(*kubeone.DynamicAuditLog), b.(*DynamicAuditLog), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*RegistryConfiguration)(nil), (*kubeone.RegistryConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_RegistryConfiguration_To_kubeone_RegistryConfiguration(a.(*RegistryConfiguration), b.(*kubeone.RegistryConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*DynamicWorkerAutoscaling)(nil), (*kubeone.DynamicWorkerAutoscaling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DynamicWorkerAutoscaling_To_kubeone_DynamicWorkerAutoscaling(a.(*DynamicWorkerAutoscaling), b.(*kubeone.DynamicWorkerAutoscaling), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.RegistryConfiguration)(nil), (*RegistryConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_RegistryConfiguration_To_v1beta1_RegistryConfiguration(a.(*kubeone.RegistryConfiguration), b.(*RegistryConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.DynamicWorkerAutoscaling)(nil), (*DynamicWorkerAutoscaling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DynamicWorkerAutoscaling_To_v1beta1_DynamicWorkerAutoscaling(a.(*kubeone.DynamicWorkerAutoscaling), b.(*DynamicWorkerAutoscaling), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*StaticAuditLog)(nil), (*kubeone.StaticAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_StaticAuditLog_To_kubeone_StaticAuditLog(a.(*StaticAuditLog), b.(*kubeone.StaticAuditLog), scope)
+	if err := s.AddGeneratedConversionFunc((*DynamicWorkerConfig)(nil), (*kubeone.DynamicWorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DynamicWorkerConfig_To_kubeone_DynamicWorkerConfig(a.(*DynamicWorkerConfig), b.(*kubeone.DynamicWorkerConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.StaticAuditLog)(nil), (*StaticAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_StaticAuditLog_To_v1beta1_StaticAuditLog(a.(*kubeone.StaticAuditLog), b.(*StaticAuditLog), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.DynamicWorkerConfig)(nil), (*DynamicWorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(a.(*kubeone.DynamicWorkerConfig), b.(*DynamicWorkerConfig), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*StaticAuditLogConfig)(nil), (*kubeone.StaticAuditLogConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_StaticAuditLogConfig_To_kubeone_StaticAuditLogConfig(a.(*StaticAuditLogConfig), b.(*kubeone.StaticAuditLogConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*EncryptionProviders)(nil), (*kubeone.EncryptionProviders)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EncryptionProviders_To_kubeone_EncryptionProviders(a.(*EncryptionProviders), b.(*kubeone.EncryptionProviders), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kubeone.StaticAuditLogConfig)(nil), (*StaticAuditLogConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(a.(*kubeone.StaticAuditLogConfig), b.(*StaticAuditLogConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*kubeone.EncryptionProviders)(nil), (*EncryptionProviders)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_EncryptionProviders_To_v1beta1_EncryptionProviders(a.(*kubeone.EncryptionProviders), b.(*EncryptionProviders), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*StaticWorkersConfig)(nil), (*kubeone.StaticWorkersConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_StaticWorkersConfig_To_kubeone_StaticWorkersConfig(a.(*StaticWorkersConfig), b.(*kubeone.StaticWorkersConfig), scope)
+	if err := s.AddGeneratedConversionFunc((*EncryptionProvidersKMS)(nil), (*kubeone.EncryptionProvidersKMS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EncryptionProvidersKMS_To_kubeone_EncryptionProvidersKMS(a.(*EncryptionProvidersKMS), b.(*kubeone.EncryptionProvidersKMS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.EncryptionProvidersKMS)(nil), (*EncryptionProvidersKMS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_EncryptionProvidersKMS_To_v1beta1_EncryptionProvidersKMS(a.(*kubeone.EncryptionProvidersKMS), b.(*EncryptionProvidersKMS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdConfig)(nil), (*kubeone.EtcdConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdConfig_To_kubeone_EtcdConfig(a.(*EtcdConfig), b.(*kubeone.EtcdConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.EtcdConfig)(nil), (*EtcdConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_EtcdConfig_To_v1beta1_EtcdConfig(a.(*kubeone.EtcdConfig), b.(*EtcdConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdTLSConfig)(nil), (*kubeone.EtcdTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EtcdTLSConfig_To_kubeone_EtcdTLSConfig(a.(*EtcdTLSConfig), b.(*kubeone.EtcdTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.EtcdTLSConfig)(nil), (*EtcdTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_EtcdTLSConfig_To_v1beta1_EtcdTLSConfig(a.(*kubeone.EtcdTLSConfig), b.(*EtcdTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EventRateLimit)(nil), (*kubeone.EventRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EventRateLimit_To_kubeone_EventRateLimit(a.(*EventRateLimit), b.(*kubeone.EventRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.EventRateLimit)(nil), (*EventRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_EventRateLimit_To_v1beta1_EventRateLimit(a.(*kubeone.EventRateLimit), b.(*EventRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EventRateLimitConfig)(nil), (*kubeone.EventRateLimitConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EventRateLimitConfig_To_kubeone_EventRateLimitConfig(a.(*EventRateLimitConfig), b.(*kubeone.EventRateLimitConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.EventRateLimitConfig)(nil), (*EventRateLimitConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_EventRateLimitConfig_To_v1beta1_EventRateLimitConfig(a.(*kubeone.EventRateLimitConfig), b.(*EventRateLimitConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EventRateLimitLimit)(nil), (*kubeone.EventRateLimitLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_EventRateLimitLimit_To_kubeone_EventRateLimitLimit(a.(*EventRateLimitLimit), b.(*kubeone.EventRateLimitLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.EventRateLimitLimit)(nil), (*EventRateLimitLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_EventRateLimitLimit_To_v1beta1_EventRateLimitLimit(a.(*kubeone.EventRateLimitLimit), b.(*EventRateLimitLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExternalCNISpec)(nil), (*kubeone.ExternalCNISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ExternalCNISpec_To_kubeone_ExternalCNISpec(a.(*ExternalCNISpec), b.(*kubeone.ExternalCNISpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ExternalCNISpec)(nil), (*ExternalCNISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ExternalCNISpec_To_v1beta1_ExternalCNISpec(a.(*kubeone.ExternalCNISpec), b.(*ExternalCNISpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExternalEtcdConfig)(nil), (*kubeone.ExternalEtcdConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ExternalEtcdConfig_To_kubeone_ExternalEtcdConfig(a.(*ExternalEtcdConfig), b.(*kubeone.ExternalEtcdConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ExternalEtcdConfig)(nil), (*ExternalEtcdConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ExternalEtcdConfig_To_v1beta1_ExternalEtcdConfig(a.(*kubeone.ExternalEtcdConfig), b.(*ExternalEtcdConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FIPS)(nil), (*kubeone.FIPS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_FIPS_To_kubeone_FIPS(a.(*FIPS), b.(*kubeone.FIPS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.FIPS)(nil), (*FIPS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_FIPS_To_v1beta1_FIPS(a.(*kubeone.FIPS), b.(*FIPS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Features)(nil), (*kubeone.Features)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Features_To_kubeone_Features(a.(*Features), b.(*kubeone.Features), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.Features)(nil), (*Features)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Features_To_v1beta1_Features(a.(*kubeone.Features), b.(*Features), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GCESpec)(nil), (*kubeone.GCESpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GCESpec_To_kubeone_GCESpec(a.(*GCESpec), b.(*kubeone.GCESpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.GCESpec)(nil), (*GCESpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_GCESpec_To_v1beta1_GCESpec(a.(*kubeone.GCESpec), b.(*GCESpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GCPKMSPlugin)(nil), (*kubeone.GCPKMSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GCPKMSPlugin_To_kubeone_GCPKMSPlugin(a.(*GCPKMSPlugin), b.(*kubeone.GCPKMSPlugin), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.GCPKMSPlugin)(nil), (*GCPKMSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_GCPKMSPlugin_To_v1beta1_GCPKMSPlugin(a.(*kubeone.GCPKMSPlugin), b.(*GCPKMSPlugin), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GCSStateBackend)(nil), (*kubeone.GCSStateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GCSStateBackend_To_kubeone_GCSStateBackend(a.(*GCSStateBackend), b.(*kubeone.GCSStateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.GCSStateBackend)(nil), (*GCSStateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_GCSStateBackend_To_v1beta1_GCSStateBackend(a.(*kubeone.GCSStateBackend), b.(*GCSStateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Gatekeeper)(nil), (*kubeone.Gatekeeper)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Gatekeeper_To_kubeone_Gatekeeper(a.(*Gatekeeper), b.(*kubeone.Gatekeeper), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.Gatekeeper)(nil), (*Gatekeeper)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Gatekeeper_To_v1beta1_Gatekeeper(a.(*kubeone.Gatekeeper), b.(*Gatekeeper), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GatekeeperConfig)(nil), (*kubeone.GatekeeperConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GatekeeperConfig_To_kubeone_GatekeeperConfig(a.(*GatekeeperConfig), b.(*kubeone.GatekeeperConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.GatekeeperConfig)(nil), (*GatekeeperConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_GatekeeperConfig_To_v1beta1_GatekeeperConfig(a.(*kubeone.GatekeeperConfig), b.(*GatekeeperConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GitAddonSource)(nil), (*kubeone.GitAddonSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GitAddonSource_To_kubeone_GitAddonSource(a.(*GitAddonSource), b.(*kubeone.GitAddonSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.GitAddonSource)(nil), (*GitAddonSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_GitAddonSource_To_v1beta1_GitAddonSource(a.(*kubeone.GitAddonSource), b.(*GitAddonSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HTTPAddonSource)(nil), (*kubeone.HTTPAddonSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HTTPAddonSource_To_kubeone_HTTPAddonSource(a.(*HTTPAddonSource), b.(*kubeone.HTTPAddonSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.HTTPAddonSource)(nil), (*HTTPAddonSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_HTTPAddonSource_To_v1beta1_HTTPAddonSource(a.(*kubeone.HTTPAddonSource), b.(*HTTPAddonSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HelmChart)(nil), (*kubeone.HelmChart)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HelmChart_To_kubeone_HelmChart(a.(*HelmChart), b.(*kubeone.HelmChart), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.HelmChart)(nil), (*HelmChart)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_HelmChart_To_v1beta1_HelmChart(a.(*kubeone.HelmChart), b.(*HelmChart), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HetznerSpec)(nil), (*kubeone.HetznerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec(a.(*HetznerSpec), b.(*kubeone.HetznerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.HetznerSpec)(nil), (*HetznerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec(a.(*kubeone.HetznerSpec), b.(*HetznerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Hook)(nil), (*kubeone.Hook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Hook_To_kubeone_Hook(a.(*Hook), b.(*kubeone.Hook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.Hook)(nil), (*Hook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Hook_To_v1beta1_Hook(a.(*kubeone.Hook), b.(*Hook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Hooks)(nil), (*kubeone.Hooks)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Hooks_To_kubeone_Hooks(a.(*Hooks), b.(*kubeone.Hooks), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.Hooks)(nil), (*Hooks)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Hooks_To_v1beta1_Hooks(a.(*kubeone.Hooks), b.(*Hooks), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HostConfig)(nil), (*kubeone.HostConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HostConfig_To_kubeone_HostConfig(a.(*HostConfig), b.(*kubeone.HostConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.HostConfig)(nil), (*HostConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_HostConfig_To_v1beta1_HostConfig(a.(*kubeone.HostConfig), b.(*HostConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HostHooks)(nil), (*kubeone.HostHooks)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HostHooks_To_kubeone_HostHooks(a.(*HostHooks), b.(*kubeone.HostHooks), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.HostHooks)(nil), (*HostHooks)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_HostHooks_To_v1beta1_HostHooks(a.(*kubeone.HostHooks), b.(*HostHooks), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HostNetworkOverrides)(nil), (*kubeone.HostNetworkOverrides)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HostNetworkOverrides_To_kubeone_HostNetworkOverrides(a.(*HostNetworkOverrides), b.(*kubeone.HostNetworkOverrides), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.HostNetworkOverrides)(nil), (*HostNetworkOverrides)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_HostNetworkOverrides_To_v1beta1_HostNetworkOverrides(a.(*kubeone.HostNetworkOverrides), b.(*HostNetworkOverrides), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HostScript)(nil), (*kubeone.HostScript)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HostScript_To_kubeone_HostScript(a.(*HostScript), b.(*kubeone.HostScript), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.HostScript)(nil), (*HostScript)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_HostScript_To_v1beta1_HostScript(a.(*kubeone.HostScript), b.(*HostScript), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*IPTables)(nil), (*kubeone.IPTables)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IPTables_To_kubeone_IPTables(a.(*IPTables), b.(*kubeone.IPTables), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.IPTables)(nil), (*IPTables)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_IPTables_To_v1beta1_IPTables(a.(*kubeone.IPTables), b.(*IPTables), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*IPVSConfig)(nil), (*kubeone.IPVSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_IPVSConfig_To_kubeone_IPVSConfig(a.(*IPVSConfig), b.(*kubeone.IPVSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.IPVSConfig)(nil), (*IPVSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_IPVSConfig_To_v1beta1_IPVSConfig(a.(*kubeone.IPVSConfig), b.(*IPVSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ImageAsset)(nil), (*kubeone.ImageAsset)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ImageAsset_To_kubeone_ImageAsset(a.(*ImageAsset), b.(*kubeone.ImageAsset), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ImageAsset)(nil), (*ImageAsset)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ImageAsset_To_v1beta1_ImageAsset(a.(*kubeone.ImageAsset), b.(*ImageAsset), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KMSPlugin)(nil), (*kubeone.KMSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KMSPlugin_To_kubeone_KMSPlugin(a.(*KMSPlugin), b.(*kubeone.KMSPlugin), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KMSPlugin)(nil), (*KMSPlugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KMSPlugin_To_v1beta1_KMSPlugin(a.(*kubeone.KMSPlugin), b.(*KMSPlugin), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Konnectivity)(nil), (*kubeone.Konnectivity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Konnectivity_To_kubeone_Konnectivity(a.(*Konnectivity), b.(*kubeone.Konnectivity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.Konnectivity)(nil), (*Konnectivity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Konnectivity_To_v1beta1_Konnectivity(a.(*kubeone.Konnectivity), b.(*Konnectivity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KonnectivityConfig)(nil), (*kubeone.KonnectivityConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KonnectivityConfig_To_kubeone_KonnectivityConfig(a.(*KonnectivityConfig), b.(*kubeone.KonnectivityConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KonnectivityConfig)(nil), (*KonnectivityConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KonnectivityConfig_To_v1beta1_KonnectivityConfig(a.(*kubeone.KonnectivityConfig), b.(*KonnectivityConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeOneCluster)(nil), (*kubeone.KubeOneCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubeOneCluster_To_kubeone_KubeOneCluster(a.(*KubeOneCluster), b.(*kubeone.KubeOneCluster), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KubeOneCluster)(nil), (*KubeOneCluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KubeOneCluster_To_v1beta1_KubeOneCluster(a.(*kubeone.KubeOneCluster), b.(*KubeOneCluster), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeProxyConfig)(nil), (*kubeone.KubeProxyConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubeProxyConfig_To_kubeone_KubeProxyConfig(a.(*KubeProxyConfig), b.(*kubeone.KubeProxyConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KubeProxyConfig)(nil), (*KubeProxyConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig(a.(*kubeone.KubeProxyConfig), b.(*KubeProxyConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeProxyConntrack)(nil), (*kubeone.KubeProxyConntrack)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubeProxyConntrack_To_kubeone_KubeProxyConntrack(a.(*KubeProxyConntrack), b.(*kubeone.KubeProxyConntrack), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KubeProxyConntrack)(nil), (*KubeProxyConntrack)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KubeProxyConntrack_To_v1beta1_KubeProxyConntrack(a.(*kubeone.KubeProxyConntrack), b.(*KubeProxyConntrack), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeVIP)(nil), (*kubeone.KubeVIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubeVIP_To_kubeone_KubeVIP(a.(*KubeVIP), b.(*kubeone.KubeVIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KubeVIP)(nil), (*KubeVIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KubeVIP_To_v1beta1_KubeVIP(a.(*kubeone.KubeVIP), b.(*KubeVIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeVirtSpec)(nil), (*kubeone.KubeVirtSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubeVirtSpec_To_kubeone_KubeVirtSpec(a.(*KubeVirtSpec), b.(*kubeone.KubeVirtSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KubeVirtSpec)(nil), (*KubeVirtSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KubeVirtSpec_To_v1beta1_KubeVirtSpec(a.(*kubeone.KubeVirtSpec), b.(*KubeVirtSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeletConfig)(nil), (*kubeone.KubeletConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubeletConfig_To_kubeone_KubeletConfig(a.(*KubeletConfig), b.(*kubeone.KubeletConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KubeletConfig)(nil), (*KubeletConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KubeletConfig_To_v1beta1_KubeletConfig(a.(*kubeone.KubeletConfig), b.(*KubeletConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubernetesSecretStateBackend)(nil), (*kubeone.KubernetesSecretStateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubernetesSecretStateBackend_To_kubeone_KubernetesSecretStateBackend(a.(*KubernetesSecretStateBackend), b.(*kubeone.KubernetesSecretStateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.KubernetesSecretStateBackend)(nil), (*KubernetesSecretStateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_KubernetesSecretStateBackend_To_v1beta1_KubernetesSecretStateBackend(a.(*kubeone.KubernetesSecretStateBackend), b.(*KubernetesSecretStateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LocalStateBackend)(nil), (*kubeone.LocalStateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_LocalStateBackend_To_kubeone_LocalStateBackend(a.(*LocalStateBackend), b.(*kubeone.LocalStateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.LocalStateBackend)(nil), (*LocalStateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_LocalStateBackend_To_v1beta1_LocalStateBackend(a.(*kubeone.LocalStateBackend), b.(*LocalStateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MachineControllerConfig)(nil), (*kubeone.MachineControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig(a.(*MachineControllerConfig), b.(*kubeone.MachineControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.MachineControllerConfig)(nil), (*MachineControllerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig(a.(*kubeone.MachineControllerConfig), b.(*MachineControllerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MetricsServer)(nil), (*kubeone.MetricsServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_MetricsServer_To_kubeone_MetricsServer(a.(*MetricsServer), b.(*kubeone.MetricsServer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.MetricsServer)(nil), (*MetricsServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_MetricsServer_To_v1beta1_MetricsServer(a.(*kubeone.MetricsServer), b.(*MetricsServer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceAdmins)(nil), (*kubeone.NamespaceAdmins)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NamespaceAdmins_To_kubeone_NamespaceAdmins(a.(*NamespaceAdmins), b.(*kubeone.NamespaceAdmins), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NamespaceAdmins)(nil), (*NamespaceAdmins)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NamespaceAdmins_To_v1beta1_NamespaceAdmins(a.(*kubeone.NamespaceAdmins), b.(*NamespaceAdmins), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeLocalDNS)(nil), (*kubeone.NodeLocalDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeLocalDNS_To_kubeone_NodeLocalDNS(a.(*NodeLocalDNS), b.(*kubeone.NodeLocalDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NodeLocalDNS)(nil), (*NodeLocalDNS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NodeLocalDNS_To_v1beta1_NodeLocalDNS(a.(*kubeone.NodeLocalDNS), b.(*NodeLocalDNS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeLocalDNSConfig)(nil), (*kubeone.NodeLocalDNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeLocalDNSConfig_To_kubeone_NodeLocalDNSConfig(a.(*NodeLocalDNSConfig), b.(*kubeone.NodeLocalDNSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NodeLocalDNSConfig)(nil), (*NodeLocalDNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NodeLocalDNSConfig_To_v1beta1_NodeLocalDNSConfig(a.(*kubeone.NodeLocalDNSConfig), b.(*NodeLocalDNSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeRestriction)(nil), (*kubeone.NodeRestriction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeRestriction_To_kubeone_NodeRestriction(a.(*NodeRestriction), b.(*kubeone.NodeRestriction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NodeRestriction)(nil), (*NodeRestriction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NodeRestriction_To_v1beta1_NodeRestriction(a.(*kubeone.NodeRestriction), b.(*NodeRestriction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NoneSpec)(nil), (*kubeone.NoneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NoneSpec_To_kubeone_NoneSpec(a.(*NoneSpec), b.(*kubeone.NoneSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NoneSpec)(nil), (*NoneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NoneSpec_To_v1beta1_NoneSpec(a.(*kubeone.NoneSpec), b.(*NoneSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Notification)(nil), (*kubeone.Notification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Notification_To_kubeone_Notification(a.(*Notification), b.(*kubeone.Notification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.Notification)(nil), (*Notification)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_Notification_To_v1beta1_Notification(a.(*kubeone.Notification), b.(*Notification), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NutanixSpec)(nil), (*kubeone.NutanixSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NutanixSpec_To_kubeone_NutanixSpec(a.(*NutanixSpec), b.(*kubeone.NutanixSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.NutanixSpec)(nil), (*NutanixSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_NutanixSpec_To_v1beta1_NutanixSpec(a.(*kubeone.NutanixSpec), b.(*NutanixSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OSMConfig)(nil), (*kubeone.OSMConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OSMConfig_To_kubeone_OSMConfig(a.(*OSMConfig), b.(*kubeone.OSMConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.OSMConfig)(nil), (*OSMConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_OSMConfig_To_v1beta1_OSMConfig(a.(*kubeone.OSMConfig), b.(*OSMConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenIDConnect)(nil), (*kubeone.OpenIDConnect)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenIDConnect_To_kubeone_OpenIDConnect(a.(*OpenIDConnect), b.(*kubeone.OpenIDConnect), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.OpenIDConnect)(nil), (*OpenIDConnect)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_OpenIDConnect_To_v1beta1_OpenIDConnect(a.(*kubeone.OpenIDConnect), b.(*OpenIDConnect), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenIDConnectConfig)(nil), (*kubeone.OpenIDConnectConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenIDConnectConfig_To_kubeone_OpenIDConnectConfig(a.(*OpenIDConnectConfig), b.(*kubeone.OpenIDConnectConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.OpenIDConnectConfig)(nil), (*OpenIDConnectConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_OpenIDConnectConfig_To_v1beta1_OpenIDConnectConfig(a.(*kubeone.OpenIDConnectConfig), b.(*OpenIDConnectConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenstackSpec)(nil), (*kubeone.OpenstackSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OpenstackSpec_To_kubeone_OpenstackSpec(a.(*OpenstackSpec), b.(*kubeone.OpenstackSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.OpenstackSpec)(nil), (*OpenstackSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_OpenstackSpec_To_v1beta1_OpenstackSpec(a.(*kubeone.OpenstackSpec), b.(*OpenstackSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PacketBGP)(nil), (*kubeone.PacketBGP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PacketBGP_To_kubeone_PacketBGP(a.(*PacketBGP), b.(*kubeone.PacketBGP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PacketBGP)(nil), (*PacketBGP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PacketBGP_To_v1beta1_PacketBGP(a.(*kubeone.PacketBGP), b.(*PacketBGP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PacketElasticIP)(nil), (*kubeone.PacketElasticIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PacketElasticIP_To_kubeone_PacketElasticIP(a.(*PacketElasticIP), b.(*kubeone.PacketElasticIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PacketElasticIP)(nil), (*PacketElasticIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PacketElasticIP_To_v1beta1_PacketElasticIP(a.(*kubeone.PacketElasticIP), b.(*PacketElasticIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PacketSpec)(nil), (*kubeone.PacketSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PacketSpec_To_kubeone_PacketSpec(a.(*PacketSpec), b.(*kubeone.PacketSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PacketSpec)(nil), (*PacketSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PacketSpec_To_v1beta1_PacketSpec(a.(*kubeone.PacketSpec), b.(*PacketSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodNodeSelector)(nil), (*kubeone.PodNodeSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodNodeSelector_To_kubeone_PodNodeSelector(a.(*PodNodeSelector), b.(*kubeone.PodNodeSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PodNodeSelector)(nil), (*PodNodeSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PodNodeSelector_To_v1beta1_PodNodeSelector(a.(*kubeone.PodNodeSelector), b.(*PodNodeSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodNodeSelectorConfig)(nil), (*kubeone.PodNodeSelectorConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodNodeSelectorConfig_To_kubeone_PodNodeSelectorConfig(a.(*PodNodeSelectorConfig), b.(*kubeone.PodNodeSelectorConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PodNodeSelectorConfig)(nil), (*PodNodeSelectorConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PodNodeSelectorConfig_To_v1beta1_PodNodeSelectorConfig(a.(*kubeone.PodNodeSelectorConfig), b.(*PodNodeSelectorConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodPresets)(nil), (*kubeone.PodPresets)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodPresets_To_kubeone_PodPresets(a.(*PodPresets), b.(*kubeone.PodPresets), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PodPresets)(nil), (*PodPresets)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PodPresets_To_v1beta1_PodPresets(a.(*kubeone.PodPresets), b.(*PodPresets), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityAdmission)(nil), (*kubeone.PodSecurityAdmission)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityAdmission_To_kubeone_PodSecurityAdmission(a.(*PodSecurityAdmission), b.(*kubeone.PodSecurityAdmission), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PodSecurityAdmission)(nil), (*PodSecurityAdmission)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PodSecurityAdmission_To_v1beta1_PodSecurityAdmission(a.(*kubeone.PodSecurityAdmission), b.(*PodSecurityAdmission), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityAdmissionConfig)(nil), (*kubeone.PodSecurityAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityAdmissionConfig_To_kubeone_PodSecurityAdmissionConfig(a.(*PodSecurityAdmissionConfig), b.(*kubeone.PodSecurityAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PodSecurityAdmissionConfig)(nil), (*PodSecurityAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PodSecurityAdmissionConfig_To_v1beta1_PodSecurityAdmissionConfig(a.(*kubeone.PodSecurityAdmissionConfig), b.(*PodSecurityAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityAdmissionExemptions)(nil), (*kubeone.PodSecurityAdmissionExemptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityAdmissionExemptions_To_kubeone_PodSecurityAdmissionExemptions(a.(*PodSecurityAdmissionExemptions), b.(*kubeone.PodSecurityAdmissionExemptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PodSecurityAdmissionExemptions)(nil), (*PodSecurityAdmissionExemptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PodSecurityAdmissionExemptions_To_v1beta1_PodSecurityAdmissionExemptions(a.(*kubeone.PodSecurityAdmissionExemptions), b.(*PodSecurityAdmissionExemptions), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodSecurityPolicy)(nil), (*kubeone.PodSecurityPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodSecurityPolicy_To_kubeone_PodSecurityPolicy(a.(*PodSecurityPolicy), b.(*kubeone.PodSecurityPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.PodSecurityPolicy)(nil), (*PodSecurityPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_PodSecurityPolicy_To_v1beta1_PodSecurityPolicy(a.(*kubeone.PodSecurityPolicy), b.(*PodSecurityPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProviderSpec)(nil), (*kubeone.ProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ProviderSpec_To_kubeone_ProviderSpec(a.(*ProviderSpec), b.(*kubeone.ProviderSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ProviderSpec)(nil), (*ProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ProviderSpec_To_v1beta1_ProviderSpec(a.(*kubeone.ProviderSpec), b.(*ProviderSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProviderStaticNetworkConfig)(nil), (*kubeone.ProviderStaticNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ProviderStaticNetworkConfig_To_kubeone_ProviderStaticNetworkConfig(a.(*ProviderStaticNetworkConfig), b.(*kubeone.ProviderStaticNetworkConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ProviderStaticNetworkConfig)(nil), (*ProviderStaticNetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ProviderStaticNetworkConfig_To_v1beta1_ProviderStaticNetworkConfig(a.(*kubeone.ProviderStaticNetworkConfig), b.(*ProviderStaticNetworkConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProxyConfig)(nil), (*kubeone.ProxyConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ProxyConfig_To_kubeone_ProxyConfig(a.(*ProxyConfig), b.(*kubeone.ProxyConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.ProxyConfig)(nil), (*ProxyConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_ProxyConfig_To_v1beta1_ProxyConfig(a.(*kubeone.ProxyConfig), b.(*ProxyConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RegistryAuth)(nil), (*kubeone.RegistryAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RegistryAuth_To_kubeone_RegistryAuth(a.(*RegistryAuth), b.(*kubeone.RegistryAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.RegistryAuth)(nil), (*RegistryAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_RegistryAuth_To_v1beta1_RegistryAuth(a.(*kubeone.RegistryAuth), b.(*RegistryAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RegistryConfiguration)(nil), (*kubeone.RegistryConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RegistryConfiguration_To_kubeone_RegistryConfiguration(a.(*RegistryConfiguration), b.(*kubeone.RegistryConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.RegistryConfiguration)(nil), (*RegistryConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_RegistryConfiguration_To_v1beta1_RegistryConfiguration(a.(*kubeone.RegistryConfiguration), b.(*RegistryConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RegistryMirror)(nil), (*kubeone.RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RegistryMirror_To_kubeone_RegistryMirror(a.(*RegistryMirror), b.(*kubeone.RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.RegistryMirror)(nil), (*RegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_RegistryMirror_To_v1beta1_RegistryMirror(a.(*kubeone.RegistryMirror), b.(*RegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*S3AddonSource)(nil), (*kubeone.S3AddonSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_S3AddonSource_To_kubeone_S3AddonSource(a.(*S3AddonSource), b.(*kubeone.S3AddonSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.S3AddonSource)(nil), (*S3AddonSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_S3AddonSource_To_v1beta1_S3AddonSource(a.(*kubeone.S3AddonSource), b.(*S3AddonSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*S3StateBackend)(nil), (*kubeone.S3StateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_S3StateBackend_To_kubeone_S3StateBackend(a.(*S3StateBackend), b.(*kubeone.S3StateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.S3StateBackend)(nil), (*S3StateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_S3StateBackend_To_v1beta1_S3StateBackend(a.(*kubeone.S3StateBackend), b.(*S3StateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SELinux)(nil), (*kubeone.SELinux)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SELinux_To_kubeone_SELinux(a.(*SELinux), b.(*kubeone.SELinux), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.SELinux)(nil), (*SELinux)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_SELinux_To_v1beta1_SELinux(a.(*kubeone.SELinux), b.(*SELinux), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SRIOV)(nil), (*kubeone.SRIOV)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SRIOV_To_kubeone_SRIOV(a.(*SRIOV), b.(*kubeone.SRIOV), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.SRIOV)(nil), (*SRIOV)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_SRIOV_To_v1beta1_SRIOV(a.(*kubeone.SRIOV), b.(*SRIOV), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SRIOVHost)(nil), (*kubeone.SRIOVHost)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SRIOVHost_To_kubeone_SRIOVHost(a.(*SRIOVHost), b.(*kubeone.SRIOVHost), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.SRIOVHost)(nil), (*SRIOVHost)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_SRIOVHost_To_v1beta1_SRIOVHost(a.(*kubeone.SRIOVHost), b.(*SRIOVHost), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SRIOVPhysicalFunction)(nil), (*kubeone.SRIOVPhysicalFunction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SRIOVPhysicalFunction_To_kubeone_SRIOVPhysicalFunction(a.(*SRIOVPhysicalFunction), b.(*kubeone.SRIOVPhysicalFunction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.SRIOVPhysicalFunction)(nil), (*SRIOVPhysicalFunction)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_SRIOVPhysicalFunction_To_v1beta1_SRIOVPhysicalFunction(a.(*kubeone.SRIOVPhysicalFunction), b.(*SRIOVPhysicalFunction), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SeccompDefault)(nil), (*kubeone.SeccompDefault)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SeccompDefault_To_kubeone_SeccompDefault(a.(*SeccompDefault), b.(*kubeone.SeccompDefault), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.SeccompDefault)(nil), (*SeccompDefault)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_SeccompDefault_To_v1beta1_SeccompDefault(a.(*kubeone.SeccompDefault), b.(*SeccompDefault), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StateBackend)(nil), (*kubeone.StateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_StateBackend_To_kubeone_StateBackend(a.(*StateBackend), b.(*kubeone.StateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.StateBackend)(nil), (*StateBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_StateBackend_To_v1beta1_StateBackend(a.(*kubeone.StateBackend), b.(*StateBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StaticAuditLog)(nil), (*kubeone.StaticAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_StaticAuditLog_To_kubeone_StaticAuditLog(a.(*StaticAuditLog), b.(*kubeone.StaticAuditLog), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.StaticAuditLog)(nil), (*StaticAuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_StaticAuditLog_To_v1beta1_StaticAuditLog(a.(*kubeone.StaticAuditLog), b.(*StaticAuditLog), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StaticAuditLogConfig)(nil), (*kubeone.StaticAuditLogConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_StaticAuditLogConfig_To_kubeone_StaticAuditLogConfig(a.(*StaticAuditLogConfig), b.(*kubeone.StaticAuditLogConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.StaticAuditLogConfig)(nil), (*StaticAuditLogConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(a.(*kubeone.StaticAuditLogConfig), b.(*StaticAuditLogConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StaticWorkerPool)(nil), (*kubeone.StaticWorkerPool)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_StaticWorkerPool_To_kubeone_StaticWorkerPool(a.(*StaticWorkerPool), b.(*kubeone.StaticWorkerPool), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.StaticWorkerPool)(nil), (*StaticWorkerPool)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_StaticWorkerPool_To_v1beta1_StaticWorkerPool(a.(*kubeone.StaticWorkerPool), b.(*StaticWorkerPool), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StaticWorkersConfig)(nil), (*kubeone.StaticWorkersConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_StaticWorkersConfig_To_kubeone_StaticWorkersConfig(a.(*StaticWorkersConfig), b.(*kubeone.StaticWorkersConfig), scope)
 	}); err != nil {
 		return err
 	}
@@ -527,6 +1347,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VMwareCloudDirectorSpec)(nil), (*kubeone.VMwareCloudDirectorSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VMwareCloudDirectorSpec_To_kubeone_VMwareCloudDirectorSpec(a.(*VMwareCloudDirectorSpec), b.(*kubeone.VMwareCloudDirectorSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.VMwareCloudDirectorSpec)(nil), (*VMwareCloudDirectorSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_VMwareCloudDirectorSpec_To_v1beta1_VMwareCloudDirectorSpec(a.(*kubeone.VMwareCloudDirectorSpec), b.(*VMwareCloudDirectorSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VersionConfig)(nil), (*kubeone.VersionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VersionConfig_To_kubeone_VersionConfig(a.(*VersionConfig), b.(*kubeone.VersionConfig), scope)
 	}); err != nil {
@@ -557,6 +1387,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*WebhookHook)(nil), (*kubeone.WebhookHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_WebhookHook_To_kubeone_WebhookHook(a.(*WebhookHook), b.(*kubeone.WebhookHook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kubeone.WebhookHook)(nil), (*WebhookHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kubeone_WebhookHook_To_v1beta1_WebhookHook(a.(*kubeone.WebhookHook), b.(*WebhookHook), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -582,6 +1422,54 @@ func Convert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(in *kubeone.APIEndpoint,
 	return autoConvert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(in, out, s)
 }
 
+func autoConvert_v1beta1_APIServerConfig_To_kubeone_APIServerConfig(in *APIServerConfig, out *kubeone.APIServerConfig, s conversion.Scope) error {
+	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
+	out.EnableAdmissionPlugins = *(*[]string)(unsafe.Pointer(&in.EnableAdmissionPlugins))
+	out.DisableAdmissionPlugins = *(*[]string)(unsafe.Pointer(&in.DisableAdmissionPlugins))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	return nil
+}
+
+// Convert_v1beta1_APIServerConfig_To_kubeone_APIServerConfig is an autogenerated conversion function.
+func Convert_v1beta1_APIServerConfig_To_kubeone_APIServerConfig(in *APIServerConfig, out *kubeone.APIServerConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_APIServerConfig_To_kubeone_APIServerConfig(in, out, s)
+}
+
+func autoConvert_kubeone_APIServerConfig_To_v1beta1_APIServerConfig(in *kubeone.APIServerConfig, out *APIServerConfig, s conversion.Scope) error {
+	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
+	out.EnableAdmissionPlugins = *(*[]string)(unsafe.Pointer(&in.EnableAdmissionPlugins))
+	out.DisableAdmissionPlugins = *(*[]string)(unsafe.Pointer(&in.DisableAdmissionPlugins))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	return nil
+}
+
+// Convert_kubeone_APIServerConfig_To_v1beta1_APIServerConfig is an autogenerated conversion function.
+func Convert_kubeone_APIServerConfig_To_v1beta1_APIServerConfig(in *kubeone.APIServerConfig, out *APIServerConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_APIServerConfig_To_v1beta1_APIServerConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_AWSKMSPlugin_To_kubeone_AWSKMSPlugin(in *AWSKMSPlugin, out *kubeone.AWSKMSPlugin, s conversion.Scope) error {
+	out.KeyARN = in.KeyARN
+	out.Region = in.Region
+	return nil
+}
+
+// Convert_v1beta1_AWSKMSPlugin_To_kubeone_AWSKMSPlugin is an autogenerated conversion function.
+func Convert_v1beta1_AWSKMSPlugin_To_kubeone_AWSKMSPlugin(in *AWSKMSPlugin, out *kubeone.AWSKMSPlugin, s conversion.Scope) error {
+	return autoConvert_v1beta1_AWSKMSPlugin_To_kubeone_AWSKMSPlugin(in, out, s)
+}
+
+func autoConvert_kubeone_AWSKMSPlugin_To_v1beta1_AWSKMSPlugin(in *kubeone.AWSKMSPlugin, out *AWSKMSPlugin, s conversion.Scope) error {
+	out.KeyARN = in.KeyARN
+	out.Region = in.Region
+	return nil
+}
+
+// Convert_kubeone_AWSKMSPlugin_To_v1beta1_AWSKMSPlugin is an autogenerated conversion function.
+func Convert_kubeone_AWSKMSPlugin_To_v1beta1_AWSKMSPlugin(in *kubeone.AWSKMSPlugin, out *AWSKMSPlugin, s conversion.Scope) error {
+	return autoConvert_kubeone_AWSKMSPlugin_To_v1beta1_AWSKMSPlugin(in, out, s)
+}
+
 func autoConvert_v1beta1_AWSSpec_To_kubeone_AWSSpec(in *AWSSpec, out *kubeone.AWSSpec, s conversion.Scope) error {
 	return nil
 }
@@ -604,6 +1492,10 @@ func autoConvert_v1beta1_Addon_To_kubeone_Addon(in *Addon, out *kubeone.Addon, s
 	out.Name = in.Name
 	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
 	out.Delete = in.Delete
+	out.Priority = in.Priority
+	out.DependsOn = *(*[]string)(unsafe.Pointer(&in.DependsOn))
+	out.WaitReady = in.WaitReady
+	out.Helm = (*kubeone.HelmChart)(unsafe.Pointer(in.Helm))
 	return nil
 }
 
@@ -616,6 +1508,10 @@ func autoConvert_kubeone_Addon_To_v1beta1_Addon(in *kubeone.Addon, out *Addon, s
 	out.Name = in.Name
 	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
 	out.Delete = in.Delete
+	out.Priority = in.Priority
+	out.DependsOn = *(*[]string)(unsafe.Pointer(&in.DependsOn))
+	out.WaitReady = in.WaitReady
+	out.Helm = (*HelmChart)(unsafe.Pointer(in.Helm))
 	return nil
 }
 
@@ -624,9 +1520,34 @@ func Convert_kubeone_Addon_To_v1beta1_Addon(in *kubeone.Addon, out *Addon, s con
 	return autoConvert_kubeone_Addon_To_v1beta1_Addon(in, out, s)
 }
 
+func autoConvert_v1beta1_AddonSource_To_kubeone_AddonSource(in *AddonSource, out *kubeone.AddonSource, s conversion.Scope) error {
+	out.Git = (*kubeone.GitAddonSource)(unsafe.Pointer(in.Git))
+	out.HTTP = (*kubeone.HTTPAddonSource)(unsafe.Pointer(in.HTTP))
+	out.S3 = (*kubeone.S3AddonSource)(unsafe.Pointer(in.S3))
+	return nil
+}
+
+// Convert_v1beta1_AddonSource_To_kubeone_AddonSource is an autogenerated conversion function.
+func Convert_v1beta1_AddonSource_To_kubeone_AddonSource(in *AddonSource, out *kubeone.AddonSource, s conversion.Scope) error {
+	return autoConvert_v1beta1_AddonSource_To_kubeone_AddonSource(in, out, s)
+}
+
+func autoConvert_kubeone_AddonSource_To_v1beta1_AddonSource(in *kubeone.AddonSource, out *AddonSource, s conversion.Scope) error {
+	out.Git = (*GitAddonSource)(unsafe.Pointer(in.Git))
+	out.HTTP = (*HTTPAddonSource)(unsafe.Pointer(in.HTTP))
+	out.S3 = (*S3AddonSource)(unsafe.Pointer(in.S3))
+	return nil
+}
+
+// Convert_kubeone_AddonSource_To_v1beta1_AddonSource is an autogenerated conversion function.
+func Convert_kubeone_AddonSource_To_v1beta1_AddonSource(in *kubeone.AddonSource, out *AddonSource, s conversion.Scope) error {
+	return autoConvert_kubeone_AddonSource_To_v1beta1_AddonSource(in, out, s)
+}
+
 func autoConvert_v1beta1_Addons_To_kubeone_Addons(in *Addons, out *kubeone.Addons, s conversion.Scope) error {
 	out.Enable = in.Enable
 	out.Path = in.Path
+	out.Sources = *(*[]kubeone.AddonSource)(unsafe.Pointer(&in.Sources))
 	out.GlobalParams = *(*map[string]string)(unsafe.Pointer(&in.GlobalParams))
 	out.Addons = *(*[]kubeone.Addon)(unsafe.Pointer(&in.Addons))
 	return nil
@@ -640,6 +1561,7 @@ func Convert_v1beta1_Addons_To_kubeone_Addons(in *Addons, out *kubeone.Addons, s
 func autoConvert_kubeone_Addons_To_v1beta1_Addons(in *kubeone.Addons, out *Addons, s conversion.Scope) error {
 	out.Enable = in.Enable
 	out.Path = in.Path
+	out.Sources = *(*[]AddonSource)(unsafe.Pointer(&in.Sources))
 	out.GlobalParams = *(*map[string]string)(unsafe.Pointer(&in.GlobalParams))
 	out.Addons = *(*[]Addon)(unsafe.Pointer(&in.Addons))
 	return nil
@@ -650,6 +1572,92 @@ func Convert_kubeone_Addons_To_v1beta1_Addons(in *kubeone.Addons, out *Addons, s
 	return autoConvert_kubeone_Addons_To_v1beta1_Addons(in, out, s)
 }
 
+func autoConvert_v1beta1_AlwaysPullImages_To_kubeone_AlwaysPullImages(in *AlwaysPullImages, out *kubeone.AlwaysPullImages, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_v1beta1_AlwaysPullImages_To_kubeone_AlwaysPullImages is an autogenerated conversion function.
+func Convert_v1beta1_AlwaysPullImages_To_kubeone_AlwaysPullImages(in *AlwaysPullImages, out *kubeone.AlwaysPullImages, s conversion.Scope) error {
+	return autoConvert_v1beta1_AlwaysPullImages_To_kubeone_AlwaysPullImages(in, out, s)
+}
+
+func autoConvert_kubeone_AlwaysPullImages_To_v1beta1_AlwaysPullImages(in *kubeone.AlwaysPullImages, out *AlwaysPullImages, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_kubeone_AlwaysPullImages_To_v1beta1_AlwaysPullImages is an autogenerated conversion function.
+func Convert_kubeone_AlwaysPullImages_To_v1beta1_AlwaysPullImages(in *kubeone.AlwaysPullImages, out *AlwaysPullImages, s conversion.Scope) error {
+	return autoConvert_kubeone_AlwaysPullImages_To_v1beta1_AlwaysPullImages(in, out, s)
+}
+
+func autoConvert_v1beta1_AppArmor_To_kubeone_AppArmor(in *AppArmor, out *kubeone.AppArmor, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Profiles = *(*[]kubeone.AppArmorProfile)(unsafe.Pointer(&in.Profiles))
+	return nil
+}
+
+// Convert_v1beta1_AppArmor_To_kubeone_AppArmor is an autogenerated conversion function.
+func Convert_v1beta1_AppArmor_To_kubeone_AppArmor(in *AppArmor, out *kubeone.AppArmor, s conversion.Scope) error {
+	return autoConvert_v1beta1_AppArmor_To_kubeone_AppArmor(in, out, s)
+}
+
+func autoConvert_kubeone_AppArmor_To_v1beta1_AppArmor(in *kubeone.AppArmor, out *AppArmor, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Profiles = *(*[]AppArmorProfile)(unsafe.Pointer(&in.Profiles))
+	return nil
+}
+
+// Convert_kubeone_AppArmor_To_v1beta1_AppArmor is an autogenerated conversion function.
+func Convert_kubeone_AppArmor_To_v1beta1_AppArmor(in *kubeone.AppArmor, out *AppArmor, s conversion.Scope) error {
+	return autoConvert_kubeone_AppArmor_To_v1beta1_AppArmor(in, out, s)
+}
+
+func autoConvert_v1beta1_AppArmorProfile_To_kubeone_AppArmorProfile(in *AppArmorProfile, out *kubeone.AppArmorProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ProfileFilePath = in.ProfileFilePath
+	return nil
+}
+
+// Convert_v1beta1_AppArmorProfile_To_kubeone_AppArmorProfile is an autogenerated conversion function.
+func Convert_v1beta1_AppArmorProfile_To_kubeone_AppArmorProfile(in *AppArmorProfile, out *kubeone.AppArmorProfile, s conversion.Scope) error {
+	return autoConvert_v1beta1_AppArmorProfile_To_kubeone_AppArmorProfile(in, out, s)
+}
+
+func autoConvert_kubeone_AppArmorProfile_To_v1beta1_AppArmorProfile(in *kubeone.AppArmorProfile, out *AppArmorProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ProfileFilePath = in.ProfileFilePath
+	return nil
+}
+
+// Convert_kubeone_AppArmorProfile_To_v1beta1_AppArmorProfile is an autogenerated conversion function.
+func Convert_kubeone_AppArmorProfile_To_v1beta1_AppArmorProfile(in *kubeone.AppArmorProfile, out *AppArmorProfile, s conversion.Scope) error {
+	return autoConvert_kubeone_AppArmorProfile_To_v1beta1_AppArmorProfile(in, out, s)
+}
+
+func autoConvert_v1beta1_AssetCache_To_kubeone_AssetCache(in *AssetCache, out *kubeone.AssetCache, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1beta1_AssetCache_To_kubeone_AssetCache is an autogenerated conversion function.
+func Convert_v1beta1_AssetCache_To_kubeone_AssetCache(in *AssetCache, out *kubeone.AssetCache, s conversion.Scope) error {
+	return autoConvert_v1beta1_AssetCache_To_kubeone_AssetCache(in, out, s)
+}
+
+func autoConvert_kubeone_AssetCache_To_v1beta1_AssetCache(in *kubeone.AssetCache, out *AssetCache, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_kubeone_AssetCache_To_v1beta1_AssetCache is an autogenerated conversion function.
+func Convert_kubeone_AssetCache_To_v1beta1_AssetCache(in *kubeone.AssetCache, out *AssetCache, s conversion.Scope) error {
+	return autoConvert_kubeone_AssetCache_To_v1beta1_AssetCache(in, out, s)
+}
+
 func autoConvert_v1beta1_AssetConfiguration_To_kubeone_AssetConfiguration(in *AssetConfiguration, out *kubeone.AssetConfiguration, s conversion.Scope) error {
 	if err := Convert_v1beta1_ImageAsset_To_kubeone_ImageAsset(&in.Kubernetes, &out.Kubernetes, s); err != nil {
 		return err
@@ -675,6 +1683,7 @@ func autoConvert_v1beta1_AssetConfiguration_To_kubeone_AssetConfiguration(in *As
 	if err := Convert_v1beta1_BinaryAsset_To_kubeone_BinaryAsset(&in.Kubectl, &out.Kubectl, s); err != nil {
 		return err
 	}
+	out.Cache = (*kubeone.AssetCache)(unsafe.Pointer(in.Cache))
 	return nil
 }
 
@@ -708,6 +1717,7 @@ func autoConvert_kubeone_AssetConfiguration_To_v1beta1_AssetConfiguration(in *ku
 	if err := Convert_kubeone_BinaryAsset_To_v1beta1_BinaryAsset(&in.Kubectl, &out.Kubectl, s); err != nil {
 		return err
 	}
+	out.Cache = (*AssetCache)(unsafe.Pointer(in.Cache))
 	return nil
 }
 
@@ -716,6 +1726,84 @@ func Convert_kubeone_AssetConfiguration_To_v1beta1_AssetConfiguration(in *kubeon
 	return autoConvert_kubeone_AssetConfiguration_To_v1beta1_AssetConfiguration(in, out, s)
 }
 
+func autoConvert_v1beta1_AuditWebhookConfig_To_kubeone_AuditWebhookConfig(in *AuditWebhookConfig, out *kubeone.AuditWebhookConfig, s conversion.Scope) error {
+	out.ConfigFilePath = in.ConfigFilePath
+	out.Mode = in.Mode
+	out.InitialBackoff = in.InitialBackoff
+	out.BatchBufferSize = in.BatchBufferSize
+	out.BatchMaxSize = in.BatchMaxSize
+	out.BatchMaxWait = in.BatchMaxWait
+	return nil
+}
+
+// Convert_v1beta1_AuditWebhookConfig_To_kubeone_AuditWebhookConfig is an autogenerated conversion function.
+func Convert_v1beta1_AuditWebhookConfig_To_kubeone_AuditWebhookConfig(in *AuditWebhookConfig, out *kubeone.AuditWebhookConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_AuditWebhookConfig_To_kubeone_AuditWebhookConfig(in, out, s)
+}
+
+func autoConvert_kubeone_AuditWebhookConfig_To_v1beta1_AuditWebhookConfig(in *kubeone.AuditWebhookConfig, out *AuditWebhookConfig, s conversion.Scope) error {
+	out.ConfigFilePath = in.ConfigFilePath
+	out.Mode = in.Mode
+	out.InitialBackoff = in.InitialBackoff
+	out.BatchBufferSize = in.BatchBufferSize
+	out.BatchMaxSize = in.BatchMaxSize
+	out.BatchMaxWait = in.BatchMaxWait
+	return nil
+}
+
+// Convert_kubeone_AuditWebhookConfig_To_v1beta1_AuditWebhookConfig is an autogenerated conversion function.
+func Convert_kubeone_AuditWebhookConfig_To_v1beta1_AuditWebhookConfig(in *kubeone.AuditWebhookConfig, out *AuditWebhookConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_AuditWebhookConfig_To_v1beta1_AuditWebhookConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_AzureBlobStateBackend_To_kubeone_AzureBlobStateBackend(in *AzureBlobStateBackend, out *kubeone.AzureBlobStateBackend, s conversion.Scope) error {
+	out.StorageAccount = in.StorageAccount
+	out.Container = in.Container
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_v1beta1_AzureBlobStateBackend_To_kubeone_AzureBlobStateBackend is an autogenerated conversion function.
+func Convert_v1beta1_AzureBlobStateBackend_To_kubeone_AzureBlobStateBackend(in *AzureBlobStateBackend, out *kubeone.AzureBlobStateBackend, s conversion.Scope) error {
+	return autoConvert_v1beta1_AzureBlobStateBackend_To_kubeone_AzureBlobStateBackend(in, out, s)
+}
+
+func autoConvert_kubeone_AzureBlobStateBackend_To_v1beta1_AzureBlobStateBackend(in *kubeone.AzureBlobStateBackend, out *AzureBlobStateBackend, s conversion.Scope) error {
+	out.StorageAccount = in.StorageAccount
+	out.Container = in.Container
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_kubeone_AzureBlobStateBackend_To_v1beta1_AzureBlobStateBackend is an autogenerated conversion function.
+func Convert_kubeone_AzureBlobStateBackend_To_v1beta1_AzureBlobStateBackend(in *kubeone.AzureBlobStateBackend, out *AzureBlobStateBackend, s conversion.Scope) error {
+	return autoConvert_kubeone_AzureBlobStateBackend_To_v1beta1_AzureBlobStateBackend(in, out, s)
+}
+
+func autoConvert_v1beta1_AzureKMSPlugin_To_kubeone_AzureKMSPlugin(in *AzureKMSPlugin, out *kubeone.AzureKMSPlugin, s conversion.Scope) error {
+	out.KeyVaultName = in.KeyVaultName
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	return nil
+}
+
+// Convert_v1beta1_AzureKMSPlugin_To_kubeone_AzureKMSPlugin is an autogenerated conversion function.
+func Convert_v1beta1_AzureKMSPlugin_To_kubeone_AzureKMSPlugin(in *AzureKMSPlugin, out *kubeone.AzureKMSPlugin, s conversion.Scope) error {
+	return autoConvert_v1beta1_AzureKMSPlugin_To_kubeone_AzureKMSPlugin(in, out, s)
+}
+
+func autoConvert_kubeone_AzureKMSPlugin_To_v1beta1_AzureKMSPlugin(in *kubeone.AzureKMSPlugin, out *AzureKMSPlugin, s conversion.Scope) error {
+	out.KeyVaultName = in.KeyVaultName
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	return nil
+}
+
+// Convert_kubeone_AzureKMSPlugin_To_v1beta1_AzureKMSPlugin is an autogenerated conversion function.
+func Convert_kubeone_AzureKMSPlugin_To_v1beta1_AzureKMSPlugin(in *kubeone.AzureKMSPlugin, out *AzureKMSPlugin, s conversion.Scope) error {
+	return autoConvert_kubeone_AzureKMSPlugin_To_v1beta1_AzureKMSPlugin(in, out, s)
+}
+
 func autoConvert_v1beta1_AzureSpec_To_kubeone_AzureSpec(in *AzureSpec, out *kubeone.AzureSpec, s conversion.Scope) error {
 	return nil
 }
@@ -734,8 +1822,56 @@ func Convert_kubeone_AzureSpec_To_v1beta1_AzureSpec(in *kubeone.AzureSpec, out *
 	return autoConvert_kubeone_AzureSpec_To_v1beta1_AzureSpec(in, out, s)
 }
 
+func autoConvert_v1beta1_Backups_To_kubeone_Backups(in *Backups, out *kubeone.Backups, s conversion.Scope) error {
+	out.Storage = (*kubeone.StateBackend)(unsafe.Pointer(in.Storage))
+	return nil
+}
+
+// Convert_v1beta1_Backups_To_kubeone_Backups is an autogenerated conversion function.
+func Convert_v1beta1_Backups_To_kubeone_Backups(in *Backups, out *kubeone.Backups, s conversion.Scope) error {
+	return autoConvert_v1beta1_Backups_To_kubeone_Backups(in, out, s)
+}
+
+func autoConvert_kubeone_Backups_To_v1beta1_Backups(in *kubeone.Backups, out *Backups, s conversion.Scope) error {
+	out.Storage = (*StateBackend)(unsafe.Pointer(in.Storage))
+	return nil
+}
+
+// Convert_kubeone_Backups_To_v1beta1_Backups is an autogenerated conversion function.
+func Convert_kubeone_Backups_To_v1beta1_Backups(in *kubeone.Backups, out *Backups, s conversion.Scope) error {
+	return autoConvert_kubeone_Backups_To_v1beta1_Backups(in, out, s)
+}
+
+func autoConvert_v1beta1_BastionConfig_To_kubeone_BastionConfig(in *BastionConfig, out *kubeone.BastionConfig, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	out.User = in.User
+	out.SSHPrivateKeyFile = in.SSHPrivateKeyFile
+	return nil
+}
+
+// Convert_v1beta1_BastionConfig_To_kubeone_BastionConfig is an autogenerated conversion function.
+func Convert_v1beta1_BastionConfig_To_kubeone_BastionConfig(in *BastionConfig, out *kubeone.BastionConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_BastionConfig_To_kubeone_BastionConfig(in, out, s)
+}
+
+func autoConvert_kubeone_BastionConfig_To_v1beta1_BastionConfig(in *kubeone.BastionConfig, out *BastionConfig, s conversion.Scope) error {
+	out.Host = in.Host
+	out.Port = in.Port
+	out.User = in.User
+	out.SSHPrivateKeyFile = in.SSHPrivateKeyFile
+	return nil
+}
+
+// Convert_kubeone_BastionConfig_To_v1beta1_BastionConfig is an autogenerated conversion function.
+func Convert_kubeone_BastionConfig_To_v1beta1_BastionConfig(in *kubeone.BastionConfig, out *BastionConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_BastionConfig_To_v1beta1_BastionConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_BinaryAsset_To_kubeone_BinaryAsset(in *BinaryAsset, out *kubeone.BinaryAsset, s conversion.Scope) error {
 	out.URL = in.URL
+	out.Mirrors = *(*[]string)(unsafe.Pointer(&in.Mirrors))
+	out.SHA256 = in.SHA256
 	return nil
 }
 
@@ -746,6 +1882,8 @@ func Convert_v1beta1_BinaryAsset_To_kubeone_BinaryAsset(in *BinaryAsset, out *ku
 
 func autoConvert_kubeone_BinaryAsset_To_v1beta1_BinaryAsset(in *kubeone.BinaryAsset, out *BinaryAsset, s conversion.Scope) error {
 	out.URL = in.URL
+	out.Mirrors = *(*[]string)(unsafe.Pointer(&in.Mirrors))
+	out.SHA256 = in.SHA256
 	return nil
 }
 
@@ -754,9 +1892,37 @@ func Convert_kubeone_BinaryAsset_To_v1beta1_BinaryAsset(in *kubeone.BinaryAsset,
 	return autoConvert_kubeone_BinaryAsset_To_v1beta1_BinaryAsset(in, out, s)
 }
 
+func autoConvert_v1beta1_BootstrapRBAC_To_kubeone_BootstrapRBAC(in *BootstrapRBAC, out *kubeone.BootstrapRBAC, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Viewers = *(*[]string)(unsafe.Pointer(&in.Viewers))
+	out.Operators = *(*[]string)(unsafe.Pointer(&in.Operators))
+	out.NamespaceAdmins = *(*[]kubeone.NamespaceAdmins)(unsafe.Pointer(&in.NamespaceAdmins))
+	return nil
+}
+
+// Convert_v1beta1_BootstrapRBAC_To_kubeone_BootstrapRBAC is an autogenerated conversion function.
+func Convert_v1beta1_BootstrapRBAC_To_kubeone_BootstrapRBAC(in *BootstrapRBAC, out *kubeone.BootstrapRBAC, s conversion.Scope) error {
+	return autoConvert_v1beta1_BootstrapRBAC_To_kubeone_BootstrapRBAC(in, out, s)
+}
+
+func autoConvert_kubeone_BootstrapRBAC_To_v1beta1_BootstrapRBAC(in *kubeone.BootstrapRBAC, out *BootstrapRBAC, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Viewers = *(*[]string)(unsafe.Pointer(&in.Viewers))
+	out.Operators = *(*[]string)(unsafe.Pointer(&in.Operators))
+	out.NamespaceAdmins = *(*[]NamespaceAdmins)(unsafe.Pointer(&in.NamespaceAdmins))
+	return nil
+}
+
+// Convert_kubeone_BootstrapRBAC_To_v1beta1_BootstrapRBAC is an autogenerated conversion function.
+func Convert_kubeone_BootstrapRBAC_To_v1beta1_BootstrapRBAC(in *kubeone.BootstrapRBAC, out *BootstrapRBAC, s conversion.Scope) error {
+	return autoConvert_kubeone_BootstrapRBAC_To_v1beta1_BootstrapRBAC(in, out, s)
+}
+
 func autoConvert_v1beta1_CNI_To_kubeone_CNI(in *CNI, out *kubeone.CNI, s conversion.Scope) error {
 	out.Canal = (*kubeone.CanalSpec)(unsafe.Pointer(in.Canal))
 	out.WeaveNet = (*kubeone.WeaveNetSpec)(unsafe.Pointer(in.WeaveNet))
+	out.Cilium = (*kubeone.CiliumSpec)(unsafe.Pointer(in.Cilium))
+	out.Calico = (*kubeone.CalicoSpec)(unsafe.Pointer(in.Calico))
 	out.External = (*kubeone.ExternalCNISpec)(unsafe.Pointer(in.External))
 	return nil
 }
@@ -769,6 +1935,8 @@ func Convert_v1beta1_CNI_To_kubeone_CNI(in *CNI, out *kubeone.CNI, s conversion.
 func autoConvert_kubeone_CNI_To_v1beta1_CNI(in *kubeone.CNI, out *CNI, s conversion.Scope) error {
 	out.Canal = (*CanalSpec)(unsafe.Pointer(in.Canal))
 	out.WeaveNet = (*WeaveNetSpec)(unsafe.Pointer(in.WeaveNet))
+	out.Cilium = (*CiliumSpec)(unsafe.Pointer(in.Cilium))
+	out.Calico = (*CalicoSpec)(unsafe.Pointer(in.Calico))
 	out.External = (*ExternalCNISpec)(unsafe.Pointer(in.External))
 	return nil
 }
@@ -778,8 +1946,87 @@ func Convert_kubeone_CNI_To_v1beta1_CNI(in *kubeone.CNI, out *CNI, s conversion.
 	return autoConvert_kubeone_CNI_To_v1beta1_CNI(in, out, s)
 }
 
+func autoConvert_v1beta1_CalicoBGPPeer_To_kubeone_CalicoBGPPeer(in *CalicoBGPPeer, out *kubeone.CalicoBGPPeer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.PeerIP = in.PeerIP
+	out.ASNumber = in.ASNumber
+	out.NodeSelector = in.NodeSelector
+	return nil
+}
+
+// Convert_v1beta1_CalicoBGPPeer_To_kubeone_CalicoBGPPeer is an autogenerated conversion function.
+func Convert_v1beta1_CalicoBGPPeer_To_kubeone_CalicoBGPPeer(in *CalicoBGPPeer, out *kubeone.CalicoBGPPeer, s conversion.Scope) error {
+	return autoConvert_v1beta1_CalicoBGPPeer_To_kubeone_CalicoBGPPeer(in, out, s)
+}
+
+func autoConvert_kubeone_CalicoBGPPeer_To_v1beta1_CalicoBGPPeer(in *kubeone.CalicoBGPPeer, out *CalicoBGPPeer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.PeerIP = in.PeerIP
+	out.ASNumber = in.ASNumber
+	out.NodeSelector = in.NodeSelector
+	return nil
+}
+
+// Convert_kubeone_CalicoBGPPeer_To_v1beta1_CalicoBGPPeer is an autogenerated conversion function.
+func Convert_kubeone_CalicoBGPPeer_To_v1beta1_CalicoBGPPeer(in *kubeone.CalicoBGPPeer, out *CalicoBGPPeer, s conversion.Scope) error {
+	return autoConvert_kubeone_CalicoBGPPeer_To_v1beta1_CalicoBGPPeer(in, out, s)
+}
+
+func autoConvert_v1beta1_CalicoBGPSpec_To_kubeone_CalicoBGPSpec(in *CalicoBGPSpec, out *kubeone.CalicoBGPSpec, s conversion.Scope) error {
+	out.ASNumber = in.ASNumber
+	out.DisableNodeToNodeMesh = in.DisableNodeToNodeMesh
+	out.Peers = *(*[]kubeone.CalicoBGPPeer)(unsafe.Pointer(&in.Peers))
+	return nil
+}
+
+// Convert_v1beta1_CalicoBGPSpec_To_kubeone_CalicoBGPSpec is an autogenerated conversion function.
+func Convert_v1beta1_CalicoBGPSpec_To_kubeone_CalicoBGPSpec(in *CalicoBGPSpec, out *kubeone.CalicoBGPSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_CalicoBGPSpec_To_kubeone_CalicoBGPSpec(in, out, s)
+}
+
+func autoConvert_kubeone_CalicoBGPSpec_To_v1beta1_CalicoBGPSpec(in *kubeone.CalicoBGPSpec, out *CalicoBGPSpec, s conversion.Scope) error {
+	out.ASNumber = in.ASNumber
+	out.DisableNodeToNodeMesh = in.DisableNodeToNodeMesh
+	out.Peers = *(*[]CalicoBGPPeer)(unsafe.Pointer(&in.Peers))
+	return nil
+}
+
+// Convert_kubeone_CalicoBGPSpec_To_v1beta1_CalicoBGPSpec is an autogenerated conversion function.
+func Convert_kubeone_CalicoBGPSpec_To_v1beta1_CalicoBGPSpec(in *kubeone.CalicoBGPSpec, out *CalicoBGPSpec, s conversion.Scope) error {
+	return autoConvert_kubeone_CalicoBGPSpec_To_v1beta1_CalicoBGPSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_CalicoSpec_To_kubeone_CalicoSpec(in *CalicoSpec, out *kubeone.CalicoSpec, s conversion.Scope) error {
+	out.Mode = kubeone.CalicoMode(in.Mode)
+	out.CrossSubnet = in.CrossSubnet
+	out.MTU = in.MTU
+	out.BGP = (*kubeone.CalicoBGPSpec)(unsafe.Pointer(in.BGP))
+	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
+	return nil
+}
+
+// Convert_v1beta1_CalicoSpec_To_kubeone_CalicoSpec is an autogenerated conversion function.
+func Convert_v1beta1_CalicoSpec_To_kubeone_CalicoSpec(in *CalicoSpec, out *kubeone.CalicoSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_CalicoSpec_To_kubeone_CalicoSpec(in, out, s)
+}
+
+func autoConvert_kubeone_CalicoSpec_To_v1beta1_CalicoSpec(in *kubeone.CalicoSpec, out *CalicoSpec, s conversion.Scope) error {
+	out.Mode = CalicoMode(in.Mode)
+	out.CrossSubnet = in.CrossSubnet
+	out.MTU = in.MTU
+	out.BGP = (*CalicoBGPSpec)(unsafe.Pointer(in.BGP))
+	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
+	return nil
+}
+
+// Convert_kubeone_CalicoSpec_To_v1beta1_CalicoSpec is an autogenerated conversion function.
+func Convert_kubeone_CalicoSpec_To_v1beta1_CalicoSpec(in *kubeone.CalicoSpec, out *CalicoSpec, s conversion.Scope) error {
+	return autoConvert_kubeone_CalicoSpec_To_v1beta1_CalicoSpec(in, out, s)
+}
+
 func autoConvert_v1beta1_CanalSpec_To_kubeone_CanalSpec(in *CanalSpec, out *kubeone.CanalSpec, s conversion.Scope) error {
 	out.MTU = in.MTU
+	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
 	return nil
 }
 
@@ -790,6 +2037,7 @@ func Convert_v1beta1_CanalSpec_To_kubeone_CanalSpec(in *CanalSpec, out *kubeone.
 
 func autoConvert_kubeone_CanalSpec_To_v1beta1_CanalSpec(in *kubeone.CanalSpec, out *CanalSpec, s conversion.Scope) error {
 	out.MTU = in.MTU
+	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
 	return nil
 }
 
@@ -798,6 +2046,54 @@ func Convert_kubeone_CanalSpec_To_v1beta1_CanalSpec(in *kubeone.CanalSpec, out *
 	return autoConvert_kubeone_CanalSpec_To_v1beta1_CanalSpec(in, out, s)
 }
 
+func autoConvert_v1beta1_CiliumHubbleSpec_To_kubeone_CiliumHubbleSpec(in *CiliumHubbleSpec, out *kubeone.CiliumHubbleSpec, s conversion.Scope) error {
+	out.Relay = in.Relay
+	out.UI = in.UI
+	out.UIServiceType = v1.ServiceType(in.UIServiceType)
+	return nil
+}
+
+// Convert_v1beta1_CiliumHubbleSpec_To_kubeone_CiliumHubbleSpec is an autogenerated conversion function.
+func Convert_v1beta1_CiliumHubbleSpec_To_kubeone_CiliumHubbleSpec(in *CiliumHubbleSpec, out *kubeone.CiliumHubbleSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_CiliumHubbleSpec_To_kubeone_CiliumHubbleSpec(in, out, s)
+}
+
+func autoConvert_kubeone_CiliumHubbleSpec_To_v1beta1_CiliumHubbleSpec(in *kubeone.CiliumHubbleSpec, out *CiliumHubbleSpec, s conversion.Scope) error {
+	out.Relay = in.Relay
+	out.UI = in.UI
+	out.UIServiceType = v1.ServiceType(in.UIServiceType)
+	return nil
+}
+
+// Convert_kubeone_CiliumHubbleSpec_To_v1beta1_CiliumHubbleSpec is an autogenerated conversion function.
+func Convert_kubeone_CiliumHubbleSpec_To_v1beta1_CiliumHubbleSpec(in *kubeone.CiliumHubbleSpec, out *CiliumHubbleSpec, s conversion.Scope) error {
+	return autoConvert_kubeone_CiliumHubbleSpec_To_v1beta1_CiliumHubbleSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_CiliumSpec_To_kubeone_CiliumSpec(in *CiliumSpec, out *kubeone.CiliumSpec, s conversion.Scope) error {
+	out.KubeProxyReplacement = kubeone.CiliumKubeProxyReplacement(in.KubeProxyReplacement)
+	out.Hubble = (*kubeone.CiliumHubbleSpec)(unsafe.Pointer(in.Hubble))
+	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
+	return nil
+}
+
+// Convert_v1beta1_CiliumSpec_To_kubeone_CiliumSpec is an autogenerated conversion function.
+func Convert_v1beta1_CiliumSpec_To_kubeone_CiliumSpec(in *CiliumSpec, out *kubeone.CiliumSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_CiliumSpec_To_kubeone_CiliumSpec(in, out, s)
+}
+
+func autoConvert_kubeone_CiliumSpec_To_v1beta1_CiliumSpec(in *kubeone.CiliumSpec, out *CiliumSpec, s conversion.Scope) error {
+	out.KubeProxyReplacement = CiliumKubeProxyReplacement(in.KubeProxyReplacement)
+	out.Hubble = (*CiliumHubbleSpec)(unsafe.Pointer(in.Hubble))
+	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
+	return nil
+}
+
+// Convert_kubeone_CiliumSpec_To_v1beta1_CiliumSpec is an autogenerated conversion function.
+func Convert_kubeone_CiliumSpec_To_v1beta1_CiliumSpec(in *kubeone.CiliumSpec, out *CiliumSpec, s conversion.Scope) error {
+	return autoConvert_kubeone_CiliumSpec_To_v1beta1_CiliumSpec(in, out, s)
+}
+
 func autoConvert_v1beta1_CloudProviderSpec_To_kubeone_CloudProviderSpec(in *CloudProviderSpec, out *kubeone.CloudProviderSpec, s conversion.Scope) error {
 	out.External = in.External
 	out.CloudConfig = in.CloudConfig
@@ -807,9 +2103,12 @@ func autoConvert_v1beta1_CloudProviderSpec_To_kubeone_CloudProviderSpec(in *Clou
 	out.DigitalOcean = (*kubeone.DigitalOceanSpec)(unsafe.Pointer(in.DigitalOcean))
 	out.GCE = (*kubeone.GCESpec)(unsafe.Pointer(in.GCE))
 	out.Hetzner = (*kubeone.HetznerSpec)(unsafe.Pointer(in.Hetzner))
+	out.KubeVirt = (*kubeone.KubeVirtSpec)(unsafe.Pointer(in.KubeVirt))
+	out.Nutanix = (*kubeone.NutanixSpec)(unsafe.Pointer(in.Nutanix))
 	out.Openstack = (*kubeone.OpenstackSpec)(unsafe.Pointer(in.Openstack))
 	out.Packet = (*kubeone.PacketSpec)(unsafe.Pointer(in.Packet))
 	out.Vsphere = (*kubeone.VsphereSpec)(unsafe.Pointer(in.Vsphere))
+	out.VMwareCloudDirector = (*kubeone.VMwareCloudDirectorSpec)(unsafe.Pointer(in.VMwareCloudDirector))
 	out.None = (*kubeone.NoneSpec)(unsafe.Pointer(in.None))
 	return nil
 }
@@ -828,9 +2127,12 @@ func autoConvert_kubeone_CloudProviderSpec_To_v1beta1_CloudProviderSpec(in *kube
 	out.DigitalOcean = (*DigitalOceanSpec)(unsafe.Pointer(in.DigitalOcean))
 	out.GCE = (*GCESpec)(unsafe.Pointer(in.GCE))
 	out.Hetzner = (*HetznerSpec)(unsafe.Pointer(in.Hetzner))
+	out.KubeVirt = (*KubeVirtSpec)(unsafe.Pointer(in.KubeVirt))
+	out.Nutanix = (*NutanixSpec)(unsafe.Pointer(in.Nutanix))
 	out.Openstack = (*OpenstackSpec)(unsafe.Pointer(in.Openstack))
 	out.Packet = (*PacketSpec)(unsafe.Pointer(in.Packet))
 	out.Vsphere = (*VsphereSpec)(unsafe.Pointer(in.Vsphere))
+	out.VMwareCloudDirector = (*VMwareCloudDirectorSpec)(unsafe.Pointer(in.VMwareCloudDirector))
 	out.None = (*NoneSpec)(unsafe.Pointer(in.None))
 	return nil
 }
@@ -842,11 +2144,16 @@ func Convert_kubeone_CloudProviderSpec_To_v1beta1_CloudProviderSpec(in *kubeone.
 
 func autoConvert_v1beta1_ClusterNetworkConfig_To_kubeone_ClusterNetworkConfig(in *ClusterNetworkConfig, out *kubeone.ClusterNetworkConfig, s conversion.Scope) error {
 	out.PodSubnet = in.PodSubnet
+	out.AdditionalPodSubnets = *(*[]string)(unsafe.Pointer(&in.AdditionalPodSubnets))
 	out.ServiceSubnet = in.ServiceSubnet
+	out.IPFamilies = *(*[]kubeone.IPFamily)(unsafe.Pointer(&in.IPFamilies))
+	out.PodSubnetIPv6 = in.PodSubnetIPv6
+	out.ServiceSubnetIPv6 = in.ServiceSubnetIPv6
 	out.ServiceDomainName = in.ServiceDomainName
 	out.NodePortRange = in.NodePortRange
 	out.CNI = (*kubeone.CNI)(unsafe.Pointer(in.CNI))
 	out.KubeProxy = (*kubeone.KubeProxyConfig)(unsafe.Pointer(in.KubeProxy))
+	out.CoreDNS = (*kubeone.CoreDNSConfig)(unsafe.Pointer(in.CoreDNS))
 	return nil
 }
 
@@ -857,11 +2164,16 @@ func Convert_v1beta1_ClusterNetworkConfig_To_kubeone_ClusterNetworkConfig(in *Cl
 
 func autoConvert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(in *kubeone.ClusterNetworkConfig, out *ClusterNetworkConfig, s conversion.Scope) error {
 	out.PodSubnet = in.PodSubnet
+	out.AdditionalPodSubnets = *(*[]string)(unsafe.Pointer(&in.AdditionalPodSubnets))
 	out.ServiceSubnet = in.ServiceSubnet
+	out.IPFamilies = *(*[]IPFamily)(unsafe.Pointer(&in.IPFamilies))
+	out.PodSubnetIPv6 = in.PodSubnetIPv6
+	out.ServiceSubnetIPv6 = in.ServiceSubnetIPv6
 	out.ServiceDomainName = in.ServiceDomainName
 	out.NodePortRange = in.NodePortRange
 	out.CNI = (*CNI)(unsafe.Pointer(in.CNI))
 	out.KubeProxy = (*KubeProxyConfig)(unsafe.Pointer(in.KubeProxy))
+	out.CoreDNS = (*CoreDNSConfig)(unsafe.Pointer(in.CoreDNS))
 	return nil
 }
 
@@ -870,9 +2182,54 @@ func Convert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(in *ku
 	return autoConvert_kubeone_ClusterNetworkConfig_To_v1beta1_ClusterNetworkConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_CommandRetryPolicy_To_kubeone_CommandRetryPolicy(in *CommandRetryPolicy, out *kubeone.CommandRetryPolicy, s conversion.Scope) error {
+	out.Attempts = in.Attempts
+	out.Backoff = in.Backoff
+	out.RetryableErrors = *(*[]string)(unsafe.Pointer(&in.RetryableErrors))
+	out.TaskAttempts = *(*map[string]int)(unsafe.Pointer(&in.TaskAttempts))
+	return nil
+}
+
+// Convert_v1beta1_CommandRetryPolicy_To_kubeone_CommandRetryPolicy is an autogenerated conversion function.
+func Convert_v1beta1_CommandRetryPolicy_To_kubeone_CommandRetryPolicy(in *CommandRetryPolicy, out *kubeone.CommandRetryPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_CommandRetryPolicy_To_kubeone_CommandRetryPolicy(in, out, s)
+}
+
+func autoConvert_kubeone_CommandRetryPolicy_To_v1beta1_CommandRetryPolicy(in *kubeone.CommandRetryPolicy, out *CommandRetryPolicy, s conversion.Scope) error {
+	out.Attempts = in.Attempts
+	out.Backoff = in.Backoff
+	out.RetryableErrors = *(*[]string)(unsafe.Pointer(&in.RetryableErrors))
+	out.TaskAttempts = *(*map[string]int)(unsafe.Pointer(&in.TaskAttempts))
+	return nil
+}
+
+// Convert_kubeone_CommandRetryPolicy_To_v1beta1_CommandRetryPolicy is an autogenerated conversion function.
+func Convert_kubeone_CommandRetryPolicy_To_v1beta1_CommandRetryPolicy(in *kubeone.CommandRetryPolicy, out *CommandRetryPolicy, s conversion.Scope) error {
+	return autoConvert_kubeone_CommandRetryPolicy_To_v1beta1_CommandRetryPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_ContainerRuntimeCRIO_To_kubeone_ContainerRuntimeCRIO(in *ContainerRuntimeCRIO, out *kubeone.ContainerRuntimeCRIO, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_v1beta1_ContainerRuntimeCRIO_To_kubeone_ContainerRuntimeCRIO is an autogenerated conversion function.
+func Convert_v1beta1_ContainerRuntimeCRIO_To_kubeone_ContainerRuntimeCRIO(in *ContainerRuntimeCRIO, out *kubeone.ContainerRuntimeCRIO, s conversion.Scope) error {
+	return autoConvert_v1beta1_ContainerRuntimeCRIO_To_kubeone_ContainerRuntimeCRIO(in, out, s)
+}
+
+func autoConvert_kubeone_ContainerRuntimeCRIO_To_v1beta1_ContainerRuntimeCRIO(in *kubeone.ContainerRuntimeCRIO, out *ContainerRuntimeCRIO, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_kubeone_ContainerRuntimeCRIO_To_v1beta1_ContainerRuntimeCRIO is an autogenerated conversion function.
+func Convert_kubeone_ContainerRuntimeCRIO_To_v1beta1_ContainerRuntimeCRIO(in *kubeone.ContainerRuntimeCRIO, out *ContainerRuntimeCRIO, s conversion.Scope) error {
+	return autoConvert_kubeone_ContainerRuntimeCRIO_To_v1beta1_ContainerRuntimeCRIO(in, out, s)
+}
+
 func autoConvert_v1beta1_ContainerRuntimeConfig_To_kubeone_ContainerRuntimeConfig(in *ContainerRuntimeConfig, out *kubeone.ContainerRuntimeConfig, s conversion.Scope) error {
 	out.Docker = (*kubeone.ContainerRuntimeDocker)(unsafe.Pointer(in.Docker))
 	out.Containerd = (*kubeone.ContainerRuntimeContainerd)(unsafe.Pointer(in.Containerd))
+	out.CRIO = (*kubeone.ContainerRuntimeCRIO)(unsafe.Pointer(in.CRIO))
 	return nil
 }
 
@@ -884,6 +2241,7 @@ func Convert_v1beta1_ContainerRuntimeConfig_To_kubeone_ContainerRuntimeConfig(in
 func autoConvert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(in *kubeone.ContainerRuntimeConfig, out *ContainerRuntimeConfig, s conversion.Scope) error {
 	out.Docker = (*ContainerRuntimeDocker)(unsafe.Pointer(in.Docker))
 	out.Containerd = (*ContainerRuntimeContainerd)(unsafe.Pointer(in.Containerd))
+	out.CRIO = (*ContainerRuntimeCRIO)(unsafe.Pointer(in.CRIO))
 	return nil
 }
 
@@ -893,6 +2251,7 @@ func Convert_kubeone_ContainerRuntimeConfig_To_v1beta1_ContainerRuntimeConfig(in
 }
 
 func autoConvert_v1beta1_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeContainerd(in *ContainerRuntimeContainerd, out *kubeone.ContainerRuntimeContainerd, s conversion.Scope) error {
+	out.Registries = *(*map[string]kubeone.RegistryMirror)(unsafe.Pointer(&in.Registries))
 	return nil
 }
 
@@ -901,51 +2260,254 @@ func Convert_v1beta1_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeConta
 	return autoConvert_v1beta1_ContainerRuntimeContainerd_To_kubeone_ContainerRuntimeContainerd(in, out, s)
 }
 
-func autoConvert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(in *kubeone.ContainerRuntimeContainerd, out *ContainerRuntimeContainerd, s conversion.Scope) error {
+func autoConvert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(in *kubeone.ContainerRuntimeContainerd, out *ContainerRuntimeContainerd, s conversion.Scope) error {
+	out.Registries = *(*map[string]RegistryMirror)(unsafe.Pointer(&in.Registries))
+	return nil
+}
+
+// Convert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd is an autogenerated conversion function.
+func Convert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(in *kubeone.ContainerRuntimeContainerd, out *ContainerRuntimeContainerd, s conversion.Scope) error {
+	return autoConvert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(in, out, s)
+}
+
+func autoConvert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(in *ContainerRuntimeDocker, out *kubeone.ContainerRuntimeDocker, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker is an autogenerated conversion function.
+func Convert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(in *ContainerRuntimeDocker, out *kubeone.ContainerRuntimeDocker, s conversion.Scope) error {
+	return autoConvert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(in, out, s)
+}
+
+func autoConvert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker(in *kubeone.ContainerRuntimeDocker, out *ContainerRuntimeDocker, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker is an autogenerated conversion function.
+func Convert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker(in *kubeone.ContainerRuntimeDocker, out *ContainerRuntimeDocker, s conversion.Scope) error {
+	return autoConvert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker(in, out, s)
+}
+
+func autoConvert_v1beta1_ControlPlaneComponentConfig_To_kubeone_ControlPlaneComponentConfig(in *ControlPlaneComponentConfig, out *kubeone.ControlPlaneComponentConfig, s conversion.Scope) error {
+	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.ExtraVolumes = *(*[]kubeone.ControlPlaneComponentVolume)(unsafe.Pointer(&in.ExtraVolumes))
+	return nil
+}
+
+// Convert_v1beta1_ControlPlaneComponentConfig_To_kubeone_ControlPlaneComponentConfig is an autogenerated conversion function.
+func Convert_v1beta1_ControlPlaneComponentConfig_To_kubeone_ControlPlaneComponentConfig(in *ControlPlaneComponentConfig, out *kubeone.ControlPlaneComponentConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ControlPlaneComponentConfig_To_kubeone_ControlPlaneComponentConfig(in, out, s)
+}
+
+func autoConvert_kubeone_ControlPlaneComponentConfig_To_v1beta1_ControlPlaneComponentConfig(in *kubeone.ControlPlaneComponentConfig, out *ControlPlaneComponentConfig, s conversion.Scope) error {
+	out.ExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.ExtraArgs))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.ExtraVolumes = *(*[]ControlPlaneComponentVolume)(unsafe.Pointer(&in.ExtraVolumes))
+	return nil
+}
+
+// Convert_kubeone_ControlPlaneComponentConfig_To_v1beta1_ControlPlaneComponentConfig is an autogenerated conversion function.
+func Convert_kubeone_ControlPlaneComponentConfig_To_v1beta1_ControlPlaneComponentConfig(in *kubeone.ControlPlaneComponentConfig, out *ControlPlaneComponentConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_ControlPlaneComponentConfig_To_v1beta1_ControlPlaneComponentConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_ControlPlaneComponentVolume_To_kubeone_ControlPlaneComponentVolume(in *ControlPlaneComponentVolume, out *kubeone.ControlPlaneComponentVolume, s conversion.Scope) error {
+	out.Name = in.Name
+	out.HostPath = in.HostPath
+	out.MountPath = in.MountPath
+	out.ReadOnly = in.ReadOnly
+	return nil
+}
+
+// Convert_v1beta1_ControlPlaneComponentVolume_To_kubeone_ControlPlaneComponentVolume is an autogenerated conversion function.
+func Convert_v1beta1_ControlPlaneComponentVolume_To_kubeone_ControlPlaneComponentVolume(in *ControlPlaneComponentVolume, out *kubeone.ControlPlaneComponentVolume, s conversion.Scope) error {
+	return autoConvert_v1beta1_ControlPlaneComponentVolume_To_kubeone_ControlPlaneComponentVolume(in, out, s)
+}
+
+func autoConvert_kubeone_ControlPlaneComponentVolume_To_v1beta1_ControlPlaneComponentVolume(in *kubeone.ControlPlaneComponentVolume, out *ControlPlaneComponentVolume, s conversion.Scope) error {
+	out.Name = in.Name
+	out.HostPath = in.HostPath
+	out.MountPath = in.MountPath
+	out.ReadOnly = in.ReadOnly
+	return nil
+}
+
+// Convert_kubeone_ControlPlaneComponentVolume_To_v1beta1_ControlPlaneComponentVolume is an autogenerated conversion function.
+func Convert_kubeone_ControlPlaneComponentVolume_To_v1beta1_ControlPlaneComponentVolume(in *kubeone.ControlPlaneComponentVolume, out *ControlPlaneComponentVolume, s conversion.Scope) error {
+	return autoConvert_kubeone_ControlPlaneComponentVolume_To_v1beta1_ControlPlaneComponentVolume(in, out, s)
+}
+
+func autoConvert_v1beta1_ControlPlaneComponents_To_kubeone_ControlPlaneComponents(in *ControlPlaneComponents, out *kubeone.ControlPlaneComponents, s conversion.Scope) error {
+	out.APIServer = (*kubeone.APIServerConfig)(unsafe.Pointer(in.APIServer))
+	out.ControllerManager = (*kubeone.ControlPlaneComponentConfig)(unsafe.Pointer(in.ControllerManager))
+	out.Scheduler = (*kubeone.ControlPlaneComponentConfig)(unsafe.Pointer(in.Scheduler))
+	return nil
+}
+
+// Convert_v1beta1_ControlPlaneComponents_To_kubeone_ControlPlaneComponents is an autogenerated conversion function.
+func Convert_v1beta1_ControlPlaneComponents_To_kubeone_ControlPlaneComponents(in *ControlPlaneComponents, out *kubeone.ControlPlaneComponents, s conversion.Scope) error {
+	return autoConvert_v1beta1_ControlPlaneComponents_To_kubeone_ControlPlaneComponents(in, out, s)
+}
+
+func autoConvert_kubeone_ControlPlaneComponents_To_v1beta1_ControlPlaneComponents(in *kubeone.ControlPlaneComponents, out *ControlPlaneComponents, s conversion.Scope) error {
+	out.APIServer = (*APIServerConfig)(unsafe.Pointer(in.APIServer))
+	out.ControllerManager = (*ControlPlaneComponentConfig)(unsafe.Pointer(in.ControllerManager))
+	out.Scheduler = (*ControlPlaneComponentConfig)(unsafe.Pointer(in.Scheduler))
+	return nil
+}
+
+// Convert_kubeone_ControlPlaneComponents_To_v1beta1_ControlPlaneComponents is an autogenerated conversion function.
+func Convert_kubeone_ControlPlaneComponents_To_v1beta1_ControlPlaneComponents(in *kubeone.ControlPlaneComponents, out *ControlPlaneComponents, s conversion.Scope) error {
+	return autoConvert_kubeone_ControlPlaneComponents_To_v1beta1_ControlPlaneComponents(in, out, s)
+}
+
+func autoConvert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(in *ControlPlaneConfig, out *kubeone.ControlPlaneConfig, s conversion.Scope) error {
+	out.Hosts = *(*[]kubeone.HostConfig)(unsafe.Pointer(&in.Hosts))
+	return nil
+}
+
+// Convert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig is an autogenerated conversion function.
+func Convert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(in *ControlPlaneConfig, out *kubeone.ControlPlaneConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(in, out, s)
+}
+
+func autoConvert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig(in *kubeone.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
+	out.Hosts = *(*[]HostConfig)(unsafe.Pointer(&in.Hosts))
+	return nil
+}
+
+// Convert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig is an autogenerated conversion function.
+func Convert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig(in *kubeone.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_CoreDNSConfig_To_kubeone_CoreDNSConfig(in *CoreDNSConfig, out *kubeone.CoreDNSConfig, s conversion.Scope) error {
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	out.DeployPodDisruptionBudget = in.DeployPodDisruptionBudget
+	out.Forwarders = *(*[]string)(unsafe.Pointer(&in.Forwarders))
+	out.StubDomains = *(*[]kubeone.CoreDNSStubDomain)(unsafe.Pointer(&in.StubDomains))
+	out.CorefileSnippet = in.CorefileSnippet
+	out.Hosts = *(*[]kubeone.CoreDNSHostEntry)(unsafe.Pointer(&in.Hosts))
+	out.Rewrites = *(*[]kubeone.CoreDNSRewriteRule)(unsafe.Pointer(&in.Rewrites))
+	out.Plugins = *(*[]kubeone.CoreDNSPlugin)(unsafe.Pointer(&in.Plugins))
+	return nil
+}
+
+// Convert_v1beta1_CoreDNSConfig_To_kubeone_CoreDNSConfig is an autogenerated conversion function.
+func Convert_v1beta1_CoreDNSConfig_To_kubeone_CoreDNSConfig(in *CoreDNSConfig, out *kubeone.CoreDNSConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_CoreDNSConfig_To_kubeone_CoreDNSConfig(in, out, s)
+}
+
+func autoConvert_kubeone_CoreDNSConfig_To_v1beta1_CoreDNSConfig(in *kubeone.CoreDNSConfig, out *CoreDNSConfig, s conversion.Scope) error {
+	out.Replicas = (*int32)(unsafe.Pointer(in.Replicas))
+	out.DeployPodDisruptionBudget = in.DeployPodDisruptionBudget
+	out.Forwarders = *(*[]string)(unsafe.Pointer(&in.Forwarders))
+	out.StubDomains = *(*[]CoreDNSStubDomain)(unsafe.Pointer(&in.StubDomains))
+	out.CorefileSnippet = in.CorefileSnippet
+	out.Hosts = *(*[]CoreDNSHostEntry)(unsafe.Pointer(&in.Hosts))
+	out.Rewrites = *(*[]CoreDNSRewriteRule)(unsafe.Pointer(&in.Rewrites))
+	out.Plugins = *(*[]CoreDNSPlugin)(unsafe.Pointer(&in.Plugins))
+	return nil
+}
+
+// Convert_kubeone_CoreDNSConfig_To_v1beta1_CoreDNSConfig is an autogenerated conversion function.
+func Convert_kubeone_CoreDNSConfig_To_v1beta1_CoreDNSConfig(in *kubeone.CoreDNSConfig, out *CoreDNSConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_CoreDNSConfig_To_v1beta1_CoreDNSConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_CoreDNSHostEntry_To_kubeone_CoreDNSHostEntry(in *CoreDNSHostEntry, out *kubeone.CoreDNSHostEntry, s conversion.Scope) error {
+	out.IP = in.IP
+	out.Hostnames = *(*[]string)(unsafe.Pointer(&in.Hostnames))
+	return nil
+}
+
+// Convert_v1beta1_CoreDNSHostEntry_To_kubeone_CoreDNSHostEntry is an autogenerated conversion function.
+func Convert_v1beta1_CoreDNSHostEntry_To_kubeone_CoreDNSHostEntry(in *CoreDNSHostEntry, out *kubeone.CoreDNSHostEntry, s conversion.Scope) error {
+	return autoConvert_v1beta1_CoreDNSHostEntry_To_kubeone_CoreDNSHostEntry(in, out, s)
+}
+
+func autoConvert_kubeone_CoreDNSHostEntry_To_v1beta1_CoreDNSHostEntry(in *kubeone.CoreDNSHostEntry, out *CoreDNSHostEntry, s conversion.Scope) error {
+	out.IP = in.IP
+	out.Hostnames = *(*[]string)(unsafe.Pointer(&in.Hostnames))
+	return nil
+}
+
+// Convert_kubeone_CoreDNSHostEntry_To_v1beta1_CoreDNSHostEntry is an autogenerated conversion function.
+func Convert_kubeone_CoreDNSHostEntry_To_v1beta1_CoreDNSHostEntry(in *kubeone.CoreDNSHostEntry, out *CoreDNSHostEntry, s conversion.Scope) error {
+	return autoConvert_kubeone_CoreDNSHostEntry_To_v1beta1_CoreDNSHostEntry(in, out, s)
+}
+
+func autoConvert_v1beta1_CoreDNSPlugin_To_kubeone_CoreDNSPlugin(in *CoreDNSPlugin, out *kubeone.CoreDNSPlugin, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.Options = *(*[]string)(unsafe.Pointer(&in.Options))
+	return nil
+}
+
+// Convert_v1beta1_CoreDNSPlugin_To_kubeone_CoreDNSPlugin is an autogenerated conversion function.
+func Convert_v1beta1_CoreDNSPlugin_To_kubeone_CoreDNSPlugin(in *CoreDNSPlugin, out *kubeone.CoreDNSPlugin, s conversion.Scope) error {
+	return autoConvert_v1beta1_CoreDNSPlugin_To_kubeone_CoreDNSPlugin(in, out, s)
+}
+
+func autoConvert_kubeone_CoreDNSPlugin_To_v1beta1_CoreDNSPlugin(in *kubeone.CoreDNSPlugin, out *CoreDNSPlugin, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Args = *(*[]string)(unsafe.Pointer(&in.Args))
+	out.Options = *(*[]string)(unsafe.Pointer(&in.Options))
 	return nil
 }
 
-// Convert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd is an autogenerated conversion function.
-func Convert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(in *kubeone.ContainerRuntimeContainerd, out *ContainerRuntimeContainerd, s conversion.Scope) error {
-	return autoConvert_kubeone_ContainerRuntimeContainerd_To_v1beta1_ContainerRuntimeContainerd(in, out, s)
+// Convert_kubeone_CoreDNSPlugin_To_v1beta1_CoreDNSPlugin is an autogenerated conversion function.
+func Convert_kubeone_CoreDNSPlugin_To_v1beta1_CoreDNSPlugin(in *kubeone.CoreDNSPlugin, out *CoreDNSPlugin, s conversion.Scope) error {
+	return autoConvert_kubeone_CoreDNSPlugin_To_v1beta1_CoreDNSPlugin(in, out, s)
 }
 
-func autoConvert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(in *ContainerRuntimeDocker, out *kubeone.ContainerRuntimeDocker, s conversion.Scope) error {
+func autoConvert_v1beta1_CoreDNSRewriteRule_To_kubeone_CoreDNSRewriteRule(in *CoreDNSRewriteRule, out *kubeone.CoreDNSRewriteRule, s conversion.Scope) error {
+	out.Field = in.Field
+	out.Match = in.Match
+	out.From = in.From
+	out.To = in.To
 	return nil
 }
 
-// Convert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker is an autogenerated conversion function.
-func Convert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(in *ContainerRuntimeDocker, out *kubeone.ContainerRuntimeDocker, s conversion.Scope) error {
-	return autoConvert_v1beta1_ContainerRuntimeDocker_To_kubeone_ContainerRuntimeDocker(in, out, s)
+// Convert_v1beta1_CoreDNSRewriteRule_To_kubeone_CoreDNSRewriteRule is an autogenerated conversion function.
+func Convert_v1beta1_CoreDNSRewriteRule_To_kubeone_CoreDNSRewriteRule(in *CoreDNSRewriteRule, out *kubeone.CoreDNSRewriteRule, s conversion.Scope) error {
+	return autoConvert_v1beta1_CoreDNSRewriteRule_To_kubeone_CoreDNSRewriteRule(in, out, s)
 }
 
-func autoConvert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker(in *kubeone.ContainerRuntimeDocker, out *ContainerRuntimeDocker, s conversion.Scope) error {
+func autoConvert_kubeone_CoreDNSRewriteRule_To_v1beta1_CoreDNSRewriteRule(in *kubeone.CoreDNSRewriteRule, out *CoreDNSRewriteRule, s conversion.Scope) error {
+	out.Field = in.Field
+	out.Match = in.Match
+	out.From = in.From
+	out.To = in.To
 	return nil
 }
 
-// Convert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker is an autogenerated conversion function.
-func Convert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker(in *kubeone.ContainerRuntimeDocker, out *ContainerRuntimeDocker, s conversion.Scope) error {
-	return autoConvert_kubeone_ContainerRuntimeDocker_To_v1beta1_ContainerRuntimeDocker(in, out, s)
+// Convert_kubeone_CoreDNSRewriteRule_To_v1beta1_CoreDNSRewriteRule is an autogenerated conversion function.
+func Convert_kubeone_CoreDNSRewriteRule_To_v1beta1_CoreDNSRewriteRule(in *kubeone.CoreDNSRewriteRule, out *CoreDNSRewriteRule, s conversion.Scope) error {
+	return autoConvert_kubeone_CoreDNSRewriteRule_To_v1beta1_CoreDNSRewriteRule(in, out, s)
 }
 
-func autoConvert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(in *ControlPlaneConfig, out *kubeone.ControlPlaneConfig, s conversion.Scope) error {
-	out.Hosts = *(*[]kubeone.HostConfig)(unsafe.Pointer(&in.Hosts))
+func autoConvert_v1beta1_CoreDNSStubDomain_To_kubeone_CoreDNSStubDomain(in *CoreDNSStubDomain, out *kubeone.CoreDNSStubDomain, s conversion.Scope) error {
+	out.Domain = in.Domain
+	out.Servers = *(*[]string)(unsafe.Pointer(&in.Servers))
 	return nil
 }
 
-// Convert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig is an autogenerated conversion function.
-func Convert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(in *ControlPlaneConfig, out *kubeone.ControlPlaneConfig, s conversion.Scope) error {
-	return autoConvert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(in, out, s)
+// Convert_v1beta1_CoreDNSStubDomain_To_kubeone_CoreDNSStubDomain is an autogenerated conversion function.
+func Convert_v1beta1_CoreDNSStubDomain_To_kubeone_CoreDNSStubDomain(in *CoreDNSStubDomain, out *kubeone.CoreDNSStubDomain, s conversion.Scope) error {
+	return autoConvert_v1beta1_CoreDNSStubDomain_To_kubeone_CoreDNSStubDomain(in, out, s)
 }
 
-func autoConvert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig(in *kubeone.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
-	out.Hosts = *(*[]HostConfig)(unsafe.Pointer(&in.Hosts))
+func autoConvert_kubeone_CoreDNSStubDomain_To_v1beta1_CoreDNSStubDomain(in *kubeone.CoreDNSStubDomain, out *CoreDNSStubDomain, s conversion.Scope) error {
+	out.Domain = in.Domain
+	out.Servers = *(*[]string)(unsafe.Pointer(&in.Servers))
 	return nil
 }
 
-// Convert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig is an autogenerated conversion function.
-func Convert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig(in *kubeone.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig(in, out, s)
+// Convert_kubeone_CoreDNSStubDomain_To_v1beta1_CoreDNSStubDomain is an autogenerated conversion function.
+func Convert_kubeone_CoreDNSStubDomain_To_v1beta1_CoreDNSStubDomain(in *kubeone.CoreDNSStubDomain, out *CoreDNSStubDomain, s conversion.Scope) error {
+	return autoConvert_kubeone_CoreDNSStubDomain_To_v1beta1_CoreDNSStubDomain(in, out, s)
 }
 
 func autoConvert_v1beta1_DNSConfig_To_kubeone_DNSConfig(in *DNSConfig, out *kubeone.DNSConfig, s conversion.Scope) error {
@@ -968,6 +2530,26 @@ func Convert_kubeone_DNSConfig_To_v1beta1_DNSConfig(in *kubeone.DNSConfig, out *
 	return autoConvert_kubeone_DNSConfig_To_v1beta1_DNSConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_DenyServiceExternalIPs_To_kubeone_DenyServiceExternalIPs(in *DenyServiceExternalIPs, out *kubeone.DenyServiceExternalIPs, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_v1beta1_DenyServiceExternalIPs_To_kubeone_DenyServiceExternalIPs is an autogenerated conversion function.
+func Convert_v1beta1_DenyServiceExternalIPs_To_kubeone_DenyServiceExternalIPs(in *DenyServiceExternalIPs, out *kubeone.DenyServiceExternalIPs, s conversion.Scope) error {
+	return autoConvert_v1beta1_DenyServiceExternalIPs_To_kubeone_DenyServiceExternalIPs(in, out, s)
+}
+
+func autoConvert_kubeone_DenyServiceExternalIPs_To_v1beta1_DenyServiceExternalIPs(in *kubeone.DenyServiceExternalIPs, out *DenyServiceExternalIPs, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_kubeone_DenyServiceExternalIPs_To_v1beta1_DenyServiceExternalIPs is an autogenerated conversion function.
+func Convert_kubeone_DenyServiceExternalIPs_To_v1beta1_DenyServiceExternalIPs(in *kubeone.DenyServiceExternalIPs, out *DenyServiceExternalIPs, s conversion.Scope) error {
+	return autoConvert_kubeone_DenyServiceExternalIPs_To_v1beta1_DenyServiceExternalIPs(in, out, s)
+}
+
 func autoConvert_v1beta1_DigitalOceanSpec_To_kubeone_DigitalOceanSpec(in *DigitalOceanSpec, out *kubeone.DigitalOceanSpec, s conversion.Scope) error {
 	return nil
 }
@@ -1006,9 +2588,32 @@ func Convert_kubeone_DynamicAuditLog_To_v1beta1_DynamicAuditLog(in *kubeone.Dyna
 	return autoConvert_kubeone_DynamicAuditLog_To_v1beta1_DynamicAuditLog(in, out, s)
 }
 
+func autoConvert_v1beta1_DynamicWorkerAutoscaling_To_kubeone_DynamicWorkerAutoscaling(in *DynamicWorkerAutoscaling, out *kubeone.DynamicWorkerAutoscaling, s conversion.Scope) error {
+	out.MinReplicas = in.MinReplicas
+	out.MaxReplicas = in.MaxReplicas
+	return nil
+}
+
+// Convert_v1beta1_DynamicWorkerAutoscaling_To_kubeone_DynamicWorkerAutoscaling is an autogenerated conversion function.
+func Convert_v1beta1_DynamicWorkerAutoscaling_To_kubeone_DynamicWorkerAutoscaling(in *DynamicWorkerAutoscaling, out *kubeone.DynamicWorkerAutoscaling, s conversion.Scope) error {
+	return autoConvert_v1beta1_DynamicWorkerAutoscaling_To_kubeone_DynamicWorkerAutoscaling(in, out, s)
+}
+
+func autoConvert_kubeone_DynamicWorkerAutoscaling_To_v1beta1_DynamicWorkerAutoscaling(in *kubeone.DynamicWorkerAutoscaling, out *DynamicWorkerAutoscaling, s conversion.Scope) error {
+	out.MinReplicas = in.MinReplicas
+	out.MaxReplicas = in.MaxReplicas
+	return nil
+}
+
+// Convert_kubeone_DynamicWorkerAutoscaling_To_v1beta1_DynamicWorkerAutoscaling is an autogenerated conversion function.
+func Convert_kubeone_DynamicWorkerAutoscaling_To_v1beta1_DynamicWorkerAutoscaling(in *kubeone.DynamicWorkerAutoscaling, out *DynamicWorkerAutoscaling, s conversion.Scope) error {
+	return autoConvert_kubeone_DynamicWorkerAutoscaling_To_v1beta1_DynamicWorkerAutoscaling(in, out, s)
+}
+
 func autoConvert_v1beta1_DynamicWorkerConfig_To_kubeone_DynamicWorkerConfig(in *DynamicWorkerConfig, out *kubeone.DynamicWorkerConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Replicas = (*int)(unsafe.Pointer(in.Replicas))
+	out.Autoscaling = (*kubeone.DynamicWorkerAutoscaling)(unsafe.Pointer(in.Autoscaling))
 	if err := Convert_v1beta1_ProviderSpec_To_kubeone_ProviderSpec(&in.Config, &out.Config, s); err != nil {
 		return err
 	}
@@ -1023,6 +2628,7 @@ func Convert_v1beta1_DynamicWorkerConfig_To_kubeone_DynamicWorkerConfig(in *Dyna
 func autoConvert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(in *kubeone.DynamicWorkerConfig, out *DynamicWorkerConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Replicas = (*int)(unsafe.Pointer(in.Replicas))
+	out.Autoscaling = (*DynamicWorkerAutoscaling)(unsafe.Pointer(in.Autoscaling))
 	if err := Convert_kubeone_ProviderSpec_To_v1beta1_ProviderSpec(&in.Config, &out.Config, s); err != nil {
 		return err
 	}
@@ -1037,6 +2643,7 @@ func Convert_kubeone_DynamicWorkerConfig_To_v1beta1_DynamicWorkerConfig(in *kube
 func autoConvert_v1beta1_EncryptionProviders_To_kubeone_EncryptionProviders(in *EncryptionProviders, out *kubeone.EncryptionProviders, s conversion.Scope) error {
 	out.Enable = in.Enable
 	out.CustomEncryptionConfiguration = in.CustomEncryptionConfiguration
+	out.KMS = (*kubeone.EncryptionProvidersKMS)(unsafe.Pointer(in.KMS))
 	return nil
 }
 
@@ -1048,6 +2655,7 @@ func Convert_v1beta1_EncryptionProviders_To_kubeone_EncryptionProviders(in *Encr
 func autoConvert_kubeone_EncryptionProviders_To_v1beta1_EncryptionProviders(in *kubeone.EncryptionProviders, out *EncryptionProviders, s conversion.Scope) error {
 	out.Enable = in.Enable
 	out.CustomEncryptionConfiguration = in.CustomEncryptionConfiguration
+	out.KMS = (*EncryptionProvidersKMS)(unsafe.Pointer(in.KMS))
 	return nil
 }
 
@@ -1056,6 +2664,156 @@ func Convert_kubeone_EncryptionProviders_To_v1beta1_EncryptionProviders(in *kube
 	return autoConvert_kubeone_EncryptionProviders_To_v1beta1_EncryptionProviders(in, out, s)
 }
 
+func autoConvert_v1beta1_EncryptionProvidersKMS_To_kubeone_EncryptionProvidersKMS(in *EncryptionProvidersKMS, out *kubeone.EncryptionProvidersKMS, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Endpoint = in.Endpoint
+	out.CacheSize = in.CacheSize
+	out.Timeout = in.Timeout
+	out.Plugin = (*kubeone.KMSPlugin)(unsafe.Pointer(in.Plugin))
+	return nil
+}
+
+// Convert_v1beta1_EncryptionProvidersKMS_To_kubeone_EncryptionProvidersKMS is an autogenerated conversion function.
+func Convert_v1beta1_EncryptionProvidersKMS_To_kubeone_EncryptionProvidersKMS(in *EncryptionProvidersKMS, out *kubeone.EncryptionProvidersKMS, s conversion.Scope) error {
+	return autoConvert_v1beta1_EncryptionProvidersKMS_To_kubeone_EncryptionProvidersKMS(in, out, s)
+}
+
+func autoConvert_kubeone_EncryptionProvidersKMS_To_v1beta1_EncryptionProvidersKMS(in *kubeone.EncryptionProvidersKMS, out *EncryptionProvidersKMS, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Endpoint = in.Endpoint
+	out.CacheSize = in.CacheSize
+	out.Timeout = in.Timeout
+	out.Plugin = (*KMSPlugin)(unsafe.Pointer(in.Plugin))
+	return nil
+}
+
+// Convert_kubeone_EncryptionProvidersKMS_To_v1beta1_EncryptionProvidersKMS is an autogenerated conversion function.
+func Convert_kubeone_EncryptionProvidersKMS_To_v1beta1_EncryptionProvidersKMS(in *kubeone.EncryptionProvidersKMS, out *EncryptionProvidersKMS, s conversion.Scope) error {
+	return autoConvert_kubeone_EncryptionProvidersKMS_To_v1beta1_EncryptionProvidersKMS(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdConfig_To_kubeone_EtcdConfig(in *EtcdConfig, out *kubeone.EtcdConfig, s conversion.Scope) error {
+	out.QuotaBackendBytes = in.QuotaBackendBytes
+	out.SnapshotCount = in.SnapshotCount
+	out.HeartbeatInterval = in.HeartbeatInterval
+	out.ElectionTimeout = in.ElectionTimeout
+	out.TLS = (*kubeone.EtcdTLSConfig)(unsafe.Pointer(in.TLS))
+	return nil
+}
+
+// Convert_v1beta1_EtcdConfig_To_kubeone_EtcdConfig is an autogenerated conversion function.
+func Convert_v1beta1_EtcdConfig_To_kubeone_EtcdConfig(in *EtcdConfig, out *kubeone.EtcdConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdConfig_To_kubeone_EtcdConfig(in, out, s)
+}
+
+func autoConvert_kubeone_EtcdConfig_To_v1beta1_EtcdConfig(in *kubeone.EtcdConfig, out *EtcdConfig, s conversion.Scope) error {
+	out.QuotaBackendBytes = in.QuotaBackendBytes
+	out.SnapshotCount = in.SnapshotCount
+	out.HeartbeatInterval = in.HeartbeatInterval
+	out.ElectionTimeout = in.ElectionTimeout
+	out.TLS = (*EtcdTLSConfig)(unsafe.Pointer(in.TLS))
+	return nil
+}
+
+// Convert_kubeone_EtcdConfig_To_v1beta1_EtcdConfig is an autogenerated conversion function.
+func Convert_kubeone_EtcdConfig_To_v1beta1_EtcdConfig(in *kubeone.EtcdConfig, out *EtcdConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_EtcdConfig_To_v1beta1_EtcdConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_EtcdTLSConfig_To_kubeone_EtcdTLSConfig(in *EtcdTLSConfig, out *kubeone.EtcdTLSConfig, s conversion.Scope) error {
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	out.ServerCertSANs = *(*[]string)(unsafe.Pointer(&in.ServerCertSANs))
+	return nil
+}
+
+// Convert_v1beta1_EtcdTLSConfig_To_kubeone_EtcdTLSConfig is an autogenerated conversion function.
+func Convert_v1beta1_EtcdTLSConfig_To_kubeone_EtcdTLSConfig(in *EtcdTLSConfig, out *kubeone.EtcdTLSConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_EtcdTLSConfig_To_kubeone_EtcdTLSConfig(in, out, s)
+}
+
+func autoConvert_kubeone_EtcdTLSConfig_To_v1beta1_EtcdTLSConfig(in *kubeone.EtcdTLSConfig, out *EtcdTLSConfig, s conversion.Scope) error {
+	out.CipherSuites = *(*[]string)(unsafe.Pointer(&in.CipherSuites))
+	out.ServerCertSANs = *(*[]string)(unsafe.Pointer(&in.ServerCertSANs))
+	return nil
+}
+
+// Convert_kubeone_EtcdTLSConfig_To_v1beta1_EtcdTLSConfig is an autogenerated conversion function.
+func Convert_kubeone_EtcdTLSConfig_To_v1beta1_EtcdTLSConfig(in *kubeone.EtcdTLSConfig, out *EtcdTLSConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_EtcdTLSConfig_To_v1beta1_EtcdTLSConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_EventRateLimit_To_kubeone_EventRateLimit(in *EventRateLimit, out *kubeone.EventRateLimit, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_v1beta1_EventRateLimitConfig_To_kubeone_EventRateLimitConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_EventRateLimit_To_kubeone_EventRateLimit is an autogenerated conversion function.
+func Convert_v1beta1_EventRateLimit_To_kubeone_EventRateLimit(in *EventRateLimit, out *kubeone.EventRateLimit, s conversion.Scope) error {
+	return autoConvert_v1beta1_EventRateLimit_To_kubeone_EventRateLimit(in, out, s)
+}
+
+func autoConvert_kubeone_EventRateLimit_To_v1beta1_EventRateLimit(in *kubeone.EventRateLimit, out *EventRateLimit, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_kubeone_EventRateLimitConfig_To_v1beta1_EventRateLimitConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_kubeone_EventRateLimit_To_v1beta1_EventRateLimit is an autogenerated conversion function.
+func Convert_kubeone_EventRateLimit_To_v1beta1_EventRateLimit(in *kubeone.EventRateLimit, out *EventRateLimit, s conversion.Scope) error {
+	return autoConvert_kubeone_EventRateLimit_To_v1beta1_EventRateLimit(in, out, s)
+}
+
+func autoConvert_v1beta1_EventRateLimitConfig_To_kubeone_EventRateLimitConfig(in *EventRateLimitConfig, out *kubeone.EventRateLimitConfig, s conversion.Scope) error {
+	out.Limits = *(*[]kubeone.EventRateLimitLimit)(unsafe.Pointer(&in.Limits))
+	return nil
+}
+
+// Convert_v1beta1_EventRateLimitConfig_To_kubeone_EventRateLimitConfig is an autogenerated conversion function.
+func Convert_v1beta1_EventRateLimitConfig_To_kubeone_EventRateLimitConfig(in *EventRateLimitConfig, out *kubeone.EventRateLimitConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_EventRateLimitConfig_To_kubeone_EventRateLimitConfig(in, out, s)
+}
+
+func autoConvert_kubeone_EventRateLimitConfig_To_v1beta1_EventRateLimitConfig(in *kubeone.EventRateLimitConfig, out *EventRateLimitConfig, s conversion.Scope) error {
+	out.Limits = *(*[]EventRateLimitLimit)(unsafe.Pointer(&in.Limits))
+	return nil
+}
+
+// Convert_kubeone_EventRateLimitConfig_To_v1beta1_EventRateLimitConfig is an autogenerated conversion function.
+func Convert_kubeone_EventRateLimitConfig_To_v1beta1_EventRateLimitConfig(in *kubeone.EventRateLimitConfig, out *EventRateLimitConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_EventRateLimitConfig_To_v1beta1_EventRateLimitConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_EventRateLimitLimit_To_kubeone_EventRateLimitLimit(in *EventRateLimitLimit, out *kubeone.EventRateLimitLimit, s conversion.Scope) error {
+	out.Type = kubeone.EventRateLimitType(in.Type)
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.CacheSize = in.CacheSize
+	return nil
+}
+
+// Convert_v1beta1_EventRateLimitLimit_To_kubeone_EventRateLimitLimit is an autogenerated conversion function.
+func Convert_v1beta1_EventRateLimitLimit_To_kubeone_EventRateLimitLimit(in *EventRateLimitLimit, out *kubeone.EventRateLimitLimit, s conversion.Scope) error {
+	return autoConvert_v1beta1_EventRateLimitLimit_To_kubeone_EventRateLimitLimit(in, out, s)
+}
+
+func autoConvert_kubeone_EventRateLimitLimit_To_v1beta1_EventRateLimitLimit(in *kubeone.EventRateLimitLimit, out *EventRateLimitLimit, s conversion.Scope) error {
+	out.Type = EventRateLimitType(in.Type)
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.CacheSize = in.CacheSize
+	return nil
+}
+
+// Convert_kubeone_EventRateLimitLimit_To_v1beta1_EventRateLimitLimit is an autogenerated conversion function.
+func Convert_kubeone_EventRateLimitLimit_To_v1beta1_EventRateLimitLimit(in *kubeone.EventRateLimitLimit, out *EventRateLimitLimit, s conversion.Scope) error {
+	return autoConvert_kubeone_EventRateLimitLimit_To_v1beta1_EventRateLimitLimit(in, out, s)
+}
+
 func autoConvert_v1beta1_ExternalCNISpec_To_kubeone_ExternalCNISpec(in *ExternalCNISpec, out *kubeone.ExternalCNISpec, s conversion.Scope) error {
 	return nil
 }
@@ -1074,15 +2832,77 @@ func Convert_kubeone_ExternalCNISpec_To_v1beta1_ExternalCNISpec(in *kubeone.Exte
 	return autoConvert_kubeone_ExternalCNISpec_To_v1beta1_ExternalCNISpec(in, out, s)
 }
 
+func autoConvert_v1beta1_ExternalEtcdConfig_To_kubeone_ExternalEtcdConfig(in *ExternalEtcdConfig, out *kubeone.ExternalEtcdConfig, s conversion.Scope) error {
+	out.Endpoints = *(*[]string)(unsafe.Pointer(&in.Endpoints))
+	out.CAFile = in.CAFile
+	out.CertFile = in.CertFile
+	out.KeyFile = in.KeyFile
+	return nil
+}
+
+// Convert_v1beta1_ExternalEtcdConfig_To_kubeone_ExternalEtcdConfig is an autogenerated conversion function.
+func Convert_v1beta1_ExternalEtcdConfig_To_kubeone_ExternalEtcdConfig(in *ExternalEtcdConfig, out *kubeone.ExternalEtcdConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ExternalEtcdConfig_To_kubeone_ExternalEtcdConfig(in, out, s)
+}
+
+func autoConvert_kubeone_ExternalEtcdConfig_To_v1beta1_ExternalEtcdConfig(in *kubeone.ExternalEtcdConfig, out *ExternalEtcdConfig, s conversion.Scope) error {
+	out.Endpoints = *(*[]string)(unsafe.Pointer(&in.Endpoints))
+	out.CAFile = in.CAFile
+	out.CertFile = in.CertFile
+	out.KeyFile = in.KeyFile
+	return nil
+}
+
+// Convert_kubeone_ExternalEtcdConfig_To_v1beta1_ExternalEtcdConfig is an autogenerated conversion function.
+func Convert_kubeone_ExternalEtcdConfig_To_v1beta1_ExternalEtcdConfig(in *kubeone.ExternalEtcdConfig, out *ExternalEtcdConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_ExternalEtcdConfig_To_v1beta1_ExternalEtcdConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_FIPS_To_kubeone_FIPS(in *FIPS, out *kubeone.FIPS, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_v1beta1_FIPS_To_kubeone_FIPS is an autogenerated conversion function.
+func Convert_v1beta1_FIPS_To_kubeone_FIPS(in *FIPS, out *kubeone.FIPS, s conversion.Scope) error {
+	return autoConvert_v1beta1_FIPS_To_kubeone_FIPS(in, out, s)
+}
+
+func autoConvert_kubeone_FIPS_To_v1beta1_FIPS(in *kubeone.FIPS, out *FIPS, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_kubeone_FIPS_To_v1beta1_FIPS is an autogenerated conversion function.
+func Convert_kubeone_FIPS_To_v1beta1_FIPS(in *kubeone.FIPS, out *FIPS, s conversion.Scope) error {
+	return autoConvert_kubeone_FIPS_To_v1beta1_FIPS(in, out, s)
+}
+
 func autoConvert_v1beta1_Features_To_kubeone_Features(in *Features, out *kubeone.Features, s conversion.Scope) error {
 	out.PodNodeSelector = (*kubeone.PodNodeSelector)(unsafe.Pointer(in.PodNodeSelector))
 	out.PodPresets = (*kubeone.PodPresets)(unsafe.Pointer(in.PodPresets))
 	out.PodSecurityPolicy = (*kubeone.PodSecurityPolicy)(unsafe.Pointer(in.PodSecurityPolicy))
+	out.PodSecurityAdmission = (*kubeone.PodSecurityAdmission)(unsafe.Pointer(in.PodSecurityAdmission))
 	out.StaticAuditLog = (*kubeone.StaticAuditLog)(unsafe.Pointer(in.StaticAuditLog))
 	out.DynamicAuditLog = (*kubeone.DynamicAuditLog)(unsafe.Pointer(in.DynamicAuditLog))
 	out.MetricsServer = (*kubeone.MetricsServer)(unsafe.Pointer(in.MetricsServer))
+	out.NodeLocalDNS = (*kubeone.NodeLocalDNS)(unsafe.Pointer(in.NodeLocalDNS))
 	out.OpenIDConnect = (*kubeone.OpenIDConnect)(unsafe.Pointer(in.OpenIDConnect))
 	out.EncryptionProviders = (*kubeone.EncryptionProviders)(unsafe.Pointer(in.EncryptionProviders))
+	out.FIPS = (*kubeone.FIPS)(unsafe.Pointer(in.FIPS))
+	out.SELinux = (*kubeone.SELinux)(unsafe.Pointer(in.SELinux))
+	out.AppArmor = (*kubeone.AppArmor)(unsafe.Pointer(in.AppArmor))
+	out.SeccompDefault = (*kubeone.SeccompDefault)(unsafe.Pointer(in.SeccompDefault))
+	out.BootstrapRBAC = (*kubeone.BootstrapRBAC)(unsafe.Pointer(in.BootstrapRBAC))
+	out.SRIOV = (*kubeone.SRIOV)(unsafe.Pointer(in.SRIOV))
+	out.KubeVIP = (*kubeone.KubeVIP)(unsafe.Pointer(in.KubeVIP))
+	out.Konnectivity = (*kubeone.Konnectivity)(unsafe.Pointer(in.Konnectivity))
+	out.EventRateLimit = (*kubeone.EventRateLimit)(unsafe.Pointer(in.EventRateLimit))
+	out.AlwaysPullImages = (*kubeone.AlwaysPullImages)(unsafe.Pointer(in.AlwaysPullImages))
+	out.DenyServiceExternalIPs = (*kubeone.DenyServiceExternalIPs)(unsafe.Pointer(in.DenyServiceExternalIPs))
+	out.NodeRestriction = (*kubeone.NodeRestriction)(unsafe.Pointer(in.NodeRestriction))
+	out.Gatekeeper = (*kubeone.Gatekeeper)(unsafe.Pointer(in.Gatekeeper))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	return nil
 }
 
@@ -1095,11 +2915,27 @@ func autoConvert_kubeone_Features_To_v1beta1_Features(in *kubeone.Features, out
 	out.PodNodeSelector = (*PodNodeSelector)(unsafe.Pointer(in.PodNodeSelector))
 	out.PodPresets = (*PodPresets)(unsafe.Pointer(in.PodPresets))
 	out.PodSecurityPolicy = (*PodSecurityPolicy)(unsafe.Pointer(in.PodSecurityPolicy))
+	out.PodSecurityAdmission = (*PodSecurityAdmission)(unsafe.Pointer(in.PodSecurityAdmission))
 	out.StaticAuditLog = (*StaticAuditLog)(unsafe.Pointer(in.StaticAuditLog))
 	out.DynamicAuditLog = (*DynamicAuditLog)(unsafe.Pointer(in.DynamicAuditLog))
 	out.MetricsServer = (*MetricsServer)(unsafe.Pointer(in.MetricsServer))
+	out.NodeLocalDNS = (*NodeLocalDNS)(unsafe.Pointer(in.NodeLocalDNS))
 	out.OpenIDConnect = (*OpenIDConnect)(unsafe.Pointer(in.OpenIDConnect))
 	out.EncryptionProviders = (*EncryptionProviders)(unsafe.Pointer(in.EncryptionProviders))
+	out.FIPS = (*FIPS)(unsafe.Pointer(in.FIPS))
+	out.SELinux = (*SELinux)(unsafe.Pointer(in.SELinux))
+	out.AppArmor = (*AppArmor)(unsafe.Pointer(in.AppArmor))
+	out.SeccompDefault = (*SeccompDefault)(unsafe.Pointer(in.SeccompDefault))
+	out.BootstrapRBAC = (*BootstrapRBAC)(unsafe.Pointer(in.BootstrapRBAC))
+	out.SRIOV = (*SRIOV)(unsafe.Pointer(in.SRIOV))
+	out.KubeVIP = (*KubeVIP)(unsafe.Pointer(in.KubeVIP))
+	out.Konnectivity = (*Konnectivity)(unsafe.Pointer(in.Konnectivity))
+	out.EventRateLimit = (*EventRateLimit)(unsafe.Pointer(in.EventRateLimit))
+	out.AlwaysPullImages = (*AlwaysPullImages)(unsafe.Pointer(in.AlwaysPullImages))
+	out.DenyServiceExternalIPs = (*DenyServiceExternalIPs)(unsafe.Pointer(in.DenyServiceExternalIPs))
+	out.NodeRestriction = (*NodeRestriction)(unsafe.Pointer(in.NodeRestriction))
+	out.Gatekeeper = (*Gatekeeper)(unsafe.Pointer(in.Gatekeeper))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
 	return nil
 }
 
@@ -1121,45 +2957,284 @@ func autoConvert_kubeone_GCESpec_To_v1beta1_GCESpec(in *kubeone.GCESpec, out *GC
 	return nil
 }
 
-// Convert_kubeone_GCESpec_To_v1beta1_GCESpec is an autogenerated conversion function.
-func Convert_kubeone_GCESpec_To_v1beta1_GCESpec(in *kubeone.GCESpec, out *GCESpec, s conversion.Scope) error {
-	return autoConvert_kubeone_GCESpec_To_v1beta1_GCESpec(in, out, s)
+// Convert_kubeone_GCESpec_To_v1beta1_GCESpec is an autogenerated conversion function.
+func Convert_kubeone_GCESpec_To_v1beta1_GCESpec(in *kubeone.GCESpec, out *GCESpec, s conversion.Scope) error {
+	return autoConvert_kubeone_GCESpec_To_v1beta1_GCESpec(in, out, s)
+}
+
+func autoConvert_v1beta1_GCPKMSPlugin_To_kubeone_GCPKMSPlugin(in *GCPKMSPlugin, out *kubeone.GCPKMSPlugin, s conversion.Scope) error {
+	out.KeyURI = in.KeyURI
+	return nil
+}
+
+// Convert_v1beta1_GCPKMSPlugin_To_kubeone_GCPKMSPlugin is an autogenerated conversion function.
+func Convert_v1beta1_GCPKMSPlugin_To_kubeone_GCPKMSPlugin(in *GCPKMSPlugin, out *kubeone.GCPKMSPlugin, s conversion.Scope) error {
+	return autoConvert_v1beta1_GCPKMSPlugin_To_kubeone_GCPKMSPlugin(in, out, s)
+}
+
+func autoConvert_kubeone_GCPKMSPlugin_To_v1beta1_GCPKMSPlugin(in *kubeone.GCPKMSPlugin, out *GCPKMSPlugin, s conversion.Scope) error {
+	out.KeyURI = in.KeyURI
+	return nil
+}
+
+// Convert_kubeone_GCPKMSPlugin_To_v1beta1_GCPKMSPlugin is an autogenerated conversion function.
+func Convert_kubeone_GCPKMSPlugin_To_v1beta1_GCPKMSPlugin(in *kubeone.GCPKMSPlugin, out *GCPKMSPlugin, s conversion.Scope) error {
+	return autoConvert_kubeone_GCPKMSPlugin_To_v1beta1_GCPKMSPlugin(in, out, s)
+}
+
+func autoConvert_v1beta1_GCSStateBackend_To_kubeone_GCSStateBackend(in *GCSStateBackend, out *kubeone.GCSStateBackend, s conversion.Scope) error {
+	out.Bucket = in.Bucket
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_v1beta1_GCSStateBackend_To_kubeone_GCSStateBackend is an autogenerated conversion function.
+func Convert_v1beta1_GCSStateBackend_To_kubeone_GCSStateBackend(in *GCSStateBackend, out *kubeone.GCSStateBackend, s conversion.Scope) error {
+	return autoConvert_v1beta1_GCSStateBackend_To_kubeone_GCSStateBackend(in, out, s)
+}
+
+func autoConvert_kubeone_GCSStateBackend_To_v1beta1_GCSStateBackend(in *kubeone.GCSStateBackend, out *GCSStateBackend, s conversion.Scope) error {
+	out.Bucket = in.Bucket
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_kubeone_GCSStateBackend_To_v1beta1_GCSStateBackend is an autogenerated conversion function.
+func Convert_kubeone_GCSStateBackend_To_v1beta1_GCSStateBackend(in *kubeone.GCSStateBackend, out *GCSStateBackend, s conversion.Scope) error {
+	return autoConvert_kubeone_GCSStateBackend_To_v1beta1_GCSStateBackend(in, out, s)
+}
+
+func autoConvert_v1beta1_Gatekeeper_To_kubeone_Gatekeeper(in *Gatekeeper, out *kubeone.Gatekeeper, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_v1beta1_GatekeeperConfig_To_kubeone_GatekeeperConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_Gatekeeper_To_kubeone_Gatekeeper is an autogenerated conversion function.
+func Convert_v1beta1_Gatekeeper_To_kubeone_Gatekeeper(in *Gatekeeper, out *kubeone.Gatekeeper, s conversion.Scope) error {
+	return autoConvert_v1beta1_Gatekeeper_To_kubeone_Gatekeeper(in, out, s)
+}
+
+func autoConvert_kubeone_Gatekeeper_To_v1beta1_Gatekeeper(in *kubeone.Gatekeeper, out *Gatekeeper, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_kubeone_GatekeeperConfig_To_v1beta1_GatekeeperConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_kubeone_Gatekeeper_To_v1beta1_Gatekeeper is an autogenerated conversion function.
+func Convert_kubeone_Gatekeeper_To_v1beta1_Gatekeeper(in *kubeone.Gatekeeper, out *Gatekeeper, s conversion.Scope) error {
+	return autoConvert_kubeone_Gatekeeper_To_v1beta1_Gatekeeper(in, out, s)
+}
+
+func autoConvert_v1beta1_GatekeeperConfig_To_kubeone_GatekeeperConfig(in *GatekeeperConfig, out *kubeone.GatekeeperConfig, s conversion.Scope) error {
+	out.AuditInterval = in.AuditInterval
+	out.ConstraintLibrary = in.ConstraintLibrary
+	return nil
+}
+
+// Convert_v1beta1_GatekeeperConfig_To_kubeone_GatekeeperConfig is an autogenerated conversion function.
+func Convert_v1beta1_GatekeeperConfig_To_kubeone_GatekeeperConfig(in *GatekeeperConfig, out *kubeone.GatekeeperConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_GatekeeperConfig_To_kubeone_GatekeeperConfig(in, out, s)
+}
+
+func autoConvert_kubeone_GatekeeperConfig_To_v1beta1_GatekeeperConfig(in *kubeone.GatekeeperConfig, out *GatekeeperConfig, s conversion.Scope) error {
+	out.AuditInterval = in.AuditInterval
+	out.ConstraintLibrary = in.ConstraintLibrary
+	return nil
+}
+
+// Convert_kubeone_GatekeeperConfig_To_v1beta1_GatekeeperConfig is an autogenerated conversion function.
+func Convert_kubeone_GatekeeperConfig_To_v1beta1_GatekeeperConfig(in *kubeone.GatekeeperConfig, out *GatekeeperConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_GatekeeperConfig_To_v1beta1_GatekeeperConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_GitAddonSource_To_kubeone_GitAddonSource(in *GitAddonSource, out *kubeone.GitAddonSource, s conversion.Scope) error {
+	out.Repository = in.Repository
+	out.Commit = in.Commit
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1beta1_GitAddonSource_To_kubeone_GitAddonSource is an autogenerated conversion function.
+func Convert_v1beta1_GitAddonSource_To_kubeone_GitAddonSource(in *GitAddonSource, out *kubeone.GitAddonSource, s conversion.Scope) error {
+	return autoConvert_v1beta1_GitAddonSource_To_kubeone_GitAddonSource(in, out, s)
+}
+
+func autoConvert_kubeone_GitAddonSource_To_v1beta1_GitAddonSource(in *kubeone.GitAddonSource, out *GitAddonSource, s conversion.Scope) error {
+	out.Repository = in.Repository
+	out.Commit = in.Commit
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kubeone_GitAddonSource_To_v1beta1_GitAddonSource is an autogenerated conversion function.
+func Convert_kubeone_GitAddonSource_To_v1beta1_GitAddonSource(in *kubeone.GitAddonSource, out *GitAddonSource, s conversion.Scope) error {
+	return autoConvert_kubeone_GitAddonSource_To_v1beta1_GitAddonSource(in, out, s)
+}
+
+func autoConvert_v1beta1_HTTPAddonSource_To_kubeone_HTTPAddonSource(in *HTTPAddonSource, out *kubeone.HTTPAddonSource, s conversion.Scope) error {
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1beta1_HTTPAddonSource_To_kubeone_HTTPAddonSource is an autogenerated conversion function.
+func Convert_v1beta1_HTTPAddonSource_To_kubeone_HTTPAddonSource(in *HTTPAddonSource, out *kubeone.HTTPAddonSource, s conversion.Scope) error {
+	return autoConvert_v1beta1_HTTPAddonSource_To_kubeone_HTTPAddonSource(in, out, s)
+}
+
+func autoConvert_kubeone_HTTPAddonSource_To_v1beta1_HTTPAddonSource(in *kubeone.HTTPAddonSource, out *HTTPAddonSource, s conversion.Scope) error {
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kubeone_HTTPAddonSource_To_v1beta1_HTTPAddonSource is an autogenerated conversion function.
+func Convert_kubeone_HTTPAddonSource_To_v1beta1_HTTPAddonSource(in *kubeone.HTTPAddonSource, out *HTTPAddonSource, s conversion.Scope) error {
+	return autoConvert_kubeone_HTTPAddonSource_To_v1beta1_HTTPAddonSource(in, out, s)
+}
+
+func autoConvert_v1beta1_HelmChart_To_kubeone_HelmChart(in *HelmChart, out *kubeone.HelmChart, s conversion.Scope) error {
+	out.Chart = in.Chart
+	out.RepoURL = in.RepoURL
+	out.Version = in.Version
+	out.Namespace = in.Namespace
+	out.ValuesFiles = *(*[]string)(unsafe.Pointer(&in.ValuesFiles))
+	out.Values = in.Values
+	return nil
+}
+
+// Convert_v1beta1_HelmChart_To_kubeone_HelmChart is an autogenerated conversion function.
+func Convert_v1beta1_HelmChart_To_kubeone_HelmChart(in *HelmChart, out *kubeone.HelmChart, s conversion.Scope) error {
+	return autoConvert_v1beta1_HelmChart_To_kubeone_HelmChart(in, out, s)
+}
+
+func autoConvert_kubeone_HelmChart_To_v1beta1_HelmChart(in *kubeone.HelmChart, out *HelmChart, s conversion.Scope) error {
+	out.Chart = in.Chart
+	out.RepoURL = in.RepoURL
+	out.Version = in.Version
+	out.Namespace = in.Namespace
+	out.ValuesFiles = *(*[]string)(unsafe.Pointer(&in.ValuesFiles))
+	out.Values = in.Values
+	return nil
+}
+
+// Convert_kubeone_HelmChart_To_v1beta1_HelmChart is an autogenerated conversion function.
+func Convert_kubeone_HelmChart_To_v1beta1_HelmChart(in *kubeone.HelmChart, out *HelmChart, s conversion.Scope) error {
+	return autoConvert_kubeone_HelmChart_To_v1beta1_HelmChart(in, out, s)
+}
+
+func autoConvert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec(in *HetznerSpec, out *kubeone.HetznerSpec, s conversion.Scope) error {
+	out.NetworkID = in.NetworkID
+	return nil
+}
+
+// Convert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec is an autogenerated conversion function.
+func Convert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec(in *HetznerSpec, out *kubeone.HetznerSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec(in, out, s)
+}
+
+func autoConvert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec(in *kubeone.HetznerSpec, out *HetznerSpec, s conversion.Scope) error {
+	out.NetworkID = in.NetworkID
+	return nil
+}
+
+// Convert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec is an autogenerated conversion function.
+func Convert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec(in *kubeone.HetznerSpec, out *HetznerSpec, s conversion.Scope) error {
+	return autoConvert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_Hook_To_kubeone_Hook(in *Hook, out *kubeone.Hook, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Command = in.Command
+	out.Webhook = (*kubeone.WebhookHook)(unsafe.Pointer(in.Webhook))
+	out.Timeout = in.Timeout
+	out.IgnoreFailure = in.IgnoreFailure
+	return nil
+}
+
+// Convert_v1beta1_Hook_To_kubeone_Hook is an autogenerated conversion function.
+func Convert_v1beta1_Hook_To_kubeone_Hook(in *Hook, out *kubeone.Hook, s conversion.Scope) error {
+	return autoConvert_v1beta1_Hook_To_kubeone_Hook(in, out, s)
+}
+
+func autoConvert_kubeone_Hook_To_v1beta1_Hook(in *kubeone.Hook, out *Hook, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Command = in.Command
+	out.Webhook = (*WebhookHook)(unsafe.Pointer(in.Webhook))
+	out.Timeout = in.Timeout
+	out.IgnoreFailure = in.IgnoreFailure
+	return nil
+}
+
+// Convert_kubeone_Hook_To_v1beta1_Hook is an autogenerated conversion function.
+func Convert_kubeone_Hook_To_v1beta1_Hook(in *kubeone.Hook, out *Hook, s conversion.Scope) error {
+	return autoConvert_kubeone_Hook_To_v1beta1_Hook(in, out, s)
 }
 
-func autoConvert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec(in *HetznerSpec, out *kubeone.HetznerSpec, s conversion.Scope) error {
-	out.NetworkID = in.NetworkID
+func autoConvert_v1beta1_Hooks_To_kubeone_Hooks(in *Hooks, out *kubeone.Hooks, s conversion.Scope) error {
+	out.PreApply = *(*[]kubeone.Hook)(unsafe.Pointer(&in.PreApply))
+	out.PostApply = *(*[]kubeone.Hook)(unsafe.Pointer(&in.PostApply))
+	out.PreUpgrade = *(*[]kubeone.Hook)(unsafe.Pointer(&in.PreUpgrade))
+	out.PostUpgrade = *(*[]kubeone.Hook)(unsafe.Pointer(&in.PostUpgrade))
+	out.PreReset = *(*[]kubeone.Hook)(unsafe.Pointer(&in.PreReset))
+	out.PostReset = *(*[]kubeone.Hook)(unsafe.Pointer(&in.PostReset))
+	out.PreNodeUpgrade = *(*[]kubeone.Hook)(unsafe.Pointer(&in.PreNodeUpgrade))
+	out.PostNodeUpgrade = *(*[]kubeone.Hook)(unsafe.Pointer(&in.PostNodeUpgrade))
+	out.Host = (*kubeone.HostHooks)(unsafe.Pointer(in.Host))
 	return nil
 }
 
-// Convert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec is an autogenerated conversion function.
-func Convert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec(in *HetznerSpec, out *kubeone.HetznerSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_HetznerSpec_To_kubeone_HetznerSpec(in, out, s)
+// Convert_v1beta1_Hooks_To_kubeone_Hooks is an autogenerated conversion function.
+func Convert_v1beta1_Hooks_To_kubeone_Hooks(in *Hooks, out *kubeone.Hooks, s conversion.Scope) error {
+	return autoConvert_v1beta1_Hooks_To_kubeone_Hooks(in, out, s)
 }
 
-func autoConvert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec(in *kubeone.HetznerSpec, out *HetznerSpec, s conversion.Scope) error {
-	out.NetworkID = in.NetworkID
+func autoConvert_kubeone_Hooks_To_v1beta1_Hooks(in *kubeone.Hooks, out *Hooks, s conversion.Scope) error {
+	out.PreApply = *(*[]Hook)(unsafe.Pointer(&in.PreApply))
+	out.PostApply = *(*[]Hook)(unsafe.Pointer(&in.PostApply))
+	out.PreUpgrade = *(*[]Hook)(unsafe.Pointer(&in.PreUpgrade))
+	out.PostUpgrade = *(*[]Hook)(unsafe.Pointer(&in.PostUpgrade))
+	out.PreReset = *(*[]Hook)(unsafe.Pointer(&in.PreReset))
+	out.PostReset = *(*[]Hook)(unsafe.Pointer(&in.PostReset))
+	out.PreNodeUpgrade = *(*[]Hook)(unsafe.Pointer(&in.PreNodeUpgrade))
+	out.PostNodeUpgrade = *(*[]Hook)(unsafe.Pointer(&in.PostNodeUpgrade))
+	out.Host = (*HostHooks)(unsafe.Pointer(in.Host))
 	return nil
 }
 
-// Convert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec is an autogenerated conversion function.
-func Convert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec(in *kubeone.HetznerSpec, out *HetznerSpec, s conversion.Scope) error {
-	return autoConvert_kubeone_HetznerSpec_To_v1beta1_HetznerSpec(in, out, s)
+// Convert_kubeone_Hooks_To_v1beta1_Hooks is an autogenerated conversion function.
+func Convert_kubeone_Hooks_To_v1beta1_Hooks(in *kubeone.Hooks, out *Hooks, s conversion.Scope) error {
+	return autoConvert_kubeone_Hooks_To_v1beta1_Hooks(in, out, s)
 }
 
 func autoConvert_v1beta1_HostConfig_To_kubeone_HostConfig(in *HostConfig, out *kubeone.HostConfig, s conversion.Scope) error {
 	out.ID = in.ID
 	out.PublicAddress = in.PublicAddress
 	out.PrivateAddress = in.PrivateAddress
+	out.IPv6Addresses = *(*[]string)(unsafe.Pointer(&in.IPv6Addresses))
 	out.SSHPort = in.SSHPort
 	out.SSHUsername = in.SSHUsername
 	out.SSHPrivateKeyFile = in.SSHPrivateKeyFile
 	out.SSHAgentSocket = in.SSHAgentSocket
+	out.SSHCertFile = in.SSHCertFile
 	out.Bastion = in.Bastion
 	out.BastionPort = in.BastionPort
 	out.BastionUser = in.BastionUser
+	out.Bastions = *(*[]kubeone.BastionConfig)(unsafe.Pointer(&in.Bastions))
 	out.Hostname = in.Hostname
 	out.IsLeader = in.IsLeader
 	out.Taints = *(*[]v1.Taint)(unsafe.Pointer(&in.Taints))
+	out.Env = *(*map[string]string)(unsafe.Pointer(&in.Env))
+	out.NetworkOverrides = (*kubeone.HostNetworkOverrides)(unsafe.Pointer(in.NetworkOverrides))
+	out.KubeletConfig = (*kubeone.KubeletConfig)(unsafe.Pointer(in.KubeletConfig))
+	out.Pool = in.Pool
 	out.OperatingSystem = kubeone.OperatingSystemName(in.OperatingSystem)
 	return nil
 }
@@ -1173,16 +3248,23 @@ func autoConvert_kubeone_HostConfig_To_v1beta1_HostConfig(in *kubeone.HostConfig
 	out.ID = in.ID
 	out.PublicAddress = in.PublicAddress
 	out.PrivateAddress = in.PrivateAddress
+	out.IPv6Addresses = *(*[]string)(unsafe.Pointer(&in.IPv6Addresses))
 	out.SSHPort = in.SSHPort
 	out.SSHUsername = in.SSHUsername
 	out.SSHPrivateKeyFile = in.SSHPrivateKeyFile
 	out.SSHAgentSocket = in.SSHAgentSocket
+	out.SSHCertFile = in.SSHCertFile
 	out.Bastion = in.Bastion
 	out.BastionPort = in.BastionPort
 	out.BastionUser = in.BastionUser
+	out.Bastions = *(*[]BastionConfig)(unsafe.Pointer(&in.Bastions))
 	out.Hostname = in.Hostname
 	out.IsLeader = in.IsLeader
 	out.Taints = *(*[]v1.Taint)(unsafe.Pointer(&in.Taints))
+	out.Env = *(*map[string]string)(unsafe.Pointer(&in.Env))
+	out.NetworkOverrides = (*HostNetworkOverrides)(unsafe.Pointer(in.NetworkOverrides))
+	out.KubeletConfig = (*KubeletConfig)(unsafe.Pointer(in.KubeletConfig))
+	out.Pool = in.Pool
 	out.OperatingSystem = OperatingSystemName(in.OperatingSystem)
 	return nil
 }
@@ -1192,6 +3274,86 @@ func Convert_kubeone_HostConfig_To_v1beta1_HostConfig(in *kubeone.HostConfig, ou
 	return autoConvert_kubeone_HostConfig_To_v1beta1_HostConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_HostHooks_To_kubeone_HostHooks(in *HostHooks, out *kubeone.HostHooks, s conversion.Scope) error {
+	out.PreProvision = *(*[]kubeone.HostScript)(unsafe.Pointer(&in.PreProvision))
+	out.PostJoin = *(*[]kubeone.HostScript)(unsafe.Pointer(&in.PostJoin))
+	out.PreDrain = *(*[]kubeone.HostScript)(unsafe.Pointer(&in.PreDrain))
+	out.PostUpgrade = *(*[]kubeone.HostScript)(unsafe.Pointer(&in.PostUpgrade))
+	return nil
+}
+
+// Convert_v1beta1_HostHooks_To_kubeone_HostHooks is an autogenerated conversion function.
+func Convert_v1beta1_HostHooks_To_kubeone_HostHooks(in *HostHooks, out *kubeone.HostHooks, s conversion.Scope) error {
+	return autoConvert_v1beta1_HostHooks_To_kubeone_HostHooks(in, out, s)
+}
+
+func autoConvert_kubeone_HostHooks_To_v1beta1_HostHooks(in *kubeone.HostHooks, out *HostHooks, s conversion.Scope) error {
+	out.PreProvision = *(*[]HostScript)(unsafe.Pointer(&in.PreProvision))
+	out.PostJoin = *(*[]HostScript)(unsafe.Pointer(&in.PostJoin))
+	out.PreDrain = *(*[]HostScript)(unsafe.Pointer(&in.PreDrain))
+	out.PostUpgrade = *(*[]HostScript)(unsafe.Pointer(&in.PostUpgrade))
+	return nil
+}
+
+// Convert_kubeone_HostHooks_To_v1beta1_HostHooks is an autogenerated conversion function.
+func Convert_kubeone_HostHooks_To_v1beta1_HostHooks(in *kubeone.HostHooks, out *HostHooks, s conversion.Scope) error {
+	return autoConvert_kubeone_HostHooks_To_v1beta1_HostHooks(in, out, s)
+}
+
+func autoConvert_v1beta1_HostNetworkOverrides_To_kubeone_HostNetworkOverrides(in *HostNetworkOverrides, out *kubeone.HostNetworkOverrides, s conversion.Scope) error {
+	out.APIServerAdvertiseAddress = in.APIServerAdvertiseAddress
+	out.EtcdListenClientURLs = *(*[]string)(unsafe.Pointer(&in.EtcdListenClientURLs))
+	out.EtcdAdvertiseClientURLs = *(*[]string)(unsafe.Pointer(&in.EtcdAdvertiseClientURLs))
+	out.EtcdListenPeerURLs = *(*[]string)(unsafe.Pointer(&in.EtcdListenPeerURLs))
+	out.KubeletNodeIP = in.KubeletNodeIP
+	return nil
+}
+
+// Convert_v1beta1_HostNetworkOverrides_To_kubeone_HostNetworkOverrides is an autogenerated conversion function.
+func Convert_v1beta1_HostNetworkOverrides_To_kubeone_HostNetworkOverrides(in *HostNetworkOverrides, out *kubeone.HostNetworkOverrides, s conversion.Scope) error {
+	return autoConvert_v1beta1_HostNetworkOverrides_To_kubeone_HostNetworkOverrides(in, out, s)
+}
+
+func autoConvert_kubeone_HostNetworkOverrides_To_v1beta1_HostNetworkOverrides(in *kubeone.HostNetworkOverrides, out *HostNetworkOverrides, s conversion.Scope) error {
+	out.APIServerAdvertiseAddress = in.APIServerAdvertiseAddress
+	out.EtcdListenClientURLs = *(*[]string)(unsafe.Pointer(&in.EtcdListenClientURLs))
+	out.EtcdAdvertiseClientURLs = *(*[]string)(unsafe.Pointer(&in.EtcdAdvertiseClientURLs))
+	out.EtcdListenPeerURLs = *(*[]string)(unsafe.Pointer(&in.EtcdListenPeerURLs))
+	out.KubeletNodeIP = in.KubeletNodeIP
+	return nil
+}
+
+// Convert_kubeone_HostNetworkOverrides_To_v1beta1_HostNetworkOverrides is an autogenerated conversion function.
+func Convert_kubeone_HostNetworkOverrides_To_v1beta1_HostNetworkOverrides(in *kubeone.HostNetworkOverrides, out *HostNetworkOverrides, s conversion.Scope) error {
+	return autoConvert_kubeone_HostNetworkOverrides_To_v1beta1_HostNetworkOverrides(in, out, s)
+}
+
+func autoConvert_v1beta1_HostScript_To_kubeone_HostScript(in *HostScript, out *kubeone.HostScript, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Inline = in.Inline
+	out.File = in.File
+	out.IgnoreFailure = in.IgnoreFailure
+	return nil
+}
+
+// Convert_v1beta1_HostScript_To_kubeone_HostScript is an autogenerated conversion function.
+func Convert_v1beta1_HostScript_To_kubeone_HostScript(in *HostScript, out *kubeone.HostScript, s conversion.Scope) error {
+	return autoConvert_v1beta1_HostScript_To_kubeone_HostScript(in, out, s)
+}
+
+func autoConvert_kubeone_HostScript_To_v1beta1_HostScript(in *kubeone.HostScript, out *HostScript, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Inline = in.Inline
+	out.File = in.File
+	out.IgnoreFailure = in.IgnoreFailure
+	return nil
+}
+
+// Convert_kubeone_HostScript_To_v1beta1_HostScript is an autogenerated conversion function.
+func Convert_kubeone_HostScript_To_v1beta1_HostScript(in *kubeone.HostScript, out *HostScript, s conversion.Scope) error {
+	return autoConvert_kubeone_HostScript_To_v1beta1_HostScript(in, out, s)
+}
+
 func autoConvert_v1beta1_IPTables_To_kubeone_IPTables(in *IPTables, out *kubeone.IPTables, s conversion.Scope) error {
 	return nil
 }
@@ -1213,7 +3375,7 @@ func Convert_kubeone_IPTables_To_v1beta1_IPTables(in *kubeone.IPTables, out *IPT
 func autoConvert_v1beta1_IPVSConfig_To_kubeone_IPVSConfig(in *IPVSConfig, out *kubeone.IPVSConfig, s conversion.Scope) error {
 	out.Scheduler = in.Scheduler
 	out.ExcludeCIDRs = *(*[]string)(unsafe.Pointer(&in.ExcludeCIDRs))
-	out.StrictARP = in.StrictARP
+	out.StrictARP = (*bool)(unsafe.Pointer(in.StrictARP))
 	out.TCPTimeout = in.TCPTimeout
 	out.TCPFinTimeout = in.TCPFinTimeout
 	out.UDPTimeout = in.UDPTimeout
@@ -1228,7 +3390,7 @@ func Convert_v1beta1_IPVSConfig_To_kubeone_IPVSConfig(in *IPVSConfig, out *kubeo
 func autoConvert_kubeone_IPVSConfig_To_v1beta1_IPVSConfig(in *kubeone.IPVSConfig, out *IPVSConfig, s conversion.Scope) error {
 	out.Scheduler = in.Scheduler
 	out.ExcludeCIDRs = *(*[]string)(unsafe.Pointer(&in.ExcludeCIDRs))
-	out.StrictARP = in.StrictARP
+	out.StrictARP = (*bool)(unsafe.Pointer(in.StrictARP))
 	out.TCPTimeout = in.TCPTimeout
 	out.TCPFinTimeout = in.TCPFinTimeout
 	out.UDPTimeout = in.UDPTimeout
@@ -1262,11 +3424,91 @@ func Convert_kubeone_ImageAsset_To_v1beta1_ImageAsset(in *kubeone.ImageAsset, ou
 	return autoConvert_kubeone_ImageAsset_To_v1beta1_ImageAsset(in, out, s)
 }
 
+func autoConvert_v1beta1_KMSPlugin_To_kubeone_KMSPlugin(in *KMSPlugin, out *kubeone.KMSPlugin, s conversion.Scope) error {
+	out.Image = in.Image
+	out.AWS = (*kubeone.AWSKMSPlugin)(unsafe.Pointer(in.AWS))
+	out.Azure = (*kubeone.AzureKMSPlugin)(unsafe.Pointer(in.Azure))
+	out.GCP = (*kubeone.GCPKMSPlugin)(unsafe.Pointer(in.GCP))
+	return nil
+}
+
+// Convert_v1beta1_KMSPlugin_To_kubeone_KMSPlugin is an autogenerated conversion function.
+func Convert_v1beta1_KMSPlugin_To_kubeone_KMSPlugin(in *KMSPlugin, out *kubeone.KMSPlugin, s conversion.Scope) error {
+	return autoConvert_v1beta1_KMSPlugin_To_kubeone_KMSPlugin(in, out, s)
+}
+
+func autoConvert_kubeone_KMSPlugin_To_v1beta1_KMSPlugin(in *kubeone.KMSPlugin, out *KMSPlugin, s conversion.Scope) error {
+	out.Image = in.Image
+	out.AWS = (*AWSKMSPlugin)(unsafe.Pointer(in.AWS))
+	out.Azure = (*AzureKMSPlugin)(unsafe.Pointer(in.Azure))
+	out.GCP = (*GCPKMSPlugin)(unsafe.Pointer(in.GCP))
+	return nil
+}
+
+// Convert_kubeone_KMSPlugin_To_v1beta1_KMSPlugin is an autogenerated conversion function.
+func Convert_kubeone_KMSPlugin_To_v1beta1_KMSPlugin(in *kubeone.KMSPlugin, out *KMSPlugin, s conversion.Scope) error {
+	return autoConvert_kubeone_KMSPlugin_To_v1beta1_KMSPlugin(in, out, s)
+}
+
+func autoConvert_v1beta1_Konnectivity_To_kubeone_Konnectivity(in *Konnectivity, out *kubeone.Konnectivity, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_v1beta1_KonnectivityConfig_To_kubeone_KonnectivityConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_Konnectivity_To_kubeone_Konnectivity is an autogenerated conversion function.
+func Convert_v1beta1_Konnectivity_To_kubeone_Konnectivity(in *Konnectivity, out *kubeone.Konnectivity, s conversion.Scope) error {
+	return autoConvert_v1beta1_Konnectivity_To_kubeone_Konnectivity(in, out, s)
+}
+
+func autoConvert_kubeone_Konnectivity_To_v1beta1_Konnectivity(in *kubeone.Konnectivity, out *Konnectivity, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_kubeone_KonnectivityConfig_To_v1beta1_KonnectivityConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_kubeone_Konnectivity_To_v1beta1_Konnectivity is an autogenerated conversion function.
+func Convert_kubeone_Konnectivity_To_v1beta1_Konnectivity(in *kubeone.Konnectivity, out *Konnectivity, s conversion.Scope) error {
+	return autoConvert_kubeone_Konnectivity_To_v1beta1_Konnectivity(in, out, s)
+}
+
+func autoConvert_v1beta1_KonnectivityConfig_To_kubeone_KonnectivityConfig(in *KonnectivityConfig, out *kubeone.KonnectivityConfig, s conversion.Scope) error {
+	out.ServerAddress = in.ServerAddress
+	out.AgentPort = in.AgentPort
+	return nil
+}
+
+// Convert_v1beta1_KonnectivityConfig_To_kubeone_KonnectivityConfig is an autogenerated conversion function.
+func Convert_v1beta1_KonnectivityConfig_To_kubeone_KonnectivityConfig(in *KonnectivityConfig, out *kubeone.KonnectivityConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_KonnectivityConfig_To_kubeone_KonnectivityConfig(in, out, s)
+}
+
+func autoConvert_kubeone_KonnectivityConfig_To_v1beta1_KonnectivityConfig(in *kubeone.KonnectivityConfig, out *KonnectivityConfig, s conversion.Scope) error {
+	out.ServerAddress = in.ServerAddress
+	out.AgentPort = in.AgentPort
+	return nil
+}
+
+// Convert_kubeone_KonnectivityConfig_To_v1beta1_KonnectivityConfig is an autogenerated conversion function.
+func Convert_kubeone_KonnectivityConfig_To_v1beta1_KonnectivityConfig(in *kubeone.KonnectivityConfig, out *KonnectivityConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_KonnectivityConfig_To_v1beta1_KonnectivityConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_KubeOneCluster_To_kubeone_KubeOneCluster(in *KubeOneCluster, out *kubeone.KubeOneCluster, s conversion.Scope) error {
 	out.Name = in.Name
 	if err := Convert_v1beta1_ControlPlaneConfig_To_kubeone_ControlPlaneConfig(&in.ControlPlane, &out.ControlPlane, s); err != nil {
 		return err
 	}
+	if err := Convert_v1beta1_EtcdConfig_To_kubeone_EtcdConfig(&in.Etcd, &out.Etcd, s); err != nil {
+		return err
+	}
+	out.ExternalEtcd = (*kubeone.ExternalEtcdConfig)(unsafe.Pointer(in.ExternalEtcd))
+	out.ControlPlaneComponents = (*kubeone.ControlPlaneComponents)(unsafe.Pointer(in.ControlPlaneComponents))
+	out.KubeletConfig = (*kubeone.KubeletConfig)(unsafe.Pointer(in.KubeletConfig))
 	if err := Convert_v1beta1_APIEndpoint_To_kubeone_APIEndpoint(&in.APIEndpoint, &out.APIEndpoint, s); err != nil {
 		return err
 	}
@@ -1290,6 +3532,7 @@ func autoConvert_v1beta1_KubeOneCluster_To_kubeone_KubeOneCluster(in *KubeOneClu
 	}
 	out.DynamicWorkers = *(*[]kubeone.DynamicWorkerConfig)(unsafe.Pointer(&in.DynamicWorkers))
 	out.MachineController = (*kubeone.MachineControllerConfig)(unsafe.Pointer(in.MachineController))
+	out.OperatingSystemManager = (*kubeone.OSMConfig)(unsafe.Pointer(in.OperatingSystemManager))
 	out.CABundle = in.CABundle
 	if err := Convert_v1beta1_Features_To_kubeone_Features(&in.Features, &out.Features, s); err != nil {
 		return err
@@ -1300,6 +3543,12 @@ func autoConvert_v1beta1_KubeOneCluster_To_kubeone_KubeOneCluster(in *KubeOneClu
 		return err
 	}
 	out.RegistryConfiguration = (*kubeone.RegistryConfiguration)(unsafe.Pointer(in.RegistryConfiguration))
+	out.StateBackend = (*kubeone.StateBackend)(unsafe.Pointer(in.StateBackend))
+	out.Backups = (*kubeone.Backups)(unsafe.Pointer(in.Backups))
+	out.Hooks = (*kubeone.Hooks)(unsafe.Pointer(in.Hooks))
+	out.MaxParallel = in.MaxParallel
+	out.CommandRetries = (*kubeone.CommandRetryPolicy)(unsafe.Pointer(in.CommandRetries))
+	out.Notifications = *(*[]kubeone.Notification)(unsafe.Pointer(&in.Notifications))
 	return nil
 }
 
@@ -1313,6 +3562,12 @@ func autoConvert_kubeone_KubeOneCluster_To_v1beta1_KubeOneCluster(in *kubeone.Ku
 	if err := Convert_kubeone_ControlPlaneConfig_To_v1beta1_ControlPlaneConfig(&in.ControlPlane, &out.ControlPlane, s); err != nil {
 		return err
 	}
+	if err := Convert_kubeone_EtcdConfig_To_v1beta1_EtcdConfig(&in.Etcd, &out.Etcd, s); err != nil {
+		return err
+	}
+	out.ExternalEtcd = (*ExternalEtcdConfig)(unsafe.Pointer(in.ExternalEtcd))
+	out.ControlPlaneComponents = (*ControlPlaneComponents)(unsafe.Pointer(in.ControlPlaneComponents))
+	out.KubeletConfig = (*KubeletConfig)(unsafe.Pointer(in.KubeletConfig))
 	if err := Convert_kubeone_APIEndpoint_To_v1beta1_APIEndpoint(&in.APIEndpoint, &out.APIEndpoint, s); err != nil {
 		return err
 	}
@@ -1336,6 +3591,7 @@ func autoConvert_kubeone_KubeOneCluster_To_v1beta1_KubeOneCluster(in *kubeone.Ku
 	}
 	out.DynamicWorkers = *(*[]DynamicWorkerConfig)(unsafe.Pointer(&in.DynamicWorkers))
 	out.MachineController = (*MachineControllerConfig)(unsafe.Pointer(in.MachineController))
+	out.OperatingSystemManager = (*OSMConfig)(unsafe.Pointer(in.OperatingSystemManager))
 	out.CABundle = in.CABundle
 	if err := Convert_kubeone_Features_To_v1beta1_Features(&in.Features, &out.Features, s); err != nil {
 		return err
@@ -1346,6 +3602,12 @@ func autoConvert_kubeone_KubeOneCluster_To_v1beta1_KubeOneCluster(in *kubeone.Ku
 		return err
 	}
 	out.RegistryConfiguration = (*RegistryConfiguration)(unsafe.Pointer(in.RegistryConfiguration))
+	out.StateBackend = (*StateBackend)(unsafe.Pointer(in.StateBackend))
+	out.Backups = (*Backups)(unsafe.Pointer(in.Backups))
+	out.Hooks = (*Hooks)(unsafe.Pointer(in.Hooks))
+	out.MaxParallel = in.MaxParallel
+	out.CommandRetries = (*CommandRetryPolicy)(unsafe.Pointer(in.CommandRetries))
+	out.Notifications = *(*[]Notification)(unsafe.Pointer(&in.Notifications))
 	return nil
 }
 
@@ -1357,6 +3619,9 @@ func Convert_kubeone_KubeOneCluster_To_v1beta1_KubeOneCluster(in *kubeone.KubeOn
 func autoConvert_v1beta1_KubeProxyConfig_To_kubeone_KubeProxyConfig(in *KubeProxyConfig, out *kubeone.KubeProxyConfig, s conversion.Scope) error {
 	out.IPVS = (*kubeone.IPVSConfig)(unsafe.Pointer(in.IPVS))
 	out.IPTables = (*kubeone.IPTables)(unsafe.Pointer(in.IPTables))
+	out.MetricsBindAddress = in.MetricsBindAddress
+	out.Conntrack = (*kubeone.KubeProxyConntrack)(unsafe.Pointer(in.Conntrack))
+	out.SyncPeriod = in.SyncPeriod
 	return nil
 }
 
@@ -1365,73 +3630,370 @@ func Convert_v1beta1_KubeProxyConfig_To_kubeone_KubeProxyConfig(in *KubeProxyCon
 	return autoConvert_v1beta1_KubeProxyConfig_To_kubeone_KubeProxyConfig(in, out, s)
 }
 
-func autoConvert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig(in *kubeone.KubeProxyConfig, out *KubeProxyConfig, s conversion.Scope) error {
-	out.IPVS = (*IPVSConfig)(unsafe.Pointer(in.IPVS))
-	out.IPTables = (*IPTables)(unsafe.Pointer(in.IPTables))
+func autoConvert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig(in *kubeone.KubeProxyConfig, out *KubeProxyConfig, s conversion.Scope) error {
+	out.IPVS = (*IPVSConfig)(unsafe.Pointer(in.IPVS))
+	out.IPTables = (*IPTables)(unsafe.Pointer(in.IPTables))
+	out.MetricsBindAddress = in.MetricsBindAddress
+	out.Conntrack = (*KubeProxyConntrack)(unsafe.Pointer(in.Conntrack))
+	out.SyncPeriod = in.SyncPeriod
+	return nil
+}
+
+// Convert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig is an autogenerated conversion function.
+func Convert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig(in *kubeone.KubeProxyConfig, out *KubeProxyConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_KubeProxyConntrack_To_kubeone_KubeProxyConntrack(in *KubeProxyConntrack, out *kubeone.KubeProxyConntrack, s conversion.Scope) error {
+	out.MaxPerCore = (*int32)(unsafe.Pointer(in.MaxPerCore))
+	out.Min = (*int32)(unsafe.Pointer(in.Min))
+	return nil
+}
+
+// Convert_v1beta1_KubeProxyConntrack_To_kubeone_KubeProxyConntrack is an autogenerated conversion function.
+func Convert_v1beta1_KubeProxyConntrack_To_kubeone_KubeProxyConntrack(in *KubeProxyConntrack, out *kubeone.KubeProxyConntrack, s conversion.Scope) error {
+	return autoConvert_v1beta1_KubeProxyConntrack_To_kubeone_KubeProxyConntrack(in, out, s)
+}
+
+func autoConvert_kubeone_KubeProxyConntrack_To_v1beta1_KubeProxyConntrack(in *kubeone.KubeProxyConntrack, out *KubeProxyConntrack, s conversion.Scope) error {
+	out.MaxPerCore = (*int32)(unsafe.Pointer(in.MaxPerCore))
+	out.Min = (*int32)(unsafe.Pointer(in.Min))
+	return nil
+}
+
+// Convert_kubeone_KubeProxyConntrack_To_v1beta1_KubeProxyConntrack is an autogenerated conversion function.
+func Convert_kubeone_KubeProxyConntrack_To_v1beta1_KubeProxyConntrack(in *kubeone.KubeProxyConntrack, out *KubeProxyConntrack, s conversion.Scope) error {
+	return autoConvert_kubeone_KubeProxyConntrack_To_v1beta1_KubeProxyConntrack(in, out, s)
+}
+
+func autoConvert_v1beta1_KubeVIP_To_kubeone_KubeVIP(in *KubeVIP, out *kubeone.KubeVIP, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Interface = in.Interface
+	return nil
+}
+
+// Convert_v1beta1_KubeVIP_To_kubeone_KubeVIP is an autogenerated conversion function.
+func Convert_v1beta1_KubeVIP_To_kubeone_KubeVIP(in *KubeVIP, out *kubeone.KubeVIP, s conversion.Scope) error {
+	return autoConvert_v1beta1_KubeVIP_To_kubeone_KubeVIP(in, out, s)
+}
+
+func autoConvert_kubeone_KubeVIP_To_v1beta1_KubeVIP(in *kubeone.KubeVIP, out *KubeVIP, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.Interface = in.Interface
+	return nil
+}
+
+// Convert_kubeone_KubeVIP_To_v1beta1_KubeVIP is an autogenerated conversion function.
+func Convert_kubeone_KubeVIP_To_v1beta1_KubeVIP(in *kubeone.KubeVIP, out *KubeVIP, s conversion.Scope) error {
+	return autoConvert_kubeone_KubeVIP_To_v1beta1_KubeVIP(in, out, s)
+}
+
+func autoConvert_v1beta1_KubeVirtSpec_To_kubeone_KubeVirtSpec(in *KubeVirtSpec, out *kubeone.KubeVirtSpec, s conversion.Scope) error {
+	out.InfraNamespace = in.InfraNamespace
+	return nil
+}
+
+// Convert_v1beta1_KubeVirtSpec_To_kubeone_KubeVirtSpec is an autogenerated conversion function.
+func Convert_v1beta1_KubeVirtSpec_To_kubeone_KubeVirtSpec(in *KubeVirtSpec, out *kubeone.KubeVirtSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_KubeVirtSpec_To_kubeone_KubeVirtSpec(in, out, s)
+}
+
+func autoConvert_kubeone_KubeVirtSpec_To_v1beta1_KubeVirtSpec(in *kubeone.KubeVirtSpec, out *KubeVirtSpec, s conversion.Scope) error {
+	out.InfraNamespace = in.InfraNamespace
+	return nil
+}
+
+// Convert_kubeone_KubeVirtSpec_To_v1beta1_KubeVirtSpec is an autogenerated conversion function.
+func Convert_kubeone_KubeVirtSpec_To_v1beta1_KubeVirtSpec(in *kubeone.KubeVirtSpec, out *KubeVirtSpec, s conversion.Scope) error {
+	return autoConvert_kubeone_KubeVirtSpec_To_v1beta1_KubeVirtSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_KubeletConfig_To_kubeone_KubeletConfig(in *KubeletConfig, out *kubeone.KubeletConfig, s conversion.Scope) error {
+	out.MaxPods = (*int32)(unsafe.Pointer(in.MaxPods))
+	out.SystemReserved = *(*map[string]string)(unsafe.Pointer(&in.SystemReserved))
+	out.KubeReserved = *(*map[string]string)(unsafe.Pointer(&in.KubeReserved))
+	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	out.CgroupDriver = in.CgroupDriver
+	return nil
+}
+
+// Convert_v1beta1_KubeletConfig_To_kubeone_KubeletConfig is an autogenerated conversion function.
+func Convert_v1beta1_KubeletConfig_To_kubeone_KubeletConfig(in *KubeletConfig, out *kubeone.KubeletConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_KubeletConfig_To_kubeone_KubeletConfig(in, out, s)
+}
+
+func autoConvert_kubeone_KubeletConfig_To_v1beta1_KubeletConfig(in *kubeone.KubeletConfig, out *KubeletConfig, s conversion.Scope) error {
+	out.MaxPods = (*int32)(unsafe.Pointer(in.MaxPods))
+	out.SystemReserved = *(*map[string]string)(unsafe.Pointer(&in.SystemReserved))
+	out.KubeReserved = *(*map[string]string)(unsafe.Pointer(&in.KubeReserved))
+	out.EvictionHard = *(*map[string]string)(unsafe.Pointer(&in.EvictionHard))
+	out.CgroupDriver = in.CgroupDriver
+	return nil
+}
+
+// Convert_kubeone_KubeletConfig_To_v1beta1_KubeletConfig is an autogenerated conversion function.
+func Convert_kubeone_KubeletConfig_To_v1beta1_KubeletConfig(in *kubeone.KubeletConfig, out *KubeletConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_KubeletConfig_To_v1beta1_KubeletConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_KubernetesSecretStateBackend_To_kubeone_KubernetesSecretStateBackend(in *KubernetesSecretStateBackend, out *kubeone.KubernetesSecretStateBackend, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_v1beta1_KubernetesSecretStateBackend_To_kubeone_KubernetesSecretStateBackend is an autogenerated conversion function.
+func Convert_v1beta1_KubernetesSecretStateBackend_To_kubeone_KubernetesSecretStateBackend(in *KubernetesSecretStateBackend, out *kubeone.KubernetesSecretStateBackend, s conversion.Scope) error {
+	return autoConvert_v1beta1_KubernetesSecretStateBackend_To_kubeone_KubernetesSecretStateBackend(in, out, s)
+}
+
+func autoConvert_kubeone_KubernetesSecretStateBackend_To_v1beta1_KubernetesSecretStateBackend(in *kubeone.KubernetesSecretStateBackend, out *KubernetesSecretStateBackend, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_kubeone_KubernetesSecretStateBackend_To_v1beta1_KubernetesSecretStateBackend is an autogenerated conversion function.
+func Convert_kubeone_KubernetesSecretStateBackend_To_v1beta1_KubernetesSecretStateBackend(in *kubeone.KubernetesSecretStateBackend, out *KubernetesSecretStateBackend, s conversion.Scope) error {
+	return autoConvert_kubeone_KubernetesSecretStateBackend_To_v1beta1_KubernetesSecretStateBackend(in, out, s)
+}
+
+func autoConvert_v1beta1_LocalStateBackend_To_kubeone_LocalStateBackend(in *LocalStateBackend, out *kubeone.LocalStateBackend, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1beta1_LocalStateBackend_To_kubeone_LocalStateBackend is an autogenerated conversion function.
+func Convert_v1beta1_LocalStateBackend_To_kubeone_LocalStateBackend(in *LocalStateBackend, out *kubeone.LocalStateBackend, s conversion.Scope) error {
+	return autoConvert_v1beta1_LocalStateBackend_To_kubeone_LocalStateBackend(in, out, s)
+}
+
+func autoConvert_kubeone_LocalStateBackend_To_v1beta1_LocalStateBackend(in *kubeone.LocalStateBackend, out *LocalStateBackend, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kubeone_LocalStateBackend_To_v1beta1_LocalStateBackend is an autogenerated conversion function.
+func Convert_kubeone_LocalStateBackend_To_v1beta1_LocalStateBackend(in *kubeone.LocalStateBackend, out *LocalStateBackend, s conversion.Scope) error {
+	return autoConvert_kubeone_LocalStateBackend_To_v1beta1_LocalStateBackend(in, out, s)
+}
+
+func autoConvert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig(in *MachineControllerConfig, out *kubeone.MachineControllerConfig, s conversion.Scope) error {
+	out.Deploy = in.Deploy
+	return nil
+}
+
+// Convert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig is an autogenerated conversion function.
+func Convert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig(in *MachineControllerConfig, out *kubeone.MachineControllerConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig(in, out, s)
+}
+
+func autoConvert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig(in *kubeone.MachineControllerConfig, out *MachineControllerConfig, s conversion.Scope) error {
+	out.Deploy = in.Deploy
+	return nil
+}
+
+// Convert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig is an autogenerated conversion function.
+func Convert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig(in *kubeone.MachineControllerConfig, out *MachineControllerConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_MetricsServer_To_kubeone_MetricsServer(in *MetricsServer, out *kubeone.MetricsServer, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_v1beta1_MetricsServer_To_kubeone_MetricsServer is an autogenerated conversion function.
+func Convert_v1beta1_MetricsServer_To_kubeone_MetricsServer(in *MetricsServer, out *kubeone.MetricsServer, s conversion.Scope) error {
+	return autoConvert_v1beta1_MetricsServer_To_kubeone_MetricsServer(in, out, s)
+}
+
+func autoConvert_kubeone_MetricsServer_To_v1beta1_MetricsServer(in *kubeone.MetricsServer, out *MetricsServer, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_kubeone_MetricsServer_To_v1beta1_MetricsServer is an autogenerated conversion function.
+func Convert_kubeone_MetricsServer_To_v1beta1_MetricsServer(in *kubeone.MetricsServer, out *MetricsServer, s conversion.Scope) error {
+	return autoConvert_kubeone_MetricsServer_To_v1beta1_MetricsServer(in, out, s)
+}
+
+func autoConvert_v1beta1_NamespaceAdmins_To_kubeone_NamespaceAdmins(in *NamespaceAdmins, out *kubeone.NamespaceAdmins, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	return nil
+}
+
+// Convert_v1beta1_NamespaceAdmins_To_kubeone_NamespaceAdmins is an autogenerated conversion function.
+func Convert_v1beta1_NamespaceAdmins_To_kubeone_NamespaceAdmins(in *NamespaceAdmins, out *kubeone.NamespaceAdmins, s conversion.Scope) error {
+	return autoConvert_v1beta1_NamespaceAdmins_To_kubeone_NamespaceAdmins(in, out, s)
+}
+
+func autoConvert_kubeone_NamespaceAdmins_To_v1beta1_NamespaceAdmins(in *kubeone.NamespaceAdmins, out *NamespaceAdmins, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	return nil
+}
+
+// Convert_kubeone_NamespaceAdmins_To_v1beta1_NamespaceAdmins is an autogenerated conversion function.
+func Convert_kubeone_NamespaceAdmins_To_v1beta1_NamespaceAdmins(in *kubeone.NamespaceAdmins, out *NamespaceAdmins, s conversion.Scope) error {
+	return autoConvert_kubeone_NamespaceAdmins_To_v1beta1_NamespaceAdmins(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeLocalDNS_To_kubeone_NodeLocalDNS(in *NodeLocalDNS, out *kubeone.NodeLocalDNS, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_v1beta1_NodeLocalDNSConfig_To_kubeone_NodeLocalDNSConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_NodeLocalDNS_To_kubeone_NodeLocalDNS is an autogenerated conversion function.
+func Convert_v1beta1_NodeLocalDNS_To_kubeone_NodeLocalDNS(in *NodeLocalDNS, out *kubeone.NodeLocalDNS, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeLocalDNS_To_kubeone_NodeLocalDNS(in, out, s)
+}
+
+func autoConvert_kubeone_NodeLocalDNS_To_v1beta1_NodeLocalDNS(in *kubeone.NodeLocalDNS, out *NodeLocalDNS, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_kubeone_NodeLocalDNSConfig_To_v1beta1_NodeLocalDNSConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_kubeone_NodeLocalDNS_To_v1beta1_NodeLocalDNS is an autogenerated conversion function.
+func Convert_kubeone_NodeLocalDNS_To_v1beta1_NodeLocalDNS(in *kubeone.NodeLocalDNS, out *NodeLocalDNS, s conversion.Scope) error {
+	return autoConvert_kubeone_NodeLocalDNS_To_v1beta1_NodeLocalDNS(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeLocalDNSConfig_To_kubeone_NodeLocalDNSConfig(in *NodeLocalDNSConfig, out *kubeone.NodeLocalDNSConfig, s conversion.Scope) error {
+	out.VirtualIP = in.VirtualIP
+	out.VirtualIPv6 = in.VirtualIPv6
+	out.UpstreamServers = *(*[]string)(unsafe.Pointer(&in.UpstreamServers))
+	out.ForceTCP = (*bool)(unsafe.Pointer(in.ForceTCP))
+	return nil
+}
+
+// Convert_v1beta1_NodeLocalDNSConfig_To_kubeone_NodeLocalDNSConfig is an autogenerated conversion function.
+func Convert_v1beta1_NodeLocalDNSConfig_To_kubeone_NodeLocalDNSConfig(in *NodeLocalDNSConfig, out *kubeone.NodeLocalDNSConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeLocalDNSConfig_To_kubeone_NodeLocalDNSConfig(in, out, s)
+}
+
+func autoConvert_kubeone_NodeLocalDNSConfig_To_v1beta1_NodeLocalDNSConfig(in *kubeone.NodeLocalDNSConfig, out *NodeLocalDNSConfig, s conversion.Scope) error {
+	out.VirtualIP = in.VirtualIP
+	out.VirtualIPv6 = in.VirtualIPv6
+	out.UpstreamServers = *(*[]string)(unsafe.Pointer(&in.UpstreamServers))
+	out.ForceTCP = (*bool)(unsafe.Pointer(in.ForceTCP))
+	return nil
+}
+
+// Convert_kubeone_NodeLocalDNSConfig_To_v1beta1_NodeLocalDNSConfig is an autogenerated conversion function.
+func Convert_kubeone_NodeLocalDNSConfig_To_v1beta1_NodeLocalDNSConfig(in *kubeone.NodeLocalDNSConfig, out *NodeLocalDNSConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_NodeLocalDNSConfig_To_v1beta1_NodeLocalDNSConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeRestriction_To_kubeone_NodeRestriction(in *NodeRestriction, out *kubeone.NodeRestriction, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_v1beta1_NodeRestriction_To_kubeone_NodeRestriction is an autogenerated conversion function.
+func Convert_v1beta1_NodeRestriction_To_kubeone_NodeRestriction(in *NodeRestriction, out *kubeone.NodeRestriction, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeRestriction_To_kubeone_NodeRestriction(in, out, s)
+}
+
+func autoConvert_kubeone_NodeRestriction_To_v1beta1_NodeRestriction(in *kubeone.NodeRestriction, out *NodeRestriction, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_kubeone_NodeRestriction_To_v1beta1_NodeRestriction is an autogenerated conversion function.
+func Convert_kubeone_NodeRestriction_To_v1beta1_NodeRestriction(in *kubeone.NodeRestriction, out *NodeRestriction, s conversion.Scope) error {
+	return autoConvert_kubeone_NodeRestriction_To_v1beta1_NodeRestriction(in, out, s)
+}
+
+func autoConvert_v1beta1_NoneSpec_To_kubeone_NoneSpec(in *NoneSpec, out *kubeone.NoneSpec, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_v1beta1_NoneSpec_To_kubeone_NoneSpec is an autogenerated conversion function.
+func Convert_v1beta1_NoneSpec_To_kubeone_NoneSpec(in *NoneSpec, out *kubeone.NoneSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_NoneSpec_To_kubeone_NoneSpec(in, out, s)
+}
+
+func autoConvert_kubeone_NoneSpec_To_v1beta1_NoneSpec(in *kubeone.NoneSpec, out *NoneSpec, s conversion.Scope) error {
 	return nil
 }
 
-// Convert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig is an autogenerated conversion function.
-func Convert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig(in *kubeone.KubeProxyConfig, out *KubeProxyConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_KubeProxyConfig_To_v1beta1_KubeProxyConfig(in, out, s)
+// Convert_kubeone_NoneSpec_To_v1beta1_NoneSpec is an autogenerated conversion function.
+func Convert_kubeone_NoneSpec_To_v1beta1_NoneSpec(in *kubeone.NoneSpec, out *NoneSpec, s conversion.Scope) error {
+	return autoConvert_kubeone_NoneSpec_To_v1beta1_NoneSpec(in, out, s)
 }
 
-func autoConvert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig(in *MachineControllerConfig, out *kubeone.MachineControllerConfig, s conversion.Scope) error {
-	out.Deploy = in.Deploy
+func autoConvert_v1beta1_Notification_To_kubeone_Notification(in *Notification, out *kubeone.Notification, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = kubeone.NotificationType(in.Type)
+	out.URL = in.URL
+	out.Headers = *(*map[string]string)(unsafe.Pointer(&in.Headers))
+	out.OnlyOnFailure = in.OnlyOnFailure
 	return nil
 }
 
-// Convert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig is an autogenerated conversion function.
-func Convert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig(in *MachineControllerConfig, out *kubeone.MachineControllerConfig, s conversion.Scope) error {
-	return autoConvert_v1beta1_MachineControllerConfig_To_kubeone_MachineControllerConfig(in, out, s)
+// Convert_v1beta1_Notification_To_kubeone_Notification is an autogenerated conversion function.
+func Convert_v1beta1_Notification_To_kubeone_Notification(in *Notification, out *kubeone.Notification, s conversion.Scope) error {
+	return autoConvert_v1beta1_Notification_To_kubeone_Notification(in, out, s)
 }
 
-func autoConvert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig(in *kubeone.MachineControllerConfig, out *MachineControllerConfig, s conversion.Scope) error {
-	out.Deploy = in.Deploy
+func autoConvert_kubeone_Notification_To_v1beta1_Notification(in *kubeone.Notification, out *Notification, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = NotificationType(in.Type)
+	out.URL = in.URL
+	out.Headers = *(*map[string]string)(unsafe.Pointer(&in.Headers))
+	out.OnlyOnFailure = in.OnlyOnFailure
 	return nil
 }
 
-// Convert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig is an autogenerated conversion function.
-func Convert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig(in *kubeone.MachineControllerConfig, out *MachineControllerConfig, s conversion.Scope) error {
-	return autoConvert_kubeone_MachineControllerConfig_To_v1beta1_MachineControllerConfig(in, out, s)
+// Convert_kubeone_Notification_To_v1beta1_Notification is an autogenerated conversion function.
+func Convert_kubeone_Notification_To_v1beta1_Notification(in *kubeone.Notification, out *Notification, s conversion.Scope) error {
+	return autoConvert_kubeone_Notification_To_v1beta1_Notification(in, out, s)
 }
 
-func autoConvert_v1beta1_MetricsServer_To_kubeone_MetricsServer(in *MetricsServer, out *kubeone.MetricsServer, s conversion.Scope) error {
-	out.Enable = in.Enable
+func autoConvert_v1beta1_NutanixSpec_To_kubeone_NutanixSpec(in *NutanixSpec, out *kubeone.NutanixSpec, s conversion.Scope) error {
 	return nil
 }
 
-// Convert_v1beta1_MetricsServer_To_kubeone_MetricsServer is an autogenerated conversion function.
-func Convert_v1beta1_MetricsServer_To_kubeone_MetricsServer(in *MetricsServer, out *kubeone.MetricsServer, s conversion.Scope) error {
-	return autoConvert_v1beta1_MetricsServer_To_kubeone_MetricsServer(in, out, s)
+// Convert_v1beta1_NutanixSpec_To_kubeone_NutanixSpec is an autogenerated conversion function.
+func Convert_v1beta1_NutanixSpec_To_kubeone_NutanixSpec(in *NutanixSpec, out *kubeone.NutanixSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_NutanixSpec_To_kubeone_NutanixSpec(in, out, s)
 }
 
-func autoConvert_kubeone_MetricsServer_To_v1beta1_MetricsServer(in *kubeone.MetricsServer, out *MetricsServer, s conversion.Scope) error {
-	out.Enable = in.Enable
+func autoConvert_kubeone_NutanixSpec_To_v1beta1_NutanixSpec(in *kubeone.NutanixSpec, out *NutanixSpec, s conversion.Scope) error {
 	return nil
 }
 
-// Convert_kubeone_MetricsServer_To_v1beta1_MetricsServer is an autogenerated conversion function.
-func Convert_kubeone_MetricsServer_To_v1beta1_MetricsServer(in *kubeone.MetricsServer, out *MetricsServer, s conversion.Scope) error {
-	return autoConvert_kubeone_MetricsServer_To_v1beta1_MetricsServer(in, out, s)
+// Convert_kubeone_NutanixSpec_To_v1beta1_NutanixSpec is an autogenerated conversion function.
+func Convert_kubeone_NutanixSpec_To_v1beta1_NutanixSpec(in *kubeone.NutanixSpec, out *NutanixSpec, s conversion.Scope) error {
+	return autoConvert_kubeone_NutanixSpec_To_v1beta1_NutanixSpec(in, out, s)
 }
 
-func autoConvert_v1beta1_NoneSpec_To_kubeone_NoneSpec(in *NoneSpec, out *kubeone.NoneSpec, s conversion.Scope) error {
+func autoConvert_v1beta1_OSMConfig_To_kubeone_OSMConfig(in *OSMConfig, out *kubeone.OSMConfig, s conversion.Scope) error {
+	out.Deploy = in.Deploy
 	return nil
 }
 
-// Convert_v1beta1_NoneSpec_To_kubeone_NoneSpec is an autogenerated conversion function.
-func Convert_v1beta1_NoneSpec_To_kubeone_NoneSpec(in *NoneSpec, out *kubeone.NoneSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_NoneSpec_To_kubeone_NoneSpec(in, out, s)
+// Convert_v1beta1_OSMConfig_To_kubeone_OSMConfig is an autogenerated conversion function.
+func Convert_v1beta1_OSMConfig_To_kubeone_OSMConfig(in *OSMConfig, out *kubeone.OSMConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_OSMConfig_To_kubeone_OSMConfig(in, out, s)
 }
 
-func autoConvert_kubeone_NoneSpec_To_v1beta1_NoneSpec(in *kubeone.NoneSpec, out *NoneSpec, s conversion.Scope) error {
+func autoConvert_kubeone_OSMConfig_To_v1beta1_OSMConfig(in *kubeone.OSMConfig, out *OSMConfig, s conversion.Scope) error {
+	out.Deploy = in.Deploy
 	return nil
 }
 
-// Convert_kubeone_NoneSpec_To_v1beta1_NoneSpec is an autogenerated conversion function.
-func Convert_kubeone_NoneSpec_To_v1beta1_NoneSpec(in *kubeone.NoneSpec, out *NoneSpec, s conversion.Scope) error {
-	return autoConvert_kubeone_NoneSpec_To_v1beta1_NoneSpec(in, out, s)
+// Convert_kubeone_OSMConfig_To_v1beta1_OSMConfig is an autogenerated conversion function.
+func Convert_kubeone_OSMConfig_To_v1beta1_OSMConfig(in *kubeone.OSMConfig, out *OSMConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_OSMConfig_To_v1beta1_OSMConfig(in, out, s)
 }
 
 func autoConvert_v1beta1_OpenIDConnect_To_kubeone_OpenIDConnect(in *OpenIDConnect, out *kubeone.OpenIDConnect, s conversion.Scope) error {
@@ -1514,7 +4076,58 @@ func Convert_kubeone_OpenstackSpec_To_v1beta1_OpenstackSpec(in *kubeone.Openstac
 	return autoConvert_kubeone_OpenstackSpec_To_v1beta1_OpenstackSpec(in, out, s)
 }
 
+func autoConvert_v1beta1_PacketBGP_To_kubeone_PacketBGP(in *PacketBGP, out *kubeone.PacketBGP, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.LocalASN = in.LocalASN
+	out.NodeSelector = in.NodeSelector
+	return nil
+}
+
+// Convert_v1beta1_PacketBGP_To_kubeone_PacketBGP is an autogenerated conversion function.
+func Convert_v1beta1_PacketBGP_To_kubeone_PacketBGP(in *PacketBGP, out *kubeone.PacketBGP, s conversion.Scope) error {
+	return autoConvert_v1beta1_PacketBGP_To_kubeone_PacketBGP(in, out, s)
+}
+
+func autoConvert_kubeone_PacketBGP_To_v1beta1_PacketBGP(in *kubeone.PacketBGP, out *PacketBGP, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.LocalASN = in.LocalASN
+	out.NodeSelector = in.NodeSelector
+	return nil
+}
+
+// Convert_kubeone_PacketBGP_To_v1beta1_PacketBGP is an autogenerated conversion function.
+func Convert_kubeone_PacketBGP_To_v1beta1_PacketBGP(in *kubeone.PacketBGP, out *PacketBGP, s conversion.Scope) error {
+	return autoConvert_kubeone_PacketBGP_To_v1beta1_PacketBGP(in, out, s)
+}
+
+func autoConvert_v1beta1_PacketElasticIP_To_kubeone_PacketElasticIP(in *PacketElasticIP, out *kubeone.PacketElasticIP, s conversion.Scope) error {
+	out.Tag = in.Tag
+	out.HealthCheckUseHostIP = in.HealthCheckUseHostIP
+	return nil
+}
+
+// Convert_v1beta1_PacketElasticIP_To_kubeone_PacketElasticIP is an autogenerated conversion function.
+func Convert_v1beta1_PacketElasticIP_To_kubeone_PacketElasticIP(in *PacketElasticIP, out *kubeone.PacketElasticIP, s conversion.Scope) error {
+	return autoConvert_v1beta1_PacketElasticIP_To_kubeone_PacketElasticIP(in, out, s)
+}
+
+func autoConvert_kubeone_PacketElasticIP_To_v1beta1_PacketElasticIP(in *kubeone.PacketElasticIP, out *PacketElasticIP, s conversion.Scope) error {
+	out.Tag = in.Tag
+	out.HealthCheckUseHostIP = in.HealthCheckUseHostIP
+	return nil
+}
+
+// Convert_kubeone_PacketElasticIP_To_v1beta1_PacketElasticIP is an autogenerated conversion function.
+func Convert_kubeone_PacketElasticIP_To_v1beta1_PacketElasticIP(in *kubeone.PacketElasticIP, out *PacketElasticIP, s conversion.Scope) error {
+	return autoConvert_kubeone_PacketElasticIP_To_v1beta1_PacketElasticIP(in, out, s)
+}
+
 func autoConvert_v1beta1_PacketSpec_To_kubeone_PacketSpec(in *PacketSpec, out *kubeone.PacketSpec, s conversion.Scope) error {
+	out.Metro = in.Metro
+	out.LoadBalancer = in.LoadBalancer
+	out.NodeIPFromMetadata = in.NodeIPFromMetadata
+	out.BGP = (*kubeone.PacketBGP)(unsafe.Pointer(in.BGP))
+	out.ElasticIP = (*kubeone.PacketElasticIP)(unsafe.Pointer(in.ElasticIP))
 	return nil
 }
 
@@ -1524,6 +4137,11 @@ func Convert_v1beta1_PacketSpec_To_kubeone_PacketSpec(in *PacketSpec, out *kubeo
 }
 
 func autoConvert_kubeone_PacketSpec_To_v1beta1_PacketSpec(in *kubeone.PacketSpec, out *PacketSpec, s conversion.Scope) error {
+	out.Metro = in.Metro
+	out.LoadBalancer = in.LoadBalancer
+	out.NodeIPFromMetadata = in.NodeIPFromMetadata
+	out.BGP = (*PacketBGP)(unsafe.Pointer(in.BGP))
+	out.ElasticIP = (*PacketElasticIP)(unsafe.Pointer(in.ElasticIP))
 	return nil
 }
 
@@ -1598,6 +4216,92 @@ func Convert_kubeone_PodPresets_To_v1beta1_PodPresets(in *kubeone.PodPresets, ou
 	return autoConvert_kubeone_PodPresets_To_v1beta1_PodPresets(in, out, s)
 }
 
+func autoConvert_v1beta1_PodSecurityAdmission_To_kubeone_PodSecurityAdmission(in *PodSecurityAdmission, out *kubeone.PodSecurityAdmission, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_v1beta1_PodSecurityAdmissionConfig_To_kubeone_PodSecurityAdmissionConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityAdmission_To_kubeone_PodSecurityAdmission is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityAdmission_To_kubeone_PodSecurityAdmission(in *PodSecurityAdmission, out *kubeone.PodSecurityAdmission, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityAdmission_To_kubeone_PodSecurityAdmission(in, out, s)
+}
+
+func autoConvert_kubeone_PodSecurityAdmission_To_v1beta1_PodSecurityAdmission(in *kubeone.PodSecurityAdmission, out *PodSecurityAdmission, s conversion.Scope) error {
+	out.Enable = in.Enable
+	if err := Convert_kubeone_PodSecurityAdmissionConfig_To_v1beta1_PodSecurityAdmissionConfig(&in.Config, &out.Config, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_kubeone_PodSecurityAdmission_To_v1beta1_PodSecurityAdmission is an autogenerated conversion function.
+func Convert_kubeone_PodSecurityAdmission_To_v1beta1_PodSecurityAdmission(in *kubeone.PodSecurityAdmission, out *PodSecurityAdmission, s conversion.Scope) error {
+	return autoConvert_kubeone_PodSecurityAdmission_To_v1beta1_PodSecurityAdmission(in, out, s)
+}
+
+func autoConvert_v1beta1_PodSecurityAdmissionConfig_To_kubeone_PodSecurityAdmissionConfig(in *PodSecurityAdmissionConfig, out *kubeone.PodSecurityAdmissionConfig, s conversion.Scope) error {
+	out.Enforce = in.Enforce
+	out.EnforceVersion = in.EnforceVersion
+	out.Audit = in.Audit
+	out.AuditVersion = in.AuditVersion
+	out.Warn = in.Warn
+	out.WarnVersion = in.WarnVersion
+	if err := Convert_v1beta1_PodSecurityAdmissionExemptions_To_kubeone_PodSecurityAdmissionExemptions(&in.Exemptions, &out.Exemptions, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityAdmissionConfig_To_kubeone_PodSecurityAdmissionConfig is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityAdmissionConfig_To_kubeone_PodSecurityAdmissionConfig(in *PodSecurityAdmissionConfig, out *kubeone.PodSecurityAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityAdmissionConfig_To_kubeone_PodSecurityAdmissionConfig(in, out, s)
+}
+
+func autoConvert_kubeone_PodSecurityAdmissionConfig_To_v1beta1_PodSecurityAdmissionConfig(in *kubeone.PodSecurityAdmissionConfig, out *PodSecurityAdmissionConfig, s conversion.Scope) error {
+	out.Enforce = in.Enforce
+	out.EnforceVersion = in.EnforceVersion
+	out.Audit = in.Audit
+	out.AuditVersion = in.AuditVersion
+	out.Warn = in.Warn
+	out.WarnVersion = in.WarnVersion
+	if err := Convert_kubeone_PodSecurityAdmissionExemptions_To_v1beta1_PodSecurityAdmissionExemptions(&in.Exemptions, &out.Exemptions, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_kubeone_PodSecurityAdmissionConfig_To_v1beta1_PodSecurityAdmissionConfig is an autogenerated conversion function.
+func Convert_kubeone_PodSecurityAdmissionConfig_To_v1beta1_PodSecurityAdmissionConfig(in *kubeone.PodSecurityAdmissionConfig, out *PodSecurityAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_kubeone_PodSecurityAdmissionConfig_To_v1beta1_PodSecurityAdmissionConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_PodSecurityAdmissionExemptions_To_kubeone_PodSecurityAdmissionExemptions(in *PodSecurityAdmissionExemptions, out *kubeone.PodSecurityAdmissionExemptions, s conversion.Scope) error {
+	out.Usernames = *(*[]string)(unsafe.Pointer(&in.Usernames))
+	out.RuntimeClasses = *(*[]string)(unsafe.Pointer(&in.RuntimeClasses))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_v1beta1_PodSecurityAdmissionExemptions_To_kubeone_PodSecurityAdmissionExemptions is an autogenerated conversion function.
+func Convert_v1beta1_PodSecurityAdmissionExemptions_To_kubeone_PodSecurityAdmissionExemptions(in *PodSecurityAdmissionExemptions, out *kubeone.PodSecurityAdmissionExemptions, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodSecurityAdmissionExemptions_To_kubeone_PodSecurityAdmissionExemptions(in, out, s)
+}
+
+func autoConvert_kubeone_PodSecurityAdmissionExemptions_To_v1beta1_PodSecurityAdmissionExemptions(in *kubeone.PodSecurityAdmissionExemptions, out *PodSecurityAdmissionExemptions, s conversion.Scope) error {
+	out.Usernames = *(*[]string)(unsafe.Pointer(&in.Usernames))
+	out.RuntimeClasses = *(*[]string)(unsafe.Pointer(&in.RuntimeClasses))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	return nil
+}
+
+// Convert_kubeone_PodSecurityAdmissionExemptions_To_v1beta1_PodSecurityAdmissionExemptions is an autogenerated conversion function.
+func Convert_kubeone_PodSecurityAdmissionExemptions_To_v1beta1_PodSecurityAdmissionExemptions(in *kubeone.PodSecurityAdmissionExemptions, out *PodSecurityAdmissionExemptions, s conversion.Scope) error {
+	return autoConvert_kubeone_PodSecurityAdmissionExemptions_To_v1beta1_PodSecurityAdmissionExemptions(in, out, s)
+}
+
 func autoConvert_v1beta1_PodSecurityPolicy_To_kubeone_PodSecurityPolicy(in *PodSecurityPolicy, out *kubeone.PodSecurityPolicy, s conversion.Scope) error {
 	out.Enable = in.Enable
 	return nil
@@ -1706,6 +4410,28 @@ func Convert_kubeone_ProxyConfig_To_v1beta1_ProxyConfig(in *kubeone.ProxyConfig,
 	return autoConvert_kubeone_ProxyConfig_To_v1beta1_ProxyConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_RegistryAuth_To_kubeone_RegistryAuth(in *RegistryAuth, out *kubeone.RegistryAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	out.Password = in.Password
+	return nil
+}
+
+// Convert_v1beta1_RegistryAuth_To_kubeone_RegistryAuth is an autogenerated conversion function.
+func Convert_v1beta1_RegistryAuth_To_kubeone_RegistryAuth(in *RegistryAuth, out *kubeone.RegistryAuth, s conversion.Scope) error {
+	return autoConvert_v1beta1_RegistryAuth_To_kubeone_RegistryAuth(in, out, s)
+}
+
+func autoConvert_kubeone_RegistryAuth_To_v1beta1_RegistryAuth(in *kubeone.RegistryAuth, out *RegistryAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	out.Password = in.Password
+	return nil
+}
+
+// Convert_kubeone_RegistryAuth_To_v1beta1_RegistryAuth is an autogenerated conversion function.
+func Convert_kubeone_RegistryAuth_To_v1beta1_RegistryAuth(in *kubeone.RegistryAuth, out *RegistryAuth, s conversion.Scope) error {
+	return autoConvert_kubeone_RegistryAuth_To_v1beta1_RegistryAuth(in, out, s)
+}
+
 func autoConvert_v1beta1_RegistryConfiguration_To_kubeone_RegistryConfiguration(in *RegistryConfiguration, out *kubeone.RegistryConfiguration, s conversion.Scope) error {
 	out.OverwriteRegistry = in.OverwriteRegistry
 	out.InsecureRegistry = in.InsecureRegistry
@@ -1728,6 +4454,224 @@ func Convert_kubeone_RegistryConfiguration_To_v1beta1_RegistryConfiguration(in *
 	return autoConvert_kubeone_RegistryConfiguration_To_v1beta1_RegistryConfiguration(in, out, s)
 }
 
+func autoConvert_v1beta1_RegistryMirror_To_kubeone_RegistryMirror(in *RegistryMirror, out *kubeone.RegistryMirror, s conversion.Scope) error {
+	out.Mirrors = *(*[]string)(unsafe.Pointer(&in.Mirrors))
+	out.Auth = (*kubeone.RegistryAuth)(unsafe.Pointer(in.Auth))
+	out.InsecureSkipVerify = in.InsecureSkipVerify
+	return nil
+}
+
+// Convert_v1beta1_RegistryMirror_To_kubeone_RegistryMirror is an autogenerated conversion function.
+func Convert_v1beta1_RegistryMirror_To_kubeone_RegistryMirror(in *RegistryMirror, out *kubeone.RegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1beta1_RegistryMirror_To_kubeone_RegistryMirror(in, out, s)
+}
+
+func autoConvert_kubeone_RegistryMirror_To_v1beta1_RegistryMirror(in *kubeone.RegistryMirror, out *RegistryMirror, s conversion.Scope) error {
+	out.Mirrors = *(*[]string)(unsafe.Pointer(&in.Mirrors))
+	out.Auth = (*RegistryAuth)(unsafe.Pointer(in.Auth))
+	out.InsecureSkipVerify = in.InsecureSkipVerify
+	return nil
+}
+
+// Convert_kubeone_RegistryMirror_To_v1beta1_RegistryMirror is an autogenerated conversion function.
+func Convert_kubeone_RegistryMirror_To_v1beta1_RegistryMirror(in *kubeone.RegistryMirror, out *RegistryMirror, s conversion.Scope) error {
+	return autoConvert_kubeone_RegistryMirror_To_v1beta1_RegistryMirror(in, out, s)
+}
+
+func autoConvert_v1beta1_S3AddonSource_To_kubeone_S3AddonSource(in *S3AddonSource, out *kubeone.S3AddonSource, s conversion.Scope) error {
+	out.Bucket = in.Bucket
+	out.Key = in.Key
+	out.Region = in.Region
+	out.Endpoint = in.Endpoint
+	out.SHA256 = in.SHA256
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1beta1_S3AddonSource_To_kubeone_S3AddonSource is an autogenerated conversion function.
+func Convert_v1beta1_S3AddonSource_To_kubeone_S3AddonSource(in *S3AddonSource, out *kubeone.S3AddonSource, s conversion.Scope) error {
+	return autoConvert_v1beta1_S3AddonSource_To_kubeone_S3AddonSource(in, out, s)
+}
+
+func autoConvert_kubeone_S3AddonSource_To_v1beta1_S3AddonSource(in *kubeone.S3AddonSource, out *S3AddonSource, s conversion.Scope) error {
+	out.Bucket = in.Bucket
+	out.Key = in.Key
+	out.Region = in.Region
+	out.Endpoint = in.Endpoint
+	out.SHA256 = in.SHA256
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kubeone_S3AddonSource_To_v1beta1_S3AddonSource is an autogenerated conversion function.
+func Convert_kubeone_S3AddonSource_To_v1beta1_S3AddonSource(in *kubeone.S3AddonSource, out *S3AddonSource, s conversion.Scope) error {
+	return autoConvert_kubeone_S3AddonSource_To_v1beta1_S3AddonSource(in, out, s)
+}
+
+func autoConvert_v1beta1_S3StateBackend_To_kubeone_S3StateBackend(in *S3StateBackend, out *kubeone.S3StateBackend, s conversion.Scope) error {
+	out.Bucket = in.Bucket
+	out.Region = in.Region
+	out.Endpoint = in.Endpoint
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_v1beta1_S3StateBackend_To_kubeone_S3StateBackend is an autogenerated conversion function.
+func Convert_v1beta1_S3StateBackend_To_kubeone_S3StateBackend(in *S3StateBackend, out *kubeone.S3StateBackend, s conversion.Scope) error {
+	return autoConvert_v1beta1_S3StateBackend_To_kubeone_S3StateBackend(in, out, s)
+}
+
+func autoConvert_kubeone_S3StateBackend_To_v1beta1_S3StateBackend(in *kubeone.S3StateBackend, out *S3StateBackend, s conversion.Scope) error {
+	out.Bucket = in.Bucket
+	out.Region = in.Region
+	out.Endpoint = in.Endpoint
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_kubeone_S3StateBackend_To_v1beta1_S3StateBackend is an autogenerated conversion function.
+func Convert_kubeone_S3StateBackend_To_v1beta1_S3StateBackend(in *kubeone.S3StateBackend, out *S3StateBackend, s conversion.Scope) error {
+	return autoConvert_kubeone_S3StateBackend_To_v1beta1_S3StateBackend(in, out, s)
+}
+
+func autoConvert_v1beta1_SELinux_To_kubeone_SELinux(in *SELinux, out *kubeone.SELinux, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_v1beta1_SELinux_To_kubeone_SELinux is an autogenerated conversion function.
+func Convert_v1beta1_SELinux_To_kubeone_SELinux(in *SELinux, out *kubeone.SELinux, s conversion.Scope) error {
+	return autoConvert_v1beta1_SELinux_To_kubeone_SELinux(in, out, s)
+}
+
+func autoConvert_kubeone_SELinux_To_v1beta1_SELinux(in *kubeone.SELinux, out *SELinux, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_kubeone_SELinux_To_v1beta1_SELinux is an autogenerated conversion function.
+func Convert_kubeone_SELinux_To_v1beta1_SELinux(in *kubeone.SELinux, out *SELinux, s conversion.Scope) error {
+	return autoConvert_kubeone_SELinux_To_v1beta1_SELinux(in, out, s)
+}
+
+func autoConvert_v1beta1_SRIOV_To_kubeone_SRIOV(in *SRIOV, out *kubeone.SRIOV, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.KernelParameters = *(*[]string)(unsafe.Pointer(&in.KernelParameters))
+	out.Hosts = *(*[]kubeone.SRIOVHost)(unsafe.Pointer(&in.Hosts))
+	return nil
+}
+
+// Convert_v1beta1_SRIOV_To_kubeone_SRIOV is an autogenerated conversion function.
+func Convert_v1beta1_SRIOV_To_kubeone_SRIOV(in *SRIOV, out *kubeone.SRIOV, s conversion.Scope) error {
+	return autoConvert_v1beta1_SRIOV_To_kubeone_SRIOV(in, out, s)
+}
+
+func autoConvert_kubeone_SRIOV_To_v1beta1_SRIOV(in *kubeone.SRIOV, out *SRIOV, s conversion.Scope) error {
+	out.Enable = in.Enable
+	out.KernelParameters = *(*[]string)(unsafe.Pointer(&in.KernelParameters))
+	out.Hosts = *(*[]SRIOVHost)(unsafe.Pointer(&in.Hosts))
+	return nil
+}
+
+// Convert_kubeone_SRIOV_To_v1beta1_SRIOV is an autogenerated conversion function.
+func Convert_kubeone_SRIOV_To_v1beta1_SRIOV(in *kubeone.SRIOV, out *SRIOV, s conversion.Scope) error {
+	return autoConvert_kubeone_SRIOV_To_v1beta1_SRIOV(in, out, s)
+}
+
+func autoConvert_v1beta1_SRIOVHost_To_kubeone_SRIOVHost(in *SRIOVHost, out *kubeone.SRIOVHost, s conversion.Scope) error {
+	out.Address = in.Address
+	out.PhysicalFunctions = *(*[]kubeone.SRIOVPhysicalFunction)(unsafe.Pointer(&in.PhysicalFunctions))
+	return nil
+}
+
+// Convert_v1beta1_SRIOVHost_To_kubeone_SRIOVHost is an autogenerated conversion function.
+func Convert_v1beta1_SRIOVHost_To_kubeone_SRIOVHost(in *SRIOVHost, out *kubeone.SRIOVHost, s conversion.Scope) error {
+	return autoConvert_v1beta1_SRIOVHost_To_kubeone_SRIOVHost(in, out, s)
+}
+
+func autoConvert_kubeone_SRIOVHost_To_v1beta1_SRIOVHost(in *kubeone.SRIOVHost, out *SRIOVHost, s conversion.Scope) error {
+	out.Address = in.Address
+	out.PhysicalFunctions = *(*[]SRIOVPhysicalFunction)(unsafe.Pointer(&in.PhysicalFunctions))
+	return nil
+}
+
+// Convert_kubeone_SRIOVHost_To_v1beta1_SRIOVHost is an autogenerated conversion function.
+func Convert_kubeone_SRIOVHost_To_v1beta1_SRIOVHost(in *kubeone.SRIOVHost, out *SRIOVHost, s conversion.Scope) error {
+	return autoConvert_kubeone_SRIOVHost_To_v1beta1_SRIOVHost(in, out, s)
+}
+
+func autoConvert_v1beta1_SRIOVPhysicalFunction_To_kubeone_SRIOVPhysicalFunction(in *SRIOVPhysicalFunction, out *kubeone.SRIOVPhysicalFunction, s conversion.Scope) error {
+	out.Interface = in.Interface
+	out.NumVFs = in.NumVFs
+	out.ResourceName = in.ResourceName
+	return nil
+}
+
+// Convert_v1beta1_SRIOVPhysicalFunction_To_kubeone_SRIOVPhysicalFunction is an autogenerated conversion function.
+func Convert_v1beta1_SRIOVPhysicalFunction_To_kubeone_SRIOVPhysicalFunction(in *SRIOVPhysicalFunction, out *kubeone.SRIOVPhysicalFunction, s conversion.Scope) error {
+	return autoConvert_v1beta1_SRIOVPhysicalFunction_To_kubeone_SRIOVPhysicalFunction(in, out, s)
+}
+
+func autoConvert_kubeone_SRIOVPhysicalFunction_To_v1beta1_SRIOVPhysicalFunction(in *kubeone.SRIOVPhysicalFunction, out *SRIOVPhysicalFunction, s conversion.Scope) error {
+	out.Interface = in.Interface
+	out.NumVFs = in.NumVFs
+	out.ResourceName = in.ResourceName
+	return nil
+}
+
+// Convert_kubeone_SRIOVPhysicalFunction_To_v1beta1_SRIOVPhysicalFunction is an autogenerated conversion function.
+func Convert_kubeone_SRIOVPhysicalFunction_To_v1beta1_SRIOVPhysicalFunction(in *kubeone.SRIOVPhysicalFunction, out *SRIOVPhysicalFunction, s conversion.Scope) error {
+	return autoConvert_kubeone_SRIOVPhysicalFunction_To_v1beta1_SRIOVPhysicalFunction(in, out, s)
+}
+
+func autoConvert_v1beta1_SeccompDefault_To_kubeone_SeccompDefault(in *SeccompDefault, out *kubeone.SeccompDefault, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_v1beta1_SeccompDefault_To_kubeone_SeccompDefault is an autogenerated conversion function.
+func Convert_v1beta1_SeccompDefault_To_kubeone_SeccompDefault(in *SeccompDefault, out *kubeone.SeccompDefault, s conversion.Scope) error {
+	return autoConvert_v1beta1_SeccompDefault_To_kubeone_SeccompDefault(in, out, s)
+}
+
+func autoConvert_kubeone_SeccompDefault_To_v1beta1_SeccompDefault(in *kubeone.SeccompDefault, out *SeccompDefault, s conversion.Scope) error {
+	out.Enable = in.Enable
+	return nil
+}
+
+// Convert_kubeone_SeccompDefault_To_v1beta1_SeccompDefault is an autogenerated conversion function.
+func Convert_kubeone_SeccompDefault_To_v1beta1_SeccompDefault(in *kubeone.SeccompDefault, out *SeccompDefault, s conversion.Scope) error {
+	return autoConvert_kubeone_SeccompDefault_To_v1beta1_SeccompDefault(in, out, s)
+}
+
+func autoConvert_v1beta1_StateBackend_To_kubeone_StateBackend(in *StateBackend, out *kubeone.StateBackend, s conversion.Scope) error {
+	out.S3 = (*kubeone.S3StateBackend)(unsafe.Pointer(in.S3))
+	out.GCS = (*kubeone.GCSStateBackend)(unsafe.Pointer(in.GCS))
+	out.AzureBlob = (*kubeone.AzureBlobStateBackend)(unsafe.Pointer(in.AzureBlob))
+	out.Local = (*kubeone.LocalStateBackend)(unsafe.Pointer(in.Local))
+	out.KubernetesSecret = (*kubeone.KubernetesSecretStateBackend)(unsafe.Pointer(in.KubernetesSecret))
+	return nil
+}
+
+// Convert_v1beta1_StateBackend_To_kubeone_StateBackend is an autogenerated conversion function.
+func Convert_v1beta1_StateBackend_To_kubeone_StateBackend(in *StateBackend, out *kubeone.StateBackend, s conversion.Scope) error {
+	return autoConvert_v1beta1_StateBackend_To_kubeone_StateBackend(in, out, s)
+}
+
+func autoConvert_kubeone_StateBackend_To_v1beta1_StateBackend(in *kubeone.StateBackend, out *StateBackend, s conversion.Scope) error {
+	out.S3 = (*S3StateBackend)(unsafe.Pointer(in.S3))
+	out.GCS = (*GCSStateBackend)(unsafe.Pointer(in.GCS))
+	out.AzureBlob = (*AzureBlobStateBackend)(unsafe.Pointer(in.AzureBlob))
+	out.Local = (*LocalStateBackend)(unsafe.Pointer(in.Local))
+	out.KubernetesSecret = (*KubernetesSecretStateBackend)(unsafe.Pointer(in.KubernetesSecret))
+	return nil
+}
+
+// Convert_kubeone_StateBackend_To_v1beta1_StateBackend is an autogenerated conversion function.
+func Convert_kubeone_StateBackend_To_v1beta1_StateBackend(in *kubeone.StateBackend, out *StateBackend, s conversion.Scope) error {
+	return autoConvert_kubeone_StateBackend_To_v1beta1_StateBackend(in, out, s)
+}
+
 func autoConvert_v1beta1_StaticAuditLog_To_kubeone_StaticAuditLog(in *StaticAuditLog, out *kubeone.StaticAuditLog, s conversion.Scope) error {
 	out.Enable = in.Enable
 	if err := Convert_v1beta1_StaticAuditLogConfig_To_kubeone_StaticAuditLogConfig(&in.Config, &out.Config, s); err != nil {
@@ -1760,6 +4704,8 @@ func autoConvert_v1beta1_StaticAuditLogConfig_To_kubeone_StaticAuditLogConfig(in
 	out.LogMaxAge = in.LogMaxAge
 	out.LogMaxBackup = in.LogMaxBackup
 	out.LogMaxSize = in.LogMaxSize
+	out.DisableLogBackend = in.DisableLogBackend
+	out.Webhook = (*kubeone.AuditWebhookConfig)(unsafe.Pointer(in.Webhook))
 	return nil
 }
 
@@ -1774,6 +4720,8 @@ func autoConvert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(in
 	out.LogMaxAge = in.LogMaxAge
 	out.LogMaxBackup = in.LogMaxBackup
 	out.LogMaxSize = in.LogMaxSize
+	out.DisableLogBackend = in.DisableLogBackend
+	out.Webhook = (*AuditWebhookConfig)(unsafe.Pointer(in.Webhook))
 	return nil
 }
 
@@ -1782,8 +4730,37 @@ func Convert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(in *ku
 	return autoConvert_kubeone_StaticAuditLogConfig_To_v1beta1_StaticAuditLogConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_StaticWorkerPool_To_kubeone_StaticWorkerPool(in *StaticWorkerPool, out *kubeone.StaticWorkerPool, s conversion.Scope) error {
+	out.Name = in.Name
+	out.KubeletExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.KubeletExtraArgs))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.Taints = *(*[]v1.Taint)(unsafe.Pointer(&in.Taints))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1beta1_StaticWorkerPool_To_kubeone_StaticWorkerPool is an autogenerated conversion function.
+func Convert_v1beta1_StaticWorkerPool_To_kubeone_StaticWorkerPool(in *StaticWorkerPool, out *kubeone.StaticWorkerPool, s conversion.Scope) error {
+	return autoConvert_v1beta1_StaticWorkerPool_To_kubeone_StaticWorkerPool(in, out, s)
+}
+
+func autoConvert_kubeone_StaticWorkerPool_To_v1beta1_StaticWorkerPool(in *kubeone.StaticWorkerPool, out *StaticWorkerPool, s conversion.Scope) error {
+	out.Name = in.Name
+	out.KubeletExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.KubeletExtraArgs))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.Taints = *(*[]v1.Taint)(unsafe.Pointer(&in.Taints))
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_kubeone_StaticWorkerPool_To_v1beta1_StaticWorkerPool is an autogenerated conversion function.
+func Convert_kubeone_StaticWorkerPool_To_v1beta1_StaticWorkerPool(in *kubeone.StaticWorkerPool, out *StaticWorkerPool, s conversion.Scope) error {
+	return autoConvert_kubeone_StaticWorkerPool_To_v1beta1_StaticWorkerPool(in, out, s)
+}
+
 func autoConvert_v1beta1_StaticWorkersConfig_To_kubeone_StaticWorkersConfig(in *StaticWorkersConfig, out *kubeone.StaticWorkersConfig, s conversion.Scope) error {
 	out.Hosts = *(*[]kubeone.HostConfig)(unsafe.Pointer(&in.Hosts))
+	out.Pools = *(*[]kubeone.StaticWorkerPool)(unsafe.Pointer(&in.Pools))
 	return nil
 }
 
@@ -1794,6 +4771,7 @@ func Convert_v1beta1_StaticWorkersConfig_To_kubeone_StaticWorkersConfig(in *Stat
 
 func autoConvert_kubeone_StaticWorkersConfig_To_v1beta1_StaticWorkersConfig(in *kubeone.StaticWorkersConfig, out *StaticWorkersConfig, s conversion.Scope) error {
 	out.Hosts = *(*[]HostConfig)(unsafe.Pointer(&in.Hosts))
+	out.Pools = *(*[]StaticWorkerPool)(unsafe.Pointer(&in.Pools))
 	return nil
 }
 
@@ -1822,6 +4800,28 @@ func Convert_kubeone_SystemPackages_To_v1beta1_SystemPackages(in *kubeone.System
 	return autoConvert_kubeone_SystemPackages_To_v1beta1_SystemPackages(in, out, s)
 }
 
+func autoConvert_v1beta1_VMwareCloudDirectorSpec_To_kubeone_VMwareCloudDirectorSpec(in *VMwareCloudDirectorSpec, out *kubeone.VMwareCloudDirectorSpec, s conversion.Scope) error {
+	out.VApp = in.VApp
+	out.StorageProfile = in.StorageProfile
+	return nil
+}
+
+// Convert_v1beta1_VMwareCloudDirectorSpec_To_kubeone_VMwareCloudDirectorSpec is an autogenerated conversion function.
+func Convert_v1beta1_VMwareCloudDirectorSpec_To_kubeone_VMwareCloudDirectorSpec(in *VMwareCloudDirectorSpec, out *kubeone.VMwareCloudDirectorSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_VMwareCloudDirectorSpec_To_kubeone_VMwareCloudDirectorSpec(in, out, s)
+}
+
+func autoConvert_kubeone_VMwareCloudDirectorSpec_To_v1beta1_VMwareCloudDirectorSpec(in *kubeone.VMwareCloudDirectorSpec, out *VMwareCloudDirectorSpec, s conversion.Scope) error {
+	out.VApp = in.VApp
+	out.StorageProfile = in.StorageProfile
+	return nil
+}
+
+// Convert_kubeone_VMwareCloudDirectorSpec_To_v1beta1_VMwareCloudDirectorSpec is an autogenerated conversion function.
+func Convert_kubeone_VMwareCloudDirectorSpec_To_v1beta1_VMwareCloudDirectorSpec(in *kubeone.VMwareCloudDirectorSpec, out *VMwareCloudDirectorSpec, s conversion.Scope) error {
+	return autoConvert_kubeone_VMwareCloudDirectorSpec_To_v1beta1_VMwareCloudDirectorSpec(in, out, s)
+}
+
 func autoConvert_v1beta1_VersionConfig_To_kubeone_VersionConfig(in *VersionConfig, out *kubeone.VersionConfig, s conversion.Scope) error {
 	out.Kubernetes = in.Kubernetes
 	return nil
@@ -1862,6 +4862,7 @@ func Convert_kubeone_VsphereSpec_To_v1beta1_VsphereSpec(in *kubeone.VsphereSpec,
 
 func autoConvert_v1beta1_WeaveNetSpec_To_kubeone_WeaveNetSpec(in *WeaveNetSpec, out *kubeone.WeaveNetSpec, s conversion.Scope) error {
 	out.Encrypted = in.Encrypted
+	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
 	return nil
 }
 
@@ -1872,6 +4873,7 @@ func Convert_v1beta1_WeaveNetSpec_To_kubeone_WeaveNetSpec(in *WeaveNetSpec, out
 
 func autoConvert_kubeone_WeaveNetSpec_To_v1beta1_WeaveNetSpec(in *kubeone.WeaveNetSpec, out *WeaveNetSpec, s conversion.Scope) error {
 	out.Encrypted = in.Encrypted
+	out.Params = *(*map[string]string)(unsafe.Pointer(&in.Params))
 	return nil
 }
 
@@ -1879,3 +4881,25 @@ func autoConvert_kubeone_WeaveNetSpec_To_v1beta1_WeaveNetSpec(in *kubeone.WeaveN
 func Convert_kubeone_WeaveNetSpec_To_v1beta1_WeaveNetSpec(in *kubeone.WeaveNetSpec, out *WeaveNetSpec, s conversion.Scope) error {
 	return autoConvert_kubeone_WeaveNetSpec_To_v1beta1_WeaveNetSpec(in, out, s)
 }
+
+func autoConvert_v1beta1_WebhookHook_To_kubeone_WebhookHook(in *WebhookHook, out *kubeone.WebhookHook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Headers = *(*map[string]string)(unsafe.Pointer(&in.Headers))
+	return nil
+}
+
+// Convert_v1beta1_WebhookHook_To_kubeone_WebhookHook is an autogenerated conversion function.
+func Convert_v1beta1_WebhookHook_To_kubeone_WebhookHook(in *WebhookHook, out *kubeone.WebhookHook, s conversion.Scope) error {
+	return autoConvert_v1beta1_WebhookHook_To_kubeone_WebhookHook(in, out, s)
+}
+
+func autoConvert_kubeone_WebhookHook_To_v1beta1_WebhookHook(in *kubeone.WebhookHook, out *WebhookHook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Headers = *(*map[string]string)(unsafe.Pointer(&in.Headers))
+	return nil
+}
+
+// Convert_kubeone_WebhookHook_To_v1beta1_WebhookHook is an autogenerated conversion function.
+func Convert_kubeone_WebhookHook_To_v1beta1_WebhookHook(in *kubeone.WebhookHook, out *WebhookHook, s conversion.Scope) error {
+	return autoConvert_kubeone_WebhookHook_To_v1beta1_WebhookHook(in, out, s)
+}
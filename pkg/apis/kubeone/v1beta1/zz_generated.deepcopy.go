@@ -43,6 +43,62 @@ func (in *APIEndpoint) DeepCopy() *APIEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerConfig) DeepCopyInto(out *APIServerConfig) {
+	*out = *in
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnableAdmissionPlugins != nil {
+		in, out := &in.EnableAdmissionPlugins, &out.EnableAdmissionPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisableAdmissionPlugins != nil {
+		in, out := &in.DisableAdmissionPlugins, &out.DisableAdmissionPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerConfig.
+func (in *APIServerConfig) DeepCopy() *APIServerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSKMSPlugin) DeepCopyInto(out *AWSKMSPlugin) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSKMSPlugin.
+func (in *AWSKMSPlugin) DeepCopy() *AWSKMSPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSKMSPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSSpec) DeepCopyInto(out *AWSSpec) {
 	*out = *in
@@ -69,6 +125,16 @@ func (in *Addon) DeepCopyInto(out *Addon) {
 			(*out)[key] = val
 		}
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(HelmChart)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -82,9 +148,47 @@ func (in *Addon) DeepCopy() *Addon {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSource) DeepCopyInto(out *AddonSource) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitAddonSource)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPAddonSource)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3AddonSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSource.
+func (in *AddonSource) DeepCopy() *AddonSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Addons) DeepCopyInto(out *Addons) {
 	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]AddonSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.GlobalParams != nil {
 		in, out := &in.GlobalParams, &out.GlobalParams
 		*out = make(map[string]string, len(*in))
@@ -113,259 +217,243 @@ func (in *Addons) DeepCopy() *Addons {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AssetConfiguration) DeepCopyInto(out *AssetConfiguration) {
+func (in *AlwaysPullImages) DeepCopyInto(out *AlwaysPullImages) {
 	*out = *in
-	out.Kubernetes = in.Kubernetes
-	out.Pause = in.Pause
-	out.CoreDNS = in.CoreDNS
-	out.Etcd = in.Etcd
-	out.MetricsServer = in.MetricsServer
-	out.CNI = in.CNI
-	out.NodeBinaries = in.NodeBinaries
-	out.Kubectl = in.Kubectl
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetConfiguration.
-func (in *AssetConfiguration) DeepCopy() *AssetConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlwaysPullImages.
+func (in *AlwaysPullImages) DeepCopy() *AlwaysPullImages {
 	if in == nil {
 		return nil
 	}
-	out := new(AssetConfiguration)
+	out := new(AlwaysPullImages)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AzureSpec) DeepCopyInto(out *AzureSpec) {
+func (in *AppArmor) DeepCopyInto(out *AppArmor) {
 	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]AppArmorProfile, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureSpec.
-func (in *AzureSpec) DeepCopy() *AzureSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppArmor.
+func (in *AppArmor) DeepCopy() *AppArmor {
 	if in == nil {
 		return nil
 	}
-	out := new(AzureSpec)
+	out := new(AppArmor)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BinaryAsset) DeepCopyInto(out *BinaryAsset) {
+func (in *AppArmorProfile) DeepCopyInto(out *AppArmorProfile) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinaryAsset.
-func (in *BinaryAsset) DeepCopy() *BinaryAsset {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppArmorProfile.
+func (in *AppArmorProfile) DeepCopy() *AppArmorProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(BinaryAsset)
+	out := new(AppArmorProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNI) DeepCopyInto(out *CNI) {
+func (in *AssetCache) DeepCopyInto(out *AssetCache) {
 	*out = *in
-	if in.Canal != nil {
-		in, out := &in.Canal, &out.Canal
-		*out = new(CanalSpec)
-		**out = **in
-	}
-	if in.WeaveNet != nil {
-		in, out := &in.WeaveNet, &out.WeaveNet
-		*out = new(WeaveNetSpec)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetCache.
+func (in *AssetCache) DeepCopy() *AssetCache {
+	if in == nil {
+		return nil
 	}
-	if in.External != nil {
-		in, out := &in.External, &out.External
-		*out = new(ExternalCNISpec)
+	out := new(AssetCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetConfiguration) DeepCopyInto(out *AssetConfiguration) {
+	*out = *in
+	out.Kubernetes = in.Kubernetes
+	out.Pause = in.Pause
+	out.CoreDNS = in.CoreDNS
+	out.Etcd = in.Etcd
+	out.MetricsServer = in.MetricsServer
+	in.CNI.DeepCopyInto(&out.CNI)
+	in.NodeBinaries.DeepCopyInto(&out.NodeBinaries)
+	in.Kubectl.DeepCopyInto(&out.Kubectl)
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(AssetCache)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNI.
-func (in *CNI) DeepCopy() *CNI {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetConfiguration.
+func (in *AssetConfiguration) DeepCopy() *AssetConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(CNI)
+	out := new(AssetConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CanalSpec) DeepCopyInto(out *CanalSpec) {
+func (in *AuditWebhookConfig) DeepCopyInto(out *AuditWebhookConfig) {
 	*out = *in
+	out.InitialBackoff = in.InitialBackoff
+	out.BatchMaxWait = in.BatchMaxWait
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanalSpec.
-func (in *CanalSpec) DeepCopy() *CanalSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditWebhookConfig.
+func (in *AuditWebhookConfig) DeepCopy() *AuditWebhookConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(CanalSpec)
+	out := new(AuditWebhookConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CloudProviderSpec) DeepCopyInto(out *CloudProviderSpec) {
+func (in *AzureBlobStateBackend) DeepCopyInto(out *AzureBlobStateBackend) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSSpec)
-		**out = **in
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureSpec)
-		**out = **in
-	}
-	if in.DigitalOcean != nil {
-		in, out := &in.DigitalOcean, &out.DigitalOcean
-		*out = new(DigitalOceanSpec)
-		**out = **in
-	}
-	if in.GCE != nil {
-		in, out := &in.GCE, &out.GCE
-		*out = new(GCESpec)
-		**out = **in
-	}
-	if in.Hetzner != nil {
-		in, out := &in.Hetzner, &out.Hetzner
-		*out = new(HetznerSpec)
-		**out = **in
-	}
-	if in.Openstack != nil {
-		in, out := &in.Openstack, &out.Openstack
-		*out = new(OpenstackSpec)
-		**out = **in
-	}
-	if in.Packet != nil {
-		in, out := &in.Packet, &out.Packet
-		*out = new(PacketSpec)
-		**out = **in
-	}
-	if in.Vsphere != nil {
-		in, out := &in.Vsphere, &out.Vsphere
-		*out = new(VsphereSpec)
-		**out = **in
-	}
-	if in.None != nil {
-		in, out := &in.None, &out.None
-		*out = new(NoneSpec)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderSpec.
-func (in *CloudProviderSpec) DeepCopy() *CloudProviderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureBlobStateBackend.
+func (in *AzureBlobStateBackend) DeepCopy() *AzureBlobStateBackend {
 	if in == nil {
 		return nil
 	}
-	out := new(CloudProviderSpec)
+	out := new(AzureBlobStateBackend)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterNetworkConfig) DeepCopyInto(out *ClusterNetworkConfig) {
+func (in *AzureKMSPlugin) DeepCopyInto(out *AzureKMSPlugin) {
 	*out = *in
-	if in.CNI != nil {
-		in, out := &in.CNI, &out.CNI
-		*out = new(CNI)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.KubeProxy != nil {
-		in, out := &in.KubeProxy, &out.KubeProxy
-		*out = new(KubeProxyConfig)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkConfig.
-func (in *ClusterNetworkConfig) DeepCopy() *ClusterNetworkConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKMSPlugin.
+func (in *AzureKMSPlugin) DeepCopy() *AzureKMSPlugin {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterNetworkConfig)
+	out := new(AzureKMSPlugin)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContainerRuntimeConfig) DeepCopyInto(out *ContainerRuntimeConfig) {
+func (in *AzureSpec) DeepCopyInto(out *AzureSpec) {
 	*out = *in
-	if in.Docker != nil {
-		in, out := &in.Docker, &out.Docker
-		*out = new(ContainerRuntimeDocker)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureSpec.
+func (in *AzureSpec) DeepCopy() *AzureSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Containerd != nil {
-		in, out := &in.Containerd, &out.Containerd
-		*out = new(ContainerRuntimeContainerd)
-		**out = **in
+	out := new(AzureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Backups) DeepCopyInto(out *Backups) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StateBackend)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRuntimeConfig.
-func (in *ContainerRuntimeConfig) DeepCopy() *ContainerRuntimeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backups.
+func (in *Backups) DeepCopy() *Backups {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerRuntimeConfig)
+	out := new(Backups)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContainerRuntimeContainerd) DeepCopyInto(out *ContainerRuntimeContainerd) {
+func (in *BastionConfig) DeepCopyInto(out *BastionConfig) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRuntimeContainerd.
-func (in *ContainerRuntimeContainerd) DeepCopy() *ContainerRuntimeContainerd {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BastionConfig.
+func (in *BastionConfig) DeepCopy() *BastionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerRuntimeContainerd)
+	out := new(BastionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContainerRuntimeDocker) DeepCopyInto(out *ContainerRuntimeDocker) {
+func (in *BinaryAsset) DeepCopyInto(out *BinaryAsset) {
 	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRuntimeDocker.
-func (in *ContainerRuntimeDocker) DeepCopy() *ContainerRuntimeDocker {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinaryAsset.
+func (in *BinaryAsset) DeepCopy() *BinaryAsset {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerRuntimeDocker)
+	out := new(BinaryAsset)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
+func (in *BootstrapRBAC) DeepCopyInto(out *BootstrapRBAC) {
 	*out = *in
-	if in.Hosts != nil {
-		in, out := &in.Hosts, &out.Hosts
-		*out = make([]HostConfig, len(*in))
+	if in.Viewers != nil {
+		in, out := &in.Viewers, &out.Viewers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Operators != nil {
+		in, out := &in.Operators, &out.Operators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceAdmins != nil {
+		in, out := &in.NamespaceAdmins, &out.NamespaceAdmins
+		*out = make([]NamespaceAdmins, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -373,536 +461,2292 @@ func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfig.
-func (in *ControlPlaneConfig) DeepCopy() *ControlPlaneConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapRBAC.
+func (in *BootstrapRBAC) DeepCopy() *BootstrapRBAC {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneConfig)
+	out := new(BootstrapRBAC)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSConfig) DeepCopyInto(out *DNSConfig) {
+func (in *CNI) DeepCopyInto(out *CNI) {
 	*out = *in
-	if in.Servers != nil {
-		in, out := &in.Servers, &out.Servers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Canal != nil {
+		in, out := &in.Canal, &out.Canal
+		*out = new(CanalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WeaveNet != nil {
+		in, out := &in.WeaveNet, &out.WeaveNet
+		*out = new(WeaveNetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cilium != nil {
+		in, out := &in.Cilium, &out.Cilium
+		*out = new(CiliumSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Calico != nil {
+		in, out := &in.Calico, &out.Calico
+		*out = new(CalicoSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalCNISpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNI.
+func (in *CNI) DeepCopy() *CNI {
+	if in == nil {
+		return nil
+	}
+	out := new(CNI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoBGPPeer) DeepCopyInto(out *CalicoBGPPeer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoBGPPeer.
+func (in *CalicoBGPPeer) DeepCopy() *CalicoBGPPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(CalicoBGPPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoBGPSpec) DeepCopyInto(out *CalicoBGPSpec) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]CalicoBGPPeer, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoBGPSpec.
+func (in *CalicoBGPSpec) DeepCopy() *CalicoBGPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CalicoBGPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CalicoSpec) DeepCopyInto(out *CalicoSpec) {
+	*out = *in
+	if in.BGP != nil {
+		in, out := &in.BGP, &out.BGP
+		*out = new(CalicoBGPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CalicoSpec.
+func (in *CalicoSpec) DeepCopy() *CalicoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CalicoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanalSpec) DeepCopyInto(out *CanalSpec) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanalSpec.
+func (in *CanalSpec) DeepCopy() *CanalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumHubbleSpec) DeepCopyInto(out *CiliumHubbleSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumHubbleSpec.
+func (in *CiliumHubbleSpec) DeepCopy() *CiliumHubbleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumHubbleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumSpec) DeepCopyInto(out *CiliumSpec) {
+	*out = *in
+	if in.Hubble != nil {
+		in, out := &in.Hubble, &out.Hubble
+		*out = new(CiliumHubbleSpec)
+		**out = **in
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumSpec.
+func (in *CiliumSpec) DeepCopy() *CiliumSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderSpec) DeepCopyInto(out *CloudProviderSpec) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSSpec)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureSpec)
+		**out = **in
+	}
+	if in.DigitalOcean != nil {
+		in, out := &in.DigitalOcean, &out.DigitalOcean
+		*out = new(DigitalOceanSpec)
+		**out = **in
+	}
+	if in.GCE != nil {
+		in, out := &in.GCE, &out.GCE
+		*out = new(GCESpec)
+		**out = **in
+	}
+	if in.Hetzner != nil {
+		in, out := &in.Hetzner, &out.Hetzner
+		*out = new(HetznerSpec)
+		**out = **in
+	}
+	if in.KubeVirt != nil {
+		in, out := &in.KubeVirt, &out.KubeVirt
+		*out = new(KubeVirtSpec)
+		**out = **in
+	}
+	if in.Nutanix != nil {
+		in, out := &in.Nutanix, &out.Nutanix
+		*out = new(NutanixSpec)
+		**out = **in
+	}
+	if in.Openstack != nil {
+		in, out := &in.Openstack, &out.Openstack
+		*out = new(OpenstackSpec)
+		**out = **in
+	}
+	if in.Packet != nil {
+		in, out := &in.Packet, &out.Packet
+		*out = new(PacketSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vsphere != nil {
+		in, out := &in.Vsphere, &out.Vsphere
+		*out = new(VsphereSpec)
+		**out = **in
+	}
+	if in.VMwareCloudDirector != nil {
+		in, out := &in.VMwareCloudDirector, &out.VMwareCloudDirector
+		*out = new(VMwareCloudDirectorSpec)
+		**out = **in
+	}
+	if in.None != nil {
+		in, out := &in.None, &out.None
+		*out = new(NoneSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderSpec.
+func (in *CloudProviderSpec) DeepCopy() *CloudProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkConfig) DeepCopyInto(out *ClusterNetworkConfig) {
+	*out = *in
+	if in.AdditionalPodSubnets != nil {
+		in, out := &in.AdditionalPodSubnets, &out.AdditionalPodSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.CNI != nil {
+		in, out := &in.CNI, &out.CNI
+		*out = new(CNI)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeProxy != nil {
+		in, out := &in.KubeProxy, &out.KubeProxy
+		*out = new(KubeProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CoreDNS != nil {
+		in, out := &in.CoreDNS, &out.CoreDNS
+		*out = new(CoreDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkConfig.
+func (in *ClusterNetworkConfig) DeepCopy() *ClusterNetworkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommandRetryPolicy) DeepCopyInto(out *CommandRetryPolicy) {
+	*out = *in
+	out.Backoff = in.Backoff
+	if in.RetryableErrors != nil {
+		in, out := &in.RetryableErrors, &out.RetryableErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TaskAttempts != nil {
+		in, out := &in.TaskAttempts, &out.TaskAttempts
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommandRetryPolicy.
+func (in *CommandRetryPolicy) DeepCopy() *CommandRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CommandRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRuntimeCRIO) DeepCopyInto(out *ContainerRuntimeCRIO) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRuntimeCRIO.
+func (in *ContainerRuntimeCRIO) DeepCopy() *ContainerRuntimeCRIO {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRuntimeCRIO)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRuntimeConfig) DeepCopyInto(out *ContainerRuntimeConfig) {
+	*out = *in
+	if in.Docker != nil {
+		in, out := &in.Docker, &out.Docker
+		*out = new(ContainerRuntimeDocker)
+		**out = **in
+	}
+	if in.Containerd != nil {
+		in, out := &in.Containerd, &out.Containerd
+		*out = new(ContainerRuntimeContainerd)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CRIO != nil {
+		in, out := &in.CRIO, &out.CRIO
+		*out = new(ContainerRuntimeCRIO)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRuntimeConfig.
+func (in *ContainerRuntimeConfig) DeepCopy() *ContainerRuntimeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRuntimeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRuntimeContainerd) DeepCopyInto(out *ContainerRuntimeContainerd) {
+	*out = *in
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]RegistryMirror, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRuntimeContainerd.
+func (in *ContainerRuntimeContainerd) DeepCopy() *ContainerRuntimeContainerd {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRuntimeContainerd)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRuntimeDocker) DeepCopyInto(out *ContainerRuntimeDocker) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRuntimeDocker.
+func (in *ContainerRuntimeDocker) DeepCopy() *ContainerRuntimeDocker {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRuntimeDocker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneComponentConfig) DeepCopyInto(out *ControlPlaneComponentConfig) {
+	*out = *in
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]ControlPlaneComponentVolume, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponentConfig.
+func (in *ControlPlaneComponentConfig) DeepCopy() *ControlPlaneComponentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneComponentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneComponentVolume) DeepCopyInto(out *ControlPlaneComponentVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponentVolume.
+func (in *ControlPlaneComponentVolume) DeepCopy() *ControlPlaneComponentVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneComponentVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneComponents) DeepCopyInto(out *ControlPlaneComponents) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(APIServerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ControlPlaneComponentConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(ControlPlaneComponentConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponents.
+func (in *ControlPlaneComponents) DeepCopy() *ControlPlaneComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]HostConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfig.
+func (in *ControlPlaneConfig) DeepCopy() *ControlPlaneConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSConfig) DeepCopyInto(out *CoreDNSConfig) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Forwarders != nil {
+		in, out := &in.Forwarders, &out.Forwarders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StubDomains != nil {
+		in, out := &in.StubDomains, &out.StubDomains
+		*out = make([]CoreDNSStubDomain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]CoreDNSHostEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rewrites != nil {
+		in, out := &in.Rewrites, &out.Rewrites
+		*out = make([]CoreDNSRewriteRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]CoreDNSPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSConfig.
+func (in *CoreDNSConfig) DeepCopy() *CoreDNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSHostEntry) DeepCopyInto(out *CoreDNSHostEntry) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSHostEntry.
+func (in *CoreDNSHostEntry) DeepCopy() *CoreDNSHostEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSHostEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSPlugin) DeepCopyInto(out *CoreDNSPlugin) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSPlugin.
+func (in *CoreDNSPlugin) DeepCopy() *CoreDNSPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSRewriteRule) DeepCopyInto(out *CoreDNSRewriteRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSRewriteRule.
+func (in *CoreDNSRewriteRule) DeepCopy() *CoreDNSRewriteRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSRewriteRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSStubDomain) DeepCopyInto(out *CoreDNSStubDomain) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSStubDomain.
+func (in *CoreDNSStubDomain) DeepCopy() *CoreDNSStubDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSStubDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSConfig) DeepCopyInto(out *DNSConfig) {
+	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSConfig.
+func (in *DNSConfig) DeepCopy() *DNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DenyServiceExternalIPs) DeepCopyInto(out *DenyServiceExternalIPs) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DenyServiceExternalIPs.
+func (in *DenyServiceExternalIPs) DeepCopy() *DenyServiceExternalIPs {
+	if in == nil {
+		return nil
+	}
+	out := new(DenyServiceExternalIPs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DigitalOceanSpec) DeepCopyInto(out *DigitalOceanSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigitalOceanSpec.
+func (in *DigitalOceanSpec) DeepCopy() *DigitalOceanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DigitalOceanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicAuditLog) DeepCopyInto(out *DynamicAuditLog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicAuditLog.
+func (in *DynamicAuditLog) DeepCopy() *DynamicAuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicAuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicWorkerAutoscaling) DeepCopyInto(out *DynamicWorkerAutoscaling) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicWorkerAutoscaling.
+func (in *DynamicWorkerAutoscaling) DeepCopy() *DynamicWorkerAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicWorkerAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicWorkerConfig) DeepCopyInto(out *DynamicWorkerConfig) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(DynamicWorkerAutoscaling)
+		**out = **in
+	}
+	in.Config.DeepCopyInto(&out.Config)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicWorkerConfig.
+func (in *DynamicWorkerConfig) DeepCopy() *DynamicWorkerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicWorkerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionProviders) DeepCopyInto(out *EncryptionProviders) {
+	*out = *in
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(EncryptionProvidersKMS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionProviders.
+func (in *EncryptionProviders) DeepCopy() *EncryptionProviders {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionProviders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionProvidersKMS) DeepCopyInto(out *EncryptionProvidersKMS) {
+	*out = *in
+	out.Timeout = in.Timeout
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(KMSPlugin)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionProvidersKMS.
+func (in *EncryptionProvidersKMS) DeepCopy() *EncryptionProvidersKMS {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionProvidersKMS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdConfig) DeepCopyInto(out *EtcdConfig) {
+	*out = *in
+	out.HeartbeatInterval = in.HeartbeatInterval
+	out.ElectionTimeout = in.ElectionTimeout
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(EtcdTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdConfig.
+func (in *EtcdConfig) DeepCopy() *EtcdConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdTLSConfig) DeepCopyInto(out *EtcdTLSConfig) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServerCertSANs != nil {
+		in, out := &in.ServerCertSANs, &out.ServerCertSANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdTLSConfig.
+func (in *EtcdTLSConfig) DeepCopy() *EtcdTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRateLimit) DeepCopyInto(out *EventRateLimit) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRateLimit.
+func (in *EventRateLimit) DeepCopy() *EventRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRateLimitConfig) DeepCopyInto(out *EventRateLimitConfig) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]EventRateLimitLimit, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRateLimitConfig.
+func (in *EventRateLimitConfig) DeepCopy() *EventRateLimitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRateLimitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRateLimitLimit) DeepCopyInto(out *EventRateLimitLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRateLimitLimit.
+func (in *EventRateLimitLimit) DeepCopy() *EventRateLimitLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRateLimitLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalCNISpec) DeepCopyInto(out *ExternalCNISpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalCNISpec.
+func (in *ExternalCNISpec) DeepCopy() *ExternalCNISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalCNISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalEtcdConfig) DeepCopyInto(out *ExternalEtcdConfig) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalEtcdConfig.
+func (in *ExternalEtcdConfig) DeepCopy() *ExternalEtcdConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalEtcdConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FIPS) DeepCopyInto(out *FIPS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FIPS.
+func (in *FIPS) DeepCopy() *FIPS {
+	if in == nil {
+		return nil
+	}
+	out := new(FIPS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Features) DeepCopyInto(out *Features) {
+	*out = *in
+	if in.PodNodeSelector != nil {
+		in, out := &in.PodNodeSelector, &out.PodNodeSelector
+		*out = new(PodNodeSelector)
+		**out = **in
+	}
+	if in.PodPresets != nil {
+		in, out := &in.PodPresets, &out.PodPresets
+		*out = new(PodPresets)
+		**out = **in
+	}
+	if in.PodSecurityPolicy != nil {
+		in, out := &in.PodSecurityPolicy, &out.PodSecurityPolicy
+		*out = new(PodSecurityPolicy)
+		**out = **in
+	}
+	if in.PodSecurityAdmission != nil {
+		in, out := &in.PodSecurityAdmission, &out.PodSecurityAdmission
+		*out = new(PodSecurityAdmission)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaticAuditLog != nil {
+		in, out := &in.StaticAuditLog, &out.StaticAuditLog
+		*out = new(StaticAuditLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DynamicAuditLog != nil {
+		in, out := &in.DynamicAuditLog, &out.DynamicAuditLog
+		*out = new(DynamicAuditLog)
+		**out = **in
+	}
+	if in.MetricsServer != nil {
+		in, out := &in.MetricsServer, &out.MetricsServer
+		*out = new(MetricsServer)
+		**out = **in
+	}
+	if in.NodeLocalDNS != nil {
+		in, out := &in.NodeLocalDNS, &out.NodeLocalDNS
+		*out = new(NodeLocalDNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenIDConnect != nil {
+		in, out := &in.OpenIDConnect, &out.OpenIDConnect
+		*out = new(OpenIDConnect)
+		**out = **in
+	}
+	if in.EncryptionProviders != nil {
+		in, out := &in.EncryptionProviders, &out.EncryptionProviders
+		*out = new(EncryptionProviders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FIPS != nil {
+		in, out := &in.FIPS, &out.FIPS
+		*out = new(FIPS)
+		**out = **in
+	}
+	if in.SELinux != nil {
+		in, out := &in.SELinux, &out.SELinux
+		*out = new(SELinux)
+		**out = **in
+	}
+	if in.AppArmor != nil {
+		in, out := &in.AppArmor, &out.AppArmor
+		*out = new(AppArmor)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SeccompDefault != nil {
+		in, out := &in.SeccompDefault, &out.SeccompDefault
+		*out = new(SeccompDefault)
+		**out = **in
+	}
+	if in.BootstrapRBAC != nil {
+		in, out := &in.BootstrapRBAC, &out.BootstrapRBAC
+		*out = new(BootstrapRBAC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SRIOV != nil {
+		in, out := &in.SRIOV, &out.SRIOV
+		*out = new(SRIOV)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeVIP != nil {
+		in, out := &in.KubeVIP, &out.KubeVIP
+		*out = new(KubeVIP)
+		**out = **in
+	}
+	if in.Konnectivity != nil {
+		in, out := &in.Konnectivity, &out.Konnectivity
+		*out = new(Konnectivity)
+		**out = **in
+	}
+	if in.EventRateLimit != nil {
+		in, out := &in.EventRateLimit, &out.EventRateLimit
+		*out = new(EventRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlwaysPullImages != nil {
+		in, out := &in.AlwaysPullImages, &out.AlwaysPullImages
+		*out = new(AlwaysPullImages)
+		**out = **in
+	}
+	if in.DenyServiceExternalIPs != nil {
+		in, out := &in.DenyServiceExternalIPs, &out.DenyServiceExternalIPs
+		*out = new(DenyServiceExternalIPs)
+		**out = **in
+	}
+	if in.NodeRestriction != nil {
+		in, out := &in.NodeRestriction, &out.NodeRestriction
+		*out = new(NodeRestriction)
+		**out = **in
+	}
+	if in.Gatekeeper != nil {
+		in, out := &in.Gatekeeper, &out.Gatekeeper
+		*out = new(Gatekeeper)
+		**out = **in
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Features.
+func (in *Features) DeepCopy() *Features {
+	if in == nil {
+		return nil
+	}
+	out := new(Features)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCESpec) DeepCopyInto(out *GCESpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCESpec.
+func (in *GCESpec) DeepCopy() *GCESpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCESpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPKMSPlugin) DeepCopyInto(out *GCPKMSPlugin) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPKMSPlugin.
+func (in *GCPKMSPlugin) DeepCopy() *GCPKMSPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPKMSPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSStateBackend) DeepCopyInto(out *GCSStateBackend) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSStateBackend.
+func (in *GCSStateBackend) DeepCopy() *GCSStateBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSStateBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Gatekeeper) DeepCopyInto(out *Gatekeeper) {
+	*out = *in
+	out.Config = in.Config
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Gatekeeper.
+func (in *Gatekeeper) DeepCopy() *Gatekeeper {
+	if in == nil {
+		return nil
+	}
+	out := new(Gatekeeper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatekeeperConfig) DeepCopyInto(out *GatekeeperConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatekeeperConfig.
+func (in *GatekeeperConfig) DeepCopy() *GatekeeperConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatekeeperConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitAddonSource) DeepCopyInto(out *GitAddonSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitAddonSource.
+func (in *GitAddonSource) DeepCopy() *GitAddonSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitAddonSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPAddonSource) DeepCopyInto(out *HTTPAddonSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPAddonSource.
+func (in *HTTPAddonSource) DeepCopy() *HTTPAddonSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPAddonSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmChart) DeepCopyInto(out *HelmChart) {
+	*out = *in
+	if in.ValuesFiles != nil {
+		in, out := &in.ValuesFiles, &out.ValuesFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmChart.
+func (in *HelmChart) DeepCopy() *HelmChart {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmChart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HetznerSpec) DeepCopyInto(out *HetznerSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HetznerSpec.
+func (in *HetznerSpec) DeepCopy() *HetznerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HetznerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hook) DeepCopyInto(out *Hook) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookHook)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Timeout = in.Timeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hook.
+func (in *Hook) DeepCopy() *Hook {
+	if in == nil {
+		return nil
+	}
+	out := new(Hook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hooks) DeepCopyInto(out *Hooks) {
+	*out = *in
+	if in.PreApply != nil {
+		in, out := &in.PreApply, &out.PreApply
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostApply != nil {
+		in, out := &in.PostApply, &out.PostApply
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreUpgrade != nil {
+		in, out := &in.PreUpgrade, &out.PreUpgrade
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostUpgrade != nil {
+		in, out := &in.PostUpgrade, &out.PostUpgrade
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreReset != nil {
+		in, out := &in.PreReset, &out.PreReset
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostReset != nil {
+		in, out := &in.PostReset, &out.PostReset
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreNodeUpgrade != nil {
+		in, out := &in.PreNodeUpgrade, &out.PreNodeUpgrade
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostNodeUpgrade != nil {
+		in, out := &in.PostNodeUpgrade, &out.PostNodeUpgrade
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(HostHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hooks.
+func (in *Hooks) DeepCopy() *Hooks {
+	if in == nil {
+		return nil
+	}
+	out := new(Hooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostConfig) DeepCopyInto(out *HostConfig) {
+	*out = *in
+	if in.IPv6Addresses != nil {
+		in, out := &in.IPv6Addresses, &out.IPv6Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bastions != nil {
+		in, out := &in.Bastions, &out.Bastions
+		*out = make([]BastionConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NetworkOverrides != nil {
+		in, out := &in.NetworkOverrides, &out.NetworkOverrides
+		*out = new(HostNetworkOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeletConfig != nil {
+		in, out := &in.KubeletConfig, &out.KubeletConfig
+		*out = new(KubeletConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostConfig.
+func (in *HostConfig) DeepCopy() *HostConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HostConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostHooks) DeepCopyInto(out *HostHooks) {
+	*out = *in
+	if in.PreProvision != nil {
+		in, out := &in.PreProvision, &out.PreProvision
+		*out = make([]HostScript, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostJoin != nil {
+		in, out := &in.PostJoin, &out.PostJoin
+		*out = make([]HostScript, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreDrain != nil {
+		in, out := &in.PreDrain, &out.PreDrain
+		*out = make([]HostScript, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostUpgrade != nil {
+		in, out := &in.PostUpgrade, &out.PostUpgrade
+		*out = make([]HostScript, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostHooks.
+func (in *HostHooks) DeepCopy() *HostHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(HostHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostNetworkOverrides) DeepCopyInto(out *HostNetworkOverrides) {
+	*out = *in
+	if in.EtcdListenClientURLs != nil {
+		in, out := &in.EtcdListenClientURLs, &out.EtcdListenClientURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EtcdAdvertiseClientURLs != nil {
+		in, out := &in.EtcdAdvertiseClientURLs, &out.EtcdAdvertiseClientURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EtcdListenPeerURLs != nil {
+		in, out := &in.EtcdListenPeerURLs, &out.EtcdListenPeerURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostNetworkOverrides.
+func (in *HostNetworkOverrides) DeepCopy() *HostNetworkOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(HostNetworkOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostScript) DeepCopyInto(out *HostScript) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostScript.
+func (in *HostScript) DeepCopy() *HostScript {
+	if in == nil {
+		return nil
+	}
+	out := new(HostScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPTables) DeepCopyInto(out *IPTables) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPTables.
+func (in *IPTables) DeepCopy() *IPTables {
+	if in == nil {
+		return nil
+	}
+	out := new(IPTables)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPVSConfig) DeepCopyInto(out *IPVSConfig) {
+	*out = *in
+	if in.ExcludeCIDRs != nil {
+		in, out := &in.ExcludeCIDRs, &out.ExcludeCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StrictARP != nil {
+		in, out := &in.StrictARP, &out.StrictARP
+		*out = new(bool)
+		**out = **in
+	}
+	out.TCPTimeout = in.TCPTimeout
+	out.TCPFinTimeout = in.TCPFinTimeout
+	out.UDPTimeout = in.UDPTimeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPVSConfig.
+func (in *IPVSConfig) DeepCopy() *IPVSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IPVSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageAsset) DeepCopyInto(out *ImageAsset) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageAsset.
+func (in *ImageAsset) DeepCopy() *ImageAsset {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageAsset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSPlugin) DeepCopyInto(out *KMSPlugin) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSKMSPlugin)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureKMSPlugin)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPKMSPlugin)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSPlugin.
+func (in *KMSPlugin) DeepCopy() *KMSPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Konnectivity) DeepCopyInto(out *Konnectivity) {
+	*out = *in
+	out.Config = in.Config
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Konnectivity.
+func (in *Konnectivity) DeepCopy() *Konnectivity {
+	if in == nil {
+		return nil
+	}
+	out := new(Konnectivity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityConfig) DeepCopyInto(out *KonnectivityConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityConfig.
+func (in *KonnectivityConfig) DeepCopy() *KonnectivityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeOneCluster) DeepCopyInto(out *KubeOneCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ControlPlane.DeepCopyInto(&out.ControlPlane)
+	in.Etcd.DeepCopyInto(&out.Etcd)
+	if in.ExternalEtcd != nil {
+		in, out := &in.ExternalEtcd, &out.ExternalEtcd
+		*out = new(ExternalEtcdConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneComponents != nil {
+		in, out := &in.ControlPlaneComponents, &out.ControlPlaneComponents
+		*out = new(ControlPlaneComponents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeletConfig != nil {
+		in, out := &in.KubeletConfig, &out.KubeletConfig
+		*out = new(KubeletConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.APIEndpoint = in.APIEndpoint
+	in.CloudProvider.DeepCopyInto(&out.CloudProvider)
+	out.Versions = in.Versions
+	in.ContainerRuntime.DeepCopyInto(&out.ContainerRuntime)
+	in.ClusterNetwork.DeepCopyInto(&out.ClusterNetwork)
+	out.Proxy = in.Proxy
+	in.StaticWorkers.DeepCopyInto(&out.StaticWorkers)
+	if in.DynamicWorkers != nil {
+		in, out := &in.DynamicWorkers, &out.DynamicWorkers
+		*out = make([]DynamicWorkerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachineController != nil {
+		in, out := &in.MachineController, &out.MachineController
+		*out = new(MachineControllerConfig)
+		**out = **in
+	}
+	if in.OperatingSystemManager != nil {
+		in, out := &in.OperatingSystemManager, &out.OperatingSystemManager
+		*out = new(OSMConfig)
+		**out = **in
+	}
+	in.Features.DeepCopyInto(&out.Features)
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = new(Addons)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SystemPackages != nil {
+		in, out := &in.SystemPackages, &out.SystemPackages
+		*out = new(SystemPackages)
+		**out = **in
+	}
+	in.AssetConfiguration.DeepCopyInto(&out.AssetConfiguration)
+	if in.RegistryConfiguration != nil {
+		in, out := &in.RegistryConfiguration, &out.RegistryConfiguration
+		*out = new(RegistryConfiguration)
+		**out = **in
+	}
+	if in.StateBackend != nil {
+		in, out := &in.StateBackend, &out.StateBackend
+		*out = new(StateBackend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = new(Backups)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(Hooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CommandRetries != nil {
+		in, out := &in.CommandRetries, &out.CommandRetries
+		*out = new(CommandRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]Notification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeOneCluster.
+func (in *KubeOneCluster) DeepCopy() *KubeOneCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeOneCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeOneCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeProxyConfig) DeepCopyInto(out *KubeProxyConfig) {
+	*out = *in
+	if in.IPVS != nil {
+		in, out := &in.IPVS, &out.IPVS
+		*out = new(IPVSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPTables != nil {
+		in, out := &in.IPTables, &out.IPTables
+		*out = new(IPTables)
+		**out = **in
+	}
+	if in.Conntrack != nil {
+		in, out := &in.Conntrack, &out.Conntrack
+		*out = new(KubeProxyConntrack)
+		(*in).DeepCopyInto(*out)
+	}
+	out.SyncPeriod = in.SyncPeriod
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeProxyConfig.
+func (in *KubeProxyConfig) DeepCopy() *KubeProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeProxyConntrack) DeepCopyInto(out *KubeProxyConntrack) {
+	*out = *in
+	if in.MaxPerCore != nil {
+		in, out := &in.MaxPerCore, &out.MaxPerCore
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeProxyConntrack.
+func (in *KubeProxyConntrack) DeepCopy() *KubeProxyConntrack {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeProxyConntrack)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVIP) DeepCopyInto(out *KubeVIP) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVIP.
+func (in *KubeVIP) DeepCopy() *KubeVIP {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtSpec) DeepCopyInto(out *KubeVirtSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtSpec.
+func (in *KubeVirtSpec) DeepCopy() *KubeVirtSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfig) DeepCopyInto(out *KubeletConfig) {
+	*out = *in
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SystemReserved != nil {
+		in, out := &in.SystemReserved, &out.SystemReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KubeReserved != nil {
+		in, out := &in.KubeReserved, &out.KubeReserved
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSConfig.
-func (in *DNSConfig) DeepCopy() *DNSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfig.
+func (in *KubeletConfig) DeepCopy() *KubeletConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSConfig)
+	out := new(KubeletConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DigitalOceanSpec) DeepCopyInto(out *DigitalOceanSpec) {
+func (in *KubernetesSecretStateBackend) DeepCopyInto(out *KubernetesSecretStateBackend) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DigitalOceanSpec.
-func (in *DigitalOceanSpec) DeepCopy() *DigitalOceanSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSecretStateBackend.
+func (in *KubernetesSecretStateBackend) DeepCopy() *KubernetesSecretStateBackend {
 	if in == nil {
 		return nil
 	}
-	out := new(DigitalOceanSpec)
+	out := new(KubernetesSecretStateBackend)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DynamicAuditLog) DeepCopyInto(out *DynamicAuditLog) {
+func (in *LocalStateBackend) DeepCopyInto(out *LocalStateBackend) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicAuditLog.
-func (in *DynamicAuditLog) DeepCopy() *DynamicAuditLog {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalStateBackend.
+func (in *LocalStateBackend) DeepCopy() *LocalStateBackend {
 	if in == nil {
 		return nil
 	}
-	out := new(DynamicAuditLog)
+	out := new(LocalStateBackend)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DynamicWorkerConfig) DeepCopyInto(out *DynamicWorkerConfig) {
+func (in *MachineControllerConfig) DeepCopyInto(out *MachineControllerConfig) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int)
-		**out = **in
-	}
-	in.Config.DeepCopyInto(&out.Config)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicWorkerConfig.
-func (in *DynamicWorkerConfig) DeepCopy() *DynamicWorkerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineControllerConfig.
+func (in *MachineControllerConfig) DeepCopy() *MachineControllerConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DynamicWorkerConfig)
+	out := new(MachineControllerConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EncryptionProviders) DeepCopyInto(out *EncryptionProviders) {
+func (in *MetricsServer) DeepCopyInto(out *MetricsServer) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionProviders.
-func (in *EncryptionProviders) DeepCopy() *EncryptionProviders {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsServer.
+func (in *MetricsServer) DeepCopy() *MetricsServer {
 	if in == nil {
 		return nil
 	}
-	out := new(EncryptionProviders)
+	out := new(MetricsServer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalCNISpec) DeepCopyInto(out *ExternalCNISpec) {
+func (in *NamespaceAdmins) DeepCopyInto(out *NamespaceAdmins) {
 	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalCNISpec.
-func (in *ExternalCNISpec) DeepCopy() *ExternalCNISpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceAdmins.
+func (in *NamespaceAdmins) DeepCopy() *NamespaceAdmins {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalCNISpec)
+	out := new(NamespaceAdmins)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Features) DeepCopyInto(out *Features) {
+func (in *NodeLocalDNS) DeepCopyInto(out *NodeLocalDNS) {
 	*out = *in
-	if in.PodNodeSelector != nil {
-		in, out := &in.PodNodeSelector, &out.PodNodeSelector
-		*out = new(PodNodeSelector)
-		**out = **in
-	}
-	if in.PodPresets != nil {
-		in, out := &in.PodPresets, &out.PodPresets
-		*out = new(PodPresets)
-		**out = **in
-	}
-	if in.PodSecurityPolicy != nil {
-		in, out := &in.PodSecurityPolicy, &out.PodSecurityPolicy
-		*out = new(PodSecurityPolicy)
-		**out = **in
-	}
-	if in.StaticAuditLog != nil {
-		in, out := &in.StaticAuditLog, &out.StaticAuditLog
-		*out = new(StaticAuditLog)
-		**out = **in
-	}
-	if in.DynamicAuditLog != nil {
-		in, out := &in.DynamicAuditLog, &out.DynamicAuditLog
-		*out = new(DynamicAuditLog)
-		**out = **in
-	}
-	if in.MetricsServer != nil {
-		in, out := &in.MetricsServer, &out.MetricsServer
-		*out = new(MetricsServer)
-		**out = **in
-	}
-	if in.OpenIDConnect != nil {
-		in, out := &in.OpenIDConnect, &out.OpenIDConnect
-		*out = new(OpenIDConnect)
-		**out = **in
-	}
-	if in.EncryptionProviders != nil {
-		in, out := &in.EncryptionProviders, &out.EncryptionProviders
-		*out = new(EncryptionProviders)
-		**out = **in
-	}
+	in.Config.DeepCopyInto(&out.Config)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Features.
-func (in *Features) DeepCopy() *Features {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLocalDNS.
+func (in *NodeLocalDNS) DeepCopy() *NodeLocalDNS {
 	if in == nil {
 		return nil
 	}
-	out := new(Features)
+	out := new(NodeLocalDNS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCESpec) DeepCopyInto(out *GCESpec) {
+func (in *NodeLocalDNSConfig) DeepCopyInto(out *NodeLocalDNSConfig) {
 	*out = *in
+	if in.UpstreamServers != nil {
+		in, out := &in.UpstreamServers, &out.UpstreamServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForceTCP != nil {
+		in, out := &in.ForceTCP, &out.ForceTCP
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCESpec.
-func (in *GCESpec) DeepCopy() *GCESpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLocalDNSConfig.
+func (in *NodeLocalDNSConfig) DeepCopy() *NodeLocalDNSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GCESpec)
+	out := new(NodeLocalDNSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HetznerSpec) DeepCopyInto(out *HetznerSpec) {
+func (in *NodeRestriction) DeepCopyInto(out *NodeRestriction) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HetznerSpec.
-func (in *HetznerSpec) DeepCopy() *HetznerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRestriction.
+func (in *NodeRestriction) DeepCopy() *NodeRestriction {
 	if in == nil {
 		return nil
 	}
-	out := new(HetznerSpec)
+	out := new(NodeRestriction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostConfig) DeepCopyInto(out *HostConfig) {
+func (in *NoneSpec) DeepCopyInto(out *NoneSpec) {
 	*out = *in
-	if in.Taints != nil {
-		in, out := &in.Taints, &out.Taints
-		*out = make([]v1.Taint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostConfig.
-func (in *HostConfig) DeepCopy() *HostConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoneSpec.
+func (in *NoneSpec) DeepCopy() *NoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HostConfig)
+	out := new(NoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPTables) DeepCopyInto(out *IPTables) {
+func (in *Notification) DeepCopyInto(out *Notification) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPTables.
-func (in *IPTables) DeepCopy() *IPTables {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
+func (in *Notification) DeepCopy() *Notification {
 	if in == nil {
 		return nil
 	}
-	out := new(IPTables)
+	out := new(Notification)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPVSConfig) DeepCopyInto(out *IPVSConfig) {
+func (in *NutanixSpec) DeepCopyInto(out *NutanixSpec) {
 	*out = *in
-	if in.ExcludeCIDRs != nil {
-		in, out := &in.ExcludeCIDRs, &out.ExcludeCIDRs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	out.TCPTimeout = in.TCPTimeout
-	out.TCPFinTimeout = in.TCPFinTimeout
-	out.UDPTimeout = in.UDPTimeout
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPVSConfig.
-func (in *IPVSConfig) DeepCopy() *IPVSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NutanixSpec.
+func (in *NutanixSpec) DeepCopy() *NutanixSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IPVSConfig)
+	out := new(NutanixSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageAsset) DeepCopyInto(out *ImageAsset) {
+func (in *OSMConfig) DeepCopyInto(out *OSMConfig) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageAsset.
-func (in *ImageAsset) DeepCopy() *ImageAsset {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSMConfig.
+func (in *OSMConfig) DeepCopy() *OSMConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageAsset)
+	out := new(OSMConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeOneCluster) DeepCopyInto(out *KubeOneCluster) {
+func (in *OpenIDConnect) DeepCopyInto(out *OpenIDConnect) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ControlPlane.DeepCopyInto(&out.ControlPlane)
-	out.APIEndpoint = in.APIEndpoint
-	in.CloudProvider.DeepCopyInto(&out.CloudProvider)
-	out.Versions = in.Versions
-	in.ContainerRuntime.DeepCopyInto(&out.ContainerRuntime)
-	in.ClusterNetwork.DeepCopyInto(&out.ClusterNetwork)
-	out.Proxy = in.Proxy
-	in.StaticWorkers.DeepCopyInto(&out.StaticWorkers)
-	if in.DynamicWorkers != nil {
-		in, out := &in.DynamicWorkers, &out.DynamicWorkers
-		*out = make([]DynamicWorkerConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.MachineController != nil {
-		in, out := &in.MachineController, &out.MachineController
-		*out = new(MachineControllerConfig)
-		**out = **in
-	}
-	in.Features.DeepCopyInto(&out.Features)
-	if in.Addons != nil {
-		in, out := &in.Addons, &out.Addons
-		*out = new(Addons)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SystemPackages != nil {
-		in, out := &in.SystemPackages, &out.SystemPackages
-		*out = new(SystemPackages)
-		**out = **in
-	}
-	out.AssetConfiguration = in.AssetConfiguration
-	if in.RegistryConfiguration != nil {
-		in, out := &in.RegistryConfiguration, &out.RegistryConfiguration
-		*out = new(RegistryConfiguration)
-		**out = **in
-	}
+	out.Config = in.Config
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeOneCluster.
-func (in *KubeOneCluster) DeepCopy() *KubeOneCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenIDConnect.
+func (in *OpenIDConnect) DeepCopy() *OpenIDConnect {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeOneCluster)
+	out := new(OpenIDConnect)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeOneCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeProxyConfig) DeepCopyInto(out *KubeProxyConfig) {
+func (in *OpenIDConnectConfig) DeepCopyInto(out *OpenIDConnectConfig) {
 	*out = *in
-	if in.IPVS != nil {
-		in, out := &in.IPVS, &out.IPVS
-		*out = new(IPVSConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.IPTables != nil {
-		in, out := &in.IPTables, &out.IPTables
-		*out = new(IPTables)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeProxyConfig.
-func (in *KubeProxyConfig) DeepCopy() *KubeProxyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenIDConnectConfig.
+func (in *OpenIDConnectConfig) DeepCopy() *OpenIDConnectConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeProxyConfig)
+	out := new(OpenIDConnectConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachineControllerConfig) DeepCopyInto(out *MachineControllerConfig) {
+func (in *OpenstackSpec) DeepCopyInto(out *OpenstackSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineControllerConfig.
-func (in *MachineControllerConfig) DeepCopy() *MachineControllerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackSpec.
+func (in *OpenstackSpec) DeepCopy() *OpenstackSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MachineControllerConfig)
+	out := new(OpenstackSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricsServer) DeepCopyInto(out *MetricsServer) {
+func (in *PacketBGP) DeepCopyInto(out *PacketBGP) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsServer.
-func (in *MetricsServer) DeepCopy() *MetricsServer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketBGP.
+func (in *PacketBGP) DeepCopy() *PacketBGP {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricsServer)
+	out := new(PacketBGP)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NoneSpec) DeepCopyInto(out *NoneSpec) {
+func (in *PacketElasticIP) DeepCopyInto(out *PacketElasticIP) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoneSpec.
-func (in *NoneSpec) DeepCopy() *NoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketElasticIP.
+func (in *PacketElasticIP) DeepCopy() *PacketElasticIP {
 	if in == nil {
 		return nil
 	}
-	out := new(NoneSpec)
+	out := new(PacketElasticIP)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenIDConnect) DeepCopyInto(out *OpenIDConnect) {
+func (in *PacketSpec) DeepCopyInto(out *PacketSpec) {
 	*out = *in
-	out.Config = in.Config
+	if in.BGP != nil {
+		in, out := &in.BGP, &out.BGP
+		*out = new(PacketBGP)
+		**out = **in
+	}
+	if in.ElasticIP != nil {
+		in, out := &in.ElasticIP, &out.ElasticIP
+		*out = new(PacketElasticIP)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenIDConnect.
-func (in *OpenIDConnect) DeepCopy() *OpenIDConnect {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketSpec.
+func (in *PacketSpec) DeepCopy() *PacketSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenIDConnect)
+	out := new(PacketSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenIDConnectConfig) DeepCopyInto(out *OpenIDConnectConfig) {
+func (in *PodNodeSelector) DeepCopyInto(out *PodNodeSelector) {
 	*out = *in
+	out.Config = in.Config
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenIDConnectConfig.
-func (in *OpenIDConnectConfig) DeepCopy() *OpenIDConnectConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNodeSelector.
+func (in *PodNodeSelector) DeepCopy() *PodNodeSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenIDConnectConfig)
+	out := new(PodNodeSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenstackSpec) DeepCopyInto(out *OpenstackSpec) {
+func (in *PodNodeSelectorConfig) DeepCopyInto(out *PodNodeSelectorConfig) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackSpec.
-func (in *OpenstackSpec) DeepCopy() *OpenstackSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNodeSelectorConfig.
+func (in *PodNodeSelectorConfig) DeepCopy() *PodNodeSelectorConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenstackSpec)
+	out := new(PodNodeSelectorConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PacketSpec) DeepCopyInto(out *PacketSpec) {
+func (in *PodPresets) DeepCopyInto(out *PodPresets) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketSpec.
-func (in *PacketSpec) DeepCopy() *PacketSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPresets.
+func (in *PodPresets) DeepCopy() *PodPresets {
 	if in == nil {
 		return nil
 	}
-	out := new(PacketSpec)
+	out := new(PodPresets)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodNodeSelector) DeepCopyInto(out *PodNodeSelector) {
+func (in *PodSecurityAdmission) DeepCopyInto(out *PodSecurityAdmission) {
 	*out = *in
-	out.Config = in.Config
+	in.Config.DeepCopyInto(&out.Config)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNodeSelector.
-func (in *PodNodeSelector) DeepCopy() *PodNodeSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmission.
+func (in *PodSecurityAdmission) DeepCopy() *PodSecurityAdmission {
 	if in == nil {
 		return nil
 	}
-	out := new(PodNodeSelector)
+	out := new(PodSecurityAdmission)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodNodeSelectorConfig) DeepCopyInto(out *PodNodeSelectorConfig) {
+func (in *PodSecurityAdmissionConfig) DeepCopyInto(out *PodSecurityAdmissionConfig) {
 	*out = *in
+	in.Exemptions.DeepCopyInto(&out.Exemptions)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNodeSelectorConfig.
-func (in *PodNodeSelectorConfig) DeepCopy() *PodNodeSelectorConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfig.
+func (in *PodSecurityAdmissionConfig) DeepCopy() *PodSecurityAdmissionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PodNodeSelectorConfig)
+	out := new(PodSecurityAdmissionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodPresets) DeepCopyInto(out *PodPresets) {
+func (in *PodSecurityAdmissionExemptions) DeepCopyInto(out *PodSecurityAdmissionExemptions) {
 	*out = *in
+	if in.Usernames != nil {
+		in, out := &in.Usernames, &out.Usernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuntimeClasses != nil {
+		in, out := &in.RuntimeClasses, &out.RuntimeClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPresets.
-func (in *PodPresets) DeepCopy() *PodPresets {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionExemptions.
+func (in *PodSecurityAdmissionExemptions) DeepCopy() *PodSecurityAdmissionExemptions {
 	if in == nil {
 		return nil
 	}
-	out := new(PodPresets)
+	out := new(PodSecurityAdmissionExemptions)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1018,6 +2862,22 @@ func (in *ProxyConfig) DeepCopy() *ProxyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryAuth) DeepCopyInto(out *RegistryAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryAuth.
+func (in *RegistryAuth) DeepCopy() *RegistryAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryConfiguration) DeepCopyInto(out *RegistryConfiguration) {
 	*out = *in
@@ -1034,10 +2894,206 @@ func (in *RegistryConfiguration) DeepCopy() *RegistryConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(RegistryAuth)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3AddonSource) DeepCopyInto(out *S3AddonSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3AddonSource.
+func (in *S3AddonSource) DeepCopy() *S3AddonSource {
+	if in == nil {
+		return nil
+	}
+	out := new(S3AddonSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3StateBackend) DeepCopyInto(out *S3StateBackend) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3StateBackend.
+func (in *S3StateBackend) DeepCopy() *S3StateBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(S3StateBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SELinux) DeepCopyInto(out *SELinux) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SELinux.
+func (in *SELinux) DeepCopy() *SELinux {
+	if in == nil {
+		return nil
+	}
+	out := new(SELinux)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SRIOV) DeepCopyInto(out *SRIOV) {
+	*out = *in
+	if in.KernelParameters != nil {
+		in, out := &in.KernelParameters, &out.KernelParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]SRIOVHost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SRIOV.
+func (in *SRIOV) DeepCopy() *SRIOV {
+	if in == nil {
+		return nil
+	}
+	out := new(SRIOV)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SRIOVHost) DeepCopyInto(out *SRIOVHost) {
+	*out = *in
+	if in.PhysicalFunctions != nil {
+		in, out := &in.PhysicalFunctions, &out.PhysicalFunctions
+		*out = make([]SRIOVPhysicalFunction, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SRIOVHost.
+func (in *SRIOVHost) DeepCopy() *SRIOVHost {
+	if in == nil {
+		return nil
+	}
+	out := new(SRIOVHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SRIOVPhysicalFunction) DeepCopyInto(out *SRIOVPhysicalFunction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SRIOVPhysicalFunction.
+func (in *SRIOVPhysicalFunction) DeepCopy() *SRIOVPhysicalFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(SRIOVPhysicalFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompDefault) DeepCopyInto(out *SeccompDefault) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompDefault.
+func (in *SeccompDefault) DeepCopy() *SeccompDefault {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompDefault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateBackend) DeepCopyInto(out *StateBackend) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3StateBackend)
+		**out = **in
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(GCSStateBackend)
+		**out = **in
+	}
+	if in.AzureBlob != nil {
+		in, out := &in.AzureBlob, &out.AzureBlob
+		*out = new(AzureBlobStateBackend)
+		**out = **in
+	}
+	if in.Local != nil {
+		in, out := &in.Local, &out.Local
+		*out = new(LocalStateBackend)
+		**out = **in
+	}
+	if in.KubernetesSecret != nil {
+		in, out := &in.KubernetesSecret, &out.KubernetesSecret
+		*out = new(KubernetesSecretStateBackend)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateBackend.
+func (in *StateBackend) DeepCopy() *StateBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(StateBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticAuditLog) DeepCopyInto(out *StaticAuditLog) {
 	*out = *in
-	out.Config = in.Config
+	in.Config.DeepCopyInto(&out.Config)
 	return
 }
 
@@ -1054,6 +3110,11 @@ func (in *StaticAuditLog) DeepCopy() *StaticAuditLog {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticAuditLogConfig) DeepCopyInto(out *StaticAuditLogConfig) {
 	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(AuditWebhookConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -1067,6 +3128,50 @@ func (in *StaticAuditLogConfig) DeepCopy() *StaticAuditLogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticWorkerPool) DeepCopyInto(out *StaticWorkerPool) {
+	*out = *in
+	if in.KubeletExtraArgs != nil {
+		in, out := &in.KubeletExtraArgs, &out.KubeletExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticWorkerPool.
+func (in *StaticWorkerPool) DeepCopy() *StaticWorkerPool {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticWorkerPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticWorkersConfig) DeepCopyInto(out *StaticWorkersConfig) {
 	*out = *in
@@ -1077,6 +3182,13 @@ func (in *StaticWorkersConfig) DeepCopyInto(out *StaticWorkersConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]StaticWorkerPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1106,6 +3218,22 @@ func (in *SystemPackages) DeepCopy() *SystemPackages {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMwareCloudDirectorSpec) DeepCopyInto(out *VMwareCloudDirectorSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMwareCloudDirectorSpec.
+func (in *VMwareCloudDirectorSpec) DeepCopy() *VMwareCloudDirectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMwareCloudDirectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VersionConfig) DeepCopyInto(out *VersionConfig) {
 	*out = *in
@@ -1141,6 +3269,13 @@ func (in *VsphereSpec) DeepCopy() *VsphereSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WeaveNetSpec) DeepCopyInto(out *WeaveNetSpec) {
 	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -1153,3 +3288,26 @@ func (in *WeaveNetSpec) DeepCopy() *WeaveNetSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookHook) DeepCopyInto(out *WebhookHook) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookHook.
+func (in *WebhookHook) DeepCopy() *WebhookHook {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookHook)
+	in.DeepCopyInto(out)
+	return out
+}
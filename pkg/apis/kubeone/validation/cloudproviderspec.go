@@ -0,0 +1,271 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// The following types mirror the machine-controller cloudProviderSpec of the
+// supported providers. The cloudProviderSpec is decoded into them
+// disallowing unknown fields, so typos and values of the wrong type are
+// caught before machine-controller fails to reconcile the Machines.
+
+// configVarString is a string value, or a reference to the Secret or the
+// ConfigMap key holding the value
+type configVarString struct{}
+
+func (configVarString) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '{' {
+		return strictUnmarshal(b, &configVarRef{})
+	}
+
+	var s string
+
+	return json.Unmarshal(b, &s)
+}
+
+// configVarBool is a boolean value, or a reference to the Secret or the
+// ConfigMap key holding the value
+type configVarBool struct{}
+
+func (configVarBool) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '{' {
+		return strictUnmarshal(b, &configVarRef{})
+	}
+
+	var v bool
+
+	return json.Unmarshal(b, &v)
+}
+
+type configVarRef struct {
+	Value           json.RawMessage  `json:"value,omitempty"`
+	SecretKeyRef    *configVarKeyRef `json:"secretKeyRef,omitempty"`
+	ConfigMapKeyRef *configVarKeyRef `json:"configMapKeyRef,omitempty"`
+}
+
+type configVarKeyRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+type awsProviderSpec struct {
+	AccessKeyID        configVarString   `json:"accessKeyId"`
+	SecretAccessKey    configVarString   `json:"secretAccessKey"`
+	Region             configVarString   `json:"region"`
+	AvailabilityZone   configVarString   `json:"availabilityZone"`
+	VpcID              configVarString   `json:"vpcId"`
+	SubnetID           configVarString   `json:"subnetId"`
+	SecurityGroupIDs   []configVarString `json:"securityGroupIDs"`
+	InstanceProfile    configVarString   `json:"instanceProfile"`
+	InstanceType       configVarString   `json:"instanceType"`
+	AMI                configVarString   `json:"ami"`
+	DiskSize           int64             `json:"diskSize"`
+	DiskType           configVarString   `json:"diskType"`
+	DiskIops           *int64            `json:"diskIops"`
+	EBSVolumeEncrypted configVarBool     `json:"ebsVolumeEncrypted"`
+	Tags               map[string]string `json:"tags"`
+	AssignPublicIP     *bool             `json:"assignPublicIP"`
+	IsSpotInstance     *bool             `json:"isSpotInstance"`
+	SpotInstanceConfig *struct {
+		MaxPrice             configVarString `json:"maxPrice"`
+		PersistentRequest    configVarBool   `json:"persistentRequest"`
+		InterruptionBehavior configVarString `json:"interruptionBehavior"`
+	} `json:"spotInstanceConfig"`
+}
+
+type azureProviderSpec struct {
+	SubscriptionID    configVarString `json:"subscriptionID"`
+	TenantID          configVarString `json:"tenantID"`
+	ClientID          configVarString `json:"clientID"`
+	ClientSecret      configVarString `json:"clientSecret"`
+	Location          configVarString `json:"location"`
+	ResourceGroup     configVarString `json:"resourceGroup"`
+	VNetResourceGroup configVarString `json:"vnetResourceGroup"`
+	VMSize            configVarString `json:"vmSize"`
+	VNetName          configVarString `json:"vnetName"`
+	SubnetName        configVarString `json:"subnetName"`
+	LoadBalancerSku   configVarString `json:"loadBalancerSku"`
+	RouteTableName    configVarString `json:"routeTableName"`
+	AvailabilitySet   configVarString `json:"availabilitySet"`
+	SecurityGroupName configVarString `json:"securityGroupName"`
+	Zones             []string        `json:"zones"`
+	ImagePlan         *struct {
+		Name      string `json:"name"`
+		Publisher string `json:"publisher"`
+		Product   string `json:"product"`
+	} `json:"imagePlan"`
+	ImageReference *struct {
+		Publisher string `json:"publisher"`
+		Offer     string `json:"offer"`
+		Sku       string `json:"sku"`
+		Version   string `json:"version"`
+	} `json:"imageReference"`
+	ImageID        configVarString   `json:"imageID"`
+	OSDiskSize     int32             `json:"osDiskSize"`
+	DataDiskSize   int32             `json:"dataDiskSize"`
+	AssignPublicIP configVarBool     `json:"assignPublicIP"`
+	Tags           map[string]string `json:"tags"`
+}
+
+type digitalOceanProviderSpec struct {
+	Token             configVarString   `json:"token"`
+	Region            configVarString   `json:"region"`
+	Size              configVarString   `json:"size"`
+	Backups           configVarBool     `json:"backups"`
+	IPv6              configVarBool     `json:"ipv6"`
+	PrivateNetworking configVarBool     `json:"private_networking"`
+	Monitoring        configVarBool     `json:"monitoring"`
+	Tags              []configVarString `json:"tags"`
+}
+
+type gceProviderSpec struct {
+	ServiceAccount        configVarString   `json:"serviceAccount"`
+	Zone                  configVarString   `json:"zone"`
+	MachineType           configVarString   `json:"machineType"`
+	DiskSize              int64             `json:"diskSize"`
+	DiskType              configVarString   `json:"diskType"`
+	Network               configVarString   `json:"network"`
+	Subnetwork            configVarString   `json:"subnetwork"`
+	Preemptible           configVarBool     `json:"preemptible"`
+	Labels                map[string]string `json:"labels"`
+	Tags                  []string          `json:"tags"`
+	AssignPublicIPAddress *configVarBool    `json:"assignPublicIPAddress"`
+	MultiZone             configVarBool     `json:"multizone"`
+	Regional              configVarBool     `json:"regional"`
+	CustomImage           configVarString   `json:"customImage"`
+}
+
+type hetznerProviderSpec struct {
+	Token      configVarString   `json:"token"`
+	ServerType configVarString   `json:"serverType"`
+	Datacenter configVarString   `json:"datacenter"`
+	Image      configVarString   `json:"image"`
+	Location   configVarString   `json:"location"`
+	Networks   []configVarString `json:"networks"`
+	Firewalls  []configVarString `json:"firewalls"`
+	Labels     map[string]string `json:"labels"`
+}
+
+type openstackProviderSpec struct {
+	IdentityEndpoint            configVarString   `json:"identityEndpoint"`
+	Username                    configVarString   `json:"username"`
+	Password                    configVarString   `json:"password"`
+	ApplicationCredentialID     configVarString   `json:"applicationCredentialID"`
+	ApplicationCredentialSecret configVarString   `json:"applicationCredentialSecret"`
+	DomainName                  configVarString   `json:"domainName"`
+	TenantName                  configVarString   `json:"tenantName"`
+	TenantID                    configVarString   `json:"tenantID"`
+	TokenID                     configVarString   `json:"tokenId"`
+	Region                      configVarString   `json:"region"`
+	InstanceReadyCheckPeriod    configVarString   `json:"instanceReadyCheckPeriod"`
+	InstanceReadyCheckTimeout   configVarString   `json:"instanceReadyCheckTimeout"`
+	Image                       configVarString   `json:"image"`
+	Flavor                      configVarString   `json:"flavor"`
+	SecurityGroups              []configVarString `json:"securityGroups"`
+	Network                     configVarString   `json:"network"`
+	Subnet                      configVarString   `json:"subnet"`
+	FloatingIPPool              configVarString   `json:"floatingIpPool"`
+	AvailabilityZone            configVarString   `json:"availabilityZone"`
+	TrustDevicePath             configVarBool     `json:"trustDevicePath"`
+	RootDiskSizeGB              *int              `json:"rootDiskSizeGB"`
+	RootDiskVolumeType          configVarString   `json:"rootDiskVolumeType"`
+	NodeVolumeAttachLimit       *uint             `json:"nodeVolumeAttachLimit"`
+	Tags                        map[string]string `json:"tags"`
+}
+
+type packetProviderSpec struct {
+	APIKey       configVarString   `json:"apiKey"`
+	ProjectID    configVarString   `json:"projectID"`
+	BillingCycle configVarString   `json:"billingCycle"`
+	InstanceType configVarString   `json:"instanceType"`
+	Facilities   []configVarString `json:"facilities"`
+	Tags         []configVarString `json:"tags"`
+}
+
+type vsphereProviderSpec struct {
+	TemplateVMName   configVarString `json:"templateVMName"`
+	VMNetName        configVarString `json:"vmNetName"`
+	Username         configVarString `json:"username"`
+	Password         configVarString `json:"password"`
+	VSphereURL       configVarString `json:"vsphereURL"`
+	Datacenter       configVarString `json:"datacenter"`
+	Cluster          configVarString `json:"cluster"`
+	Folder           configVarString `json:"folder"`
+	ResourcePool     configVarString `json:"resourcePool"`
+	DatastoreCluster configVarString `json:"datastoreCluster"`
+	Datastore        configVarString `json:"datastore"`
+	CPUs             int32           `json:"cpus"`
+	MemoryMB         int64           `json:"memoryMB"`
+	DiskSizeGB       *int64          `json:"diskSizeGB"`
+	AllowInsecure    configVarBool   `json:"allowInsecure"`
+}
+
+// ValidateDynamicWorkerCloudProviderSpec validates the machine-controller cloudProviderSpec
+// of the dynamic workers against the typed spec of the cloud provider.
+// Providers without the typed spec are not validated.
+func ValidateDynamicWorkerCloudProviderSpec(p kubeone.CloudProviderSpec, spec json.RawMessage, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(bytes.TrimSpace(spec)) == 0 || bytes.Equal(bytes.TrimSpace(spec), []byte("null")) {
+		return allErrs
+	}
+
+	var typed interface{}
+	switch {
+	case p.AWS != nil:
+		typed = &awsProviderSpec{}
+	case p.Azure != nil:
+		typed = &azureProviderSpec{}
+	case p.DigitalOcean != nil:
+		typed = &digitalOceanProviderSpec{}
+	case p.GCE != nil:
+		typed = &gceProviderSpec{}
+	case p.Hetzner != nil:
+		typed = &hetznerProviderSpec{}
+	case p.Openstack != nil:
+		typed = &openstackProviderSpec{}
+	case p.Packet != nil:
+		typed = &packetProviderSpec{}
+	case p.Vsphere != nil:
+		typed = &vsphereProviderSpec{}
+	default:
+		return allErrs
+	}
+
+	if err := strictUnmarshal(spec, typed); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, string(spec), fmt.Sprintf("invalid %s cloudProviderSpec: %v", p.CloudProviderName(), err)))
+	}
+
+	return allErrs
+}
+
+// strictUnmarshal decodes the JSON disallowing unknown fields
+func strictUnmarshal(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
@@ -18,16 +18,35 @@ package validation
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
 	"net"
+	"net/url"
+	"path"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 
 	"k8c.io/kubeone/pkg/apis/kubeone"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	"sigs.k8s.io/yaml"
 )
 
 // ValidateKubeOneCluster validates the KubeOneCluster object
@@ -38,25 +57,95 @@ func ValidateKubeOneCluster(c kubeone.KubeOneCluster) field.ErrorList {
 		allErrs = append(allErrs, field.Required(field.NewPath("name"), "cluster name `.name` is a required field."))
 	}
 	allErrs = append(allErrs, ValidateControlPlaneConfig(c.ControlPlane, field.NewPath("controlPlane"))...)
+	allErrs = append(allErrs, ValidateEtcdConfig(c.Etcd, field.NewPath("etcd"))...)
+	if c.Features.FIPS != nil && c.Features.FIPS.Enable && c.Etcd.TLS != nil && len(c.Etcd.TLS.CipherSuites) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("etcd", "tls", "cipherSuites"), "etcd cipher suites are configured by .features.fips"))
+	}
+	if c.ExternalEtcd != nil {
+		allErrs = append(allErrs, ValidateExternalEtcd(c, field.NewPath("externalEtcd"))...)
+	}
 	allErrs = append(allErrs, ValidateAPIEndpoint(c.APIEndpoint, field.NewPath("apiEndpoint"))...)
 	allErrs = append(allErrs, ValidateCloudProviderSpec(c.CloudProvider, field.NewPath("provider"))...)
 	allErrs = append(allErrs, ValidateVersionConfig(c.Versions, field.NewPath("versions"))...)
 	allErrs = append(allErrs, ValidateCloudProviderSupportsKubernetes(c, field.NewPath(""))...)
 	allErrs = append(allErrs, ValidateContainerRuntimeConfig(c.ContainerRuntime, c.Versions, field.NewPath("containerRuntime"))...)
 	allErrs = append(allErrs, ValidateClusterNetworkConfig(c.ClusterNetwork, field.NewPath("clusterNetwork"))...)
+	if c.ClusterNetwork.CiliumReplacesKubeProxy() {
+		kubeVer, _ := semver.NewVersion(c.Versions.Kubernetes)
+		gteKube122Condition, _ := semver.NewConstraint(">= 1.22")
+		if kubeVer != nil && !gteKube122Condition.Check(kubeVer) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("clusterNetwork", "cni", "cilium", "kubeProxyReplacement"), "replacing kube-proxy requires kubernetes 1.22+"))
+		}
+		if c.ClusterNetwork.KubeProxy != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("clusterNetwork", "kubeProxy"), "kube-proxy is not deployed when replaced by cilium"))
+		}
+	}
+	if c.ClusterNetwork.DualStack() {
+		kubeVer, _ := semver.NewVersion(c.Versions.Kubernetes)
+		gteKube121Condition, _ := semver.NewConstraint(">= 1.21")
+		if kubeVer != nil && !gteKube121Condition.Check(kubeVer) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("clusterNetwork", "ipFamilies"), "dual-stack cluster requires kubernetes 1.21+"))
+		}
+	}
 	allErrs = append(allErrs, ValidateStaticWorkersConfig(c.StaticWorkers, field.NewPath("staticWorkers"))...)
+	for i, h := range c.ControlPlane.Hosts {
+		allErrs = append(allErrs, ValidateHostNetworkOverrides(h.NetworkOverrides, true, c.Versions, field.NewPath("controlPlane", "hosts").Index(i).Child("networkOverrides"))...)
+		allErrs = append(allErrs, validateHostIPFamilies(h, c.ClusterNetwork, field.NewPath("controlPlane", "hosts").Index(i))...)
+	}
+	for i, h := range c.StaticWorkers.Hosts {
+		allErrs = append(allErrs, ValidateHostNetworkOverrides(h.NetworkOverrides, false, c.Versions, field.NewPath("staticWorkers", "hosts").Index(i).Child("networkOverrides"))...)
+		allErrs = append(allErrs, validateHostIPFamilies(h, c.ClusterNetwork, field.NewPath("staticWorkers", "hosts").Index(i))...)
+	}
 
 	if c.MachineController != nil && c.MachineController.Deploy {
 		allErrs = append(allErrs, ValidateDynamicWorkerConfig(c.DynamicWorkers, field.NewPath("dynamicWorkers"))...)
+		for i, w := range c.DynamicWorkers {
+			specPath := field.NewPath("dynamicWorkers").Index(i).Child("providerSpec", "cloudProviderSpec")
+			allErrs = append(allErrs, ValidateDynamicWorkerCloudProviderSpec(c.CloudProvider, w.Config.CloudProviderSpec, specPath)...)
+			switch {
+			case c.CloudProvider.KubeVirt != nil:
+				allErrs = append(allErrs, ValidateKubeVirtProviderSpec(w.Config.CloudProviderSpec, specPath)...)
+			case c.CloudProvider.Nutanix != nil:
+				allErrs = append(allErrs, ValidateNutanixProviderSpec(w.Config.CloudProviderSpec, specPath)...)
+			}
+		}
 	} else if len(c.DynamicWorkers) > 0 {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("dynamicWorkers"),
 			"machine-controller deployment is disabled, but the configuration still contains dynamic workers"))
 	}
+	if c.OperatingSystemManagerEnabled() && (c.MachineController == nil || !c.MachineController.Deploy) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("operatingSystemManager", "deploy"),
+			"operating-system-manager requires machine-controller to be deployed"))
+	}
 
+	allErrs = append(allErrs, ValidateControlPlaneComponents(c.ControlPlaneComponents, field.NewPath("controlPlaneComponents"))...)
+	allErrs = append(allErrs, ValidateKubeletConfig(c.KubeletConfig, field.NewPath("kubeletConfig"))...)
 	allErrs = append(allErrs, ValidateCABundle(c.CABundle, field.NewPath("caBundle"))...)
 	allErrs = append(allErrs, ValidateFeatures(c.Features, c.Versions, field.NewPath("features"))...)
+	if c.Features.SRIOV.Enabled() {
+		hosts := append(append([]kubeone.HostConfig{}, c.ControlPlane.Hosts...), c.StaticWorkers.Hosts...)
+		allErrs = append(allErrs, ValidateSRIOV(*c.Features.SRIOV, hosts, field.NewPath("features", "sriov"))...)
+	}
+	if c.Features.KubeVIP.Enabled() && net.ParseIP(c.APIEndpoint.Host) == nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("apiEndpoint", "host"), c.APIEndpoint.Host, "apiEndpoint.host must be an IP address announced by kube-vip when .features.kubeVIP is enabled"))
+	}
+	if ep := c.Features.EncryptionProviders; ep != nil && ep.Enable && ep.KMS != nil && ep.KMS.Plugin != nil &&
+		ep.KMS.Plugin.Azure != nil && c.CloudProvider.Azure == nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("features", "encryptionProviders", "kms", "plugin", "azure"), "azure kms plugin requires the azure cloud provider"))
+	}
 	allErrs = append(allErrs, ValidateAddons(c.Addons, field.NewPath("addons"))...)
 	allErrs = append(allErrs, ValidateRegistryConfiguration(c.RegistryConfiguration, field.NewPath("registryConfiguration"))...)
+	allErrs = append(allErrs, ValidateAssetCache(c, field.NewPath("assetConfiguration", "cache"))...)
+	allErrs = append(allErrs, ValidateStateBackend(c.StateBackend, field.NewPath("stateBackend"))...)
+	allErrs = append(allErrs, ValidateBackups(c.Backups, field.NewPath("backups"))...)
+	allErrs = append(allErrs, ValidateHooks(c.Hooks, field.NewPath("hooks"))...)
+	allErrs = append(allErrs, ValidateCommandRetryPolicy(c.CommandRetries, field.NewPath("commandRetries"))...)
+	for i, n := range c.Notifications {
+		allErrs = append(allErrs, ValidateNotification(n, field.NewPath("notifications").Index(i))...)
+	}
+	if c.MaxParallel < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("maxParallel"), c.MaxParallel, "maxParallel must be 0 (unlimited) or greater"))
+	}
 
 	return allErrs
 }
@@ -67,6 +156,11 @@ func ValidateControlPlaneConfig(c kubeone.ControlPlaneConfig, fldPath *field.Pat
 
 	if len(c.Hosts) > 0 {
 		allErrs = append(allErrs, ValidateHostConfig(c.Hosts, fldPath.Child("hosts"))...)
+		for i, h := range c.Hosts {
+			if h.Pool != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("hosts").Index(i).Child("pool"), "pools are allowed only for static workers"))
+			}
+		}
 	} else {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("hosts"), "",
 			".controlPlane.Hosts is a required field. There must be at least one control plane instance in the cluster."))
@@ -75,6 +169,149 @@ func ValidateControlPlaneConfig(c kubeone.ControlPlaneConfig, fldPath *field.Pat
 	return allErrs
 }
 
+const (
+	etcdMaxQuotaBackendBytes     = 8 * 1024 * 1024 * 1024
+	etcdDefaultHeartbeatInterval = 100 * time.Millisecond
+	etcdDefaultElectionTimeout   = time.Second
+	etcdMaxElectionTimeout       = 50 * time.Second
+)
+
+// ValidateEtcdConfig validates the EtcdConfig structure
+func ValidateEtcdConfig(c kubeone.EtcdConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.QuotaBackendBytes < 0 || c.QuotaBackendBytes > etcdMaxQuotaBackendBytes {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("quotaBackendBytes"), c.QuotaBackendBytes, "must be between 0 and 8GiB"))
+	}
+
+	quotaErrs := len(allErrs)
+	if c.HeartbeatInterval.Duration < 0 || c.HeartbeatInterval.Duration%time.Millisecond != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("heartbeatInterval"), c.HeartbeatInterval.String(), "must be a non-negative number of milliseconds"))
+	}
+	if c.ElectionTimeout.Duration < 0 || c.ElectionTimeout.Duration%time.Millisecond != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("electionTimeout"), c.ElectionTimeout.String(), "must be a non-negative number of milliseconds"))
+	}
+	if len(allErrs) > quotaErrs {
+		return allErrs
+	}
+
+	heartbeat := etcdDefaultHeartbeatInterval
+	if c.HeartbeatInterval.Duration > 0 {
+		heartbeat = c.HeartbeatInterval.Duration
+	}
+	election := etcdDefaultElectionTimeout
+	if c.ElectionTimeout.Duration > 0 {
+		election = c.ElectionTimeout.Duration
+	}
+
+	if election > etcdMaxElectionTimeout {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("electionTimeout"), election.String(), "must not be greater than 50s"))
+	}
+	if election < 5*heartbeat {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("electionTimeout"), election.String(),
+			fmt.Sprintf("must be at least five times the heartbeat interval of %s", heartbeat)))
+	}
+
+	if c.TLS != nil {
+		allErrs = append(allErrs, ValidateEtcdTLSConfig(*c.TLS, fldPath.Child("tls"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateEtcdTLSConfig validates the EtcdTLSConfig structure
+func ValidateEtcdTLSConfig(c kubeone.EtcdTLSConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	supported := map[string]bool{}
+	for _, suite := range tls.CipherSuites() {
+		supported[suite.Name] = true
+	}
+	for i, suite := range c.CipherSuites {
+		if !supported[suite] {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("cipherSuites").Index(i), suite, sets.StringKeySet(supported).List()))
+		}
+	}
+
+	for i, san := range c.ServerCertSANs {
+		if net.ParseIP(san) != nil {
+			continue
+		}
+		if len(utilvalidation.IsDNS1123Subdomain(san)) > 0 && len(utilvalidation.IsWildcardDNS1123Subdomain(san)) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serverCertSANs").Index(i), san, "must be an IP address or a DNS name"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateExternalEtcd validates the ExternalEtcdConfig structure and that
+// no settings of the etcd stacked on the control plane nodes are used along
+// with the external etcd
+func ValidateExternalEtcd(c kubeone.KubeOneCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	e := c.ExternalEtcd
+
+	if len(e.Endpoints) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("endpoints"), "at least one etcd endpoint is required"))
+	}
+
+	secure := false
+	for i, endpoint := range e.Endpoints {
+		parsed, err := url.Parse(endpoint)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoints").Index(i), endpoint, fmt.Sprintf("failed to parse url: %v", err)))
+
+			continue
+		}
+		if parsed.Scheme != "https" && parsed.Scheme != "http" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoints").Index(i), endpoint, "url scheme must be https or http"))
+		}
+		if parsed.Hostname() == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoints").Index(i), endpoint, "url host is required"))
+		}
+		if parsed.Scheme == "https" {
+			secure = true
+		}
+	}
+
+	files := []struct {
+		name string
+		path string
+	}{
+		{name: "caFile", path: e.CAFile},
+		{name: "certFile", path: e.CertFile},
+		{name: "keyFile", path: e.KeyFile},
+	}
+	for _, file := range files {
+		switch {
+		case file.path == "" && secure:
+			allErrs = append(allErrs, field.Required(fldPath.Child(file.name), "required for the https etcd endpoints"))
+		case file.path != "" && !path.IsAbs(file.path):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(file.name), file.path, "must be an absolute path on the control plane nodes"))
+		}
+	}
+	if (e.CertFile == "") != (e.KeyFile == "") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keyFile"), e.KeyFile, "certFile and keyFile must be set together"))
+	}
+
+	if c.Etcd.QuotaBackendBytes != 0 || c.Etcd.SnapshotCount != 0 || c.Etcd.HeartbeatInterval.Duration != 0 ||
+		c.Etcd.ElectionTimeout.Duration != 0 || c.Etcd.TLS != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("etcd"), "etcd settings apply only to the etcd stacked on the control plane nodes"))
+	}
+	for i, h := range c.ControlPlane.Hosts {
+		if h.NetworkOverrides.EtcdURLsOverridden() {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("controlPlane", "hosts").Index(i).Child("networkOverrides"),
+				"etcd urls can't be overridden when using the external etcd"))
+		}
+	}
+	if c.Backups != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("backups"), "the external etcd must be backed up outside of KubeOne"))
+	}
+
+	return allErrs
+}
+
 // ValidateAPIEndpoint validates the APIEndpoint structure
 func ValidateAPIEndpoint(a kubeone.APIEndpoint, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -127,12 +364,34 @@ func ValidateCloudProviderSpec(p kubeone.CloudProviderSpec, fldPath *field.Path)
 		}
 		providerFound = true
 	}
+	if p.KubeVirt != nil {
+		if providerFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("kubevirt"), "only one provider can be used at the same time"))
+		}
+		if !p.External {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("external"), p.External, ".cloudProvider.external must be enabled for kubevirt provider"))
+		}
+		if p.KubeVirt.InfraNamespace == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("kubevirt", "infraNamespace"), ".cloudProvider.kubevirt.infraNamespace is required"))
+		}
+		providerFound = true
+	}
+	if p.Nutanix != nil {
+		if providerFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("nutanix"), "only one provider can be used at the same time"))
+		}
+		if !p.External {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("external"), p.External, ".cloudProvider.external must be enabled for nutanix provider"))
+		}
+		providerFound = true
+	}
 	if p.Openstack != nil {
 		if providerFound {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("openstack"), "only one provider can be used at the same time"))
 		}
-		if len(p.CloudConfig) == 0 {
-			allErrs = append(allErrs, field.Required(fldPath.Child("cloudConfig"), ".cloudProvider.cloudConfig is required for openstack provider"))
+		// the external CCM and CSI cloud-config can be rendered from the credentials
+		if len(p.CloudConfig) == 0 && !p.External {
+			allErrs = append(allErrs, field.Required(fldPath.Child("cloudConfig"), ".cloudProvider.cloudConfig is required for openstack provider without external CCM"))
 		}
 		providerFound = true
 	}
@@ -140,6 +399,7 @@ func ValidateCloudProviderSpec(p kubeone.CloudProviderSpec, fldPath *field.Path)
 		if providerFound {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("packet"), "only one provider can be used at the same time"))
 		}
+		allErrs = append(allErrs, ValidatePacketSpec(*p.Packet, p.External, fldPath.Child("packet"))...)
 		providerFound = true
 	}
 	if p.Vsphere != nil {
@@ -151,6 +411,18 @@ func ValidateCloudProviderSpec(p kubeone.CloudProviderSpec, fldPath *field.Path)
 		}
 		providerFound = true
 	}
+	if p.VMwareCloudDirector != nil {
+		if providerFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("vmwareCloudDirector"), "only one provider can be used at the same time"))
+		}
+		if !p.External {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("external"), p.External, ".cloudProvider.external must be enabled for vmwareCloudDirector provider"))
+		}
+		if p.VMwareCloudDirector.VApp == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("vmwareCloudDirector", "vApp"), ".cloudProvider.vmwareCloudDirector.vApp is required"))
+		}
+		providerFound = true
+	}
 	if p.None != nil {
 		if providerFound {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("none"), "only one provider can be used at the same time"))
@@ -174,6 +446,28 @@ func ValidateCloudProviderSpec(p kubeone.CloudProviderSpec, fldPath *field.Path)
 	return allErrs
 }
 
+// ValidatePacketSpec validates the PacketSpec structure
+func ValidatePacketSpec(p kubeone.PacketSpec, external bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !external && (p.LoadBalancer != "" || p.BGP != nil || p.ElasticIP != nil) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "loadBalancer, bgp and elasticIP are configured by the CCM and require .cloudProvider.external to be enabled"))
+	}
+	if p.LoadBalancer != "" {
+		if u, err := url.Parse(p.LoadBalancer); err != nil || u.Scheme == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("loadBalancer"), p.LoadBalancer, "loadBalancer must be a URL, e.g. kube-vip:// or metallb:///metallb-system"))
+		}
+	}
+	if p.BGP != nil && (p.BGP.LocalASN < 0 || int64(p.BGP.LocalASN) > math.MaxUint32) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("bgp", "localASN"), p.BGP.LocalASN, "localASN must be a valid 32-bit ASN"))
+	}
+	if p.ElasticIP != nil && p.ElasticIP.Tag == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("elasticIP", "tag"), "tag of the Elastic IP is required"))
+	}
+
+	return allErrs
+}
+
 // ValidateVersionConfig validates the VersionConfig structure
 func ValidateVersionConfig(version kubeone.VersionConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -215,6 +509,7 @@ func ValidateContainerRuntimeConfig(cr kubeone.ContainerRuntimeConfig, versions
 	allCRs := []interface{}{
 		cr.Docker,
 		cr.Containerd,
+		cr.CRIO,
 	}
 
 	var found bool
@@ -235,6 +530,46 @@ func ValidateContainerRuntimeConfig(cr kubeone.ContainerRuntimeConfig, versions
 		}
 	}
 
+	if cr.Containerd != nil {
+		allErrs = append(allErrs, validateContainerdRegistries(cr.Containerd.Registries, fldPath.Child("containerd", "registries"))...)
+	}
+
+	return allErrs
+}
+
+// validateContainerdRegistries validates that registries are keyed by the
+// registry host and that mirrors are http(s) URLs
+func validateContainerdRegistries(registries map[string]kubeone.RegistryMirror, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for registry, mirror := range registries {
+		registryPath := fldPath.Key(registry)
+
+		if registry == "" || strings.Contains(registry, "/") {
+			allErrs = append(allErrs, field.Invalid(registryPath, registry, "registry must be a host, optionally with a port, without the scheme or the path"))
+		}
+
+		if len(mirror.Mirrors) == 0 {
+			allErrs = append(allErrs, field.Required(registryPath.Child("mirrors"), "at least one mirror must be specified"))
+		}
+
+		for i, m := range mirror.Mirrors {
+			parsed, err := url.Parse(m)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(registryPath.Child("mirrors").Index(i), m, fmt.Sprintf("failed to parse url: %v", err)))
+
+				continue
+			}
+			if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				allErrs = append(allErrs, field.Invalid(registryPath.Child("mirrors").Index(i), m, "mirror must be an http or https url"))
+			}
+		}
+
+		if mirror.Auth != nil && (mirror.Auth.Username == "" || mirror.Auth.Password == "") {
+			allErrs = append(allErrs, field.Required(registryPath.Child("auth"), "both username and password must be specified"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -252,191 +587,1519 @@ func ValidateClusterNetworkConfig(c kubeone.ClusterNetworkConfig, fldPath *field
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceSubnet"), c.ServiceSubnet, ".clusterNetwork.serviceSubnet must be a valid CIDR string"))
 		}
 	}
+	if len(c.AdditionalPodSubnets) > 0 {
+		allErrs = append(allErrs, validateAdditionalPodSubnets(c, fldPath.Child("additionalPodSubnets"))...)
+	}
+	if len(c.IPFamilies) > 0 || c.PodSubnetIPv6 != "" || c.ServiceSubnetIPv6 != "" {
+		allErrs = append(allErrs, validateIPFamilies(c, fldPath)...)
+	}
+	if len(c.NodePortRange) > 0 {
+		if _, _, err := kubeone.ParseNodePortRange(c.NodePortRange); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodePortRange"), c.NodePortRange, ".clusterNetwork.nodePortRange must be a valid port range, such as 30000-32767"))
+		}
+	}
 	if c.CNI != nil {
 		allErrs = append(allErrs, ValidateCNI(c.CNI, fldPath.Child("cni"))...)
 	}
 	if c.KubeProxy != nil {
 		allErrs = append(allErrs, ValidateKubeProxy(c.KubeProxy, fldPath.Child("kubeProxy"))...)
 	}
+	if c.CoreDNS != nil {
+		allErrs = append(allErrs, ValidateCoreDNSConfig(c.CoreDNS, fldPath.Child("coreDNS"))...)
+	}
 
 	return allErrs
 }
 
-func ValidateKubeProxy(kbPrxConf *kubeone.KubeProxyConfig, fldPath *field.Path) field.ErrorList {
-	var (
-		allErrs     field.ErrorList
-		configFound bool
-	)
-
-	if kbPrxConf.IPTables != nil {
-		configFound = true
-	}
+// validateIPFamilies validates the IP families of the cluster network, the
+// subnets of the families and whether the CNI supports them
+func validateIPFamilies(c kubeone.ClusterNetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 
-	if kbPrxConf.IPVS != nil {
-		if configFound {
-			allErrs = append(allErrs, field.Invalid(fldPath, "", "should have only 1, ether iptables or ipvs or none"))
+	seen := map[kubeone.IPFamily]bool{}
+	for i, family := range c.IPFamilies {
+		switch family {
+		case kubeone.IPFamilyIPv4, kubeone.IPFamilyIPv6:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("ipFamilies").Index(i), family, []string{
+				string(kubeone.IPFamilyIPv4),
+				string(kubeone.IPFamilyIPv6),
+			}))
+		}
+		if seen[family] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("ipFamilies").Index(i), family))
 		}
+		seen[family] = true
 	}
 
-	return allErrs
-}
+	if !c.HasIPFamily(kubeone.IPFamilyIPv6) {
+		if c.PodSubnetIPv6 != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("podSubnetIPv6"), "requires the IPv6 family in .clusterNetwork.ipFamilies"))
+		}
+		if c.ServiceSubnetIPv6 != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("serviceSubnetIPv6"), "requires the IPv6 family in .clusterNetwork.ipFamilies"))
+		}
 
-// ValidateCNI validates the CNI structure
-func ValidateCNI(c *kubeone.CNI, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
+		return allErrs
+	}
 
-	cniFound := false
-	if c.Canal != nil {
-		cniFound = true
-		if c.Canal.MTU == 0 {
-			allErrs = append(allErrs,
-				field.Invalid(fldPath.Child("canal").Child("mtu"), c.Canal.MTU, "invalid value"))
-		}
+	if _, podSubnet, err := net.ParseCIDR(c.PodSubnetIPv6); err != nil || podSubnet.IP.To4() != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("podSubnetIPv6"), c.PodSubnetIPv6, "must be a valid IPv6 CIDR string"))
 	}
-	if c.WeaveNet != nil {
-		if cniFound {
-			allErrs = append(allErrs, field.Forbidden(fldPath.Child("weaveNet"), "only one cni plugin can be used at the same time"))
-		}
-		cniFound = true
+	if _, serviceSubnet, err := net.ParseCIDR(c.ServiceSubnetIPv6); err != nil || serviceSubnet.IP.To4() != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceSubnetIPv6"), c.ServiceSubnetIPv6, "must be a valid IPv6 CIDR string"))
+	} else if ones, bits := serviceSubnet.Mask.Size(); bits-ones > 20 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceSubnetIPv6"), c.ServiceSubnetIPv6, "must not be larger than /108"))
 	}
-	if c.External != nil {
-		if cniFound {
-			allErrs = append(allErrs, field.Forbidden(fldPath.Child("external"), "only one cni plugin can be used at the same time"))
+
+	if c.HasIPFamily(kubeone.IPFamilyIPv4) {
+		if ip, _, err := net.ParseCIDR(c.PodSubnet); err == nil && ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("podSubnet"), c.PodSubnet, "must be an IPv4 CIDR string in the dual-stack cluster"))
 		}
-		cniFound = true
+		if ip, _, err := net.ParseCIDR(c.ServiceSubnet); err == nil && ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceSubnet"), c.ServiceSubnet, "must be an IPv4 CIDR string in the dual-stack cluster"))
+		}
+	} else if len(c.AdditionalPodSubnets) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalPodSubnets"), "additional pod subnets require the IPv4 family"))
 	}
 
-	if !cniFound {
-		allErrs = append(allErrs, field.Invalid(fldPath, "", "cni plugin must be specified"))
+	if c.CNI != nil {
+		switch {
+		case c.CNI.Canal != nil, c.CNI.WeaveNet != nil:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("cni"), "the IPv6 family is supported only by the cilium, calico and external CNI"))
+		case c.CNI.Calico != nil && c.CNI.Calico.Mode != kubeone.CalicoModeBGP:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("cni", "calico", "mode"), "calico routes the IPv6 family only in the bgp mode"))
+		}
 	}
 
 	return allErrs
 }
 
-// ValidateStaticWorkersConfig validates the StaticWorkersConfig structure
-func ValidateStaticWorkersConfig(staticWorkers kubeone.StaticWorkersConfig, fldPath *field.Path) field.ErrorList {
+// validateHostIPFamilies validates the host has the node IP of each IP
+// family of the cluster network
+func validateHostIPFamilies(h kubeone.HostConfig, network kubeone.ClusterNetworkConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if len(staticWorkers.Hosts) > 0 {
-		allErrs = append(allErrs, ValidateHostConfig(staticWorkers.Hosts, fldPath.Child("hosts"))...)
+	if len(network.IPFamilies) == 0 {
+		return allErrs
 	}
 
-	return allErrs
-}
-
-// ValidateDynamicWorkerConfig validates the DynamicWorkerConfig structure
-func ValidateDynamicWorkerConfig(workerset []kubeone.DynamicWorkerConfig, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
-
-	for _, w := range workerset {
-		if w.Name == "" {
-			allErrs = append(allErrs, field.Required(fldPath.Child("name"), ".dynamicWorkers.name is a required field"))
+	if network.HasIPFamily(kubeone.IPFamilyIPv4) {
+		if ip := net.ParseIP(h.NodeIP()); ip != nil && ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, h.NodeIP(), "the node IP of the IPv4 family must be an IPv4 address"))
 		}
-		if w.Replicas == nil || *w.Replicas < 0 {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), w.Replicas, ".dynamicWorkers.replicas must be specified and >= 0"))
+	}
+	if network.HasIPFamily(kubeone.IPFamilyIPv6) {
+		if ip := net.ParseIP(h.NodeIPv6()); ip == nil || ip.To4() != nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("ipv6Addresses"), "an IPv6 address is required by the IPv6 family"))
 		}
 	}
 
 	return allErrs
 }
 
-func ValidateCABundle(caBundle string, fldPath *field.Path) field.ErrorList {
+// validateAdditionalPodSubnets validates the subnets appended to the
+// PodSubnet don't overlap with the other subnets
+func validateAdditionalPodSubnets(c kubeone.ClusterNetworkConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	caPEM := bytes.TrimSpace([]byte(caBundle))
-	if len(caPEM) == 0 {
-		return allErrs
+	if c.CNI != nil && c.CNI.WeaveNet != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "additional pod subnets are not supported by WeaveNet"))
 	}
 
-	pool := x509.NewCertPool()
-	if ok := pool.AppendCertsFromPEM(caPEM); !ok {
-		allErrs = append(allErrs, field.Invalid(fldPath, "", "can't parse caBundle"))
+	_, podSubnet, err := net.ParseCIDR(c.PodSubnet)
+	if err != nil {
+		return allErrs
 	}
 
-	return allErrs
-}
-
-// ValidateFeatures validates the Features structure
-func ValidateFeatures(f kubeone.Features, versions kubeone.VersionConfig, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
-
-	if f.PodNodeSelector != nil && f.PodNodeSelector.Enable {
-		allErrs = append(allErrs, ValidatePodNodeSelectorConfig(f.PodNodeSelector.Config, fldPath.Child("podNodeSelector"))...)
-	}
-	if f.StaticAuditLog != nil && f.StaticAuditLog.Enable {
-		allErrs = append(allErrs, ValidateStaticAuditLogConfig(f.StaticAuditLog.Config, fldPath.Child("staticAuditLog"))...)
+	subnets := []*net.IPNet{podSubnet}
+	for i, subnet := range c.AdditionalPodSubnets {
+		_, ipnet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), subnet, "must be a valid CIDR string"))
+			continue
+		}
+		if (ipnet.IP.To4() == nil) != (podSubnet.IP.To4() == nil) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), subnet, "must be of the same IP family as .clusterNetwork.podSubnet"))
+			continue
+		}
+		for _, other := range subnets {
+			if other.Contains(ipnet.IP) || ipnet.Contains(other.IP) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), subnet, fmt.Sprintf("overlaps with the pod subnet %s", other)))
+			}
+		}
+		subnets = append(subnets, ipnet)
 	}
-	if f.OpenIDConnect != nil && f.OpenIDConnect.Enable {
-		allErrs = append(allErrs, ValidateOIDCConfig(f.OpenIDConnect.Config, fldPath.Child("openidConnect"))...)
+
+	_, clusterCIDR, err := net.ParseCIDR(c.ClusterCIDR())
+	if err != nil {
+		return allErrs
 	}
-	if f.PodPresets != nil && f.PodPresets.Enable {
-		kubeVer, _ := semver.NewVersion(versions.Kubernetes)
-		gteKube120Condition, _ := semver.NewConstraint(">= 1.20")
-		if gteKube120Condition.Check(kubeVer) {
-			allErrs = append(allErrs, field.Forbidden(fldPath.Child("podPresets"), "podPresets feature is removed in kubernetes 1.20+ and must be disabled"))
+	if _, serviceSubnet, err := net.ParseCIDR(c.ServiceSubnet); err == nil {
+		if clusterCIDR.Contains(serviceSubnet.IP) || serviceSubnet.Contains(clusterCIDR.IP) {
+			allErrs = append(allErrs, field.Invalid(fldPath, c.AdditionalPodSubnets,
+				fmt.Sprintf("the subnet %s covering the pod subnets overlaps with .clusterNetwork.serviceSubnet", clusterCIDR)))
 		}
 	}
 
 	return allErrs
 }
 
-// ValidatePodNodeSelectorConfig validates the PodNodeSelectorConfig structure
-func ValidatePodNodeSelectorConfig(n kubeone.PodNodeSelectorConfig, fldPath *field.Path) field.ErrorList {
-	allErrs := field.ErrorList{}
-
-	if len(n.ConfigFilePath) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("configFilePath"), ".podNodeSelector.config.configFilePath is a required field"))
-	}
-
-	return allErrs
+// coreDNSReservedPlugins are the plugins configured by the kubeadm Corefile,
+// or by the dedicated CoreDNSConfig fields. CoreDNS refuses to start if the
+// plugin is configured twice in the same server block.
+var coreDNSReservedPlugins = map[string]bool{
+	"errors":      true,
+	"health":      true,
+	"ready":       true,
+	"kubernetes":  true,
+	"prometheus":  true,
+	"forward":     true,
+	"cache":       true,
+	"loop":        true,
+	"reload":      true,
+	"loadbalance": true,
+	"hosts":       true,
+	"rewrite":     true,
 }
 
-// ValidateStaticAuditLogConfig validates the StaticAuditLogConfig structure
-func ValidateStaticAuditLogConfig(s kubeone.StaticAuditLogConfig, fldPath *field.Path) field.ErrorList {
+// ValidateCoreDNSConfig validates the CoreDNSConfig structure
+func ValidateCoreDNSConfig(c *kubeone.CoreDNSConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if len(s.PolicyFilePath) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("policyFilePath"), ".staticAuditLog.config.policyFilePath is a required field"))
-	}
-	if len(s.LogPath) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("logPath"), ".staticAuditLog.config.logPath is a required field"))
+	if c.Replicas != nil && *c.Replicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *c.Replicas, "replicas must be at least 1"))
 	}
-	if s.LogMaxAge <= 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxAge"), s.LogMaxAge, ".staticAuditLog.config.logMaxAge must be greater than 0"))
+
+	for i, server := range c.Forwarders {
+		if !isDNSServerAddress(server) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("forwarders").Index(i), server, "forwarder must be an IP address with the optional port"))
+		}
 	}
-	if s.LogMaxBackup <= 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxBackup"), s.LogMaxBackup, ".staticAuditLog.config.logMaxBackup must be greater than 0"))
+
+	for i, stub := range c.StubDomains {
+		stubPath := fldPath.Child("stubDomains").Index(i)
+		if errs := utilvalidation.IsDNS1123Subdomain(stub.Domain); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(stubPath.Child("domain"), stub.Domain, strings.Join(errs, ", ")))
+		}
+		if len(stub.Servers) == 0 {
+			allErrs = append(allErrs, field.Required(stubPath.Child("servers"), "at least one server is required"))
+		}
+		for j, server := range stub.Servers {
+			if !isDNSServerAddress(server) {
+				allErrs = append(allErrs, field.Invalid(stubPath.Child("servers").Index(j), server, "server must be an IP address with the optional port"))
+			}
+		}
 	}
-	if s.LogMaxSize <= 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxSize"), s.LogMaxSize, ".staticAuditLog.config.logMaxSize must be greater than 0"))
+
+	if strings.Count(c.CorefileSnippet, "{") != strings.Count(c.CorefileSnippet, "}") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("corefileSnippet"), c.CorefileSnippet, "corefileSnippet must have balanced braces"))
+	}
+
+	for i, host := range c.Hosts {
+		if net.ParseIP(host.IP) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hosts").Index(i).Child("ip"), host.IP, "must be a valid IP address"))
+		}
+		if len(host.Hostnames) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("hosts").Index(i).Child("hostnames"), "at least one hostname is required"))
+		}
+		for j, hostname := range host.Hostnames {
+			if errs := utilvalidation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("hosts").Index(i).Child("hostnames").Index(j), hostname, strings.Join(errs, ", ")))
+			}
+		}
+	}
+
+	for i, rule := range c.Rewrites {
+		rulePath := fldPath.Child("rewrites").Index(i)
+		switch rule.Field {
+		case "", "name":
+			switch rule.Match {
+			case "", "exact", "prefix", "suffix", "substring", "regex":
+			default:
+				allErrs = append(allErrs, field.NotSupported(rulePath.Child("match"), rule.Match, []string{"exact", "prefix", "suffix", "substring", "regex"}))
+			}
+		case "type", "class":
+			if rule.Match != "" {
+				allErrs = append(allErrs, field.Forbidden(rulePath.Child("match"), "match is supported only when rewriting the name field"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("field"), rule.Field, []string{"name", "type", "class"}))
+		}
+		if rule.From == "" || strings.ContainsAny(rule.From, " \t\n") {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("from"), rule.From, "must be a non-empty value without whitespaces"))
+		}
+		if rule.To == "" || strings.ContainsAny(rule.To, " \t\n") {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("to"), rule.To, "must be a non-empty value without whitespaces"))
+		}
+	}
+
+	for i, plugin := range c.Plugins {
+		pluginPath := fldPath.Child("plugins").Index(i)
+		if plugin.Name == "" || strings.ContainsAny(plugin.Name, " \t\n{}") {
+			allErrs = append(allErrs, field.Invalid(pluginPath.Child("name"), plugin.Name, "must be a valid plugin name"))
+		} else if coreDNSReservedPlugins[plugin.Name] {
+			allErrs = append(allErrs, field.Forbidden(pluginPath.Child("name"), fmt.Sprintf("plugin %q is already configured", plugin.Name)))
+		}
+		for j, arg := range plugin.Args {
+			if arg == "" || strings.ContainsAny(arg, " \t\n{}") {
+				allErrs = append(allErrs, field.Invalid(pluginPath.Child("args").Index(j), arg, "must be a non-empty value without whitespaces and braces"))
+			}
+		}
+		for j, option := range plugin.Options {
+			if strings.Contains(option, "\n") || strings.Count(option, "{") != strings.Count(option, "}") {
+				allErrs = append(allErrs, field.Invalid(pluginPath.Child("options").Index(j), option, "must be a single line with balanced braces"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// isDNSServerAddress reports whether the address is an IP address with the
+// optional port
+func isDNSServerAddress(address string) bool {
+	host := address
+	if h, _, err := net.SplitHostPort(address); err == nil {
+		host = h
+	}
+
+	return net.ParseIP(host) != nil
+}
+
+func ValidateKubeProxy(kbPrxConf *kubeone.KubeProxyConfig, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs     field.ErrorList
+		configFound bool
+	)
+
+	if kbPrxConf.IPTables != nil {
+		configFound = true
+	}
+
+	if kbPrxConf.IPVS != nil {
+		if configFound {
+			allErrs = append(allErrs, field.Invalid(fldPath, "", "should have only 1, ether iptables or ipvs or none"))
+		}
+	}
+
+	if kbPrxConf.MetricsBindAddress != "" {
+		host, port, err := net.SplitHostPort(kbPrxConf.MetricsBindAddress)
+		portNum, portErr := strconv.Atoi(port)
+		if err != nil || portErr != nil || net.ParseIP(host) == nil || len(utilvalidation.IsValidPortNum(portNum)) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("metricsBindAddress"), kbPrxConf.MetricsBindAddress, "must be an IP address with a port, such as 0.0.0.0:10249"))
+		}
+	}
+
+	if kbPrxConf.Conntrack != nil {
+		if kbPrxConf.Conntrack.MaxPerCore != nil && *kbPrxConf.Conntrack.MaxPerCore < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("conntrack", "maxPerCore"), *kbPrxConf.Conntrack.MaxPerCore, "must not be negative"))
+		}
+		if kbPrxConf.Conntrack.Min != nil && *kbPrxConf.Conntrack.Min < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("conntrack", "min"), *kbPrxConf.Conntrack.Min, "must not be negative"))
+		}
+	}
+
+	if kbPrxConf.SyncPeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("syncPeriod"), kbPrxConf.SyncPeriod.String(), "must not be negative"))
+	}
+
+	return allErrs
+}
+
+// ValidateCNI validates the CNI structure
+func ValidateCNI(c *kubeone.CNI, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	cniFound := false
+	if c.Canal != nil {
+		cniFound = true
+		if c.Canal.MTU == 0 {
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("canal").Child("mtu"), c.Canal.MTU, "invalid value"))
+		}
+	}
+	if c.WeaveNet != nil {
+		if cniFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("weaveNet"), "only one cni plugin can be used at the same time"))
+		}
+		cniFound = true
+	}
+	if c.Cilium != nil {
+		if cniFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("cilium"), "only one cni plugin can be used at the same time"))
+		}
+		cniFound = true
+		allErrs = append(allErrs, ValidateCilium(c.Cilium, fldPath.Child("cilium"))...)
+	}
+	if c.Calico != nil {
+		if cniFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("calico"), "only one cni plugin can be used at the same time"))
+		}
+		cniFound = true
+		allErrs = append(allErrs, ValidateCalico(c.Calico, fldPath.Child("calico"))...)
+	}
+	if c.External != nil {
+		if cniFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("external"), "only one cni plugin can be used at the same time"))
+		}
+		cniFound = true
+	}
+
+	if !cniFound {
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "cni plugin must be specified"))
+	}
+
+	return allErrs
+}
+
+// ValidateCilium validates the CiliumSpec structure
+func ValidateCilium(c *kubeone.CiliumSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch c.KubeProxyReplacement {
+	case "",
+		kubeone.CiliumKubeProxyReplacementDisabled,
+		kubeone.CiliumKubeProxyReplacementProbe,
+		kubeone.CiliumKubeProxyReplacementPartial,
+		kubeone.CiliumKubeProxyReplacementStrict:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("kubeProxyReplacement"), c.KubeProxyReplacement, []string{
+			string(kubeone.CiliumKubeProxyReplacementDisabled),
+			string(kubeone.CiliumKubeProxyReplacementProbe),
+			string(kubeone.CiliumKubeProxyReplacementPartial),
+			string(kubeone.CiliumKubeProxyReplacementStrict),
+		}))
+	}
+
+	if c.Hubble == nil {
+		return allErrs
+	}
+
+	if c.Hubble.UI && !c.Hubble.Relay {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("hubble", "ui"), "hubble ui requires the hubble relay"))
+	}
+
+	switch c.Hubble.UIServiceType {
+	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("hubble", "uiServiceType"), c.Hubble.UIServiceType, []string{
+			string(corev1.ServiceTypeClusterIP),
+			string(corev1.ServiceTypeNodePort),
+			string(corev1.ServiceTypeLoadBalancer),
+		}))
+	}
+
+	return allErrs
+}
+
+// ValidateCalico validates the CalicoSpec structure
+func ValidateCalico(c *kubeone.CalicoSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	mode := c.Mode
+	switch mode {
+	case "":
+		mode = kubeone.CalicoModeVXLAN
+	case kubeone.CalicoModeVXLAN, kubeone.CalicoModeIPIP, kubeone.CalicoModeBGP:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), c.Mode, []string{
+			string(kubeone.CalicoModeVXLAN),
+			string(kubeone.CalicoModeIPIP),
+			string(kubeone.CalicoModeBGP),
+		}))
+	}
+
+	if c.CrossSubnet && mode == kubeone.CalicoModeBGP {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("crossSubnet"), "the traffic is not encapsulated in the bgp mode"))
+	}
+
+	if c.MTU < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("mtu"), c.MTU, "must not be negative"))
+	}
+
+	if c.BGP == nil {
+		return allErrs
+	}
+
+	if mode == kubeone.CalicoModeVXLAN {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("bgp"), "bgp is not used in the vxlan mode"))
+	}
+
+	names := sets.NewString()
+	for i, peer := range c.BGP.Peers {
+		peerPath := fldPath.Child("bgp", "peers").Index(i)
+
+		if errs := utilvalidation.IsDNS1123Subdomain(peer.Name); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(peerPath.Child("name"), peer.Name, strings.Join(errs, ", ")))
+		} else if names.Has(peer.Name) {
+			allErrs = append(allErrs, field.Duplicate(peerPath.Child("name"), peer.Name))
+		}
+		names.Insert(peer.Name)
+
+		if net.ParseIP(peer.PeerIP) == nil {
+			allErrs = append(allErrs, field.Invalid(peerPath.Child("peerIP"), peer.PeerIP, "must be a valid IP address"))
+		}
+		if peer.ASNumber == 0 {
+			allErrs = append(allErrs, field.Required(peerPath.Child("asNumber"), "peer AS number is required"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateStaticWorkersConfig validates the StaticWorkersConfig structure
+func ValidateStaticWorkersConfig(staticWorkers kubeone.StaticWorkersConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(staticWorkers.Hosts) > 0 {
+		allErrs = append(allErrs, ValidateHostConfig(staticWorkers.Hosts, fldPath.Child("hosts"))...)
+	}
+	allErrs = append(allErrs, ValidateStaticWorkerPools(staticWorkers.Pools, fldPath.Child("pools"))...)
+
+	for i, h := range staticWorkers.Hosts {
+		if h.Pool != "" && staticWorkers.Pool(h.Pool) == nil {
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("hosts").Index(i).Child("pool"), h.Pool))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateStaticWorkerPools validates the StaticWorkerPool structures
+func ValidateStaticWorkerPools(pools []kubeone.StaticWorkerPool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := map[string]bool{}
+	for i, pool := range pools {
+		poolPath := fldPath.Index(i)
+
+		switch {
+		case pool.Name == "":
+			allErrs = append(allErrs, field.Required(poolPath.Child("name"), "pool name is required"))
+		case names[pool.Name]:
+			allErrs = append(allErrs, field.Duplicate(poolPath.Child("name"), pool.Name))
+		default:
+			for _, msg := range utilvalidation.IsDNS1123Label(pool.Name) {
+				allErrs = append(allErrs, field.Invalid(poolPath.Child("name"), pool.Name, msg))
+			}
+		}
+		names[pool.Name] = true
+
+		for name := range pool.KubeletExtraArgs {
+			if name == "" || strings.HasPrefix(name, "-") {
+				allErrs = append(allErrs, field.Invalid(poolPath.Child("kubeletExtraArgs"), name, "flag name must be non-empty and without leading dashes"))
+			}
+		}
+		for name := range pool.FeatureGates {
+			if name == "" {
+				allErrs = append(allErrs, field.Invalid(poolPath.Child("featureGates"), name, "feature gate name must be non-empty"))
+			}
+		}
+		for j, taint := range pool.Taints {
+			for _, msg := range utilvalidation.IsQualifiedName(taint.Key) {
+				allErrs = append(allErrs, field.Invalid(poolPath.Child("taints").Index(j).Child("key"), taint.Key, msg))
+			}
+			switch taint.Effect {
+			case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+			default:
+				allErrs = append(allErrs, field.NotSupported(poolPath.Child("taints").Index(j).Child("effect"), taint.Effect,
+					[]string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)}))
+			}
+		}
+		allErrs = append(allErrs, metav1validation.ValidateLabels(pool.Labels, poolPath.Child("labels"))...)
+	}
+
+	return allErrs
+}
+
+// apiServerReservedFlags are the API server flags that must be configured
+// using the dedicated APIServerConfig fields
+var apiServerReservedFlags = map[string]string{
+	"admission-control":         "enableAdmissionPlugins",
+	"enable-admission-plugins":  "enableAdmissionPlugins",
+	"disable-admission-plugins": "disableAdmissionPlugins",
+	"feature-gates":             "featureGates",
+}
+
+// ValidateControlPlaneComponents validates the ControlPlaneComponents structure
+func ValidateControlPlaneComponents(c *kubeone.ControlPlaneComponents, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateAPIServerConfig(c.APIServer, fldPath.Child("apiServer"))...)
+	allErrs = append(allErrs, validateControlPlaneComponentConfig(c.ControllerManager, fldPath.Child("controllerManager"))...)
+	allErrs = append(allErrs, validateControlPlaneComponentConfig(c.Scheduler, fldPath.Child("scheduler"))...)
+
+	return allErrs
+}
+
+func validateAPIServerConfig(c *kubeone.APIServerConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c == nil {
+		return allErrs
+	}
+
+	for flag := range c.ExtraArgs {
+		switch {
+		case flag == "" || strings.HasPrefix(flag, "-"):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("extraArgs"), flag, "flag must be given without the leading dashes"))
+		case apiServerReservedFlags[flag] != "":
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("extraArgs").Key(flag),
+				fmt.Sprintf("flag must be configured using .controlPlaneComponents.apiServer.%s", apiServerReservedFlags[flag])))
+		}
+	}
+
+	disabled := map[string]bool{}
+	for i, plugin := range c.DisableAdmissionPlugins {
+		if plugin == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("disableAdmissionPlugins").Index(i), "admission plugin name is required"))
+		}
+		disabled[plugin] = true
+	}
+	for i, plugin := range c.EnableAdmissionPlugins {
+		switch {
+		case plugin == "":
+			allErrs = append(allErrs, field.Required(fldPath.Child("enableAdmissionPlugins").Index(i), "admission plugin name is required"))
+		case disabled[plugin]:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("enableAdmissionPlugins").Index(i), plugin, "admission plugin can't be both enabled and disabled"))
+		}
+	}
+
+	allErrs = append(allErrs, validateFeatureGateNames(c.FeatureGates, fldPath.Child("featureGates"))...)
+
+	return allErrs
+}
+
+// validateControlPlaneComponentConfig validates the controller manager and
+// the scheduler configuration
+func validateControlPlaneComponentConfig(c *kubeone.ControlPlaneComponentConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c == nil {
+		return allErrs
+	}
+
+	for flag := range c.ExtraArgs {
+		switch {
+		case flag == "" || strings.HasPrefix(flag, "-"):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("extraArgs"), flag, "flag must be given without the leading dashes"))
+		case flag == "feature-gates":
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("extraArgs").Key(flag), "flag must be configured using the featureGates field"))
+		}
+	}
+
+	allErrs = append(allErrs, validateFeatureGateNames(c.FeatureGates, fldPath.Child("featureGates"))...)
+
+	names := map[string]bool{}
+	for i, vol := range c.ExtraVolumes {
+		volPath := fldPath.Child("extraVolumes").Index(i)
+		switch {
+		case vol.Name == "":
+			allErrs = append(allErrs, field.Required(volPath.Child("name"), "volume name is required"))
+		case names[vol.Name]:
+			allErrs = append(allErrs, field.Duplicate(volPath.Child("name"), vol.Name))
+		}
+		names[vol.Name] = true
+
+		if !path.IsAbs(vol.HostPath) {
+			allErrs = append(allErrs, field.Invalid(volPath.Child("hostPath"), vol.HostPath, "host path must be an absolute path"))
+		}
+		if !path.IsAbs(vol.MountPath) {
+			allErrs = append(allErrs, field.Invalid(volPath.Child("mountPath"), vol.MountPath, "mount path must be an absolute path"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateFeatureGateNames(featureGates map[string]bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for gate := range featureGates {
+		if gate == "" || strings.ContainsAny(gate, "=,") {
+			allErrs = append(allErrs, field.Invalid(fldPath, gate, "invalid feature gate name"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateDynamicWorkerConfig validates the DynamicWorkerConfig structure
+func ValidateDynamicWorkerConfig(workerset []kubeone.DynamicWorkerConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, w := range workerset {
+		if w.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), ".dynamicWorkers.name is a required field"))
+		}
+		if w.Replicas == nil || *w.Replicas < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), w.Replicas, ".dynamicWorkers.replicas must be specified and >= 0"))
+		}
+		if w.Autoscaling != nil {
+			allErrs = append(allErrs, validateDynamicWorkerAutoscaling(*w.Autoscaling, w.Replicas, fldPath.Child("autoscaling"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateDynamicWorkerAutoscaling(a kubeone.DynamicWorkerAutoscaling, replicas *int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if a.MinReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), a.MinReplicas, "minReplicas must be greater than zero"))
+	}
+	if a.MaxReplicas < a.MinReplicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), a.MaxReplicas, "maxReplicas must be greater than or equal to minReplicas"))
+	}
+	if replicas != nil && (*replicas < a.MinReplicas || *replicas > a.MaxReplicas) {
+		allErrs = append(allErrs, field.Invalid(fldPath, *replicas, "replicas must be between minReplicas and maxReplicas"))
+	}
+
+	return allErrs
+}
+
+// ValidateKubeVirtProviderSpec validates the machine-controller
+// cloudProviderSpec of the KubeVirt dynamic workers
+func ValidateKubeVirtProviderSpec(spec json.RawMessage, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var kubevirtSpec struct {
+		VirtualMachine struct {
+			Template struct {
+				CPUs        string `json:"cpus"`
+				Memory      string `json:"memory"`
+				PrimaryDisk struct {
+					OsImage          string `json:"osImage"`
+					Size             string `json:"size"`
+					StorageClassName string `json:"storageClassName"`
+				} `json:"primaryDisk"`
+			} `json:"template"`
+		} `json:"virtualMachine"`
+	}
+	if err := json.Unmarshal(spec, &kubevirtSpec); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, string(spec), fmt.Sprintf("failed to parse kubevirt cloudProviderSpec: %v", err)))
+
+		return allErrs
+	}
+
+	template := kubevirtSpec.VirtualMachine.Template
+	templatePath := fldPath.Child("virtualMachine", "template")
+
+	quantities := []struct {
+		path  *field.Path
+		value string
+	}{
+		{path: templatePath.Child("cpus"), value: template.CPUs},
+		{path: templatePath.Child("memory"), value: template.Memory},
+		{path: templatePath.Child("primaryDisk", "size"), value: template.PrimaryDisk.Size},
+	}
+	for _, q := range quantities {
+		if q.value == "" {
+			allErrs = append(allErrs, field.Required(q.path, "kubevirt VM template quantity is required"))
+
+			continue
+		}
+		if _, err := resource.ParseQuantity(q.value); err != nil {
+			allErrs = append(allErrs, field.Invalid(q.path, q.value, fmt.Sprintf("invalid quantity: %v", err)))
+		}
+	}
+
+	if template.PrimaryDisk.OsImage == "" {
+		allErrs = append(allErrs, field.Required(templatePath.Child("primaryDisk", "osImage"), "kubevirt primary disk OS image is required"))
+	}
+	if template.PrimaryDisk.StorageClassName == "" {
+		allErrs = append(allErrs, field.Required(templatePath.Child("primaryDisk", "storageClassName"), "kubevirt primary disk storage class name is required"))
+	}
+
+	return allErrs
+}
+
+// ValidateNutanixProviderSpec validates the machine-controller cloudProviderSpec
+// of the Nutanix dynamic workers
+func ValidateNutanixProviderSpec(spec json.RawMessage, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var nutanixSpec struct {
+		ClusterName string `json:"clusterName"`
+		SubnetName  string `json:"subnetName"`
+		ImageName   string `json:"imageName"`
+		CPUs        int    `json:"cpus"`
+		MemoryMB    int    `json:"memoryMB"`
+	}
+	if err := json.Unmarshal(spec, &nutanixSpec); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, string(spec), fmt.Sprintf("failed to parse nutanix cloudProviderSpec: %v", err)))
+
+		return allErrs
+	}
+
+	if nutanixSpec.ClusterName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clusterName"), "nutanix cluster name is required"))
+	}
+	if nutanixSpec.SubnetName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("subnetName"), "nutanix subnet name is required"))
+	}
+	if nutanixSpec.ImageName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("imageName"), "nutanix image name is required"))
+	}
+	if nutanixSpec.CPUs <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpus"), nutanixSpec.CPUs, "cpus must be greater than 0"))
+	}
+	if nutanixSpec.MemoryMB <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMB"), nutanixSpec.MemoryMB, "memoryMB must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+func ValidateCABundle(caBundle string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	caPEM := bytes.TrimSpace([]byte(caBundle))
+	if len(caPEM) == 0 {
+		return allErrs
+	}
+
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(caPEM); !ok {
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "can't parse caBundle"))
+	}
+
+	return allErrs
+}
+
+// ValidateFeatures validates the Features structure
+func ValidateFeatures(f kubeone.Features, versions kubeone.VersionConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if f.PodNodeSelector != nil && f.PodNodeSelector.Enable {
+		allErrs = append(allErrs, ValidatePodNodeSelectorConfig(f.PodNodeSelector.Config, fldPath.Child("podNodeSelector"))...)
+	}
+	if f.PodSecurityAdmission != nil && f.PodSecurityAdmission.Enable {
+		kubeVer, _ := semver.NewVersion(versions.Kubernetes)
+		gteKube122Condition, _ := semver.NewConstraint(">= 1.22")
+		kube122Condition, _ := semver.NewConstraint("1.22.x")
+		switch {
+		case !gteKube122Condition.Check(kubeVer):
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("podSecurityAdmission"), "podSecurityAdmission feature requires kubernetes 1.22+"))
+		case kube122Condition.Check(kubeVer) && !f.FeatureGates["PodSecurity"]:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("podSecurityAdmission"), "podSecurityAdmission feature requires the PodSecurity feature gate on kubernetes 1.22"))
+		}
+		allErrs = append(allErrs, ValidatePodSecurityAdmissionConfig(f.PodSecurityAdmission.Config, fldPath.Child("podSecurityAdmission", "config"))...)
+	}
+	if f.StaticAuditLog != nil && f.StaticAuditLog.Enable {
+		allErrs = append(allErrs, ValidateStaticAuditLogConfig(f.StaticAuditLog.Config, fldPath.Child("staticAuditLog"))...)
+	}
+	if f.OpenIDConnect != nil && f.OpenIDConnect.Enable {
+		allErrs = append(allErrs, ValidateOIDCConfig(f.OpenIDConnect.Config, fldPath.Child("openidConnect"))...)
+	}
+	if f.PodPresets != nil && f.PodPresets.Enable {
+		kubeVer, _ := semver.NewVersion(versions.Kubernetes)
+		gteKube120Condition, _ := semver.NewConstraint(">= 1.20")
+		if gteKube120Condition.Check(kubeVer) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("podPresets"), "podPresets feature is removed in kubernetes 1.20+ and must be disabled"))
+		}
+	}
+	if f.AppArmor != nil && f.AppArmor.Enable {
+		allErrs = append(allErrs, ValidateAppArmor(*f.AppArmor, fldPath.Child("appArmor"))...)
+	}
+	if f.SeccompDefault != nil && f.SeccompDefault.Enable {
+		kubeVer, _ := semver.NewVersion(versions.Kubernetes)
+		gteKube122Condition, _ := semver.NewConstraint(">= 1.22")
+		if !gteKube122Condition.Check(kubeVer) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("seccompDefault"), "seccompDefault feature requires kubernetes 1.22+"))
+		}
+	}
+	if f.BootstrapRBAC != nil && f.BootstrapRBAC.Enable {
+		if f.OpenIDConnect == nil || !f.OpenIDConnect.Enable {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("bootstrapRBAC"), "bootstrapRBAC feature requires the openidConnect feature to be enabled"))
+		}
+		allErrs = append(allErrs, ValidateBootstrapRBAC(*f.BootstrapRBAC, fldPath.Child("bootstrapRBAC"))...)
+	}
+	if f.FIPS != nil && f.FIPS.Enable {
+		allErrs = append(allErrs, ValidateFIPS(f, fldPath.Child("fips"))...)
+	}
+	if f.DenyServiceExternalIPs != nil && f.DenyServiceExternalIPs.Enable {
+		kubeVer, _ := semver.NewVersion(versions.Kubernetes)
+		gteKube121Condition, _ := semver.NewConstraint(">= 1.21")
+		if !gteKube121Condition.Check(kubeVer) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("denyServiceExternalIPs"), "denyServiceExternalIPs feature requires kubernetes 1.21+"))
+		}
+	}
+	if f.EventRateLimit != nil && f.EventRateLimit.Enable {
+		allErrs = append(allErrs, ValidateEventRateLimitConfig(f.EventRateLimit.Config, fldPath.Child("eventRateLimit", "config"))...)
+	}
+	if f.EncryptionProviders != nil && f.EncryptionProviders.Enable && f.EncryptionProviders.KMS != nil {
+		allErrs = append(allErrs, ValidateEncryptionProvidersKMS(*f.EncryptionProviders.KMS, fldPath.Child("encryptionProviders", "kms"))...)
+	}
+	if f.NodeLocalDNS.Enabled() {
+		allErrs = append(allErrs, ValidateNodeLocalDNSConfig(f.NodeLocalDNS.Config, fldPath.Child("nodeLocalDNS", "config"))...)
+	}
+	if f.Konnectivity.Enabled() {
+		allErrs = append(allErrs, ValidateKonnectivityConfig(f.Konnectivity.Config, fldPath.Child("konnectivity", "config"))...)
+	}
+	if f.Gatekeeper.Enabled() {
+		allErrs = append(allErrs, ValidateGatekeeperConfig(f.Gatekeeper.Config, fldPath.Child("gatekeeper", "config"))...)
+	}
+	if len(f.FeatureGates) > 0 {
+		allErrs = append(allErrs, ValidateFeatureGates(f.FeatureGates, versions, fldPath.Child("featureGates"))...)
+	}
+
+	return allErrs
+}
+
+// removedFeatureGates are the feature gates mapped to the Kubernetes minor
+// version in which they are removed. Components refuse to start with an
+// unknown feature gate.
+var removedFeatureGates = map[string]uint64{
+	"SupportIPVSProxyMode":     20,
+	"BlockVolume":              21,
+	"CSIBlockVolume":           21,
+	"CSIDriverRegistry":        21,
+	"VolumePVCDataSource":      21,
+	"ServiceAppProtocol":       22,
+	"VolumeSnapshotDataSource": 22,
+}
+
+// ValidateFeatureGates validates the cluster-wide feature gates against the
+// Kubernetes version
+func ValidateFeatureGates(featureGates map[string]bool, versions kubeone.VersionConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := validateFeatureGateNames(featureGates, fldPath)
+
+	kubeVer, err := semver.NewVersion(versions.Kubernetes)
+	if err != nil {
+		// The version is validated by ValidateVersionConfig
+		return allErrs
+	}
+
+	for gate := range featureGates {
+		if removedIn, ok := removedFeatureGates[gate]; ok && kubeVer.Minor() >= removedIn {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Key(gate), fmt.Sprintf("feature gate is removed in kubernetes 1.%d", removedIn)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateEncryptionProvidersKMS validates the EncryptionProvidersKMS structure
+func ValidateEncryptionProvidersKMS(k kubeone.EncryptionProvidersKMS, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(k.Name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ".encryptionProviders.kms.name is a required field"))
+	}
+	if !path.IsAbs(k.Endpoint) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("endpoint"), k.Endpoint, "endpoint must be an absolute path to the unix socket"))
+	}
+	if k.CacheSize < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cacheSize"), k.CacheSize, "cacheSize can't be negative"))
+	}
+	if k.Timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), k.Timeout.Duration.String(), "timeout can't be negative"))
+	}
+	if k.Plugin != nil {
+		allErrs = append(allErrs, ValidateKMSPlugin(*k.Plugin, fldPath.Child("plugin"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateKMSPlugin validates the KMSPlugin structure
+func ValidateKMSPlugin(p kubeone.KMSPlugin, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	providerFound := false
+	if p.AWS != nil {
+		providerFound = true
+		if len(p.AWS.KeyARN) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("aws", "keyARN"), ".kms.plugin.aws.keyARN is a required field"))
+		}
+		if len(p.AWS.Region) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("aws", "region"), ".kms.plugin.aws.region is a required field"))
+		}
+	}
+	if p.Azure != nil {
+		if providerFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("azure"), "only one kms plugin provider can be used at the same time"))
+		}
+		providerFound = true
+		if len(p.Azure.KeyVaultName) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("azure", "keyVaultName"), ".kms.plugin.azure.keyVaultName is a required field"))
+		}
+		if len(p.Azure.KeyName) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("azure", "keyName"), ".kms.plugin.azure.keyName is a required field"))
+		}
+		if len(p.Azure.KeyVersion) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("azure", "keyVersion"), ".kms.plugin.azure.keyVersion is a required field"))
+		}
+	}
+	if p.GCP != nil {
+		if providerFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("gcp"), "only one kms plugin provider can be used at the same time"))
+		}
+		providerFound = true
+		if len(p.GCP.KeyURI) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("gcp", "keyURI"), ".kms.plugin.gcp.keyURI is a required field"))
+		}
+	}
+	if !providerFound {
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "provider must be specified"))
+	}
+
+	return allErrs
+}
+
+// ValidateFIPS validates that enabled features are compliant with the FIPS mode
+func ValidateFIPS(f kubeone.Features, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if f.EncryptionProviders != nil && f.EncryptionProviders.Enable && f.EncryptionProviders.CustomEncryptionConfiguration != "" {
+		configPath := field.NewPath("features", "encryptionProviders", "customEncryptionConfiguration")
+		config := &apiserverconfigv1.EncryptionConfiguration{}
+		if err := yaml.UnmarshalStrict([]byte(f.EncryptionProviders.CustomEncryptionConfiguration), config); err != nil {
+			allErrs = append(allErrs, field.Invalid(configPath, "", fmt.Sprintf("failed to parse the encryption configuration: %v", err)))
+		} else {
+			allErrs = append(allErrs, ValidateFIPSEncryptionConfiguration(config, configPath)...)
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateFIPSEncryptionConfiguration validates that the encryption
+// configuration uses only FIPS-approved encryption providers
+func ValidateFIPSEncryptionConfiguration(config *apiserverconfigv1.EncryptionConfiguration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, resource := range config.Resources {
+		for j, provider := range resource.Providers {
+			// secretbox (XSalsa20-Poly1305) is not a FIPS-approved algorithm
+			if provider.Secretbox != nil {
+				providerPath := fldPath.Child("resources").Index(i).Child("providers").Index(j).Child("secretbox")
+				allErrs = append(allErrs, field.Forbidden(providerPath, "secretbox encryption provider is not FIPS-compliant and can't be used when .features.fips is enabled"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateAppArmor validates the AppArmor structure
+func ValidateAppArmor(a kubeone.AppArmor, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := map[string]bool{}
+	for i, p := range a.Profiles {
+		profilePath := fldPath.Child("profiles").Index(i)
+		if len(p.Name) == 0 {
+			allErrs = append(allErrs, field.Required(profilePath.Child("name"), ".appArmor.profiles.name is a required field"))
+		} else if strings.Contains(p.Name, "/") || p.Name == "." || p.Name == ".." {
+			allErrs = append(allErrs, field.Invalid(profilePath.Child("name"), p.Name, ".appArmor.profiles.name must be a valid file name"))
+		} else if names[p.Name] {
+			allErrs = append(allErrs, field.Duplicate(profilePath.Child("name"), p.Name))
+		}
+		names[p.Name] = true
+
+		if len(p.ProfileFilePath) == 0 {
+			allErrs = append(allErrs, field.Required(profilePath.Child("profileFilePath"), ".appArmor.profiles.profileFilePath is a required field"))
+		}
+	}
+
+	return allErrs
+}
+
+var (
+	sriovInterfaceRegex    = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
+	sriovResourceNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+)
+
+// ValidateSRIOV validates the SRIOV structure against the cluster hosts
+func ValidateSRIOV(sriov kubeone.SRIOV, hosts []kubeone.HostConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, param := range sriov.KernelParameters {
+		if len(param) == 0 || strings.ContainsAny(param, " \t\"'$`") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kernelParameters").Index(i), param, "kernel parameter must be a single word"))
+		}
+	}
+
+	seenHosts := map[string]bool{}
+	for i, h := range sriov.Hosts {
+		hostPath := fldPath.Child("hosts").Index(i)
+
+		matched := -1
+		for j, host := range hosts {
+			if h.Matches(host) {
+				matched = j
+				break
+			}
+		}
+		switch {
+		case len(h.Address) == 0:
+			allErrs = append(allErrs, field.Required(hostPath.Child("address"), ".sriov.hosts.address is a required field"))
+		case matched < 0:
+			allErrs = append(allErrs, field.NotFound(hostPath.Child("address"), h.Address))
+		case seenHosts[hosts[matched].PublicAddress]:
+			allErrs = append(allErrs, field.Duplicate(hostPath.Child("address"), h.Address))
+		default:
+			seenHosts[hosts[matched].PublicAddress] = true
+		}
+
+		if len(h.PhysicalFunctions) == 0 {
+			allErrs = append(allErrs, field.Required(hostPath.Child("physicalFunctions"), ".sriov.hosts.physicalFunctions is a required field"))
+		}
+
+		interfaces := map[string]bool{}
+		for j, pf := range h.PhysicalFunctions {
+			pfPath := hostPath.Child("physicalFunctions").Index(j)
+			switch {
+			case len(pf.Interface) == 0:
+				allErrs = append(allErrs, field.Required(pfPath.Child("interface"), ".sriov.hosts.physicalFunctions.interface is a required field"))
+			case !sriovInterfaceRegex.MatchString(pf.Interface):
+				allErrs = append(allErrs, field.Invalid(pfPath.Child("interface"), pf.Interface, "interface must be a valid network interface name"))
+			case interfaces[pf.Interface]:
+				allErrs = append(allErrs, field.Duplicate(pfPath.Child("interface"), pf.Interface))
+			}
+			interfaces[pf.Interface] = true
+
+			if pf.NumVFs < 1 {
+				allErrs = append(allErrs, field.Invalid(pfPath.Child("numVFs"), pf.NumVFs, "numVFs must be a positive number"))
+			}
+			if !sriovResourceNameRegex.MatchString(pf.ResourceName) {
+				allErrs = append(allErrs, field.Invalid(pfPath.Child("resourceName"), pf.ResourceName, "resourceName can contain only alphanumeric characters and underscores"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateBootstrapRBAC validates the BootstrapRBAC structure
+func ValidateBootstrapRBAC(b kubeone.BootstrapRBAC, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, nsAdmins := range b.NamespaceAdmins {
+		nsPath := fldPath.Child("namespaceAdmins").Index(i)
+		if len(nsAdmins.Namespace) == 0 {
+			allErrs = append(allErrs, field.Required(nsPath.Child("namespace"), ".bootstrapRBAC.namespaceAdmins.namespace is a required field"))
+		}
+		if len(nsAdmins.Groups) == 0 {
+			allErrs = append(allErrs, field.Required(nsPath.Child("groups"), ".bootstrapRBAC.namespaceAdmins.groups is a required field"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidatePodNodeSelectorConfig validates the PodNodeSelectorConfig structure
+func ValidatePodNodeSelectorConfig(n kubeone.PodNodeSelectorConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(n.ConfigFilePath) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("configFilePath"), ".podNodeSelector.config.configFilePath is a required field"))
+	}
+
+	return allErrs
+}
+
+// ValidateEventRateLimitConfig validates the EventRateLimitConfig structure
+func ValidateEventRateLimitConfig(c kubeone.EventRateLimitConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(c.Limits) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("limits"), "at least one limit is required"))
+	}
+
+	types := map[kubeone.EventRateLimitType]bool{}
+	for i, limit := range c.Limits {
+		limitPath := fldPath.Child("limits").Index(i)
+
+		switch limit.Type {
+		case kubeone.EventRateLimitTypeServer, kubeone.EventRateLimitTypeNamespace, kubeone.EventRateLimitTypeUser, kubeone.EventRateLimitTypeSourceAndObject:
+			if types[limit.Type] {
+				allErrs = append(allErrs, field.Duplicate(limitPath.Child("type"), limit.Type))
+			}
+			types[limit.Type] = true
+		default:
+			allErrs = append(allErrs, field.NotSupported(limitPath.Child("type"), limit.Type, []string{
+				string(kubeone.EventRateLimitTypeServer),
+				string(kubeone.EventRateLimitTypeNamespace),
+				string(kubeone.EventRateLimitTypeUser),
+				string(kubeone.EventRateLimitTypeSourceAndObject),
+			}))
+		}
+
+		if limit.QPS <= 0 {
+			allErrs = append(allErrs, field.Invalid(limitPath.Child("qps"), limit.QPS, "qps must be greater than 0"))
+		}
+		if limit.Burst <= 0 {
+			allErrs = append(allErrs, field.Invalid(limitPath.Child("burst"), limit.Burst, "burst must be greater than 0"))
+		}
+		if limit.CacheSize < 0 {
+			allErrs = append(allErrs, field.Invalid(limitPath.Child("cacheSize"), limit.CacheSize, "cacheSize must not be negative"))
+		}
+		if limit.CacheSize != 0 && limit.Type == kubeone.EventRateLimitTypeServer {
+			allErrs = append(allErrs, field.Forbidden(limitPath.Child("cacheSize"), "cacheSize is not used for the Server limit type"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateStaticAuditLogConfig validates the StaticAuditLogConfig structure
+func ValidateStaticAuditLogConfig(s kubeone.StaticAuditLogConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(s.PolicyFilePath) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("policyFilePath"), ".staticAuditLog.config.policyFilePath is a required field"))
+	}
+	if s.DisableLogBackend {
+		if s.Webhook == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("webhook"), ".staticAuditLog.config.webhook is required when the log backend is disabled"))
+		}
+	} else {
+		if len(s.LogPath) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("logPath"), ".staticAuditLog.config.logPath is a required field"))
+		}
+		if s.LogMaxAge <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxAge"), s.LogMaxAge, ".staticAuditLog.config.logMaxAge must be greater than 0"))
+		}
+		if s.LogMaxBackup <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxBackup"), s.LogMaxBackup, ".staticAuditLog.config.logMaxBackup must be greater than 0"))
+		}
+		if s.LogMaxSize <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("logMaxSize"), s.LogMaxSize, ".staticAuditLog.config.logMaxSize must be greater than 0"))
+		}
+	}
+
+	if s.Webhook != nil {
+		allErrs = append(allErrs, ValidateAuditWebhookConfig(*s.Webhook, fldPath.Child("webhook"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateAuditWebhookConfig validates the AuditWebhookConfig structure
+func ValidateAuditWebhookConfig(w kubeone.AuditWebhookConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(w.ConfigFilePath) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("configFilePath"), ".staticAuditLog.config.webhook.configFilePath is a required field"))
+	}
+
+	switch w.Mode {
+	case "", "batch":
+	case "blocking", "blocking-strict":
+		if w.BatchBufferSize != 0 || w.BatchMaxSize != 0 || w.BatchMaxWait.Duration != 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "batch options are allowed only in the batch mode"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), w.Mode, []string{"batch", "blocking", "blocking-strict"}))
+	}
+
+	if w.InitialBackoff.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialBackoff"), w.InitialBackoff.Duration.String(), "initialBackoff can't be negative"))
+	}
+	if w.BatchBufferSize < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("batchBufferSize"), w.BatchBufferSize, "batchBufferSize can't be negative"))
+	}
+	if w.BatchMaxSize < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("batchMaxSize"), w.BatchMaxSize, "batchMaxSize can't be negative"))
+	}
+	if w.BatchMaxWait.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("batchMaxWait"), w.BatchMaxWait.Duration.String(), "batchMaxWait can't be negative"))
+	}
+
+	return allErrs
+}
+
+// podSecurityLevels are the Pod Security Standards levels
+var podSecurityLevels = []string{"privileged", "baseline", "restricted"}
+
+// podSecurityVersionRegex matches the Pod Security Standards versions other
+// than latest
+var podSecurityVersionRegex = regexp.MustCompile(`^v1\.[0-9]+$`)
+
+// ValidatePodSecurityAdmissionConfig validates the PodSecurityAdmissionConfig structure
+func ValidatePodSecurityAdmissionConfig(c kubeone.PodSecurityAdmissionConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	levels := []struct {
+		name, level, versionName, version string
+	}{
+		{"enforce", c.Enforce, "enforceVersion", c.EnforceVersion},
+		{"audit", c.Audit, "auditVersion", c.AuditVersion},
+		{"warn", c.Warn, "warnVersion", c.WarnVersion},
+	}
+	for _, l := range levels {
+		if !sets.NewString(podSecurityLevels...).Has(l.level) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child(l.name), l.level, podSecurityLevels))
+		}
+		if l.version != "latest" && !podSecurityVersionRegex.MatchString(l.version) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(l.versionName), l.version, "version must be latest or in the v1.X format"))
+		}
+	}
+
+	exemptions := []struct {
+		name   string
+		values []string
+	}{
+		{"usernames", c.Exemptions.Usernames},
+		{"runtimeClasses", c.Exemptions.RuntimeClasses},
+		{"namespaces", c.Exemptions.Namespaces},
+	}
+	for _, e := range exemptions {
+		for i, value := range e.values {
+			if len(value) == 0 {
+				allErrs = append(allErrs, field.Required(fldPath.Child("exemptions", e.name).Index(i), "exemption can't be empty"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateNodeLocalDNSConfig validates the NodeLocalDNSConfig structure
+func ValidateNodeLocalDNSConfig(c kubeone.NodeLocalDNSConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.VirtualIP != "" {
+		if ip := net.ParseIP(c.VirtualIP); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("virtualIP"), c.VirtualIP, "virtualIP must be an IPv4 address"))
+		}
+	}
+	if c.VirtualIPv6 != "" {
+		if ip := net.ParseIP(c.VirtualIPv6); ip == nil || ip.To4() != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("virtualIPv6"), c.VirtualIPv6, "virtualIPv6 must be an IPv6 address"))
+		}
+	}
+	for i, server := range c.UpstreamServers {
+		if !isDNSServerAddress(server) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("upstreamServers").Index(i), server, "upstream server must be an IP address with the optional port"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateKonnectivityConfig validates the KonnectivityConfig structure
+func ValidateKonnectivityConfig(c kubeone.KonnectivityConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.ServerAddress == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("serverAddress"), ".konnectivity.config.serverAddress is a required field"))
+	}
+	if c.AgentPort <= 0 || c.AgentPort > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("agentPort"), c.AgentPort, "agentPort must be between 1 and 65535"))
+	}
+
+	return allErrs
+}
+
+// ValidateGatekeeperConfig validates the GatekeeperConfig structure
+func ValidateGatekeeperConfig(c kubeone.GatekeeperConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.AuditInterval < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("auditInterval"), c.AuditInterval, "auditInterval can't be negative"))
+	}
+
+	return allErrs
+}
+
+// ValidateOIDCConfig validates the OpenIDConnectConfig structure
+func ValidateOIDCConfig(o kubeone.OpenIDConnectConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(o.IssuerURL) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("issuerURL"), ".openidConnect.config.issuerURL is a required field"))
+	}
+	if len(o.ClientID) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clientID"), ".openidConnect.config.clientID is a required field"))
+	}
+
+	return allErrs
+}
+
+// ValidateAddons validates the Addons configuration
+func ValidateAddons(o *kubeone.Addons, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if o == nil || !o.Enable {
+		return allErrs
+	}
+	if o.Enable && len(o.Path) == 0 && len(o.Sources) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), "", ".addons.path or .addons.sources must be specified"))
+	}
+
+	for i, source := range o.Sources {
+		allErrs = append(allErrs, ValidateAddonSource(source, fldPath.Child("sources").Index(i))...)
+	}
+
+	for i, addon := range o.Addons {
+		addonPath := fldPath.Child("addons").Index(i)
+		for j, dep := range addon.DependsOn {
+			switch dep {
+			case "":
+				allErrs = append(allErrs, field.Required(addonPath.Child("dependsOn").Index(j), "name of the addon dependency must be specified"))
+			case addon.Name:
+				allErrs = append(allErrs, field.Invalid(addonPath.Child("dependsOn").Index(j), dep, "addon can't depend on itself"))
+			}
+		}
+		if addon.Delete && (len(addon.DependsOn) > 0 || addon.WaitReady) {
+			allErrs = append(allErrs, field.Forbidden(addonPath, "dependsOn and waitReady can't be used with deleted addons"))
+		}
+
+		if addon.Helm == nil {
+			continue
+		}
+
+		if addon.Name == "" {
+			allErrs = append(allErrs, field.Required(addonPath.Child("name"), "name of the helm addon must be specified"))
+		}
+		if len(addon.Params) > 0 {
+			allErrs = append(allErrs, field.Forbidden(addonPath.Child("params"), "params can't be used with helm addons, use .helm.values instead"))
+		}
+		allErrs = append(allErrs, ValidateHelmChart(addon.Helm, addonPath.Child("helm"))...)
 	}
 
 	return allErrs
 }
 
-// ValidateOIDCConfig validates the OpenIDConnectConfig structure
-func ValidateOIDCConfig(o kubeone.OpenIDConnectConfig, fldPath *field.Path) field.ErrorList {
+var (
+	// gitCommitRegex matches the full SHA-1 and SHA-256 commit hashes
+	gitCommitRegex = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+	// gitSCPRegex matches the scp-like ssh repositories, such as
+	// git@github.com:example/addons.git
+	gitSCPRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[A-Za-z0-9._~][A-Za-z0-9._~/-]*$`)
+)
+
+// ValidateAddonSource validates the AddonSource structure
+func ValidateAddonSource(src kubeone.AddonSource, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if len(o.IssuerURL) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("issuerURL"), ".openidConnect.config.issuerURL is a required field"))
+	var sourcePath string
+	var sourcePathField *field.Path
+	sources := 0
+
+	if src.Git != nil {
+		sources++
+		if src.Git.Repository == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("git", "repository"), "repository must be specified"))
+		} else if !validGitRepository(src.Git.Repository) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("git", "repository"), src.Git.Repository, "repository must be a https:// or ssh:// URL, or a user@host:path ssh location"))
+		}
+		if src.Git.Commit == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("git", "commit"), "commit must be specified"))
+		} else if !gitCommitRegex.MatchString(src.Git.Commit) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("git", "commit"), src.Git.Commit, "commit must be the full SHA of the commit"))
+		}
+		sourcePath, sourcePathField = src.Git.Path, fldPath.Child("git", "path")
 	}
-	if len(o.ClientID) == 0 {
-		allErrs = append(allErrs, field.Required(fldPath.Child("clientID"), ".openidConnect.config.clientID is a required field"))
+	if src.HTTP != nil {
+		sources++
+		if u, err := url.Parse(src.HTTP.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("http", "url"), src.HTTP.URL, "url must be a valid https URL"))
+		}
+		allErrs = append(allErrs, validateSourceChecksum(src.HTTP.SHA256, fldPath.Child("http", "sha256"))...)
+		sourcePath, sourcePathField = src.HTTP.Path, fldPath.Child("http", "path")
+	}
+	if src.S3 != nil {
+		sources++
+		if src.S3.Bucket == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("s3", "bucket"), "bucket must be specified"))
+		}
+		if src.S3.Key == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("s3", "key"), "key must be specified"))
+		}
+		allErrs = append(allErrs, validateSourceChecksum(src.S3.SHA256, fldPath.Child("s3", "sha256"))...)
+		sourcePath, sourcePathField = src.S3.Path, fldPath.Child("s3", "path")
+	}
+
+	switch {
+	case sources == 0:
+		allErrs = append(allErrs, field.Required(fldPath, "one of git, http or s3 must be specified"))
+	case sources > 1:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of git, http or s3 can be specified"))
+	case sourcePath != "" && (path.IsAbs(sourcePath) || !fs.ValidPath(path.Clean(sourcePath)) || hasParentElement(sourcePath)):
+		allErrs = append(allErrs, field.Invalid(sourcePathField, sourcePath, "path must be relative and can't point outside of the source"))
 	}
 
 	return allErrs
 }
 
-// ValidateAddons validates the Addons configuration
-func ValidateAddons(o *kubeone.Addons, fldPath *field.Path) field.ErrorList {
+// validGitRepository returns whether the repository is fetched over https or
+// ssh. Other git transports, such as ext:: and file://, and the values git
+// could take for an option are rejected.
+func validGitRepository(repository string) bool {
+	if gitSCPRegex.MatchString(repository) {
+		return true
+	}
+
+	u, err := url.Parse(repository)
+	if err != nil || u.Host == "" || strings.HasPrefix(u.Host, "-") {
+		return false
+	}
+
+	return u.Scheme == "https" || u.Scheme == "ssh"
+}
+
+// hasParentElement returns whether any element of the slash separated path
+// is "..", even if the cleaned path stays within the source
+func hasParentElement(p string) bool {
+	for _, elem := range strings.Split(p, "/") {
+		if elem == ".." {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateSourceChecksum(checksum string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if o == nil || !o.Enable {
-		return allErrs
+	if checksum == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "sha256 must be specified"))
+	} else if _, err := hex.DecodeString(checksum); err != nil || len(checksum) != sha256.Size*2 {
+		allErrs = append(allErrs, field.Invalid(fldPath, checksum, "sha256 must be a hex encoded SHA256 checksum"))
+	}
+
+	return allErrs
+}
+
+// ValidateHelmChart validates the HelmChart structure
+func ValidateHelmChart(h *kubeone.HelmChart, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if h.Chart == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("chart"), "chart must be specified"))
 	}
-	if o.Enable && len(o.Path) == 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), "", ".addons.path must be specified"))
+	if strings.HasPrefix(h.Chart, "oci://") && h.Version == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("version"), "version must be specified for the OCI chart reference"))
+	}
+	if h.RepoURL != "" {
+		if strings.HasPrefix(h.Chart, "oci://") {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("repoURL"), "repoURL can't be used with the OCI chart reference"))
+		} else if u, err := url.Parse(h.RepoURL); err != nil || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("repoURL"), h.RepoURL, "must be a valid URL"))
+		}
+	}
+	if h.Namespace != "" {
+		for _, msg := range utilvalidation.IsDNS1123Label(h.Namespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), h.Namespace, msg))
+		}
+	}
+	if h.Values != "" {
+		values := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(h.Values), &values); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("values"), h.Values, fmt.Sprintf("must be a valid YAML map: %v", err)))
+		}
 	}
 
 	return allErrs
@@ -467,6 +2130,167 @@ func ValidateHostConfig(hosts []kubeone.HostConfig, fldPath *field.Path) field.E
 		if len(h.SSHUsername) == 0 {
 			allErrs = append(allErrs, field.Required(fldPath, "no SSH username given"))
 		}
+		if len(h.Bastion) > 0 && len(h.Bastions) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("bastions"), "bastion and bastions are mutually exclusive"))
+		}
+		for i, b := range h.Bastions {
+			if len(b.Host) == 0 {
+				allErrs = append(allErrs, field.Required(fldPath.Child("bastions").Index(i).Child("host"), "no bastion IP/address given"))
+			}
+			if b.Port < 0 || b.Port > 65535 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("bastions").Index(i).Child("port"), b.Port, "port must be between 1 and 65535"))
+			}
+		}
+		for name := range h.Env {
+			for _, msg := range utilvalidation.IsCIdentifier(name) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("env"), name, msg))
+			}
+		}
+		for i, addr := range h.IPv6Addresses {
+			if ip := net.ParseIP(addr); ip == nil || ip.To4() != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("ipv6Addresses").Index(i), addr, "must be a valid IPv6 address"))
+			}
+		}
+		allErrs = append(allErrs, ValidateKubeletConfig(h.KubeletConfig, fldPath.Child("kubeletConfig"))...)
+	}
+
+	return allErrs
+}
+
+// kubeletReservedResources are the resources that can be reserved for the
+// system and the Kubernetes daemons
+var kubeletReservedResources = sets.NewString("cpu", "memory", "ephemeral-storage", "pid")
+
+// kubeletEvictionSignals are the signals supported by the hard eviction
+var kubeletEvictionSignals = sets.NewString(
+	"memory.available",
+	"nodefs.available",
+	"nodefs.inodesFree",
+	"imagefs.available",
+	"imagefs.inodesFree",
+	"pid.available",
+)
+
+// ValidateKubeletConfig validates the KubeletConfig structure
+func ValidateKubeletConfig(k *kubeone.KubeletConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if k == nil {
+		return allErrs
+	}
+
+	if k.MaxPods != nil && *k.MaxPods <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPods"), *k.MaxPods, "maxPods must be greater than 0"))
+	}
+
+	switch k.CgroupDriver {
+	case "", "systemd", "cgroupfs":
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("cgroupDriver"), k.CgroupDriver, []string{"systemd", "cgroupfs"}))
+	}
+
+	allErrs = append(allErrs, validateKubeletReservedResources(k.SystemReserved, fldPath.Child("systemReserved"))...)
+	allErrs = append(allErrs, validateKubeletReservedResources(k.KubeReserved, fldPath.Child("kubeReserved"))...)
+
+	for signal, threshold := range k.EvictionHard {
+		if !kubeletEvictionSignals.Has(signal) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("evictionHard"), signal, kubeletEvictionSignals.List()))
+
+			continue
+		}
+		if percentage := strings.TrimSuffix(threshold, "%"); percentage != threshold {
+			if p, err := strconv.ParseFloat(percentage, 64); err != nil || p < 0 || p > 100 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("evictionHard").Key(signal), threshold, "must be a percentage between 0% and 100%"))
+			}
+
+			continue
+		}
+		if _, err := resource.ParseQuantity(threshold); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("evictionHard").Key(signal), threshold, "must be a quantity or a percentage"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateKubeletReservedResources(resources map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for name, quantity := range resources {
+		if !kubeletReservedResources.Has(name) {
+			allErrs = append(allErrs, field.NotSupported(fldPath, name, kubeletReservedResources.List()))
+
+			continue
+		}
+		if _, err := resource.ParseQuantity(quantity); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(name), quantity, "must be a valid quantity"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateHostNetworkOverrides validates the HostNetworkOverrides structure.
+// The apiserver and etcd overrides are allowed only for control plane hosts.
+func ValidateHostNetworkOverrides(o *kubeone.HostNetworkOverrides, controlPlane bool, versions kubeone.VersionConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if o == nil {
+		return allErrs
+	}
+
+	if o.KubeletNodeIP != "" && net.ParseIP(o.KubeletNodeIP) == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("kubeletNodeIP"), o.KubeletNodeIP, "must be a valid IP address"))
+	}
+
+	if !controlPlane {
+		if o.APIServerAdvertiseAddress != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("apiServerAdvertiseAddress"), "apiServerAdvertiseAddress is allowed only for control plane hosts"))
+		}
+		if o.EtcdURLsOverridden() {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "etcd URLs are allowed only for control plane hosts"))
+		}
+
+		return allErrs
+	}
+
+	if o.APIServerAdvertiseAddress != "" && net.ParseIP(o.APIServerAdvertiseAddress) == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("apiServerAdvertiseAddress"), o.APIServerAdvertiseAddress, "must be a valid IP address"))
+	}
+
+	if o.EtcdURLsOverridden() {
+		kubeVer, _ := semver.NewVersion(versions.Kubernetes)
+		gteKube122Condition, _ := semver.NewConstraint(">= 1.22")
+		if kubeVer != nil && !gteKube122Condition.Check(kubeVer) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "overriding etcd URLs requires kubernetes 1.22+"))
+		}
+	}
+
+	allErrs = append(allErrs, validateEtcdURLs(o.EtcdListenClientURLs, fldPath.Child("etcdListenClientURLs"))...)
+	allErrs = append(allErrs, validateEtcdURLs(o.EtcdAdvertiseClientURLs, fldPath.Child("etcdAdvertiseClientURLs"))...)
+	allErrs = append(allErrs, validateEtcdURLs(o.EtcdListenPeerURLs, fldPath.Child("etcdListenPeerURLs"))...)
+
+	return allErrs
+}
+
+// validateEtcdURLs validates that etcd URLs are https URLs with an IP address and a port,
+// as etcd deployed by kubeadm serves only TLS and can't listen on hostnames
+func validateEtcdURLs(urls []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, u := range urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), u, fmt.Sprintf("failed to parse url: %v", err)))
+
+			continue
+		}
+		if parsed.Scheme != "https" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), u, "url scheme must be https"))
+		}
+		if net.ParseIP(parsed.Hostname()) == nil || parsed.Port() == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), u, "url host must be an IP address with a port"))
+		}
 	}
 
 	return allErrs
@@ -486,6 +2310,266 @@ func ValidateRegistryConfiguration(r *kubeone.RegistryConfiguration, fldPath *fi
 	return allErrs
 }
 
+// ValidateStateBackend validates the StateBackend structure
+func ValidateStateBackend(b *kubeone.StateBackend, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if b == nil {
+		return allErrs
+	}
+
+	backendFound := false
+	if b.S3 != nil {
+		if b.S3.Bucket == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("s3", "bucket"), "bucket is required"))
+		}
+		backendFound = true
+	}
+	if b.GCS != nil {
+		if backendFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("gcs"), "only one state backend can be used at the same time"))
+		}
+		if b.GCS.Bucket == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("gcs", "bucket"), "bucket is required"))
+		}
+		backendFound = true
+	}
+	if b.AzureBlob != nil {
+		if backendFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("azureBlob"), "only one state backend can be used at the same time"))
+		}
+		if b.AzureBlob.StorageAccount == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("azureBlob", "storageAccount"), "storageAccount is required"))
+		}
+		if b.AzureBlob.Container == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("azureBlob", "container"), "container is required"))
+		}
+		backendFound = true
+	}
+	if b.Local != nil {
+		if backendFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("local"), "only one state backend can be used at the same time"))
+		}
+		if b.Local.Path == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("local", "path"), "path is required"))
+		}
+		backendFound = true
+	}
+	if b.KubernetesSecret != nil {
+		if backendFound {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("kubernetesSecret"), "only one state backend can be used at the same time"))
+		}
+		if b.KubernetesSecret.Namespace != "" {
+			for _, msg := range utilvalidation.IsDNS1123Label(b.KubernetesSecret.Namespace) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("kubernetesSecret", "namespace"), b.KubernetesSecret.Namespace, msg))
+			}
+		}
+		backendFound = true
+	}
+
+	if !backendFound {
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "no state backend specified"))
+	}
+
+	return allErrs
+}
+
+// ValidateBackups validates the Backups structure
+func ValidateBackups(b *kubeone.Backups, fldPath *field.Path) field.ErrorList {
+	if b == nil || b.Storage == nil {
+		return field.ErrorList{}
+	}
+
+	allErrs := ValidateStateBackend(b.Storage, fldPath.Child("storage"))
+	if b.Storage.KubernetesSecret != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("storage", "kubernetesSecret"), "etcd snapshots can't be stored in the cluster they back up"))
+	}
+
+	return allErrs
+}
+
+// ValidateHooks validates the Hooks structure
+func ValidateHooks(h *kubeone.Hooks, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if h == nil {
+		return allErrs
+	}
+
+	events := []struct {
+		name  string
+		hooks []kubeone.Hook
+	}{
+		{"preApply", h.PreApply},
+		{"postApply", h.PostApply},
+		{"preUpgrade", h.PreUpgrade},
+		{"postUpgrade", h.PostUpgrade},
+		{"preReset", h.PreReset},
+		{"postReset", h.PostReset},
+		{"preNodeUpgrade", h.PreNodeUpgrade},
+		{"postNodeUpgrade", h.PostNodeUpgrade},
+	}
+	for _, event := range events {
+		for i, hook := range event.hooks {
+			allErrs = append(allErrs, ValidateHook(hook, fldPath.Child(event.name).Index(i))...)
+		}
+	}
+
+	allErrs = append(allErrs, ValidateHostHooks(h.Host, fldPath.Child("host"))...)
+
+	return allErrs
+}
+
+// ValidateCommandRetryPolicy validates the CommandRetryPolicy structure
+func ValidateCommandRetryPolicy(p *kubeone.CommandRetryPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if p == nil {
+		return allErrs
+	}
+
+	if p.Attempts < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("attempts"), p.Attempts, "attempts must be greater than 0"))
+	}
+	if p.Backoff.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("backoff"), p.Backoff.String(), "backoff must not be negative"))
+	}
+	for i, expr := range p.RetryableErrors {
+		if _, err := regexp.Compile(expr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("retryableErrors").Index(i), expr, err.Error()))
+		}
+	}
+	for task, attempts := range p.TaskAttempts {
+		if attempts <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("taskAttempts").Key(task), attempts, "attempts must be greater than 0"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateNotification validates the Notification structure
+func ValidateNotification(n kubeone.Notification, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch n.Type {
+	case kubeone.NotificationTypeWebhook, kubeone.NotificationTypeSlack, kubeone.NotificationTypeTeams:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), n.Type,
+			[]string{string(kubeone.NotificationTypeWebhook), string(kubeone.NotificationTypeSlack), string(kubeone.NotificationTypeTeams)}))
+	}
+
+	switch {
+	case n.URL == "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("url"), "url is required"))
+	case strings.HasPrefix(n.URL, "env:"):
+		if strings.TrimPrefix(n.URL, "env:") == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), n.URL, "environment variable name is required"))
+		}
+	default:
+		u, err := url.Parse(n.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), n.URL, "must be the http or https URL"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateHostHooks validates the HostHooks structure
+func ValidateHostHooks(h *kubeone.HostHooks, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if h == nil {
+		return allErrs
+	}
+
+	points := []struct {
+		name    string
+		scripts []kubeone.HostScript
+	}{
+		{"preProvision", h.PreProvision},
+		{"postJoin", h.PostJoin},
+		{"preDrain", h.PreDrain},
+		{"postUpgrade", h.PostUpgrade},
+	}
+	for _, point := range points {
+		for i, script := range point.scripts {
+			allErrs = append(allErrs, ValidateHostScript(script, fldPath.Child(point.name).Index(i))...)
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateHostScript validates the HostScript structure
+func ValidateHostScript(s kubeone.HostScript, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch {
+	case s.Inline != "" && s.File != "":
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of inline and file can be set"))
+	case s.Inline == "" && s.File == "":
+		allErrs = append(allErrs, field.Required(fldPath, "inline or file is required"))
+	}
+
+	return allErrs
+}
+
+// ValidateHook validates the Hook structure
+func ValidateHook(h kubeone.Hook, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch {
+	case h.Command != "" && h.Webhook != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of command and webhook can be set"))
+	case h.Command == "" && h.Webhook == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "command or webhook is required"))
+	}
+
+	if h.Webhook != nil {
+		switch {
+		case strings.HasPrefix(h.Webhook.URL, "env:"):
+			if strings.TrimPrefix(h.Webhook.URL, "env:") == "" {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("webhook", "url"), h.Webhook.URL, "environment variable name is required"))
+			}
+		default:
+			u, err := url.Parse(h.Webhook.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("webhook", "url"), h.Webhook.URL, "must be the http or https URL"))
+			}
+		}
+	}
+
+	if h.Timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), h.Timeout.String(), "must not be negative"))
+	}
+
+	return allErrs
+}
+
+// ValidateAssetCache validates the asset cache configuration
+func ValidateAssetCache(c kubeone.KubeOneCluster, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	cache := c.AssetConfiguration.Cache
+	if cache == nil {
+		return allErrs
+	}
+
+	if cache.Host == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("host"), "asset cache host is required"))
+	} else if _, err := c.AssetCacheHost(); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("host"), cache.Host, err.Error()))
+	}
+
+	if cache.Port <= 0 || cache.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), cache.Port, "asset cache port must be between 1 and 65535"))
+	}
+
+	return allErrs
+}
+
 func ValidateAssetConfiguration(a *kubeone.AssetConfiguration, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -514,5 +2598,37 @@ func ValidateAssetConfiguration(a *kubeone.AssetConfiguration, fldPath *field.Pa
 		allErrs = append(allErrs, field.Invalid(fldPath, "", "all binary assets must be specified (cni, nodeBinaries, kubectl)"))
 	}
 
+	allErrs = append(allErrs, ValidateBinaryAsset(a.CNI, fldPath.Child("cni"))...)
+	allErrs = append(allErrs, ValidateBinaryAsset(a.NodeBinaries, fldPath.Child("nodeBinaries"))...)
+	allErrs = append(allErrs, ValidateBinaryAsset(a.Kubectl, fldPath.Child("kubectl"))...)
+
+	return allErrs
+}
+
+// ValidateBinaryAsset validates the BinaryAsset structure
+func ValidateBinaryAsset(a kubeone.BinaryAsset, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if a.URL == "" {
+		if len(a.Mirrors) > 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("url"), "url is required when mirrors are specified"))
+		}
+		if a.SHA256 != "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("url"), "url is required when sha256 is specified"))
+		}
+	}
+
+	if a.SHA256 != "" {
+		if _, err := hex.DecodeString(a.SHA256); err != nil || len(a.SHA256) != sha256.Size*2 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sha256"), a.SHA256, "sha256 must be a hex encoded SHA256 checksum"))
+		}
+	}
+
+	for i, mirror := range a.Mirrors {
+		if mirror == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("mirrors").Index(i), "mirror url can't be empty"))
+		}
+	}
+
 	return allErrs
 }
@@ -17,12 +17,16 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc/v2"
 
 	"k8c.io/kubeone/pkg/apis/kubeone"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -232,6 +236,274 @@ func TestValidateKubeOneCluster(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name: "kube-vip with the virtual IP",
+			cluster: kubeone.KubeOneCluster{
+				Name: "test",
+				ControlPlane: kubeone.ControlPlaneConfig{
+					Hosts: []kubeone.HostConfig{
+						{
+							PublicAddress:  "1.1.1.1",
+							PrivateAddress: "10.0.0.1",
+							SSHAgentSocket: "env:SSH_AUTH_SOCK",
+							SSHUsername:    "ubuntu",
+						},
+					},
+				},
+				APIEndpoint: kubeone.APIEndpoint{
+					Host: "10.0.0.100",
+					Port: 6443,
+				},
+				CloudProvider: kubeone.CloudProviderSpec{
+					None: &kubeone.NoneSpec{},
+				},
+				Versions: kubeone.VersionConfig{
+					Kubernetes: "1.22.1",
+				},
+				MachineController: &kubeone.MachineControllerConfig{},
+				Features: kubeone.Features{
+					KubeVIP: &kubeone.KubeVIP{
+						Enable:    true,
+						Interface: "eth0",
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "kube-vip with the hostname API endpoint",
+			cluster: kubeone.KubeOneCluster{
+				Name: "test",
+				ControlPlane: kubeone.ControlPlaneConfig{
+					Hosts: []kubeone.HostConfig{
+						{
+							PublicAddress:  "1.1.1.1",
+							PrivateAddress: "10.0.0.1",
+							SSHAgentSocket: "env:SSH_AUTH_SOCK",
+							SSHUsername:    "ubuntu",
+						},
+					},
+				},
+				APIEndpoint: kubeone.APIEndpoint{
+					Host: "api.example.com",
+					Port: 6443,
+				},
+				CloudProvider: kubeone.CloudProviderSpec{
+					None: &kubeone.NoneSpec{},
+				},
+				Versions: kubeone.VersionConfig{
+					Kubernetes: "1.22.1",
+				},
+				MachineController: &kubeone.MachineControllerConfig{},
+				Features: kubeone.Features{
+					KubeVIP: &kubeone.KubeVIP{
+						Enable:    true,
+						Interface: "eth0",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "external etcd",
+			cluster: kubeone.KubeOneCluster{
+				Name: "test",
+				ControlPlane: kubeone.ControlPlaneConfig{
+					Hosts: []kubeone.HostConfig{
+						{
+							PublicAddress:  "1.1.1.1",
+							PrivateAddress: "10.0.0.1",
+							SSHAgentSocket: "env:SSH_AUTH_SOCK",
+							SSHUsername:    "ubuntu",
+						},
+					},
+				},
+				ExternalEtcd: &kubeone.ExternalEtcdConfig{
+					Endpoints: []string{"https://10.0.0.10:2379", "https://10.0.0.11:2379"},
+					CAFile:    "/etc/etcd/pki/ca.crt",
+					CertFile:  "/etc/etcd/pki/apiserver-etcd-client.crt",
+					KeyFile:   "/etc/etcd/pki/apiserver-etcd-client.key",
+				},
+				APIEndpoint: kubeone.APIEndpoint{
+					Host: "10.0.0.100",
+					Port: 6443,
+				},
+				CloudProvider: kubeone.CloudProviderSpec{
+					None: &kubeone.NoneSpec{},
+				},
+				Versions: kubeone.VersionConfig{
+					Kubernetes: "1.22.1",
+				},
+				MachineController: &kubeone.MachineControllerConfig{},
+			},
+			expectedError: false,
+		},
+		{
+			name: "external etcd with https endpoints without certificates",
+			cluster: kubeone.KubeOneCluster{
+				Name: "test",
+				ControlPlane: kubeone.ControlPlaneConfig{
+					Hosts: []kubeone.HostConfig{
+						{
+							PublicAddress:  "1.1.1.1",
+							PrivateAddress: "10.0.0.1",
+							SSHAgentSocket: "env:SSH_AUTH_SOCK",
+							SSHUsername:    "ubuntu",
+						},
+					},
+				},
+				ExternalEtcd: &kubeone.ExternalEtcdConfig{
+					Endpoints: []string{"https://10.0.0.10:2379"},
+				},
+				APIEndpoint: kubeone.APIEndpoint{
+					Host: "10.0.0.100",
+					Port: 6443,
+				},
+				CloudProvider: kubeone.CloudProviderSpec{
+					None: &kubeone.NoneSpec{},
+				},
+				Versions: kubeone.VersionConfig{
+					Kubernetes: "1.22.1",
+				},
+				MachineController: &kubeone.MachineControllerConfig{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "external etcd with relative certificate paths",
+			cluster: kubeone.KubeOneCluster{
+				Name: "test",
+				ControlPlane: kubeone.ControlPlaneConfig{
+					Hosts: []kubeone.HostConfig{
+						{
+							PublicAddress:  "1.1.1.1",
+							PrivateAddress: "10.0.0.1",
+							SSHAgentSocket: "env:SSH_AUTH_SOCK",
+							SSHUsername:    "ubuntu",
+						},
+					},
+				},
+				ExternalEtcd: &kubeone.ExternalEtcdConfig{
+					Endpoints: []string{"https://10.0.0.10:2379", "https://10.0.0.11:2379"},
+					CAFile:    "pki/ca.crt",
+					CertFile:  "/etc/etcd/pki/apiserver-etcd-client.crt",
+					KeyFile:   "/etc/etcd/pki/apiserver-etcd-client.key",
+				},
+				APIEndpoint: kubeone.APIEndpoint{
+					Host: "10.0.0.100",
+					Port: 6443,
+				},
+				CloudProvider: kubeone.CloudProviderSpec{
+					None: &kubeone.NoneSpec{},
+				},
+				Versions: kubeone.VersionConfig{
+					Kubernetes: "1.22.1",
+				},
+				MachineController: &kubeone.MachineControllerConfig{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "external etcd with the stacked etcd settings",
+			cluster: kubeone.KubeOneCluster{
+				Name: "test",
+				ControlPlane: kubeone.ControlPlaneConfig{
+					Hosts: []kubeone.HostConfig{
+						{
+							PublicAddress:  "1.1.1.1",
+							PrivateAddress: "10.0.0.1",
+							SSHAgentSocket: "env:SSH_AUTH_SOCK",
+							SSHUsername:    "ubuntu",
+						},
+					},
+				},
+				Etcd: kubeone.EtcdConfig{
+					QuotaBackendBytes: 4294967296,
+				},
+				ExternalEtcd: &kubeone.ExternalEtcdConfig{
+					Endpoints: []string{"https://10.0.0.10:2379", "https://10.0.0.11:2379"},
+					CAFile:    "/etc/etcd/pki/ca.crt",
+					CertFile:  "/etc/etcd/pki/apiserver-etcd-client.crt",
+					KeyFile:   "/etc/etcd/pki/apiserver-etcd-client.key",
+				},
+				APIEndpoint: kubeone.APIEndpoint{
+					Host: "10.0.0.100",
+					Port: 6443,
+				},
+				CloudProvider: kubeone.CloudProviderSpec{
+					None: &kubeone.NoneSpec{},
+				},
+				Versions: kubeone.VersionConfig{
+					Kubernetes: "1.22.1",
+				},
+				MachineController: &kubeone.MachineControllerConfig{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "operating-system-manager with machine-controller",
+			cluster: kubeone.KubeOneCluster{
+				Name: "test",
+				ControlPlane: kubeone.ControlPlaneConfig{
+					Hosts: []kubeone.HostConfig{
+						{
+							PublicAddress:  "1.1.1.1",
+							PrivateAddress: "10.0.0.1",
+							SSHAgentSocket: "env:SSH_AUTH_SOCK",
+							SSHUsername:    "ubuntu",
+						},
+					},
+				},
+				APIEndpoint: kubeone.APIEndpoint{
+					Host: "localhost",
+					Port: 6443,
+				},
+				CloudProvider: kubeone.CloudProviderSpec{
+					AWS: &kubeone.AWSSpec{},
+				},
+				Versions: kubeone.VersionConfig{
+					Kubernetes: "1.22.1",
+				},
+				MachineController: &kubeone.MachineControllerConfig{
+					Deploy: true,
+				},
+				OperatingSystemManager: &kubeone.OSMConfig{
+					Deploy: true,
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "operating-system-manager without machine-controller",
+			cluster: kubeone.KubeOneCluster{
+				Name: "test",
+				ControlPlane: kubeone.ControlPlaneConfig{
+					Hosts: []kubeone.HostConfig{
+						{
+							PublicAddress:  "1.1.1.1",
+							PrivateAddress: "10.0.0.1",
+							SSHAgentSocket: "env:SSH_AUTH_SOCK",
+							SSHUsername:    "ubuntu",
+						},
+					},
+				},
+				APIEndpoint: kubeone.APIEndpoint{
+					Host: "localhost",
+					Port: 6443,
+				},
+				CloudProvider: kubeone.CloudProviderSpec{
+					AWS: &kubeone.AWSSpec{},
+				},
+				Versions: kubeone.VersionConfig{
+					Kubernetes: "1.22.1",
+				},
+				MachineController: &kubeone.MachineControllerConfig{},
+				OperatingSystemManager: &kubeone.OSMConfig{
+					Deploy: true,
+				},
+			},
+			expectedError: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -290,6 +562,21 @@ func TestValidateControlPlaneConfig(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name: "control plane host in static workers pool",
+			controlPlaneConfig: kubeone.ControlPlaneConfig{
+				Hosts: []kubeone.HostConfig{
+					{
+						PublicAddress:  "1.1.1.1",
+						PrivateAddress: "10.0.0.1",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+						Pool:           "gpu",
+					},
+				},
+			},
+			expectedError: true,
+		},
 		{
 			name: "no hosts provided",
 			controlPlaneConfig: kubeone.ControlPlaneConfig{
@@ -314,64 +601,167 @@ func TestValidateControlPlaneConfig(t *testing.T) {
 	}
 }
 
-func TestValidateAPIEndpoint(t *testing.T) {
+func TestValidateEtcdConfig(t *testing.T) {
 	tests := []struct {
 		name          string
-		apiEndpoint   kubeone.APIEndpoint
+		etcdConfig    kubeone.EtcdConfig
 		expectedError bool
 	}{
 		{
-			name: "valid apiEndpoint config (localhost:6443)",
-			apiEndpoint: kubeone.APIEndpoint{
-				Host: "localhost",
-				Port: 6443,
-			},
+			name:          "empty etcd config",
+			etcdConfig:    kubeone.EtcdConfig{},
 			expectedError: false,
 		},
 		{
-			name: "valid apiEndpoint config (example.com:443)",
-			apiEndpoint: kubeone.APIEndpoint{
-				Host: "example.com",
-				Port: 443,
+			name: "valid etcd config",
+			etcdConfig: kubeone.EtcdConfig{
+				QuotaBackendBytes: 8589934592,
+				SnapshotCount:     50000,
+				HeartbeatInterval: metav1.Duration{Duration: 250 * time.Millisecond},
+				ElectionTimeout:   metav1.Duration{Duration: 2500 * time.Millisecond},
 			},
 			expectedError: false,
 		},
 		{
-			name: "no host specified",
-			apiEndpoint: kubeone.APIEndpoint{
-				Port: 6443,
+			name: "quota greater than 8GiB",
+			etcdConfig: kubeone.EtcdConfig{
+				QuotaBackendBytes: 8589934593,
 			},
 			expectedError: true,
 		},
 		{
-			name: "no port specified",
-			apiEndpoint: kubeone.APIEndpoint{
-				Host: "localhost",
+			name: "negative quota",
+			etcdConfig: kubeone.EtcdConfig{
+				QuotaBackendBytes: -1,
 			},
 			expectedError: true,
 		},
 		{
-			name: "port lower than 0",
-			apiEndpoint: kubeone.APIEndpoint{
-				Host: "localhost",
-				Port: -1,
+			name: "heartbeat interval with sub-millisecond precision",
+			etcdConfig: kubeone.EtcdConfig{
+				HeartbeatInterval: metav1.Duration{Duration: 100500 * time.Microsecond},
 			},
 			expectedError: true,
 		},
 		{
-			name: "port greater than 65535",
-			apiEndpoint: kubeone.APIEndpoint{
-				Host: "localhost",
-				Port: 65536,
+			name: "election timeout lower than five heartbeat intervals",
+			etcdConfig: kubeone.EtcdConfig{
+				HeartbeatInterval: metav1.Duration{Duration: 500 * time.Millisecond},
 			},
 			expectedError: true,
 		},
-	}
-	for _, tc := range tests {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateAPIEndpoint(tc.apiEndpoint, nil)
-			if (len(errs) == 0) == tc.expectedError {
+		{
+			name: "election timeout lower than five default heartbeat intervals",
+			etcdConfig: kubeone.EtcdConfig{
+				ElectionTimeout: metav1.Duration{Duration: 400 * time.Millisecond},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid tls config",
+			etcdConfig: kubeone.EtcdConfig{
+				TLS: &kubeone.EtcdTLSConfig{
+					CipherSuites:   []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+					ServerCertSANs: []string{"etcd.example.com", "*.etcd.example.com", "10.0.0.10"},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "unknown cipher suite",
+			etcdConfig: kubeone.EtcdConfig{
+				TLS: &kubeone.EtcdTLSConfig{
+					CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid server cert SAN",
+			etcdConfig: kubeone.EtcdConfig{
+				TLS: &kubeone.EtcdTLSConfig{
+					ServerCertSANs: []string{"etcd_example.com"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "election timeout greater than 50s",
+			etcdConfig: kubeone.EtcdConfig{
+				ElectionTimeout: metav1.Duration{Duration: time.Minute},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateEtcdConfig(tc.etcdConfig, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateAPIEndpoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		apiEndpoint   kubeone.APIEndpoint
+		expectedError bool
+	}{
+		{
+			name: "valid apiEndpoint config (localhost:6443)",
+			apiEndpoint: kubeone.APIEndpoint{
+				Host: "localhost",
+				Port: 6443,
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid apiEndpoint config (example.com:443)",
+			apiEndpoint: kubeone.APIEndpoint{
+				Host: "example.com",
+				Port: 443,
+			},
+			expectedError: false,
+		},
+		{
+			name: "no host specified",
+			apiEndpoint: kubeone.APIEndpoint{
+				Port: 6443,
+			},
+			expectedError: true,
+		},
+		{
+			name: "no port specified",
+			apiEndpoint: kubeone.APIEndpoint{
+				Host: "localhost",
+			},
+			expectedError: true,
+		},
+		{
+			name: "port lower than 0",
+			apiEndpoint: kubeone.APIEndpoint{
+				Host: "localhost",
+				Port: -1,
+			},
+			expectedError: true,
+		},
+		{
+			name: "port greater than 65535",
+			apiEndpoint: kubeone.APIEndpoint{
+				Host: "localhost",
+				Port: 65536,
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateAPIEndpoint(tc.apiEndpoint, nil)
+			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
 		})
@@ -420,6 +810,44 @@ func TestValidateCloudProviderSpec(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "valid KubeVirt provider config",
+			providerConfig: kubeone.CloudProviderSpec{
+				KubeVirt: &kubeone.KubeVirtSpec{InfraNamespace: "tenant"},
+				External: true,
+			},
+			expectedError: false,
+		},
+		{
+			name: "KubeVirt provider config without infra namespace",
+			providerConfig: kubeone.CloudProviderSpec{
+				KubeVirt: &kubeone.KubeVirtSpec{},
+				External: true,
+			},
+			expectedError: true,
+		},
+		{
+			name: "KubeVirt provider config without external CCM",
+			providerConfig: kubeone.CloudProviderSpec{
+				KubeVirt: &kubeone.KubeVirtSpec{InfraNamespace: "tenant"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid Nutanix provider config",
+			providerConfig: kubeone.CloudProviderSpec{
+				Nutanix:  &kubeone.NutanixSpec{},
+				External: true,
+			},
+			expectedError: false,
+		},
+		{
+			name: "Nutanix provider config without external CCM",
+			providerConfig: kubeone.CloudProviderSpec{
+				Nutanix: &kubeone.NutanixSpec{},
+			},
+			expectedError: true,
+		},
 		{
 			name: "valid OpenStack provider config",
 			providerConfig: kubeone.CloudProviderSpec{
@@ -443,6 +871,33 @@ func TestValidateCloudProviderSpec(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "valid VMware Cloud Director provider config",
+			providerConfig: kubeone.CloudProviderSpec{
+				VMwareCloudDirector: &kubeone.VMwareCloudDirectorSpec{
+					VApp: "kubeone",
+				},
+				External: true,
+			},
+			expectedError: false,
+		},
+		{
+			name: "VMware Cloud Director provider config without vApp",
+			providerConfig: kubeone.CloudProviderSpec{
+				VMwareCloudDirector: &kubeone.VMwareCloudDirectorSpec{},
+				External:            true,
+			},
+			expectedError: true,
+		},
+		{
+			name: "VMware Cloud Director provider config without external CCM",
+			providerConfig: kubeone.CloudProviderSpec{
+				VMwareCloudDirector: &kubeone.VMwareCloudDirectorSpec{
+					VApp: "kubeone",
+				},
+			},
+			expectedError: true,
+		},
 		{
 			name: "valid None provider config",
 			providerConfig: kubeone.CloudProviderSpec{
@@ -554,6 +1009,14 @@ func TestValidateCloudProviderSpec(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name: "OpenStack provider config with external CCM and without cloudConfig",
+			providerConfig: kubeone.CloudProviderSpec{
+				Openstack: &kubeone.OpenstackSpec{},
+				External:  true,
+			},
+			expectedError: false,
+		},
 		{
 			name: "vSphere provider config without cloudConfig",
 			providerConfig: kubeone.CloudProviderSpec{
@@ -623,6 +1086,90 @@ func TestValidateCloudProviderSpec(t *testing.T) {
 	}
 }
 
+func TestValidatePacketSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          kubeone.PacketSpec
+		external      bool
+		expectedError bool
+	}{
+		{
+			name:          "empty spec",
+			spec:          kubeone.PacketSpec{},
+			expectedError: false,
+		},
+		{
+			name: "metadata node IP without external CCM",
+			spec: kubeone.PacketSpec{
+				NodeIPFromMetadata: true,
+			},
+			expectedError: false,
+		},
+		{
+			name: "CCM options",
+			spec: kubeone.PacketSpec{
+				Metro:        "am",
+				LoadBalancer: "metallb:///metallb-system?crdConfiguration=true",
+				BGP: &kubeone.PacketBGP{
+					Enable:   true,
+					LocalASN: 65000,
+				},
+				ElasticIP: &kubeone.PacketElasticIP{
+					Tag: "kubeone-cluster-eip",
+				},
+			},
+			external:      true,
+			expectedError: false,
+		},
+		{
+			name: "CCM options without external CCM",
+			spec: kubeone.PacketSpec{
+				BGP: &kubeone.PacketBGP{
+					Enable: true,
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid load balancer",
+			spec: kubeone.PacketSpec{
+				LoadBalancer: "metallb",
+			},
+			external:      true,
+			expectedError: true,
+		},
+		{
+			name: "invalid local ASN",
+			spec: kubeone.PacketSpec{
+				BGP: &kubeone.PacketBGP{
+					Enable:   true,
+					LocalASN: -1,
+				},
+			},
+			external:      true,
+			expectedError: true,
+		},
+		{
+			name: "elastic IP without tag",
+			spec: kubeone.PacketSpec{
+				ElasticIP: &kubeone.PacketElasticIP{},
+			},
+			external:      true,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidatePacketSpec(tc.spec, tc.external, field.NewPath("packet"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
 func TestValidateVersionConfig(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -830,6 +1377,87 @@ func TestValidateContainerRuntimeConfig(t *testing.T) {
 			versions:      kubeone.VersionConfig{Kubernetes: "1.20"},
 			expectedError: true,
 		},
+		{
+			name: "containerd with registries",
+			containerRuntime: kubeone.ContainerRuntimeConfig{
+				Containerd: &kubeone.ContainerRuntimeContainerd{
+					Registries: map[string]kubeone.RegistryMirror{
+						"docker.io": {
+							Mirrors: []string{"https://mirror.example.com", "http://10.0.0.1:5000"},
+							Auth:    &kubeone.RegistryAuth{Username: "user", Password: "pass"},
+						},
+					},
+				},
+			},
+			versions:      kubeone.VersionConfig{Kubernetes: "1.22"},
+			expectedError: false,
+		},
+		{
+			name: "containerd with registry without mirrors",
+			containerRuntime: kubeone.ContainerRuntimeConfig{
+				Containerd: &kubeone.ContainerRuntimeContainerd{
+					Registries: map[string]kubeone.RegistryMirror{
+						"docker.io": {},
+					},
+				},
+			},
+			versions:      kubeone.VersionConfig{Kubernetes: "1.22"},
+			expectedError: true,
+		},
+		{
+			name: "containerd with registry mirror without scheme",
+			containerRuntime: kubeone.ContainerRuntimeConfig{
+				Containerd: &kubeone.ContainerRuntimeContainerd{
+					Registries: map[string]kubeone.RegistryMirror{
+						"docker.io": {Mirrors: []string{"mirror.example.com"}},
+					},
+				},
+			},
+			versions:      kubeone.VersionConfig{Kubernetes: "1.22"},
+			expectedError: true,
+		},
+		{
+			name: "containerd with registry url as key",
+			containerRuntime: kubeone.ContainerRuntimeConfig{
+				Containerd: &kubeone.ContainerRuntimeContainerd{
+					Registries: map[string]kubeone.RegistryMirror{
+						"https://docker.io": {Mirrors: []string{"https://mirror.example.com"}},
+					},
+				},
+			},
+			versions:      kubeone.VersionConfig{Kubernetes: "1.22"},
+			expectedError: true,
+		},
+		{
+			name: "containerd with registry auth without password",
+			containerRuntime: kubeone.ContainerRuntimeConfig{
+				Containerd: &kubeone.ContainerRuntimeContainerd{
+					Registries: map[string]kubeone.RegistryMirror{
+						"docker.io": {
+							Mirrors: []string{"https://mirror.example.com"},
+							Auth:    &kubeone.RegistryAuth{Username: "user"},
+						},
+					},
+				},
+			},
+			versions:      kubeone.VersionConfig{Kubernetes: "1.22"},
+			expectedError: true,
+		},
+		{
+			name:             "only crio defined",
+			containerRuntime: kubeone.ContainerRuntimeConfig{CRIO: &kubeone.ContainerRuntimeCRIO{}},
+			versions:         kubeone.VersionConfig{Kubernetes: "1.22"},
+			expectedError:    false,
+		},
+		{
+			name: "containerd and crio defined",
+			containerRuntime: kubeone.ContainerRuntimeConfig{
+				Containerd: &kubeone.ContainerRuntimeContainerd{},
+				CRIO:       &kubeone.ContainerRuntimeCRIO{},
+			},
+			versions:      kubeone.VersionConfig{Kubernetes: "1.22"},
+			expectedError: true,
+		},
 		{
 			name:             "non defined",
 			containerRuntime: kubeone.ContainerRuntimeConfig{},
@@ -902,97 +1530,168 @@ func TestValidateClusterNetworkConfig(t *testing.T) {
 			expectedError: true,
 		},
 		{
-			name: "invalid cni config",
+			name: "valid additional pod subnets",
 			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
-				CNI: &kubeone.CNI{
-					Canal:    &kubeone.CanalSpec{},
-					WeaveNet: &kubeone.WeaveNetSpec{},
-				},
+				PodSubnet:            "10.244.0.0/16",
+				AdditionalPodSubnets: []string{"10.245.0.0/16"},
+				ServiceSubnet:        "10.96.0.0/12",
+			},
+			expectedError: false,
+		},
+		{
+			name: "additional pod subnet overlapping with pod subnet",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				PodSubnet:            "10.244.0.0/16",
+				AdditionalPodSubnets: []string{"10.244.128.0/17"},
 			},
 			expectedError: true,
 		},
-	}
-	for _, tc := range tests {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateClusterNetworkConfig(tc.clusterNetworkConfig, nil)
-			if (len(errs) == 0) == tc.expectedError {
-				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
-			}
-		})
-	}
-}
-
-func TestValidateCNIConfig(t *testing.T) {
-	tests := []struct {
-		name          string
-		cniConfig     *kubeone.CNI
-		expectedError bool
-	}{
 		{
-			name: "valid Canal CNI config",
-			cniConfig: &kubeone.CNI{
-				Canal: &kubeone.CanalSpec{MTU: 1500},
+			name: "additional pod subnet of other IP family",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				PodSubnet:            "10.244.0.0/16",
+				AdditionalPodSubnets: []string{"fd00:10:244::/64"},
 			},
-			expectedError: false,
+			expectedError: true,
 		},
 		{
-			name: "valid WeaveNet CNI config",
-			cniConfig: &kubeone.CNI{
-				WeaveNet: &kubeone.WeaveNetSpec{},
+			name: "additional pod subnets covered by subnet overlapping with service subnet",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				PodSubnet:            "10.244.0.0/16",
+				AdditionalPodSubnets: []string{"10.100.0.0/16"},
+				ServiceSubnet:        "10.96.0.0/12",
 			},
-			expectedError: false,
+			expectedError: true,
 		},
 		{
-			name: "valid WeaveNet CNI config with encryption enabled",
-			cniConfig: &kubeone.CNI{
-				WeaveNet: &kubeone.WeaveNetSpec{
-					Encrypted: true,
-				},
+			name: "additional pod subnets with WeaveNet",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				PodSubnet:            "10.244.0.0/16",
+				AdditionalPodSubnets: []string{"10.245.0.0/16"},
+				CNI:                  &kubeone.CNI{WeaveNet: &kubeone.WeaveNetSpec{}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid dual-stack",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				IPFamilies:        []kubeone.IPFamily{kubeone.IPFamilyIPv4, kubeone.IPFamilyIPv6},
+				PodSubnet:         "10.244.0.0/16",
+				ServiceSubnet:     "10.96.0.0/12",
+				PodSubnetIPv6:     "fd01::/48",
+				ServiceSubnetIPv6: "fd02::/120",
+				CNI:               &kubeone.CNI{Cilium: &kubeone.CiliumSpec{}},
 			},
 			expectedError: false,
 		},
 		{
-			name: "valid External CNI config",
-			cniConfig: &kubeone.CNI{
-				External: &kubeone.ExternalCNISpec{},
+			name: "dual-stack with calico in bgp mode",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				IPFamilies:        []kubeone.IPFamily{kubeone.IPFamilyIPv4, kubeone.IPFamilyIPv6},
+				PodSubnet:         "10.244.0.0/16",
+				ServiceSubnet:     "10.96.0.0/12",
+				PodSubnetIPv6:     "fd01::/48",
+				ServiceSubnetIPv6: "fd02::/120",
+				CNI:               &kubeone.CNI{Calico: &kubeone.CalicoSpec{Mode: kubeone.CalicoModeBGP}},
 			},
 			expectedError: false,
 		},
 		{
-			name: "Canal and WeaveNet specified at the same time",
-			cniConfig: &kubeone.CNI{
-				Canal:    &kubeone.CanalSpec{},
-				WeaveNet: &kubeone.WeaveNetSpec{},
+			name: "dual-stack with calico in vxlan mode",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				IPFamilies:        []kubeone.IPFamily{kubeone.IPFamilyIPv4, kubeone.IPFamilyIPv6},
+				PodSubnetIPv6:     "fd01::/48",
+				ServiceSubnetIPv6: "fd02::/120",
+				CNI:               &kubeone.CNI{Calico: &kubeone.CalicoSpec{Mode: kubeone.CalicoModeVXLAN}},
 			},
 			expectedError: true,
 		},
 		{
-			name: "Canal and External specified at the same time",
-			cniConfig: &kubeone.CNI{
-				Canal:    &kubeone.CanalSpec{},
-				External: &kubeone.ExternalCNISpec{},
+			name: "dual-stack with canal",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				IPFamilies:        []kubeone.IPFamily{kubeone.IPFamilyIPv4, kubeone.IPFamilyIPv6},
+				PodSubnetIPv6:     "fd01::/48",
+				ServiceSubnetIPv6: "fd02::/120",
+				CNI:               &kubeone.CNI{Canal: &kubeone.CanalSpec{MTU: 1450}},
 			},
 			expectedError: true,
 		},
 		{
-			name: "WeaveNet and External specified at the same time",
-			cniConfig: &kubeone.CNI{
-				WeaveNet: &kubeone.WeaveNetSpec{},
-				External: &kubeone.ExternalCNISpec{},
+			name: "dual-stack without ipv6 subnets",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				IPFamilies: []kubeone.IPFamily{kubeone.IPFamilyIPv4, kubeone.IPFamilyIPv6},
+				CNI:        &kubeone.CNI{External: &kubeone.ExternalCNISpec{}},
 			},
 			expectedError: true,
 		},
 		{
-			name:          "no CNI config specified",
-			cniConfig:     &kubeone.CNI{},
+			name: "dual-stack with ipv4 subnet in ipv6 family",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				IPFamilies:        []kubeone.IPFamily{kubeone.IPFamilyIPv4, kubeone.IPFamilyIPv6},
+				PodSubnetIPv6:     "10.245.0.0/16",
+				ServiceSubnetIPv6: "fd02::/120",
+			},
+			expectedError: true,
+		},
+		{
+			name: "too large ipv6 service subnet",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				IPFamilies:        []kubeone.IPFamily{kubeone.IPFamilyIPv6},
+				PodSubnetIPv6:     "fd01::/48",
+				ServiceSubnetIPv6: "fd02::/64",
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicate ip family",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				IPFamilies: []kubeone.IPFamily{kubeone.IPFamilyIPv4, kubeone.IPFamilyIPv4},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported ip family",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				IPFamilies: []kubeone.IPFamily{"IPv5"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "ipv6 pod subnet without ipv6 family",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				PodSubnetIPv6: "fd01::/48",
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid node port range",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				NodePortRange: "30000-32767",
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid node port range",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				NodePortRange: "32767-30000",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid cni config",
+			clusterNetworkConfig: kubeone.ClusterNetworkConfig{
+				CNI: &kubeone.CNI{
+					Canal:    &kubeone.CanalSpec{},
+					WeaveNet: &kubeone.WeaveNetSpec{},
+				},
+			},
 			expectedError: true,
 		},
 	}
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateCNI(tc.cniConfig, nil)
+			errs := ValidateClusterNetworkConfig(tc.clusterNetworkConfig, nil)
 			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
@@ -1000,61 +1699,125 @@ func TestValidateCNIConfig(t *testing.T) {
 	}
 }
 
-func TestValidateStaticWorkersConfig(t *testing.T) {
+func TestValidateCoreDNSConfig(t *testing.T) {
+	replicas := int32(3)
+	zeroReplicas := int32(0)
+
 	tests := []struct {
-		name                string
-		staticWorkersConfig kubeone.StaticWorkersConfig
-		expectedError       bool
+		name          string
+		coreDNSConfig *kubeone.CoreDNSConfig
+		expectedError bool
 	}{
 		{
-			name: "valid StaticWorkers config",
-			staticWorkersConfig: kubeone.StaticWorkersConfig{
-				Hosts: []kubeone.HostConfig{
-					{
-						PublicAddress:  "1.1.1.1",
-						PrivateAddress: "10.0.0.1",
-						SSHAgentSocket: "env:SSH_AUTH_SOCK",
-						SSHUsername:    "ubuntu",
-					},
-					{
-						PublicAddress:  "1.1.1.2",
-						PrivateAddress: "10.0.0.2",
-						SSHAgentSocket: "env:SSH_AUTH_SOCK",
-						SSHUsername:    "ubuntu",
-					},
+			name: "valid CoreDNS config",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Replicas:                  &replicas,
+				DeployPodDisruptionBudget: true,
+				Hosts: []kubeone.CoreDNSHostEntry{
+					{IP: "10.0.0.10", Hostnames: []string{"registry.example.com", "mirror.example.com"}},
+				},
+				Rewrites: []kubeone.CoreDNSRewriteRule{
+					{Field: "name", Match: "suffix", From: ".corp.example.com", To: ".svc.cluster.local"},
+					{Field: "type", From: "ANY", To: "HINFO"},
+				},
+				Plugins: []kubeone.CoreDNSPlugin{
+					{Name: "log"},
+					{Name: "template", Args: []string{"IN", "A", "example.org"}, Options: []string{`answer "{{ .Name }} 60 IN A 10.0.0.20"`}},
+				},
+				Forwarders: []string{"8.8.8.8", "[2001:4860:4860::8888]:53"},
+				StubDomains: []kubeone.CoreDNSStubDomain{
+					{Domain: "corp.example.com", Servers: []string{"10.0.0.53", "10.0.0.54:5353"}},
 				},
+				CorefileSnippet: "example.org:53 {\n    whoami\n}\n",
 			},
 			expectedError: false,
 		},
 		{
-			name: "no hosts provided",
-			staticWorkersConfig: kubeone.StaticWorkersConfig{
-				Hosts: []kubeone.HostConfig{},
-			},
+			name:          "empty CoreDNS config",
+			coreDNSConfig: &kubeone.CoreDNSConfig{},
 			expectedError: false,
 		},
 		{
-			name:                "no hosts field present",
-			staticWorkersConfig: kubeone.StaticWorkersConfig{},
-			expectedError:       false,
+			name: "invalid hosts entry IP",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Hosts: []kubeone.CoreDNSHostEntry{{IP: "10.0.0", Hostnames: []string{"registry.example.com"}}},
+			},
+			expectedError: true,
 		},
 		{
-			name: "invalid host config",
-			staticWorkersConfig: kubeone.StaticWorkersConfig{
-				Hosts: []kubeone.HostConfig{
-					{
-						PublicAddress:  "1.1.1.1",
-						PrivateAddress: "10.0.0.1",
-						SSHAgentSocket: "env:SSH_AUTH_SOCK",
-						SSHUsername:    "ubuntu",
-					},
-					{
-						PublicAddress:  "1.1.1.2",
-						PrivateAddress: "10.0.0.2",
-						SSHAgentSocket: "env:SSH_AUTH_SOCK",
-						SSHUsername:    "",
-					},
-				},
+			name: "hosts entry without hostnames",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Hosts: []kubeone.CoreDNSHostEntry{{IP: "10.0.0.10"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid rewrite match",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Rewrites: []kubeone.CoreDNSRewriteRule{{Field: "name", Match: "glob", From: "a", To: "b"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "rewrite match for the type field",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Rewrites: []kubeone.CoreDNSRewriteRule{{Field: "type", Match: "exact", From: "ANY", To: "HINFO"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "rewrite without target",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Rewrites: []kubeone.CoreDNSRewriteRule{{From: "a.example.com"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "plugin configured by kubeadm",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Plugins: []kubeone.CoreDNSPlugin{{Name: "forward", Args: []string{".", "8.8.8.8"}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "plugin option with braces",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Plugins: []kubeone.CoreDNSPlugin{{Name: "log", Options: []string{"class error }"}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "zero replicas",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Replicas: &zeroReplicas,
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid forwarder",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				Forwarders: []string{"dns.example.com"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid stub domain",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				StubDomains: []kubeone.CoreDNSStubDomain{{Domain: "Corp_Example", Servers: []string{"10.0.0.53"}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "stub domain without servers",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				StubDomains: []kubeone.CoreDNSStubDomain{{Domain: "corp.example.com"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "snippet with unbalanced braces",
+			coreDNSConfig: &kubeone.CoreDNSConfig{
+				CorefileSnippet: "example.org:53 {\n    whoami\n",
 			},
 			expectedError: true,
 		},
@@ -1062,7 +1825,7 @@ func TestValidateStaticWorkersConfig(t *testing.T) {
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateStaticWorkersConfig(tc.staticWorkersConfig, nil)
+			errs := ValidateCoreDNSConfig(tc.coreDNSConfig, nil)
 			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
@@ -1070,63 +1833,53 @@ func TestValidateStaticWorkersConfig(t *testing.T) {
 	}
 }
 
-func TestValidateDynamicWorkerConfig(t *testing.T) {
+func TestValidateKubeProxy(t *testing.T) {
+	negative := int32(-1)
+	maxPerCore := int32(65536)
+
 	tests := []struct {
-		name                string
-		dynamicWorkerConfig []kubeone.DynamicWorkerConfig
-		expectedError       bool
+		name          string
+		kubeProxy     *kubeone.KubeProxyConfig
+		expectedError bool
 	}{
 		{
-			name: "valid worker config",
-			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
-				{
-					Name:     "test-1",
-					Replicas: intPtr(3),
-				},
-				{
-					Name:     "test-2",
-					Replicas: intPtr(5),
-				},
-				{
-					Name:     "test-3",
-					Replicas: intPtr(0),
-				},
+			name: "valid kube-proxy config",
+			kubeProxy: &kubeone.KubeProxyConfig{
+				IPVS:               &kubeone.IPVSConfig{},
+				MetricsBindAddress: "0.0.0.0:10249",
+				Conntrack:          &kubeone.KubeProxyConntrack{MaxPerCore: &maxPerCore},
+				SyncPeriod:         metav1.Duration{Duration: time.Minute},
 			},
 			expectedError: false,
 		},
 		{
-			name:                "valid worker config (no worker defined)",
-			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{},
-			expectedError:       false,
+			name: "invalid kube-proxy config (both modes)",
+			kubeProxy: &kubeone.KubeProxyConfig{
+				IPVS:     &kubeone.IPVSConfig{},
+				IPTables: &kubeone.IPTables{},
+			},
+			expectedError: true,
 		},
 		{
-			name: "invalid worker config (replicas not provided)",
-			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
-				{
-					Name:     "test-1",
-					Replicas: intPtr(3),
-				},
-				{
-					Name: "test-2",
-				},
-			},
+			name:          "invalid kube-proxy config (metrics address without port)",
+			kubeProxy:     &kubeone.KubeProxyConfig{MetricsBindAddress: "0.0.0.0"},
 			expectedError: true,
 		},
 		{
-			name: "invalid worker config (no name given)",
-			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
-				{
-					Replicas: intPtr(3),
-				},
-			},
+			name:          "invalid kube-proxy config (negative conntrack min)",
+			kubeProxy:     &kubeone.KubeProxyConfig{Conntrack: &kubeone.KubeProxyConntrack{Min: &negative}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid kube-proxy config (negative sync period)",
+			kubeProxy:     &kubeone.KubeProxyConfig{SyncPeriod: metav1.Duration{Duration: -time.Second}},
 			expectedError: true,
 		},
 	}
-
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateDynamicWorkerConfig(tc.dynamicWorkerConfig, nil)
+			errs := ValidateKubeProxy(tc.kubeProxy, nil)
 			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
@@ -1134,211 +1887,182 @@ func TestValidateDynamicWorkerConfig(t *testing.T) {
 	}
 }
 
-func TestValidateCABundle(t *testing.T) {
+func TestValidateCNIConfig(t *testing.T) {
 	tests := []struct {
 		name          string
-		caBundle      string
+		cniConfig     *kubeone.CNI
 		expectedError bool
 	}{
 		{
-			name:          "empty",
-			caBundle:      "",
+			name: "valid Canal CNI config",
+			cniConfig: &kubeone.CNI{
+				Canal: &kubeone.CanalSpec{MTU: 1500},
+			},
 			expectedError: false,
 		},
 		{
-			name: "correct",
-			caBundle: heredoc.Doc(`
-				## some comments
-
-				GlobalSign Root CA
-				==================
-				-----BEGIN CERTIFICATE-----
-				MIIDdTCCAl2gAwIBAgILBAAAAAABFUtaw5QwDQYJKoZIhvcNAQEFBQAwVzELMAkGA1UEBhMCQkUx
-				GTAXBgNVBAoTEEdsb2JhbFNpZ24gbnYtc2ExEDAOBgNVBAsTB1Jvb3QgQ0ExGzAZBgNVBAMTEkds
-				b2JhbFNpZ24gUm9vdCBDQTAeFw05ODA5MDExMjAwMDBaFw0yODAxMjgxMjAwMDBaMFcxCzAJBgNV
-				BAYTAkJFMRkwFwYDVQQKExBHbG9iYWxTaWduIG52LXNhMRAwDgYDVQQLEwdSb290IENBMRswGQYD
-				VQQDExJHbG9iYWxTaWduIFJvb3QgQ0EwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIBAQDa
-				DuaZjc6j40+Kfvvxi4Mla+pIH/EqsLmVEQS98GPR4mdmzxzdzxtIK+6NiY6arymAZavpxy0Sy6sc
-				THAHoT0KMM0VjU/43dSMUBUc71DuxC73/OlS8pF94G3VNTCOXkNz8kHp1Wrjsok6Vjk4bwY8iGlb
-				Kk3Fp1S4bInMm/k8yuX9ifUSPJJ4ltbcdG6TRGHRjcdGsnUOhugZitVtbNV4FpWi6cgKOOvyJBNP
-				c1STE4U6G7weNLWLBYy5d4ux2x8gkasJU26Qzns3dLlwR5EiUWMWea6xrkEmCMgZK9FGqkjWZCrX
-				gzT/LCrBbBlDSgeF59N89iFo7+ryUp9/k5DPAgMBAAGjQjBAMA4GA1UdDwEB/wQEAwIBBjAPBgNV
-				HRMBAf8EBTADAQH/MB0GA1UdDgQWBBRge2YaRQ2XyolQL30EzTSo//z9SzANBgkqhkiG9w0BAQUF
-				AAOCAQEA1nPnfE920I2/7LqivjTFKDK1fPxsnCwrvQmeU79rXqoRSLblCKOzyj1hTdNGCbM+w6Dj
-				Y1Ub8rrvrTnhQ7k4o+YviiY776BQVvnGCv04zcQLcFGUl5gE38NflNUVyRRBnMRddWQVDf9VMOyG
-				j/8N7yy5Y0b2qvzfvGn9LhJIZJrglfCm7ymPAbEVtQwdpf5pLGkkeB6zpxxxYu7KyJesF12KwvhH
-				hm4qxFYxldBniYUr+WymXUadDKqC5JlR3XC321Y9YeRq4VzW9v493kHMB65jUr9TU/Qr6cf9tveC
-				X4XSQRjbgbMEHMUfpIBvFSDJ3gyICh3WZlXi/EjJKSZp4A==
-				-----END CERTIFICATE-----
-			`),
+			name: "valid WeaveNet CNI config",
+			cniConfig: &kubeone.CNI{
+				WeaveNet: &kubeone.WeaveNetSpec{},
+			},
 			expectedError: false,
 		},
 		{
-			name: "no certs but with comments",
-			caBundle: heredoc.Doc(`
-				# leading comment
-				## additional comment
-			`),
-			expectedError: true,
+			name: "valid WeaveNet CNI config with encryption enabled",
+			cniConfig: &kubeone.CNI{
+				WeaveNet: &kubeone.WeaveNetSpec{
+					Encrypted: true,
+				},
+			},
+			expectedError: false,
 		},
 		{
-			name:          "incorrect",
-			caBundle:      "garbadge",
-			expectedError: true,
+			name: "valid External CNI config",
+			cniConfig: &kubeone.CNI{
+				External: &kubeone.ExternalCNISpec{},
+			},
+			expectedError: false,
 		},
-	}
-
-	for _, tc := range tests {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateCABundle(tc.caBundle, field.NewPath("caBundle"))
-			if (len(errs) == 0) == tc.expectedError {
-				t.Logf("failed value:\n%q", tc.caBundle)
-				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
-			}
-		})
-	}
-}
-
-func TestValidateFeatures(t *testing.T) {
-	tests := []struct {
-		name          string
-		features      kubeone.Features
-		versions      kubeone.VersionConfig
-		expectedError bool
-	}{
 		{
-			name: "psp and auditing enabled",
-			features: kubeone.Features{
-				PodSecurityPolicy: &kubeone.PodSecurityPolicy{
-					Enable: true,
-				},
-				DynamicAuditLog: &kubeone.DynamicAuditLog{
-					Enable: true,
-				},
-			},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.20.2",
+			name: "Canal and WeaveNet specified at the same time",
+			cniConfig: &kubeone.CNI{
+				Canal:    &kubeone.CanalSpec{},
+				WeaveNet: &kubeone.WeaveNetSpec{},
 			},
-			expectedError: false,
+			expectedError: true,
 		},
 		{
-			name: "metrics server disabled",
-			features: kubeone.Features{
-				MetricsServer: &kubeone.MetricsServer{
-					Enable: false,
-				},
+			name: "Canal and External specified at the same time",
+			cniConfig: &kubeone.CNI{
+				Canal:    &kubeone.CanalSpec{},
+				External: &kubeone.ExternalCNISpec{},
 			},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.20.2",
+			expectedError: true,
+		},
+		{
+			name: "WeaveNet and External specified at the same time",
+			cniConfig: &kubeone.CNI{
+				WeaveNet: &kubeone.WeaveNetSpec{},
+				External: &kubeone.ExternalCNISpec{},
 			},
-			expectedError: false,
+			expectedError: true,
 		},
 		{
-			name:     "no feature configured",
-			features: kubeone.Features{},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.20.2",
+			name: "valid Cilium CNI config",
+			cniConfig: &kubeone.CNI{
+				Cilium: &kubeone.CiliumSpec{
+					KubeProxyReplacement: kubeone.CiliumKubeProxyReplacementStrict,
+					Hubble: &kubeone.CiliumHubbleSpec{
+						Relay:         true,
+						UI:            true,
+						UIServiceType: corev1.ServiceTypeNodePort,
+					},
+				},
 			},
 			expectedError: false,
 		},
 		{
-			name: "oidc enabled",
-			features: kubeone.Features{
-				OpenIDConnect: &kubeone.OpenIDConnect{
-					Enable: true,
-					Config: kubeone.OpenIDConnectConfig{
-						IssuerURL:     "test.cluster.local",
-						ClientID:      "123",
-						RequiredClaim: "test",
+			name: "valid Calico BGP config",
+			cniConfig: &kubeone.CNI{
+				Calico: &kubeone.CalicoSpec{
+					Mode: kubeone.CalicoModeBGP,
+					BGP: &kubeone.CalicoBGPSpec{
+						ASNumber:              64512,
+						DisableNodeToNodeMesh: true,
+						Peers: []kubeone.CalicoBGPPeer{
+							{Name: "tor-1", PeerIP: "192.168.1.1", ASNumber: 64513, NodeSelector: "rack == 'rack-1'"},
+						},
 					},
 				},
 			},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.20.2",
-			},
 			expectedError: false,
 		},
 		{
-			name: "invalid staticAudit config",
-			features: kubeone.Features{
-				StaticAuditLog: &kubeone.StaticAuditLog{
-					Enable: true,
-					Config: kubeone.StaticAuditLogConfig{},
-				},
+			name: "Calico and Cilium specified at the same time",
+			cniConfig: &kubeone.CNI{
+				Calico: &kubeone.CalicoSpec{},
+				Cilium: &kubeone.CiliumSpec{},
 			},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.20.2",
+			expectedError: true,
+		},
+		{
+			name: "invalid Calico mode",
+			cniConfig: &kubeone.CNI{
+				Calico: &kubeone.CalicoSpec{Mode: "flannel"},
 			},
 			expectedError: true,
 		},
 		{
-			name: "invalid oidc config",
-			features: kubeone.Features{
-				OpenIDConnect: &kubeone.OpenIDConnect{
-					Enable: true,
-					Config: kubeone.OpenIDConnectConfig{},
+			name: "Calico BGP peering in the vxlan mode",
+			cniConfig: &kubeone.CNI{
+				Calico: &kubeone.CalicoSpec{
+					BGP: &kubeone.CalicoBGPSpec{},
 				},
 			},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.20.2",
+			expectedError: true,
+		},
+		{
+			name: "Calico cross subnet encapsulation in the bgp mode",
+			cniConfig: &kubeone.CNI{
+				Calico: &kubeone.CalicoSpec{Mode: kubeone.CalicoModeBGP, CrossSubnet: true},
 			},
 			expectedError: true,
 		},
 		{
-			name: "invalid podNodeSelector config",
-			features: kubeone.Features{
-				PodNodeSelector: &kubeone.PodNodeSelector{
-					Enable: true,
-					Config: kubeone.PodNodeSelectorConfig{},
+			name: "invalid Calico BGP peer",
+			cniConfig: &kubeone.CNI{
+				Calico: &kubeone.CalicoSpec{
+					Mode: kubeone.CalicoModeIPIP,
+					BGP: &kubeone.CalicoBGPSpec{
+						Peers: []kubeone.CalicoBGPPeer{{Name: "tor-1", PeerIP: "tor-1.example.com"}},
+					},
 				},
 			},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.20.2",
-			},
 			expectedError: true,
 		},
 		{
-			name: "podPresets enabled on 1.19 cluster",
-			features: kubeone.Features{
-				PodPresets: &kubeone.PodPresets{
-					Enable: true,
-				},
+			name: "Canal and Cilium specified at the same time",
+			cniConfig: &kubeone.CNI{
+				Canal:  &kubeone.CanalSpec{MTU: 1450},
+				Cilium: &kubeone.CiliumSpec{},
 			},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.19.7",
+			expectedError: true,
+		},
+		{
+			name: "invalid Cilium kube-proxy replacement",
+			cniConfig: &kubeone.CNI{
+				Cilium: &kubeone.CiliumSpec{KubeProxyReplacement: "full"},
 			},
-			expectedError: false,
+			expectedError: true,
 		},
 		{
-			name: "podPresets enabled on 1.20 cluster",
-			features: kubeone.Features{
-				PodPresets: &kubeone.PodPresets{
-					Enable: true,
+			name: "Hubble UI without relay",
+			cniConfig: &kubeone.CNI{
+				Cilium: &kubeone.CiliumSpec{
+					Hubble: &kubeone.CiliumHubbleSpec{UI: true},
 				},
 			},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.20.2",
-			},
 			expectedError: true,
 		},
 		{
-			name: "podPresets enabled on 1.21 cluster",
-			features: kubeone.Features{
-				PodPresets: &kubeone.PodPresets{
-					Enable: true,
+			name: "invalid Hubble UI service type",
+			cniConfig: &kubeone.CNI{
+				Cilium: &kubeone.CiliumSpec{
+					Hubble: &kubeone.CiliumHubbleSpec{Relay: true, UI: true, UIServiceType: corev1.ServiceTypeExternalName},
 				},
 			},
-			versions: kubeone.VersionConfig{
-				Kubernetes: "1.21.0",
-			},
+			expectedError: true,
+		},
+		{
+			name:          "no CNI config specified",
+			cniConfig:     &kubeone.CNI{},
 			expectedError: true,
 		},
 	}
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateFeatures(tc.features, tc.versions, nil)
+			errs := ValidateCNI(tc.cniConfig, nil)
 			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
@@ -1346,111 +2070,2586 @@ func TestValidateFeatures(t *testing.T) {
 	}
 }
 
-func TestValidatePodNodeSelectorConfig(t *testing.T) {
+func TestValidateStaticWorkersConfig(t *testing.T) {
 	tests := []struct {
-		name                  string
-		podNodeSelectorConfig kubeone.PodNodeSelectorConfig
-		expectedError         bool
+		name                string
+		staticWorkersConfig kubeone.StaticWorkersConfig
+		expectedError       bool
 	}{
 		{
-			name: "valid podNodeSelector config",
-			podNodeSelectorConfig: kubeone.PodNodeSelectorConfig{
-				ConfigFilePath: "./podnodeselector.yaml",
+			name: "valid StaticWorkers config",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Hosts: []kubeone.HostConfig{
+					{
+						PublicAddress:  "1.1.1.1",
+						PrivateAddress: "10.0.0.1",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+					},
+					{
+						PublicAddress:  "1.1.1.2",
+						PrivateAddress: "10.0.0.2",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+					},
+				},
 			},
 			expectedError: false,
 		},
 		{
-			name:                  "invalid podNodeSelector config",
-			podNodeSelectorConfig: kubeone.PodNodeSelectorConfig{},
-			expectedError:         true,
+			name: "no hosts provided",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Hosts: []kubeone.HostConfig{},
+			},
+			expectedError: false,
+		},
+		{
+			name:                "no hosts field present",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{},
+			expectedError:       false,
+		},
+		{
+			name: "invalid host config",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Hosts: []kubeone.HostConfig{
+					{
+						PublicAddress:  "1.1.1.1",
+						PrivateAddress: "10.0.0.1",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+					},
+					{
+						PublicAddress:  "1.1.1.2",
+						PrivateAddress: "10.0.0.2",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid pools",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Hosts: []kubeone.HostConfig{
+					{
+						PublicAddress:  "1.1.1.1",
+						PrivateAddress: "10.0.0.1",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+						Pool:           "gpu",
+					},
+				},
+				Pools: []kubeone.StaticWorkerPool{
+					{
+						Name:             "gpu",
+						KubeletExtraArgs: map[string]string{"max-pods": "50"},
+						FeatureGates:     map[string]bool{"DevicePlugins": true},
+						Taints:           []corev1.Taint{{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule}},
+						Labels:           map[string]string{"accelerator": "nvidia"},
+					},
+					{
+						Name: "storage",
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "host in unknown pool",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Hosts: []kubeone.HostConfig{
+					{
+						PublicAddress:  "1.1.1.1",
+						PrivateAddress: "10.0.0.1",
+						SSHAgentSocket: "env:SSH_AUTH_SOCK",
+						SSHUsername:    "ubuntu",
+						Pool:           "gpu",
+					},
+				},
+				Pools: []kubeone.StaticWorkerPool{{Name: "storage"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "pool without name",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Pools: []kubeone.StaticWorkerPool{{Labels: map[string]string{"accelerator": "nvidia"}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid pool name",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Pools: []kubeone.StaticWorkerPool{{Name: "GPU_pool"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicate pool names",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Pools: []kubeone.StaticWorkerPool{{Name: "gpu"}, {Name: "gpu"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "kubelet flag with leading dashes",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Pools: []kubeone.StaticWorkerPool{{Name: "gpu", KubeletExtraArgs: map[string]string{"--max-pods": "50"}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid taint effect",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Pools: []kubeone.StaticWorkerPool{{Name: "gpu", Taints: []corev1.Taint{{Key: "nvidia.com/gpu", Effect: "Forbid"}}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid label",
+			staticWorkersConfig: kubeone.StaticWorkersConfig{
+				Pools: []kubeone.StaticWorkerPool{{Name: "gpu", Labels: map[string]string{"accelerator": "nvidia gpu"}}},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateStaticWorkersConfig(tc.staticWorkersConfig, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateDynamicWorkerConfig(t *testing.T) {
+	tests := []struct {
+		name                string
+		dynamicWorkerConfig []kubeone.DynamicWorkerConfig
+		expectedError       bool
+	}{
+		{
+			name: "valid worker config",
+			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+				},
+				{
+					Name:     "test-2",
+					Replicas: intPtr(5),
+				},
+				{
+					Name:     "test-3",
+					Replicas: intPtr(0),
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name:                "valid worker config (no worker defined)",
+			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{},
+			expectedError:       false,
+		},
+		{
+			name: "invalid worker config (replicas not provided)",
+			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
+				{
+					Name:     "test-1",
+					Replicas: intPtr(3),
+				},
+				{
+					Name: "test-2",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid worker config (no name given)",
+			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
+				{
+					Replicas: intPtr(3),
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid worker config (autoscaling)",
+			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
+				{
+					Name:        "test-1",
+					Replicas:    intPtr(3),
+					Autoscaling: &kubeone.DynamicWorkerAutoscaling{MinReplicas: 1, MaxReplicas: 5},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid worker config (autoscaling minReplicas is zero)",
+			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
+				{
+					Name:        "test-1",
+					Replicas:    intPtr(1),
+					Autoscaling: &kubeone.DynamicWorkerAutoscaling{MinReplicas: 0, MaxReplicas: 5},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid worker config (autoscaling maxReplicas lower than minReplicas)",
+			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
+				{
+					Name:        "test-1",
+					Replicas:    intPtr(3),
+					Autoscaling: &kubeone.DynamicWorkerAutoscaling{MinReplicas: 3, MaxReplicas: 2},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid worker config (replicas out of autoscaling range)",
+			dynamicWorkerConfig: []kubeone.DynamicWorkerConfig{
+				{
+					Name:        "test-1",
+					Replicas:    intPtr(6),
+					Autoscaling: &kubeone.DynamicWorkerAutoscaling{MinReplicas: 1, MaxReplicas: 5},
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateDynamicWorkerConfig(tc.dynamicWorkerConfig, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateDynamicWorkerCloudProviderSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		provider      kubeone.CloudProviderSpec
+		spec          string
+		expectedError bool
+	}{
+		{
+			name:          "valid aws spec",
+			provider:      kubeone.CloudProviderSpec{AWS: &kubeone.AWSSpec{}},
+			spec:          `{"region": "eu-west-3", "instanceType": "t3.medium", "diskSize": 50, "assignPublicIP": true, "tags": {"owner": "kubeone"}}`,
+			expectedError: false,
+		},
+		{
+			name:          "valid aws spec with a secret reference",
+			provider:      kubeone.CloudProviderSpec{AWS: &kubeone.AWSSpec{}},
+			spec:          `{"region": "eu-west-3", "ami": {"secretKeyRef": {"namespace": "kube-system", "name": "aws", "key": "ami"}}}`,
+			expectedError: false,
+		},
+		{
+			name:          "typo in aws spec",
+			provider:      kubeone.CloudProviderSpec{AWS: &kubeone.AWSSpec{}},
+			spec:          `{"region": "eu-west-3", "instanceTyp": "t3.medium"}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid type in hetzner spec",
+			provider:      kubeone.CloudProviderSpec{Hetzner: &kubeone.HetznerSpec{}},
+			spec:          `{"serverType": "cx21", "networks": "kubeone"}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid type in vsphere spec",
+			provider:      kubeone.CloudProviderSpec{Vsphere: &kubeone.VsphereSpec{}},
+			spec:          `{"cpus": "2", "memoryMB": 2048}`,
+			expectedError: true,
+		},
+		{
+			name:          "case insensitive field names in openstack spec",
+			provider:      kubeone.CloudProviderSpec{Openstack: &kubeone.OpenstackSpec{}},
+			spec:          `{"image": "ubuntu", "flavor": "m1.small", "floatingIPPool": "public"}`,
+			expectedError: false,
+		},
+		{
+			name:          "empty spec",
+			provider:      kubeone.CloudProviderSpec{AWS: &kubeone.AWSSpec{}},
+			spec:          ``,
+			expectedError: false,
+		},
+		{
+			name:          "provider without the typed spec",
+			provider:      kubeone.CloudProviderSpec{None: &kubeone.NoneSpec{}},
+			spec:          `{"anything": true}`,
+			expectedError: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateDynamicWorkerCloudProviderSpec(tc.provider, []byte(tc.spec), field.NewPath("cloudProviderSpec"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v: %v", tc.expectedError, (len(errs) != 0), errs)
+			}
+		})
+	}
+}
+
+func TestValidateKubeVirtProviderSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		expectedError bool
+	}{
+		{
+			name:          "valid spec",
+			spec:          `{"virtualMachine": {"template": {"cpus": "2", "memory": "4Gi", "primaryDisk": {"osImage": "http://images/ubuntu.img", "size": "20Gi", "storageClassName": "standard"}}}}`,
+			expectedError: false,
+		},
+		{
+			name:          "memory missing",
+			spec:          `{"virtualMachine": {"template": {"cpus": "2", "primaryDisk": {"osImage": "http://images/ubuntu.img", "size": "20Gi", "storageClassName": "standard"}}}}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid disk size",
+			spec:          `{"virtualMachine": {"template": {"cpus": "2", "memory": "4Gi", "primaryDisk": {"osImage": "http://images/ubuntu.img", "size": "20 GB", "storageClassName": "standard"}}}}`,
+			expectedError: true,
+		},
+		{
+			name:          "OS image missing",
+			spec:          `{"virtualMachine": {"template": {"cpus": "2", "memory": "4Gi", "primaryDisk": {"size": "20Gi", "storageClassName": "standard"}}}}`,
+			expectedError: true,
+		},
+		{
+			name:          "storage class missing",
+			spec:          `{"virtualMachine": {"template": {"cpus": "2", "memory": "4Gi", "primaryDisk": {"osImage": "http://images/ubuntu.img", "size": "20Gi"}}}}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid json",
+			spec:          `{"virtualMachine": `,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateKubeVirtProviderSpec([]byte(tc.spec), field.NewPath("cloudProviderSpec"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateNutanixProviderSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		expectedError bool
+	}{
+		{
+			name:          "valid spec",
+			spec:          `{"clusterName": "cluster", "subnetName": "subnet", "imageName": "ubuntu-20.04", "cpus": 2, "memoryMB": 4096}`,
+			expectedError: false,
+		},
+		{
+			name:          "valid spec with optional fields",
+			spec:          `{"clusterName": "cluster", "projectName": "project", "subnetName": "subnet", "imageName": "ubuntu-20.04", "cpus": 2, "memoryMB": 4096, "diskSize": 40}`,
+			expectedError: false,
+		},
+		{
+			name:          "cluster name missing",
+			spec:          `{"subnetName": "subnet", "imageName": "ubuntu-20.04", "cpus": 2, "memoryMB": 4096}`,
+			expectedError: true,
+		},
+		{
+			name:          "subnet name missing",
+			spec:          `{"clusterName": "cluster", "imageName": "ubuntu-20.04", "cpus": 2, "memoryMB": 4096}`,
+			expectedError: true,
+		},
+		{
+			name:          "image name missing",
+			spec:          `{"clusterName": "cluster", "subnetName": "subnet", "cpus": 2, "memoryMB": 4096}`,
+			expectedError: true,
+		},
+		{
+			name:          "cpus missing",
+			spec:          `{"clusterName": "cluster", "subnetName": "subnet", "imageName": "ubuntu-20.04", "memoryMB": 4096}`,
+			expectedError: true,
+		},
+		{
+			name:          "negative memory",
+			spec:          `{"clusterName": "cluster", "subnetName": "subnet", "imageName": "ubuntu-20.04", "cpus": 2, "memoryMB": -1}`,
+			expectedError: true,
+		},
+		{
+			name:          "invalid json",
+			spec:          `{"clusterName": `,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateNutanixProviderSpec([]byte(tc.spec), field.NewPath("cloudProviderSpec"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateCABundle(t *testing.T) {
+	tests := []struct {
+		name          string
+		caBundle      string
+		expectedError bool
+	}{
+		{
+			name:          "empty",
+			caBundle:      "",
+			expectedError: false,
+		},
+		{
+			name: "correct",
+			caBundle: heredoc.Doc(`
+				## some comments
+
+				GlobalSign Root CA
+				==================
+				-----BEGIN CERTIFICATE-----
+				MIIDdTCCAl2gAwIBAgILBAAAAAABFUtaw5QwDQYJKoZIhvcNAQEFBQAwVzELMAkGA1UEBhMCQkUx
+				GTAXBgNVBAoTEEdsb2JhbFNpZ24gbnYtc2ExEDAOBgNVBAsTB1Jvb3QgQ0ExGzAZBgNVBAMTEkds
+				b2JhbFNpZ24gUm9vdCBDQTAeFw05ODA5MDExMjAwMDBaFw0yODAxMjgxMjAwMDBaMFcxCzAJBgNV
+				BAYTAkJFMRkwFwYDVQQKExBHbG9iYWxTaWduIG52LXNhMRAwDgYDVQQLEwdSb290IENBMRswGQYD
+				VQQDExJHbG9iYWxTaWduIFJvb3QgQ0EwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIBAQDa
+				DuaZjc6j40+Kfvvxi4Mla+pIH/EqsLmVEQS98GPR4mdmzxzdzxtIK+6NiY6arymAZavpxy0Sy6sc
+				THAHoT0KMM0VjU/43dSMUBUc71DuxC73/OlS8pF94G3VNTCOXkNz8kHp1Wrjsok6Vjk4bwY8iGlb
+				Kk3Fp1S4bInMm/k8yuX9ifUSPJJ4ltbcdG6TRGHRjcdGsnUOhugZitVtbNV4FpWi6cgKOOvyJBNP
+				c1STE4U6G7weNLWLBYy5d4ux2x8gkasJU26Qzns3dLlwR5EiUWMWea6xrkEmCMgZK9FGqkjWZCrX
+				gzT/LCrBbBlDSgeF59N89iFo7+ryUp9/k5DPAgMBAAGjQjBAMA4GA1UdDwEB/wQEAwIBBjAPBgNV
+				HRMBAf8EBTADAQH/MB0GA1UdDgQWBBRge2YaRQ2XyolQL30EzTSo//z9SzANBgkqhkiG9w0BAQUF
+				AAOCAQEA1nPnfE920I2/7LqivjTFKDK1fPxsnCwrvQmeU79rXqoRSLblCKOzyj1hTdNGCbM+w6Dj
+				Y1Ub8rrvrTnhQ7k4o+YviiY776BQVvnGCv04zcQLcFGUl5gE38NflNUVyRRBnMRddWQVDf9VMOyG
+				j/8N7yy5Y0b2qvzfvGn9LhJIZJrglfCm7ymPAbEVtQwdpf5pLGkkeB6zpxxxYu7KyJesF12KwvhH
+				hm4qxFYxldBniYUr+WymXUadDKqC5JlR3XC321Y9YeRq4VzW9v493kHMB65jUr9TU/Qr6cf9tveC
+				X4XSQRjbgbMEHMUfpIBvFSDJ3gyICh3WZlXi/EjJKSZp4A==
+				-----END CERTIFICATE-----
+			`),
+			expectedError: false,
+		},
+		{
+			name: "no certs but with comments",
+			caBundle: heredoc.Doc(`
+				# leading comment
+				## additional comment
+			`),
+			expectedError: true,
+		},
+		{
+			name:          "incorrect",
+			caBundle:      "garbadge",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateCABundle(tc.caBundle, field.NewPath("caBundle"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Logf("failed value:\n%q", tc.caBundle)
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateControlPlaneComponents(t *testing.T) {
+	tests := []struct {
+		name          string
+		components    *kubeone.ControlPlaneComponents
+		expectedError bool
+	}{
+		{
+			name:          "not configured",
+			components:    nil,
+			expectedError: false,
+		},
+		{
+			name: "valid api server config",
+			components: &kubeone.ControlPlaneComponents{
+				APIServer: &kubeone.APIServerConfig{
+					ExtraArgs: map[string]string{
+						"audit-log-maxage": "30",
+					},
+					EnableAdmissionPlugins:  []string{"AlwaysPullImages", "PodNodeSelector"},
+					DisableAdmissionPlugins: []string{"DefaultStorageClass"},
+					FeatureGates: map[string]bool{
+						"EphemeralContainers": true,
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "flag with leading dashes",
+			components: &kubeone.ControlPlaneComponents{
+				APIServer: &kubeone.APIServerConfig{
+					ExtraArgs: map[string]string{
+						"--audit-log-maxage": "30",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "admission plugins in extra args",
+			components: &kubeone.ControlPlaneComponents{
+				APIServer: &kubeone.APIServerConfig{
+					ExtraArgs: map[string]string{
+						"enable-admission-plugins": "AlwaysPullImages",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "feature gates in extra args",
+			components: &kubeone.ControlPlaneComponents{
+				APIServer: &kubeone.APIServerConfig{
+					ExtraArgs: map[string]string{
+						"feature-gates": "EphemeralContainers=true",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "admission plugin both enabled and disabled",
+			components: &kubeone.ControlPlaneComponents{
+				APIServer: &kubeone.APIServerConfig{
+					EnableAdmissionPlugins:  []string{"AlwaysPullImages"},
+					DisableAdmissionPlugins: []string{"AlwaysPullImages"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "empty admission plugin name",
+			components: &kubeone.ControlPlaneComponents{
+				APIServer: &kubeone.APIServerConfig{
+					EnableAdmissionPlugins: []string{""},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid controller manager and scheduler config",
+			components: &kubeone.ControlPlaneComponents{
+				ControllerManager: &kubeone.ControlPlaneComponentConfig{
+					ExtraArgs: map[string]string{
+						"node-cidr-mask-size": "25",
+					},
+					FeatureGates: map[string]bool{
+						"EphemeralContainers": true,
+					},
+				},
+				Scheduler: &kubeone.ControlPlaneComponentConfig{
+					ExtraArgs: map[string]string{
+						"config": "/etc/kubernetes/scheduler/config.yaml",
+					},
+					ExtraVolumes: []kubeone.ControlPlaneComponentVolume{
+						{
+							Name:      "scheduler-config",
+							HostPath:  "/etc/kubernetes/scheduler",
+							MountPath: "/etc/kubernetes/scheduler",
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "feature gates in controller manager extra args",
+			components: &kubeone.ControlPlaneComponents{
+				ControllerManager: &kubeone.ControlPlaneComponentConfig{
+					ExtraArgs: map[string]string{
+						"feature-gates": "EphemeralContainers=true",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "scheduler volume with relative host path",
+			components: &kubeone.ControlPlaneComponents{
+				Scheduler: &kubeone.ControlPlaneComponentConfig{
+					ExtraVolumes: []kubeone.ControlPlaneComponentVolume{
+						{
+							Name:      "scheduler-config",
+							HostPath:  "scheduler",
+							MountPath: "/etc/kubernetes/scheduler",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicated scheduler volume names",
+			components: &kubeone.ControlPlaneComponents{
+				Scheduler: &kubeone.ControlPlaneComponentConfig{
+					ExtraVolumes: []kubeone.ControlPlaneComponentVolume{
+						{
+							Name:      "scheduler-config",
+							HostPath:  "/etc/kubernetes/scheduler",
+							MountPath: "/etc/kubernetes/scheduler",
+						},
+						{
+							Name:      "scheduler-config",
+							HostPath:  "/etc/kubernetes/policies",
+							MountPath: "/etc/kubernetes/policies",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid feature gate name",
+			components: &kubeone.ControlPlaneComponents{
+				APIServer: &kubeone.APIServerConfig{
+					FeatureGates: map[string]bool{
+						"A=true,B": true,
+					},
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateControlPlaneComponents(tc.components, field.NewPath("controlPlaneComponents"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateFeatures(t *testing.T) {
+	tests := []struct {
+		name          string
+		features      kubeone.Features
+		versions      kubeone.VersionConfig
+		expectedError bool
+	}{
+		{
+			name: "psp and auditing enabled",
+			features: kubeone.Features{
+				PodSecurityPolicy: &kubeone.PodSecurityPolicy{
+					Enable: true,
+				},
+				DynamicAuditLog: &kubeone.DynamicAuditLog{
+					Enable: true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name: "metrics server disabled",
+			features: kubeone.Features{
+				MetricsServer: &kubeone.MetricsServer{
+					Enable: false,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name:     "no feature configured",
+			features: kubeone.Features{},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name: "oidc enabled",
+			features: kubeone.Features{
+				OpenIDConnect: &kubeone.OpenIDConnect{
+					Enable: true,
+					Config: kubeone.OpenIDConnectConfig{
+						IssuerURL:     "test.cluster.local",
+						ClientID:      "123",
+						RequiredClaim: "test",
+					},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid staticAudit config",
+			features: kubeone.Features{
+				StaticAuditLog: &kubeone.StaticAuditLog{
+					Enable: true,
+					Config: kubeone.StaticAuditLogConfig{},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid oidc config",
+			features: kubeone.Features{
+				OpenIDConnect: &kubeone.OpenIDConnect{
+					Enable: true,
+					Config: kubeone.OpenIDConnectConfig{},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid podNodeSelector config",
+			features: kubeone.Features{
+				PodNodeSelector: &kubeone.PodNodeSelector{
+					Enable: true,
+					Config: kubeone.PodNodeSelectorConfig{},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "podPresets enabled on 1.19 cluster",
+			features: kubeone.Features{
+				PodPresets: &kubeone.PodPresets{
+					Enable: true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.19.7",
+			},
+			expectedError: false,
+		},
+		{
+			name: "podPresets enabled on 1.20 cluster",
+			features: kubeone.Features{
+				PodPresets: &kubeone.PodPresets{
+					Enable: true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "podPresets enabled on 1.21 cluster",
+			features: kubeone.Features{
+				PodPresets: &kubeone.PodPresets{
+					Enable: true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: true,
+		},
+		{
+			name: "admission hardening enabled",
+			features: kubeone.Features{
+				AlwaysPullImages: &kubeone.AlwaysPullImages{
+					Enable: true,
+				},
+				DenyServiceExternalIPs: &kubeone.DenyServiceExternalIPs{
+					Enable: true,
+				},
+				NodeRestriction: &kubeone.NodeRestriction{
+					Enable: true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: false,
+		},
+		{
+			name: "denyServiceExternalIPs enabled on 1.20 cluster",
+			features: kubeone.Features{
+				DenyServiceExternalIPs: &kubeone.DenyServiceExternalIPs{
+					Enable: true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.20.2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "appArmor enabled",
+			features: kubeone.Features{
+				AppArmor: &kubeone.AppArmor{
+					Enable: true,
+					Profiles: []kubeone.AppArmorProfile{
+						{
+							Name:            "k8s-nginx",
+							ProfileFilePath: "apparmor/k8s-nginx",
+						},
+					},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: false,
+		},
+		{
+			name: "appArmor profile without path",
+			features: kubeone.Features{
+				AppArmor: &kubeone.AppArmor{
+					Enable: true,
+					Profiles: []kubeone.AppArmorProfile{
+						{
+							Name: "k8s-nginx",
+						},
+					},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: true,
+		},
+		{
+			name: "appArmor profile with duplicated name",
+			features: kubeone.Features{
+				AppArmor: &kubeone.AppArmor{
+					Enable: true,
+					Profiles: []kubeone.AppArmorProfile{
+						{
+							Name:            "k8s-nginx",
+							ProfileFilePath: "apparmor/k8s-nginx",
+						},
+						{
+							Name:            "k8s-nginx",
+							ProfileFilePath: "apparmor/k8s-nginx-v2",
+						},
+					},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: true,
+		},
+		{
+			name: "seccompDefault enabled on 1.21 cluster",
+			features: kubeone.Features{
+				SeccompDefault: &kubeone.SeccompDefault{
+					Enable: true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: true,
+		},
+		{
+			name: "seccompDefault enabled on 1.22 cluster",
+			features: kubeone.Features{
+				SeccompDefault: &kubeone.SeccompDefault{
+					Enable: true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: false,
+		},
+		{
+			name: "podSecurityAdmission enabled on 1.21 cluster",
+			features: kubeone.Features{
+				PodSecurityAdmission: &kubeone.PodSecurityAdmission{
+					Enable: true,
+					Config: kubeone.PodSecurityAdmissionConfig{
+						Enforce:        "baseline",
+						EnforceVersion: "latest",
+						Audit:          "restricted",
+						AuditVersion:   "latest",
+						Warn:           "restricted",
+						WarnVersion:    "latest",
+					},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: true,
+		},
+		{
+			name: "podSecurityAdmission enabled on 1.22 cluster without feature gate",
+			features: kubeone.Features{
+				PodSecurityAdmission: &kubeone.PodSecurityAdmission{
+					Enable: true,
+					Config: kubeone.PodSecurityAdmissionConfig{
+						Enforce:        "baseline",
+						EnforceVersion: "latest",
+						Audit:          "restricted",
+						AuditVersion:   "latest",
+						Warn:           "restricted",
+						WarnVersion:    "latest",
+					},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: true,
+		},
+		{
+			name: "podSecurityAdmission enabled on 1.22 cluster",
+			features: kubeone.Features{
+				PodSecurityAdmission: &kubeone.PodSecurityAdmission{
+					Enable: true,
+					Config: kubeone.PodSecurityAdmissionConfig{
+						Enforce:        "baseline",
+						EnforceVersion: "latest",
+						Audit:          "restricted",
+						AuditVersion:   "latest",
+						Warn:           "restricted",
+						WarnVersion:    "latest",
+					},
+				},
+				FeatureGates: map[string]bool{
+					"PodSecurity": true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.22.1",
+			},
+			expectedError: false,
+		},
+		{
+			name: "bootstrapRBAC enabled with oidc",
+			features: kubeone.Features{
+				OpenIDConnect: &kubeone.OpenIDConnect{
+					Enable: true,
+					Config: kubeone.OpenIDConnectConfig{
+						IssuerURL: "test.cluster.local",
+						ClientID:  "123",
+					},
+				},
+				BootstrapRBAC: &kubeone.BootstrapRBAC{
+					Enable:  true,
+					Viewers: []string{"developers"},
+					NamespaceAdmins: []kubeone.NamespaceAdmins{
+						{
+							Namespace: "team-a",
+							Groups:    []string{"team-a-admins"},
+						},
+					},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: false,
+		},
+		{
+			name: "bootstrapRBAC enabled without oidc",
+			features: kubeone.Features{
+				BootstrapRBAC: &kubeone.BootstrapRBAC{
+					Enable:  true,
+					Viewers: []string{"developers"},
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: true,
+		},
+		{
+			name: "fips enabled with aescbc encryption",
+			features: kubeone.Features{
+				FIPS: &kubeone.FIPS{
+					Enable: true,
+				},
+				EncryptionProviders: &kubeone.EncryptionProviders{
+					Enable:                        true,
+					CustomEncryptionConfiguration: fipsEncryptionConfiguration("aescbc", "secretbox-replacement"),
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: false,
+		},
+		{
+			name: "fips enabled with secretbox encryption",
+			features: kubeone.Features{
+				FIPS: &kubeone.FIPS{
+					Enable: true,
+				},
+				EncryptionProviders: &kubeone.EncryptionProviders{
+					Enable:                        true,
+					CustomEncryptionConfiguration: fipsEncryptionConfiguration("secretbox", "key1"),
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: true,
+		},
+		{
+			name: "fips enabled with invalid encryption configuration",
+			features: kubeone.Features{
+				FIPS: &kubeone.FIPS{
+					Enable: true,
+				},
+				EncryptionProviders: &kubeone.EncryptionProviders{
+					Enable:                        true,
+					CustomEncryptionConfiguration: "aescbc",
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: true,
+		},
+		{
+			name: "feature gates",
+			features: kubeone.Features{
+				FeatureGates: map[string]bool{
+					"EphemeralContainers":      true,
+					"VolumeSnapshotDataSource": true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: false,
+		},
+		{
+			name: "feature gate removed in the kubernetes version",
+			features: kubeone.Features{
+				FeatureGates: map[string]bool{
+					"VolumeSnapshotDataSource": true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.22.0",
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid feature gate name",
+			features: kubeone.Features{
+				FeatureGates: map[string]bool{
+					"EphemeralContainers=true": true,
+				},
+			},
+			versions: kubeone.VersionConfig{
+				Kubernetes: "1.21.0",
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateFeatures(tc.features, tc.versions, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidatePodNodeSelectorConfig(t *testing.T) {
+	tests := []struct {
+		name                  string
+		podNodeSelectorConfig kubeone.PodNodeSelectorConfig
+		expectedError         bool
+	}{
+		{
+			name: "valid podNodeSelector config",
+			podNodeSelectorConfig: kubeone.PodNodeSelectorConfig{
+				ConfigFilePath: "./podnodeselector.yaml",
+			},
+			expectedError: false,
+		},
+		{
+			name:                  "invalid podNodeSelector config",
+			podNodeSelectorConfig: kubeone.PodNodeSelectorConfig{},
+			expectedError:         true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidatePodNodeSelectorConfig(tc.podNodeSelectorConfig, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateEventRateLimitConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        kubeone.EventRateLimitConfig
+		expectedError bool
+	}{
+		{
+			name: "valid eventRateLimit config",
+			config: kubeone.EventRateLimitConfig{
+				Limits: []kubeone.EventRateLimitLimit{
+					{Type: kubeone.EventRateLimitTypeServer, QPS: 50, Burst: 100},
+					{Type: kubeone.EventRateLimitTypeNamespace, QPS: 50, Burst: 100, CacheSize: 2000},
+					{Type: kubeone.EventRateLimitTypeUser, QPS: 10, Burst: 50},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name:          "no limits",
+			config:        kubeone.EventRateLimitConfig{},
+			expectedError: true,
+		},
+		{
+			name: "unknown limit type",
+			config: kubeone.EventRateLimitConfig{
+				Limits: []kubeone.EventRateLimitLimit{{Type: "Cluster", QPS: 50, Burst: 100}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "duplicate limit type",
+			config: kubeone.EventRateLimitConfig{
+				Limits: []kubeone.EventRateLimitLimit{
+					{Type: kubeone.EventRateLimitTypeUser, QPS: 10, Burst: 50},
+					{Type: kubeone.EventRateLimitTypeUser, QPS: 20, Burst: 50},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "zero qps",
+			config: kubeone.EventRateLimitConfig{
+				Limits: []kubeone.EventRateLimitLimit{{Type: kubeone.EventRateLimitTypeServer, Burst: 100}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "zero burst",
+			config: kubeone.EventRateLimitConfig{
+				Limits: []kubeone.EventRateLimitLimit{{Type: kubeone.EventRateLimitTypeServer, QPS: 50}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "cache size for the server limit",
+			config: kubeone.EventRateLimitConfig{
+				Limits: []kubeone.EventRateLimitLimit{{Type: kubeone.EventRateLimitTypeServer, QPS: 50, Burst: 100, CacheSize: 2000}},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateEventRateLimitConfig(tc.config, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateSRIOV(t *testing.T) {
+	hosts := []kubeone.HostConfig{
+		{PublicAddress: "192.168.1.1", PrivateAddress: "10.0.0.1"},
+		{PublicAddress: "192.168.1.2", PrivateAddress: "10.0.0.2", Hostname: "worker-1"},
+	}
+	validPF := []kubeone.SRIOVPhysicalFunction{{Interface: "ens1f0", NumVFs: 8, ResourceName: "sriov_ens1f0"}}
+
+	tests := []struct {
+		name          string
+		sriov         kubeone.SRIOV
+		expectedError bool
+	}{
+		{
+			name: "valid sriov config",
+			sriov: kubeone.SRIOV{
+				KernelParameters: []string{"intel_iommu=on", "iommu=pt"},
+				Hosts: []kubeone.SRIOVHost{
+					{Address: "10.0.0.1", PhysicalFunctions: validPF},
+					{Address: "worker-1", PhysicalFunctions: validPF},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid sriov config (unknown host)",
+			sriov: kubeone.SRIOV{
+				Hosts: []kubeone.SRIOVHost{{Address: "10.0.0.3", PhysicalFunctions: validPF}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid sriov config (host configured twice)",
+			sriov: kubeone.SRIOV{
+				Hosts: []kubeone.SRIOVHost{
+					{Address: "10.0.0.2", PhysicalFunctions: validPF},
+					{Address: "worker-1", PhysicalFunctions: validPF},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid sriov config (no physical functions)",
+			sriov: kubeone.SRIOV{
+				Hosts: []kubeone.SRIOVHost{{Address: "10.0.0.1"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid sriov config (no virtual functions)",
+			sriov: kubeone.SRIOV{
+				Hosts: []kubeone.SRIOVHost{{Address: "10.0.0.1", PhysicalFunctions: []kubeone.SRIOVPhysicalFunction{
+					{Interface: "ens1f0", ResourceName: "sriov_ens1f0"},
+				}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid sriov config (invalid interface)",
+			sriov: kubeone.SRIOV{
+				Hosts: []kubeone.SRIOVHost{{Address: "10.0.0.1", PhysicalFunctions: []kubeone.SRIOVPhysicalFunction{
+					{Interface: "ens1f0; reboot", NumVFs: 8, ResourceName: "sriov_ens1f0"},
+				}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid sriov config (invalid resource name)",
+			sriov: kubeone.SRIOV{
+				Hosts: []kubeone.SRIOVHost{{Address: "10.0.0.1", PhysicalFunctions: []kubeone.SRIOVPhysicalFunction{
+					{Interface: "ens1f0", NumVFs: 8, ResourceName: "intel.com/sriov"},
+				}}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid sriov config (invalid kernel parameter)",
+			sriov: kubeone.SRIOV{
+				KernelParameters: []string{"intel_iommu=on iommu=pt"},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateSRIOV(tc.sriov, hosts, field.NewPath("sriov"))
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateStaticAuditLogConfig(t *testing.T) {
+	tests := []struct {
+		name                 string
+		staticAuditLogConfig kubeone.StaticAuditLogConfig
+		expectedError        bool
+	}{
+		{
+			name: "valid staticAuditLog config",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath: "/etc/kubernetes/policy.yaml",
+				LogPath:        "/var/log/kubernetes",
+				LogMaxAge:      10,
+				LogMaxBackup:   10,
+				LogMaxSize:     100,
+			},
+			expectedError: false,
+		},
+		{
+			name: "policy file path missing",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				LogPath:      "/var/log/kubernetes",
+				LogMaxAge:    10,
+				LogMaxBackup: 10,
+				LogMaxSize:   100,
+			},
+			expectedError: true,
+		},
+		{
+			name: "log file path missing",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath: "/etc/kubernetes/policy.yaml",
+				LogMaxAge:      10,
+				LogMaxBackup:   10,
+				LogMaxSize:     100,
+			},
+			expectedError: true,
+		},
+		{
+			name: "log max age set to 0",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath: "/etc/kubernetes/policy.yaml",
+				LogPath:        "/var/log/kubernetes",
+				LogMaxAge:      0,
+				LogMaxBackup:   10,
+				LogMaxSize:     100,
+			},
+			expectedError: true,
+		},
+		{
+			name: "log max backup set to 0",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath: "/etc/kubernetes/policy.yaml",
+				LogPath:        "/var/log/kubernetes",
+				LogMaxAge:      10,
+				LogMaxBackup:   0,
+				LogMaxSize:     100,
+			},
+			expectedError: true,
+		},
+		{
+			name: "log max size set to 0",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath: "/etc/kubernetes/policy.yaml",
+				LogPath:        "/var/log/kubernetes",
+				LogMaxAge:      10,
+				LogMaxBackup:   10,
+				LogMaxSize:     0,
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid webhook config",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath: "/etc/kubernetes/policy.yaml",
+				LogPath:        "/var/log/kubernetes",
+				LogMaxAge:      10,
+				LogMaxBackup:   10,
+				LogMaxSize:     100,
+				Webhook: &kubeone.AuditWebhookConfig{
+					ConfigFilePath: "./audit-webhook.yaml",
+					Mode:           "batch",
+					BatchMaxSize:   100,
+					BatchMaxWait:   metav1.Duration{Duration: 5 * time.Second},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "webhook only",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath:    "/etc/kubernetes/policy.yaml",
+				DisableLogBackend: true,
+				Webhook: &kubeone.AuditWebhookConfig{
+					ConfigFilePath: "./audit-webhook.yaml",
+					Mode:           "blocking",
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "log backend disabled without webhook",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath:    "/etc/kubernetes/policy.yaml",
+				DisableLogBackend: true,
+			},
+			expectedError: true,
+		},
+		{
+			name: "webhook config file path missing",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath:    "/etc/kubernetes/policy.yaml",
+				DisableLogBackend: true,
+				Webhook:           &kubeone.AuditWebhookConfig{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported webhook mode",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath:    "/etc/kubernetes/policy.yaml",
+				DisableLogBackend: true,
+				Webhook: &kubeone.AuditWebhookConfig{
+					ConfigFilePath: "./audit-webhook.yaml",
+					Mode:           "async",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "batch options in blocking mode",
+			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
+				PolicyFilePath:    "/etc/kubernetes/policy.yaml",
+				DisableLogBackend: true,
+				Webhook: &kubeone.AuditWebhookConfig{
+					ConfigFilePath: "./audit-webhook.yaml",
+					Mode:           "blocking",
+					BatchMaxSize:   100,
+				},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateStaticAuditLogConfig(tc.staticAuditLogConfig, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateEncryptionProvidersKMS(t *testing.T) {
+	tests := []struct {
+		name          string
+		kms           kubeone.EncryptionProvidersKMS
+		expectedError bool
+	}{
+		{
+			name: "valid kms config without plugin",
+			kms: kubeone.EncryptionProvidersKMS{
+				Name:     "kms",
+				Endpoint: "/var/run/kmsplugin/socket.sock",
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid kms config with aws plugin",
+			kms: kubeone.EncryptionProvidersKMS{
+				Name:     "kms",
+				Endpoint: "/var/run/kmsplugin/socket.sock",
+				Plugin: &kubeone.KMSPlugin{
+					AWS: &kubeone.AWSKMSPlugin{
+						KeyARN: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd",
+						Region: "eu-west-1",
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "no name",
+			kms: kubeone.EncryptionProvidersKMS{
+				Endpoint: "/var/run/kmsplugin/socket.sock",
+			},
+			expectedError: true,
+		},
+		{
+			name: "relative endpoint",
+			kms: kubeone.EncryptionProvidersKMS{
+				Name:     "kms",
+				Endpoint: "unix:///var/run/kmsplugin/socket.sock",
+			},
+			expectedError: true,
+		},
+		{
+			name: "negative cache size",
+			kms: kubeone.EncryptionProvidersKMS{
+				Name:      "kms",
+				Endpoint:  "/var/run/kmsplugin/socket.sock",
+				CacheSize: -1,
+			},
+			expectedError: true,
+		},
+		{
+			name: "plugin without provider",
+			kms: kubeone.EncryptionProvidersKMS{
+				Name:     "kms",
+				Endpoint: "/var/run/kmsplugin/socket.sock",
+				Plugin:   &kubeone.KMSPlugin{},
+			},
+			expectedError: true,
+		},
+		{
+			name: "multiple plugin providers",
+			kms: kubeone.EncryptionProvidersKMS{
+				Name:     "kms",
+				Endpoint: "/var/run/kmsplugin/socket.sock",
+				Plugin: &kubeone.KMSPlugin{
+					AWS: &kubeone.AWSKMSPlugin{
+						KeyARN: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd",
+						Region: "eu-west-1",
+					},
+					GCP: &kubeone.GCPKMSPlugin{
+						KeyURI: "projects/kubeone/locations/global/keyRings/kubeone/cryptoKeys/secrets",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "azure plugin without key version",
+			kms: kubeone.EncryptionProvidersKMS{
+				Name:     "kms",
+				Endpoint: "/var/run/kmsplugin/socket.sock",
+				Plugin: &kubeone.KMSPlugin{
+					Azure: &kubeone.AzureKMSPlugin{
+						KeyVaultName: "vault",
+						KeyName:      "key",
+					},
+				},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateEncryptionProvidersKMS(tc.kms, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidatePodSecurityAdmissionConfig(t *testing.T) {
+	valid := kubeone.PodSecurityAdmissionConfig{
+		Enforce:        "baseline",
+		EnforceVersion: "v1.22",
+		Audit:          "restricted",
+		AuditVersion:   "latest",
+		Warn:           "restricted",
+		WarnVersion:    "latest",
+		Exemptions: kubeone.PodSecurityAdmissionExemptions{
+			Namespaces: []string{"kube-system"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		update        func(*kubeone.PodSecurityAdmissionConfig)
+		expectedError bool
+	}{
+		{
+			name:          "valid config",
+			update:        func(*kubeone.PodSecurityAdmissionConfig) {},
+			expectedError: false,
+		},
+		{
+			name:          "invalid level",
+			update:        func(c *kubeone.PodSecurityAdmissionConfig) { c.Enforce = "strict" },
+			expectedError: true,
+		},
+		{
+			name:          "empty level",
+			update:        func(c *kubeone.PodSecurityAdmissionConfig) { c.Warn = "" },
+			expectedError: true,
+		},
+		{
+			name:          "invalid version",
+			update:        func(c *kubeone.PodSecurityAdmissionConfig) { c.AuditVersion = "1.22" },
+			expectedError: true,
+		},
+		{
+			name:          "empty exemption",
+			update:        func(c *kubeone.PodSecurityAdmissionConfig) { c.Exemptions.Usernames = []string{""} },
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid
+			tc.update(&config)
+
+			errs := ValidatePodSecurityAdmissionConfig(config, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateNodeLocalDNSConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        kubeone.NodeLocalDNSConfig
+		expectedError bool
+	}{
+		{
+			name: "valid config",
+			config: kubeone.NodeLocalDNSConfig{
+				VirtualIP:       "169.254.20.10",
+				VirtualIPv6:     "fd00::10",
+				UpstreamServers: []string{"8.8.8.8", "1.1.1.1:53", "[2001:4860:4860::8888]:53"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "ipv6 virtual ip",
+			config: kubeone.NodeLocalDNSConfig{
+				VirtualIP: "fd00::10",
+			},
+			expectedError: true,
+		},
+		{
+			name: "ipv4 virtual ipv6",
+			config: kubeone.NodeLocalDNSConfig{
+				VirtualIPv6: "169.254.20.10",
+			},
+			expectedError: true,
+		},
+		{
+			name: "hostname upstream server",
+			config: kubeone.NodeLocalDNSConfig{
+				UpstreamServers: []string{"dns.google"},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateNodeLocalDNSConfig(tc.config, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateKonnectivityConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        kubeone.KonnectivityConfig
+		expectedError bool
+	}{
+		{
+			name: "valid config",
+			config: kubeone.KonnectivityConfig{
+				ServerAddress: "api.example.com",
+				AgentPort:     8132,
+			},
+			expectedError: false,
+		},
+		{
+			name: "missing server address",
+			config: kubeone.KonnectivityConfig{
+				AgentPort: 8132,
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid agent port",
+			config: kubeone.KonnectivityConfig{
+				ServerAddress: "api.example.com",
+				AgentPort:     70000,
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateKonnectivityConfig(tc.config, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateGatekeeperConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        kubeone.GatekeeperConfig
+		expectedError bool
+	}{
+		{
+			name: "valid config",
+			config: kubeone.GatekeeperConfig{
+				AuditInterval:     60,
+				ConstraintLibrary: true,
+			},
+			expectedError: false,
+		},
+		{
+			name: "negative audit interval",
+			config: kubeone.GatekeeperConfig{
+				AuditInterval: -1,
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateGatekeeperConfig(tc.config, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateOIDCConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		oidcConfig    kubeone.OpenIDConnectConfig
+		expectedError bool
+	}{
+		{
+			name: "valid oidc config",
+			oidcConfig: kubeone.OpenIDConnectConfig{
+				IssuerURL: "test.cluster.local",
+				ClientID:  "test",
+			},
+			expectedError: false,
+		},
+		{
+			name: "no issuer url",
+			oidcConfig: kubeone.OpenIDConnectConfig{
+				ClientID: "test",
+			},
+			expectedError: true,
+		},
+		{
+			name: "no client id",
+			oidcConfig: kubeone.OpenIDConnectConfig{
+				IssuerURL: "test.cluster.local",
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateOIDCConfig(tc.oidcConfig, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateAddons(t *testing.T) {
+	tests := []struct {
+		name          string
+		addons        *kubeone.Addons
+		expectedError bool
+	}{
+		{
+			name: "valid addons config (enabled)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+			},
+			expectedError: false,
+		},
+		{
+			name: "addons enabled, no path set",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "",
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid addons config (disabled)",
+			addons: &kubeone.Addons{
+				Enable: false,
+			},
+			expectedError: false,
+		},
+		{
+			name:          "valid addons config (empty)",
+			addons:        &kubeone.Addons{},
+			expectedError: false,
+		},
+		{
+			name:          "valid addons config (nil)",
+			addons:        nil,
+			expectedError: false,
+		},
+		{
+			name: "invalid addons config (enabled without path)",
+			addons: &kubeone.Addons{
+				Enable: true,
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid addons dependencies",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+				Addons: []kubeone.Addon{
+					{
+						Name:      "cert-manager",
+						Priority:  -10,
+						WaitReady: true,
+					},
+					{
+						Name:      "issuers",
+						DependsOn: []string{"cert-manager"},
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid addon depending on itself",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+				Addons: []kubeone.Addon{
+					{
+						Name:      "issuers",
+						DependsOn: []string{"issuers"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid deleted addon with dependencies",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+				Addons: []kubeone.Addon{
+					{
+						Name:      "issuers",
+						Delete:    true,
+						DependsOn: []string{"cert-manager"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid addons config with sources only",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						Git: &kubeone.GitAddonSource{
+							Repository: "https://github.com/example/addons.git",
+							Commit:     "0123456789abcdef0123456789abcdef01234567",
+							Path:       "manifests",
+						},
+					},
+					{
+						Git: &kubeone.GitAddonSource{
+							Repository: "git@github.com:example/addons.git",
+							Commit:     "0123456789abcdef0123456789abcdef01234567",
+						},
+					},
+					{
+						HTTP: &kubeone.HTTPAddonSource{
+							URL:    "https://example.com/addons.tar.gz",
+							SHA256: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+						},
+					},
+					{
+						S3: &kubeone.S3AddonSource{
+							Bucket: "addons",
+							Key:    "addons.tar.gz",
+							SHA256: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+						},
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid addon source (git without commit)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						Git: &kubeone.GitAddonSource{
+							Repository: "https://github.com/example/addons.git",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (git with branch instead of commit)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						Git: &kubeone.GitAddonSource{
+							Repository: "https://github.com/example/addons.git",
+							Commit:     "main",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (git with ext transport)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						Git: &kubeone.GitAddonSource{
+							Repository: "ext::sh -c touch% /tmp/pwned",
+							Commit:     "0123456789abcdef0123456789abcdef01234567",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (git repository as option)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						Git: &kubeone.GitAddonSource{
+							Repository: "--upload-pack=touch /tmp/pwned",
+							Commit:     "0123456789abcdef0123456789abcdef01234567",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (git path with parent element)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						Git: &kubeone.GitAddonSource{
+							Repository: "https://github.com/example/addons.git",
+							Commit:     "0123456789abcdef0123456789abcdef01234567",
+							Path:       "manifests/../../etc",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (git path with parent element staying within the source)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						Git: &kubeone.GitAddonSource{
+							Repository: "https://github.com/example/addons.git",
+							Commit:     "0123456789abcdef0123456789abcdef01234567",
+							Path:       "manifests/../addons",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (no source type)",
+			addons: &kubeone.Addons{
+				Enable:  true,
+				Sources: []kubeone.AddonSource{{}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (multiple source types)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						Git: &kubeone.GitAddonSource{
+							Repository: "https://github.com/example/addons.git",
+						},
+						HTTP: &kubeone.HTTPAddonSource{
+							URL:    "https://example.com/addons.tar.gz",
+							SHA256: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (http without checksum)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						HTTP: &kubeone.HTTPAddonSource{
+							URL: "https://example.com/addons.tar.gz",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (plain http URL)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						HTTP: &kubeone.HTTPAddonSource{
+							URL:    "http://example.com/addons.tar.gz",
+							SHA256: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid addon source (path outside of the source)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Sources: []kubeone.AddonSource{
+					{
+						S3: &kubeone.S3AddonSource{
+							Bucket: "addons",
+							Key:    "addons.tar.gz",
+							SHA256: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+							Path:   "../addons",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid helm addon",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+				Addons: []kubeone.Addon{
+					{
+						Name: "ingress-nginx",
+						Helm: &kubeone.HelmChart{
+							Chart:     "ingress-nginx",
+							RepoURL:   "https://kubernetes.github.io/ingress-nginx",
+							Version:   "4.0.13",
+							Namespace: "ingress-nginx",
+							Values:    "controller:\n  replicaCount: 2\n",
+						},
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid helm addon (oci)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+				Addons: []kubeone.Addon{
+					{
+						Name: "podinfo",
+						Helm: &kubeone.HelmChart{
+							Chart:   "oci://ghcr.io/stefanprodan/charts/podinfo",
+							Version: "6.0.3",
+						},
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid helm addon (no chart)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+				Addons: []kubeone.Addon{
+					{
+						Name: "ingress-nginx",
+						Helm: &kubeone.HelmChart{
+							RepoURL: "https://kubernetes.github.io/ingress-nginx",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid helm addon (repoURL with oci)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+				Addons: []kubeone.Addon{
+					{
+						Name: "podinfo",
+						Helm: &kubeone.HelmChart{
+							Chart:   "oci://ghcr.io/stefanprodan/charts/podinfo",
+							RepoURL: "https://stefanprodan.github.io/podinfo",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid helm addon (params)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+				Addons: []kubeone.Addon{
+					{
+						Name:   "ingress-nginx",
+						Params: map[string]string{"key": "value"},
+						Helm: &kubeone.HelmChart{
+							Chart:   "ingress-nginx",
+							RepoURL: "https://kubernetes.github.io/ingress-nginx",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid helm addon (values)",
+			addons: &kubeone.Addons{
+				Enable: true,
+				Path:   "./addons",
+				Addons: []kubeone.Addon{
+					{
+						Name: "ingress-nginx",
+						Helm: &kubeone.HelmChart{
+							Chart:   "ingress-nginx",
+							RepoURL: "https://kubernetes.github.io/ingress-nginx",
+							Values:  "- not a map",
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateAddons(tc.addons, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Log(errs[0])
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateHostConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		hostConfig    []kubeone.HostConfig
+		expectedError bool
+	}{
+		{
+			name: "host config with ip addresses",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "host config with dns domain",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "cluster-test.public.local",
+					PrivateAddress:    "cluster-test.private.local",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "host config with ipv6 addresses",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					IPv6Addresses:     []string{"fd00::1"},
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "ipv4 address in ipv6 addresses",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					IPv6Addresses:     []string{"192.168.0.2"},
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "no public address provided",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "",
+					PrivateAddress:    "cluster-test.private.local",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "no private address provided",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "cluster-test.public.local",
+					PrivateAddress:    "",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "no private key file and agent provided",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "cluster-test.public.local",
+					PrivateAddress:    "cluster-test.private.local",
+					SSHPrivateKeyFile: "",
+					SSHAgentSocket:    "",
+					SSHUsername:       "root",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "no username provided",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "cluster-test.public.local",
+					PrivateAddress:    "cluster-test.private.local",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "one valid host config and one invalid host config (no username)",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+				},
+				{
+					PublicAddress:     "cluster-test.public.local",
+					PrivateAddress:    "cluster-test.private.local",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "host config with environment variables",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+					Env:               map[string]string{"HTTP_PROXY": "http://proxy.local:3128", "_VENDOR_PATH": "/opt/vendor"},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "invalid environment variable name",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+					Env:               map[string]string{"VENDOR-PATH": "/opt/vendor"},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "two leaders at the same time",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+					IsLeader:          true,
+				},
+				{
+					PublicAddress:     "cluster-test.public.local",
+					PrivateAddress:    "cluster-test.private.local",
+					SSHPrivateKeyFile: "test",
+					SSHAgentSocket:    "test",
+					SSHUsername:       "root",
+					IsLeader:          true,
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "host config with bastions chain",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHUsername:       "root",
+					Bastions: []kubeone.BastionConfig{
+						{Host: "bastion-1.example.com", Port: 22, User: "jump"},
+						{Host: "10.0.0.5", Port: 2222, User: "root", SSHPrivateKeyFile: "inner"},
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "host config with bastion and bastions",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHUsername:       "root",
+					Bastion:           "bastion-1.example.com",
+					Bastions: []kubeone.BastionConfig{
+						{Host: "10.0.0.5", Port: 22, User: "root"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "host config with bastion without host",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHUsername:       "root",
+					Bastions: []kubeone.BastionConfig{
+						{Host: "bastion-1.example.com", Port: 22, User: "root"},
+						{Port: 22, User: "root"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "host config with bastion with invalid port",
+			hostConfig: []kubeone.HostConfig{
+				{
+					PublicAddress:     "192.168.1.1",
+					PrivateAddress:    "192.168.0.1",
+					SSHPrivateKeyFile: "test",
+					SSHUsername:       "root",
+					Bastions: []kubeone.BastionConfig{
+						{Host: "bastion-1.example.com", Port: 70000, User: "root"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateHostConfig(tc.hostConfig, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateHostNetworkOverrides(t *testing.T) {
+	tests := []struct {
+		name          string
+		overrides     *kubeone.HostNetworkOverrides
+		controlPlane  bool
+		kubeVersion   string
+		expectedError bool
+	}{
+		{
+			name:          "no overrides",
+			controlPlane:  true,
+			kubeVersion:   "1.22.1",
+			expectedError: false,
+		},
+		{
+			name: "valid control plane overrides",
+			overrides: &kubeone.HostNetworkOverrides{
+				APIServerAdvertiseAddress: "10.1.0.1",
+				EtcdListenClientURLs:      []string{"https://127.0.0.1:2379", "https://10.1.0.1:2379"},
+				EtcdAdvertiseClientURLs:   []string{"https://10.1.0.1:2379"},
+				EtcdListenPeerURLs:        []string{"https://10.1.0.1:2380"},
+				KubeletNodeIP:             "10.2.0.1",
+			},
+			controlPlane:  true,
+			kubeVersion:   "1.22.1",
+			expectedError: false,
 		},
-	}
-	for _, tc := range tests {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidatePodNodeSelectorConfig(tc.podNodeSelectorConfig, nil)
-			if (len(errs) == 0) == tc.expectedError {
-				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
-			}
-		})
-	}
-}
-
-func TestValidateStaticAuditLogConfig(t *testing.T) {
-	tests := []struct {
-		name                 string
-		staticAuditLogConfig kubeone.StaticAuditLogConfig
-		expectedError        bool
-	}{
 		{
-			name: "valid staticAuditLog config",
-			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
-				PolicyFilePath: "/etc/kubernetes/policy.yaml",
-				LogPath:        "/var/log/kubernetes",
-				LogMaxAge:      10,
-				LogMaxBackup:   10,
-				LogMaxSize:     100,
+			name: "valid static worker overrides",
+			overrides: &kubeone.HostNetworkOverrides{
+				KubeletNodeIP: "fd00::1",
 			},
+			controlPlane:  false,
+			kubeVersion:   "1.22.1",
 			expectedError: false,
 		},
 		{
-			name: "policy file path missing",
-			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
-				LogPath:      "/var/log/kubernetes",
-				LogMaxAge:    10,
-				LogMaxBackup: 10,
-				LogMaxSize:   100,
+			name: "invalid advertise address",
+			overrides: &kubeone.HostNetworkOverrides{
+				APIServerAdvertiseAddress: "cp-1.local",
 			},
+			controlPlane:  true,
+			kubeVersion:   "1.22.1",
 			expectedError: true,
 		},
 		{
-			name: "log file path missing",
-			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
-				PolicyFilePath: "/etc/kubernetes/policy.yaml",
-				LogMaxAge:      10,
-				LogMaxBackup:   10,
-				LogMaxSize:     100,
+			name: "invalid kubelet node ip",
+			overrides: &kubeone.HostNetworkOverrides{
+				KubeletNodeIP: "10.2.0",
 			},
+			controlPlane:  true,
+			kubeVersion:   "1.22.1",
 			expectedError: true,
 		},
 		{
-			name: "log max age set to 0",
-			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
-				PolicyFilePath: "/etc/kubernetes/policy.yaml",
-				LogPath:        "/var/log/kubernetes",
-				LogMaxAge:      0,
-				LogMaxBackup:   10,
-				LogMaxSize:     100,
+			name: "etcd url without tls",
+			overrides: &kubeone.HostNetworkOverrides{
+				EtcdListenPeerURLs: []string{"http://10.1.0.1:2380"},
 			},
+			controlPlane:  true,
+			kubeVersion:   "1.22.1",
 			expectedError: true,
 		},
 		{
-			name: "log max backup set to 0",
-			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
-				PolicyFilePath: "/etc/kubernetes/policy.yaml",
-				LogPath:        "/var/log/kubernetes",
-				LogMaxAge:      10,
-				LogMaxBackup:   0,
-				LogMaxSize:     100,
+			name: "etcd url without port",
+			overrides: &kubeone.HostNetworkOverrides{
+				EtcdAdvertiseClientURLs: []string{"https://10.1.0.1"},
 			},
+			controlPlane:  true,
+			kubeVersion:   "1.22.1",
 			expectedError: true,
 		},
 		{
-			name: "log max size set to 0",
-			staticAuditLogConfig: kubeone.StaticAuditLogConfig{
-				PolicyFilePath: "/etc/kubernetes/policy.yaml",
-				LogPath:        "/var/log/kubernetes",
-				LogMaxAge:      10,
-				LogMaxBackup:   10,
-				LogMaxSize:     0,
+			name: "etcd url with hostname",
+			overrides: &kubeone.HostNetworkOverrides{
+				EtcdListenClientURLs: []string{"https://cp-1.local:2379"},
+			},
+			controlPlane:  true,
+			kubeVersion:   "1.22.1",
+			expectedError: true,
+		},
+		{
+			name: "etcd urls on kubernetes 1.21",
+			overrides: &kubeone.HostNetworkOverrides{
+				EtcdListenPeerURLs: []string{"https://10.1.0.1:2380"},
+			},
+			controlPlane:  true,
+			kubeVersion:   "1.21.5",
+			expectedError: true,
+		},
+		{
+			name: "advertise address on static worker",
+			overrides: &kubeone.HostNetworkOverrides{
+				APIServerAdvertiseAddress: "10.1.0.1",
+			},
+			controlPlane:  false,
+			kubeVersion:   "1.22.1",
+			expectedError: true,
+		},
+		{
+			name: "etcd urls on static worker",
+			overrides: &kubeone.HostNetworkOverrides{
+				EtcdListenPeerURLs: []string{"https://10.1.0.1:2380"},
 			},
+			controlPlane:  false,
+			kubeVersion:   "1.22.1",
 			expectedError: true,
 		},
 	}
+
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateStaticAuditLogConfig(tc.staticAuditLogConfig, nil)
+			errs := ValidateHostNetworkOverrides(tc.overrides, tc.controlPlane, kubeone.VersionConfig{Kubernetes: tc.kubeVersion}, nil)
 			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
@@ -1458,39 +4657,78 @@ func TestValidateStaticAuditLogConfig(t *testing.T) {
 	}
 }
 
-func TestValidateOIDCConfig(t *testing.T) {
+func TestValidateKubeletConfig(t *testing.T) {
+	maxPods := int32(250)
+	zeroMaxPods := int32(0)
+
 	tests := []struct {
 		name          string
-		oidcConfig    kubeone.OpenIDConnectConfig
+		kubeletConfig *kubeone.KubeletConfig
 		expectedError bool
 	}{
 		{
-			name: "valid oidc config",
-			oidcConfig: kubeone.OpenIDConnectConfig{
-				IssuerURL: "test.cluster.local",
-				ClientID:  "test",
+			name:          "not configured",
+			expectedError: false,
+		},
+		{
+			name: "valid config",
+			kubeletConfig: &kubeone.KubeletConfig{
+				MaxPods:        &maxPods,
+				SystemReserved: map[string]string{"cpu": "100m", "memory": "500Mi"},
+				KubeReserved:   map[string]string{"ephemeral-storage": "1Gi", "pid": "1000"},
+				EvictionHard:   map[string]string{"memory.available": "100Mi", "nodefs.available": "10%"},
+				CgroupDriver:   "systemd",
 			},
 			expectedError: false,
 		},
 		{
-			name: "no issuer url",
-			oidcConfig: kubeone.OpenIDConnectConfig{
-				ClientID: "test",
+			name: "zero max pods",
+			kubeletConfig: &kubeone.KubeletConfig{
+				MaxPods: &zeroMaxPods,
 			},
 			expectedError: true,
 		},
 		{
-			name: "no client id",
-			oidcConfig: kubeone.OpenIDConnectConfig{
-				IssuerURL: "test.cluster.local",
+			name: "unsupported cgroup driver",
+			kubeletConfig: &kubeone.KubeletConfig{
+				CgroupDriver: "cgroupv2",
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported reserved resource",
+			kubeletConfig: &kubeone.KubeletConfig{
+				SystemReserved: map[string]string{"nvidia.com/gpu": "1"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid reserved quantity",
+			kubeletConfig: &kubeone.KubeletConfig{
+				KubeReserved: map[string]string{"memory": "lots"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "unsupported eviction signal",
+			kubeletConfig: &kubeone.KubeletConfig{
+				EvictionHard: map[string]string{"memory.free": "100Mi"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid eviction percentage",
+			kubeletConfig: &kubeone.KubeletConfig{
+				EvictionHard: map[string]string{"nodefs.available": "110%"},
 			},
 			expectedError: true,
 		},
 	}
+
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateOIDCConfig(tc.oidcConfig, nil)
+			errs := ValidateKubeletConfig(tc.kubeletConfig, field.NewPath("kubeletConfig"))
 			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
@@ -1498,49 +4736,41 @@ func TestValidateOIDCConfig(t *testing.T) {
 	}
 }
 
-func TestValidateAddons(t *testing.T) {
+func TestValidateRegistryConfiguration(t *testing.T) {
 	tests := []struct {
-		name          string
-		addons        *kubeone.Addons
-		expectedError bool
+		name                  string
+		registryConfiguration *kubeone.RegistryConfiguration
+		expectedError         bool
 	}{
 		{
-			name: "valid addons config (enabled)",
-			addons: &kubeone.Addons{
-				Enable: true,
-				Path:   "./addons",
+			name: "valid registry config (overwrite registry)",
+			registryConfiguration: &kubeone.RegistryConfiguration{
+				OverwriteRegistry: "127.0.0.1:5000",
 			},
 			expectedError: false,
 		},
 		{
-			name: "addons enabled, no path set",
-			addons: &kubeone.Addons{
-				Enable: true,
-				Path:   "",
-			},
-			expectedError: true,
-		},
-		{
-			name: "valid addons config (disabled)",
-			addons: &kubeone.Addons{
-				Enable: false,
+			name: "valid registry config (overwrite registry and insecure)",
+			registryConfiguration: &kubeone.RegistryConfiguration{
+				OverwriteRegistry: "127.0.0.1:5000",
+				InsecureRegistry:  true,
 			},
 			expectedError: false,
 		},
 		{
-			name:          "valid addons config (empty)",
-			addons:        &kubeone.Addons{},
-			expectedError: false,
+			name:                  "valid registry config (empty)",
+			registryConfiguration: &kubeone.RegistryConfiguration{},
+			expectedError:         false,
 		},
 		{
-			name:          "valid addons config (nil)",
-			addons:        nil,
-			expectedError: false,
+			name:                  "valid registry config (nil)",
+			registryConfiguration: nil,
+			expectedError:         false,
 		},
 		{
-			name: "invalid addons config (enabled without path)",
-			addons: &kubeone.Addons{
-				Enable: true,
+			name: "invalid registry config (insecure registry without overwrite registry)",
+			registryConfiguration: &kubeone.RegistryConfiguration{
+				InsecureRegistry: true,
 			},
 			expectedError: true,
 		},
@@ -1548,7 +4778,7 @@ func TestValidateAddons(t *testing.T) {
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateAddons(tc.addons, nil)
+			errs := ValidateRegistryConfiguration(tc.registryConfiguration, nil)
 			if (len(errs) == 0) == tc.expectedError {
 				t.Log(errs[0])
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
@@ -1557,138 +4787,98 @@ func TestValidateAddons(t *testing.T) {
 	}
 }
 
-func TestValidateHostConfig(t *testing.T) {
+func TestValidateStateBackend(t *testing.T) {
 	tests := []struct {
 		name          string
-		hostConfig    []kubeone.HostConfig
+		stateBackend  *kubeone.StateBackend
 		expectedError bool
 	}{
 		{
-			name: "host config with ip addresses",
-			hostConfig: []kubeone.HostConfig{
-				{
-					PublicAddress:     "192.168.1.1",
-					PrivateAddress:    "192.168.0.1",
-					SSHPrivateKeyFile: "test",
-					SSHAgentSocket:    "test",
-					SSHUsername:       "root",
-				},
+			name:          "valid state backend (nil)",
+			stateBackend:  nil,
+			expectedError: false,
+		},
+		{
+			name: "valid state backend (s3)",
+			stateBackend: &kubeone.StateBackend{
+				S3: &kubeone.S3StateBackend{Bucket: "kubeone-state", Region: "eu-west-1"},
 			},
 			expectedError: false,
 		},
 		{
-			name: "host config with dns domain",
-			hostConfig: []kubeone.HostConfig{
-				{
-					PublicAddress:     "cluster-test.public.local",
-					PrivateAddress:    "cluster-test.private.local",
-					SSHPrivateKeyFile: "test",
-					SSHAgentSocket:    "test",
-					SSHUsername:       "root",
-				},
+			name: "valid state backend (gcs)",
+			stateBackend: &kubeone.StateBackend{
+				GCS: &kubeone.GCSStateBackend{Bucket: "kubeone-state"},
 			},
 			expectedError: false,
 		},
 		{
-			name: "no public address provided",
-			hostConfig: []kubeone.HostConfig{
-				{
-					PublicAddress:     "",
-					PrivateAddress:    "cluster-test.private.local",
-					SSHPrivateKeyFile: "test",
-					SSHAgentSocket:    "test",
-					SSHUsername:       "root",
-				},
+			name: "valid state backend (azure blob)",
+			stateBackend: &kubeone.StateBackend{
+				AzureBlob: &kubeone.AzureBlobStateBackend{StorageAccount: "kubeone", Container: "state"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid state backend (local)",
+			stateBackend: &kubeone.StateBackend{
+				Local: &kubeone.LocalStateBackend{Path: "/mnt/shared/kubeone"},
+			},
+			expectedError: false,
+		},
+		{
+			name: "valid state backend (kubernetes secret)",
+			stateBackend: &kubeone.StateBackend{
+				KubernetesSecret: &kubeone.KubernetesSecretStateBackend{Namespace: "kubeone"},
 			},
+			expectedError: false,
+		},
+		{
+			name:          "invalid state backend (empty)",
+			stateBackend:  &kubeone.StateBackend{},
 			expectedError: true,
 		},
 		{
-			name: "no private address provided",
-			hostConfig: []kubeone.HostConfig{
-				{
-					PublicAddress:     "cluster-test.public.local",
-					PrivateAddress:    "",
-					SSHPrivateKeyFile: "test",
-					SSHAgentSocket:    "test",
-					SSHUsername:       "root",
-				},
+			name: "invalid state backend (multiple backends)",
+			stateBackend: &kubeone.StateBackend{
+				S3:  &kubeone.S3StateBackend{Bucket: "kubeone-state"},
+				GCS: &kubeone.GCSStateBackend{Bucket: "kubeone-state"},
 			},
 			expectedError: true,
 		},
 		{
-			name: "no private key file and agent provided",
-			hostConfig: []kubeone.HostConfig{
-				{
-					PublicAddress:     "cluster-test.public.local",
-					PrivateAddress:    "cluster-test.private.local",
-					SSHPrivateKeyFile: "",
-					SSHAgentSocket:    "",
-					SSHUsername:       "root",
-				},
+			name: "invalid state backend (s3 without bucket)",
+			stateBackend: &kubeone.StateBackend{
+				S3: &kubeone.S3StateBackend{Region: "eu-west-1"},
 			},
 			expectedError: true,
 		},
 		{
-			name: "no username provided",
-			hostConfig: []kubeone.HostConfig{
-				{
-					PublicAddress:     "cluster-test.public.local",
-					PrivateAddress:    "cluster-test.private.local",
-					SSHPrivateKeyFile: "test",
-					SSHAgentSocket:    "test",
-					SSHUsername:       "",
-				},
+			name: "invalid state backend (azure blob without container)",
+			stateBackend: &kubeone.StateBackend{
+				AzureBlob: &kubeone.AzureBlobStateBackend{StorageAccount: "kubeone"},
 			},
 			expectedError: true,
 		},
 		{
-			name: "one valid host config and one invalid host config (no username)",
-			hostConfig: []kubeone.HostConfig{
-				{
-					PublicAddress:     "192.168.1.1",
-					PrivateAddress:    "192.168.0.1",
-					SSHPrivateKeyFile: "test",
-					SSHAgentSocket:    "test",
-					SSHUsername:       "root",
-				},
-				{
-					PublicAddress:     "cluster-test.public.local",
-					PrivateAddress:    "cluster-test.private.local",
-					SSHPrivateKeyFile: "test",
-					SSHAgentSocket:    "test",
-					SSHUsername:       "",
-				},
+			name: "invalid state backend (local without path)",
+			stateBackend: &kubeone.StateBackend{
+				Local: &kubeone.LocalStateBackend{},
 			},
 			expectedError: true,
 		},
 		{
-			name: "two leaders at the same time",
-			hostConfig: []kubeone.HostConfig{
-				{
-					PublicAddress:     "192.168.1.1",
-					PrivateAddress:    "192.168.0.1",
-					SSHPrivateKeyFile: "test",
-					SSHAgentSocket:    "test",
-					SSHUsername:       "root",
-					IsLeader:          true,
-				},
-				{
-					PublicAddress:     "cluster-test.public.local",
-					PrivateAddress:    "cluster-test.private.local",
-					SSHPrivateKeyFile: "test",
-					SSHAgentSocket:    "test",
-					SSHUsername:       "root",
-					IsLeader:          true,
-				},
+			name: "invalid state backend (kubernetes secret with invalid namespace)",
+			stateBackend: &kubeone.StateBackend{
+				KubernetesSecret: &kubeone.KubernetesSecretStateBackend{Namespace: "Kube_System"},
 			},
 			expectedError: true,
 		},
 	}
-
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateHostConfig(tc.hostConfig, nil)
+			errs := ValidateStateBackend(tc.stateBackend, nil)
 			if (len(errs) == 0) == tc.expectedError {
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
@@ -1696,41 +4886,46 @@ func TestValidateHostConfig(t *testing.T) {
 	}
 }
 
-func TestValidateRegistryConfiguration(t *testing.T) {
+func TestValidateBackups(t *testing.T) {
 	tests := []struct {
-		name                  string
-		registryConfiguration *kubeone.RegistryConfiguration
-		expectedError         bool
+		name          string
+		backups       *kubeone.Backups
+		expectedError bool
 	}{
-		{
-			name: "valid registry config (overwrite registry)",
-			registryConfiguration: &kubeone.RegistryConfiguration{
-				OverwriteRegistry: "127.0.0.1:5000",
-			},
+		{
+			name:          "valid backups (nil)",
+			backups:       nil,
 			expectedError: false,
 		},
 		{
-			name: "valid registry config (overwrite registry and insecure)",
-			registryConfiguration: &kubeone.RegistryConfiguration{
-				OverwriteRegistry: "127.0.0.1:5000",
-				InsecureRegistry:  true,
-			},
+			name:          "valid backups (local only)",
+			backups:       &kubeone.Backups{},
 			expectedError: false,
 		},
 		{
-			name:                  "valid registry config (empty)",
-			registryConfiguration: &kubeone.RegistryConfiguration{},
-			expectedError:         false,
+			name: "valid backups (s3)",
+			backups: &kubeone.Backups{
+				Storage: &kubeone.StateBackend{
+					S3: &kubeone.S3StateBackend{Bucket: "kubeone-backups"},
+				},
+			},
+			expectedError: false,
 		},
 		{
-			name:                  "valid registry config (nil)",
-			registryConfiguration: nil,
-			expectedError:         false,
+			name: "invalid backups (kubernetes secret)",
+			backups: &kubeone.Backups{
+				Storage: &kubeone.StateBackend{
+					KubernetesSecret: &kubeone.KubernetesSecretStateBackend{},
+				},
+			},
+			expectedError: true,
 		},
 		{
-			name: "invalid registry config (insecure registry without overwrite registry)",
-			registryConfiguration: &kubeone.RegistryConfiguration{
-				InsecureRegistry: true,
+			name: "invalid backups (gcs without bucket)",
+			backups: &kubeone.Backups{
+				Storage: &kubeone.StateBackend{
+					GCS: &kubeone.GCSStateBackend{},
+				},
 			},
 			expectedError: true,
 		},
@@ -1738,9 +4933,8 @@ func TestValidateRegistryConfiguration(t *testing.T) {
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			errs := ValidateRegistryConfiguration(tc.registryConfiguration, nil)
+			errs := ValidateBackups(tc.backups, field.NewPath("backups"))
 			if (len(errs) == 0) == tc.expectedError {
-				t.Log(errs[0])
 				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
 			}
 		})
@@ -1913,6 +5107,48 @@ func TestValidateAssetConfiguration(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "binary assets with mirrors and checksums",
+			assetConfiguration: &kubeone.AssetConfiguration{
+				CNI: kubeone.BinaryAsset{
+					URL:     "https://127.0.0.1/cni",
+					Mirrors: []string{"https://127.0.0.2/cni"},
+					SHA256:  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				},
+				NodeBinaries: kubeone.BinaryAsset{
+					URL: "https://127.0.0.1/kubernetes-node-linux-amd64.tar.gz",
+				},
+				Kubectl: kubeone.BinaryAsset{
+					URL: "https://127.0.0.1/kubectl",
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "binary asset with invalid checksum",
+			assetConfiguration: &kubeone.AssetConfiguration{
+				CNI: kubeone.BinaryAsset{
+					URL:    "https://127.0.0.1/cni",
+					SHA256: "not-a-checksum",
+				},
+				NodeBinaries: kubeone.BinaryAsset{
+					URL: "https://127.0.0.1/kubernetes-node-linux-amd64.tar.gz",
+				},
+				Kubectl: kubeone.BinaryAsset{
+					URL: "https://127.0.0.1/kubectl",
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "binary asset mirrors without url",
+			assetConfiguration: &kubeone.AssetConfiguration{
+				Kubectl: kubeone.BinaryAsset{
+					Mirrors: []string{"https://127.0.0.2/kubectl"},
+				},
+			},
+			expectedError: true,
+		},
 		{
 			name: "binary assets configured (node binaries missing)",
 			assetConfiguration: &kubeone.AssetConfiguration{
@@ -1938,6 +5174,338 @@ func TestValidateAssetConfiguration(t *testing.T) {
 	}
 }
 
+func TestValidateAssetCache(t *testing.T) {
+	tests := []struct {
+		name          string
+		cache         *kubeone.AssetCache
+		expectedError bool
+	}{
+		{
+			name:          "cache not configured",
+			expectedError: false,
+		},
+		{
+			name:          "control plane host by private address",
+			cache:         &kubeone.AssetCache{Host: "10.0.0.1", Port: 8008},
+			expectedError: false,
+		},
+		{
+			name:          "static worker host by private address",
+			cache:         &kubeone.AssetCache{Host: "10.0.0.2", Port: 8008},
+			expectedError: false,
+		},
+		{
+			name:          "static worker host by public address",
+			cache:         &kubeone.AssetCache{Host: "192.168.1.2", Port: 8008},
+			expectedError: true,
+		},
+		{
+			name:          "unknown host",
+			cache:         &kubeone.AssetCache{Host: "10.0.0.3", Port: 8008},
+			expectedError: true,
+		},
+		{
+			name:          "host missing",
+			cache:         &kubeone.AssetCache{Port: 8008},
+			expectedError: true,
+		},
+		{
+			name:          "invalid port",
+			cache:         &kubeone.AssetCache{Host: "10.0.0.1", Port: 70000},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := kubeone.KubeOneCluster{
+				ControlPlane: kubeone.ControlPlaneConfig{
+					Hosts: []kubeone.HostConfig{
+						{PublicAddress: "192.168.1.1", PrivateAddress: "10.0.0.1"},
+					},
+				},
+				StaticWorkers: kubeone.StaticWorkersConfig{
+					Hosts: []kubeone.HostConfig{
+						{PublicAddress: "192.168.1.2", PrivateAddress: "10.0.0.2"},
+					},
+				},
+				AssetConfiguration: kubeone.AssetConfiguration{
+					Cache: tc.cache,
+				},
+			}
+
+			errs := ValidateAssetCache(c, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestValidateHooks(t *testing.T) {
+	tests := []struct {
+		name          string
+		hooks         *kubeone.Hooks
+		expectedError bool
+	}{
+		{
+			name:          "no hooks",
+			hooks:         nil,
+			expectedError: false,
+		},
+		{
+			name: "valid hooks",
+			hooks: &kubeone.Hooks{
+				PreApply: []kubeone.Hook{
+					{Name: "silence alerts", Command: "amtool silence add cluster=prod"},
+				},
+				PostNodeUpgrade: []kubeone.Hook{
+					{
+						Webhook: &kubeone.WebhookHook{
+							URL:     "https://lb.example.com/members",
+							Headers: map[string]string{"Authorization": "env:LB_TOKEN"},
+						},
+						Timeout: metav1.Duration{Duration: 30 * time.Second},
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "both command and webhook",
+			hooks: &kubeone.Hooks{
+				PreReset: []kubeone.Hook{
+					{Command: "true", Webhook: &kubeone.WebhookHook{URL: "https://example.com"}},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "neither command nor webhook",
+			hooks: &kubeone.Hooks{
+				PostUpgrade: []kubeone.Hook{{Name: "empty"}},
+			},
+			expectedError: true,
+		},
+		{
+			name: "webhook without scheme",
+			hooks: &kubeone.Hooks{
+				PreUpgrade: []kubeone.Hook{
+					{Webhook: &kubeone.WebhookHook{URL: "example.com/hook"}},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "webhook url from environment",
+			hooks: &kubeone.Hooks{
+				PreUpgrade: []kubeone.Hook{
+					{Webhook: &kubeone.WebhookHook{URL: "env:ALERTMANAGER_URL"}},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "webhook url from environment without variable name",
+			hooks: &kubeone.Hooks{
+				PreUpgrade: []kubeone.Hook{
+					{Webhook: &kubeone.WebhookHook{URL: "env:"}},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "negative timeout",
+			hooks: &kubeone.Hooks{
+				PostApply: []kubeone.Hook{
+					{Command: "true", Timeout: metav1.Duration{Duration: -time.Second}},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "valid host scripts",
+			hooks: &kubeone.Hooks{
+				Host: &kubeone.HostHooks{
+					PreProvision: []kubeone.HostScript{{Name: "mirrors", File: "scripts/mirrors.sh"}},
+					PreDrain:     []kubeone.HostScript{{Inline: "echo {{ .HOSTNAME }}", IgnoreFailure: true}},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "host script with both inline and file",
+			hooks: &kubeone.Hooks{
+				Host: &kubeone.HostHooks{
+					PostJoin: []kubeone.HostScript{{Inline: "true", File: "scripts/post-join.sh"}},
+				},
+			},
+			expectedError: true,
+		},
+		{
+			name: "empty host script",
+			hooks: &kubeone.Hooks{
+				Host: &kubeone.HostHooks{
+					PostUpgrade: []kubeone.HostScript{{Name: "empty"}},
+				},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateHooks(tc.hooks, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateCommandRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        *kubeone.CommandRetryPolicy
+		expectedError bool
+	}{
+		{
+			name:          "default policy",
+			policy:        nil,
+			expectedError: false,
+		},
+		{
+			name: "valid policy",
+			policy: &kubeone.CommandRetryPolicy{
+				Attempts:        5,
+				Backoff:         metav1.Duration{Duration: 10 * time.Second},
+				RetryableErrors: []string{`Could not get lock`, `(?i)connection reset`},
+				TaskAttempts:    map[string]int{"install-prerequisites": 10},
+			},
+			expectedError: false,
+		},
+		{
+			name: "negative attempts",
+			policy: &kubeone.CommandRetryPolicy{
+				Attempts: -1,
+			},
+			expectedError: true,
+		},
+		{
+			name: "negative backoff",
+			policy: &kubeone.CommandRetryPolicy{
+				Backoff: metav1.Duration{Duration: -time.Second},
+			},
+			expectedError: true,
+		},
+		{
+			name: "invalid retryable error",
+			policy: &kubeone.CommandRetryPolicy{
+				RetryableErrors: []string{`lock (`},
+			},
+			expectedError: true,
+		},
+		{
+			name: "zero task attempts",
+			policy: &kubeone.CommandRetryPolicy{
+				TaskAttempts: map[string]int{"install-prerequisites": 0},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateCommandRetryPolicy(tc.policy, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func TestValidateNotification(t *testing.T) {
+	tests := []struct {
+		name          string
+		notification  kubeone.Notification
+		expectedError bool
+	}{
+		{
+			name: "valid webhook",
+			notification: kubeone.Notification{
+				Type: kubeone.NotificationTypeWebhook,
+				URL:  "https://hooks.example.com/kubeone",
+			},
+			expectedError: false,
+		},
+		{
+			name: "slack URL from environment",
+			notification: kubeone.Notification{
+				Type: kubeone.NotificationTypeSlack,
+				URL:  "env:SLACK_WEBHOOK_URL",
+			},
+			expectedError: false,
+		},
+		{
+			name: "unknown type",
+			notification: kubeone.Notification{
+				Type: "pager",
+				URL:  "https://hooks.example.com/kubeone",
+			},
+			expectedError: true,
+		},
+		{
+			name: "missing URL",
+			notification: kubeone.Notification{
+				Type: kubeone.NotificationTypeTeams,
+			},
+			expectedError: true,
+		},
+		{
+			name: "non-http URL",
+			notification: kubeone.Notification{
+				Type: kubeone.NotificationTypeWebhook,
+				URL:  "ftp://hooks.example.com/kubeone",
+			},
+			expectedError: true,
+		},
+		{
+			name: "empty environment variable name",
+			notification: kubeone.Notification{
+				Type: kubeone.NotificationTypeSlack,
+				URL:  "env:",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateNotification(tc.notification, nil)
+			if (len(errs) == 0) == tc.expectedError {
+				t.Errorf("test case failed: expected %v, but got %v", tc.expectedError, (len(errs) != 0))
+			}
+		})
+	}
+}
+
+func fipsEncryptionConfiguration(provider, keyName string) string {
+	return fmt.Sprintf(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - %s:
+      keys:
+      - name: %s
+        secret: c2VjcmV0IGlzIHNlY3VyZSwgb3IgaXMgaXQ/Cg==
+  - identity: {}
+`, provider, keyName)
+}
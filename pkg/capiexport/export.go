@@ -0,0 +1,279 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capiexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	kyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	clusterAPIVersion   = "cluster.x-k8s.io/v1beta1"
+	bootstrapAPIVersion = "bootstrap.cluster.x-k8s.io/v1beta1"
+
+	labelClusterName = "cluster.x-k8s.io/cluster-name"
+	labelWorkerset   = "workerset"
+)
+
+// Options configures the export of the worker definitions
+type Options struct {
+	// Provider is the Cluster API infrastructure provider name. The cloud
+	// provider of the cluster is used if empty.
+	Provider string
+	// ClusterName is the name of the Cluster API Cluster object the
+	// MachineDeployments belong to. The KubeOne cluster name is used if empty.
+	ClusterName string
+	// Namespace where the Cluster API objects are created
+	Namespace string
+}
+
+type object map[string]interface{}
+
+// Export converts the dynamic workers of the cluster into Cluster API
+// MachineDeployments, together with the KubeadmConfigTemplates and the
+// infrastructure provider MachineTemplates they reference.
+//
+// The machine-controller cloudProviderSpec fields without the Cluster API
+// counterpart are not exported, but returned as warnings, so they can be
+// reviewed before the manifest is applied.
+func Export(cluster *kubeoneapi.KubeOneCluster, opts Options) (string, []string, error) {
+	providerName := opts.Provider
+	if providerName == "" {
+		providerName = cluster.CloudProvider.CloudProviderName()
+	}
+
+	provider, ok := providers[providerName]
+	if !ok {
+		return "", nil, errors.Errorf("infrastructure provider %q is not supported, supported providers are: %s",
+			providerName, strings.Join(SupportedProviders(), ", "))
+	}
+
+	if opts.ClusterName == "" {
+		opts.ClusterName = cluster.Name
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+	}
+
+	var (
+		objects  []object
+		warnings []string
+	)
+
+	for _, worker := range cluster.DynamicWorkers {
+		spec := map[string]interface{}{}
+		if len(worker.Config.CloudProviderSpec) > 0 {
+			if err := json.Unmarshal(worker.Config.CloudProviderSpec, &spec); err != nil {
+				return "", nil, errors.Wrapf(err, "failed to unmarshal cloudProviderSpec of the worker %q", worker.Name)
+			}
+		}
+
+		infraSpec, failureDomain, unmapped := provider.convert(spec)
+		for _, field := range unmapped {
+			warnings = append(warnings, fmt.Sprintf("worker %q: cloudProviderSpec field %q is not exported", worker.Name, field))
+		}
+		if worker.Config.Network != nil {
+			warnings = append(warnings, fmt.Sprintf("worker %q: static network configuration is not exported", worker.Name))
+		}
+
+		objects = append(objects,
+			machineDeployment(worker, provider, failureDomain, cluster.Versions.Kubernetes, opts),
+			kubeadmConfigTemplate(worker, cluster.CloudProvider, opts),
+			machineTemplate(worker, provider, infraSpec, opts),
+		)
+	}
+
+	var buf bytes.Buffer
+	for _, obj := range objects {
+		b, err := kyaml.Marshal(obj)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to marshal the Cluster API object")
+		}
+		buf.WriteString("---\n")
+		buf.Write(b)
+	}
+
+	return buf.String(), warnings, nil
+}
+
+// SupportedProviders returns the sorted names of the supported
+// infrastructure providers
+func SupportedProviders() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func machineDeployment(worker kubeoneapi.DynamicWorkerConfig, provider infraProvider, failureDomain, version string, opts Options) object {
+	labels := map[string]interface{}{
+		labelClusterName: opts.ClusterName,
+		labelWorkerset:   worker.Name,
+	}
+
+	templateSpec := object{
+		"clusterName": opts.ClusterName,
+		"version":     "v" + strings.TrimPrefix(version, "v"),
+		"bootstrap": object{
+			"configRef": object{
+				"apiVersion": bootstrapAPIVersion,
+				"kind":       "KubeadmConfigTemplate",
+				"name":       worker.Name,
+			},
+		},
+		"infrastructureRef": object{
+			"apiVersion": provider.apiVersion,
+			"kind":       provider.kind,
+			"name":       worker.Name,
+		},
+	}
+	if failureDomain != "" {
+		templateSpec["failureDomain"] = failureDomain
+	}
+
+	templateMetadata := object{"labels": labels}
+	if len(worker.Config.Annotations) > 0 {
+		templateMetadata["annotations"] = worker.Config.Annotations
+	}
+
+	replicas := 0
+	if worker.Replicas != nil {
+		replicas = *worker.Replicas
+	}
+
+	return object{
+		"apiVersion": clusterAPIVersion,
+		"kind":       "MachineDeployment",
+		"metadata":   objectMeta(worker.Name, opts),
+		"spec": object{
+			"clusterName": opts.ClusterName,
+			"replicas":    replicas,
+			"selector": object{
+				"matchLabels": labels,
+			},
+			"template": object{
+				"metadata": templateMetadata,
+				"spec":     templateSpec,
+			},
+		},
+	}
+}
+
+// kubeadmConfigTemplate carries the node labels, taints and SSH keys, which
+// are set by machine-controller from the ProviderSpec
+func kubeadmConfigTemplate(worker kubeoneapi.DynamicWorkerConfig, cloudProvider kubeoneapi.CloudProviderSpec, opts Options) object {
+	kubeletArgs := object{}
+	if cloudProvider.External {
+		kubeletArgs["cloud-provider"] = "external"
+	} else if cloudProvider.CloudProviderInTree() {
+		kubeletArgs["cloud-provider"] = cloudProvider.CloudProviderName()
+	}
+
+	if len(worker.Config.Labels) > 0 {
+		var labels []string
+		for k, v := range worker.Config.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		kubeletArgs["node-labels"] = strings.Join(labels, ",")
+	}
+
+	nodeRegistration := object{
+		"kubeletExtraArgs": kubeletArgs,
+	}
+	if len(worker.Config.Taints) > 0 {
+		nodeRegistration["taints"] = worker.Config.Taints
+	}
+
+	spec := object{
+		"joinConfiguration": object{
+			"nodeRegistration": nodeRegistration,
+		},
+	}
+	if len(worker.Config.SSHPublicKeys) > 0 {
+		spec["users"] = []object{
+			{
+				"name":              sshUsername(worker.Config.OperatingSystem),
+				"sshAuthorizedKeys": worker.Config.SSHPublicKeys,
+				"sudo":              "ALL=(ALL) NOPASSWD:ALL",
+			},
+		}
+	}
+
+	return object{
+		"apiVersion": bootstrapAPIVersion,
+		"kind":       "KubeadmConfigTemplate",
+		"metadata":   objectMeta(worker.Name, opts),
+		"spec": object{
+			"template": object{
+				"spec": spec,
+			},
+		},
+	}
+}
+
+func machineTemplate(worker kubeoneapi.DynamicWorkerConfig, provider infraProvider, spec object, opts Options) object {
+	return object{
+		"apiVersion": provider.apiVersion,
+		"kind":       provider.kind,
+		"metadata":   objectMeta(worker.Name, opts),
+		"spec": object{
+			"template": object{
+				"spec": spec,
+			},
+		},
+	}
+}
+
+func objectMeta(name string, opts Options) object {
+	return object{
+		"name":      name,
+		"namespace": opts.Namespace,
+		"labels": object{
+			labelClusterName: opts.ClusterName,
+		},
+	}
+}
+
+// sshUsername returns the default user of the cloud images of the operating
+// system, matching the user machine-controller provisions the SSH keys for
+func sshUsername(osName string) string {
+	switch kubeoneapi.OperatingSystemName(osName) {
+	case kubeoneapi.OperatingSystemNameCentOS:
+		return "centos"
+	case kubeoneapi.OperatingSystemNameFlatcar:
+		return "core"
+	case kubeoneapi.OperatingSystemNameRHEL:
+		return "cloud-user"
+	case kubeoneapi.OperatingSystemNameAmazon:
+		return "ec2-user"
+	}
+
+	return "ubuntu"
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capiexport
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/testhelper"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var updateFlag = flag.Bool("update", false, "update testdata files")
+
+func TestExport(t *testing.T) {
+	replicas := 3
+	cluster := &kubeoneapi.KubeOneCluster{
+		Name: "demo",
+		Versions: kubeoneapi.VersionConfig{
+			Kubernetes: "1.22.5",
+		},
+		CloudProvider: kubeoneapi.CloudProviderSpec{
+			AWS: &kubeoneapi.AWSSpec{},
+		},
+		DynamicWorkers: []kubeoneapi.DynamicWorkerConfig{
+			{
+				Name:     "demo-pool1",
+				Replicas: &replicas,
+				Config: kubeoneapi.ProviderSpec{
+					CloudProviderSpec: []byte(`{
+						"ami": "ami-0123456789",
+						"availabilityZone": "eu-west-3a",
+						"diskSize": 50,
+						"diskType": "gp2",
+						"instanceProfile": "demo-profile",
+						"instanceType": "t3.medium",
+						"region": "eu-west-3",
+						"securityGroupIDs": ["sg-1", "sg-2"],
+						"subnetId": "subnet-1",
+						"tags": {"owner": "kubeone"},
+						"vpcId": "vpc-1"
+					}`),
+					Annotations:     map[string]string{"cluster.k8s.io/cluster-api-autoscaler-node-group-min-size": "1"},
+					Labels:          map[string]string{"pool": "one", "env": "test"},
+					Taints:          []corev1.Taint{{Key: "dedicated", Value: "one", Effect: corev1.TaintEffectNoSchedule}},
+					SSHPublicKeys:   []string{"ssh-ed25519 AAAA demo"},
+					OperatingSystem: "ubuntu",
+				},
+			},
+		},
+	}
+
+	manifest, warnings, err := Export(cluster, Options{Namespace: "capi-demo"})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	expectedWarnings := []string{
+		`worker "demo-pool1": cloudProviderSpec field "region" is not exported`,
+		`worker "demo-pool1": cloudProviderSpec field "vpcId" is not exported`,
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("expected warnings %q, but got %q", expectedWarnings, warnings)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), manifest, *updateFlag)
+}
+
+func TestExportUnsupportedProvider(t *testing.T) {
+	cluster := &kubeoneapi.KubeOneCluster{
+		Name: "demo",
+		CloudProvider: kubeoneapi.CloudProviderSpec{
+			None: &kubeoneapi.NoneSpec{},
+		},
+	}
+
+	if _, _, err := Export(cluster, Options{}); err == nil {
+		t.Errorf("expected error for the unsupported provider")
+	}
+
+	if _, _, err := Export(cluster, Options{Provider: "hetzner"}); err != nil {
+		t.Errorf("Export() with the explicit provider error = %v", err)
+	}
+}
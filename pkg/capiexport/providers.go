@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capiexport
+
+import (
+	"sort"
+	"strings"
+)
+
+// fieldMapping maps the machine-controller cloudProviderSpec field to the
+// dot-separated path in the infrastructure provider MachineTemplate spec
+type fieldMapping struct {
+	from      string
+	to        string
+	transform func(value interface{}) interface{}
+}
+
+type infraProvider struct {
+	apiVersion string
+	kind       string
+	// failureDomain is the cloudProviderSpec field set as the Machine
+	// failure domain
+	failureDomain string
+	fields        []fieldMapping
+	// defaults are set in the MachineTemplate spec before the fields are
+	// mapped
+	defaults object
+}
+
+var providers = map[string]infraProvider{
+	"aws": {
+		apiVersion:    "infrastructure.cluster.x-k8s.io/v1beta1",
+		kind:          "AWSMachineTemplate",
+		failureDomain: "availabilityZone",
+		fields: []fieldMapping{
+			{from: "instanceType", to: "instanceType"},
+			{from: "ami", to: "ami.id"},
+			{from: "diskSize", to: "rootVolume.size"},
+			{from: "diskType", to: "rootVolume.type"},
+			{from: "diskIops", to: "rootVolume.iops"},
+			{from: "ebsVolumeEncrypted", to: "rootVolume.encrypted"},
+			{from: "subnetId", to: "subnet.id"},
+			{from: "securityGroupIDs", to: "additionalSecurityGroups", transform: listOf("id")},
+			{from: "instanceProfile", to: "iamInstanceProfile"},
+			{from: "assignPublicIP", to: "publicIP"},
+			{from: "tags", to: "additionalTags"},
+			{from: "isSpotInstance", to: "spotMarketOptions", transform: spotMarketOptions},
+		},
+	},
+	"azure": {
+		apiVersion:    "infrastructure.cluster.x-k8s.io/v1beta1",
+		kind:          "AzureMachineTemplate",
+		failureDomain: "zone",
+		defaults: object{
+			"osDisk": object{"osType": "Linux"},
+		},
+		fields: []fieldMapping{
+			{from: "vmSize", to: "vmSize"},
+			{from: "osDiskSize", to: "osDisk.diskSizeGB"},
+			{from: "osDiskSKU", to: "osDisk.managedDisk.storageAccountType"},
+			{from: "imageID", to: "image.id"},
+			{from: "tags", to: "additionalTags"},
+			{from: "assignPublicIP", to: "allocatePublicIP"},
+		},
+	},
+	"gce": {
+		apiVersion:    "infrastructure.cluster.x-k8s.io/v1beta1",
+		kind:          "GCPMachineTemplate",
+		failureDomain: "zone",
+		fields: []fieldMapping{
+			{from: "machineType", to: "instanceType"},
+			{from: "diskSize", to: "rootDeviceSize"},
+			{from: "diskType", to: "rootDeviceType"},
+			{from: "customImage", to: "image"},
+			{from: "tags", to: "additionalNetworkTags"},
+			{from: "labels", to: "additionalLabels"},
+			{from: "preemptible", to: "preemptible"},
+			{from: "assignPublicIPAddress", to: "publicIP"},
+		},
+	},
+	"hetzner": {
+		apiVersion:    "infrastructure.cluster.x-k8s.io/v1beta1",
+		kind:          "HCloudMachineTemplate",
+		failureDomain: "location",
+		fields: []fieldMapping{
+			{from: "serverType", to: "type"},
+			{from: "image", to: "imageName"},
+			{from: "placementGroupPrefix", to: "placementGroupName"},
+		},
+	},
+	"openstack": {
+		apiVersion:    "infrastructure.cluster.x-k8s.io/v1alpha4",
+		kind:          "OpenStackMachineTemplate",
+		failureDomain: "availabilityZone",
+		fields: []fieldMapping{
+			{from: "flavor", to: "flavor"},
+			{from: "image", to: "image"},
+			{from: "securityGroups", to: "securityGroups", transform: listOf("name")},
+			{from: "network", to: "networks", transform: networkFilter},
+			{from: "rootDiskSizeGB", to: "rootVolume.diskSize"},
+			{from: "tags", to: "serverMetadata"},
+		},
+	},
+	"vsphere": {
+		apiVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		kind:       "VSphereMachineTemplate",
+		defaults: object{
+			"cloneMode": "linkedClone",
+		},
+		fields: []fieldMapping{
+			{from: "templateVMName", to: "template"},
+			{from: "cpus", to: "numCPUs"},
+			{from: "memoryMB", to: "memoryMiB"},
+			{from: "diskSizeGB", to: "diskGiB"},
+			{from: "datacenter", to: "datacenter"},
+			{from: "datastore", to: "datastore"},
+			{from: "folder", to: "folder"},
+			{from: "resourcePool", to: "resourcePool"},
+			{from: "vmNetName", to: "network.devices", transform: vsphereNetwork},
+		},
+	},
+	"digitalocean": {
+		apiVersion:    "infrastructure.cluster.x-k8s.io/v1beta1",
+		kind:          "DOMachineTemplate",
+		failureDomain: "region",
+		fields: []fieldMapping{
+			{from: "size", to: "size"},
+			{from: "image", to: "image"},
+			{from: "tags", to: "additionalTags"},
+		},
+	},
+}
+
+// convert maps the cloudProviderSpec to the MachineTemplate spec. It returns
+// the spec, the failure domain, and the sorted list of the cloudProviderSpec
+// fields that were not mapped.
+func (p infraProvider) convert(spec map[string]interface{}) (object, string, []string) {
+	out := object{}
+	for k, v := range p.defaults {
+		out[k] = deepCopy(v)
+	}
+
+	mapped := map[string]bool{}
+	for _, field := range p.fields {
+		value, ok := spec[field.from]
+		if !ok {
+			continue
+		}
+		mapped[field.from] = true
+		if isEmpty(value) {
+			continue
+		}
+		if field.transform != nil {
+			value = field.transform(value)
+		}
+		setPath(out, field.to, value)
+	}
+
+	var failureDomain string
+	if p.failureDomain != "" {
+		if value, ok := spec[p.failureDomain].(string); ok {
+			failureDomain = value
+			mapped[p.failureDomain] = true
+		}
+	}
+
+	var unmapped []string
+	for k, v := range spec {
+		if !mapped[k] && !isEmpty(v) {
+			unmapped = append(unmapped, k)
+		}
+	}
+	sort.Strings(unmapped)
+
+	return out, failureDomain, unmapped
+}
+
+func setPath(obj object, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := obj[key].(object)
+		if !ok {
+			next = object{}
+			obj[key] = next
+		}
+		obj = next
+	}
+	obj[keys[len(keys)-1]] = value
+}
+
+func deepCopy(value interface{}) interface{} {
+	obj, ok := value.(object)
+	if !ok {
+		return value
+	}
+
+	out := object{}
+	for k, v := range obj {
+		out[k] = deepCopy(v)
+	}
+
+	return out
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+
+	return false
+}
+
+// listOf converts the list of values to the list of objects with the value
+// set under the given key
+func listOf(key string) func(interface{}) interface{} {
+	return func(value interface{}) interface{} {
+		values, ok := value.([]interface{})
+		if !ok {
+			values = []interface{}{value}
+		}
+
+		out := make([]object, 0, len(values))
+		for _, v := range values {
+			out = append(out, object{key: v})
+		}
+
+		return out
+	}
+}
+
+func spotMarketOptions(interface{}) interface{} {
+	return object{}
+}
+
+func networkFilter(value interface{}) interface{} {
+	return []object{
+		{"filter": object{"name": value}},
+	}
+}
+
+func vsphereNetwork(value interface{}) interface{} {
+	return []object{
+		{"networkName": value, "dhcp4": true},
+	}
+}
@@ -19,14 +19,15 @@ package certificate
 import (
 	"bytes"
 	"fmt"
-	"io"
 	"io/fs"
-	"path"
+
+	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/ssh"
-	"k8c.io/kubeone/pkg/ssh/sshiofs"
 	"k8c.io/kubeone/pkg/state"
+
+	certutil "k8s.io/client-go/util/cert"
 )
 
 const (
@@ -73,36 +74,47 @@ func DownloadKubePKI(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connecti
 }
 
 func UploadKubePKI(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
-	sshfs := s.Runner.NewFS().(sshiofs.MkdirFS)
-
 	for _, fname := range kubernetesPKIFiles() {
 		buf, found := s.Configuration.KubernetesPKI[fname]
 		if !found {
 			return fmt.Errorf("file %q found found in PKI", fname)
 		}
 
-		if err := sshfs.MkdirAll(path.Dir(fname), 0700); err != nil {
+		if err := ssh.Upload(conn, bytes.NewReader(buf), fname, 0600); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		f, err := sshfs.Open(fname)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-		fw := f.(sshiofs.ExtendedFile)
+// NewKubernetesCA generates a self-signed Kubernetes CA and returns its PEM
+// encoded certificate and key
+func NewKubernetesCA() (certPEM, keyPEM []byte, err error) {
+	key, err := newPrivateKey()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate CA private key")
+	}
 
-		if err = fw.Truncate(0); err != nil {
-			return err
-		}
+	cert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: "kubernetes"}, key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate CA certificate")
+	}
 
-		if err = fw.Chmod(0600); err != nil {
-			return err
-		}
+	return encodeCertPEM(cert), encodePrivateKeyPEM(key), nil
+}
 
-		if _, err = io.Copy(fw, bytes.NewBuffer(buf)); err != nil {
-			return err
-		}
+// GenerateKubernetesCA generates a throwaway self-signed Kubernetes CA and
+// stores it in place of the PKI downloaded from the leader. It's used to
+// render the addons manifests without connecting to the cluster, therefore
+// certificates signed by it are never valid for the actual cluster.
+func GenerateKubernetesCA(s *state.State) error {
+	certPEM, keyPEM, err := NewKubernetesCA()
+	if err != nil {
+		return err
 	}
+
+	s.Configuration.KubernetesPKI[KubernetesCACertPath] = certPEM
+	s.Configuration.KubernetesPKI[KubernetesCAKeyPath] = keyPEM
+
 	return nil
 }
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package carotation implements the phases of the Kubernetes CA rotation.
+//
+// The CA is rotated in three phases, so the old and the new certificates are
+// trusted by all components at any time:
+//
+//   - trust: the bundle of the old and the new CA is distributed to all nodes
+//     and kubeconfig files, while the old CA still signs the certificates
+//   - reissue: the new CA signs the certificates, and the certificates signed
+//     by the old CA are reissued
+//   - finalize: the old CA is removed from the bundle
+//
+// The old and the new CA, and the last completed phase, are kept on the
+// control plane nodes until the rotation is finalized, so an interrupted
+// rotation can be resumed.
+package carotation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+// Phase is a phase of the CA rotation
+type Phase string
+
+const (
+	PhaseTrust    Phase = "trust"
+	PhaseReissue  Phase = "reissue"
+	PhaseFinalize Phase = "finalize"
+)
+
+const (
+	// Dir is the directory on the control plane nodes the old and the new
+	// CA are kept in during the rotation
+	Dir = "/etc/kubernetes/pki/ca-rotation"
+	// PhaseFile records the last completed phase of the rotation
+	PhaseFile   = Dir + "/phase"
+	OldCertFile = Dir + "/old-ca.crt"
+	OldKeyFile  = Dir + "/old-ca.key"
+	NewCertFile = Dir + "/new-ca.crt"
+	NewKeyFile  = Dir + "/new-ca.key"
+)
+
+// Phases are the phases of the rotation in the order they are run
+var Phases = []Phase{PhaseTrust, PhaseReissue, PhaseFinalize}
+
+// CA is the PEM encoded certificate and key of a CA
+type CA struct {
+	Cert []byte
+	Key  []byte
+}
+
+// Parse parses the certificate and the key of the CA
+func (ca CA) Parse() (*x509.Certificate, crypto.Signer, error) {
+	certs, err := certutil.ParseCertsPEM(ca.Cert)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse CA certificate")
+	}
+
+	key, err := keyutil.ParsePrivateKeyPEM(ca.Key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse CA key")
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.New("CA key can't sign certificates")
+	}
+
+	return certs[0], signer, nil
+}
+
+// RemainingPhases returns the phases left after the given completed phase,
+// as read from the PhaseFile. All phases are left if the rotation hasn't
+// started yet.
+func RemainingPhases(completed string) ([]Phase, error) {
+	completed = strings.TrimSpace(completed)
+	if completed == "" {
+		return Phases, nil
+	}
+
+	for i, phase := range Phases {
+		if string(phase) == completed {
+			return Phases[i+1:], nil
+		}
+	}
+
+	return nil, errors.Errorf("unknown CA rotation phase %q", completed)
+}
+
+// Files returns the content of ca.crt and ca.key during the phase. The
+// first certificate of the bundle is the one signing the certificates, and
+// it must match the key.
+func Files(phase Phase, oldCA, newCA CA) (cert, key []byte) {
+	switch phase {
+	case PhaseTrust:
+		return Bundle(oldCA.Cert, newCA.Cert), oldCA.Key
+	case PhaseReissue:
+		return Bundle(newCA.Cert, oldCA.Cert), newCA.Key
+	}
+
+	return newCA.Cert, newCA.Key
+}
+
+// Bundle concatenates the PEM encoded certificates
+func Bundle(certs ...[]byte) []byte {
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		buf.Write(bytes.TrimSpace(cert))
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
+
+// Contains returns whether the PEM encoded bundle contains the certificate
+func Contains(bundle, cert []byte) (bool, error) {
+	bundleCerts, err := certutil.ParseCertsPEM(bundle)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse CA bundle")
+	}
+
+	certs, err := certutil.ParseCertsPEM(cert)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse CA certificate")
+	}
+
+	for _, bundleCert := range bundleCerts {
+		if bytes.Equal(bundleCert.Raw, certs[0].Raw) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// SetKubeconfigCA sets the CA trusted by all clusters of the kubeconfig
+func SetKubeconfigCA(kubeconfig, caCert []byte) ([]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse kubeconfig")
+	}
+
+	for _, cluster := range config.Clusters {
+		cluster.CertificateAuthority = ""
+		cluster.CertificateAuthorityData = caCert
+	}
+
+	buf, err := clientcmd.Write(*config)
+
+	return buf, errors.Wrap(err, "failed to serialize kubeconfig")
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+func testCA(t *testing.T, name string) CA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	cert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: name}, key)
+	if err != nil {
+		t.Fatalf("failed to generate CA: %v", err)
+	}
+
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+
+	return CA{
+		Cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+		Key:  keyPEM,
+	}
+}
+
+func TestCAParse(t *testing.T) {
+	ca := testCA(t, "new")
+
+	cert, signer, err := ca.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cert.Subject.CommonName != "new" || !cert.IsCA {
+		t.Errorf("Parse() returned certificate %q, want the new CA", cert.Subject.CommonName)
+	}
+	if signer == nil {
+		t.Error("Parse() returned no signer")
+	}
+
+	if _, _, err = (CA{Cert: ca.Cert, Key: []byte("garbage")}).Parse(); err == nil {
+		t.Error("Parse() expected error for invalid key")
+	}
+}
+
+func TestRemainingPhases(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed string
+		want      []Phase
+		wantErr   bool
+	}{
+		{
+			name: "not started",
+			want: []Phase{PhaseTrust, PhaseReissue, PhaseFinalize},
+		},
+		{
+			name:      "trusted",
+			completed: "trust\n",
+			want:      []Phase{PhaseReissue, PhaseFinalize},
+		},
+		{
+			name:      "reissued",
+			completed: "reissue",
+			want:      []Phase{PhaseFinalize},
+		},
+		{
+			name:      "unknown",
+			completed: "rollback",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RemainingPhases(tt.completed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RemainingPhases() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("RemainingPhases() differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFiles(t *testing.T) {
+	oldCA := testCA(t, "old")
+	newCA := testCA(t, "new")
+
+	tests := []struct {
+		phase      Phase
+		wantSigner CA
+		wantCerts  []string
+	}{
+		{phase: PhaseTrust, wantSigner: oldCA, wantCerts: []string{"old", "new"}},
+		{phase: PhaseReissue, wantSigner: newCA, wantCerts: []string{"new", "old"}},
+		{phase: PhaseFinalize, wantSigner: newCA, wantCerts: []string{"new"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.phase), func(t *testing.T) {
+			cert, key := Files(tt.phase, oldCA, newCA)
+
+			certs, err := certutil.ParseCertsPEM(cert)
+			if err != nil {
+				t.Fatalf("failed to parse ca.crt: %v", err)
+			}
+
+			names := []string{}
+			for _, c := range certs {
+				names = append(names, c.Subject.CommonName)
+			}
+			if diff := cmp.Diff(tt.wantCerts, names); diff != "" {
+				t.Errorf("ca.crt differs (-want +got):\n%s", diff)
+			}
+			if !bytes.Equal(key, tt.wantSigner.Key) {
+				t.Errorf("ca.key doesn't match the %s CA", names[0])
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	oldCA := testCA(t, "old")
+	newCA := testCA(t, "new")
+
+	if ok, err := Contains(Bundle(oldCA.Cert, newCA.Cert), newCA.Cert); err != nil || !ok {
+		t.Errorf("Contains() = %v, %v, want the new CA found in the bundle", ok, err)
+	}
+	if ok, err := Contains(oldCA.Cert, newCA.Cert); err != nil || ok {
+		t.Errorf("Contains() = %v, %v, want the new CA not found", ok, err)
+	}
+	if _, err := Contains([]byte("garbage"), newCA.Cert); err == nil {
+		t.Error("Contains() expected error for invalid bundle")
+	}
+}
+
+func TestSetKubeconfigCA(t *testing.T) {
+	kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: kubernetes
+  cluster:
+    server: https://10.0.0.1:6443
+    certificate-authority-data: b2xk
+contexts:
+- name: admin@kubernetes
+  context:
+    cluster: kubernetes
+    user: admin
+current-context: admin@kubernetes
+users:
+- name: admin
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+`)
+	bundle := Bundle(testCA(t, "old").Cert, testCA(t, "new").Cert)
+
+	got, err := SetKubeconfigCA(kubeconfig, bundle)
+	if err != nil {
+		t.Fatalf("SetKubeconfigCA() error = %v", err)
+	}
+
+	config, err := clientcmd.Load(got)
+	if err != nil {
+		t.Fatalf("failed to load kubeconfig: %v", err)
+	}
+
+	if !bytes.Equal(config.Clusters["kubernetes"].CertificateAuthorityData, bundle) {
+		t.Error("CA data of the cluster is not replaced with the bundle")
+	}
+	if config.Clusters["kubernetes"].Server != "https://10.0.0.1:6443" {
+		t.Errorf("server changed to %q", config.Clusters["kubernetes"].Server)
+	}
+	if string(config.AuthInfos["admin"].ClientCertificateData) != "cert" {
+		t.Error("client certificate changed")
+	}
+}
@@ -99,3 +99,25 @@ func NewSignedTLSCert(name, namespace, domain string, caKey crypto.Signer, caCer
 		resources.KubernetesCACertName: string(encodeCertPEM(caCert)),
 	}, nil
 }
+
+// NewSignedClientCert generates the client certificate and key for the given
+// user and groups, signed by the given CA, encoded as PEM
+func NewSignedClientCert(user string, groups []string, caKey crypto.Signer, caCert *x509.Certificate) (certPEM, keyPEM []byte, err error) {
+	key, err := newPrivateKey()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate private key")
+	}
+
+	certCfg := certutil.Config{
+		CommonName:   user,
+		Organization: groups,
+		Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	cert, err := newSignedCert(&certCfg, key, caCert, caKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate certificate")
+	}
+
+	return encodeCertPEM(cert), encodePrivateKeyPEM(key), nil
+}
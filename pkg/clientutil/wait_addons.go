@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientutil
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AddonResourcesReadyCondition generate a k8s.io/apimachinery/pkg/util/wait.ConditionFunc function to be used in
+// k8s.io/apimachinery/pkg/util/wait.Poll* family of functions. It will check all selected CustomResourceDefinitions to
+// be Established, and all selected Deployments, StatefulSets and DaemonSets to have all replicas updated and ready.
+func AddonResourcesReadyCondition(ctx context.Context, c dynclient.Client, listOpts dynclient.ListOptions) func() (bool, error) {
+	return func() (bool, error) {
+		crds := apiextensions.CustomResourceDefinitionList{}
+		if err := c.List(ctx, &crds, &listOpts); err != nil {
+			return false, errors.Wrap(err, "failed to list customresourcedefinitions")
+		}
+
+		for i := range crds.Items {
+			if !crdEstablished(&crds.Items[i]) {
+				return false, nil
+			}
+		}
+
+		deployments := appsv1.DeploymentList{}
+		if err := c.List(ctx, &deployments, &listOpts); err != nil {
+			return false, errors.Wrap(err, "failed to list deployments")
+		}
+
+		for _, deploy := range deployments.Items {
+			replicas := replicasOrDefault(deploy.Spec.Replicas)
+			if deploy.Status.ObservedGeneration < deploy.Generation ||
+				deploy.Status.UpdatedReplicas != replicas ||
+				deploy.Status.AvailableReplicas != replicas {
+				return false, nil
+			}
+		}
+
+		statefulSets := appsv1.StatefulSetList{}
+		if err := c.List(ctx, &statefulSets, &listOpts); err != nil {
+			return false, errors.Wrap(err, "failed to list statefulsets")
+		}
+
+		for _, sts := range statefulSets.Items {
+			replicas := replicasOrDefault(sts.Spec.Replicas)
+			if sts.Status.ObservedGeneration < sts.Generation ||
+				sts.Status.UpdatedReplicas != replicas ||
+				sts.Status.ReadyReplicas != replicas {
+				return false, nil
+			}
+		}
+
+		daemonSets := appsv1.DaemonSetList{}
+		if err := c.List(ctx, &daemonSets, &listOpts); err != nil {
+			return false, errors.Wrap(err, "failed to list daemonsets")
+		}
+
+		for _, ds := range daemonSets.Items {
+			if ds.Status.ObservedGeneration < ds.Generation ||
+				ds.Status.UpdatedNumberScheduled != ds.Status.DesiredNumberScheduled ||
+				ds.Status.NumberAvailable != ds.Status.DesiredNumberScheduled {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}
+}
+
+func crdEstablished(crd *apiextensions.CustomResourceDefinition) bool {
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apiextensions.Established && cond.Status == apiextensions.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replicasOrDefault returns the number of replicas, which defaults to 1
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
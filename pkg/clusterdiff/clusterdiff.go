@@ -0,0 +1,431 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdiff
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/clusterinfo"
+	"k8c.io/kubeone/pkg/tabwriter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats of the drift report
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+// Missing is reported as the value of the fields and objects not set
+const Missing = "<not set>"
+
+// Drift is the difference of a single field between the desired state
+// rendered from the manifest and the live cluster
+type Drift struct {
+	// Object identifies the compared object, e.g. "Deployment kube-system/coredns"
+	Object string `json:"object"`
+	// Field is the path of the field, e.g. "spec.replicas". It's empty if the
+	// whole object is missing.
+	Field   string `json:"field,omitempty"`
+	Desired string `json:"desired"`
+	Live    string `json:"live"`
+}
+
+// GetFunc returns the live object with the kind, namespace and name of the
+// given object, or nil if it doesn't exist
+type GetFunc func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+
+// Fields compares the fields set in the desired object to the live object.
+// Fields set only in the live object are not reported, as they are usually
+// defaulted by the API server or the controllers.
+func Fields(object string, desired, live map[string]interface{}) []Drift {
+	c := &comparator{object: object}
+	c.compare("", desired, live)
+
+	return c.drifts
+}
+
+// Manifest compares the objects in the multi-document YAML manifest to the
+// live objects returned by get. The labels, the annotations and the fields
+// other than metadata and status are compared.
+//
+// Certificates and the data of Secrets are not compared, as the desired
+// manifests are rendered with a throwaway CA and the data of Secrets must
+// not be printed.
+func Manifest(manifest []byte, get GetFunc) ([]Drift, error) {
+	docs, err := splitDocuments(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []Drift
+	for _, doc := range docs {
+		desired := &unstructured.Unstructured{Object: doc}
+		object := objectName(desired)
+
+		live, err := get(desired)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get %s", object)
+		}
+		if live == nil {
+			drifts = append(drifts, Drift{Object: object, Desired: "present", Live: Missing})
+			continue
+		}
+
+		drifts = append(drifts, Fields(object, comparedFields(desired), comparedFields(live))...)
+	}
+
+	return drifts, nil
+}
+
+// Kubeadm compares the ClusterConfiguration in the multi-document kubeadm
+// config rendered by KubeOne to the one stored in the kubeadm-config
+// ConfigMap. The fields are not compared if the configurations have
+// different API versions, e.g. after kubeadm migrated the stored one.
+func Kubeadm(desiredConfig, liveClusterConfiguration string) ([]Drift, error) {
+	const object = "kubeadm ClusterConfiguration"
+
+	docs, err := splitDocuments([]byte(desiredConfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the kubeadm config")
+	}
+
+	var desired map[string]interface{}
+	for _, doc := range docs {
+		if doc["kind"] == "ClusterConfiguration" {
+			desired = doc
+			break
+		}
+	}
+	if desired == nil {
+		return nil, errors.New("the kubeadm config doesn't contain ClusterConfiguration")
+	}
+
+	if liveClusterConfiguration == "" {
+		return []Drift{{Object: object, Desired: "present", Live: Missing}}, nil
+	}
+
+	live := map[string]interface{}{}
+	if err = yaml.Unmarshal([]byte(liveClusterConfiguration), &live); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the live ClusterConfiguration")
+	}
+
+	if desired["apiVersion"] != live["apiVersion"] {
+		return Fields(object,
+			map[string]interface{}{"apiVersion": desired["apiVersion"]},
+			map[string]interface{}{"apiVersion": live["apiVersion"]},
+		), nil
+	}
+
+	return Fields(object, desired, live), nil
+}
+
+// ClusterInfo compares the Kubernetes version, the manifest, the features
+// and the components versions to the ones recorded by the last apply. The
+// applied Info is nil if the kubeone-info ConfigMap doesn't exist.
+func ClusterInfo(applied, desired *clusterinfo.Info) []Drift {
+	object := fmt.Sprintf("ConfigMap %s/%s", metav1.NamespaceSystem, clusterinfo.ConfigMapName)
+	if applied == nil {
+		return []Drift{{Object: object, Desired: "present", Live: Missing}}
+	}
+
+	toMap := func(info *clusterinfo.Info) map[string]interface{} {
+		features := map[string]interface{}{}
+		for _, feature := range info.Features {
+			features[feature] = "enabled"
+		}
+
+		addons := map[string]interface{}{}
+		for name, tag := range info.Addons {
+			addons[name] = tag
+		}
+
+		return map[string]interface{}{
+			"kubernetesVersion": info.KubernetesVersion,
+			"manifestHash":      info.ManifestHash,
+			"features":          features,
+			"addons":            addons,
+		}
+	}
+
+	desiredFields, appliedFields := toMap(desired), toMap(applied)
+	drifts := Fields(object, desiredFields, appliedFields)
+
+	// the features and the components enabled only in the cluster are
+	// reported as well
+	drifts = append(drifts, Fields(object,
+		map[string]interface{}{"features": onlyIn(appliedFields["features"], desiredFields["features"], "disabled")},
+		map[string]interface{}{"features": appliedFields["features"]},
+	)...)
+	drifts = append(drifts, Fields(object,
+		map[string]interface{}{"addons": onlyIn(appliedFields["addons"], desiredFields["addons"], Missing)},
+		map[string]interface{}{"addons": appliedFields["addons"]},
+	)...)
+
+	return drifts
+}
+
+// Print prints the drifts in the given output format
+func Print(w io.Writer, drifts []Drift, output string) error {
+	if drifts == nil {
+		drifts = []Drift{}
+	}
+
+	switch output {
+	case OutputJSON:
+		b, err := json.MarshalIndent(drifts, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal drifts")
+		}
+		_, err = fmt.Fprintln(w, string(b))
+
+		return errors.WithStack(err)
+	case OutputYAML:
+		b, err := yaml.Marshal(drifts)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal drifts")
+		}
+		_, err = fmt.Fprint(w, string(b))
+
+		return errors.WithStack(err)
+	}
+
+	if len(drifts) == 0 {
+		_, err := fmt.Fprintln(w, "No drift detected, the cluster matches the manifest.")
+		return errors.WithStack(err)
+	}
+
+	printer := tabwriter.GetNewTabWriter(w)
+	fmt.Fprintln(printer, "OBJECT\tFIELD\tDESIRED\tLIVE\t")
+	for _, drift := range drifts {
+		fmt.Fprintf(printer, "%s\t%s\t%s\t%s\t\n", drift.Object, drift.Field, drift.Desired, drift.Live)
+	}
+
+	return errors.WithStack(printer.Flush())
+}
+
+type comparator struct {
+	object string
+	drifts []Drift
+}
+
+func (c *comparator) compare(field string, desired, live interface{}) {
+	switch d := desired.(type) {
+	case nil:
+		// empty fields are dropped by the API server
+		return
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if live != nil && !ok {
+			c.add(field, desired, live)
+			return
+		}
+
+		keys := make([]string, 0, len(d))
+		for key := range d {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			c.compare(joinField(field, key), d[key], l[key])
+		}
+	case []interface{}:
+		l, ok := live.([]interface{})
+		switch {
+		case len(d) == 0 && live == nil:
+			return
+		case !ok || len(l) != len(d):
+			c.add(field, desired, live)
+			return
+		}
+
+		for i := range d {
+			c.compare(fmt.Sprintf("%s[%d]", field, i), d[i], l[i])
+		}
+	default:
+		if live == nil || encode(desired) != encode(live) {
+			c.add(field, desired, live)
+		}
+	}
+}
+
+func (c *comparator) add(field string, desired, live interface{}) {
+	c.drifts = append(c.drifts, Drift{
+		Object:  c.object,
+		Field:   field,
+		Desired: encode(desired),
+		Live:    encode(live),
+	})
+}
+
+// comparedFields returns the labels, the annotations and the fields other
+// than metadata and status of the object
+func comparedFields(obj *unstructured.Unstructured) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for key, value := range obj.Object {
+		switch key {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		case "data", "stringData":
+			if obj.GetKind() == "Secret" {
+				continue
+			}
+		}
+		fields[key] = withoutCABundles(value)
+	}
+
+	metadata := map[string]interface{}{}
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		metadata["labels"] = stringMap(labels)
+	}
+	if annotations := obj.GetAnnotations(); len(annotations) > 0 {
+		metadata["annotations"] = stringMap(annotations)
+	}
+	if len(metadata) > 0 {
+		fields["metadata"] = metadata
+	}
+
+	return fields
+}
+
+// withoutCABundles returns the copy of the value without the caBundle fields
+// of the webhooks, the APIServices and the CRD conversion webhooks
+func withoutCABundles(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if key != "caBundle" {
+				out[key] = withoutCABundles(item)
+			}
+		}
+
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = withoutCABundles(item)
+		}
+
+		return out
+	}
+
+	return value
+}
+
+// onlyIn returns the keys of the from map missing in the other map, set to
+// the given value
+func onlyIn(from, other interface{}, value string) map[string]interface{} {
+	fromMap, _ := from.(map[string]interface{})
+	otherMap, _ := other.(map[string]interface{})
+
+	out := map[string]interface{}{}
+	for key := range fromMap {
+		if _, ok := otherMap[key]; !ok {
+			out[key] = value
+		}
+	}
+
+	return out
+}
+
+func splitDocuments(manifest []byte) ([]map[string]interface{}, error) {
+	reader := kyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(manifest)))
+
+	var docs []map[string]interface{}
+	for {
+		b, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the manifest")
+		}
+
+		doc := map[string]interface{}{}
+		if err = yaml.Unmarshal(b, &doc); err != nil {
+			return nil, errors.Wrap(err, "failed to parse the manifest")
+		}
+		if len(doc) == 0 {
+			continue
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func objectName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+	}
+
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
+
+func joinField(field, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%q]", field, key)
+	}
+	if field == "" {
+		return key
+	}
+
+	return field + "." + key
+}
+
+func stringMap(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		out[key] = value
+	}
+
+	return out
+}
+
+// encode returns the value as it's printed and compared. Numbers are
+// encoded the same regardless of their Go type.
+func encode(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return Missing
+	case string:
+		return v
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(b)
+}
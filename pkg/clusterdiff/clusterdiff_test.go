@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdiff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8c.io/kubeone/pkg/clusterinfo"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestManifest(t *testing.T) {
+	manifest := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: coredns
+  namespace: kube-system
+  labels:
+    kubeone.io/addon: coredns
+  creationTimestamp: null
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: coredns
+        image: registry.k8s.io/coredns/coredns:v1.10.1
+        args: ["-conf", "/etc/coredns/Corefile"]
+      tolerations: []
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: webhook-tls
+  namespace: kube-system
+data:
+  tls.crt: cmVuZGVyZWQ=
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: webhook
+webhooks:
+- name: webhook.kubeone.io
+  clientConfig:
+    caBundle: cmVuZGVyZWQ=
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: removed
+  namespace: kube-system
+`
+
+	live := map[string]*unstructured.Unstructured{
+		"Deployment": {Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "coredns",
+				"namespace": "kube-system",
+				"uid":       "1234",
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(3),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name":                     "coredns",
+								"image":                    "registry.k8s.io/coredns/coredns:v1.10.1",
+								"args":                     []interface{}{"-conf", "/etc/coredns/Corefile"},
+								"terminationMessagePolicy": "File",
+							},
+						},
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"replicas": int64(3),
+			},
+		}},
+		"Secret": {Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": "webhook-tls", "namespace": "kube-system"},
+			"data":       map[string]interface{}{"tls.crt": "bGl2ZQ=="},
+		}},
+		"MutatingWebhookConfiguration": {Object: map[string]interface{}{
+			"apiVersion": "admissionregistration.k8s.io/v1",
+			"kind":       "MutatingWebhookConfiguration",
+			"metadata":   map[string]interface{}{"name": "webhook"},
+			"webhooks": []interface{}{
+				map[string]interface{}{
+					"name":         "webhook.kubeone.io",
+					"clientConfig": map[string]interface{}{"caBundle": "bGl2ZQ=="},
+				},
+			},
+		}},
+	}
+
+	get := func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+		return live[obj.GetKind()], nil
+	}
+
+	drifts, err := Manifest([]byte(manifest), get)
+	if err != nil {
+		t.Fatalf("Manifest() error = %v", err)
+	}
+
+	want := []Drift{
+		{Object: "Deployment kube-system/coredns", Field: `metadata.labels["kubeone.io/addon"]`, Desired: "coredns", Live: Missing},
+		{Object: "Deployment kube-system/coredns", Field: "spec.replicas", Desired: "2", Live: "3"},
+		{Object: "ConfigMap kube-system/removed", Desired: "present", Live: Missing},
+	}
+	if diff := cmp.Diff(want, drifts); diff != "" {
+		t.Errorf("Manifest() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestKubeadm(t *testing.T) {
+	desired := `
+apiVersion: kubeadm.k8s.io/v1beta3
+kind: InitConfiguration
+nodeRegistration:
+  name: cp-0
+---
+apiVersion: kubeadm.k8s.io/v1beta3
+kind: ClusterConfiguration
+kubernetesVersion: v1.27.1
+apiServer:
+  extraArgs:
+    audit-log-path: /var/log/audit.log
+networking:
+  podSubnet: 10.244.0.0/16
+`
+
+	tests := []struct {
+		name string
+		live string
+		want []Drift
+	}{
+		{
+			name: "changed fields",
+			live: `
+apiVersion: kubeadm.k8s.io/v1beta3
+kind: ClusterConfiguration
+kubernetesVersion: v1.27.1
+apiServer:
+  extraArgs:
+    enable-admission-plugins: NodeRestriction
+networking:
+  podSubnet: 10.244.0.0/16
+  serviceSubnet: 10.96.0.0/12
+`,
+			want: []Drift{
+				{Object: "kubeadm ClusterConfiguration", Field: "apiServer.extraArgs.audit-log-path", Desired: "/var/log/audit.log", Live: Missing},
+			},
+		},
+		{
+			name: "migrated API version",
+			live: `
+apiVersion: kubeadm.k8s.io/v1beta4
+kind: ClusterConfiguration
+kubernetesVersion: v1.27.1
+`,
+			want: []Drift{
+				{Object: "kubeadm ClusterConfiguration", Field: "apiVersion", Desired: "kubeadm.k8s.io/v1beta3", Live: "kubeadm.k8s.io/v1beta4"},
+			},
+		},
+		{
+			name: "missing",
+			want: []Drift{
+				{Object: "kubeadm ClusterConfiguration", Desired: "present", Live: Missing},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drifts, err := Kubeadm(desired, tt.live)
+			if err != nil {
+				t.Fatalf("Kubeadm() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, drifts); diff != "" {
+				t.Errorf("Kubeadm() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClusterInfo(t *testing.T) {
+	const object = "ConfigMap kube-system/kubeone-info"
+
+	applied := &clusterinfo.Info{
+		KubernetesVersion: "1.27.1",
+		ManifestHash:      "abc",
+		Features:          []string{"metricsServer", "podSecurityPolicy"},
+		Addons:            map[string]string{"calico": "v3.26.1", "weave": "2.8.1"},
+	}
+	desired := &clusterinfo.Info{
+		KubernetesVersion: "1.27.3",
+		ManifestHash:      "abc",
+		Features:          []string{"metricsServer", "staticAuditLog"},
+		Addons:            map[string]string{"calico": "v3.26.1", "cilium": "v1.14.1"},
+	}
+
+	want := []Drift{
+		{Object: object, Field: "addons.cilium", Desired: "v1.14.1", Live: Missing},
+		{Object: object, Field: "features.staticAuditLog", Desired: "enabled", Live: Missing},
+		{Object: object, Field: "kubernetesVersion", Desired: "1.27.3", Live: "1.27.1"},
+		{Object: object, Field: "features.podSecurityPolicy", Desired: "disabled", Live: "enabled"},
+		{Object: object, Field: "addons.weave", Desired: Missing, Live: "2.8.1"},
+	}
+	if diff := cmp.Diff(want, ClusterInfo(applied, desired)); diff != "" {
+		t.Errorf("ClusterInfo() mismatch (-want +got):\n%s", diff)
+	}
+
+	if drifts := ClusterInfo(nil, desired); len(drifts) != 1 || drifts[0].Live != Missing {
+		t.Errorf("expected missing kubeone-info to be reported, but got %v", drifts)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var out bytes.Buffer
+	if err := Print(&out, nil, OutputJSON); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "[]" {
+		t.Errorf("expected empty JSON list, but got %q", got)
+	}
+
+	out.Reset()
+	drifts := []Drift{{Object: "Deployment kube-system/coredns", Field: "spec.replicas", Desired: "2", Live: "3"}}
+	if err := Print(&out, drifts, OutputText); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "spec.replicas") {
+		t.Errorf("expected the field to be printed, but got %q", out.String())
+	}
+}
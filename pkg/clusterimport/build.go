@@ -0,0 +1,449 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterimport
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clusterinfo"
+
+	corev1 "k8s.io/api/core/v1"
+	kyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	labelControlPlane       = "node-role.kubernetes.io/control-plane"
+	labelMaster             = "node-role.kubernetes.io/master"
+	annotationMachine       = "cluster.k8s.io/machine"
+	defaultAPIEndpointPort  = 6443
+	containerdRuntimePrefix = "containerd://"
+	crioRuntimePrefix       = "cri-o://"
+)
+
+// Inputs are the objects read from the live cluster
+type Inputs struct {
+	// Nodes are all Nodes of the cluster
+	Nodes []corev1.Node
+	// ClusterConfiguration is the kubeadm ClusterConfiguration stored in
+	// the kubeadm-config ConfigMap
+	ClusterConfiguration string
+	// DaemonSets are the names of DaemonSets in the kube-system namespace
+	DaemonSets []string
+	// Deployments are the names of Deployments in the kube-system namespace
+	Deployments []string
+	// Info is the content of the kubeone-info ConfigMap, if any
+	Info *clusterinfo.Info
+}
+
+// HostDefaults are set on every reconstructed host, since the SSH access
+// can't be discovered from the cluster
+type HostDefaults struct {
+	SSHUsername       string
+	SSHPort           int
+	SSHPrivateKeyFile string
+	Bastion           string
+}
+
+// kubeadmClusterConfiguration is the subset of the kubeadm
+// ClusterConfiguration used to reconstruct the manifest
+type kubeadmClusterConfiguration struct {
+	ClusterName          string `json:"clusterName"`
+	KubernetesVersion    string `json:"kubernetesVersion"`
+	ControlPlaneEndpoint string `json:"controlPlaneEndpoint"`
+	Networking           struct {
+		PodSubnet     string `json:"podSubnet"`
+		ServiceSubnet string `json:"serviceSubnet"`
+		DNSDomain     string `json:"dnsDomain"`
+	} `json:"networking"`
+	APIServer struct {
+		ExtraArgs map[string]string `json:"extraArgs"`
+	} `json:"apiServer"`
+	ControllerManager struct {
+		ExtraArgs map[string]string `json:"extraArgs"`
+	} `json:"controllerManager"`
+	Etcd struct {
+		External *struct{} `json:"external"`
+	} `json:"etcd"`
+}
+
+// Build reconstructs the best-effort KubeOneCluster from the objects read
+// from the live cluster. The returned warnings describe the configuration
+// that couldn't be reconstructed and must be reviewed.
+func Build(in Inputs, name string, defaults HostDefaults) (*kubeoneapi.KubeOneCluster, []string, error) {
+	kubeadmCfg := kubeadmClusterConfiguration{}
+	if err := kyaml.Unmarshal([]byte(in.ClusterConfiguration), &kubeadmCfg); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse kubeadm ClusterConfiguration")
+	}
+
+	if kubeadmCfg.Etcd.External != nil {
+		return nil, nil, errors.New("clusters with the external etcd are not supported")
+	}
+
+	var warnings []string
+	warnf := func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	}
+
+	if name == "" {
+		name = kubeadmCfg.ClusterName
+	}
+
+	cluster := &kubeoneapi.KubeOneCluster{
+		Name: name,
+		Versions: kubeoneapi.VersionConfig{
+			Kubernetes: strings.TrimPrefix(kubeadmCfg.KubernetesVersion, "v"),
+		},
+		ClusterNetwork: kubeoneapi.ClusterNetworkConfig{
+			PodSubnet:         kubeadmCfg.Networking.PodSubnet,
+			ServiceSubnet:     kubeadmCfg.Networking.ServiceSubnet,
+			ServiceDomainName: kubeadmCfg.Networking.DNSDomain,
+			NodePortRange:     kubeadmCfg.APIServer.ExtraArgs["service-node-port-range"],
+			CNI:               detectCNI(in.DaemonSets, warnf),
+		},
+		MachineController: &kubeoneapi.MachineControllerConfig{
+			Deploy: contains(in.Deployments, "machine-controller"),
+		},
+	}
+
+	setIPFamilies(&cluster.ClusterNetwork, kubeadmCfg.Networking.PodSubnet, kubeadmCfg.Networking.ServiceSubnet)
+
+	apiEndpoint, err := parseAPIEndpoint(kubeadmCfg.ControlPlaneEndpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	cluster.APIEndpoint = apiEndpoint
+
+	nodes := append([]corev1.Node{}, in.Nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	var dynamicWorkers int
+	for _, node := range nodes {
+		switch {
+		case isControlPlane(node):
+			cluster.ControlPlane.Hosts = append(cluster.ControlPlane.Hosts, hostConfig(node, defaults))
+		case node.Annotations[annotationMachine] != "":
+			dynamicWorkers++
+		default:
+			cluster.StaticWorkers.Hosts = append(cluster.StaticWorkers.Hosts, hostConfig(node, defaults))
+		}
+	}
+	if len(cluster.ControlPlane.Hosts) == 0 {
+		return nil, nil, errors.New("no control plane nodes found")
+	}
+	if dynamicWorkers > 0 {
+		warnf("%d worker nodes are managed by machine-controller, their MachineDeployments are not added to the dynamicWorkers", dynamicWorkers)
+	}
+
+	cluster.ContainerRuntime = detectContainerRuntime(nodes)
+	cluster.CloudProvider = detectCloudProvider(nodes, kubeadmCfg.ControllerManager.ExtraArgs["cloud-provider"], warnf)
+	cluster.Features = detectFeatures(kubeadmCfg.APIServer.ExtraArgs, in, warnf)
+
+	if in.Info != nil && len(in.Info.UserAddons) > 0 {
+		warnf("the addons %s were deployed from a local directory, configure the addons path to keep them", strings.Join(in.Info.UserAddons, ", "))
+	}
+
+	return cluster, warnings, nil
+}
+
+func parseAPIEndpoint(endpoint string) (kubeoneapi.APIEndpoint, error) {
+	if endpoint == "" {
+		return kubeoneapi.APIEndpoint{}, errors.New("controlPlaneEndpoint is not set in the kubeadm ClusterConfiguration")
+	}
+
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		// the endpoint without the port
+		return kubeoneapi.APIEndpoint{Host: endpoint, Port: defaultAPIEndpointPort}, nil
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return kubeoneapi.APIEndpoint{}, errors.Wrapf(err, "invalid controlPlaneEndpoint port %q", port)
+	}
+
+	return kubeoneapi.APIEndpoint{Host: host, Port: p}, nil
+}
+
+// setIPFamilies splits the comma-separated subnets of the dual-stack cluster
+// into the subnets of the IP families, ordered as the pod subnets
+func setIPFamilies(network *kubeoneapi.ClusterNetworkConfig, podSubnets, serviceSubnets string) {
+	pods := strings.Split(podSubnets, ",")
+	if len(pods) < 2 {
+		return
+	}
+
+	network.PodSubnet = ""
+	for _, subnet := range pods {
+		if isIPv6CIDR(subnet) {
+			network.IPFamilies = append(network.IPFamilies, kubeoneapi.IPFamilyIPv6)
+			network.PodSubnetIPv6 = subnet
+		} else {
+			network.IPFamilies = append(network.IPFamilies, kubeoneapi.IPFamilyIPv4)
+			network.PodSubnet = subnet
+		}
+	}
+
+	network.ServiceSubnet = ""
+	for _, subnet := range strings.Split(serviceSubnets, ",") {
+		if isIPv6CIDR(subnet) {
+			network.ServiceSubnetIPv6 = subnet
+		} else {
+			network.ServiceSubnet = subnet
+		}
+	}
+}
+
+func isIPv6CIDR(subnet string) bool {
+	ip, _, err := net.ParseCIDR(subnet)
+
+	return err == nil && ip.To4() == nil
+}
+
+func isControlPlane(node corev1.Node) bool {
+	_, cp := node.Labels[labelControlPlane]
+	_, master := node.Labels[labelMaster]
+
+	return cp || master
+}
+
+func hostConfig(node corev1.Node, defaults HostDefaults) kubeoneapi.HostConfig {
+	host := kubeoneapi.HostConfig{
+		Hostname:          node.Name,
+		SSHUsername:       defaults.SSHUsername,
+		SSHPort:           defaults.SSHPort,
+		SSHPrivateKeyFile: defaults.SSHPrivateKeyFile,
+		Bastion:           defaults.Bastion,
+		Taints:            node.Spec.Taints,
+	}
+
+	for _, addr := range node.Status.Addresses {
+		switch addr.Type {
+		case corev1.NodeInternalIP:
+			if ip := net.ParseIP(addr.Address); ip != nil && ip.To4() == nil {
+				host.IPv6Addresses = append(host.IPv6Addresses, addr.Address)
+			} else if host.PrivateAddress == "" {
+				host.PrivateAddress = addr.Address
+			}
+		case corev1.NodeExternalIP:
+			if host.PublicAddress == "" {
+				host.PublicAddress = addr.Address
+			}
+		}
+	}
+	if host.PrivateAddress == "" && len(host.IPv6Addresses) > 0 {
+		host.PrivateAddress = host.IPv6Addresses[0]
+	}
+	if host.PublicAddress == "" {
+		host.PublicAddress = host.PrivateAddress
+	}
+
+	return host
+}
+
+func detectCNI(daemonSets []string, warnf func(string, ...interface{})) *kubeoneapi.CNI {
+	switch {
+	case contains(daemonSets, "canal"):
+		return &kubeoneapi.CNI{Canal: &kubeoneapi.CanalSpec{}}
+	case contains(daemonSets, "weave-net"):
+		warnf("WeaveNet encryption can't be detected, set clusterNetwork.cni.weaveNet.encrypted if it's used")
+		return &kubeoneapi.CNI{WeaveNet: &kubeoneapi.WeaveNetSpec{}}
+	case contains(daemonSets, "calico-node"):
+		warnf("Calico mode and BGP peering can't be detected, set clusterNetwork.cni.calico if they're used")
+		return &kubeoneapi.CNI{Calico: &kubeoneapi.CalicoSpec{}}
+	case contains(daemonSets, "cilium"):
+		warnf("Cilium kube-proxy replacement and Hubble can't be detected, set clusterNetwork.cni.cilium if they're used")
+		return &kubeoneapi.CNI{Cilium: &kubeoneapi.CiliumSpec{}}
+	}
+
+	return &kubeoneapi.CNI{External: &kubeoneapi.ExternalCNISpec{}}
+}
+
+func detectContainerRuntime(nodes []corev1.Node) kubeoneapi.ContainerRuntimeConfig {
+	for _, node := range nodes {
+		if strings.HasPrefix(node.Status.NodeInfo.ContainerRuntimeVersion, crioRuntimePrefix) {
+			return kubeoneapi.ContainerRuntimeConfig{CRIO: &kubeoneapi.ContainerRuntimeCRIO{}}
+		}
+		if !strings.HasPrefix(node.Status.NodeInfo.ContainerRuntimeVersion, containerdRuntimePrefix) {
+			return kubeoneapi.ContainerRuntimeConfig{Docker: &kubeoneapi.ContainerRuntimeDocker{}}
+		}
+	}
+
+	return kubeoneapi.ContainerRuntimeConfig{Containerd: &kubeoneapi.ContainerRuntimeContainerd{}}
+}
+
+func detectCloudProvider(nodes []corev1.Node, cloudProviderFlag string, warnf func(string, ...interface{})) kubeoneapi.CloudProviderSpec {
+	spec := kubeoneapi.CloudProviderSpec{
+		External: cloudProviderFlag == "external",
+	}
+
+	provider := cloudProviderFlag
+	for _, node := range nodes {
+		if i := strings.Index(node.Spec.ProviderID, "://"); i > 0 {
+			provider = node.Spec.ProviderID[:i]
+			break
+		}
+	}
+
+	switch provider {
+	case "aws":
+		spec.AWS = &kubeoneapi.AWSSpec{}
+	case "azure":
+		spec.Azure = &kubeoneapi.AzureSpec{}
+	case "digitalocean":
+		spec.DigitalOcean = &kubeoneapi.DigitalOceanSpec{}
+	case "gce":
+		spec.GCE = &kubeoneapi.GCESpec{}
+	case "hcloud":
+		spec.Hetzner = &kubeoneapi.HetznerSpec{}
+	case "kubevirt":
+		spec.KubeVirt = &kubeoneapi.KubeVirtSpec{}
+	case "nutanix":
+		spec.Nutanix = &kubeoneapi.NutanixSpec{}
+	case "openstack":
+		spec.Openstack = &kubeoneapi.OpenstackSpec{}
+	case "packet", "equinixmetal":
+		spec.Packet = &kubeoneapi.PacketSpec{}
+	case "vsphere":
+		spec.Vsphere = &kubeoneapi.VsphereSpec{}
+	case "vmware-cloud-director":
+		spec.VMwareCloudDirector = &kubeoneapi.VMwareCloudDirectorSpec{}
+	case "", "external":
+		spec.None = &kubeoneapi.NoneSpec{}
+		return spec
+	default:
+		warnf("the cloud provider %q is not supported, the none provider is used", provider)
+		spec.None = &kubeoneapi.NoneSpec{}
+		return spec
+	}
+
+	if spec.Azure != nil || spec.Openstack != nil || spec.Vsphere != nil {
+		warnf("the cloudConfig contains credentials and can't be reconstructed, set cloudProvider.cloudConfig")
+	}
+
+	return spec
+}
+
+func detectFeatures(apiServerArgs map[string]string, in Inputs, warnf func(string, ...interface{})) kubeoneapi.Features {
+	features := kubeoneapi.Features{
+		MetricsServer: &kubeoneapi.MetricsServer{
+			Enable: contains(in.Deployments, "metrics-server"),
+		},
+	}
+
+	admissionPlugins := strings.Split(apiServerArgs["enable-admission-plugins"], ",")
+
+	if contains(admissionPlugins, "PodNodeSelector") {
+		features.PodNodeSelector = &kubeoneapi.PodNodeSelector{Enable: true}
+		warnf("the PodNodeSelector configuration file can't be reconstructed, set features.podNodeSelector.config.configFilePath")
+	}
+
+	if contains(admissionPlugins, "EventRateLimit") {
+		features.EventRateLimit = &kubeoneapi.EventRateLimit{Enable: true}
+		warnf("the EventRateLimit limits can't be reconstructed, set features.eventRateLimit.config.limits to keep custom limits")
+	}
+
+	if contains(admissionPlugins, "AlwaysPullImages") {
+		features.AlwaysPullImages = &kubeoneapi.AlwaysPullImages{Enable: true}
+	}
+
+	if contains(admissionPlugins, "DenyServiceExternalIPs") {
+		features.DenyServiceExternalIPs = &kubeoneapi.DenyServiceExternalIPs{Enable: true}
+	}
+
+	if contains(admissionPlugins, "NodeRestriction") {
+		features.NodeRestriction = &kubeoneapi.NodeRestriction{Enable: true}
+	}
+
+	if contains(admissionPlugins, "PodSecurityPolicy") {
+		features.PodSecurityPolicy = &kubeoneapi.PodSecurityPolicy{Enable: true}
+	}
+
+	if apiServerArgs["audit-policy-file"] != "" {
+		features.StaticAuditLog = &kubeoneapi.StaticAuditLog{
+			Enable: true,
+			Config: kubeoneapi.StaticAuditLogConfig{
+				LogPath: apiServerArgs["audit-log-path"],
+			},
+		}
+		warnf("the audit policy can't be reconstructed, set features.staticAuditLog.config.policyFilePath")
+	}
+
+	if issuer := apiServerArgs["oidc-issuer-url"]; issuer != "" {
+		features.OpenIDConnect = &kubeoneapi.OpenIDConnect{
+			Enable: true,
+			Config: kubeoneapi.OpenIDConnectConfig{
+				IssuerURL:      issuer,
+				ClientID:       apiServerArgs["oidc-client-id"],
+				UsernameClaim:  apiServerArgs["oidc-username-claim"],
+				UsernamePrefix: apiServerArgs["oidc-username-prefix"],
+				GroupsClaim:    apiServerArgs["oidc-groups-claim"],
+				GroupsPrefix:   apiServerArgs["oidc-groups-prefix"],
+				RequiredClaim:  apiServerArgs["oidc-required-claim"],
+				SigningAlgs:    apiServerArgs["oidc-signing-algs"],
+				CAFile:         apiServerArgs["oidc-ca-file"],
+			},
+		}
+	}
+
+	if apiServerArgs["encryption-provider-config"] != "" {
+		features.EncryptionProviders = &kubeoneapi.EncryptionProviders{Enable: true}
+	}
+
+	// Features that don't leave traces in the kubeadm configuration are
+	// taken from the kubeone-info ConfigMap, if it exists
+	if in.Info != nil {
+		for _, feature := range in.Info.Features {
+			switch feature {
+			case "fips":
+				features.FIPS = &kubeoneapi.FIPS{Enable: true}
+			case "selinux":
+				features.SELinux = &kubeoneapi.SELinux{Enable: true}
+			case "appArmor":
+				features.AppArmor = &kubeoneapi.AppArmor{Enable: true}
+				warnf("the AppArmor profiles can't be reconstructed, set features.appArmor")
+			case "seccompDefault":
+				features.SeccompDefault = &kubeoneapi.SeccompDefault{Enable: true}
+			case "bootstrapRBAC":
+				features.BootstrapRBAC = &kubeoneapi.BootstrapRBAC{Enable: true}
+				warnf("the bootstrap RBAC subjects can't be reconstructed, set features.bootstrapRBAC")
+			case "dynamicAuditLog":
+				features.DynamicAuditLog = &kubeoneapi.DynamicAuditLog{Enable: true}
+			case "podPresets":
+				features.PodPresets = &kubeoneapi.PodPresets{Enable: true}
+			}
+		}
+	}
+
+	return features
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterimport
+
+import (
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clusterinfo"
+	"k8c.io/kubeone/pkg/testhelper"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kyaml "sigs.k8s.io/yaml"
+)
+
+var updateFlag = flag.Bool("update", false, "update testdata files")
+
+const testClusterConfiguration = `
+apiVersion: kubeadm.k8s.io/v1beta2
+kind: ClusterConfiguration
+clusterName: kubernetes
+kubernetesVersion: v1.22.5
+controlPlaneEndpoint: api.example.com:6443
+networking:
+  dnsDomain: cluster.local
+  podSubnet: 10.244.0.0/16
+  serviceSubnet: 10.96.0.0/12
+apiServer:
+  extraArgs:
+    enable-admission-plugins: NodeRestriction,PodNodeSelector
+    oidc-issuer-url: https://dex.example.com
+    oidc-client-id: kubernetes
+controllerManager:
+  extraArgs:
+    cloud-provider: external
+`
+
+func testNode(name string, labels, annotations map[string]string, internalIP, externalIP string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: "hcloud://" + name,
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: internalIP},
+				{Type: corev1.NodeExternalIP, Address: externalIP},
+			},
+			NodeInfo: corev1.NodeSystemInfo{
+				ContainerRuntimeVersion: "containerd://1.4.12",
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	cp := map[string]string{labelMaster: ""}
+	in := Inputs{
+		Nodes: []corev1.Node{
+			testNode("cp-1", cp, nil, "10.0.0.3", "192.0.2.3"),
+			testNode("cp-0", cp, nil, "10.0.0.2", "192.0.2.2"),
+			testNode("worker-0", nil, nil, "10.0.0.10", ""),
+			testNode("pool-abc", nil, map[string]string{annotationMachine: "kube-system/pool-abc"}, "10.0.0.20", ""),
+		},
+		ClusterConfiguration: testClusterConfiguration,
+		DaemonSets:           []string{"canal", "kube-proxy", "node-local-dns"},
+		Deployments:          []string{"coredns", "machine-controller", "metrics-server"},
+		Info: &clusterinfo.Info{
+			Features:   []string{"metricsServer", "seccompDefault"},
+			UserAddons: []string{"backups"},
+		},
+	}
+
+	cluster, warnings, err := Build(in, "demo", HostDefaults{SSHUsername: "root", SSHPort: 22})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if len(warnings) != 3 {
+		t.Errorf("expected 3 warnings, but got %q", warnings)
+	}
+
+	manifest, err := kyaml.Marshal(cluster)
+	if err != nil {
+		t.Fatalf("failed to marshal the cluster: %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), string(manifest), *updateFlag)
+}
+
+func TestBuildDualStack(t *testing.T) {
+	node := testNode("cp-0", map[string]string{labelMaster: ""}, nil, "10.0.0.2", "192.0.2.2")
+	node.Status.Addresses = append(node.Status.Addresses, corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "fd00::2"})
+
+	clusterConfiguration := strings.NewReplacer(
+		"podSubnet: 10.244.0.0/16", "podSubnet: 10.244.0.0/16,fd01::/48",
+		"serviceSubnet: 10.96.0.0/12", "serviceSubnet: 10.96.0.0/12,fd02::/120",
+	).Replace(testClusterConfiguration)
+
+	cluster, _, err := Build(Inputs{Nodes: []corev1.Node{node}, ClusterConfiguration: clusterConfiguration}, "demo", HostDefaults{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	expected := kubeoneapi.ClusterNetworkConfig{
+		IPFamilies:        []kubeoneapi.IPFamily{kubeoneapi.IPFamilyIPv4, kubeoneapi.IPFamilyIPv6},
+		PodSubnet:         "10.244.0.0/16",
+		ServiceSubnet:     "10.96.0.0/12",
+		PodSubnetIPv6:     "fd01::/48",
+		ServiceSubnetIPv6: "fd02::/120",
+		ServiceDomainName: "cluster.local",
+	}
+	cluster.ClusterNetwork.CNI = nil
+	if !reflect.DeepEqual(cluster.ClusterNetwork, expected) {
+		t.Errorf("expected cluster network %+v, but got %+v", expected, cluster.ClusterNetwork)
+	}
+
+	host := cluster.ControlPlane.Hosts[0]
+	if host.PrivateAddress != "10.0.0.2" || !reflect.DeepEqual(host.IPv6Addresses, []string{"fd00::2"}) {
+		t.Errorf("expected addresses 10.0.0.2 and [fd00::2], but got %s and %v", host.PrivateAddress, host.IPv6Addresses)
+	}
+}
+
+func TestBuildWithoutControlPlane(t *testing.T) {
+	in := Inputs{
+		Nodes:                []corev1.Node{testNode("worker-0", nil, nil, "10.0.0.10", "")},
+		ClusterConfiguration: testClusterConfiguration,
+	}
+
+	if _, _, err := Build(in, "", HostDefaults{}); err == nil {
+		t.Errorf("expected error for a cluster without control plane nodes")
+	}
+}
+
+func TestBuildExternalEtcd(t *testing.T) {
+	in := Inputs{
+		Nodes:                []corev1.Node{testNode("cp-0", map[string]string{labelMaster: ""}, nil, "10.0.0.2", "")},
+		ClusterConfiguration: testClusterConfiguration + "etcd:\n  external:\n    endpoints:\n    - https://10.0.0.100:2379\n",
+	}
+
+	if _, _, err := Build(in, "", HostDefaults{}); err == nil {
+		t.Errorf("expected error for a cluster with the external etcd")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	cp := map[string]string{labelMaster: ""}
+	in := Inputs{
+		Nodes: []corev1.Node{
+			testNode("cp-0", cp, nil, "10.0.0.2", ""),
+			testNode("cp-1", cp, nil, "10.0.0.3", ""),
+		},
+		ClusterConfiguration: testClusterConfiguration,
+		DaemonSets:           []string{"canal"},
+	}
+
+	live, _, err := Build(in, "demo", HostDefaults{})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	manifest := live.DeepCopy()
+	if diffs := Diff(manifest, live); len(diffs) != 0 {
+		t.Errorf("expected no differences, but got %q", diffs)
+	}
+
+	manifest.Versions.Kubernetes = "1.22.4"
+	manifest.ClusterNetwork.CNI = &kubeoneapi.CNI{WeaveNet: &kubeoneapi.WeaveNetSpec{}}
+	manifest.ControlPlane.Hosts = manifest.ControlPlane.Hosts[:1]
+
+	expected := []string{
+		`versions.kubernetes is "1.22.4" in the manifest, but "1.22.5" in the cluster`,
+		`clusterNetwork.cni is "weaveNet" in the manifest, but "canal" in the cluster`,
+		`controlPlane.hosts is "cp-0" in the manifest, but "cp-0,cp-1" in the cluster`,
+	}
+	if diffs := Diff(manifest, live); !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("expected differences %q, but got %q", expected, diffs)
+	}
+}
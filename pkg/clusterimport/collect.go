@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterimport
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/clusterinfo"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kubeadmConfigMapName           = "kubeadm-config"
+	kubeadmClusterConfigurationKey = "ClusterConfiguration"
+)
+
+// Collect reads the objects needed to reconstruct the manifest from the
+// live cluster
+func Collect(ctx context.Context, c dynclient.Client) (*Inputs, error) {
+	in := &Inputs{}
+
+	nodes := corev1.NodeList{}
+	if err := c.List(ctx, &nodes); err != nil {
+		return nil, errors.Wrap(err, "failed to list nodes")
+	}
+	in.Nodes = nodes.Items
+
+	kubeadmConfig := corev1.ConfigMap{}
+	key := dynclient.ObjectKey{Name: kubeadmConfigMapName, Namespace: metav1.NamespaceSystem}
+	if err := c.Get(ctx, key, &kubeadmConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to get kubeadm-config ConfigMap, is the cluster provisioned by kubeadm?")
+	}
+	in.ClusterConfiguration = kubeadmConfig.Data[kubeadmClusterConfigurationKey]
+
+	daemonSets := appsv1.DaemonSetList{}
+	if err := c.List(ctx, &daemonSets, dynclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, errors.Wrap(err, "failed to list DaemonSets")
+	}
+	for _, ds := range daemonSets.Items {
+		in.DaemonSets = append(in.DaemonSets, ds.Name)
+	}
+
+	deployments := appsv1.DeploymentList{}
+	if err := c.List(ctx, &deployments, dynclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return nil, errors.Wrap(err, "failed to list Deployments")
+	}
+	for _, deploy := range deployments.Items {
+		in.Deployments = append(in.Deployments, deploy.Name)
+	}
+
+	info, err := clusterinfo.Load(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	in.Info = info
+
+	return in, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterimport
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+// Diff returns the differences between the manifest and the cluster
+// reconstructed from the live cluster, that can't be reconciled without
+// recreating the control plane
+func Diff(manifest, live *kubeoneapi.KubeOneCluster) []string {
+	var diffs []string
+	compare := func(field, manifestValue, liveValue string) {
+		if manifestValue != liveValue {
+			diffs = append(diffs, fmt.Sprintf("%s is %q in the manifest, but %q in the cluster", field, manifestValue, liveValue))
+		}
+	}
+
+	compare("versions.kubernetes", manifest.Versions.Kubernetes, live.Versions.Kubernetes)
+	compare("apiEndpoint", apiEndpointString(manifest.APIEndpoint), apiEndpointString(live.APIEndpoint))
+	compare("clusterNetwork.podSubnet", manifest.ClusterNetwork.PodSubnet, live.ClusterNetwork.PodSubnet)
+	compare("clusterNetwork.serviceSubnet", manifest.ClusterNetwork.ServiceSubnet, live.ClusterNetwork.ServiceSubnet)
+	compare("clusterNetwork.podSubnetIPv6", manifest.ClusterNetwork.PodSubnetIPv6, live.ClusterNetwork.PodSubnetIPv6)
+	compare("clusterNetwork.serviceSubnetIPv6", manifest.ClusterNetwork.ServiceSubnetIPv6, live.ClusterNetwork.ServiceSubnetIPv6)
+	compare("clusterNetwork.serviceDomainName", manifest.ClusterNetwork.ServiceDomainName, live.ClusterNetwork.ServiceDomainName)
+	compare("clusterNetwork.cni", cniName(manifest.ClusterNetwork.CNI), cniName(live.ClusterNetwork.CNI))
+	compare("controlPlane.hosts", hostnames(manifest.ControlPlane.Hosts), hostnames(live.ControlPlane.Hosts))
+
+	return diffs
+}
+
+func apiEndpointString(endpoint kubeoneapi.APIEndpoint) string {
+	return fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port)
+}
+
+func cniName(cni *kubeoneapi.CNI) string {
+	switch {
+	case cni == nil:
+		return ""
+	case cni.Canal != nil:
+		return "canal"
+	case cni.WeaveNet != nil:
+		return "weaveNet"
+	case cni.Cilium != nil:
+		return "cilium"
+	case cni.Calico != nil:
+		return "calico"
+	case cni.External != nil:
+		return "external"
+	}
+
+	return ""
+}
+
+func hostnames(hosts []kubeoneapi.HostConfig) string {
+	names := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		names = append(names, host.Hostname)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterinfo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap in the kube-system namespace
+	// recording which KubeOne release and manifest last applied the cluster
+	ConfigMapName = "kubeone-info"
+
+	keyKubeOneVersion    = "kubeoneVersion"
+	keyKubernetesVersion = "kubernetesVersion"
+	keyManifestHash      = "manifestHash"
+	keyFeatures          = "features"
+	keyAddons            = "addons"
+	keyUserAddons        = "userAddons"
+	keyAppliedAt         = "appliedAt"
+)
+
+// Info describes the last successful apply of the cluster
+type Info struct {
+	KubeOneVersion    string            `json:"kubeoneVersion"`
+	KubernetesVersion string            `json:"kubernetesVersion"`
+	ManifestHash      string            `json:"manifestHash"`
+	Features          []string          `json:"features,omitempty"`
+	Addons            map[string]string `json:"addons,omitempty"`
+	UserAddons        []string          `json:"userAddons,omitempty"`
+	AppliedAt         time.Time         `json:"appliedAt"`
+}
+
+// ManifestHash returns the hex-encoded SHA256 sum of the manifest
+func ManifestHash(manifest []byte) string {
+	sum := sha256.Sum256(manifest)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// EnabledFeatures returns the sorted names of the enabled features
+func EnabledFeatures(features kubeoneapi.Features) []string {
+	enabled := map[string]bool{
+		"podNodeSelector":        features.PodNodeSelector != nil && features.PodNodeSelector.Enable,
+		"podPresets":             features.PodPresets != nil && features.PodPresets.Enable,
+		"podSecurityPolicy":      features.PodSecurityPolicy != nil && features.PodSecurityPolicy.Enable,
+		"staticAuditLog":         features.StaticAuditLog != nil && features.StaticAuditLog.Enable,
+		"dynamicAuditLog":        features.DynamicAuditLog != nil && features.DynamicAuditLog.Enable,
+		"metricsServer":          features.MetricsServer != nil && features.MetricsServer.Enable,
+		"openidConnect":          features.OpenIDConnect != nil && features.OpenIDConnect.Enable,
+		"encryptionProviders":    features.EncryptionProviders != nil && features.EncryptionProviders.Enable,
+		"fips":                   features.FIPS != nil && features.FIPS.Enable,
+		"selinux":                features.SELinux != nil && features.SELinux.Enable,
+		"appArmor":               features.AppArmor != nil && features.AppArmor.Enable,
+		"seccompDefault":         features.SeccompDefault != nil && features.SeccompDefault.Enable,
+		"bootstrapRBAC":          features.BootstrapRBAC != nil && features.BootstrapRBAC.Enable,
+		"eventRateLimit":         features.EventRateLimit != nil && features.EventRateLimit.Enable,
+		"alwaysPullImages":       features.AlwaysPullImages != nil && features.AlwaysPullImages.Enable,
+		"denyServiceExternalIPs": features.DenyServiceExternalIPs != nil && features.DenyServiceExternalIPs.Enable,
+		"nodeRestriction":        features.NodeRestriction != nil && features.NodeRestriction.Enable,
+	}
+
+	names := []string{}
+	for name, on := range enabled {
+		if on {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// AddonsChanges returns the changes of the components versions and the user
+// addons between the applied and the desired Info, prefixed with "+" for
+// the added, "-" for the removed and "~" for the changed ones
+func AddonsChanges(applied, desired *Info) []string {
+	changes := []string{}
+
+	names := []string{}
+	for name := range applied.Addons {
+		names = append(names, name)
+	}
+	for name := range desired.Addons {
+		if _, ok := applied.Addons[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		appliedTag, wasApplied := applied.Addons[name]
+		desiredTag, isDesired := desired.Addons[name]
+
+		switch {
+		case !wasApplied:
+			changes = append(changes, fmt.Sprintf("+ %s %s", name, desiredTag))
+		case !isDesired:
+			changes = append(changes, fmt.Sprintf("- %s %s", name, appliedTag))
+		case appliedTag != desiredTag:
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s", name, appliedTag, desiredTag))
+		}
+	}
+
+	appliedUserAddons := sets.NewString(applied.UserAddons...)
+	desiredUserAddons := sets.NewString(desired.UserAddons...)
+	for _, name := range desiredUserAddons.Difference(appliedUserAddons).List() {
+		changes = append(changes, fmt.Sprintf("+ user addon %q", name))
+	}
+	for _, name := range appliedUserAddons.Difference(desiredUserAddons).List() {
+		changes = append(changes, fmt.Sprintf("- user addon %q", name))
+	}
+
+	return changes
+}
+
+// ConfigMap returns the ConfigMap recording the Info
+func (i *Info) ConfigMap() (*corev1.ConfigMap, error) {
+	addons, err := json.Marshal(i.Addons)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal addons versions")
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Data: map[string]string{
+			keyKubeOneVersion:    i.KubeOneVersion,
+			keyKubernetesVersion: i.KubernetesVersion,
+			keyManifestHash:      i.ManifestHash,
+			keyFeatures:          strings.Join(i.Features, ","),
+			keyAddons:            string(addons),
+			keyUserAddons:        strings.Join(i.UserAddons, ","),
+			keyAppliedAt:         i.AppliedAt.UTC().Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// FromConfigMap parses the Info recorded in the ConfigMap
+func FromConfigMap(cm *corev1.ConfigMap) (*Info, error) {
+	info := &Info{
+		KubeOneVersion:    cm.Data[keyKubeOneVersion],
+		KubernetesVersion: cm.Data[keyKubernetesVersion],
+		ManifestHash:      cm.Data[keyManifestHash],
+		Features:          splitList(cm.Data[keyFeatures]),
+		UserAddons:        splitList(cm.Data[keyUserAddons]),
+	}
+
+	if addons := cm.Data[keyAddons]; addons != "" {
+		if err := json.Unmarshal([]byte(addons), &info.Addons); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q", keyAddons)
+		}
+	}
+
+	if appliedAt := cm.Data[keyAppliedAt]; appliedAt != "" {
+		t, err := time.Parse(time.RFC3339, appliedAt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %q", keyAppliedAt)
+		}
+		info.AppliedAt = t
+	}
+
+	return info, nil
+}
+
+// Save creates or updates the kubeone-info ConfigMap. The data of the
+// existing ConfigMap is replaced, so values left empty are not kept from the
+// previous apply.
+func Save(ctx context.Context, c dynclient.Client, info *Info) error {
+	cm, err := info.ConfigMap()
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.ConfigMap{}
+	err = c.Get(ctx, dynclient.ObjectKeyFromObject(cm), existing)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return errors.Wrapf(c.Create(ctx, cm), "failed to create %s ConfigMap", ConfigMapName)
+	case err != nil:
+		return errors.Wrapf(err, "failed to get %s ConfigMap", ConfigMapName)
+	}
+
+	existing.Data = cm.Data
+
+	return errors.Wrapf(c.Update(ctx, existing), "failed to update %s ConfigMap", ConfigMapName)
+}
+
+// Load returns the Info recorded in the cluster, or nil if the cluster was
+// never applied by a KubeOne release recording it
+func Load(ctx context.Context, c dynclient.Client) (*Info, error) {
+	cm := &corev1.ConfigMap{}
+	key := dynclient.ObjectKey{
+		Name:      ConfigMapName,
+		Namespace: metav1.NamespaceSystem,
+	}
+
+	err := c.Get(ctx, key, cm)
+	switch {
+	case k8serrors.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get %s ConfigMap", ConfigMapName)
+	}
+
+	return FromConfigMap(cm)
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, ",")
+}
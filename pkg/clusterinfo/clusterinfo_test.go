@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterinfo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestEnabledFeatures(t *testing.T) {
+	features := kubeoneapi.Features{
+		StaticAuditLog:    &kubeoneapi.StaticAuditLog{Enable: true},
+		MetricsServer:     &kubeoneapi.MetricsServer{Enable: true},
+		PodSecurityPolicy: &kubeoneapi.PodSecurityPolicy{Enable: false},
+	}
+
+	expected := []string{"metricsServer", "staticAuditLog"}
+	if got := EnabledFeatures(features); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected features %v, but got %v", expected, got)
+	}
+
+	if got := EnabledFeatures(kubeoneapi.Features{}); len(got) != 0 {
+		t.Errorf("expected no features, but got %v", got)
+	}
+}
+
+func TestConfigMapRoundTrip(t *testing.T) {
+	info := &Info{
+		KubeOneVersion:    "v1.4.0",
+		KubernetesVersion: "1.22.5",
+		ManifestHash:      ManifestHash([]byte("apiVersion: kubeone.io/v1beta1")),
+		Features:          []string{"metricsServer", "staticAuditLog"},
+		Addons:            map[string]string{"CoreDNS": "v1.8.4"},
+		UserAddons:        []string{"backups"},
+		AppliedAt:         time.Date(2021, 11, 3, 10, 0, 0, 0, time.UTC),
+	}
+
+	cm, err := info.ConfigMap()
+	if err != nil {
+		t.Fatalf("ConfigMap() error = %v", err)
+	}
+
+	got, err := FromConfigMap(cm)
+	if err != nil {
+		t.Fatalf("FromConfigMap() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, info) {
+		t.Errorf("expected info %+v, but got %+v", info, got)
+	}
+}
+
+func TestAddonsChanges(t *testing.T) {
+	applied := &Info{
+		Addons:     map[string]string{"CoreDNS": "v1.8.0", "Flannel": "v0.15.0", "MetricsServer": "v0.5.0"},
+		UserAddons: []string{"backups", "monitoring"},
+	}
+	desired := &Info{
+		Addons:     map[string]string{"CoreDNS": "v1.8.4", "Canal": "v3.21.0", "MetricsServer": "v0.5.0"},
+		UserAddons: []string{"backups", "logging"},
+	}
+
+	expected := []string{
+		"+ Canal v3.21.0",
+		"~ CoreDNS: v1.8.0 -> v1.8.4",
+		"- Flannel v0.15.0",
+		"+ user addon \"logging\"",
+		"- user addon \"monitoring\"",
+	}
+	if got := AddonsChanges(applied, desired); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected changes %q, but got %q", expected, got)
+	}
+
+	if got := AddonsChanges(desired, desired); len(got) != 0 {
+		t.Errorf("expected no changes, but got %q", got)
+	}
+}
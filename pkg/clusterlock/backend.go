@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterlock
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/statebackend"
+)
+
+// LockKey is the key of the lock object in the state backend
+const LockKey = "lock.json"
+
+type backendLock struct {
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	RenewedAt  time.Time `json:"renewedAt"`
+}
+
+// backendLocker stores the lock in the state backend. The lock is written
+// only if it wasn't changed since it was read, so only one of the concurrent
+// runs acquires or renews it.
+type backendLocker struct {
+	backend    statebackend.ConditionalBackend
+	identity   string
+	acquiredAt time.Time
+	version    string
+	now        func() time.Time
+}
+
+// NewBackendLocker returns the Locker storing the lock in the state backend
+func NewBackendLocker(backend statebackend.ConditionalBackend, identity string) Locker {
+	return &backendLocker{
+		backend:  backend,
+		identity: identity,
+		now:      time.Now,
+	}
+}
+
+func (l *backendLocker) Acquire(ctx context.Context) error {
+	current, version, err := l.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current != nil && heldByOther(current.Holder, l.identity, current.RenewedAt, l.now()) {
+		return &LockedError{Holder: current.Holder, RenewedAt: current.RenewedAt}
+	}
+
+	l.acquiredAt = l.now()
+	version, err = l.put(ctx, version)
+	if errors.Is(err, statebackend.ErrPreconditionFailed) {
+		// another run acquired the lock since it was read
+		if current, _, err = l.get(ctx); err == nil && current != nil {
+			return &LockedError{Holder: current.Holder, RenewedAt: current.RenewedAt}
+		}
+
+		return errLockLost
+	}
+	if err != nil {
+		return err
+	}
+	l.version = version
+
+	return nil
+}
+
+func (l *backendLocker) Renew(ctx context.Context) error {
+	version, err := l.put(ctx, l.version)
+	if errors.Is(err, statebackend.ErrPreconditionFailed) {
+		return errLockLost
+	}
+	if err != nil {
+		return err
+	}
+	l.version = version
+
+	return nil
+}
+
+func (l *backendLocker) Release(ctx context.Context) error {
+	if l.version == "" {
+		return nil
+	}
+
+	err := l.backend.DeleteIfMatch(ctx, LockKey, l.version)
+	if errors.Is(err, statebackend.ErrPreconditionFailed) {
+		// the lock was taken over by another run
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to release the cluster lock")
+}
+
+func (l *backendLocker) Break(ctx context.Context) error {
+	err := l.backend.Delete(ctx, LockKey)
+	if errors.Is(err, statebackend.ErrNotFound) {
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to delete the cluster lock")
+}
+
+func (l *backendLocker) get(ctx context.Context) (*backendLock, string, error) {
+	data, version, err := l.backend.GetVersion(ctx, LockKey)
+	switch {
+	case errors.Is(err, statebackend.ErrNotFound):
+		return nil, "", nil
+	case err != nil:
+		return nil, "", errors.Wrap(err, "failed to read the cluster lock")
+	}
+
+	lock := &backendLock{}
+	if err = json.Unmarshal(data, lock); err != nil {
+		return nil, "", errors.Wrap(err, "failed to unmarshal the cluster lock")
+	}
+
+	return lock, version, nil
+}
+
+// put writes the lock if its current version is the given one, and returns
+// the version of the written lock
+func (l *backendLocker) put(ctx context.Context, version string) (string, error) {
+	data, err := json.Marshal(backendLock{
+		Holder:     l.identity,
+		AcquiredAt: l.acquiredAt,
+		RenewedAt:  l.now(),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the cluster lock")
+	}
+
+	version, err = l.backend.PutIfMatch(ctx, LockKey, data, version)
+	if errors.Is(err, statebackend.ErrPreconditionFailed) {
+		return "", err
+	}
+
+	return version, errors.Wrap(err, "failed to write the cluster lock")
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterlock
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/statebackend"
+)
+
+type memoryBackend struct {
+	lock     sync.Mutex
+	objects  map[string][]byte
+	versions map[string]string
+	next     int
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{
+		objects:  map[string][]byte{},
+		versions: map[string]string{},
+	}
+}
+
+func (m *memoryBackend) Put(_ context.Context, key string, data []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.put(key, data)
+
+	return nil
+}
+
+func (m *memoryBackend) put(key string, data []byte) string {
+	m.next++
+	m.objects[key] = data
+	m.versions[key] = strconv.Itoa(m.next)
+
+	return m.versions[key]
+}
+
+func (m *memoryBackend) Get(_ context.Context, key string) ([]byte, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, statebackend.ErrNotFound
+	}
+
+	return data, nil
+}
+
+func (m *memoryBackend) Delete(_ context.Context, key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.objects, key)
+	delete(m.versions, key)
+
+	return nil
+}
+
+func (m *memoryBackend) GetVersion(_ context.Context, key string) ([]byte, string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, "", statebackend.ErrNotFound
+	}
+
+	return data, m.versions[key], nil
+}
+
+func (m *memoryBackend) PutIfMatch(_ context.Context, key string, data []byte, version string) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.versions[key] != version {
+		return "", statebackend.ErrPreconditionFailed
+	}
+
+	return m.put(key, data), nil
+}
+
+func (m *memoryBackend) DeleteIfMatch(_ context.Context, key, version string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if current, ok := m.versions[key]; ok && current != version {
+		return statebackend.ErrPreconditionFailed
+	}
+	delete(m.objects, key)
+	delete(m.versions, key)
+
+	return nil
+}
+
+func TestBackendLocker(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemoryBackend()
+	now := time.Date(2021, 11, 3, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	first := &backendLocker{backend: backend, identity: "ci/1", now: clock}
+	second := &backendLocker{backend: backend, identity: "laptop/2", now: clock}
+
+	if err := first.Acquire(ctx); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	var lockedErr *LockedError
+	if err := second.Acquire(ctx); !errors.As(err, &lockedErr) {
+		t.Fatalf("expected LockedError, but got %v", err)
+	}
+	if lockedErr.Holder != "ci/1" {
+		t.Errorf("expected holder %q, but got %q", "ci/1", lockedErr.Holder)
+	}
+
+	now = now.Add(LeaseDuration / 2)
+	if err := first.Renew(ctx); err != nil {
+		t.Fatalf("Renew() error = %v", err)
+	}
+
+	now = now.Add(LeaseDuration / 2)
+	if err := second.Acquire(ctx); !errors.As(err, &lockedErr) {
+		t.Fatalf("expected LockedError for renewed lock, but got %v", err)
+	}
+
+	if err := first.Release(ctx); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if err := second.Acquire(ctx); err != nil {
+		t.Fatalf("Acquire() of released lock error = %v", err)
+	}
+
+	// the expired lock is taken over
+	now = now.Add(LeaseDuration)
+	if err := first.Acquire(ctx); err != nil {
+		t.Fatalf("Acquire() of expired lock error = %v", err)
+	}
+	if err := second.Renew(ctx); err == nil {
+		t.Errorf("expected Renew() of lost lock to fail")
+	}
+	if err := second.Release(ctx); err != nil {
+		t.Fatalf("Release() of lost lock error = %v", err)
+	}
+	if _, err := backend.Get(ctx, LockKey); err != nil {
+		t.Errorf("expected lock of another holder to be kept, but got %v", err)
+	}
+
+	// the lock of another holder is removed by force
+	if err := second.Break(ctx); err != nil {
+		t.Fatalf("Break() error = %v", err)
+	}
+	if err := second.Acquire(ctx); err != nil {
+		t.Fatalf("Acquire() of broken lock error = %v", err)
+	}
+	if err := first.Renew(ctx); err == nil {
+		t.Errorf("expected Renew() of broken lock to fail")
+	}
+}
+
+func TestBackendLockerConcurrentAcquire(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemoryBackend()
+
+	const runs = 10
+	var (
+		wg       sync.WaitGroup
+		acquired int32
+	)
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			locker := NewBackendLocker(backend, fmt.Sprintf("run/%d", i))
+			if err := locker.Acquire(ctx); err == nil {
+				atomic.AddInt32(&acquired, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if acquired != 1 {
+		t.Errorf("expected exactly one run to acquire the lock, but %d did", acquired)
+	}
+}
+
+func TestHoldBackendLockerTakenOver(t *testing.T) {
+	ctx := context.Background()
+	backend := newMemoryBackend()
+
+	heldCtx, release, err := hold(ctx, NewBackendLocker(backend, "ci/1"), discardf, 10*time.Millisecond, time.Hour)
+	if err != nil {
+		t.Fatalf("hold() error = %v", err)
+	}
+	defer release()
+
+	// another run removes the lock by force and acquires it, so the
+	// conditional write renewing the lock fails
+	other := NewBackendLocker(backend, "laptop/2")
+	if err = other.Break(ctx); err != nil {
+		t.Fatalf("Break() error = %v", err)
+	}
+	if err = other.Acquire(ctx); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	waitDone(heldCtx, t)
+	if !errors.Is(heldCtx.Err(), errLockLost) {
+		t.Errorf("expected the lost lock error, but got %v", heldCtx.Err())
+	}
+
+	release()
+	current, _, err := other.(*backendLocker).get(ctx)
+	if err != nil || current == nil {
+		t.Fatalf("expected the lock of another run to be kept, but got %v", err)
+	}
+	if current.Holder != "laptop/2" {
+		t.Errorf("expected the lock to be held by %q, but got %q", "laptop/2", current.Holder)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterlock
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LeaseName is the name of the Lease in the kube-system namespace holding
+// the lock
+const LeaseName = "kubeone-lock"
+
+// leaseLocker stores the lock in the Lease object in the cluster. Concurrent
+// acquisitions are resolved by the optimistic concurrency of the API server.
+type leaseLocker struct {
+	client   dynclient.Client
+	identity string
+	now      func() time.Time
+}
+
+// NewLeaseLocker returns the Locker storing the lock in the Lease object in
+// the cluster
+func NewLeaseLocker(client dynclient.Client, identity string) Locker {
+	return &leaseLocker{
+		client:   client,
+		identity: identity,
+		now:      time.Now,
+	}
+}
+
+func (l *leaseLocker) Acquire(ctx context.Context) error {
+	lease := &coordinationv1.Lease{}
+	err := l.client.Get(ctx, l.key(), lease)
+
+	switch {
+	case k8serrors.IsNotFound(err):
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      LeaseName,
+				Namespace: metav1.NamespaceSystem,
+			},
+		}
+		l.hold(lease, true)
+
+		err = l.client.Create(ctx, lease)
+		if k8serrors.IsAlreadyExists(err) {
+			return errLockLost
+		}
+
+		return errors.Wrap(err, "failed to create the cluster lock")
+	case err != nil:
+		return errors.Wrap(err, "failed to get the cluster lock")
+	}
+
+	holder, renewedAt := leaseHolder(lease)
+	if heldByOther(holder, l.identity, renewedAt, l.now()) {
+		return &LockedError{Holder: holder, RenewedAt: renewedAt}
+	}
+
+	l.hold(lease, true)
+
+	return l.update(ctx, lease)
+}
+
+func (l *leaseLocker) Renew(ctx context.Context) error {
+	lease := &coordinationv1.Lease{}
+	if err := l.client.Get(ctx, l.key(), lease); err != nil {
+		return errors.Wrap(err, "failed to get the cluster lock")
+	}
+
+	if holder, _ := leaseHolder(lease); holder != l.identity {
+		return errLockLost
+	}
+
+	l.hold(lease, false)
+
+	return l.update(ctx, lease)
+}
+
+func (l *leaseLocker) Release(ctx context.Context) error {
+	lease := &coordinationv1.Lease{}
+	err := l.client.Get(ctx, l.key(), lease)
+
+	switch {
+	case k8serrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return errors.Wrap(err, "failed to get the cluster lock")
+	}
+
+	if holder, _ := leaseHolder(lease); holder != l.identity {
+		return nil
+	}
+
+	return errors.Wrap(dynclient.IgnoreNotFound(l.client.Delete(ctx, lease)), "failed to delete the cluster lock")
+}
+
+func (l *leaseLocker) Break(ctx context.Context) error {
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LeaseName,
+			Namespace: metav1.NamespaceSystem,
+		},
+	}
+
+	return errors.Wrap(dynclient.IgnoreNotFound(l.client.Delete(ctx, lease)), "failed to delete the cluster lock")
+}
+
+func (l *leaseLocker) key() dynclient.ObjectKey {
+	return dynclient.ObjectKey{
+		Name:      LeaseName,
+		Namespace: metav1.NamespaceSystem,
+	}
+}
+
+func (l *leaseLocker) hold(lease *coordinationv1.Lease, acquire bool) {
+	now := metav1.NewMicroTime(l.now())
+	duration := int32(LeaseDuration.Seconds())
+
+	lease.Spec.HolderIdentity = &l.identity
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.RenewTime = &now
+	if acquire {
+		lease.Spec.AcquireTime = &now
+	}
+}
+
+func (l *leaseLocker) update(ctx context.Context, lease *coordinationv1.Lease) error {
+	err := l.client.Update(ctx, lease)
+	if k8serrors.IsConflict(err) {
+		return errLockLost
+	}
+
+	return errors.Wrap(err, "failed to update the cluster lock")
+}
+
+func leaseHolder(lease *coordinationv1.Lease) (string, time.Time) {
+	var (
+		holder    string
+		renewedAt time.Time
+	)
+
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+	if lease.Spec.RenewTime != nil {
+		renewedAt = lease.Spec.RenewTime.Time
+	}
+
+	return holder, renewedAt
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterlock
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// LeaseDuration is how long the lock is held without being renewed.
+	// Locks of crashed runs are taken over after it passes.
+	LeaseDuration = 2 * time.Minute
+
+	// RenewInterval is how often the held lock is renewed
+	RenewInterval = 30 * time.Second
+)
+
+// Locker acquires the exclusive lock on the cluster
+type Locker interface {
+	// Acquire takes the lock, or returns the LockedError if the lock is held
+	// by another run
+	Acquire(ctx context.Context) error
+	// Renew extends the held lock
+	Renew(ctx context.Context) error
+	// Release gives up the held lock
+	Release(ctx context.Context) error
+	// Break removes the lock regardless of its holder
+	Break(ctx context.Context) error
+}
+
+// LockedError is returned when the lock is held by another run
+type LockedError struct {
+	Holder    string
+	RenewedAt time.Time
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("the cluster is locked by %s (last renewed at %s), "+
+		"wait for the other operation to finish or for the lock to expire after %s, "+
+		"or use --force-unlock if the other run is known to be gone",
+		e.Holder, e.RenewedAt.Format(time.RFC3339), LeaseDuration)
+}
+
+// Identity returns the identity of this run, used as the holder of the lock
+func Identity() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return fmt.Sprintf("%s/%d", hostname, os.Getpid())
+}
+
+// Hold acquires the lock and renews it in the background until the returned
+// release function is called. The returned context is cancelled when the lock
+// is lost, either because it was taken over by another run or because it
+// couldn't be renewed for LeaseDuration, after which other runs can take it
+// over. The operation must observe the context and abort, its Err returns
+// the reason. Other failures to renew are reported to warnf.
+func Hold(ctx context.Context, locker Locker, warnf func(format string, args ...interface{})) (context.Context, func(), error) {
+	return hold(ctx, locker, warnf, RenewInterval, LeaseDuration)
+}
+
+func hold(ctx context.Context, locker Locker, warnf func(format string, args ...interface{}), renewInterval, leaseDuration time.Duration) (context.Context, func(), error) {
+	if err := locker.Acquire(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	heldCtx := newHeldContext(ctx)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(renewInterval)
+		defer ticker.Stop()
+
+		renewedAt := time.Now()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := locker.Renew(ctx)
+				if err == nil {
+					renewedAt = time.Now()
+					continue
+				}
+
+				if !errors.Is(err, errLockLost) && time.Since(renewedAt) < leaseDuration {
+					warnf("Failed to renew the cluster lock: %v", err)
+					continue
+				}
+
+				if !errors.Is(err, errLockLost) {
+					err = errors.Wrapf(err, "the cluster lock couldn't be renewed for %s", leaseDuration)
+				}
+				err = errors.Wrap(err, "lost the cluster lock, aborting the operation")
+				warnf("%v", err)
+				heldCtx.cancel(err)
+
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return heldCtx, func() {
+		once.Do(func() {
+			close(stop)
+			wg.Wait()
+			heldCtx.cancel(context.Canceled)
+
+			if err := locker.Release(context.Background()); err != nil {
+				warnf("Failed to release the cluster lock: %v", err)
+			}
+		})
+	}, nil
+}
+
+// heldContext is the context of the operation holding the lock. It's
+// cancelled with the reason the lock was lost, returned by Err.
+type heldContext struct {
+	context.Context
+
+	cancelFn context.CancelFunc
+	lock     sync.Mutex
+	err      error
+}
+
+func newHeldContext(parent context.Context) *heldContext {
+	ctx, cancel := context.WithCancel(parent)
+
+	return &heldContext{Context: ctx, cancelFn: cancel}
+}
+
+func (c *heldContext) cancel(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err == nil && c.Context.Err() == nil {
+		c.err = err
+	}
+	c.cancelFn()
+}
+
+func (c *heldContext) Err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	return c.Context.Err()
+}
+
+// heldByOther returns whether the lock is held by another holder and is
+// not expired
+func heldByOther(holder, identity string, renewedAt, now time.Time) bool {
+	return holder != "" && holder != identity && now.Sub(renewedAt) < LeaseDuration
+}
+
+var errLockLost = errors.New("the cluster lock was taken over by another run")
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterlock
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type failingLocker struct {
+	Locker
+
+	lock     sync.Mutex
+	renewals int
+	err      error
+}
+
+func (l *failingLocker) Renew(ctx context.Context) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.renewals++
+
+	return l.err
+}
+
+func (l *failingLocker) setErr(err error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.err = err
+}
+
+func discardf(string, ...interface{}) {}
+
+func waitDone(ctx context.Context, t *testing.T) {
+	t.Helper()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the context to be cancelled")
+	}
+}
+
+func TestHoldTakenOver(t *testing.T) {
+	locker := &failingLocker{
+		Locker: NewBackendLocker(newMemoryBackend(), "ci/1"),
+	}
+
+	heldCtx, release, err := hold(context.Background(), locker, discardf, 10*time.Millisecond, time.Hour)
+	if err != nil {
+		t.Fatalf("hold() error = %v", err)
+	}
+	defer release()
+
+	time.Sleep(50 * time.Millisecond)
+	if err = heldCtx.Err(); err != nil {
+		t.Fatalf("expected the context of the held lock not to be cancelled, but got %v", err)
+	}
+
+	// another run takes the lock over
+	locker.setErr(errLockLost)
+
+	waitDone(heldCtx, t)
+	if !errors.Is(heldCtx.Err(), errLockLost) {
+		t.Errorf("expected the lost lock error, but got %v", heldCtx.Err())
+	}
+}
+
+func TestHoldRenewFailure(t *testing.T) {
+	locker := &failingLocker{
+		Locker: NewBackendLocker(newMemoryBackend(), "ci/1"),
+		err:    errors.New("connection refused"),
+	}
+
+	heldCtx, release, err := hold(context.Background(), locker, discardf, 10*time.Millisecond, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("hold() error = %v", err)
+	}
+	defer release()
+
+	waitDone(heldCtx, t)
+	if heldCtx.Err() == nil || errors.Is(heldCtx.Err(), context.Canceled) {
+		t.Errorf("expected the renew error, but got %v", heldCtx.Err())
+	}
+	locker.lock.Lock()
+	defer locker.lock.Unlock()
+	if renewals := locker.renewals; renewals < 2 {
+		t.Errorf("expected the failed renewal to be retried until the lease expires, but it was tried %d times", renewals)
+	}
+}
+
+func TestHoldRelease(t *testing.T) {
+	backend := newMemoryBackend()
+
+	heldCtx, release, err := hold(context.Background(), NewBackendLocker(backend, "ci/1"), discardf, 10*time.Millisecond, time.Hour)
+	if err != nil {
+		t.Fatalf("hold() error = %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if err = heldCtx.Err(); err != nil {
+		t.Fatalf("expected the renewed lock to be held, but got %v", err)
+	}
+
+	release()
+	if _, err = backend.Get(context.Background(), LockKey); err == nil {
+		t.Errorf("expected the released lock to be removed")
+	}
+}
@@ -45,7 +45,7 @@ func Get(s *state.State, node kubeoneapi.HostConfig) (*Report, error) {
 		}, err
 	}
 
-	health, err := apiserverHealth(roundTripper, node.PrivateAddress)
+	health, err := apiserverHealth(roundTripper, node.AdvertiseAddress())
 	if err != nil {
 		return &Report{
 			Health: false,
@@ -0,0 +1,255 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certstatus reports the expiration of the certificates managed by
+// kubeadm on the control plane nodes.
+package certstatus
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/tabwriter"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats of the certificates report
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+// DefaultWarnWithin is how long before the expiration the certificates are
+// reported as expiring soon. It matches the period in which apply warns
+// about the expiring certificates.
+const DefaultWarnWithin = 90 * 24 * time.Hour
+
+// Certificate is a certificate managed by kubeadm
+type Certificate struct {
+	// Name is the name kubeadm knows the certificate by, as used by
+	// 'kubeadm certs renew'
+	Name string
+	// Path is the path of the certificate, or of the kubeconfig file the
+	// client certificate is embedded in, on the control plane node
+	Path string
+	// Kubeconfig is true if the certificate is embedded in a kubeconfig file
+	Kubeconfig bool
+	// CA is true for the certificate authorities. kubeadm doesn't renew them.
+	CA bool
+	// Optional is true for the files that don't exist on all Kubernetes
+	// versions
+	Optional bool
+}
+
+// Status is the expiration status of a certificate on a control plane node
+type Status struct {
+	Node     string    `json:"node"`
+	Name     string    `json:"name"`
+	Path     string    `json:"path"`
+	CA       bool      `json:"ca,omitempty"`
+	NotAfter time.Time `json:"notAfter,omitempty"`
+	// ExpiresInDays is the number of whole days left until the expiration.
+	// It's negative for the expired certificates.
+	ExpiresInDays int  `json:"expiresInDays"`
+	Expired       bool `json:"expired"`
+	ExpiresSoon   bool `json:"expiresSoon"`
+	// Error is set if the certificate couldn't be read
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the expiration status of all certificates managed by kubeadm
+type Report struct {
+	Certificates []Status `json:"certificates"`
+}
+
+// KubeadmCertificates returns the certificates managed by kubeadm on the
+// control plane nodes. The etcd certificates are generated only for the
+// etcd stacked on the control plane nodes.
+func KubeadmCertificates(localEtcd bool) []Certificate {
+	certs := []Certificate{
+		{Name: "admin.conf", Path: "/etc/kubernetes/admin.conf", Kubeconfig: true},
+		{Name: "super-admin.conf", Path: "/etc/kubernetes/super-admin.conf", Kubeconfig: true, Optional: true},
+		{Name: "controller-manager.conf", Path: "/etc/kubernetes/controller-manager.conf", Kubeconfig: true},
+		{Name: "scheduler.conf", Path: "/etc/kubernetes/scheduler.conf", Kubeconfig: true},
+		{Name: "apiserver", Path: "/etc/kubernetes/pki/apiserver.crt"},
+		{Name: "apiserver-kubelet-client", Path: "/etc/kubernetes/pki/apiserver-kubelet-client.crt"},
+		{Name: "front-proxy-client", Path: "/etc/kubernetes/pki/front-proxy-client.crt"},
+		{Name: "ca", Path: "/etc/kubernetes/pki/ca.crt", CA: true},
+		{Name: "front-proxy-ca", Path: "/etc/kubernetes/pki/front-proxy-ca.crt", CA: true},
+	}
+
+	if localEtcd {
+		certs = append(certs,
+			Certificate{Name: "apiserver-etcd-client", Path: "/etc/kubernetes/pki/apiserver-etcd-client.crt"},
+			Certificate{Name: "etcd-server", Path: "/etc/kubernetes/pki/etcd/server.crt"},
+			Certificate{Name: "etcd-peer", Path: "/etc/kubernetes/pki/etcd/peer.crt"},
+			Certificate{Name: "etcd-healthcheck-client", Path: "/etc/kubernetes/pki/etcd/healthcheck-client.crt"},
+			Certificate{Name: "etcd-ca", Path: "/etc/kubernetes/pki/etcd/ca.crt", CA: true},
+		)
+	}
+
+	return certs
+}
+
+// Parse parses the certificate from the content of the file it's stored in
+func (c Certificate) Parse(buf []byte) (*x509.Certificate, error) {
+	if !c.Kubeconfig {
+		return parsePEM(buf)
+	}
+
+	kubeconfig := struct {
+		Users []struct {
+			User struct {
+				ClientCertificateData string `json:"client-certificate-data"`
+			} `json:"user"`
+		} `json:"users"`
+	}{}
+	if err := yaml.Unmarshal(buf, &kubeconfig); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal kubeconfig")
+	}
+
+	if len(kubeconfig.Users) == 0 || kubeconfig.Users[0].User.ClientCertificateData == "" {
+		return nil, errors.New("kubeconfig has no embedded client certificate")
+	}
+
+	pemBytes, err := base64.StdEncoding.DecodeString(kubeconfig.Users[0].User.ClientCertificateData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode client certificate")
+	}
+
+	return parsePEM(pemBytes)
+}
+
+func parsePEM(buf []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(buf)
+	if block == nil {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse certificate")
+	}
+
+	return cert, nil
+}
+
+// NewStatus returns the status of the certificate found on the node. The
+// certificate is expiring soon if it expires within the warnWithin period.
+func NewStatus(node string, cert Certificate, x509Cert *x509.Certificate, now time.Time, warnWithin time.Duration) Status {
+	left := x509Cert.NotAfter.Sub(now)
+
+	return Status{
+		Node:          node,
+		Name:          cert.Name,
+		Path:          cert.Path,
+		CA:            cert.CA,
+		NotAfter:      x509Cert.NotAfter.UTC(),
+		ExpiresInDays: int(left.Hours() / 24),
+		Expired:       left <= 0,
+		ExpiresSoon:   left < warnWithin,
+	}
+}
+
+// ErrorStatus returns the status of the certificate that couldn't be read
+func ErrorStatus(node string, cert Certificate, err error) Status {
+	return Status{
+		Node:  node,
+		Name:  cert.Name,
+		Path:  cert.Path,
+		CA:    cert.CA,
+		Error: err.Error(),
+	}
+}
+
+// ExpiringSoon returns the certificates that are expired or expire soon
+func (r Report) ExpiringSoon() []Status {
+	statuses := []Status{}
+	for _, status := range r.Certificates {
+		if status.ExpiresSoon {
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses
+}
+
+// Print prints the report in the given output format
+func Print(w io.Writer, report Report, output string) error {
+	if report.Certificates == nil {
+		report.Certificates = []Status{}
+	}
+
+	switch output {
+	case OutputJSON:
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal certificates report")
+		}
+		_, err = fmt.Fprintln(w, string(b))
+
+		return errors.WithStack(err)
+	case OutputYAML:
+		b, err := yaml.Marshal(report)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal certificates report")
+		}
+		_, err = fmt.Fprint(w, string(b))
+
+		return errors.WithStack(err)
+	}
+
+	printer := tabwriter.GetNewTabWriter(w)
+	fmt.Fprintln(printer, "NODE\tCERTIFICATE\tEXPIRES\tRESIDUAL TIME\tCA\tSTATUS\t")
+	for _, status := range report.Certificates {
+		if status.Error != "" {
+			fmt.Fprintf(printer, "%s\t%s\t\t\t%t\terror: %s\t\n", status.Node, status.Name, status.CA, status.Error)
+			continue
+		}
+
+		fmt.Fprintf(printer, "%s\t%s\t%s\t%dd\t%t\t%s\t\n",
+			status.Node,
+			status.Name,
+			status.NotAfter.Format(time.RFC3339),
+			status.ExpiresInDays,
+			status.CA,
+			statusText(status))
+	}
+
+	return errors.WithStack(printer.Flush())
+}
+
+func statusText(status Status) string {
+	switch {
+	case status.Expired:
+		return "expired"
+	case status.ExpiresSoon && status.CA:
+		return "expires soon, not renewable"
+	case status.ExpiresSoon:
+		return "expires soon"
+	}
+
+	return "ok"
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certstatus
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var now = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func testCertificatePEM(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kube-apiserver"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCertificateParse(t *testing.T) {
+	notAfter := now.Add(30 * 24 * time.Hour)
+	certPEM := testCertificatePEM(t, notAfter)
+	kubeconfig := "apiVersion: v1\nkind: Config\nusers:\n- name: kubernetes-admin\n  user:\n    client-certificate-data: " +
+		base64.StdEncoding.EncodeToString(certPEM) + "\n    client-key-data: a2V5\n"
+
+	tests := []struct {
+		name    string
+		cert    Certificate
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "PEM certificate",
+			cert:    Certificate{Name: "apiserver"},
+			content: string(certPEM),
+		},
+		{
+			name:    "kubeconfig",
+			cert:    Certificate{Name: "admin.conf", Kubeconfig: true},
+			content: kubeconfig,
+		},
+		{
+			name:    "kubeconfig without client certificate",
+			cert:    Certificate{Name: "admin.conf", Kubeconfig: true},
+			content: "apiVersion: v1\nkind: Config\nusers: []\n",
+			wantErr: true,
+		},
+		{
+			name:    "not a certificate",
+			cert:    Certificate{Name: "apiserver"},
+			content: "garbage",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cert.Parse([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !got.NotAfter.Equal(notAfter) {
+				t.Errorf("Parse() NotAfter = %v, want %v", got.NotAfter, notAfter)
+			}
+		})
+	}
+}
+
+func TestNewStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		notAfter time.Time
+		want     Status
+	}{
+		{
+			name:     "valid",
+			notAfter: now.Add(200 * 24 * time.Hour),
+			want:     Status{ExpiresInDays: 200},
+		},
+		{
+			name:     "expires soon",
+			notAfter: now.Add(30*24*time.Hour + time.Hour),
+			want:     Status{ExpiresInDays: 30, ExpiresSoon: true},
+		},
+		{
+			name:     "expired",
+			notAfter: now.Add(-48 * time.Hour),
+			want:     Status{ExpiresInDays: -2, Expired: true, ExpiresSoon: true},
+		},
+	}
+
+	cert := Certificate{Name: "apiserver", Path: "/etc/kubernetes/pki/apiserver.crt"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.Node = "cp-0"
+			tt.want.Name = cert.Name
+			tt.want.Path = cert.Path
+			tt.want.NotAfter = tt.notAfter
+
+			got := NewStatus("cp-0", cert, &x509.Certificate{NotAfter: tt.notAfter}, now, DefaultWarnWithin)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewStatus() differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKubeadmCertificates(t *testing.T) {
+	if got, want := len(KubeadmCertificates(true))-len(KubeadmCertificates(false)), 5; got != want {
+		t.Errorf("local etcd adds %d certificates, want %d", got, want)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	report := Report{
+		Certificates: []Status{
+			NewStatus("cp-0", Certificate{Name: "apiserver"}, &x509.Certificate{NotAfter: now.Add(200 * 24 * time.Hour)}, now, DefaultWarnWithin),
+			NewStatus("cp-0", Certificate{Name: "ca", CA: true}, &x509.Certificate{NotAfter: now.Add(10 * 24 * time.Hour)}, now, DefaultWarnWithin),
+			ErrorStatus("cp-1", Certificate{Name: "scheduler.conf"}, errFake("connection refused")),
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := Print(buf, report, OutputText); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+
+	want := "NODE   CERTIFICATE      EXPIRES                RESIDUAL TIME   CA      STATUS                        \n" +
+		"cp-0   apiserver        2021-12-18T00:00:00Z   200d            false   ok                            \n" +
+		"cp-0   ca               2021-06-11T00:00:00Z   10d             true    expires soon, not renewable   \n" +
+		"cp-1   scheduler.conf                                          false   error: connection refused     \n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Print() differs (-want +got):\n%s", diff)
+	}
+
+	if got := report.ExpiringSoon(); len(got) != 1 || got[0].Name != "ca" {
+		t.Errorf("ExpiringSoon() = %v, want the ca certificate", got)
+	}
+}
+
+type errFake string
+
+func (e errFake) Error() string { return string(e) }
@@ -17,12 +17,16 @@ limitations under the License.
 package clusterstatus
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
 
+	"k8c.io/kubeone/pkg/clusterinfo"
 	"k8c.io/kubeone/pkg/clusterstatus/apiserverstatus"
 	"k8c.io/kubeone/pkg/clusterstatus/etcdstatus"
 	"k8c.io/kubeone/pkg/clusterstatus/preflightstatus"
@@ -30,25 +34,90 @@ import (
 	"k8c.io/kubeone/pkg/tabwriter"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
-type nodeStatus struct {
-	NodeName  string `json:"nodeName,omitempty"`
-	Version   string `json:"version,omitempty"`
-	APIServer bool   `json:"apiServer,omitempty"`
-	Etcd      bool   `json:"etcd,omitempty"`
+// Output formats of the cluster status
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+// ClusterStatus is the status of the cluster, as reported by the status
+// command
+type ClusterStatus struct {
+	Nodes        []NodeStatus        `json:"nodes"`
+	CCMMigration *CCMMigrationStatus `json:"ccmMigration,omitempty"`
+	// Info is the information about the last apply, recorded in the
+	// kubeone-info ConfigMap. It's nil if the cluster was last applied by
+	// an older KubeOne release.
+	Info *clusterinfo.Info `json:"info,omitempty"`
+	// ManifestChanged is true if the manifest changed since the last apply
+	ManifestChanged bool `json:"manifestChanged"`
 }
 
-func Print(s *state.State) error {
-	status, err := getClusterStatus(s)
+// NodeStatus is the status of a control plane node
+type NodeStatus struct {
+	Name             string           `json:"name"`
+	KubeletVersion   string           `json:"kubeletVersion,omitempty"`
+	APIServerVersion string           `json:"apiServerVersion,omitempty"`
+	APIServerHealthy bool             `json:"apiServerHealthy"`
+	Etcd             EtcdMemberStatus `json:"etcd"`
+}
+
+// EtcdMemberStatus is the status of the etcd member running on the node.
+// The health of the external etcd is not checked, as its members don't run
+// on the control plane nodes.
+type EtcdMemberStatus struct {
+	External bool `json:"external,omitempty"`
+	Member   bool `json:"member"`
+	Healthy  bool `json:"healthy"`
+}
+
+// CCMMigrationStatus is the status of the migration from the in-tree cloud
+// provider to the external CCM/CSI
+type CCMMigrationStatus struct {
+	InTreeCloudProviderEnabled      bool `json:"inTreeCloudProviderEnabled"`
+	InTreeCloudProviderUnregistered bool `json:"inTreeCloudProviderUnregistered"`
+	ExternalCCMDeployed             bool `json:"externalCCMDeployed"`
+	CSIMigrationEnabled             bool `json:"csiMigrationEnabled"`
+}
+
+// Print prints the cluster status in the given output format
+func Print(s *state.State, output string) error {
+	status, err := Get(s)
 	if err != nil {
 		return errors.Wrap(err, "unable to get cluster status")
 	}
 
+	switch output {
+	case OutputJSON:
+		b, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal cluster status")
+		}
+		fmt.Println(string(b))
+
+		return nil
+	case OutputYAML:
+		b, err := yaml.Marshal(status)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal cluster status")
+		}
+		fmt.Print(string(b))
+
+		return nil
+	}
+
+	return printText(status)
+}
+
+func printText(status *ClusterStatus) error {
 	printer := tabwriter.GetNewTabWriter(os.Stdout)
-	defer printer.Flush()
 
 	headers := clusterStatusHeader()
 	for _, h := range headers {
@@ -56,26 +125,64 @@ func Print(s *state.State) error {
 	}
 
 	fmt.Fprintln(printer, "")
-	for _, s := range status {
-		fmt.Fprintf(printer, "%s\t", s.NodeName)
-		fmt.Fprintf(printer, "%s\t", s.Version)
+	for _, node := range status.Nodes {
+		fmt.Fprintf(printer, "%s\t", node.Name)
+		fmt.Fprintf(printer, "%s\t", node.KubeletVersion)
 
-		if s.APIServer {
+		if node.APIServerHealthy {
 			fmt.Fprintf(printer, "healthy\t")
 		} else {
 			fmt.Fprintf(printer, "unhealthy\t")
 		}
 
-		if s.Etcd {
+		switch {
+		case node.Etcd.External:
+			fmt.Fprintf(printer, "external\t")
+		case node.Etcd.Member && node.Etcd.Healthy:
 			fmt.Fprintf(printer, "healthy\t")
-		} else {
+		default:
 			fmt.Fprintf(printer, "unhealthy\t")
 		}
 
 		fmt.Fprintln(printer, "")
 	}
 
-	return nil
+	if err := printer.Flush(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return printClusterInfo(status)
+}
+
+// printClusterInfo prints which KubeOne release and manifest last applied
+// the cluster, as recorded in the kubeone-info ConfigMap
+func printClusterInfo(status *ClusterStatus) error {
+	info := status.Info
+
+	fmt.Println()
+	if info == nil {
+		fmt.Printf("The %s ConfigMap is not found, the cluster was last applied by an older KubeOne release.\n", clusterinfo.ConfigMapName)
+		return nil
+	}
+
+	manifest := "unchanged since the last apply"
+	if status.ManifestChanged {
+		manifest = "changed since the last apply"
+	}
+
+	features := strings.Join(info.Features, ", ")
+	if features == "" {
+		features = "none"
+	}
+
+	printer := tabwriter.GetNewTabWriter(os.Stdout)
+	fmt.Fprintf(printer, "Applied by KubeOne:\t%s\n", info.KubeOneVersion)
+	fmt.Fprintf(printer, "Applied at:\t%s\n", info.AppliedAt.Format(time.RFC3339))
+	fmt.Fprintf(printer, "Kubernetes version:\t%s\n", info.KubernetesVersion)
+	fmt.Fprintf(printer, "Features:\t%s\n", features)
+	fmt.Fprintf(printer, "Manifest:\t%s\n", manifest)
+
+	return errors.WithStack(printer.Flush())
 }
 
 func clusterStatusHeader() []string {
@@ -87,7 +194,9 @@ func clusterStatusHeader() []string {
 	}
 }
 
-func getClusterStatus(s *state.State) ([]nodeStatus, error) {
+// Get gets the status of the control plane nodes, the CCM migration status
+// and the information about the last apply
+func Get(s *state.State) (*ClusterStatus, error) {
 	if s.DynamicClient == nil {
 		return nil, errors.New("kubernetes client not initialized")
 	}
@@ -107,18 +216,31 @@ func getClusterStatus(s *state.State) ([]nodeStatus, error) {
 		return nil, err
 	}
 
-	status := []nodeStatus{}
+	apiserverVersions, err := apiServerVersions(s)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ClusterStatus{}
 	errs := []error{}
 
-	etcdRing, err := etcdstatus.MemberList(s)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get etcd ring")
+	// The health of the external etcd is not checked, as its members don't
+	// run on the control plane nodes
+	var etcdRing *clientv3.MemberListResponse
+	if s.Cluster.LocalEtcd() {
+		etcdRing, err = etcdstatus.MemberList(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get etcd ring")
+		}
 	}
 
 	for _, host := range s.Cluster.ControlPlane.Hosts {
-		etcdStatus, err := etcdstatus.Get(s, host, etcdRing)
-		if err != nil {
-			errs = append(errs, err)
+		var etcdStatus *etcdstatus.Report
+		if etcdRing != nil {
+			etcdStatus, err = etcdstatus.Get(s, host, etcdRing)
+			if err != nil {
+				errs = append(errs, err)
+			}
 		}
 
 		apiserverStatus, err := apiserverstatus.Get(s, host)
@@ -133,21 +255,18 @@ func getClusterStatus(s *state.State) ([]nodeStatus, error) {
 			}
 		}
 
-		eStatus := false
-		if etcdStatus != nil && etcdStatus.Health && etcdStatus.Member {
-			eStatus = true
+		etcdMember := EtcdMemberStatus{External: !s.Cluster.LocalEtcd()}
+		if etcdStatus != nil {
+			etcdMember.Member = etcdStatus.Member
+			etcdMember.Healthy = etcdStatus.Health
 		}
 
-		aStatus := false
-		if apiserverStatus != nil && apiserverStatus.Health {
-			aStatus = true
-		}
-
-		status = append(status, nodeStatus{
-			NodeName:  host.Hostname,
-			Version:   kubeletVersion,
-			Etcd:      eStatus,
-			APIServer: aStatus,
+		status.Nodes = append(status.Nodes, NodeStatus{
+			Name:             host.Hostname,
+			KubeletVersion:   kubeletVersion,
+			APIServerVersion: apiserverVersions[host.Hostname],
+			APIServerHealthy: apiserverStatus != nil && apiserverStatus.Health,
+			Etcd:             etcdMember,
 		})
 	}
 
@@ -157,5 +276,64 @@ func getClusterStatus(s *state.State) ([]nodeStatus, error) {
 		}
 	}
 
+	if s.LiveCluster != nil && s.LiveCluster.CCMStatus != nil {
+		ccmStatus := s.LiveCluster.CCMStatus
+		status.CCMMigration = &CCMMigrationStatus{
+			InTreeCloudProviderEnabled:      ccmStatus.InTreeCloudProviderEnabled,
+			InTreeCloudProviderUnregistered: ccmStatus.InTreeCloudProviderUnregistered,
+			ExternalCCMDeployed:             ccmStatus.ExternalCCMDeployed,
+			CSIMigrationEnabled:             ccmStatus.CSIMigrationEnabled,
+		}
+	}
+
+	info, err := clusterinfo.Load(s.Context, s.DynamicClient)
+	if err != nil {
+		return nil, err
+	}
+	if info != nil {
+		status.Info = info
+		status.ManifestChanged = info.ManifestHash != s.ManifestHash
+	}
+
 	return status, nil
 }
+
+// apiServerVersions returns the versions of the kube-apiserver static pods,
+// based on their image tags, keyed by the node name
+func apiServerVersions(s *state.State) (map[string]string, error) {
+	pods := corev1.PodList{}
+	err := s.DynamicClient.List(s.Context, &pods, &dynclient.ListOptions{
+		Namespace: metav1.NamespaceSystem,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			"component": "kube-apiserver",
+		}),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list kube-apiserver pods")
+	}
+
+	versions := map[string]string{}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if container.Name == "kube-apiserver" {
+				versions[pod.Spec.NodeName] = imageTag(container.Image)
+			}
+		}
+	}
+
+	return versions, nil
+}
+
+// imageTag returns the tag of the image, or an empty string if the image is
+// not tagged
+func imageTag(image string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+
+	return ""
+}
@@ -48,7 +48,7 @@ func MemberList(s *state.State) (*clientv3.MemberListResponse, error) {
 	if err != nil {
 		return nil, err
 	}
-	etcdEndpoints := []string{fmt.Sprintf(clientEndpointFmt, leader.PrivateAddress)}
+	etcdEndpoints := []string{fmt.Sprintf(clientEndpointFmt, leader.AdvertiseAddress())}
 
 	etcdcfg, err := etcdutil.NewClientConfig(s, leader)
 	if err != nil {
@@ -84,7 +84,7 @@ func Get(s *state.State, node kubeoneapi.HostConfig, etcdRing *clientv3.MemberLi
 	}
 
 	// Check etcd member health
-	health, err := memberHealth(roundTripper, node.PrivateAddress)
+	health, err := memberHealth(roundTripper, node.AdvertiseAddress())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/credentials"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type adoptOpts struct {
+	globalOptions
+	AutoApprove bool `longflag:"auto-approve" shortflag:"y"`
+}
+
+func adoptCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &adoptOpts{}
+	cmd := &cobra.Command{
+		Use:   "adopt",
+		Short: "Take over the management of the cluster built by kubeadm",
+		Long: heredoc.Doc(`
+			Take over the management of the existing cluster built with plain kubeadm.
+
+			The cluster is verified to match the manifest (Kubernetes version, API endpoint, cluster networking, CNI
+			plugin and control plane hosts). Then the KubeOne configuration files are uploaded to the hosts, the addons
+			and other resources are reconciled, and the cluster state is recorded. The control plane is not recreated.
+			After the adoption, the cluster is managed by running apply.
+
+			Use 'kubeone config import' to generate the manifest matching the cluster.
+		`),
+		Args:    cobra.ExactArgs(0),
+		Example: `kubeone adopt -m mycluster.yaml -t terraformoutput.json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runAdopt(opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&opts.AutoApprove,
+		longFlagName(opts, "AutoApprove"),
+		shortFlagName(opts, "AutoApprove"),
+		false,
+		"auto approve the adoption")
+
+	return cmd
+}
+
+// runAdopt takes over the management of the cluster
+func runAdopt(opts *adoptOpts) error {
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	// Validate credentials
+	_, err = credentials.ProviderCredentials(s.Cluster.CloudProvider, opts.CredentialsFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to validate credentials")
+	}
+
+	s.Logger.Warnln("This command will configure the cluster to be managed by KubeOne, deploying the addons and the resources from the manifest.")
+
+	confirm, err := confirmCommand(opts.AutoApprove)
+	if err != nil {
+		return err
+	}
+
+	if !confirm {
+		s.Logger.Println("Operation canceled.")
+		return nil
+	}
+
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
+	return errors.Wrap(tasks.WithAdopt(nil).Run(s), "failed to adopt the cluster")
+}
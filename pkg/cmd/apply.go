@@ -21,14 +21,25 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/apis/kubeone/config"
 	"k8c.io/kubeone/pkg/credentials"
+	"k8c.io/kubeone/pkg/features"
+	"k8c.io/kubeone/pkg/hooks"
+	"k8c.io/kubeone/pkg/metrics"
+	"k8c.io/kubeone/pkg/notifications"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/tasks"
 
@@ -43,10 +54,29 @@ type applyOpts struct {
 	BackupFile   string `longflag:"backup" shortflag:"b"`
 	NoInit       bool   `longflag:"no-init"`
 	ForceInstall bool   `longflag:"force-install"`
+	NoStepCache  bool   `longflag:"no-step-cache"`
+	Resume       bool   `longflag:"resume"`
+	// Preflight flags
+	SkipPreflightChecks bool `longflag:"skip-preflight-checks"`
 	// Upgrade flags
 	ForceUpgrade              bool `longflag:"force-upgrade"`
 	UpgradeMachineDeployments bool `longflag:"upgrade-machine-deployments"`
 	RotateEncryptionKey       bool `longflag:"rotate-encryption-key"`
+	// Workspace flags
+	All                   bool `longflag:"all"`
+	MaxConcurrentClusters int  `longflag:"max-concurrent-clusters"`
+	// Graph flags
+	Graph string `longflag:"graph"`
+	// Plan flags
+	Plan bool `longflag:"plan"`
+	// Interactive flags
+	Interactive bool `longflag:"interactive"`
+	// Dry-run flags
+	DryRun    bool   `longflag:"dry-run"`
+	DryRunDir string `longflag:"dry-run-dir"`
+	DryRunOS  string `longflag:"dry-run-os"`
+	// Metrics flags
+	MetricsAddress string `longflag:"metrics-address"`
 }
 
 func (opts *applyOpts) BuildState() (*state.State, error) {
@@ -55,13 +85,37 @@ func (opts *applyOpts) BuildState() (*state.State, error) {
 		return nil, errors.Wrap(err, "failed to build state")
 	}
 
+	return opts.initState(s)
+}
+
+func (opts *applyOpts) buildClusterState(wc *config.WorkspaceCluster, logger logrus.FieldLogger) (*state.State, error) {
+	s, err := opts.globalOptions.buildClusterState(wc, logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build state")
+	}
+
+	return opts.initState(s)
+}
+
+func (opts *applyOpts) initState(s *state.State) (*state.State, error) {
 	s.BackupFile = opts.BackupFile
 	s.ForceInstall = opts.ForceInstall
+	s.NoStepCache = opts.NoStepCache
+	s.SkipPreflightChecks = opts.SkipPreflightChecks
+	if opts.Interactive {
+		s.Confirm = confirmStep
+	}
 	s.ForceUpgrade = opts.ForceUpgrade
 	s.UpgradeMachineDeployments = opts.UpgradeMachineDeployments
 
+	// nothing is backed up in the dry-run mode
+	if opts.DryRun {
+		s.DryRunOutput = state.NewDryRunOutput()
+		return s, nil
+	}
+
 	if s.BackupFile == "" {
-		fullPath, _ := filepath.Abs(opts.ManifestFile)
+		fullPath, _ := filepath.Abs(s.ManifestFilePath)
 		clusterName := s.Cluster.Name
 		s.BackupFile = filepath.Join(filepath.Dir(fullPath), fmt.Sprintf("%s.tar.gz", clusterName))
 	}
@@ -97,8 +151,52 @@ func applyCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 
 			This command takes KubeOne manifest which contains information about hosts and how the cluster should be provisioned.
 			It's possible to source information about hosts from Terraform output, using the '--tfjson' flag.
+
+			When the manifest contains multiple clusters, the cluster to reconcile is selected using the '--cluster' flag.
+			Alternatively, all clusters can be reconciled at once using the '--all' flag.
+
+			The '--graph' flag prints the graph of tasks that would be run, based on the manifest and the state detected
+			by probing the cluster, instead of reconciling the cluster. Tasks executed on multiple hosts fan out to a node
+			per host.
+
+			The '--plan' flag probes the cluster and prints the plan instead of reconciling the cluster: whether the cluster
+			would be installed, repaired or upgraded, the tasks that would be run along with the hosts they would be run on,
+			and the changes of the addons since the last apply.
+
+			Host preparation steps, such as installing the prerequisites, are fingerprinted on each host and skipped
+			on the subsequent runs if the configuration affecting them didn't change. Use '--no-step-cache' to run
+			them unconditionally.
+
+			The progress of the apply is recorded in the state backend if one is configured, otherwise in the
+			'<cluster name>.progress.json' file next to the manifest. When the apply fails, for example because of
+			a transient error, it can be resumed using the '--resume' flag. Resuming the apply skips the completed
+			steps which are expensive to repeat, such as installing the prerequisites, upgrading the nodes or deploying
+			the addons. The progress is removed once the apply succeeds.
+
+			The '--interactive' flag pauses before each destructive step, such as draining a node, upgrading a node
+			with 'kubeadm upgrade' or deleting an addon, and asks whether to proceed with the step, skip it or abort.
+
+			The '--dry-run' flag renders every shell script, configuration file, kubeadm configuration and addon manifest
+			used to provision the cluster, without connecting to any host. The rendered files are printed, or written to
+			the directory given with '--dry-run-dir'. As hosts are not probed, everything is rendered as for a new cluster,
+			hosts are assumed to run the operating system given with '--dry-run-os', and certificates in the manifests are
+			signed by a throwaway CA. The rendered files can contain credentials from the manifest and the environment.
+
+			The '--metrics-address' flag exposes Prometheus metrics, such as reconciliation, task and upgrade durations,
+			the result of the last reconciliation per cluster, task results and node counts, at the /metrics path while
+			the command runs. Use the global '--metrics-pushgateway' and '--metrics-file' flags to publish the metrics
+			when the command finishes.
+		`),
+		Example: heredoc.Doc(`
+			kubeone apply -m mycluster.yaml -t terraformoutput.json
+			kubeone apply -m base.yaml -m overlays/prod/
+			kubeone apply -m clusters/ --cluster edge-1
+			kubeone apply -m clusters/ --all --max-concurrent-clusters 3 --auto-approve
+			kubeone apply -m mycluster.yaml -t terraformoutput.json --graph dot | dot -Tsvg > tasks.svg
+			kubeone apply -m mycluster.yaml -t terraformoutput.json --plan
+			kubeone apply -m mycluster.yaml -t terraformoutput.json --interactive
+			kubeone apply -m mycluster.yaml -t terraformoutput.json --dry-run --dry-run-dir rendered/
 		`),
-		Example: `kubeone apply -m mycluster.yaml -t terraformoutput.json`,
 		RunE: func(_ *cobra.Command, args []string) error {
 			gopts, err := persistentGlobalOptions(rootFlags)
 			if err != nil {
@@ -137,6 +235,24 @@ func applyCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 		false,
 		"use force to install new binary versions (!dangerous!)")
 
+	cmd.Flags().BoolVar(
+		&opts.NoStepCache,
+		longFlagName(opts, "NoStepCache"),
+		false,
+		"don't skip host preparation steps already completed with the same configuration")
+
+	cmd.Flags().BoolVar(
+		&opts.Resume,
+		longFlagName(opts, "Resume"),
+		false,
+		"resume the failed apply, skipping the steps it completed")
+
+	cmd.Flags().BoolVar(
+		&opts.SkipPreflightChecks,
+		longFlagName(opts, "SkipPreflightChecks"),
+		false,
+		"don't check the hosts meet the requirements before provisioning or upgrading them")
+
 	cmd.Flags().BoolVar(
 		&opts.ForceUpgrade,
 		longFlagName(opts, "ForceUpgrade"),
@@ -155,26 +271,257 @@ func applyCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 		false,
 		"rotate Encryption Provider encryption key")
 
+	cmd.Flags().BoolVar(
+		&opts.All,
+		longFlagName(opts, "All"),
+		false,
+		"reconcile all clusters found in the manifest (requires --auto-approve)")
+
+	cmd.Flags().IntVar(
+		&opts.MaxConcurrentClusters,
+		longFlagName(opts, "MaxConcurrentClusters"),
+		2,
+		"maximum number of clusters reconciled at the same time when using --all")
+
+	cmd.Flags().StringVar(
+		&opts.Graph,
+		longFlagName(opts, "Graph"),
+		"",
+		fmt.Sprintf("print the graph of tasks that would be run instead of running them. Possible values: %q, %q", tasks.GraphFormatDot, tasks.GraphFormatMermaid))
+
+	cmd.Flags().BoolVar(
+		&opts.Plan,
+		longFlagName(opts, "Plan"),
+		false,
+		"print the tasks that would be run and the addons changes instead of reconciling the cluster")
+
+	cmd.Flags().BoolVar(
+		&opts.Interactive,
+		longFlagName(opts, "Interactive"),
+		false,
+		"ask for confirmation before each destructive step, such as draining a node, upgrading a node or deleting an addon")
+
+	cmd.Flags().StringVar(
+		&opts.MetricsAddress,
+		longFlagName(opts, "MetricsAddress"),
+		"",
+		"address to expose Prometheus metrics on while reconciling, e.g. :9090 (default: disabled)")
+
+	cmd.Flags().BoolVar(
+		&opts.DryRun,
+		longFlagName(opts, "DryRun"),
+		false,
+		"render scripts, configuration files and addons manifests without connecting to any host")
+
+	cmd.Flags().StringVar(
+		&opts.DryRunDir,
+		longFlagName(opts, "DryRunDir"),
+		"",
+		"directory to write the files rendered with --dry-run to, instead of printing them")
+
+	cmd.Flags().StringVar(
+		&opts.DryRunOS,
+		longFlagName(opts, "DryRunOS"),
+		string(kubeoneapi.OperatingSystemNameUbuntu),
+		"operating system assumed for hosts when rendering with --dry-run")
+
 	return cmd
 }
 
 func runApply(opts *applyOpts) error {
+	switch opts.Graph {
+	case "", tasks.GraphFormatDot, tasks.GraphFormatMermaid:
+	default:
+		return errors.Errorf("unknown --graph format %q, supported formats are %q and %q", opts.Graph, tasks.GraphFormatDot, tasks.GraphFormatMermaid)
+	}
+
+	if opts.DryRunDir != "" && !opts.DryRun {
+		return errors.New("--dry-run-dir requires the --dry-run flag")
+	}
+
+	if opts.Plan {
+		switch {
+		case opts.All:
+			return errors.New("--plan can't be used with --all, select a single cluster with --cluster")
+		case opts.Graph != "":
+			return errors.New("--plan and --graph flags are mutually exclusive")
+		case opts.Interactive:
+			return errors.New("--plan and --interactive flags are mutually exclusive")
+		case opts.DryRun:
+			return errors.New("--plan and --dry-run flags are mutually exclusive")
+		}
+	}
+
+	if opts.DryRun {
+		switch {
+		case opts.All:
+			return errors.New("--dry-run can't be used with --all, select a single cluster with --cluster")
+		case opts.Graph != "":
+			return errors.New("--dry-run and --graph flags are mutually exclusive")
+		case opts.Interactive:
+			return errors.New("--dry-run and --interactive flags are mutually exclusive")
+		case opts.Resume:
+			return errors.New("--dry-run and --resume flags are mutually exclusive")
+		}
+	}
+
+	if opts.Interactive && opts.Graph == "" {
+		if err := ensureTerminal(); err != nil {
+			return errors.Wrap(err, "--interactive requires a terminal")
+		}
+	}
+
+	if opts.MetricsAddress != "" {
+		stop, err := serveMetrics(opts.MetricsAddress, opts.newLogger())
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+
+	if opts.All {
+		return runApplyAll(opts)
+	}
+
 	s, err := opts.BuildState()
 	if err != nil {
 		return errors.Wrap(err, "failed to initialize State")
 	}
 
+	return observeApplyCluster(s, opts)
+}
+
+// runApplyAll reconciles all clusters from the workspace, running at most
+// MaxConcurrentClusters reconciliations at the same time
+func runApplyAll(opts *applyOpts) error {
+	switch {
+	case opts.Cluster != "":
+		return errors.New("--all and --cluster flags are mutually exclusive")
+	case !opts.AutoApprove:
+		return errors.New("--all requires the --auto-approve flag")
+	case opts.BackupFile != "":
+		return errors.New("--backup can't be used with --all, backups are placed next to the cluster manifests")
+	case opts.TerraformState != "":
+		return errors.New("--tfjson can't be used with --all, terraform output can't be shared between clusters")
+	case opts.MaxConcurrentClusters < 1:
+		return errors.New("--max-concurrent-clusters must be at least 1")
+	case opts.Graph != "":
+		return errors.New("--graph can't be used with --all, select a single cluster with --cluster")
+	case opts.Interactive:
+		return errors.New("--interactive can't be used with --all, select a single cluster with --cluster")
+	}
+
+	ws, err := opts.loadWorkspace()
+	if err != nil {
+		return errors.Wrap(err, "failed to load workspace")
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []string
+		sem    = make(chan struct{}, opts.MaxConcurrentClusters)
+	)
+
+	for i := range ws.Clusters {
+		wc := &ws.Clusters[i]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			logger := opts.newLogger().WithField("cluster", wc.Name)
+			logger.Infoln("Reconciling cluster...")
+
+			s, err := opts.buildClusterState(wc, logger)
+			if err == nil {
+				err = observeApplyCluster(s, opts)
+			}
+
+			if err != nil {
+				logger.Errorf("Failed to reconcile the cluster: %v", err)
+
+				mu.Lock()
+				failed = append(failed, wc.Name)
+				mu.Unlock()
+
+				return
+			}
+
+			logger.Infoln("Cluster reconciled")
+		}()
+	}
+
+	wg.Wait()
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return errors.Errorf("failed to reconcile clusters: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// observeApplyCluster reconciles the cluster, recording the duration and
+// the result of the reconciliation in the metrics
+func observeApplyCluster(s *state.State, opts *applyOpts) error {
+	if opts.DryRun || opts.Graph != "" || opts.Plan {
+		return runApplyCluster(s, opts)
+	}
+
+	observeNodes(s)
+
+	start := time.Now()
+	err := runApplyCluster(s, opts)
+	metrics.ObserveReconcile(s.Cluster.Name, time.Since(start), err)
+
+	return err
+}
+
+func runApplyCluster(s *state.State, opts *applyOpts) error {
+	if opts.DryRun {
+		return runApplyDryRun(s, opts)
+	}
+
 	// Validate credentials
-	_, err = credentials.ProviderCredentials(s.Cluster.CloudProvider, opts.CredentialsFile)
+	_, err := credentials.ProviderCredentials(s.Cluster.CloudProvider, opts.CredentialsFile)
 	if err != nil {
 		return errors.Wrap(err, "failed to validate credentials")
 	}
 
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
+	// printing the task graph or the plan doesn't change the cluster
+	if opts.Graph != "" || opts.Plan {
+		return reconcileCluster(s, opts)
+	}
+
+	if err = initProgress(s, opts.Resume); err != nil {
+		return err
+	}
+
+	return notify(s, notifications.OperationApply, func() error {
+		return tasks.WithHooks(s, hooks.PreApply, hooks.PostApply, func() error {
+			return reconcileCluster(s, opts)
+		})
+	})
+}
+
+// reconcileCluster probes the cluster and installs, repairs or upgrades it
+// as needed
+func reconcileCluster(s *state.State, opts *applyOpts) error {
 	// Probe the cluster for the actual state and the needed tasks.
 	probbing := tasks.WithHostnameOS(nil)
 	probbing = tasks.WithProbesAndSafeguard(probbing)
 
-	if err = probbing.Run(s); err != nil {
+	if err := probbing.Run(s); err != nil {
 		return err
 	}
 
@@ -262,6 +609,10 @@ func runApply(opts *applyOpts) error {
 			return errors.New("Encryption Providers support is not enabled for this cluster")
 		}
 
+		if s.Cluster.Features.EncryptionProviders != nil &&
+			s.Cluster.Features.EncryptionProviders.KMS != nil {
+			return errors.New("key rotation of KMS provider is managed by the KMS")
+		}
 		if s.Cluster.Features.EncryptionProviders != nil &&
 			s.Cluster.Features.EncryptionProviders.CustomEncryptionConfiguration != "" {
 			return errors.New("key rotation of custom providers file is not supported")
@@ -272,7 +623,37 @@ func runApply(opts *applyOpts) error {
 	return runApplyUpgradeIfNeeded(s, opts)
 }
 
-func runApplyInstall(s *state.State, opts *applyOpts) error { // Print the expected changes
+// runApplyDryRun renders the scripts, configuration files and addons
+// manifests, and prints them or writes them to the --dry-run-dir directory
+func runApplyDryRun(s *state.State, opts *applyOpts) error {
+	if err := tasks.DryRun(s, kubeoneapi.OperatingSystemName(opts.DryRunOS)); err != nil {
+		return errors.Wrap(err, "failed to render the cluster")
+	}
+
+	if opts.DryRunDir == "" {
+		return s.DryRunOutput.Print(os.Stdout)
+	}
+
+	if err := s.DryRunOutput.WriteTo(opts.DryRunDir); err != nil {
+		return errors.Wrap(err, "failed to write rendered files")
+	}
+
+	s.Logger.Infof("Rendered files written to %s", opts.DryRunDir)
+
+	return nil
+}
+
+func runApplyInstall(s *state.State, opts *applyOpts) error {
+	tasksToRun := tasks.WithFullInstall(nil)
+	if opts.NoInit {
+		tasksToRun = tasks.WithBinariesOnly(nil)
+	}
+
+	if opts.Graph != "" {
+		return printTaskGraph(s, tasksToRun, opts.Graph)
+	}
+
+	// Print the expected changes
 	fmt.Println("The following actions will be taken: ")
 	fmt.Println("Run with --verbose flag for more information.")
 
@@ -309,6 +690,15 @@ func runApplyInstall(s *state.State, opts *applyOpts) error { // Print the expec
 	}
 
 	fmt.Println()
+	if opts.Plan {
+		action := "install"
+		if s.LiveCluster.IsProvisioned() {
+			action = "repair"
+		}
+
+		return printPlan(s, action, tasksToRun)
+	}
+
 	confirm, err := confirmCommand(opts.AutoApprove)
 	if err != nil {
 		return err
@@ -320,18 +710,13 @@ func runApplyInstall(s *state.State, opts *applyOpts) error { // Print the expec
 	}
 
 	if opts.NoInit {
-		return errors.Wrap(tasks.WithBinariesOnly(nil).Run(s), "failed to install kubernetes binaries")
+		return errors.Wrap(runResumable(s, tasksToRun), "failed to install kubernetes binaries")
 	}
 
-	return errors.Wrap(tasks.WithFullInstall(nil).Run(s), "failed to install the cluster")
+	return errors.Wrap(runResumable(s, tasksToRun), "failed to install the cluster")
 }
 
 func runApplyUpgradeIfNeeded(s *state.State, opts *applyOpts) error {
-	fmt.Println("The following actions will be taken: ")
-	if !opts.Verbose {
-		fmt.Println("Run with --verbose flag for more information.")
-	}
-
 	upgradeNeeded, err := s.LiveCluster.UpgradeNeeded()
 	if err != nil {
 		s.Logger.Errorf("Upgrade not allowed: %v\n", err)
@@ -401,6 +786,52 @@ func runApplyUpgradeIfNeeded(s *state.State, opts *applyOpts) error {
 		}
 	} else {
 		tasksToRun = tasks.WithResources(nil)
+
+		if s.LiveCluster.NodePortRangeChanged(s.Cluster.ClusterNetwork.NodePortRange) {
+			operations = append(operations,
+				fmt.Sprintf("update NodePort range: %s -> %s",
+					strings.Join(s.LiveCluster.NodePortRanges, ", "),
+					s.Cluster.ClusterNetwork.NodePortRange))
+			tasksToRun = tasks.WithNodePortRange(tasksToRun)
+		}
+
+		if s.LiveCluster.ClusterCIDRChanged(s.Cluster.ClusterNetwork.PodSubnets()) {
+			operations = append(operations,
+				fmt.Sprintf("update cluster CIDR: %s -> %s",
+					strings.Join(s.LiveCluster.ClusterCIDRs, ", "),
+					s.Cluster.ClusterNetwork.PodSubnets()))
+			tasksToRun = tasks.WithClusterCIDR(tasksToRun)
+		}
+
+		// The external etcd is managed outside of KubeOne
+		if s.Cluster.LocalEtcd() {
+			if etcdSettings := kubeoneapi.FormatExtraArgs(features.EtcdExtraArgs(s.Cluster)); s.LiveCluster.EtcdSettingsChanged(etcdSettings) {
+				live := []string{}
+				for _, settings := range s.LiveCluster.EtcdSettings {
+					live = append(live, etcdSettingsString(settings))
+				}
+				operations = append(operations,
+					fmt.Sprintf("update etcd settings: %s -> %s",
+						strings.Join(live, "; "),
+						etcdSettingsString(etcdSettings)))
+				tasksToRun = tasks.WithEtcdSettings(tasksToRun)
+			}
+
+			if sans := s.Cluster.Etcd.ServerCertSANs(); s.LiveCluster.EtcdServerCertSANsMissing(sans) {
+				operations = append(operations,
+					fmt.Sprintf("regenerate etcd server certificates with SANs: %s", strings.Join(sans, ", ")))
+				tasksToRun = tasks.WithEtcdServerCertSANs(tasksToRun)
+			}
+		}
+	}
+
+	if opts.Graph != "" {
+		return printTaskGraph(s, tasksToRun, opts.Graph)
+	}
+
+	fmt.Println("The following actions will be taken: ")
+	if !opts.Verbose {
+		fmt.Println("Run with --verbose flag for more information.")
 	}
 
 	fmt.Println()
@@ -413,6 +844,15 @@ func runApplyUpgradeIfNeeded(s *state.State, opts *applyOpts) error {
 	}
 
 	fmt.Println()
+	if opts.Plan {
+		action := "reconcile"
+		if upgradeNeeded || opts.ForceUpgrade {
+			action = "upgrade"
+		}
+
+		return printPlan(s, action, tasksToRun)
+	}
+
 	confirm, err := confirmCommand(opts.AutoApprove)
 	if err != nil {
 		return err
@@ -423,7 +863,15 @@ func runApplyUpgradeIfNeeded(s *state.State, opts *applyOpts) error {
 		return nil
 	}
 
-	return errors.Wrap(tasksToRun.Run(s), "failed to reconcile the cluster")
+	if upgradeNeeded || opts.ForceUpgrade {
+		return observeUpgrade(s, func() error {
+			return tasks.WithHooks(s, hooks.PreUpgrade, hooks.PostUpgrade, func() error {
+				return errors.Wrap(runResumable(s, tasksToRun), "failed to reconcile the cluster")
+			})
+		})
+	}
+
+	return errors.Wrap(runResumable(s, tasksToRun), "failed to reconcile the cluster")
 }
 
 func runApplyRotateKey(s *state.State, opts *applyOpts) error {
@@ -436,15 +884,23 @@ func runApplyRotateKey(s *state.State, opts *applyOpts) error {
 		return errors.New("rotating encryption keys failed: Encryption Providers support is not enabled")
 	}
 
+	tasksToRun := tasks.WithRotateKey(nil, "")
+	if opts.Graph != "" {
+		return printTaskGraph(s, tasksToRun, opts.Graph)
+	}
+
 	fmt.Println("The following actions will be taken: ")
 	fmt.Println("Run with --verbose flag for more information.")
-	tasksToRun := tasks.WithRotateKey(nil)
 
 	for _, op := range tasksToRun.Descriptions(s) {
 		fmt.Printf("\t~ %s\n", op)
 	}
 
 	fmt.Println()
+	if opts.Plan {
+		return printPlan(s, "rotate the encryption key", tasksToRun)
+	}
+
 	confirm, err := confirmCommand(opts.AutoApprove)
 	if err != nil {
 		return err
@@ -454,7 +910,63 @@ func runApplyRotateKey(s *state.State, opts *applyOpts) error {
 		s.Logger.Println("Operation canceled.")
 		return nil
 	}
-	return errors.Wrap(tasksToRun.Run(s), "failed to reconcile the cluster")
+	return errors.Wrap(runResumable(s, tasksToRun), "failed to reconcile the cluster")
+}
+
+// etcdSettingsString returns the etcd settings for printing
+func etcdSettingsString(settings string) string {
+	if settings == "" {
+		return "etcd defaults"
+	}
+
+	return settings
+}
+
+// printTaskGraph prints the graph of the given tasks in the given format to
+// the standard output
+func printTaskGraph(s *state.State, tasksToRun tasks.Tasks, format string) error {
+	graph, err := tasksToRun.Graph(s, format)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(graph)
+
+	return nil
+}
+
+// printPlan prints the tasks that would be run along with the hosts they
+// would be run on, and the addons changes since the last apply
+func printPlan(s *state.State, action string, tasksToRun tasks.Tasks) error {
+	fmt.Printf("Plan: %s the cluster\n", action)
+	fmt.Println()
+
+	fmt.Println("Tasks:")
+	for _, step := range tasksToRun.Plan(s) {
+		if len(step.Hosts) == 0 {
+			fmt.Printf("\t> %s\n", step.Task)
+			continue
+		}
+		fmt.Printf("\t> %s on %s\n", step.Task, strings.Join(step.Hosts, ", "))
+	}
+
+	fmt.Println()
+	fmt.Println("Addons:")
+	changes := tasks.AddonsChanges(s)
+	switch {
+	case changes == nil:
+		fmt.Println("\tunknown, the cluster was last applied by an older KubeOne release")
+	case len(changes) == 0:
+		fmt.Println("\tno changes")
+	}
+	for _, change := range changes {
+		fmt.Printf("\t%s\n", change)
+	}
+
+	fmt.Println()
+	fmt.Println("Nothing was changed, run 'kubeone apply' without the --plan flag to reconcile the cluster.")
+
+	return nil
 }
 
 func printHostInformation(host state.Host) {
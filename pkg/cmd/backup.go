@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type backupEtcdOpts struct {
+	globalOptions
+	OutputDir string `longflag:"output-dir" shortflag:"o"`
+}
+
+func backupCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up the cluster",
+	}
+
+	cmd.AddCommand(backupEtcdCmd(rootFlags))
+
+	return cmd
+}
+
+func backupEtcdCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &backupEtcdOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "etcd",
+		Short: "Take the etcd snapshot",
+		Long: heredoc.Doc(`
+			Take the etcd snapshot on the leader control plane node using etcdctl, verify it and download it to the output
+			directory. If the backups storage is configured in the KubeOne manifest (.backups.storage), the snapshot is
+			uploaded to the storage as well.
+		`),
+		Example: `kubeone backup etcd -m mycluster.yaml -t terraformoutput.json -o ./backups`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runBackupEtcd(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.OutputDir,
+		longFlagName(opts, "OutputDir"),
+		shortFlagName(opts, "OutputDir"),
+		".",
+		"directory to save the etcd snapshot to")
+
+	return cmd
+}
+
+func runBackupEtcd(opts *backupEtcdOpts) error {
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	if !s.Cluster.LocalEtcd() {
+		return errors.New("the external etcd is managed outside of KubeOne")
+	}
+
+	filename := fmt.Sprintf("%s-etcd-%s.db", s.Cluster.Name, time.Now().UTC().Format("20060102-150405"))
+	target := filepath.Join(opts.OutputDir, filename)
+
+	if err = tasks.WithEtcdBackup(nil, target).Run(s); err != nil {
+		return err
+	}
+
+	s.Logger.Infof("etcd snapshot saved to %s", target)
+
+	return nil
+}
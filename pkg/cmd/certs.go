@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/clusterstatus/certstatus"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type certsStatusOpts struct {
+	globalOptions
+	Output     string        `longflag:"output" shortflag:"o"`
+	WarnWithin time.Duration `longflag:"warn-within"`
+	ExitCode   bool          `longflag:"exit-code"`
+}
+
+type certsRenewOpts struct {
+	globalOptions
+	AutoApprove bool `longflag:"auto-approve" shortflag:"y"`
+}
+
+// certsCmd returns the structure for declaring the "certs" subcommand.
+func certsCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "certs",
+		Short: "Manage the certificates of the control plane",
+	}
+
+	cmd.AddCommand(certsStatusCmd(rootFlags))
+	cmd.AddCommand(certsRenewCmd(rootFlags))
+
+	return cmd
+}
+
+func certsStatusCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &certsStatusOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Report the expiration of the control plane certificates",
+		Long: heredoc.Doc(`
+			Report the expiration of the certificates managed by kubeadm on all control plane nodes, including the client
+			certificates embedded in the admin.conf, controller-manager.conf and scheduler.conf kubeconfig files.
+
+			The report is printed as a table by default. Use '--output json' or '--output yaml' to print it in a
+			machine-readable format, and '--exit-code' to fail if any certificate expires within the '--warn-within' period.
+			The certificate authorities are reported as well, but they are not renewed by 'kubeone certs renew'.
+		`),
+		Example: `kubeone certs status -m mycluster.yaml -t terraformoutput.json -o json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runCertsStatus(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.Output,
+		longFlagName(opts, "Output"),
+		shortFlagName(opts, "Output"),
+		certstatus.OutputText,
+		"format of the report, one of: text, json, yaml")
+
+	cmd.Flags().DurationVar(
+		&opts.WarnWithin,
+		longFlagName(opts, "WarnWithin"),
+		certstatus.DefaultWarnWithin,
+		"report the certificates expiring within the given period of time as expiring soon")
+
+	cmd.Flags().BoolVar(
+		&opts.ExitCode,
+		longFlagName(opts, "ExitCode"),
+		false,
+		"exit with an error if a certificate is expired or expires soon")
+
+	return cmd
+}
+
+func certsRenewCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &certsRenewOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "renew",
+		Short: "Renew the control plane certificates",
+		Long: heredoc.Doc(`
+			Renew the certificates managed by kubeadm on all control plane nodes using 'kubeadm certs renew all'.
+
+			The control plane nodes are renewed one at a time, starting with the leader. After the certificates of a node
+			are renewed, etcd, kube-apiserver, kube-controller-manager and kube-scheduler are restarted one by one, waiting
+			for each of them to become ready, so they load the renewed certificates. The kubeconfig with the renewed client
+			certificate is downloaded at the end.
+
+			The certificate authorities are not renewed.
+		`),
+		Example: `kubeone certs renew -m mycluster.yaml -t terraformoutput.json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runCertsRenew(opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&opts.AutoApprove,
+		longFlagName(opts, "AutoApprove"),
+		shortFlagName(opts, "AutoApprove"),
+		false,
+		"auto approve plan")
+
+	return cmd
+}
+
+// runCertsStatus prints the expiration of the control plane certificates
+func runCertsStatus(opts *certsStatusOpts) error {
+	switch opts.Output {
+	case certstatus.OutputText, certstatus.OutputJSON, certstatus.OutputYAML:
+	default:
+		return errors.Errorf("unknown output format %q", opts.Output)
+	}
+
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	if err = tasks.WithHostnameOS(nil).Run(s); err != nil {
+		return err
+	}
+
+	report, err := tasks.CertificatesStatus(s, opts.WarnWithin)
+	if err != nil {
+		return errors.Wrap(err, "failed to get certificates status")
+	}
+
+	if err = certstatus.Print(os.Stdout, report, opts.Output); err != nil {
+		return err
+	}
+
+	if expiring := report.ExpiringSoon(); opts.ExitCode && len(expiring) > 0 {
+		return errors.Errorf("%d certificates are expired or expire within %s", len(expiring), opts.WarnWithin)
+	}
+
+	return nil
+}
+
+// runCertsRenew renews the control plane certificates
+func runCertsRenew(opts *certsRenewOpts) error {
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
+	// Probe the cluster for the actual state and the needed tasks.
+	probbing := tasks.WithHostnameOS(nil)
+	probbing = tasks.WithProbes(probbing)
+
+	if err = probbing.Run(s); err != nil {
+		return err
+	}
+
+	if !s.LiveCluster.IsProvisioned() {
+		return errors.New("the target cluster is not provisioned")
+	}
+	if !s.LiveCluster.Healthy() {
+		return errors.New("the target cluster is not healthy, please run 'kubeone apply' first")
+	}
+
+	tasksToRun := tasks.WithCertificatesRenewal(nil)
+
+	fmt.Println("The following actions will be taken: ")
+	fmt.Println("Run with --verbose flag for more information.")
+
+	for _, op := range tasksToRun.Descriptions(s) {
+		fmt.Printf("\t~ %s\n", op)
+	}
+
+	fmt.Println()
+
+	confirm, err := confirmCommand(opts.AutoApprove)
+	if err != nil {
+		return err
+	}
+
+	if !confirm {
+		s.Logger.Println("Operation canceled.")
+		return nil
+	}
+
+	if err = tasksToRun.Run(s); err != nil {
+		return errors.Wrap(err, "failed to renew the certificates")
+	}
+
+	report, err := tasks.CertificatesStatus(s, certstatus.DefaultWarnWithin)
+	if err != nil {
+		return errors.Wrap(err, "failed to get certificates status")
+	}
+
+	return certstatus.Print(os.Stdout, report, certstatus.OutputText)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	embeddedaddons "k8c.io/kubeone/addons"
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type completionFunc func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+
+// registerDynamicCompletions registers completion functions suggesting
+// values sourced from the loaded KubeOne config and the KubeOne binary for
+// the flags of the command and all its subcommands. Flags are matched by
+// name, so the flags added later get the completion as well.
+func registerDynamicCompletions(rootCmd *cobra.Command, rootFlags *pflag.FlagSet) {
+	completions := map[string]completionFunc{
+		"cluster": completeClusterNames(rootFlags),
+		"node":    completeHosts(rootFlags),
+		"nodes":   completeHosts(rootFlags),
+		"host":    completeHosts(rootFlags),
+		"hosts":   completeHosts(rootFlags),
+		"addon":   completeAddons,
+		"addons":  completeAddons,
+		"phase":   completeTasks,
+		"phases":  completeTasks,
+		"task":    completeTasks,
+		"tasks":   completeTasks,
+		"graph":   completeValues(tasks.GraphFormatDot, tasks.GraphFormatMermaid),
+	}
+
+	registered := map[*pflag.Flag]bool{}
+
+	var register func(cmd *cobra.Command)
+	register = func(cmd *cobra.Command) {
+		cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+			fn, ok := completions[flag.Name]
+			if !ok || registered[flag] {
+				return
+			}
+			registered[flag] = true
+			_ = cmd.RegisterFlagCompletionFunc(flag.Name, fn)
+		})
+
+		for _, sub := range cmd.Commands() {
+			register(sub)
+		}
+	}
+
+	register(rootCmd)
+}
+
+func completeValues(values ...string) completionFunc {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeClusterNames(rootFlags *pflag.FlagSet) completionFunc {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		opts, err := persistentGlobalOptions(rootFlags)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		ws, err := opts.loadWorkspace()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var names []string
+		for _, name := range ws.Names() {
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeHosts(rootFlags *pflag.FlagSet) completionFunc {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		cluster, err := completionCluster(rootFlags)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var hosts []string
+		for _, group := range [][]kubeoneapi.HostConfig{cluster.ControlPlane.Hosts, cluster.StaticWorkers.Hosts} {
+			for _, host := range group {
+				if host.Hostname != "" {
+					hosts = append(hosts, host.PublicAddress+"\t"+host.Hostname)
+					continue
+				}
+				hosts = append(hosts, host.PublicAddress)
+			}
+		}
+
+		return hosts, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func completeAddons(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	entries, err := fs.ReadDir(embeddedaddons.F, ".")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeTasks(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	seen := map[string]bool{}
+
+	var names []string
+	for _, t := range []tasks.Tasks{
+		tasks.WithFullInstall(nil),
+		tasks.WithUpgrade(nil),
+		tasks.WithReset(nil),
+	} {
+		for _, label := range t.Labels() {
+			name := strings.ReplaceAll(label, " ", "-")
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionCluster loads the cluster selected by the global flags without
+// logging anything, as the output of the completion is read by the shell
+func completionCluster(rootFlags *pflag.FlagSet) (*kubeoneapi.KubeOneCluster, error) {
+	opts, err := persistentGlobalOptions(rootFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	wc, err := opts.workspaceCluster()
+	if err != nil {
+		return nil, err
+	}
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	return loadClusterConfig(wc, opts.TerraformState, opts.CredentialsFile, logger)
+}
@@ -18,13 +18,13 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"k8c.io/kubeone/pkg/apis/kubeone/config"
 	kubeonev1beta1 "k8c.io/kubeone/pkg/apis/kubeone/v1beta1"
 	"k8c.io/kubeone/pkg/templates/images"
 
@@ -32,8 +32,8 @@ import (
 )
 
 type listImagesOpts struct {
-	ManifestFile string `longflag:"manifest" shortflag:"m"`
-	Filter       string `longflag:"filter"`
+	ManifestFiles []string `longflag:"manifest" shortflag:"m"`
+	Filter        string   `longflag:"filter"`
 }
 
 func configImagesCmd(rootFlags *pflag.FlagSet) *cobra.Command {
@@ -66,11 +66,11 @@ func listImagesCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 			kubeone config images list -m mycluster.yaml
 		`),
 		RunE: func(*cobra.Command, []string) error {
-			manifestFile, err := rootFlags.GetString(longFlagName(opts, "ManifestFile"))
+			manifestFiles, err := rootFlags.GetStringArray(longFlagName(opts, "ManifestFiles"))
 			if err != nil {
 				return errors.WithStack(err)
 			}
-			opts.ManifestFile = manifestFile
+			opts.ManifestFiles = manifestFiles
 
 			return listImages(opts)
 		},
@@ -82,6 +82,8 @@ func listImagesCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 		"none",
 		"images list filter, one of the [none|base|optional]")
 
+	_ = cmd.RegisterFlagCompletionFunc(longFlagName(opts, "Filter"), completeValues("none", "base", "optional"))
+
 	return cmd
 }
 
@@ -100,9 +102,11 @@ func listImages(opts *listImagesOpts) error {
 
 	var resolveropts []images.Opt
 
-	// FOR FUTURE READER: we only attempt to read the ManifestFile, but if it's not there, we don't care.
-	configBuf, err := os.ReadFile(opts.ManifestFile)
-	if err == nil {
+	// FOR FUTURE READER: we only attempt to read the ManifestFiles, but if they're not there, we don't care.
+	ws, err := config.LoadWorkspace(opts.ManifestFiles, nil)
+	if err == nil && len(ws.Clusters) == 1 {
+		configBuf := ws.Clusters[0].Manifest
+
 		// Custom loading of the config is needed to avoid "normal" validation process, but we here don't care about
 		// validity of the config, the only part that's needed is `.RegistryConfiguration`
 		var conf kubeonev1beta1.KubeOneCluster
@@ -89,8 +89,11 @@ func configCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 	}
 
 	cmd.AddCommand(configPrintCmd())
+	cmd.AddCommand(configDumpCmd(rootFlags))
 	cmd.AddCommand(configMigrateCmd(rootFlags))
 	cmd.AddCommand(configMachinedeploymentsCmd(rootFlags))
+	cmd.AddCommand(configCAPIExportCmd(rootFlags))
+	cmd.AddCommand(configImportCmd(rootFlags))
 	cmd.AddCommand(configImagesCmd(rootFlags))
 
 	return cmd
@@ -141,7 +144,7 @@ func configPrintCmd() *cobra.Command {
 		longFlagName(opts, "CloudProviderName"),
 		shortFlagName(opts, "CloudProviderName"),
 		defaultCloudProviderName,
-		"cloud provider name (aws, digitalocean, gce, hetzner, packet, openstack, vsphere, none)")
+		"cloud provider name (aws, digitalocean, gce, hetzner, kubevirt, nutanix, packet, openstack, vsphere, vmware-cloud-director, none)")
 
 	// Hosts
 	cmd.Flags().StringVar(&opts.ControlPlaneHosts, longFlagName(opts, "ControlPlaneHosts"), "", "control plane hosts in format of comma-separated key:value list, example: publicAddress:192.168.0.100,privateAddress:192.168.1.100,sshUsername:ubuntu,sshPort:22. Use quoted string of space separated values for multiple hosts")
@@ -177,6 +180,34 @@ func configPrintCmd() *cobra.Command {
 	return cmd
 }
 
+// configDumpCmd setups the dump command
+func configDumpCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Print the effective configuration manifest",
+		Long: heredoc.Doc(`
+			Print the effective KubeOneCluster manifest that apply acts on.
+
+			The manifest is loaded together with the Terraform output and the credentials
+			file, and all defaults are applied. Values of the fields that can contain
+			secrets, such as the cloud-config, are redacted.
+			The manifest is printed on the standard output.
+		`),
+		Args:    cobra.ExactArgs(0),
+		Example: `kubeone config dump -m mycluster.yaml -t tf.json -c credentials.yaml`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			return runDump(gopts)
+		},
+	}
+
+	return cmd
+}
+
 // configMigrateCmd setups the migrate command
 func configMigrateCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 	cmd := &cobra.Command{
@@ -234,7 +265,7 @@ The manifest is printed on the standard output.
 func runPrint(printOptions *printOpts) error {
 	if printOptions.FullConfig {
 		switch printOptions.CloudProviderName {
-		case "digitalocean", "packet", "hetzner":
+		case "digitalocean", "packet", "hetzner", "kubevirt", "nutanix", "vmware-cloud-director":
 			printOptions.CloudProviderExternal = true
 		case "openstack":
 			printOptions.CloudProviderCloudCfg = "<< cloudConfig is required for OpenStack >>"
@@ -305,6 +336,12 @@ func createAndPrintManifest(printOptions *printOpts) error {
 	case "hetzner":
 		cfg.Set(yamled.Path{"cloudProvider", "hetzner"}, providerVal)
 		cfg.Set(yamled.Path{"cloudProvider", "external"}, true)
+	case "kubevirt":
+		cfg.Set(yamled.Path{"cloudProvider", "kubevirt", "infraNamespace"}, "<< infraNamespace is required for KubeVirt >>")
+		cfg.Set(yamled.Path{"cloudProvider", "external"}, true)
+	case "nutanix":
+		cfg.Set(yamled.Path{"cloudProvider", "nutanix"}, providerVal)
+		cfg.Set(yamled.Path{"cloudProvider", "external"}, true)
 	case "openstack":
 		cfg.Set(yamled.Path{"cloudProvider", "openstack"}, providerVal)
 		cfg.Set(yamled.Path{"cloudProvider", "cloudConfig"}, "<< cloudConfig is required for OpenStack >>\n")
@@ -314,6 +351,9 @@ func createAndPrintManifest(printOptions *printOpts) error {
 	case "vsphere":
 		cfg.Set(yamled.Path{"cloudProvider", "vsphere"}, providerVal)
 		cfg.Set(yamled.Path{"cloudProvider", "cloudConfig"}, "<< cloudConfig is required for vSphere >>\n")
+	case "vmware-cloud-director":
+		cfg.Set(yamled.Path{"cloudProvider", "vmwareCloudDirector", "vApp"}, "<< vApp is required for VMware Cloud Director >>")
+		cfg.Set(yamled.Path{"cloudProvider", "external"}, true)
 	case "none":
 		cfg.Set(yamled.Path{"cloudProvider", "none"}, providerVal)
 	}
@@ -440,8 +480,12 @@ func parseControlPlaneHosts(cfg *yamled.Document, hostList string) error {
 
 // runMigrate migrates the KubeOneCluster manifest from v1alpha1 to v1beta1
 func runMigrate(opts *globalOptions) error {
+	if len(opts.ManifestFiles) != 1 {
+		return errors.New("migrate supports exactly one manifest file")
+	}
+
 	// Convert old config yaml to new config yaml
-	newConfigYAML, err := config.MigrateOldConfig(opts.ManifestFile)
+	newConfigYAML, err := config.MigrateOldConfig(opts.ManifestFiles[0])
 	if err != nil {
 		return errors.Wrap(err, "unable to migrate the provided configuration")
 	}
@@ -454,6 +498,28 @@ func runMigrate(opts *globalOptions) error {
 	return nil
 }
 
+// runDump prints the effective KubeOneCluster manifest
+func runDump(opts *globalOptions) error {
+	wc, err := opts.workspaceCluster()
+	if err != nil {
+		return errors.Wrap(err, "failed to load cluster")
+	}
+
+	cluster, err := loadClusterConfig(wc, opts.TerraformState, opts.CredentialsFile, opts.newLogger())
+	if err != nil {
+		return err
+	}
+
+	manifest, err := config.MarshalKubeOneCluster(config.RedactKubeOneCluster(cluster))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the effective configuration")
+	}
+
+	fmt.Print(string(manifest))
+
+	return nil
+}
+
 // runGenerateMachineDeployments generates the MachineDeployments manifest
 func runGenerateMachineDeployments(opts *globalOptions) error {
 	s, err := opts.BuildState()
@@ -505,8 +571,21 @@ versions:
 clusterNetwork:
   # the subnet used for pods (default: 10.244.0.0/16)
   podSubnet: "{{ .PodSubnet }}"
+  # additional subnets appended to the pod network when podSubnet is
+  # exhausted; supported with canal and calico-vxlan
+  # additionalPodSubnets:
+  # - "10.245.0.0/16"
   # the subnet used for services (default: 10.96.0.0/12)
   serviceSubnet: "{{ .ServiceSubnet }}"
+  # the IP families of the cluster, the first one being the primary family;
+  # two families deploy a dual-stack cluster, which requires Kubernetes 1.21
+  # or newer and the cilium, calico (in the bgp mode) or external CNI
+  # ipFamilies:
+  # - IPv4
+  # - IPv6
+  # the subnets of the IPv6 family (default: fd01::/48 and fd02::/120)
+  # podSubnetIPv6: "fd01::/48"
+  # serviceSubnetIPv6: "fd02::/120"
   # the domain name used for services (default: cluster.local)
   serviceDomainName: "{{ .ServiceDNS }}"
   # a nodePort range to reserve for services (default: 30000-32767)
@@ -523,19 +602,31 @@ clusterNetwork:
       # * sed: shortest expected delay
       # * nq: never queue
       scheduler: rr
-      strictArp: false
+      # enabled automatically if not set and an addon announcing
+      # LoadBalancer IPs using ARP (such as MetalLB) is deployed
+      # strictARP: false
       tcpTimeout: "0"
       tcpFinTimeout: "0"
       udpTimeout: "0"
       excludeCIDRs: []
     # if mode is by default
     iptables: {}
+    # address with the port the metrics server serves on (default: 127.0.0.1:10249)
+    # metricsBindAddress: "0.0.0.0:10249"
+    # size of the connection tracking table
+    # conntrack:
+    #   maxPerCore: 32768
+    #   min: 131072
+    # maximum interval of refreshing the iptables or IPVS rules (default: 30s)
+    # syncPeriod: 30s
   # CNI plugin of choice. CNI can not be changed later at upgrade time.
   cni:
     # Only one CNI plugin can be defined at the same time
     # Supported CNI plugins:
     # * canal
     # * weave-net
+    # * cilium
+    # * calico
     # * external - The CNI plugin can be installed as an addon or manually
     canal:
       # MTU represents the maximum transmission unit.
@@ -546,12 +637,92 @@ clusterNetwork:
       # * OpenStack - 1400 (OpenStack specific 1450 bytes - 50 VXLAN bytes)
       # * Default - 1450
       mtu: 1450
+      # Params passed to the embedded addon templates: FlannelBackend,
+      # FlannelIface and the FELIX_* environment variables
+      params: {}
+      #   FlannelIface: eth1
+      #   FELIX_LOGSEVERITYSCREEN: Warning
     # weaveNet:
     #   # When true is set, secret will be automatically generated and
     #   # referenced in appropriate manifests. Currently only weave-net
     #   # supports encryption.
     #   encrypted: true
+    #   # Params passed to the embedded addon templates: MTU and ExtraArgs
+    #   params:
+    #     MTU: "1376"
+    # cilium:
+    #   # Replaces kube-proxy using eBPF: disabled (default), probe, partial
+    #   # or strict. kube-proxy is not deployed to the new clusters with
+    #   # strict, which requires Kubernetes 1.22+.
+    #   kubeProxyReplacement: disabled
+    #   # Enables the Hubble network observability
+    #   hubble:
+    #     relay: true
+    #     ui: true
+    #     # ClusterIP (default), NodePort or LoadBalancer
+    #     uiServiceType: ClusterIP
+    #   # Params passed to the embedded addon templates: MTU and Tunnel
+    #   params:
+    #     Tunnel: geneve
+    # calico:
+    #   # vxlan (default), ipip or bgp (no encapsulation)
+    #   mode: bgp
+    #   # Encapsulate only the traffic crossing the subnets (vxlan and ipip)
+    #   crossSubnet: false
+    #   # Detected automatically if not set
+    #   mtu: 1450
+    #   # BGP peering (ipip and bgp)
+    #   bgp:
+    #     # Default value is 64512
+    #     asNumber: 64512
+    #     disableNodeToNodeMesh: true
+    #     peers:
+    #     - name: tor-1
+    #       peerIP: 192.168.1.1
+    #       asNumber: 64513
+    #       # Calico selector syntax, all nodes if not set
+    #       nodeSelector: rack == 'rack-1'
+    #   # Params passed to the embedded addon templates:
+    #   # IPAutodetectionMethod, IP6AutodetectionMethod and the FELIX_*
+    #   # environment variables
+    #   params:
+    #     IPAutodetectionMethod: interface=eth.*
     # external: {}
+  # CoreDNS directives rendered into the kubeadm-managed Corefile and restored
+  # on every apply and upgrade
+  # coreDNS:
+  #   # number of CoreDNS replicas, defaults to the kubeadm default (2)
+  #   replicas: 3
+  #   # deploy PodDisruptionBudget allowing at most one unavailable replica
+  #   deployPodDisruptionBudget: true
+  #   # upstream DNS servers used instead of the node's /etc/resolv.conf
+  #   forwarders:
+  #   - 8.8.8.8
+  #   - 1.1.1.1
+  #   # DNS domains resolved by the dedicated DNS servers
+  #   stubDomains:
+  #   - domain: corp.example.com
+  #     servers:
+  #     - 10.0.0.53
+  #   # snippet appended to the Corefile as is
+  #   corefileSnippet: |
+  #     example.org:53 {
+  #         whoami
+  #     }
+  #   # hosts-file style entries served by the hosts plugin
+  #   hosts:
+  #   - ip: 10.0.0.10
+  #     hostnames:
+  #     - registry.example.com
+  #   # rules of the rewrite plugin
+  #   rewrites:
+  #   - field: name
+  #     match: suffix
+  #     from: .corp.example.com
+  #     to: .svc.cluster.local
+  #   # additional plugins enabled in the root server block
+  #   plugins:
+  #   - name: log
 
 cloudProvider:
   # Only one cloud provider can be defined at the same time.
@@ -562,11 +733,33 @@ cloudProvider:
   # gce: {}
   # hetzner:
   #   networkID: ""
+  # kubevirt:
+  #   infraNamespace: ""
+  # nutanix: {}
   # openstack: {}
-  # packet: {}
+  # packet:
+  #   metro: ""
+  #   loadBalancer: ""
+  #   nodeIPFromMetadata: false
+  #   bgp:
+  #     enable: false
+  #     localASN: 65000
+  #   elasticIP:
+  #     tag: ""
   # vsphere: {}
+  # vmwareCloudDirector:
+  #   vApp: ""
+  #   storageProfile: ""
   # none: {}
+  {{- if eq .CloudProviderName "vmware-cloud-director" }}
+  vmwareCloudDirector:
+    vApp: ""
+  {{- else if eq .CloudProviderName "kubevirt" }}
+  kubevirt:
+    infraNamespace: ""
+  {{- else }}
   {{ .CloudProviderName }}: {}
+  {{- end }}
   # Set the kubelet flag '--cloud-provider=external' and deploy the external CCM for supported providers
   external: {{ .CloudProviderExternal }}
   # Path to file that will be uploaded and used as custom '--cloud-config' file.
@@ -591,10 +784,23 @@ containerRuntime:
   # Installs containerd container runtime.
   # Default for 1.21+ Kubernetes clusters.
   # containerd: {}
+  #   # Configures the registry mirrors in per-registry hosts.toml files.
+  #   # containerd 1.5+ is installed when registries are configured.
+  #   registries:
+  #     docker.io:
+  #       mirrors:
+  #       - https://mirror.example.com
+  #       auth:
+  #         username: ""
+  #         password: ""
+  #       insecureSkipVerify: false
   # Installs Docker container runtime.
   # Default for Kubernetes clusters up to 1.20.
   # This option will be removed once Kubernetes 1.21 reaches EOL.
   # docker: {}
+  # Installs CRI-O container runtime matching the Kubernetes minor version.
+  # CRI-O is not available on Flatcar Linux.
+  # crio: {}
 
 features:
   # Enable the PodNodeSelector admission plugin in API server.
@@ -607,11 +813,66 @@ features:
       # configFilePath is is a required field.
       # More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#configuration-file-format-1
       configFilePath: ""
+  # Enable the EventRateLimit admission plugin in API server, protecting etcd
+  # from event storms on large or noisy clusters.
+  # More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#eventratelimit
+  eventRateLimit:
+    enable: false
+    config:
+      # limits default to the Server limit with 50 QPS and 100 burst, and the
+      # Namespace limit with 50 QPS, 100 burst and the cache size of 2000.
+      # Supported limit types are Server, Namespace, User and SourceAndObject.
+      limits:
+      - type: Server
+        qps: 50
+        burst: 100
+      - type: Namespace
+        qps: 50
+        burst: 100
+        cacheSize: 2000
+  # Enables the AlwaysPullImages admission plugin in API server, forcing every
+  # new pod to pull its images, so only the pods with the registry credentials
+  # can use the images cached on the node.
+  # More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#alwayspullimages
+  alwaysPullImages:
+    enable: false
+  # Enables the DenyServiceExternalIPs admission plugin in API server, rejecting
+  # new Services with externalIPs. Supported on Kubernetes 1.21 and newer.
+  # More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#denyserviceexternalips
+  denyServiceExternalIPs:
+    enable: false
+  # Enables the NodeRestriction admission plugin in API server, limiting the
+  # Node and Pod objects a kubelet can modify.
+  # More info: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#noderestriction
+  nodeRestriction:
+    enable: false
   # Enables PodSecurityPolicy admission plugin in API server, as well as creates
   # default 'privileged' PodSecurityPolicy, plus RBAC rules to authorize
   # 'kube-system' namespace pods to 'use' it.
   podSecurityPolicy:
     enable: {{ .EnablePodSecurityPolicy }}
+  # Enables PodSecurity admission plugin in API server, enforcing the Pod
+  # Security Standards, which replace the deprecated PodSecurityPolicy.
+  # The cluster-wide defaults apply to namespaces without the
+  # pod-security.kubernetes.io labels. The PodSecurity feature gate is enabled
+  # on Kubernetes 1.22.
+  podSecurityAdmission:
+    enable: false
+    config:
+      # privileged, baseline or restricted
+      enforce: privileged
+      # latest or v1.X
+      enforceVersion: latest
+      audit: privileged
+      auditVersion: latest
+      warn: privileged
+      warnVersion: latest
+      exemptions:
+        usernames: []
+        runtimeClasses: []
+        # default exempted namespaces
+        namespaces:
+        - kube-system
   # Enables PodPresets admission plugin in API server.
   # The PodPresets feature has been removed in Kubernetes 1.20.
   # This feature is deprecated and will be removed from the API once
@@ -638,6 +899,21 @@ features:
       logMaxBackup: 3
       # LogMaxSize is maximum size in megabytes of audit log file before it gets rotated
       logMaxSize: 100
+      # DisableLogBackend disables the audit log files, e.g. when the audit
+      # events are sent to the webhook backend only
+      disableLogBackend: false
+      # Webhook sends the audit events to the external collector described by
+      # the kubeconfig file on local file system, in addition to or instead of
+      # the audit log files.
+      # More info: https://kubernetes.io/docs/tasks/debug-application-cluster/audit/#webhook-backend
+      # webhook:
+      #   configFilePath: "./audit-webhook-kubeconfig.yaml"
+      #   # batch (default), blocking or blocking-strict
+      #   mode: batch
+      #   initialBackoff: 10s
+      #   batchBufferSize: 10000
+      #   batchMaxSize: 400
+      #   batchMaxWait: 30s
   # Enables dynamic audit logs.
   # After enablig this, operator should create auditregistration.k8s.io/v1alpha1
   # AuditSink object.
@@ -649,6 +925,20 @@ features:
   metricsServer:
     # enabled by default
     enable: {{ .EnableMetricsServer }}
+  # Deploy the NodeLocalDNS cache on all nodes and configure kubelet to use it
+  # instead of the cluster DNS service
+  nodeLocalDNS:
+    # enabled by default
+    enable: true
+    config:
+      # addresses the cache binds to on all nodes
+      virtualIP: "169.254.20.10"
+      virtualIPv6: "fd00::10"
+      # resolvers for the names outside of the cluster domain, defaults to
+      # the resolvers in /etc/resolv.conf of the nodes
+      upstreamServers: []
+      # use TCP for the forwarded queries
+      forceTCP: true
   # Enable OpenID-Connect support in API server
   # More info: https://kubernetes.io/docs/reference/access-authn-authz/authentication/#openid-connect-tokens
   openidConnect:
@@ -697,6 +987,132 @@ features:
     enable: {{ .EnableEncryptionProviders }}
     # inline string
     customEncryptionConfiguration: ""
+    # Generate the EncryptionConfiguration for the KMS provider instead of
+    # providing the customEncryptionConfiguration. If the plugin is set,
+    # KubeOne deploys the KMS plugin as a static pod on control plane nodes,
+    # otherwise the plugin listening on the endpoint must be deployed by the
+    # user.
+    # kms:
+    #   name: kubeone-kms
+    #   # default endpoint
+    #   endpoint: /var/run/kmsplugin/socket.sock
+    #   cacheSize: 1000
+    #   timeout: 3s
+    #   plugin:
+    #     # overrides the default plugin image
+    #     image: ""
+    #     # only one of aws, azure and gcp can be set
+    #     aws:
+    #       keyARN: ""
+    #       region: ""
+    #     azure:
+    #       keyVaultName: ""
+    #       keyName: ""
+    #       keyVersion: ""
+    #     gcp:
+    #       keyURI: ""
+
+  # Enable FIPS-compliant deployment mode. Only FIPS-approved TLS settings are
+  # used for kube-apiserver, etcd and kubelet, and all hosts must be running a
+  # kernel booted in FIPS mode (/proc/sys/crypto/fips_enabled = 1).
+  fips:
+    # disabled by default
+    enable: false
+
+  # Keep SELinux in the enforcing mode on CentOS/RHEL hosts instead of
+  # switching it to the permissive mode. container-selinux policies are
+  # installed and SELinux contexts are set for kubelet, etcd and CNI directories.
+  selinux:
+    # disabled by default
+    enable: false
+
+  # Deploy custom AppArmor profiles to all hosts. Profiles are installed into
+  # /etc/apparmor.d and loaded using apparmor_parser.
+  appArmor:
+    # disabled by default
+    enable: false
+    profiles: []
+    # - name: k8s-nginx
+    #   # path is relative to the KubeOne configuration file
+    #   profileFilePath: apparmor/k8s-nginx
+
+  # Use the RuntimeDefault seccomp profile by default for all workloads by
+  # enabling the kubelet SeccompDefault setting. Requires Kubernetes 1.22+.
+  seccompDefault:
+    # disabled by default
+    enable: false
+
+  # Deploy an opinionated set of ClusterRoles and bindings (viewer, operator
+  # and namespace-admin) mapped to the OIDC groups. Requires openidConnect.
+  # Group names are prefixed with openidConnect.config.groupsPrefix.
+  bootstrapRBAC:
+    # disabled by default
+    enable: false
+    viewers: []
+    operators: []
+    namespaceAdmins: []
+    # - namespace: team-a
+    #   groups:
+    #   - team-a-admins
+
+  # Deploy kube-vip as a static pod on the control plane nodes, announcing
+  # apiEndpoint.host as the virtual IP using ARP. apiEndpoint.host must be an
+  # unused IP address in the control plane nodes network.
+  kubeVIP:
+    # disabled by default
+    enable: false
+    # interface the virtual IP is announced on, defaults to the interface of
+    # the default route
+    interface: ""
+
+  # Deploy konnectivity-server on the control plane nodes and
+  # konnectivity-agent on all nodes, and route the kube-apiserver traffic to
+  # the nodes, pods and services through the agents. Used when kube-apiserver
+  # can't reach the kubelets directly, e.g. the nodes are behind NAT.
+  konnectivity:
+    # disabled by default
+    enable: false
+    config:
+      # address the agents connect to, must forward the agent port to the
+      # control plane nodes and be covered by the kube-apiserver certificate.
+      # Defaults to apiEndpoint.host.
+      serverAddress: ""
+      # port konnectivity-server listens on for the agents
+      agentPort: 8132
+
+  # Configure SR-IOV virtual functions on the given hosts and deploy the
+  # SR-IOV network device plugin and CNI plugin. Hosts must be rebooted for
+  # the kernel parameters to take effect.
+  sriov:
+    # disabled by default
+    enable: false
+    # kernelParameters: ["intel_iommu=on", "iommu=pt"]
+    hosts: []
+    # - address: 10.0.0.10
+    #   physicalFunctions:
+    #   - interface: ens1f0
+    #     numVFs: 8
+    #     # advertised as intel.com/<resourceName> (default: sriov_<interface>)
+    #     resourceName: sriov_ens1f0
+
+  # Deploy the OPA Gatekeeper policy controller. Policies are enforced by
+  # creating ConstraintTemplates and Constraints.
+  # More info: https://open-policy-agent.github.io/gatekeeper/website/docs/
+  gatekeeper:
+    # disabled by default
+    enable: false
+    config:
+      # interval in seconds between audits of the existing resources
+      auditInterval: 60
+      # deploy the ConstraintTemplates of the commonly used policies from the
+      # Gatekeeper policy library
+      constraintLibrary: false
+
+  # Kubernetes feature gates enabled or disabled on the API server, the
+  # controller manager, the scheduler, kube-proxy and kubelet. The feature
+  # gates configured by KubeOne or for the specific component take precedence.
+  featureGates: {}
+  #   EphemeralContainers: true
 
 ## Bundle of Root CA Certificates extracted from Mozilla
 ## can be found here: https://curl.se/ca/cacert.pem
@@ -799,9 +1215,29 @@ addons:
   enable: false
   # In case when the relative path is provided, the path is relative
   # to the KubeOne configuration file.
-  # This path must be always provided and the directory must exist, even if
-  # using only embedded addons.
+  # Either this path or sources must be provided, even if using only embedded
+  # addons. If provided, the directory must exist.
   path: "./addons"
+  # sources are the remote sources of addons manifests, downloaded to the local
+  # cache and merged with the addons directory. Exactly one of git, http and s3
+  # must be set per source. Git sources are pinned to the commit, while
+  # archives must be .tar.gz files and are verified against the sha256
+  # checksum.
+  # sources:
+  # - git:
+  #     repository: https://github.com/example/kubeone-addons.git
+  #     # full SHA of the commit pinning the addons manifests
+  #     commit: ""
+  #     # path is the directory in the repository with addons manifests
+  #     path: addons
+  # - http:
+  #     url: https://example.com/kubeone-addons.tar.gz
+  #     sha256: ""
+  # - s3:
+  #     bucket: kubeone-addons
+  #     key: kubeone-addons.tar.gz
+  #     region: eu-central-1
+  #     sha256: ""
   # globalParams is a key-value map of values passed to the addons templating engine,
   # to be used in the addons' manifests. The values defined here are passed to all
   # addons.
@@ -823,6 +1259,205 @@ addons:
       # defined in globalParams.
       params:
         key: value
+      # priority orders the addons that don't depend on each other, the addons
+      # with the lower priority are applied first
+      priority: 0
+      # dependsOn is a list of addons applied before this addon
+      dependsOn: []
+      # waitReady waits for CRDs, Deployments, StatefulSets and DaemonSets of
+      # the addon to become ready before applying the next addon
+      waitReady: false
+    # helm deploys the addon from the Helm chart instead of the addons directory.
+    # The chart is rendered by KubeOne, and the rendered manifests are applied
+    # and pruned the same way as the other addons. Chart hooks are not run.
+    # - name: ingress-nginx
+    #   helm:
+    #     # chart is the chart name in the repository, the OCI reference
+    #     # (oci://registry/repository/chart), or the path to the local chart
+    #     chart: ingress-nginx
+    #     repoURL: https://kubernetes.github.io/ingress-nginx
+    #     version: 4.0.13
+    #     # namespace to deploy the chart to, defaults to kube-system
+    #     namespace: ingress-nginx
+    #     # valuesFiles are relative to the KubeOne configuration file
+    #     valuesFiles:
+    #     - ./ingress-nginx-values.yaml
+    #     # values override the values from valuesFiles
+    #     values: |
+    #       controller:
+    #         replicaCount: 2
+
+# hooks are the local commands and webhooks run before and after the KubeOne
+# operations. Commands are run with /bin/sh and get the operation in the
+# KUBEONE_HOOK_EVENT, KUBEONE_CLUSTER_NAME, KUBEONE_NODE_HOSTNAME,
+# KUBEONE_NODE_ADDRESS and KUBEONE_ERROR environment variables. Webhooks get
+# the operation as the JSON body of the POST request. Post hooks are run even
+# if the operation fails.
+# Supported events are preApply, postApply, preUpgrade, postUpgrade,
+# preReset, postReset, preNodeUpgrade and postNodeUpgrade.
+# hooks:
+#   preApply:
+#   - name: silence-alerts
+#     command: amtool silence add cluster="$KUBEONE_CLUSTER_NAME" --duration=2h
+#     timeout: 30s
+#   preNodeUpgrade:
+#   - name: remove-from-lb
+#     webhook:
+#       url: https://lb.example.com/hooks/drain
+#       headers:
+#         # values prefixed with "env:" are read from the environment variables
+#         Authorization: env:LB_TOKEN
+#   postApply:
+#   - name: notify
+#     command: ./notify.sh
+#     # log the failure instead of failing the operation
+#     ignoreFailure: true
+#   # host scripts are run on the control plane and static worker nodes over
+#   # SSH. Scripts are rendered as Go templates with the .CLUSTER_NAME,
+#   # .KUBERNETES_VERSION, .HOSTNAME, .PUBLIC_ADDRESS, .PRIVATE_ADDRESS,
+#   # .NODE_ID, .CONTROL_PLANE and .LEADER variables.
+#   host:
+#     preProvision:
+#     - name: mirrors
+#       # relative to this configuration file
+#       file: ./scripts/mirrors.sh
+#     postJoin:
+#     - name: label
+#       inline: echo "{{ "{{ .HOSTNAME }}" }} joined the cluster"
+#     preDrain:
+#     - name: deregister
+#       inline: curl -X DELETE https://inventory.example.com/nodes/{{ "{{ .HOSTNAME }}" }}
+#       ignoreFailure: true
+#     postUpgrade: []
+
+# The maximum number of hosts the tasks run on concurrently, useful for the
+# clusters with many static workers. Tasks that must run host by host, such
+# as joining the control plane, are still run sequentially. The --max-parallel
+# flag takes precedence. Default value is 0, i.e. all hosts at once.
+# maxParallel: 10
+
+# Retry the commands run on the hosts failing with transient errors, such as
+# dropped SSH connections or apt/yum lock contention. Commands are retried if
+# the SSH session couldn't be opened to start them, or if they fail with one
+# of the retryable errors. Other failures are retried by retrying the whole
+# task.
+# commandRetries:
+#   attempts: 3  # can be left out if using the default (3), 1 disables retries
+#   backoff: 5s  # doubled before every next retry
+#   # regular expressions matched against the error and stderr of the command,
+#   # the whole script is run again, so match only the errors failing the
+#   # script before it changes anything
+#   retryableErrors:
+#   - 'Could not get lock'
+#   - 'Unable to acquire the dpkg frontend lock'
+#   # attempts per task, tasks are named with spaces replaced by dashes
+#   taskAttempts:
+#     install-prerequisites: 10
+
+# notifications are the endpoints notified with the summary of the apply,
+# upgrade and reset operations (operation, cluster, duration, result and the
+# failed tasks) when they finish. Failing to notify doesn't fail the operation.
+# notifications:
+# - name: ops-channel
+#   type: slack  # one of: webhook (default), slack, teams
+#   url: env:SLACK_WEBHOOK_URL  # read from the environment variable
+# - type: webhook
+#   url: https://ci.example.com/kubeone-events
+#   headers:
+#     Authorization: env:CI_TOKEN
+#   onlyOnFailure: true
+
+# etcd configures the etcd members deployed on the control plane nodes.
+# The settings are left to the etcd defaults if not set. Changing the settings
+# of the existing cluster restarts the etcd members one at a time.
+# etcd:
+#   # The size limit of the etcd database in bytes, up to 8GiB.
+#   # Default value is 2GiB.
+#   quotaBackendBytes: 8589934592
+#   # The number of committed transactions after which a snapshot is taken.
+#   # Default value is 100000.
+#   snapshotCount: 100000
+#   # The interval of the leader heartbeats. Default value is 100ms.
+#   heartbeatInterval: 100ms
+#   # How long a follower waits for the heartbeat before starting the
+#   # leader election. Must be at least five times the heartbeat interval.
+#   # Default value is 1s.
+#   electionTimeout: 1s
+#   tls:
+#     # The TLS cipher suites allowed for the client and the peer connections.
+#     # Can't be used along with the FIPS feature.
+#     cipherSuites:
+#     - TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256
+#     - TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384
+#     # Additional DNS names or IP addresses of the etcd server certificates.
+#     # The certificates are regenerated when new SANs are added.
+#     serverCertSANs:
+#     - etcd.example.com
+
+# externalEtcd configures the control plane to use the etcd cluster managed
+# outside of KubeOne instead of the etcd members stacked on the control plane
+# nodes. Can't be used along with the etcd settings above and can't be changed
+# once the cluster is provisioned. The certificate files must be present on all
+# control plane nodes. The external etcd is not backed up by KubeOne.
+# externalEtcd:
+#   endpoints:
+#   - https://10.0.0.10:2379
+#   - https://10.0.0.11:2379
+#   - https://10.0.0.12:2379
+#   caFile: /etc/etcd/pki/ca.crt
+#   certFile: /etc/etcd/pki/apiserver-etcd-client.crt
+#   keyFile: /etc/etcd/pki/apiserver-etcd-client.key
+
+# controlPlaneComponents configures the Kubernetes control plane components.
+# Flags, admission plugins and feature gates configured by KubeOne take
+# precedence over the ones provided here, except explicitly disabled admission
+# plugins. Changing the settings of the existing cluster requires running
+# kubeone apply with the --force-upgrade flag.
+# controlPlaneComponents:
+#   apiServer:
+#     # Additional flags passed to the kube-apiserver, without the leading
+#     # dashes. Admission plugins and feature gates must be configured using
+#     # the dedicated fields below.
+#     extraArgs:
+#       audit-log-maxage: "30"
+#     enableAdmissionPlugins:
+#     - AlwaysPullImages
+#     disableAdmissionPlugins:
+#     - DefaultStorageClass
+#     featureGates:
+#       EphemeralContainers: true
+#   # The controller manager and the scheduler support the extraArgs and the
+#   # featureGates fields as well. extraVolumes mount the host paths, such as
+#   # the custom configuration files, into the component.
+#   controllerManager:
+#     extraArgs:
+#       node-cidr-mask-size: "25"
+#   scheduler:
+#     extraArgs:
+#       config: /etc/kubernetes/scheduler/config.yaml
+#     extraVolumes:
+#     - name: scheduler-config
+#       hostPath: /etc/kubernetes/scheduler
+#       mountPath: /etc/kubernetes/scheduler
+#       readOnly: true
+
+# kubeletConfig configures kubelet on the control plane and static worker
+# nodes. The settings can be overridden for the specific host using the
+# kubeletConfig field of the host. The settings are reapplied to the existing
+# nodes on every kubeone apply, restarting kubelet if they change.
+# kubeletConfig:
+#   maxPods: 110
+#   systemReserved:
+#     cpu: 100m
+#     memory: 500Mi
+#   kubeReserved:
+#     cpu: 200m
+#     memory: 1Gi
+#   evictionHard:
+#     memory.available: 100Mi
+#     nodefs.available: 10%
+#   # must match the cgroup driver of the container runtime
+#   cgroupDriver: systemd
 
 # The list of nodes can be overwritten by providing Terraform output.
 # You are strongly encouraged to provide an odd number of nodes and
@@ -832,9 +1467,21 @@ addons:
 #   hosts:
 #   - publicAddress: '1.2.3.4'
 #     privateAddress: '172.18.0.1'
+#     # IPv6 addresses of the host, the first one is used as the node IP of
+#     # the IPv6 family in the cluster with the IPv6 family
+#     ipv6Addresses:
+#     - 'fd00::1'
 #     bastion: '4.3.2.1'
 #     bastionPort: 22  # can be left out if using the default (22)
 #     bastionUser: 'root'  # can be left out if using the default ('root')
+#     # Chain of bastion hosts to connect through, in order, instead of the
+#     # single bastion above. Each bastion can use its own SSH key.
+#     # bastions:
+#     # - host: '4.3.2.1'
+#     #   port: 22  # can be left out if using the default (22)
+#     #   user: 'jump'  # can be left out if using the sshUsername
+#     # - host: '10.0.0.5'
+#     #   sshPrivateKeyFile: '/home/me/.ssh/id_inner'
 #     sshPort: 22 # can be left out if using the default (22)
 #     sshUsername: root
 #     # You usually want to configure either a private key OR an
@@ -842,6 +1489,8 @@ addons:
 #     # prefixed with "env:" to refer to an environment variable.
 #     sshPrivateKeyFile: '/home/me/.ssh/id_rsa'
 #     sshAgentSocket: 'env:SSH_AUTH_SOCK'
+#     # OpenSSH certificate signing the SSH key, e.g. issued by Vault or step-ca
+#     sshCertFile: '/home/me/.ssh/id_rsa-cert.pub'
 #     # Taints is used to apply taints to the node.
 #     # If not provided defaults to TaintEffectNoSchedule, with key
 #     # node-role.kubernetes.io/master for control plane nodes.
@@ -849,6 +1498,23 @@ addons:
 #     taints:
 #     - key: "node-role.kubernetes.io/master"
 #       effect: "NoSchedule"
+#     # NetworkOverrides is used on hosts with multiple network interfaces
+#     # to override the addresses used by Kubernetes components. If not
+#     # provided, privateAddress is used. Overriding etcd URLs requires
+#     # Kubernetes 1.22 or newer.
+#     networkOverrides:
+#       apiServerAdvertiseAddress: '172.19.0.1'
+#       etcdListenClientURLs:
+#       - 'https://127.0.0.1:2379'
+#       - 'https://172.19.0.1:2379'
+#       etcdAdvertiseClientURLs:
+#       - 'https://172.19.0.1:2379'
+#       etcdListenPeerURLs:
+#       - 'https://172.19.0.1:2380'
+#       kubeletNodeIP: '172.19.0.1'
+#     # KubeletConfig overrides the cluster-wide kubelet settings on the host.
+#     kubeletConfig:
+#       maxPods: 250
 
 # A list of static workers, not managed by MachineController.
 # The list of nodes can be overwritten by providing Terraform output.
@@ -871,6 +1537,27 @@ addons:
 #     # taints:
 #     # - key: ""
 #     #   effect: ""
+#     # NetworkOverrides is used on hosts with multiple network interfaces
+#     # to override the node IP reported by kubelet.
+#     # networkOverrides:
+#     #   kubeletNodeIP: '172.19.0.2'
+#     # Pool assigns the host to one of the static worker pools below.
+#     # pool: 'gpu'
+#   # Pools group static workers sharing kubelet flags, feature gates,
+#   # taints and labels. Kubelet settings and taints are applied when
+#   # the host joins the cluster, while labels are applied on every apply.
+#   # Taints of the pool are used only if the host has no taints configured.
+#   pools:
+#   - name: 'gpu'
+#     kubeletExtraArgs:
+#       max-pods: '50'
+#     featureGates:
+#       DevicePlugins: true
+#     taints:
+#     - key: 'nvidia.com/gpu'
+#       effect: 'NoSchedule'
+#     labels:
+#       accelerator: 'nvidia'
 
 # The API server can also be overwritten by Terraform. Provide the
 # external address of your load balancer or the public addresses of
@@ -885,6 +1572,12 @@ addons:
 machineController:
   deploy: {{ .DeployMachineController }}
 
+# operating-system-manager renders the provisioning configuration (user-data)
+# of the worker nodes managed by machine-controller from OperatingSystemProfiles.
+# It requires machine-controller to be deployed.
+# operatingSystemManager:
+#   deploy: false
+
 # Proxy is used to configure HTTP_PROXY, HTTPS_PROXY and NO_PROXY
 # for Docker daemon and kubelet, and to be used when provisioning cluster
 # (e.g. for curl, apt-get..).
@@ -902,6 +1595,11 @@ machineController:
 # dynamicWorkers:
 # - name: fra1-a
 #   replicas: 1
+#   # autoscaling deploys the cluster-autoscaler, which scales this
+#   # workerset between minReplicas and maxReplicas
+#   # autoscaling:
+#   #   minReplicas: 1
+#   #   maxReplicas: 3
 #   providerSpec:
 #     labels:
 #       mylabel: 'fra1-a'
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/capiexport"
+)
+
+type capiExportOpts struct {
+	Provider    string `longflag:"provider" shortflag:"p"`
+	ClusterName string `longflag:"capi-cluster-name"`
+	Namespace   string `longflag:"namespace"`
+}
+
+// configCAPIExportCmd setups the capi-export command
+func configCAPIExportCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &capiExportOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "capi-export",
+		Short: "Print the Cluster API manifest for the dynamic workers",
+		Long: heredoc.Doc(`
+			Print the Cluster API manifest for the dynamic workers defined in the API/config.
+
+			Each dynamic worker is converted into the Cluster API MachineDeployment, the KubeadmConfigTemplate with
+			the node labels, taints and SSH keys, and the MachineTemplate of the infrastructure provider. The
+			cloudProviderSpec fields which can't be converted are reported as warnings and must be reviewed before
+			applying the manifest to the Cluster API management cluster.
+			The manifest is printed on the standard output.
+		`),
+		Args: cobra.ExactArgs(0),
+		Example: heredoc.Doc(`
+			kubeone config capi-export --manifest mycluster.yaml --namespace capi-mycluster > machinedeployments.yaml
+		`),
+		RunE: func(_ *cobra.Command, args []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			return runCAPIExport(gopts, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.Provider,
+		longFlagName(opts, "Provider"),
+		shortFlagName(opts, "Provider"),
+		"",
+		fmt.Sprintf("infrastructure provider, one of: %s (default is the cluster cloud provider)",
+			strings.Join(capiexport.SupportedProviders(), ", ")))
+
+	cmd.Flags().StringVar(
+		&opts.ClusterName,
+		longFlagName(opts, "ClusterName"),
+		"",
+		"name of the Cluster API Cluster object (default is the cluster name)")
+
+	cmd.Flags().StringVar(
+		&opts.Namespace,
+		longFlagName(opts, "Namespace"),
+		"default",
+		"namespace of the Cluster API objects")
+
+	return cmd
+}
+
+// runCAPIExport prints the Cluster API manifest for the dynamic workers
+func runCAPIExport(gopts *globalOptions, opts *capiExportOpts) error {
+	s, err := gopts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	manifest, warnings, err := capiexport.Export(s.Cluster, capiexport.Options{
+		Provider:    opts.Provider,
+		ClusterName: opts.ClusterName,
+		Namespace:   opts.Namespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to export the dynamic workers")
+	}
+
+	for _, warning := range warnings {
+		s.Logger.Warnln(warning)
+	}
+
+	fmt.Print(manifest)
+
+	return nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/apis/kubeone/config"
+	"k8c.io/kubeone/pkg/clusterimport"
+	"k8c.io/kubeone/pkg/kubeconfig"
+	"k8c.io/kubeone/pkg/state"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type importOpts struct {
+	Kubeconfig        string `longflag:"kubeconfig"`
+	Host              string `longflag:"host"`
+	ClusterName       string `longflag:"cluster-name" shortflag:"n"`
+	SSHUsername       string `longflag:"ssh-username"`
+	SSHPort           int    `longflag:"ssh-port"`
+	SSHPrivateKeyFile string `longflag:"ssh-private-key-file"`
+	Bastion           string `longflag:"bastion"`
+}
+
+// configImportCmd setups the import command
+func configImportCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &importOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Generate the KubeOneCluster manifest from the live cluster",
+		Long: heredoc.Doc(`
+			Generate the best-effort KubeOneCluster manifest from the existing cluster provisioned by KubeOne.
+
+			The Kubernetes API is accessed using the kubeconfig file, or over SSH through the given control plane host.
+			Versions, hosts, cluster networking, the CNI plugin, the cloud provider and the enabled features are
+			reconstructed from the Nodes, the kubeadm configuration and the kube-system workloads. The configuration
+			that can't be reconstructed, such as credentials, is reported as warnings and must be reviewed before
+			running apply.
+			The manifest is printed on the standard output.
+		`),
+		Args: cobra.ExactArgs(0),
+		Example: heredoc.Doc(`
+			kubeone config import --kubeconfig mycluster-kubeconfig --ssh-username ubuntu > mycluster.yaml
+			kubeone config import --host 192.0.2.10 --ssh-username ubuntu --ssh-private-key-file ~/.ssh/id_rsa > mycluster.yaml
+		`),
+		RunE: func(_ *cobra.Command, args []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			return runImport(gopts, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&opts.Kubeconfig,
+		longFlagName(opts, "Kubeconfig"),
+		"",
+		"path to the admin kubeconfig file of the cluster")
+
+	cmd.Flags().StringVar(
+		&opts.Host,
+		longFlagName(opts, "Host"),
+		"",
+		"address of the control plane host to download the kubeconfig from over SSH")
+
+	cmd.Flags().StringVarP(
+		&opts.ClusterName,
+		longFlagName(opts, "ClusterName"),
+		shortFlagName(opts, "ClusterName"),
+		"",
+		"cluster name (default is the kubeadm cluster name)")
+
+	cmd.Flags().StringVar(
+		&opts.SSHUsername,
+		longFlagName(opts, "SSHUsername"),
+		"root",
+		"SSH username set on all hosts")
+
+	cmd.Flags().IntVar(
+		&opts.SSHPort,
+		longFlagName(opts, "SSHPort"),
+		22,
+		"SSH port set on all hosts")
+
+	cmd.Flags().StringVar(
+		&opts.SSHPrivateKeyFile,
+		longFlagName(opts, "SSHPrivateKeyFile"),
+		"",
+		"SSH private key file set on all hosts (the SSH agent is used if empty)")
+
+	cmd.Flags().StringVar(
+		&opts.Bastion,
+		longFlagName(opts, "Bastion"),
+		"",
+		"SSH bastion host set on all hosts")
+
+	return cmd
+}
+
+// runImport prints the KubeOneCluster manifest reconstructed from the live
+// cluster
+func runImport(gopts *globalOptions, opts *importOpts) error {
+	if (opts.Kubeconfig == "") == (opts.Host == "") {
+		return errors.New("exactly one of --kubeconfig and --host is required")
+	}
+
+	s, err := state.New(context.Background())
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+	s.Logger = gopts.newLogger()
+
+	defaults := clusterimport.HostDefaults{
+		SSHUsername:       opts.SSHUsername,
+		SSHPort:           opts.SSHPort,
+		SSHPrivateKeyFile: opts.SSHPrivateKeyFile,
+		Bastion:           opts.Bastion,
+	}
+
+	if opts.Kubeconfig != "" {
+		err = importClientFromKubeconfig(s, opts.Kubeconfig)
+	} else {
+		err = importClientOverSSH(s, opts.Host, defaults)
+	}
+	if err != nil {
+		return err
+	}
+
+	in, err := clusterimport.Collect(s.Context, s.DynamicClient)
+	if err != nil {
+		return err
+	}
+
+	cluster, warnings, err := clusterimport.Build(*in, opts.ClusterName, defaults)
+	if err != nil {
+		return errors.Wrap(err, "failed to reconstruct the manifest")
+	}
+
+	for _, warning := range warnings {
+		s.Logger.Warnln(warning)
+	}
+
+	manifest, err := config.MarshalKubeOneCluster(cluster)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the manifest")
+	}
+
+	fmt.Print(string(manifest))
+
+	return nil
+}
+
+func importClientFromKubeconfig(s *state.State, path string) error {
+	kubeconfigBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to read kubeconfig")
+	}
+
+	s.RESTConfig, err = clientcmd.RESTConfigFromKubeConfig(kubeconfigBytes)
+	if err != nil {
+		return errors.Wrap(err, "unable to build config from kubeconfig bytes")
+	}
+
+	return errors.WithStack(kubeconfig.HackIssue321InitDynamicClient(s))
+}
+
+// importClientOverSSH downloads the kubeconfig from the control plane host and
+// reaches the Kubernetes API through the SSH tunnel, the same as other
+// commands do
+func importClientOverSSH(s *state.State, host string, defaults clusterimport.HostDefaults) error {
+	s.Cluster = &kubeoneapi.KubeOneCluster{
+		ControlPlane: kubeoneapi.ControlPlaneConfig{
+			Hosts: []kubeoneapi.HostConfig{
+				{
+					PublicAddress:     host,
+					PrivateAddress:    host,
+					SSHUsername:       defaults.SSHUsername,
+					SSHPort:           defaults.SSHPort,
+					SSHPrivateKeyFile: defaults.SSHPrivateKeyFile,
+					SSHAgentSocket:    "env:SSH_AUTH_SOCK",
+					Bastion:           defaults.Bastion,
+					BastionPort:       22,
+					BastionUser:       defaults.SSHUsername,
+					IsLeader:          true,
+				},
+			},
+		},
+	}
+
+	return kubeconfig.BuildKubernetesClientset(s)
+}
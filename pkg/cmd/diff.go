@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/clusterdiff"
+	"k8c.io/kubeone/pkg/kubeconfig"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type diffOpts struct {
+	globalOptions
+	Output   string `longflag:"output" shortflag:"o"`
+	ExitCode bool   `longflag:"exit-code"`
+}
+
+// diffCmd returns the structure for declaring the "diff" subcommand.
+func diffCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &diffOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Report drift between the manifest and the live cluster",
+		Long: heredoc.Doc(`
+			Report drift between the manifest and the live cluster.
+
+			This command renders the desired state of the cluster the same way as 'kubeone apply --dry-run' and compares
+			it to the live cluster, so manual changes can be detected before the next apply overwrites them. Compared are:
+
+			  * the kubeadm ClusterConfiguration stored in the kubeadm-config ConfigMap,
+			  * the objects of the addons deployed by KubeOne and the user addons,
+			  * the Kubernetes version, the features and the components versions recorded by the last apply.
+
+			Only the fields set in the manifest are compared, fields defaulted in the cluster are not reported. The data of
+			Secrets and the CA bundles are not compared. Use '--exit-code' to fail if a drift is detected.
+		`),
+		Example: `kubeone diff -m mycluster.yaml -t terraformoutput.json -o json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runDiff(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.Output,
+		longFlagName(opts, "Output"),
+		shortFlagName(opts, "Output"),
+		clusterdiff.OutputText,
+		"format of the drift report, one of: text, json, yaml")
+
+	cmd.Flags().BoolVar(
+		&opts.ExitCode,
+		longFlagName(opts, "ExitCode"),
+		false,
+		"exit with an error if a drift is detected")
+
+	return cmd
+}
+
+// runDiff compares the manifest to the live cluster and prints the drift
+func runDiff(opts *diffOpts) error {
+	switch opts.Output {
+	case clusterdiff.OutputText, clusterdiff.OutputJSON, clusterdiff.OutputYAML:
+	default:
+		return errors.Errorf("unknown output format %q", opts.Output)
+	}
+
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	if err = tasks.WithHostnameOS(nil).Run(s); err != nil {
+		return err
+	}
+
+	if err = kubeconfig.BuildKubernetesClientset(s); err != nil {
+		return errors.Wrap(err, "failed to build kubernetes clientset")
+	}
+
+	drifts, err := tasks.Diff(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to compare the manifest to the cluster")
+	}
+
+	if err = clusterdiff.Print(os.Stdout, drifts, opts.Output); err != nil {
+		return err
+	}
+
+	if opts.ExitCode && len(drifts) > 0 {
+		return errors.Errorf("detected %d drifted fields", len(drifts))
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/archive"
+	"k8c.io/kubeone/pkg/diagnostics"
+	"k8c.io/kubeone/pkg/kubeconfig"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type doctorOpts struct {
+	globalOptions
+	Output string        `longflag:"output" shortflag:"o"`
+	Since  time.Duration `longflag:"since"`
+}
+
+// doctorCmd returns the structure for declaring the "doctor" subcommand.
+func doctorCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &doctorOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Collect the diagnostics bundle of the cluster",
+		Long: heredoc.Doc(`
+			Collect the diagnostics bundle of the cluster to be attached to the support cases.
+
+			This command connects to all hosts over SSH and collects into a .tar.gz archive:
+
+			  * the kubelet, containerd and kernel logs from journald,
+			  * the kubeadm configuration files, the kubelet configuration and the static pod manifests,
+			  * the containers, the failed systemd units and the host system information,
+			  * the conditions of the nodes and the health of the etcd members, if the cluster is reachable.
+
+			The hosts and the parts that couldn't be collected don't fail the command, they are listed in the errors.txt
+			file of the bundle. The tokens, the passwords and the secrets are redacted from the collected files, but
+			review the bundle before sharing it.
+		`),
+		Example: `kubeone doctor -m mycluster.yaml -t terraformoutput.json --since 2h`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runDoctor(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.Output,
+		longFlagName(opts, "Output"),
+		shortFlagName(opts, "Output"),
+		"",
+		"path of the bundle (default \"kubeone-diagnostics-<cluster>-<timestamp>.tar.gz\")")
+
+	cmd.Flags().DurationVar(
+		&opts.Since,
+		longFlagName(opts, "Since"),
+		24*time.Hour,
+		"collect the logs of the given period of time")
+
+	return cmd
+}
+
+// runDoctor collects the diagnostics bundle of the cluster
+func runDoctor(opts *doctorOpts) error {
+	if opts.Since <= 0 {
+		return errors.New("--since must be a positive duration")
+	}
+
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	target := opts.Output
+	if target == "" {
+		target = fmt.Sprintf("kubeone-diagnostics-%s-%s.tar.gz", s.Cluster.Name, time.Now().UTC().Format("20060102-150405"))
+	}
+
+	// the diagnostics are collected from the broken clusters as well, so
+	// the unreachable hosts and API server are not fatal
+	if err = tasks.WithHostnameOS(nil).Run(s); err != nil {
+		s.Logger.Warnf("Failed to determine the hostname and the operating system of all hosts: %v", err)
+	}
+
+	if err = kubeconfig.BuildKubernetesClientset(s); err != nil {
+		s.Logger.Warnf("The cluster is not reachable, the nodes and the etcd health are not collected: %v", err)
+	}
+
+	arch, err := archive.NewTarGzip(target)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the diagnostics bundle")
+	}
+
+	bundle := diagnostics.NewBundle(arch)
+
+	s.Logger.Info("Collecting the diagnostics...")
+	diagnostics.Collect(s, bundle, opts.Since)
+
+	errs := bundle.Errors()
+	if err = bundle.Close(); err != nil {
+		return errors.Wrap(err, "failed to write the diagnostics bundle")
+	}
+
+	for _, e := range errs {
+		s.Logger.Warnf("Not collected %s", e)
+	}
+
+	s.Logger.Infof("Diagnostics bundle saved to %s", target)
+
+	return nil
+}
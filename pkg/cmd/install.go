@@ -47,7 +47,7 @@ func (opts *installOpts) BuildState() (*state.State, error) {
 	s.ForceInstall = opts.Force
 	s.BackupFile = opts.BackupFile
 	if s.BackupFile == "" {
-		fullPath, _ := filepath.Abs(opts.ManifestFile)
+		fullPath, _ := filepath.Abs(s.ManifestFilePath)
 		clusterName := s.Cluster.Name
 		s.BackupFile = filepath.Join(filepath.Dir(fullPath), fmt.Sprintf("%s.tar.gz", clusterName))
 	}
@@ -131,6 +131,12 @@ func runInstall(opts *installOpts) error {
 		return errors.Wrap(err, "failed to validate credentials")
 	}
 
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
 	if opts.NoInit {
 		return errors.Wrap(tasks.WithBinariesOnly(nil).Run(s), "failed to install kubernetes binaries")
 	}
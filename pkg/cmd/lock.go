@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/clusterlock"
+	"k8c.io/kubeone/pkg/kubeconfig"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/statebackend"
+)
+
+// lockCluster acquires the lock on the cluster, so concurrent KubeOne runs
+// against the same cluster fail fast. The lock is stored in the state backend
+// if one is configured, so the cluster is locked even if its Kubernetes API
+// is not reachable. Otherwise, and with the Kubernetes Secret backend, the
+// lock is the Lease object in the cluster. Clusters whose Kubernetes API is
+// not reachable, such as the clusters that are not provisioned yet, can't
+// hold the Lease, so they are not locked without the state backend. With
+// --force-unlock, the lock held by another run is removed first.
+//
+// The State context is replaced with the one cancelled when the lock is lost,
+// so the running tasks abort. The returned function releases the lock.
+func lockCluster(s *state.State) (func(), error) {
+	identity := clusterlock.Identity()
+
+	var locker clusterlock.Locker
+	if s.Cluster.StateBackend != nil && s.Cluster.StateBackend.KubernetesSecret == nil {
+		backend, err := statebackend.New(s.Cluster.StateBackend, s.Cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+		conditional, ok := backend.(statebackend.ConditionalBackend)
+		if !ok {
+			return nil, errors.New("the state backend doesn't support the conditional writes required by the cluster lock")
+		}
+		locker = clusterlock.NewBackendLocker(conditional, identity)
+	} else {
+		if s.DynamicClient == nil {
+			if err := kubeconfig.BuildKubernetesClientset(s); err != nil {
+				s.Logger.Warnf("Not locking the cluster, the Kubernetes API is not reachable and no state backend is configured: %v", err)
+				return func() {}, nil
+			}
+		}
+		locker = clusterlock.NewLeaseLocker(s.DynamicClient, identity)
+	}
+
+	if s.ForceUnlock {
+		s.Logger.Warn("Removing the cluster lock as requested by --force-unlock...")
+		if err := locker.Break(s.Context); err != nil {
+			return nil, err
+		}
+	}
+
+	s.Logger.Infof("Acquiring the cluster lock as %s...", identity)
+
+	ctx, release, err := clusterlock.Hold(s.Context, locker, s.Logger.Warnf)
+	if err != nil {
+		return nil, err
+	}
+	s.Context = ctx
+
+	return release, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/metrics"
+	"k8c.io/kubeone/pkg/state"
+)
+
+const (
+	metricsPath = "/metrics"
+
+	// metricsJob is the job the metrics are pushed to the Pushgateway as
+	metricsJob = "kubeone"
+
+	// metricsPushTimeout is how long pushing the metrics can take
+	metricsPushTimeout = 30 * time.Second
+)
+
+// serveMetrics serves the Prometheus metrics on the given address in the
+// background. The returned function stops the server.
+func serveMetrics(address string, logger logrus.FieldLogger) (func(), error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on %s for metrics", address)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, metrics.DefaultRegistry)
+
+	srv := &http.Server{Handler: mux}
+
+	go func() {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorf("Metrics server failed: %v", err)
+		}
+	}()
+
+	logger.Infof("Serving metrics on %s%s...", listener.Addr(), metricsPath)
+
+	return func() {
+		_ = srv.Shutdown(context.Background())
+	}, nil
+}
+
+// observeNodes records the number of nodes of the cluster in the metrics
+func observeNodes(s *state.State) {
+	metrics.SetNodes(s.Cluster.Name, metrics.RoleControlPlane, len(s.Cluster.ControlPlane.Hosts))
+	metrics.SetNodes(s.Cluster.Name, metrics.RoleStaticWorker, len(s.Cluster.StaticWorkers.Hosts))
+}
+
+// observeUpgrade runs the upgrade, recording its duration and result in
+// the metrics
+func observeUpgrade(s *state.State, upgrade func() error) error {
+	start := time.Now()
+	err := upgrade()
+	metrics.ObserveUpgrade(s.Cluster.Name, time.Since(start), err)
+
+	return err
+}
+
+// publishMetrics pushes the metrics collected by the command to the
+// Pushgateway and writes them to the OpenMetrics file, if requested by the
+// global flags
+func publishMetrics(fs *pflag.FlagSet) error {
+	opts := &globalOptions{}
+
+	file, err := fs.GetString(longFlagName(opts, "MetricsFile"))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if file != "" {
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open %q", file)
+		}
+
+		err = metrics.DefaultRegistry.WriteOpenMetrics(f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to write metrics to %q", file)
+		}
+	}
+
+	gateway, err := fs.GetString(longFlagName(opts, "MetricsPush"))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if gateway != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), metricsPushTimeout)
+		defer cancel()
+
+		return metrics.DefaultRegistry.Push(ctx, gateway, metricsJob)
+	}
+
+	return nil
+}
@@ -35,6 +35,7 @@ func migrateCmd(fs *pflag.FlagSet) *cobra.Command {
 
 	cmd.AddCommand(migrateToContainerdCmd(fs))
 	cmd.AddCommand(migrateToCCMCSICmd(fs))
+	cmd.AddCommand(migrateStaticWorkerCmd(fs))
 	return cmd
 }
 
@@ -64,6 +65,12 @@ func runMigrateToContainerd(opts *globalOptions) error {
 		return errors.Wrap(err, "failed to initialize State")
 	}
 
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
 	return errors.Wrap(tasks.WithContainerDMigration(nil).Run(s), "failed to get cluster status")
 }
 
@@ -99,7 +106,7 @@ func migrateToCCMCSICmd(fs *pflag.FlagSet) *cobra.Command {
 			Note: if your cluster was created with .cloudProvider.external enabled, the CCM/CSI migration is not needed
 			because the cluster is already using external CCM.
 
-			Migration is currently available for OpenStack and vSphere. Other providers will be added in future KubeOne releases.
+			Migration is currently available for AWS, Azure, OpenStack and vSphere. Other providers will be added in future KubeOne releases.
 			Note: vSphere support is currently experimental!
 
 			The migration is done in two phases:
@@ -160,6 +167,12 @@ func runMigrateToCCMCSI(opts *migrateCCMOptions) error {
 		return errors.Wrap(err, "failed to validate credentials")
 	}
 
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
 	// Probe the cluster for the actual state and the needed tasks.
 	probbing := tasks.WithHostnameOS(nil)
 	probbing = tasks.WithProbes(probbing)
@@ -191,3 +204,96 @@ func runMigrateToCCMCSI(opts *migrateCCMOptions) error {
 
 	return errors.Wrap(tasks.WithCCMCSIMigration(nil).Run(s), "failed to migrate to ccm/csi")
 }
+
+type migrateStaticWorkerOptions struct {
+	globalOptions
+	AutoApprove       bool   `longflag:"auto-approve" shortflag:"y"`
+	Host              string `longflag:"host"`
+	MachineDeployment string `longflag:"machinedeployment"`
+}
+
+func migrateStaticWorkerCmd(fs *pflag.FlagSet) *cobra.Command {
+	opts := &migrateStaticWorkerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "static-worker",
+		Short: "Migrate the static worker to the worker nodes managed by machine-controller",
+		Long: heredoc.Doc(`
+			Drain the static worker node, reset it and remove it from the cluster, so its workload is moved to the
+			worker nodes managed by machine-controller.
+
+			If the --machinedeployment flag is given, the MachineDeployment of the dynamic workerset with that name
+			is created from the KubeOne manifest and rolled out before the static worker is drained.
+
+			Once the static worker is removed, it must be removed from the .staticWorkers.hosts in the KubeOne manifest
+			as well, otherwise it's provisioned again by the next "kubeone apply".
+		`),
+		Example: `kubeone migrate static-worker -m mycluster.yaml -t terraformoutput.json --host 192.168.1.10 --machinedeployment pool1`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(fs)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runMigrateStaticWorker(opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&opts.AutoApprove,
+		longFlagName(opts, "AutoApprove"),
+		shortFlagName(opts, "AutoApprove"),
+		false,
+		"auto approve plan")
+
+	cmd.Flags().StringVar(
+		&opts.Host,
+		longFlagName(opts, "Host"),
+		"",
+		"public address, private address or hostname of the static worker to migrate")
+
+	cmd.Flags().StringVar(
+		&opts.MachineDeployment,
+		longFlagName(opts, "MachineDeployment"),
+		"",
+		"name of the dynamic workerset to create the MachineDeployment for")
+
+	return cmd
+}
+
+func runMigrateStaticWorker(opts *migrateStaticWorkerOptions) error {
+	if opts.Host == "" {
+		return errors.New("the static worker must be given using the --host flag")
+	}
+
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	if !s.Cluster.MachineController.Deploy {
+		return errors.New("machine-controller is not deployed, the static worker can't be migrated")
+	}
+
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
+	s.Logger.Warnf("This command will drain the static worker %s and remove it from the cluster.", opts.Host)
+
+	confirm, err := confirmCommand(opts.AutoApprove)
+	if err != nil {
+		return err
+	}
+
+	if !confirm {
+		s.Logger.Println("Operation canceled.")
+		return nil
+	}
+
+	return errors.Wrap(tasks.WithStaticWorkerMigration(nil, opts.Host, opts.MachineDeployment).Run(s), "failed to migrate the static worker")
+}
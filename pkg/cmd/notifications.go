@@ -0,0 +1,40 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"k8c.io/kubeone/pkg/notifications"
+	"k8c.io/kubeone/pkg/state"
+)
+
+// notify runs the operation and sends its summary to the notifications
+// configured in the manifest and given with the --notify-* flags. The
+// notifications are sent even if the State context is canceled.
+func notify(s *state.State, operation string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+
+	if len(s.Cluster.Notifications) > 0 {
+		summary := notifications.NewSummary(operation, s.Cluster.Name, start, err, s.FailedTasks)
+		notifications.Send(context.Background(), s.Logger, s.Cluster.Notifications, summary)
+	}
+
+	return err
+}
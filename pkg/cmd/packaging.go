@@ -26,15 +26,19 @@ import (
 
 func completionCmd(rootCmd *cobra.Command) *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:   "completion <bash|zsh>",
-		Short: "Generates completion scripts for bash and zsh",
+		Use:   "completion <bash|zsh|fish|powershell>",
+		Short: "Generates completion scripts for bash, zsh, fish and powershell",
 		Long: heredoc.Doc(`
 			To load completion run into your current shell run
 
 			. <(kubeone completion <shell>)
+
+			Besides commands and flags, the completion suggests the cluster names, host
+			addresses, addon names and task names sourced from the KubeOne config given
+			with the --manifest flag and from the KubeOne binary.
 		`),
 		Example:   "kubeone completion bash",
-		ValidArgs: []string{"bash", "zsh"},
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
 		Args:      cobra.ExactValidArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			switch args[0] {
@@ -42,6 +46,10 @@ func completionCmd(rootCmd *cobra.Command) *cobra.Command {
 				err = rootCmd.GenBashCompletion(os.Stdout)
 			case "zsh":
 				err = rootCmd.GenZshCompletion(os.Stdout)
+			case "fish":
+				err = rootCmd.GenFishCompletion(os.Stdout, true)
+			case "powershell":
+				err = rootCmd.GenPowerShellCompletion(os.Stdout)
 			}
 			return
 		},
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/preflight"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type preflightOpts struct {
+	globalOptions
+	Output string `longflag:"output" shortflag:"o"`
+}
+
+// preflightCmd returns the structure for declaring the "preflight" subcommand.
+func preflightCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &preflightOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "preflight",
+		Short: "Check the hosts meet the requirements",
+		Long: heredoc.Doc(`
+			Check the control plane and static worker hosts meet the requirements of provisioning them.
+
+			The SSH connection, passwordless sudo, CPUs, memory, free disk space in /var/lib, time synchronization,
+			DNS resolution of the API endpoint, kernel modules and the ports used by Kubernetes are checked on every host.
+			The ports are not checked on the hosts which are already provisioned. The same checks are run by
+			'kubeone apply' before provisioning or upgrading the hosts, unless the '--skip-preflight-checks' flag is used.
+
+			The command fails if any check with the error severity fails.
+		`),
+		Example: `kubeone preflight -m mycluster.yaml -t terraformoutput.json -o json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runPreflight(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.Output,
+		longFlagName(opts, "Output"),
+		shortFlagName(opts, "Output"),
+		preflight.OutputText,
+		"format of the report, one of: text, json")
+
+	return cmd
+}
+
+// runPreflight runs the host preflight checks and prints the report
+func runPreflight(opts *preflightOpts) error {
+	if opts.Output != preflight.OutputText && opts.Output != preflight.OutputJSON {
+		return errors.Errorf("unknown output format %q", opts.Output)
+	}
+
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	report := tasks.HostPreflight(s)
+	if err = report.Print(os.Stdout, opts.Output); err != nil {
+		return err
+	}
+
+	if !report.Passed {
+		return errors.New("preflight checks failed")
+	}
+
+	return nil
+}
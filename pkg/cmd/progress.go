@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/statebackend"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+// initProgress sets up recording the progress of the apply. When resuming,
+// the progress of the interrupted apply is loaded, so the resumable tasks it
+// completed are skipped.
+func initProgress(s *state.State, resume bool) error {
+	store, err := progressStore(s)
+	if err != nil {
+		return err
+	}
+
+	if !resume {
+		s.Progress = state.NewProgress(store, s.ManifestHash)
+		return nil
+	}
+
+	progress, err := state.LoadProgress(store)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the progress of the interrupted apply")
+	}
+
+	switch {
+	case progress == nil:
+		s.Logger.Warnln("No interrupted apply found, running all tasks...")
+		progress = state.NewProgress(store, s.ManifestHash)
+	case progress.ManifestHash != s.ManifestHash:
+		return errors.New("the manifest has changed since the interrupted apply, run apply without --resume")
+	}
+
+	s.Progress = progress
+
+	return nil
+}
+
+// progressStore returns the store of the apply progress. The progress is
+// stored in the state backend if one is configured, otherwise in the file
+// next to the manifest. The Kubernetes Secret backend is not used, as the
+// progress must be recorded before the cluster is provisioned.
+func progressStore(s *state.State) (state.ProgressStore, error) {
+	if s.Cluster.StateBackend != nil && s.Cluster.StateBackend.KubernetesSecret == nil {
+		backend, err := statebackend.New(s.Cluster.StateBackend, s.Cluster.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		return &backendProgressStore{ctx: s.Context, backend: backend}, nil
+	}
+
+	fullPath, _ := filepath.Abs(s.ManifestFilePath)
+
+	return state.NewFileProgressStore(filepath.Join(filepath.Dir(fullPath), fmt.Sprintf("%s.progress.json", s.Cluster.Name))), nil
+}
+
+// runResumable runs the tasks and clears the recorded progress once all of
+// them succeed, so the next apply starts from scratch
+func runResumable(s *state.State, tasksToRun tasks.Tasks) error {
+	if err := tasksToRun.Run(s); err != nil {
+		if s.Progress != nil {
+			s.Logger.Warnln("The apply can be resumed with the --resume flag, skipping the completed tasks.")
+		}
+
+		return err
+	}
+
+	if err := s.Progress.Clear(); err != nil {
+		s.Logger.Warnf("Failed to clear the progress of the apply: %v", err)
+	}
+
+	return nil
+}
+
+type backendProgressStore struct {
+	ctx     context.Context
+	backend statebackend.Backend
+}
+
+func (b *backendProgressStore) Get() ([]byte, error) {
+	data, err := b.backend.Get(b.ctx, statebackend.ProgressKey)
+	if errors.Is(err, statebackend.ErrNotFound) {
+		return nil, nil
+	}
+
+	return data, err
+}
+
+func (b *backendProgressStore) Put(data []byte) error {
+	return b.backend.Put(b.ctx, statebackend.ProgressKey, data)
+}
+
+func (b *backendProgressStore) Delete() error {
+	return b.backend.Delete(b.ctx, statebackend.ProgressKey)
+}
@@ -24,7 +24,9 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"k8c.io/kubeone/pkg/hooks"
 	"k8c.io/kubeone/pkg/kubeconfig"
+	"k8c.io/kubeone/pkg/notifications"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/tasks"
 
@@ -149,5 +151,15 @@ func runReset(opts *resetOpts) error {
 		return nil
 	}
 
-	return errors.Wrap(tasks.WithReset(nil).Run(s), "failed to reset the cluster")
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
+	return notify(s, notifications.OperationReset, func() error {
+		return tasks.WithHooks(s, hooks.PreReset, hooks.PostReset, func() error {
+			return errors.Wrap(tasks.WithReset(nil).Run(s), "failed to reset the cluster")
+		})
+	})
 }
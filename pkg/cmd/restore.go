@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type restoreEtcdOpts struct {
+	globalOptions
+	AutoApprove bool   `longflag:"auto-approve" shortflag:"y"`
+	Snapshot    string `longflag:"snapshot"`
+}
+
+func restoreCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the cluster from a backup",
+	}
+
+	cmd.AddCommand(restoreEtcdCmd(rootFlags))
+
+	return cmd
+}
+
+func restoreEtcdCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &restoreEtcdOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "etcd",
+		Short: "Restore etcd from the snapshot",
+		Long: heredoc.Doc(`
+			Restore etcd from the snapshot taken by 'kubeone backup etcd'.
+
+			The control plane is stopped on all control plane nodes, the snapshot is restored on the leader as a single member
+			etcd cluster, and the remaining control plane nodes rejoin it one by one as new etcd members. The replaced etcd
+			data is kept in the /var/lib/etcd-backup-<timestamp> directory on every control plane node.
+
+			The etcd container must be running on the leader, even if the etcd cluster lost the quorum, as the snapshot is
+			restored using its etcdctl.
+		`),
+		Example: `kubeone restore etcd -m mycluster.yaml -t terraformoutput.json --snapshot ./mycluster-etcd-20211201-120000.db`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runRestoreEtcd(opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&opts.AutoApprove,
+		longFlagName(opts, "AutoApprove"),
+		shortFlagName(opts, "AutoApprove"),
+		false,
+		"auto approve restore")
+
+	cmd.Flags().StringVar(
+		&opts.Snapshot,
+		longFlagName(opts, "Snapshot"),
+		"",
+		"path to the etcd snapshot")
+
+	return cmd
+}
+
+func runRestoreEtcd(opts *restoreEtcdOpts) error {
+	if opts.Snapshot == "" {
+		return errors.New("the etcd snapshot must be specified using the --snapshot flag")
+	}
+	if _, err := os.Stat(opts.Snapshot); err != nil {
+		return errors.Wrap(err, "failed to find etcd snapshot")
+	}
+
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	if !s.Cluster.LocalEtcd() {
+		return errors.New("the external etcd is managed outside of KubeOne")
+	}
+
+	s.Logger.Warnln("This command will REPLACE the etcd data of the cluster running on the following nodes:")
+	for _, node := range s.Cluster.ControlPlane.Hosts {
+		fmt.Printf("\t- control plane node %q (%s)\n", node.Hostname, node.PrivateAddress)
+	}
+	fmt.Printf("\nThe cluster will be restored to the state from the snapshot %s.\n", opts.Snapshot)
+	fmt.Printf("The Kubernetes API will be unavailable until the restore is complete.\n")
+
+	confirm, err := confirmCommand(opts.AutoApprove)
+	if err != nil {
+		return err
+	}
+
+	if !confirm {
+		s.Logger.Println("Operation canceled.")
+
+		return nil
+	}
+
+	// the Lease lock is stored in etcd, which is replaced by the restore, so
+	// the cluster is locked only if the state backend is configured
+	if s.Cluster.StateBackend != nil {
+		release, lerr := lockCluster(s)
+		if lerr != nil {
+			return errors.Wrap(lerr, "failed to lock the cluster")
+		}
+		defer release()
+	}
+
+	return errors.Wrap(tasks.WithEtcdRestore(nil, opts.Snapshot).Run(s), "failed to restore etcd")
+}
@@ -24,6 +24,8 @@ import (
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 
+	"k8c.io/kubeone/pkg/state"
+
 	apiextensionsscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
 	"k8s.io/client-go/kubernetes/scheme"
 	apiregscheme "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset/scheme"
@@ -49,12 +51,22 @@ func Execute() {
 
 	rootCmd := newRoot()
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if perr := publishMetrics(rootCmd.PersistentFlags()); perr != nil {
+		fmt.Fprintf(os.Stderr, "Failed to publish metrics: %v\n", perr)
+	}
+
+	if err != nil {
 		debug, _ := rootCmd.PersistentFlags().GetBool(longFlagName(&globalOptions{}, "Debug"))
+		logFormat, _ := rootCmd.PersistentFlags().GetString(longFlagName(&globalOptions{}, "LogFormat"))
 
-		if debug {
+		switch {
+		case debug:
 			fmt.Printf("%+v\n", err)
-		} else {
+		case logFormat == logFormatJSON:
+			opts := &globalOptions{LogFormat: logFormatJSON}
+			opts.newLogger().WithField("error_category", state.ErrorCategory(err)).Error(err)
+		default:
 			fmt.Println(err)
 		}
 
@@ -77,11 +89,27 @@ func newRoot() *cobra.Command {
 
 	fs := rootCmd.PersistentFlags()
 
-	fs.StringVarP(&opts.ManifestFile,
-		longFlagName(opts, "ManifestFile"),
-		shortFlagName(opts, "ManifestFile"),
-		"./kubeone.yaml",
-		"Path to the KubeOne config")
+	fs.StringArrayVarP(&opts.ManifestFiles,
+		longFlagName(opts, "ManifestFiles"),
+		shortFlagName(opts, "ManifestFiles"),
+		[]string{"./kubeone.yaml"},
+		"Path to the KubeOne config. It can be a multi-document file or a directory containing manifests of multiple clusters. "+
+			"Can be repeated to deep-merge overlays on top of the base config, later files taking precedence")
+
+	fs.StringVar(&opts.Cluster,
+		longFlagName(opts, "Cluster"),
+		"",
+		"Name of the cluster to select if the KubeOne config contains multiple clusters")
+
+	fs.StringArrayVar(&opts.Set,
+		longFlagName(opts, "Set"),
+		nil,
+		"Set a variable referenced as ${NAME} in the KubeOne config (can be repeated, e.g. --set NAME=value). The KubeOne config is read as it is unless --set or --env is used")
+
+	fs.BoolVar(&opts.Env,
+		longFlagName(opts, "Env"),
+		false,
+		"Substitute the variables referenced as ${NAME} in the KubeOne config and not given with --set from the environment")
 
 	fs.StringVarP(&opts.TerraformState,
 		longFlagName(opts, "TerraformState"),
@@ -107,20 +135,87 @@ func newRoot() *cobra.Command {
 		false,
 		"debug output with stacktrace")
 
+	fs.StringVar(&opts.LogFormat,
+		longFlagName(opts, "LogFormat"),
+		logFormatText,
+		"Format of the log output, one of: text, json. The json format also logs the structured events of every task and node task")
+
+	fs.IntVar(&opts.MaxParallel,
+		longFlagName(opts, "MaxParallel"),
+		0,
+		"Maximum number of hosts to run the tasks on concurrently, overriding .maxParallel from the KubeOne config. "+
+			"Tasks that must run host by host, such as joining the control plane, are still run sequentially. 0 means no limit")
+
+	fs.StringVar(&opts.MetricsPush,
+		longFlagName(opts, "MetricsPush"),
+		"",
+		"URL of the Prometheus Pushgateway to push the metrics of the run to when the command finishes, "+
+			"such as task durations and results, upgrade durations and node counts. The metrics of every cluster are pushed to the kubeone job, grouped by the cluster label")
+
+	fs.StringVar(&opts.MetricsFile,
+		longFlagName(opts, "MetricsFile"),
+		"",
+		"Path to write the metrics of the run to in the OpenMetrics format when the command finishes")
+
+	fs.StringArrayVar(&opts.NotifyWebhooks,
+		longFlagName(opts, "NotifyWebhooks"),
+		nil,
+		"URL to POST the JSON summary of the apply, upgrade or reset to when it finishes, in addition to .notifications from the KubeOne config (can be repeated)")
+
+	fs.StringArrayVar(&opts.NotifySlack,
+		longFlagName(opts, "NotifySlack"),
+		nil,
+		"Slack incoming webhook URL to send the summary of the apply, upgrade or reset to when it finishes (can be repeated)")
+
+	fs.StringArrayVar(&opts.NotifyTeams,
+		longFlagName(opts, "NotifyTeams"),
+		nil,
+		"Microsoft Teams incoming webhook URL to send the summary of the apply, upgrade or reset to when it finishes (can be repeated)")
+
+	fs.BoolVar(&opts.ForceUnlock,
+		longFlagName(opts, "ForceUnlock"),
+		false,
+		"Remove the cluster lock held by another run before acquiring it. Use only if the other run is known to be gone, "+
+			"as concurrent runs can corrupt the cluster")
+
+	fs.BoolVar(&opts.AskSSHPassword,
+		longFlagName(opts, "AskSSHPassword"),
+		false,
+		"Prompt for the SSH password used to authenticate to all hosts. If not prompted, the password is read from the "+sshPasswordEnv+" environment variable")
+
+	fs.BoolVar(&opts.AskSudoPassword,
+		longFlagName(opts, "AskSudoPassword"),
+		false,
+		"Prompt for the sudo password used on all hosts where sudo requires a password. If not prompted, the password is read from the "+sudoPasswordEnv+" environment variable")
+
 	rootCmd.AddCommand(
 		installCmd(fs),
 		applyCmd(fs),
 		upgradeCmd(fs),
+		adoptCmd(fs),
 		resetCmd(fs),
+		rotateEncryptionKeyCmd(fs),
+		rotateCACmd(fs),
 		kubeconfigCmd(fs),
 		configCmd(fs),
 		versionCmd(),
 		statusCmd(fs),
+		diffCmd(fs),
+		doctorCmd(fs),
+		certsCmd(fs),
+		preflightCmd(fs),
+		stateCmd(fs),
+		backupCmd(fs),
+		restoreCmd(fs),
 		proxyCmd(fs),
 		migrateCmd(fs),
+		workersCmd(fs),
+		webhookCmd(fs),
 		completionCmd(rootCmd),
 		documentCmd(rootCmd),
 	)
 
+	registerDynamicCompletions(rootCmd, fs)
+
 	return rootCmd
 }
@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/certificate/carotation"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type rotateCAOpts struct {
+	globalOptions
+	AutoApprove bool `longflag:"auto-approve" shortflag:"y"`
+	AllPhases   bool `longflag:"all-phases"`
+}
+
+// rotateCACmd setups rotate-ca command
+func rotateCACmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &rotateCAOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "rotate-ca",
+		Short: "Rotate the Kubernetes CA",
+		Long: heredoc.Doc(`
+			Replace the Kubernetes CA with a newly generated one, so the old and the new certificates are trusted by all
+			components at any time.
+
+			The etcd and the front-proxy CAs are not rotated. They are separate from the Kubernetes CA and are kept only
+			on the control plane nodes, so rotating the Kubernetes CA doesn't depend on them. If any of them is
+			compromised, rotate it manually following the kubeadm documentation on the manual certificate rotation.
+
+			The rotation is done in the following phases, one phase per run of this command unless '--all-phases' is used:
+
+			  * trust: distribute the bundle of the old and the new CA to all nodes and kubeconfig files, restart the
+			    control plane components and kubelets, restart the kube-system Deployments and DaemonSets once the new
+			    CA is published to the pods, and replace the Machines of all MachineDeployments, so they trust the bundle
+			    before any certificate is signed by the new CA
+			  * reissue: make the new CA sign the certificates, reissue the control plane certificates and the kubelet
+			    client certificates, and replace the Machines of all MachineDeployments
+			  * finalize: remove the old CA from all nodes and kubeconfig files
+
+			Between the trust and the reissue phases, restart the workloads talking to the API server in the other
+			namespaces, as the Kubernetes clients load the CA only on startup. The progress is kept on the control plane
+			nodes, so an interrupted phase is resumed by running this command again. After the rotation is finalized, run
+			'kubeone apply' to reissue the certificates KubeOne signs with the CA for the addons.
+		`),
+		Example: `kubeone rotate-ca -m mycluster.yaml -t terraformoutput.json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runRotateCA(opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&opts.AutoApprove,
+		longFlagName(opts, "AutoApprove"),
+		shortFlagName(opts, "AutoApprove"),
+		false,
+		"auto approve plan")
+
+	cmd.Flags().BoolVar(
+		&opts.AllPhases,
+		longFlagName(opts, "AllPhases"),
+		false,
+		"run all remaining phases of the rotation instead of the next one")
+
+	return cmd
+}
+
+func runRotateCA(opts *rotateCAOpts) error {
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
+	// Probe the cluster for the actual state and the needed tasks.
+	probbing := tasks.WithHostnameOS(nil)
+	probbing = tasks.WithProbes(probbing)
+
+	if err = probbing.Run(s); err != nil {
+		return err
+	}
+
+	if !s.LiveCluster.IsProvisioned() {
+		return errors.New("the target cluster is not provisioned")
+	}
+	if !s.LiveCluster.Healthy() {
+		return errors.New("the target cluster is not healthy, please run 'kubeone apply' first")
+	}
+
+	phases, err := tasks.CARotationPhases(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to detect the CA rotation phase")
+	}
+
+	if len(phases) == 0 {
+		return errors.New("the CA rotation is already finalized")
+	}
+
+	var remaining []carotation.Phase
+	if !opts.AllPhases {
+		phases, remaining = phases[:1], phases[1:]
+	}
+
+	tasksToRun := tasks.WithCARotation(nil, phases)
+
+	fmt.Printf("The following phases of the CA rotation will be run: %v\n", phases)
+	fmt.Println("The following actions will be taken: ")
+	fmt.Println("Run with --verbose flag for more information.")
+
+	for _, op := range tasksToRun.Descriptions(s) {
+		fmt.Printf("\t~ %s\n", op)
+	}
+
+	fmt.Println()
+
+	confirm, err := confirmCommand(opts.AutoApprove)
+	if err != nil {
+		return err
+	}
+
+	if !confirm {
+		s.Logger.Println("Operation canceled.")
+		return nil
+	}
+
+	if err = tasksToRun.Run(s); err != nil {
+		return errors.Wrap(err, "failed to rotate the CA")
+	}
+
+	if len(remaining) > 0 {
+		s.Logger.Infof("Run 'kubeone rotate-ca' again to run the %s phase", remaining[0])
+		return nil
+	}
+
+	s.Logger.Info("The CA rotation is finalized, run 'kubeone apply' to reissue the addons certificates")
+
+	return nil
+}
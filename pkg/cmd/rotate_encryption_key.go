@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/tasks"
+	"k8c.io/kubeone/pkg/templates/encryptionproviders"
+)
+
+type rotateEncryptionKeyOpts struct {
+	globalOptions
+	AutoApprove bool   `longflag:"auto-approve" shortflag:"y"`
+	KeyProvider string `longflag:"key-provider"`
+}
+
+// rotateEncryptionKeyCmd setups rotate-encryption-key command
+func rotateEncryptionKeyCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &rotateEncryptionKeyOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "rotate-encryption-key",
+		Short: "Rotate the key used to encrypt the Secrets",
+		Long: heredoc.Doc(`
+			Generate a new encryption key and re-encrypt all Secrets with it.
+
+			The Encryption Providers feature must be enabled and use the configuration generated by KubeOne.
+			Keys of the custom encryption configuration and the KMS provider must be rotated by the user.
+
+			The rotation is done in the following steps, restarting kube-apiserver on all control plane nodes
+			after each of them, so all kube-apiservers can decrypt the Secrets at any time:
+
+			  * add the new key to the EncryptionConfiguration as the decryption key
+			  * use the new key as the encryption key and rewrite all Secrets
+			  * remove the old key from the EncryptionConfiguration
+
+			The old keys are kept until all Secrets are rewritten, so the interrupted rotation can be
+			completed by running the command again, which rotates to another new key.
+		`),
+		Example: `kubeone rotate-encryption-key -m mycluster.yaml -t terraformoutput.json`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runRotateEncryptionKey(opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(
+		&opts.AutoApprove,
+		longFlagName(opts, "AutoApprove"),
+		shortFlagName(opts, "AutoApprove"),
+		false,
+		"auto approve plan")
+
+	cmd.Flags().StringVar(
+		&opts.KeyProvider,
+		longFlagName(opts, "KeyProvider"),
+		"",
+		fmt.Sprintf("key provider of the new key, %q or %q (defaults to the key provider currently used)",
+			encryptionproviders.KeyProviderAESCBC, encryptionproviders.KeyProviderSecretbox))
+
+	return cmd
+}
+
+func runRotateEncryptionKey(opts *rotateEncryptionKeyOpts) error {
+	switch opts.KeyProvider {
+	case "", encryptionproviders.KeyProviderAESCBC, encryptionproviders.KeyProviderSecretbox:
+	default:
+		return errors.Errorf("unsupported key provider %q", opts.KeyProvider)
+	}
+
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	if err = validateRotateEncryptionKey(s, opts.KeyProvider); err != nil {
+		return err
+	}
+
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
+	// Probe the cluster for the actual state and the needed tasks.
+	probbing := tasks.WithHostnameOS(nil)
+	probbing = tasks.WithProbes(probbing)
+
+	if err = probbing.Run(s); err != nil {
+		return err
+	}
+
+	if !s.LiveCluster.IsProvisioned() {
+		return errors.New("the target cluster is not provisioned")
+	}
+	if !s.LiveCluster.Healthy() {
+		return errors.New("the target cluster is not healthy, please run 'kubeone apply' first")
+	}
+	if !s.EncryptionEnabled() {
+		return errors.New("Encryption Providers support is not enabled for this cluster")
+	}
+	if s.LiveCluster.CustomEncryptionEnabled() {
+		return errors.New("key rotation of custom providers file is not supported")
+	}
+
+	tasksToRun := tasks.WithRotateKey(nil, opts.KeyProvider)
+
+	fmt.Println("The following actions will be taken: ")
+	fmt.Println("Run with --verbose flag for more information.")
+
+	for _, op := range tasksToRun.Descriptions(s) {
+		fmt.Printf("\t~ %s\n", op)
+	}
+
+	fmt.Println()
+
+	confirm, err := confirmCommand(opts.AutoApprove)
+	if err != nil {
+		return err
+	}
+
+	if !confirm {
+		s.Logger.Println("Operation canceled.")
+		return nil
+	}
+
+	return errors.Wrap(tasksToRun.Run(s), "failed to rotate the encryption key")
+}
+
+// validateRotateEncryptionKey validates the manifest before connecting to the
+// cluster, so the rotation is rejected early
+func validateRotateEncryptionKey(s *state.State, keyProvider string) error {
+	ep := s.Cluster.Features.EncryptionProviders
+	if ep == nil || !ep.Enable {
+		return errors.New("Encryption Providers support is not enabled in the manifest")
+	}
+	if ep.KMS != nil {
+		return errors.New("key rotation of KMS provider is managed by the KMS")
+	}
+	if ep.CustomEncryptionConfiguration != "" {
+		return errors.New("key rotation of custom providers file is not supported")
+	}
+	if keyProvider == encryptionproviders.KeyProviderSecretbox &&
+		s.Cluster.Features.FIPS != nil && s.Cluster.Features.FIPS.Enable {
+		return errors.New("secretbox encryption provider is not FIPS-compliant and can't be used when .features.fips is enabled")
+	}
+
+	return nil
+}
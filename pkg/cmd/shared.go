@@ -23,6 +23,8 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -31,39 +33,144 @@ import (
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/apis/kubeone/config"
+	"k8c.io/kubeone/pkg/apis/kubeone/validation"
+	"k8c.io/kubeone/pkg/clusterinfo"
+	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const yes = "yes"
 
 type globalOptions struct {
-	ManifestFile    string `longflag:"manifest" shortflag:"m"`
-	TerraformState  string `longflag:"tfjson" shortflag:"t"`
-	CredentialsFile string `longflag:"credentials" shortflag:"c"`
-	Verbose         bool   `longflag:"verbose" shortflag:"v"`
-	Debug           bool   `longflag:"debug" shortflag:"d"`
+	ManifestFiles   []string `longflag:"manifest" shortflag:"m"`
+	Cluster         string   `longflag:"cluster"`
+	Set             []string `longflag:"set"`
+	Env             bool     `longflag:"env"`
+	TerraformState  string   `longflag:"tfjson" shortflag:"t"`
+	CredentialsFile string   `longflag:"credentials" shortflag:"c"`
+	Verbose         bool     `longflag:"verbose" shortflag:"v"`
+	Debug           bool     `longflag:"debug" shortflag:"d"`
+	LogFormat       string   `longflag:"log-format"`
+	AskSSHPassword  bool     `longflag:"ask-ssh-password"`
+	AskSudoPassword bool     `longflag:"ask-sudo-password"`
+	MaxParallel     int      `longflag:"max-parallel"`
+	ForceUnlock     bool     `longflag:"force-unlock"`
+	MetricsPush     string   `longflag:"metrics-pushgateway"`
+	MetricsFile     string   `longflag:"metrics-file"`
+	NotifyWebhooks  []string `longflag:"notify-webhook"`
+	NotifySlack     []string `longflag:"notify-slack"`
+	NotifyTeams     []string `longflag:"notify-teams"`
+
+	// the passwords are read once, as the clusters of the workspace are
+	// reconciled concurrently
+	sshPassword  *lazyPassword
+	sudoPassword *lazyPassword
 }
 
+// Environment variables holding the SSH and sudo passwords, used unless the
+// passwords are prompted for
+const (
+	sshPasswordEnv  = "KUBEONE_SSH_PASSWORD"
+	sudoPasswordEnv = "KUBEONE_SUDO_PASSWORD"
+)
+
+// Log formats
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
 func (opts *globalOptions) BuildState() (*state.State, error) {
+	wc, err := opts.workspaceCluster()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load cluster")
+	}
+
+	return opts.buildClusterState(wc, opts.newLogger())
+}
+
+// workspaceCluster returns the cluster selected by the --cluster flag
+func (opts *globalOptions) workspaceCluster() (*config.WorkspaceCluster, error) {
+	ws, err := opts.loadWorkspace()
+	if err != nil {
+		return nil, err
+	}
+
+	return ws.Cluster(opts.Cluster)
+}
+
+// loadWorkspace loads the clusters from the given manifest. Variables are
+// substituted only if they're given with the --set flag or the --env flag is
+// used, in which case the environment is used for variables not given with
+// --set.
+func (opts *globalOptions) loadWorkspace() (*config.Workspace, error) {
+	vars, err := opts.variables()
+	if err != nil {
+		return nil, err
+	}
+
+	return config.LoadWorkspace(opts.ManifestFiles, vars)
+}
+
+// variables returns the variables to substitute in the manifest, or nil if
+// neither --set nor --env is used
+func (opts *globalOptions) variables() (map[string]string, error) {
+	if len(opts.Set) == 0 && !opts.Env {
+		return nil, nil
+	}
+
+	vars, err := config.ParseVariables(opts.Set)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse --set flag")
+	}
+
+	if opts.Env {
+		for _, kv := range os.Environ() {
+			pair := strings.SplitN(kv, "=", 2)
+			if _, ok := vars[pair[0]]; !ok && len(pair) == 2 {
+				vars[pair[0]] = pair[1]
+			}
+		}
+	}
+
+	return vars, nil
+}
+
+// buildClusterState initializes the State for the given cluster from the workspace
+func (opts *globalOptions) buildClusterState(wc *config.WorkspaceCluster, logger logrus.FieldLogger) (*state.State, error) {
 	rootContext := context.Background()
 	s, err := state.New(rootContext)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize State")
 	}
-	s.Logger = newLogger(opts.Verbose)
+	s.Logger = logger
 
-	cluster, err := loadClusterConfig(opts.ManifestFile, opts.TerraformState, opts.CredentialsFile, s.Logger)
+	cluster, err := loadClusterConfig(wc, opts.TerraformState, opts.CredentialsFile, s.Logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load cluster")
 	}
 
 	s.Cluster = cluster
-	s.ManifestFilePath = opts.ManifestFile
+	s.ManifestFilePath = wc.Path
+	s.ManifestHash = clusterinfo.ManifestHash(wc.Manifest)
+	s.KubeOneVersion = version
 	s.CredentialsFilePath = opts.CredentialsFile
 	s.Verbose = opts.Verbose
+	s.StructuredLogs = opts.LogFormat == logFormatJSON
+	s.MaxParallel = opts.MaxParallel
+	s.ForceUnlock = opts.ForceUnlock
+	s.Cluster.Notifications = append(s.Cluster.Notifications, opts.notifications()...)
+
+	sshPassword, err := opts.sshPassword.get()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read SSH password")
+	}
+	s.Connector.SetPasswords(sshPassword, opts.sudoPassword.passwordFunc())
 
 	// Validate Addons path if provided
-	if s.Cluster.Addons.Enabled() {
+	if s.Cluster.Addons.Enabled() && s.Cluster.Addons.Path != "" {
 		addonsPath, err := s.Cluster.Addons.RelativePath(s.ManifestFilePath)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get addons path")
@@ -95,11 +202,29 @@ func shortFlagName(obj interface{}, fieldName string) string {
 func persistentGlobalOptions(fs *pflag.FlagSet) (*globalOptions, error) {
 	gf := &globalOptions{}
 
-	manifestFile, err := fs.GetString(longFlagName(gf, "ManifestFile"))
+	manifestFiles, err := fs.GetStringArray(longFlagName(gf, "ManifestFiles"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	gf.ManifestFiles = manifestFiles
+
+	clusterName, err := fs.GetString(longFlagName(gf, "Cluster"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	gf.Cluster = clusterName
+
+	set, err := fs.GetStringArray(longFlagName(gf, "Set"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	gf.Set = set
+
+	env, err := fs.GetBool(longFlagName(gf, "Env"))
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	gf.ManifestFile = manifestFile
+	gf.Env = env
 
 	verbose, err := fs.GetBool(longFlagName(gf, "Verbose"))
 	if err != nil {
@@ -119,25 +244,118 @@ func persistentGlobalOptions(fs *pflag.FlagSet) (*globalOptions, error) {
 	}
 	gf.CredentialsFile = creds
 
+	logFormat, err := fs.GetString(longFlagName(gf, "LogFormat"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if logFormat != logFormatText && logFormat != logFormatJSON {
+		return nil, errors.Errorf("unknown log format %q, must be one of: text, json", logFormat)
+	}
+	gf.LogFormat = logFormat
+
+	maxParallel, err := fs.GetInt(longFlagName(gf, "MaxParallel"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if maxParallel < 0 {
+		return nil, errors.Errorf("--%s must be 0 (unlimited) or greater", longFlagName(gf, "MaxParallel"))
+	}
+	gf.MaxParallel = maxParallel
+
+	forceUnlock, err := fs.GetBool(longFlagName(gf, "ForceUnlock"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	gf.ForceUnlock = forceUnlock
+
+	for _, notify := range []struct {
+		field  string
+		target *[]string
+	}{
+		{"NotifyWebhooks", &gf.NotifyWebhooks},
+		{"NotifySlack", &gf.NotifySlack},
+		{"NotifyTeams", &gf.NotifyTeams},
+	} {
+		urls, err := fs.GetStringArray(longFlagName(gf, notify.field))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		*notify.target = urls
+	}
+
+	for i, n := range gf.notifications() {
+		if errs := validation.ValidateNotification(n, field.NewPath("notifications").Index(i)); len(errs) > 0 {
+			return nil, errors.Wrap(errs.ToAggregate(), "invalid notification flags")
+		}
+	}
+
+	askSSHPassword, err := fs.GetBool(longFlagName(gf, "AskSSHPassword"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	gf.AskSSHPassword = askSSHPassword
+
+	gf.sshPassword = &lazyPassword{ask: askSSHPassword, envName: sshPasswordEnv, prompt: "SSH password: "}
+
+	askSudoPassword, err := fs.GetBool(longFlagName(gf, "AskSudoPassword"))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	gf.AskSudoPassword = askSudoPassword
+
+	gf.sudoPassword = &lazyPassword{ask: askSudoPassword, envName: sudoPasswordEnv, prompt: "sudo password: "}
+
 	return gf, nil
 }
 
-func newLogger(verbose bool) *logrus.Logger {
+// notifications returns the notifications given with the --notify-webhook,
+// --notify-slack and --notify-teams flags
+func (opts *globalOptions) notifications() []kubeoneapi.Notification {
+	var notifications []kubeoneapi.Notification
+
+	for _, notify := range []struct {
+		notificationType kubeoneapi.NotificationType
+		urls             []string
+	}{
+		{kubeoneapi.NotificationTypeWebhook, opts.NotifyWebhooks},
+		{kubeoneapi.NotificationTypeSlack, opts.NotifySlack},
+		{kubeoneapi.NotificationTypeTeams, opts.NotifyTeams},
+	} {
+		for _, u := range notify.urls {
+			notifications = append(notifications, kubeoneapi.Notification{
+				Type: notify.notificationType,
+				URL:  u,
+			})
+		}
+	}
+
+	return notifications
+}
+
+// newLogger returns the logger using the log format given with the
+// --log-format flag
+func (opts *globalOptions) newLogger() *logrus.Logger {
 	logger := logrus.New()
 	logger.Formatter = &logrus.TextFormatter{
 		FullTimestamp:   true,
 		TimestampFormat: "15:04:05 MST",
 	}
 
-	if verbose {
+	if opts.LogFormat == logFormatJSON {
+		logger.Formatter = &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		}
+	}
+
+	if opts.Verbose {
 		logger.SetLevel(logrus.DebugLevel)
 	}
 
 	return logger
 }
 
-func loadClusterConfig(filename, terraformOutputPath, credentialsFilePath string, logger logrus.FieldLogger) (*kubeoneapi.KubeOneCluster, error) {
-	a, err := config.LoadKubeOneCluster(filename, terraformOutputPath, credentialsFilePath, logger)
+func loadClusterConfig(wc *config.WorkspaceCluster, terraformOutputPath, credentialsFilePath string, logger logrus.FieldLogger) (*kubeoneapi.KubeOneCluster, error) {
+	a, err := config.LoadWorkspaceKubeOneCluster(wc, terraformOutputPath, credentialsFilePath, logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to load a given KubeOneCluster object")
 	}
@@ -150,8 +368,8 @@ func confirmCommand(autoApprove bool) (bool, error) {
 		return true, nil
 	}
 
-	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
-		return false, errors.New("not running in the terminal")
+	if err := ensureTerminal(); err != nil {
+		return false, err
 	}
 
 	reader := bufio.NewReader(os.Stdin)
@@ -166,3 +384,91 @@ func confirmCommand(autoApprove bool) (bool, error) {
 
 	return strings.Trim(confirmation, "\n") == yes, nil
 }
+
+// lazyPassword is the password read when it's needed for the first time, so
+// the commands not using it don't prompt for it
+type lazyPassword struct {
+	ask     bool
+	envName string
+	prompt  string
+
+	once     sync.Once
+	password string
+	err      error
+}
+
+// get returns the password, reading it on the first call
+func (p *lazyPassword) get() (string, error) {
+	if p == nil {
+		return "", nil
+	}
+
+	p.once.Do(func() {
+		p.password, p.err = readPassword(p.ask, p.envName, p.prompt)
+	})
+
+	return p.password, p.err
+}
+
+// passwordFunc returns the function reading the password, or nil if the
+// password is neither prompted for nor set in the environment
+func (p *lazyPassword) passwordFunc() ssh.PasswordFunc {
+	if p == nil || (!p.ask && os.Getenv(p.envName) == "") {
+		return nil
+	}
+
+	return p.get
+}
+
+// readPassword prompts for the password without echoing it, or reads it from
+// the environment variable if not asked to prompt
+func readPassword(ask bool, envName, prompt string) (string, error) {
+	if !ask {
+		return os.Getenv(envName), nil
+	}
+
+	if err := ensureTerminal(); err != nil {
+		return "", err
+	}
+
+	fmt.Print(prompt)
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+
+	return string(password), nil
+}
+
+func ensureTerminal() error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("not running in the terminal")
+	}
+
+	return nil
+}
+
+// confirmStep asks whether to proceed with the destructive step, skip it, or
+// abort the whole operation
+func confirmStep(message string) (bool, error) {
+	reader := bufio.NewReader(os.Stdin)
+
+	for {
+		fmt.Printf("\nAbout to %s.\nDo you want to proceed (yes/skip/abort): ", message)
+
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.TrimSpace(answer) {
+		case yes:
+			return true, nil
+		case "skip":
+			return false, nil
+		case "abort":
+			return false, errors.Wrapf(state.ErrAborted, "before the step to %s", message)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+)
+
+func TestMaxParallelFlag(t *testing.T) {
+	manifest := heredoc.Doc(`
+		apiVersion: kubeone.io/v1beta1
+		kind: KubeOneCluster
+		name: test
+		maxParallel: 4
+		versions:
+		  kubernetes: 1.22.2
+		cloudProvider:
+		  none: {}
+		controlPlane:
+		  hosts:
+		  - publicAddress: 10.0.0.1
+		    privateAddress: 10.0.0.1
+		    sshPrivateKeyFile: /dev/null
+	`)
+
+	path := filepath.Join(t.TempDir(), "kubeone.yaml")
+	if err := os.WriteFile(path, []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedFlag  int
+		expectedError bool
+	}{
+		{
+			name:         "flag not set",
+			args:         []string{"--manifest", path},
+			expectedFlag: 0,
+		},
+		{
+			name:         "flag set",
+			args:         []string{"--manifest", path, "--max-parallel", "2"},
+			expectedFlag: 2,
+		},
+		{
+			name:          "negative flag",
+			args:          []string{"--manifest", path, "--max-parallel", "-1"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fs := newRoot().PersistentFlags()
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatal(err)
+			}
+
+			opts, err := persistentGlobalOptions(fs)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error = %v, but got: %v", tc.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			s, err := opts.BuildState()
+			if err != nil {
+				t.Fatalf("BuildState() error = %v", err)
+			}
+
+			// the flag is kept apart from the config, which is used by the
+			// tasks when the flag is 0
+			if s.MaxParallel != tc.expectedFlag {
+				t.Errorf("expected the flag %d, but got %d", tc.expectedFlag, s.MaxParallel)
+			}
+			if s.Cluster.MaxParallel != 4 {
+				t.Errorf("expected the config %d, but got %d", 4, s.Cluster.MaxParallel)
+			}
+		})
+	}
+}
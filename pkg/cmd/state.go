@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/statebackend"
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type statePullOpts struct {
+	globalOptions
+	OutputDir string `longflag:"output-dir" shortflag:"o"`
+}
+
+func stateCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Manage the state stored in the state backend",
+	}
+
+	cmd.AddCommand(statePullCmd(rootFlags))
+
+	return cmd
+}
+
+func statePullCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &statePullOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Download the state from the state backend",
+		Long: heredoc.Doc(`
+			Download the rendered configuration files, the PKI backup and the last apply checkpoint from the state backend
+			configured in the KubeOne manifest (.stateBackend), so the cluster can be operated without the local workdir
+			of the teammate or the CI runner who applied it.
+		`),
+		Example: `kubeone state pull -m mycluster.yaml -o ./state`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runStatePull(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.OutputDir,
+		longFlagName(opts, "OutputDir"),
+		shortFlagName(opts, "OutputDir"),
+		".",
+		"directory to download the state to")
+
+	return cmd
+}
+
+func runStatePull(opts *statePullOpts) error {
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	backend, err := tasks.NewStateBackend(s, s.Cluster.StateBackend)
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err := statebackend.GetCheckpoint(s.Context, backend)
+	if err != nil {
+		if errors.Is(err, statebackend.ErrNotFound) {
+			return errors.New("the cluster was never applied with the state backend")
+		}
+
+		return err
+	}
+
+	if checkpoint.ManifestHash != s.ManifestHash {
+		s.Logger.Warnf("The manifest has changed since the cluster was last applied at %s.", checkpoint.AppliedAt)
+	}
+
+	keys := []string{statebackend.CheckpointKey, statebackend.PKIBackupKey}
+	for _, filename := range checkpoint.Configs {
+		keys = append(keys, statebackend.ConfigKey(filename))
+	}
+
+	for _, key := range keys {
+		data, err := backend.Get(s.Context, key)
+		if err != nil {
+			return errors.Wrapf(err, "failed to download %s", key)
+		}
+
+		target := filepath.Join(opts.OutputDir, filepath.FromSlash(key))
+		if err = os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			return errors.Wrapf(err, "failed to create directory for %s", key)
+		}
+		if err = ioutil.WriteFile(target, data, 0600); err != nil {
+			return errors.Wrapf(err, "failed to write %s", target)
+		}
+
+		s.Logger.Infof("Downloaded %s", target)
+	}
+
+	return nil
+}
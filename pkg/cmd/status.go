@@ -22,11 +22,19 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"k8c.io/kubeone/pkg/clusterstatus"
 	"k8c.io/kubeone/pkg/tasks"
 )
 
+type statusOpts struct {
+	globalOptions
+	Output string `longflag:"output" shortflag:"o"`
+}
+
 // statusCmd returns the structure for declaring the "status" subcommand.
 func statusCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &statusOpts{}
+
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Status of the cluster",
@@ -35,27 +43,45 @@ func statusCmd(rootFlags *pflag.FlagSet) *cobra.Command {
 
 			This command takes KubeOne manifest which contains information about hosts. It's possible to source information about
 			hosts from Terraform output, using the '--tfjson' flag.
+
+			The status is printed as a table by default. Use '--output json' or '--output yaml' to print the status of the
+			control plane nodes, etcd members, CCM migration and the last apply in a machine-readable format.
 		`),
-		Example: `kubeone status -m mycluster.yaml -t terraformoutput.json`,
+		Example: `kubeone status -m mycluster.yaml -t terraformoutput.json -o json`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			gopts, err := persistentGlobalOptions(rootFlags)
 			if err != nil {
 				return errors.Wrap(err, "unable to get global flags")
 			}
 
-			return runStatus(gopts)
+			opts.globalOptions = *gopts
+
+			return runStatus(opts)
 		},
 	}
 
+	cmd.Flags().StringVarP(
+		&opts.Output,
+		longFlagName(opts, "Output"),
+		shortFlagName(opts, "Output"),
+		clusterstatus.OutputText,
+		"format of the status, one of: text, json, yaml")
+
 	return cmd
 }
 
 // runStatus gets cluster status
-func runStatus(opts *globalOptions) error {
+func runStatus(opts *statusOpts) error {
+	switch opts.Output {
+	case clusterstatus.OutputText, clusterstatus.OutputJSON, clusterstatus.OutputYAML:
+	default:
+		return errors.Errorf("unknown output format %q", opts.Output)
+	}
+
 	s, err := opts.BuildState()
 	if err != nil {
 		return errors.Wrap(err, "failed to initialize State")
 	}
 
-	return errors.Wrap(tasks.WithClusterStatus(nil).Run(s), "failed to get cluster status")
+	return errors.Wrap(tasks.WithClusterStatus(nil, opts.Output).Run(s), "failed to get cluster status")
 }
@@ -23,6 +23,8 @@ import (
 	"github.com/spf13/pflag"
 
 	"k8c.io/kubeone/pkg/credentials"
+	"k8c.io/kubeone/pkg/hooks"
+	"k8c.io/kubeone/pkg/notifications"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/tasks"
 )
@@ -96,5 +98,19 @@ func runUpgrade(opts *upgradeOpts) error {
 		return errors.Wrap(err, "failed to validate credentials")
 	}
 
-	return errors.Wrap(tasks.WithUpgrade(nil).Run(s), "failed to upgrade cluster")
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
+	observeNodes(s)
+
+	return notify(s, notifications.OperationUpgrade, func() error {
+		return observeUpgrade(s, func() error {
+			return tasks.WithHooks(s, hooks.PreUpgrade, hooks.PostUpgrade, func() error {
+				return errors.Wrap(tasks.WithUpgrade(nil).Run(s), "failed to upgrade cluster")
+			})
+		})
+	})
 }
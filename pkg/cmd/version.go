@@ -17,14 +17,20 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"runtime"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"k8c.io/kubeone/pkg/templates/images"
@@ -38,59 +44,254 @@ var (
 	version = "dev"
 )
 
+var (
+	// latestReleaseURL is the GitHub API endpoint returning the latest KubeOne release
+	latestReleaseURL = "https://api.github.com/repos/kubermatic/kubeone/releases/latest"
+	// vulnerabilitiesQueryURL is the OSV API endpoint returning the known
+	// vulnerabilities affecting the given version of a package
+	vulnerabilitiesQueryURL = "https://api.osv.dev/v1/query"
+
+	// componentModules maps the components bundled with KubeOne to Go modules
+	// used to look up the known vulnerabilities
+	componentModules = map[string]string{
+		"kubeone":                         "k8c.io/kubeone",
+		images.MachineController.String(): "github.com/kubermatic/machine-controller",
+		images.MetricsServer.String():     "sigs.k8s.io/metrics-server",
+		images.CalicoNode.String():        "github.com/projectcalico/calico",
+		images.Flannel.String():           "github.com/flannel-io/flannel",
+		images.DNSNodeCache.String():      "k8s.io/dns",
+	}
+)
+
+type versionOpts struct {
+	Check bool `longflag:"check"`
+}
+
 type kubeoneVersions struct {
-	Kubeone           k8sversion.Info `json:"kubeone"`
-	MachineController k8sversion.Info `json:"machine_controller"`
+	Kubeone           k8sversion.Info    `json:"kubeone"`
+	MachineController k8sversion.Info    `json:"machine_controller"`
+	Addons            map[string]string  `json:"addons"`
+	Advisories        *versionAdvisories `json:"advisories,omitempty"`
+}
+
+type versionAdvisories struct {
+	LatestRelease   string                 `json:"latest_release"`
+	UpdateAvailable bool                   `json:"update_available"`
+	Vulnerabilities []versionVulnerability `json:"vulnerabilities"`
+}
+
+type versionVulnerability struct {
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	ID        string `json:"id"`
+	Summary   string `json:"summary,omitempty"`
 }
 
 // versionCmd setups version command
 func versionCmd() *cobra.Command {
+	opts := &versionOpts{}
+
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Display KubeOne version",
 		Long: heredoc.Doc(`
-			Prints the exact version number, as embedded by the build system.
+			Prints the exact version number, as embedded by the build system, together
+			with the versions of machine-controller and the default addons bundled
+			with KubeOne.
+
+			With --check, KubeOne queries GitHub for a newer KubeOne release and the
+			OSV database (https://osv.dev) for known vulnerabilities affecting KubeOne
+			and the bundled components.
+		`),
+		Example: heredoc.Doc(`
+			kubeone version
+			kubeone version --check
 		`),
 		Args: cobra.ExactArgs(0),
 		RunE: func(_ *cobra.Command, _ []string) error {
-			ownver := k8sversion.Info{
-				GitVersion: version,
-				GitCommit:  commit,
-				BuildDate:  date,
-				Platform:   fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
-				Compiler:   runtime.Compiler,
-				GoVersion:  runtime.Version(),
+			versions := bundledVersions()
+
+			if opts.Check {
+				advisories, err := checkAdvisories(versions)
+				if err != nil {
+					return err
+				}
+				versions.Advisories = advisories
 			}
 
-			imgResolver := images.NewResolver()
-			mcTag := imgResolver.Tag(images.MachineController)
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
 
-			mcver := k8sversion.Info{
-				GitVersion: mcTag,
-				Platform:   "linux/amd64",
-			}
+			return enc.Encode(versions)
+		},
+	}
 
-			ownsver, err := semver.NewVersion(version)
-			if err == nil {
-				ownver.Major = strconv.Itoa(int(ownsver.Major()))
-				ownver.Minor = strconv.Itoa(int(ownsver.Minor()))
-			}
+	cmd.Flags().BoolVar(
+		&opts.Check,
+		longFlagName(opts, "Check"),
+		false,
+		"check for newer KubeOne releases and known vulnerabilities in the bundled components")
 
-			mcsver, err := semver.NewVersion(mcTag)
-			if err == nil {
-				mcver.Major = strconv.Itoa(int(mcsver.Major()))
-				mcver.Minor = strconv.Itoa(int(mcsver.Minor()))
-			}
+	return cmd
+}
 
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
+func bundledVersions() kubeoneVersions {
+	ownver := k8sversion.Info{
+		GitVersion: version,
+		GitCommit:  commit,
+		BuildDate:  date,
+		Platform:   fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		Compiler:   runtime.Compiler,
+		GoVersion:  runtime.Version(),
+	}
+
+	imgResolver := images.NewResolver()
+	mcTag := imgResolver.Tag(images.MachineController)
+
+	mcver := k8sversion.Info{
+		GitVersion: mcTag,
+		Platform:   "linux/amd64",
+	}
+
+	ownsver, err := semver.NewVersion(version)
+	if err == nil {
+		ownver.Major = strconv.Itoa(int(ownsver.Major()))
+		ownver.Minor = strconv.Itoa(int(ownsver.Minor()))
+	}
+
+	mcsver, err := semver.NewVersion(mcTag)
+	if err == nil {
+		mcver.Major = strconv.Itoa(int(mcsver.Major()))
+		mcver.Minor = strconv.Itoa(int(mcsver.Minor()))
+	}
+
+	addons := imgResolver.Tags(images.ListFilterBase)
+	delete(addons, images.MachineController.String())
+
+	return kubeoneVersions{
+		Kubeone:           ownver,
+		MachineController: mcver,
+		Addons:            addons,
+	}
+}
 
-			return enc.Encode(kubeoneVersions{
-				Kubeone:           ownver,
-				MachineController: mcver,
+func checkAdvisories(versions kubeoneVersions) (*versionAdvisories, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	latest, err := latestRelease(client)
+	if err != nil {
+		return nil, err
+	}
+
+	advisories := &versionAdvisories{
+		LatestRelease:   latest,
+		Vulnerabilities: []versionVulnerability{},
+	}
+
+	latestsver, latestErr := semver.NewVersion(latest)
+	ownsver, ownErr := semver.NewVersion(versions.Kubeone.GitVersion)
+	if latestErr == nil && ownErr == nil {
+		advisories.UpdateAvailable = latestsver.GreaterThan(ownsver)
+	}
+
+	components := map[string]string{
+		"kubeone":                         versions.Kubeone.GitVersion,
+		images.MachineController.String(): versions.MachineController.GitVersion,
+	}
+	for name, tag := range versions.Addons {
+		components[name] = tag
+	}
+
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		module, ok := componentModules[name]
+		if !ok {
+			continue
+		}
+
+		ver := components[name]
+		if _, err := semver.NewVersion(ver); err != nil {
+			// development builds and non-semver tags can't be matched
+			continue
+		}
+
+		vulns, err := queryVulnerabilities(client, module, ver)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range vulns {
+			advisories.Vulnerabilities = append(advisories.Vulnerabilities, versionVulnerability{
+				Component: name,
+				Version:   ver,
+				ID:        v.ID,
+				Summary:   v.Summary,
 			})
+		}
+	}
+
+	return advisories, nil
+}
+
+func latestRelease(client *http.Client) (string, error) {
+	resp, err := client.Get(latestReleaseURL)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to check the latest KubeOne release")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to check the latest KubeOne release: %s", resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", errors.Wrap(err, "failed to decode the latest KubeOne release")
+	}
+
+	return release.TagName, nil
+}
+
+type osvVulnerability struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary"`
+}
+
+func queryVulnerabilities(client *http.Client, module, ver string) ([]osvVulnerability, error) {
+	query, err := json.Marshal(map[string]interface{}{
+		"version": strings.TrimPrefix(ver, "v"),
+		"package": map[string]string{
+			"name":      module,
+			"ecosystem": "Go",
 		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
 
-	return cmd
+	resp, err := client.Post(vulnerabilitiesQueryURL, "application/json", bytes.NewReader(query))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query vulnerabilities of %s", module)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to query vulnerabilities of %s: %s", module, resp.Status)
+	}
+
+	var result struct {
+		Vulns []osvVulnerability `json:"vulns"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode vulnerabilities of %s", module)
+	}
+
+	return result.Vulns, nil
 }
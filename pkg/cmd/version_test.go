@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	k8sversion "k8s.io/apimachinery/pkg/version"
+)
+
+func TestCheckAdvisories(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/releases/latest", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name": "v1.4.0"}`))
+	})
+	mux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
+		var query struct {
+			Version string `json:"version"`
+			Package struct {
+				Name string `json:"name"`
+			} `json:"package"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if query.Package.Name == "sigs.k8s.io/metrics-server" && query.Version == "0.5.0" {
+			_, _ = w.Write([]byte(`{"vulns": [{"id": "GO-2021-0001", "summary": "metrics-server is vulnerable"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	latestReleaseURL = srv.URL + "/releases/latest"
+	vulnerabilitiesQueryURL = srv.URL + "/query"
+
+	tests := []struct {
+		name     string
+		versions kubeoneVersions
+		expected *versionAdvisories
+	}{
+		{
+			name: "update available",
+			versions: kubeoneVersions{
+				Kubeone:           k8sversion.Info{GitVersion: "v1.3.0"},
+				MachineController: k8sversion.Info{GitVersion: "v1.35.2"},
+				Addons:            map[string]string{"MetricsServer": "v0.5.0", "Unknown": "v1.0.0"},
+			},
+			expected: &versionAdvisories{
+				LatestRelease:   "v1.4.0",
+				UpdateAvailable: true,
+				Vulnerabilities: []versionVulnerability{
+					{
+						Component: "MetricsServer",
+						Version:   "v0.5.0",
+						ID:        "GO-2021-0001",
+						Summary:   "metrics-server is vulnerable",
+					},
+				},
+			},
+		},
+		{
+			name: "development build",
+			versions: kubeoneVersions{
+				Kubeone:           k8sversion.Info{GitVersion: "dev"},
+				MachineController: k8sversion.Info{GitVersion: "v1.35.2"},
+			},
+			expected: &versionAdvisories{
+				LatestRelease:   "v1.4.0",
+				Vulnerabilities: []versionVulnerability{},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := checkAdvisories(tc.versions)
+			if err != nil {
+				t.Fatalf("checkAdvisories() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected advisories %+v, but got %+v", tc.expected, got)
+			}
+		})
+	}
+}
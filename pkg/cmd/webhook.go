@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/webhook"
+)
+
+type webhookOpts struct {
+	Host    string `longflag:"host"`
+	Port    int    `longflag:"port"`
+	CertDir string `longflag:"cert-dir"`
+}
+
+func webhookCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &webhookOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Serve the KubeOneCluster validating admission webhook",
+		Long: heredoc.Doc(`
+			Serve the validating admission webhook for KubeOneCluster objects.
+
+			The objects are defaulted and validated exactly the same way as the manifests given
+			to the other KubeOne commands. The webhook is served over HTTPS at the
+			/validate-kubeone-io-kubeonecluster path, using the tls.crt and tls.key files from
+			the directory given with the '--cert-dir' flag.
+		`),
+		Example: `kubeone webhook --port 9443 --cert-dir /etc/kubeone/webhook`,
+		RunE: func(*cobra.Command, []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			return runWebhook(opts, gopts.newLogger())
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Host, longFlagName(opts, "Host"), "", "address to listen on (default: all addresses)")
+	cmd.Flags().IntVar(&opts.Port, longFlagName(opts, "Port"), 9443, "port to listen on")
+	cmd.Flags().StringVar(&opts.CertDir, longFlagName(opts, "CertDir"), "", "directory containing the tls.crt and tls.key files")
+
+	return cmd
+}
+
+func runWebhook(opts *webhookOpts, logger logrus.FieldLogger) error {
+	if opts.CertDir == "" {
+		return errors.New("--cert-dir is required")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(webhook.ValidateKubeOneClusterPath, webhook.NewHandler(logger))
+
+	srv := &http.Server{
+		Addr:    net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)),
+		Handler: mux,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		logger.Infoln("Shutting down webhook server...")
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	logger.Infof("Serving KubeOneCluster validating webhook on %s...", srv.Addr)
+
+	err := srv.ListenAndServeTLS(filepath.Join(opts.CertDir, "tls.crt"), filepath.Join(opts.CertDir, "tls.key"))
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, "webhook server failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8c.io/kubeone/pkg/tasks"
+)
+
+type workersRolloutRestartOpts struct {
+	globalOptions
+	Names []string `longflag:"name"`
+}
+
+func workersCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workers",
+		Short: "Manage the worker nodes managed by machine-controller",
+	}
+
+	cmd.AddCommand(workersRolloutRestartCmd(rootFlags))
+
+	return cmd
+}
+
+func workersRolloutRestartCmd(rootFlags *pflag.FlagSet) *cobra.Command {
+	opts := &workersRolloutRestartOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "rollout-restart",
+		Short: "Replace the worker nodes managed by machine-controller",
+		Long: heredoc.Doc(`
+			Trigger the rolling replacement of the Machines of the MachineDeployments by annotating the MachineDeployment
+			template, and wait for the new Machines to become ready. All MachineDeployments in the kube-system namespace
+			are restarted, unless the names are given using the --name flag.
+		`),
+		Example: `kubeone workers rollout-restart -m mycluster.yaml -t terraformoutput.json --name pool1 --name pool2`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			gopts, err := persistentGlobalOptions(rootFlags)
+			if err != nil {
+				return errors.Wrap(err, "unable to get global flags")
+			}
+
+			opts.globalOptions = *gopts
+
+			return runWorkersRolloutRestart(opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(
+		&opts.Names,
+		longFlagName(opts, "Names"),
+		nil,
+		"names of the MachineDeployments to restart (default: all MachineDeployments)")
+
+	return cmd
+}
+
+func runWorkersRolloutRestart(opts *workersRolloutRestartOpts) error {
+	s, err := opts.BuildState()
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize State")
+	}
+
+	if !s.Cluster.MachineController.Deploy {
+		return errors.New("the worker nodes are not managed by machine-controller")
+	}
+
+	release, err := lockCluster(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock the cluster")
+	}
+	defer release()
+
+	return tasks.WithRolloutRestart(nil, opts.Names).Run(s)
+}
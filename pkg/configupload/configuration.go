@@ -17,16 +17,15 @@ limitations under the License.
 package configupload
 
 import (
-	"io"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
 
 	"k8c.io/kubeone/pkg/archive"
 	"k8c.io/kubeone/pkg/ssh"
-	"k8c.io/kubeone/pkg/ssh/sshiofs"
 )
 
 // Configuration holds a map of generated files
@@ -71,41 +70,13 @@ func (c *Configuration) AddFilePath(filename, filePath, manifestFilePath string)
 
 // UploadTo directory all the files
 func (c *Configuration) UploadTo(conn ssh.Connection, directory string) error {
-	sshfs := sshiofs.New(conn).(sshiofs.MkdirFS)
-
 	for filename, content := range c.files {
 		target := filepath.Join(directory, filename)
 
-		// ensure the base dir exists
-		dir := filepath.Dir(target)
-		if err := sshfs.MkdirAll(dir, 0700); err != nil {
-			return err
-		}
-
-		f, err := sshfs.Open(target)
-		if err != nil {
-			return err
-		}
-		defer f.Close()
-
-		file := f.(sshiofs.ExtendedFile)
-		if err = file.Truncate(0); err != nil {
-			return err
-		}
-
-		_, err = file.Seek(0, io.SeekStart)
-		if err != nil {
-			return err
-		}
-
-		_, err = io.Copy(file, strings.NewReader(content))
+		err := ssh.Upload(conn, strings.NewReader(content), target, 0600)
 		if err != nil {
 			return errors.Wrapf(err, "failed to write remote file %s", filename)
 		}
-
-		if err := file.Chmod(0600); err != nil {
-			return err
-		}
 	}
 
 	return nil
@@ -145,3 +116,14 @@ func (c *Configuration) Get(filename string) (string, error) {
 
 	return content, nil
 }
+
+// Filenames returns the sorted filenames of all generated files
+func (c *Configuration) Filenames() []string {
+	filenames := make([]string, 0, len(c.files))
+	for filename := range c.files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	return filenames
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"io/ioutil"
 	"os"
+	"regexp"
 	"strings"
 
 	awscredentials "github.com/aws/aws-sdk-go/aws/credentials"
@@ -27,32 +28,57 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"k8c.io/kubeone/pkg/apis/kubeone"
+
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 // The environment variable names with credential in them
 const (
 	// Variables that KubeOne (and Terraform) expect to see
-	AWSAccessKeyID          = "AWS_ACCESS_KEY_ID"
-	AWSSecretAccessKey      = "AWS_SECRET_ACCESS_KEY" //nolint:gosec
-	AzureClientID           = "ARM_CLIENT_ID"
-	AzureClientSecret       = "ARM_CLIENT_SECRET" //nolint:gosec
-	AzureTenantID           = "ARM_TENANT_ID"
-	AzureSubscribtionID     = "ARM_SUBSCRIPTION_ID"
-	DigitalOceanTokenKey    = "DIGITALOCEAN_TOKEN"
-	GoogleServiceAccountKey = "GOOGLE_CREDENTIALS"
-	HetznerTokenKey         = "HCLOUD_TOKEN"
-	OpenStackAuthURL        = "OS_AUTH_URL"
-	OpenStackDomainName     = "OS_DOMAIN_NAME"
-	OpenStackPassword       = "OS_PASSWORD"
-	OpenStackRegionName     = "OS_REGION_NAME"
-	OpenStackTenantID       = "OS_TENANT_ID"
-	OpenStackTenantName     = "OS_TENANT_NAME"
-	OpenStackUserName       = "OS_USERNAME"
-	PacketAPIKey            = "PACKET_AUTH_TOKEN"
-	PacketProjectID         = "PACKET_PROJECT_ID"
-	VSphereAddress          = "VSPHERE_SERVER"
-	VSpherePassword         = "VSPHERE_PASSWORD"
-	VSphereUsername         = "VSPHERE_USER"
+	AWSAccessKeyID                       = "AWS_ACCESS_KEY_ID"
+	AWSSecretAccessKey                   = "AWS_SECRET_ACCESS_KEY" //nolint:gosec
+	AzureClientID                        = "ARM_CLIENT_ID"
+	AzureClientSecret                    = "ARM_CLIENT_SECRET" //nolint:gosec
+	AzureTenantID                        = "ARM_TENANT_ID"
+	AzureSubscribtionID                  = "ARM_SUBSCRIPTION_ID"
+	DigitalOceanTokenKey                 = "DIGITALOCEAN_TOKEN"
+	GoogleServiceAccountKey              = "GOOGLE_CREDENTIALS"
+	HetznerTokenKey                      = "HCLOUD_TOKEN"
+	KubeVirtKubeconfig                   = "KUBEVIRT_KUBECONFIG"
+	NutanixEndpoint                      = "NUTANIX_ENDPOINT"
+	NutanixPort                          = "NUTANIX_PORT"
+	NutanixUsername                      = "NUTANIX_USERNAME"
+	NutanixPassword                      = "NUTANIX_PASSWORD"
+	NutanixInsecure                      = "NUTANIX_INSECURE"
+	NutanixProxyURL                      = "NUTANIX_PROXY_URL"
+	NutanixClusterName                   = "NUTANIX_CLUSTER_NAME"
+	NutanixPEEndpoint                    = "NUTANIX_PE_ENDPOINT"
+	NutanixPEUsername                    = "NUTANIX_PE_USERNAME"
+	NutanixPEPassword                    = "NUTANIX_PE_PASSWORD"
+	OpenStackAuthURL                     = "OS_AUTH_URL"
+	OpenStackApplicationCredentialID     = "OS_APPLICATION_CREDENTIAL_ID"
+	OpenStackApplicationCredentialSecret = "OS_APPLICATION_CREDENTIAL_SECRET" //nolint:gosec
+	OpenStackCloud                       = "OS_CLOUD"
+	OpenStackCloudsYAML                  = "OS_CLOUDS_YAML"
+	OpenStackClientConfigFile            = "OS_CLIENT_CONFIG_FILE"
+	OpenStackDomainName                  = "OS_DOMAIN_NAME"
+	OpenStackPassword                    = "OS_PASSWORD"
+	OpenStackRegionName                  = "OS_REGION_NAME"
+	OpenStackTenantID                    = "OS_TENANT_ID"
+	OpenStackTenantName                  = "OS_TENANT_NAME"
+	OpenStackUserName                    = "OS_USERNAME"
+	PacketAPIKey                         = "PACKET_AUTH_TOKEN"
+	PacketProjectID                      = "PACKET_PROJECT_ID"
+	PacketBGPPassword                    = "PACKET_BGP_PASSWORD" //nolint:gosec
+	VSphereAddress                       = "VSPHERE_SERVER"
+	VSpherePassword                      = "VSPHERE_PASSWORD"
+	VSphereUsername                      = "VSPHERE_USER"
+	VMwareCloudDirectorUsername          = "VCD_USER"
+	VMwareCloudDirectorPassword          = "VCD_PASSWORD"
+	VMwareCloudDirectorOrganization      = "VCD_ORG"
+	VMwareCloudDirectorURL               = "VCD_URL"
+	VMwareCloudDirectorVDC               = "VCD_VDC"
+	VMwareCloudDirectorSkipTLS           = "VCD_ALLOW_UNVERIFIED_SSL"
 
 	// Variables that machine-controller expects
 	AzureClientIDMC           = "AZURE_CLIENT_ID"
@@ -79,7 +105,20 @@ var (
 		DigitalOceanTokenKey,
 		GoogleServiceAccountKey,
 		HetznerTokenKey,
+		KubeVirtKubeconfig,
+		NutanixEndpoint,
+		NutanixPort,
+		NutanixUsername,
+		NutanixPassword,
+		NutanixInsecure,
+		NutanixProxyURL,
+		NutanixClusterName,
+		NutanixPEEndpoint,
+		NutanixPEUsername,
+		NutanixPEPassword,
 		OpenStackAuthURL,
+		OpenStackApplicationCredentialID,
+		OpenStackApplicationCredentialSecret,
 		OpenStackDomainName,
 		OpenStackPassword,
 		OpenStackRegionName,
@@ -88,12 +127,22 @@ var (
 		OpenStackUserName,
 		PacketAPIKey,
 		PacketProjectID,
+		PacketBGPPassword,
 		VSphereAddress,
 		VSpherePassword,
 		VSphereUsername,
+		VMwareCloudDirectorUsername,
+		VMwareCloudDirectorPassword,
+		VMwareCloudDirectorOrganization,
+		VMwareCloudDirectorURL,
+		VMwareCloudDirectorVDC,
+		VMwareCloudDirectorSkipTLS,
 	}
 )
 
+// uuidRegex matches the UUIDs identifying the Equinix Metal projects
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 // ProviderEnvironmentVariable is used to match environment variable used by KubeOne to environment variable used by
 // machine-controller.
 type ProviderEnvironmentVariable struct {
@@ -154,7 +203,32 @@ func ProviderCredentials(cloudProvider kubeone.CloudProviderSpec, credentialsFil
 		return credentialsFinder.parseCredentialVariables([]ProviderEnvironmentVariable{
 			{Name: HetznerTokenKey, MachineControllerName: HetznerTokenKeyMC},
 		}, defaultValidationFunc)
+	case cloudProvider.KubeVirt != nil:
+		return credentialsFinder.parseCredentialVariables([]ProviderEnvironmentVariable{
+			{Name: KubeVirtKubeconfig},
+		}, kubevirtValidationFunc)
+	case cloudProvider.Nutanix != nil:
+		return credentialsFinder.parseCredentialVariables([]ProviderEnvironmentVariable{
+			{Name: NutanixEndpoint},
+			{Name: NutanixPort},
+			{Name: NutanixUsername},
+			{Name: NutanixPassword},
+			{Name: NutanixInsecure},
+			{Name: NutanixProxyURL},
+			{Name: NutanixClusterName},
+			{Name: NutanixPEEndpoint},
+			{Name: NutanixPEUsername},
+			{Name: NutanixPEPassword},
+		}, nutanixValidationFunc)
 	case cloudProvider.Openstack != nil:
+		if credentialsFinder(OpenStackApplicationCredentialID) != "" {
+			return credentialsFinder.parseCredentialVariables([]ProviderEnvironmentVariable{
+				{Name: OpenStackAuthURL},
+				{Name: OpenStackApplicationCredentialID},
+				{Name: OpenStackApplicationCredentialSecret},
+				{Name: OpenStackRegionName},
+			}, defaultValidationFunc)
+		}
 		return credentialsFinder.parseCredentialVariables([]ProviderEnvironmentVariable{
 			{Name: OpenStackAuthURL},
 			{Name: OpenStackUserName, MachineControllerName: OpenStackUserNameMC},
@@ -168,7 +242,7 @@ func ProviderCredentials(cloudProvider kubeone.CloudProviderSpec, credentialsFil
 		return credentialsFinder.parseCredentialVariables([]ProviderEnvironmentVariable{
 			{Name: PacketAPIKey, MachineControllerName: PacketAPIKeyMC},
 			{Name: PacketProjectID},
-		}, defaultValidationFunc)
+		}, packetValidationFunc)
 	case cloudProvider.Vsphere != nil:
 		vscreds, err := credentialsFinder.parseCredentialVariables([]ProviderEnvironmentVariable{
 			{Name: VSphereAddress, MachineControllerName: VSphereAddressMC},
@@ -181,6 +255,15 @@ func ProviderCredentials(cloudProvider kubeone.CloudProviderSpec, credentialsFil
 		// force scheme, as machine-controller requires it while terraform does not
 		vscreds[VSphereAddressMC] = "https://" + vscreds[VSphereAddressMC]
 		return vscreds, nil
+	case cloudProvider.VMwareCloudDirector != nil:
+		return credentialsFinder.parseCredentialVariables([]ProviderEnvironmentVariable{
+			{Name: VMwareCloudDirectorUsername},
+			{Name: VMwareCloudDirectorPassword},
+			{Name: VMwareCloudDirectorOrganization},
+			{Name: VMwareCloudDirectorURL},
+			{Name: VMwareCloudDirectorVDC},
+			{Name: VMwareCloudDirectorSkipTLS},
+		}, vmwareCloudDirectorValidationFunc)
 	case cloudProvider.None != nil:
 		return map[string]string{}, nil
 	}
@@ -197,20 +280,109 @@ func newCredsFinder(credentialsFilePath string) (lookupFunc, error) {
 		return staticMap[name]
 	}
 
-	if credentialsFilePath == "" {
-		return finder, nil
+	if credentialsFilePath != "" {
+		buf, err := ioutil.ReadFile(credentialsFilePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load credentials file")
+		}
+
+		if err = yaml.Unmarshal(buf, &staticMap); err != nil {
+			return nil, errors.Wrap(err, "unable to unmarshal credentials file")
+		}
 	}
 
-	buf, err := ioutil.ReadFile(credentialsFilePath)
+	cloudsMap, err := openstackCloudsCredentials(finder)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to load credentials file")
+		return nil, err
+	}
+	if len(cloudsMap) == 0 {
+		return finder, nil
+	}
+
+	// credentials from the clouds.yaml file are used only if they're not
+	// provided explicitly via environment variables or the credentials file
+	return func(name string) string {
+		if val := finder(name); val != "" {
+			return val
+		}
+		return cloudsMap[name]
+	}, nil
+}
+
+// openstackClouds is the subset of the OpenStack clouds.yaml file used to
+// source the OpenStack credentials
+type openstackClouds struct {
+	Clouds map[string]struct {
+		Auth struct {
+			AuthURL                     string `yaml:"auth_url"`
+			Username                    string `yaml:"username"`
+			Password                    string `yaml:"password"`
+			ProjectID                   string `yaml:"project_id"`
+			ProjectName                 string `yaml:"project_name"`
+			DomainName                  string `yaml:"domain_name"`
+			UserDomainName              string `yaml:"user_domain_name"`
+			ApplicationCredentialID     string `yaml:"application_credential_id"`
+			ApplicationCredentialSecret string `yaml:"application_credential_secret"`
+		} `yaml:"auth"`
+		RegionName string `yaml:"region_name"`
+	} `yaml:"clouds"`
+}
+
+// openstackCloudsCredentials returns the OpenStack credentials from the
+// clouds.yaml file, provided inline via OS_CLOUDS_YAML or as a path via
+// OS_CLIENT_CONFIG_FILE. The cloud is selected by OS_CLOUD, and can be
+// omitted if the file defines only one cloud.
+func openstackCloudsCredentials(lookup lookupFunc) (map[string]string, error) {
+	buf := []byte(lookup(OpenStackCloudsYAML))
+	if len(buf) == 0 {
+		path := lookup(OpenStackClientConfigFile)
+		if path == "" {
+			return nil, nil
+		}
+
+		var err error
+		buf, err = ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to load %v", OpenStackClientConfigFile)
+		}
 	}
 
-	if err = yaml.Unmarshal(buf, &staticMap); err != nil {
-		return nil, errors.Wrap(err, "unable to unmarshal credentials file")
+	clouds := openstackClouds{}
+	if err := yaml.Unmarshal(buf, &clouds); err != nil {
+		return nil, errors.Wrap(err, "unable to unmarshal OpenStack clouds.yaml")
 	}
 
-	return finder, nil
+	cloudName := lookup(OpenStackCloud)
+	if cloudName == "" {
+		if len(clouds.Clouds) != 1 {
+			return nil, errors.Errorf("key %v is required to select the cloud from OpenStack clouds.yaml", OpenStackCloud)
+		}
+		for name := range clouds.Clouds {
+			cloudName = name
+		}
+	}
+
+	cloud, ok := clouds.Clouds[cloudName]
+	if !ok {
+		return nil, errors.Errorf("cloud %q not found in OpenStack clouds.yaml", cloudName)
+	}
+
+	domainName := cloud.Auth.DomainName
+	if domainName == "" {
+		domainName = cloud.Auth.UserDomainName
+	}
+
+	return map[string]string{
+		OpenStackAuthURL:                     cloud.Auth.AuthURL,
+		OpenStackUserName:                    cloud.Auth.Username,
+		OpenStackPassword:                    cloud.Auth.Password,
+		OpenStackTenantID:                    cloud.Auth.ProjectID,
+		OpenStackTenantName:                  cloud.Auth.ProjectName,
+		OpenStackDomainName:                  domainName,
+		OpenStackRegionName:                  cloud.RegionName,
+		OpenStackApplicationCredentialID:     cloud.Auth.ApplicationCredentialID,
+		OpenStackApplicationCredentialSecret: cloud.Auth.ApplicationCredentialSecret,
+	}, nil
 }
 
 // lookupFunc is function that retrieves credentials from the sources
@@ -301,3 +473,54 @@ func openstackValidationFunc(creds map[string]string) error {
 
 	return nil
 }
+
+func kubevirtValidationFunc(creds map[string]string) error {
+	if err := defaultValidationFunc(creds); err != nil {
+		return err
+	}
+
+	if _, err := clientcmd.Load([]byte(creds[KubeVirtKubeconfig])); err != nil {
+		return errors.Wrapf(err, "key %v must contain the kubeconfig of the infrastructure KubeVirt cluster", KubeVirtKubeconfig)
+	}
+
+	return nil
+}
+
+func nutanixValidationFunc(creds map[string]string) error {
+	for k, v := range creds {
+		// insecure, proxy URL and cluster name are optional
+		if k == NutanixInsecure || k == NutanixProxyURL || k == NutanixClusterName {
+			continue
+		}
+		if len(v) == 0 {
+			return errors.Errorf("key %v is required but isn't present", k)
+		}
+	}
+
+	return nil
+}
+
+func vmwareCloudDirectorValidationFunc(creds map[string]string) error {
+	for k, v := range creds {
+		if k == VMwareCloudDirectorSkipTLS {
+			continue
+		}
+		if len(v) == 0 {
+			return errors.Errorf("key %v is required but isn't present", k)
+		}
+	}
+
+	return nil
+}
+
+func packetValidationFunc(creds map[string]string) error {
+	if err := defaultValidationFunc(creds); err != nil {
+		return err
+	}
+
+	if !uuidRegex.MatchString(creds[PacketProjectID]) {
+		return errors.Errorf("key %v must be the UUID of the Equinix Metal project", PacketProjectID)
+	}
+
+	return nil
+}
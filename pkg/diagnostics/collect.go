@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clusterstatus/etcdstatus"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+)
+
+const (
+	nodesFile = "cluster/nodes.yaml"
+	etcdFile  = "cluster/etcd.yaml"
+)
+
+type nodeReport struct {
+	Name       string                 `json:"name"`
+	Conditions []corev1.NodeCondition `json:"conditions,omitempty"`
+	Taints     []corev1.Taint         `json:"taints,omitempty"`
+	NodeInfo   corev1.NodeSystemInfo  `json:"nodeInfo"`
+}
+
+type etcdReport struct {
+	Host   string `json:"host"`
+	Health bool   `json:"health"`
+	Member bool   `json:"member"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Collect collects the diagnostics of all hosts over SSH, and the node
+// conditions and the etcd health if the cluster is reachable. Whatever
+// can't be collected is recorded in the bundle.
+func Collect(s *state.State, bundle *Bundle, since time.Duration) {
+	collectHosts(s, bundle, since)
+
+	if s.DynamicClient == nil {
+		bundle.Errorf(nodesFile, "the cluster is not reachable")
+	} else {
+		collectNodes(s, bundle)
+	}
+
+	if s.Cluster.LocalEtcd() {
+		collectEtcd(s, bundle)
+	}
+}
+
+// HostDir returns the directory of the bundle the diagnostics of the host
+// are stored in
+func HostDir(host kubeoneapi.HostConfig) string {
+	name := host.Hostname
+	if name == "" {
+		name = host.PublicAddress
+	}
+
+	return path.Join("hosts", name)
+}
+
+func collectHosts(s *state.State, bundle *Bundle, since time.Duration) {
+	var (
+		lock      sync.Mutex
+		collected = map[string]bool{}
+	)
+
+	hosts := []kubeoneapi.HostConfig{}
+	hosts = append(hosts, s.Cluster.ControlPlane.Hosts...)
+	hosts = append(hosts, s.Cluster.StaticWorkers.Hosts...)
+
+	// errors are recorded in the bundle, the hosts that couldn't be connected
+	// to are found below
+	_ = s.RunTaskOnNodes(hosts, func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		dir := HostDir(*node)
+		for _, cmd := range HostCommands(s.WorkDir, since, isControlPlane(s, node)) {
+			file := path.Join(dir, cmd.File)
+
+			stdout, stderr, _, err := conn.Exec(cmd.Command)
+			if err != nil {
+				bundle.Errorf(file, "%v: %s", err, stderr)
+			}
+			if stdout != "" {
+				bundle.Add(file, stdout)
+			}
+		}
+
+		lock.Lock()
+		collected[node.PublicAddress] = true
+		lock.Unlock()
+
+		return nil
+	}, state.RunParallel)
+
+	for _, host := range hosts {
+		if !collected[host.PublicAddress] {
+			bundle.Errorf(HostDir(host), "failed to connect to the host %s", host.PublicAddress)
+		}
+	}
+}
+
+func isControlPlane(s *state.State, node *kubeoneapi.HostConfig) bool {
+	for _, host := range s.Cluster.ControlPlane.Hosts {
+		if host.PublicAddress == node.PublicAddress {
+			return true
+		}
+	}
+
+	return false
+}
+
+func collectNodes(s *state.State, bundle *Bundle) {
+	ctx, cancel := context.WithTimeout(s.Context, time.Minute)
+	defer cancel()
+
+	nodes := corev1.NodeList{}
+	if err := s.DynamicClient.List(ctx, &nodes); err != nil {
+		bundle.Errorf(nodesFile, "failed to list nodes: %v", err)
+		return
+	}
+
+	reports := []nodeReport{}
+	for _, node := range nodes.Items {
+		reports = append(reports, nodeReport{
+			Name:       node.Name,
+			Conditions: node.Status.Conditions,
+			Taints:     node.Spec.Taints,
+			NodeInfo:   node.Status.NodeInfo,
+		})
+	}
+
+	addYAML(bundle, nodesFile, reports)
+}
+
+func collectEtcd(s *state.State, bundle *Bundle) {
+	etcdRing, err := etcdstatus.MemberList(s)
+	if err != nil {
+		bundle.Errorf(etcdFile, "failed to list etcd members: %v", err)
+		return
+	}
+
+	reports := []etcdReport{}
+	for _, host := range s.Cluster.ControlPlane.Hosts {
+		report := etcdReport{Host: host.PublicAddress}
+
+		status, err := etcdstatus.Get(s, host, etcdRing)
+		if err != nil {
+			report.Error = err.Error()
+		} else {
+			report.Health = status.Health
+			report.Member = status.Member
+		}
+
+		reports = append(reports, report)
+	}
+
+	addYAML(bundle, etcdFile, reports)
+}
+
+func addYAML(bundle *Bundle, file string, obj interface{}) {
+	buf, err := yaml.Marshal(obj)
+	if err != nil {
+		bundle.Errorf(file, "failed to marshal: %v", err)
+		return
+	}
+
+	bundle.Add(file, string(buf))
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diagnostics collects the logs, the configuration files and the
+// health of the cluster hosts into a bundle that can be attached to the
+// support cases.
+package diagnostics
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8c.io/kubeone/pkg/archive"
+)
+
+const (
+	// ErrorsFile is the file of the bundle listing everything that couldn't
+	// be collected
+	ErrorsFile = "errors.txt"
+
+	redacted = "REDACTED"
+)
+
+var (
+	// secretValueRegexp matches the values of the keys and the flags named
+	// after tokens, passwords and secrets
+	secretValueRegexp = regexp.MustCompile(`(?i)([\w-]*(?:token|password|secret)[\w-]*["']?[ \t]*[:=][ \t]*["']?)[^\s"',]+`)
+	// bootstrapTokenRegexp matches the kubeadm bootstrap tokens
+	bootstrapTokenRegexp = regexp.MustCompile(`\b[a-z0-9]{6}\.[a-z0-9]{16}\b`)
+)
+
+// HostCommand is a command run on the host whose output is stored in the
+// bundle
+type HostCommand struct {
+	// File is the name of the file in the host directory of the bundle
+	File string
+	// Command is the command run on the host
+	Command string
+}
+
+// HostCommands returns the commands collecting the diagnostics of the host.
+// The journald logs are collected for the given period of time. The static
+// pod manifests are collected only from the control plane hosts.
+func HostCommands(workDir string, since time.Duration, controlPlane bool) []HostCommand {
+	journal := func(args string) string {
+		return fmt.Sprintf("sudo journalctl %s --no-pager --since=-%ds", args, int64(since.Seconds()))
+	}
+
+	cmds := []HostCommand{
+		{File: "kubelet.log", Command: journal("-u kubelet")},
+		{File: "containerd.log", Command: journal("-u containerd")},
+		{File: "kernel.log", Command: journal("-k")},
+		{File: "kubeadm-config.yaml", Command: catFiles(path.Join(workDir, "cfg", "*.yaml"))},
+		{File: "kubelet-config.yaml", Command: catFiles("/var/lib/kubelet/config.yaml", "/var/lib/kubelet/kubeadm-flags.env")},
+		{File: "containers.txt", Command: "sudo crictl ps -a"},
+		{File: "system.txt", Command: "uname -a; cat /etc/os-release; uptime; free -m; df -h; ip addr; sudo systemctl --failed --no-pager"},
+	}
+
+	if controlPlane {
+		cmds = append(cmds, HostCommand{File: "static-pod-manifests.yaml", Command: catFiles("/etc/kubernetes/manifests/*")})
+	}
+
+	return cmds
+}
+
+// catFiles returns the command printing the files matching the given
+// patterns, each of them preceded by its path
+func catFiles(patterns ...string) string {
+	return fmt.Sprintf(`for f in %s; do [ -f "$f" ] || continue; echo "# $f"; sudo cat "$f"; echo "---"; done`, strings.Join(patterns, " "))
+}
+
+// Redact replaces the tokens, the passwords and the secrets in the content
+func Redact(content string) string {
+	content = secretValueRegexp.ReplaceAllString(content, "${1}"+redacted)
+	return bootstrapTokenRegexp.ReplaceAllString(content, redacted)
+}
+
+// Bundle is the archive the diagnostics are collected into. It's safe for
+// concurrent use, and records the files that couldn't be collected instead
+// of failing, so one broken host doesn't prevent collecting the rest.
+type Bundle struct {
+	lock    sync.Mutex
+	archive archive.Archive
+	errors  []string
+}
+
+// NewBundle returns the bundle writing to the given archive
+func NewBundle(arch archive.Archive) *Bundle {
+	return &Bundle{archive: arch}
+}
+
+// Add redacts the content and adds it to the bundle as the given file
+func (b *Bundle) Add(file, content string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := b.archive.Add(file, Redact(content)); err != nil {
+		b.errors = append(b.errors, fmt.Sprintf("%s: %v", file, err))
+	}
+}
+
+// Errorf records that the given file couldn't be collected
+func (b *Bundle) Errorf(file, format string, args ...interface{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.errors = append(b.errors, fmt.Sprintf("%s: %s", file, fmt.Sprintf(format, args...)))
+}
+
+// Errors returns the sorted list of the files that couldn't be collected
+// and the reason why
+func (b *Bundle) Errors() []string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	errs := append([]string{}, b.errors...)
+	sort.Strings(errs)
+
+	return errs
+}
+
+// Close adds the list of errors to the bundle and closes the archive
+func (b *Bundle) Close() error {
+	errs := b.Errors()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var err error
+	if len(errs) > 0 {
+		err = b.archive.Add(ErrorsFile, strings.Join(errs, "\n")+"\n")
+	}
+	b.archive.Close()
+
+	return err
+}
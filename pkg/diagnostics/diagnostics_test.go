@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+type fakeArchive struct {
+	files  map[string]string
+	closed bool
+}
+
+func (a *fakeArchive) Add(file string, content string) error {
+	if a.closed {
+		return errors.New("archive has already been closed")
+	}
+	if file == "broken" {
+		return errors.New("write failed")
+	}
+	a.files[file] = content
+
+	return nil
+}
+
+func (a *fakeArchive) Close() {
+	a.closed = true
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "bootstrap token",
+			content: "kubeadm join 10.0.0.1:6443 --token abcdef.0123456789abcdef",
+			want:    "kubeadm join 10.0.0.1:6443 --token REDACTED",
+		},
+		{
+			name:    "yaml keys",
+			content: "bootstrapTokens:\n- token: \"secret-value\"\npassword: hunter2\nkind: Secret\n",
+			want:    "bootstrapTokens:\n- token: \"REDACTED\"\npassword: REDACTED\nkind: Secret\n",
+		},
+		{
+			name:    "flags",
+			content: "--client-secret=foo --bind-address=0.0.0.0",
+			want:    "--client-secret=REDACTED --bind-address=0.0.0.0",
+		},
+		{
+			name:    "nothing to redact",
+			content: "Started kubelet: The Kubernetes Node Agent.",
+			want:    "Started kubelet: The Kubernetes Node Agent.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.content); got != tt.want {
+				t.Errorf("Redact() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostCommands(t *testing.T) {
+	files := func(cmds []HostCommand) []string {
+		names := []string{}
+		for _, cmd := range cmds {
+			names = append(names, cmd.File)
+		}
+
+		return names
+	}
+
+	worker := HostCommands("./kubeone", time.Hour, false)
+	controlPlane := HostCommands("./kubeone", time.Hour, true)
+
+	if diff := cmp.Diff(append(files(worker), "static-pod-manifests.yaml"), files(controlPlane)); diff != "" {
+		t.Errorf("control plane commands differ from the worker ones (-want +got):\n%s", diff)
+	}
+
+	for _, cmd := range worker {
+		if cmd.File == "kubelet.log" && !strings.Contains(cmd.Command, "--since=-3600s") {
+			t.Errorf("kubelet logs are not limited to the last hour: %s", cmd.Command)
+		}
+		if cmd.File == "kubeadm-config.yaml" && !strings.Contains(cmd.Command, "kubeone/cfg/*.yaml") {
+			t.Errorf("kubeadm configs are not collected from the work directory: %s", cmd.Command)
+		}
+	}
+}
+
+func TestBundle(t *testing.T) {
+	arch := &fakeArchive{files: map[string]string{}}
+	bundle := NewBundle(arch)
+
+	bundle.Add("hosts/cp-0/kubelet.log", "password=hunter2")
+	bundle.Add("broken", "content")
+	bundle.Errorf("hosts/cp-1", "failed to connect to the host %s", "10.0.0.2")
+
+	if err := bundle.Close(); err != nil {
+		t.Fatalf("failed to close the bundle: %v", err)
+	}
+
+	want := map[string]string{
+		"hosts/cp-0/kubelet.log": "password=REDACTED",
+		ErrorsFile:               "broken: write failed\nhosts/cp-1: failed to connect to the host 10.0.0.2\n",
+	}
+	if diff := cmp.Diff(want, arch.files); diff != "" {
+		t.Errorf("bundle content differs (-want +got):\n%s", diff)
+	}
+	if !arch.closed {
+		t.Error("archive is not closed")
+	}
+}
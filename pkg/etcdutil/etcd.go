@@ -53,7 +53,7 @@ func NewClientConfig(s *state.State, host kubeone.HostConfig) (*clientv3.Config,
 	}
 
 	return &clientv3.Config{
-		Endpoints:   []string{fmt.Sprintf("%s:2379", host.PrivateAddress)},
+		Endpoints:   []string{fmt.Sprintf("%s:2379", host.AdvertiseAddress())},
 		TLS:         tlsConf,
 		Context:     s.Context,
 		DialTimeout: 5 * time.Second,
@@ -50,6 +50,18 @@ func Activate(s *state.State) error {
 		return errors.Wrap(err, "failed to install podNodeSelector")
 	}
 
+	if err := installSRIOV(s.Cluster.Features.SRIOV, s); err != nil {
+		return errors.Wrap(err, "failed to install SR-IOV device plugin")
+	}
+
+	if err := installGatekeeper(s.Cluster.Features.Gatekeeper, s); err != nil {
+		return errors.Wrap(err, "failed to install OPA Gatekeeper")
+	}
+
+	if err := installBootstrapRBAC(s.Context, s.DynamicClient, s.Cluster.Features.BootstrapRBAC, s.Cluster.Features.OpenIDConnect); err != nil {
+		return errors.Wrap(err, "failed to install bootstrap RBAC")
+	}
+
 	return nil
 }
 
@@ -57,10 +69,17 @@ func Activate(s *state.State) error {
 // v1beta1.ClusterConfiguration according to enabled features
 func UpdateKubeadmClusterConfiguration(featuresCfg kubeoneapi.Features, args *kubeadmargs.Args) {
 	activateKubeadmPSP(featuresCfg.PodSecurityPolicy, args)
+	activateKubeadmPodSecurityAdmission(featuresCfg.PodSecurityAdmission, args)
 	activateKubeadmStaticAuditLogs(featuresCfg.StaticAuditLog, args)
 	activateKubeadmDynamicAuditLogs(featuresCfg.DynamicAuditLog, args)
 	activateKubeadmOIDC(featuresCfg.OpenIDConnect, args)
 	activateKubeadmPodPresets(featuresCfg.PodPresets, args)
 	activateKubeadmPodNodeSelector(featuresCfg.PodNodeSelector, args)
+	activateKubeadmEventRateLimit(featuresCfg.EventRateLimit, args)
+	activateKubeadmAlwaysPullImages(featuresCfg.AlwaysPullImages, args)
+	activateKubeadmDenyServiceExternalIPs(featuresCfg.DenyServiceExternalIPs, args)
+	activateKubeadmNodeRestriction(featuresCfg.NodeRestriction, args)
 	activateEncryptionProviders(featuresCfg.EncryptionProviders, args)
+	activateKubeadmKonnectivity(featuresCfg.Konnectivity, args)
+	activateKubeadmFIPS(featuresCfg.FIPS, args)
 }
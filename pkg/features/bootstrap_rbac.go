@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clientutil"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	bootstrapRBACViewerName         = "kubeone:viewer"
+	bootstrapRBACOperatorName       = "kubeone:operator"
+	bootstrapRBACNamespaceAdminName = "kubeone:namespace-admin"
+)
+
+func installBootstrapRBAC(ctx context.Context, c client.Client, feature *kubeoneapi.BootstrapRBAC, oidc *kubeoneapi.OpenIDConnect) error {
+	if feature == nil || !feature.Enable {
+		return nil
+	}
+
+	groupsPrefix := ""
+	if oidc != nil {
+		groupsPrefix = oidc.Config.GroupsPrefix
+	}
+
+	k8sobjects := []client.Object{
+		operatorClusterRole(),
+		clusterRoleBinding(bootstrapRBACViewerName, "view", groupsPrefix, feature.Viewers),
+		clusterRoleBinding(bootstrapRBACOperatorName, "edit", groupsPrefix, feature.Operators),
+		clusterRoleBinding(bootstrapRBACOperatorName+"-nodes", bootstrapRBACOperatorName, groupsPrefix, feature.Operators),
+	}
+
+	for _, nsAdmins := range feature.NamespaceAdmins {
+		k8sobjects = append(k8sobjects,
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nsAdmins.Namespace,
+				},
+			},
+			namespaceAdminRoleBinding(nsAdmins.Namespace, groupsPrefix, nsAdmins.Groups),
+		)
+	}
+
+	for _, obj := range k8sobjects {
+		if err := clientutil.CreateOrUpdate(ctx, c, obj); err != nil {
+			return errors.Wrapf(err, "failed to ensure %T %s", obj, obj.GetName())
+		}
+	}
+
+	return nil
+}
+
+// operatorClusterRole grants permissions to manage nodes, in addition to
+// the built-in edit ClusterRole
+func operatorClusterRole() *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: bootstrapRBACOperatorName,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"nodes"},
+				Verbs:     []string{"get", "list", "watch", "patch", "update"},
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods/eviction"},
+				Verbs:     []string{"create"},
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"namespaces", "persistentvolumes"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"storage.k8s.io"},
+				Resources: []string{"storageclasses"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+}
+
+func clusterRoleBinding(name, clusterRole, groupsPrefix string, groups []string) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		RoleRef: rbacv1.RoleRef{
+			Name:     clusterRole,
+			Kind:     "ClusterRole",
+			APIGroup: rbacv1.GroupName,
+		},
+		Subjects: groupSubjects(groupsPrefix, groups),
+	}
+}
+
+func namespaceAdminRoleBinding(namespace, groupsPrefix string, groups []string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bootstrapRBACNamespaceAdminName,
+			Namespace: namespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			Name:     "admin",
+			Kind:     "ClusterRole",
+			APIGroup: rbacv1.GroupName,
+		},
+		Subjects: groupSubjects(groupsPrefix, groups),
+	}
+}
+
+func groupSubjects(groupsPrefix string, groups []string) []rbacv1.Subject {
+	subjects := []rbacv1.Subject{}
+	for _, group := range groups {
+		subjects = append(subjects, rbacv1.Subject{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Group",
+			Name:     groupsPrefix + group,
+		})
+	}
+
+	return subjects
+}
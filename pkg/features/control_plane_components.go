@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"sort"
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
+)
+
+const apiServerDisableAdmissionPluginsFlag = "disable-admission-plugins"
+
+// UpdateKubeadmAPIServerConfiguration merges the user-provided API server
+// configuration into the kubeadm's ClusterConfiguration. It must be called
+// after UpdateKubeadmClusterConfiguration, as flags, admission plugins and
+// feature gates already configured by KubeOne take precedence over the
+// user-provided ones. The only exception are explicitly disabled admission
+// plugins, which are removed from the list of enabled admission plugins.
+//
+// The resulting lists are sorted, so the generated configuration doesn't
+// change between runs (e.g. upgrades) unless the manifest changes.
+func UpdateKubeadmAPIServerConfiguration(components *kubeoneapi.ControlPlaneComponents, args *kubeadmargs.Args) {
+	if components == nil || components.APIServer == nil {
+		return
+	}
+	cfg := components.APIServer
+
+	for flag, value := range cfg.ExtraArgs {
+		if _, ok := args.APIServer.ExtraArgs[flag]; !ok {
+			args.APIServer.ExtraArgs[flag] = value
+		}
+	}
+
+	mergeAdmissionPlugins(cfg, args)
+	mergeFeatureGates(cfg.FeatureGates, args.APIServer.ExtraArgs)
+}
+
+// MergeControlPlaneComponentArgs merges the user-provided controller manager
+// or scheduler flags and feature gates into the flags configured by KubeOne
+// and returns the resulting flags. Flags and feature gates configured by
+// KubeOne take precedence over the user-provided ones.
+func MergeControlPlaneComponentArgs(cfg *kubeoneapi.ControlPlaneComponentConfig, extraArgs map[string]string) map[string]string {
+	if cfg == nil {
+		return extraArgs
+	}
+
+	if extraArgs == nil {
+		extraArgs = map[string]string{}
+	}
+
+	for flag, value := range cfg.ExtraArgs {
+		if _, ok := extraArgs[flag]; !ok {
+			extraArgs[flag] = value
+		}
+	}
+
+	mergeFeatureGates(cfg.FeatureGates, extraArgs)
+
+	return extraArgs
+}
+
+func mergeAdmissionPlugins(cfg *kubeoneapi.APIServerConfig, args *kubeadmargs.Args) {
+	if len(cfg.EnableAdmissionPlugins) == 0 && len(cfg.DisableAdmissionPlugins) == 0 {
+		return
+	}
+
+	disabled := map[string]bool{}
+	for _, plugin := range splitFlagValue(args.APIServer.ExtraArgs[apiServerDisableAdmissionPluginsFlag]) {
+		disabled[plugin] = true
+	}
+	for _, plugin := range cfg.DisableAdmissionPlugins {
+		disabled[plugin] = true
+	}
+
+	enabled := map[string]bool{}
+	for _, plugin := range splitFlagValue(args.APIServer.ExtraArgs[apiServerAdmissionPluginsFlag]) {
+		enabled[plugin] = true
+	}
+	for _, plugin := range cfg.EnableAdmissionPlugins {
+		enabled[plugin] = true
+	}
+	for plugin := range disabled {
+		delete(enabled, plugin)
+	}
+
+	setListFlag(args, apiServerAdmissionPluginsFlag, enabled)
+	setListFlag(args, apiServerDisableAdmissionPluginsFlag, disabled)
+}
+
+func mergeFeatureGates(featureGates map[string]bool, extraArgs map[string]string) {
+	if len(featureGates) == 0 {
+		return
+	}
+
+	gates := map[string]string{}
+	for _, gate := range splitFlagValue(extraArgs[featureGatesFlag]) {
+		kv := strings.SplitN(gate, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		gates[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	for gate, enabled := range featureGates {
+		if _, ok := gates[gate]; !ok {
+			if enabled {
+				gates[gate] = "true"
+			} else {
+				gates[gate] = "false"
+			}
+		}
+	}
+
+	list := make([]string, 0, len(gates))
+	for gate, value := range gates {
+		list = append(list, gate+"="+value)
+	}
+	sort.Strings(list)
+	extraArgs[featureGatesFlag] = strings.Join(list, ",")
+}
+
+func setListFlag(args *kubeadmargs.Args, flag string, values map[string]bool) {
+	if len(values) == 0 {
+		delete(args.APIServer.ExtraArgs, flag)
+
+		return
+	}
+
+	list := make([]string, 0, len(values))
+	for value := range values {
+		list = append(list, value)
+	}
+	sort.Strings(list)
+	args.APIServer.ExtraArgs[flag] = strings.Join(list, ",")
+}
+
+func splitFlagValue(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
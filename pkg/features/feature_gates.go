@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+// MergeFeatureGatesArgs merges the cluster-wide feature gates into the
+// feature-gates flag of the control plane component and returns the resulting
+// flags. Feature gates already present in the flag take precedence.
+func MergeFeatureGatesArgs(featureGates map[string]bool, extraArgs map[string]string) map[string]string {
+	if len(featureGates) == 0 {
+		return extraArgs
+	}
+
+	if extraArgs == nil {
+		extraArgs = map[string]string{}
+	}
+
+	mergeFeatureGates(featureGates, extraArgs)
+
+	return extraArgs
+}
+
+// MergeFeatureGates merges the cluster-wide feature gates into the kubelet or
+// kube-proxy feature gates. Feature gates already present take precedence.
+func MergeFeatureGates(featureGates map[string]bool, componentFeatureGates map[string]bool) map[string]bool {
+	if len(featureGates) == 0 {
+		return componentFeatureGates
+	}
+
+	if componentFeatureGates == nil {
+		componentFeatureGates = map[string]bool{}
+	}
+
+	for gate, enabled := range featureGates {
+		if _, ok := componentFeatureGates[gate]; !ok {
+			componentFeatureGates[gate] = enabled
+		}
+	}
+
+	return componentFeatureGates
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
+)
+
+const (
+	apiServerTLSCipherSuitesFlag = "tls-cipher-suites"
+	apiServerTLSMinVersionFlag   = "tls-min-version"
+
+	// FIPSTLSMinVersion is the minimum TLS version allowed in the FIPS mode
+	FIPSTLSMinVersion = "VersionTLS12"
+)
+
+// FIPSTLSCipherSuites is a list of FIPS-approved TLS cipher suites supported
+// by Kubernetes components
+var FIPSTLSCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+}
+
+// FIPSEtcdExtraArgs returns etcd flags enforcing FIPS-approved TLS settings
+func FIPSEtcdExtraArgs() map[string]string {
+	return map[string]string{
+		"cipher-suites": strings.Join(FIPSTLSCipherSuites, ","),
+	}
+}
+
+// EtcdExtraArgs returns the etcd flags for the cluster, enforcing the
+// FIPS-approved cipher suites if FIPS is enabled
+func EtcdExtraArgs(cluster *kubeoneapi.KubeOneCluster) map[string]string {
+	args := cluster.Etcd.ExtraArgs()
+
+	if cluster.Features.FIPS != nil && cluster.Features.FIPS.Enable {
+		for k, v := range FIPSEtcdExtraArgs() {
+			args[k] = v
+		}
+	}
+
+	return args
+}
+
+func activateKubeadmFIPS(feature *kubeoneapi.FIPS, args *kubeadmargs.Args) {
+	if feature == nil || !feature.Enable {
+		return
+	}
+
+	args.APIServer.ExtraArgs[apiServerTLSCipherSuitesFlag] = strings.Join(FIPSTLSCipherSuites, ",")
+	args.APIServer.ExtraArgs[apiServerTLSMinVersionFlag] = FIPSTLSMinVersion
+}
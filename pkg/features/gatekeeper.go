@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"k8c.io/kubeone/pkg/addons"
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/resources"
+)
+
+func installGatekeeper(feature *kubeoneapi.Gatekeeper, s *state.State) error {
+	if !feature.Enabled() {
+		return nil
+	}
+
+	if err := addons.EnsureAddonByName(s, resources.AddonGatekeeper); err != nil {
+		return err
+	}
+
+	// The webhook rejects namespace requests until it's serving, and
+	// ConstraintTemplates can't be created until the CRD is established
+	if err := addons.WaitForAddonReady(s, resources.AddonGatekeeper); err != nil {
+		return err
+	}
+
+	if !feature.Config.ConstraintLibrary {
+		return nil
+	}
+
+	return addons.EnsureAddonByName(s, resources.AddonGatekeeperLibrary)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+// UpdateKubeletConfiguration applies the cluster-wide kubelet settings to the
+// kubeadm's KubeletConfiguration. The host specific settings are passed as
+// the kubelet flags, which take precedence over the KubeletConfiguration.
+func UpdateKubeletConfiguration(cfg *kubeoneapi.KubeletConfig, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration) {
+	if cfg == nil {
+		return
+	}
+
+	if cfg.MaxPods != nil {
+		kubeletConfig.MaxPods = *cfg.MaxPods
+	}
+	if cfg.CgroupDriver != "" {
+		kubeletConfig.CgroupDriver = cfg.CgroupDriver
+	}
+	if len(cfg.SystemReserved) > 0 {
+		kubeletConfig.SystemReserved = cfg.SystemReserved
+	}
+	if len(cfg.KubeReserved) > 0 {
+		kubeletConfig.KubeReserved = cfg.KubeReserved
+	}
+	if len(cfg.EvictionHard) > 0 {
+		kubeletConfig.EvictionHard = cfg.EvictionHard
+	}
+}
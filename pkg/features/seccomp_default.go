@@ -0,0 +1,52 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"github.com/Masterminds/semver/v3"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+const (
+	kubeletSeccompDefaultFlag = "seccomp-default"
+	seccompDefaultFeatureGate = "SeccompDefault"
+)
+
+var (
+	// SeccompDefault feature gate is enabled by default since Kubernetes 1.25
+	seccompDefaultFeatureGateConstraint, _ = semver.NewConstraint("< 1.25")
+)
+
+// SeccompDefaultFeatureGateRequired returns true if the SeccompDefault feature
+// gate must be explicitly enabled for the given Kubernetes version
+func SeccompDefaultFeatureGateRequired(kubeVersion *semver.Version) bool {
+	return seccompDefaultFeatureGateConstraint.Check(kubeVersion)
+}
+
+// UpdateKubeletSeccompDefault sets kubelet flags and feature gates required to
+// use the RuntimeDefault seccomp profile by default
+func UpdateKubeletSeccompDefault(feature *kubeoneapi.SeccompDefault, kubeVersion *semver.Version, kubeletFlags map[string]string, featureGates map[string]bool) {
+	if feature == nil || !feature.Enable {
+		return
+	}
+
+	kubeletFlags[kubeletSeccompDefaultFlag] = "true"
+	if SeccompDefaultFeatureGateRequired(kubeVersion) {
+		featureGates[seccompDefaultFeatureGate] = true
+	}
+}
@@ -24,11 +24,18 @@ import (
 )
 
 const (
-	auditPolicyFileFlag   = "audit-policy-file"
-	auditLogPathFlag      = "audit-log-path"
-	auditLogMaxAgeFlag    = "audit-log-maxage"
-	auditLogMaxBackupFlag = "audit-log-maxbackup"
-	auditLogMaxSizeFlag   = "audit-log-maxsize"
+	auditPolicyFileFlag              = "audit-policy-file"
+	auditLogPathFlag                 = "audit-log-path"
+	auditLogMaxAgeFlag               = "audit-log-maxage"
+	auditLogMaxBackupFlag            = "audit-log-maxbackup"
+	auditLogMaxSizeFlag              = "audit-log-maxsize"
+	auditWebhookConfigFileFlag       = "audit-webhook-config-file"
+	auditWebhookModeFlag             = "audit-webhook-mode"
+	auditWebhookInitialBackoffFlag   = "audit-webhook-initial-backoff"
+	auditWebhookBatchBufferSizeFlag  = "audit-webhook-batch-buffer-size"
+	auditWebhookBatchMaxSizeFlag     = "audit-webhook-batch-max-size"
+	auditWebhookBatchMaxWaitFlag     = "audit-webhook-batch-max-wait"
+	auditWebhookConfigFileOnNodePath = "/etc/kubernetes/audit/webhook-config.yaml"
 )
 
 func activateKubeadmStaticAuditLogs(feature *kubeoneapi.StaticAuditLog, args *kubeadmargs.Args) {
@@ -37,8 +44,30 @@ func activateKubeadmStaticAuditLogs(feature *kubeoneapi.StaticAuditLog, args *ku
 	}
 
 	args.APIServer.ExtraArgs[auditPolicyFileFlag] = "/etc/kubernetes/audit/policy.yaml"
-	args.APIServer.ExtraArgs[auditLogPathFlag] = feature.Config.LogPath
-	args.APIServer.ExtraArgs[auditLogMaxAgeFlag] = strconv.Itoa(feature.Config.LogMaxAge)
-	args.APIServer.ExtraArgs[auditLogMaxBackupFlag] = strconv.Itoa(feature.Config.LogMaxBackup)
-	args.APIServer.ExtraArgs[auditLogMaxSizeFlag] = strconv.Itoa(feature.Config.LogMaxSize)
+
+	if !feature.Config.DisableLogBackend {
+		args.APIServer.ExtraArgs[auditLogPathFlag] = feature.Config.LogPath
+		args.APIServer.ExtraArgs[auditLogMaxAgeFlag] = strconv.Itoa(feature.Config.LogMaxAge)
+		args.APIServer.ExtraArgs[auditLogMaxBackupFlag] = strconv.Itoa(feature.Config.LogMaxBackup)
+		args.APIServer.ExtraArgs[auditLogMaxSizeFlag] = strconv.Itoa(feature.Config.LogMaxSize)
+	}
+
+	if webhook := feature.Config.Webhook; webhook != nil {
+		args.APIServer.ExtraArgs[auditWebhookConfigFileFlag] = auditWebhookConfigFileOnNodePath
+		if webhook.Mode != "" {
+			args.APIServer.ExtraArgs[auditWebhookModeFlag] = webhook.Mode
+		}
+		if webhook.InitialBackoff.Duration > 0 {
+			args.APIServer.ExtraArgs[auditWebhookInitialBackoffFlag] = webhook.InitialBackoff.Duration.String()
+		}
+		if webhook.BatchBufferSize > 0 {
+			args.APIServer.ExtraArgs[auditWebhookBatchBufferSizeFlag] = strconv.Itoa(webhook.BatchBufferSize)
+		}
+		if webhook.BatchMaxSize > 0 {
+			args.APIServer.ExtraArgs[auditWebhookBatchMaxSizeFlag] = strconv.Itoa(webhook.BatchMaxSize)
+		}
+		if webhook.BatchMaxWait.Duration > 0 {
+			args.APIServer.ExtraArgs[auditWebhookBatchMaxWaitFlag] = webhook.BatchMaxWait.Duration.String()
+		}
+	}
 }
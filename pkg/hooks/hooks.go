@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hooks runs the user-defined hooks, the local commands and the
+// webhooks, before and after the KubeOne operations. It also renders the
+// user-defined scripts run on the hosts at the points of the node lifecycle.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+// Event is the step of the operation the hooks are run at
+type Event string
+
+// Events the hooks are run at
+const (
+	PreApply        Event = "preApply"
+	PostApply       Event = "postApply"
+	PreUpgrade      Event = "preUpgrade"
+	PostUpgrade     Event = "postUpgrade"
+	PreReset        Event = "preReset"
+	PostReset       Event = "postReset"
+	PreNodeUpgrade  Event = "preNodeUpgrade"
+	PostNodeUpgrade Event = "postNodeUpgrade"
+)
+
+const (
+	defaultTimeout = time.Minute
+	envPrefix      = "env:"
+)
+
+// Payload describes the operation the hooks are run for. It's passed to the
+// commands as the environment variables, and to the webhooks as the JSON
+// body.
+type Payload struct {
+	Event   Event  `json:"event"`
+	Cluster string `json:"cluster"`
+	Node    *Node  `json:"node,omitempty"`
+	// Error is the error the operation failed with. Set only for the post
+	// hooks.
+	Error string `json:"error,omitempty"`
+}
+
+// Node describes the node the per-node hooks are run for
+type Node struct {
+	Hostname       string `json:"hostname"`
+	PublicAddress  string `json:"publicAddress"`
+	PrivateAddress string `json:"privateAddress,omitempty"`
+}
+
+// NewPayload returns the payload of the event. The node is nil for the
+// cluster-wide events, and opErr is nil for the pre hooks and the succeeded
+// operations.
+func NewPayload(event Event, cluster string, node *kubeoneapi.HostConfig, opErr error) Payload {
+	payload := Payload{
+		Event:   event,
+		Cluster: cluster,
+	}
+
+	if node != nil {
+		payload.Node = &Node{
+			Hostname:       node.Hostname,
+			PublicAddress:  node.PublicAddress,
+			PrivateAddress: node.PrivateAddress,
+		}
+	}
+
+	if opErr != nil {
+		payload.Error = opErr.Error()
+	}
+
+	return payload
+}
+
+// Env returns the environment variables passed to the commands
+func (p Payload) Env() []string {
+	env := []string{
+		"KUBEONE_HOOK_EVENT=" + string(p.Event),
+		"KUBEONE_CLUSTER_NAME=" + p.Cluster,
+	}
+
+	if p.Node != nil {
+		env = append(env,
+			"KUBEONE_NODE_HOSTNAME="+p.Node.Hostname,
+			"KUBEONE_NODE_ADDRESS="+p.Node.PublicAddress,
+		)
+	}
+
+	if p.Error != "" {
+		env = append(env, "KUBEONE_ERROR="+p.Error)
+	}
+
+	return env
+}
+
+// For returns the hooks run at the event
+func For(h *kubeoneapi.Hooks, event Event) []kubeoneapi.Hook {
+	if h == nil {
+		return nil
+	}
+
+	switch event {
+	case PreApply:
+		return h.PreApply
+	case PostApply:
+		return h.PostApply
+	case PreUpgrade:
+		return h.PreUpgrade
+	case PostUpgrade:
+		return h.PostUpgrade
+	case PreReset:
+		return h.PreReset
+	case PostReset:
+		return h.PostReset
+	case PreNodeUpgrade:
+		return h.PreNodeUpgrade
+	case PostNodeUpgrade:
+		return h.PostNodeUpgrade
+	}
+
+	return nil
+}
+
+// Run runs the hooks of the payload event one by one. The failed hook stops
+// the remaining hooks and fails the operation, unless it ignores failures.
+func Run(ctx context.Context, logger logrus.FieldLogger, h *kubeoneapi.Hooks, payload Payload) error {
+	for i, hook := range For(h, payload.Event) {
+		name := hook.Name
+		if name == "" {
+			name = fmt.Sprintf("%s[%d]", payload.Event, i)
+		}
+
+		logger.Infof("Running %s hook %q...", payload.Event, name)
+
+		err := run(ctx, logger, hook, payload)
+		if err == nil {
+			continue
+		}
+
+		if hook.IgnoreFailure {
+			logger.Warnf("Ignoring failure of %s hook %q: %v", payload.Event, name, err)
+			continue
+		}
+
+		return errors.Wrapf(err, "%s hook %q failed", payload.Event, name)
+	}
+
+	return nil
+}
+
+func run(ctx context.Context, logger logrus.FieldLogger, hook kubeoneapi.Hook, payload Payload) error {
+	timeout := hook.Timeout.Duration
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if hook.Webhook != nil {
+		return callWebhook(ctx, *hook.Webhook, payload)
+	}
+
+	return runCommand(ctx, logger, hook.Command, payload)
+}
+
+func runCommand(ctx context.Context, logger logrus.FieldLogger, command string, payload Payload) error {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Env = append(os.Environ(), payload.Env()...)
+
+	out, err := cmd.CombinedOutput()
+	logger.Debugf("Hook output: %s", out)
+	if err != nil {
+		return errors.Wrapf(err, "command failed: %s", strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+func callWebhook(ctx context.Context, webhook kubeoneapi.WebhookHook, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the payload")
+	}
+
+	endpoint := fromEnv(webhook.URL)
+	if endpoint == "" {
+		return errors.Errorf("the URL is not set in the %s environment variable", strings.TrimPrefix(webhook.URL, envPrefix))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range webhook.Headers {
+		req.Header.Set(k, fromEnv(v))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call the webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+// fromEnv returns the value of the environment variable if the value is
+// prefixed with "env:", or the value itself otherwise
+func fromEnv(value string) string {
+	if strings.HasPrefix(value, envPrefix) {
+		return os.Getenv(strings.TrimPrefix(value, envPrefix))
+	}
+
+	return value
+}
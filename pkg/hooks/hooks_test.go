@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestRunCommand(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	hooks := &kubeoneapi.Hooks{
+		PreNodeUpgrade: []kubeoneapi.Hook{
+			{Command: `echo "$KUBEONE_HOOK_EVENT $KUBEONE_CLUSTER_NAME $KUBEONE_NODE_HOSTNAME" > ` + out},
+		},
+	}
+	node := &kubeoneapi.HostConfig{Hostname: "cp-0", PublicAddress: "192.168.1.1"}
+
+	if err := Run(context.Background(), logrus.New(), hooks, NewPayload(PreNodeUpgrade, "test", node, nil)); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	b, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatalf("failed to read the command output: %v", err)
+	}
+	if expected := "preNodeUpgrade test cp-0\n"; string(b) != expected {
+		t.Errorf("expected command output %q, but got %q", expected, string(b))
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	tests := []struct {
+		name          string
+		hooks         []kubeoneapi.Hook
+		expectedError bool
+	}{
+		{
+			name:          "failed command",
+			hooks:         []kubeoneapi.Hook{{Name: "fail", Command: "exit 1"}},
+			expectedError: true,
+		},
+		{
+			name:          "ignored failure",
+			hooks:         []kubeoneapi.Hook{{Command: "exit 1", IgnoreFailure: true}, {Command: "true"}},
+			expectedError: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			hooks := &kubeoneapi.Hooks{PostReset: tc.hooks}
+			err := Run(context.Background(), logrus.New(), hooks, NewPayload(PostReset, "test", nil, nil))
+			if (err != nil) != tc.expectedError {
+				t.Errorf("Run() error = %v, expected error %v", err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestRunWebhook(t *testing.T) {
+	os.Setenv("KUBEONE_TEST_HOOK_TOKEN", "secret")
+	defer os.Unsetenv("KUBEONE_TEST_HOOK_TOKEN")
+
+	var (
+		payload Payload
+		auth    string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("KUBEONE_TEST_HOOK_URL", server.URL+"/notify")
+	defer os.Unsetenv("KUBEONE_TEST_HOOK_URL")
+
+	hooks := &kubeoneapi.Hooks{
+		PostApply: []kubeoneapi.Hook{
+			{
+				Webhook: &kubeoneapi.WebhookHook{
+					URL:     "env:KUBEONE_TEST_HOOK_URL",
+					Headers: map[string]string{"Authorization": "env:KUBEONE_TEST_HOOK_TOKEN"},
+				},
+			},
+		},
+		PostUpgrade: []kubeoneapi.Hook{
+			{Webhook: &kubeoneapi.WebhookHook{URL: server.URL + "/fail"}},
+		},
+	}
+
+	err := Run(context.Background(), logrus.New(), hooks, NewPayload(PostApply, "test", nil, errors.New("apply failed")))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if auth != "secret" {
+		t.Errorf("expected Authorization header %q, but got %q", "secret", auth)
+	}
+	if payload.Event != PostApply || payload.Cluster != "test" || payload.Error != "apply failed" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	if err = Run(context.Background(), logrus.New(), hooks, NewPayload(PostUpgrade, "test", nil, nil)); err == nil {
+		t.Error("expected the failed webhook to fail the hooks")
+	}
+
+	os.Unsetenv("KUBEONE_TEST_HOOK_URL")
+	if err = Run(context.Background(), logrus.New(), hooks, NewPayload(PostApply, "test", nil, nil)); err == nil {
+		t.Error("expected the webhook with the URL environment variable not set to fail the hooks")
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/scripts"
+)
+
+// HostPoint is the point of the node lifecycle the host scripts are run at
+type HostPoint string
+
+// Points of the node lifecycle the host scripts are run at
+const (
+	HostPreProvision HostPoint = "preProvision"
+	HostPostJoin     HostPoint = "postJoin"
+	HostPreDrain     HostPoint = "preDrain"
+	HostPostUpgrade  HostPoint = "postUpgrade"
+)
+
+// HostScript is the host script rendered for the node
+type HostScript struct {
+	Name          string
+	Script        string
+	IgnoreFailure bool
+}
+
+// HostScriptsFor returns the host scripts run at the point
+func HostScriptsFor(h *kubeoneapi.Hooks, point HostPoint) []kubeoneapi.HostScript {
+	if h == nil || h.Host == nil {
+		return nil
+	}
+
+	switch point {
+	case HostPreProvision:
+		return h.Host.PreProvision
+	case HostPostJoin:
+		return h.Host.PostJoin
+	case HostPreDrain:
+		return h.Host.PreDrain
+	case HostPostUpgrade:
+		return h.Host.PostUpgrade
+	}
+
+	return nil
+}
+
+// HostScriptVariables returns the template variables describing the node the
+// host scripts are run on
+func HostScriptVariables(cluster *kubeoneapi.KubeOneCluster, node *kubeoneapi.HostConfig) scripts.Data {
+	controlPlane := false
+	for _, host := range cluster.ControlPlane.Hosts {
+		if host.PublicAddress == node.PublicAddress {
+			controlPlane = true
+			break
+		}
+	}
+
+	return scripts.Data{
+		"CLUSTER_NAME":       cluster.Name,
+		"KUBERNETES_VERSION": cluster.Versions.Kubernetes,
+		"HOSTNAME":           node.Hostname,
+		"PUBLIC_ADDRESS":     node.PublicAddress,
+		"PRIVATE_ADDRESS":    node.PrivateAddress,
+		"NODE_ID":            node.ID,
+		"CONTROL_PLANE":      controlPlane,
+		"LEADER":             node.IsLeader,
+	}
+}
+
+// RenderHostScripts reads and renders the host scripts run at the point on
+// the node. Relative file paths are relative to the manifestFilePath
+// directory.
+func RenderHostScripts(cluster *kubeoneapi.KubeOneCluster, node *kubeoneapi.HostConfig, point HostPoint, manifestFilePath string) ([]HostScript, error) {
+	var rendered []HostScript
+
+	variables := HostScriptVariables(cluster, node)
+
+	for i, script := range HostScriptsFor(cluster.Hooks, point) {
+		name := script.Name
+		if name == "" {
+			name = fmt.Sprintf("%s[%d]", point, i)
+		}
+
+		content := script.Inline
+		if script.File != "" {
+			b, err := readHostScript(script.File, manifestFilePath)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read %s script %q", point, name)
+			}
+			content = string(b)
+		}
+
+		cmd, err := scripts.Render(content, variables)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render %s script %q", point, name)
+		}
+
+		rendered = append(rendered, HostScript{
+			Name:          name,
+			Script:        cmd,
+			IgnoreFailure: script.IgnoreFailure,
+		})
+	}
+
+	return rendered, nil
+}
+
+func readHostScript(filePath, manifestFilePath string) ([]byte, error) {
+	if !filepath.IsAbs(filePath) && manifestFilePath != "" {
+		manifestAbsPath, err := filepath.Abs(filepath.Dir(manifestFilePath))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get absolute path to the cluster manifest")
+		}
+		filePath = filepath.Join(manifestAbsPath, filePath)
+	}
+
+	b, err := ioutil.ReadFile(filePath)
+
+	return b, errors.WithStack(err)
+}
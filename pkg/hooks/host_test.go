@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestRenderHostScripts(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "post-join.sh"), []byte("echo {{ .HOSTNAME }} {{ .CONTROL_PLANE }}"), 0600); err != nil {
+		t.Fatalf("failed to write the script: %v", err)
+	}
+
+	cluster := &kubeoneapi.KubeOneCluster{
+		Name: "test",
+		ControlPlane: kubeoneapi.ControlPlaneConfig{
+			Hosts: []kubeoneapi.HostConfig{{PublicAddress: "192.168.1.1", Hostname: "cp-0", IsLeader: true}},
+		},
+		StaticWorkers: kubeoneapi.StaticWorkersConfig{
+			Hosts: []kubeoneapi.HostConfig{{PublicAddress: "192.168.1.2", Hostname: "worker-0"}},
+		},
+		Hooks: &kubeoneapi.Hooks{
+			Host: &kubeoneapi.HostHooks{
+				PostJoin: []kubeoneapi.HostScript{
+					{Name: "inline", Inline: "echo {{ .CLUSTER_NAME }} {{ .LEADER }}"},
+					{File: "post-join.sh", IgnoreFailure: true},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		node     *kubeoneapi.HostConfig
+		expected []HostScript
+	}{
+		{
+			name: "control plane node",
+			node: &cluster.ControlPlane.Hosts[0],
+			expected: []HostScript{
+				{Name: "inline", Script: "echo test true"},
+				{Name: "postJoin[1]", Script: "echo cp-0 true", IgnoreFailure: true},
+			},
+		},
+		{
+			name: "static worker node",
+			node: &cluster.StaticWorkers.Hosts[0],
+			expected: []HostScript{
+				{Name: "inline", Script: "echo test false"},
+				{Name: "postJoin[1]", Script: "echo worker-0 false", IgnoreFailure: true},
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := RenderHostScripts(cluster, tc.node, HostPostJoin, filepath.Join(dir, "kubeone.yaml"))
+			if err != nil {
+				t.Fatalf("RenderHostScripts() error = %v", err)
+			}
+
+			if len(rendered) != len(tc.expected) {
+				t.Fatalf("expected %d scripts, but got %d", len(tc.expected), len(rendered))
+			}
+
+			for i, script := range rendered {
+				expected := tc.expected[i]
+				if script.Name != expected.Name || script.IgnoreFailure != expected.IgnoreFailure {
+					t.Errorf("expected script %q (ignoreFailure %v), but got %q (ignoreFailure %v)",
+						expected.Name, expected.IgnoreFailure, script.Name, script.IgnoreFailure)
+				}
+				// the shell options are prepended to every rendered script
+				if !strings.HasSuffix(script.Script, "\n"+expected.Script) {
+					t.Errorf("expected script %q to end with %q", script.Script, expected.Script)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderHostScriptsMissingFile(t *testing.T) {
+	cluster := &kubeoneapi.KubeOneCluster{
+		Hooks: &kubeoneapi.Hooks{
+			Host: &kubeoneapi.HostHooks{
+				PreDrain: []kubeoneapi.HostScript{{File: "missing.sh"}},
+			},
+		},
+	}
+
+	_, err := RenderHostScripts(cluster, &kubeoneapi.HostConfig{}, HostPreDrain, filepath.Join(t.TempDir(), "kubeone.yaml"))
+	if err == nil {
+		t.Fatal("expected the missing script file to fail rendering")
+	}
+}
@@ -0,0 +1,453 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics collects metrics about KubeOne operations and exposes
+// them in the Prometheus text exposition format.
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultRegistry is the registry used by the package level functions
+var DefaultRegistry = NewRegistry()
+
+// Content types of the exposition formats
+const (
+	ContentTypeText        = "text/plain; version=0.0.4; charset=utf-8"
+	ContentTypeOpenMetrics = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// Roles of the nodes counted by the kubeone_nodes metric
+const (
+	RoleControlPlane = "control-plane"
+	RoleStaticWorker = "static-worker"
+)
+
+type durationSummary struct {
+	sum   float64
+	count int
+}
+
+func (d *durationSummary) observe(duration time.Duration) {
+	d.sum += duration.Seconds()
+	d.count++
+}
+
+type resultCounts struct {
+	success int
+	failure int
+}
+
+func (c *resultCounts) observe(err error) {
+	if err == nil {
+		c.success++
+	} else {
+		c.failure++
+	}
+}
+
+type applyResult struct {
+	success   bool
+	timestamp time.Time
+}
+
+type taskKey struct {
+	cluster string
+	task    string
+}
+
+type nodesKey struct {
+	cluster string
+	role    string
+}
+
+// Registry holds the collected metrics
+type Registry struct {
+	lock               sync.Mutex
+	now                func() time.Time
+	reconcileDurations map[string]*durationSummary
+	lastApply          map[string]applyResult
+	taskFailures       map[taskKey]int
+	taskDurations      map[taskKey]*durationSummary
+	taskResults        map[taskKey]*resultCounts
+	upgradeDurations   map[string]*durationSummary
+	upgradeResults     map[string]*resultCounts
+	nodes              map[nodesKey]int
+}
+
+// NewRegistry constructor
+func NewRegistry() *Registry {
+	return &Registry{
+		now:                time.Now,
+		reconcileDurations: map[string]*durationSummary{},
+		lastApply:          map[string]applyResult{},
+		taskFailures:       map[taskKey]int{},
+		taskDurations:      map[taskKey]*durationSummary{},
+		taskResults:        map[taskKey]*resultCounts{},
+		upgradeDurations:   map[string]*durationSummary{},
+		upgradeResults:     map[string]*resultCounts{},
+		nodes:              map[nodesKey]int{},
+	}
+}
+
+// ObserveReconcile records the duration and the result of the cluster
+// reconciliation
+func ObserveReconcile(cluster string, duration time.Duration, err error) {
+	DefaultRegistry.ObserveReconcile(cluster, duration, err)
+}
+
+// TaskFailed records a failed attempt to run the task
+func TaskFailed(cluster, task string) {
+	DefaultRegistry.TaskFailed(cluster, task)
+}
+
+// ObserveTask records the duration and the result of the task, including
+// all its attempts
+func ObserveTask(cluster, task string, duration time.Duration, err error) {
+	DefaultRegistry.ObserveTask(cluster, task, duration, err)
+}
+
+// ObserveUpgrade records the duration and the result of the cluster upgrade
+func ObserveUpgrade(cluster string, duration time.Duration, err error) {
+	DefaultRegistry.ObserveUpgrade(cluster, duration, err)
+}
+
+// SetNodes records the number of nodes of the cluster with the given role
+func SetNodes(cluster, role string, count int) {
+	DefaultRegistry.SetNodes(cluster, role, count)
+}
+
+// ObserveReconcile records the duration and the result of the cluster
+// reconciliation
+func (r *Registry) ObserveReconcile(cluster string, duration time.Duration, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	summary, ok := r.reconcileDurations[cluster]
+	if !ok {
+		summary = &durationSummary{}
+		r.reconcileDurations[cluster] = summary
+	}
+	summary.observe(duration)
+
+	r.lastApply[cluster] = applyResult{
+		success:   err == nil,
+		timestamp: r.now(),
+	}
+}
+
+// TaskFailed records a failed attempt to run the task
+func (r *Registry) TaskFailed(cluster, task string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.taskFailures[taskKey{cluster: cluster, task: task}]++
+}
+
+// ObserveTask records the duration and the result of the task, including
+// all its attempts
+func (r *Registry) ObserveTask(cluster, task string, duration time.Duration, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := taskKey{cluster: cluster, task: task}
+
+	summary, ok := r.taskDurations[key]
+	if !ok {
+		summary = &durationSummary{}
+		r.taskDurations[key] = summary
+	}
+	summary.observe(duration)
+
+	results, ok := r.taskResults[key]
+	if !ok {
+		results = &resultCounts{}
+		r.taskResults[key] = results
+	}
+	results.observe(err)
+}
+
+// ObserveUpgrade records the duration and the result of the cluster upgrade
+func (r *Registry) ObserveUpgrade(cluster string, duration time.Duration, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	summary, ok := r.upgradeDurations[cluster]
+	if !ok {
+		summary = &durationSummary{}
+		r.upgradeDurations[cluster] = summary
+	}
+	summary.observe(duration)
+
+	results, ok := r.upgradeResults[cluster]
+	if !ok {
+		results = &resultCounts{}
+		r.upgradeResults[cluster] = results
+	}
+	results.observe(err)
+}
+
+// SetNodes records the number of nodes of the cluster with the given role
+func (r *Registry) SetNodes(cluster, role string, count int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.nodes[nodesKey{cluster: cluster, role: role}] = count
+}
+
+// Write writes all metrics to w in the Prometheus text exposition format
+func (r *Registry) Write(w io.Writer) error {
+	return r.write(w, false, "")
+}
+
+// WriteOpenMetrics writes all metrics to w in the OpenMetrics text format
+func (r *Registry) WriteOpenMetrics(w io.Writer) error {
+	return r.write(w, true, "")
+}
+
+// Push pushes the metrics to the Prometheus Pushgateway at the given URL.
+// The metrics of every cluster are pushed to their own group, identified by
+// the job and the cluster labels, replacing the metrics previously pushed
+// for the cluster.
+func (r *Registry) Push(ctx context.Context, gatewayURL, job string) error {
+	for _, cluster := range r.clusters() {
+		var buf bytes.Buffer
+		if err := r.write(&buf, false, cluster); err != nil {
+			return err
+		}
+
+		target := fmt.Sprintf("%s/metrics/job/%s/cluster/%s",
+			strings.TrimSuffix(gatewayURL, "/"), url.PathEscape(job), url.PathEscape(cluster))
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, &buf)
+		if err != nil {
+			return errors.Wrap(err, "failed to create the Pushgateway request")
+		}
+		req.Header.Set("Content-Type", ContentTypeText)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return errors.Wrapf(err, "failed to push metrics of cluster %q", cluster)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode/100 != 2 {
+			return errors.Errorf("failed to push metrics of cluster %q: unexpected status %s", cluster, resp.Status)
+		}
+	}
+
+	return nil
+}
+
+// clusters returns the sorted names of all clusters with collected metrics
+func (r *Registry) clusters() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	set := map[string]struct{}{}
+	for cluster := range r.reconcileDurations {
+		set[cluster] = struct{}{}
+	}
+	for key := range r.taskDurations {
+		set[key.cluster] = struct{}{}
+	}
+	for key := range r.taskFailures {
+		set[key.cluster] = struct{}{}
+	}
+	for cluster := range r.upgradeDurations {
+		set[cluster] = struct{}{}
+	}
+	for key := range r.nodes {
+		set[key.cluster] = struct{}{}
+	}
+
+	clusters := make([]string, 0, len(set))
+	for cluster := range set {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	return clusters
+}
+
+// write writes the metrics of the given cluster, or of all clusters if it's
+// empty, in the Prometheus text or the OpenMetrics format
+func (r *Registry) write(w io.Writer, openMetrics bool, only string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var buf strings.Builder
+	header := func(name, kind, help string) {
+		writeHeader(&buf, name, kind, help, openMetrics)
+	}
+	selected := func(cluster string) bool {
+		return only == "" || cluster == only
+	}
+
+	clusters := make([]string, 0, len(r.reconcileDurations))
+	for cluster := range r.reconcileDurations {
+		if selected(cluster) {
+			clusters = append(clusters, cluster)
+		}
+	}
+	sort.Strings(clusters)
+
+	header("kubeone_reconcile_duration_seconds", "summary", "Duration of cluster reconciliations.")
+	for _, cluster := range clusters {
+		summary := r.reconcileDurations[cluster]
+		fmt.Fprintf(&buf, "kubeone_reconcile_duration_seconds_sum{cluster=%s} %g\n", labelValue(cluster), summary.sum)
+		fmt.Fprintf(&buf, "kubeone_reconcile_duration_seconds_count{cluster=%s} %d\n", labelValue(cluster), summary.count)
+	}
+
+	header("kubeone_last_apply_success", "gauge", "Whether the last reconciliation of the cluster succeeded.")
+	for _, cluster := range clusters {
+		success := 0
+		if r.lastApply[cluster].success {
+			success = 1
+		}
+		fmt.Fprintf(&buf, "kubeone_last_apply_success{cluster=%s} %d\n", labelValue(cluster), success)
+	}
+
+	header("kubeone_last_apply_timestamp_seconds", "gauge", "Time of the last reconciliation of the cluster.")
+	for _, cluster := range clusters {
+		fmt.Fprintf(&buf, "kubeone_last_apply_timestamp_seconds{cluster=%s} %d\n", labelValue(cluster), r.lastApply[cluster].timestamp.Unix())
+	}
+
+	keys := make([]taskKey, 0, len(r.taskFailures))
+	for key := range r.taskFailures {
+		if selected(key.cluster) {
+			keys = append(keys, key)
+		}
+	}
+	sortTaskKeys(keys)
+
+	header("kubeone_task_failures_total", "counter", "Number of failed task attempts, including the retried ones.")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "kubeone_task_failures_total{cluster=%s,task=%s} %d\n", labelValue(key.cluster), labelValue(key.task), r.taskFailures[key])
+	}
+
+	keys = make([]taskKey, 0, len(r.taskDurations))
+	for key := range r.taskDurations {
+		if selected(key.cluster) {
+			keys = append(keys, key)
+		}
+	}
+	sortTaskKeys(keys)
+
+	header("kubeone_task_duration_seconds", "summary", "Duration of tasks, including all their attempts.")
+	for _, key := range keys {
+		summary := r.taskDurations[key]
+		fmt.Fprintf(&buf, "kubeone_task_duration_seconds_sum{cluster=%s,task=%s} %g\n", labelValue(key.cluster), labelValue(key.task), summary.sum)
+		fmt.Fprintf(&buf, "kubeone_task_duration_seconds_count{cluster=%s,task=%s} %d\n", labelValue(key.cluster), labelValue(key.task), summary.count)
+	}
+
+	header("kubeone_task_runs_total", "counter", "Number of finished tasks by their result.")
+	for _, key := range keys {
+		results := r.taskResults[key]
+		fmt.Fprintf(&buf, "kubeone_task_runs_total{cluster=%s,task=%s,result=\"success\"} %d\n", labelValue(key.cluster), labelValue(key.task), results.success)
+		fmt.Fprintf(&buf, "kubeone_task_runs_total{cluster=%s,task=%s,result=\"failure\"} %d\n", labelValue(key.cluster), labelValue(key.task), results.failure)
+	}
+
+	upgraded := make([]string, 0, len(r.upgradeDurations))
+	for cluster := range r.upgradeDurations {
+		if selected(cluster) {
+			upgraded = append(upgraded, cluster)
+		}
+	}
+	sort.Strings(upgraded)
+
+	header("kubeone_upgrade_duration_seconds", "summary", "Duration of cluster upgrades.")
+	for _, cluster := range upgraded {
+		summary := r.upgradeDurations[cluster]
+		fmt.Fprintf(&buf, "kubeone_upgrade_duration_seconds_sum{cluster=%s} %g\n", labelValue(cluster), summary.sum)
+		fmt.Fprintf(&buf, "kubeone_upgrade_duration_seconds_count{cluster=%s} %d\n", labelValue(cluster), summary.count)
+	}
+
+	header("kubeone_upgrades_total", "counter", "Number of cluster upgrades by their result.")
+	for _, cluster := range upgraded {
+		results := r.upgradeResults[cluster]
+		fmt.Fprintf(&buf, "kubeone_upgrades_total{cluster=%s,result=\"success\"} %d\n", labelValue(cluster), results.success)
+		fmt.Fprintf(&buf, "kubeone_upgrades_total{cluster=%s,result=\"failure\"} %d\n", labelValue(cluster), results.failure)
+	}
+
+	nodeKeys := make([]nodesKey, 0, len(r.nodes))
+	for key := range r.nodes {
+		if selected(key.cluster) {
+			nodeKeys = append(nodeKeys, key)
+		}
+	}
+	sort.Slice(nodeKeys, func(i, j int) bool {
+		if nodeKeys[i].cluster != nodeKeys[j].cluster {
+			return nodeKeys[i].cluster < nodeKeys[j].cluster
+		}
+		return nodeKeys[i].role < nodeKeys[j].role
+	})
+
+	header("kubeone_nodes", "gauge", "Number of nodes in the manifest of the cluster by their role.")
+	for _, key := range nodeKeys {
+		fmt.Fprintf(&buf, "kubeone_nodes{cluster=%s,role=%s} %d\n", labelValue(key.cluster), labelValue(key.role), r.nodes[key])
+	}
+
+	if openMetrics {
+		buf.WriteString("# EOF\n")
+	}
+
+	_, err := io.WriteString(w, buf.String())
+
+	return err
+}
+
+// ServeHTTP serves the metrics to the Prometheus scrapes
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentTypeText)
+	_ = r.Write(w)
+}
+
+// writeHeader writes the HELP and TYPE lines of the metric family. In the
+// OpenMetrics format, the name of counter families has no _total suffix.
+func writeHeader(buf *strings.Builder, name, kind, help string, openMetrics bool) {
+	if openMetrics && kind == "counter" {
+		name = strings.TrimSuffix(name, "_total")
+	}
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func sortTaskKeys(keys []taskKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].cluster != keys[j].cluster {
+			return keys[i].cluster < keys[j].cluster
+		}
+		return keys[i].task < keys[j].task
+	})
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func labelValue(value string) string {
+	return `"` + labelValueEscaper.Replace(value) + `"`
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	r := NewRegistry()
+	r.now = func() time.Time { return time.Unix(1630000000, 0) }
+
+	r.ObserveReconcile("prod", 90*time.Second, nil)
+	r.ObserveReconcile("prod", 30*time.Second, errors.New("failed"))
+	r.ObserveReconcile("edge-1", 45*time.Second, nil)
+	r.TaskFailed("prod", "install prerequisites")
+	r.TaskFailed("prod", "install prerequisites")
+	r.TaskFailed("edge-1", `join "worker"`)
+
+	var buf strings.Builder
+	if err := r.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	expected := `# HELP kubeone_reconcile_duration_seconds Duration of cluster reconciliations.
+# TYPE kubeone_reconcile_duration_seconds summary
+kubeone_reconcile_duration_seconds_sum{cluster="edge-1"} 45
+kubeone_reconcile_duration_seconds_count{cluster="edge-1"} 1
+kubeone_reconcile_duration_seconds_sum{cluster="prod"} 120
+kubeone_reconcile_duration_seconds_count{cluster="prod"} 2
+# HELP kubeone_last_apply_success Whether the last reconciliation of the cluster succeeded.
+# TYPE kubeone_last_apply_success gauge
+kubeone_last_apply_success{cluster="edge-1"} 1
+kubeone_last_apply_success{cluster="prod"} 0
+# HELP kubeone_last_apply_timestamp_seconds Time of the last reconciliation of the cluster.
+# TYPE kubeone_last_apply_timestamp_seconds gauge
+kubeone_last_apply_timestamp_seconds{cluster="edge-1"} 1630000000
+kubeone_last_apply_timestamp_seconds{cluster="prod"} 1630000000
+# HELP kubeone_task_failures_total Number of failed task attempts, including the retried ones.
+# TYPE kubeone_task_failures_total counter
+kubeone_task_failures_total{cluster="edge-1",task="join \"worker\""} 1
+kubeone_task_failures_total{cluster="prod",task="install prerequisites"} 2
+# HELP kubeone_task_duration_seconds Duration of tasks, including all their attempts.
+# TYPE kubeone_task_duration_seconds summary
+# HELP kubeone_task_runs_total Number of finished tasks by their result.
+# TYPE kubeone_task_runs_total counter
+# HELP kubeone_upgrade_duration_seconds Duration of cluster upgrades.
+# TYPE kubeone_upgrade_duration_seconds summary
+# HELP kubeone_upgrades_total Number of cluster upgrades by their result.
+# TYPE kubeone_upgrades_total counter
+# HELP kubeone_nodes Number of nodes in the manifest of the cluster by their role.
+# TYPE kubeone_nodes gauge
+`
+	if buf.String() != expected {
+		t.Errorf("expected metrics:\n%s\nbut got:\n%s", expected, buf.String())
+	}
+}
+
+func TestRegistryWriteOpenMetrics(t *testing.T) {
+	r := NewRegistry()
+
+	r.ObserveTask("prod", "install prerequisites", 3*time.Second, nil)
+	r.ObserveTask("prod", "install prerequisites", 5*time.Second, errors.New("failed"))
+	r.ObserveUpgrade("prod", 10*time.Minute, nil)
+	r.SetNodes("prod", RoleControlPlane, 3)
+	r.SetNodes("prod", RoleStaticWorker, 2)
+
+	var buf strings.Builder
+	if err := r.WriteOpenMetrics(&buf); err != nil {
+		t.Fatalf("WriteOpenMetrics() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, expected := range []string{
+		"# TYPE kubeone_task_failures counter\n",
+		`kubeone_task_duration_seconds_sum{cluster="prod",task="install prerequisites"} 8` + "\n",
+		`kubeone_task_duration_seconds_count{cluster="prod",task="install prerequisites"} 2` + "\n",
+		"# TYPE kubeone_task_runs counter\n",
+		`kubeone_task_runs_total{cluster="prod",task="install prerequisites",result="success"} 1` + "\n",
+		`kubeone_task_runs_total{cluster="prod",task="install prerequisites",result="failure"} 1` + "\n",
+		`kubeone_upgrade_duration_seconds_sum{cluster="prod"} 600` + "\n",
+		`kubeone_upgrades_total{cluster="prod",result="success"} 1` + "\n",
+		`kubeone_nodes{cluster="prod",role="control-plane"} 3` + "\n",
+		`kubeone_nodes{cluster="prod",role="static-worker"} 2` + "\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected metrics to contain %q, but got:\n%s", expected, out)
+		}
+	}
+
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Errorf("expected metrics to end with # EOF, but got:\n%s", out)
+	}
+}
+
+func TestRegistryPush(t *testing.T) {
+	var (
+		lock   sync.Mutex
+		pushed = map[string]string{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			t.Errorf("expected PUT request, but got %s", req.Method)
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+
+		lock.Lock()
+		pushed[req.URL.Path] = string(body)
+		lock.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	r := NewRegistry()
+	r.ObserveReconcile("prod", time.Minute, nil)
+	r.SetNodes("edge-1", RoleControlPlane, 1)
+
+	if err := r.Push(context.Background(), server.URL+"/", "kubeone"); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	if len(pushed) != 2 {
+		t.Fatalf("expected metrics of 2 clusters to be pushed, but got %v", pushed)
+	}
+
+	prod := pushed["/metrics/job/kubeone/cluster/prod"]
+	if !strings.Contains(prod, `kubeone_reconcile_duration_seconds_count{cluster="prod"} 1`) || strings.Contains(prod, "edge-1") {
+		t.Errorf("expected only the metrics of the prod cluster, but got:\n%s", prod)
+	}
+
+	edge := pushed["/metrics/job/kubeone/cluster/edge-1"]
+	if !strings.Contains(edge, `kubeone_nodes{cluster="edge-1",role="control-plane"} 1`) || strings.Contains(edge, "prod") {
+		t.Errorf("expected only the metrics of the edge-1 cluster, but got:\n%s", edge)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failing.Close()
+
+	if err := r.Push(context.Background(), failing.URL, "kubeone"); err == nil {
+		t.Errorf("expected Push() to fail on the error status")
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notifications notifies the user-defined endpoints, such as the
+// webhooks and the Slack and Microsoft Teams channels, with the summary of
+// the KubeOne operations when they finish.
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+// Operations the notifications are sent for
+const (
+	OperationApply   = "apply"
+	OperationUpgrade = "upgrade"
+	OperationReset   = "reset"
+)
+
+// Results of the operations
+const (
+	ResultSucceeded = "succeeded"
+	ResultFailed    = "failed"
+)
+
+const (
+	timeout   = 30 * time.Second
+	envPrefix = "env:"
+
+	teamsColorSucceeded = "2DC72D"
+	teamsColorFailed    = "D70000"
+)
+
+// Summary describes the finished operation. It's sent to the webhooks as
+// the JSON body, and formatted as the message for Slack and Teams.
+type Summary struct {
+	Operation       string    `json:"operation"`
+	Cluster         string    `json:"cluster"`
+	Result          string    `json:"result"`
+	StartedAt       time.Time `json:"startedAt"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	// Error is the error the operation failed with
+	Error string `json:"error,omitempty"`
+	// FailedTasks are the tasks that failed after all their attempts
+	FailedTasks []string `json:"failedTasks,omitempty"`
+}
+
+// NewSummary returns the summary of the operation started at the given
+// time. The opErr is nil for the succeeded operations.
+func NewSummary(operation, cluster string, startedAt time.Time, opErr error, failedTasks []string) Summary {
+	summary := Summary{
+		Operation:       operation,
+		Cluster:         cluster,
+		Result:          ResultSucceeded,
+		StartedAt:       startedAt.UTC(),
+		DurationSeconds: time.Since(startedAt).Round(time.Second).Seconds(),
+		FailedTasks:     failedTasks,
+	}
+
+	if opErr != nil {
+		summary.Result = ResultFailed
+		summary.Error = opErr.Error()
+	}
+
+	return summary
+}
+
+// Message returns the summary as the human-readable message
+func (s Summary) Message() string {
+	var b strings.Builder
+
+	duration := time.Duration(s.DurationSeconds * float64(time.Second))
+	fmt.Fprintf(&b, "KubeOne %s of cluster %q %s after %s", s.Operation, s.Cluster, s.Result, duration)
+	if len(s.FailedTasks) > 0 {
+		fmt.Fprintf(&b, "\nFailed tasks: %s", strings.Join(s.FailedTasks, ", "))
+	}
+	if s.Error != "" {
+		fmt.Fprintf(&b, "\nError: %s", s.Error)
+	}
+
+	return b.String()
+}
+
+// Send notifies all endpoints with the summary. The endpoints are notified
+// one by one, and failing to notify one of them is logged instead of
+// failing the operation.
+func Send(ctx context.Context, logger logrus.FieldLogger, notifications []kubeoneapi.Notification, summary Summary) {
+	for _, n := range notifications {
+		if n.OnlyOnFailure && summary.Result != ResultFailed {
+			continue
+		}
+
+		name := n.Name
+		if name == "" {
+			name = string(n.Type)
+		}
+
+		logger.Debugf("Sending %q notification...", name)
+		if err := send(ctx, n, summary); err != nil {
+			logger.Warnf("Failed to send %q notification: %v", name, err)
+		}
+	}
+}
+
+func send(ctx context.Context, n kubeoneapi.Notification, summary Summary) error {
+	body, err := Body(n.Type, summary)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fromEnv(n.URL)
+	if endpoint == "" {
+		return errors.Errorf("the URL is not set in the %s environment variable", strings.TrimPrefix(n.URL, envPrefix))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create the request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range n.Headers {
+		req.Header.Set(k, fromEnv(v))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call the endpoint")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("endpoint responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Body returns the JSON body of the request notifying the endpoint of the
+// given type
+func Body(notificationType kubeoneapi.NotificationType, summary Summary) ([]byte, error) {
+	var payload interface{}
+
+	switch notificationType {
+	case kubeoneapi.NotificationTypeWebhook, "":
+		payload = summary
+	case kubeoneapi.NotificationTypeSlack:
+		payload = map[string]string{
+			"text": summary.Message(),
+		}
+	case kubeoneapi.NotificationTypeTeams:
+		color := teamsColorSucceeded
+		if summary.Result == ResultFailed {
+			color = teamsColorFailed
+		}
+
+		lines := strings.SplitN(summary.Message(), "\n", 2)
+		text := ""
+		if len(lines) > 1 {
+			text = strings.ReplaceAll(lines[1], "\n", "\n\n")
+		}
+
+		payload = map[string]string{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    lines[0],
+			"title":      lines[0],
+			"text":       text,
+			"themeColor": color,
+		}
+	default:
+		return nil, errors.Errorf("unknown notification type %q", notificationType)
+	}
+
+	body, err := json.Marshal(payload)
+
+	return body, errors.Wrap(err, "failed to marshal the notification")
+}
+
+func fromEnv(value string) string {
+	if strings.HasPrefix(value, envPrefix) {
+		return os.Getenv(strings.TrimPrefix(value, envPrefix))
+	}
+
+	return value
+}
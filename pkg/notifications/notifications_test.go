@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func testSummary() Summary {
+	return Summary{
+		Operation:       OperationUpgrade,
+		Cluster:         "prod",
+		Result:          ResultFailed,
+		StartedAt:       time.Date(2021, 11, 3, 10, 0, 0, 0, time.UTC),
+		DurationSeconds: 312,
+		Error:           "failed to upgrade leader control plane: kubeadm upgrade failed",
+		FailedTasks:     []string{"upgrade leader control plane"},
+	}
+}
+
+func TestNewSummary(t *testing.T) {
+	summary := NewSummary(OperationApply, "prod", time.Now().Add(-90*time.Second), nil, nil)
+	if summary.Result != ResultSucceeded || summary.Error != "" {
+		t.Errorf("expected succeeded summary, but got %+v", summary)
+	}
+	if summary.DurationSeconds != 90 {
+		t.Errorf("expected duration of 90s, but got %v", summary.DurationSeconds)
+	}
+
+	summary = NewSummary(OperationReset, "prod", time.Now(), errors.New("boom"), []string{"reset"})
+	if summary.Result != ResultFailed || summary.Error != "boom" {
+		t.Errorf("expected failed summary, but got %+v", summary)
+	}
+}
+
+func TestBody(t *testing.T) {
+	tests := []struct {
+		notificationType kubeoneapi.NotificationType
+		expected         map[string]interface{}
+	}{
+		{
+			notificationType: kubeoneapi.NotificationTypeWebhook,
+			expected: map[string]interface{}{
+				"operation":       "upgrade",
+				"cluster":         "prod",
+				"result":          "failed",
+				"startedAt":       "2021-11-03T10:00:00Z",
+				"durationSeconds": float64(312),
+				"error":           "failed to upgrade leader control plane: kubeadm upgrade failed",
+				"failedTasks":     []interface{}{"upgrade leader control plane"},
+			},
+		},
+		{
+			notificationType: kubeoneapi.NotificationTypeSlack,
+			expected: map[string]interface{}{
+				"text": "KubeOne upgrade of cluster \"prod\" failed after 5m12s\n" +
+					"Failed tasks: upgrade leader control plane\n" +
+					"Error: failed to upgrade leader control plane: kubeadm upgrade failed",
+			},
+		},
+		{
+			notificationType: kubeoneapi.NotificationTypeTeams,
+			expected: map[string]interface{}{
+				"@type":      "MessageCard",
+				"@context":   "https://schema.org/extensions",
+				"summary":    "KubeOne upgrade of cluster \"prod\" failed after 5m12s",
+				"title":      "KubeOne upgrade of cluster \"prod\" failed after 5m12s",
+				"text":       "Failed tasks: upgrade leader control plane\n\nError: failed to upgrade leader control plane: kubeadm upgrade failed",
+				"themeColor": "D70000",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(string(tc.notificationType), func(t *testing.T) {
+			body, err := Body(tc.notificationType, testSummary())
+			if err != nil {
+				t.Fatalf("Body() error = %v", err)
+			}
+
+			got := map[string]interface{}{}
+			if err = json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("failed to unmarshal body: %v", err)
+			}
+
+			expected, _ := json.Marshal(tc.expected)
+			actual, _ := json.Marshal(got)
+			if string(expected) != string(actual) {
+				t.Errorf("expected body %s, but got %s", expected, actual)
+			}
+		})
+	}
+
+	if _, err := Body("pager", testSummary()); err == nil {
+		t.Errorf("expected unknown notification type to fail")
+	}
+}
+
+func TestSend(t *testing.T) {
+	var received []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		received = append(received, req.URL.Path+" "+req.Header.Get("Authorization")+" "+string(body))
+
+		if req.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("KUBEONE_TEST_NOTIFICATION_URL", server.URL+"/slack")
+	defer os.Unsetenv("KUBEONE_TEST_NOTIFICATION_URL")
+	os.Setenv("KUBEONE_TEST_NOTIFICATION_TOKEN", "Bearer secret")
+	defer os.Unsetenv("KUBEONE_TEST_NOTIFICATION_TOKEN")
+
+	notifications := []kubeoneapi.Notification{
+		{Type: kubeoneapi.NotificationTypeWebhook, URL: server.URL + "/broken"},
+		{Type: kubeoneapi.NotificationTypeSlack, URL: "env:KUBEONE_TEST_NOTIFICATION_URL"},
+		{
+			Type:    kubeoneapi.NotificationTypeWebhook,
+			URL:     server.URL + "/webhook",
+			Headers: map[string]string{"Authorization": "env:KUBEONE_TEST_NOTIFICATION_TOKEN"},
+		},
+		{Type: kubeoneapi.NotificationTypeTeams, URL: server.URL + "/teams", OnlyOnFailure: true},
+	}
+
+	summary := testSummary()
+	summary.Result = ResultSucceeded
+	summary.Error = ""
+	summary.FailedTasks = nil
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	Send(context.Background(), logger, notifications, summary)
+
+	if len(received) != 3 {
+		t.Fatalf("expected 3 requests, the failing endpoint not stopping the others, but got %v", received)
+	}
+	if !strings.HasPrefix(received[1], "/slack  {\"text\":") {
+		t.Errorf("expected Slack message at the URL from the environment, but got %q", received[1])
+	}
+	if !strings.HasPrefix(received[2], "/webhook Bearer secret {") {
+		t.Errorf("expected webhook with the header from the environment, but got %q", received[2])
+	}
+}
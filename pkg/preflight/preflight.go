@@ -0,0 +1,248 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preflight evaluates the facts collected from the hosts against the
+// requirements of provisioning them, and reports the results.
+package preflight
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/tabwriter"
+)
+
+// Severity is the severity of the failed check
+type Severity string
+
+const (
+	// SeverityError fails the preflight checks
+	SeverityError Severity = "error"
+	// SeverityWarning is only reported
+	SeverityWarning Severity = "warning"
+)
+
+// Report formats
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// Names of the checks
+const (
+	CheckSSH              = "ssh"
+	CheckSudo             = "sudo"
+	CheckCPU              = "cpu"
+	CheckMemory           = "memory"
+	CheckDisk             = "disk"
+	CheckTimeSynchronized = "time-synchronized"
+	CheckPort             = "port"
+	CheckDNS              = "dns"
+	CheckKernelModule     = "kernel-module"
+	CheckFIPS             = "fips"
+)
+
+// Result is the result of the single check on the host
+type Result struct {
+	Host     string   `json:"host"`
+	Check    string   `json:"check"`
+	Passed   bool     `json:"passed"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message,omitempty"`
+}
+
+// Report is the result of all checks on all hosts
+type Report struct {
+	Passed  bool     `json:"passed"`
+	Results []Result `json:"results"`
+}
+
+// Requirements are the requirements the host must meet
+type Requirements struct {
+	CPUs          int
+	MemoryMiB     int
+	DiskFreeGiB   int
+	Ports         []int
+	DNSNames      []string
+	KernelModules []string
+	// FIPS requires the kernel to be running in the FIPS mode
+	FIPS bool
+}
+
+// NewReport returns the report of the given results. The report is passed
+// unless any of the checks with the error severity failed.
+func NewReport(results []Result) *Report {
+	report := &Report{
+		Passed:  true,
+		Results: results,
+	}
+
+	for _, r := range results {
+		if !r.Passed && r.Severity == SeverityError {
+			report.Passed = false
+		}
+	}
+
+	return report
+}
+
+// Failures returns the failed checks
+func (r *Report) Failures() []Result {
+	failures := []Result{}
+	for _, result := range r.Results {
+		if !result.Passed {
+			failures = append(failures, result)
+		}
+	}
+
+	return failures
+}
+
+// Print prints the report in the given format
+func (r *Report) Print(w io.Writer, format string) error {
+	switch format {
+	case OutputJSON:
+		buf, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal preflight report")
+		}
+		_, err = fmt.Fprintln(w, string(buf))
+
+		return errors.WithStack(err)
+	case OutputText, "":
+		printer := tabwriter.GetNewTabWriter(w)
+		fmt.Fprintln(printer, "HOST\tCHECK\tSTATUS\tMESSAGE\t")
+		for _, result := range r.Results {
+			status := "passed"
+			if !result.Passed {
+				status = string(result.Severity)
+			}
+			fmt.Fprintf(printer, "%s\t%s\t%s\t%s\t\n", result.Host, result.Check, status, result.Message)
+		}
+
+		return errors.WithStack(printer.Flush())
+	default:
+		return errors.Errorf("unknown output format %q", format)
+	}
+}
+
+// Unreachable returns the result of the host which can't be reached over SSH
+func Unreachable(host string, err error) Result {
+	return Result{
+		Host:     host,
+		Check:    CheckSSH,
+		Severity: SeverityError,
+		Message:  err.Error(),
+	}
+}
+
+// ParseFacts parses the key=value facts printed by the preflight script
+func ParseFacts(out string) map[string]string {
+	facts := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(kv) == 2 {
+			facts[kv[0]] = kv[1]
+		}
+	}
+
+	return facts
+}
+
+// Evaluate returns the results of checking the facts collected from the host
+// against the requirements. The ports are not checked on the hosts which are
+// already provisioned, as they are used by the Kubernetes components.
+func Evaluate(host string, req Requirements, facts map[string]string) []Result {
+	results := []Result{
+		{Host: host, Check: CheckSSH, Passed: true, Severity: SeverityError},
+	}
+
+	add := func(check string, severity Severity, passed bool, format string, args ...interface{}) {
+		results = append(results, Result{
+			Host:     host,
+			Check:    check,
+			Passed:   passed,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	if facts["sudo"] == "ok" {
+		add(CheckSudo, SeverityError, true, "")
+	} else {
+		add(CheckSudo, SeverityError, false, "passwordless sudo is not allowed")
+	}
+
+	cpus, _ := strconv.Atoi(facts["cpus"])
+	add(CheckCPU, SeverityError, cpus >= req.CPUs, "%d CPUs, at least %d required", cpus, req.CPUs)
+
+	memoryMiB, _ := strconv.Atoi(facts["memory_kib"])
+	memoryMiB /= 1024
+	add(CheckMemory, SeverityError, memoryMiB >= req.MemoryMiB, "%d MiB, at least %d MiB required", memoryMiB, req.MemoryMiB)
+
+	diskGiB, _ := strconv.Atoi(facts["disk_free_kib"])
+	diskGiB /= 1024 * 1024
+	add(CheckDisk, SeverityError, diskGiB >= req.DiskFreeGiB, "%d GiB free in /var/lib, at least %d GiB required", diskGiB, req.DiskFreeGiB)
+
+	switch facts["time_synchronized"] {
+	case "yes":
+		add(CheckTimeSynchronized, SeverityWarning, true, "")
+	case "no":
+		add(CheckTimeSynchronized, SeverityWarning, false, "system clock is not synchronized")
+	default:
+		add(CheckTimeSynchronized, SeverityWarning, false, "system clock synchronization can't be determined")
+	}
+
+	if facts["provisioned"] != "true" {
+		for _, port := range req.Ports {
+			inUse := facts[fmt.Sprintf("port/%d", port)] != "free"
+			add(CheckPort, SeverityError, !inUse, "port %d%s", port, message(inUse, " is in use"))
+		}
+	}
+
+	// kubeadm only warns about the hostname which can't be resolved
+	failed := facts["dns/hostname"] != "ok"
+	add(CheckDNS, SeverityWarning, !failed, "hostname%s", message(failed, " can't be resolved"))
+	for _, name := range req.DNSNames {
+		failed = facts["dns/"+name] != "ok"
+		add(CheckDNS, SeverityError, !failed, "%s%s", name, message(failed, " can't be resolved"))
+	}
+
+	for _, module := range req.KernelModules {
+		missing := facts["module/"+module] != "ok"
+		add(CheckKernelModule, SeverityError, !missing, "%s%s", module, message(missing, " is not available"))
+	}
+
+	if req.FIPS {
+		disabled := facts["fips_enabled"] != "1"
+		add(CheckFIPS, SeverityError, !disabled, "kernel%s", message(disabled, " is not running in FIPS mode"))
+	}
+
+	return results
+}
+
+func message(failed bool, msg string) string {
+	if failed {
+		return msg
+	}
+
+	return ""
+}
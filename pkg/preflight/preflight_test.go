@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"errors"
+	"testing"
+)
+
+const passingFacts = `
+sudo=ok
+provisioned=false
+cpus=2
+memory_kib=4026532
+disk_free_kib=41943040
+time_synchronized=yes
+port/6443=free
+port/10250=free
+dns/hostname=ok
+dns/api.example.com=ok
+module/overlay=ok
+fips_enabled=1
+`
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	req := Requirements{
+		CPUs:          2,
+		MemoryMiB:     1700,
+		DiskFreeGiB:   10,
+		Ports:         []int{6443, 10250},
+		DNSNames:      []string{"api.example.com"},
+		KernelModules: []string{"overlay"},
+		FIPS:          true,
+	}
+
+	tests := []struct {
+		name           string
+		facts          map[string]string
+		expectedFailed []string
+		expectedPassed bool
+	}{
+		{
+			name:           "all checks passed",
+			facts:          ParseFacts(passingFacts),
+			expectedPassed: true,
+		},
+		{
+			name: "not enough resources",
+			facts: withFacts(map[string]string{
+				"cpus":          "1",
+				"memory_kib":    "1015808",
+				"disk_free_kib": "1048576",
+			}),
+			expectedFailed: []string{CheckCPU, CheckMemory, CheckDisk},
+		},
+		{
+			name: "port in use on the new host",
+			facts: withFacts(map[string]string{
+				"port/6443": "in-use",
+			}),
+			expectedFailed: []string{CheckPort},
+		},
+		{
+			name: "port in use on the provisioned host",
+			facts: withFacts(map[string]string{
+				"provisioned": "true",
+				"port/6443":   "in-use",
+			}),
+			expectedPassed: true,
+		},
+		{
+			name: "clock and hostname are only warnings",
+			facts: withFacts(map[string]string{
+				"time_synchronized": "unknown",
+				"dns/hostname":      "fail",
+			}),
+			expectedFailed: []string{CheckTimeSynchronized, CheckDNS},
+			expectedPassed: true,
+		},
+		{
+			name: "sudo, api endpoint and kernel module",
+			facts: withFacts(map[string]string{
+				"sudo":                "fail",
+				"dns/api.example.com": "fail",
+				"module/overlay":      "missing",
+			}),
+			expectedFailed: []string{CheckSudo, CheckDNS, CheckKernelModule},
+		},
+		{
+			name: "kernel not running in FIPS mode",
+			facts: withFacts(map[string]string{
+				"fips_enabled": "0",
+			}),
+			expectedFailed: []string{CheckFIPS},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := NewReport(Evaluate("10.0.0.1", req, tc.facts))
+			if report.Passed != tc.expectedPassed {
+				t.Errorf("expected passed %v, but got %v", tc.expectedPassed, report.Passed)
+			}
+
+			failed := []string{}
+			for _, r := range report.Failures() {
+				failed = append(failed, r.Check)
+			}
+			if len(failed) != len(tc.expectedFailed) {
+				t.Fatalf("expected failed checks %v, but got %v", tc.expectedFailed, failed)
+			}
+			for i := range failed {
+				if failed[i] != tc.expectedFailed[i] {
+					t.Errorf("expected failed checks %v, but got %v", tc.expectedFailed, failed)
+				}
+			}
+		})
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	t.Parallel()
+
+	report := NewReport([]Result{Unreachable("10.0.0.1", errors.New("connection refused"))})
+	if report.Passed {
+		t.Error("expected the report with the unreachable host to fail")
+	}
+}
+
+func withFacts(overrides map[string]string) map[string]string {
+	facts := ParseFacts(passingFacts)
+	for k, v := range overrides {
+		facts[k] = v
+	}
+
+	return facts
+}
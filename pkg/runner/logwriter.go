@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewLogWriter constructor
+func NewLogWriter(logger logrus.FieldLogger, stream string) *LogWriter {
+	return &LogWriter{
+		logger: logger.WithField("stream", stream),
+	}
+}
+
+// LogWriter logs every line of the command output as the structured log
+// entry, so the output doesn't break the JSON logs
+type LogWriter struct {
+	logger logrus.FieldLogger
+	buffer bytes.Buffer
+}
+
+func (w *LogWriter) Write(p []byte) (int, error) {
+	w.buffer.Write(p)
+
+	for {
+		i := bytes.IndexByte(w.buffer.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		w.log(w.buffer.Next(i + 1)[:i])
+	}
+
+	return len(p), nil
+}
+
+// Close logs the last line if it's not terminated by the newline
+func (w *LogWriter) Close() error {
+	if w.buffer.Len() > 0 {
+		w.log(w.buffer.Bytes())
+		w.buffer.Reset()
+	}
+
+	return nil
+}
+
+func (w *LogWriter) log(line []byte) {
+	w.logger.WithField("output", string(bytes.TrimSuffix(line, []byte("\r")))).Info("command output")
+}
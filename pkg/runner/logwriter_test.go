@@ -0,0 +1,52 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLogWriter(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+
+	w := NewLogWriter(logger.WithField("node", "192.0.2.1"), "stderr")
+	for _, chunk := range []string{"+ apt-get", " update\nHit:1 http://archive", ".ubuntu.com\r\n", "Reading package lists..."} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	expected := []string{"+ apt-get update", "Hit:1 http://archive.ubuntu.com", "Reading package lists..."}
+	got := []string{}
+	for _, entry := range hook.AllEntries() {
+		if entry.Data["node"] != "192.0.2.1" || entry.Data["stream"] != "stderr" || entry.Level != logrus.InfoLevel {
+			t.Errorf("unexpected log entry fields %v at level %s", entry.Data, entry.Level)
+		}
+		got = append(got, entry.Data["output"].(string))
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the output lines %q, but got %q", expected, got)
+	}
+}
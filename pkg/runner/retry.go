@@ -0,0 +1,128 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/ssh"
+)
+
+const (
+	defaultRetryAttempts = 3
+	defaultRetryBackoff  = 5 * time.Second
+)
+
+// RetryPolicy retries the commands that couldn't be started because the SSH
+// session couldn't be opened, and the commands failing with the retryable
+// errors
+type RetryPolicy struct {
+	Attempts  int
+	Backoff   time.Duration
+	Retryable []*regexp.Regexp
+}
+
+// NewRetryPolicy returns the retry policy for the commands run by the given
+// task, filling in the defaults for the fields not set in the configuration.
+// The commands are not retried, and nil is returned, if the retry policy is
+// not configured.
+func NewRetryPolicy(cfg *kubeoneapi.CommandRetryPolicy, taskName string) (*RetryPolicy, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	policy := &RetryPolicy{
+		Attempts: defaultRetryAttempts,
+		Backoff:  defaultRetryBackoff,
+	}
+
+	if cfg.Attempts > 0 {
+		policy.Attempts = cfg.Attempts
+	}
+	if attempts, ok := cfg.TaskAttempts[taskName]; ok {
+		policy.Attempts = attempts
+	}
+	if cfg.Backoff.Duration > 0 {
+		policy.Backoff = cfg.Backoff.Duration
+	}
+
+	for _, expr := range cfg.RetryableErrors {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse retryable error %q", expr)
+		}
+		policy.Retryable = append(policy.Retryable, re)
+	}
+
+	return policy, nil
+}
+
+// retryable returns true if the command couldn't be started, or if the error
+// or the stderr of the failed command match one of the retryable errors. The
+// commands traced by the scripts (set -x) are not matched, as they'd match
+// the error messages the scripts are looking for.
+func (p *RetryPolicy) retryable(err error, stderr string) bool {
+	if err == nil {
+		return false
+	}
+
+	var notStarted *ssh.NotStartedError
+	if errors.As(err, &notStarted) {
+		return true
+	}
+
+	output := []string{err.Error()}
+	for _, line := range strings.Split(stderr, "\n") {
+		if !strings.HasPrefix(line, "+") {
+			output = append(output, line)
+		}
+	}
+
+	for _, re := range p.Retryable {
+		if re.MatchString(strings.Join(output, "\n")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// run runs the command until it succeeds, fails with the non-retryable
+// error, or runs out of the attempts. The backoff is doubled before every
+// retry. onRetry is called before every retry.
+func (p *RetryPolicy) run(fn func() (string, string, error), onRetry func(attempt int, err error)) (string, string, error) {
+	stdout, stderr, err := fn()
+
+	backoff := p.Backoff
+	for attempt := 2; attempt <= p.Attempts && p.retryable(err, stderr); attempt++ {
+		if onRetry != nil {
+			onRetry(attempt, err)
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+
+		stdout, stderr, err = fn()
+	}
+
+	return stdout, stderr, err
+}
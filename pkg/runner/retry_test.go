@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/ssh"
+)
+
+func TestNewRetryPolicy(t *testing.T) {
+	policy, err := NewRetryPolicy(&kubeoneapi.CommandRetryPolicy{
+		Attempts:     5,
+		TaskAttempts: map[string]int{"install-prerequisites": 10},
+	}, "install-prerequisites")
+	if err != nil {
+		t.Fatalf("NewRetryPolicy() error = %v", err)
+	}
+
+	if policy.Attempts != 10 {
+		t.Errorf("expected the task attempts 10 to override the attempts, but got %d", policy.Attempts)
+	}
+	if policy.Backoff != defaultRetryBackoff {
+		t.Errorf("expected the default backoff %s, but got %s", defaultRetryBackoff, policy.Backoff)
+	}
+	if len(policy.Retryable) != 0 {
+		t.Errorf("expected no retryable errors, but got %v", policy.Retryable)
+	}
+}
+
+func TestNewRetryPolicyNotConfigured(t *testing.T) {
+	policy, err := NewRetryPolicy(nil, "install-prerequisites")
+	if err != nil {
+		t.Fatalf("NewRetryPolicy() error = %v", err)
+	}
+
+	if policy != nil {
+		t.Errorf("expected the commands not to be retried, but got %+v", policy)
+	}
+}
+
+func TestRetryPolicyRun(t *testing.T) {
+	tests := []struct {
+		name             string
+		errs             []error
+		stderr           string
+		expectedAttempts int
+		expectedErr      bool
+	}{
+		{
+			name:             "success",
+			errs:             []error{nil},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "session not started",
+			errs:             []error{&ssh.NotStartedError{Err: io.EOF}, nil},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "connection dropped while running",
+			errs:             []error{errors.New("wait: remote command exited without exit status or exit signal"), nil},
+			stderr:           "connection reset by peer",
+			expectedAttempts: 1,
+			expectedErr:      true,
+		},
+		{
+			name:             "apt lock contention",
+			errs:             []error{errors.New("Process exited with status 100"), errors.New("Process exited with status 100"), nil},
+			stderr:           "E: Could not get lock /var/lib/dpkg/lock-frontend",
+			expectedAttempts: 3,
+		},
+		{
+			name:             "traced command matching",
+			errs:             []error{errors.New("Process exited with status 1"), nil},
+			stderr:           "+ grep -q 'Could not get lock' /tmp/apt.log",
+			expectedAttempts: 1,
+			expectedErr:      true,
+		},
+		{
+			name:             "non-retryable error",
+			errs:             []error{errors.New("Process exited with status 1"), nil},
+			expectedAttempts: 1,
+			expectedErr:      true,
+		},
+		{
+			name: "out of attempts",
+			errs: []error{
+				errors.WithStack(&ssh.NotStartedError{Err: io.EOF}),
+				errors.WithStack(&ssh.NotStartedError{Err: io.EOF}),
+				errors.WithStack(&ssh.NotStartedError{Err: io.EOF}),
+				nil,
+			},
+			expectedAttempts: 3,
+			expectedErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := NewRetryPolicy(&kubeoneapi.CommandRetryPolicy{
+				RetryableErrors: []string{"Could not get lock"},
+			}, "")
+			if err != nil {
+				t.Fatalf("NewRetryPolicy() error = %v", err)
+			}
+			policy.Backoff = 0
+
+			attempts := 0
+			_, _, err = policy.run(func() (string, string, error) {
+				err := tt.errs[attempts]
+				attempts++
+				if err != nil {
+					return "", tt.stderr, err
+				}
+
+				return "ok", "", nil
+			}, nil)
+
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("run() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, but got %d", tt.expectedAttempts, attempts)
+			}
+		})
+	}
+}
@@ -17,11 +17,13 @@ limitations under the License.
 package runner
 
 import (
+	"io"
 	"io/fs"
 	"os"
 
 	"github.com/koron-go/prefixw"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/scripts"
@@ -35,7 +37,16 @@ type Runner struct {
 	Conn    ssh.Connection
 	Prefix  string
 	OS      kubeoneapi.OperatingSystemName
+	Env     map[string]string
 	Verbose bool
+	// StructuredOutput logs the output of the commands in the verbose mode
+	// through the Logger instead of printing it prefixed to stdout/stderr
+	StructuredOutput bool
+	// Retry retries the commands failing with the transient errors. The
+	// commands are not retried if it's nil.
+	Retry *RetryPolicy
+	// Logger logs the retried commands, if set
+	Logger logrus.FieldLogger
 }
 
 // TemplateVariables is a render context for templates
@@ -50,6 +61,22 @@ func (r *Runner) RunRaw(cmd string) (string, string, error) {
 		return "", "", errors.New("runner is not tied to an opened SSH connection")
 	}
 
+	cmd = scripts.WithEnvironment(cmd, r.Env)
+
+	if r.Retry == nil {
+		return r.runRaw(cmd)
+	}
+
+	return r.Retry.run(func() (string, string, error) {
+		return r.runRaw(cmd)
+	}, func(attempt int, err error) {
+		if r.Logger != nil {
+			r.Logger.Warnf("Retrying command (attempt %d/%d) after transient error: %v", attempt, r.Retry.Attempts, err)
+		}
+	})
+}
+
+func (r *Runner) runRaw(cmd string) (string, string, error) {
 	if !r.Verbose {
 		stdout, stderr, _, err := r.Conn.Exec(cmd)
 		if err != nil {
@@ -59,10 +86,15 @@ func (r *Runner) RunRaw(cmd string) (string, string, error) {
 		return stdout, stderr, err
 	}
 
-	stdout := NewTee(prefixw.New(os.Stdout, r.Prefix))
+	var stdoutWriter, stderrWriter io.WriteCloser = prefixw.New(os.Stdout, r.Prefix), prefixw.New(os.Stderr, r.Prefix)
+	if r.StructuredOutput && r.Logger != nil {
+		stdoutWriter, stderrWriter = NewLogWriter(r.Logger, "stdout"), NewLogWriter(r.Logger, "stderr")
+	}
+
+	stdout := NewTee(stdoutWriter)
 	defer stdout.Close()
 
-	stderr := NewTee(prefixw.New(os.Stderr, r.Prefix))
+	stderr := NewTee(stderrWriter)
 	defer stderr.Close()
 
 	// run the command
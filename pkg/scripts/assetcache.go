@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	assetCacheScriptTemplate = heredoc.Doc(`
+		if type apt-get &>/dev/null; then
+			sudo apt-get update
+			sudo DEBIAN_FRONTEND=noninteractive apt-get install -y nginx
+		elif type amazon-linux-extras &>/dev/null; then
+			sudo amazon-linux-extras install -y nginx1
+		elif type yum &>/dev/null; then
+			sudo yum install -y nginx
+		else
+			echo "unable to install nginx for the asset cache on this operating system"
+			exit 1
+		fi
+
+		if type getenforce &>/dev/null && [[ "$(getenforce)" != "Disabled" ]]; then
+			sudo setsebool -P httpd_can_network_connect 1
+		fi
+
+		sudo mkdir -p /var/cache/kubeone-assets /etc/nginx/conf.d
+		echo "resolver $(awk '/^nameserver/ { print $2; exit }' /etc/resolv.conf) ipv6=off;" |
+			sudo tee /etc/nginx/conf.d/kubeone-asset-cache-resolver.conf >/dev/null
+
+		cat <<'EOF' | sudo tee /etc/nginx/conf.d/kubeone-asset-cache.conf >/dev/null
+		proxy_cache_path /var/cache/kubeone-assets levels=1:2 keys_zone=kubeone_assets:10m max_size=10g inactive=30d use_temp_path=off;
+
+		# only the hosts the assets are downloaded from are proxied, so the
+		# cache can't be used as an open proxy
+		map $kubeone_upstream_host $kubeone_upstream_allowed {
+			default 0;
+		{{- range .UPSTREAM_HOSTS }}
+			{{ . }} 1;
+		{{- end }}
+		}
+
+		map $upstream_http_location $kubeone_redirect_allowed {
+			default 0;
+		{{- range .UPSTREAM_HOSTS }}
+			"~^https?://{{ replace "." "[.]" . }}([/?#]|$)" 1;
+		{{- end }}
+		}
+
+		server {
+			listen {{ .LISTEN_ADDRESS }};
+
+			proxy_cache kubeone_assets;
+			proxy_cache_valid 200 1h;
+			proxy_cache_revalidate on;
+			proxy_cache_lock on;
+			proxy_cache_use_stale error timeout updating;
+			proxy_ssl_server_name on;
+			proxy_intercept_errors on;
+			error_page 301 302 303 307 308 = @redirect;
+
+			location ~ ^/(?<kubeone_upstream_scheme>https?)/(?<kubeone_upstream_host>[^/]+)/(?<kubeone_upstream_path>.*)$ {
+				if ($kubeone_upstream_allowed = 0) {
+					return 403;
+				}
+				proxy_set_header Host $kubeone_upstream_host;
+				proxy_pass $kubeone_upstream_scheme://$kubeone_upstream_host/$kubeone_upstream_path$is_args$args;
+			}
+
+			location / {
+				return 404;
+			}
+
+			# redirects are followed only to the allowed hosts, such as from
+			# github.com to objects.githubusercontent.com
+			location @redirect {
+				if ($kubeone_redirect_allowed = 0) {
+					return 502;
+				}
+				set $kubeone_location $upstream_http_location;
+				proxy_pass $kubeone_location;
+			}
+		}
+		EOF
+
+		sudo nginx -t
+		sudo systemctl enable nginx
+		sudo systemctl restart nginx
+	`)
+)
+
+// upstreamHostRegex matches the hosts, optionally with the port, that can be
+// safely rendered into the nginx configuration
+var upstreamHostRegex = regexp.MustCompile(`^[a-z0-9.-]+(:[0-9]+)?$`)
+
+// assetCacheDefaultUpstreams are the hosts the packages and the binaries are
+// downloaded from by default, along with the hosts they redirect to
+var assetCacheDefaultUpstreams = []string{
+	"apt.kubernetes.io",
+	"cdn.dl.k8s.io",
+	"dl.k8s.io",
+	"github-releases.githubusercontent.com",
+	"github.com",
+	"objects.githubusercontent.com",
+	"packages.cloud.google.com",
+	"storage.googleapis.com",
+}
+
+// AssetCache installs and configures nginx as a caching proxy for the
+// binaries and packages downloaded by other nodes. nginx listens only on the
+// given private address and proxies only the default upstreams and the hosts
+// of the configured binary assets.
+func AssetCache(assets kubeone.AssetConfiguration, privateAddress string) (string, error) {
+	return Render(assetCacheScriptTemplate, Data{
+		"LISTEN_ADDRESS": net.JoinHostPort(privateAddress, strconv.Itoa(assets.Cache.Port)),
+		"UPSTREAM_HOSTS": assetCacheUpstreams(assets),
+	})
+}
+
+func assetCacheUpstreams(assets kubeone.AssetConfiguration) []string {
+	hosts := sets.NewString(assetCacheDefaultUpstreams...)
+
+	for _, asset := range []kubeone.BinaryAsset{assets.CNI, assets.NodeBinaries, assets.Kubectl} {
+		for _, assetURL := range append([]string{asset.URL}, asset.Mirrors...) {
+			if u, err := url.Parse(assetURL); err == nil && upstreamHostRegex.MatchString(strings.ToLower(u.Host)) {
+				hosts.Insert(strings.ToLower(u.Host))
+			}
+		}
+	}
+
+	return hosts.List()
+}
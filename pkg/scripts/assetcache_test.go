@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestAssetCache(t *testing.T) {
+	t.Parallel()
+
+	got, err := AssetCache(kubeone.AssetConfiguration{
+		NodeBinaries: kubeone.BinaryAsset{
+			URL:     "https://mirror.example.com/kubernetes-node-linux-amd64.tar.gz",
+			Mirrors: []string{"https://MIRROR2.example.com:8443/kubernetes-node-linux-amd64.tar.gz"},
+		},
+		Cache: &kubeone.AssetCache{Host: "10.0.0.1", Port: 8008},
+	}, "10.0.0.1")
+	if err != nil {
+		t.Errorf("AssetCache() error = %v", err)
+		return
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 
 	"k8c.io/kubeone/pkg/certificate/cabundle"
+	"k8c.io/kubeone/pkg/templates/konnectivity"
 )
 
 var (
@@ -36,15 +37,31 @@ var (
 			sudo mv {{ .WORK_DIR }}/cfg/audit-policy.yaml /etc/kubernetes/audit/policy.yaml
 			sudo chown root:root /etc/kubernetes/audit/policy.yaml
 		fi
+		if sudo test -f "{{ .WORK_DIR }}/cfg/audit-webhook-config.yaml"; then
+			sudo mkdir -p /etc/kubernetes/audit
+			sudo mv {{ .WORK_DIR }}/cfg/audit-webhook-config.yaml /etc/kubernetes/audit/webhook-config.yaml
+			sudo chown root:root /etc/kubernetes/audit/webhook-config.yaml
+			sudo chmod 600 /etc/kubernetes/audit/webhook-config.yaml
+		fi
 	`)
 
-	podNodeSelectorConfigTemplate = heredoc.Doc(`
-		if sudo test -f "{{ .WORK_DIR }}/cfg/podnodeselector.yaml"; then
+	admissionConfigTemplate = heredoc.Doc(`
+		if sudo test -f "{{ .WORK_DIR }}/cfg/admission-config.yaml"; then
 			sudo mkdir -p /etc/kubernetes/admission
-			sudo mv {{ .WORK_DIR }}/cfg/podnodeselector.yaml /etc/kubernetes/admission/podnodeselector.yaml
-			sudo mv {{ .WORK_DIR }}/cfg/admission-config.yaml /etc/kubernetes/admission/admission-config.yaml
-			sudo chown root:root /etc/kubernetes/admission/podnodeselector.yaml
-			sudo chown root:root /etc/kubernetes/admission/admission-config.yaml
+			for config in admission-config.yaml podnodeselector.yaml eventratelimit.yaml podsecurity.yaml; do
+				if sudo test -f "{{ .WORK_DIR }}/cfg/${config}"; then
+					sudo mv "{{ .WORK_DIR }}/cfg/${config}" "/etc/kubernetes/admission/${config}"
+					sudo chown root:root "/etc/kubernetes/admission/${config}"
+				fi
+			done
+		fi
+	`)
+
+	konnectivityConfigTemplate = heredoc.Doc(`
+		if sudo test -f "{{ .WORK_DIR }}/cfg/egress-selector-config.yaml"; then
+			sudo mkdir -p {{ .CONFIG_DIR }}
+			sudo mv {{ .WORK_DIR }}/cfg/egress-selector-config.yaml {{ .CONFIG_PATH }}
+			sudo chown root:root {{ .CONFIG_PATH }}
 		fi
 	`)
 
@@ -63,6 +80,21 @@ var (
 		fi
 	`)
 
+	appArmorProfilesTemplate = heredoc.Doc(`
+		if sudo test -d "{{ .WORK_DIR }}/cfg/apparmor"; then
+			if ! command -v apparmor_parser >/dev/null; then
+				echo "AppArmor profiles are configured, but AppArmor is not available on this host"
+				exit 1
+			fi
+			sudo mkdir -p /etc/apparmor.d
+			sudo find "{{ .WORK_DIR }}/cfg/apparmor" -mindepth 1 -maxdepth 1 -type f \
+				-exec install -o root -g root -m 644 -t /etc/apparmor.d {} +
+			sudo find "{{ .WORK_DIR }}/cfg/apparmor" -mindepth 1 -maxdepth 1 -type f \
+				-exec sh -c 'apparmor_parser --replace --write-cache "/etc/apparmor.d/$(basename "$1")"' _ {} \;
+			sudo rm -rf "{{ .WORK_DIR }}/cfg/apparmor"
+		fi
+	`)
+
 	deleteEncryptionProvidersConfigTemplate = heredoc.Doc(`
 		sudo rm -rf /etc/kubernetes/encryption-providers/*
 	`)
@@ -80,12 +112,20 @@ func SaveAuditPolicyConfig(workdir string) (string, error) {
 	})
 }
 
-func SavePodNodeSelectorConfig(workdir string) (string, error) {
-	return Render(podNodeSelectorConfigTemplate, Data{
+func SaveAdmissionConfig(workdir string) (string, error) {
+	return Render(admissionConfigTemplate, Data{
 		"WORK_DIR": workdir,
 	})
 }
 
+func SaveKonnectivityConfig(workdir string) (string, error) {
+	return Render(konnectivityConfigTemplate, Data{
+		"CONFIG_DIR":  konnectivity.ConfigDir,
+		"CONFIG_PATH": konnectivity.EgressSelectorConfigPath,
+		"WORK_DIR":    workdir,
+	})
+}
+
 func SaveEncryptionProvidersConfig(workdir, fileName string) (string, error) {
 	return Render(encryptionProvidersConfigTemplate, Data{
 		"WORK_DIR":  workdir,
@@ -93,6 +133,12 @@ func SaveEncryptionProvidersConfig(workdir, fileName string) (string, error) {
 	})
 }
 
+func SaveAppArmorProfiles(workdir string) (string, error) {
+	return Render(appArmorProfilesTemplate, Data{
+		"WORK_DIR": workdir,
+	})
+}
+
 func DeleteEncryptionProvidersConfig(fileName string) string {
 	return deleteEncryptionProvidersConfigTemplate
 }
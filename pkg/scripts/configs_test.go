@@ -73,3 +73,29 @@ func TestSaveAuditPolicyConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveAppArmorProfiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		workdir string
+		err     error
+	}{
+		{name: "kubeone1", workdir: "test-dir1"},
+		{name: "kubeone2", workdir: "./subdir/test"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SaveAppArmorProfiles(tt.workdir)
+			if err != tt.err {
+				t.Errorf("SaveAppArmorProfiles() error = %v, wantErr %v", err, tt.err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
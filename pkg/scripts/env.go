@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WithEnvironment prepends the preamble exporting the given host environment
+// variables to the script. sudo is wrapped to preserve the variables, as it
+// resets the environment by default. The preamble is placed before the
+// script enables tracing, so the values are not printed.
+func WithEnvironment(script string, env map[string]string) string {
+	if len(env) == 0 {
+		return script
+	}
+
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&buf, "export %s=%s\n", name, shellQuote(env[name]))
+	}
+	// keep passing the password to the askpass helper on the hosts requiring
+	// the sudo password, see the ssh package
+	fmt.Fprintf(&buf, "sudo() ( ${KUBEONE_SUDO_PASSWORD:+export KUBEONE_SUDO_PASSWORD}; command sudo ${KUBEONE_SUDO_PASSWORD:+-A} --preserve-env=%s \"$@\" )\n", strings.Join(names, ","))
+	buf.WriteString(script)
+
+	return buf.String()
+}
+
+// shellQuote quotes the value to be used as a single shell word
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
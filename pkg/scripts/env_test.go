@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestWithEnvironment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{
+			name: "no-env",
+		},
+		{
+			name: "env",
+			env: map[string]string{
+				"HTTP_PROXY":  "http://proxy.local:3128",
+				"VENDOR_PATH": "/opt/vendor/bin",
+				"GREETING":    "it's $HOME",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			script, err := KubeadmReset("", "test-wd")
+			if err != nil {
+				t.Fatalf("KubeadmReset() error = %v", err)
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), WithEnvironment(script, tt.env), *updateFlag)
+		})
+	}
+}
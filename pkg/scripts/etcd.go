@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import "github.com/MakeNowJust/heredoc/v2"
+
+var (
+	etcdLibraryTemplate = heredoc.Doc(`
+		{{ define "etcd-container" -}}
+		etcd_id=$(sudo crictl ps --name='^etcd$' --state=running -q | head -n 1)
+		if [ -z "$etcd_id" ]; then
+			echo "etcd container is not running"
+			exit 1
+		fi
+
+		etcdctl() {
+			sudo crictl exec "$etcd_id" etcdctl \
+				--endpoints=https://127.0.0.1:2379 \
+				--cacert=/etc/kubernetes/pki/etcd/ca.crt \
+				--cert=/etc/kubernetes/pki/etcd/healthcheck-client.crt \
+				--key=/etc/kubernetes/pki/etcd/healthcheck-client.key \
+				"$@"
+		}
+		{{ end -}}
+
+		{{ define "start-control-plane" -}}
+		for component in etcd kube-apiserver kube-controller-manager kube-scheduler; do
+			if sudo test -f "{{ .STOPPED_MANIFESTS_DIR }}/${component}.yaml"; then
+				sudo mv "{{ .STOPPED_MANIFESTS_DIR }}/${component}.yaml" /etc/kubernetes/manifests/
+			fi
+		done
+		{{ end -}}
+	`)
+
+	etcdSnapshotSaveTemplate = etcdLibraryTemplate + heredoc.Doc(`
+		{{ template "etcd-container" }}
+
+		# the snapshot is saved to the etcd data directory, which is mounted
+		# from the host to the etcd container
+		etcdctl snapshot save {{ .SNAPSHOT }}
+
+		# snapshot status fails if the snapshot is corrupted
+		etcdctl snapshot status {{ .SNAPSHOT }} --write-out=table
+	`)
+
+	etcdSnapshotRestoreTemplate = etcdLibraryTemplate + heredoc.Doc(`
+		{{ template "etcd-container" }}
+
+		# the snapshot is restored next to the current data, which is replaced
+		# only once the control plane is stopped
+		sudo rm -rf {{ .RESTORE_DIR }}
+		etcdctl snapshot restore {{ .SNAPSHOT }} \
+			--data-dir={{ .RESTORE_DIR }} \
+			--name={{ .NAME }} \
+			--initial-cluster={{ .NAME }}={{ .PEER_URL }} \
+			--initial-advertise-peer-urls={{ .PEER_URL }}
+		sudo rm -f {{ .SNAPSHOT }}
+	`)
+
+	stopControlPlaneTemplate = heredoc.Doc(`
+		sudo mkdir -p {{ .STOPPED_MANIFESTS_DIR }}
+		for component in kube-apiserver kube-controller-manager kube-scheduler etcd; do
+			if sudo test -f "/etc/kubernetes/manifests/${component}.yaml"; then
+				sudo mv "/etc/kubernetes/manifests/${component}.yaml" {{ .STOPPED_MANIFESTS_DIR }}/
+			fi
+		done
+
+		# the kubelet stops the static pods once their manifests are removed
+		stopped=false
+		for attempt in $(seq 1 60); do
+			if [ -z "$(sudo crictl ps --name='^(etcd|kube-apiserver|kube-controller-manager|kube-scheduler)$' -q)" ]; then
+				stopped=true
+				break
+			fi
+			sleep 2
+		done
+		if [ "$stopped" != true ]; then
+			echo "control plane containers are still running"
+			exit 1
+		fi
+
+		if sudo test -d {{ .DATA_DIR }}/member; then
+			sudo mkdir -p {{ .DATA_BACKUP_DIR }}
+			sudo mv {{ .DATA_DIR }}/member {{ .DATA_BACKUP_DIR }}/
+		fi
+	`)
+
+	etcdStartRestoredTemplate = etcdLibraryTemplate + heredoc.Doc(`
+		sudo mv {{ .RESTORE_DIR }}/member {{ .DATA_DIR }}/member
+		sudo rm -rf {{ .RESTORE_DIR }}
+
+		{{ template "start-control-plane" . }}
+	`)
+
+	etcdStartRejoinedTemplate = etcdLibraryTemplate + heredoc.Doc(`
+		manifest="{{ .STOPPED_MANIFESTS_DIR }}/etcd.yaml"
+
+		# the member joins the restored cluster with an empty data directory
+		sudo sed -i 's#--initial-cluster=.*#--initial-cluster={{ .INITIAL_CLUSTER }}#' "$manifest"
+		if sudo grep -q -- "--initial-cluster-state=" "$manifest"; then
+			sudo sed -i 's#--initial-cluster-state=.*#--initial-cluster-state=existing#' "$manifest"
+		else
+			sudo sed -i 's#^\( *\)- --initial-cluster=.*#&\n\1- --initial-cluster-state=existing#' "$manifest"
+		fi
+
+		{{ template "start-control-plane" . }}
+	`)
+)
+
+// EtcdSnapshotSave saves and verifies the etcd snapshot on the control plane
+// node, using the etcdctl from the etcd container
+func EtcdSnapshotSave(snapshot string) (string, error) {
+	return Render(etcdSnapshotSaveTemplate, Data{
+		"SNAPSHOT": snapshot,
+	})
+}
+
+// EtcdSnapshotRestore restores the snapshot to the restore directory as the
+// data of the single member etcd cluster, using the etcdctl from the etcd
+// container. Both the snapshot and the restore directory must be in the
+// etcd data directory.
+func EtcdSnapshotRestore(snapshot, restoreDir, name, peerURL string) (string, error) {
+	return Render(etcdSnapshotRestoreTemplate, Data{
+		"SNAPSHOT":    snapshot,
+		"RESTORE_DIR": restoreDir,
+		"NAME":        name,
+		"PEER_URL":    peerURL,
+	})
+}
+
+// StopControlPlane stops the control plane static pods by moving their
+// manifests to the stoppedManifestsDir, and moves the etcd data to the
+// dataBackupDir
+func StopControlPlane(stoppedManifestsDir, dataDir, dataBackupDir string) (string, error) {
+	return Render(stopControlPlaneTemplate, Data{
+		"STOPPED_MANIFESTS_DIR": stoppedManifestsDir,
+		"DATA_DIR":              dataDir,
+		"DATA_BACKUP_DIR":       dataBackupDir,
+	})
+}
+
+// EtcdStartRestored replaces the etcd data with the restored data and starts
+// the control plane static pods stopped by StopControlPlane
+func EtcdStartRestored(stoppedManifestsDir, dataDir, restoreDir string) (string, error) {
+	return Render(etcdStartRestoredTemplate, Data{
+		"STOPPED_MANIFESTS_DIR": stoppedManifestsDir,
+		"DATA_DIR":              dataDir,
+		"RESTORE_DIR":           restoreDir,
+	})
+}
+
+// EtcdStartRejoined starts the control plane static pods stopped by
+// StopControlPlane, with the etcd member joining the existing cluster of the
+// initialCluster members
+func EtcdStartRejoined(stoppedManifestsDir, initialCluster string) (string, error) {
+	return Render(etcdStartRejoinedTemplate, Data{
+		"STOPPED_MANIFESTS_DIR": stoppedManifestsDir,
+		"INITIAL_CLUSTER":       initialCluster,
+	})
+}
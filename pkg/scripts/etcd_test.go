@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestEtcdSnapshotSave(t *testing.T) {
+	t.Parallel()
+
+	got, err := EtcdSnapshotSave("/var/lib/etcd/kubeone-snapshot.db")
+	if err != nil {
+		t.Fatalf("EtcdSnapshotSave() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
+func TestEtcdSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	got, err := EtcdSnapshotRestore("/var/lib/etcd/kubeone-restore.db", "/var/lib/etcd/kubeone-restore", "cp-0", "https://10.0.0.1:2380")
+	if err != nil {
+		t.Fatalf("EtcdSnapshotRestore() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
+func TestStopControlPlane(t *testing.T) {
+	t.Parallel()
+
+	got, err := StopControlPlane("/etc/kubernetes/kubeone-stopped", "/var/lib/etcd", "/var/lib/etcd-backup-20211201-120000")
+	if err != nil {
+		t.Fatalf("StopControlPlane() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
+func TestEtcdStartRestored(t *testing.T) {
+	t.Parallel()
+
+	got, err := EtcdStartRestored("/etc/kubernetes/kubeone-stopped", "/var/lib/etcd", "/var/lib/etcd/kubeone-restore")
+	if err != nil {
+		t.Fatalf("EtcdStartRestored() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
+
+func TestEtcdStartRejoined(t *testing.T) {
+	t.Parallel()
+
+	got, err := EtcdStartRejoined("/etc/kubernetes/kubeone-stopped", "cp-0=https://10.0.0.1:2380,cp-1=https://10.0.0.2:2380")
+	if err != nil {
+		t.Fatalf("EtcdStartRejoined() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
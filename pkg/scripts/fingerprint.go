@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+)
+
+const (
+	// FingerprintsDir is the directory on the host where fingerprints of
+	// the completed host-preparation steps are stored. It's removed
+	// together with /etc/kubeone when the node is reset.
+	FingerprintsDir = "/etc/kubeone/fingerprints"
+)
+
+var (
+	readFingerprintScriptTemplate = heredoc.Doc(`
+		sudo cat "{{ .FINGERPRINTS_DIR }}/{{ .STEP }}" 2>/dev/null || true
+	`)
+
+	saveFingerprintScriptTemplate = heredoc.Doc(`
+		sudo mkdir -p "{{ .FINGERPRINTS_DIR }}"
+		echo "{{ .FINGERPRINT }}" | sudo tee "{{ .FINGERPRINTS_DIR }}/{{ .STEP }}" >/dev/null
+	`)
+)
+
+// ReadFingerprint prints the fingerprint of the given step, or nothing if the
+// step has not been completed yet
+func ReadFingerprint(step string) (string, error) {
+	return Render(readFingerprintScriptTemplate, Data{
+		"FINGERPRINTS_DIR": FingerprintsDir,
+		"STEP":             step,
+	})
+}
+
+// SaveFingerprint records the fingerprint of the given completed step
+func SaveFingerprint(step, fingerprint string) (string, error) {
+	return Render(saveFingerprintScriptTemplate, Data{
+		"FINGERPRINTS_DIR": FingerprintsDir,
+		"STEP":             step,
+		"FINGERPRINT":      fingerprint,
+	})
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package scripts
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/MakeNowJust/heredoc/v2"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
 )
 
 var (
@@ -43,6 +46,65 @@ var (
 		esac
 		{{ end }}
 
+		{{ define "download-file" }}
+		# fetch_url OUTPUT URL
+		# Downloads the URL, resuming interrupted downloads and retrying with
+		# backoff.
+		fetch_url() {
+			local output="$1" url="$2" attempt
+			rm -f "$output"
+			for attempt in 1 2 3 4; do
+				if curl -fL --connect-timeout 30 --retry 3 --continue-at - --output "$output" "$url"; then
+					return 0
+				fi
+				sleep $((attempt * attempt * 2))
+			done
+			rm -f "$output"
+			return 1
+		}
+
+		# download_file OUTPUT SHA256 CHECKSUM_URL URL [MIRROR_URL...]
+		# Downloads the file from the first URL that serves it. The file is
+		# verified against SHA256 or, if empty, against the checksum published
+		# at CHECKSUM_URL. The checksum is never taken from the asset cache or
+		# the mirrors, so CHECKSUM_URL must point to the upstream. The download
+		# fails if the checksum can't be obtained or doesn't match.
+		download_file() {
+			local output="$1" expected="$2" checksum_url="$3" url
+			shift 3
+			if [[ -z "$expected" && -n "$checksum_url" ]] && fetch_url "$output.sha256" "$checksum_url"; then
+				expected="$(awk '{ print $1; exit }' "$output.sha256")"
+				rm -f "$output.sha256"
+			fi
+			if [[ -z "$expected" ]]; then
+				echo "no checksum to verify $output against"
+				return 1
+			fi
+			for url in "$@"; do
+				if fetch_url "$output" "$url"; then
+					if echo "$expected  $output" | sha256sum --check --status; then
+						return 0
+					fi
+					echo "checksum verification of $url failed"
+					rm -f "$output"
+				fi
+			done
+			echo "failed to download $output"
+			return 1
+		}
+
+		# download_upstream OUTPUT URL
+		# Downloads the file which has no published checksum, such as the
+		# repository signing keys and definitions. These files are fetched
+		# from the upstream only, never through the asset cache or the mirrors.
+		download_upstream() {
+			if ! fetch_url "$1" "$2"; then
+				echo "failed to download $1"
+				return 1
+			fi
+		}
+		{{ end }}
+
 		{{ define "docker-daemon-config" }}
 		sudo mkdir -p /etc/docker
 		cat <<EOF | sudo tee /etc/docker/daemon.json
@@ -71,6 +133,45 @@ var (
 		sudo sysctl --system
 		{{ end }}
 
+		{{ define "selinux-verify-enforcing" }}
+		if [[ "$(getenforce)" != "Enforcing" ]]; then
+			echo "SELinux is expected to be in the enforcing mode, but it's $(getenforce)"
+			exit 1
+		fi
+		{{ end }}
+
+		{{ define "selinux-contexts" }}
+		sudo yum install -y container-selinux
+		sudo yum install -y policycoreutils-python-utils || sudo yum install -y policycoreutils-python
+
+		sudo mkdir -p \
+			/etc/cni/net.d \
+			/etc/kubernetes \
+			/opt/cni/bin \
+			/var/lib/cni \
+			/var/lib/etcd \
+			/var/lib/kubelet
+
+		selinux_fcontext() {
+			sudo semanage fcontext -a -t "$1" "$2" || sudo semanage fcontext -m -t "$1" "$2"
+		}
+		selinux_fcontext container_file_t "/etc/cni/net.d(/.*)?"
+		selinux_fcontext container_file_t "/etc/kubernetes(/.*)?"
+		selinux_fcontext container_file_t "/opt/cni/bin(/.*)?"
+		selinux_fcontext container_var_lib_t "/var/lib/cni(/.*)?"
+		selinux_fcontext container_var_lib_t "/var/lib/etcd(/.*)?"
+		selinux_fcontext container_var_lib_t "/var/lib/kubelet(/.*)?"
+		selinux_fcontext container_file_t "/var/lib/kubelet/pods(/.*)?"
+
+		sudo restorecon -R \
+			/etc/cni/net.d \
+			/etc/kubernetes \
+			/opt/cni/bin \
+			/var/lib/cni \
+			/var/lib/etcd \
+			/var/lib/kubelet
+		{{ end }}
+
 		{{ define "journald-config" }}
 		sudo mkdir -p /etc/systemd/journald.conf.d
 		cat <<EOF | sudo tee /etc/systemd/journald.conf.d/max_disk_use.conf
@@ -89,6 +190,12 @@ const (
 	defaultContainerdVersion       = "1.4.*"
 	defaultAmazonContainerdVersion = "1.4.*"
 	defaultAmazonCrictlVersion     = "1.13.0"
+
+	// hosts.toml files are supported since containerd 1.5
+	registriesContainerdVersion       = "1.5.*"
+	registriesAmazonContainerdVersion = "1.6.*"
+
+	containerdCertsDir = "/etc/containerd/certs.d"
 )
 
 type dockerConfig struct {
@@ -99,6 +206,53 @@ type dockerConfig struct {
 	InsecureRegistries []string          `json:"insecure-registries,omitempty"`
 }
 
+// cachedURL returns the URL through which the asset is downloaded from the
+// asset cache, or the URL as it is if the cache is not configured
+func cachedURL(cache *kubeone.AssetCache, url string) string {
+	return cache.URL(url)
+}
+
+// downloadURLs returns the quoted list of URLs to be passed to
+// download_file. URLs served by the asset cache come first, followed by the
+// given URLs to fall back to if the cache is unavailable.
+func downloadURLs(cache *kubeone.AssetCache, urls ...string) string {
+	var (
+		list []string
+		seen = map[string]bool{}
+	)
+
+	for _, candidates := range [][]string{cachedURLs(cache, urls), urls} {
+		for _, u := range candidates {
+			if u == "" || seen[u] {
+				continue
+			}
+			seen[u] = true
+			list = append(list, fmt.Sprintf(`"%s"`, u))
+		}
+	}
+
+	return strings.Join(list, " ")
+}
+
+// assetURLs returns the quoted list of URLs from where the binary asset is
+// downloaded
+func assetURLs(cache *kubeone.AssetCache, asset kubeone.BinaryAsset) string {
+	return downloadURLs(cache, append([]string{asset.URL}, asset.Mirrors...)...)
+}
+
+func cachedURLs(cache *kubeone.AssetCache, urls []string) []string {
+	if cache == nil {
+		return nil
+	}
+
+	cached := make([]string, 0, len(urls))
+	for _, u := range urls {
+		cached = append(cached, cache.URL(u))
+	}
+
+	return cached
+}
+
 func dockerCfg(insecureRegistry string) (string, error) {
 	cfg := dockerConfig{
 		ExecOpts:      []string{"native.cgroupdriver=systemd"},
@@ -149,14 +303,15 @@ type containerdCRIRuncOptions struct {
 }
 
 type containerdCRIRegistry struct {
-	Mirrors map[string]containerdMirror `toml:"mirrors"`
+	ConfigPath string                      `toml:"config_path,omitempty"`
+	Mirrors    map[string]containerdMirror `toml:"mirrors,omitempty"`
 }
 
 type containerdMirror struct {
 	Endpoint []string `toml:"endpoint"`
 }
 
-func containerdCfg(insecureRegistry string) (string, error) {
+func containerdCfg(insecureRegistry string, registries map[string]kubeone.RegistryMirror) (string, error) {
 	criPlugin := containerdCRIPlugin{
 		Containerd: &containerdCRISettings{
 			Runtimes: map[string]containerdCRIRuntime{
@@ -177,7 +332,14 @@ func containerdCfg(insecureRegistry string) (string, error) {
 		},
 	}
 
-	if insecureRegistry != "" {
+	switch {
+	case len(registries) > 0:
+		// containerd refuses the mirrors when the config_path is set, the
+		// insecure registry is then a part of the registries as well
+		criPlugin.Registry = &containerdCRIRegistry{
+			ConfigPath: containerdCertsDir,
+		}
+	case insecureRegistry != "":
 		criPlugin.Registry.Mirrors[insecureRegistry] = containerdMirror{
 			Endpoint: []string{fmt.Sprintf("http://%s", insecureRegistry)},
 		}
@@ -202,3 +364,56 @@ func containerdCfg(insecureRegistry string) (string, error) {
 
 	return buf.String(), err
 }
+
+// containerdRegistries returns the registries for which the hosts.toml files
+// are rendered. The insecure registry is added to the configured registries,
+// as containerd ignores the mirrors from config.toml once hosts.toml files
+// are used.
+func containerdRegistries(cluster *kubeone.KubeOneCluster) map[string]kubeone.RegistryMirror {
+	if cluster.ContainerRuntime.Containerd == nil || len(cluster.ContainerRuntime.Containerd.Registries) == 0 {
+		return nil
+	}
+
+	registries := map[string]kubeone.RegistryMirror{}
+	for registry, mirror := range cluster.ContainerRuntime.Containerd.Registries {
+		registries[registry] = mirror
+	}
+
+	insecureRegistry := cluster.RegistryConfiguration.InsecureRegistryAddress()
+	if _, ok := registries[insecureRegistry]; insecureRegistry != "" && !ok {
+		registries[insecureRegistry] = kubeone.RegistryMirror{
+			Mirrors:            []string{fmt.Sprintf("http://%s", insecureRegistry)},
+			InsecureSkipVerify: true,
+		}
+	}
+
+	return registries
+}
+
+// containerdHostsCfg renders the hosts.toml file of the registry. The file is
+// written by hand, as the order of the hosts defines the order in which the
+// mirrors are tried.
+func containerdHostsCfg(registry string, mirror kubeone.RegistryMirror) string {
+	server := fmt.Sprintf("https://%s", registry)
+	if registry == "docker.io" {
+		server = "https://registry-1.docker.io"
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "server = %q\n", server)
+
+	for _, m := range mirror.Mirrors {
+		fmt.Fprintf(&buf, "\n[host.%q]\n", m)
+		buf.WriteString("capabilities = [\"pull\", \"resolve\"]\n")
+		if mirror.InsecureSkipVerify {
+			buf.WriteString("skip_verify = true\n")
+		}
+		if mirror.Auth != nil {
+			credentials := base64.StdEncoding.EncodeToString([]byte(mirror.Auth.Username + ":" + mirror.Auth.Password))
+			fmt.Fprintf(&buf, "[host.%q.header]\n", m)
+			fmt.Fprintf(&buf, "authorization = %q\n", "Basic "+credentials)
+		}
+	}
+
+	return buf.String()
+}
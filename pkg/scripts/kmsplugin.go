@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+const (
+	kmsPluginAzureConfigPath = "/etc/kubernetes/azure.json"
+)
+
+var (
+	kmsPluginScriptTemplate = heredoc.Doc(`
+		sudo mkdir -p /etc/kubernetes/manifests {{ .SOCKET_DIR }}
+		cat <<'EOF' | sudo tee /etc/kubernetes/manifests/kms-plugin.yaml >/dev/null
+		apiVersion: v1
+		kind: Pod
+		metadata:
+		  name: kms-plugin
+		  namespace: kube-system
+		  labels:
+		    component: kms-plugin
+		spec:
+		  containers:
+		  - name: kms-plugin
+		    image: {{ .IMAGE }}
+		    args:
+		{{- range .ARGS }}
+		    - {{ quote . }}
+		{{- end }}
+		    volumeMounts:
+		    - mountPath: {{ .SOCKET_DIR }}
+		      name: socket-dir
+		{{- if .CLOUD_CONFIG }}
+		    - mountPath: {{ .CLOUD_CONFIG }}
+		      name: cloud-config
+		      readOnly: true
+		{{- end }}
+		  hostNetwork: true
+		  priorityClassName: system-node-critical
+		  volumes:
+		  - hostPath:
+		      path: {{ .SOCKET_DIR }}
+		      type: DirectoryOrCreate
+		    name: socket-dir
+		{{- if .CLOUD_CONFIG }}
+		  - hostPath:
+		      path: /etc/kubernetes/cloud-config
+		      type: File
+		    name: cloud-config
+		{{- end }}
+		EOF
+	`)
+)
+
+// KMSPlugin writes the static pod manifest of the KMS plugin listening on the
+// KMS provider endpoint. The plugin is deployed as a static pod, because
+// kube-apiserver requires it to encrypt the Secrets before any workload
+// can be scheduled.
+func KMSPlugin(kms *kubeone.EncryptionProvidersKMS, image string) (string, error) {
+	if kms == nil || kms.Plugin == nil {
+		return "", errors.New("kms plugin is not configured")
+	}
+
+	var (
+		args        []string
+		cloudConfig string
+		plugin      = kms.Plugin
+	)
+
+	switch {
+	case plugin.AWS != nil:
+		args = []string{
+			fmt.Sprintf("--key=%s", plugin.AWS.KeyARN),
+			fmt.Sprintf("--region=%s", plugin.AWS.Region),
+			fmt.Sprintf("--listen=%s", kms.Endpoint),
+		}
+	case plugin.Azure != nil:
+		args = []string{
+			fmt.Sprintf("--listen-addr=unix://%s", kms.Endpoint),
+			fmt.Sprintf("--keyvault-name=%s", plugin.Azure.KeyVaultName),
+			fmt.Sprintf("--key-name=%s", plugin.Azure.KeyName),
+			fmt.Sprintf("--key-version=%s", plugin.Azure.KeyVersion),
+			fmt.Sprintf("--config-file-path=%s", kmsPluginAzureConfigPath),
+		}
+		cloudConfig = kmsPluginAzureConfigPath
+	case plugin.GCP != nil:
+		args = []string{
+			fmt.Sprintf("--key-uri=%s", plugin.GCP.KeyURI),
+			fmt.Sprintf("--path-to-unix-socket=%s", kms.Endpoint),
+		}
+	default:
+		return "", errors.New("kms plugin provider is not configured")
+	}
+
+	return Render(kmsPluginScriptTemplate, Data{
+		"ARGS":         args,
+		"CLOUD_CONFIG": cloudConfig,
+		"IMAGE":        image,
+		"SOCKET_DIR":   filepath.Dir(kms.Endpoint),
+	})
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestKMSPlugin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		plugin *kubeone.KMSPlugin
+		image  string
+	}{
+		{
+			name: "aws",
+			plugin: &kubeone.KMSPlugin{
+				AWS: &kubeone.AWSKMSPlugin{
+					KeyARN: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+					Region: "eu-west-1",
+				},
+			},
+			image: "gcr.io/k8s-staging-provider-aws/aws-encryption-provider:v0.1.0",
+		},
+		{
+			name: "azure",
+			plugin: &kubeone.KMSPlugin{
+				Azure: &kubeone.AzureKMSPlugin{
+					KeyVaultName: "kubeone-vault",
+					KeyName:      "kubeone-key",
+					KeyVersion:   "0123456789abcdef0123456789abcdef",
+				},
+			},
+			image: "mcr.microsoft.com/oss/azure/kms/keyvault:v0.2.0",
+		},
+		{
+			name: "gcp",
+			plugin: &kubeone.KMSPlugin{
+				GCP: &kubeone.GCPKMSPlugin{
+					KeyURI: "projects/kubeone/locations/global/keyRings/kubeone/cryptoKeys/secrets",
+				},
+			},
+			image: "gcr.io/google-containers/k8s-cloud-kms-plugin:v0.1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kms := &kubeone.EncryptionProvidersKMS{
+				Name:     "kubeone-kms",
+				Endpoint: "/var/run/kmsplugin/socket.sock",
+				Plugin:   tt.plugin,
+			}
+
+			got, err := KMSPlugin(kms, tt.image)
+			if err != nil {
+				t.Errorf("KMSPlugin() error = %v", err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/templates/konnectivity"
+)
+
+var (
+	konnectivityServerScriptTemplate = heredoc.Doc(`
+		sudo mkdir -p /etc/kubernetes/manifests {{ .SOCKET_DIR }}
+		cat <<'EOF' | sudo tee /etc/kubernetes/manifests/konnectivity-server.yaml >/dev/null
+		apiVersion: v1
+		kind: Pod
+		metadata:
+		  name: konnectivity-server
+		  namespace: kube-system
+		  labels:
+		    component: konnectivity-server
+		spec:
+		  containers:
+		  - name: konnectivity-server
+		    image: {{ .IMAGE }}
+		    command:
+		    - /proxy-server
+		    args:
+		    - --logtostderr=true
+		    - --uds-name={{ .SOCKET_PATH }}
+		    - --delete-existing-uds-file
+		    - --cluster-cert=/etc/kubernetes/pki/apiserver.crt
+		    - --cluster-key=/etc/kubernetes/pki/apiserver.key
+		    - --mode=grpc
+		    - --server-port=0
+		    - --agent-port={{ .AGENT_PORT }}
+		    - --admin-port=8133
+		    - --health-port=8134
+		    - --agent-namespace=kube-system
+		    - --agent-service-account={{ .AGENT_SERVICE_ACCOUNT }}
+		    - --kubeconfig={{ .KUBECONFIG }}
+		    - --authentication-audience={{ .AUDIENCE }}
+		    - --server-count={{ .SERVER_COUNT }}
+		    livenessProbe:
+		      httpGet:
+		        host: 127.0.0.1
+		        path: /healthz
+		        port: 8134
+		      initialDelaySeconds: 30
+		      timeoutSeconds: 60
+		    volumeMounts:
+		    - mountPath: /etc/kubernetes/pki
+		      name: pki
+		      readOnly: true
+		    - mountPath: {{ .KUBECONFIG }}
+		      name: kubeconfig
+		      readOnly: true
+		    - mountPath: {{ .SOCKET_DIR }}
+		      name: socket-dir
+		  hostNetwork: true
+		  priorityClassName: system-cluster-critical
+		  volumes:
+		  - hostPath:
+		      path: /etc/kubernetes/pki
+		      type: Directory
+		    name: pki
+		  - hostPath:
+		      path: {{ .KUBECONFIG }}
+		      type: File
+		    name: kubeconfig
+		  - hostPath:
+		      path: {{ .SOCKET_DIR }}
+		      type: DirectoryOrCreate
+		    name: socket-dir
+		EOF
+	`)
+)
+
+// KonnectivityServer writes the konnectivity-server static pod manifest. The
+// server accepts the agents connections on the agent port, authenticating
+// them with the kube-apiserver certificate, and proxies the kube-apiserver
+// traffic received over the socket through the agents. The kubeconfig must be
+// present before the manifest is written.
+func KonnectivityServer(cfg kubeone.KonnectivityConfig, serverCount int, image string) (string, error) {
+	return Render(konnectivityServerScriptTemplate, Data{
+		"AGENT_PORT":            cfg.AgentPort,
+		"AGENT_SERVICE_ACCOUNT": konnectivity.AgentServiceAccount,
+		"AUDIENCE":              konnectivity.ServerUser,
+		"IMAGE":                 image,
+		"KUBECONFIG":            konnectivity.KubeconfigPath,
+		"SERVER_COUNT":          serverCount,
+		"SOCKET_DIR":            konnectivity.SocketDir,
+		"SOCKET_PATH":           konnectivity.SocketPath,
+	})
+}
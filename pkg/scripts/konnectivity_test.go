@@ -0,0 +1,40 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestKonnectivityServer(t *testing.T) {
+	t.Parallel()
+
+	cfg := kubeone.KonnectivityConfig{
+		ServerAddress: "api.example.com",
+		AgentPort:     8132,
+	}
+
+	got, err := KonnectivityServer(cfg, 3, "k8s.gcr.io/kas-network-proxy/proxy-server:v0.0.27")
+	if err != nil {
+		t.Fatalf("KonnectivityServer() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
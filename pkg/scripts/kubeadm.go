@@ -52,6 +52,8 @@ var (
 		sudo rm -f /etc/kubernetes/cloud-config
 		sudo rm -rf /etc/kubernetes/admission
 		sudo rm -rf /etc/kubernetes/encryption-providers
+		sudo rm -rf /etc/kubernetes/konnectivity /etc/kubernetes/konnectivity-server
+		sudo rm -f /etc/kubernetes/konnectivity-server.conf
 		sudo rm -rf /var/lib/etcd/
 		sudo rm -rf "{{ .WORK_DIR }}"
 		sudo rm -rf /etc/kubeone
@@ -61,6 +63,58 @@ var (
 		sudo {{ .KUBEADM_UPGRADE }} --config={{ .WORK_DIR }}/cfg/master_0.yaml
 	`)
 
+	kubeadmControlPlaneManifestScriptTemplate = heredoc.Doc(`
+		sudo kubeadm {{ .VERBOSE }} init phase control-plane {{ .COMPONENT }} \
+			--config={{ .WORK_DIR }}/cfg/master_{{ .NODE_ID }}.yaml
+	`)
+
+	kubeadmEtcdManifestScriptTemplate = heredoc.Doc(`
+		sudo kubeadm {{ .VERBOSE }} init phase etcd local \
+			--config={{ .WORK_DIR }}/cfg/master_{{ .NODE_ID }}.yaml
+	`)
+
+	kubeadmEtcdServerCertScriptTemplate = heredoc.Doc(`
+		sudo mv -f /etc/kubernetes/pki/etcd/server.crt /etc/kubernetes/pki/etcd/server.crt.bak
+		sudo mv -f /etc/kubernetes/pki/etcd/server.key /etc/kubernetes/pki/etcd/server.key.bak
+
+		if ! sudo kubeadm {{ .VERBOSE }} init phase certs etcd-server \
+			--config={{ .WORK_DIR }}/cfg/master_{{ .NODE_ID }}.yaml; then
+			sudo mv -f /etc/kubernetes/pki/etcd/server.crt.bak /etc/kubernetes/pki/etcd/server.crt
+			sudo mv -f /etc/kubernetes/pki/etcd/server.key.bak /etc/kubernetes/pki/etcd/server.key
+			exit 1
+		fi
+
+		sudo rm -f /etc/kubernetes/pki/etcd/server.crt.bak /etc/kubernetes/pki/etcd/server.key.bak
+	`)
+
+	kubeadmUploadConfigScriptTemplate = heredoc.Doc(`
+		sudo kubeadm {{ .VERBOSE }} init phase upload-config kubeadm \
+			--config={{ .WORK_DIR }}/cfg/master_{{ .NODE_ID }}.yaml
+	`)
+
+	kubeadmCertsRenewScriptTemplate = heredoc.Doc(`
+		sudo kubeadm {{ .VERBOSE }} {{ if .ALPHA }}alpha {{ end }}certs renew all
+	`)
+
+	restartStaticPodScriptTemplate = heredoc.Doc(`
+		# kubelet starts a new container of the static pod after the running
+		# one is stopped, and the new container loads the renewed certificates
+		old_ids=$(sudo crictl ps --name='^{{ .COMPONENT }}$' -q)
+		[ -z "$old_ids" ] && exit 1
+		sudo crictl stop $old_ids
+
+		for _ in $(seq 1 60); do
+			new_ids=$(sudo crictl ps --name='^{{ .COMPONENT }}$' --state=running -q)
+			if [ -n "$new_ids" ] && [ "$new_ids" != "$old_ids" ]; then
+				exit 0
+			fi
+			sleep 5
+		done
+
+		echo "{{ .COMPONENT }} has not been restarted"
+		exit 1
+	`)
+
 	kubeadmPauseImageVersionScriptTemplate = heredoc.Doc(`
 		sudo kubeadm config images list --kubernetes-version={{ .KUBERNETES_VERSION }} |
 			grep "k8s.gcr.io/pause" |
@@ -116,6 +170,67 @@ func KubeadmUpgradeLeader(kubeadmCmd, workdir string) (string, error) {
 	})
 }
 
+// KubeadmControlPlaneManifest regenerates the static pod manifest of the
+// control plane component, such as apiserver or controller-manager, from the
+// kubeadm configuration
+func KubeadmControlPlaneManifest(workdir string, nodeID int, verboseFlag, component string) (string, error) {
+	return Render(kubeadmControlPlaneManifestScriptTemplate, Data{
+		"WORK_DIR":  workdir,
+		"NODE_ID":   nodeID,
+		"VERBOSE":   verboseFlag,
+		"COMPONENT": component,
+	})
+}
+
+// KubeadmEtcdManifest regenerates the static pod manifest of the local etcd
+// member from the kubeadm configuration
+func KubeadmEtcdManifest(workdir string, nodeID int, verboseFlag string) (string, error) {
+	return Render(kubeadmEtcdManifestScriptTemplate, Data{
+		"WORK_DIR": workdir,
+		"NODE_ID":  nodeID,
+		"VERBOSE":  verboseFlag,
+	})
+}
+
+// KubeadmEtcdServerCert regenerates the etcd server certificate from the
+// kubeadm configuration. The existing certificate is restored if kubeadm
+// fails.
+func KubeadmEtcdServerCert(workdir string, nodeID int, verboseFlag string) (string, error) {
+	return Render(kubeadmEtcdServerCertScriptTemplate, Data{
+		"WORK_DIR": workdir,
+		"NODE_ID":  nodeID,
+		"VERBOSE":  verboseFlag,
+	})
+}
+
+// KubeadmUploadConfig uploads the kubeadm ClusterConfiguration to the
+// kubeadm-config ConfigMap, so joining and upgrading nodes use it
+func KubeadmUploadConfig(workdir string, nodeID int, verboseFlag string) (string, error) {
+	return Render(kubeadmUploadConfigScriptTemplate, Data{
+		"WORK_DIR": workdir,
+		"NODE_ID":  nodeID,
+		"VERBOSE":  verboseFlag,
+	})
+}
+
+// KubeadmCertsRenew renews all certificates managed by kubeadm on the
+// control plane node. The certs subcommand is under alpha before Kubernetes
+// 1.20.
+func KubeadmCertsRenew(verboseFlag string, alpha bool) (string, error) {
+	return Render(kubeadmCertsRenewScriptTemplate, Data{
+		"VERBOSE": verboseFlag,
+		"ALPHA":   alpha,
+	})
+}
+
+// RestartStaticPod restarts the container of the control plane component,
+// such as etcd or kube-apiserver, and waits for kubelet to start a new one
+func RestartStaticPod(component string) (string, error) {
+	return Render(restartStaticPodScriptTemplate, Data{
+		"COMPONENT": component,
+	})
+}
+
 func KubeadmPauseImageVersion(kubernetesVersion string) (string, error) {
 	return Render(kubeadmPauseImageVersionScriptTemplate, map[string]interface{}{
 		"KUBERNETES_VERSION": kubernetesVersion,
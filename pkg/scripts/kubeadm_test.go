@@ -154,6 +154,185 @@ func TestKubeadmCert(t *testing.T) {
 	}
 }
 
+func TestKubeadmControlPlaneManifest(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		workdir     string
+		nodeID      int
+		verboseFlag string
+		component   string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "verbose",
+			args: args{
+				workdir:     "test-wd",
+				nodeID:      0,
+				verboseFlag: "--v=6",
+				component:   "apiserver",
+			},
+		},
+		{
+			name: "not-verbose",
+			args: args{
+				workdir:   "test-wd",
+				component: "controller-manager",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KubeadmControlPlaneManifest(tt.args.workdir, tt.args.nodeID, tt.args.verboseFlag, tt.args.component)
+			if err != tt.err {
+				t.Errorf("KubeadmControlPlaneManifest() error = %v, wantErr %v", err, tt.err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
+
+func TestKubeadmEtcdManifest(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		workdir     string
+		nodeID      int
+		verboseFlag string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "verbose",
+			args: args{
+				workdir:     "test-wd",
+				nodeID:      0,
+				verboseFlag: "--v=6",
+			},
+		},
+		{
+			name: "not-verbose",
+			args: args{
+				workdir: "test-wd",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KubeadmEtcdManifest(tt.args.workdir, tt.args.nodeID, tt.args.verboseFlag)
+			if err != tt.err {
+				t.Errorf("KubeadmEtcdManifest() error = %v, wantErr %v", err, tt.err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
+
+func TestKubeadmEtcdServerCert(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		workdir     string
+		nodeID      int
+		verboseFlag string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "verbose",
+			args: args{
+				workdir:     "test-wd",
+				nodeID:      0,
+				verboseFlag: "--v=6",
+			},
+		},
+		{
+			name: "not-verbose",
+			args: args{
+				workdir: "test-wd",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KubeadmEtcdServerCert(tt.args.workdir, tt.args.nodeID, tt.args.verboseFlag)
+			if err != tt.err {
+				t.Errorf("KubeadmEtcdServerCert() error = %v, wantErr %v", err, tt.err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
+
+func TestKubeadmUploadConfig(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		workdir     string
+		nodeID      int
+		verboseFlag string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "verbose",
+			args: args{
+				workdir:     "test-wd",
+				nodeID:      0,
+				verboseFlag: "--v=6",
+			},
+		},
+		{
+			name: "not-verbose",
+			args: args{
+				workdir: "test-wd",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KubeadmUploadConfig(tt.args.workdir, tt.args.nodeID, tt.args.verboseFlag)
+			if err != tt.err {
+				t.Errorf("KubeadmUploadConfig() error = %v, wantErr %v", err, tt.err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
+
 func TestKubeadmInit(t *testing.T) {
 	t.Parallel()
 
@@ -279,3 +458,54 @@ func TestKubeadmUpgradeLeader(t *testing.T) {
 		})
 	}
 }
+
+func TestKubeadmCertsRenew(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		verboseFlag string
+		alpha       bool
+	}
+	tests := []struct {
+		name string
+		args args
+		err  error
+	}{
+		{
+			name: "verbose",
+			args: args{
+				verboseFlag: "--v=6",
+			},
+		},
+		{
+			name: "alpha",
+			args: args{
+				alpha: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KubeadmCertsRenew(tt.args.verboseFlag, tt.args.alpha)
+			if err != tt.err {
+				t.Errorf("KubeadmCertsRenew() error = %v, wantErr %v", err, tt.err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
+
+func TestRestartStaticPod(t *testing.T) {
+	t.Parallel()
+
+	got, err := RestartStaticPod("kube-apiserver")
+	if err != nil {
+		t.Fatalf("RestartStaticPod() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"net"
+
+	"github.com/MakeNowJust/heredoc/v2"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+var (
+	kubeVIPScriptTemplate = heredoc.Doc(`
+		sudo mkdir -p /etc/kubernetes/manifests
+		cat <<'EOF' | sudo tee /etc/kubernetes/manifests/kube-vip.yaml >/dev/null
+		apiVersion: v1
+		kind: Pod
+		metadata:
+		  name: kube-vip
+		  namespace: kube-system
+		spec:
+		  containers:
+		  - name: kube-vip
+		    image: {{ .IMAGE }}
+		    args:
+		    - manager
+		    env:
+		    - name: vip_arp
+		      value: "true"
+		    - name: port
+		      value: "{{ .PORT }}"
+		{{- if .INTERFACE }}
+		    - name: vip_interface
+		      value: "{{ .INTERFACE }}"
+		{{- end }}
+		    - name: vip_cidr
+		      value: "{{ .CIDR }}"
+		    - name: cp_enable
+		      value: "true"
+		    - name: cp_namespace
+		      value: kube-system
+		    - name: vip_leaderelection
+		      value: "true"
+		    - name: vip_leaseduration
+		      value: "5"
+		    - name: vip_renewdeadline
+		      value: "3"
+		    - name: vip_retryperiod
+		      value: "1"
+		    - name: address
+		      value: "{{ .ADDRESS }}"
+		    securityContext:
+		      capabilities:
+		        add:
+		        - NET_ADMIN
+		        - NET_RAW
+		    volumeMounts:
+		    - mountPath: /etc/kubernetes/admin.conf
+		      name: kubeconfig
+		  hostAliases:
+		  - hostnames:
+		    - kubernetes
+		    ip: 127.0.0.1
+		  hostNetwork: true
+		  volumes:
+		  - hostPath:
+		      path: /etc/kubernetes/admin.conf
+		    name: kubeconfig
+		EOF
+	`)
+)
+
+// KubeVIP writes the kube-vip static pod manifest announcing the API
+// endpoint as the virtual IP. kube-vip uses admin.conf for the leader
+// election, which becomes available once the node has joined the cluster.
+func KubeVIP(kubeVIP *kubeone.KubeVIP, apiEndpoint kubeone.APIEndpoint, image string) (string, error) {
+	cidr := 32
+	if ip := net.ParseIP(apiEndpoint.Host); ip != nil && ip.To4() == nil {
+		cidr = 128
+	}
+
+	return Render(kubeVIPScriptTemplate, Data{
+		"ADDRESS":   apiEndpoint.Host,
+		"CIDR":      cidr,
+		"IMAGE":     image,
+		"INTERFACE": kubeVIP.Interface,
+		"PORT":      apiEndpoint.Port,
+	})
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestKubeVIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		kubeVIP *kubeone.KubeVIP
+		host    string
+	}{
+		{
+			name:    "default-interface",
+			kubeVIP: &kubeone.KubeVIP{Enable: true},
+			host:    "192.168.1.100",
+		},
+		{
+			name:    "with-interface",
+			kubeVIP: &kubeone.KubeVIP{Enable: true, Interface: "eth1"},
+			host:    "192.168.1.100",
+		},
+		{
+			name:    "ipv6",
+			kubeVIP: &kubeone.KubeVIP{Enable: true},
+			host:    "fd00::100",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := KubeVIP(tt.kubeVIP, kubeone.APIEndpoint{Host: tt.host, Port: 6443}, "ghcr.io/kube-vip/kube-vip:v0.4.0")
+			if err != nil {
+				t.Errorf("KubeVIP() error = %v", err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
@@ -25,6 +25,10 @@ var (
 		echo "$fqdn"
 	`)
 
+	equinixMetalMetadataScript = heredoc.Doc(`
+		curl -fsSL --retry 3 https://metadata.platformequinix.com/metadata
+	`)
+
 	restartKubeAPIServerCrictlTemplate = heredoc.Doc(`
 		# Disable exit immediately if a command in a pipeline fails.
 		# crictl logs can fail if kubelet fails to set up symlink for the API
@@ -47,14 +51,43 @@ var (
 		fi
 	{{ end }}
 	`)
+
+	kubeletSeccompDefaultTemplate = heredoc.Doc(`
+		flags_file=/var/lib/kubelet/kubeadm-flags.env
+		sudo test -f "${flags_file}" || exit 0
+
+		{{ if .ENABLE }}
+		if ! sudo grep -q -- "--seccomp-default=true" "${flags_file}"; then
+			sudo sed -i 's/^KUBELET_KUBEADM_ARGS="/KUBELET_KUBEADM_ARGS="--seccomp-default=true {{ if .FEATURE_GATE }}--feature-gates=SeccompDefault=true {{ end }}/' "${flags_file}"
+			sudo systemctl restart kubelet
+		fi
+		{{ else }}
+		if sudo grep -q -- "--seccomp-default=true" "${flags_file}"; then
+			sudo sed -i 's/ *--seccomp-default=true//; s/ *--feature-gates=SeccompDefault=true//' "${flags_file}"
+			sudo systemctl restart kubelet
+		fi
+		{{ end }}
+	`)
 )
 
 func Hostname() string {
 	return hostnameScript
 }
 
+// EquinixMetalMetadata prints the metadata of the Equinix Metal device
+func EquinixMetalMetadata() string {
+	return equinixMetalMetadataScript
+}
+
 func RestartKubeAPIServerCrictl(ensure bool) (string, error) {
 	return Render(restartKubeAPIServerCrictlTemplate, Data{
 		"ENSURE": ensure,
 	})
 }
+
+func KubeletSeccompDefault(enable, featureGate bool) (string, error) {
+	return Render(kubeletSeccompDefaultTemplate, Data{
+		"ENABLE":       enable,
+		"FEATURE_GATE": featureGate,
+	})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestKubeletSeccompDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		enable      bool
+		featureGate bool
+		err         error
+	}{
+		{name: "enable", enable: true},
+		{name: "enable-with-feature-gate", enable: true, featureGate: true},
+		{name: "disable"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KubeletSeccompDefault(tt.enable, tt.featureGate)
+			if err != tt.err {
+				t.Errorf("KubeletSeccompDefault() error = %v, wantErr %v", err, tt.err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
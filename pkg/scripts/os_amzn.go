@@ -43,11 +43,11 @@ sudo mv /tmp/yum.conf /etc/yum.conf
 cat <<EOF | sudo tee /etc/yum.repos.d/kubernetes.repo
 [kubernetes]
 name=Kubernetes
-baseurl=https://packages.cloud.google.com/yum/repos/kubernetes-el7-x86_64
+baseurl={{ cachedURL .ASSET_CACHE "https://packages.cloud.google.com" }}/yum/repos/kubernetes-el7-x86_64
 enabled=1
 gpgcheck=1
 repo_gpgcheck=0
-gpgkey=https://packages.cloud.google.com/yum/doc/yum-key.gpg https://packages.cloud.google.com/yum/doc/rpm-package-key.gpg
+gpgkey={{ cachedURL .ASSET_CACHE "https://packages.cloud.google.com" }}/yum/doc/yum-key.gpg {{ cachedURL .ASSET_CACHE "https://packages.cloud.google.com" }}/yum/doc/rpm-package-key.gpg
 EOF
 {{ end }}
 
@@ -69,6 +69,9 @@ sudo yum install -y \
 {{ if .INSTALL_CONTAINERD }}
 {{ template "yum-containerd-amzn" . }}
 {{ end }}
+{{- if .INSTALL_CRIO }}
+{{ template "yum-crio" . }}
+{{ end }}
 
 sudo mkdir -p /opt/bin /etc/kubernetes/pki /etc/kubernetes/manifests
 
@@ -76,17 +79,22 @@ rm -rf /tmp/k8s-binaries
 mkdir -p /tmp/k8s-binaries
 cd /tmp/k8s-binaries
 
-{{- if .CNI_URL }}
+{{- if or .CNI_ASSET.URL .NODE_BINARIES_ASSET.URL .KUBECTL_ASSET.URL }}
+{{ template "download-file" }}
+{{- end }}
+
+{{- if .CNI_ASSET.URL }}
 sudo mkdir -p /opt/cni/bin
-curl -L "{{ .CNI_URL }}" | sudo tar -C /opt/cni/bin -xz
+download_file /tmp/k8s-binaries/cni.tar.gz "{{ .CNI_ASSET.SHA256 }}" "{{ .CNI_ASSET.URL }}.sha256" {{ assetURLs .ASSET_CACHE .CNI_ASSET }}
+sudo tar -C /opt/cni/bin -xzf /tmp/k8s-binaries/cni.tar.gz
 {{- end }}
 
-{{- if .NODE_BINARIES_URL }}
-curl -L --output /tmp/k8s-binaries/node.tar.gz {{ .NODE_BINARIES_URL }}
+{{- if .NODE_BINARIES_ASSET.URL }}
+download_file /tmp/k8s-binaries/node.tar.gz "{{ .NODE_BINARIES_ASSET.SHA256 }}" "{{ .NODE_BINARIES_ASSET.URL }}.sha256" {{ assetURLs .ASSET_CACHE .NODE_BINARIES_ASSET }}
 tar xvf node.tar.gz
 {{- end }}
 
-{{- if and .KUBELET .NODE_BINARIES_URL }}
+{{- if and .KUBELET .NODE_BINARIES_ASSET.URL }}
 sudo install --owner=0 --group=0 --mode=0755 /tmp/k8s-binaries/kubernetes/node/bin/kubelet /opt/bin/kubelet
 sudo ln -sf /opt/bin/kubelet /usr/bin/
 rm /tmp/k8s-binaries/kubernetes/node/bin/kubelet
@@ -123,14 +131,14 @@ ExecStart=/opt/bin/kubelet \$KUBELET_KUBECONFIG_ARGS \$KUBELET_CONFIG_ARGS \$KUB
 EOF
 {{- end }}
 
-{{- if and .KUBEADM .NODE_BINARIES_URL }}
+{{- if and .KUBEADM .NODE_BINARIES_ASSET.URL }}
 sudo install --owner=0 --group=0 --mode=0755 /tmp/k8s-binaries/kubernetes/node/bin/kubeadm /opt/bin/kubeadm
 sudo ln -sf /opt/bin/kubeadm /usr/bin/
 rm /tmp/k8s-binaries/kubernetes/node/bin/kubeadm
 {{- end }}
 
-{{- if and .KUBECTL .KUBECTL_URL }}
-curl -L --output /tmp/k8s-binaries/kubectl {{ .KUBECTL_URL }}
+{{- if and .KUBECTL .KUBECTL_ASSET.URL }}
+download_file /tmp/k8s-binaries/kubectl "{{ .KUBECTL_ASSET.SHA256 }}" "{{ .KUBECTL_ASSET.URL }}.sha256" {{ assetURLs .ASSET_CACHE .KUBECTL_ASSET }}
 sudo install --owner=0 --group=0 --mode=0755 /tmp/k8s-binaries/kubectl /opt/bin/kubectl
 sudo ln -sf /opt/bin/kubectl /usr/bin/
 rm /tmp/k8s-binaries/kubectl
@@ -198,9 +206,9 @@ func KubeadmAmazonLinux(cluster *kubeone.KubeOneCluster, force bool) (string, er
 		"KUBELET":                true,
 		"KUBEADM":                true,
 		"KUBECTL":                true,
-		"NODE_BINARIES_URL":      cluster.AssetConfiguration.NodeBinaries.URL,
-		"CNI_URL":                cluster.AssetConfiguration.CNI.URL,
-		"KUBECTL_URL":            cluster.AssetConfiguration.Kubectl.URL,
+		"CNI_ASSET":              cluster.AssetConfiguration.CNI,
+		"NODE_BINARIES_ASSET":    cluster.AssetConfiguration.NodeBinaries,
+		"KUBECTL_ASSET":          cluster.AssetConfiguration.Kubectl,
 		"KUBERNETES_VERSION":     cluster.Versions.Kubernetes,
 		"KUBERNETES_CNI_VERSION": defaultKubernetesCNIVersion,
 		"CONFIGURE_REPOSITORIES": cluster.SystemPackages.ConfigureRepositories,
@@ -209,6 +217,9 @@ func KubeadmAmazonLinux(cluster *kubeone.KubeOneCluster, force bool) (string, er
 		"FORCE":                  force,
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"CONTAINERD_REGISTRIES":  containerdRegistries(cluster),
+		"INSTALL_CRIO":           cluster.ContainerRuntime.CRIO,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
 		"USE_KUBERNETES_REPO":    useKubernetesRepo,
 	})
 }
@@ -228,8 +239,9 @@ func UpgradeKubeadmAndCNIAmazonLinux(cluster *kubeone.KubeOneCluster) (string, e
 	return Render(kubeadmAmazonLinuxTemplate, Data{
 		"UPGRADE":                true,
 		"KUBEADM":                true,
-		"NODE_BINARIES_URL":      cluster.AssetConfiguration.NodeBinaries.URL,
-		"CNI_URL":                cluster.AssetConfiguration.CNI.URL,
+		"CNI_ASSET":              cluster.AssetConfiguration.CNI,
+		"NODE_BINARIES_ASSET":    cluster.AssetConfiguration.NodeBinaries,
+		"KUBECTL_ASSET":          cluster.AssetConfiguration.Kubectl,
 		"KUBERNETES_VERSION":     cluster.Versions.Kubernetes,
 		"KUBERNETES_CNI_VERSION": defaultKubernetesCNIVersion,
 		"CONFIGURE_REPOSITORIES": cluster.SystemPackages.ConfigureRepositories,
@@ -237,6 +249,9 @@ func UpgradeKubeadmAndCNIAmazonLinux(cluster *kubeone.KubeOneCluster) (string, e
 		"PROXY":                  proxy,
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"CONTAINERD_REGISTRIES":  containerdRegistries(cluster),
+		"INSTALL_CRIO":           cluster.ContainerRuntime.CRIO,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
 		"USE_KUBERNETES_REPO":    useKubernetesRepo,
 	})
 }
@@ -253,8 +268,9 @@ func UpgradeKubeletAndKubectlAmazonLinux(cluster *kubeone.KubeOneCluster) (strin
 		"UPGRADE":                true,
 		"KUBELET":                true,
 		"KUBECTL":                true,
-		"NODE_BINARIES_URL":      cluster.AssetConfiguration.NodeBinaries.URL,
-		"KUBECTL_URL":            cluster.AssetConfiguration.Kubectl.URL,
+		"CNI_ASSET":              cluster.AssetConfiguration.CNI,
+		"NODE_BINARIES_ASSET":    cluster.AssetConfiguration.NodeBinaries,
+		"KUBECTL_ASSET":          cluster.AssetConfiguration.Kubectl,
 		"KUBERNETES_VERSION":     cluster.Versions.Kubernetes,
 		"KUBERNETES_CNI_VERSION": defaultKubernetesCNIVersion,
 		"CONFIGURE_REPOSITORIES": cluster.SystemPackages.ConfigureRepositories,
@@ -262,6 +278,9 @@ func UpgradeKubeletAndKubectlAmazonLinux(cluster *kubeone.KubeOneCluster) (strin
 		"PROXY":                  proxy,
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"CONTAINERD_REGISTRIES":  containerdRegistries(cluster),
+		"INSTALL_CRIO":           cluster.ContainerRuntime.CRIO,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
 		"USE_KUBERNETES_REPO":    useKubernetesRepo,
 	})
 }
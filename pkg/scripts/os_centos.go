@@ -22,9 +22,13 @@ const (
 	kubeadmCentOSTemplate = `
 sudo swapoff -a
 sudo sed -i '/.*swap.*/d' /etc/fstab
+{{- if .SELINUX }}
+{{ template "selinux-verify-enforcing" }}
+{{- else }}
 sudo setenforce 0 || true
 sudo sed -i 's/SELINUX=enforcing/SELINUX=permissive/g' /etc/sysconfig/selinux
 sudo sed -i 's/SELINUX=enforcing/SELINUX=permissive/g' /etc/selinux/config
+{{- end }}
 sudo systemctl disable --now firewalld || true
 
 source /etc/kubeone/proxy-env
@@ -44,11 +48,11 @@ sudo mv /tmp/yum.conf /etc/yum.conf
 cat <<EOF | sudo tee /etc/yum.repos.d/kubernetes.repo
 [kubernetes]
 name=Kubernetes
-baseurl=https://packages.cloud.google.com/yum/repos/kubernetes-el7-x86_64
+baseurl={{ cachedURL .ASSET_CACHE "https://packages.cloud.google.com" }}/yum/repos/kubernetes-el7-x86_64
 enabled=1
 gpgcheck=1
 repo_gpgcheck=0
-gpgkey=https://packages.cloud.google.com/yum/doc/yum-key.gpg https://packages.cloud.google.com/yum/doc/rpm-package-key.gpg
+gpgkey={{ cachedURL .ASSET_CACHE "https://packages.cloud.google.com" }}/yum/doc/yum-key.gpg {{ cachedURL .ASSET_CACHE "https://packages.cloud.google.com" }}/yum/doc/rpm-package-key.gpg
 EOF
 {{ end }}
 
@@ -61,6 +65,10 @@ sudo yum install -y \
 	socat \
 	iproute-tc \
 	rsync
+{{- if .SELINUX }}
+
+{{ template "selinux-contexts" }}
+{{- end }}
 
 {{ if .INSTALL_DOCKER }}
 {{ template "docker-daemon-config" . }}
@@ -70,6 +78,9 @@ sudo yum install -y \
 {{ if .INSTALL_CONTAINERD }}
 {{ template "yum-containerd" . }}
 {{ end }}
+{{- if .INSTALL_CRIO }}
+{{ template "yum-crio" . }}
+{{ end }}
 
 {{- if or .FORCE .UPGRADE }}
 sudo yum versionlock delete kubelet kubeadm kubectl kubernetes-cni || true
@@ -122,6 +133,10 @@ func KubeadmCentOS(cluster *kubeone.KubeOneCluster, force bool) (string, error)
 		"FORCE":                  force,
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"CONTAINERD_REGISTRIES":  containerdRegistries(cluster),
+		"INSTALL_CRIO":           cluster.ContainerRuntime.CRIO,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
+		"SELINUX":                cluster.Features.SELinux != nil && cluster.Features.SELinux.Enable,
 	})
 }
 
@@ -145,6 +160,10 @@ func UpgradeKubeadmAndCNICentOS(cluster *kubeone.KubeOneCluster) (string, error)
 		"PROXY":                  proxy,
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"CONTAINERD_REGISTRIES":  containerdRegistries(cluster),
+		"INSTALL_CRIO":           cluster.ContainerRuntime.CRIO,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
+		"SELINUX":                cluster.Features.SELinux != nil && cluster.Features.SELinux.Enable,
 	})
 }
 
@@ -165,5 +184,9 @@ func UpgradeKubeletAndKubectlCentOS(cluster *kubeone.KubeOneCluster) (string, er
 		"PROXY":                  proxy,
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"CONTAINERD_REGISTRIES":  containerdRegistries(cluster),
+		"INSTALL_CRIO":           cluster.ContainerRuntime.CRIO,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
+		"SELINUX":                cluster.Features.SELinux != nil && cluster.Features.SELinux.Enable,
 	})
 }
@@ -48,11 +48,14 @@ sudo DEBIAN_FRONTEND=noninteractive apt-get install --option "Dpkg::Options::=--
 	rsync
 
 {{- if .CONFIGURE_REPOSITORIES }}
-curl -fsSL https://packages.cloud.google.com/apt/doc/apt-key.gpg | sudo apt-key add -
+{{ template "download-file" }}
+download_upstream /tmp/kubernetes-apt-key.gpg "https://packages.cloud.google.com/apt/doc/apt-key.gpg"
+sudo apt-key add /tmp/kubernetes-apt-key.gpg
+rm /tmp/kubernetes-apt-key.gpg
 
 # You'd think that kubernetes-$(lsb_release -sc) belongs there instead, but the debian repo
 # contains neither kubeadm nor kubelet, and the docs themselves suggest using xenial repo.
-echo "deb http://apt.kubernetes.io/ kubernetes-xenial main" | sudo tee /etc/apt/sources.list.d/kubernetes.list
+echo "deb {{ cachedURL .ASSET_CACHE "http://apt.kubernetes.io" }}/ kubernetes-xenial main" | sudo tee /etc/apt/sources.list.d/kubernetes.list
 
 sudo apt-get update
 {{- end }}
@@ -72,6 +75,9 @@ sudo apt-mark unhold kubelet kubeadm kubectl kubernetes-cni
 {{ if .INSTALL_CONTAINERD }}
 {{ template "apt-containerd" . }}
 {{ end }}
+{{- if .INSTALL_CRIO }}
+{{ template "apt-crio" . }}
+{{ end }}
 
 sudo DEBIAN_FRONTEND=noninteractive apt-get install \
 	--option "Dpkg::Options::=--force-confold" \
@@ -125,6 +131,9 @@ func KubeadmDebian(cluster *kubeone.KubeOneCluster, force bool) (string, error)
 		"FORCE":                  force,
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"CONTAINERD_REGISTRIES":  containerdRegistries(cluster),
+		"INSTALL_CRIO":           cluster.ContainerRuntime.CRIO,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
 	})
 }
 
@@ -144,6 +153,9 @@ func UpgradeKubeadmAndCNIDebian(cluster *kubeone.KubeOneCluster) (string, error)
 		"HTTPS_PROXY":            cluster.Proxy.HTTPS,
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"CONTAINERD_REGISTRIES":  containerdRegistries(cluster),
+		"INSTALL_CRIO":           cluster.ContainerRuntime.CRIO,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
 	})
 }
 
@@ -160,5 +172,8 @@ func UpgradeKubeletAndKubectlDebian(cluster *kubeone.KubeOneCluster) (string, er
 		"HTTPS_PROXY":            cluster.Proxy.HTTPS,
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"CONTAINERD_REGISTRIES":  containerdRegistries(cluster),
+		"INSTALL_CRIO":           cluster.ContainerRuntime.CRIO,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
 	})
 }
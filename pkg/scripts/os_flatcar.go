@@ -16,7 +16,11 @@ limitations under the License.
 
 package scripts
 
-import "k8c.io/kubeone/pkg/apis/kubeone"
+import (
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/apis/kubeone"
+)
 
 const (
 	kubeadmFlatcarTemplate = `
@@ -25,16 +29,22 @@ source /etc/kubeone/proxy-env
 {{ template "detect-host-cpu-architecture" }}
 {{ template "sysctl-k8s" }}
 {{ template "journald-config" }}
-
-sudo mkdir -p /opt/cni/bin /etc/kubernetes/pki /etc/kubernetes/manifests
-curl -L "https://github.com/containernetworking/plugins/releases/download/v{{ .KUBERNETES_CNI_VERSION }}/cni-plugins-linux-${HOST_ARCH}-v{{ .KUBERNETES_CNI_VERSION }}.tgz" |
-	sudo tar -C /opt/cni/bin -xz
+{{ template "download-file" }}
 
 RELEASE="v{{ .KUBERNETES_VERSION }}"
+CNI_RELEASE="v{{ .KUBERNETES_CNI_VERSION }}"
 CRI_TOOLS_RELEASE="v{{ .CRITOOLS_VERSION }}"
 
-curl -L https://github.com/kubernetes-sigs/cri-tools/releases/download/${CRI_TOOLS_RELEASE}/crictl-${CRI_TOOLS_RELEASE}-linux-${HOST_ARCH}.tar.gz |
-	sudo tar -C /opt/bin -xz
+sudo mkdir -p /opt/cni/bin /opt/bin /etc/kubernetes/pki /etc/kubernetes/manifests
+download_file /tmp/cni-plugins.tgz "" "https://github.com/containernetworking/plugins/releases/download/${CNI_RELEASE}/cni-plugins-linux-${HOST_ARCH}-${CNI_RELEASE}.tgz.sha256" \
+	{{ downloadURLs .ASSET_CACHE "https://github.com/containernetworking/plugins/releases/download/${CNI_RELEASE}/cni-plugins-linux-${HOST_ARCH}-${CNI_RELEASE}.tgz" }}
+sudo tar -C /opt/cni/bin -xzf /tmp/cni-plugins.tgz
+rm /tmp/cni-plugins.tgz
+
+download_file /tmp/crictl.tar.gz "" "https://github.com/kubernetes-sigs/cri-tools/releases/download/${CRI_TOOLS_RELEASE}/crictl-${CRI_TOOLS_RELEASE}-linux-${HOST_ARCH}.tar.gz.sha256" \
+	{{ downloadURLs .ASSET_CACHE "https://github.com/kubernetes-sigs/cri-tools/releases/download/${CRI_TOOLS_RELEASE}/crictl-${CRI_TOOLS_RELEASE}-linux-${HOST_ARCH}.tar.gz" }}
+sudo tar -C /opt/bin -xzf /tmp/crictl.tar.gz
+rm /tmp/crictl.tar.gz
 
 {{ if .INSTALL_DOCKER }}
 {{ template "docker-daemon-config" . }}
@@ -45,12 +55,10 @@ curl -L https://github.com/kubernetes-sigs/cri-tools/releases/download/${CRI_TOO
 {{ template "flatcar-containerd" }}
 {{ end }}
 
-sudo mkdir -p /opt/bin
 cd /opt/bin
-k8s_rel_baseurl=https://storage.googleapis.com/kubernetes-release/release
 for binary in kubeadm kubelet kubectl; do
-	curl -L --output /tmp/$binary \
-		$k8s_rel_baseurl/${RELEASE}/bin/linux/${HOST_ARCH}/$binary
+	download_file /tmp/$binary "" "https://dl.k8s.io/release/${RELEASE}/bin/linux/${HOST_ARCH}/${binary}.sha256" \
+		{{ downloadURLs .ASSET_CACHE "https://storage.googleapis.com/kubernetes-release/release/${RELEASE}/bin/linux/${HOST_ARCH}/${binary}" "https://dl.k8s.io/release/${RELEASE}/bin/linux/${HOST_ARCH}/${binary}" }}
 	sudo install --owner=0 --group=0 --mode=0755 /tmp/$binary /opt/bin/$binary
 	rm /tmp/$binary
 done
@@ -103,41 +111,45 @@ sudo systemctl daemon-reload
 
 	upgradeKubeadmAndCNIFlatcarScriptTemplate = `
 {{ template "detect-host-cpu-architecture" }}
+{{ template "download-file" }}
 
 source /etc/kubeone/proxy-env
 
-sudo mkdir -p /opt/cni/bin
-curl -L "https://github.com/containernetworking/plugins/releases/download/v{{ .KUBERNETES_CNI_VERSION }}/cni-plugins-linux-${HOST_ARCH}-v{{ .KUBERNETES_CNI_VERSION }}.tgz" |
-	sudo tar -C /opt/cni/bin -xz
-
 RELEASE="v{{ .KUBERNETES_VERSION }}"
+CNI_RELEASE="v{{ .KUBERNETES_CNI_VERSION }}"
 
-sudo mkdir -p /var/tmp/kube-binaries
-cd /var/tmp/kube-binaries
-sudo curl -L --remote-name-all \
-	https://storage.googleapis.com/kubernetes-release/release/${RELEASE}/bin/linux/${HOST_ARCH}/kubeadm
+sudo mkdir -p /opt/cni/bin
+download_file /tmp/cni-plugins.tgz "" "https://github.com/containernetworking/plugins/releases/download/${CNI_RELEASE}/cni-plugins-linux-${HOST_ARCH}-${CNI_RELEASE}.tgz.sha256" \
+	{{ downloadURLs .ASSET_CACHE "https://github.com/containernetworking/plugins/releases/download/${CNI_RELEASE}/cni-plugins-linux-${HOST_ARCH}-${CNI_RELEASE}.tgz" }}
+sudo tar -C /opt/cni/bin -xzf /tmp/cni-plugins.tgz
+rm /tmp/cni-plugins.tgz
+
+download_file /tmp/kubeadm "" "https://dl.k8s.io/release/${RELEASE}/bin/linux/${HOST_ARCH}/kubeadm.sha256" \
+	{{ downloadURLs .ASSET_CACHE "https://storage.googleapis.com/kubernetes-release/release/${RELEASE}/bin/linux/${HOST_ARCH}/kubeadm" "https://dl.k8s.io/release/${RELEASE}/bin/linux/${HOST_ARCH}/kubeadm" }}
 
 sudo mkdir -p /opt/bin
-cd /opt/bin
-sudo mv /var/tmp/kube-binaries/kubeadm .
-sudo chmod +x kubeadm
+sudo install --owner=0 --group=0 --mode=0755 /tmp/kubeadm /opt/bin/kubeadm
+rm /tmp/kubeadm
 `
 
 	upgradeKubeletAndKubectlFlatcarScriptTemplate = `
 source /etc/kubeone/proxy-env
 
 {{ template "detect-host-cpu-architecture" }}
+{{ template "download-file" }}
 
 RELEASE="v{{ .KUBERNETES_VERSION }}"
-sudo mkdir -p /var/tmp/kube-binaries
-cd /var/tmp/kube-binaries
-sudo curl -L --remote-name-all \
-	https://storage.googleapis.com/kubernetes-release/release/${RELEASE}/bin/linux/${HOST_ARCH}/{kubelet,kubectl}
+for binary in kubelet kubectl; do
+	download_file /tmp/$binary "" "https://dl.k8s.io/release/${RELEASE}/bin/linux/${HOST_ARCH}/${binary}.sha256" \
+		{{ downloadURLs .ASSET_CACHE "https://storage.googleapis.com/kubernetes-release/release/${RELEASE}/bin/linux/${HOST_ARCH}/${binary}" "https://dl.k8s.io/release/${RELEASE}/bin/linux/${HOST_ARCH}/${binary}" }}
+done
+
 sudo mkdir -p /opt/bin
-cd /opt/bin
 sudo systemctl stop kubelet
-sudo mv /var/tmp/kube-binaries/{kubelet,kubectl} .
-sudo chmod +x {kubelet,kubectl}
+for binary in kubelet kubectl; do
+	sudo install --owner=0 --group=0 --mode=0755 /tmp/$binary /opt/bin/$binary
+	rm /tmp/$binary
+done
 
 cat <<EOF | sudo tee /etc/systemd/system/kubelet.service
 [Unit]
@@ -176,6 +188,14 @@ sudo systemctl start kubelet
 )
 
 func KubeadmFlatcar(cluster *kubeone.KubeOneCluster) (string, error) {
+	if cluster.ContainerRuntime.CRIO != nil {
+		return "", errors.New("cri-o container runtime is not available on flatcar")
+	}
+
+	if len(containerdRegistries(cluster)) > 0 {
+		return "", errors.New("containerd registries are not supported on flatcar")
+	}
+
 	return Render(kubeadmFlatcarTemplate, Data{
 		"KUBERNETES_VERSION":     cluster.Versions.Kubernetes,
 		"KUBERNETES_CNI_VERSION": defaultKubernetesCNIVersion,
@@ -183,6 +203,7 @@ func KubeadmFlatcar(cluster *kubeone.KubeOneCluster) (string, error) {
 		"INSECURE_REGISTRY":      cluster.RegistryConfiguration.InsecureRegistryAddress(),
 		"INSTALL_DOCKER":         cluster.ContainerRuntime.Docker,
 		"INSTALL_CONTAINERD":     cluster.ContainerRuntime.Containerd,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
 	})
 }
 
@@ -190,15 +211,17 @@ func RemoveBinariesFlatcar() (string, error) {
 	return Render(removeBinariesFlatcarScriptTemplate, nil)
 }
 
-func UpgradeKubeadmAndCNIFlatcar(k8sVersion string) (string, error) {
+func UpgradeKubeadmAndCNIFlatcar(cluster *kubeone.KubeOneCluster) (string, error) {
 	return Render(upgradeKubeadmAndCNIFlatcarScriptTemplate, Data{
-		"KUBERNETES_VERSION":     k8sVersion,
+		"KUBERNETES_VERSION":     cluster.Versions.Kubernetes,
 		"KUBERNETES_CNI_VERSION": defaultKubernetesCNIVersion,
+		"ASSET_CACHE":            cluster.AssetConfiguration.Cache,
 	})
 }
 
-func UpgradeKubeletAndKubectlFlatcar(k8sVersion string) (string, error) {
+func UpgradeKubeletAndKubectlFlatcar(cluster *kubeone.KubeOneCluster) (string, error) {
 	return Render(upgradeKubeletAndKubectlFlatcarScriptTemplate, Data{
-		"KUBERNETES_VERSION": k8sVersion,
+		"KUBERNETES_VERSION": cluster.Versions.Kubernetes,
+		"ASSET_CACHE":        cluster.AssetConfiguration.Cache,
 	})
 }
@@ -35,6 +35,31 @@ func withDocker(cls *kubeone.KubeOneCluster) {
 	cls.ContainerRuntime.Docker = &kubeone.ContainerRuntimeDocker{}
 }
 
+func withContainerdRegistries(cls *kubeone.KubeOneCluster) {
+	cls.ContainerRuntime.Containerd = &kubeone.ContainerRuntimeContainerd{
+		Registries: map[string]kubeone.RegistryMirror{
+			"docker.io": {
+				Mirrors: []string{"https://mirror.example.com", "https://mirror2.example.com"},
+			},
+			"registry.example.com": {
+				Mirrors:            []string{"https://10.0.0.1:5000"},
+				Auth:               &kubeone.RegistryAuth{Username: "user", Password: "pass"},
+				InsecureSkipVerify: true,
+			},
+		},
+	}
+}
+
+func withCRIO(cls *kubeone.KubeOneCluster) {
+	cls.ContainerRuntime.Containerd = nil
+	cls.ContainerRuntime.Docker = nil
+	cls.ContainerRuntime.CRIO = &kubeone.ContainerRuntimeCRIO{}
+}
+
+func withSELinux(cls *kubeone.KubeOneCluster) {
+	cls.Features.SELinux = &kubeone.SELinux{Enable: true}
+}
+
 func withKubeVersion(ver string) genClusterOpts {
 	return func(cls *kubeone.KubeOneCluster) {
 		cls.Versions.Kubernetes = ver
@@ -82,6 +107,18 @@ func withDefaultAssetConfiguration(cls *kubeone.KubeOneCluster) {
 	}
 }
 
+func withBinaryAssetMirrors(cls *kubeone.KubeOneCluster) {
+	cls.AssetConfiguration.CNI.Mirrors = []string{"http://127.0.0.2/cni.tar.gz"}
+	cls.AssetConfiguration.CNI.SHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+}
+
+func withAssetCache(cls *kubeone.KubeOneCluster) {
+	cls.AssetConfiguration.Cache = &kubeone.AssetCache{
+		Host: "10.0.0.1",
+		Port: 8008,
+	}
+}
+
 func genCluster(opts ...genClusterOpts) kubeone.KubeOneCluster {
 	cls := &kubeone.KubeOneCluster{
 		Versions: kubeone.VersionConfig{
@@ -145,6 +182,36 @@ func TestKubeadmDebian(t *testing.T) {
 				cluster: genCluster(withContainerd, withInsecureRegistry("127.0.0.1:5000")),
 			},
 		},
+		{
+			name: "with containerd with registries",
+			args: args{
+				cluster: genCluster(withContainerdRegistries),
+			},
+		},
+		{
+			name: "with containerd with registries with insecure registry",
+			args: args{
+				cluster: genCluster(withContainerdRegistries, withInsecureRegistry("127.0.0.1:5000")),
+			},
+		},
+		{
+			name: "with crio",
+			args: args{
+				cluster: genCluster(withCRIO, withKubeVersion("1.22.5")),
+			},
+		},
+		{
+			name: "with crio with insecure registry",
+			args: args{
+				cluster: genCluster(withCRIO, withKubeVersion("1.22.5"), withInsecureRegistry("127.0.0.1:5000")),
+			},
+		},
+		{
+			name: "with asset cache",
+			args: args{
+				cluster: genCluster(withContainerd, withAssetCache),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -260,6 +327,36 @@ func TestKubeadmCentOS(t *testing.T) {
 				cluster: genCluster(withContainerd, withInsecureRegistry("127.0.0.1:5000")),
 			},
 		},
+		{
+			name: "with containerd with registries",
+			args: args{
+				cluster: genCluster(withContainerdRegistries),
+			},
+		},
+		{
+			name: "with containerd with registries with insecure registry",
+			args: args{
+				cluster: genCluster(withContainerdRegistries, withInsecureRegistry("127.0.0.1:5000")),
+			},
+		},
+		{
+			name: "with crio",
+			args: args{
+				cluster: genCluster(withCRIO, withKubeVersion("1.22.5")),
+			},
+		},
+		{
+			name: "with crio with insecure registry",
+			args: args{
+				cluster: genCluster(withCRIO, withKubeVersion("1.22.5"), withInsecureRegistry("127.0.0.1:5000")),
+			},
+		},
+		{
+			name: "with selinux",
+			args: args{
+				cluster: genCluster(withContainerd, withSELinux),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -359,6 +456,42 @@ func TestKubeadmAmazonLinux(t *testing.T) {
 				cluster: genCluster(withContainerd, withInsecureRegistry("127.0.0.1:5000")),
 			},
 		},
+		{
+			name: "with containerd with registries",
+			args: args{
+				cluster: genCluster(withContainerdRegistries),
+			},
+		},
+		{
+			name: "with containerd with registries with insecure registry",
+			args: args{
+				cluster: genCluster(withContainerdRegistries, withInsecureRegistry("127.0.0.1:5000")),
+			},
+		},
+		{
+			name: "with crio",
+			args: args{
+				cluster: genCluster(withCRIO, withKubeVersion("1.22.5")),
+			},
+		},
+		{
+			name: "with crio with insecure registry",
+			args: args{
+				cluster: genCluster(withCRIO, withKubeVersion("1.22.5"), withInsecureRegistry("127.0.0.1:5000")),
+			},
+		},
+		{
+			name: "with asset cache",
+			args: args{
+				cluster: genCluster(withContainerd, withDefaultAssetConfiguration, withAssetCache),
+			},
+		},
+		{
+			name: "with binary asset mirrors",
+			args: args{
+				cluster: genCluster(withContainerd, withDefaultAssetConfiguration, withBinaryAssetMirrors),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -422,6 +555,12 @@ func TestKubeadmFlatcar(t *testing.T) {
 				cluster: genCluster(withContainerd),
 			},
 		},
+		{
+			name: "with asset cache",
+			args: args{
+				cluster: genCluster(withContainerd, withAssetCache),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -438,6 +577,24 @@ func TestKubeadmFlatcar(t *testing.T) {
 	}
 }
 
+func TestKubeadmFlatcarCRIO(t *testing.T) {
+	t.Parallel()
+
+	cluster := genCluster(withCRIO)
+	if _, err := KubeadmFlatcar(&cluster); err == nil {
+		t.Errorf("KubeadmFlatcar() expected error for cri-o container runtime")
+	}
+}
+
+func TestKubeadmFlatcarContainerdRegistries(t *testing.T) {
+	t.Parallel()
+
+	cluster := genCluster(withContainerdRegistries)
+	if _, err := KubeadmFlatcar(&cluster); err == nil {
+		t.Errorf("KubeadmFlatcar() expected error for containerd registries")
+	}
+}
+
 func TestRemoveBinariesDebian(t *testing.T) {
 	t.Parallel()
 
@@ -528,7 +685,8 @@ func TestUpgradeKubeadmAndCNIAmazonLinux(t *testing.T) {
 func TestUpgradeKubeadmAndCNIFlatcar(t *testing.T) {
 	t.Parallel()
 
-	got, err := UpgradeKubeadmAndCNIFlatcar("v1.17.4")
+	cls := genCluster(withDocker, withAssetCache)
+	got, err := UpgradeKubeadmAndCNIFlatcar(&cls)
 	if err != nil {
 		t.Errorf("UpgradeKubeadmAndCNIFlatcar() error = %v", err)
 		return
@@ -579,7 +737,8 @@ func TestUpgradeKubeletAndKubectlAmazonLinux(t *testing.T) {
 func TestUpgradeKubeletAndKubectlFlatcar(t *testing.T) {
 	t.Parallel()
 
-	got, err := UpgradeKubeletAndKubectlFlatcar("v1.17.4")
+	cls := genCluster(withDocker, withAssetCache)
+	got, err := UpgradeKubeletAndKubectlFlatcar(&cls)
 	if err != nil {
 		t.Errorf("UpgradeKubeletAndKubectlFlatcar() error = %v", err)
 		return
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+)
+
+var (
+	hostPreflightTemplate = heredoc.Doc(`
+		fact() {
+			echo "$1=$2"
+		}
+
+		if sudo -n true >/dev/null 2>&1; then fact sudo ok; else fact sudo fail; fi
+		if [[ -f /etc/kubernetes/kubelet.conf ]]; then fact provisioned true; else fact provisioned false; fi
+
+		fact cpus "$(nproc)"
+		fact memory_kib "$(awk '/^MemTotal:/ {print $2}' /proc/meminfo)"
+		fact disk_free_kib "$(df -Pk /var/lib | awk 'NR==2 {print $4}')"
+		fact time_synchronized "$(timedatectl show --property=NTPSynchronized --value 2>/dev/null || echo unknown)"
+		fact fips_enabled "$(cat /proc/sys/crypto/fips_enabled 2>/dev/null || echo 0)"
+
+		{{- range .PORTS }}
+		if ss -ltn "sport = :{{ . }}" | tail -n +2 | grep -q .; then fact port/{{ . }} in-use; else fact port/{{ . }} free; fi
+		{{- end }}
+
+		if getent hosts "$(hostname)" >/dev/null; then fact dns/hostname ok; else fact dns/hostname fail; fi
+		{{- range .DNS_NAMES }}
+		if getent hosts {{ . }} >/dev/null; then fact dns/{{ . }} ok; else fact dns/{{ . }} fail; fi
+		{{- end }}
+
+		{{- range .KERNEL_MODULES }}
+		if [[ -d /sys/module/{{ . }} ]] || modinfo {{ . }} >/dev/null 2>&1; then fact module/{{ . }} ok; else fact module/{{ . }} missing; fi
+		{{- end }}
+	`)
+)
+
+// HostPreflight collects the facts about the host checked before provisioning
+// it, one key=value pair per line. The host must not listen on the given
+// ports, and the given DNS names must be resolvable from the host.
+func HostPreflight(ports []int, dnsNames, kernelModules []string) (string, error) {
+	return Render(hostPreflightTemplate, Data{
+		"PORTS":          ports,
+		"DNS_NAMES":      dnsNames,
+		"KERNEL_MODULES": kernelModules,
+	})
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestHostPreflight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		ports    []int
+		dnsNames []string
+	}{
+		{name: "control-plane", ports: []int{6443, 2379, 2380, 10250}, dnsNames: []string{"api.example.com"}},
+		{name: "worker", ports: []int{10250}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HostPreflight(tt.ports, tt.dnsNames, []string{"overlay", "br_netfilter"})
+			if err != nil {
+				t.Errorf("HostPreflight() error = %v", err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
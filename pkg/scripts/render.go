@@ -27,9 +27,22 @@ import (
 
 var (
 	containerRuntimeTemplates = map[string]string{
+		"containerd-registries": heredoc.Docf(`
+			{{- range $registry, $mirror := .CONTAINERD_REGISTRIES -}}
+			sudo mkdir -p %[1]s/{{ $registry }}
+			cat <<EOF | sudo tee %[1]s/{{ $registry }}/hosts.toml
+			{{ containerdHostsCfg $registry $mirror -}}
+			EOF
+
+			{{ end -}}
+			`,
+			containerdCertsDir,
+		),
+
 		"containerd-config": heredoc.Doc(`
+			{{ template "containerd-registries" . -}}
 			cat <<EOF | sudo tee /etc/containerd/config.toml
-			{{ containerdCfg .INSECURE_REGISTRY -}}
+			{{ containerdCfg .INSECURE_REGISTRY .CONTAINERD_REGISTRIES -}}
 			EOF
 
 			cat <<EOF | sudo tee /etc/crictl.yaml
@@ -50,7 +63,10 @@ var (
 
 		"apt-docker-ce": heredoc.Docf(`
 			{{ if .CONFIGURE_REPOSITORIES }}
-			curl -fsSL https://download.docker.com/linux/ubuntu/gpg | sudo apt-key add -
+			{{ template "download-file" }}
+			download_upstream /tmp/docker-apt-key.gpg "https://download.docker.com/linux/ubuntu/gpg"
+			sudo apt-key add /tmp/docker-apt-key.gpg
+			rm /tmp/docker-apt-key.gpg
 			# Docker provides two different apt repos for ubuntu, bionic and focal. The focal repo currently
 			# contains only Docker 19.03.14, which is not validated for all Kubernetes version.
 			# Therefore, we use bionic repo which has all Docker versions.
@@ -184,11 +200,15 @@ var (
 		),
 
 		"apt-containerd": heredoc.Docf(`
+			{{- $CONTAINERD_VERSION_TO_INSTALL := "%s" }}
+			{{- if .CONTAINERD_REGISTRIES }}{{ $CONTAINERD_VERSION_TO_INSTALL = "%s" }}{{ end -}}
 			{{ if .CONFIGURE_REPOSITORIES }}
 			sudo apt-get update
 			sudo apt-get install -y apt-transport-https ca-certificates curl software-properties-common lsb-release
-			curl -fsSL https://download.docker.com/linux/ubuntu/gpg |
-				sudo apt-key add -
+			{{ template "download-file" }}
+			download_upstream /tmp/docker-apt-key.gpg "https://download.docker.com/linux/ubuntu/gpg"
+			sudo apt-key add /tmp/docker-apt-key.gpg
+			rm /tmp/docker-apt-key.gpg
 			sudo add-apt-repository "deb https://download.docker.com/linux/ubuntu $(lsb_release -cs) stable"
 			{{ end }}
 
@@ -196,15 +216,18 @@ var (
 			sudo apt-mark unhold containerd.io || true
 			{{ end }}
 
-			sudo apt-get install -y containerd.io=%s
+			sudo apt-get install -y containerd.io={{ $CONTAINERD_VERSION_TO_INSTALL }}
 			sudo apt-mark hold containerd.io
 
 			{{ template "containerd-config" . -}}
 			`,
 			defaultContainerdVersion,
+			registriesContainerdVersion,
 		),
 
 		"yum-containerd": heredoc.Docf(`
+			{{- $CONTAINERD_VERSION_TO_INSTALL := "%s" }}
+			{{- if .CONTAINERD_REGISTRIES }}{{ $CONTAINERD_VERSION_TO_INSTALL = "%s" }}{{ end -}}
 			{{ if .CONFIGURE_REPOSITORIES }}
 			sudo yum install -y yum-utils
 			sudo yum-config-manager --add-repo=https://download.docker.com/linux/centos/docker-ce.repo
@@ -219,28 +242,141 @@ var (
 			sudo yum versionlock delete containerd.io
 			{{- end }}
 
-			sudo yum install -y containerd.io-%s
+			sudo yum install -y containerd.io-{{ $CONTAINERD_VERSION_TO_INSTALL }}
 			sudo yum versionlock add containerd.io
 
 			{{ template "containerd-config" . -}}
 			`,
 			defaultContainerdVersion,
+			registriesContainerdVersion,
 		),
 
 		"yum-containerd-amzn": heredoc.Docf(`
+			{{- $CONTAINERD_VERSION_TO_INSTALL := "%s" }}
+			{{- if .CONTAINERD_REGISTRIES }}{{ $CONTAINERD_VERSION_TO_INSTALL = "%s" }}{{ end }}
 			{{- if or .FORCE .UPGRADE }}
 			sudo yum versionlock delete containerd cri-tools
 			{{- end }}
 
-			sudo yum install -y containerd-%s cri-tools-%s
+			sudo yum install -y containerd-{{ $CONTAINERD_VERSION_TO_INSTALL }} cri-tools-%s
 			sudo yum versionlock add containerd cri-tools
 
 			{{ template "containerd-config" . -}}
 			`,
 			defaultAmazonContainerdVersion,
+			registriesAmazonContainerdVersion,
 			defaultAmazonCrictlVersion,
 		),
 
+		"crio-config": heredoc.Doc(`
+			sudo mkdir -p /etc/crio/crio.conf.d
+			cat <<EOF | sudo tee /etc/crio/crio.conf.d/10-kubeone.conf
+			[crio.runtime]
+			cgroup_manager = "systemd"
+			conmon_cgroup = "pod"
+			EOF
+
+			{{- if .INSECURE_REGISTRY }}
+			sudo mkdir -p /etc/containers/registries.conf.d
+			cat <<EOF | sudo tee /etc/containers/registries.conf.d/kubeone.conf
+			[[registry]]
+			location = "{{ .INSECURE_REGISTRY }}"
+			insecure = true
+			EOF
+			{{- end }}
+
+			# The pod network is configured by the CNI addon, not by the bridge
+			# networks shipped with the CRI-O packages
+			sudo rm -f /etc/cni/net.d/100-crio-bridge.conf /etc/cni/net.d/87-podman-bridge.conflist
+
+			cat <<EOF | sudo tee /etc/crictl.yaml
+			runtime-endpoint: unix:///var/run/crio/crio.sock
+			EOF
+
+			sudo mkdir -p /etc/systemd/system/crio.service.d
+			cat <<EOF | sudo tee /etc/systemd/system/crio.service.d/environment.conf
+			[Service]
+			Restart=always
+			EnvironmentFile=-/etc/environment
+			EOF
+
+			sudo systemctl daemon-reload
+			sudo systemctl enable --now crio
+			sudo systemctl restart crio
+		`),
+
+		"apt-crio": heredoc.Doc(`
+			{{- $kubeVersion := semver .KUBERNETES_VERSION }}
+			{{- $crioVersion := printf "%d.%d" $kubeVersion.Major $kubeVersion.Minor }}
+			{{ if .CONFIGURE_REPOSITORIES }}
+			source /etc/os-release
+			crio_os="xUbuntu_${VERSION_ID}"
+			if [[ "${ID}" == "debian" ]]; then
+				crio_os="Debian_${VERSION_ID}"
+			fi
+			kubic_repo="https://download.opensuse.org/repositories/devel:/kubic:/libcontainers:/stable"
+			sudo rm -f /etc/apt/sources.list.d/devel:kubic:libcontainers:stable:cri-o:*.list
+			echo "deb ${kubic_repo}/${crio_os}/ /" |
+				sudo tee /etc/apt/sources.list.d/devel:kubic:libcontainers:stable.list
+			echo "deb ${kubic_repo}:/cri-o:/{{ $crioVersion }}/${crio_os}/ /" |
+				sudo tee /etc/apt/sources.list.d/devel:kubic:libcontainers:stable:cri-o:{{ $crioVersion }}.list
+			{{ template "download-file" }}
+			download_upstream /tmp/kubic-release.key "${kubic_repo}/${crio_os}/Release.key"
+			download_upstream /tmp/kubic-crio-release.key "${kubic_repo}:/cri-o:/{{ $crioVersion }}/${crio_os}/Release.key"
+			sudo apt-key add /tmp/kubic-release.key
+			sudo apt-key add /tmp/kubic-crio-release.key
+			rm /tmp/kubic-release.key /tmp/kubic-crio-release.key
+			sudo apt-get update
+			{{ end }}
+
+			{{ if or .FORCE .UPGRADE }}
+			sudo apt-mark unhold cri-o cri-o-runc || true
+			{{ end }}
+
+			sudo DEBIAN_FRONTEND=noninteractive apt-get install \
+				--option "Dpkg::Options::=--force-confold" \
+				-y \
+				cri-o \
+				cri-o-runc
+			sudo apt-mark hold cri-o cri-o-runc
+
+			{{ template "crio-config" . -}}
+			`),
+
+		"yum-crio": heredoc.Doc(`
+			{{- $kubeVersion := semver .KUBERNETES_VERSION }}
+			{{- $crioVersion := printf "%d.%d" $kubeVersion.Major $kubeVersion.Minor }}
+			{{ if .CONFIGURE_REPOSITORIES }}
+			source /etc/os-release
+			crio_os="CentOS_${VERSION_ID%%.*}"
+			# Amazon Linux 2 uses the CentOS 7 packages
+			if [[ "${ID}" == "amzn" ]]; then
+				crio_os="CentOS_7"
+			fi
+			kubic_repo="https://download.opensuse.org/repositories/devel:/kubic:/libcontainers:/stable"
+			sudo rm -f /etc/yum.repos.d/devel:kubic:libcontainers:stable:cri-o:*.repo
+			{{ template "download-file" }}
+			download_upstream /tmp/kubic.repo \
+				"${kubic_repo}/${crio_os}/devel:kubic:libcontainers:stable.repo"
+			download_upstream /tmp/kubic-crio.repo \
+				"${kubic_repo}:/cri-o:/{{ $crioVersion }}/${crio_os}/devel:kubic:libcontainers:stable:cri-o:{{ $crioVersion }}.repo"
+			sudo install --owner=0 --group=0 --mode=0644 /tmp/kubic.repo \
+				/etc/yum.repos.d/devel:kubic:libcontainers:stable.repo
+			sudo install --owner=0 --group=0 --mode=0644 /tmp/kubic-crio.repo \
+				/etc/yum.repos.d/devel:kubic:libcontainers:stable:cri-o:{{ $crioVersion }}.repo
+			rm /tmp/kubic.repo /tmp/kubic-crio.repo
+			{{ end }}
+
+			{{ if or .FORCE .UPGRADE }}
+			sudo yum versionlock delete cri-o || true
+			{{- end }}
+
+			sudo yum install -y cri-o
+			sudo yum versionlock add cri-o
+
+			{{ template "crio-config" . -}}
+			`),
+
 		"flatcar-containerd": heredoc.Doc(`
 			cat <<EOF | sudo tee /etc/crictl.yaml
 			runtime-endpoint: unix:///run/containerd/containerd.sock
@@ -268,8 +404,12 @@ func Render(cmd string, variables map[string]interface{}) (string, error) {
 	tpl := template.New("base").
 		Funcs(sprig.TxtFuncMap()).
 		Funcs(template.FuncMap{
-			"dockerCfg":     dockerCfg,
-			"containerdCfg": containerdCfg,
+			"dockerCfg":          dockerCfg,
+			"containerdCfg":      containerdCfg,
+			"containerdHostsCfg": containerdHostsCfg,
+			"cachedURL":          cachedURL,
+			"downloadURLs":       downloadURLs,
+			"assetURLs":          assetURLs,
 		})
 
 	_, err := tpl.New("library").Parse(libraryTemplate)
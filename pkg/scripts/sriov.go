@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"strings"
+
+	"github.com/MakeNowJust/heredoc/v2"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+var (
+	sriovTemplate = heredoc.Doc(`
+		{{- if .KERNEL_PARAMETERS }}
+		if [ -f /etc/default/grub ]; then
+			grub_changed=false
+			for param in {{ .KERNEL_PARAMETERS }}; do
+				if ! grep -q "^GRUB_CMDLINE_LINUX=.*[\" ]${param}[\" ]" /etc/default/grub; then
+					sudo sed -i "s/^GRUB_CMDLINE_LINUX=\"\(.*\)\"/GRUB_CMDLINE_LINUX=\"\1 ${param}\"/" /etc/default/grub
+					grub_changed=true
+				fi
+			done
+
+			if [ "${grub_changed}" = true ]; then
+				if command -v update-grub >/dev/null; then
+					sudo update-grub
+				else
+					sudo grub2-mkconfig -o /boot/grub2/grub.cfg
+				fi
+			fi
+		fi
+
+		for param in {{ .KERNEL_PARAMETERS }}; do
+			if ! grep -qw -- "${param}" /proc/cmdline; then
+				echo "WARNING: kernel parameter ${param} is not active, reboot the host to enable SR-IOV"
+			fi
+		done
+		{{- end }}
+
+		sudo mkdir -p /etc/kubeone /opt/bin
+		cat <<EOF | sudo tee /etc/kubeone/sriov-vfs.conf
+		{{- range .PHYSICAL_FUNCTIONS }}
+		{{ .Interface }} {{ .NumVFs }}
+		{{- end }}
+		EOF
+
+		cat <<'EOF' | sudo tee /opt/bin/kubeone-sriov-vfs
+		#!/bin/sh
+		set -eu
+		while read -r pf vfs; do
+			numvfs="/sys/class/net/${pf}/device/sriov_numvfs"
+			if [ ! -f "${numvfs}" ]; then
+				echo "${pf} doesn't support SR-IOV"
+				exit 1
+			fi
+			if [ "$(cat "${numvfs}")" != "${vfs}" ]; then
+				# the number of virtual functions can be changed only from zero
+				echo 0 > "${numvfs}"
+				echo "${vfs}" > "${numvfs}"
+			fi
+		done < /etc/kubeone/sriov-vfs.conf
+		EOF
+		sudo chmod 755 /opt/bin/kubeone-sriov-vfs
+
+		cat <<EOF | sudo tee /etc/systemd/system/kubeone-sriov-vfs.service
+		[Unit]
+		Description=Configure SR-IOV virtual functions
+		After=network-pre.target
+		Before=kubelet.service
+
+		[Service]
+		Type=oneshot
+		RemainAfterExit=yes
+		ExecStart=/opt/bin/kubeone-sriov-vfs
+
+		[Install]
+		WantedBy=multi-user.target
+		EOF
+
+		sudo systemctl daemon-reload
+		sudo systemctl enable kubeone-sriov-vfs.service
+		sudo systemctl restart kubeone-sriov-vfs.service
+	`)
+)
+
+// SRIOV configures the kernel parameters and the virtual functions of the
+// physical functions on the host. Virtual functions are recreated on boot by
+// the kubeone-sriov-vfs service.
+func SRIOV(kernelParameters []string, physicalFunctions []kubeoneapi.SRIOVPhysicalFunction) (string, error) {
+	return Render(sriovTemplate, Data{
+		"KERNEL_PARAMETERS":  strings.Join(kernelParameters, " "),
+		"PHYSICAL_FUNCTIONS": physicalFunctions,
+	})
+}
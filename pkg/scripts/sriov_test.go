@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scripts
+
+import (
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+func TestSRIOV(t *testing.T) {
+	t.Parallel()
+
+	physicalFunctions := []kubeoneapi.SRIOVPhysicalFunction{
+		{Interface: "ens1f0", NumVFs: 8},
+		{Interface: "ens1f1", NumVFs: 4},
+	}
+
+	tests := []struct {
+		name             string
+		kernelParameters []string
+	}{
+		{name: "with-kernel-parameters", kernelParameters: []string{"intel_iommu=on", "iommu=pt"}},
+		{name: "without-kernel-parameters"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SRIOV(tt.kernelParameters, physicalFunctions)
+			if err != nil {
+				t.Errorf("SRIOV() error = %v", err)
+				return
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+		})
+	}
+}
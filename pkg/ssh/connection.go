@@ -17,6 +17,7 @@ limitations under the License.
 package ssh
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"io/ioutil"
@@ -34,6 +35,41 @@ import (
 
 const socketEnvPrefix = "env:"
 
+const (
+	// sudoPasswordEnv is the variable holding the sudo password in the
+	// commands run on the hosts requiring a password for sudo. The password
+	// is read from stdin, so it never appears on the command line, and it's
+	// exported only to sudo, which passes it to the askpass helper but not to
+	// the command it runs.
+	sudoPasswordEnv = "KUBEONE_SUDO_PASSWORD"
+
+	// askpassTemplate is the template of the SUDO_ASKPASS helper printing the
+	// sudo password. The helper doesn't contain the password itself.
+	askpassTemplate = "$HOME/.kubeone-askpass.XXXXXX"
+
+	askpassScript = `#!/bin/sh
+printf '%s\n' "$` + sudoPasswordEnv + `"
+`
+)
+
+// sudoPasswordPrelude reads the sudo password from stdin and makes sudo use
+// the askpass helper at the given path
+func sudoPasswordPrelude(askpassPath string) string {
+	return `IFS= read -r ` + sudoPasswordEnv + `
+export SUDO_ASKPASS=` + shellQuote(askpassPath) + `
+sudo() ( export ` + sudoPasswordEnv + `; command sudo -A "$@" )
+`
+}
+
+// shellQuote quotes the value to be used as a single shell word
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
+
+// PasswordFunc returns the password when it's needed for the first time, e.g.
+// by prompting for it
+type PasswordFunc func() (string, error)
+
 var (
 	_ Tunneler = &connection{}
 )
@@ -45,6 +81,21 @@ type Connection interface {
 	io.Closer
 }
 
+// NotStartedError is returned when the command is not started because the SSH
+// session couldn't be opened. Unlike the commands failing on the host, such
+// commands are safe to run again.
+type NotStartedError struct {
+	Err error
+}
+
+func (e *NotStartedError) Error() string {
+	return "failed to get SSH session: " + e.Err.Error()
+}
+
+func (e *NotStartedError) Unwrap() error {
+	return e.Err
+}
+
 // Tunneler interface creates net.Conn originating from the remote ssh host to
 // target `addr`
 type Tunneler interface {
@@ -56,18 +107,36 @@ type Tunneler interface {
 // Opts represents all the possible options for connecting to
 // a remote server via SSH.
 type Opts struct {
-	Context     context.Context
-	Username    string
-	Password    string
-	Hostname    string
-	Port        int
-	PrivateKey  string
-	KeyFile     string
-	AgentSocket string
-	Timeout     time.Duration
-	Bastion     string
-	BastionPort int
-	BastionUser string
+	Context  context.Context
+	Username string
+	Password string
+	// SudoPassword returns the password sudo asks for on the hosts where the
+	// user is not allowed to run sudo without a password. It's called only
+	// if sudo requires the password on the host.
+	SudoPassword PasswordFunc
+	Hostname     string
+	Port         int
+	PrivateKey   string
+	KeyFile      string
+	Certificate  string
+	CertFile     string
+	AgentSocket  string
+	Timeout      time.Duration
+	Bastion      string
+	BastionPort  int
+	BastionUser  string
+	Bastions     []Bastion
+}
+
+// Bastion represents the options for connecting to a single bastion (or jump)
+// host. Bastions are connected through in order, the private key is offered
+// in addition to the keys of the target host.
+type Bastion struct {
+	Hostname   string
+	Port       int
+	Username   string
+	PrivateKey string
+	KeyFile    string
 }
 
 func validateOptions(o Opts) (Opts, error) {
@@ -93,6 +162,16 @@ func validateOptions(o Opts) (Opts, error) {
 		o.KeyFile = ""
 	}
 
+	if len(o.CertFile) > 0 {
+		content, err := ioutil.ReadFile(o.CertFile)
+		if err != nil {
+			return o, errors.Wrapf(err, "failed to read certificate file %q", o.CertFile)
+		}
+
+		o.Certificate = string(content)
+		o.CertFile = ""
+	}
+
 	if o.Port <= 0 {
 		o.Port = 22
 	}
@@ -105,6 +184,43 @@ func validateOptions(o Opts) (Opts, error) {
 		o.BastionUser = o.Username
 	}
 
+	if o.Bastion != "" {
+		o.Bastions = append([]Bastion{{
+			Hostname: o.Bastion,
+			Port:     o.BastionPort,
+			Username: o.BastionUser,
+		}}, o.Bastions...)
+		o.Bastion = ""
+	}
+
+	bastions := make([]Bastion, 0, len(o.Bastions))
+	for _, b := range o.Bastions {
+		if len(b.Hostname) == 0 {
+			return o, errors.New("no hostname specified for SSH bastion")
+		}
+
+		if len(b.KeyFile) > 0 {
+			content, err := ioutil.ReadFile(b.KeyFile)
+			if err != nil {
+				return o, errors.Wrapf(err, "failed to read bastion keyfile %q", b.KeyFile)
+			}
+
+			b.PrivateKey = string(content)
+			b.KeyFile = ""
+		}
+
+		if b.Port <= 0 {
+			b.Port = 22
+		}
+
+		if b.Username == "" {
+			b.Username = o.Username
+		}
+
+		bastions = append(bastions, b)
+	}
+	o.Bastions = bastions
+
 	if o.Timeout == 0 {
 		o.Timeout = 60 * time.Second
 	}
@@ -112,12 +228,26 @@ func validateOptions(o Opts) (Opts, error) {
 	return o, nil
 }
 
+// keepaliveInterval is the interval of the keepalive requests detecting the
+// broken connections
+const keepaliveInterval = 30 * time.Second
+
+// connection is the SSH client multiplexing the sessions of all commands run
+// on the host. The broken client is redialed when the next session is opened.
 type connection struct {
 	mu        sync.Mutex
 	sshclient *ssh.Client
-	connector *Connector
-	ctx       context.Context
-	cancel    context.CancelFunc
+	bastions  []*ssh.Client
+	closed    bool
+	opts      Opts
+	signers   []ssh.Signer
+	// sudoPassword is written to stdin of every command, see sudoPasswordPrelude
+	sudoPassword string
+	// askpassPath is the path of the askpass helper installed on the host
+	askpassPath string
+	connector   *Connector
+	ctx         context.Context
+	cancel      context.CancelFunc
 }
 
 // NewConnection attempts to create a new SSH connection to the host
@@ -128,11 +258,7 @@ func NewConnection(connector *Connector, o Opts) (Connection, error) {
 		return nil, errors.Wrap(err, "failed to validate ssh connection options")
 	}
 
-	authMethods := make([]ssh.AuthMethod, 0)
-
-	if len(o.Password) > 0 {
-		authMethods = append(authMethods, ssh.Password(o.Password))
-	}
+	var signers []ssh.Signer
 
 	if len(o.PrivateKey) > 0 {
 		signer, parseErr := ssh.ParsePrivateKey([]byte(o.PrivateKey))
@@ -140,10 +266,10 @@ func NewConnection(connector *Connector, o Opts) (Connection, error) {
 			return nil, errors.Wrap(parseErr, "the given SSH key could not be parsed (note that password-protected keys are not supported)")
 		}
 
-		authMethods = append(authMethods, ssh.PublicKeys(signer))
+		signers = append(signers, signer)
 	}
 
-	if len(o.AgentSocket) > 0 {
+	if len(o.AgentSocket) > 0 && !agentSocketUnset(o) {
 		addr := o.AgentSocket
 
 		if strings.HasPrefix(o.AgentSocket, socketEnvPrefix) {
@@ -161,76 +287,376 @@ func NewConnection(connector *Connector, o Opts) (Connection, error) {
 
 		agentClient := agent.NewClient(socket)
 
-		signers, signersErr := agentClient.Signers()
+		agentSigners, signersErr := agentClient.Signers()
 		if signersErr != nil {
 			socket.Close()
 			return nil, errors.Wrap(signersErr, "error when creating signer for SSH agent")
 		}
 
-		authMethods = append(authMethods, ssh.PublicKeys(signers...))
+		signers = append(signers, agentSigners...)
 	}
 
-	sshConfig := &ssh.ClientConfig{
-		User:            o.Username,
-		Timeout:         o.Timeout,
-		Auth:            authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+	if len(o.Certificate) > 0 {
+		certSigners, certErr := certificateSigners(o.Certificate, signers, time.Now())
+		if certErr != nil {
+			return nil, certErr
+		}
+
+		// the certificate is offered first, the plain keys are still offered
+		// to the hosts not trusting the certificate authority
+		signers = append(certSigners, signers...)
 	}
 
-	targetHost := o.Hostname
-	targetPort := strconv.Itoa(o.Port)
+	ctx, cancelFn := context.WithCancel(connector.ctx)
+	sshConn := &connection{
+		opts:      o,
+		signers:   signers,
+		connector: connector,
+		ctx:       ctx,
+		cancel:    cancelFn,
+	}
 
-	if o.Bastion != "" {
-		targetHost = o.Bastion
-		targetPort = strconv.Itoa(o.BastionPort)
-		sshConfig.User = o.BastionUser
+	if err = sshConn.dial(); err != nil {
+		cancelFn()
+		return nil, err
 	}
 
-	// do not use fmt.Sprintf() to allow proper IPv6 handling if hostname is an IP address
-	endpoint := net.JoinHostPort(targetHost, targetPort)
+	go sshConn.keepalive()
+
+	return sshConn, nil
+}
+
+// dial connects to the host through the bastions. It must be called with the
+// lock held, unless the connection is not shared yet.
+func (c *connection) dial() error {
+	o := c.opts
+
+	// the target host is the last hop of the chain
+	hops := make([]Bastion, 0, len(o.Bastions)+1)
+	hops = append(hops, o.Bastions...)
+	hops = append(hops, Bastion{
+		Hostname: o.Hostname,
+		Port:     o.Port,
+		Username: o.Username,
+	})
+
+	var clients []*ssh.Client
+	closeClients := func() {
+		for i := len(clients) - 1; i >= 0; i-- {
+			clients[i].Close()
+		}
+	}
+
+	for _, hop := range hops {
+		hopSigners := c.signers
+		if len(hop.PrivateKey) > 0 {
+			signer, parseErr := ssh.ParsePrivateKey([]byte(hop.PrivateKey))
+			if parseErr != nil {
+				closeClients()
+				return errors.Wrapf(parseErr, "the SSH key of the bastion %q could not be parsed (note that password-protected keys are not supported)", hop.Hostname)
+			}
+
+			hopSigners = append([]ssh.Signer{signer}, c.signers...)
+		}
+
+		sshConfig := &ssh.ClientConfig{
+			User:            hop.Username,
+			Timeout:         o.Timeout,
+			Auth:            authMethods(o.Password, hopSigners),
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+		}
+
+		// do not use fmt.Sprintf() to allow proper IPv6 handling if hostname is an IP address
+		endpoint := net.JoinHostPort(hop.Hostname, strconv.Itoa(hop.Port))
+
+		client, dialErr := dialHop(clients, endpoint, sshConfig)
+		if dialErr != nil {
+			closeClients()
+			return errors.Wrapf(dialErr, "could not establish connection to %s", endpoint)
+		}
+
+		clients = append(clients, client)
+	}
+
+	if err := c.setupSudoPassword(clients[len(clients)-1]); err != nil {
+		closeClients()
+		return errors.Wrapf(err, "failed to set up the sudo password on %s", o.Hostname)
+	}
+
+	c.sshclient = clients[len(clients)-1]
+	c.bastions = clients[:len(clients)-1]
+
+	return nil
+}
+
+// keepalive periodically checks the connection is alive, and closes the
+// broken client so it's redialed by the next command
+func (c *connection) keepalive() {
+	ticker := time.NewTicker(keepaliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		c.mu.Lock()
+		client := c.sshclient
+		c.mu.Unlock()
+
+		if client == nil {
+			continue
+		}
+
+		if err := sendKeepalive(client, c.opts.Timeout); err != nil {
+			c.mu.Lock()
+			c.resetClient(client)
+			c.mu.Unlock()
+		}
+	}
+}
+
+// sendKeepalive sends the keepalive request, failing if there is no reply
+// within the timeout
+func sendKeepalive(client *ssh.Client, timeout time.Duration) error {
+	errCh := make(chan error, 1)
+	go func() {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-time.After(timeout):
+		return errors.New("keepalive timed out")
+	}
+}
+
+// resetClient closes the broken client, unless it's already been redialed.
+// It must be called with the lock held.
+func (c *connection) resetClient(broken *ssh.Client) {
+	if c.sshclient != broken {
+		return
+	}
+
+	c.closeClients()
+}
+
+// closeClients closes the client and the bastions clients. It must be called
+// with the lock held.
+func (c *connection) closeClients() error {
+	if c.sshclient == nil {
+		return nil
+	}
+
+	err := c.sshclient.Close()
+	for i := len(c.bastions) - 1; i >= 0; i-- {
+		c.bastions[i].Close()
+	}
+	c.sshclient = nil
+	c.bastions = nil
+
+	return err
+}
+
+// client returns the SSH client, redialing the broken one
+func (c *connection) client() (*ssh.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	client, err := ssh.Dial("tcp", endpoint, sshConfig)
+	if c.closed {
+		return nil, errors.New("connection closed")
+	}
+
+	if c.sshclient == nil {
+		if err := c.dial(); err != nil {
+			return nil, errors.Wrap(err, "failed to reconnect")
+		}
+	}
+
+	return c.sshclient, nil
+}
+
+// agentSocketUnset returns true if the agent socket refers to the unset
+// environment variable while the password is given, in which case the
+// password is used instead of the agent
+func agentSocketUnset(o Opts) bool {
+	if len(o.Password) == 0 || !strings.HasPrefix(o.AgentSocket, socketEnvPrefix) {
+		return false
+	}
+
+	return len(os.Getenv(strings.TrimPrefix(o.AgentSocket, socketEnvPrefix))) == 0
+}
+
+// setupSudoPassword installs the askpass helper if sudo requires the
+// password on the host. The password is asked for only then, and it's kept
+// for the next dials, along with the path of the helper.
+func (c *connection) setupSudoPassword(client *ssh.Client) error {
+	if c.opts.SudoPassword == nil {
+		return nil
+	}
+
+	if c.sudoPassword == "" {
+		if err := runSession(client, "sudo -n true", nil, nil); err == nil {
+			return nil
+		}
+
+		password, err := c.opts.SudoPassword()
+		if err != nil {
+			return errors.Wrap(err, "failed to read the sudo password")
+		}
+		if password == "" {
+			return errors.New("sudo requires a password, but no sudo password is given")
+		}
+		c.sudoPassword = password
+	}
+
+	path := c.askpassPath
+	if path == "" {
+		var stdout strings.Builder
+		if err := runSession(client, `mktemp "`+askpassTemplate+`"`, nil, &stdout); err != nil {
+			return errors.Wrap(err, "failed to create the sudo askpass helper")
+		}
+		path = strings.TrimSpace(stdout.String())
+	}
+
+	cmd := `cat > ` + shellQuote(path) + ` && chmod 700 ` + shellQuote(path)
+	if err := runSession(client, cmd, strings.NewReader(askpassScript), nil); err != nil {
+		return errors.Wrap(err, "failed to install the sudo askpass helper")
+	}
+	c.askpassPath = path
+
+	return nil
+}
+
+// removeAskpass removes the askpass helper from the host. It must be called
+// with the lock held.
+func (c *connection) removeAskpass() error {
+	if c.askpassPath == "" || c.sshclient == nil {
+		return nil
+	}
+
+	err := runSession(c.sshclient, `rm -f `+shellQuote(c.askpassPath), nil, nil)
+	c.askpassPath = ""
+
+	return errors.Wrap(err, "failed to remove the sudo askpass helper")
+}
+
+// runSession runs the command in a new session of the client, reading its
+// stdin from and writing its stdout to the given reader and writer, if set
+func runSession(client *ssh.Client, cmd string, stdin io.Reader, stdout io.Writer) error {
+	sess, err := client.NewSession()
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not establish connection to %s", endpoint)
+		return err
 	}
+	defer sess.Close()
 
-	ctx, cancelFn := context.WithCancel(connector.ctx)
-	sshConn := &connection{
-		connector: connector,
-		ctx:       ctx,
-		cancel:    cancelFn,
+	var stderr strings.Builder
+	sess.Stdin = stdin
+	sess.Stdout = stdout
+	sess.Stderr = &stderr
+
+	if err = sess.Run(cmd); err != nil {
+		return errors.Wrap(err, stderr.String())
+	}
+
+	return nil
+}
+
+func authMethods(password string, signers []ssh.Signer) []ssh.AuthMethod {
+	methods := make([]ssh.AuthMethod, 0)
+
+	if len(password) > 0 {
+		methods = append(methods, ssh.Password(password))
 	}
 
-	if o.Bastion == "" {
-		sshConn.sshclient = client
-		// connection established
-		return sshConn, nil
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	return methods
+}
+
+// dialHop connects to the endpoint directly, or through the last of the
+// already connected bastions
+func dialHop(bastions []*ssh.Client, endpoint string, sshConfig *ssh.ClientConfig) (*ssh.Client, error) {
+	if len(bastions) == 0 {
+		return ssh.Dial("tcp", endpoint, sshConfig)
 	}
 
-	// continue to setup if we are running over bastion
-	endpointBehindBastion := net.JoinHostPort(o.Hostname, strconv.Itoa(o.Port))
+	// Dial a connection to the next hop, from the bastion
+	conn, err := bastions[len(bastions)-1].Dial("tcp", endpoint)
+	if err != nil {
+		return nil, err
+	}
 
-	// Dial a connection to the service host, from the bastion
-	conn, err := client.Dial("tcp", endpointBehindBastion)
+	ncc, chans, reqs, err := ssh.NewClientConn(conn, endpoint, sshConfig)
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not establish connection to %s", endpointBehindBastion)
+		conn.Close()
+		return nil, err
 	}
 
-	sshConfig.User = o.Username
-	ncc, chans, reqs, err := ssh.NewClientConn(conn, endpointBehindBastion, sshConfig)
+	return ssh.NewClient(ncc, chans, reqs), nil
+}
+
+// certificateSigners parses the OpenSSH certificate and returns the signers
+// authenticating with the certificate, one for each of the given signers
+// holding the certified key
+func certificateSigners(certificate string, signers []ssh.Signer, now time.Time) ([]ssh.Signer, error) {
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not establish connection to %s", endpointBehindBastion)
+		return nil, errors.Wrap(err, "the given SSH certificate could not be parsed")
 	}
 
-	sshConn.sshclient = ssh.NewClient(ncc, chans, reqs)
-	return sshConn, nil
+	cert, ok := pubKey.(*ssh.Certificate)
+	if !ok {
+		return nil, errors.New("the given SSH certificate file contains a public key instead of a certificate")
+	}
+
+	if cert.CertType != ssh.UserCert {
+		return nil, errors.New("the given SSH certificate is not a user certificate")
+	}
+
+	if cert.ValidBefore != ssh.CertTimeInfinity && uint64(now.Unix()) >= cert.ValidBefore {
+		return nil, errors.Errorf("the given SSH certificate has expired at %s", time.Unix(int64(cert.ValidBefore), 0).UTC())
+	}
+
+	certKey := cert.Key.Marshal()
+	certSigners := []ssh.Signer{}
+
+	for _, signer := range signers {
+		if !bytes.Equal(signer.PublicKey().Marshal(), certKey) {
+			continue
+		}
+
+		certSigner, err := ssh.NewCertSigner(cert, signer)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create SSH certificate signer")
+		}
+
+		certSigners = append(certSigners, certSigner)
+	}
+
+	if len(certSigners) == 0 {
+		return nil, errors.New("the given SSH certificate doesn't certify the private key or any of the SSH agent keys")
+	}
+
+	return certSigners, nil
 }
 
 func (c *connection) TunnelTo(_ context.Context, network, addr string) (net.Conn, error) {
 	// the voided context.Context is voided as a workaround of always Done
 	// context that being passed. Please don't try to <-ctx.Done(), it will
 	// always return immediately
-	netconn, err := c.sshclient.Dial(network, addr)
+	client, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+
+	netconn, err := client.Dial(network, addr)
 	if err == nil {
 		go func() {
 			<-c.ctx.Done()
@@ -244,24 +670,43 @@ func (c *connection) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.sshclient == nil {
+	if c.closed {
 		return nil
 	}
+	c.closed = true
 	c.cancel()
 
-	defer func() { c.sshclient = nil }()
 	defer c.connector.forgetConnection(c)
 
-	return c.sshclient.Close()
+	if err := c.removeAskpass(); err != nil {
+		c.closeClients()
+		return err
+	}
+
+	return c.closeClients()
 }
 
 func (c *connection) POpen(cmd string, stdin io.Reader, stdout io.Writer, stderr io.Writer) (int, error) {
 	sess, err := c.session()
 	if err != nil {
-		return 0, errors.Wrap(err, "failed to get SSH session")
+		return 0, errors.WithStack(&NotStartedError{Err: err})
 	}
 	defer sess.Close()
 
+	c.mu.Lock()
+	sudoPassword, askpassPath := c.sudoPassword, c.askpassPath
+	c.mu.Unlock()
+
+	if len(sudoPassword) > 0 {
+		cmd = sudoPasswordPrelude(askpassPath) + cmd
+		passwordReader := strings.NewReader(sudoPassword + "\n")
+		if stdin != nil {
+			stdin = io.MultiReader(passwordReader, stdin)
+		} else {
+			stdin = passwordReader
+		}
+	}
+
 	sess.Stdin = stdin
 	sess.Stdout = stdout
 	sess.Stderr = stderr
@@ -286,13 +731,27 @@ func (c *connection) Exec(cmd string) (string, string, int, error) {
 	return strings.TrimSpace(stdoutBuf.String()), stderrBuf.String(), exitCode, err
 }
 
+// session opens the new session, redialing the client once if the connection
+// broke since the last keepalive
 func (c *connection) session() (*ssh.Session, error) {
+	client, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := client.NewSession()
+	if err == nil {
+		return sess, nil
+	}
+
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.resetClient(client)
+	c.mu.Unlock()
 
-	if c.sshclient == nil {
-		return nil, errors.New("connection closed")
+	client, err = c.client()
+	if err != nil {
+		return nil, err
 	}
 
-	return c.sshclient.NewSession()
+	return client.NewSession()
 }
@@ -0,0 +1,455 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	return signer
+}
+
+func newTestCertificate(t *testing.T, key ssh.PublicKey, certType uint32, validBefore uint64) string {
+	t.Helper()
+
+	cert := &ssh.Certificate{
+		Key:             key,
+		CertType:        certType,
+		KeyId:           "kubeone",
+		ValidPrincipals: []string{"root"},
+		ValidBefore:     validBefore,
+	}
+	if err := cert.SignCert(rand.Reader, newTestSigner(t)); err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+
+	return string(ssh.MarshalAuthorizedKey(cert))
+}
+
+func TestCertificateSigners(t *testing.T) {
+	now := time.Now()
+	userKey := newTestSigner(t)
+	otherKey := newTestSigner(t)
+
+	tests := []struct {
+		name        string
+		certificate string
+		signers     []ssh.Signer
+		wantSigners int
+		wantErr     bool
+	}{
+		{
+			name:        "certificate of the private key",
+			certificate: newTestCertificate(t, userKey.PublicKey(), ssh.UserCert, ssh.CertTimeInfinity),
+			signers:     []ssh.Signer{otherKey, userKey},
+			wantSigners: 1,
+		},
+		{
+			name:        "certificate valid for an hour",
+			certificate: newTestCertificate(t, userKey.PublicKey(), ssh.UserCert, uint64(now.Add(time.Hour).Unix())),
+			signers:     []ssh.Signer{userKey},
+			wantSigners: 1,
+		},
+		{
+			name:        "expired certificate",
+			certificate: newTestCertificate(t, userKey.PublicKey(), ssh.UserCert, uint64(now.Add(-time.Hour).Unix())),
+			signers:     []ssh.Signer{userKey},
+			wantErr:     true,
+		},
+		{
+			name:        "host certificate",
+			certificate: newTestCertificate(t, userKey.PublicKey(), ssh.HostCert, ssh.CertTimeInfinity),
+			signers:     []ssh.Signer{userKey},
+			wantErr:     true,
+		},
+		{
+			name:        "certificate of another key",
+			certificate: newTestCertificate(t, userKey.PublicKey(), ssh.UserCert, ssh.CertTimeInfinity),
+			signers:     []ssh.Signer{otherKey},
+			wantErr:     true,
+		},
+		{
+			name:        "public key instead of certificate",
+			certificate: string(ssh.MarshalAuthorizedKey(userKey.PublicKey())),
+			signers:     []ssh.Signer{userKey},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := certificateSigners(tt.certificate, tt.signers, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("certificateSigners() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.wantSigners {
+				t.Fatalf("expected %d signers, but got %d", tt.wantSigners, len(got))
+			}
+			for _, signer := range got {
+				if _, ok := signer.PublicKey().(*ssh.Certificate); !ok {
+					t.Errorf("expected the signer to authenticate with the certificate")
+				}
+			}
+		})
+	}
+}
+
+func TestValidateOptionsBastions(t *testing.T) {
+	o, err := validateOptions(Opts{
+		Username:    "ubuntu",
+		Hostname:    "10.0.1.10",
+		Password:    "test",
+		Bastion:     "bastion.example.com",
+		BastionUser: "jump",
+		Bastions: []Bastion{
+			{Hostname: "10.0.0.5", Port: 2222},
+		},
+	})
+	if err != nil {
+		t.Fatalf("validateOptions() error = %v", err)
+	}
+
+	expected := []Bastion{
+		{Hostname: "bastion.example.com", Port: 22, Username: "jump"},
+		{Hostname: "10.0.0.5", Port: 2222, Username: "ubuntu"},
+	}
+	if !reflect.DeepEqual(o.Bastions, expected) {
+		t.Errorf("expected bastions %+v, but got %+v", expected, o.Bastions)
+	}
+
+	if _, err = validateOptions(Opts{
+		Username: "ubuntu",
+		Hostname: "10.0.1.10",
+		Password: "test",
+		Bastions: []Bastion{{Port: 22}},
+	}); err == nil {
+		t.Errorf("expected an error for a bastion without hostname")
+	}
+}
+
+func TestAgentSocketUnset(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Opts
+		expected bool
+	}{
+		{
+			name:     "unset socket variable with password",
+			opts:     Opts{Password: "test", AgentSocket: "env:KUBEONE_TEST_AUTH_SOCK"},
+			expected: true,
+		},
+		{
+			name:     "unset socket variable without password",
+			opts:     Opts{AgentSocket: "env:KUBEONE_TEST_AUTH_SOCK"},
+			expected: false,
+		},
+		{
+			name:     "socket path with password",
+			opts:     Opts{Password: "test", AgentSocket: "/run/agent.sock"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := agentSocketUnset(tt.opts); got != tt.expected {
+				t.Errorf("agentSocketUnset() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// testServer is the SSH server replying "ok" to every command, unless the
+// reply is overridden
+type testServer struct {
+	mu       sync.Mutex
+	listener net.Listener
+	conns    []net.Conn
+	// commands are the commands run on the server
+	commands []string
+	// reply returns the stdout and the exit status of the command
+	reply func(cmd string) (string, uint32)
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if string(password) != "test" {
+				return nil, errors.New("wrong password")
+			}
+
+			return nil, nil
+		},
+	}
+	config.AddHostKey(newTestSigner(t))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := &testServer{listener: listener}
+	t.Cleanup(srv.close)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			srv.mu.Lock()
+			srv.conns = append(srv.conns, conn)
+			srv.mu.Unlock()
+
+			go srv.serve(conn, config)
+		}
+	}()
+
+	return srv
+}
+
+func (srv *testServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go func() {
+			for req := range requests {
+				if req.Type != "exec" {
+					_ = req.Reply(false, nil)
+
+					continue
+				}
+
+				var payload struct{ Command string }
+				if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+					_ = req.Reply(false, nil)
+
+					continue
+				}
+				_ = req.Reply(true, nil)
+
+				stdout, status := "ok\n", uint32(0)
+				srv.mu.Lock()
+				srv.commands = append(srv.commands, payload.Command)
+				if srv.reply != nil {
+					stdout, status = srv.reply(payload.Command)
+				}
+				srv.mu.Unlock()
+
+				_, _ = io.Copy(io.Discard, channel)
+				_, _ = channel.Write([]byte(stdout))
+				_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+				channel.Close()
+
+				return
+			}
+		}()
+	}
+}
+
+// dropConnections closes the accepted connections, as if the network broke
+func (srv *testServer) dropConnections() int {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	for _, conn := range srv.conns {
+		conn.Close()
+	}
+
+	return len(srv.conns)
+}
+
+func (srv *testServer) close() {
+	srv.listener.Close()
+	srv.dropConnections()
+}
+
+func TestConnectionReconnect(t *testing.T) {
+	srv := newTestServer(t)
+	addr := srv.listener.Addr().(*net.TCPAddr)
+
+	conn, err := NewConnection(NewConnector(context.Background()), Opts{
+		Username: "root",
+		Password: "test",
+		Hostname: addr.IP.String(),
+		Port:     addr.Port,
+		Timeout:  5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewConnection() error = %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		stdout, _, _, execErr := conn.Exec("true")
+		if execErr != nil {
+			t.Fatalf("Exec() error = %v", execErr)
+		}
+		if stdout != "ok" {
+			t.Fatalf("expected stdout %q, but got %q", "ok", stdout)
+		}
+	}
+
+	if dropped := srv.dropConnections(); dropped != 1 {
+		t.Fatalf("expected the commands to share 1 connection, but got %d", dropped)
+	}
+
+	stdout, _, _, err := conn.Exec("true")
+	if err != nil {
+		t.Fatalf("Exec() after the connection broke error = %v", err)
+	}
+	if stdout != "ok" {
+		t.Fatalf("expected stdout %q, but got %q", "ok", stdout)
+	}
+
+	if err = conn.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if _, _, _, err = conn.Exec("true"); err == nil {
+		t.Errorf("expected an error running the command on the closed connection")
+	}
+}
+
+func TestConnectionSudoPassword(t *testing.T) {
+	const askpass = "/home/test/.kubeone-askpass.abc123"
+
+	tests := []struct {
+		name             string
+		sudoNoPassword   bool
+		expectedPrompts  int
+		expectedPrelude  bool
+		expectedCommands []string
+	}{
+		{
+			name:            "sudo without password",
+			sudoNoPassword:  true,
+			expectedPrompts: 0,
+			expectedCommands: []string{
+				"sudo -n true",
+				"sudo true",
+			},
+		},
+		{
+			name:            "sudo requiring password",
+			expectedPrompts: 1,
+			expectedPrelude: true,
+			expectedCommands: []string{
+				"sudo -n true",
+				`mktemp "$HOME/.kubeone-askpass.XXXXXX"`,
+				`cat > '` + askpass + `' && chmod 700 '` + askpass + `'`,
+				sudoPasswordPrelude(askpass) + "sudo true",
+				`rm -f '` + askpass + `'`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t)
+			srv.reply = func(cmd string) (string, uint32) {
+				switch {
+				case cmd == "sudo -n true" && !tt.sudoNoPassword:
+					return "", 1
+				case strings.HasPrefix(cmd, "mktemp "):
+					return askpass + "\n", 0
+				}
+
+				return "ok\n", 0
+			}
+			addr := srv.listener.Addr().(*net.TCPAddr)
+
+			prompts := 0
+			conn, err := NewConnection(NewConnector(context.Background()), Opts{
+				Username: "test",
+				Password: "test",
+				SudoPassword: func() (string, error) {
+					prompts++
+					return "secret", nil
+				},
+				Hostname: addr.IP.String(),
+				Port:     addr.Port,
+				Timeout:  5 * time.Second,
+			})
+			if err != nil {
+				t.Fatalf("NewConnection() error = %v", err)
+			}
+
+			if _, _, _, err = conn.Exec("sudo true"); err != nil {
+				t.Fatalf("Exec() error = %v", err)
+			}
+			if err = conn.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			if prompts != tt.expectedPrompts {
+				t.Errorf("expected the sudo password to be asked for %d times, but got %d", tt.expectedPrompts, prompts)
+			}
+
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if !reflect.DeepEqual(srv.commands, tt.expectedCommands) {
+				t.Errorf("expected commands %q, but got %q", tt.expectedCommands, srv.commands)
+			}
+		})
+	}
+}
@@ -28,9 +28,11 @@ import (
 
 // Connector holds a map of Connections
 type Connector struct {
-	lock        sync.Mutex
-	connections map[int]Connection
-	ctx         context.Context
+	lock         sync.Mutex
+	connections  map[int]Connection
+	ctx          context.Context
+	password     string
+	sudoPassword PasswordFunc
 }
 
 // NewConnector constructor
@@ -41,6 +43,17 @@ func NewConnector(ctx context.Context) *Connector {
 	}
 }
 
+// SetPasswords sets the SSH password and the sudo password used for the
+// connections to all hosts. The empty SSH password is not used, and the sudo
+// password is asked for only if sudo requires it on the host.
+func (c *Connector) SetPasswords(password string, sudoPassword PasswordFunc) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.password = password
+	c.sudoPassword = sudoPassword
+}
+
 // Tunnel returns established SSH tunnel
 func (c *Connector) Tunnel(host kubeoneapi.HostConfig) (Tunneler, error) {
 	conn, err := c.Connect(host)
@@ -67,6 +80,8 @@ func (c *Connector) Connect(host kubeoneapi.HostConfig) (Connection, error) {
 	if !found {
 		opts := sshOpts(host)
 		opts.Context = c.ctx
+		opts.Password = c.password
+		opts.SudoPassword = c.sudoPassword
 		conn, err = NewConnection(c, opts)
 		if err != nil {
 			return nil, err
@@ -95,10 +110,27 @@ func sshOpts(host kubeoneapi.HostConfig) Opts {
 		Port:        host.SSHPort,
 		Hostname:    host.PublicAddress,
 		KeyFile:     host.SSHPrivateKeyFile,
+		CertFile:    host.SSHCertFile,
 		AgentSocket: host.SSHAgentSocket,
 		Timeout:     10 * time.Second,
 		Bastion:     host.Bastion,
 		BastionPort: host.BastionPort,
 		BastionUser: host.BastionUser,
+		Bastions:    sshBastions(host.Bastions),
 	}
 }
+
+func sshBastions(bastions []kubeoneapi.BastionConfig) []Bastion {
+	var result []Bastion
+
+	for _, b := range bastions {
+		result = append(result, Bastion{
+			Hostname: b.Host,
+			Port:     b.Port,
+			Username: b.User,
+			KeyFile:  b.SSHPrivateKeyFile,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Upload streams the content of src to the target file on the remote host.
+//
+// The content is streamed over the standard input of a single SSH session to
+// a temporary file next to the target, so the size of the file is not
+// limited by the maximum length of a command. Once the content is uploaded,
+// its SHA256 checksum is verified against the checksum of the streamed data
+// and the temporary file is atomically moved to the target path. The parent
+// directory is created if it doesn't exist.
+//
+// Upload is used for the files generated by KubeOne, such as the
+// configuration files, certificates and etcd snapshots. The content is not
+// transferred over SFTP, which writes the files as the SSH user, while the
+// uploaded files are owned by root, and which is not enabled on all hosts.
+// The asset binaries are not uploaded, they are downloaded on the hosts by
+// the provisioning scripts and verified against their checksums, optionally
+// through the asset cache.
+func Upload(conn Connection, src io.Reader, target string, mode os.FileMode) error {
+	var (
+		tmp    = target + ".kubeone-upload"
+		hasher = sha256.New()
+	)
+
+	cmd := fmt.Sprintf(`sudo mkdir --mode=700 --parents %s && sudo tee %s >/dev/null`, shellQuote(path.Dir(target)), shellQuote(tmp))
+	if err := runUploadCmd(conn, cmd, io.TeeReader(src, hasher)); err != nil {
+		return errors.Wrapf(err, "failed to upload %q", target)
+	}
+
+	expected := hex.EncodeToString(hasher.Sum(nil))
+
+	stdout, stderr, _, err := conn.Exec(fmt.Sprintf(`sudo sha256sum %s`, shellQuote(tmp)))
+	if err != nil {
+		return errors.Wrapf(err, "failed to calculate checksum of %q: %s", target, stderr)
+	}
+
+	fields := strings.Fields(stdout)
+	if len(fields) == 0 || fields[0] != expected {
+		_, _, _, _ = conn.Exec(fmt.Sprintf(`sudo rm -f %s`, shellQuote(tmp)))
+		return errors.Errorf("checksum mismatch for %q after upload, expected %s but got %q", target, expected, stdout)
+	}
+
+	cmd = fmt.Sprintf(`sudo chmod %o %s && sudo mv -f %s %s`, mode, shellQuote(tmp), shellQuote(tmp), shellQuote(target))
+	if err := runUploadCmd(conn, cmd, nil); err != nil {
+		return errors.Wrapf(err, "failed to move %q into place", target)
+	}
+
+	return nil
+}
+
+func runUploadCmd(conn Connection, cmd string, stdin io.Reader) error {
+	var stdout, stderr strings.Builder
+
+	_, err := conn.POpen(cmd, stdin, &stdout, &stderr)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s", stderr.String(), stdout.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeUploadConnection records the commands run by Upload, the content
+// streamed to them, and fails the commands containing failCmd
+type fakeUploadConnection struct {
+	commands []string
+	uploaded string
+	checksum string
+	failCmd  string
+}
+
+func (c *fakeUploadConnection) Exec(cmd string) (string, string, int, error) {
+	c.commands = append(c.commands, cmd)
+	if c.failCmd != "" && strings.Contains(cmd, c.failCmd) {
+		return "", "permission denied", 1, errors.New("exit status 1")
+	}
+
+	if strings.Contains(cmd, "sha256sum") {
+		checksum := c.checksum
+		if checksum == "" {
+			sum := sha256.Sum256([]byte(c.uploaded))
+			checksum = hex.EncodeToString(sum[:])
+		}
+
+		return checksum + "  /etc/kubeone/file.kubeone-upload\n", "", 0, nil
+	}
+
+	return "", "", 0, nil
+}
+
+func (c *fakeUploadConnection) POpen(cmd string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	c.commands = append(c.commands, cmd)
+	if c.failCmd != "" && strings.Contains(cmd, c.failCmd) {
+		_, _ = io.WriteString(stderr, "permission denied")
+		return 1, errors.New("exit status 1")
+	}
+
+	if stdin != nil {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return 1, err
+		}
+		c.uploaded = string(data)
+	}
+
+	return 0, nil
+}
+
+func (c *fakeUploadConnection) Close() error {
+	return nil
+}
+
+func TestUpload(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		checksum     string
+		failCmd      string
+		wantCommands []string
+		wantErr      string
+	}{
+		{
+			name:   "uploaded",
+			target: "/etc/kubeone/file",
+			wantCommands: []string{
+				`sudo mkdir --mode=700 --parents '/etc/kubeone' && sudo tee '/etc/kubeone/file.kubeone-upload' >/dev/null`,
+				`sudo sha256sum '/etc/kubeone/file.kubeone-upload'`,
+				`sudo chmod 600 '/etc/kubeone/file.kubeone-upload' && sudo mv -f '/etc/kubeone/file.kubeone-upload' '/etc/kubeone/file'`,
+			},
+		},
+		{
+			name:   "path is not expanded by the shell",
+			target: "/etc/$(id)/`id`/it's",
+			wantCommands: []string{
+				`sudo mkdir --mode=700 --parents '/etc/$(id)/` + "`id`" + `' && sudo tee '/etc/$(id)/` + "`id`" + `/it'"'"'s.kubeone-upload' >/dev/null`,
+				`sudo sha256sum '/etc/$(id)/` + "`id`" + `/it'"'"'s.kubeone-upload'`,
+				`sudo chmod 600 '/etc/$(id)/` + "`id`" + `/it'"'"'s.kubeone-upload' && sudo mv -f '/etc/$(id)/` + "`id`" + `/it'"'"'s.kubeone-upload' '/etc/$(id)/` + "`id`" + `/it'"'"'s'`,
+			},
+		},
+		{
+			name:     "checksum mismatch",
+			target:   "/etc/kubeone/file",
+			checksum: "0000",
+			wantCommands: []string{
+				`sudo mkdir --mode=700 --parents '/etc/kubeone' && sudo tee '/etc/kubeone/file.kubeone-upload' >/dev/null`,
+				`sudo sha256sum '/etc/kubeone/file.kubeone-upload'`,
+				`sudo rm -f '/etc/kubeone/file.kubeone-upload'`,
+			},
+			wantErr: "checksum mismatch",
+		},
+		{
+			name:    "mkdir or tee failure",
+			target:  "/etc/kubeone/file",
+			failCmd: "sudo tee",
+			wantCommands: []string{
+				`sudo mkdir --mode=700 --parents '/etc/kubeone' && sudo tee '/etc/kubeone/file.kubeone-upload' >/dev/null`,
+			},
+			wantErr: "failed to upload \"/etc/kubeone/file\": permission denied",
+		},
+		{
+			name:    "checksum failure",
+			target:  "/etc/kubeone/file",
+			failCmd: "sha256sum",
+			wantCommands: []string{
+				`sudo mkdir --mode=700 --parents '/etc/kubeone' && sudo tee '/etc/kubeone/file.kubeone-upload' >/dev/null`,
+				`sudo sha256sum '/etc/kubeone/file.kubeone-upload'`,
+			},
+			wantErr: "failed to calculate checksum",
+		},
+		{
+			name:    "move failure",
+			target:  "/etc/kubeone/file",
+			failCmd: "sudo mv",
+			wantCommands: []string{
+				`sudo mkdir --mode=700 --parents '/etc/kubeone' && sudo tee '/etc/kubeone/file.kubeone-upload' >/dev/null`,
+				`sudo sha256sum '/etc/kubeone/file.kubeone-upload'`,
+				`sudo chmod 600 '/etc/kubeone/file.kubeone-upload' && sudo mv -f '/etc/kubeone/file.kubeone-upload' '/etc/kubeone/file'`,
+			},
+			wantErr: "failed to move",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &fakeUploadConnection{checksum: tt.checksum, failCmd: tt.failCmd}
+			content := "apiVersion: v1\nkind: Config\n"
+
+			err := Upload(conn, strings.NewReader(content), tt.target, 0600)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Upload() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Upload() error = %v, want error containing %q", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(conn.commands, tt.wantCommands) {
+				t.Errorf("Upload() commands = %q, want %q", conn.commands, tt.wantCommands)
+			}
+			if tt.failCmd != "sudo tee" && conn.uploaded != content {
+				t.Errorf("Upload() uploaded %q, want %q", conn.uploaded, content)
+			}
+		})
+	}
+}
@@ -18,13 +18,17 @@ package state
 
 import (
 	"errors"
+	"net"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
 
 	"k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clusterinfo"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -36,7 +40,17 @@ type Cluster struct {
 	ExpectedVersion         *semver.Version
 	EncryptionConfiguration *EncryptionConfiguration
 	CCMStatus               *CCMStatus
-	Lock                    sync.Mutex
+	Info                    *clusterinfo.Info
+	// NodePortRanges are the distinct NodePort ranges kube-apiserver
+	// instances run with
+	NodePortRanges []string
+	// ClusterCIDRs are the distinct cluster CIDRs kube-controller-manager
+	// instances run with
+	ClusterCIDRs []string
+	// EtcdSettings are the distinct etcd settings, formatted as the
+	// comma-separated list of flags, the etcd members run with
+	EtcdSettings []string
+	Lock         sync.Mutex
 }
 
 type EncryptionConfiguration struct {
@@ -57,6 +71,7 @@ type Host struct {
 
 	ContainerRuntimeDocker     ComponentStatus
 	ContainerRuntimeContainerd ComponentStatus
+	ContainerRuntimeCRIO       ComponentStatus
 	Kubelet                    ComponentStatus
 
 	// Applicable only for CP nodes
@@ -64,6 +79,9 @@ type Host struct {
 	Etcd      ContainerStatus
 
 	EarliestCertExpiry time.Time
+	// EtcdServerCertSANs are the SANs, DNS names and IP addresses, of the
+	// etcd server certificate. Applicable only for CP nodes
+	EtcdServerCertSANs []string
 
 	IsInCluster bool
 	Kubeconfig  []byte
@@ -209,6 +227,75 @@ func (c *Cluster) EtcdToleranceRemain() int {
 	return tolerance
 }
 
+// NodePortRangeChanged returns whether any kube-apiserver instance runs with
+// the NodePort range other than the desired one
+func (c *Cluster) NodePortRangeChanged(desired string) bool {
+	for _, portRange := range c.NodePortRanges {
+		if portRange != desired {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClusterCIDRChanged returns whether any kube-controller-manager instance
+// runs with the cluster CIDR other than the desired one
+func (c *Cluster) ClusterCIDRChanged(desired string) bool {
+	for _, cidr := range c.ClusterCIDRs {
+		if cidr != desired {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EtcdSettingsChanged returns whether any etcd member runs with the settings
+// other than the desired ones
+func (c *Cluster) EtcdSettingsChanged(desired string) bool {
+	for _, settings := range c.EtcdSettings {
+		if settings != desired {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EtcdServerCertSANsMissing returns whether the etcd server certificate of
+// any control plane node is missing any of the desired SANs
+func (c *Cluster) EtcdServerCertSANsMissing(desired []string) bool {
+	for i := range c.ControlPlane {
+		if !c.ControlPlane[i].Initialized() {
+			continue
+		}
+
+		existing := sets.NewString()
+		for _, san := range c.ControlPlane[i].EtcdServerCertSANs {
+			existing.Insert(normalizeSAN(san))
+		}
+
+		for _, san := range desired {
+			if !existing.Has(normalizeSAN(san)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// normalizeSAN returns the canonical form of the IP address or the DNS name,
+// so the SANs written differently can be compared
+func normalizeSAN(san string) string {
+	if ip := net.ParseIP(san); ip != nil {
+		return ip.String()
+	}
+
+	return strings.ToLower(san)
+}
+
 // UpgradeNeeded compares actual and expected Kubernetes versions for control plane and static worker nodes
 func (c *Cluster) UpgradeNeeded() (bool, error) {
 	for i := range c.ControlPlane {
@@ -279,7 +366,7 @@ func (h *Host) Initialized() bool {
 
 // IsProvisioned checks are CRI and Kubelet provisioned on a host
 func (h *Host) IsProvisioned() bool {
-	return (h.ContainerRuntimeDocker.IsProvisioned() || h.ContainerRuntimeContainerd.IsProvisioned()) && h.Kubelet.IsProvisioned()
+	return (h.ContainerRuntimeDocker.IsProvisioned() || h.ContainerRuntimeContainerd.IsProvisioned() || h.ContainerRuntimeCRIO.IsProvisioned()) && h.Kubelet.IsProvisioned()
 }
 
 // ControlPlaneHealthy checks is a control-plane host part of the cluster and are CRI, Kubelet, and API server healthy
@@ -295,10 +382,13 @@ func (h *Host) WorkerHealthy() bool {
 func (h *Host) healthy() bool {
 	var crStatus bool
 
-	if h.ContainerRuntimeDocker.IsProvisioned() {
+	switch {
+	case h.ContainerRuntimeDocker.IsProvisioned():
 		// docker + containerd are installed
 		crStatus = h.ContainerRuntimeDocker.Healthy() && h.ContainerRuntimeContainerd.Healthy()
-	} else {
+	case h.ContainerRuntimeCRIO.IsProvisioned():
+		crStatus = h.ContainerRuntimeCRIO.Healthy()
+	default:
 		// only containerd is installed
 		crStatus = h.ContainerRuntimeContainerd.Healthy()
 	}
@@ -57,3 +57,58 @@ func TestCluster_CertsToExpireInLessThen90Days(t *testing.T) {
 		})
 	}
 }
+
+func TestCluster_EtcdServerCertSANsMissing(t *testing.T) {
+	initialized := func(sans ...string) Host {
+		return Host{
+			ContainerRuntimeContainerd: ComponentStatus{Status: ComponentInstalled},
+			Kubelet:                    ComponentStatus{Status: ComponentInstalled | KubeletInitialized},
+			EtcdServerCertSANs:         sans,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		hosts   []Host
+		desired []string
+		want    bool
+	}{
+		{
+			name:    "no desired SANs",
+			hosts:   []Host{initialized("cp-0", "localhost", "127.0.0.1")},
+			desired: nil,
+			want:    false,
+		},
+		{
+			name:    "SANs present",
+			hosts:   []Host{initialized("cp-0", "etcd.example.com", "10.0.0.10")},
+			desired: []string{"ETCD.example.com", "10.0.0.10"},
+			want:    false,
+		},
+		{
+			name:    "SAN missing on one node",
+			hosts:   []Host{initialized("cp-0", "etcd.example.com"), initialized("cp-1")},
+			desired: []string{"etcd.example.com"},
+			want:    true,
+		},
+		{
+			name:    "uninitialized node",
+			hosts:   []Host{initialized("cp-0", "etcd.example.com"), {}},
+			desired: []string{"etcd.example.com"},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{
+				ControlPlane: tt.hosts,
+			}
+
+			if got := c.EtcdServerCertSANsMissing(tt.desired); got != tt.want {
+				t.Errorf("Cluster.EtcdServerCertSANsMissing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ErrAborted is returned, possibly wrapped, by the ConfirmFunc when the user
+// aborts the whole operation. Tasks failing with it are not retried.
+var ErrAborted = errors.New("aborted by the user")
+
+// ConfirmFunc asks for the confirmation of the step described by the
+// message. It returns false if the step should be skipped, and ErrAborted if
+// the whole operation should be aborted.
+type ConfirmFunc func(message string) (bool, error)
+
+// ConfirmStep asks for the confirmation of a destructive step when running in
+// the interactive mode. Steps are always confirmed otherwise.
+func (s *State) ConfirmStep(format string, args ...interface{}) (bool, error) {
+	if s.Confirm == nil {
+		return true, nil
+	}
+
+	return s.Confirm(fmt.Sprintf(format, args...))
+}
@@ -84,6 +84,7 @@ type State struct {
 	Configuration             *configupload.Configuration
 	Images                    *images.Resolver
 	Runner                    *runner.Runner
+	CommandRetry              *runner.RetryPolicy
 	Context                   context.Context
 	WorkDir                   string
 	JoinCommand               string
@@ -91,17 +92,30 @@ type State struct {
 	RESTConfig                *rest.Config
 	DynamicClient             dynclient.Client
 	Verbose                   bool
+	StructuredLogs            bool
+	MaxParallel               int
+	ForceUnlock               bool
 	BackupFile                string
 	DestroyWorkers            bool
 	RemoveBinaries            bool
 	ForceUpgrade              bool
 	ForceInstall              bool
+	NoStepCache               bool
+	SkipPreflightChecks       bool
 	UpgradeMachineDeployments bool
 	CCMMigration              bool
 	CCMMigrationComplete      bool
 	CredentialsFilePath       string
 	ManifestFilePath          string
+	ManifestHash              string
+	KubeOneVersion            string
 	PauseImage                string
+	Confirm                   ConfirmFunc
+	DryRunOutput              *DryRunOutput
+	Progress                  *Progress
+	// FailedTasks are the tasks that failed after all their attempts,
+	// reported in the notifications
+	FailedTasks []string
 }
 
 func (s *State) KubeadmVerboseFlag() string {
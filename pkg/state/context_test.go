@@ -59,6 +59,48 @@ func TestShouldEnableInTreeCloudProvider(t *testing.T) {
 			ccmMigrationComplete: false,
 			want:                 false,
 		},
+		{
+			name: "new AWS cluster with external disabled",
+			cluster: &kubeoneapi.KubeOneCluster{
+				CloudProvider: kubeoneapi.CloudProviderSpec{
+					AWS:      &kubeoneapi.AWSSpec{},
+					External: false,
+				},
+			},
+			liveCluster: &Cluster{
+				CCMStatus: nil,
+			},
+			ccmMigrationComplete: false,
+			want:                 true,
+		},
+		{
+			name: "new AWS cluster with external enabled",
+			cluster: &kubeoneapi.KubeOneCluster{
+				CloudProvider: kubeoneapi.CloudProviderSpec{
+					AWS:      &kubeoneapi.AWSSpec{},
+					External: true,
+				},
+			},
+			liveCluster: &Cluster{
+				CCMStatus: nil,
+			},
+			ccmMigrationComplete: false,
+			want:                 false,
+		},
+		{
+			name: "new Azure cluster with external enabled",
+			cluster: &kubeoneapi.KubeOneCluster{
+				CloudProvider: kubeoneapi.CloudProviderSpec{
+					Azure:    &kubeoneapi.AzureSpec{},
+					External: true,
+				},
+			},
+			liveCluster: &Cluster{
+				CCMStatus: nil,
+			},
+			ccmMigrationComplete: false,
+			want:                 false,
+		},
 		{
 			name: "new Hetzner cluster with external disabled",
 			cluster: &kubeoneapi.KubeOneCluster{
@@ -217,6 +259,34 @@ func TestShouldEnableCSIMigration(t *testing.T) {
 			ccmMigration: false,
 			want:         true,
 		},
+		{
+			name: "new AWS cluster with external disabled",
+			cluster: &kubeoneapi.KubeOneCluster{
+				CloudProvider: kubeoneapi.CloudProviderSpec{
+					AWS:      &kubeoneapi.AWSSpec{},
+					External: false,
+				},
+			},
+			liveCluster: &Cluster{
+				CCMStatus: nil,
+			},
+			ccmMigration: false,
+			want:         false,
+		},
+		{
+			name: "new AWS cluster with external enabled",
+			cluster: &kubeoneapi.KubeOneCluster{
+				CloudProvider: kubeoneapi.CloudProviderSpec{
+					AWS:      &kubeoneapi.AWSSpec{},
+					External: true,
+				},
+			},
+			liveCluster: &Cluster{
+				CCMStatus: nil,
+			},
+			ccmMigration: false,
+			want:         true,
+		},
 		{
 			name: "new Hetzner cluster with external disabled",
 			cluster: &kubeoneapi.KubeOneCluster{
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// DryRunOutput collects the scripts, configuration files and manifests
+// rendered in the dry-run mode, instead of running or applying them
+type DryRunOutput struct {
+	lock  sync.Mutex
+	files map[string]string
+}
+
+// NewDryRunOutput constructor
+func NewDryRunOutput() *DryRunOutput {
+	return &DryRunOutput{
+		files: map[string]string{},
+	}
+}
+
+// Add records the rendered content under the given slash-separated name
+func (o *DryRunOutput) Add(name, content string) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.files[name] = strings.TrimSpace(content) + "\n"
+}
+
+// Names returns the sorted names of all recorded files
+func (o *DryRunOutput) Names() []string {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	names := make([]string, 0, len(o.files))
+	for name := range o.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Get returns the recorded content under the given name
+func (o *DryRunOutput) Get(name string) (string, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	content, ok := o.files[name]
+
+	return content, ok
+}
+
+// Print writes all recorded files to w, each preceded by its name
+func (o *DryRunOutput) Print(w io.Writer) error {
+	for _, name := range o.Names() {
+		content, _ := o.Get(name)
+		if _, err := fmt.Fprintf(w, "# Source: %s\n%s---\n", name, content); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// WriteTo writes all recorded files to the given directory, keeping the
+// directory structure of their names
+func (o *DryRunOutput) WriteTo(dir string) error {
+	for _, name := range o.Names() {
+		content, _ := o.Get(name)
+		target := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			return errors.Wrapf(err, "failed to create directory for %s", name)
+		}
+
+		if err := ioutil.WriteFile(target, []byte(content), 0600); err != nil {
+			return errors.Wrapf(err, "failed to write %s", name)
+		}
+	}
+
+	return nil
+}
+
+// DryRun returns whether the state is used to render the scripts and
+// manifests without connecting to hosts
+func (s *State) DryRun() bool {
+	return s.DryRunOutput != nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDryRunOutput(t *testing.T) {
+	out := NewDryRunOutput()
+	out.Add("hosts/control-plane-0/01-prerequisites.sh", "  echo prerequisites\n\n")
+	out.Add("addons/apply/canal.yaml", "kind: DaemonSet")
+
+	var buf strings.Builder
+	if err := out.Print(&buf); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+
+	expected := "# Source: addons/apply/canal.yaml\nkind: DaemonSet\n---\n" +
+		"# Source: hosts/control-plane-0/01-prerequisites.sh\necho prerequisites\n---\n"
+	if buf.String() != expected {
+		t.Errorf("expected printed output %q, but got %q", expected, buf.String())
+	}
+
+	dir := t.TempDir()
+	if err := out.WriteTo(dir); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "hosts", "control-plane-0", "01-prerequisites.sh"))
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+	if string(b) != "echo prerequisites\n" {
+		t.Errorf("expected written content %q, but got %q", "echo prerequisites\n", string(b))
+	}
+}
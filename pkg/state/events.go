@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// eventSourceComponent is the component reported as the source of Events
+const eventSourceComponent = "kubeone"
+
+// Reasons of Events recorded for KubeOne operations
+const (
+	EventReasonNodeUpgradeStarted       = "KubeOneNodeUpgradeStarted"
+	EventReasonNodeUpgradeFinished      = "KubeOneNodeUpgradeFinished"
+	EventReasonAddonApplied             = "KubeOneAddonApplied"
+	EventReasonAddonDeleted             = "KubeOneAddonDeleted"
+	EventReasonCCMMigrationStarted      = "KubeOneCCMMigrationStarted"
+	EventReasonCCMMigrationControlPlane = "KubeOneCCMMigrationControlPlaneMigrated"
+	EventReasonCCMMigrationFinished     = "KubeOneCCMMigrationFinished"
+)
+
+// RecordNodeEvent records a Kubernetes Event about the operation on the node,
+// so in-cluster observers can correlate disruptions with KubeOne activity.
+func (s *State) RecordNodeEvent(node *kubeoneapi.HostConfig, reason, format string, args ...interface{}) {
+	s.recordEvent(metav1.NamespaceDefault, corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Node",
+		Name:       node.Hostname,
+	}, reason, fmt.Sprintf(format, args...))
+}
+
+// RecordClusterEvent records a Kubernetes Event about the cluster-wide
+// operation, such as applying an addon. Such Events are recorded on the
+// kube-system Namespace.
+func (s *State) RecordClusterEvent(reason, format string, args ...interface{}) {
+	s.recordEvent(metav1.NamespaceSystem, corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Namespace",
+		Name:       metav1.NamespaceSystem,
+	}, reason, fmt.Sprintf(format, args...))
+}
+
+// recordEvent creates the Event on the best effort basis. Events are not
+// recorded until the Kubernetes client is initialized, and failing to record
+// one doesn't fail the operation.
+func (s *State) recordEvent(namespace string, object corev1.ObjectReference, reason, message string) {
+	if s.DynamicClient == nil || s.DryRun() {
+		return
+	}
+
+	now := metav1.NewTime(time.Now())
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: object.Name + ".",
+			Namespace:    namespace,
+		},
+		InvolvedObject: object,
+		Reason:         reason,
+		Message:        message,
+		Type:           corev1.EventTypeNormal,
+		Source: corev1.EventSource{
+			Component: eventSourceComponent,
+		},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	if err := s.DynamicClient.Create(s.Context, event); err != nil {
+		s.Logger.Warnf("Failed to record %s event: %v", reason, err)
+	}
+}
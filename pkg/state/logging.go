@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Categories of the errors reported by the task and node events
+const (
+	ErrorCategoryConnection    = "connection"
+	ErrorCategoryCommand       = "command"
+	ErrorCategoryKubernetesAPI = "kubernetes-api"
+	ErrorCategoryTimeout       = "timeout"
+	ErrorCategoryOther         = "other"
+)
+
+// ConnectionError is returned when connecting to the host fails
+type ConnectionError struct {
+	Host string
+	Err  error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("failed to connect to %s: %v", e.Host, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCategory returns the category of the error, so the failures can be
+// aggregated without parsing the error messages
+func ErrorCategory(err error) string {
+	var (
+		connErr *ConnectionError
+		exitErr *ssh.ExitError
+		apiErr  apierrors.APIStatus
+	)
+
+	switch {
+	case errors.As(err, &connErr):
+		return ErrorCategoryConnection
+	case errors.As(err, &exitErr):
+		return ErrorCategoryCommand
+	case errors.As(err, &apiErr):
+		return ErrorCategoryKubernetesAPI
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, wait.ErrWaitTimeout):
+		return ErrorCategoryTimeout
+	}
+
+	return ErrorCategoryOther
+}
+
+// LogEvent logs the structured event about the finished task or node task.
+// The events are logged at the info level with the JSON log format, and at the
+// debug level otherwise, not to clutter the human readable output.
+func (s *State) LogEvent(event string, start time.Time, err error, fields logrus.Fields) {
+	entry := s.Logger.WithFields(fields).WithFields(logrus.Fields{
+		"event":            event,
+		"status":           "succeeded",
+		"duration_seconds": time.Since(start).Seconds(),
+	})
+
+	if err != nil {
+		entry = entry.WithError(err).WithFields(logrus.Fields{
+			"status":         "failed",
+			"error_category": ErrorCategory(err),
+		})
+	}
+
+	if s.StructuredLogs {
+		entry.Info(event + " finished")
+	} else {
+		entry.Debug(event + " finished")
+	}
+}
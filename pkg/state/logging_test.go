@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestErrorCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "connection",
+			err:      errors.Wrap(&ConnectionError{Host: "10.0.0.1", Err: errors.New("connection refused")}, "failed to install prerequisites"),
+			expected: ErrorCategoryConnection,
+		},
+		{
+			name:     "kubernetes api",
+			err:      errors.Wrap(apierrors.NewNotFound(schema.GroupResource{Resource: "nodes"}, "node1"), "failed to get node"),
+			expected: ErrorCategoryKubernetesAPI,
+		},
+		{
+			name:     "context timeout",
+			err:      errors.Wrap(context.DeadlineExceeded, "failed to wait"),
+			expected: ErrorCategoryTimeout,
+		},
+		{
+			name:     "wait timeout",
+			err:      wait.ErrWaitTimeout,
+			expected: ErrorCategoryTimeout,
+		},
+		{
+			name:     "other",
+			err:      errors.New("invalid manifest"),
+			expected: ErrorCategoryOther,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ErrorCategory(tc.err); got != tc.expected {
+				t.Errorf("expected category %q, but got %q", tc.expected, got)
+			}
+		})
+	}
+}
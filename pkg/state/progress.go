@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ProgressStore persists the progress of the apply
+type ProgressStore interface {
+	// Get returns the stored progress, or nil if nothing is stored
+	Get() ([]byte, error)
+	// Put stores the progress, overwriting the previously stored one
+	Put(data []byte) error
+	// Delete removes the stored progress
+	Delete() error
+}
+
+// Progress records the resumable tasks completed by the apply, so the apply
+// interrupted by a transient failure can be resumed without running them
+// again. All methods are safe to call on the nil Progress, which records
+// nothing.
+type Progress struct {
+	ManifestHash string   `json:"manifestHash"`
+	Completed    []string `json:"completed,omitempty"`
+
+	lock  sync.Mutex
+	store ProgressStore
+}
+
+// NewProgress returns the empty progress of applying the manifest with the
+// given hash, stored in the given store
+func NewProgress(store ProgressStore, manifestHash string) *Progress {
+	return &Progress{
+		ManifestHash: manifestHash,
+		store:        store,
+	}
+}
+
+// LoadProgress returns the progress of the interrupted apply, or nil if no
+// progress is stored
+func LoadProgress(store ProgressStore) (*Progress, error) {
+	data, err := store.Get()
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	p := &Progress{store: store}
+	if err = json.Unmarshal(data, p); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the apply progress")
+	}
+
+	return p, nil
+}
+
+// IsCompleted returns whether the task was completed
+func (p *Progress) IsCompleted(task string) bool {
+	if p == nil {
+		return false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, completed := range p.Completed {
+		if completed == task {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MarkCompleted records the task as completed and stores the progress
+func (p *Progress) MarkCompleted(task string) error {
+	if p == nil {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.Completed = append(p.Completed, task)
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the apply progress")
+	}
+
+	return p.store.Put(data)
+}
+
+// Clear removes the stored progress once the apply succeeds
+func (p *Progress) Clear() error {
+	if p == nil {
+		return nil
+	}
+
+	return p.store.Delete()
+}
+
+type fileProgressStore struct {
+	path string
+}
+
+// NewFileProgressStore returns the ProgressStore storing the progress in
+// the file at the given path
+func NewFileProgressStore(path string) ProgressStore {
+	return &fileProgressStore{path: path}
+}
+
+func (f *fileProgressStore) Get() ([]byte, error) {
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return data, errors.Wrapf(err, "failed to read %s", f.path)
+}
+
+func (f *fileProgressStore) Put(data []byte) error {
+	return errors.Wrapf(ioutil.WriteFile(f.path, data, 0600), "failed to write %s", f.path)
+}
+
+func (f *fileProgressStore) Delete() error {
+	if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove %s", f.path)
+	}
+
+	return nil
+}
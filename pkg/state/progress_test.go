@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	store := NewFileProgressStore(filepath.Join(t.TempDir(), "progress.json"))
+
+	p, err := LoadProgress(store)
+	if err != nil {
+		t.Fatalf("LoadProgress() error = %v", err)
+	}
+	if p != nil {
+		t.Fatalf("expected no progress, but got %+v", p)
+	}
+	if p.IsCompleted("ensure CNI") {
+		t.Errorf("expected the nil progress to have no completed tasks")
+	}
+
+	p = NewProgress(store, "hash")
+	if err = p.MarkCompleted("ensure CNI"); err != nil {
+		t.Fatalf("MarkCompleted() error = %v", err)
+	}
+
+	loaded, err := LoadProgress(store)
+	if err != nil {
+		t.Fatalf("LoadProgress() error = %v", err)
+	}
+	if loaded.ManifestHash != "hash" {
+		t.Errorf("expected manifest hash %q, but got %q", "hash", loaded.ManifestHash)
+	}
+	if !loaded.IsCompleted("ensure CNI") || loaded.IsCompleted("ensure addons") {
+		t.Errorf("expected only %q to be completed, but got %v", "ensure CNI", loaded.Completed)
+	}
+
+	if err = loaded.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if p, err = LoadProgress(store); err != nil || p != nil {
+		t.Errorf("expected no progress after Clear(), but got %+v, %v", p, err)
+	}
+}
@@ -19,6 +19,7 @@ package state
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -36,29 +37,74 @@ func (s *State) runTask(node *kubeoneapi.HostConfig, task NodeTask) error {
 		conn ssh.Connection
 	)
 
+	start := time.Now()
+
 	// connect to the host (and do not close connection
 	// because we want to re-use it for future tasks)
 	conn, err = s.Connector.Connect(*node)
 	if err != nil {
-		return errors.Wrapf(err, "failed to connect to %s", node.PublicAddress)
+		err = errors.WithStack(&ConnectionError{Host: node.PublicAddress, Err: err})
+		s.LogEvent("node-task", start, err, nil)
+
+		return err
 	}
 
 	s.Runner = &runner.Runner{
-		Conn:    conn,
-		Verbose: s.Verbose,
-		OS:      node.OperatingSystem,
-		Prefix:  fmt.Sprintf("[%s] ", node.PublicAddress),
+		Conn:             conn,
+		Verbose:          s.Verbose,
+		StructuredOutput: s.StructuredLogs,
+		OS:               node.OperatingSystem,
+		Env:              node.Env,
+		Prefix:           fmt.Sprintf("[%s] ", node.PublicAddress),
+		Retry:            s.CommandRetry,
+		Logger:           s.Logger,
+	}
+
+	err = task(s, node, conn)
+	s.LogEvent("node-task", start, err, nil)
+
+	return err
+}
+
+// maxParallel returns the maximum number of hosts the parallel tasks run on
+// concurrently, 0 meaning no limit. The --max-parallel flag takes precedence
+// over the cluster configuration.
+func (s *State) maxParallel() int {
+	if s.MaxParallel > 0 || s.Cluster == nil {
+		return s.MaxParallel
 	}
 
-	return task(s, node, conn)
+	return s.Cluster.MaxParallel
 }
 
 // RunTaskOnNodes runs the given task on the given selection of hosts.
 func (s *State) RunTaskOnNodes(nodes []kubeoneapi.HostConfig, task NodeTask, parallel RunModeEnum) error {
-	var err error
+	return s.runOnNodes(nodes, parallel, func(ctx *State, node *kubeoneapi.HostConfig) error {
+		return ctx.runTask(node, task)
+	})
+}
 
-	wg := sync.WaitGroup{}
-	hasErrors := false
+// runOnNodes calls run with the copy of the State for each of the given
+// hosts. The parallel runs are limited to maxParallel hosts at once, and
+// the sequential runs stop at the first error.
+func (s *State) runOnNodes(nodes []kubeoneapi.HostConfig, parallel RunModeEnum, run func(ctx *State, node *kubeoneapi.HostConfig) error) error {
+	var (
+		err       error
+		wg        sync.WaitGroup
+		lock      sync.Mutex
+		hasErrors bool
+	)
+
+	// limits the number of hosts the parallel task runs on at once
+	var sem chan struct{}
+	if limit := s.maxParallel(); limit > 0 {
+		sem = make(chan struct{}, limit)
+	}
+
+	// the confirmation prompts of the interactive mode must not interleave
+	if s.Confirm != nil {
+		parallel = RunSequentially
+	}
 
 	for i := range nodes {
 		ctx := s.Clone()
@@ -67,15 +113,22 @@ func (s *State) RunTaskOnNodes(nodes []kubeoneapi.HostConfig, task NodeTask, par
 		if parallel == RunParallel {
 			wg.Add(1)
 			go func(ctx *State, node *kubeoneapi.HostConfig) {
-				err = ctx.runTask(node, task)
-				if err != nil {
+				defer wg.Done()
+
+				if sem != nil {
+					sem <- struct{}{}
+					defer func() { <-sem }()
+				}
+
+				if err := run(ctx, node); err != nil {
 					ctx.Logger.Error(err)
+					lock.Lock()
 					hasErrors = true
+					lock.Unlock()
 				}
-				wg.Done()
 			}(ctx, &nodes[i])
 		} else {
-			err = ctx.runTask(&nodes[i], task)
+			err = run(ctx, &nodes[i])
 			if err != nil {
 				break
 			}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestRunOnNodesMaxParallel(t *testing.T) {
+	nodes := []kubeoneapi.HostConfig{}
+	for i := 0; i < 6; i++ {
+		nodes = append(nodes, kubeoneapi.HostConfig{ID: i, PublicAddress: fmt.Sprintf("10.0.0.%d", i)})
+	}
+
+	tests := []struct {
+		name           string
+		flag           int
+		config         int
+		parallel       RunModeEnum
+		expectedLimit  int
+		expectedFailed bool
+	}{
+		{
+			name:          "flag limits the parallel runs",
+			flag:          2,
+			parallel:      RunParallel,
+			expectedLimit: 2,
+		},
+		{
+			name:          "flag takes precedence over the config",
+			flag:          2,
+			config:        4,
+			parallel:      RunParallel,
+			expectedLimit: 2,
+		},
+		{
+			name:          "config is used without the flag",
+			config:        2,
+			parallel:      RunParallel,
+			expectedLimit: 2,
+		},
+		{
+			name:          "no limit",
+			parallel:      RunParallel,
+			expectedLimit: len(nodes),
+		},
+		{
+			name:          "sequential runs",
+			flag:          2,
+			parallel:      RunSequentially,
+			expectedLimit: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := &State{
+				Logger:      logrus.New(),
+				Cluster:     &kubeoneapi.KubeOneCluster{MaxParallel: tc.config},
+				MaxParallel: tc.flag,
+			}
+
+			var (
+				lock             sync.Mutex
+				running, maxSeen int
+				ran              = map[int]bool{}
+			)
+			err := s.runOnNodes(nodes, tc.parallel, func(_ *State, node *kubeoneapi.HostConfig) error {
+				lock.Lock()
+				running++
+				if running > maxSeen {
+					maxSeen = running
+				}
+				ran[node.ID] = true
+				lock.Unlock()
+
+				// give the other hosts the chance to run concurrently
+				time.Sleep(20 * time.Millisecond)
+
+				lock.Lock()
+				running--
+				lock.Unlock()
+
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("runOnNodes() error = %v", err)
+			}
+
+			if maxSeen > tc.expectedLimit {
+				t.Errorf("expected at most %d hosts at once, but %d ran concurrently", tc.expectedLimit, maxSeen)
+			}
+			if tc.expectedLimit > 1 && maxSeen < 2 {
+				t.Errorf("expected the hosts to run concurrently, but at most %d ran at once", maxSeen)
+			}
+			if len(ran) != len(nodes) {
+				t.Errorf("expected all %d hosts to run, but %d did", len(nodes), len(ran))
+			}
+		})
+	}
+}
+
+func TestRunOnNodesErrors(t *testing.T) {
+	nodes := []kubeoneapi.HostConfig{{ID: 0}, {ID: 1}, {ID: 2}}
+	failing := func(_ *State, node *kubeoneapi.HostConfig) error {
+		if node.ID == 1 {
+			return errors.New("failed")
+		}
+
+		return nil
+	}
+
+	s := &State{Logger: logrus.New(), Cluster: &kubeoneapi.KubeOneCluster{}, MaxParallel: 2}
+	if err := s.runOnNodes(nodes, RunParallel, failing); err == nil {
+		t.Error("expected the failed parallel run to fail")
+	}
+
+	ran := 0
+	err := s.runOnNodes(nodes, RunSequentially, func(ctx *State, node *kubeoneapi.HostConfig) error {
+		ran++
+		return failing(ctx, node)
+	})
+	if err == nil || ran != 2 {
+		t.Errorf("expected the sequential run to stop at the failed host, but got error %v after %d hosts", err, ran)
+	}
+}
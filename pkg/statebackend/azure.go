@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+const (
+	// AzureSASTokenEnv is the environment variable with the SAS token used
+	// to access the Azure Blob Storage container
+	AzureSASTokenEnv = "AZURE_STORAGE_SAS_TOKEN"
+
+	azureBlobAPIVersion = "2020-04-08"
+)
+
+// azureBlobBackend uses the Blob service REST API
+type azureBlobBackend struct {
+	client    *http.Client
+	endpoint  string
+	container string
+	sasToken  string
+}
+
+func newAzureBlobBackend(cfg *kubeoneapi.AzureBlobStateBackend) (*azureBlobBackend, error) {
+	sasToken := strings.TrimPrefix(os.Getenv(AzureSASTokenEnv), "?")
+	if sasToken == "" {
+		return nil, errors.Errorf("the %s environment variable is required to access the Azure Blob Storage container", AzureSASTokenEnv)
+	}
+
+	return &azureBlobBackend{
+		client:    http.DefaultClient,
+		endpoint:  fmt.Sprintf("https://%s.blob.core.windows.net", cfg.StorageAccount),
+		container: cfg.Container,
+		sasToken:  sasToken,
+	}, nil
+}
+
+func (b *azureBlobBackend) Put(ctx context.Context, key string, data []byte) error {
+	header := b.header()
+	header.Set("x-ms-blob-type", "BlockBlob")
+	header.Set("Content-Type", "application/octet-stream")
+
+	_, err := doRequest(ctx, b.client, http.MethodPut, b.blobURL(key), header, data)
+
+	return errors.Wrapf(err, "failed to put blob %s/%s", b.container, key)
+}
+
+func (b *azureBlobBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := doRequest(ctx, b.client, http.MethodGet, b.blobURL(key), b.header(), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	return data, errors.Wrapf(err, "failed to get blob %s/%s", b.container, key)
+}
+
+func (b *azureBlobBackend) Delete(ctx context.Context, key string) error {
+	_, err := doRequest(ctx, b.client, http.MethodDelete, b.blobURL(key), b.header(), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	return errors.Wrapf(err, "failed to delete blob %s/%s", b.container, key)
+}
+
+func (b *azureBlobBackend) GetVersion(ctx context.Context, key string) ([]byte, string, error) {
+	data, header, err := doRequestWithHeader(ctx, b.client, http.MethodGet, b.blobURL(key), b.header(), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, "", err
+	}
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to get blob %s/%s", b.container, key)
+	}
+
+	return data, header.Get("ETag"), nil
+}
+
+// PutIfMatch uses the ETag of the blob as its version
+func (b *azureBlobBackend) PutIfMatch(ctx context.Context, key string, data []byte, version string) (string, error) {
+	header := b.header()
+	header.Set("x-ms-blob-type", "BlockBlob")
+	header.Set("Content-Type", "application/octet-stream")
+	setIfMatch(header, version)
+
+	_, respHeader, err := doRequestWithHeader(ctx, b.client, http.MethodPut, b.blobURL(key), header, data)
+	if errors.Is(err, ErrPreconditionFailed) {
+		return "", err
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to put blob %s/%s", b.container, key)
+	}
+
+	return respHeader.Get("ETag"), nil
+}
+
+func (b *azureBlobBackend) DeleteIfMatch(ctx context.Context, key, version string) error {
+	header := b.header()
+	header.Set("If-Match", version)
+
+	_, err := doRequest(ctx, b.client, http.MethodDelete, b.blobURL(key), header, nil)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return nil
+	case errors.Is(err, ErrPreconditionFailed):
+		return err
+	}
+
+	return errors.Wrapf(err, "failed to delete blob %s/%s", b.container, key)
+}
+
+func (b *azureBlobBackend) blobURL(key string) string {
+	return fmt.Sprintf("%s/%s/%s?%s", b.endpoint, url.PathEscape(b.container), (&url.URL{Path: key}).EscapedPath(), b.sasToken)
+}
+
+func (b *azureBlobBackend) header() http.Header {
+	header := http.Header{}
+	header.Set("x-ms-version", azureBlobAPIVersion)
+
+	return header
+}
+
+// setIfMatch sets the conditional request header matching the ETag, or
+// matching only the missing object if the ETag is empty
+func setIfMatch(header http.Header, etag string) {
+	if etag == "" {
+		header.Set("If-None-Match", "*")
+	} else {
+		header.Set("If-Match", etag)
+	}
+}
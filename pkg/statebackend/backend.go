@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackend
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+// ErrNotFound is returned when the requested object doesn't exist
+var ErrNotFound = errors.New("object not found")
+
+// ErrPreconditionFailed is returned when the conditional write doesn't
+// apply, because the object was changed, created or deleted in the meantime
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// Backend stores the KubeOne state objects
+type Backend interface {
+	// Put creates or overwrites the object
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the object, or ErrNotFound if it doesn't exist
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the object, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// ConditionalBackend is the Backend supporting the conditional writes. It
+// stores the objects written concurrently by several KubeOne runs, such as
+// the cluster lock.
+type ConditionalBackend interface {
+	Backend
+	// GetVersion returns the object along with its version, or ErrNotFound
+	// if it doesn't exist
+	GetVersion(ctx context.Context, key string) ([]byte, string, error)
+	// PutIfMatch writes the object only if its current version is the given
+	// one, or only if it doesn't exist when the version is empty, and returns
+	// the version of the written object. ErrPreconditionFailed is returned
+	// if the version doesn't match.
+	PutIfMatch(ctx context.Context, key string, data []byte, version string) (string, error)
+	// DeleteIfMatch removes the object only if its current version is the
+	// given one. ErrPreconditionFailed is returned if the version doesn't
+	// match, deleting a missing object is not an error.
+	DeleteIfMatch(ctx context.Context, key, version string) error
+}
+
+// Option configures the Backend returned by New
+type Option func(*options)
+
+type options struct {
+	client ClientFunc
+}
+
+// WithKubernetesClient sets the function returning the client of the cluster
+// provisioned by KubeOne, required by the Kubernetes Secret backend. The
+// function is called on every access to the backend, so the cluster doesn't
+// have to be reachable when the backend is created.
+func WithKubernetesClient(client ClientFunc) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// New returns the Backend configured by the StateBackend. Objects of the
+// cluster are stored under the configured prefix joined with the cluster name.
+func New(cfg *kubeoneapi.StateBackend, clusterName string, opts ...Option) (Backend, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	switch {
+	case cfg == nil:
+		return nil, errors.New("state backend is not configured")
+	case cfg.S3 != nil:
+		b, err := newS3Backend(cfg.S3)
+		if err != nil {
+			return nil, err
+		}
+
+		return withPrefix(b, path.Join(cfg.S3.Prefix, clusterName)), nil
+	case cfg.GCS != nil:
+		b, err := newGCSBackend(cfg.GCS)
+		if err != nil {
+			return nil, err
+		}
+
+		return withPrefix(b, path.Join(cfg.GCS.Prefix, clusterName)), nil
+	case cfg.AzureBlob != nil:
+		b, err := newAzureBlobBackend(cfg.AzureBlob)
+		if err != nil {
+			return nil, err
+		}
+
+		return withPrefix(b, path.Join(cfg.AzureBlob.Prefix, clusterName)), nil
+	case cfg.Local != nil:
+		return withPrefix(newLocalBackend(cfg.Local), clusterName), nil
+	case cfg.KubernetesSecret != nil:
+		b, err := newKubernetesSecretBackend(cfg.KubernetesSecret, o.client)
+		if err != nil {
+			return nil, err
+		}
+
+		return withPrefix(b, path.Join(cfg.KubernetesSecret.Prefix, clusterName)), nil
+	}
+
+	return nil, errors.New("no state backend specified")
+}
+
+type prefixedBackend struct {
+	backend Backend
+	prefix  string
+}
+
+func withPrefix(b Backend, prefix string) Backend {
+	p := &prefixedBackend{backend: b, prefix: prefix}
+	if conditional, ok := b.(ConditionalBackend); ok {
+		return &prefixedConditionalBackend{prefixedBackend: p, conditional: conditional}
+	}
+
+	return p
+}
+
+func (p *prefixedBackend) Put(ctx context.Context, key string, data []byte) error {
+	return p.backend.Put(ctx, path.Join(p.prefix, key), data)
+}
+
+func (p *prefixedBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	return p.backend.Get(ctx, path.Join(p.prefix, key))
+}
+
+func (p *prefixedBackend) Delete(ctx context.Context, key string) error {
+	return p.backend.Delete(ctx, path.Join(p.prefix, key))
+}
+
+type prefixedConditionalBackend struct {
+	*prefixedBackend
+	conditional ConditionalBackend
+}
+
+func (p *prefixedConditionalBackend) GetVersion(ctx context.Context, key string) ([]byte, string, error) {
+	return p.conditional.GetVersion(ctx, path.Join(p.prefix, key))
+}
+
+func (p *prefixedConditionalBackend) PutIfMatch(ctx context.Context, key string, data []byte, version string) (string, error) {
+	return p.conditional.PutIfMatch(ctx, path.Join(p.prefix, key), data, version)
+}
+
+func (p *prefixedConditionalBackend) DeleteIfMatch(ctx context.Context, key, version string) error {
+	return p.conditional.DeleteIfMatch(ctx, path.Join(p.prefix, key), version)
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackend
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeObjectStore stores request bodies by the request path and query. The
+// objects are versioned, and the conditional requests are evaluated the way
+// GCS (ifGenerationMatch) and Azure (If-Match and If-None-Match) do.
+type fakeObjectStore struct {
+	lock     sync.Mutex
+	objects  map[string][]byte
+	versions map[string]int
+	next     int
+	keyFn    func(r *http.Request) string
+	check    func(r *http.Request) bool
+}
+
+func (f *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.check(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.versions == nil {
+		f.versions = map[string]int{}
+	}
+
+	key := f.keyFn(r)
+	_, exists := f.objects[key]
+	version := strconv.Itoa(f.versions[key])
+
+	// reads aren't conditional, and deleting the missing object is 404
+	// regardless of the conditions
+	conditional := r.Method != http.MethodGet && (r.Method != http.MethodDelete || exists)
+	if conditional && !f.matches(r, exists, version) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", `"`+version+`"`)
+		w.Header().Set(gcsGenerationHeader, version)
+		_, _ = w.Write(f.objects[key])
+	case http.MethodDelete:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.objects, key)
+		delete(f.versions, key)
+	default:
+		data, _ := ioutil.ReadAll(r.Body)
+		f.next++
+		f.objects[key] = data
+		f.versions[key] = f.next
+		version = strconv.Itoa(f.next)
+		w.Header().Set("ETag", `"`+version+`"`)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"generation": %q}`, version)
+	}
+}
+
+func (f *fakeObjectStore) matches(r *http.Request, exists bool, version string) bool {
+	if generation := r.URL.Query().Get("ifGenerationMatch"); generation != "" {
+		return (generation == "0" && !exists) || (exists && generation == version)
+	}
+	if r.Header.Get("If-None-Match") == "*" && exists {
+		return false
+	}
+	if etag := r.Header.Get("If-Match"); etag != "" {
+		return exists && etag == `"`+version+`"`
+	}
+
+	return true
+}
+
+func testBackend(t *testing.T, b Backend) {
+	ctx := context.Background()
+
+	if _, err := b.Get(ctx, "checkpoint.json"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for missing object, but got %v", err)
+	}
+
+	if err := b.Put(ctx, "configs/cfg/kubeadm.yaml", []byte("kind: ClusterConfiguration")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	data, err := b.Get(ctx, "configs/cfg/kubeadm.yaml")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(data) != "kind: ClusterConfiguration" {
+		t.Errorf("expected object %q, but got %q", "kind: ClusterConfiguration", string(data))
+	}
+
+	if err = b.Delete(ctx, "configs/cfg/kubeadm.yaml"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err = b.Delete(ctx, "configs/cfg/kubeadm.yaml"); err != nil {
+		t.Fatalf("Delete() of missing object error = %v", err)
+	}
+	if _, err = b.Get(ctx, "configs/cfg/kubeadm.yaml"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for deleted object, but got %v", err)
+	}
+
+	if err = b.Put(ctx, "configs/cfg/kubeadm.yaml", []byte("kind: ClusterConfiguration")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+}
+
+func testConditionalBackend(t *testing.T, b Backend) {
+	ctx := context.Background()
+
+	cb, ok := b.(ConditionalBackend)
+	if !ok {
+		t.Fatalf("expected the backend to support the conditional writes")
+	}
+
+	first, err := cb.PutIfMatch(ctx, "lock.json", []byte("first"), "")
+	if err != nil {
+		t.Fatalf("PutIfMatch() of missing object error = %v", err)
+	}
+	if _, err = cb.PutIfMatch(ctx, "lock.json", []byte("second"), ""); !errors.Is(err, ErrPreconditionFailed) {
+		t.Fatalf("expected ErrPreconditionFailed creating existing object, but got %v", err)
+	}
+
+	data, version, err := cb.GetVersion(ctx, "lock.json")
+	if err != nil {
+		t.Fatalf("GetVersion() error = %v", err)
+	}
+	if string(data) != "first" || version != first {
+		t.Errorf("expected object %q with version %q, but got %q with version %q", "first", first, string(data), version)
+	}
+
+	second, err := cb.PutIfMatch(ctx, "lock.json", []byte("second"), first)
+	if err != nil {
+		t.Fatalf("PutIfMatch() of matching version error = %v", err)
+	}
+	if _, err = cb.PutIfMatch(ctx, "lock.json", []byte("third"), first); !errors.Is(err, ErrPreconditionFailed) {
+		t.Fatalf("expected ErrPreconditionFailed writing stale version, but got %v", err)
+	}
+	if err = cb.DeleteIfMatch(ctx, "lock.json", first); !errors.Is(err, ErrPreconditionFailed) {
+		t.Fatalf("expected ErrPreconditionFailed deleting stale version, but got %v", err)
+	}
+	if err = cb.DeleteIfMatch(ctx, "lock.json", second); err != nil {
+		t.Fatalf("DeleteIfMatch() error = %v", err)
+	}
+	if err = cb.DeleteIfMatch(ctx, "lock.json", second); err != nil {
+		t.Fatalf("DeleteIfMatch() of missing object error = %v", err)
+	}
+	if _, _, err = cb.GetVersion(ctx, "lock.json"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for deleted object, but got %v", err)
+	}
+}
+
+func TestGCSBackend(t *testing.T) {
+	store := &fakeObjectStore{
+		objects: map[string][]byte{},
+		keyFn: func(r *http.Request) string {
+			if r.Method == http.MethodPost {
+				return r.URL.Query().Get("name")
+			}
+			return strings.TrimPrefix(r.URL.Path, "/storage/v1/b/kubeone-state/o/")
+		},
+		check: func(r *http.Request) bool {
+			return r.Header.Get("Authorization") == "Bearer token"
+		},
+	}
+	srv := httptest.NewServer(store)
+	defer srv.Close()
+
+	b := withPrefix(&gcsBackend{
+		client:   srv.Client(),
+		endpoint: srv.URL,
+		bucket:   "kubeone-state",
+		token:    "token",
+	}, "team/demo")
+	testBackend(t, b)
+	testConditionalBackend(t, b)
+
+	if _, ok := store.objects["team/demo/configs/cfg/kubeadm.yaml"]; !ok {
+		t.Errorf("expected object to be stored under the prefix, but got %v", store.objects)
+	}
+}
+
+func TestAzureBlobBackend(t *testing.T) {
+	store := &fakeObjectStore{
+		objects: map[string][]byte{},
+		keyFn: func(r *http.Request) string {
+			return strings.TrimPrefix(r.URL.Path, "/state/")
+		},
+		check: func(r *http.Request) bool {
+			if r.Method == http.MethodPut && r.Header.Get("x-ms-blob-type") != "BlockBlob" {
+				return false
+			}
+			return r.URL.Query().Get("sig") == "secret"
+		},
+	}
+	srv := httptest.NewServer(store)
+	defer srv.Close()
+
+	b := withPrefix(&azureBlobBackend{
+		client:    srv.Client(),
+		endpoint:  srv.URL,
+		container: "state",
+		sasToken:  "sv=2020-04-08&sig=secret",
+	}, "demo")
+	testBackend(t, b)
+	testConditionalBackend(t, b)
+
+	if _, ok := store.objects["demo/configs/cfg/kubeadm.yaml"]; !ok {
+		t.Errorf("expected object to be stored under the prefix, but got %v", store.objects)
+	}
+}
+
+func TestLocalBackend(t *testing.T) {
+	dir := t.TempDir()
+
+	b, err := New(&kubeoneapi.StateBackend{
+		Local: &kubeoneapi.LocalStateBackend{Path: dir},
+	}, "demo")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	testBackend(t, b)
+	testConditionalBackend(t, b)
+
+	if _, err = os.Stat(filepath.Join(dir, "demo", "configs", "cfg", "kubeadm.yaml")); err != nil {
+		t.Errorf("expected object to be stored under the cluster directory: %v", err)
+	}
+}
+
+func TestKubernetesSecretBackend(t *testing.T) {
+	client := fake.NewClientBuilder().Build()
+
+	b, err := New(&kubeoneapi.StateBackend{
+		KubernetesSecret: &kubeoneapi.KubernetesSecretStateBackend{},
+	}, "demo", WithKubernetesClient(func() (dynclient.Client, error) {
+		return client, nil
+	}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	testBackend(t, b)
+
+	secrets := &corev1.SecretList{}
+	if err = client.List(context.Background(), secrets); err != nil {
+		t.Fatalf("failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 1 {
+		t.Fatalf("expected 1 secret, but got %d", len(secrets.Items))
+	}
+
+	secret := secrets.Items[0]
+	if secret.Namespace != "kube-system" {
+		t.Errorf("expected the secret in the kube-system namespace, but got %q", secret.Namespace)
+	}
+	if key := secret.Annotations[kubernetesSecretKeyAnnotation]; key != "demo/configs/cfg/kubeadm.yaml" {
+		t.Errorf("expected the secret to record the key %q, but got %q", "demo/configs/cfg/kubeadm.yaml", key)
+	}
+
+	if _, err = New(&kubeoneapi.StateBackend{
+		KubernetesSecret: &kubeoneapi.KubernetesSecretStateBackend{},
+	}, "demo"); err == nil {
+		t.Errorf("expected an error creating the Kubernetes Secret backend without the client")
+	}
+}
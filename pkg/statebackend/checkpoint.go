@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackend
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Keys of the objects stored in the state backend, relative to the cluster
+// prefix
+const (
+	CheckpointKey = "checkpoint.json"
+	ProgressKey   = "progress.json"
+	PKIBackupKey  = "pki-backup.tar.gz"
+	ConfigsPrefix = "configs"
+
+	EtcdSnapshotsPrefix = "etcd-snapshots"
+)
+
+// Checkpoint describes the last successful apply stored in the backend
+type Checkpoint struct {
+	KubeOneVersion string    `json:"kubeoneVersion"`
+	ManifestHash   string    `json:"manifestHash"`
+	AppliedAt      time.Time `json:"appliedAt"`
+	// Configs are the names of the rendered configuration files, stored
+	// under the ConfigsPrefix
+	Configs []string `json:"configs,omitempty"`
+	// Hosts maps the hosts to the IDs assigned by KubeOne, which name the
+	// per-host configuration files
+	Hosts []CheckpointHost `json:"hosts,omitempty"`
+}
+
+// CheckpointHost describes the host of the applied cluster
+type CheckpointHost struct {
+	ID             int    `json:"id"`
+	PublicAddress  string `json:"publicAddress"`
+	PrivateAddress string `json:"privateAddress"`
+	Hostname       string `json:"hostname,omitempty"`
+	ControlPlane   bool   `json:"controlPlane,omitempty"`
+}
+
+// ConfigKey returns the key of the rendered configuration file
+func ConfigKey(filename string) string {
+	return path.Join(ConfigsPrefix, filename)
+}
+
+// EtcdSnapshotKey returns the key of the etcd snapshot
+func EtcdSnapshotKey(filename string) string {
+	return path.Join(EtcdSnapshotsPrefix, filename)
+}
+
+// PutCheckpoint stores the checkpoint
+func PutCheckpoint(ctx context.Context, b Backend, checkpoint *Checkpoint) error {
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal checkpoint")
+	}
+
+	return b.Put(ctx, CheckpointKey, data)
+}
+
+// GetCheckpoint returns the stored checkpoint, or ErrNotFound if the cluster
+// was never applied with the state backend
+func GetCheckpoint(ctx context.Context, b Backend) (*Checkpoint, error) {
+	data, err := b.Get(ctx, CheckpointKey)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal checkpoint")
+	}
+
+	return checkpoint, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+const (
+	// GCSAccessTokenEnv is the environment variable with the OAuth2 access
+	// token used to access the GCS bucket
+	GCSAccessTokenEnv = "GOOGLE_OAUTH_ACCESS_TOKEN"
+
+	gcsEndpoint         = "https://storage.googleapis.com"
+	gcsGenerationHeader = "X-Goog-Generation"
+)
+
+// gcsBackend uses the Cloud Storage JSON API
+type gcsBackend struct {
+	client   *http.Client
+	endpoint string
+	bucket   string
+	token    string
+}
+
+func newGCSBackend(cfg *kubeoneapi.GCSStateBackend) (*gcsBackend, error) {
+	token := os.Getenv(GCSAccessTokenEnv)
+	if token == "" {
+		return nil, errors.Errorf("the %s environment variable is required to access the GCS bucket", GCSAccessTokenEnv)
+	}
+
+	return &gcsBackend{
+		client:   http.DefaultClient,
+		endpoint: gcsEndpoint,
+		bucket:   cfg.Bucket,
+		token:    token,
+	}, nil
+}
+
+func (b *gcsBackend) Put(ctx context.Context, key string, data []byte) error {
+	u := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s", b.endpoint, url.PathEscape(b.bucket), url.QueryEscape(key))
+	header := b.header()
+	header.Set("Content-Type", "application/octet-stream")
+
+	_, err := doRequest(ctx, b.client, http.MethodPost, u, header, data)
+
+	return errors.Wrapf(err, "failed to put gs://%s/%s", b.bucket, key)
+}
+
+func (b *gcsBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	u := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media", b.endpoint, url.PathEscape(b.bucket), url.PathEscape(key))
+
+	data, err := doRequest(ctx, b.client, http.MethodGet, u, b.header(), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	return data, errors.Wrapf(err, "failed to get gs://%s/%s", b.bucket, key)
+}
+
+func (b *gcsBackend) Delete(ctx context.Context, key string) error {
+	u := fmt.Sprintf("%s/storage/v1/b/%s/o/%s", b.endpoint, url.PathEscape(b.bucket), url.PathEscape(key))
+
+	_, err := doRequest(ctx, b.client, http.MethodDelete, u, b.header(), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	return errors.Wrapf(err, "failed to delete gs://%s/%s", b.bucket, key)
+}
+
+func (b *gcsBackend) GetVersion(ctx context.Context, key string) ([]byte, string, error) {
+	u := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media", b.endpoint, url.PathEscape(b.bucket), url.PathEscape(key))
+
+	data, header, err := doRequestWithHeader(ctx, b.client, http.MethodGet, u, b.header(), nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, "", err
+	}
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to get gs://%s/%s", b.bucket, key)
+	}
+
+	return data, header.Get(gcsGenerationHeader), nil
+}
+
+// PutIfMatch uses the generation of the object as its version. The
+// generation 0 matches only the object that doesn't exist.
+func (b *gcsBackend) PutIfMatch(ctx context.Context, key string, data []byte, version string) (string, error) {
+	if version == "" {
+		version = "0"
+	}
+	u := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s&ifGenerationMatch=%s",
+		b.endpoint, url.PathEscape(b.bucket), url.QueryEscape(key), url.QueryEscape(version))
+	header := b.header()
+	header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := doRequest(ctx, b.client, http.MethodPost, u, header, data)
+	if errors.Is(err, ErrPreconditionFailed) {
+		return "", err
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to put gs://%s/%s", b.bucket, key)
+	}
+
+	object := struct {
+		Generation string `json:"generation"`
+	}{}
+	if err = json.Unmarshal(resp, &object); err != nil {
+		return "", errors.Wrapf(err, "failed to parse the metadata of gs://%s/%s", b.bucket, key)
+	}
+
+	return object.Generation, nil
+}
+
+func (b *gcsBackend) DeleteIfMatch(ctx context.Context, key, version string) error {
+	u := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?ifGenerationMatch=%s",
+		b.endpoint, url.PathEscape(b.bucket), url.PathEscape(key), url.QueryEscape(version))
+
+	_, err := doRequest(ctx, b.client, http.MethodDelete, u, b.header(), nil)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return nil
+	case errors.Is(err, ErrPreconditionFailed):
+		return err
+	}
+
+	return errors.Wrapf(err, "failed to delete gs://%s/%s", b.bucket, key)
+}
+
+func (b *gcsBackend) header() http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+b.token)
+
+	return header
+}
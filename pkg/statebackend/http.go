@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackend
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// doRequest sends the request and returns the response body. The
+// ErrNotFound is returned for 404 responses.
+func doRequest(ctx context.Context, client *http.Client, method, url string, header http.Header, body []byte) ([]byte, error) {
+	data, _, err := doRequestWithHeader(ctx, client, method, url, header, body)
+
+	return data, err
+}
+
+// doRequestWithHeader sends the request and returns the response body along
+// with the response headers. The ErrNotFound is returned for 404 responses
+// and the ErrPreconditionFailed for 412 responses. The 409 responses to the
+// conditional requests are reported as ErrPreconditionFailed as well, as
+// some storages report the object created in the meantime this way.
+func doRequestWithHeader(ctx context.Context, client *http.Client, method, url string, header http.Header, body []byte) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil, ErrNotFound
+	case resp.StatusCode == http.StatusPreconditionFailed,
+		resp.StatusCode == http.StatusConflict && (header.Get("If-Match") != "" || header.Get("If-None-Match") != ""):
+		return nil, nil, ErrPreconditionFailed
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, nil, errors.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+
+	return data, resp.Header, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackend
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// kubernetesSecretKeyAnnotation records the key of the object stored in
+	// the Secret, as the Secret name is derived from the key hash
+	kubernetesSecretKeyAnnotation = "kubeone.k8c.io/state-key"
+	kubernetesSecretLabel         = "kubeone.k8c.io/state"
+	kubernetesSecretDataKey       = "data"
+	kubernetesSecretNamePrefix    = "kubeone-state-"
+)
+
+// ClientFunc returns the client of the cluster provisioned by KubeOne
+type ClientFunc func() (dynclient.Client, error)
+
+// kubernetesSecretBackend stores every object in its own Secret, named after
+// the hash of the key, so the objects don't share the Secret size limit
+type kubernetesSecretBackend struct {
+	client    ClientFunc
+	namespace string
+}
+
+func newKubernetesSecretBackend(cfg *kubeoneapi.KubernetesSecretStateBackend, client ClientFunc) (*kubernetesSecretBackend, error) {
+	if client == nil {
+		return nil, errors.New("the Kubernetes Secret state backend requires the Kubernetes client")
+	}
+
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceSystem
+	}
+
+	return &kubernetesSecretBackend{
+		client:    client,
+		namespace: namespace,
+	}, nil
+}
+
+func (b *kubernetesSecretBackend) Put(ctx context.Context, key string, data []byte) error {
+	client, err := b.client()
+	if err != nil {
+		return errors.Wrapf(err, "failed to put %s", key)
+	}
+
+	secret := &corev1.Secret{}
+	err = client.Get(ctx, b.objectKey(key), secret)
+
+	switch {
+	case k8serrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        b.objectKey(key).Name,
+				Namespace:   b.namespace,
+				Labels:      map[string]string{kubernetesSecretLabel: "true"},
+				Annotations: map[string]string{kubernetesSecretKeyAnnotation: key},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{kubernetesSecretDataKey: data},
+		}
+
+		return errors.Wrapf(client.Create(ctx, secret), "failed to put %s", key)
+	case err != nil:
+		return errors.Wrapf(err, "failed to put %s", key)
+	}
+
+	secret.Data = map[string][]byte{kubernetesSecretDataKey: data}
+
+	return errors.Wrapf(client.Update(ctx, secret), "failed to put %s", key)
+}
+
+func (b *kubernetesSecretBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	client, err := b.client()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s", key)
+	}
+
+	secret := &corev1.Secret{}
+	err = client.Get(ctx, b.objectKey(key), secret)
+	if k8serrors.IsNotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s", key)
+	}
+
+	return secret.Data[kubernetesSecretDataKey], nil
+}
+
+func (b *kubernetesSecretBackend) Delete(ctx context.Context, key string) error {
+	client, err := b.client()
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete %s", key)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.objectKey(key).Name,
+			Namespace: b.namespace,
+		},
+	}
+
+	return errors.Wrapf(dynclient.IgnoreNotFound(client.Delete(ctx, secret)), "failed to delete %s", key)
+}
+
+func (b *kubernetesSecretBackend) objectKey(key string) dynclient.ObjectKey {
+	return dynclient.ObjectKey{
+		Name:      fmt.Sprintf("%s%x", kubernetesSecretNamePrefix, sha256.Sum256([]byte(key)))[:len(kubernetesSecretNamePrefix)+32],
+		Namespace: b.namespace,
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackend
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+// localLockTimeout is how long the conditional writes wait for the lock file
+const localLockTimeout = 10 * time.Second
+
+// localBackend stores the objects as the files in the local directory
+type localBackend struct {
+	dir string
+}
+
+func newLocalBackend(cfg *kubeoneapi.LocalStateBackend) *localBackend {
+	return &localBackend{dir: cfg.Path}
+}
+
+func (b *localBackend) Put(_ context.Context, key string, data []byte) error {
+	target := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory for %s", target)
+	}
+
+	// the object is replaced atomically, so concurrent readers never get the
+	// partially written file
+	tmp, err := ioutil.TempFile(filepath.Dir(target), ".kubeone-state-")
+	if err != nil {
+		return errors.Wrapf(err, "failed to put %s", target)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "failed to put %s", target)
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to put %s", target)
+	}
+
+	return errors.Wrapf(os.Rename(tmp.Name(), target), "failed to put %s", target)
+}
+
+func (b *localBackend) Get(_ context.Context, key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(b.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return data, errors.Wrapf(err, "failed to get %s", b.path(key))
+}
+
+func (b *localBackend) Delete(_ context.Context, key string) error {
+	err := os.Remove(b.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return errors.Wrapf(err, "failed to delete %s", b.path(key))
+}
+
+// GetVersion uses the checksum of the file as its version
+func (b *localBackend) GetVersion(ctx context.Context, key string) ([]byte, string, error) {
+	data, err := b.Get(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, checksum(data), nil
+}
+
+// PutIfMatch compares and writes the file while holding the lock file next to
+// it, so the concurrent KubeOne runs on the same machine are serialized
+func (b *localBackend) PutIfMatch(ctx context.Context, key string, data []byte, version string) (string, error) {
+	err := b.withFileLock(key, func() error {
+		if err := b.matchVersion(ctx, key, version); err != nil {
+			return err
+		}
+
+		return b.Put(ctx, key, data)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return checksum(data), nil
+}
+
+func (b *localBackend) DeleteIfMatch(ctx context.Context, key, version string) error {
+	return b.withFileLock(key, func() error {
+		if _, err := b.Get(ctx, key); errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		if err := b.matchVersion(ctx, key, version); err != nil {
+			return err
+		}
+
+		return b.Delete(ctx, key)
+	})
+}
+
+// matchVersion returns ErrPreconditionFailed if the version of the file isn't
+// the given one. The empty version matches only the missing file.
+func (b *localBackend) matchVersion(ctx context.Context, key, version string) error {
+	data, err := b.Get(ctx, key)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if version != "" {
+			return ErrPreconditionFailed
+		}
+
+		return nil
+	case err != nil:
+		return err
+	case checksum(data) != version:
+		return ErrPreconditionFailed
+	}
+
+	return nil
+}
+
+func (b *localBackend) withFileLock(key string, fn func() error) error {
+	lockPath := b.path(key) + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory for %s", lockPath)
+	}
+
+	deadline := time.Now().Add(localLockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			break
+		}
+		if !os.IsExist(err) {
+			return errors.Wrapf(err, "failed to create %s", lockPath)
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out waiting for %s, remove it if no other KubeOne run is using it", lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	defer os.Remove(lockPath)
+
+	return fn()
+}
+
+func checksum(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+func (b *localBackend) path(key string) string {
+	return filepath.Join(b.dir, filepath.FromSlash(key))
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackend
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+type s3Backend struct {
+	client *s3.S3
+	bucket string
+}
+
+func newS3Backend(cfg *kubeoneapi.S3StateBackend) (*s3Backend, error) {
+	awsConfig := aws.Config{}
+	if cfg.Region != "" {
+		awsConfig.Region = aws.String(cfg.Region)
+	}
+	if cfg.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.Endpoint)
+		// S3-compatible storages usually don't support virtual-hosted buckets
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            awsConfig,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session")
+	}
+
+	return &s3Backend{
+		client: s3.New(sess),
+		bucket: cfg.Bucket,
+	}, nil
+}
+
+func (b *s3Backend) Put(ctx context.Context, key string, data []byte) error {
+	_, err := b.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+
+	return errors.Wrapf(err, "failed to put s3://%s/%s", b.bucket, key)
+}
+
+func (b *s3Backend) Get(ctx context.Context, key string) ([]byte, error) {
+	out, err := b.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var aerr awserr.Error
+		if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrNotFound
+		}
+
+		return nil, errors.Wrapf(err, "failed to get s3://%s/%s", b.bucket, key)
+	}
+	defer out.Body.Close()
+
+	data, err := ioutil.ReadAll(out.Body)
+
+	return data, errors.Wrapf(err, "failed to read s3://%s/%s", b.bucket, key)
+}
+
+func (b *s3Backend) Delete(ctx context.Context, key string) error {
+	_, err := b.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+
+	return errors.Wrapf(err, "failed to delete s3://%s/%s", b.bucket, key)
+}
+
+func (b *s3Backend) GetVersion(ctx context.Context, key string) ([]byte, string, error) {
+	out, err := b.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var aerr awserr.Error
+		if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, "", ErrNotFound
+		}
+
+		return nil, "", errors.Wrapf(err, "failed to get s3://%s/%s", b.bucket, key)
+	}
+	defer out.Body.Close()
+
+	data, err := ioutil.ReadAll(out.Body)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to read s3://%s/%s", b.bucket, key)
+	}
+
+	return data, aws.StringValue(out.ETag), nil
+}
+
+// PutIfMatch uses the ETag of the object as its version. The S3-compatible
+// storages must support the conditional writes for the cluster lock to be
+// exclusive.
+func (b *s3Backend) PutIfMatch(ctx context.Context, key string, data []byte, version string) (string, error) {
+	header := http.Header{}
+	setIfMatch(header, version)
+
+	out, err := b.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	}, withRequestHeader(header))
+	if isS3PreconditionFailed(err) {
+		return "", ErrPreconditionFailed
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to put s3://%s/%s", b.bucket, key)
+	}
+
+	return aws.StringValue(out.ETag), nil
+}
+
+func (b *s3Backend) DeleteIfMatch(ctx context.Context, key, version string) error {
+	header := http.Header{}
+	header.Set("If-Match", version)
+
+	_, err := b.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	}, withRequestHeader(header))
+	if isS3PreconditionFailed(err) {
+		return ErrPreconditionFailed
+	}
+
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+		return nil
+	}
+
+	return errors.Wrapf(err, "failed to delete s3://%s/%s", b.bucket, key)
+}
+
+func withRequestHeader(header http.Header) request.Option {
+	headers := map[string]string{}
+	for name := range header {
+		headers[name] = header.Get(name)
+	}
+
+	return request.WithSetRequestHeaders(headers)
+}
+
+// isS3PreconditionFailed returns whether the conditional request failed
+// because the object was changed, or is being written concurrently
+func isS3PreconditionFailed(err error) bool {
+	var rerr awserr.RequestFailure
+	if !errors.As(err, &rerr) {
+		return false
+	}
+
+	return rerr.StatusCode() == http.StatusPreconditionFailed || rerr.StatusCode() == http.StatusConflict
+}
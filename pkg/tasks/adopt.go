@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/clusterimport"
+	"k8c.io/kubeone/pkg/state"
+)
+
+// verifyAdoption verifies the cluster built by kubeadm matches the manifest
+// closely enough to be managed by KubeOne without recreating the control
+// plane
+func verifyAdoption(s *state.State) error {
+	if !s.LiveCluster.IsProvisioned() {
+		return errors.New("no initialized control plane host found, use apply to provision the cluster")
+	}
+
+	s.Logger.Infoln("Verifying the cluster layout...")
+
+	in, err := clusterimport.Collect(s.Context, s.DynamicClient)
+	if err != nil {
+		return err
+	}
+
+	if in.Info != nil {
+		s.Logger.Warnf("The cluster is already managed by KubeOne %s.", in.Info.KubeOneVersion)
+	}
+
+	live, warnings, err := clusterimport.Build(*in, s.Cluster.Name, clusterimport.HostDefaults{})
+	if err != nil {
+		return errors.Wrap(err, "failed to read the cluster layout")
+	}
+	for _, warning := range warnings {
+		s.Logger.Debugln(warning)
+	}
+
+	if diffs := clusterimport.Diff(s.Cluster, live); len(diffs) > 0 {
+		return errors.Errorf("the manifest doesn't match the cluster:\n- %s", strings.Join(diffs, "\n- "))
+	}
+
+	for _, host := range s.LiveCluster.ControlPlane {
+		if !host.IsInCluster {
+			return errors.Errorf("control plane host %q is not a node of the cluster", host.Config.Hostname)
+		}
+	}
+
+	return nil
+}
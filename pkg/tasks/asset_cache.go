@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+)
+
+func ensureAssetCache(s *state.State) error {
+	host, err := s.Cluster.AssetCacheHost()
+	if err != nil {
+		return err
+	}
+
+	return s.RunTaskOnNodes([]kubeoneapi.HostConfig{host}, ensureAssetCacheOnNode, state.RunSequentially)
+}
+
+func ensureAssetCacheOnNode(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	s.Logger.WithField("node", node.PublicAddress).Info("Configuring asset cache...")
+
+	cmd, err := scripts.AssetCache(s.Cluster.AssetConfiguration, node.PrivateAddress)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.Runner.RunRaw(cmd)
+
+	return err
+}
+
+func assetCacheEnabled(s *state.State) bool {
+	return s.Cluster.AssetConfiguration.Cache != nil
+}
@@ -0,0 +1,547 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/certificate"
+	"k8c.io/kubeone/pkg/certificate/carotation"
+	"k8c.io/kubeone/pkg/kubeconfig"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/ssh/sshiofs"
+	"k8c.io/kubeone/pkg/state"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kubeletKubeconfigFile  = "/etc/kubernetes/kubelet.conf"
+	kubeletClientCertFile  = "/var/lib/kubelet/pki/kubelet-client-current.pem"
+	kubeletRotatedCertFile = "/var/lib/kubelet/pki/kubelet-client-ca-rotation.pem"
+	clusterInfoConfigMap   = "cluster-info"
+	rootCAConfigMap        = "kube-root-ca.crt"
+	caRotationReadyTimeout = 5 * time.Minute
+	caRotationPollInterval = 5 * time.Second
+	kubeletRestartTimeout  = 2 * time.Minute
+	caCertFileMode         = 0644
+	privateFileMode        = 0600
+)
+
+// controlPlaneKubeconfigs are the kubeconfig files on the control plane nodes
+// trusting the Kubernetes CA, besides the kubelet.conf. The super-admin.conf
+// exists only since Kubernetes 1.29.
+var controlPlaneKubeconfigs = []string{
+	"/etc/kubernetes/admin.conf",
+	"/etc/kubernetes/super-admin.conf",
+	"/etc/kubernetes/controller-manager.conf",
+	"/etc/kubernetes/scheduler.conf",
+}
+
+// caRotation holds the old and the new CA for the tasks rotating the
+// Kubernetes CA
+type caRotation struct {
+	oldCA carotation.CA
+	newCA carotation.CA
+}
+
+// CARotationPhases returns the phases of the CA rotation left to run, read
+// from the leader. All phases are left if the rotation hasn't started yet.
+func CARotationPhases(s *state.State) ([]carotation.Phase, error) {
+	var phases []carotation.Phase
+
+	err := s.RunTaskOnLeader(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		completed, err := readOptionalFile(conn, carotation.PhaseFile)
+		if err != nil {
+			return err
+		}
+
+		phases, err = carotation.RemainingPhases(string(completed))
+
+		return err
+	})
+
+	return phases, err
+}
+
+// WithCARotation runs the given phases of the Kubernetes CA rotation
+func WithCARotation(t Tasks, phases []carotation.Phase) Tasks {
+	r := &caRotation{}
+
+	rotation := Tasks{
+		{Fn: kubeconfig.BuildKubernetesClientset, ErrMsg: "failed to build kubernetes clientset"},
+		{
+			Fn:          r.prepare,
+			ErrMsg:      "failed to prepare the CA rotation",
+			Scope:       ScopeControlPlane,
+			Description: "generate the new CA and keep it along the old one on the control plane nodes",
+		},
+	}
+
+	for _, phase := range phases {
+		rotation = append(rotation, r.phaseTasks(phase)...)
+	}
+
+	rotation = append(rotation, Task{Fn: saveKubeconfig, ErrMsg: "failed to save kubeconfig to the local machine"})
+
+	return t.append(rotation...)
+}
+
+func (r *caRotation) phaseTasks(phase carotation.Phase) Tasks {
+	distribute := Task{
+		Fn:     r.distribute(phase),
+		ErrMsg: "failed to distribute the CA",
+		Scope:  ScopeAllNodes,
+	}
+
+	switch phase {
+	case carotation.PhaseTrust:
+		distribute.Description = "distribute the bundle of the old and the new CA to all nodes"
+
+		return Tasks{
+			distribute,
+			{
+				Fn:          restartKubeControlPlane,
+				ErrMsg:      "failed to restart the control plane components",
+				Scope:       ScopeControlPlane,
+				Description: "restart the control plane components to trust the new CA",
+			},
+			{
+				Fn:          restartKubelets,
+				ErrMsg:      "failed to restart kubelets",
+				Scope:       ScopeAllNodes,
+				Description: "restart kubelets to trust the new CA",
+			},
+			{
+				Fn:          r.restartKubeSystemWorkloads,
+				ErrMsg:      "failed to restart the kube-system workloads",
+				Description: "restart the kube-system Deployments and DaemonSets once the new CA is published to the pods",
+			},
+			{
+				Fn: func(s *state.State) error {
+					return rolloutRestartMachineDeployments(s, nil)
+				},
+				ErrMsg:      "failed to rollout restart MachineDeployments",
+				Description: "replace the Machines of all MachineDeployments, so they join the cluster trusting the bundle of the old and the new CA",
+				Predicate:   func(s *state.State) bool { return s.Cluster.MachineController.Deploy },
+			},
+			r.completePhase(phase),
+		}
+	case carotation.PhaseReissue:
+		distribute.Description = "make the new CA sign the certificates"
+
+		return Tasks{
+			distribute,
+			{
+				Fn: func(s *state.State) error {
+					return runOnLeaderFirst(s, r.reissueControlPlaneCerts)
+				},
+				ErrMsg:      "failed to reissue the control plane certificates",
+				Scope:       ScopeControlPlane,
+				Description: "reissue the control plane certificates, including the API server serving certificate, and restart the control plane components",
+			},
+			{
+				Fn: func(s *state.State) error {
+					return s.RunTaskOnAllNodes(r.reissueKubeletCert, state.RunSequentially)
+				},
+				ErrMsg:      "failed to reissue the kubelet client certificates",
+				Scope:       ScopeAllNodes,
+				Description: "reissue the kubelet client certificates and restart kubelets",
+			},
+			{
+				Fn: func(s *state.State) error {
+					return rolloutRestartMachineDeployments(s, nil)
+				},
+				ErrMsg:      "failed to rollout restart MachineDeployments",
+				Description: "replace the Machines of all MachineDeployments, so their certificates are signed by the new CA",
+				Predicate:   func(s *state.State) bool { return s.Cluster.MachineController.Deploy },
+			},
+			r.completePhase(phase),
+		}
+	}
+
+	distribute.Description = "remove the old CA from all nodes"
+
+	return Tasks{
+		distribute,
+		{
+			Fn:          restartKubeControlPlane,
+			ErrMsg:      "failed to restart the control plane components",
+			Scope:       ScopeControlPlane,
+			Description: "restart the control plane components to stop trusting the old CA",
+		},
+		{
+			Fn:          restartKubelets,
+			ErrMsg:      "failed to restart kubelets",
+			Scope:       ScopeAllNodes,
+			Description: "restart kubelets to stop trusting the old CA",
+		},
+		{
+			Fn: func(s *state.State) error {
+				return s.RunTaskOnControlPlane(func(s *state.State, _ *kubeoneapi.HostConfig, _ ssh.Connection) error {
+					_, _, err := s.Runner.RunRaw(fmt.Sprintf("sudo rm -rf %s", carotation.Dir))
+					return err
+				}, state.RunParallel)
+			},
+			ErrMsg:      "failed to remove the CA rotation files",
+			Scope:       ScopeControlPlane,
+			Description: "remove the old CA from the control plane nodes",
+		},
+	}
+}
+
+// prepare loads the old and the new CA of the started rotation from the
+// leader, or generates the new CA, and keeps both on all control plane nodes
+func (r *caRotation) prepare(s *state.State) error {
+	err := s.RunTaskOnLeader(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		newCert, err := readOptionalFile(conn, carotation.NewCertFile)
+		if err != nil {
+			return err
+		}
+
+		if newCert != nil {
+			s.Logger.Info("Resuming the CA rotation...")
+
+			return r.load(conn)
+		}
+
+		sshfs := sshiofs.New(conn)
+		if r.oldCA.Cert, err = fs.ReadFile(sshfs, certificate.KubernetesCACertPath); err != nil {
+			return err
+		}
+		if r.oldCA.Key, err = fs.ReadFile(sshfs, certificate.KubernetesCAKeyPath); err != nil {
+			return err
+		}
+
+		// the bundle is left by a rotation done outside of KubeOne
+		if bytes.Count(r.oldCA.Cert, []byte("BEGIN CERTIFICATE")) > 1 {
+			return errors.Errorf("%s contains more than one certificate", certificate.KubernetesCACertPath)
+		}
+
+		s.Logger.Info("Generating the new CA...")
+		r.newCA.Cert, r.newCA.Key, err = certificate.NewKubernetesCA()
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, _, err = r.newCA.Parse(); err != nil {
+		return err
+	}
+
+	return s.RunTaskOnControlPlane(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		files := map[string][]byte{
+			carotation.OldCertFile: r.oldCA.Cert,
+			carotation.OldKeyFile:  r.oldCA.Key,
+			carotation.NewCertFile: r.newCA.Cert,
+			carotation.NewKeyFile:  r.newCA.Key,
+		}
+
+		for name, content := range files {
+			if err := uploadFile(conn, name, content, privateFileMode); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, state.RunParallel)
+}
+
+func (r *caRotation) load(conn ssh.Connection) error {
+	sshfs := sshiofs.New(conn)
+	files := map[string]*[]byte{
+		carotation.OldCertFile: &r.oldCA.Cert,
+		carotation.OldKeyFile:  &r.oldCA.Key,
+		carotation.NewCertFile: &r.newCA.Cert,
+		carotation.NewKeyFile:  &r.newCA.Key,
+	}
+
+	for name, content := range files {
+		buf, err := fs.ReadFile(sshfs, name)
+		if err != nil {
+			return err
+		}
+		*content = buf
+	}
+
+	return nil
+}
+
+// distribute writes the CA files and the CA trusted by the kubeconfig files
+// of the phase to all nodes, and to the cluster-info ConfigMap used by the
+// joining nodes
+func (r *caRotation) distribute(phase carotation.Phase) func(*state.State) error {
+	return func(s *state.State) error {
+		cert, key := carotation.Files(phase, r.oldCA, r.newCA)
+
+		s.Logger.Infof("Distributing the CA files of the %s phase...", phase)
+
+		err := s.RunTaskOnControlPlane(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+			if err := uploadFile(conn, certificate.KubernetesCAKeyPath, key, privateFileMode); err != nil {
+				return err
+			}
+
+			return writeTrustedCA(conn, cert, append([]string{kubeletKubeconfigFile}, controlPlaneKubeconfigs...))
+		}, state.RunParallel)
+		if err != nil {
+			return err
+		}
+
+		err = s.RunTaskOnStaticWorkers(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+			return writeTrustedCA(conn, cert, []string{kubeletKubeconfigFile})
+		}, state.RunParallel)
+		if err != nil {
+			return err
+		}
+
+		return updateClusterInfo(s, cert)
+	}
+}
+
+// writeTrustedCA writes the ca.crt and sets the CA trusted by the given
+// kubeconfig files, skipping the files that don't exist
+func writeTrustedCA(conn ssh.Connection, cert []byte, kubeconfigs []string) error {
+	if err := uploadFile(conn, certificate.KubernetesCACertPath, cert, caCertFileMode); err != nil {
+		return err
+	}
+
+	for _, name := range kubeconfigs {
+		buf, err := readOptionalFile(conn, name)
+		if err != nil {
+			return err
+		}
+		if buf == nil {
+			continue
+		}
+
+		if buf, err = carotation.SetKubeconfigCA(buf, cert); err != nil {
+			return errors.Wrapf(err, "failed to update %s", name)
+		}
+
+		if err = uploadFile(conn, name, buf, privateFileMode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateClusterInfo sets the CA trusted by the kubeconfig in the cluster-info
+// ConfigMap. The bootstrap signer re-signs it for the bootstrap tokens.
+func updateClusterInfo(s *state.State, cert []byte) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := corev1.ConfigMap{}
+		key := dynclient.ObjectKey{Name: clusterInfoConfigMap, Namespace: metav1.NamespacePublic}
+		if err := s.DynamicClient.Get(s.Context, key, &cm); err != nil {
+			return errors.Wrap(err, "failed to get cluster-info ConfigMap")
+		}
+
+		buf, err := carotation.SetKubeconfigCA([]byte(cm.Data["kubeconfig"]), cert)
+		if err != nil {
+			return errors.Wrap(err, "failed to update cluster-info kubeconfig")
+		}
+		cm.Data["kubeconfig"] = string(buf)
+
+		return s.DynamicClient.Update(s.Context, &cm)
+	})
+}
+
+// reissueControlPlaneCerts renews the kubeadm certificates, signed by the
+// new CA, and restarts the control plane components
+func (r *caRotation) reissueControlPlaneCerts(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	if err := renewKubeadmCerts(s, node); err != nil {
+		return err
+	}
+
+	// kubeadm writes only the CA signing the certificates to the renewed
+	// kubeconfig files, the followers still serve the certificates signed
+	// by the old one
+	cert, _ := carotation.Files(carotation.PhaseReissue, r.oldCA, r.newCA)
+	if err := writeTrustedCA(conn, cert, controlPlaneKubeconfigs); err != nil {
+		return err
+	}
+
+	return restartControlPlaneComponents(s, node, kubeControlPlaneComponents)
+}
+
+// reissueKubeletCert replaces the kubelet client certificate with the one
+// signed by the new CA. kubelet rotates it using the CertificateSigningRequests
+// afterwards.
+func (r *caRotation) reissueKubeletCert(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	s.Logger.WithField("node", node.PublicAddress).Info("Reissuing kubelet client certificate...")
+
+	caCert, caKey, err := r.newCA.Parse()
+	if err != nil {
+		return err
+	}
+
+	certPEM, keyPEM, err := certificate.NewSignedClientCert("system:node:"+node.Hostname, []string{"system:nodes"}, caKey, caCert)
+	if err != nil {
+		return err
+	}
+
+	if err = uploadFile(conn, kubeletRotatedCertFile, carotation.Bundle(certPEM, keyPEM), privateFileMode); err != nil {
+		return err
+	}
+
+	if _, _, err = s.Runner.RunRaw(fmt.Sprintf("sudo ln -sf %s %s", kubeletRotatedCertFile, kubeletClientCertFile)); err != nil {
+		return err
+	}
+
+	return restartKubeletOnNode(s, node, conn)
+}
+
+// restartKubeSystemWorkloads waits for the controller-manager to publish the
+// new CA to the pods, and restarts the kube-system Deployments and DaemonSets,
+// as the Kubernetes clients load the CA only on startup
+func (r *caRotation) restartKubeSystemWorkloads(s *state.State) error {
+	s.Logger.Info("Waiting for the new CA to be published to the pods...")
+
+	err := wait.PollImmediate(caRotationPollInterval, caRotationReadyTimeout, func() (bool, error) {
+		cm := corev1.ConfigMap{}
+		key := dynclient.ObjectKey{Name: rootCAConfigMap, Namespace: metav1.NamespaceSystem}
+		if err := s.DynamicClient.Get(s.Context, key, &cm); err != nil {
+			s.Logger.Debugf("Failed to get %s ConfigMap: %v", rootCAConfigMap, err)
+			return false, nil
+		}
+		if cm.Data["ca.crt"] == "" {
+			return false, nil
+		}
+
+		return carotation.Contains([]byte(cm.Data["ca.crt"]), r.newCA.Cert)
+	})
+	if err != nil {
+		return errors.Wrap(err, "new CA has not been published to the pods")
+	}
+
+	restartedAt := time.Now().Format(time.RFC3339)
+	restart := func(obj dynclient.Object, template *corev1.PodTemplateSpec) error {
+		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := s.DynamicClient.Get(s.Context, dynclient.ObjectKeyFromObject(obj), obj); err != nil {
+				return err
+			}
+
+			if template.Annotations == nil {
+				template.Annotations = map[string]string{}
+			}
+			template.Annotations[restartedAtAnnotation] = restartedAt
+
+			return s.DynamicClient.Update(s.Context, obj)
+		})
+	}
+
+	deployments := appsv1.DeploymentList{}
+	if err = s.DynamicClient.List(s.Context, &deployments, dynclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return errors.Wrap(err, "failed to list Deployments")
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		s.Logger.Infof("Restarting Deployment %q...", deployment.Name)
+		if err = restart(deployment, &deployment.Spec.Template); err != nil {
+			return errors.Wrapf(err, "failed to restart Deployment %s", deployment.Name)
+		}
+	}
+
+	daemonSets := appsv1.DaemonSetList{}
+	if err = s.DynamicClient.List(s.Context, &daemonSets, dynclient.InNamespace(metav1.NamespaceSystem)); err != nil {
+		return errors.Wrap(err, "failed to list DaemonSets")
+	}
+
+	for i := range daemonSets.Items {
+		daemonSet := &daemonSets.Items[i]
+		s.Logger.Infof("Restarting DaemonSet %q...", daemonSet.Name)
+		if err = restart(daemonSet, &daemonSet.Spec.Template); err != nil {
+			return errors.Wrapf(err, "failed to restart DaemonSet %s", daemonSet.Name)
+		}
+	}
+
+	s.Logger.Warn("Restart the workloads talking to the API server in the other namespaces before running the next phase")
+
+	return nil
+}
+
+// completePhase records the completed phase on all control plane nodes, so
+// the interrupted rotation resumes with the next one
+func (r *caRotation) completePhase(phase carotation.Phase) Task {
+	return Task{
+		Fn: func(s *state.State) error {
+			return s.RunTaskOnControlPlane(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+				return uploadFile(conn, carotation.PhaseFile, []byte(phase), privateFileMode)
+			}, state.RunParallel)
+		},
+		ErrMsg:      "failed to record the completed CA rotation phase",
+		Scope:       ScopeControlPlane,
+		Description: fmt.Sprintf("record the completed %s phase", phase),
+	}
+}
+
+func restartKubeControlPlane(s *state.State) error {
+	return s.RunTaskOnControlPlane(func(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		return restartControlPlaneComponents(s, node, kubeControlPlaneComponents)
+	}, state.RunSequentially)
+}
+
+func restartKubelets(s *state.State) error {
+	return s.RunTaskOnAllNodes(restartKubeletOnNode, state.RunSequentially)
+}
+
+// restartKubeletOnNode restarts kubelet and waits for it to run
+func restartKubeletOnNode(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	s.Logger.WithField("node", node.PublicAddress).Info("Restarting kubelet...")
+
+	if _, _, err := s.Runner.RunRaw("sudo systemctl restart kubelet"); err != nil {
+		return errors.Wrap(err, "failed to restart kubelet")
+	}
+
+	return wait.PollImmediate(caRotationPollInterval, kubeletRestartTimeout, func() (bool, error) {
+		kubeletStatus, err := systemdStatus(conn, "kubelet")
+		if err != nil {
+			return false, err
+		}
+
+		return kubeletStatus&state.SystemDStatusRunning != 0 && kubeletStatus&state.SystemDStatusRestarting == 0, nil
+	})
+}
+
+// readOptionalFile returns the content of the file, or nil if it doesn't
+// exist
+func readOptionalFile(conn ssh.Connection, name string) ([]byte, error) {
+	buf, err := fs.ReadFile(sshiofs.New(conn), name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	return buf, err
+}
+
+func uploadFile(conn ssh.Connection, name string, content []byte, mode fs.FileMode) error {
+	return ssh.Upload(conn, bytes.NewReader(content), name, mode)
+}
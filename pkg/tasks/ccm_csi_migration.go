@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
@@ -40,6 +41,12 @@ import (
 
 const (
 	provisionedByAnnotation            = "pv.kubernetes.io/provisioned-by"
+	provisionedByAWSInTreeEBS          = "kubernetes.io/aws-ebs"
+	provisionedByAWSCSIEBS             = "ebs.csi.aws.com"
+	provisionedByAzureInTreeDisk       = "kubernetes.io/azure-disk"
+	provisionedByAzureCSIDisk          = "disk.csi.azure.com"
+	provisionedByAzureInTreeFile       = "kubernetes.io/azure-file"
+	provisionedByAzureCSIFile          = "file.csi.azure.com"
 	provisionedByOpenStackInTreeCinder = "kubernetes.io/cinder"
 	provisionedByOpenStackCSICinder    = "cinder.csi.openstack.org"
 )
@@ -56,6 +63,17 @@ func validateExternalCloudProviderConfig(s *state.State) error {
 	} else if s.LiveCluster.CCMStatus.ExternalCCMDeployed && !s.CCMMigrationComplete {
 		return errors.New("the ccm/csi migration is currently in progress, run command with --complete to finish it")
 	}
+	if s.Cluster.CloudProvider.Azure != nil {
+		// The Azure File CSI migration is available since Kubernetes 1.21
+		v, err := semver.NewVersion(s.Cluster.Versions.Kubernetes)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse kubernetes version")
+		}
+		lessThan21, _ := semver.NewConstraint("< 1.21.0")
+		if lessThan21.Check(v) {
+			return errors.New("the ccm/csi migration for azure requires kubernetes 1.21 or newer")
+		}
+	}
 	if s.Cluster.CloudProvider.Vsphere != nil && s.Cluster.CloudProvider.CSIConfig == "" {
 		return errors.New("the ccm/csi migration for vsphere requires providing csi configuration using .cloudProvider.csiConfig field")
 	}
@@ -144,14 +162,22 @@ func updateKubeletConfigInternal(s *state.State, node *kubeoneapi.HostConfig, co
 
 	drainer := nodeutils.NewDrainer(s.RESTConfig, logger)
 
-	logger.Infoln("Cordoning node...")
-	if err := drainer.Cordon(s.Context, node.Hostname, true); err != nil {
-		return errors.Wrap(err, "failed to cordon follower control plane node")
+	drain, err := s.ConfirmStep("drain the node %s", node.Hostname)
+	if err != nil {
+		return err
 	}
+	if drain {
+		logger.Infoln("Cordoning node...")
+		if err := drainer.Cordon(s.Context, node.Hostname, true); err != nil {
+			return errors.Wrap(err, "failed to cordon follower control plane node")
+		}
 
-	logger.Infoln("Draining node...")
-	if err := drainer.Drain(s.Context, node.Hostname); err != nil {
-		return errors.Wrap(err, "failed to drain follower control plane node")
+		logger.Infoln("Draining node...")
+		if err := drainer.Drain(s.Context, node.Hostname); err != nil {
+			return errors.Wrap(err, "failed to drain follower control plane node")
+		}
+	} else {
+		logger.Warnln("Skipping draining...")
 	}
 
 	cmd, err := scripts.CCMMigrationUpdateKubeletConfig(s.WorkDir, node.ID, s.KubeadmVerboseFlag())
@@ -181,9 +207,11 @@ func updateKubeletConfigInternal(s *state.State, node *kubeoneapi.HostConfig, co
 		return err
 	}
 
-	logger.Infoln("Uncordoning node...")
-	if err := drainer.Cordon(s.Context, node.Hostname, false); err != nil {
-		return errors.Wrap(err, "failed to uncordon follower control plane node")
+	if drain {
+		logger.Infoln("Uncordoning node...")
+		if err := drainer.Cordon(s.Context, node.Hostname, false); err != nil {
+			return errors.Wrap(err, "failed to uncordon follower control plane node")
+		}
 	}
 
 	return nil
@@ -245,11 +273,30 @@ func waitForStaticPodReady(s *state.State, timeout time.Duration, staticPodName,
 }
 
 func migrateOpenStackPVs(s *state.State) error {
+	return migratePVsProvisioner(s, "OpenStack", provisionedByOpenStackInTreeCinder, provisionedByOpenStackCSICinder)
+}
+
+func migrateAWSPVs(s *state.State) error {
+	return migratePVsProvisioner(s, "AWS", provisionedByAWSInTreeEBS, provisionedByAWSCSIEBS)
+}
+
+func migrateAzurePVs(s *state.State) error {
+	if err := migratePVsProvisioner(s, "Azure Disk", provisionedByAzureInTreeDisk, provisionedByAzureCSIDisk); err != nil {
+		return err
+	}
+
+	return migratePVsProvisioner(s, "Azure File", provisionedByAzureInTreeFile, provisionedByAzureCSIFile)
+}
+
+// migratePVsProvisioner patches the provisioned-by annotation of the
+// PersistentVolumes provisioned by the in-tree volume plugin to point to the
+// CSI driver
+func migratePVsProvisioner(s *state.State, providerName, inTreeProvisioner, csiProvisioner string) error {
 	if s.DynamicClient == nil {
 		return errors.New("dynamic client is not initialized")
 	}
 
-	s.Logger.Infof("Patching OpenStack PersistentVolumes with annotation \"%s=%s\"...", provisionedByAnnotation, provisionedByOpenStackCSICinder)
+	s.Logger.Infof("Patching %s PersistentVolumes with annotation \"%s=%s\"...", providerName, provisionedByAnnotation, csiProvisioner)
 
 	pvList := corev1.PersistentVolumeList{}
 	if err := s.DynamicClient.List(s.Context, &pvList, &client.ListOptions{}); err != nil {
@@ -257,19 +304,39 @@ func migrateOpenStackPVs(s *state.State) error {
 	}
 
 	for i, pv := range pvList.Items {
-		if pv.Annotations[provisionedByAnnotation] == provisionedByOpenStackInTreeCinder {
+		if pv.Annotations[provisionedByAnnotation] == inTreeProvisioner {
 			if s.Verbose {
 				s.Logger.Debugf("Patching PersistentVolume \"%s/%s\"...", pv.Namespace, pv.Name)
 			}
 
 			oldPv := pv.DeepCopy()
-			pv.Annotations[provisionedByAnnotation] = provisionedByOpenStackCSICinder
+			pv.Annotations[provisionedByAnnotation] = csiProvisioner
 
 			if err := s.DynamicClient.Patch(s.Context, &pvList.Items[i], client.MergeFrom(oldPv)); err != nil {
-				return errors.Wrapf(err, "failed to patch persistnetvolume %q with annotation \"%s=%s\"", pv.Name, provisionedByAnnotation, provisionedByOpenStackCSICinder)
+				return errors.Wrapf(err, "failed to patch persistnetvolume %q with annotation \"%s=%s\"", pv.Name, provisionedByAnnotation, csiProvisioner)
 			}
 		}
 	}
 
 	return nil
 }
+
+// ccmMigrationEvent returns the task recording an Event about the phase of
+// the CCM/CSI migration. The message is formatted with the name of the
+// operation, as the migration is run in two steps.
+func ccmMigrationEvent(reason, message, description string) Task {
+	return Task{
+		Fn: func(s *state.State) error {
+			operation := "migration"
+			if s.CCMMigrationComplete {
+				operation = "migration completion"
+			}
+
+			s.RecordClusterEvent(reason, message, operation)
+
+			return nil
+		},
+		ErrMsg:      "failed to record CCM/CSI migration event",
+		Description: description,
+	}
+}
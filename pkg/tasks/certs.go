@@ -20,6 +20,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"io/fs"
+	"sync"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
@@ -28,44 +29,189 @@ import (
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/certificate/cabundle"
 	"k8c.io/kubeone/pkg/clientutil"
+	"k8c.io/kubeone/pkg/clusterstatus/certstatus"
 	"k8c.io/kubeone/pkg/kubeconfig"
 	"k8c.io/kubeone/pkg/scripts"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/ssh/sshiofs"
 	"k8c.io/kubeone/pkg/state"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const etcdServerCertFile = "/etc/kubernetes/pki/etcd/server.crt"
+
 func renewControlPlaneCerts(s *state.State) error {
 	if !s.ForceUpgrade {
 		s.Logger.Warn("Your control-plane certificates are about to expire in less then 90 days")
-		s.Logger.Warn("To renew them without changing kubernetes version run `kubeone apply --force-upgrade`")
+		s.Logger.Warn("To renew them without changing kubernetes version run `kubeone apply --force-upgrade` or `kubeone certs renew`")
 		return nil
 	}
 	s.Logger.Warn("Your control-plane certificates are about to expire in less then 90 days")
 	s.Logger.Warn("Force renewing Kubernetes certificates")
 
+	return renewCertificates(s)
+}
+
+// renewCertificates renews the certificates managed by kubeadm one control
+// plane node at a time, starting with the leader, and restarts the control
+// plane components so they load the renewed certificates
+func renewCertificates(s *state.State) error {
+	return runOnLeaderFirst(s, renewCertificatesOnNode)
+}
+
+// runOnLeaderFirst runs the task renewing the admin.conf on the leader, and
+// then on the followers one at a time, with the Kubernetes client using the
+// renewed admin.conf
+func runOnLeaderFirst(s *state.State, task state.NodeTask) error {
+	if err := s.RunTaskOnLeader(task); err != nil {
+		return err
+	}
+
 	// /etc/kubernetes/admin.conf will be changed after certificates renew, so we have to initialize client again
 	s.Logger.Infoln("Resetting Kubernetes clientset...")
 	s.DynamicClient = nil
+	if err := kubeconfig.BuildKubernetesClientset(s); err != nil {
+		return err
+	}
+
+	return s.RunTaskOnFollowers(task, state.RunSequentially)
+}
+
+func renewCertificatesOnNode(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	if err := renewKubeadmCerts(s, node); err != nil {
+		return err
+	}
+
+	return restartControlPlaneComponents(s, node, controlPlaneComponents(s))
+}
+
+// renewKubeadmCerts renews all certificates managed by kubeadm on the node
+func renewKubeadmCerts(s *state.State, node *kubeoneapi.HostConfig) error {
+	s.Logger.WithField("node", node.PublicAddress).Info("Renewing certificates...")
+
+	alpha := false
+	if s.LiveCluster != nil && s.LiveCluster.ExpectedVersion != nil {
+		lessThen120, _ := semver.NewConstraint("< 1.20")
+		alpha = lessThen120.Check(s.LiveCluster.ExpectedVersion)
+	}
 
-	renewCmd := "sudo kubeadm alpha certs renew all"
-	greaterThen120, _ := semver.NewConstraint(">=1.20")
-	if greaterThen120.Check(s.LiveCluster.ExpectedVersion) {
-		renewCmd = "sudo kubeadm certs renew all"
+	cmd, err := scripts.KubeadmCertsRenew(s.KubeadmVerboseFlag(), alpha)
+	if err != nil {
+		return err
 	}
 
-	err := s.RunTaskOnControlPlane(
-		func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
-			_, _, err := s.Runner.RunRaw(renewCmd)
+	_, _, err = s.Runner.RunRaw(cmd)
+
+	return err
+}
+
+// restartControlPlaneComponents restarts the given control plane components
+// of the node one at a time, waiting for each of them to become ready
+func restartControlPlaneComponents(s *state.State, node *kubeoneapi.HostConfig, components []string) error {
+	logger := s.Logger.WithField("node", node.PublicAddress)
+
+	if node.IsLeader {
+		// the client of the parent state is reset once the leader is done,
+		// this one is used to wait for the restarted components below
+		s.DynamicClient = nil
+		if err := kubeconfig.BuildKubernetesClientset(s); err != nil {
 			return err
-		},
-		state.RunParallel,
+		}
+	}
+
+	timeout := 5 * time.Minute
+	for _, component := range components {
+		logger.Infof("Restarting %s...", component)
+
+		cmd, err := scripts.RestartStaticPod(component)
+		if err != nil {
+			return err
+		}
+
+		if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+			return errors.Wrapf(err, "failed to restart %s", component)
+		}
+
+		logger.Infof("Waiting up to %s for %s to become ready...", timeout, component)
+		if component == "etcd" {
+			err = waitForEtcdMemberHealthy(s, timeout, *node)
+		} else {
+			err = waitForStaticPodReady(s, timeout, component+"-"+node.Hostname, metav1.NamespaceSystem)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "%s failed to become ready", component)
+		}
+	}
+
+	return nil
+}
+
+// kubeControlPlaneComponents are the static pods of the Kubernetes control
+// plane, in the order they are restarted
+var kubeControlPlaneComponents = []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler"}
+
+// controlPlaneComponents returns the static pods using the certificates
+// renewed by kubeadm, in the order they are restarted
+func controlPlaneComponents(s *state.State) []string {
+	if s.Cluster.LocalEtcd() {
+		return append([]string{"etcd"}, kubeControlPlaneComponents...)
+	}
+
+	return kubeControlPlaneComponents
+}
+
+// CertificatesStatus reads the expiration of the certificates managed by
+// kubeadm on all control plane nodes. The certificates are reported as
+// expiring soon if they expire within the warnWithin period.
+func CertificatesStatus(s *state.State, warnWithin time.Duration) (certstatus.Report, error) {
+	var (
+		lock     sync.Mutex
+		statuses = map[string][]certstatus.Status{}
+		now      = time.Now()
 	)
+
+	err := s.RunTaskOnControlPlane(func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		nodeStatuses := []certstatus.Status{}
+		sshfs := sshiofs.New(conn)
+
+		for _, cert := range certstatus.KubeadmCertificates(s.Cluster.LocalEtcd()) {
+			buf, err := fs.ReadFile(sshfs, cert.Path)
+			if err != nil {
+				if cert.Optional && errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				nodeStatuses = append(nodeStatuses, certstatus.ErrorStatus(node.Hostname, cert, err))
+
+				continue
+			}
+
+			x509Cert, err := cert.Parse(buf)
+			if err != nil {
+				nodeStatuses = append(nodeStatuses, certstatus.ErrorStatus(node.Hostname, cert, err))
+
+				continue
+			}
+
+			nodeStatuses = append(nodeStatuses, certstatus.NewStatus(node.Hostname, cert, x509Cert, now, warnWithin))
+		}
+
+		lock.Lock()
+		statuses[node.PublicAddress] = nodeStatuses
+		lock.Unlock()
+
+		return nil
+	}, state.RunParallel)
 	if err != nil {
-		return err
+		return certstatus.Report{}, err
 	}
 
-	return kubeconfig.BuildKubernetesClientset(s)
+	report := certstatus.Report{}
+	for _, host := range s.Cluster.ControlPlane.Hosts {
+		report.Certificates = append(report.Certificates, statuses[host.PublicAddress]...)
+	}
+
+	return report, nil
 }
 
 func fetchCert(sshfs fs.FS, filename string) (*x509.Certificate, error) {
@@ -95,24 +241,32 @@ func timeBefore(t1 time.Time, t2 time.Time) bool {
 	return t1.Before(t2)
 }
 
-func earliestCertExpiry(conn ssh.Connection) (time.Time, error) {
+// earliestCertExpiry returns the earliest expiry of the certificates
+// generated by kubeadm. The etcd certificates are generated only for the
+// etcd stacked on the control plane nodes.
+func earliestCertExpiry(conn ssh.Connection, localEtcd bool) (time.Time, error) {
 	var (
 		earliestCertExpirationTime time.Time
 
 		certsToCheck = []string{
-			"/etc/kubernetes/pki/apiserver-etcd-client.crt",
 			"/etc/kubernetes/pki/apiserver-kubelet-client.crt",
 			"/etc/kubernetes/pki/apiserver.crt",
 			"/etc/kubernetes/pki/ca.crt",
-			"/etc/kubernetes/pki/etcd/ca.crt",
-			"/etc/kubernetes/pki/etcd/healthcheck-client.crt",
-			"/etc/kubernetes/pki/etcd/peer.crt",
-			"/etc/kubernetes/pki/etcd/server.crt",
 			"/etc/kubernetes/pki/front-proxy-ca.crt",
 			"/etc/kubernetes/pki/front-proxy-client.crt",
 		}
 	)
 
+	if localEtcd {
+		certsToCheck = append(certsToCheck,
+			"/etc/kubernetes/pki/apiserver-etcd-client.crt",
+			"/etc/kubernetes/pki/etcd/ca.crt",
+			"/etc/kubernetes/pki/etcd/healthcheck-client.crt",
+			"/etc/kubernetes/pki/etcd/peer.crt",
+			etcdServerCertFile,
+		)
+	}
+
 	sshfs := sshiofs.New(conn)
 	for _, certName := range certsToCheck {
 		cert, err := fetchCert(sshfs, certName)
@@ -128,6 +282,22 @@ func earliestCertExpiry(conn ssh.Connection) (time.Time, error) {
 	return earliestCertExpirationTime, nil
 }
 
+// etcdServerCertSANs returns the DNS names and the IP addresses of the etcd
+// server certificate
+func etcdServerCertSANs(conn ssh.Connection) ([]string, error) {
+	cert, err := fetchCert(sshiofs.New(conn), etcdServerCertFile)
+	if err != nil {
+		return nil, err
+	}
+
+	sans := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return sans, nil
+}
+
 func ensureCABundleConfigMap(s *state.State) error {
 	if s.DynamicClient == nil {
 		return errors.New("kubernetes client not initialized")
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/state"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	clusterCIDRFlag       = "--cluster-cidr="
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// detectClusterCIDRs returns the distinct cluster CIDRs the
+// kube-controller-manager instances run with
+func detectClusterCIDRs(s *state.State) ([]string, error) {
+	if s.DynamicClient == nil {
+		return nil, errors.New("kubernetes dynamic client is not initialized")
+	}
+
+	pods := corev1.PodList{}
+	err := s.DynamicClient.List(s.Context, &pods, &dynclient.ListOptions{
+		Namespace: metav1.NamespaceSystem,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			"component": "kube-controller-manager",
+		}),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list kube-controller-manager pods")
+	}
+
+	found := map[string]bool{}
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.Containers[0].Command {
+			if strings.HasPrefix(c, clusterCIDRFlag) {
+				found[strings.TrimPrefix(c, clusterCIDRFlag)] = true
+			}
+		}
+	}
+
+	cidrs := []string{}
+	for cidr := range found {
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Strings(cidrs)
+
+	return cidrs, nil
+}
+
+func updateClusterCIDR(s *state.State) error {
+	if err := s.RunTaskOnControlPlane(regenerateControlPlaneManifest("controller-manager"), state.RunSequentially); err != nil {
+		return err
+	}
+
+	return uploadKubeadmConfig(s)
+}
+
+// restartCanal restarts the canal pods after the addon is applied, because
+// flannel reads the pod network configuration only on startup
+func restartCanal(s *state.State) error {
+	if s.DynamicClient == nil {
+		return errors.New("kubernetes client not initialized")
+	}
+
+	s.Logger.Infoln("Restarting canal to pick up the new pod network...")
+
+	ds := &appsv1.DaemonSet{}
+	key := dynclient.ObjectKey{
+		Name:      "canal",
+		Namespace: metav1.NamespaceSystem,
+	}
+
+	if err := s.DynamicClient.Get(s.Context, key, ds); err != nil {
+		return errors.Wrap(err, "failed to get canal daemonset")
+	}
+
+	if ds.Spec.Template.Annotations == nil {
+		ds.Spec.Template.Annotations = map[string]string{}
+	}
+	ds.Spec.Template.Annotations[restartedAtAnnotation] = time.Now().Format(time.RFC3339)
+
+	return errors.Wrap(s.DynamicClient.Update(s.Context, ds), "failed to update canal daemonset")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+
+	"k8c.io/kubeone/pkg/clusterinfo"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/images"
+)
+
+// saveClusterInfo records the KubeOne version, the manifest and the deployed
+// components in the kubeone-info ConfigMap, so later runs and other users
+// can tell how the cluster was provisioned
+func saveClusterInfo(s *state.State) error {
+	return clusterinfo.Save(s.Context, s.DynamicClient, desiredClusterInfo(s))
+}
+
+// AddonsChanges returns the changes of the components versions and the user
+// addons since the last apply, or nil if the provisioned cluster was not
+// applied by a KubeOne release recording them
+func AddonsChanges(s *state.State) []string {
+	applied := &clusterinfo.Info{}
+	if s.LiveCluster.IsProvisioned() {
+		if s.LiveCluster.Info == nil {
+			return nil
+		}
+		applied = s.LiveCluster.Info
+	}
+
+	return clusterinfo.AddonsChanges(applied, desiredClusterInfo(s))
+}
+
+// desiredClusterInfo returns the Info recorded after applying the manifest
+func desiredClusterInfo(s *state.State) *clusterinfo.Info {
+	info := &clusterinfo.Info{
+		KubeOneVersion:    s.KubeOneVersion,
+		KubernetesVersion: s.Cluster.Versions.Kubernetes,
+		ManifestHash:      s.ManifestHash,
+		Features:          clusterinfo.EnabledFeatures(s.Cluster.Features),
+		Addons:            s.Images.Tags(images.ListFilterBase),
+		AppliedAt:         time.Now(),
+	}
+
+	if s.Cluster.Addons.Enabled() {
+		for _, addon := range s.Cluster.Addons.Addons {
+			if !addon.Delete {
+				info.UserAddons = append(info.UserAddons, addon.Name)
+			}
+		}
+	}
+
+	return info
+}
+
+// detectClusterInfo reads the kubeone-info ConfigMap and warns if the
+// cluster was last applied from a different manifest or by a newer KubeOne
+func detectClusterInfo(s *state.State) error {
+	info, err := clusterinfo.Load(s.Context, s.DynamicClient)
+	if err != nil || info == nil {
+		return err
+	}
+
+	s.LiveCluster.Lock.Lock()
+	s.LiveCluster.Info = info
+	s.LiveCluster.Lock.Unlock()
+
+	if info.ManifestHash != "" && s.ManifestHash != "" && info.ManifestHash != s.ManifestHash {
+		s.Logger.Warnf("The manifest has changed since the cluster was last applied at %s.", info.AppliedAt.Format(time.RFC3339))
+	}
+
+	applied, err := semver.NewVersion(info.KubeOneVersion)
+	if err != nil {
+		// development builds don't have a semantic version
+		return nil
+	}
+	current, err := semver.NewVersion(s.KubeOneVersion)
+	if err != nil {
+		return nil
+	}
+	if applied.GreaterThan(current) {
+		s.Logger.Warnf("The cluster was last applied by a newer KubeOne %s, but this is KubeOne %s.", info.KubeOneVersion, s.KubeOneVersion)
+	}
+
+	return nil
+}
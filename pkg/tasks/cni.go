@@ -41,6 +41,14 @@ func ensureCNI(s *state.State) error {
 		if err := addons.EnsureAddonByName(s, resources.AddonCNIWeavenet); err != nil {
 			return err
 		}
+	case s.Cluster.ClusterNetwork.CNI.Cilium != nil:
+		if err := addons.EnsureAddonByName(s, resources.AddonCNICilium); err != nil {
+			return err
+		}
+	case s.Cluster.ClusterNetwork.CNI.Calico != nil:
+		if err := addons.EnsureAddonByName(s, resources.AddonCNICalico); err != nil {
+			return err
+		}
 	case s.Cluster.ClusterNetwork.CNI.External != nil:
 		s.Logger.Infoln("External CNI plugin will be used")
 	default:
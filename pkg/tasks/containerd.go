@@ -91,45 +91,15 @@ func migrateToContainerd(s *state.State) error {
 func migrateToContainerdTask(s *state.State, node *kubeone.HostConfig, conn ssh.Connection) error {
 	s.Logger.Info("Migrating container runtime to containerd")
 
-	sshfs := s.Runner.NewFS()
-	f, err := sshfs.Open(kubeadmEnvFlagsFile)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	buf, err := io.ReadAll(f)
-	if err != nil {
-		return err
-	}
-
-	kubeletFlags, err := unmarshalKubeletFlags(buf)
+	_, err := updateKubeletFlags(s, func(kubeletFlags map[string]string) {
+		for k, v := range containerdKubeletFlags {
+			kubeletFlags[k] = v
+		}
+	})
 	if err != nil {
 		return err
 	}
 
-	for k, v := range containerdKubeletFlags {
-		kubeletFlags[k] = v
-	}
-
-	buf = marshalKubeletFlags(kubeletFlags)
-	fw, ok := f.(sshiofs.ExtendedFile)
-	if !ok {
-		return errors.New("file is not writable")
-	}
-
-	if err = fw.Truncate(0); err != nil {
-		return err
-	}
-
-	if _, err = fw.Seek(0, io.SeekStart); err != nil {
-		return err
-	}
-
-	if _, err = io.Copy(fw, bytes.NewBuffer(buf)); err != nil {
-		return err
-	}
-
 	generateContainerdConfig := node.OperatingSystem != kubeone.OperatingSystemNameFlatcar
 	migrateScript, err := scripts.MigrateToContainerd(s.Cluster.RegistryConfiguration.InsecureRegistryAddress(), generateContainerdConfig)
 	if err != nil {
@@ -181,9 +151,57 @@ func migrateToContainerdTask(s *state.State, node *kubeone.HostConfig, conn ssh.
 	return err
 }
 
+// updateKubeletFlags updates the kubelet flags in the kubeadm-flags.env file
+// of the node and returns whether the flags were changed. Flags are keyed
+// with the leading dashes.
+func updateKubeletFlags(s *state.State, update func(kubeletFlags map[string]string)) (bool, error) {
+	sshfs := s.Runner.NewFS()
+	f, err := sshfs.Open(kubeadmEnvFlagsFile)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	buf, err := io.ReadAll(f)
+	if err != nil {
+		return false, err
+	}
+
+	kubeletFlags, err := unmarshalKubeletFlags(buf)
+	if err != nil {
+		return false, err
+	}
+
+	original := marshalKubeletFlags(kubeletFlags)
+	update(kubeletFlags)
+	newBuf := marshalKubeletFlags(kubeletFlags)
+	if bytes.Equal(original, newBuf) {
+		return false, nil
+	}
+
+	fw, ok := f.(sshiofs.ExtendedFile)
+	if !ok {
+		return false, errors.New("file is not writable")
+	}
+
+	if err = fw.Truncate(0); err != nil {
+		return false, err
+	}
+
+	if _, err = fw.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	if _, err = io.Copy(fw, bytes.NewBuffer(newBuf)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func unmarshalKubeletFlags(buf []byte) (map[string]string, error) {
 	// throw away KUBELET_KUBEADM_ARGS=
-	s1 := strings.SplitN(string(buf), "=", 2)
+	s1 := strings.SplitN(strings.TrimSpace(string(buf)), "=", 2)
 	if len(s1) != 2 {
 		return nil, errors.New("can't parse: wrong split length")
 	}
@@ -193,7 +211,7 @@ func unmarshalKubeletFlags(buf []byte) (map[string]string, error) {
 	kubeletflagsMap := map[string]string{}
 
 	for _, flg := range flagsvalues {
-		fl := strings.Split(flg, "=")
+		fl := strings.SplitN(flg, "=", 2)
 		if len(fl) != 2 {
 			return nil, errors.New("wrong split length")
 		}
@@ -67,6 +67,15 @@ func Test_unmarshalKubeletFlags(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "values with equal signs",
+			buf:  []byte("KUBELET_KUBEADM_ARGS=\"--system-reserved=cpu=100m,memory=500Mi --eviction-hard=memory.available<100Mi\"\n"),
+			want: map[string]string{
+				"--system-reserved": "cpu=100m,memory=500Mi",
+				"--eviction-hard":   "memory.available<100Mi",
+			},
+			wantErr: false,
+		},
 		{
 			name:    "error1",
 			buf:     []byte{},
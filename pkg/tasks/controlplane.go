@@ -17,12 +17,18 @@ limitations under the License.
 package tasks
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
+
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/hooks"
 	"k8c.io/kubeone/pkg/scripts"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func joinControlplaneNode(s *state.State) error {
@@ -43,8 +49,11 @@ func joinControlPlaneNodeInternal(s *state.State, node *kubeoneapi.HostConfig, c
 		return err
 	}
 
-	_, _, err = s.Runner.RunRaw(cmd)
-	return err
+	if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+		return err
+	}
+
+	return runHostScripts(s, node, hooks.HostPostJoin)
 }
 
 func kubeadmCertsExecutor(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
@@ -69,6 +78,56 @@ func initKubernetesLeader(s *state.State) error {
 			return err
 		}
 
+		if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+			return err
+		}
+
+		return runHostScripts(s, node, hooks.HostPostJoin)
+	})
+}
+
+// regenerateControlPlaneManifest returns the task regenerating the static pod
+// manifest of the control plane component, such as apiserver or
+// controller-manager, and waiting for the component to roll-out
+func regenerateControlPlaneManifest(component string) state.NodeTask {
+	return func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		logger := s.Logger.WithField("node", node.PublicAddress)
+		logger.Infof("Regenerating %s manifest...", component)
+
+		cmd, err := scripts.KubeadmControlPlaneManifest(s.WorkDir, node.ID, s.KubeadmVerboseFlag(), component)
+		if err != nil {
+			return err
+		}
+
+		if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+			return err
+		}
+
+		timeout := 30 * time.Second
+		logger.Infof("Waiting %s for Kubelet to roll-out static pods...", timeout)
+		time.Sleep(timeout)
+
+		timeout = 2 * time.Minute
+		logger.Infof("Waiting up to %s for %s to become healthy...", timeout, component)
+		podName := fmt.Sprintf("kube-%s-%s", component, node.Hostname)
+
+		return errors.Wrapf(waitForStaticPodReady(s, timeout, podName, metav1.NamespaceSystem),
+			"%s failed to come up for %s", component, timeout)
+	}
+}
+
+// uploadKubeadmConfig uploads the kubeadm ClusterConfiguration from the
+// leader, so the updated configuration is used when joining and upgrading
+// nodes
+func uploadKubeadmConfig(s *state.State) error {
+	return s.RunTaskOnLeader(func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		s.Logger.WithField("node", node.PublicAddress).Info("Uploading kubeadm configuration...")
+
+		cmd, err := scripts.KubeadmUploadConfig(s.WorkDir, node.ID, s.KubeadmVerboseFlag())
+		if err != nil {
+			return err
+		}
+
 		_, _, err = s.Runner.RunRaw(cmd)
 
 		return err
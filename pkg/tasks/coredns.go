@@ -21,7 +21,10 @@ import (
 
 	"github.com/pkg/errors"
 
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clientutil"
 	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/coredns"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -62,3 +65,81 @@ func patchCoreDNS(s *state.State) error {
 
 	return errors.Wrap(s.DynamicClient.Update(ctx, dep), "failed to update coredns deployment")
 }
+
+// ensureCoreDNSConfig renders the configured hosts entries, rewrite rules,
+// plugins, forwarders, stub domains and the snippet into the kubeadm-managed
+// Corefile. kubeadm may reset the Corefile when upgrading, so the directives
+// are restored on every apply and upgrade. CoreDNS reloads the Corefile on its
+// own.
+func ensureCoreDNSConfig(s *state.State) error {
+	if s.DynamicClient == nil {
+		return errors.New("kubernetes client not initialized")
+	}
+
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{
+		Name:      coredns.ConfigMapName,
+		Namespace: metav1.NamespaceSystem,
+	}
+
+	if err := s.DynamicClient.Get(s.Context, key, cm); err != nil {
+		return errors.Wrap(err, "failed to get coredns configmap")
+	}
+
+	corefile, err := coredns.UpdateCorefile(cm.Data[coredns.CorefileKey], s.Cluster.ClusterNetwork.CoreDNS)
+	if err != nil {
+		return err
+	}
+
+	if corefile == cm.Data[coredns.CorefileKey] {
+		return nil
+	}
+
+	s.Logger.Infoln("Updating CoreDNS configuration...")
+	cm.Data[coredns.CorefileKey] = corefile
+
+	return errors.Wrap(s.DynamicClient.Update(s.Context, cm), "failed to update coredns configmap")
+}
+
+// ensureCoreDNSDeployment scales the kubeadm-managed CoreDNS Deployment to the
+// configured number of replicas, and deploys or removes the CoreDNS
+// PodDisruptionBudget. kubeadm resets the replicas when upgrading, so they are
+// restored on every apply and upgrade.
+func ensureCoreDNSDeployment(s *state.State) error {
+	if s.DynamicClient == nil {
+		return errors.New("kubernetes client not initialized")
+	}
+
+	cfg := s.Cluster.ClusterNetwork.CoreDNS
+	if cfg == nil {
+		cfg = &kubeoneapi.CoreDNSConfig{}
+	}
+
+	if cfg.Replicas != nil {
+		dep := &appsv1.Deployment{}
+		key := client.ObjectKey{
+			Name:      coredns.DeploymentName,
+			Namespace: metav1.NamespaceSystem,
+		}
+
+		if err := s.DynamicClient.Get(s.Context, key, dep); err != nil {
+			return errors.Wrap(err, "failed to get coredns deployment")
+		}
+
+		if dep.Spec.Replicas == nil || *dep.Spec.Replicas != *cfg.Replicas {
+			s.Logger.Infof("Scaling CoreDNS to %d replicas...", *cfg.Replicas)
+			dep.Spec.Replicas = cfg.Replicas
+
+			if err := s.DynamicClient.Update(s.Context, dep); err != nil {
+				return errors.Wrap(err, "failed to update coredns deployment")
+			}
+		}
+	}
+
+	pdb := coredns.PodDisruptionBudget()
+	if !cfg.DeployPodDisruptionBudget {
+		return clientutil.DeleteIfExists(s.Context, s.DynamicClient, pdb)
+	}
+
+	return clientutil.CreateOrUpdate(s.Context, s.DynamicClient, pdb)
+}
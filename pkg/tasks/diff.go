@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clusterdiff"
+	"k8c.io/kubeone/pkg/clusterinfo"
+	"k8c.io/kubeone/pkg/state"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kubeadmConfigMapName           = "kubeadm-config"
+	kubeadmClusterConfigurationKey = "ClusterConfiguration"
+)
+
+// Diff compares the desired state rendered from the manifest to the live
+// cluster and returns the drifted fields of the kubeadm ClusterConfiguration,
+// the addons manifests, and the features and components recorded by the last
+// apply. The desired state is rendered the same way as in the dry-run mode,
+// so the Kubernetes client must be initialized and the hosts probed.
+func Diff(s *state.State) ([]clusterdiff.Drift, error) {
+	applied, err := clusterinfo.Load(s.Context, s.DynamicClient)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeadmConfig := corev1.ConfigMap{}
+	key := dynclient.ObjectKey{Name: kubeadmConfigMapName, Namespace: metav1.NamespaceSystem}
+	if err = s.DynamicClient.Get(s.Context, key, &kubeadmConfig); dynclient.IgnoreNotFound(err) != nil {
+		return nil, errors.Wrapf(err, "failed to get %s ConfigMap", kubeadmConfigMapName)
+	}
+
+	rendered, err := renderDesired(s)
+	if err != nil {
+		return nil, err
+	}
+
+	leader, err := s.Cluster.Leader()
+	if err != nil {
+		return nil, err
+	}
+
+	desiredKubeadm, _ := rendered.Get(fmt.Sprintf("cfg/master_%d.yaml", leader.ID))
+	drifts, err := clusterdiff.Kubeadm(desiredKubeadm, kubeadmConfig.Data[kubeadmClusterConfigurationKey])
+	if err != nil {
+		return nil, err
+	}
+
+	drifts = append(drifts, clusterdiff.ClusterInfo(applied, desiredClusterInfo(s))...)
+
+	for _, name := range rendered.Names() {
+		if !strings.HasPrefix(name, "addons/apply/") {
+			continue
+		}
+
+		manifest, _ := rendered.Get(name)
+		manifestDrifts, err := clusterdiff.Manifest([]byte(manifest), liveObjectGetter(s))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compare %s", name)
+		}
+		drifts = append(drifts, manifestDrifts...)
+	}
+
+	return drifts, nil
+}
+
+// renderDesired renders the desired state as in the dry-run mode. The
+// Kubernetes client is unset while rendering, so nothing is recorded or
+// applied to the cluster.
+func renderDesired(s *state.State) (*state.DryRunOutput, error) {
+	client, liveCluster := s.DynamicClient, s.LiveCluster
+	s.DynamicClient = nil
+	s.DryRunOutput = state.NewDryRunOutput()
+
+	defer func() {
+		s.DynamicClient, s.LiveCluster = client, liveCluster
+		s.DryRunOutput = nil
+	}()
+
+	rendered := s.DryRunOutput
+	if err := DryRun(s, kubeoneapi.OperatingSystemNameUnknown); err != nil {
+		return nil, errors.Wrap(err, "failed to render the desired state")
+	}
+
+	return rendered, nil
+}
+
+// liveObjectGetter returns the GetFunc reading the live objects. Objects of
+// the kinds not served by the cluster are reported as missing.
+func liveObjectGetter(s *state.State) clusterdiff.GetFunc {
+	return func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+
+		err := s.DynamicClient.Get(s.Context, dynclient.ObjectKeyFromObject(obj), live)
+		switch {
+		case k8serrors.IsNotFound(err), meta.IsNoMatchError(err):
+			return nil, nil
+		case err != nil:
+			return nil, err
+		}
+
+		return live, nil
+	}
+}
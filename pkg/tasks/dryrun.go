@@ -0,0 +1,324 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/addons"
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/certificate"
+	"k8c.io/kubeone/pkg/hooks"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/csi"
+	"k8c.io/kubeone/pkg/templates/machinecontroller"
+	"k8c.io/kubeone/pkg/templates/operatingsystemmanager"
+	"k8c.io/kubeone/pkg/templates/resources"
+)
+
+// DryRun renders the scripts, the configuration files and the addons
+// manifests used to provision the cluster and records them to
+// s.DryRunOutput, without connecting to any host.
+//
+// Since hosts are not probed, everything is rendered as for a new cluster.
+// Hosts are assumed to run the given operating system, kubeadm picks the
+// default pause image, and the addons manifests are rendered using a
+// throwaway CA, so the rendered certificates are never the ones deployed.
+func DryRun(s *state.State, osName kubeoneapi.OperatingSystemName) error {
+	if !s.DryRun() {
+		return errors.New("dry-run output is not configured")
+	}
+
+	s.LiveCluster = &state.Cluster{
+		EncryptionConfiguration: &state.EncryptionConfiguration{},
+	}
+
+	for i := range s.Cluster.ControlPlane.Hosts {
+		setDryRunOperatingSystem(&s.Cluster.ControlPlane.Hosts[i], osName)
+	}
+	for i := range s.Cluster.StaticWorkers.Hosts {
+		setDryRunOperatingSystem(&s.Cluster.StaticWorkers.Hosts[i], osName)
+	}
+
+	if err := generateConfigurationFiles(s); err != nil {
+		return errors.Wrap(err, "failed to generate config files")
+	}
+
+	if err := renderKubeadm(s); err != nil {
+		return errors.Wrap(err, "failed to generate kubeadm config files")
+	}
+
+	for _, filename := range s.Configuration.Filenames() {
+		content, err := s.Configuration.Get(filename)
+		if err != nil {
+			return err
+		}
+		s.DryRunOutput.Add(filename, content)
+	}
+
+	if err := dryRunAssetCache(s); err != nil {
+		return errors.Wrap(err, "failed to render asset cache script")
+	}
+
+	for _, node := range s.Cluster.ControlPlane.Hosts {
+		if err := dryRunNode(s, node, fmt.Sprintf("control-plane-%d", node.ID), true); err != nil {
+			return errors.Wrapf(err, "failed to render scripts for control plane node %s", node.PublicAddress)
+		}
+	}
+
+	for _, node := range s.Cluster.StaticWorkers.Hosts {
+		if err := dryRunNode(s, node, fmt.Sprintf("static-worker-%d", node.ID), false); err != nil {
+			return errors.Wrapf(err, "failed to render scripts for static worker node %s", node.PublicAddress)
+		}
+	}
+
+	if err := certificate.GenerateKubernetesCA(s); err != nil {
+		return errors.Wrap(err, "failed to generate throwaway CA")
+	}
+
+	return errors.Wrap(dryRunAddons(s), "failed to render addons")
+}
+
+func setDryRunOperatingSystem(node *kubeoneapi.HostConfig, osName kubeoneapi.OperatingSystemName) {
+	if node.OperatingSystem == kubeoneapi.OperatingSystemNameUnknown {
+		node.SetOperatingSystem(osName)
+	}
+}
+
+func dryRunAssetCache(s *state.State) error {
+	if !assetCacheEnabled(s) {
+		return nil
+	}
+
+	host, err := s.Cluster.AssetCacheHost()
+	if err != nil {
+		return err
+	}
+
+	cmd, err := scripts.AssetCache(s.Cluster.AssetConfiguration, host.PrivateAddress)
+	if err != nil {
+		return err
+	}
+
+	s.DryRunOutput.Add("hosts/asset-cache.sh", scripts.WithEnvironment(cmd, host.Env))
+
+	return nil
+}
+
+// dryRunNode renders the scripts run on the node, in the order they are run
+// when provisioning a new cluster
+func dryRunNode(s *state.State, node kubeoneapi.HostConfig, dir string, controlPlane bool) error {
+	add := func(name, script string) {
+		s.DryRunOutput.Add(path.Join("hosts", dir, name), scripts.WithEnvironment(script, node.Env))
+	}
+
+	addHostScripts := func(point hooks.HostPoint) error {
+		rendered, err := hooks.RenderHostScripts(s.Cluster, &node, point, s.ManifestFilePath)
+		if err != nil {
+			return err
+		}
+		for i, script := range rendered {
+			add(fmt.Sprintf("hooks/%s-%d.sh", point, i), script.Script)
+		}
+
+		return nil
+	}
+
+	if err := addHostScripts(hooks.HostPreProvision); err != nil {
+		return err
+	}
+
+	envCmd, proxyCmd, installCmd, err := prerequisitesScripts(s, node)
+	if err != nil {
+		return err
+	}
+
+	prerequisites := []string{envCmd}
+	if s.Cluster.Proxy.HTTP != "" || s.Cluster.Proxy.HTTPS != "" || s.Cluster.Proxy.NoProxy != "" {
+		prerequisites = append(prerequisites, proxyCmd)
+	}
+	prerequisites = append(prerequisites, installCmd)
+	add("01-prerequisites.sh", strings.Join(prerequisites, "\n"))
+
+	saveCmds, err := saveConfigurationFilesScripts(s)
+	if err != nil {
+		return err
+	}
+	if controlPlane && s.Cluster.Features.KubeVIP.Enabled() {
+		kubeVIPCmd, kerr := kubeVIPScript(s)
+		if kerr != nil {
+			return kerr
+		}
+		saveCmds = append(saveCmds, kubeVIPCmd)
+	}
+	if controlPlane && kmsPluginEnabled(s) {
+		kmsPluginCmd, kerr := kmsPluginScript(s)
+		if kerr != nil {
+			return kerr
+		}
+		saveCmds = append(saveCmds, kmsPluginCmd)
+	}
+	add("02-configuration-files.sh", strings.Join(saveCmds, "\n"))
+
+	var kubeadmCmd string
+	switch {
+	case controlPlane && node.IsLeader:
+		kubeadmCmd, err = scripts.KubeadmInit(s.WorkDir, node.ID, s.KubeadmVerboseFlag(), s.JoinToken, time.Hour.String())
+	case controlPlane:
+		kubeadmCmd, err = scripts.KubeadmJoin(s.WorkDir, node.ID, s.KubeadmVerboseFlag())
+	default:
+		kubeadmCmd, err = scripts.KubeadmJoinWorker(s.WorkDir, node.ID, s.KubeadmVerboseFlag())
+	}
+	if err != nil {
+		return err
+	}
+	add("03-kubeadm.sh", kubeadmCmd)
+
+	if err = addHostScripts(hooks.HostPostJoin); err != nil {
+		return err
+	}
+
+	// the konnectivity-server kubeconfig is signed by the cluster CA, so only
+	// the static pod manifest is rendered
+	if controlPlane && s.Cluster.Features.Konnectivity.Enabled() {
+		konnectivityCmd, err := konnectivityServerScript(s)
+		if err != nil {
+			return err
+		}
+		add("04-konnectivity-server.sh", konnectivityCmd)
+	}
+
+	if hostConfig := s.Cluster.Features.SRIOV.HostConfig(node); hostConfig != nil {
+		sriovCmd, err := scripts.SRIOV(s.Cluster.Features.SRIOV.KernelParameters, hostConfig.PhysicalFunctions)
+		if err != nil {
+			return err
+		}
+		add("04-sriov.sh", sriovCmd)
+	}
+
+	return nil
+}
+
+// dryRunAddons renders the addons applied when provisioning a new cluster.
+// The runKubectlApply and runKubectlDelete functions record the manifests
+// instead of applying them in the dry-run mode.
+func dryRunAddons(s *state.State) error {
+	var embedded []string
+
+	if s.Cluster.Features.Konnectivity.Enabled() {
+		embedded = append(embedded, resources.AddonKonnectivityAgent)
+	}
+
+	if s.Cluster.Features.NodeLocalDNS.Enabled() {
+		embedded = append(embedded, resources.AddonNodeLocalDNS)
+	}
+
+	if s.Cluster.Features.MetricsServer != nil && s.Cluster.Features.MetricsServer.Enable {
+		embedded = append(embedded, resources.AddonMetricsServer)
+	}
+
+	if s.Cluster.Features.SRIOV.Enabled() {
+		embedded = append(embedded, resources.AddonSRIOV)
+	}
+
+	if s.Cluster.Features.Gatekeeper.Enabled() {
+		embedded = append(embedded, resources.AddonGatekeeper)
+		if s.Cluster.Features.Gatekeeper.Config.ConstraintLibrary {
+			embedded = append(embedded, resources.AddonGatekeeperLibrary)
+		}
+	}
+
+	switch {
+	case s.Cluster.ClusterNetwork.CNI.Canal != nil:
+		embedded = append(embedded, resources.AddonCNICanal)
+	case s.Cluster.ClusterNetwork.CNI.WeaveNet != nil:
+		embedded = append(embedded, resources.AddonCNIWeavenet)
+	case s.Cluster.ClusterNetwork.CNI.Cilium != nil:
+		embedded = append(embedded, resources.AddonCNICilium)
+	case s.Cluster.ClusterNetwork.CNI.Calico != nil:
+		embedded = append(embedded, resources.AddonCNICalico)
+	}
+
+	if s.Cluster.CloudProvider.External {
+		switch {
+		case s.Cluster.CloudProvider.AWS != nil:
+			embedded = append(embedded, resources.AddonCCMAws)
+		case s.Cluster.CloudProvider.Azure != nil:
+			embedded = append(embedded, resources.AddonCCMAzure)
+		case s.Cluster.CloudProvider.Hetzner != nil:
+			embedded = append(embedded, resources.AddonCCMHetzner)
+		case s.Cluster.CloudProvider.DigitalOcean != nil:
+			embedded = append(embedded, resources.AddonCCMDigitalOcean)
+		case s.Cluster.CloudProvider.KubeVirt != nil:
+			embedded = append(embedded, resources.AddonCCMKubeVirt)
+		case s.Cluster.CloudProvider.Nutanix != nil:
+			embedded = append(embedded, resources.AddonCCMNutanix)
+		case s.Cluster.CloudProvider.Packet != nil:
+			embedded = append(embedded, resources.AddonCCMPacket)
+		case s.Cluster.CloudProvider.Openstack != nil:
+			embedded = append(embedded, resources.AddonCCMOpenStack)
+		case s.Cluster.CloudProvider.Vsphere != nil:
+			embedded = append(embedded, resources.AddonCCMVsphere)
+		case s.Cluster.CloudProvider.VMwareCloudDirector != nil:
+			embedded = append(embedded, resources.AddonCCMVMwareCloudDirector)
+		}
+	}
+
+	for _, addonName := range embedded {
+		if err := addons.EnsureAddonByName(s, addonName); err != nil {
+			return errors.Wrapf(err, "failed to render the addon %q", addonName)
+		}
+	}
+
+	if s.Cluster.Addons != nil && s.Cluster.Addons.Enable {
+		if err := addons.EnsureUserAddons(s); err != nil {
+			return err
+		}
+	}
+
+	if s.Cluster.CloudProvider.External {
+		if err := csi.Ensure(s); err != nil {
+			return err
+		}
+	}
+
+	if s.Cluster.MachineController.Deploy {
+		if err := machinecontroller.Ensure(s); err != nil {
+			return err
+		}
+	}
+
+	if s.Cluster.OperatingSystemManagerEnabled() {
+		if err := operatingsystemmanager.Ensure(s); err != nil {
+			return err
+		}
+	}
+
+	if s.Cluster.ClusterAutoscalerEnabled() {
+		if err := addons.EnsureAddonByName(s, resources.AddonClusterAutoscaler); err != nil {
+			return errors.Wrapf(err, "failed to render the addon %q", resources.AddonClusterAutoscaler)
+		}
+	}
+
+	return nil
+}
@@ -95,7 +95,39 @@ func uploadIdentityFirstEncryptionConfiguration(s *state.State) error {
 	return s.RunTaskOnControlPlane(pushEncryptionConfigurationOnNode, state.RunParallel)
 }
 
-func uploadEncryptionConfigurationWithNewKey(s *state.State) error {
+// keyRotation is the encryption key rotation shared by its steps, so the
+// retried steps don't add another key or make another key the encryption key
+type keyRotation struct {
+	provider string
+	newKey   string
+}
+
+// uploadWithNewDecryptKey uploads the configuration with the new key of the
+// rotation's key provider, used only to decrypt the resources. The new key
+// must be known to all kube-apiservers before any of them starts encrypting
+// with it.
+func (r *keyRotation) uploadWithNewDecryptKey(s *state.State) error {
+	s.Logger.Infof("Uploading EncryptionProviders configuration file...")
+
+	if s.LiveCluster.EncryptionConfiguration == nil ||
+		s.LiveCluster.EncryptionConfiguration.Config == nil {
+		return errors.New("failed to read live cluster encryption providers configuration")
+	}
+
+	if r.newKey == "" {
+		name, err := encryptionproviders.UpdateEncryptionConfigAddNewKey(s.LiveCluster.EncryptionConfiguration.Config, r.provider)
+		if err != nil {
+			return err
+		}
+		r.newKey = name
+	}
+
+	return uploadLiveEncryptionConfiguration(s)
+}
+
+// uploadWithNewEncryptKey uploads the configuration using the new key for
+// encryption, while the old keys are still used for decryption
+func (r *keyRotation) uploadWithNewEncryptKey(s *state.State) error {
 	s.Logger.Infof("Uploading EncryptionProviders configuration file...")
 
 	if s.LiveCluster.EncryptionConfiguration == nil ||
@@ -103,10 +135,16 @@ func uploadEncryptionConfigurationWithNewKey(s *state.State) error {
 		return errors.New("failed to read live cluster encryption providers configuration")
 	}
 
-	if err := encryptionproviders.UpdateEncryptionConfigWithNewKey(s.LiveCluster.EncryptionConfiguration.Config); err != nil {
+	if err := encryptionproviders.UpdateEncryptionConfigWithNewKey(s.LiveCluster.EncryptionConfiguration.Config, r.newKey); err != nil {
 		return err
 	}
 
+	return uploadLiveEncryptionConfiguration(s)
+}
+
+// uploadLiveEncryptionConfiguration uploads the live cluster encryption
+// configuration to all control plane nodes
+func uploadLiveEncryptionConfiguration(s *state.State) error {
 	config, err := templates.KubernetesToYAML([]runtime.Object{s.LiveCluster.EncryptionConfiguration.Config})
 	if err != nil {
 		return err
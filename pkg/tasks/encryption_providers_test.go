@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/configupload"
+	"k8c.io/kubeone/pkg/state"
+
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+)
+
+func TestKeyRotationRetry(t *testing.T) {
+	oldKey := apiserverconfigv1.Key{Name: "kubeone-old", Secret: "c2VjcmV0"}
+	s := &state.State{
+		Logger:        logrus.New(),
+		Cluster:       &kubeoneapi.KubeOneCluster{Name: "test"},
+		Configuration: configupload.NewConfiguration(),
+		LiveCluster: &state.Cluster{
+			EncryptionConfiguration: &state.EncryptionConfiguration{
+				Enable: true,
+				Config: &apiserverconfigv1.EncryptionConfiguration{
+					Resources: []apiserverconfigv1.ResourceConfiguration{
+						{
+							Resources: []string{"secrets"},
+							Providers: []apiserverconfigv1.ProviderConfiguration{
+								{AESCBC: &apiserverconfigv1.AESConfiguration{Keys: []apiserverconfigv1.Key{oldKey}}},
+								{Identity: &apiserverconfigv1.IdentityConfiguration{}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	config := func() *apiserverconfigv1.EncryptionConfiguration {
+		return s.LiveCluster.EncryptionConfiguration.Config
+	}
+
+	rotation := &keyRotation{}
+
+	// the retried steps, such as after failing to upload the configuration
+	// to one of the nodes, must not add another key or swap the keys back
+	for i := 0; i < 2; i++ {
+		if err := rotation.uploadWithNewDecryptKey(s); err != nil {
+			t.Fatalf("uploadWithNewDecryptKey() error = %v", err)
+		}
+	}
+
+	keys := config().Resources[0].Providers[0].AESCBC.Keys
+	if len(keys) != 2 || keys[0].Name != oldKey.Name || keys[1].Name != rotation.newKey {
+		t.Fatalf("expected the old key to encrypt and the new key %q to decrypt, but got %v", rotation.newKey, keys)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := rotation.uploadWithNewEncryptKey(s); err != nil {
+			t.Fatalf("uploadWithNewEncryptKey() error = %v", err)
+		}
+	}
+
+	keys = config().Resources[0].Providers[0].AESCBC.Keys
+	if len(keys) != 2 || keys[0].Name != rotation.newKey || keys[1].Name != oldKey.Name {
+		t.Fatalf("expected the new key %q to encrypt and the old key to decrypt, but got %v", rotation.newKey, keys)
+	}
+	if len(config().Resources[0].Providers) != 2 || config().Resources[0].Providers[1].Identity == nil {
+		t.Errorf("expected the identity provider to be kept as the fallback, but got %v", config().Resources[0].Providers)
+	}
+}
@@ -33,6 +33,10 @@ import (
 )
 
 func repairClusterIfNeeded(s *state.State) error {
+	if !s.Cluster.LocalEtcd() {
+		return nil
+	}
+
 	s.Logger.Info("Check if cluster needs any repairs...")
 
 	leader, err := s.Cluster.Leader()
@@ -62,7 +66,7 @@ func repairClusterIfNeeded(s *state.State) error {
 	knownEtcdMembersIdentities := sets.NewString()
 
 	for _, host := range s.Cluster.ControlPlane.Hosts {
-		knownHostsIdentities.Insert(host.Hostname, host.PublicAddress, host.PrivateAddress)
+		knownHostsIdentities.Insert(host.Hostname, host.PublicAddress, host.PrivateAddress, host.AdvertiseAddress())
 	}
 
 	membersToDelete := make(map[string]uint64)
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/statebackend"
+)
+
+// etcdSnapshotPath is where the etcd snapshot is saved on the leader before
+// it's downloaded. It must be in the etcd data directory, which is the only
+// host directory writable from the etcd container.
+const etcdSnapshotPath = "/var/lib/etcd/kubeone-snapshot.db"
+
+// WithEtcdBackup saves the etcd snapshot on the leader, downloads it to the
+// target file and uploads it to the backups storage, if configured
+func WithEtcdBackup(t Tasks, target string) Tasks {
+	return t.append(Tasks{
+		{
+			Fn: func(s *state.State) error {
+				return s.RunTaskOnLeader(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+					return saveEtcdSnapshot(s, conn, target)
+				})
+			},
+			ErrMsg:  "failed to save etcd snapshot",
+			Retries: 3,
+			Scope:   ScopeLeader,
+		},
+		{
+			Fn: func(s *state.State) error {
+				return uploadEtcdSnapshot(s, target)
+			},
+			ErrMsg:    "failed to upload etcd snapshot",
+			Retries:   3,
+			Predicate: backupsStorageConfigured,
+		},
+	}...)
+}
+
+func backupsStorageConfigured(s *state.State) bool {
+	return s.Cluster.Backups != nil && s.Cluster.Backups.Storage != nil
+}
+
+func saveEtcdSnapshot(s *state.State, conn ssh.Connection, target string) error {
+	s.Logger.Infoln("Saving etcd snapshot...")
+
+	cmd, err := scripts.EtcdSnapshotSave(etcdSnapshotPath)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+		return err
+	}
+	defer func() {
+		_, _, _, _ = conn.Exec(fmt.Sprintf("sudo rm -f %s", etcdSnapshotPath))
+	}()
+
+	if err = os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+		return errors.Wrap(err, "failed to create snapshot directory")
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create snapshot file")
+	}
+	defer f.Close()
+
+	s.Logger.Infof("Downloading etcd snapshot to %s...", target)
+
+	var stderr strings.Builder
+	if _, err = conn.POpen(fmt.Sprintf("sudo cat %s", etcdSnapshotPath), nil, f, &stderr); err != nil {
+		return errors.Wrapf(err, "failed to download etcd snapshot: %s", stderr.String())
+	}
+
+	return errors.WithStack(f.Close())
+}
+
+func uploadEtcdSnapshot(s *state.State, target string) error {
+	backend, err := statebackend.New(s.Cluster.Backups.Storage, s.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := ioutil.ReadFile(target)
+	if err != nil {
+		return errors.Wrap(err, "failed to read etcd snapshot")
+	}
+
+	key := statebackend.EtcdSnapshotKey(filepath.Base(target))
+	s.Logger.Infof("Uploading etcd snapshot to %s...", key)
+
+	return backend.Put(s.Context, key, snapshot)
+}
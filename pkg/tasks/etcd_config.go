@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/clusterstatus/etcdstatus"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// detectEtcdSettings returns the distinct etcd settings, formatted by
+// kubeoneapi.FormatExtraArgs, the etcd members run with. Only the flags
+// configured by the EtcdConfig are taken into account.
+func detectEtcdSettings(s *state.State) ([]string, error) {
+	if s.DynamicClient == nil {
+		return nil, errors.New("kubernetes dynamic client is not initialized")
+	}
+
+	pods := corev1.PodList{}
+	err := s.DynamicClient.List(s.Context, &pods, &dynclient.ListOptions{
+		Namespace: metav1.NamespaceSystem,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			"component": "etcd",
+		}),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list etcd pods")
+	}
+
+	found := map[string]bool{}
+	for _, pod := range pods.Items {
+		args := map[string]string{}
+		for _, c := range pod.Spec.Containers[0].Command {
+			for _, flag := range kubeoneapi.EtcdSettingsFlags {
+				if prefix := "--" + flag + "="; strings.HasPrefix(c, prefix) {
+					args[flag] = strings.TrimPrefix(c, prefix)
+				}
+			}
+		}
+		found[kubeoneapi.FormatExtraArgs(args)] = true
+	}
+
+	settings := []string{}
+	for setting := range found {
+		settings = append(settings, setting)
+	}
+	sort.Strings(settings)
+
+	return settings, nil
+}
+
+// validateEtcdHealthy makes sure all etcd members are healthy before they are
+// restarted, because restarting a member while another one is down could
+// make the etcd cluster lose the quorum
+func validateEtcdHealthy(s *state.State) error {
+	for _, node := range s.LiveCluster.ControlPlane {
+		if !node.IsInCluster || !node.Etcd.Healthy() {
+			return errors.Errorf("etcd member on the node %q is not healthy", node.Config.Hostname)
+		}
+	}
+
+	return nil
+}
+
+// updateEtcdSettings regenerates the etcd manifests one member at a time,
+// waiting for each member to become healthy before moving to the next one
+func updateEtcdSettings(s *state.State) error {
+	if err := s.RunTaskOnControlPlane(regenerateEtcdManifest, state.RunSequentially); err != nil {
+		return err
+	}
+
+	return uploadKubeadmConfig(s)
+}
+
+func regenerateEtcdManifest(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	logger := s.Logger.WithField("node", node.PublicAddress)
+	logger.Info("Regenerating etcd manifest...")
+
+	cmd, err := scripts.KubeadmEtcdManifest(s.WorkDir, node.ID, s.KubeadmVerboseFlag())
+	if err != nil {
+		return err
+	}
+
+	if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+		return err
+	}
+
+	timeout := 30 * time.Second
+	logger.Infof("Waiting %s for Kubelet to roll-out static pods...", timeout)
+	time.Sleep(timeout)
+
+	timeout = 2 * time.Minute
+	logger.Infof("Waiting up to %s for etcd to become healthy...", timeout)
+	if err = waitForStaticPodReady(s, timeout, "etcd-"+node.Hostname, metav1.NamespaceSystem); err != nil {
+		return errors.Wrapf(err, "etcd failed to come up for %s", timeout)
+	}
+
+	return errors.Wrap(waitForEtcdMemberHealthy(s, timeout, *node), "etcd member failed to become healthy")
+}
+
+// updateEtcdServerCerts regenerates the etcd server certificates one member at
+// a time, so the new SANs are included
+func updateEtcdServerCerts(s *state.State) error {
+	if err := s.RunTaskOnControlPlane(regenerateEtcdServerCert, state.RunSequentially); err != nil {
+		return err
+	}
+
+	return uploadKubeadmConfig(s)
+}
+
+// regenerateEtcdServerCert regenerates the etcd server certificate on the
+// node. etcd reloads the certificate on every TLS handshake, so the member
+// doesn't need to be restarted.
+func regenerateEtcdServerCert(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+	logger := s.Logger.WithField("node", node.PublicAddress)
+	logger.Info("Regenerating etcd server certificate...")
+
+	cmd, err := scripts.KubeadmEtcdServerCert(s.WorkDir, node.ID, s.KubeadmVerboseFlag())
+	if err != nil {
+		return err
+	}
+
+	if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+		return err
+	}
+
+	timeout := 2 * time.Minute
+	logger.Infof("Waiting up to %s for etcd to become healthy...", timeout)
+
+	return errors.Wrap(waitForEtcdMemberHealthy(s, timeout, *node), "etcd member failed to become healthy")
+}
+
+// waitForEtcdMemberHealthy waits for the etcd member running on the node to
+// rejoin the etcd cluster and report as healthy
+func waitForEtcdMemberHealthy(s *state.State, timeout time.Duration, node kubeoneapi.HostConfig) error {
+	return wait.PollImmediate(5*time.Second, timeout, func() (bool, error) {
+		members, err := etcdstatus.MemberList(s)
+		if err != nil {
+			s.Logger.Debugf("Failed to list etcd members: %v", err)
+			return false, nil
+		}
+
+		status, err := etcdstatus.Get(s, node, members)
+		if err != nil {
+			s.Logger.Debugf("Failed to get etcd member status: %v", err)
+			return false, nil
+		}
+
+		return status.Health && status.Member, nil
+	})
+}
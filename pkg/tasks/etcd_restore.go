@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/etcdutil"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	etcdDataDir = "/var/lib/etcd"
+	// etcdRestoreDir and etcdRestoreSnapshotPath must be in the etcd data
+	// directory, which is the only host directory writable from the etcd
+	// container
+	etcdRestoreDir          = etcdDataDir + "/kubeone-restore"
+	etcdRestoreSnapshotPath = etcdDataDir + "/kubeone-restore.db"
+	stoppedManifestsDir     = "/etc/kubernetes/kubeone-stopped"
+)
+
+// WithEtcdRestore restores the etcd snapshot on the leader and rejoins the
+// followers as new etcd members of the restored cluster. The replaced etcd
+// data is kept in the /var/lib/etcd-backup-<timestamp> directory on all
+// control plane nodes.
+func WithEtcdRestore(t Tasks, snapshot string) Tasks {
+	dataBackupDir := fmt.Sprintf("%s-backup-%s", etcdDataDir, time.Now().UTC().Format("20060102-150405"))
+
+	return WithHostnameOS(t).
+		append(Tasks{
+			{
+				Fn: func(s *state.State) error {
+					return s.RunTaskOnLeader(func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+						return prepareEtcdRestore(s, node, conn, snapshot)
+					})
+				},
+				ErrMsg:  "failed to restore etcd snapshot",
+				Retries: 1,
+				Scope:   ScopeLeader,
+			},
+			{
+				Fn: func(s *state.State) error {
+					s.Logger.Infoln("Stopping control plane...")
+
+					return s.RunTaskOnNodes(s.Cluster.ControlPlane.Hosts, func(s *state.State, _ *kubeoneapi.HostConfig, _ ssh.Connection) error {
+						cmd, err := scripts.StopControlPlane(stoppedManifestsDir, etcdDataDir, dataBackupDir)
+						if err != nil {
+							return err
+						}
+
+						_, _, err = s.Runner.RunRaw(cmd)
+
+						return err
+					}, state.RunParallel)
+				},
+				ErrMsg:  "failed to stop control plane",
+				Retries: 1,
+				Scope:   ScopeControlPlane,
+			},
+			{
+				Fn:      startRestoredEtcd,
+				ErrMsg:  "failed to start restored etcd",
+				Retries: 1,
+				Scope:   ScopeLeader,
+			},
+			{
+				Fn:      rejoinEtcdFollowers,
+				ErrMsg:  "failed to rejoin etcd members",
+				Retries: 1,
+				Scope:   ScopeFollowers,
+			},
+		}...)
+}
+
+func prepareEtcdRestore(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection, snapshot string) error {
+	f, err := os.Open(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "failed to open etcd snapshot")
+	}
+	defer f.Close()
+
+	s.Logger.Infof("Uploading etcd snapshot to %s...", node.PublicAddress)
+	if err = ssh.Upload(conn, f, etcdRestoreSnapshotPath, 0600); err != nil {
+		return err
+	}
+
+	s.Logger.Infoln("Restoring etcd snapshot...")
+	cmd, err := scripts.EtcdSnapshotRestore(etcdRestoreSnapshotPath, etcdRestoreDir, node.Hostname, etcdPeerURL(*node))
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.Runner.RunRaw(cmd)
+
+	return err
+}
+
+func startRestoredEtcd(s *state.State) error {
+	err := s.RunTaskOnLeader(func(s *state.State, _ *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		s.Logger.Infoln("Starting control plane with the restored etcd...")
+
+		cmd, err := scripts.EtcdStartRestored(stoppedManifestsDir, etcdDataDir, etcdRestoreDir)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = s.Runner.RunRaw(cmd)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return waitEtcdQuorum(s, 1)
+}
+
+// rejoinEtcdFollowers adds the followers one by one as new members of the
+// restored etcd cluster, waiting for the quorum after each member
+func rejoinEtcdFollowers(s *state.State) error {
+	leader, err := s.Cluster.Leader()
+	if err != nil {
+		return err
+	}
+
+	initialCluster := []string{fmt.Sprintf("%s=%s", leader.Hostname, etcdPeerURL(leader))}
+
+	for _, follower := range s.Cluster.Followers() {
+		follower := follower
+		s.Logger.Infof("Adding etcd member %s...", follower.Hostname)
+
+		if err = addEtcdMember(s, leader, etcdPeerURL(follower)); err != nil {
+			return err
+		}
+
+		initialCluster = append(initialCluster, fmt.Sprintf("%s=%s", follower.Hostname, etcdPeerURL(follower)))
+
+		err = s.RunTaskOnNodes([]kubeoneapi.HostConfig{follower}, func(s *state.State, _ *kubeoneapi.HostConfig, _ ssh.Connection) error {
+			cmd, rerr := scripts.EtcdStartRejoined(stoppedManifestsDir, strings.Join(initialCluster, ","))
+			if rerr != nil {
+				return rerr
+			}
+
+			_, _, rerr = s.Runner.RunRaw(cmd)
+
+			return rerr
+		}, state.RunSequentially)
+		if err != nil {
+			return err
+		}
+
+		if err = waitEtcdQuorum(s, len(initialCluster)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addEtcdMember(s *state.State, leader kubeoneapi.HostConfig, peerURL string) error {
+	etcdcfg, err := etcdutil.NewClientConfig(s, leader)
+	if err != nil {
+		return err
+	}
+
+	etcdcli, err := clientv3.New(*etcdcfg)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer etcdcli.Close()
+
+	_, err = etcdcli.MemberAdd(s.Context, []string{peerURL})
+
+	return errors.Wrapf(err, "failed to add etcd member %s", peerURL)
+}
+
+// waitEtcdQuorum waits until the etcd cluster has the expected number of
+// started members, all of them healthy
+func waitEtcdQuorum(s *state.State, members int) error {
+	leader, err := s.Cluster.Leader()
+	if err != nil {
+		return err
+	}
+
+	s.Logger.Infof("Waiting for %d etcd member(s) to become healthy...", members)
+
+	return wait.Poll(5*time.Second, 5*time.Minute, func() (bool, error) {
+		etcdcfg, cerr := etcdutil.NewClientConfig(s, leader)
+		if cerr != nil {
+			return false, cerr
+		}
+
+		etcdcli, cerr := clientv3.New(*etcdcfg)
+		if cerr != nil {
+			s.Logger.Debugf("etcd is not reachable yet: %v", cerr)
+
+			return false, nil
+		}
+		defer etcdcli.Close()
+
+		memberList, cerr := etcdcli.MemberList(s.Context)
+		if cerr != nil {
+			s.Logger.Debugf("failed to list etcd members: %v", cerr)
+
+			return false, nil
+		}
+
+		if len(memberList.Members) != members {
+			return false, nil
+		}
+
+		for _, member := range memberList.Members {
+			// members which haven't started yet have no client URLs
+			if len(member.ClientURLs) == 0 {
+				return false, nil
+			}
+
+			for _, endpoint := range member.ClientURLs {
+				endpointURL, uerr := url.Parse(endpoint)
+				if uerr != nil {
+					return false, errors.Wrap(uerr, "failed to parse etcd clientURL")
+				}
+
+				status, serr := etcdcli.Status(s.Context, endpointURL.Host)
+				if serr != nil || len(status.Errors) > 0 {
+					return false, nil
+				}
+			}
+		}
+
+		return true, nil
+	})
+}
+
+// etcdPeerURL returns the peer URL of the etcd member on the host, as
+// configured by kubeadm
+func etcdPeerURL(host kubeoneapi.HostConfig) string {
+	return "https://" + net.JoinHostPort(host.AdvertiseAddress(), "2380")
+}
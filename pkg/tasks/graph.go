@@ -0,0 +1,185 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/state"
+)
+
+const (
+	// GraphFormatDot renders the task graph in the Graphviz DOT language
+	GraphFormatDot = "dot"
+	// GraphFormatMermaid renders the task graph as a Mermaid flowchart
+	GraphFormatMermaid = "mermaid"
+)
+
+type graphNode struct {
+	id    string
+	label string
+	host  bool
+}
+
+type graphEdge struct {
+	from string
+	to   string
+}
+
+// Graph renders the tasks that would be run against the given state as a
+// graph in the given format. Tasks are run one after another, while tasks
+// executed on multiple hosts fan out to a node per host.
+func (t Tasks) Graph(s *state.State, format string) (string, error) {
+	var (
+		nodes []graphNode
+		edges []graphEdge
+		prev  []string
+	)
+
+	for i := range t {
+		step := t[i]
+		if step.Predicate != nil && !step.Predicate(s) {
+			continue
+		}
+
+		id := fmt.Sprintf("t%d", i)
+		nodes = append(nodes, graphNode{id: id, label: step.label()})
+		for _, p := range prev {
+			edges = append(edges, graphEdge{from: p, to: id})
+		}
+		prev = []string{id}
+
+		hosts := step.hosts(s)
+		if len(hosts) == 0 {
+			continue
+		}
+
+		prev = nil
+		for j, host := range hosts {
+			hostID := fmt.Sprintf("%s_%d", id, j)
+			nodes = append(nodes, graphNode{id: hostID, label: hostLabel(host), host: true})
+			edges = append(edges, graphEdge{from: id, to: hostID})
+			prev = append(prev, hostID)
+		}
+	}
+
+	var sb strings.Builder
+
+	switch format {
+	case GraphFormatDot:
+		sb.WriteString("digraph kubeone {\n")
+		sb.WriteString("  node [shape=box];\n")
+		for _, n := range nodes {
+			if n.host {
+				fmt.Fprintf(&sb, "  %s [label=%s, shape=ellipse];\n", n.id, strconv.Quote(n.label))
+				continue
+			}
+			fmt.Fprintf(&sb, "  %s [label=%s];\n", n.id, strconv.Quote(n.label))
+		}
+		for _, e := range edges {
+			fmt.Fprintf(&sb, "  %s -> %s;\n", e.from, e.to)
+		}
+		sb.WriteString("}\n")
+	case GraphFormatMermaid:
+		sb.WriteString("flowchart TD\n")
+		for _, n := range nodes {
+			label := strings.ReplaceAll(n.label, `"`, "#quot;")
+			if n.host {
+				fmt.Fprintf(&sb, "  %s([\"%s\"])\n", n.id, label)
+				continue
+			}
+			fmt.Fprintf(&sb, "  %s[\"%s\"]\n", n.id, label)
+		}
+		for _, e := range edges {
+			fmt.Fprintf(&sb, "  %s --> %s\n", e.from, e.to)
+		}
+	default:
+		return "", errors.Errorf("unknown graph format %q, supported formats are %q and %q", format, GraphFormatDot, GraphFormatMermaid)
+	}
+
+	return sb.String(), nil
+}
+
+// PlanStep is a task that would be run, along with the hosts it would be
+// run on
+type PlanStep struct {
+	Task  string
+	Hosts []string
+}
+
+// Plan returns the tasks that would be run against the given state, in the
+// order they would be run
+func (t Tasks) Plan(s *state.State) []PlanStep {
+	var steps []PlanStep
+
+	for i := range t {
+		step := t[i]
+		if step.Predicate != nil && !step.Predicate(s) {
+			continue
+		}
+
+		planStep := PlanStep{Task: step.label()}
+		for _, host := range step.hosts(s) {
+			planStep.Hosts = append(planStep.Hosts, hostLabel(host))
+		}
+
+		steps = append(steps, planStep)
+	}
+
+	return steps
+}
+
+// hostLabel returns the hostname of the host, or its public address if the
+// hostname is not known yet
+func hostLabel(host kubeoneapi.HostConfig) string {
+	if host.Hostname != "" {
+		return host.Hostname
+	}
+
+	return host.PublicAddress
+}
+
+// label returns the human readable name of the task, derived from the error
+// message when the task has no description
+func (t *Task) label() string {
+	if t.Description != "" {
+		return strings.TrimSpace(t.Description)
+	}
+
+	label := t.ErrMsg
+	for _, prefix := range []string{"failed to ", "unable to "} {
+		label = strings.TrimPrefix(label, prefix)
+	}
+
+	return strings.TrimSuffix(label, " failed")
+}
+
+// Labels returns the human readable names of all tasks, regardless of
+// whether they would be run
+func (t Tasks) Labels() []string {
+	labels := make([]string, 0, len(t))
+	for i := range t {
+		labels = append(labels, t[i].label())
+	}
+
+	return labels
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/state"
+)
+
+func TestTasksGraph(t *testing.T) {
+	s := &state.State{
+		Cluster: &kubeoneapi.KubeOneCluster{
+			ControlPlane: kubeoneapi.ControlPlaneConfig{
+				Hosts: []kubeoneapi.HostConfig{
+					{PublicAddress: "10.0.0.1", Hostname: "cp-1", IsLeader: true},
+					{PublicAddress: "10.0.0.2"},
+				},
+			},
+		},
+	}
+
+	noop := func(*state.State) error { return nil }
+	tasksToRun := Tasks{
+		{Fn: noop, ErrMsg: "failed to detect hostname", Scope: ScopeAllNodes},
+		{Fn: noop, ErrMsg: "failed to init kubernetes on leader", Scope: ScopeLeader},
+		{Fn: noop, ErrMsg: "failed to install cni plugin", Description: "ensure CNI"},
+		{Fn: noop, ErrMsg: "skipped", Predicate: func(*state.State) bool { return false }},
+		{Fn: noop, ErrMsg: "probes failed"},
+	}
+
+	tests := []struct {
+		name          string
+		format        string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:   "dot",
+			format: GraphFormatDot,
+			expected: heredoc.Doc(`
+				digraph kubeone {
+				  node [shape=box];
+				  t0 [label="detect hostname"];
+				  t0_0 [label="cp-1", shape=ellipse];
+				  t0_1 [label="10.0.0.2", shape=ellipse];
+				  t1 [label="init kubernetes on leader"];
+				  t1_0 [label="cp-1", shape=ellipse];
+				  t2 [label="ensure CNI"];
+				  t4 [label="probes"];
+				  t0 -> t0_0;
+				  t0 -> t0_1;
+				  t0_0 -> t1;
+				  t0_1 -> t1;
+				  t1 -> t1_0;
+				  t1_0 -> t2;
+				  t2 -> t4;
+				}
+			`),
+		},
+		{
+			name:   "mermaid",
+			format: GraphFormatMermaid,
+			expected: heredoc.Doc(`
+				flowchart TD
+				  t0["detect hostname"]
+				  t0_0(["cp-1"])
+				  t0_1(["10.0.0.2"])
+				  t1["init kubernetes on leader"]
+				  t1_0(["cp-1"])
+				  t2["ensure CNI"]
+				  t4["probes"]
+				  t0 --> t0_0
+				  t0 --> t0_1
+				  t0_0 --> t1
+				  t0_1 --> t1
+				  t1 --> t1_0
+				  t1_0 --> t2
+				  t2 --> t4
+			`),
+		},
+		{
+			name:          "unknown format",
+			format:        "svg",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tasksToRun.Graph(s, tc.format)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error = %v, but got: %v", tc.expectedError, err)
+			}
+
+			if got != tc.expected {
+				t.Errorf("expected graph\n%s\nbut got\n%s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTasksPlan(t *testing.T) {
+	s := &state.State{
+		Cluster: &kubeoneapi.KubeOneCluster{
+			ControlPlane: kubeoneapi.ControlPlaneConfig{
+				Hosts: []kubeoneapi.HostConfig{
+					{PublicAddress: "10.0.0.1", Hostname: "cp-1", IsLeader: true},
+					{PublicAddress: "10.0.0.2"},
+				},
+			},
+		},
+	}
+
+	noop := func(*state.State) error { return nil }
+	tasksToRun := Tasks{
+		{Fn: noop, ErrMsg: "failed to upgrade leader control plane", Scope: ScopeLeader},
+		{Fn: noop, ErrMsg: "skipped", Predicate: func(*state.State) bool { return false }},
+		{Fn: noop, ErrMsg: "failed to upgrade follower control plane", Scope: ScopeFollowers},
+		{Fn: noop, ErrMsg: "failed to ensure addons", Description: "ensure addons"},
+	}
+
+	expected := []PlanStep{
+		{Task: "upgrade leader control plane", Hosts: []string{"cp-1"}},
+		{Task: "upgrade follower control plane", Hosts: []string{"10.0.0.2"}},
+		{Task: "ensure addons"},
+	}
+	if got := tasksToRun.Plan(s); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected plan %+v, but got %+v", expected, got)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/hooks"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+)
+
+// WithHooks runs fn between the pre and the post hooks of the operation. The
+// post hooks are run even if fn fails, so they can revert what the pre hooks
+// did, such as silencing alerts.
+func WithHooks(s *state.State, pre, post hooks.Event, fn func() error) error {
+	return runWithHooks(s, nil, pre, post, fn)
+}
+
+// withNodeUpgradeHooks runs the per-node hooks around the node upgrade
+func withNodeUpgradeHooks(task state.NodeTask) state.NodeTask {
+	return func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		return runWithHooks(s, node, hooks.PreNodeUpgrade, hooks.PostNodeUpgrade, func() error {
+			return task(s, node, conn)
+		})
+	}
+}
+
+func runWithHooks(s *state.State, node *kubeoneapi.HostConfig, pre, post hooks.Event, fn func() error) error {
+	if s.DryRun() {
+		return fn()
+	}
+
+	if err := hooks.Run(s.Context, s.Logger, s.Cluster.Hooks, hooks.NewPayload(pre, s.Cluster.Name, node, nil)); err != nil {
+		return err
+	}
+
+	opErr := fn()
+
+	err := hooks.Run(s.Context, s.Logger, s.Cluster.Hooks, hooks.NewPayload(post, s.Cluster.Name, node, opErr))
+	if opErr != nil {
+		if err != nil {
+			s.Logger.Errorf("Failed to run %s hooks: %v", post, err)
+		}
+
+		return opErr
+	}
+
+	return err
+}
+
+// runHostScripts runs the host scripts of the lifecycle point on the node,
+// one by one
+func runHostScripts(s *state.State, node *kubeoneapi.HostConfig, point hooks.HostPoint) error {
+	rendered, err := hooks.RenderHostScripts(s.Cluster, node, point, s.ManifestFilePath)
+	if err != nil {
+		return err
+	}
+
+	logger := s.Logger.WithField("node", node.PublicAddress)
+
+	for _, script := range rendered {
+		logger.Infof("Running %s script %q...", point, script.Name)
+
+		if _, _, err := s.Runner.RunRaw(script.Script); err != nil {
+			if script.IgnoreFailure {
+				logger.Warnf("Ignoring failure of %s script %q: %v", point, script.Name, err)
+				continue
+			}
+
+			return errors.Wrapf(err, "%s script %q failed", point, script.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/preflight"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+)
+
+// The minimal resources of the hosts, matching the kubeadm preflight checks
+// of the control plane nodes
+const (
+	controlPlaneMinCPUs      = 2
+	controlPlaneMinMemoryMiB = 1700
+	workerMinCPUs            = 1
+	workerMinMemoryMiB       = 1024
+	minDiskFreeGiB           = 5
+)
+
+// kernelModules are the kernel modules loaded by the container runtime
+// installation
+var kernelModules = []string{"overlay", "br_netfilter"}
+
+// HostPreflight checks all control plane and static worker hosts meet the
+// requirements of provisioning them. The hosts are checked in parallel, and
+// the unreachable hosts are reported instead of failing the checks.
+func HostPreflight(s *state.State) *preflight.Report {
+	hosts := append(append([]kubeoneapi.HostConfig{}, s.Cluster.ControlPlane.Hosts...), s.Cluster.StaticWorkers.Hosts...)
+	results := make([][]preflight.Result, len(hosts))
+
+	wg := sync.WaitGroup{}
+	for i := range hosts {
+		wg.Add(1)
+		go func(ctx *state.State, i int) {
+			defer wg.Done()
+			results[i] = hostPreflight(ctx, hosts[i], i < len(s.Cluster.ControlPlane.Hosts))
+		}(s.Clone(), i)
+	}
+	wg.Wait()
+
+	all := []preflight.Result{}
+	for _, r := range results {
+		all = append(all, r...)
+	}
+
+	return preflight.NewReport(all)
+}
+
+func hostPreflight(s *state.State, host kubeoneapi.HostConfig, controlPlane bool) []preflight.Result {
+	req := hostPreflightRequirements(s, controlPlane)
+
+	var results []preflight.Result
+	err := s.RunTaskOnNodes([]kubeoneapi.HostConfig{host}, func(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		cmd, err := scripts.HostPreflight(req.Ports, req.DNSNames, req.KernelModules)
+		if err != nil {
+			return err
+		}
+
+		stdout, _, err := s.Runner.RunRaw(cmd)
+		if err != nil {
+			return err
+		}
+
+		results = preflight.Evaluate(node.PublicAddress, req, preflight.ParseFacts(stdout))
+
+		return nil
+	}, state.RunSequentially)
+	if err != nil {
+		return []preflight.Result{preflight.Unreachable(host.PublicAddress, err)}
+	}
+
+	return results
+}
+
+func hostPreflightRequirements(s *state.State, controlPlane bool) preflight.Requirements {
+	req := preflight.Requirements{
+		CPUs:          workerMinCPUs,
+		MemoryMiB:     workerMinMemoryMiB,
+		DiskFreeGiB:   minDiskFreeGiB,
+		Ports:         []int{10250},
+		KernelModules: kernelModules,
+		FIPS:          s.Cluster.Features.FIPS != nil && s.Cluster.Features.FIPS.Enable,
+	}
+
+	if net.ParseIP(s.Cluster.APIEndpoint.Host) == nil {
+		req.DNSNames = []string{s.Cluster.APIEndpoint.Host}
+	}
+
+	if controlPlane {
+		req.CPUs = controlPlaneMinCPUs
+		req.MemoryMiB = controlPlaneMinMemoryMiB
+		req.Ports = []int{6443, 10250, 10257, 10259}
+		if s.Cluster.LocalEtcd() {
+			req.Ports = append(req.Ports, 2379, 2380)
+		}
+	}
+
+	return req
+}
+
+// hostPreflightTask returns the task running the host preflight checks,
+// unless they are skipped with the --skip-preflight-checks flag
+func hostPreflightTask() Task {
+	return Task{
+		Fn:          runHostPreflightChecks,
+		ErrMsg:      "host preflight checks failed",
+		Description: "run host preflight checks",
+		Scope:       ScopeAllNodes,
+		Predicate:   func(s *state.State) bool { return !s.SkipPreflightChecks },
+	}
+}
+
+// runHostPreflightChecks fails if any host doesn't meet the requirements of
+// provisioning it, before anything is changed on the hosts
+func runHostPreflightChecks(s *state.State) error {
+	s.Logger.Infoln("Running host preflight checks...")
+
+	report := HostPreflight(s)
+	for _, failure := range report.Failures() {
+		logger := s.Logger.WithField("node", failure.Host)
+		if failure.Severity == preflight.SeverityWarning {
+			logger.Warnf("%s: %s", failure.Check, failure.Message)
+		} else {
+			logger.Errorf("%s: %s", failure.Check, failure.Message)
+		}
+	}
+
+	if !report.Passed {
+		return errors.New("hosts don't meet the requirements, run 'kubeone preflight' for the full report")
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/images"
+)
+
+// ensureKMSPlugin writes the KMS plugin static pod manifest on the control
+// plane nodes. Same as for kube-vip, the manifest is written before the nodes
+// are initialized or joined, so the plugin is listening by the time
+// kube-apiserver starts encrypting the Secrets.
+func ensureKMSPlugin(s *state.State) error {
+	s.Logger.Infoln("Deploying KMS plugin...")
+
+	return s.RunTaskOnControlPlane(func(s *state.State, _ *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		cmd, err := kmsPluginScript(s)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = s.Runner.RunRaw(cmd)
+
+		return err
+	}, state.RunParallel)
+}
+
+func kmsPluginEnabled(s *state.State) bool {
+	ep := s.Cluster.Features.EncryptionProviders
+
+	return ep != nil && ep.Enable && ep.KMS != nil && ep.KMS.Plugin != nil
+}
+
+func kmsPluginScript(s *state.State) (string, error) {
+	kms := s.Cluster.Features.EncryptionProviders.KMS
+
+	image := kms.Plugin.Image
+	if image == "" {
+		switch {
+		case kms.Plugin.AWS != nil:
+			image = s.Images.Get(images.AwsEncryptionProvider)
+		case kms.Plugin.Azure != nil:
+			image = s.Images.Get(images.AzureKMSPlugin)
+		case kms.Plugin.GCP != nil:
+			image = s.Images.Get(images.GCPKMSPlugin)
+		}
+	}
+
+	return scripts.KMSPlugin(kms, image)
+}
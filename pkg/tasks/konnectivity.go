@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"io/fs"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/addons"
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/certificate"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/images"
+	"k8c.io/kubeone/pkg/templates/konnectivity"
+	"k8c.io/kubeone/pkg/templates/resources"
+)
+
+// konnectivityServerAPIServer is the address konnectivity-server reaches the
+// local kube-apiserver on
+const konnectivityServerAPIServer = "https://127.0.0.1:6443"
+
+// ensureKonnectivityServer writes the konnectivity-server kubeconfig and
+// static pod manifest on the control plane nodes. The client certificate is
+// signed by the Kubernetes CA, so it requires the PKI downloaded from the
+// leader, and it's renewed when it expires in less than 90 days.
+func ensureKonnectivityServer(s *state.State) error {
+	s.Logger.Infoln("Deploying konnectivity-server...")
+
+	return s.RunTaskOnControlPlane(func(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		existing, err := fs.ReadFile(s.Runner.NewFS(), konnectivity.KubeconfigPath)
+		if err != nil || konnectivity.ServerKubeconfigNeedsRenewal(existing) {
+			kubeconfig, kerr := konnectivityServerKubeconfig(s)
+			if kerr != nil {
+				return kerr
+			}
+
+			if kerr = ssh.Upload(conn, bytes.NewReader(kubeconfig), konnectivity.KubeconfigPath, 0600); kerr != nil {
+				return errors.Wrap(kerr, "failed to upload konnectivity-server kubeconfig")
+			}
+		}
+
+		cmd, err := konnectivityServerScript(s)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = s.Runner.RunRaw(cmd)
+
+		return err
+	}, state.RunParallel)
+}
+
+func konnectivityServerKubeconfig(s *state.State) ([]byte, error) {
+	caKey, caCert, err := certificate.CAKeyPair(s.Configuration)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load CA keypair")
+	}
+
+	certPEM, keyPEM, err := certificate.NewSignedClientCert(konnectivity.ServerUser, nil, caKey, caCert)
+	if err != nil {
+		return nil, err
+	}
+
+	caCertPEM := s.Configuration.KubernetesPKI[certificate.KubernetesCACertPath]
+
+	return konnectivity.ServerKubeconfig(konnectivityServerAPIServer, caCertPEM, certPEM, keyPEM)
+}
+
+func konnectivityServerScript(s *state.State) (string, error) {
+	return scripts.KonnectivityServer(s.Cluster.Features.Konnectivity.Config, len(s.Cluster.ControlPlane.Hosts), s.Images.Get(images.KonnectivityServer))
+}
+
+func ensureKonnectivityAgent(s *state.State) error {
+	s.Logger.Infoln("Ensure konnectivity-agent...")
+
+	return addons.EnsureAddonByName(s, resources.AddonKonnectivityAgent)
+}
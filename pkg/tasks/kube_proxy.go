@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/addons"
+	"k8c.io/kubeone/pkg/state"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	kubeProxyName         = "kube-proxy"
+	kubeProxyConfigMapKey = "config.conf"
+)
+
+// ensureKubeProxyConfig reconciles the configured kube-proxy settings in the
+// kube-proxy configuration stored in the cluster, which kubeadm doesn't
+// update after the cluster is provisioned. kube-proxy is restarted to pick up
+// the changes.
+func ensureKubeProxyConfig(s *state.State) error {
+	if s.DynamicClient == nil {
+		return errors.New("kubernetes client not initialized")
+	}
+
+	kubeProxy := s.Cluster.ClusterNetwork.KubeProxy
+
+	if ipvs := kubeProxy.IPVS; ipvs != nil && ipvs.StrictARP != nil && !*ipvs.StrictARP {
+		addon, err := addons.L2LoadBalancerAddon(s.Cluster, s.ManifestFilePath)
+		if err == nil && addon != "" {
+			s.Logger.Warnf("kube-proxy strictARP is disabled, but the %q addon requires it in the IPVS mode, LoadBalancer IPs might be unreachable", addon)
+		}
+	}
+
+	key := dynclient.ObjectKey{
+		Name:      kubeProxyName,
+		Namespace: metav1.NamespaceSystem,
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := s.DynamicClient.Get(s.Context, key, cm); err != nil {
+		return errors.Wrap(err, "failed to get kube-proxy configmap")
+	}
+
+	if cm.Data[kubeProxyConfigMapKey] == "" {
+		return errors.Errorf("kube-proxy configmap doesn't contain %s", kubeProxyConfigMapKey)
+	}
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(cm.Data[kubeProxyConfigMapKey]), &config); err != nil {
+		return errors.Wrap(err, "failed to unmarshal kube-proxy configuration")
+	}
+
+	mode := "iptables"
+	if kubeProxy.IPVS != nil {
+		mode = "ipvs"
+	}
+
+	fields := map[string]interface{}{}
+	if kubeProxy.IPVS != nil {
+		fields["ipvs.strictARP"] = addons.KubeProxyStrictARP(s)
+	}
+	if kubeProxy.MetricsBindAddress != "" {
+		fields["metricsBindAddress"] = kubeProxy.MetricsBindAddress
+	}
+	if kubeProxy.Conntrack != nil && kubeProxy.Conntrack.MaxPerCore != nil {
+		fields["conntrack.maxPerCore"] = *kubeProxy.Conntrack.MaxPerCore
+	}
+	if kubeProxy.Conntrack != nil && kubeProxy.Conntrack.Min != nil {
+		fields["conntrack.min"] = *kubeProxy.Conntrack.Min
+	}
+	if kubeProxy.SyncPeriod.Duration != 0 {
+		fields[mode+".syncPeriod"] = kubeProxy.SyncPeriod.Duration.String()
+	}
+
+	changed := false
+	for path, value := range fields {
+		fieldChanged, err := setKubeProxyField(config, path, value)
+		if err != nil {
+			return err
+		}
+		if fieldChanged {
+			s.Logger.Infof("Setting kube-proxy %s to %v...", path, value)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	buf, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal kube-proxy configuration")
+	}
+	cm.Data[kubeProxyConfigMapKey] = string(buf)
+
+	if err = s.DynamicClient.Update(s.Context, cm); err != nil {
+		return errors.Wrap(err, "failed to update kube-proxy configmap")
+	}
+
+	ds := &appsv1.DaemonSet{}
+	if err = s.DynamicClient.Get(s.Context, key, ds); err != nil {
+		return errors.Wrap(err, "failed to get kube-proxy daemonset")
+	}
+
+	if ds.Spec.Template.Annotations == nil {
+		ds.Spec.Template.Annotations = map[string]string{}
+	}
+	ds.Spec.Template.Annotations[restartedAtAnnotation] = time.Now().Format(time.RFC3339)
+
+	return errors.Wrap(s.DynamicClient.Update(s.Context, ds), "failed to update kube-proxy daemonset")
+}
+
+// setKubeProxyField sets the value of the dot-separated field in the
+// kube-proxy configuration, and returns whether the value changed
+func setKubeProxyField(config map[string]interface{}, path string, value interface{}) (bool, error) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		child, _ := config[key].(map[string]interface{})
+		if child == nil {
+			child = map[string]interface{}{}
+			config[key] = child
+		}
+		config = child
+	}
+
+	// the value is normalized to the types used when unmarshaling, so it can
+	// be compared to the current value
+	buf, err := json.Marshal(value)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to marshal kube-proxy %s", path)
+	}
+	var normalized interface{}
+	if err = json.Unmarshal(buf, &normalized); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal kube-proxy %s", path)
+	}
+
+	last := keys[len(keys)-1]
+	if reflect.DeepEqual(config[last], normalized) {
+		return false, nil
+	}
+	config[last] = normalized
+
+	return true, nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/images"
+)
+
+// ensureKubeVIP writes the kube-vip static pod manifest on the control plane
+// nodes. The manifest is written before the nodes are initialized or
+// joined, so the virtual IP is announced as soon as kube-apiserver is up.
+func ensureKubeVIP(s *state.State) error {
+	s.Logger.Infoln("Deploying kube-vip...")
+
+	return s.RunTaskOnControlPlane(func(s *state.State, _ *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		cmd, err := kubeVIPScript(s)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = s.Runner.RunRaw(cmd)
+
+		return err
+	}, state.RunParallel)
+}
+
+func kubeVIPScript(s *state.State) (string, error) {
+	return scripts.KubeVIP(s.Cluster.Features.KubeVIP, s.Cluster.APIEndpoint, s.Images.Get(images.KubeVIP))
+}
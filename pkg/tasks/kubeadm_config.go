@@ -61,6 +61,16 @@ func generateKubeadm(s *state.State) error {
 		return errors.Wrap(err, "failed to determine pause image")
 	}
 
+	if err := renderKubeadm(s); err != nil {
+		return err
+	}
+
+	return s.RunTaskOnAllNodes(uploadKubeadmToNode, state.RunParallel)
+}
+
+// renderKubeadm adds the kubeadm configuration files of all nodes to the
+// configuration
+func renderKubeadm(s *state.State) error {
 	kubeadmProvider, err := kubeadm.New(s.Cluster.Versions.Kubernetes)
 	if err != nil {
 		return errors.Wrap(err, "failed to init kubeadm")
@@ -74,6 +84,15 @@ func generateKubeadm(s *state.State) error {
 		}
 
 		s.Configuration.AddFile(fmt.Sprintf("cfg/master_%d.yaml", node.ID), kubeadmConf)
+
+		patches, err := kubeadm.Patches(node)
+		if err != nil {
+			return errors.Wrap(err, "failed to create kubeadm patches")
+		}
+
+		for filename, patch := range patches {
+			s.Configuration.AddFile(filename, patch)
+		}
 	}
 
 	for idx := range s.Cluster.StaticWorkers.Hosts {
@@ -86,7 +105,7 @@ func generateKubeadm(s *state.State) error {
 		s.Configuration.AddFile(fmt.Sprintf("cfg/worker_%d.yaml", node.ID), kubeadmConf)
 	}
 
-	return s.RunTaskOnAllNodes(uploadKubeadmToNode, state.RunParallel)
+	return nil
 }
 
 func uploadKubeadmToNode(s *state.State, _ *kubeoneapi.HostConfig, conn ssh.Connection) error {
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+)
+
+// ensureKubeletConfig reapplies the kubelet flags configured by KubeletConfig
+// on the existing nodes, as kubeadm writes the kubelet flags only when the
+// node joins the cluster
+func ensureKubeletConfig(s *state.State) error {
+	s.Logger.Infoln("Ensuring kubelet configuration...")
+
+	return s.RunTaskOnAllNodes(ensureKubeletConfigOnNode, state.RunSequentially)
+}
+
+func ensureKubeletConfigOnNode(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+	desired := kubeletConfigFlags(s.Cluster, *node)
+
+	changed, err := updateKubeletFlags(s, func(kubeletFlags map[string]string) {
+		for _, flag := range kubeoneapi.KubeletConfigFlags {
+			// kubeadm versions prior to 1.21 write the detected cgroup
+			// driver to the flags file, so it's never removed
+			if flag == "cgroup-driver" {
+				continue
+			}
+			delete(kubeletFlags, "--"+flag)
+		}
+		for flag, value := range desired {
+			kubeletFlags["--"+flag] = value
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update kubelet flags")
+	}
+	if !changed {
+		return nil
+	}
+
+	s.Logger.Infof("Restarting kubelet on %q to apply the configuration...", node.Hostname)
+	_, _, err = s.Runner.RunRaw("sudo systemctl restart kubelet")
+
+	return errors.Wrap(err, "failed to restart kubelet")
+}
+
+// kubeletConfigFlags returns the kubelet flags managed by KubeletConfig as
+// rendered into the kubeadm configuration of the node, i.e. the static worker
+// pool flags overridden by the KubeletConfig of the host
+func kubeletConfigFlags(cluster *kubeoneapi.KubeOneCluster, host kubeoneapi.HostConfig) map[string]string {
+	flags := map[string]string{}
+
+	if pool := cluster.StaticWorkers.Pool(host.Pool); pool != nil {
+		for _, flag := range kubeoneapi.KubeletConfigFlags {
+			if value, ok := pool.KubeletExtraArgs[flag]; ok {
+				flags[flag] = value
+			}
+		}
+	}
+
+	for flag, value := range cluster.HostKubeletConfig(host).Flags() {
+		flags[flag] = value
+	}
+
+	return flags
+}
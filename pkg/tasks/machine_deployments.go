@@ -17,18 +17,30 @@ limitations under the License.
 package tasks
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 
+	"k8c.io/kubeone/pkg/kubeconfig"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates/machinecontroller"
 
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// machineRestartedAtAnnotation is set on the MachineDeployment template to
+	// trigger the rolling replacement of its Machines
+	machineRestartedAtAnnotation = "kubeone.io/restartedAt"
+
+	machineDeploymentRolloutTimeout = 30 * time.Minute
+)
+
 func createMachineDeployments(s *state.State) error {
 	if len(s.Cluster.DynamicWorkers) == 0 {
 		return nil
@@ -76,3 +88,120 @@ func upgradeMachineDeployments(s *state.State) error {
 
 	return nil
 }
+
+// WithRolloutRestart triggers the rolling replacement of the Machines of the
+// given MachineDeployments, or of all MachineDeployments if no names are
+// given, and waits for the new Machines to become ready.
+func WithRolloutRestart(t Tasks, names []string) Tasks {
+	return t.append(Tasks{
+		{Fn: kubeconfig.BuildKubernetesClientset, ErrMsg: "failed to build kubernetes clientset"},
+		{
+			Fn: func(s *state.State) error {
+				return rolloutRestartMachineDeployments(s, names)
+			},
+			ErrMsg: "failed to rollout restart MachineDeployments",
+		},
+	}...)
+}
+
+func rolloutRestartMachineDeployments(s *state.State, names []string) error {
+	machineDeployments := clusterv1alpha1.MachineDeploymentList{}
+	err := s.DynamicClient.List(
+		s.Context,
+		&machineDeployments,
+		dynclient.InNamespace(metav1.NamespaceSystem),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to list MachineDeployments")
+	}
+
+	selected, err := selectMachineDeployments(machineDeployments.Items, names)
+	if err != nil {
+		return err
+	}
+
+	restartedAt := time.Now().UTC().Format(time.RFC3339)
+	for _, md := range selected {
+		s.Logger.Infof("Restarting MachineDeployment %q...", md.Name)
+
+		machineKey := dynclient.ObjectKey{Name: md.Name, Namespace: md.Namespace}
+		retErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			machine := clusterv1alpha1.MachineDeployment{}
+			if err := s.DynamicClient.Get(s.Context, machineKey, &machine); err != nil {
+				return err
+			}
+
+			if machine.Spec.Template.Annotations == nil {
+				machine.Spec.Template.Annotations = map[string]string{}
+			}
+			machine.Spec.Template.Annotations[machineRestartedAtAnnotation] = restartedAt
+			return s.DynamicClient.Update(s.Context, &machine)
+		})
+
+		if retErr != nil {
+			return errors.Wrapf(retErr, "failed to update MachineDeployment %s", md.Name)
+		}
+	}
+
+	for _, md := range selected {
+		s.Logger.Infof("Waiting for MachineDeployment %q to roll out...", md.Name)
+
+		machineKey := dynclient.ObjectKey{Name: md.Name, Namespace: md.Namespace}
+		err = wait.Poll(10*time.Second, machineDeploymentRolloutTimeout, func() (bool, error) {
+			machine := clusterv1alpha1.MachineDeployment{}
+			if err := s.DynamicClient.Get(s.Context, machineKey, &machine); err != nil {
+				return false, err
+			}
+
+			return machineDeploymentRolledOut(&machine), nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "MachineDeployment %s did not roll out", md.Name)
+		}
+	}
+
+	return nil
+}
+
+// selectMachineDeployments returns the MachineDeployments with the given
+// names, or all of them if no names are given
+func selectMachineDeployments(mds []clusterv1alpha1.MachineDeployment, names []string) ([]clusterv1alpha1.MachineDeployment, error) {
+	if len(names) == 0 {
+		return mds, nil
+	}
+
+	byName := map[string]clusterv1alpha1.MachineDeployment{}
+	for _, md := range mds {
+		byName[md.Name] = md
+	}
+
+	selected := []clusterv1alpha1.MachineDeployment{}
+	for _, name := range names {
+		md, ok := byName[name]
+		if !ok {
+			return nil, errors.Errorf("MachineDeployment %q not found", name)
+		}
+		selected = append(selected, md)
+	}
+
+	return selected, nil
+}
+
+// machineDeploymentRolledOut returns whether all Machines of the
+// MachineDeployment are updated to the latest template and ready, and all
+// old Machines are gone
+func machineDeploymentRolledOut(md *clusterv1alpha1.MachineDeployment) bool {
+	if md.Status.ObservedGeneration < md.Generation {
+		return false
+	}
+
+	var replicas int32 = 1
+	if md.Spec.Replicas != nil {
+		replicas = *md.Spec.Replicas
+	}
+
+	return md.Status.UpdatedReplicas == replicas &&
+		md.Status.Replicas == replicas &&
+		md.Status.ReadyReplicas == replicas &&
+		md.Status.AvailableReplicas == replicas
+}
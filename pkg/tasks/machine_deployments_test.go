@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectMachineDeployments(t *testing.T) {
+	mds := []clusterv1alpha1.MachineDeployment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pool1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pool2"}},
+	}
+
+	tests := []struct {
+		name          string
+		names         []string
+		expected      []string
+		expectedError bool
+	}{
+		{
+			name:     "all MachineDeployments",
+			expected: []string{"pool1", "pool2"},
+		},
+		{
+			name:     "selected MachineDeployment",
+			names:    []string{"pool2"},
+			expected: []string{"pool2"},
+		},
+		{
+			name:          "unknown MachineDeployment",
+			names:         []string{"pool1", "pool3"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			selected, err := selectMachineDeployments(mds, tc.names)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, but got %v", tc.expectedError, err)
+			}
+
+			var got []string
+			for _, md := range selected {
+				got = append(got, md.Name)
+			}
+			if len(got) != len(tc.expected) {
+				t.Fatalf("expected %v, but got %v", tc.expected, got)
+			}
+			for i := range got {
+				if got[i] != tc.expected[i] {
+					t.Errorf("expected %v, but got %v", tc.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMachineDeploymentRolledOut(t *testing.T) {
+	replicas := int32(2)
+
+	tests := []struct {
+		name     string
+		status   clusterv1alpha1.MachineDeploymentStatus
+		expected bool
+	}{
+		{
+			name: "rolled out",
+			status: clusterv1alpha1.MachineDeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           2,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      2,
+				AvailableReplicas:  2,
+			},
+			expected: true,
+		},
+		{
+			name: "generation not observed",
+			status: clusterv1alpha1.MachineDeploymentStatus{
+				ObservedGeneration: 1,
+				Replicas:           2,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      2,
+				AvailableReplicas:  2,
+			},
+		},
+		{
+			name: "old machines not deleted",
+			status: clusterv1alpha1.MachineDeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           3,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      3,
+				AvailableReplicas:  3,
+			},
+		},
+		{
+			name: "new machines not ready",
+			status: clusterv1alpha1.MachineDeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           2,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      1,
+				AvailableReplicas:  1,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			md := &clusterv1alpha1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       clusterv1alpha1.MachineDeploymentSpec{Replicas: &replicas},
+				Status:     tc.status,
+			}
+			if got := machineDeploymentRolledOut(md); got != tc.expected {
+				t.Errorf("expected %v, but got %v", tc.expected, got)
+			}
+		})
+	}
+}
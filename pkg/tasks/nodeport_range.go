@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	kubeonev1beta1 "k8c.io/kubeone/pkg/apis/kubeone/v1beta1"
+	"k8c.io/kubeone/pkg/state"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const nodePortRangeFlag = "--service-node-port-range="
+
+// detectNodePortRanges returns the distinct NodePort ranges the kube-apiserver
+// instances run with. kube-apiserver defaults to the same range as KubeOne if
+// the flag is not set.
+func detectNodePortRanges(s *state.State) ([]string, error) {
+	if s.DynamicClient == nil {
+		return nil, errors.New("kubernetes dynamic client is not initialized")
+	}
+
+	pods := corev1.PodList{}
+	err := s.DynamicClient.List(s.Context, &pods, &dynclient.ListOptions{
+		Namespace: metav1.NamespaceSystem,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			"component": "kube-apiserver",
+		}),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list kube-apiserver pods")
+	}
+
+	found := map[string]bool{}
+	for _, pod := range pods.Items {
+		portRange := kubeonev1beta1.DefaultNodePortRange
+		for _, c := range pod.Spec.Containers[0].Command {
+			if strings.HasPrefix(c, nodePortRangeFlag) {
+				portRange = strings.TrimPrefix(c, nodePortRangeFlag)
+			}
+		}
+		found[portRange] = true
+	}
+
+	ranges := []string{}
+	for portRange := range found {
+		ranges = append(ranges, portRange)
+	}
+	sort.Strings(ranges)
+
+	return ranges, nil
+}
+
+// validateNodePortRange makes sure no Service is allocated the NodePort
+// outside of the new range. kube-apiserver doesn't reallocate such NodePorts,
+// and the Services would fail validation on every update.
+func validateNodePortRange(s *state.State) error {
+	first, last, err := kubeoneapi.ParseNodePortRange(s.Cluster.ClusterNetwork.NodePortRange)
+	if err != nil {
+		return err
+	}
+
+	services := corev1.ServiceList{}
+	if err = s.DynamicClient.List(s.Context, &services); err != nil {
+		return errors.Wrap(err, "unable to list services")
+	}
+
+	var outside []string
+	for _, svc := range services.Items {
+		for _, port := range svc.Spec.Ports {
+			if port.NodePort != 0 && (int(port.NodePort) < first || int(port.NodePort) > last) {
+				outside = append(outside, fmt.Sprintf("%s/%s:%d", svc.Namespace, svc.Name, port.NodePort))
+			}
+		}
+	}
+
+	if len(outside) > 0 {
+		return errors.Errorf("services are allocated NodePorts outside of the range %s: %s",
+			s.Cluster.ClusterNetwork.NodePortRange, strings.Join(outside, ", "))
+	}
+
+	return nil
+}
+
+func updateNodePortRange(s *state.State) error {
+	if err := s.RunTaskOnControlPlane(regenerateControlPlaneManifest("apiserver"), state.RunSequentially); err != nil {
+		return err
+	}
+
+	return uploadKubeadmConfig(s)
+}
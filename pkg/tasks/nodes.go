@@ -21,14 +21,17 @@ import (
 	"io"
 	"path/filepath"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/certificate/cabundle"
+	"k8c.io/kubeone/pkg/features"
 	"k8c.io/kubeone/pkg/scripts"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/ssh/sshiofs"
 	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/resources"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -140,6 +143,41 @@ func labelNodeOSes(s *state.State) error {
 	return nil
 }
 
+// labelStaticWorkerPools labels nodes of static workers in pools with the
+// pool name and the labels configured for the pool
+func labelStaticWorkerPools(s *state.State) error {
+	for _, host := range s.Cluster.StaticWorkers.Hosts {
+		pool := s.Cluster.StaticWorkers.Pool(host.Pool)
+		if pool == nil {
+			continue
+		}
+
+		nodeName := host.Hostname
+		updateErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			var node corev1.Node
+
+			if err := s.DynamicClient.Get(s.Context, types.NamespacedName{Name: nodeName}, &node); err != nil {
+				return err
+			}
+
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			for k, v := range pool.Labels {
+				node.Labels[k] = v
+			}
+			node.Labels[resources.StaticWorkerPoolNodeLabel] = pool.Name
+
+			return s.DynamicClient.Update(s.Context, &node)
+		})
+		if updateErr != nil {
+			return errors.Wrapf(updateErr, "failed to label node %q", nodeName)
+		}
+	}
+
+	return nil
+}
+
 func patchStaticPods(s *state.State) error {
 	return s.RunTaskOnControlPlane(func(ctx *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
 		s.Logger.Infoln("Patching static pods...")
@@ -210,3 +248,26 @@ func patchStaticPods(s *state.State) error {
 		return errors.Wrap(err, "failed to write kube-controller-manager.yaml")
 	}, state.RunParallel)
 }
+
+func ensureKubeletSeccompDefault(s *state.State) error {
+	s.Logger.Infoln("Ensuring kubelet SeccompDefault configuration...")
+
+	kubeVersion, err := semver.NewVersion(s.Cluster.Versions.Kubernetes)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse kubernetes version")
+	}
+
+	enable := s.Cluster.Features.SeccompDefault.Enable
+	featureGate := features.SeccompDefaultFeatureGateRequired(kubeVersion)
+
+	return s.RunTaskOnAllNodes(func(s *state.State, _ *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		cmd, err := scripts.KubeletSeccompDefault(enable, featureGate)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = s.Runner.RunRaw(cmd)
+
+		return err
+	}, state.RunSequentially)
+}
@@ -17,17 +17,21 @@ limitations under the License.
 package tasks
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/hooks"
 	"k8c.io/kubeone/pkg/scripts"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
 	"k8c.io/kubeone/pkg/templates"
 	"k8c.io/kubeone/pkg/templates/admissionconfig"
 	encryptionproviders "k8c.io/kubeone/pkg/templates/encryptionproviders"
+	"k8c.io/kubeone/pkg/templates/konnectivity"
 
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -45,18 +49,58 @@ func generateConfigurationFiles(s *state.State) error {
 		if err := s.Configuration.AddFilePath("cfg/audit-policy.yaml", s.Cluster.Features.StaticAuditLog.Config.PolicyFilePath, s.ManifestFilePath); err != nil {
 			return errors.Wrap(err, "unable to add policy file")
 		}
+		if webhook := s.Cluster.Features.StaticAuditLog.Config.Webhook; webhook != nil {
+			if err := s.Configuration.AddFilePath("cfg/audit-webhook-config.yaml", webhook.ConfigFilePath, s.ManifestFilePath); err != nil {
+				return errors.Wrap(err, "unable to add audit webhook config file")
+			}
+		}
 	}
-	if s.Cluster.Features.PodNodeSelector != nil && s.Cluster.Features.PodNodeSelector.Enable {
-		admissionCfg, err := admissionconfig.NewAdmissionConfig(s.Cluster.Versions.Kubernetes, s.Cluster.Features.PodNodeSelector)
+	podNodeSelector := s.Cluster.Features.PodNodeSelector != nil && s.Cluster.Features.PodNodeSelector.Enable
+	eventRateLimit := s.Cluster.Features.EventRateLimit != nil && s.Cluster.Features.EventRateLimit.Enable
+	podSecurityAdmission := s.Cluster.Features.PodSecurityAdmission != nil && s.Cluster.Features.PodSecurityAdmission.Enable
+
+	if podNodeSelector || eventRateLimit || podSecurityAdmission {
+		admissionCfg, err := admissionconfig.NewAdmissionConfig(s.Cluster.Versions.Kubernetes, s.Cluster.Features)
 		if err != nil {
 			return errors.Wrap(err, "failed to generate admissionconfiguration manifest")
 		}
 		s.Configuration.AddFile("cfg/admission-config.yaml", admissionCfg)
-
+	}
+	if podNodeSelector {
 		if err := s.Configuration.AddFilePath("cfg/podnodeselector.yaml", s.Cluster.Features.PodNodeSelector.Config.ConfigFilePath, s.ManifestFilePath); err != nil {
 			return errors.Wrap(err, "failed to add podnodeselector config file")
 		}
 	}
+	if eventRateLimit {
+		eventRateLimitCfg, err := admissionconfig.NewEventRateLimitConfig(s.Cluster.Features.EventRateLimit)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate eventratelimit config file")
+		}
+		s.Configuration.AddFile("cfg/eventratelimit.yaml", eventRateLimitCfg)
+	}
+	if podSecurityAdmission {
+		podSecurityCfg, err := admissionconfig.NewPodSecurityConfig(s.Cluster.Versions.Kubernetes, s.Cluster.Features.PodSecurityAdmission)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate podsecurity config file")
+		}
+		s.Configuration.AddFile("cfg/podsecurity.yaml", podSecurityCfg)
+	}
+
+	if s.Cluster.Features.Konnectivity.Enabled() {
+		egressSelectorCfg, err := konnectivity.EgressSelectorConfig()
+		if err != nil {
+			return errors.Wrap(err, "failed to generate egress selector config file")
+		}
+		s.Configuration.AddFile("cfg/egress-selector-config.yaml", egressSelectorCfg)
+	}
+
+	if s.Cluster.Features.AppArmor != nil && s.Cluster.Features.AppArmor.Enable {
+		for _, profile := range s.Cluster.Features.AppArmor.Profiles {
+			if err := s.Configuration.AddFilePath(fmt.Sprintf("cfg/apparmor/%s", profile.Name), profile.ProfileFilePath, s.ManifestFilePath); err != nil {
+				return errors.Wrapf(err, "unable to add AppArmor profile %q", profile.Name)
+			}
+		}
+	}
 
 	if s.ShouldEnableEncryption() || s.EncryptionEnabled() {
 		configFileName := s.GetEncryptionProviderConfigName()
@@ -81,80 +125,132 @@ func generateConfigurationFiles(s *state.State) error {
 	return nil
 }
 
+const (
+	// prerequisitesStep is name of the fingerprinted step installing the
+	// prerequisites on the node
+	prerequisitesStep = "prerequisites"
+)
+
 func installPrerequisitesOnNode(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
 	logger := s.Logger.WithField("os", node.OperatingSystem)
 
+	fingerprint, err := prerequisitesFingerprint(s, *node)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate prerequisites fingerprint")
+	}
+
+	// the fingerprint is ignored when forcing the installation, as the
+	// binaries are expected to be reinstalled in that case
+	if !s.ForceInstall && !s.NoStepCache {
+		current, err := readFingerprint(s, prerequisitesStep)
+		if err != nil {
+			return errors.Wrap(err, "failed to read prerequisites fingerprint")
+		}
+
+		if current == fingerprint {
+			logger.Infoln("Prerequisites are already installed, skipping...")
+			return nil
+		}
+	}
+
+	if err = runHostScripts(s, node, hooks.HostPreProvision); err != nil {
+		return err
+	}
+
 	logger.Infoln("Creating environment file...")
-	if err := createEnvironmentFile(s); err != nil {
+	if err = createEnvironmentFile(s); err != nil {
 		return errors.Wrap(err, "failed to create environment file")
 	}
 
 	logger.Infoln("Configuring proxy...")
-	if err := configureProxy(s); err != nil {
+	if err = configureProxy(s); err != nil {
 		return errors.Wrap(err, "failed to configure proxy for docker daemon")
 	}
 
 	logger.Infoln("Installing kubeadm...")
-	return errors.Wrap(installKubeadm(s, *node), "failed to install kubeadm")
+	if err = installKubeadm(s, *node); err != nil {
+		return errors.Wrap(err, "failed to install kubeadm")
+	}
+
+	return errors.Wrap(saveFingerprint(s, prerequisitesStep, fingerprint), "failed to save prerequisites fingerprint")
 }
 
-func createEnvironmentFile(s *state.State) error {
-	cmd, err := scripts.EnvironmentFile(s.Cluster)
+// prerequisitesFingerprint returns a hash of all scripts run to install the
+// prerequisites on the node. Any change in the cluster configuration
+// affecting the prerequisites, such as the Kubernetes version, the container
+// runtime or the proxy settings, results in a different fingerprint.
+func prerequisitesFingerprint(s *state.State, node kubeoneapi.HostConfig) (string, error) {
+	envCmd, proxyCmd, installCmd, err := prerequisitesScripts(s, node)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, _, err = s.Runner.RunRaw(cmd)
+	return fingerprintOf(string(node.OperatingSystem), envCmd, proxyCmd, installCmd), nil
+}
 
-	return err
+// prerequisitesScripts returns the scripts creating the environment file,
+// configuring the proxy and installing kubeadm on the node
+func prerequisitesScripts(s *state.State, node kubeoneapi.HostConfig) (envCmd, proxyCmd, installCmd string, err error) {
+	envCmd, err = scripts.EnvironmentFile(s.Cluster)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	proxyCmd, err = scripts.DaemonsProxy()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	installCmd, err = installKubeadmScript(s, node)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return envCmd, proxyCmd, installCmd, nil
 }
 
-func installKubeadm(s *state.State, node kubeoneapi.HostConfig) error {
-	return runOnOS(s, node.OperatingSystem, map[kubeoneapi.OperatingSystemName]runOnOSFn{
-		kubeoneapi.OperatingSystemNameAmazon:  installKubeadmAmazonLinux,
-		kubeoneapi.OperatingSystemNameCentOS:  installKubeadmCentOS,
-		kubeoneapi.OperatingSystemNameDebian:  installKubeadmDebian,
-		kubeoneapi.OperatingSystemNameFlatcar: installKubeadmFlatcar,
-		kubeoneapi.OperatingSystemNameRHEL:    installKubeadmCentOS,
-		kubeoneapi.OperatingSystemNameUbuntu:  installKubeadmDebian,
-	})
+func fingerprintOf(parts ...string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(parts, "\x00"))))
 }
 
-func installKubeadmDebian(s *state.State) error {
-	cmd, err := scripts.KubeadmDebian(s.Cluster, s.ForceInstall)
+func readFingerprint(s *state.State, step string) (string, error) {
+	cmd, err := scripts.ReadFingerprint(step)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, _, err = s.Runner.RunRaw(cmd)
+	stdout, _, err := s.Runner.RunRaw(cmd)
+	if err != nil {
+		return "", err
+	}
 
-	return errors.WithStack(err)
+	return strings.TrimSpace(stdout), nil
 }
 
-func installKubeadmCentOS(s *state.State) error {
-	cmd, err := scripts.KubeadmCentOS(s.Cluster, s.ForceInstall)
+func saveFingerprint(s *state.State, step, fingerprint string) error {
+	cmd, err := scripts.SaveFingerprint(step, fingerprint)
 	if err != nil {
 		return err
 	}
 
 	_, _, err = s.Runner.RunRaw(cmd)
 
-	return errors.WithStack(err)
+	return err
 }
 
-func installKubeadmAmazonLinux(s *state.State) error {
-	cmd, err := scripts.KubeadmAmazonLinux(s.Cluster, s.ForceInstall)
+func createEnvironmentFile(s *state.State) error {
+	cmd, err := scripts.EnvironmentFile(s.Cluster)
 	if err != nil {
 		return err
 	}
 
 	_, _, err = s.Runner.RunRaw(cmd)
 
-	return errors.WithStack(err)
+	return err
 }
 
-func installKubeadmFlatcar(s *state.State) error {
-	cmd, err := scripts.KubeadmFlatcar(s.Cluster)
+func installKubeadm(s *state.State, node kubeoneapi.HostConfig) error {
+	cmd, err := installKubeadmScript(s, node)
 	if err != nil {
 		return err
 	}
@@ -164,6 +260,21 @@ func installKubeadmFlatcar(s *state.State) error {
 	return errors.WithStack(err)
 }
 
+func installKubeadmScript(s *state.State, node kubeoneapi.HostConfig) (string, error) {
+	switch node.OperatingSystem {
+	case kubeoneapi.OperatingSystemNameAmazon:
+		return scripts.KubeadmAmazonLinux(s.Cluster, s.ForceInstall)
+	case kubeoneapi.OperatingSystemNameCentOS, kubeoneapi.OperatingSystemNameRHEL:
+		return scripts.KubeadmCentOS(s.Cluster, s.ForceInstall)
+	case kubeoneapi.OperatingSystemNameDebian, kubeoneapi.OperatingSystemNameUbuntu:
+		return scripts.KubeadmDebian(s.Cluster, s.ForceInstall)
+	case kubeoneapi.OperatingSystemNameFlatcar:
+		return scripts.KubeadmFlatcar(s.Cluster)
+	}
+
+	return "", errors.Errorf("%q is not a supported operating system", node.OperatingSystem)
+}
+
 func uploadConfigurationFiles(s *state.State) error {
 	return s.RunTaskOnAllNodes(uploadConfigurationFilesToNode, state.RunParallel)
 }
@@ -175,45 +286,45 @@ func uploadConfigurationFilesToNode(s *state.State, node *kubeoneapi.HostConfig,
 		return errors.Wrap(err, "failed to upload")
 	}
 
-	cmd, err := scripts.SaveCloudConfig(s.WorkDir)
+	cmds, err := saveConfigurationFilesScripts(s)
 	if err != nil {
 		return err
 	}
 
 	// move config files to their permanent locations
-	_, _, err = s.Runner.RunRaw(cmd)
-	if err != nil {
-		return err
+	for _, cmd := range cmds {
+		if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+			return err
+		}
 	}
 
-	cmd, err = scripts.SaveAuditPolicyConfig(s.WorkDir)
-	if err != nil {
-		return err
-	}
-	_, _, err = s.Runner.RunRaw(cmd)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	cmd, err = scripts.SavePodNodeSelectorConfig(s.WorkDir)
-	if err != nil {
-		return err
-	}
-	_, _, err = s.Runner.RunRaw(cmd)
-	if err != nil {
-		return err
+// saveConfigurationFilesScripts returns the scripts moving the uploaded
+// configuration files to their permanent locations
+func saveConfigurationFilesScripts(s *state.State) ([]string, error) {
+	generators := []func() (string, error){
+		func() (string, error) { return scripts.SaveCloudConfig(s.WorkDir) },
+		func() (string, error) { return scripts.SaveAuditPolicyConfig(s.WorkDir) },
+		func() (string, error) { return scripts.SaveAdmissionConfig(s.WorkDir) },
+		func() (string, error) { return scripts.SaveKonnectivityConfig(s.WorkDir) },
+		func() (string, error) {
+			return scripts.SaveEncryptionProvidersConfig(s.WorkDir, s.GetEncryptionProviderConfigName())
+		},
+		func() (string, error) { return scripts.SaveAppArmorProfiles(s.WorkDir) },
 	}
 
-	cmd, err = scripts.SaveEncryptionProvidersConfig(s.WorkDir, s.GetEncryptionProviderConfigName())
-	if err != nil {
-		return err
-	}
-	_, _, err = s.Runner.RunRaw(cmd)
-	if err != nil {
-		return err
+	cmds := make([]string, 0, len(generators))
+	for _, generate := range generators {
+		cmd, err := generate()
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, cmd)
 	}
 
-	return nil
+	return cmds, nil
 }
 
 func configureProxy(s *state.State) error {
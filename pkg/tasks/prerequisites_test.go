@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"flag"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/configupload"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+var updateFlag = flag.Bool("update", false, "update testdata files")
+
+// TestAppArmorProfilesUpload renders the paths the AppArmor profiles are
+// uploaded to along with the scripts installing them, which must agree
+func TestAppArmorProfilesUpload(t *testing.T) {
+	s := &state.State{
+		Cluster: &kubeoneapi.KubeOneCluster{
+			Versions: kubeoneapi.VersionConfig{Kubernetes: "1.22.2"},
+			Features: kubeoneapi.Features{
+				AppArmor: &kubeoneapi.AppArmor{
+					Enable: true,
+					Profiles: []kubeoneapi.AppArmorProfile{
+						{Name: "k8s-nginx", ProfileFilePath: "apparmor-k8s-nginx"},
+					},
+				},
+			},
+		},
+		LiveCluster: &state.Cluster{
+			EncryptionConfiguration: &state.EncryptionConfiguration{},
+		},
+		Configuration:    configupload.NewConfiguration(),
+		ManifestFilePath: "testdata/kubeone.yaml",
+		WorkDir:          "./kubeone",
+	}
+
+	if err := generateConfigurationFiles(s); err != nil {
+		t.Fatalf("generateConfigurationFiles() error = %v", err)
+	}
+
+	var uploaded []string
+	for _, filename := range s.Configuration.Filenames() {
+		uploaded = append(uploaded, filepath.Join(s.WorkDir, filename))
+	}
+	sort.Strings(uploaded)
+
+	cmds, err := saveConfigurationFilesScripts(s)
+	if err != nil {
+		t.Fatalf("saveConfigurationFilesScripts() error = %v", err)
+	}
+
+	got := "# uploaded files\n" + strings.Join(uploaded, "\n") + "\n\n" + strings.Join(cmds, "\n")
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), got, *updateFlag)
+}
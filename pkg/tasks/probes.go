@@ -26,6 +26,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/apis/kubeone/validation"
 	"k8c.io/kubeone/pkg/clusterstatus/apiserverstatus"
 	"k8c.io/kubeone/pkg/clusterstatus/etcdstatus"
 	"k8c.io/kubeone/pkg/kubeconfig"
@@ -36,6 +37,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -83,6 +85,16 @@ func safeguard(s *state.State) error {
 		}
 	}
 
+	// Block enabling the FIPS mode on the cluster encrypting the secrets with
+	// the provider which is not FIPS-approved
+	if s.Cluster.Features.FIPS != nil && s.Cluster.Features.FIPS.Enable &&
+		s.LiveCluster.EncryptionEnabled() && s.LiveCluster.EncryptionConfiguration.Config != nil {
+		fldPath := field.NewPath("encryptionConfiguration")
+		if errs := validation.ValidateFIPSEncryptionConfiguration(s.LiveCluster.EncryptionConfiguration.Config, fldPath); len(errs) > 0 {
+			return errors.Wrap(errs.ToAggregate(), "encryption configuration of the cluster is not FIPS-compliant, rotate the encryption key using the FIPS-approved provider first")
+		}
+	}
+
 	// Block kubeone apply if .cloudProvider.external is enabled on cluster with
 	// in-tree cloud provider, but with no external CCM
 	st := s.LiveCluster.CCMStatus
@@ -141,6 +153,8 @@ func runProbes(s *state.State) error {
 	switch {
 	case s.Cluster.ContainerRuntime.Containerd != nil:
 		return nil
+	case s.Cluster.ContainerRuntime.CRIO != nil:
+		return nil
 	case s.Cluster.ContainerRuntime.Docker != nil:
 		return nil
 	}
@@ -170,6 +184,10 @@ func versionCmdGenerator(execPath string) string {
 	return fmt.Sprintf("%s --version | awk '{print $3}' | awk -F - '{print $1}'  | awk -F , '{print $1}'", execPath)
 }
 
+func crioVersionCmdGenerator(execPath string) string {
+	return fmt.Sprintf("%s --version | head -1 | awk '{print $3}'", execPath)
+}
+
 func kubeletVersionCmdGenerator(execPath string) string {
 	return fmt.Sprintf("%s --version | awk '{print $2}'", execPath)
 }
@@ -226,6 +244,11 @@ func investigateHost(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Conne
 		return err
 	}
 
+	foundHost.ContainerRuntimeCRIO, err = systemdUnitInfo("crio", conn, withComponentVersion(crioVersionCmdGenerator))
+	if err != nil {
+		return err
+	}
+
 	foundHost.Kubelet, err = systemdUnitInfo("kubelet", conn, withComponentVersion(kubeletVersionCmdGenerator))
 	if err != nil {
 		return err
@@ -236,10 +259,17 @@ func investigateHost(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Conne
 	}
 
 	if foundHost.Initialized() && controlPlane {
-		foundHost.EarliestCertExpiry, err = earliestCertExpiry(conn)
+		foundHost.EarliestCertExpiry, err = earliestCertExpiry(conn, s.Cluster.LocalEtcd())
 		if err != nil {
 			return err
 		}
+
+		if s.Cluster.LocalEtcd() {
+			foundHost.EtcdServerCertSANs, err = etcdServerCertSANs(conn)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	s.LiveCluster.Lock.Lock()
@@ -252,6 +282,34 @@ func investigateHost(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Conne
 	return nil
 }
 
+// investigateEtcd sets the status of the etcd members stacked on the control
+// plane nodes. The external etcd is not managed by KubeOne, so its members are
+// considered running and its health is left to the external etcd operators.
+func investigateEtcd(s *state.State) error {
+	if !s.Cluster.LocalEtcd() {
+		for i := range s.LiveCluster.ControlPlane {
+			s.LiveCluster.ControlPlane[i].Etcd.Status |= state.PodRunning
+		}
+
+		return nil
+	}
+
+	etcdMembers, err := etcdstatus.MemberList(s)
+	if err != nil {
+		return err
+	}
+	for i := range s.LiveCluster.ControlPlane {
+		etcdStatus, _ := etcdstatus.Get(s, *s.LiveCluster.ControlPlane[i].Config, etcdMembers)
+		if etcdStatus != nil {
+			if etcdStatus.Member && etcdStatus.Health {
+				s.LiveCluster.ControlPlane[i].Etcd.Status |= state.PodRunning
+			}
+		}
+	}
+
+	return nil
+}
+
 func investigateCluster(s *state.State) error {
 	if !s.LiveCluster.IsProvisioned() {
 		return errors.New("unable to investigate non-provisioned cluster")
@@ -282,18 +340,10 @@ func investigateCluster(s *state.State) error {
 		return errors.New("leader not elected, quorum mostly like lost")
 	}
 
-	etcdMembers, err := etcdstatus.MemberList(s)
+	err := investigateEtcd(s)
 	if err != nil {
 		return err
 	}
-	for i := range s.LiveCluster.ControlPlane {
-		etcdStatus, _ := etcdstatus.Get(s, *s.LiveCluster.ControlPlane[i].Config, etcdMembers)
-		if etcdStatus != nil {
-			if etcdStatus.Member && etcdStatus.Health {
-				s.LiveCluster.ControlPlane[i].Etcd.Status |= state.PodRunning
-			}
-		}
-	}
 	s.LiveCluster.Lock.Unlock()
 
 	if s.DynamicClient == nil {
@@ -359,6 +409,37 @@ func investigateCluster(s *state.State) error {
 		}
 	}
 
+	nodePortRanges, err := detectNodePortRanges(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to detect the NodePort range")
+	}
+	s.LiveCluster.Lock.Lock()
+	s.LiveCluster.NodePortRanges = nodePortRanges
+	s.LiveCluster.Lock.Unlock()
+
+	clusterCIDRs, err := detectClusterCIDRs(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to detect the cluster CIDR")
+	}
+	s.LiveCluster.Lock.Lock()
+	s.LiveCluster.ClusterCIDRs = clusterCIDRs
+	s.LiveCluster.Lock.Unlock()
+
+	etcdSettings, err := detectEtcdSettings(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to detect the etcd settings")
+	}
+	if !s.Cluster.LocalEtcd() && len(etcdSettings) > 0 {
+		return errors.New("the cluster runs the etcd stacked on the control plane nodes, migrating to the external etcd is not supported")
+	}
+	s.LiveCluster.Lock.Lock()
+	s.LiveCluster.EtcdSettings = etcdSettings
+	s.LiveCluster.Lock.Unlock()
+
+	if err = detectClusterInfo(s); err != nil {
+		return errors.Wrap(err, "failed to read cluster info")
+	}
+
 	ccmStatus, err := detectCCMMigrationStatus(s)
 	if err != nil {
 		return errors.Wrap(err, "failed to check is in-tree cloud provider enabled")
@@ -542,6 +623,22 @@ func detectEncryptionProvidersEnabled(s *state.State) (ees encryptionEnabledStat
 	return ees, nil
 }
 
+// probeCCMMigrationStatus detects the CCM migration status without running
+// the full probes, so it can be reported by the status command
+func probeCCMMigrationStatus(s *state.State) error {
+	ccmStatus, err := detectCCMMigrationStatus(s)
+	if err != nil {
+		return err
+	}
+
+	if s.LiveCluster == nil {
+		s.LiveCluster = &state.Cluster{}
+	}
+	s.LiveCluster.CCMStatus = ccmStatus
+
+	return nil
+}
+
 func detectCCMMigrationStatus(s *state.State) (*state.CCMStatus, error) {
 	if s.DynamicClient == nil {
 		return nil, errors.New("kubernetes dynamic client is not initialized")
@@ -571,9 +668,10 @@ func detectCCMMigrationStatus(s *state.State) (*state.CCMStatus, error) {
 				if csiFlagRegex.MatchString(c) {
 					status.CSIMigrationEnabled = true
 				}
-				unregister := s.Cluster.InTreePluginUnregisterFeatureGate()
-				if unregister != "" && strings.Contains(c, fmt.Sprintf("%s=true", unregister)) {
-					status.InTreeCloudProviderUnregistered = true
+				for _, unregister := range s.Cluster.InTreePluginUnregisterFeatureGates() {
+					if strings.Contains(c, fmt.Sprintf("%s=true", unregister)) {
+						status.InTreeCloudProviderUnregistered = true
+					}
 				}
 			}
 		}
@@ -582,6 +680,12 @@ func detectCCMMigrationStatus(s *state.State) (*state.CCMStatus, error) {
 	ccmLabel := ""
 	ccmLabelValue := ""
 	switch {
+	case s.Cluster.CloudProvider.AWS != nil:
+		ccmLabel = "k8s-app"
+		ccmLabelValue = "aws-cloud-controller-manager"
+	case s.Cluster.CloudProvider.Azure != nil:
+		ccmLabel = "k8s-app"
+		ccmLabelValue = "azure-cloud-controller-manager"
 	case s.Cluster.CloudProvider.Openstack != nil:
 		ccmLabel = "k8s-app"
 		ccmLabelValue = "openstack-cloud-controller-manager"
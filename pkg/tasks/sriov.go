@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/scripts"
+	"k8c.io/kubeone/pkg/ssh"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// ensureSRIOV configures the virtual functions on the hosts listed in the
+// SR-IOV feature, and labels their nodes so the device plugin and CNI plugin
+// are scheduled on them
+func ensureSRIOV(s *state.State) error {
+	s.Logger.Infoln("Configuring SR-IOV virtual functions...")
+
+	sriov := s.Cluster.Features.SRIOV
+
+	err := s.RunTaskOnAllNodes(func(s *state.State, node *kubeoneapi.HostConfig, _ ssh.Connection) error {
+		hostConfig := sriov.HostConfig(*node)
+		if hostConfig == nil {
+			return nil
+		}
+
+		cmd, err := scripts.SRIOV(sriov.KernelParameters, hostConfig.PhysicalFunctions)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = s.Runner.RunRaw(cmd)
+
+		return err
+	}, state.RunParallel)
+	if err != nil {
+		return err
+	}
+
+	for _, host := range append(s.Cluster.ControlPlane.Hosts, s.Cluster.StaticWorkers.Hosts...) {
+		if sriov.HostConfig(host) == nil {
+			continue
+		}
+
+		nodeName := host.Hostname
+		updateErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			var node corev1.Node
+
+			if err := s.DynamicClient.Get(s.Context, types.NamespacedName{Name: nodeName}, &node); err != nil {
+				return err
+			}
+
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[resources.SRIOVNodeLabel] = "true"
+
+			return s.DynamicClient.Update(s.Context, &node)
+		})
+		if updateErr != nil {
+			return errors.Wrapf(updateErr, "failed to label node %q", nodeName)
+		}
+	}
+
+	return nil
+}
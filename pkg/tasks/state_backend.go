@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/kubeconfig"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/statebackend"
+
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewStateBackend returns the configured state backend of the cluster. The
+// Kubernetes Secret backend connects to the cluster on the first access.
+func NewStateBackend(s *state.State, cfg *kubeoneapi.StateBackend) (statebackend.Backend, error) {
+	return statebackend.New(cfg, s.Cluster.Name, statebackend.WithKubernetesClient(func() (dynclient.Client, error) {
+		if s.DynamicClient == nil {
+			if err := kubeconfig.BuildKubernetesClientset(s); err != nil {
+				return nil, err
+			}
+		}
+
+		return s.DynamicClient, nil
+	}))
+}
+
+// uploadState stores the rendered configuration files, the PKI backup and
+// the checkpoint of this apply to the configured state backend
+func uploadState(s *state.State) error {
+	backend, err := NewStateBackend(s, s.Cluster.StateBackend)
+	if err != nil {
+		return err
+	}
+
+	s.Logger.Info("Uploading state to the state backend...")
+
+	checkpoint := &statebackend.Checkpoint{
+		KubeOneVersion: s.KubeOneVersion,
+		ManifestHash:   s.ManifestHash,
+		AppliedAt:      time.Now(),
+	}
+
+	for _, host := range s.Cluster.ControlPlane.Hosts {
+		checkpoint.Hosts = append(checkpoint.Hosts, checkpointHost(host, true))
+	}
+	for _, host := range s.Cluster.StaticWorkers.Hosts {
+		checkpoint.Hosts = append(checkpoint.Hosts, checkpointHost(host, false))
+	}
+
+	for _, filename := range s.Configuration.Filenames() {
+		content, err := s.Configuration.Get(filename)
+		if err != nil {
+			return err
+		}
+
+		if err = backend.Put(s.Context, statebackend.ConfigKey(filename), []byte(content)); err != nil {
+			return err
+		}
+		checkpoint.Configs = append(checkpoint.Configs, filename)
+	}
+
+	backup, err := pkiBackup(s)
+	if err != nil {
+		return err
+	}
+	if err = backend.Put(s.Context, statebackend.PKIBackupKey, backup); err != nil {
+		return err
+	}
+
+	return statebackend.PutCheckpoint(s.Context, backend, checkpoint)
+}
+
+func checkpointHost(host kubeoneapi.HostConfig, controlPlane bool) statebackend.CheckpointHost {
+	return statebackend.CheckpointHost{
+		ID:             host.ID,
+		PublicAddress:  host.PublicAddress,
+		PrivateAddress: host.PrivateAddress,
+		Hostname:       host.Hostname,
+		ControlPlane:   controlPlane,
+	}
+}
+
+// pkiBackup returns the archive with the configuration files and the PKI,
+// the same as the local backup
+func pkiBackup(s *state.State) ([]byte, error) {
+	dir, err := ioutil.TempDir("", "kubeone-state")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer os.RemoveAll(dir)
+
+	target := filepath.Join(dir, statebackend.PKIBackupKey)
+	if err = s.Configuration.Backup(target); err != nil {
+		return nil, errors.Wrap(err, "failed to create PKI backup")
+	}
+
+	backup, err := ioutil.ReadFile(target)
+
+	return backup, errors.WithStack(err)
+}
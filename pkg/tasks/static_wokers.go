@@ -18,6 +18,7 @@ package tasks
 
 import (
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/hooks"
 	"k8c.io/kubeone/pkg/scripts"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
@@ -36,6 +37,9 @@ func joinStaticWorkerInternal(s *state.State, node *kubeoneapi.HostConfig, conn
 		return err
 	}
 
-	_, _, err = s.Runner.RunRaw(cmd)
-	return err
+	if _, _, err = s.Runner.RunRaw(cmd); err != nil {
+		return err
+	}
+
+	return runHostScripts(s, node, hooks.HostPostJoin)
 }
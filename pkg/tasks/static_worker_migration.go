@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/kubeconfig"
+	"k8c.io/kubeone/pkg/nodeutils"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/machinecontroller"
+
+	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	errorsutil "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WithStaticWorkerMigration moves the workload of the static worker with the
+// given address or hostname to the machine-controller managed workers. If
+// the workerset name is given, its MachineDeployment is created and rolled
+// out first, so the workload of the static worker has a place to run.
+// The static worker is then drained, reset and its Node object is deleted.
+func WithStaticWorkerMigration(t Tasks, host, workerset string) Tasks {
+	return WithHostnameOS(t).
+		append(Tasks{
+			{Fn: kubeconfig.BuildKubernetesClientset, ErrMsg: "failed to build kubernetes clientset"},
+			{
+				Fn: func(s *state.State) error {
+					return createMigrationMachineDeployment(s, workerset)
+				},
+				ErrMsg:    "failed to create MachineDeployment",
+				Predicate: func(*state.State) bool { return workerset != "" },
+			},
+			{
+				Fn: func(s *state.State) error {
+					return removeStaticWorker(s, host)
+				},
+				ErrMsg: "failed to remove the static worker",
+			},
+		}...)
+}
+
+// findStaticWorker returns the static worker matching the public address,
+// the private address or the hostname
+func findStaticWorker(hosts []kubeoneapi.HostConfig, host string) (kubeoneapi.HostConfig, error) {
+	for _, h := range hosts {
+		if h.PublicAddress == host || h.PrivateAddress == host || h.Hostname == host {
+			return h, nil
+		}
+	}
+
+	return kubeoneapi.HostConfig{}, errors.Errorf("static worker %q not found", host)
+}
+
+func createMigrationMachineDeployment(s *state.State, workerset string) error {
+	s.Logger.Infof("Creating MachineDeployment %q...", workerset)
+
+	if err := machinecontroller.CreateMachineDeployment(s, workerset); err != nil {
+		return err
+	}
+
+	s.Logger.Infof("Waiting for MachineDeployment %q to roll out...", workerset)
+
+	machineKey := dynclient.ObjectKey{Name: workerset, Namespace: metav1.NamespaceSystem}
+	return wait.Poll(10*time.Second, machineDeploymentRolloutTimeout, func() (bool, error) {
+		md := clusterv1alpha1.MachineDeployment{}
+		if err := s.DynamicClient.Get(s.Context, machineKey, &md); err != nil {
+			return false, err
+		}
+
+		return machineDeploymentRolledOut(&md), nil
+	})
+}
+
+func removeStaticWorker(s *state.State, host string) error {
+	node, err := findStaticWorker(s.Cluster.StaticWorkers.Hosts, host)
+	if err != nil {
+		return err
+	}
+
+	logger := s.Logger.WithField("node", node.PublicAddress)
+
+	proceed, err := s.ConfirmStep("remove the static worker node %s from the cluster", node.PublicAddress)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		logger.Warnln("Skipping removal of the static worker...")
+		return nil
+	}
+
+	drainer := nodeutils.NewDrainer(s.RESTConfig, logger)
+
+	logger.Infoln("Cordoning static worker node...")
+	if err = drainer.Cordon(s.Context, node.Hostname, true); err != nil {
+		return errors.Wrap(err, "failed to cordon static worker node")
+	}
+
+	logger.Infoln("Draining static worker node...")
+	if err = drainer.Drain(s.Context, node.Hostname); err != nil {
+		return errors.Wrap(err, "failed to drain static worker node")
+	}
+
+	logger.Infoln("Resetting static worker node...")
+	if err = s.RunTaskOnNodes([]kubeoneapi.HostConfig{node}, resetNode, state.RunSequentially); err != nil {
+		return errors.Wrap(err, "failed to reset static worker node")
+	}
+
+	logger.Infoln("Deleting static worker Node object...")
+	nodeObj := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: node.Hostname},
+	}
+	if err = s.DynamicClient.Delete(s.Context, nodeObj); err != nil && !errorsutil.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete Node %s", node.Hostname)
+	}
+
+	logger.Warnln("The static worker is removed from the cluster. Remove it from the staticWorkers in the KubeOne manifest,")
+	logger.Warnln("otherwise the next 'kubeone apply' joins it to the cluster again.")
+
+	return nil
+}
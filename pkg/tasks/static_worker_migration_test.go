@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+func TestFindStaticWorker(t *testing.T) {
+	hosts := []kubeoneapi.HostConfig{
+		{PublicAddress: "1.1.1.1", PrivateAddress: "10.0.0.1", Hostname: "worker-1"},
+		{PublicAddress: "1.1.1.2", PrivateAddress: "10.0.0.2", Hostname: "worker-2"},
+	}
+
+	tests := []struct {
+		name          string
+		host          string
+		expected      string
+		expectedError bool
+	}{
+		{name: "public address", host: "1.1.1.2", expected: "worker-2"},
+		{name: "private address", host: "10.0.0.1", expected: "worker-1"},
+		{name: "hostname", host: "worker-2", expected: "worker-2"},
+		{name: "unknown host", host: "1.1.1.3", expectedError: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := findStaticWorker(hosts, tc.host)
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error %v, but got %v", tc.expectedError, err)
+			}
+			if got.Hostname != tc.expected {
+				t.Errorf("expected %q, but got %q", tc.expected, got.Hostname)
+			}
+		})
+	}
+}
@@ -17,8 +17,15 @@ limitations under the License.
 package tasks
 
 import (
+	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/metrics"
+	"k8c.io/kubeone/pkg/runner"
 	"k8c.io/kubeone/pkg/state"
 
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -33,6 +40,24 @@ func defaultRetryBackoff(retries int) wait.Backoff {
 	}
 }
 
+// TaskScope describes on which hosts the task is executed
+type TaskScope int
+
+const (
+	// ScopeCluster tasks are run once, e.g. against the Kubernetes API
+	ScopeCluster TaskScope = iota
+	// ScopeAllNodes tasks are run on all control plane and static worker nodes
+	ScopeAllNodes
+	// ScopeControlPlane tasks are run on all control plane nodes
+	ScopeControlPlane
+	// ScopeLeader tasks are run on the leader control plane node
+	ScopeLeader
+	// ScopeFollowers tasks are run on the follower control plane nodes
+	ScopeFollowers
+	// ScopeStaticWorkers tasks are run on the static worker nodes
+	ScopeStaticWorkers
+)
+
 // Task is a runnable task
 type Task struct {
 	Fn          func(*state.State) error
@@ -40,6 +65,44 @@ type Task struct {
 	Description string
 	ErrMsg      string
 	Retries     int
+	Scope       TaskScope
+	// Resumable tasks completed by the interrupted apply are skipped when
+	// resuming it. Tasks populating the State used by the subsequent tasks
+	// must not be resumable.
+	Resumable bool
+}
+
+// hosts returns the hosts the task is executed on, or nil for the cluster
+// scoped tasks
+func (t *Task) hosts(s *state.State) []kubeoneapi.HostConfig {
+	switch t.Scope {
+	case ScopeAllNodes:
+		hosts := append([]kubeoneapi.HostConfig{}, s.Cluster.ControlPlane.Hosts...)
+		return append(hosts, s.Cluster.StaticWorkers.Hosts...)
+	case ScopeControlPlane:
+		return s.Cluster.ControlPlane.Hosts
+	case ScopeLeader:
+		leader, err := s.Cluster.Leader()
+		if err != nil {
+			return nil
+		}
+		return []kubeoneapi.HostConfig{leader}
+	case ScopeFollowers:
+		return s.Cluster.Followers()
+	case ScopeStaticWorkers:
+		return s.Cluster.StaticWorkers.Hosts
+	}
+
+	return nil
+}
+
+// contextErr returns the error of the State context if it's cancelled
+func contextErr(s *state.State) error {
+	if s.Context == nil {
+		return nil
+	}
+
+	return s.Context.Err()
 }
 
 // Run runs a task
@@ -50,15 +113,47 @@ func (t *Task) Run(s *state.State) error {
 
 	backoff := defaultRetryBackoff(t.Retries)
 
+	// every log line of the task is attributed to it in the structured logs
+	if s.StructuredLogs {
+		logger := s.Logger
+		s.Logger = logger.WithField("task", t.label())
+		defer func() { s.Logger = logger }()
+	}
+
+	// the commands run by the task are retried using the task's policy
+	commandRetry, err := runner.NewRetryPolicy(s.Cluster.CommandRetries, strings.ReplaceAll(t.label(), " ", "-"))
+	if err != nil {
+		return err
+	}
+	defer func(retry *runner.RetryPolicy) { s.CommandRetry = retry }(s.CommandRetry)
+	s.CommandRetry = commandRetry
+
+	start := time.Now()
+	attempts := 0
+
 	var lastError error
-	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+	err = wait.ExponentialBackoff(backoff, func() (bool, error) {
 		if lastError != nil {
 			s.Logger.Warn("Retrying task...")
 		}
 
+		if err := contextErr(s); err != nil {
+			return false, err
+		}
+
+		attempts++
 		lastError = t.Fn(s)
+		if errors.Is(lastError, state.ErrAborted) {
+			// the user asked to stop, retrying would ask again
+			return false, lastError
+		}
+		if err := contextErr(s); err != nil {
+			// the operation must not go on, such as when the cluster lock is lost
+			return false, err
+		}
 		if lastError != nil {
 			s.Logger.Warnf("Task failed, error was: %s", lastError)
+			metrics.TaskFailed(s.Cluster.Name, t.label())
 			return false, nil
 		}
 		return true, nil
@@ -66,5 +161,12 @@ func (t *Task) Run(s *state.State) error {
 	if err == wait.ErrWaitTimeout {
 		err = lastError
 	}
+
+	s.LogEvent("task", start, err, logrus.Fields{"task": t.label(), "attempts": attempts})
+	metrics.ObserveTask(s.Cluster.Name, t.label(), time.Since(start), err)
+	if err != nil {
+		s.FailedTasks = append(s.FailedTasks, t.label())
+	}
+
 	return err
 }
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/state"
+)
+
+func TestTaskRunAborted(t *testing.T) {
+	s := &state.State{
+		Logger:  logrus.New(),
+		Cluster: &kubeoneapi.KubeOneCluster{Name: "test"},
+	}
+
+	calls := 0
+	task := Task{
+		Fn: func(*state.State) error {
+			calls++
+			return errors.Wrap(state.ErrAborted, "before the step to drain the node")
+		},
+		ErrMsg:  "failed to upgrade",
+		Retries: 3,
+	}
+
+	err := task.Run(s)
+	if !errors.Is(err, state.ErrAborted) {
+		t.Fatalf("expected the aborted error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the aborted task to run once, it ran %d times", calls)
+	}
+}
+
+func TestTasksRunContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &state.State{
+		Logger:  logrus.New(),
+		Cluster: &kubeoneapi.KubeOneCluster{Name: "test"},
+		Context: ctx,
+	}
+
+	var ran []string
+	tasks := Tasks{
+		{
+			Fn: func(*state.State) error {
+				ran = append(ran, "first")
+				// the lock is lost while the first task runs
+				cancel()
+				return nil
+			},
+			ErrMsg: "failed to run the first task",
+		},
+		{
+			Fn: func(*state.State) error {
+				ran = append(ran, "second")
+				return nil
+			},
+			ErrMsg: "failed to run the second task",
+		},
+	}
+
+	err := tasks.Run(s)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled context error, got %v", err)
+	}
+	if len(ran) != 1 {
+		t.Errorf("expected only the first task to run, but ran %v", ran)
+	}
+}
+
+func TestTaskRunContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &state.State{
+		Logger:  logrus.New(),
+		Cluster: &kubeoneapi.KubeOneCluster{Name: "test"},
+		Context: ctx,
+	}
+
+	calls := 0
+	task := Task{
+		Fn: func(*state.State) error {
+			calls++
+			cancel()
+			return errors.New("connection reset")
+		},
+		ErrMsg:  "failed to upgrade",
+		Retries: 3,
+	}
+
+	if err := task.Run(s); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled context error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the task not to be retried after the context is cancelled, it ran %d times", calls)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"k8c.io/kubeone/pkg/templates/csi"
 	"k8c.io/kubeone/pkg/templates/externalccm"
 	"k8c.io/kubeone/pkg/templates/machinecontroller"
+	"k8c.io/kubeone/pkg/templates/operatingsystemmanager"
 	"k8c.io/kubeone/pkg/templates/resources"
 )
 
@@ -36,12 +37,31 @@ type Tasks []Task
 
 func (t Tasks) Run(s *state.State) error {
 	for _, step := range t {
+		// the context is cancelled when the operation must not go on, such
+		// as when the cluster lock is lost
+		if err := contextErr(s); err != nil {
+			return err
+		}
+
 		if step.Predicate != nil && !step.Predicate(s) {
 			continue
 		}
+
+		label := step.label()
+		if step.Resumable && s.Progress.IsCompleted(label) {
+			s.Logger.Infof("Skipping %q, already completed by the interrupted apply", label)
+			continue
+		}
+
 		if err := step.Run(s); err != nil {
 			return errors.Wrap(err, step.ErrMsg)
 		}
+
+		if step.Resumable {
+			if err := s.Progress.MarkCompleted(label); err != nil {
+				s.Logger.Warnf("Failed to record the progress of the apply: %v", err)
+			}
+		}
 	}
 
 	return nil
@@ -76,30 +96,41 @@ func (t Tasks) prepend(newtasks ...Task) Tasks {
 func WithBinariesOnly(t Tasks) Tasks {
 	return WithHostnameOSAndProbes(t).
 		append(
-			Task{Fn: installPrerequisites, ErrMsg: "failed to install prerequisites"},
+			hostPreflightTask(),
+			Task{Fn: ensureAssetCache, ErrMsg: "failed to configure asset cache", Predicate: assetCacheEnabled},
+			Task{Fn: installPrerequisites, ErrMsg: "failed to install prerequisites", Scope: ScopeAllNodes, Resumable: true},
 		)
 }
 
-// WithHostnameOS will prepend passed tasks with 2 basic tasks:
+// WithHostnameOS will prepend passed tasks with basic tasks:
 //  * detect OS on all cluster hosts
 //  * detect hostnames  on all cluster hosts
+//  * detect node IPs using Equinix Metal metadata, if enabled
 func WithHostnameOS(t Tasks) Tasks {
 	return t.prepend(
-		Task{Fn: determineHostname, ErrMsg: "failed to detect hostname"},
-		Task{Fn: determineOS, ErrMsg: "failed to detect OS"},
+		Task{Fn: determineHostname, ErrMsg: "failed to detect hostname", Scope: ScopeAllNodes},
+		Task{Fn: determineOS, ErrMsg: "failed to detect OS", Scope: ScopeAllNodes},
+		Task{
+			Fn:     determinePacketNodeIP,
+			ErrMsg: "failed to detect node IP",
+			Scope:  ScopeAllNodes,
+			Predicate: func(s *state.State) bool {
+				return s.Cluster.CloudProvider.Packet != nil && s.Cluster.CloudProvider.Packet.NodeIPFromMetadata
+			},
+		},
 	)
 }
 
 // WithProbes will run different probes over the defined cluster
 func WithProbes(t Tasks) Tasks {
 	return t.append(
-		Task{Fn: runProbes, ErrMsg: "probes failed"},
+		Task{Fn: runProbes, ErrMsg: "probes failed", Scope: ScopeAllNodes},
 	)
 }
 
 func WithProbesAndSafeguard(t Tasks) Tasks {
 	return t.append(
-		Task{Fn: runProbes, ErrMsg: "probes failed"},
+		Task{Fn: runProbes, ErrMsg: "probes failed", Scope: ScopeAllNodes},
 		Task{Fn: safeguard, ErrMsg: "probes analysis failed"},
 	)
 }
@@ -120,6 +151,7 @@ func WithFullInstall(t Tasks) Tasks {
 					return s.RunTaskOnLeader(kubeadmCertsExecutor)
 				},
 				ErrMsg: "failed to provision certs and etcd on leader",
+				Scope:  ScopeLeader,
 			},
 			{
 				Fn: func(s *state.State) error {
@@ -127,6 +159,7 @@ func WithFullInstall(t Tasks) Tasks {
 					return s.RunTaskOnLeader(certificate.DownloadKubePKI)
 				},
 				ErrMsg: "failed to download Kubernetes PKI from the leader",
+				Scope:  ScopeLeader,
 			},
 			{
 				Fn: func(s *state.State) error {
@@ -134,6 +167,7 @@ func WithFullInstall(t Tasks) Tasks {
 					return s.RunTaskOnFollowers(certificate.UploadKubePKI, state.RunParallel)
 				},
 				ErrMsg: "failed to upload Kubernetes PKI",
+				Scope:  ScopeFollowers,
 			},
 			{
 				Fn: func(s *state.State) error {
@@ -141,12 +175,13 @@ func WithFullInstall(t Tasks) Tasks {
 					return s.RunTaskOnFollowers(kubeadmCertsExecutor, state.RunParallel)
 				},
 				ErrMsg: "failed to provision certs and etcd on followers",
+				Scope:  ScopeFollowers,
 			},
-			{Fn: initKubernetesLeader, ErrMsg: "failed to init kubernetes on leader"},
+			{Fn: initKubernetesLeader, ErrMsg: "failed to init kubernetes on leader", Scope: ScopeLeader},
 			{Fn: kubeconfig.BuildKubernetesClientset, ErrMsg: "failed to build kubernetes clientset"},
 			{Fn: repairClusterIfNeeded, ErrMsg: "failed to repair cluster"},
-			{Fn: joinControlplaneNode, ErrMsg: "failed to join other masters a cluster"},
-			{Fn: restartKubeAPIServer, ErrMsg: "failed to restart unhealthy kube-apiserver"},
+			{Fn: joinControlplaneNode, ErrMsg: "failed to join other masters a cluster", Scope: ScopeFollowers},
+			{Fn: restartKubeAPIServer, ErrMsg: "failed to restart unhealthy kube-apiserver", Scope: ScopeControlPlane},
 		}...).
 		append(WithResources(nil)...).
 		append(
@@ -158,7 +193,9 @@ func WithResources(t Tasks) Tasks {
 	return t.append(
 		Tasks{
 			{
-				Fn: saveCABundle,
+				Fn:     saveCABundle,
+				ErrMsg: "failed to save CA bundle",
+				Scope:  ScopeControlPlane,
 				Predicate: func(s *state.State) bool {
 					return s.Cluster.CABundle != ""
 				},
@@ -166,10 +203,12 @@ func WithResources(t Tasks) Tasks {
 			{
 				Fn:     patchStaticPods,
 				ErrMsg: "failed to patch static pods",
+				Scope:  ScopeControlPlane,
 			},
 			{
 				Fn:          renewControlPlaneCerts,
 				ErrMsg:      "failed to renew certificates",
+				Scope:       ScopeControlPlane,
 				Description: "renew all certificates",
 				Predicate: func(s *state.State) bool {
 					return s.LiveCluster.CertsToExpireInLessThen90Days()
@@ -185,6 +224,21 @@ func WithResources(t Tasks) Tasks {
 					return s.RunTaskOnLeader(certificate.DownloadKubePKI)
 				},
 				ErrMsg: "failed to download Kubernetes PKI from the leader",
+				Scope:  ScopeLeader,
+			},
+			{
+				Fn:          ensureKonnectivityServer,
+				ErrMsg:      "failed to deploy konnectivity-server",
+				Scope:       ScopeControlPlane,
+				Description: "ensure konnectivity-server",
+				Predicate:   func(s *state.State) bool { return s.Cluster.Features.Konnectivity.Enabled() },
+			},
+			{
+				Fn:          ensureKonnectivityAgent,
+				ErrMsg:      "failed to deploy konnectivity-agent",
+				Description: "ensure konnectivity-agent",
+				Predicate:   func(s *state.State) bool { return s.Cluster.Features.Konnectivity.Enabled() },
+				Resumable:   true,
 			},
 			{
 				Fn: func(s *state.State) error {
@@ -193,6 +247,10 @@ func WithResources(t Tasks) Tasks {
 				},
 				ErrMsg:      "failed to deploy nodelocaldns",
 				Description: "ensure nodelocaldns",
+				Predicate: func(s *state.State) bool {
+					return s.Cluster.Features.NodeLocalDNS.Enabled()
+				},
+				Resumable: true,
 			},
 			{
 				Fn:     features.Activate,
@@ -202,11 +260,30 @@ func WithResources(t Tasks) Tasks {
 				Fn:     patchCoreDNS,
 				ErrMsg: "failed to patch CoreDNS",
 			},
+			{
+				Fn:          ensureCoreDNSConfig,
+				ErrMsg:      "failed to configure CoreDNS",
+				Description: "ensure CoreDNS configuration",
+				Resumable:   true,
+			},
+			{
+				Fn:          ensureCoreDNSDeployment,
+				ErrMsg:      "failed to ensure CoreDNS deployment",
+				Description: "ensure CoreDNS replicas and PodDisruptionBudget",
+				Resumable:   true,
+			},
 			{
 				Fn:          ensureCNI,
 				ErrMsg:      "failed to install cni plugin",
 				Description: "ensure CNI",
 				Predicate:   func(s *state.State) bool { return s.Cluster.ClusterNetwork.CNI.External == nil },
+				Resumable:   true,
+			},
+			{
+				Fn:          ensureKubeProxyConfig,
+				ErrMsg:      "failed to ensure kube-proxy configuration",
+				Description: "ensure kube-proxy configuration",
+				Predicate:   func(s *state.State) bool { return s.Cluster.ClusterNetwork.KubeProxy != nil },
 			},
 			{
 				Fn:          ensureCABundleConfigMap,
@@ -219,6 +296,7 @@ func WithResources(t Tasks) Tasks {
 				ErrMsg:      "failed to apply addons",
 				Description: "ensure addons",
 				Predicate:   func(s *state.State) bool { return s.Cluster.Addons != nil && s.Cluster.Addons.Enable },
+				Resumable:   true,
 			},
 			{
 				Fn:          credentials.Ensure,
@@ -230,61 +308,128 @@ func WithResources(t Tasks) Tasks {
 				ErrMsg:      "failed to ensure external CCM",
 				Description: "ensure external CCM",
 				Predicate:   func(s *state.State) bool { return s.Cluster.CloudProvider.External },
+				Resumable:   true,
 			},
 			{
 				Fn:          csi.Ensure,
 				ErrMsg:      "failed to ensure CSI driver",
 				Description: "ensure CSI driver",
 				Predicate:   func(s *state.State) bool { return s.Cluster.CloudProvider.External },
+				Resumable:   true,
 			},
 			{
-				Fn:     joinStaticWorkerNodes,
-				ErrMsg: "failed to join worker nodes to the cluster",
+				Fn:        joinStaticWorkerNodes,
+				ErrMsg:    "failed to join worker nodes to the cluster",
+				Scope:     ScopeStaticWorkers,
+				Resumable: true,
 			},
 			{
 				Fn:     labelNodeOSes,
 				ErrMsg: "failed to label nodes with their OS",
 			},
+			{
+				Fn:          labelStaticWorkerPools,
+				ErrMsg:      "failed to label static worker pools",
+				Description: "label nodes of static worker pools",
+				Predicate:   func(s *state.State) bool { return len(s.Cluster.StaticWorkers.Pools) > 0 },
+			},
+			{
+				Fn:          ensureKubeletSeccompDefault,
+				ErrMsg:      "failed to ensure kubelet SeccompDefault",
+				Scope:       ScopeAllNodes,
+				Description: "ensure kubelet SeccompDefault",
+				Predicate:   func(s *state.State) bool { return s.Cluster.Features.SeccompDefault != nil },
+			},
+			{
+				Fn:          ensureKubeletConfig,
+				ErrMsg:      "failed to ensure kubelet configuration",
+				Scope:       ScopeAllNodes,
+				Description: "ensure kubelet configuration",
+			},
+			{
+				Fn:          ensureSRIOV,
+				ErrMsg:      "failed to configure SR-IOV",
+				Scope:       ScopeAllNodes,
+				Description: "ensure SR-IOV virtual functions",
+				Predicate:   func(s *state.State) bool { return s.Cluster.Features.SRIOV.Enabled() },
+				Resumable:   true,
+			},
 			{
 				Fn:          machinecontroller.Ensure,
 				ErrMsg:      "failed to ensure machine-controller",
 				Description: "ensure machine-controller",
 				Predicate:   func(s *state.State) bool { return s.Cluster.MachineController.Deploy },
+				Resumable:   true,
 			},
 			{
 				Fn:     machinecontroller.WaitReady,
 				ErrMsg: "failed to wait for machine-controller",
 			},
+			{
+				Fn:          operatingsystemmanager.Ensure,
+				ErrMsg:      "failed to ensure operating-system-manager",
+				Description: "ensure operating-system-manager",
+				Predicate:   func(s *state.State) bool { return s.Cluster.OperatingSystemManagerEnabled() },
+				Resumable:   true,
+			},
+			{
+				Fn:     operatingsystemmanager.WaitReady,
+				ErrMsg: "failed to wait for operating-system-manager",
+			},
+			{
+				Fn: func(s *state.State) error {
+					s.Logger.Infoln("Ensure cluster-autoscaler...")
+					return addons.EnsureAddonByName(s, resources.AddonClusterAutoscaler)
+				},
+				ErrMsg:      "failed to deploy cluster-autoscaler",
+				Description: "ensure cluster-autoscaler",
+				Predicate:   func(s *state.State) bool { return s.Cluster.ClusterAutoscalerEnabled() },
+				Resumable:   true,
+			},
 			{
 				Fn:          upgradeMachineDeployments,
 				ErrMsg:      "failed to upgrade MachineDeployments",
 				Description: "upgrade MachineDeployments",
 				Predicate:   func(s *state.State) bool { return s.UpgradeMachineDeployments },
 			},
+			{
+				Fn:          saveClusterInfo,
+				ErrMsg:      "failed to save cluster info",
+				Description: "save cluster info",
+			},
+			{
+				Fn:          uploadState,
+				ErrMsg:      "failed to upload state to the state backend",
+				Description: "upload state",
+				Predicate:   func(s *state.State) bool { return s.Cluster.StateBackend != nil },
+			},
 		}...,
 	)
 }
 
 func WithUpgrade(t Tasks) Tasks {
 	return WithHostnameOSAndProbes(t).
+		append(hostPreflightTask()).
 		append(kubernetesConfigFiles()...). // this, in the upgrade process where config rails are handled
 		append(Tasks{
 			{Fn: kubeconfig.BuildKubernetesClientset, ErrMsg: "failed to build kubernetes clientset"},
 			{Fn: runPreflightChecks, ErrMsg: "preflight checks failed", Retries: 1},
-			{Fn: upgradeLeader, ErrMsg: "failed to upgrade leader control plane"},
-			{Fn: upgradeFollower, ErrMsg: "failed to upgrade follower control plane"},
+			{Fn: ensureAssetCache, ErrMsg: "failed to configure asset cache", Predicate: assetCacheEnabled},
+			{Fn: upgradeLeader, ErrMsg: "failed to upgrade leader control plane", Scope: ScopeLeader, Resumable: true},
+			{Fn: upgradeFollower, ErrMsg: "failed to upgrade follower control plane", Scope: ScopeFollowers, Resumable: true},
 			{
 				Fn: func(s *state.State) error {
 					s.Logger.Info("Downloading PKI...")
 					return s.RunTaskOnLeader(certificate.DownloadKubePKI)
 				},
 				ErrMsg: "failed to download Kubernetes PKI from the leader",
+				Scope:  ScopeLeader,
 			},
 		}...).
 		append(WithResources(nil)...).
 		append(
-			Task{Fn: restartKubeAPIServer, ErrMsg: "failed to restart unhealthy kube-apiserver"},
-			Task{Fn: upgradeStaticWorkers, ErrMsg: "unable to upgrade static worker nodes"},
+			Task{Fn: restartKubeAPIServer, ErrMsg: "failed to restart unhealthy kube-apiserver", Scope: ScopeControlPlane},
+			Task{Fn: upgradeStaticWorkers, ErrMsg: "unable to upgrade static worker nodes", Scope: ScopeStaticWorkers, Resumable: true},
 			Task{
 				Fn:          upgradeMachineDeployments,
 				ErrMsg:      "failed to upgrade MachineDeployments",
@@ -294,11 +439,106 @@ func WithUpgrade(t Tasks) Tasks {
 		)
 }
 
+// WithNodePortRange updates the NodePort range of the kube-apiserver instances
+// before running the passed tasks. The kube-apiserver manifests are
+// regenerated one node at a time, and the kubeadm configuration stored in the
+// cluster is updated, so the range persists when joining and upgrading nodes.
+func WithNodePortRange(t Tasks) Tasks {
+	return kubernetesConfigFiles().
+		prepend(Task{
+			Fn:          validateNodePortRange,
+			ErrMsg:      "the NodePort range can't be updated",
+			Description: "validate Services NodePorts fit the new NodePort range",
+		}).
+		append(Task{
+			Fn:          updateNodePortRange,
+			ErrMsg:      "failed to update the NodePort range",
+			Description: "update the NodePort range",
+			Scope:       ScopeControlPlane,
+		}).
+		append(t...)
+}
+
+// WithClusterCIDR updates the cluster CIDR of the kube-controller-manager
+// instances, so the pod subnets appended to the cluster are allocated to
+// nodes, before running the passed tasks. Canal is restarted after the passed
+// tasks reapply it, so flannel picks up the new network.
+func WithClusterCIDR(t Tasks) Tasks {
+	return kubernetesConfigFiles().
+		append(Task{
+			Fn:          updateClusterCIDR,
+			ErrMsg:      "failed to update the cluster CIDR",
+			Description: "update the cluster CIDR",
+			Scope:       ScopeControlPlane,
+		}).
+		append(t...).
+		append(Task{
+			Fn:          restartCanal,
+			ErrMsg:      "failed to restart canal",
+			Description: "restart canal",
+			Predicate:   func(s *state.State) bool { return s.Cluster.ClusterNetwork.CNI.Canal != nil },
+		})
+}
+
+// WithEtcdSettings updates the settings of the etcd members before running
+// the passed tasks. The etcd members must be healthy, and their manifests are
+// regenerated one node at a time, waiting for each member to rejoin the etcd
+// cluster, so the quorum is kept. The kubeadm configuration stored in the
+// cluster is updated, so the settings persist when joining and upgrading
+// nodes.
+func WithEtcdSettings(t Tasks) Tasks {
+	return kubernetesConfigFiles().
+		prepend(Task{
+			Fn:          validateEtcdHealthy,
+			ErrMsg:      "the etcd settings can't be updated",
+			Description: "validate all etcd members are healthy",
+		}).
+		append(Task{
+			Fn:          updateEtcdSettings,
+			ErrMsg:      "failed to update the etcd settings",
+			Description: "update the etcd settings",
+			Scope:       ScopeControlPlane,
+		}).
+		append(t...)
+}
+
+// WithEtcdServerCertSANs regenerates the etcd server certificates, so they
+// include the configured SANs, before running the passed tasks. The
+// certificates are regenerated one node at a time, waiting for each member
+// to report as healthy.
+func WithEtcdServerCertSANs(t Tasks) Tasks {
+	return kubernetesConfigFiles().
+		prepend(Task{
+			Fn:          validateEtcdHealthy,
+			ErrMsg:      "the etcd server certificates can't be regenerated",
+			Description: "validate all etcd members are healthy",
+		}).
+		append(Task{
+			Fn:          updateEtcdServerCerts,
+			ErrMsg:      "failed to regenerate the etcd server certificates",
+			Description: "regenerate the etcd server certificates",
+			Scope:       ScopeControlPlane,
+		}).
+		append(t...)
+}
+
+// WithAdopt takes over the management of the cluster built by kubeadm. The
+// layout of the cluster is verified, and the KubeOne configuration files and
+// resources are applied without touching the control plane.
+func WithAdopt(t Tasks) Tasks {
+	return WithHostnameOSAndProbes(t).
+		append(Tasks{
+			{Fn: verifyAdoption, ErrMsg: "the cluster can't be adopted"},
+		}...).
+		append(kubernetesConfigFiles()...).
+		append(WithResources(nil)...)
+}
+
 func WithReset(t Tasks) Tasks {
 	return t.append(Tasks{
 		{Fn: destroyWorkers, ErrMsg: "failed to destroy workers"},
-		{Fn: resetAllNodes, ErrMsg: "failed to reset nodes"},
-		{Fn: removeBinariesAllNodes, ErrMsg: "failed to remove binaries from nodes"},
+		{Fn: resetAllNodes, ErrMsg: "failed to reset nodes", Scope: ScopeAllNodes},
+		{Fn: removeBinariesAllNodes, ErrMsg: "failed to remove binaries from nodes", Scope: ScopeAllNodes},
 	}...)
 }
 
@@ -307,7 +547,7 @@ func WithContainerDMigration(t Tasks) Tasks {
 		append(Tasks{
 			{Fn: validateContainerdInConfig, ErrMsg: "failed to validate config", Retries: 1},
 			{Fn: kubeconfig.BuildKubernetesClientset, ErrMsg: "failed to build kubernetes clientset"},
-			{Fn: migrateToContainerd, ErrMsg: "failed to migrate to containerd"},
+			{Fn: migrateToContainerd, ErrMsg: "failed to migrate to containerd", Scope: ScopeAllNodes},
 			{Fn: patchCRISocketAnnotation, ErrMsg: "failed to patch Node objects"},
 			{
 				Fn: func(s *state.State) error {
@@ -315,6 +555,7 @@ func WithContainerDMigration(t Tasks) Tasks {
 					return s.RunTaskOnLeader(certificate.DownloadKubePKI)
 				},
 				ErrMsg: "failed to download Kubernetes PKI from the leader",
+				Scope:  ScopeLeader,
 			},
 			{
 				Fn: func(s *state.State) error {
@@ -332,19 +573,55 @@ func WithContainerDMigration(t Tasks) Tasks {
 		}...)
 }
 
-func WithClusterStatus(t Tasks) Tasks {
+func WithClusterStatus(t Tasks, output string) Tasks {
 	return WithHostnameOS(t).
 		append(Tasks{
 			{Fn: kubeconfig.BuildKubernetesClientset, ErrMsg: "failed to build kubernetes clientset"},
-			{Fn: clusterstatus.Print, ErrMsg: "failed to get cluster status"},
+			{Fn: probeCCMMigrationStatus, ErrMsg: "failed to detect the CCM migration status"},
+			{
+				Fn: func(s *state.State) error {
+					return clusterstatus.Print(s, output)
+				},
+				ErrMsg: "failed to get cluster status",
+			},
 		}...)
 }
 
+// WithCertificatesRenewal renews the certificates managed by kubeadm on the
+// control plane nodes, restarts the control plane components and downloads
+// the kubeconfig with the renewed client certificate
+func WithCertificatesRenewal(t Tasks) Tasks {
+	return t.append(Tasks{
+		{Fn: kubeconfig.BuildKubernetesClientset, ErrMsg: "failed to build kubernetes clientset"},
+		{
+			Fn:          renewCertificates,
+			ErrMsg:      "failed to renew certificates",
+			Scope:       ScopeControlPlane,
+			Description: "renew all certificates and restart the control plane components",
+		},
+		{Fn: saveKubeconfig, ErrMsg: "failed to save kubeconfig to the local machine"},
+	}...)
+}
+
 func kubernetesConfigFiles() Tasks {
 	return Tasks{
-		{Fn: generateKubeadm, ErrMsg: "failed to generate kubeadm config files"},
+		{Fn: generateKubeadm, ErrMsg: "failed to generate kubeadm config files", Scope: ScopeAllNodes},
 		{Fn: generateConfigurationFiles, ErrMsg: "failed to generate config files"},
-		{Fn: uploadConfigurationFiles, ErrMsg: "failed to upload config files"},
+		{Fn: uploadConfigurationFiles, ErrMsg: "failed to upload config files", Scope: ScopeAllNodes},
+		{
+			Fn:          ensureKubeVIP,
+			ErrMsg:      "failed to deploy kube-vip",
+			Scope:       ScopeControlPlane,
+			Description: "ensure kube-vip",
+			Predicate:   func(s *state.State) bool { return s.Cluster.Features.KubeVIP.Enabled() },
+		},
+		{
+			Fn:          ensureKMSPlugin,
+			ErrMsg:      "failed to deploy KMS plugin",
+			Scope:       ScopeControlPlane,
+			Description: "ensure KMS plugin",
+			Predicate:   kmsPluginEnabled,
+		},
 	}
 }
 
@@ -355,11 +632,13 @@ func WithDisableEncryptionProviders(t Tasks, customConfig bool) Tasks {
 			{
 				Fn:          removeEncryptionProviderFile,
 				ErrMsg:      "failed to remove encryption providers configuration",
+				Scope:       ScopeControlPlane,
 				Description: "remove old Encryption Providers configuration file",
 			},
 			{
 				Fn:          ensureRestartKubeAPIServer,
 				ErrMsg:      "failed to restart KubeAPI",
+				Scope:       ScopeControlPlane,
 				Description: "restart KubeAPI containers",
 			},
 
@@ -374,14 +653,17 @@ func WithDisableEncryptionProviders(t Tasks, customConfig bool) Tasks {
 		{
 			Fn:          fetchEncryptionProvidersFile,
 			ErrMsg:      "failed to fetch EncryptionProviders config",
+			Scope:       ScopeLeader,
 			Description: "fetch current Encryption Providers configuration file "},
 		{
 			Fn:          uploadIdentityFirstEncryptionConfiguration,
 			ErrMsg:      "failed to upload encryption providers configuration",
+			Scope:       ScopeControlPlane,
 			Description: "upload updated Encryption Providers configuration file"},
 		{
 			Fn:          ensureRestartKubeAPIServer,
 			ErrMsg:      "failed to restart KubeAPI",
+			Scope:       ScopeControlPlane,
 			Description: "restart KubeAPI containers",
 		},
 		{
@@ -392,6 +674,7 @@ func WithDisableEncryptionProviders(t Tasks, customConfig bool) Tasks {
 		{
 			Fn:          removeEncryptionProviderFile,
 			ErrMsg:      "failed to remove encryption providers configuration",
+			Scope:       ScopeControlPlane,
 			Description: "remove old Encryption Providers configuration file",
 		},
 	}...)
@@ -411,6 +694,7 @@ func WithCustomEncryptionConfigUpdated(t Tasks) Tasks {
 		{
 			Fn:          ensureRestartKubeAPIServer,
 			ErrMsg:      "failed to restart KubeAPI",
+			Scope:       ScopeControlPlane,
 			Description: "restart KubeAPI containers",
 		},
 		{
@@ -421,22 +705,44 @@ func WithCustomEncryptionConfigUpdated(t Tasks) Tasks {
 	}...)
 }
 
-func WithRotateKey(t Tasks) Tasks {
+// WithRotateKey rotates the encryption key. The new key of the given key
+// provider (or of the current one, if empty) is first added for decryption,
+// then used for encryption and the Secrets are rewritten with it, and finally
+// the old key is removed. kube-apiservers are restarted after each step, so
+// all of them can decrypt the Secrets at any time.
+func WithRotateKey(t Tasks, provider string) Tasks {
+	rotation := &keyRotation{provider: provider}
+
 	return WithHostnameOSAndProbes(t).
 		append(Tasks{
 			{
 				Fn:          fetchEncryptionProvidersFile,
 				ErrMsg:      "failed to fetch EncryptionProviders config",
+				Scope:       ScopeLeader,
 				Description: "fetch current Encryption Providers configuration file ",
 			},
 			{
-				Fn:          uploadEncryptionConfigurationWithNewKey,
+				Fn:          rotation.uploadWithNewDecryptKey,
 				ErrMsg:      "failed to upload encryption providers configuration",
+				Scope:       ScopeControlPlane,
+				Description: "upload Encryption Providers configuration file with the new decryption key",
+			},
+			{
+				Fn:          ensureRestartKubeAPIServer,
+				ErrMsg:      "failed to restart KubeAPI",
+				Scope:       ScopeControlPlane,
+				Description: "restart KubeAPI containers",
+			},
+			{
+				Fn:          rotation.uploadWithNewEncryptKey,
+				ErrMsg:      "failed to upload encryption providers configuration",
+				Scope:       ScopeControlPlane,
 				Description: "upload updated Encryption Providers configuration file",
 			},
 			{
 				Fn:          ensureRestartKubeAPIServer,
 				ErrMsg:      "failed to restart KubeAPI",
+				Scope:       ScopeControlPlane,
 				Description: "restart KubeAPI containers",
 			},
 			{
@@ -447,11 +753,13 @@ func WithRotateKey(t Tasks) Tasks {
 			{
 				Fn:          uploadEncryptionConfigurationWithoutOldKey,
 				ErrMsg:      "failed to upload encryption providers configuration",
+				Scope:       ScopeControlPlane,
 				Description: "upload updated Encryption Providers configuration file",
 			},
 			{
 				Fn:          ensureRestartKubeAPIServer,
 				ErrMsg:      "failed to restart KubeAPI",
+				Scope:       ScopeControlPlane,
 				Description: "restart KubeAPI containers",
 			},
 		}...)
@@ -467,11 +775,13 @@ func WithCCMCSIMigration(t Tasks) Tasks {
 				return s.CCMMigrationComplete
 			},
 		},
+		ccmMigrationEvent(state.EventReasonCCMMigrationStarted, "Started the CCM/CSI %s", "record CCM/CSI migration start"),
 	}...).
 		append(kubernetesConfigFiles()...).
 		append(
-			Task{Fn: regenerateControlPlaneManifests, ErrMsg: "failed to regenerate static pod manifests"},
-			Task{Fn: updateKubeletConfig, ErrMsg: "failed to update kubelet config on control plane nodes"},
+			Task{Fn: regenerateControlPlaneManifests, ErrMsg: "failed to regenerate static pod manifests", Scope: ScopeControlPlane},
+			Task{Fn: updateKubeletConfig, ErrMsg: "failed to update kubelet config on control plane nodes", Scope: ScopeControlPlane},
+			ccmMigrationEvent(state.EventReasonCCMMigrationControlPlane, "Migrated the control plane components in the CCM/CSI %s", "record CCM/CSI control plane migration"),
 		).
 		append(WithResources(nil)...).
 		append(
@@ -480,6 +790,17 @@ func WithCCMCSIMigration(t Tasks) Tasks {
 				ErrMsg:    "failed to migrate openstack persistentvolumes",
 				Predicate: func(s *state.State) bool { return s.Cluster.CloudProvider.Openstack != nil },
 			},
+			Task{
+				Fn:        migrateAWSPVs,
+				ErrMsg:    "failed to migrate aws persistentvolumes",
+				Predicate: func(s *state.State) bool { return s.Cluster.CloudProvider.AWS != nil },
+			},
+			Task{
+				Fn:        migrateAzurePVs,
+				ErrMsg:    "failed to migrate azure persistentvolumes",
+				Predicate: func(s *state.State) bool { return s.Cluster.CloudProvider.Azure != nil },
+			},
+			ccmMigrationEvent(state.EventReasonCCMMigrationFinished, "Finished the CCM/CSI %s", "record CCM/CSI migration finish"),
 			Task{
 				Fn: func(s *state.State) error {
 					s.Logger.Warn("Now please rolling restart your machineDeployments to migrate to ccm/csi")
@@ -22,18 +22,30 @@ import (
 	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/hooks"
 	"k8c.io/kubeone/pkg/nodeutils"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
 )
 
 func upgradeFollower(s *state.State) error {
-	return s.RunTaskOnFollowers(upgradeFollowerExecutor, state.RunSequentially)
+	return s.RunTaskOnFollowers(withNodeUpgradeHooks(upgradeFollowerExecutor), state.RunSequentially)
 }
 
 func upgradeFollowerExecutor(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
 	logger := s.Logger.WithField("node", node.PublicAddress)
 
+	proceed, err := s.ConfirmStep("upgrade the follower control plane node %s", node.PublicAddress)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		logger.Warnln("Skipping upgrade of the follower control plane...")
+		return nil
+	}
+
+	s.RecordNodeEvent(node, state.EventReasonNodeUpgradeStarted, "Upgrading the follower control plane node to Kubernetes %s", s.Cluster.Versions.Kubernetes)
+
 	logger.Infoln("Labeling follower control plane...")
 	if err := labelNode(s.DynamicClient, node); err != nil {
 		return errors.Wrap(err, "failed to label follower control plane node")
@@ -41,14 +53,26 @@ func upgradeFollowerExecutor(s *state.State, node *kubeoneapi.HostConfig, conn s
 
 	drainer := nodeutils.NewDrainer(s.RESTConfig, logger)
 
-	logger.Infoln("Cordon the follower control plane node...")
-	if err := drainer.Cordon(s.Context, node.Hostname, true); err != nil {
-		return errors.Wrap(err, "failed to cordon follower control plane node")
+	drain, err := s.ConfirmStep("drain the node %s", node.Hostname)
+	if err != nil {
+		return err
 	}
-
-	logger.Infoln("Drain the follower control plane node...")
-	if err := drainer.Drain(s.Context, node.Hostname); err != nil {
-		return errors.Wrap(err, "failed to drain follower control plane node")
+	if drain {
+		if err := runHostScripts(s, node, hooks.HostPreDrain); err != nil {
+			return err
+		}
+
+		logger.Infoln("Cordon the follower control plane node...")
+		if err := drainer.Cordon(s.Context, node.Hostname, true); err != nil {
+			return errors.Wrap(err, "failed to cordon follower control plane node")
+		}
+
+		logger.Infoln("Drain the follower control plane node...")
+		if err := drainer.Drain(s.Context, node.Hostname); err != nil {
+			return errors.Wrap(err, "failed to drain follower control plane node")
+		}
+	} else {
+		logger.Warnln("Skipping draining...")
 	}
 
 	logger.Infoln("Upgrading Kubernetes binaries on follower control plane...")
@@ -57,7 +81,7 @@ func upgradeFollowerExecutor(s *state.State, node *kubeoneapi.HostConfig, conn s
 	}
 
 	logger.Infoln("Running 'kubeadm upgrade' on the follower control plane node...")
-	if err := upgradeFollowerControlPlane(s); err != nil {
+	if err := upgradeFollowerControlPlane(s, node); err != nil {
 		return errors.Wrap(err, "failed to upgrade follower control plane")
 	}
 
@@ -66,9 +90,11 @@ func upgradeFollowerExecutor(s *state.State, node *kubeoneapi.HostConfig, conn s
 		return errors.Wrap(err, "failed to upgrade kubernetes system binaries on follower control plane")
 	}
 
-	logger.Infoln("Uncordoning follower control plane...")
-	if err := drainer.Cordon(s.Context, node.Hostname, false); err != nil {
-		return errors.Wrap(err, "failed to uncordon follower control plane node")
+	if drain {
+		logger.Infoln("Uncordoning follower control plane...")
+		if err := drainer.Cordon(s.Context, node.Hostname, false); err != nil {
+			return errors.Wrap(err, "failed to uncordon follower control plane node")
+		}
 	}
 
 	logger.Infof("Waiting %v to ensure all components are up...", timeoutNodeUpgrade)
@@ -79,5 +105,11 @@ func upgradeFollowerExecutor(s *state.State, node *kubeoneapi.HostConfig, conn s
 		return errors.Wrap(err, "failed to unlabel follower control plane node")
 	}
 
+	if err := runHostScripts(s, node, hooks.HostPostUpgrade); err != nil {
+		return err
+	}
+
+	s.RecordNodeEvent(node, state.EventReasonNodeUpgradeFinished, "Upgraded the follower control plane node to Kubernetes %s", s.Cluster.Versions.Kubernetes)
+
 	return nil
 }
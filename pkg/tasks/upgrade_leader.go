@@ -22,18 +22,30 @@ import (
 	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/hooks"
 	"k8c.io/kubeone/pkg/nodeutils"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
 )
 
 func upgradeLeader(s *state.State) error {
-	return s.RunTaskOnLeader(upgradeLeaderExecutor)
+	return s.RunTaskOnLeader(withNodeUpgradeHooks(upgradeLeaderExecutor))
 }
 
 func upgradeLeaderExecutor(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
 	logger := s.Logger.WithField("node", node.PublicAddress)
 
+	proceed, err := s.ConfirmStep("upgrade the leader control plane node %s", node.PublicAddress)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		logger.Warnln("Skipping upgrade of the leader control plane...")
+		return nil
+	}
+
+	s.RecordNodeEvent(node, state.EventReasonNodeUpgradeStarted, "Upgrading the leader control plane node to Kubernetes %s", s.Cluster.Versions.Kubernetes)
+
 	logger.Infoln("Labeling leader control plane...")
 	if err := labelNode(s.DynamicClient, node); err != nil {
 		return errors.Wrap(err, "failed to label leader control plane node")
@@ -41,14 +53,26 @@ func upgradeLeaderExecutor(s *state.State, node *kubeoneapi.HostConfig, conn ssh
 
 	drainer := nodeutils.NewDrainer(s.RESTConfig, logger)
 
-	logger.Infoln("Cordoning leader control plane...")
-	if err := drainer.Cordon(s.Context, node.Hostname, true); err != nil {
-		return errors.Wrap(err, "failed to cordon follower control plane node")
+	drain, err := s.ConfirmStep("drain the node %s", node.Hostname)
+	if err != nil {
+		return err
 	}
-
-	logger.Infoln("Draining leader control plane...")
-	if err := drainer.Drain(s.Context, node.Hostname); err != nil {
-		return errors.Wrap(err, "failed to drain follower control plane node")
+	if drain {
+		if err := runHostScripts(s, node, hooks.HostPreDrain); err != nil {
+			return err
+		}
+
+		logger.Infoln("Cordoning leader control plane...")
+		if err := drainer.Cordon(s.Context, node.Hostname, true); err != nil {
+			return errors.Wrap(err, "failed to cordon follower control plane node")
+		}
+
+		logger.Infoln("Draining leader control plane...")
+		if err := drainer.Drain(s.Context, node.Hostname); err != nil {
+			return errors.Wrap(err, "failed to drain follower control plane node")
+		}
+	} else {
+		logger.Warnln("Skipping draining...")
 	}
 
 	logger.Infoln("Upgrading kubeadm binary on the leader control plane...")
@@ -57,7 +81,7 @@ func upgradeLeaderExecutor(s *state.State, node *kubeoneapi.HostConfig, conn ssh
 	}
 
 	logger.Infoln("Running 'kubeadm upgrade' on leader control plane node...")
-	if err := upgradeLeaderControlPlane(s); err != nil {
+	if err := upgradeLeaderControlPlane(s, node); err != nil {
 		return errors.Wrap(err, "failed to run 'kubeadm upgrade' on leader control plane")
 	}
 
@@ -66,9 +90,11 @@ func upgradeLeaderExecutor(s *state.State, node *kubeoneapi.HostConfig, conn ssh
 		return errors.Wrap(err, "failed to upgrade kubernetes system binaries on leader control plane")
 	}
 
-	logger.Infoln("Uncordoning leader control plane...")
-	if err := drainer.Cordon(s.Context, node.Hostname, false); err != nil {
-		return errors.Wrap(err, "failed to uncordon follower control plane node")
+	if drain {
+		logger.Infoln("Uncordoning leader control plane...")
+		if err := drainer.Cordon(s.Context, node.Hostname, false); err != nil {
+			return errors.Wrap(err, "failed to uncordon follower control plane node")
+		}
 	}
 
 	logger.Infof("Waiting %v to ensure all components are up...", timeoutNodeUpgrade)
@@ -79,5 +105,11 @@ func upgradeLeaderExecutor(s *state.State, node *kubeoneapi.HostConfig, conn ssh
 		return errors.Wrap(err, "failed to unlabel leader control plane node")
 	}
 
+	if err := runHostScripts(s, node, hooks.HostPostUpgrade); err != nil {
+		return err
+	}
+
+	s.RecordNodeEvent(node, state.EventReasonNodeUpgradeFinished, "Upgraded the leader control plane node to Kubernetes %s", s.Cluster.Versions.Kubernetes)
+
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/hooks"
 	"k8c.io/kubeone/pkg/nodeutils"
 	"k8c.io/kubeone/pkg/ssh"
 	"k8c.io/kubeone/pkg/state"
@@ -29,12 +30,23 @@ import (
 
 func upgradeStaticWorkers(s *state.State) error {
 	// we upgrade seqentially to minimize cluster disruption
-	return s.RunTaskOnStaticWorkers(upgradeStaticWorkersExecutor, state.RunSequentially)
+	return s.RunTaskOnStaticWorkers(withNodeUpgradeHooks(upgradeStaticWorkersExecutor), state.RunSequentially)
 }
 
 func upgradeStaticWorkersExecutor(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
 	logger := s.Logger.WithField("node", node.PublicAddress)
 
+	proceed, err := s.ConfirmStep("upgrade the static worker node %s", node.PublicAddress)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		logger.Warnln("Skipping upgrade of the static worker...")
+		return nil
+	}
+
+	s.RecordNodeEvent(node, state.EventReasonNodeUpgradeStarted, "Upgrading the static worker node to Kubernetes %s", s.Cluster.Versions.Kubernetes)
+
 	logger.Infoln("Labeling static worker node...")
 
 	if err := labelNode(s.DynamicClient, node); err != nil {
@@ -43,14 +55,26 @@ func upgradeStaticWorkersExecutor(s *state.State, node *kubeoneapi.HostConfig, c
 
 	drainer := nodeutils.NewDrainer(s.RESTConfig, logger)
 
-	logger.Infoln("Cordoning static worker node...")
-	if err := drainer.Cordon(s.Context, node.Hostname, true); err != nil {
-		return errors.Wrap(err, "failed to cordon follower control plane node")
+	drain, err := s.ConfirmStep("drain the node %s", node.Hostname)
+	if err != nil {
+		return err
 	}
-
-	logger.Infoln("Draining static worker node...")
-	if err := drainer.Drain(s.Context, node.Hostname); err != nil {
-		return errors.Wrap(err, "failed to drain follower control plane node")
+	if drain {
+		if err := runHostScripts(s, node, hooks.HostPreDrain); err != nil {
+			return err
+		}
+
+		logger.Infoln("Cordoning static worker node...")
+		if err := drainer.Cordon(s.Context, node.Hostname, true); err != nil {
+			return errors.Wrap(err, "failed to cordon follower control plane node")
+		}
+
+		logger.Infoln("Draining static worker node...")
+		if err := drainer.Drain(s.Context, node.Hostname); err != nil {
+			return errors.Wrap(err, "failed to drain follower control plane node")
+		}
+	} else {
+		logger.Warnln("Skipping draining...")
 	}
 
 	logger.Infoln("Upgrading Kubernetes binaries on static worker node...")
@@ -68,9 +92,11 @@ func upgradeStaticWorkersExecutor(s *state.State, node *kubeoneapi.HostConfig, c
 		return errors.Wrap(err, "failed to upgrade kubernetes system binaries on the static worker node")
 	}
 
-	logger.Infoln("Uncordoning static worker node...")
-	if err := drainer.Cordon(s.Context, node.Hostname, false); err != nil {
-		return errors.Wrap(err, "failed to uncordon follower control plane node")
+	if drain {
+		logger.Infoln("Uncordoning static worker node...")
+		if err := drainer.Cordon(s.Context, node.Hostname, false); err != nil {
+			return errors.Wrap(err, "failed to uncordon follower control plane node")
+		}
 	}
 
 	logger.Infof("Waiting %v to ensure all components are up...", timeoutNodeUpgrade)
@@ -81,5 +107,11 @@ func upgradeStaticWorkersExecutor(s *state.State, node *kubeoneapi.HostConfig, c
 		return errors.Wrap(err, "failed to unlabel static worker node node")
 	}
 
+	if err := runHostScripts(s, node, hooks.HostPostUpgrade); err != nil {
+		return err
+	}
+
+	s.RecordNodeEvent(node, state.EventReasonNodeUpgradeFinished, "Upgraded the static worker node to Kubernetes %s", s.Cluster.Versions.Kubernetes)
+
 	return nil
 }
@@ -18,6 +18,7 @@ package tasks
 
 import (
 	"context"
+	"encoding/json"
 	"io/fs"
 	"time"
 
@@ -82,6 +83,60 @@ func determineOS(s *state.State) error {
 	}, state.RunParallel)
 }
 
+// determinePacketNodeIP sets the kubelet node IP of the hosts to their private
+// IPv4 address reported by the Equinix Metal metadata service, unless the node
+// IP is overridden for the host
+func determinePacketNodeIP(s *state.State) error {
+	s.Logger.Infoln("Determine node IP using Equinix Metal metadata...")
+	return s.RunTaskOnAllNodes(func(s *state.State, node *kubeoneapi.HostConfig, conn ssh.Connection) error {
+		if node.NetworkOverrides != nil && node.NetworkOverrides.KubeletNodeIP != "" {
+			return nil
+		}
+
+		stdout, _, err := s.Runner.RunRaw(scripts.EquinixMetalMetadata())
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch Equinix Metal metadata")
+		}
+
+		nodeIP, err := packetPrivateIPv4([]byte(stdout))
+		if err != nil {
+			return err
+		}
+
+		if node.NetworkOverrides == nil {
+			node.NetworkOverrides = &kubeoneapi.HostNetworkOverrides{}
+		}
+		node.NetworkOverrides.KubeletNodeIP = nodeIP
+
+		return nil
+	}, state.RunParallel)
+}
+
+// packetPrivateIPv4 returns the private IPv4 address from the Equinix Metal
+// device metadata
+func packetPrivateIPv4(metadata []byte) (string, error) {
+	var md struct {
+		Network struct {
+			Addresses []struct {
+				Address       string `json:"address"`
+				AddressFamily int    `json:"address_family"`
+				Public        bool   `json:"public"`
+			} `json:"addresses"`
+		} `json:"network"`
+	}
+	if err := json.Unmarshal(metadata, &md); err != nil {
+		return "", errors.Wrap(err, "failed to parse Equinix Metal metadata")
+	}
+
+	for _, addr := range md.Network.Addresses {
+		if addr.AddressFamily == 4 && !addr.Public {
+			return addr.Address, nil
+		}
+	}
+
+	return "", errors.New("no private IPv4 address found in Equinix Metal metadata")
+}
+
 func labelNode(client dynclient.Client, host *kubeoneapi.HostConfig) error {
 	retErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		node := corev1.Node{
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import "testing"
+
+func TestPacketPrivateIPv4(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		expected string
+		err      bool
+	}{
+		{
+			name: "private IPv4 address",
+			metadata: `{"network": {"addresses": [
+				{"address": "147.75.1.2", "address_family": 4, "public": true},
+				{"address": "2604:1380::1", "address_family": 6, "public": true},
+				{"address": "10.80.1.2", "address_family": 4, "public": false}
+			]}}`,
+			expected: "10.80.1.2",
+		},
+		{
+			name:     "no private IPv4 address",
+			metadata: `{"network": {"addresses": [{"address": "147.75.1.2", "address_family": 4, "public": true}]}}`,
+			err:      true,
+		},
+		{
+			name:     "invalid metadata",
+			metadata: "not found",
+			err:      true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := packetPrivateIPv4([]byte(tc.metadata))
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error = %v, but got %v", tc.err, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, but got %q", tc.expected, got)
+			}
+		})
+	}
+}
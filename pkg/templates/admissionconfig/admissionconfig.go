@@ -27,10 +27,56 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
 )
 
+const (
+	podNodeSelectorConfigPath = "/etc/kubernetes/admission/podnodeselector.yaml"
+	eventRateLimitConfigPath  = "/etc/kubernetes/admission/eventratelimit.yaml"
+	podSecurityConfigPath     = "/etc/kubernetes/admission/podsecurity.yaml"
+)
+
+// eventRateLimitConfiguration is the configuration of the EventRateLimit
+// admission plugin
+type eventRateLimitConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Limits []eventRateLimit `json:"limits"`
+}
+
+type eventRateLimit struct {
+	Type      string `json:"type"`
+	QPS       int32  `json:"qps"`
+	Burst     int32  `json:"burst"`
+	CacheSize int32  `json:"cacheSize,omitempty"`
+}
+
+// podSecurityConfiguration is the configuration of the PodSecurity admission
+// plugin
+type podSecurityConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Defaults   podSecurityDefaults   `json:"defaults"`
+	Exemptions podSecurityExemptions `json:"exemptions"`
+}
+
+type podSecurityDefaults struct {
+	Enforce        string `json:"enforce"`
+	EnforceVersion string `json:"enforce-version"`
+	Audit          string `json:"audit"`
+	AuditVersion   string `json:"audit-version"`
+	Warn           string `json:"warn"`
+	WarnVersion    string `json:"warn-version"`
+}
+
+type podSecurityExemptions struct {
+	Usernames      []string `json:"usernames"`
+	RuntimeClasses []string `json:"runtimeClasses"`
+	Namespaces     []string `json:"namespaces"`
+}
+
 // NewAdmissionConfig generates the AdmissionConfiguration manifest
-func NewAdmissionConfig(k8sVersion string, podNodeSelectorFeature *kubeoneapi.PodNodeSelector) (string, error) {
+func NewAdmissionConfig(k8sVersion string, features kubeoneapi.Features) (string, error) {
 	sver, err := semver.NewVersion(k8sVersion)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to parse version")
@@ -43,15 +89,15 @@ func NewAdmissionConfig(k8sVersion string, podNodeSelectorFeature *kubeoneapi.Po
 	var admissionCfg []runtime.Object
 	switch {
 	case c.Check(sver):
-		admissionCfg = admissionConfigV1alpha1(podNodeSelectorFeature)
+		admissionCfg = admissionConfigV1alpha1(features)
 	default:
-		admissionCfg = admissionConfigV1(podNodeSelectorFeature)
+		admissionCfg = admissionConfigV1(features)
 	}
 
 	return templates.KubernetesToYAML(admissionCfg)
 }
 
-func admissionConfigV1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector) []runtime.Object {
+func admissionConfigV1(features kubeoneapi.Features) []runtime.Object {
 	admissionConfig := &apiserverv1.AdmissionConfiguration{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apiserver.config.k8s.io/v1",
@@ -59,18 +105,34 @@ func admissionConfigV1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector) []run
 		},
 	}
 
-	if podNodeSelectorFeature != nil && podNodeSelectorFeature.Enable {
+	if features.PodNodeSelector != nil && features.PodNodeSelector.Enable {
 		pnsPlugin := apiserverv1.AdmissionPluginConfiguration{
 			Name: "PodNodeSelector",
-			Path: "/etc/kubernetes/admission/podnodeselector.yaml",
+			Path: podNodeSelectorConfigPath,
 		}
 		admissionConfig.Plugins = append(admissionConfig.Plugins, pnsPlugin)
 	}
 
+	if features.EventRateLimit != nil && features.EventRateLimit.Enable {
+		erlPlugin := apiserverv1.AdmissionPluginConfiguration{
+			Name: "EventRateLimit",
+			Path: eventRateLimitConfigPath,
+		}
+		admissionConfig.Plugins = append(admissionConfig.Plugins, erlPlugin)
+	}
+
+	if features.PodSecurityAdmission != nil && features.PodSecurityAdmission.Enable {
+		psaPlugin := apiserverv1.AdmissionPluginConfiguration{
+			Name: "PodSecurity",
+			Path: podSecurityConfigPath,
+		}
+		admissionConfig.Plugins = append(admissionConfig.Plugins, psaPlugin)
+	}
+
 	return []runtime.Object{admissionConfig}
 }
 
-func admissionConfigV1alpha1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector) []runtime.Object {
+func admissionConfigV1alpha1(features kubeoneapi.Features) []runtime.Object {
 	admissionConfig := &apiserverv1alpha1.AdmissionConfiguration{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apiserver.k8s.io/v1alpha1",
@@ -78,13 +140,113 @@ func admissionConfigV1alpha1(podNodeSelectorFeature *kubeoneapi.PodNodeSelector)
 		},
 	}
 
-	if podNodeSelectorFeature != nil && podNodeSelectorFeature.Enable {
+	if features.PodNodeSelector != nil && features.PodNodeSelector.Enable {
 		pnsPlugin := apiserverv1alpha1.AdmissionPluginConfiguration{
 			Name: "PodNodeSelector",
-			Path: "/etc/kubernetes/admission/podnodeselector.yaml",
+			Path: podNodeSelectorConfigPath,
 		}
 		admissionConfig.Plugins = append(admissionConfig.Plugins, pnsPlugin)
 	}
 
+	if features.EventRateLimit != nil && features.EventRateLimit.Enable {
+		erlPlugin := apiserverv1alpha1.AdmissionPluginConfiguration{
+			Name: "EventRateLimit",
+			Path: eventRateLimitConfigPath,
+		}
+		admissionConfig.Plugins = append(admissionConfig.Plugins, erlPlugin)
+	}
+
+	if features.PodSecurityAdmission != nil && features.PodSecurityAdmission.Enable {
+		psaPlugin := apiserverv1alpha1.AdmissionPluginConfiguration{
+			Name: "PodSecurity",
+			Path: podSecurityConfigPath,
+		}
+		admissionConfig.Plugins = append(admissionConfig.Plugins, psaPlugin)
+	}
+
 	return []runtime.Object{admissionConfig}
 }
+
+// NewEventRateLimitConfig generates the EventRateLimit admission plugin
+// configuration manifest
+func NewEventRateLimitConfig(feature *kubeoneapi.EventRateLimit) (string, error) {
+	config := eventRateLimitConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "eventratelimit.admission.k8s.io/v1alpha1",
+			Kind:       "Configuration",
+		},
+	}
+
+	for _, limit := range feature.Config.Limits {
+		config.Limits = append(config.Limits, eventRateLimit{
+			Type:      string(limit.Type),
+			QPS:       limit.QPS,
+			Burst:     limit.Burst,
+			CacheSize: limit.CacheSize,
+		})
+	}
+
+	b, err := yaml.Marshal(config)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal EventRateLimit configuration")
+	}
+
+	return string(b), nil
+}
+
+// NewPodSecurityConfig generates the PodSecurity admission plugin
+// configuration manifest. The configuration API version follows the
+// graduation of the plugin.
+func NewPodSecurityConfig(k8sVersion string, feature *kubeoneapi.PodSecurityAdmission) (string, error) {
+	sver, err := semver.NewVersion(k8sVersion)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse version")
+	}
+
+	lt123, err := semver.NewConstraint("< 1.23.0")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse the semver constraint")
+	}
+	lt125, err := semver.NewConstraint("< 1.25.0")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse the semver constraint")
+	}
+
+	var apiVersion string
+	switch {
+	case lt123.Check(sver):
+		apiVersion = "pod-security.admission.config.k8s.io/v1alpha1"
+	case lt125.Check(sver):
+		apiVersion = "pod-security.admission.config.k8s.io/v1beta1"
+	default:
+		apiVersion = "pod-security.admission.config.k8s.io/v1"
+	}
+
+	cfg := feature.Config
+	config := podSecurityConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiVersion,
+			Kind:       "PodSecurityConfiguration",
+		},
+		Defaults: podSecurityDefaults{
+			Enforce:        cfg.Enforce,
+			EnforceVersion: cfg.EnforceVersion,
+			Audit:          cfg.Audit,
+			AuditVersion:   cfg.AuditVersion,
+			Warn:           cfg.Warn,
+			WarnVersion:    cfg.WarnVersion,
+		},
+		Exemptions: podSecurityExemptions{
+			Usernames:      append([]string{}, cfg.Exemptions.Usernames...),
+			RuntimeClasses: append([]string{}, cfg.Exemptions.RuntimeClasses...),
+			Namespaces:     append([]string{}, cfg.Exemptions.Namespaces...),
+		},
+	}
+
+	b, err := yaml.Marshal(config)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal PodSecurity configuration")
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissionconfig
+
+import (
+	"flag"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+var updateFlag = flag.Bool("update", false, "update testdata files")
+
+func TestNewAdmissionConfig(t *testing.T) {
+	features := kubeoneapi.Features{
+		PodNodeSelector:      &kubeoneapi.PodNodeSelector{Enable: true},
+		EventRateLimit:       &kubeoneapi.EventRateLimit{Enable: true},
+		PodSecurityAdmission: &kubeoneapi.PodSecurityAdmission{Enable: true},
+	}
+
+	config, err := NewAdmissionConfig("1.22.1", features)
+	if err != nil {
+		t.Fatalf("NewAdmissionConfig() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), config, *updateFlag)
+}
+
+func TestNewEventRateLimitConfig(t *testing.T) {
+	feature := &kubeoneapi.EventRateLimit{
+		Enable: true,
+		Config: kubeoneapi.EventRateLimitConfig{
+			Limits: []kubeoneapi.EventRateLimitLimit{
+				{Type: kubeoneapi.EventRateLimitTypeServer, QPS: 50, Burst: 100},
+				{Type: kubeoneapi.EventRateLimitTypeNamespace, QPS: 50, Burst: 100, CacheSize: 2000},
+			},
+		},
+	}
+
+	config, err := NewEventRateLimitConfig(feature)
+	if err != nil {
+		t.Fatalf("NewEventRateLimitConfig() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), config, *updateFlag)
+}
+
+func TestNewPodSecurityConfig(t *testing.T) {
+	feature := &kubeoneapi.PodSecurityAdmission{
+		Enable: true,
+		Config: kubeoneapi.PodSecurityAdmissionConfig{
+			Enforce:        "baseline",
+			EnforceVersion: "latest",
+			Audit:          "restricted",
+			AuditVersion:   "latest",
+			Warn:           "restricted",
+			WarnVersion:    "v1.22",
+			Exemptions: kubeoneapi.PodSecurityAdmissionExemptions{
+				Namespaces: []string{"kube-system"},
+			},
+		},
+	}
+
+	for _, version := range []string{"1.22.1", "1.23.0", "1.25.0"} {
+		version := version
+		t.Run(version, func(t *testing.T) {
+			config, err := NewPodSecurityConfig(version, feature)
+			if err != nil {
+				t.Fatalf("NewPodSecurityConfig() error = %v", err)
+			}
+
+			testhelper.DiffOutput(t, testhelper.FSGoldenName(t), config, *updateFlag)
+		})
+	}
+}
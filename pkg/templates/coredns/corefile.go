@@ -0,0 +1,258 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coredns
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+)
+
+const (
+	// ConfigMapName is the name of the kubeadm-managed CoreDNS ConfigMap
+	ConfigMapName = "coredns"
+	// DeploymentName is the name of the kubeadm-managed CoreDNS Deployment
+	DeploymentName = "coredns"
+	// CorefileKey is the ConfigMap key holding the Corefile
+	CorefileKey = "Corefile"
+
+	beginMarker   = "# BEGIN KubeOne managed directives, do not edit"
+	endMarker     = "# END KubeOne managed directives"
+	forwardMarker = "# KubeOne managed forwarders, replaced:"
+	indent        = "    "
+)
+
+var (
+	// rootServerBlock matches the opening line of the server block kubeadm
+	// configures for the root zone
+	rootServerBlock = regexp.MustCompile(`^\s*\.:53\s*\{\s*$`)
+
+	// forwardDirective matches the forward plugin directive of the root zone,
+	// capturing the upstreams
+	forwardDirective = regexp.MustCompile(`^(\s*forward\s+\.\s+)([^{#]+?)(\s*\{)?\s*$`)
+)
+
+// UpdateCorefile returns the Corefile with the directives rendered from the
+// config placed at the top of the root server block, the upstreams of the root
+// zone replaced by the forwarders, and the stub domains and the snippet
+// appended to the end. The directives are enclosed by markers, and the
+// replaced upstreams are recorded in the comment, so the previously rendered
+// config is replaced, and removed when the config is empty.
+func UpdateCorefile(corefile string, cfg *kubeoneapi.CoreDNSConfig) (string, error) {
+	lines := restoreForwarders(stripManaged(strings.Split(corefile, "\n")))
+
+	directives := Directives(cfg)
+	serverBlocks := ServerBlocks(cfg)
+
+	var forwarders []string
+	if cfg != nil {
+		forwarders = cfg.Forwarders
+	}
+
+	if len(directives) > 0 || len(forwarders) > 0 {
+		root := -1
+		for i, line := range lines {
+			if rootServerBlock.MatchString(line) {
+				root = i
+
+				break
+			}
+		}
+		if root < 0 {
+			return "", errors.New("the root server block (.:53) is not found in the Corefile")
+		}
+
+		if len(forwarders) > 0 {
+			if err := replaceForwarders(lines[root+1:], forwarders); err != nil {
+				return "", err
+			}
+		}
+
+		if len(directives) > 0 {
+			managed := []string{indent + beginMarker}
+			for _, directive := range directives {
+				managed = append(managed, indent+directive)
+			}
+			managed = append(managed, indent+endMarker)
+
+			out := append([]string{}, lines[:root+1]...)
+			out = append(out, managed...)
+			lines = append(out, lines[root+1:]...)
+		}
+	}
+
+	if len(serverBlocks) > 0 {
+		// place the server blocks after the last non-empty line, keeping the
+		// trailing newline of the Corefile
+		last := len(lines)
+		for last > 0 && strings.TrimSpace(lines[last-1]) == "" {
+			last--
+		}
+
+		out := append([]string{}, lines[:last]...)
+		out = append(out, beginMarker)
+		out = append(out, serverBlocks...)
+		out = append(out, endMarker)
+		lines = append(out, lines[last:]...)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// Directives renders the config into the Corefile directive lines. Lines of
+// the directive blocks are indented.
+func Directives(cfg *kubeoneapi.CoreDNSConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+
+	var lines []string
+
+	if len(cfg.Hosts) > 0 {
+		lines = append(lines, "hosts {")
+		for _, host := range cfg.Hosts {
+			lines = append(lines, indent+host.IP+" "+strings.Join(host.Hostnames, " "))
+		}
+		lines = append(lines, indent+"fallthrough", "}")
+	}
+
+	for _, rule := range cfg.Rewrites {
+		field := rule.Field
+		if field == "" {
+			field = "name"
+		}
+
+		args := []string{"rewrite", field}
+		if field == "name" {
+			match := rule.Match
+			if match == "" {
+				match = "exact"
+			}
+			args = append(args, match)
+		}
+		args = append(args, rule.From, rule.To)
+		lines = append(lines, strings.Join(args, " "))
+	}
+
+	for _, plugin := range cfg.Plugins {
+		directive := strings.Join(append([]string{plugin.Name}, plugin.Args...), " ")
+		if len(plugin.Options) == 0 {
+			lines = append(lines, directive)
+			continue
+		}
+
+		lines = append(lines, directive+" {")
+		for _, option := range plugin.Options {
+			lines = append(lines, indent+option)
+		}
+		lines = append(lines, "}")
+	}
+
+	return lines
+}
+
+// ServerBlocks renders the stub domains into the server blocks, followed by
+// the Corefile snippet
+func ServerBlocks(cfg *kubeoneapi.CoreDNSConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+
+	var lines []string
+
+	for _, stub := range cfg.StubDomains {
+		lines = append(lines,
+			stub.Domain+":53 {",
+			indent+"errors",
+			indent+"cache 30",
+			indent+"forward . "+strings.Join(stub.Servers, " "),
+			"}",
+		)
+	}
+
+	if snippet := strings.TrimRight(cfg.CorefileSnippet, "\n"); snippet != "" {
+		lines = append(lines, strings.Split(snippet, "\n")...)
+	}
+
+	return lines
+}
+
+// replaceForwarders replaces the upstreams of the forward directive of the
+// root server block, recording the replaced upstreams in the comment
+func replaceForwarders(lines []string, forwarders []string) error {
+	depth := 0
+
+	for i, line := range lines {
+		if depth == 0 {
+			if match := forwardDirective.FindStringSubmatch(line); match != nil {
+				lines[i] = match[1] + strings.Join(forwarders, " ") + match[3] + " " + forwardMarker + " " + match[2]
+
+				return nil
+			}
+		}
+
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth < 0 {
+			break
+		}
+	}
+
+	return errors.New("the forward directive is not found in the root server block (.:53) of the Corefile")
+}
+
+// restoreForwarders restores the upstreams replaced by the forwarders
+func restoreForwarders(lines []string) []string {
+	for i, line := range lines {
+		idx := strings.Index(line, forwardMarker)
+		if idx < 0 {
+			continue
+		}
+
+		replaced := strings.TrimSpace(line[idx+len(forwardMarker):])
+		if match := forwardDirective.FindStringSubmatch(strings.TrimRight(line[:idx], " ")); match != nil {
+			lines[i] = match[1] + replaced + match[3]
+		}
+	}
+
+	return lines
+}
+
+// stripManaged removes the lines between the markers, including the markers
+func stripManaged(lines []string) []string {
+	out := make([]string, 0, len(lines))
+	managed := false
+
+	for _, line := range lines {
+		switch strings.TrimSpace(line) {
+		case beginMarker:
+			managed = true
+			continue
+		case endMarker:
+			managed = false
+			continue
+		}
+
+		if !managed {
+			out = append(out, line)
+		}
+	}
+
+	return out
+}
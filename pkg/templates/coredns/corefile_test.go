@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coredns
+
+import (
+	"flag"
+	"testing"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/testhelper"
+)
+
+var updateFlag = flag.Bool("update", false, "update testdata files")
+
+const kubeadmCorefile = `.:53 {
+    errors
+    health {
+       lameduck 5s
+    }
+    ready
+    kubernetes cluster.local in-addr.arpa ip6.arpa {
+       pods insecure
+       fallthrough in-addr.arpa ip6.arpa
+       ttl 30
+    }
+    prometheus :9153
+    forward . /etc/resolv.conf {
+       max_concurrent 1000
+    }
+    cache 30
+    loop
+    reload
+    loadbalance
+}
+`
+
+func TestUpdateCorefile(t *testing.T) {
+	cfg := &kubeoneapi.CoreDNSConfig{
+		Hosts: []kubeoneapi.CoreDNSHostEntry{
+			{IP: "10.0.0.10", Hostnames: []string{"registry.example.com", "mirror.example.com"}},
+		},
+		Rewrites: []kubeoneapi.CoreDNSRewriteRule{
+			{Field: "name", Match: "suffix", From: ".corp.example.com", To: ".svc.cluster.local"},
+			{Field: "type", From: "ANY", To: "HINFO"},
+		},
+		Plugins: []kubeoneapi.CoreDNSPlugin{
+			{Name: "log"},
+			{Name: "template", Args: []string{"IN", "A", "example.org"}, Options: []string{`answer "{{ .Name }} 60 IN A 10.0.0.20"`}},
+		},
+		Forwarders: []string{"8.8.8.8", "1.1.1.1"},
+		StubDomains: []kubeoneapi.CoreDNSStubDomain{
+			{Domain: "corp.example.com", Servers: []string{"10.0.0.53", "10.0.0.54:5353"}},
+		},
+		CorefileSnippet: "example.org:53 {\n    whoami\n}\n",
+	}
+
+	corefile, err := UpdateCorefile(kubeadmCorefile, cfg)
+	if err != nil {
+		t.Fatalf("UpdateCorefile() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), corefile, *updateFlag)
+
+	updated, err := UpdateCorefile(corefile, cfg)
+	if err != nil {
+		t.Fatalf("UpdateCorefile() error = %v", err)
+	}
+	if updated != corefile {
+		t.Errorf("expected the update to be idempotent, but got:\n%s", updated)
+	}
+
+	stripped, err := UpdateCorefile(corefile, nil)
+	if err != nil {
+		t.Fatalf("UpdateCorefile() error = %v", err)
+	}
+	if stripped != kubeadmCorefile {
+		t.Errorf("expected the managed directives to be removed, but got:\n%s", stripped)
+	}
+}
+
+func TestUpdateCorefileWithoutRootServerBlock(t *testing.T) {
+	cfg := &kubeoneapi.CoreDNSConfig{
+		Plugins: []kubeoneapi.CoreDNSPlugin{{Name: "log"}},
+	}
+
+	if _, err := UpdateCorefile("example.org:53 {\n    whoami\n}\n", cfg); err == nil {
+		t.Errorf("expected error for the Corefile without the root server block")
+	}
+}
+
+func TestUpdateCorefileWithoutForwardDirective(t *testing.T) {
+	cfg := &kubeoneapi.CoreDNSConfig{
+		Forwarders: []string{"8.8.8.8"},
+	}
+
+	if _, err := UpdateCorefile(".:53 {\n    errors\n}\n", cfg); err == nil {
+		t.Errorf("expected error for the Corefile without the forward directive")
+	}
+}
@@ -35,8 +35,22 @@ func Ensure(s *state.State) error {
 	var err error
 
 	switch {
+	case s.Cluster.CloudProvider.AWS != nil:
+		err = addons.EnsureAddonByName(s, resources.AddonCSIAwsEBS)
+	case s.Cluster.CloudProvider.Azure != nil:
+		if s.Cluster.CloudProvider.CloudConfig == "" {
+			return errors.New("cloudConfig not defined")
+		}
+		if err = addons.EnsureAddonByName(s, resources.AddonCSIAzureDisk); err != nil {
+			break
+		}
+		err = addons.EnsureAddonByName(s, resources.AddonCSIAzureFile)
 	case s.Cluster.CloudProvider.Hetzner != nil:
 		err = addons.EnsureAddonByName(s, resources.AddonCSIHetnzer)
+	case s.Cluster.CloudProvider.KubeVirt != nil:
+		err = addons.EnsureAddonByName(s, resources.AddonCSIKubeVirt)
+	case s.Cluster.CloudProvider.Nutanix != nil:
+		err = addons.EnsureAddonByName(s, resources.AddonCSINutanix)
 	case s.Cluster.CloudProvider.Openstack != nil:
 		if s.Cluster.CloudProvider.CloudConfig == "" {
 			return errors.New("cloudConfig not defined")
@@ -58,6 +72,8 @@ func Ensure(s *state.State) error {
 			return nil
 		}
 		err = addons.EnsureAddonByName(s, resources.AddonCSIVsphere)
+	case s.Cluster.CloudProvider.VMwareCloudDirector != nil:
+		err = addons.EnsureAddonByName(s, resources.AddonCSIVMwareCloudDirector)
 	default:
 		s.Logger.Infof("CSI driver for %q not yet supported, skipping", s.Cluster.CloudProvider.CloudProviderName())
 		return nil
@@ -70,8 +70,14 @@ func NewEncyrptionProvidersConfig(s *state.State) (*apiserverconfigv1.Encryption
 	}, nil
 }
 
+// Key providers supported by the encryption key rotation
+const (
+	KeyProviderAESCBC    = "aescbc"
+	KeyProviderSecretbox = "secretbox"
+)
+
 func UpdateEncryptionConfigDecryptOnly(config *apiserverconfigv1.EncryptionConfiguration) error {
-	if config.Resources[0].Providers[0].AESCBC == nil {
+	if keyProvider(config.Resources[0].Providers[0]) == "" {
 		return errors.New("empty AESCBC key configuration")
 	}
 
@@ -79,49 +85,159 @@ func UpdateEncryptionConfigDecryptOnly(config *apiserverconfigv1.EncryptionConfi
 		{
 			Identity: &apiserverconfigv1.IdentityConfiguration{},
 		},
-		{
-			AESCBC: config.Resources[0].Providers[0].AESCBC,
-		},
+		config.Resources[0].Providers[0],
 	}
 	return nil
 }
 
-func UpdateEncryptionConfigWithNewKey(config *apiserverconfigv1.EncryptionConfiguration) error {
+// UpdateEncryptionConfigAddNewKey adds the new key of the given key provider
+// and returns its name. The key is added after the keys of the same provider,
+// or after all providers if the provider isn't used yet, so kube-apiserver
+// can decrypt the resources encrypted with the new key, but keeps encrypting
+// with the current one. The current key provider is used if the provider is
+// empty. All existing keys are kept, so the resources encrypted by an
+// interrupted rotation can still be decrypted.
+func UpdateEncryptionConfigAddNewKey(config *apiserverconfigv1.EncryptionConfiguration, provider string) (string, error) {
+	providers := config.Resources[0].Providers
+	current := keyProvider(providers[0])
+	if current == "" {
+		return "", errors.New("empty AESCBC key configuration")
+	}
+	if provider == "" {
+		provider = current
+	}
+	if provider != KeyProviderAESCBC && provider != KeyProviderSecretbox {
+		return "", fmt.Errorf("unsupported key provider %q", provider)
+	}
+
 	secret, err := generateAESCBCSecret()
 	if err != nil {
-		return err
+		return "", err
+	}
+	key := apiserverconfigv1.Key{
+		Name:   fmt.Sprintf("kubeone-%s", utilrand.String(6)),
+		Secret: secret,
 	}
-	config.Resources[0].Providers = []apiserverconfigv1.ProviderConfiguration{
-		{
-			AESCBC: &apiserverconfigv1.AESConfiguration{
-				Keys: []apiserverconfigv1.Key{
-					{
-						Name:   fmt.Sprintf("kubeone-%s", utilrand.String(6)),
-						Secret: secret,
-					},
 
-					config.Resources[0].Providers[0].AESCBC.Keys[len(config.Resources[0].Providers[0].AESCBC.Keys)-1],
-				},
-			},
-		},
-		{
+	providers = append([]apiserverconfigv1.ProviderConfiguration{}, providers...)
+	last, same := -1, -1
+	for i := range providers {
+		switch keyProvider(providers[i]) {
+		case "":
+			continue
+		case provider:
+			same = i
+		}
+		last = i
+	}
+
+	switch {
+	case same >= 0:
+		keys := providerKeys(&providers[same])
+		*keys = append(*keys, key)
+	case provider == KeyProviderAESCBC:
+		providers = insertProvider(providers, last+1, apiserverconfigv1.ProviderConfiguration{
+			AESCBC: &apiserverconfigv1.AESConfiguration{Keys: []apiserverconfigv1.Key{key}},
+		})
+	case provider == KeyProviderSecretbox:
+		providers = insertProvider(providers, last+1, apiserverconfigv1.ProviderConfiguration{
+			Secretbox: &apiserverconfigv1.SecretboxConfiguration{Keys: []apiserverconfigv1.Key{key}},
+		})
+	}
+
+	hasIdentity := false
+	for _, p := range providers {
+		hasIdentity = hasIdentity || p.Identity != nil
+	}
+	if !hasIdentity {
+		providers = append(providers, apiserverconfigv1.ProviderConfiguration{
 			Identity: &apiserverconfigv1.IdentityConfiguration{},
-		},
+		})
 	}
-	return nil
+
+	config.Resources[0].Providers = providers
+	return key.Name, nil
 }
 
+// UpdateEncryptionConfigWithNewKey makes the key with the given name, added
+// by the UpdateEncryptionConfigAddNewKey, the one used for encryption, while
+// the other keys are still used for decryption. The configuration is left as
+// it is if the key is already used for encryption.
+func UpdateEncryptionConfigWithNewKey(config *apiserverconfigv1.EncryptionConfiguration, name string) error {
+	providers := config.Resources[0].Providers
+	if len(providers) < 2 || keyProvider(providers[0]) == "" {
+		return errors.New("empty AESCBC key configuration")
+	}
+
+	for i := range providers {
+		if keyProvider(providers[i]) == "" {
+			continue
+		}
+
+		provider := providers[i]
+		keys := providerKeys(&provider)
+		for j, key := range *keys {
+			if key.Name != name {
+				continue
+			}
+
+			// the key is moved to the front of its provider, and the
+			// provider to the front of the providers
+			*keys = append(append([]apiserverconfigv1.Key{key}, (*keys)[:j]...), (*keys)[j+1:]...)
+			reordered := []apiserverconfigv1.ProviderConfiguration{provider}
+			reordered = append(reordered, providers[:i]...)
+			config.Resources[0].Providers = append(reordered, providers[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("new encryption key %q is not found", name)
+}
+
+// UpdateEncryptionConfigRemoveOldKey keeps only the key used for encryption
 func UpdateEncryptionConfigRemoveOldKey(config *apiserverconfigv1.EncryptionConfiguration) {
+	provider := config.Resources[0].Providers[0]
+	keys := providerKeys(&provider)
+	*keys = []apiserverconfigv1.Key{(*keys)[0]}
+
 	config.Resources[0].Providers = []apiserverconfigv1.ProviderConfiguration{
-		{
-			AESCBC: &apiserverconfigv1.AESConfiguration{
-				Keys: []apiserverconfigv1.Key{
-					config.Resources[0].Providers[0].AESCBC.Keys[0],
-				},
-			},
-		},
+		provider,
 		{
 			Identity: &apiserverconfigv1.IdentityConfiguration{},
 		},
 	}
 }
+
+func insertProvider(providers []apiserverconfigv1.ProviderConfiguration, i int, provider apiserverconfigv1.ProviderConfiguration) []apiserverconfigv1.ProviderConfiguration {
+	providers = append(providers, apiserverconfigv1.ProviderConfiguration{})
+	copy(providers[i+1:], providers[i:])
+	providers[i] = provider
+
+	return providers
+}
+
+func keyProvider(provider apiserverconfigv1.ProviderConfiguration) string {
+	switch {
+	case provider.AESCBC != nil && len(provider.AESCBC.Keys) > 0:
+		return KeyProviderAESCBC
+	case provider.Secretbox != nil && len(provider.Secretbox.Keys) > 0:
+		return KeyProviderSecretbox
+	}
+	return ""
+}
+
+// providerKeys returns the keys of the aescbc or the secretbox provider,
+// copied, so they can be modified without modifying the original config
+func providerKeys(provider *apiserverconfigv1.ProviderConfiguration) *[]apiserverconfigv1.Key {
+	if provider.AESCBC != nil {
+		provider.AESCBC = &apiserverconfigv1.AESConfiguration{
+			Keys: append([]apiserverconfigv1.Key{}, provider.AESCBC.Keys...),
+		}
+		return &provider.AESCBC.Keys
+	}
+	provider.Secretbox = &apiserverconfigv1.SecretboxConfiguration{
+		Keys: append([]apiserverconfigv1.Key{}, provider.Secretbox.Keys...),
+	}
+	return &provider.Secretbox.Keys
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryptionproviders
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+)
+
+// newConfig returns the configuration with the given providers, described as
+// "aescbc:key1,key2", "secretbox:key1" or "identity"
+func newConfig(providers ...string) *apiserverconfigv1.EncryptionConfiguration {
+	config := &apiserverconfigv1.EncryptionConfiguration{
+		Resources: []apiserverconfigv1.ResourceConfiguration{
+			{Resources: []string{"secrets"}},
+		},
+	}
+
+	for _, p := range providers {
+		kind, names := p, ""
+		if i := strings.Index(p, ":"); i >= 0 {
+			kind, names = p[:i], p[i+1:]
+		}
+
+		var keys []apiserverconfigv1.Key
+		for _, name := range strings.Split(names, ",") {
+			keys = append(keys, apiserverconfigv1.Key{Name: name, Secret: "secret-" + name})
+		}
+
+		provider := apiserverconfigv1.ProviderConfiguration{}
+		switch kind {
+		case KeyProviderAESCBC:
+			provider.AESCBC = &apiserverconfigv1.AESConfiguration{Keys: keys}
+		case KeyProviderSecretbox:
+			provider.Secretbox = &apiserverconfigv1.SecretboxConfiguration{Keys: keys}
+		default:
+			provider.Identity = &apiserverconfigv1.IdentityConfiguration{}
+		}
+		config.Resources[0].Providers = append(config.Resources[0].Providers, provider)
+	}
+
+	return config
+}
+
+// describe returns the providers of the configuration in the form used by
+// newConfig, with the key of the given name shown as NEW
+func describe(config *apiserverconfigv1.EncryptionConfiguration, newKey string) []string {
+	var providers []string
+
+	for _, p := range config.Resources[0].Providers {
+		var (
+			kind string
+			keys []apiserverconfigv1.Key
+		)
+		switch {
+		case p.AESCBC != nil:
+			kind, keys = KeyProviderAESCBC, p.AESCBC.Keys
+		case p.Secretbox != nil:
+			kind, keys = KeyProviderSecretbox, p.Secretbox.Keys
+		default:
+			providers = append(providers, "identity")
+			continue
+		}
+
+		var names []string
+		for _, key := range keys {
+			if key.Name == newKey {
+				names = append(names, "NEW")
+				continue
+			}
+			names = append(names, key.Name)
+		}
+		providers = append(providers, kind+":"+strings.Join(names, ","))
+	}
+
+	return providers
+}
+
+func TestKeyRotationStages(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      []string
+		provider    string
+		wantAdded   []string
+		wantEncrypt []string
+		wantRemoved []string
+	}{
+		{
+			name:        "current key provider",
+			config:      []string{"aescbc:old", "identity"},
+			wantAdded:   []string{"aescbc:old,NEW", "identity"},
+			wantEncrypt: []string{"aescbc:NEW,old", "identity"},
+			wantRemoved: []string{"aescbc:NEW", "identity"},
+		},
+		{
+			name:        "same key provider",
+			config:      []string{"secretbox:old", "identity"},
+			provider:    KeyProviderSecretbox,
+			wantAdded:   []string{"secretbox:old,NEW", "identity"},
+			wantEncrypt: []string{"secretbox:NEW,old", "identity"},
+			wantRemoved: []string{"secretbox:NEW", "identity"},
+		},
+		{
+			name:        "different key provider",
+			config:      []string{"aescbc:old", "identity"},
+			provider:    KeyProviderSecretbox,
+			wantAdded:   []string{"aescbc:old", "secretbox:NEW", "identity"},
+			wantEncrypt: []string{"secretbox:NEW", "aescbc:old", "identity"},
+			wantRemoved: []string{"secretbox:NEW", "identity"},
+		},
+		{
+			name:        "identity fallback is added",
+			config:      []string{"aescbc:old"},
+			wantAdded:   []string{"aescbc:old,NEW", "identity"},
+			wantEncrypt: []string{"aescbc:NEW,old", "identity"},
+			wantRemoved: []string{"aescbc:NEW", "identity"},
+		},
+		{
+			name:        "resumed after the new key was added",
+			config:      []string{"aescbc:old,new1", "identity"},
+			wantAdded:   []string{"aescbc:old,new1,NEW", "identity"},
+			wantEncrypt: []string{"aescbc:NEW,old,new1", "identity"},
+			wantRemoved: []string{"aescbc:NEW", "identity"},
+		},
+		{
+			name:        "resumed after the secrets were partially rewritten",
+			config:      []string{"secretbox:new1", "aescbc:old", "identity"},
+			wantAdded:   []string{"secretbox:new1,NEW", "aescbc:old", "identity"},
+			wantEncrypt: []string{"secretbox:NEW,new1", "aescbc:old", "identity"},
+			wantRemoved: []string{"secretbox:NEW", "identity"},
+		},
+		{
+			name:        "resumed with the previous key provider",
+			config:      []string{"aescbc:new1", "secretbox:old", "identity"},
+			provider:    KeyProviderSecretbox,
+			wantAdded:   []string{"aescbc:new1", "secretbox:old,NEW", "identity"},
+			wantEncrypt: []string{"secretbox:NEW,old", "aescbc:new1", "identity"},
+			wantRemoved: []string{"secretbox:NEW", "identity"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config := newConfig(tt.config...)
+			original := config.DeepCopy()
+
+			newKey, err := UpdateEncryptionConfigAddNewKey(config, tt.provider)
+			if err != nil {
+				t.Fatalf("UpdateEncryptionConfigAddNewKey() error = %v", err)
+			}
+			if got := describe(config, newKey); !reflect.DeepEqual(got, tt.wantAdded) {
+				t.Errorf("after adding the new key got %q, want %q", got, tt.wantAdded)
+			}
+			if !reflect.DeepEqual(describe(original, ""), tt.config) {
+				t.Errorf("the keys of the original configuration must not be modified, got %q", describe(original, ""))
+			}
+
+			// the stage is retried after it's done, e.g. when restarting
+			// kube-apiserver fails
+			for i := 0; i < 2; i++ {
+				if err = UpdateEncryptionConfigWithNewKey(config, newKey); err != nil {
+					t.Fatalf("UpdateEncryptionConfigWithNewKey() error = %v", err)
+				}
+				if got := describe(config, newKey); !reflect.DeepEqual(got, tt.wantEncrypt) {
+					t.Errorf("after using the new key for encryption (%d) got %q, want %q", i, got, tt.wantEncrypt)
+				}
+			}
+
+			for i := 0; i < 2; i++ {
+				UpdateEncryptionConfigRemoveOldKey(config)
+				if got := describe(config, newKey); !reflect.DeepEqual(got, tt.wantRemoved) {
+					t.Errorf("after removing the old keys (%d) got %q, want %q", i, got, tt.wantRemoved)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyRotationErrors(t *testing.T) {
+	if _, err := UpdateEncryptionConfigAddNewKey(newConfig("identity", "aescbc:old"), ""); err == nil {
+		t.Error("expected error adding the key to the decrypt-only configuration")
+	}
+
+	if _, err := UpdateEncryptionConfigAddNewKey(newConfig("aescbc:old", "identity"), "kms"); err == nil {
+		t.Error("expected error adding the key of the unsupported key provider")
+	}
+
+	config := newConfig("aescbc:old,new1", "identity")
+	if err := UpdateEncryptionConfigWithNewKey(config, "missing"); err == nil {
+		t.Error("expected error using the missing key for encryption")
+	}
+	if got, want := describe(config, ""), []string{"aescbc:old,new1", "identity"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("the configuration must be kept on error, got %q, want %q", got, want)
+	}
+}
+
+func TestUpdateEncryptionConfigDecryptOnly(t *testing.T) {
+	config := newConfig("aescbc:old", "identity")
+	if err := UpdateEncryptionConfigDecryptOnly(config); err != nil {
+		t.Fatalf("UpdateEncryptionConfigDecryptOnly() error = %v", err)
+	}
+
+	if got, want := describe(config, ""), []string{"identity", "aescbc:old"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -44,10 +44,21 @@ func Ensure(s *state.State) error {
 	var err error
 
 	switch {
+	case s.Cluster.CloudProvider.AWS != nil:
+		err = addons.EnsureAddonByName(s, resources.AddonCCMAws)
+	case s.Cluster.CloudProvider.Azure != nil:
+		if s.Cluster.CloudProvider.CloudConfig == "" {
+			return errors.New("cloudConfig not defined")
+		}
+		err = addons.EnsureAddonByName(s, resources.AddonCCMAzure)
 	case s.Cluster.CloudProvider.Hetzner != nil:
 		err = addons.EnsureAddonByName(s, resources.AddonCCMHetzner)
 	case s.Cluster.CloudProvider.DigitalOcean != nil:
 		err = addons.EnsureAddonByName(s, resources.AddonCCMDigitalOcean)
+	case s.Cluster.CloudProvider.KubeVirt != nil:
+		err = addons.EnsureAddonByName(s, resources.AddonCCMKubeVirt)
+	case s.Cluster.CloudProvider.Nutanix != nil:
+		err = addons.EnsureAddonByName(s, resources.AddonCCMNutanix)
 	case s.Cluster.CloudProvider.Packet != nil:
 		err = addons.EnsureAddonByName(s, resources.AddonCCMPacket)
 	case s.Cluster.CloudProvider.Openstack != nil:
@@ -60,6 +71,8 @@ func Ensure(s *state.State) error {
 			return errors.Wrap(err, "failed to migrate to vsphere addon")
 		}
 		err = addons.EnsureAddonByName(s, resources.AddonCCMVsphere)
+	case s.Cluster.CloudProvider.VMwareCloudDirector != nil:
+		err = addons.EnsureAddonByName(s, resources.AddonCCMVMwareCloudDirector)
 	default:
 		s.Logger.Infof("External CCM for %q not yet supported, skipping", s.Cluster.CloudProvider.CloudProviderName())
 		return nil
@@ -44,11 +44,20 @@ func (res Resource) namedReference(kubernetesVersionGetter func() string) refere
 
 const (
 	// default 0 index has no meaning
-	AzureCCM Resource = iota + 1
+	AwsCCM Resource = iota + 1
+	AwsEbsCSI
+	AwsEncryptionProvider
+	AzureCCM
 	AzureCNM
+	AzureDiskCSI
+	AzureFileCSI
+	AzureKMSPlugin
 	CalicoCNI
 	CalicoController
 	CalicoNode
+	CiliumAgent
+	CiliumOperator
+	ClusterAutoscaler
 	CSIAttacher
 	CSINodeDriverRegistar
 	CSIProvisioner
@@ -58,16 +67,33 @@ const (
 	DigitaloceanCCM
 	DNSNodeCache
 	Flannel
+	GCPKMSPlugin
+	Gatekeeper
 	HetznerCCM
 	HetznerCSI
+	HubbleRelay
+	HubbleUI
+	HubbleUIBackend
+	KonnectivityAgent
+	KonnectivityServer
+	KubeVIP
+	KubeVirtCCM
+	KubeVirtCSI
 	MachineController
 	MetricsServer
+	NutanixCCM
+	NutanixCSI
 	OpenstackCCM
 	OpenstackCSI
+	OperatingSystemManager
 	PacketCCM
+	SRIOVCNI
+	SRIOVDevicePlugin
 	VsphereCCM
 	VsphereCSIDriver
 	VsphereCSISyncer
+	VMwareCloudDirectorCCM
+	VMwareCloudDirectorCSI
 	WeaveNetCNIKube
 	WeaveNetCNINPC
 )
@@ -107,19 +133,64 @@ func optionalResources() map[Resource]map[string]string {
 			">= 1.20.0":           "k8s.gcr.io/sig-storage/csi-snapshotter:v4.2.0",
 		},
 
+		// Cluster autoscaler, the minor version should match the Kubernetes minor version
+		ClusterAutoscaler: {
+			"1.19.x":    "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.19.2",
+			"1.20.x":    "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.20.1",
+			"1.21.x":    "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.21.1",
+			">= 1.22.0": "k8s.gcr.io/autoscaling/cluster-autoscaler:v1.22.1",
+		},
+
+		// AWS CCM
+		AwsCCM: {
+			"1.19.x":    "k8s.gcr.io/provider-aws/cloud-controller-manager:v1.19.0-alpha.1",
+			"1.20.x":    "k8s.gcr.io/provider-aws/cloud-controller-manager:v1.20.0-alpha.0",
+			"1.21.x":    "k8s.gcr.io/provider-aws/cloud-controller-manager:v1.21.0-alpha.0",
+			">= 1.22.0": "k8s.gcr.io/provider-aws/cloud-controller-manager:v1.22.0-alpha.0",
+		},
+
+		// AWS EBS CSI
+		AwsEbsCSI: {"*": "k8s.gcr.io/provider-aws/aws-ebs-csi-driver:v1.4.0"},
+
 		// Azure CCM
 		AzureCCM: {"*": "mcr.microsoft.com/oss/kubernetes/azure-cloud-controller-manager:v1.0.1"},
 		AzureCNM: {"*": "mcr.microsoft.com/oss/kubernetes/azure-cloud-node-manager:v1.0.1"},
 
+		// Azure CSI
+		AzureDiskCSI: {"*": "mcr.microsoft.com/k8s/csi/azuredisk-csi:v1.8.0"},
+		AzureFileCSI: {"*": "mcr.microsoft.com/k8s/csi/azurefile-csi:v1.7.0"},
+
+		// Cilium CNI plugin
+		CiliumAgent:     {"*": "quay.io/cilium/cilium:v1.11.0"},
+		CiliumOperator:  {"*": "quay.io/cilium/operator-generic:v1.11.0"},
+		HubbleRelay:     {"*": "quay.io/cilium/hubble-relay:v1.11.0"},
+		HubbleUI:        {"*": "quay.io/cilium/hubble-ui:v0.8.5"},
+		HubbleUIBackend: {"*": "quay.io/cilium/hubble-ui-backend:v0.8.5"},
+
 		// DigitalOcean CCM
 		DigitaloceanCCM: {"*": "docker.io/digitalocean/digitalocean-cloud-controller-manager:v0.1.33"},
 
+		// OPA Gatekeeper
+		Gatekeeper: {"*": "docker.io/openpolicyagent/gatekeeper:v3.7.0"},
+
 		// Hetzner CCM
 		HetznerCCM: {"*": "docker.io/hetznercloud/hcloud-cloud-controller-manager:v1.9.1"},
 
 		// Hetzner CSI
 		HetznerCSI: {"*": "docker.io/hetznercloud/hcloud-csi-driver:1.6.0"},
 
+		// KubeVirt CCM
+		KubeVirtCCM: {"*": "quay.io/kubevirt/kubevirt-cloud-controller-manager:v0.4.0"},
+
+		// KubeVirt CSI
+		KubeVirtCSI: {"*": "quay.io/kubevirt/kubevirt-csi-driver:v0.1.0"},
+
+		// Nutanix CCM
+		NutanixCCM: {"*": "ghcr.io/nutanix-cloud-native/cloud-provider-nutanix/controller:v0.2.0"},
+
+		// Nutanix CSI
+		NutanixCSI: {"*": "quay.io/karbon/ntnx-csi:v2.5.1"},
+
 		// OpenStack CCM
 		OpenstackCCM: {
 			"1.19.x":    "docker.io/k8scloudprovider/openstack-cloud-controller-manager:v1.19.2",
@@ -136,8 +207,27 @@ func optionalResources() map[Resource]map[string]string {
 			">= 1.22.0": "docker.io/k8scloudprovider/cinder-csi-plugin:v1.22.0",
 		},
 
-		// Packet CCM
-		PacketCCM: {"*": "docker.io/packethost/packet-ccm:v1.0.0"},
+		// operating-system-manager
+		OperatingSystemManager: {"*": "quay.io/kubermatic/operating-system-manager:v0.4.0"},
+
+		// kube-vip
+		KubeVIP: {"*": "ghcr.io/kube-vip/kube-vip:v0.4.0"},
+
+		// Konnectivity
+		KonnectivityAgent:  {"*": "k8s.gcr.io/kas-network-proxy/proxy-agent:v0.0.27"},
+		KonnectivityServer: {"*": "k8s.gcr.io/kas-network-proxy/proxy-server:v0.0.27"},
+
+		// KMS plugins
+		AwsEncryptionProvider: {"*": "gcr.io/k8s-staging-provider-aws/aws-encryption-provider:v0.1.0"},
+		AzureKMSPlugin:        {"*": "mcr.microsoft.com/oss/azure/kms/keyvault:v0.2.0"},
+		GCPKMSPlugin:          {"*": "gcr.io/google-containers/k8s-cloud-kms-plugin:v0.1.1"},
+
+		// Equinix Metal (Packet) CCM
+		PacketCCM: {"*": "docker.io/equinix/cloud-provider-equinix-metal:v3.3.0"},
+
+		// SR-IOV
+		SRIOVCNI:          {"*": "ghcr.io/k8snetworkplumbingwg/sriov-cni:v2.6.1"},
+		SRIOVDevicePlugin: {"*": "ghcr.io/k8snetworkplumbingwg/sriov-network-device-plugin:v3.3.2"},
 
 		// vSphere CCM
 		VsphereCCM: {
@@ -150,6 +240,12 @@ func optionalResources() map[Resource]map[string]string {
 		VsphereCSIDriver: {"*": "gcr.io/cloud-provider-vsphere/csi/release/driver:v2.3.0"},
 		VsphereCSISyncer: {"*": "gcr.io/cloud-provider-vsphere/csi/release/syncer:v2.3.0"},
 
+		// VMware Cloud Director CCM
+		VMwareCloudDirectorCCM: {"*": "projects.registry.vmware.com/vmware-cloud-director/cloud-provider-for-cloud-director:1.1.0"},
+
+		// VMware Cloud Director CSI
+		VMwareCloudDirectorCSI: {"*": "projects.registry.vmware.com/vmware-cloud-director/cloud-director-named-disk-csi-driver:1.1.0"},
+
 		// WeaveNet CNI plugin
 		WeaveNetCNIKube: {"*": "docker.io/weaveworks/weave-kube:2.8.1"},
 		WeaveNetCNINPC:  {"*": "docker.io/weaveworks/weave-npc:2.8.1"},
@@ -211,18 +307,21 @@ const (
 	ListFilterOpional
 )
 
-func (r *Resolver) List(lf ListFilter) []string {
-	var list []string
-
-	fn := allResources
+func (lf ListFilter) resources() map[Resource]map[string]string {
 	switch lf {
 	case ListFilterBase:
-		fn = baseResources
+		return baseResources()
 	case ListFilterOpional:
-		fn = optionalResources
+		return optionalResources()
 	}
 
-	for res := range fn() {
+	return allResources()
+}
+
+func (r *Resolver) List(lf ListFilter) []string {
+	var list []string
+
+	for res := range lf.resources() {
 		img := r.Get(res)
 		if img != "" {
 			list = append(list, img)
@@ -233,6 +332,20 @@ func (r *Resolver) List(lf ListFilter) []string {
 	return list
 }
 
+// Tags returns the image tags of the resources matching the filter, keyed by
+// the resource name
+func (r *Resolver) Tags(lf ListFilter) map[string]string {
+	tags := map[string]string{}
+
+	for res := range lf.resources() {
+		if r.Get(res) != "" {
+			tags[res.String()] = r.Tag(res)
+		}
+	}
+
+	return tags
+}
+
 func (r *Resolver) Tag(res Resource) string {
 	named := res.namedReference(r.kubernetesVersionGetter)
 	if tagged, ok := named.(reference.Tagged); ok {
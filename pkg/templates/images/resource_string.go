@@ -8,37 +8,63 @@ func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
 	var x [1]struct{}
-	_ = x[AzureCCM-1]
-	_ = x[AzureCNM-2]
-	_ = x[CalicoCNI-3]
-	_ = x[CalicoController-4]
-	_ = x[CalicoNode-5]
-	_ = x[CSIAttacher-6]
-	_ = x[CSINodeDriverRegistar-7]
-	_ = x[CSIProvisioner-8]
-	_ = x[CSISnapshotter-9]
-	_ = x[CSIResizer-10]
-	_ = x[CSILivenessProbe-11]
-	_ = x[DigitaloceanCCM-12]
-	_ = x[DNSNodeCache-13]
-	_ = x[Flannel-14]
-	_ = x[HetznerCCM-15]
-	_ = x[HetznerCSI-16]
-	_ = x[MachineController-17]
-	_ = x[MetricsServer-18]
-	_ = x[OpenstackCCM-19]
-	_ = x[OpenstackCSI-20]
-	_ = x[PacketCCM-21]
-	_ = x[VsphereCCM-22]
-	_ = x[VsphereCSIDriver-23]
-	_ = x[VsphereCSISyncer-24]
-	_ = x[WeaveNetCNIKube-25]
-	_ = x[WeaveNetCNINPC-26]
+	_ = x[AwsCCM-1]
+	_ = x[AwsEbsCSI-2]
+	_ = x[AwsEncryptionProvider-3]
+	_ = x[AzureCCM-4]
+	_ = x[AzureCNM-5]
+	_ = x[AzureDiskCSI-6]
+	_ = x[AzureFileCSI-7]
+	_ = x[AzureKMSPlugin-8]
+	_ = x[CalicoCNI-9]
+	_ = x[CalicoController-10]
+	_ = x[CalicoNode-11]
+	_ = x[CiliumAgent-12]
+	_ = x[CiliumOperator-13]
+	_ = x[ClusterAutoscaler-14]
+	_ = x[CSIAttacher-15]
+	_ = x[CSINodeDriverRegistar-16]
+	_ = x[CSIProvisioner-17]
+	_ = x[CSISnapshotter-18]
+	_ = x[CSIResizer-19]
+	_ = x[CSILivenessProbe-20]
+	_ = x[DigitaloceanCCM-21]
+	_ = x[DNSNodeCache-22]
+	_ = x[Flannel-23]
+	_ = x[GCPKMSPlugin-24]
+	_ = x[Gatekeeper-25]
+	_ = x[HetznerCCM-26]
+	_ = x[HetznerCSI-27]
+	_ = x[HubbleRelay-28]
+	_ = x[HubbleUI-29]
+	_ = x[HubbleUIBackend-30]
+	_ = x[KonnectivityAgent-31]
+	_ = x[KonnectivityServer-32]
+	_ = x[KubeVIP-33]
+	_ = x[KubeVirtCCM-34]
+	_ = x[KubeVirtCSI-35]
+	_ = x[MachineController-36]
+	_ = x[MetricsServer-37]
+	_ = x[NutanixCCM-38]
+	_ = x[NutanixCSI-39]
+	_ = x[OpenstackCCM-40]
+	_ = x[OpenstackCSI-41]
+	_ = x[OperatingSystemManager-42]
+	_ = x[PacketCCM-43]
+	_ = x[SRIOVCNI-44]
+	_ = x[SRIOVDevicePlugin-45]
+	_ = x[VsphereCCM-46]
+	_ = x[VsphereCSIDriver-47]
+	_ = x[VsphereCSISyncer-48]
+	_ = x[VMwareCloudDirectorCCM-49]
+	_ = x[VMwareCloudDirectorCSI-50]
+	_ = x[WeaveNetCNIKube-51]
+	_ = x[WeaveNetCNINPC-52]
 }
 
-const _Resource_name = "AzureCCMAzureCNMCalicoCNICalicoControllerCalicoNodeCSIAttacherCSINodeDriverRegistarCSIProvisionerCSISnapshotterCSIResizerCSILivenessProbeDigitaloceanCCMDNSNodeCacheFlannelHetznerCCMHetznerCSIMachineControllerMetricsServerOpenstackCCMOpenstackCSIPacketCCMVsphereCCMVsphereCSIDriverVsphereCSISyncerWeaveNetCNIKubeWeaveNetCNINPC"
+const _Resource_name = "AwsCCMAwsEbsCSIAwsEncryptionProviderAzureCCMAzureCNMAzureDiskCSIAzureFileCSIAzureKMSPluginCalicoCNICalicoControllerCalicoNodeCiliumAgentCiliumOperatorClusterAutoscalerCSIAttacherCSINodeDriverRegistarCSIProvisionerCSISnapshotterCSIResizerCSILivenessProbeDigitaloceanCCMDNSNodeCacheFlannelGCPKMSPluginGatekeeperHetznerCCMHetznerCSIHubbleRelayHubbleUIHubbleUIBackendKonnectivityAgentKonnectivityServerKubeVIPKubeVirtCCMKubeVirtCSIMachineControllerMetricsServerNutanixCCMNutanixCSIOpenstackCCMOpenstackCSIOperatingSystemManagerPacketCCMSRIOVCNISRIOVDevicePluginVsphereCCMVsphereCSIDriverVsphereCSISyncerVMwareCloudDirectorCCMVMwareCloudDirectorCSIWeaveNetCNIKubeWeaveNetCNINPC"
 
-var _Resource_index = [...]uint16{0, 8, 16, 25, 41, 51, 62, 83, 97, 111, 121, 137, 152, 164, 171, 181, 191, 208, 221, 233, 245, 254, 264, 280, 296, 311, 325}
+var _Resource_index = [...]uint16{0, 6, 15, 36, 44, 52, 64, 76, 90, 99, 115, 125, 136, 150, 167, 178, 199, 213, 227, 237, 253, 268, 280, 287, 299, 309, 319, 329, 340, 348, 363, 380, 398, 405, 416, 427, 444, 457, 467, 477, 489, 501, 523, 532, 540, 557, 567, 583, 599, 621, 643, 658, 672}
 
 func (i Resource) String() string {
 	i -= 1
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigDir is the directory of the EgressSelectorConfiguration on the
+	// control plane nodes, mounted to kube-apiserver
+	ConfigDir = "/etc/kubernetes/konnectivity"
+	// EgressSelectorConfigPath is the path of the EgressSelectorConfiguration
+	EgressSelectorConfigPath = ConfigDir + "/egress-selector-config.yaml"
+	// SocketDir is the directory of the konnectivity-server socket, mounted
+	// to kube-apiserver
+	SocketDir = "/etc/kubernetes/konnectivity-server"
+	// SocketPath is the path of the socket kube-apiserver connects to
+	// konnectivity-server over
+	SocketPath = SocketDir + "/konnectivity-server.socket"
+	// KubeconfigPath is the path of the konnectivity-server kubeconfig
+	KubeconfigPath = "/etc/kubernetes/konnectivity-server.conf"
+
+	// ServerUser is the user konnectivity-server authenticates as, and the
+	// audience of the konnectivity-agent ServiceAccount tokens
+	ServerUser = "system:konnectivity-server"
+	// AgentServiceAccount is the name of the konnectivity-agent
+	// ServiceAccount in the kube-system namespace
+	AgentServiceAccount = "konnectivity-agent"
+
+	// kubeconfigRenewBefore is how long before the expiration the
+	// konnectivity-server client certificate is renewed
+	kubeconfigRenewBefore = 90 * 24 * time.Hour
+)
+
+// egressSelectorConfiguration is the configuration of the kube-apiserver
+// egress selector
+type egressSelectorConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	EgressSelections []egressSelection `json:"egressSelections"`
+}
+
+type egressSelection struct {
+	Name       string     `json:"name"`
+	Connection connection `json:"connection"`
+}
+
+type connection struct {
+	ProxyProtocol string     `json:"proxyProtocol"`
+	Transport     *transport `json:"transport,omitempty"`
+}
+
+type transport struct {
+	UDS *udsTransport `json:"uds,omitempty"`
+}
+
+type udsTransport struct {
+	UDSName string `json:"udsName"`
+}
+
+// EgressSelectorConfig generates the EgressSelectorConfiguration manifest
+// routing the kube-apiserver traffic to the nodes, pods and services through
+// the konnectivity-server socket
+func EgressSelectorConfig() (string, error) {
+	cfg := egressSelectorConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apiserver.k8s.io/v1beta1",
+			Kind:       "EgressSelectorConfiguration",
+		},
+		EgressSelections: []egressSelection{
+			{
+				Name: "cluster",
+				Connection: connection{
+					ProxyProtocol: "GRPC",
+					Transport: &transport{
+						UDS: &udsTransport{
+							UDSName: SocketPath,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	buf, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal EgressSelectorConfiguration")
+	}
+
+	return string(buf), nil
+}
+
+// ServerKubeconfig generates the kubeconfig konnectivity-server uses to
+// authenticate the agents' tokens against kube-apiserver, using the given
+// client certificate signed by the Kubernetes CA
+func ServerKubeconfig(server string, caCertPEM, certPEM, keyPEM []byte) ([]byte, error) {
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters["kubernetes"] = &clientcmdapi.Cluster{
+		Server:                   server,
+		CertificateAuthorityData: caCertPEM,
+	}
+	kubeconfig.AuthInfos[ServerUser] = &clientcmdapi.AuthInfo{
+		ClientCertificateData: certPEM,
+		ClientKeyData:         keyPEM,
+	}
+	kubeconfig.Contexts[ServerUser] = &clientcmdapi.Context{
+		Cluster:  "kubernetes",
+		AuthInfo: ServerUser,
+	}
+	kubeconfig.CurrentContext = ServerUser
+
+	buf, err := clientcmd.Write(*kubeconfig)
+
+	return buf, errors.Wrap(err, "failed to serialize konnectivity-server kubeconfig")
+}
+
+// ServerKubeconfigNeedsRenewal returns whether the existing
+// konnectivity-server kubeconfig must be generated again, because it can't be
+// parsed or the client certificate expires in less than 90 days
+func ServerKubeconfigNeedsRenewal(kubeconfig []byte) bool {
+	cfg, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return true
+	}
+
+	authInfo, ok := cfg.AuthInfos[ServerUser]
+	if !ok {
+		return true
+	}
+
+	certs, err := certutil.ParseCertsPEM(authInfo.ClientCertificateData)
+	if err != nil || len(certs) == 0 {
+		return true
+	}
+
+	return time.Now().Add(kubeconfigRenewBefore).After(certs[0].NotAfter)
+}
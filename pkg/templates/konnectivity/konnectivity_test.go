@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package konnectivity
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"testing"
+
+	"k8c.io/kubeone/pkg/testhelper"
+
+	certutil "k8s.io/client-go/util/cert"
+)
+
+var updateFlag = flag.Bool("update", false, "update testdata files")
+
+func TestEgressSelectorConfig(t *testing.T) {
+	cfg, err := EgressSelectorConfig()
+	if err != nil {
+		t.Fatalf("EgressSelectorConfig() error = %v", err)
+	}
+
+	testhelper.DiffOutput(t, testhelper.FSGoldenName(t), cfg, *updateFlag)
+}
+
+func TestServerKubeconfigNeedsRenewal(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	cert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: ServerUser}, key)
+	if err != nil {
+		t.Fatalf("failed to generate certificate: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	kubeconfig, err := ServerKubeconfig("https://127.0.0.1:6443", certPEM, certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("ServerKubeconfig() error = %v", err)
+	}
+
+	if ServerKubeconfigNeedsRenewal(kubeconfig) {
+		t.Errorf("expected the valid kubeconfig not to need the renewal")
+	}
+
+	if !ServerKubeconfigNeedsRenewal([]byte("invalid")) {
+		t.Errorf("expected the invalid kubeconfig to need the renewal")
+	}
+}
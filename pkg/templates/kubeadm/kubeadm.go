@@ -17,11 +17,14 @@ limitations under the License.
 package kubeadm
 
 import (
+	"fmt"
+
 	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/kubeadm/v1beta3"
 )
 
 const (
@@ -56,6 +59,23 @@ func New(ver string) (Kubedm, error) {
 	}
 }
 
+// Patches returns kubeadm patches of the control plane host, keyed by their
+// path relative to the workdir. Patches are supported only by the v1beta3
+// kubeadm API, so they're rejected by validation for older Kubernetes versions.
+func Patches(host kubeoneapi.HostConfig) (map[string]string, error) {
+	return v1beta3.NewPatches(host)
+}
+
+// UpgradePatchesFlag returns the flag applying kubeadm patches of the control
+// plane host when upgrading it, or an empty string if there are no patches
+func UpgradePatchesFlag(workdir string, host kubeoneapi.HostConfig) string {
+	if !host.NetworkOverrides.EtcdURLsOverridden() {
+		return ""
+	}
+
+	return fmt.Sprintf(" --patches=%s/%s", workdir, v1beta3.PatchesDir(host))
+}
+
 func mustParseConstraint(constraint string) *semver.Constraints {
 	c, err := semver.NewConstraint(constraint)
 	if err != nil {
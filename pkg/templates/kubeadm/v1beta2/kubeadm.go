@@ -25,13 +25,14 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 
+	"k8c.io/kubeone/pkg/addons"
 	kubeadmv1beta2 "k8c.io/kubeone/pkg/apis/kubeadm/v1beta2"
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/features"
 	"k8c.io/kubeone/pkg/kubeflags"
 	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/konnectivity"
 	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
-	"k8c.io/kubeone/pkg/templates/resources"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -88,7 +89,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 			},
 		},
 		LocalAPIEndpoint: kubeadmv1beta2.APIEndpoint{
-			AdvertiseAddress: newNodeIP(host),
+			AdvertiseAddress: host.AdvertiseAddress(),
 		},
 	}
 
@@ -99,7 +100,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		},
 		ControlPlane: &kubeadmv1beta2.JoinControlPlane{
 			LocalAPIEndpoint: kubeadmv1beta2.APIEndpoint{
-				AdvertiseAddress: newNodeIP(host),
+				AdvertiseAddress: host.AdvertiseAddress(),
 			},
 		},
 		Discovery: kubeadmv1beta2.Discovery{
@@ -117,8 +118,8 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 			Kind:       "ClusterConfiguration",
 		},
 		Networking: kubeadmv1beta2.Networking{
-			PodSubnet:     cluster.ClusterNetwork.PodSubnet,
-			ServiceSubnet: cluster.ClusterNetwork.ServiceSubnet,
+			PodSubnet:     cluster.ClusterNetwork.PodSubnets(),
+			ServiceSubnet: cluster.ClusterNetwork.ServiceSubnets(),
 			DNSDomain:     cluster.ClusterNetwork.ServiceDomainName,
 		},
 		KubernetesVersion:    cluster.Versions.Kubernetes,
@@ -142,14 +143,6 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		},
 		ClusterName:     cluster.Name,
 		ImageRepository: cluster.AssetConfiguration.Kubernetes.ImageRepository,
-		Etcd: kubeadmv1beta2.Etcd{
-			Local: &kubeadmv1beta2.LocalEtcd{
-				ImageMeta: kubeadmv1beta2.ImageMeta{
-					ImageRepository: cluster.AssetConfiguration.Etcd.ImageRepository,
-					ImageTag:        cluster.AssetConfiguration.Etcd.ImageTag,
-				},
-			},
-		},
 		DNS: kubeadmv1beta2.DNS{
 			ImageMeta: kubeadmv1beta2.ImageMeta{
 				ImageRepository: cluster.AssetConfiguration.CoreDNS.ImageRepository,
@@ -158,6 +151,24 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		},
 	}
 
+	if ext := cluster.ExternalEtcd; ext != nil {
+		clusterConfig.Etcd.External = &kubeadmv1beta2.ExternalEtcd{
+			Endpoints: ext.Endpoints,
+			CAFile:    ext.CAFile,
+			CertFile:  ext.CertFile,
+			KeyFile:   ext.KeyFile,
+		}
+	} else {
+		clusterConfig.Etcd.Local = &kubeadmv1beta2.LocalEtcd{
+			ImageMeta: kubeadmv1beta2.ImageMeta{
+				ImageRepository: cluster.AssetConfiguration.Etcd.ImageRepository,
+				ImageTag:        cluster.AssetConfiguration.Etcd.ImageTag,
+			},
+			ExtraArgs:      features.EtcdExtraArgs(cluster),
+			ServerCertSANs: cluster.Etcd.ServerCertSANs(),
+		}
+	}
+
 	bfalse := false
 	kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{
 		TypeMeta: metav1.TypeMeta{
@@ -167,7 +178,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		CgroupDriver:       "systemd",
 		ReadOnlyPort:       0,
 		RotateCertificates: true,
-		ClusterDNS:         []string{resources.NodeLocalDNSVirtualIP},
+		ClusterDNS:         features.KubeletClusterDNS(cluster),
 		Authentication: kubeletconfigv1beta1.KubeletAuthentication{
 			Anonymous: kubeletconfigv1beta1.KubeletAnonymousAuthentication{
 				Enabled: &bfalse,
@@ -176,6 +187,16 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		FeatureGates: map[string]bool{},
 	}
 
+	if cluster.Features.FIPS != nil && cluster.Features.FIPS.Enable {
+		kubeletConfig.TLSCipherSuites = features.FIPSTLSCipherSuites
+		kubeletConfig.TLSMinVersion = features.FIPSTLSMinVersion
+	}
+
+	features.UpdateKubeletConfiguration(cluster.KubeletConfig, kubeletConfig)
+	for k, v := range cluster.HostKubeletConfig(host).Flags() {
+		nodeRegistration.KubeletExtraArgs[k] = v
+	}
+
 	if cluster.AssetConfiguration.Pause.ImageRepository != "" {
 		nodeRegistration.KubeletExtraArgs["pod-infra-container-image"] = cluster.AssetConfiguration.Pause.ImageRepository + "/pause:" + cluster.AssetConfiguration.Pause.ImageTag
 	}
@@ -267,10 +288,14 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 			PathType:  corev1.HostPathDirectoryOrCreate,
 		}
 		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, auditPolicyVol)
-		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, logVol)
+		if !cluster.Features.StaticAuditLog.Config.DisableLogBackend {
+			clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, logVol)
+		}
 	}
 
-	if cluster.Features.PodNodeSelector != nil && cluster.Features.PodNodeSelector.Enable {
+	if (cluster.Features.PodNodeSelector != nil && cluster.Features.PodNodeSelector.Enable) ||
+		(cluster.Features.EventRateLimit != nil && cluster.Features.EventRateLimit.Enable) ||
+		(cluster.Features.PodSecurityAdmission != nil && cluster.Features.PodSecurityAdmission.Enable) {
 		admissionVol := kubeadmv1beta2.HostPathMount{
 			Name:      "admission-conf",
 			HostPath:  "/etc/kubernetes/admission",
@@ -280,6 +305,23 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		}
 		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, admissionVol)
 	}
+	if cluster.Features.Konnectivity.Enabled() {
+		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes,
+			kubeadmv1beta2.HostPathMount{
+				Name:      "konnectivity-conf",
+				HostPath:  konnectivity.ConfigDir,
+				MountPath: konnectivity.ConfigDir,
+				ReadOnly:  true,
+				PathType:  corev1.HostPathDirectoryOrCreate,
+			},
+			kubeadmv1beta2.HostPathMount{
+				Name:      "konnectivity-uds",
+				HostPath:  konnectivity.SocketDir,
+				MountPath: konnectivity.SocketDir,
+				PathType:  corev1.HostPathDirectoryOrCreate,
+			},
+		)
+	}
 	// this is not exactly as s.EncryptionEnabled(). We need this to be true during the enable/disable or disable/enable transition.
 	if (cluster.Features.EncryptionProviders != nil && cluster.Features.EncryptionProviders.Enable) ||
 		s.LiveCluster.EncryptionConfiguration.Enable {
@@ -312,10 +354,23 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 
 	args := kubeadmargs.NewFrom(clusterConfig.APIServer.ExtraArgs)
 	features.UpdateKubeadmClusterConfiguration(cluster.Features, args)
+	features.UpdateKubeadmAPIServerConfiguration(cluster.ControlPlaneComponents, args)
 
 	clusterConfig.APIServer.ExtraArgs = args.APIServer.ExtraArgs
 	clusterConfig.FeatureGates = args.FeatureGates
 
+	if components := cluster.ControlPlaneComponents; components != nil {
+		clusterConfig.ControllerManager.ExtraArgs = features.MergeControlPlaneComponentArgs(components.ControllerManager, clusterConfig.ControllerManager.ExtraArgs)
+		clusterConfig.ControllerManager.ExtraVolumes = append(clusterConfig.ControllerManager.ExtraVolumes, controlPlaneComponentVolumes(components.ControllerManager)...)
+		clusterConfig.Scheduler.ExtraArgs = features.MergeControlPlaneComponentArgs(components.Scheduler, clusterConfig.Scheduler.ExtraArgs)
+		clusterConfig.Scheduler.ExtraVolumes = append(clusterConfig.Scheduler.ExtraVolumes, controlPlaneComponentVolumes(components.Scheduler)...)
+	}
+
+	clusterConfig.APIServer.ExtraArgs = features.MergeFeatureGatesArgs(cluster.Features.FeatureGates, clusterConfig.APIServer.ExtraArgs)
+	clusterConfig.ControllerManager.ExtraArgs = features.MergeFeatureGatesArgs(cluster.Features.FeatureGates, clusterConfig.ControllerManager.ExtraArgs)
+	clusterConfig.Scheduler.ExtraArgs = features.MergeFeatureGatesArgs(cluster.Features.FeatureGates, clusterConfig.Scheduler.ExtraArgs)
+	kubeletConfig.FeatureGates = features.MergeFeatureGates(cluster.Features.FeatureGates, kubeletConfig.FeatureGates)
+
 	initConfig.NodeRegistration = nodeRegistration
 	joinConfig.NodeRegistration = nodeRegistration
 
@@ -324,6 +379,24 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 	return []runtime.Object{initConfig, joinConfig, clusterConfig, kubeletConfig, kubeproxyConfig}, nil
 }
 
+func controlPlaneComponentVolumes(cfg *kubeoneapi.ControlPlaneComponentConfig) []kubeadmv1beta2.HostPathMount {
+	if cfg == nil {
+		return nil
+	}
+
+	volumes := []kubeadmv1beta2.HostPathMount{}
+	for _, vol := range cfg.ExtraVolumes {
+		volumes = append(volumes, kubeadmv1beta2.HostPathMount{
+			Name:      vol.Name,
+			HostPath:  vol.HostPath,
+			MountPath: vol.MountPath,
+			ReadOnly:  vol.ReadOnly,
+		})
+	}
+
+	return volumes
+}
+
 // NewConfig returns all required configs to init a cluster via a set of v1beta2 configs
 func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, error) {
 	cluster := s.Cluster
@@ -358,7 +431,7 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 		CgroupDriver:       "systemd",
 		ReadOnlyPort:       0,
 		RotateCertificates: true,
-		ClusterDNS:         []string{resources.NodeLocalDNSVirtualIP},
+		ClusterDNS:         features.KubeletClusterDNS(cluster),
 		Authentication: kubeletconfigv1beta1.KubeletAuthentication{
 			Anonymous: kubeletconfigv1beta1.KubeletAnonymousAuthentication{
 				Enabled: &bfalse,
@@ -367,6 +440,13 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 		FeatureGates: map[string]bool{},
 	}
 
+	if cluster.Features.FIPS != nil && cluster.Features.FIPS.Enable {
+		kubeletConfig.TLSCipherSuites = features.FIPSTLSCipherSuites
+		kubeletConfig.TLSMinVersion = features.FIPSTLSMinVersion
+	}
+
+	features.UpdateKubeletConfiguration(cluster.KubeletConfig, kubeletConfig)
+
 	if cluster.AssetConfiguration.Pause.ImageRepository != "" {
 		nodeRegistration.KubeletExtraArgs["pod-infra-container-image"] = cluster.AssetConfiguration.Pause.ImageRepository + "/pause:" + cluster.AssetConfiguration.Pause.ImageTag
 	}
@@ -393,6 +473,21 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 		}
 	}
 
+	kubeletConfig.FeatureGates = features.MergeFeatureGates(cluster.Features.FeatureGates, kubeletConfig.FeatureGates)
+
+	if pool := cluster.StaticWorkers.Pool(host.Pool); pool != nil {
+		for k, v := range pool.KubeletExtraArgs {
+			nodeRegistration.KubeletExtraArgs[k] = v
+		}
+		for k, v := range pool.FeatureGates {
+			kubeletConfig.FeatureGates[k] = v
+		}
+	}
+
+	for k, v := range cluster.HostKubeletConfig(host).Flags() {
+		nodeRegistration.KubeletExtraArgs[k] = v
+	}
+
 	joinConfig.NodeRegistration = nodeRegistration
 
 	kubeproxyConfig := kubeProxyConfiguration(s)
@@ -400,22 +495,13 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 	return []runtime.Object{joinConfig, kubeletConfig, kubeproxyConfig}, nil
 }
 
-func newNodeIP(host kubeoneapi.HostConfig) string {
-	nodeIP := host.PrivateAddress
-	if nodeIP == "" {
-		nodeIP = host.PublicAddress
-	}
-
-	return nodeIP
-}
-
 func newNodeRegistration(s *state.State, host kubeoneapi.HostConfig) kubeadmv1beta2.NodeRegistrationOptions {
 	return kubeadmv1beta2.NodeRegistrationOptions{
 		Name:      host.Hostname,
 		Taints:    host.Taints,
 		CRISocket: s.Cluster.ContainerRuntime.CRISocket(),
 		KubeletExtraArgs: map[string]string{
-			"node-ip":           newNodeIP(host),
+			"node-ip":           host.NodeIPs(s.Cluster.ClusterNetwork),
 			"volume-plugin-dir": "/var/lib/kubelet/volumeplugins",
 		},
 	}
@@ -427,10 +513,11 @@ func kubeProxyConfiguration(s *state.State) *kubeproxyv1alpha1.KubeProxyConfigur
 			Kind:       "KubeProxyConfiguration",
 			APIVersion: "kubeproxy.config.k8s.io/v1alpha1",
 		},
-		ClusterCIDR: s.Cluster.ClusterNetwork.PodSubnet,
+		ClusterCIDR: s.Cluster.ClusterNetwork.PodSubnets(),
 		ClientConnection: componentbasev1alpha1.ClientConnectionConfiguration{
 			Kubeconfig: "/var/lib/kube-proxy/kubeconfig.conf",
 		},
+		FeatureGates: features.MergeFeatureGates(s.Cluster.Features.FeatureGates, nil),
 	}
 
 	if kbPrx := s.Cluster.ClusterNetwork.KubeProxy; kbPrx != nil {
@@ -438,9 +525,10 @@ func kubeProxyConfiguration(s *state.State) *kubeproxyv1alpha1.KubeProxyConfigur
 		case kbPrx.IPVS != nil:
 			kubeProxyConfig.Mode = kubeproxyv1alpha1.ProxyMode("ipvs")
 			kubeProxyConfig.IPVS = kubeproxyv1alpha1.KubeProxyIPVSConfiguration{
-				StrictARP:     kbPrx.IPVS.StrictARP,
+				StrictARP:     addons.KubeProxyStrictARP(s),
 				Scheduler:     kbPrx.IPVS.Scheduler,
 				ExcludeCIDRs:  kbPrx.IPVS.ExcludeCIDRs,
+				SyncPeriod:    kbPrx.SyncPeriod,
 				TCPTimeout:    kbPrx.IPVS.TCPTimeout,
 				TCPFinTimeout: kbPrx.IPVS.TCPFinTimeout,
 				UDPTimeout:    kbPrx.IPVS.UDPTimeout,
@@ -448,6 +536,15 @@ func kubeProxyConfiguration(s *state.State) *kubeproxyv1alpha1.KubeProxyConfigur
 		case kbPrx.IPTables != nil:
 			kubeProxyConfig.Mode = kubeproxyv1alpha1.ProxyMode("iptables")
 		}
+
+		if kbPrx.IPVS == nil {
+			kubeProxyConfig.IPTables.SyncPeriod = kbPrx.SyncPeriod
+		}
+		kubeProxyConfig.MetricsBindAddress = kbPrx.MetricsBindAddress
+		if kbPrx.Conntrack != nil {
+			kubeProxyConfig.Conntrack.MaxPerCore = kbPrx.Conntrack.MaxPerCore
+			kubeProxyConfig.Conntrack.Min = kbPrx.Conntrack.Min
+		}
 	}
 
 	return kubeProxyConfig
@@ -25,14 +25,15 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 
+	"k8c.io/kubeone/pkg/addons"
 	bootstraptokenv1 "k8c.io/kubeone/pkg/apis/kubeadm/bootstraptoken/v1"
 	kubeadmv1beta3 "k8c.io/kubeone/pkg/apis/kubeadm/v1beta3"
 	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/features"
 	"k8c.io/kubeone/pkg/kubeflags"
 	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/konnectivity"
 	"k8c.io/kubeone/pkg/templates/kubeadm/kubeadmargs"
-	"k8c.io/kubeone/pkg/templates/resources"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -89,8 +90,15 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 			},
 		},
 		LocalAPIEndpoint: kubeadmv1beta3.APIEndpoint{
-			AdvertiseAddress: newNodeIP(host),
+			AdvertiseAddress: host.AdvertiseAddress(),
 		},
+		Patches: newPatches(s, host),
+	}
+
+	if cluster.ClusterNetwork.CiliumReplacesKubeProxy() {
+		// kubeadm doesn't upgrade kube-proxy if its ConfigMap is missing, so
+		// it's enough to skip it when initializing the cluster
+		initConfig.SkipPhases = []string{"addon/kube-proxy"}
 	}
 
 	joinConfig := &kubeadmv1beta3.JoinConfiguration{
@@ -100,7 +108,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		},
 		ControlPlane: &kubeadmv1beta3.JoinControlPlane{
 			LocalAPIEndpoint: kubeadmv1beta3.APIEndpoint{
-				AdvertiseAddress: newNodeIP(host),
+				AdvertiseAddress: host.AdvertiseAddress(),
 			},
 		},
 		Discovery: kubeadmv1beta3.Discovery{
@@ -110,6 +118,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 				UnsafeSkipCAVerification: true,
 			},
 		},
+		Patches: newPatches(s, host),
 	}
 
 	clusterConfig := &kubeadmv1beta3.ClusterConfiguration{
@@ -118,8 +127,8 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 			Kind:       "ClusterConfiguration",
 		},
 		Networking: kubeadmv1beta3.Networking{
-			PodSubnet:     cluster.ClusterNetwork.PodSubnet,
-			ServiceSubnet: cluster.ClusterNetwork.ServiceSubnet,
+			PodSubnet:     cluster.ClusterNetwork.PodSubnets(),
+			ServiceSubnet: cluster.ClusterNetwork.ServiceSubnets(),
 			DNSDomain:     cluster.ClusterNetwork.ServiceDomainName,
 		},
 		KubernetesVersion:    cluster.Versions.Kubernetes,
@@ -143,14 +152,6 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		},
 		ClusterName:     cluster.Name,
 		ImageRepository: cluster.AssetConfiguration.Kubernetes.ImageRepository,
-		Etcd: kubeadmv1beta3.Etcd{
-			Local: &kubeadmv1beta3.LocalEtcd{
-				ImageMeta: kubeadmv1beta3.ImageMeta{
-					ImageRepository: cluster.AssetConfiguration.Etcd.ImageRepository,
-					ImageTag:        cluster.AssetConfiguration.Etcd.ImageTag,
-				},
-			},
-		},
 		DNS: kubeadmv1beta3.DNS{
 			ImageMeta: kubeadmv1beta3.ImageMeta{
 				ImageRepository: cluster.AssetConfiguration.CoreDNS.ImageRepository,
@@ -159,6 +160,24 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		},
 	}
 
+	if ext := cluster.ExternalEtcd; ext != nil {
+		clusterConfig.Etcd.External = &kubeadmv1beta3.ExternalEtcd{
+			Endpoints: ext.Endpoints,
+			CAFile:    ext.CAFile,
+			CertFile:  ext.CertFile,
+			KeyFile:   ext.KeyFile,
+		}
+	} else {
+		clusterConfig.Etcd.Local = &kubeadmv1beta3.LocalEtcd{
+			ImageMeta: kubeadmv1beta3.ImageMeta{
+				ImageRepository: cluster.AssetConfiguration.Etcd.ImageRepository,
+				ImageTag:        cluster.AssetConfiguration.Etcd.ImageTag,
+			},
+			ExtraArgs:      features.EtcdExtraArgs(cluster),
+			ServerCertSANs: cluster.Etcd.ServerCertSANs(),
+		}
+	}
+
 	bfalse := false
 	kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{
 		TypeMeta: metav1.TypeMeta{
@@ -168,7 +187,7 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		CgroupDriver:       "systemd",
 		ReadOnlyPort:       0,
 		RotateCertificates: true,
-		ClusterDNS:         []string{resources.NodeLocalDNSVirtualIP},
+		ClusterDNS:         features.KubeletClusterDNS(cluster),
 		Authentication: kubeletconfigv1beta1.KubeletAuthentication{
 			Anonymous: kubeletconfigv1beta1.KubeletAnonymousAuthentication{
 				Enabled: &bfalse,
@@ -177,6 +196,18 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		FeatureGates: map[string]bool{},
 	}
 
+	features.UpdateKubeletSeccompDefault(cluster.Features.SeccompDefault, kubeSemVer, nodeRegistration.KubeletExtraArgs, kubeletConfig.FeatureGates)
+
+	if cluster.Features.FIPS != nil && cluster.Features.FIPS.Enable {
+		kubeletConfig.TLSCipherSuites = features.FIPSTLSCipherSuites
+		kubeletConfig.TLSMinVersion = features.FIPSTLSMinVersion
+	}
+
+	features.UpdateKubeletConfiguration(cluster.KubeletConfig, kubeletConfig)
+	for k, v := range cluster.HostKubeletConfig(host).Flags() {
+		nodeRegistration.KubeletExtraArgs[k] = v
+	}
+
 	if cluster.AssetConfiguration.Pause.ImageRepository != "" {
 		nodeRegistration.KubeletExtraArgs["pod-infra-container-image"] = cluster.AssetConfiguration.Pause.ImageRepository + "/pause:" + cluster.AssetConfiguration.Pause.ImageTag
 	}
@@ -268,10 +299,14 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 			PathType:  corev1.HostPathDirectoryOrCreate,
 		}
 		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, auditPolicyVol)
-		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, logVol)
+		if !cluster.Features.StaticAuditLog.Config.DisableLogBackend {
+			clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, logVol)
+		}
 	}
 
-	if cluster.Features.PodNodeSelector != nil && cluster.Features.PodNodeSelector.Enable {
+	if (cluster.Features.PodNodeSelector != nil && cluster.Features.PodNodeSelector.Enable) ||
+		(cluster.Features.EventRateLimit != nil && cluster.Features.EventRateLimit.Enable) ||
+		(cluster.Features.PodSecurityAdmission != nil && cluster.Features.PodSecurityAdmission.Enable) {
 		admissionVol := kubeadmv1beta3.HostPathMount{
 			Name:      "admission-conf",
 			HostPath:  "/etc/kubernetes/admission",
@@ -281,6 +316,23 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 		}
 		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes, admissionVol)
 	}
+	if cluster.Features.Konnectivity.Enabled() {
+		clusterConfig.APIServer.ExtraVolumes = append(clusterConfig.APIServer.ExtraVolumes,
+			kubeadmv1beta3.HostPathMount{
+				Name:      "konnectivity-conf",
+				HostPath:  konnectivity.ConfigDir,
+				MountPath: konnectivity.ConfigDir,
+				ReadOnly:  true,
+				PathType:  corev1.HostPathDirectoryOrCreate,
+			},
+			kubeadmv1beta3.HostPathMount{
+				Name:      "konnectivity-uds",
+				HostPath:  konnectivity.SocketDir,
+				MountPath: konnectivity.SocketDir,
+				PathType:  corev1.HostPathDirectoryOrCreate,
+			},
+		)
+	}
 	// this is not exactly as s.EncryptionEnabled(). We need this to be true during the enable/disable or disable/enable transition.
 	if (cluster.Features.EncryptionProviders != nil && cluster.Features.EncryptionProviders.Enable) ||
 		s.LiveCluster.EncryptionConfiguration.Enable {
@@ -313,10 +365,23 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 
 	args := kubeadmargs.NewFrom(clusterConfig.APIServer.ExtraArgs)
 	features.UpdateKubeadmClusterConfiguration(cluster.Features, args)
+	features.UpdateKubeadmAPIServerConfiguration(cluster.ControlPlaneComponents, args)
 
 	clusterConfig.APIServer.ExtraArgs = args.APIServer.ExtraArgs
 	clusterConfig.FeatureGates = args.FeatureGates
 
+	if components := cluster.ControlPlaneComponents; components != nil {
+		clusterConfig.ControllerManager.ExtraArgs = features.MergeControlPlaneComponentArgs(components.ControllerManager, clusterConfig.ControllerManager.ExtraArgs)
+		clusterConfig.ControllerManager.ExtraVolumes = append(clusterConfig.ControllerManager.ExtraVolumes, controlPlaneComponentVolumes(components.ControllerManager)...)
+		clusterConfig.Scheduler.ExtraArgs = features.MergeControlPlaneComponentArgs(components.Scheduler, clusterConfig.Scheduler.ExtraArgs)
+		clusterConfig.Scheduler.ExtraVolumes = append(clusterConfig.Scheduler.ExtraVolumes, controlPlaneComponentVolumes(components.Scheduler)...)
+	}
+
+	clusterConfig.APIServer.ExtraArgs = features.MergeFeatureGatesArgs(cluster.Features.FeatureGates, clusterConfig.APIServer.ExtraArgs)
+	clusterConfig.ControllerManager.ExtraArgs = features.MergeFeatureGatesArgs(cluster.Features.FeatureGates, clusterConfig.ControllerManager.ExtraArgs)
+	clusterConfig.Scheduler.ExtraArgs = features.MergeFeatureGatesArgs(cluster.Features.FeatureGates, clusterConfig.Scheduler.ExtraArgs)
+	kubeletConfig.FeatureGates = features.MergeFeatureGates(cluster.Features.FeatureGates, kubeletConfig.FeatureGates)
+
 	initConfig.NodeRegistration = nodeRegistration
 	joinConfig.NodeRegistration = nodeRegistration
 
@@ -325,9 +390,31 @@ func NewConfig(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, er
 	return []runtime.Object{initConfig, joinConfig, clusterConfig, kubeletConfig, kubeproxyConfig}, nil
 }
 
+func controlPlaneComponentVolumes(cfg *kubeoneapi.ControlPlaneComponentConfig) []kubeadmv1beta3.HostPathMount {
+	if cfg == nil {
+		return nil
+	}
+
+	volumes := []kubeadmv1beta3.HostPathMount{}
+	for _, vol := range cfg.ExtraVolumes {
+		volumes = append(volumes, kubeadmv1beta3.HostPathMount{
+			Name:      vol.Name,
+			HostPath:  vol.HostPath,
+			MountPath: vol.MountPath,
+			ReadOnly:  vol.ReadOnly,
+		})
+	}
+
+	return volumes
+}
+
 // NewConfig returns all required configs to init a cluster via a set of v13 configs
 func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Object, error) {
 	cluster := s.Cluster
+	kubeSemVer, err := semver.NewVersion(cluster.Versions.Kubernetes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse generate config, wrong kubernetes version %s", cluster.Versions.Kubernetes)
+	}
 
 	nodeRegistration := newNodeRegistration(s, host)
 	nodeRegistration.IgnorePreflightErrors = []string{
@@ -359,7 +446,7 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 		CgroupDriver:       "systemd",
 		ReadOnlyPort:       0,
 		RotateCertificates: true,
-		ClusterDNS:         []string{resources.NodeLocalDNSVirtualIP},
+		ClusterDNS:         features.KubeletClusterDNS(cluster),
 		Authentication: kubeletconfigv1beta1.KubeletAuthentication{
 			Anonymous: kubeletconfigv1beta1.KubeletAnonymousAuthentication{
 				Enabled: &bfalse,
@@ -368,6 +455,15 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 		FeatureGates: map[string]bool{},
 	}
 
+	features.UpdateKubeletSeccompDefault(cluster.Features.SeccompDefault, kubeSemVer, nodeRegistration.KubeletExtraArgs, kubeletConfig.FeatureGates)
+
+	if cluster.Features.FIPS != nil && cluster.Features.FIPS.Enable {
+		kubeletConfig.TLSCipherSuites = features.FIPSTLSCipherSuites
+		kubeletConfig.TLSMinVersion = features.FIPSTLSMinVersion
+	}
+
+	features.UpdateKubeletConfiguration(cluster.KubeletConfig, kubeletConfig)
+
 	if cluster.AssetConfiguration.Pause.ImageRepository != "" {
 		nodeRegistration.KubeletExtraArgs["pod-infra-container-image"] = cluster.AssetConfiguration.Pause.ImageRepository + "/pause:" + cluster.AssetConfiguration.Pause.ImageTag
 	}
@@ -394,6 +490,21 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 		}
 	}
 
+	kubeletConfig.FeatureGates = features.MergeFeatureGates(cluster.Features.FeatureGates, kubeletConfig.FeatureGates)
+
+	if pool := cluster.StaticWorkers.Pool(host.Pool); pool != nil {
+		for k, v := range pool.KubeletExtraArgs {
+			nodeRegistration.KubeletExtraArgs[k] = v
+		}
+		for k, v := range pool.FeatureGates {
+			kubeletConfig.FeatureGates[k] = v
+		}
+	}
+
+	for k, v := range cluster.HostKubeletConfig(host).Flags() {
+		nodeRegistration.KubeletExtraArgs[k] = v
+	}
+
 	joinConfig.NodeRegistration = nodeRegistration
 
 	kubeproxyConfig := kubeProxyConfiguration(s)
@@ -401,22 +512,13 @@ func NewConfigWorker(s *state.State, host kubeoneapi.HostConfig) ([]runtime.Obje
 	return []runtime.Object{joinConfig, kubeletConfig, kubeproxyConfig}, nil
 }
 
-func newNodeIP(host kubeoneapi.HostConfig) string {
-	nodeIP := host.PrivateAddress
-	if nodeIP == "" {
-		nodeIP = host.PublicAddress
-	}
-
-	return nodeIP
-}
-
 func newNodeRegistration(s *state.State, host kubeoneapi.HostConfig) kubeadmv1beta3.NodeRegistrationOptions {
 	return kubeadmv1beta3.NodeRegistrationOptions{
 		Name:      host.Hostname,
 		Taints:    host.Taints,
 		CRISocket: s.Cluster.ContainerRuntime.CRISocket(),
 		KubeletExtraArgs: map[string]string{
-			"node-ip":           newNodeIP(host),
+			"node-ip":           host.NodeIPs(s.Cluster.ClusterNetwork),
 			"volume-plugin-dir": "/var/lib/kubelet/volumeplugins",
 		},
 	}
@@ -428,10 +530,11 @@ func kubeProxyConfiguration(s *state.State) *kubeproxyv1alpha1.KubeProxyConfigur
 			Kind:       "KubeProxyConfiguration",
 			APIVersion: "kubeproxy.config.k8s.io/v1alpha1",
 		},
-		ClusterCIDR: s.Cluster.ClusterNetwork.PodSubnet,
+		ClusterCIDR: s.Cluster.ClusterNetwork.PodSubnets(),
 		ClientConnection: componentbasev1alpha1.ClientConnectionConfiguration{
 			Kubeconfig: "/var/lib/kube-proxy/kubeconfig.conf",
 		},
+		FeatureGates: features.MergeFeatureGates(s.Cluster.Features.FeatureGates, nil),
 	}
 
 	if kbPrx := s.Cluster.ClusterNetwork.KubeProxy; kbPrx != nil {
@@ -439,9 +542,10 @@ func kubeProxyConfiguration(s *state.State) *kubeproxyv1alpha1.KubeProxyConfigur
 		case kbPrx.IPVS != nil:
 			kubeProxyConfig.Mode = kubeproxyv1alpha1.ProxyMode("ipvs")
 			kubeProxyConfig.IPVS = kubeproxyv1alpha1.KubeProxyIPVSConfiguration{
-				StrictARP:     kbPrx.IPVS.StrictARP,
+				StrictARP:     addons.KubeProxyStrictARP(s),
 				Scheduler:     kbPrx.IPVS.Scheduler,
 				ExcludeCIDRs:  kbPrx.IPVS.ExcludeCIDRs,
+				SyncPeriod:    kbPrx.SyncPeriod,
 				TCPTimeout:    kbPrx.IPVS.TCPTimeout,
 				TCPFinTimeout: kbPrx.IPVS.TCPFinTimeout,
 				UDPTimeout:    kbPrx.IPVS.UDPTimeout,
@@ -449,6 +553,15 @@ func kubeProxyConfiguration(s *state.State) *kubeproxyv1alpha1.KubeProxyConfigur
 		case kbPrx.IPTables != nil:
 			kubeProxyConfig.Mode = kubeproxyv1alpha1.ProxyMode("iptables")
 		}
+
+		if kbPrx.IPVS == nil {
+			kubeProxyConfig.IPTables.SyncPeriod = kbPrx.SyncPeriod
+		}
+		kubeProxyConfig.MetricsBindAddress = kbPrx.MetricsBindAddress
+		if kbPrx.Conntrack != nil {
+			kubeProxyConfig.Conntrack.MaxPerCore = kbPrx.Conntrack.MaxPerCore
+			kubeProxyConfig.Conntrack.Min = kbPrx.Conntrack.Min
+		}
 	}
 
 	return kubeProxyConfig
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta3
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	kubeadmv1beta3 "k8c.io/kubeone/pkg/apis/kubeadm/v1beta3"
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
+	"k8c.io/kubeone/pkg/state"
+
+	"sigs.k8s.io/yaml"
+)
+
+// etcdPatchFile is the kubeadm patch of the etcd static pod manifest. The
+// json patch appends flags to the etcd command, and since etcd uses the last
+// occurrence of a flag, they override the flags rendered by kubeadm.
+const etcdPatchFile = "etcd+json.yaml"
+
+type jsonPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// PatchesDir returns the directory with kubeadm patches of the control plane
+// host, relative to the workdir
+func PatchesDir(host kubeoneapi.HostConfig) string {
+	return fmt.Sprintf("cfg/patches/master_%d", host.ID)
+}
+
+// NewPatches returns kubeadm patches of the control plane host, keyed by
+// their path relative to the workdir
+func NewPatches(host kubeoneapi.HostConfig) (map[string]string, error) {
+	overrides := host.NetworkOverrides
+	if !overrides.EtcdURLsOverridden() {
+		return nil, nil
+	}
+
+	etcdFlags := []struct {
+		name string
+		urls []string
+	}{
+		{name: "listen-client-urls", urls: overrides.EtcdListenClientURLs},
+		{name: "advertise-client-urls", urls: overrides.EtcdAdvertiseClientURLs},
+		{name: "listen-peer-urls", urls: overrides.EtcdListenPeerURLs},
+	}
+
+	ops := []jsonPatchOperation{}
+	for _, flag := range etcdFlags {
+		if len(flag.urls) == 0 {
+			continue
+		}
+
+		ops = append(ops, jsonPatchOperation{
+			Op:    "add",
+			Path:  "/spec/containers/0/command/-",
+			Value: fmt.Sprintf("--%s=%s", flag.name, strings.Join(flag.urls, ",")),
+		})
+	}
+
+	patch, err := yaml.Marshal(ops)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal etcd patch")
+	}
+
+	return map[string]string{
+		path.Join(PatchesDir(host), etcdPatchFile): string(patch),
+	}, nil
+}
+
+func newPatches(s *state.State, host kubeoneapi.HostConfig) *kubeadmv1beta3.Patches {
+	if !host.NetworkOverrides.EtcdURLsOverridden() {
+		return nil
+	}
+
+	return &kubeadmv1beta3.Patches{
+		Directory: s.WorkDir + "/" + PatchesDir(host),
+	}
+}
@@ -88,6 +88,49 @@ type HetznerSpec struct {
 	Labels     map[string]string `json:"labels,omitempty"`
 }
 
+// KubeVirtSpec holds cloudprovider spec for KubeVirt
+type KubeVirtSpec struct {
+	ClusterName    string                 `json:"clusterName,omitempty"`
+	VirtualMachine KubeVirtVirtualMachine `json:"virtualMachine"`
+}
+
+// KubeVirtVirtualMachine describes the KubeVirt VirtualMachines backing the
+// Machines
+type KubeVirtVirtualMachine struct {
+	Template  KubeVirtVirtualMachineTemplate `json:"template"`
+	DNSPolicy string                         `json:"dnsPolicy,omitempty"`
+}
+
+// KubeVirtVirtualMachineTemplate describes the resources of the KubeVirt
+// VirtualMachines
+type KubeVirtVirtualMachineTemplate struct {
+	CPUs        string              `json:"cpus"`
+	Memory      string              `json:"memory"`
+	PrimaryDisk KubeVirtPrimaryDisk `json:"primaryDisk"`
+}
+
+// KubeVirtPrimaryDisk describes the primary disk of the KubeVirt
+// VirtualMachines
+type KubeVirtPrimaryDisk struct {
+	OsImage          string `json:"osImage"`
+	Size             string `json:"size"`
+	StorageClassName string `json:"storageClassName"`
+}
+
+// NutanixSpec holds cloudprovider spec for Nutanix
+type NutanixSpec struct {
+	ClusterName    string            `json:"clusterName"`
+	ProjectName    string            `json:"projectName,omitempty"`
+	SubnetName     string            `json:"subnetName"`
+	ImageName      string            `json:"imageName"`
+	CPUs           int               `json:"cpus"`
+	CPUCores       *int              `json:"cpuCores,omitempty"`
+	CPUPassthrough *bool             `json:"cpuPassthrough,omitempty"`
+	MemoryMB       int               `json:"memoryMB"`
+	DiskSize       *int              `json:"diskSize,omitempty"`
+	Categories     map[string]string `json:"categories,omitempty"`
+}
+
 // PacketSpec holds cloudprovider spec for Packet
 type PacketSpec struct {
 	ProjectID    string   `json:"projectID"`
@@ -113,6 +156,23 @@ type VSphereSpec struct {
 	VMNetName        string `json:"vmNetName,omitempty"`
 }
 
+// VMwareCloudDirectorSpec holds cloudprovider spec for VMware Cloud Director
+type VMwareCloudDirectorSpec struct {
+	Organization     string            `json:"organization"`
+	VDC              string            `json:"vdc"`
+	VApp             string            `json:"vapp"`
+	Catalog          string            `json:"catalog"`
+	Template         string            `json:"template"`
+	Network          string            `json:"network"`
+	IPAllocationMode string            `json:"ipAllocationMode,omitempty"`
+	CPUs             int               `json:"cpus"`
+	CPUCores         int               `json:"cpuCores"`
+	MemoryMB         int               `json:"memoryMB"`
+	DiskSizeGB       *int              `json:"diskSizeGB,omitempty"`
+	StorageProfile   *string           `json:"storageProfile,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
 // AzureSpec holds cloudprovider spec for Azure
 type AzureSpec struct {
 	AssignPublicIP    bool              `json:"assignPublicIP"`
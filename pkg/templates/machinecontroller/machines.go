@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/pkg/errors"
 
@@ -37,6 +38,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+const (
+	// autoscalerMinSizeAnnotation and autoscalerMaxSizeAnnotation are used by
+	// the cluster-autoscaler to discover the MachineDeployments to scale
+	autoscalerMinSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-min-size"
+	autoscalerMaxSizeAnnotation = "cluster.k8s.io/cluster-api-autoscaler-node-group-max-size"
+)
+
 // CreateMachineDeployments creates MachineDeployments that create appropriate
 // worker machines
 func CreateMachineDeployments(s *state.State) error {
@@ -62,6 +70,29 @@ func CreateMachineDeployments(s *state.State) error {
 	return nil
 }
 
+// CreateMachineDeployment creates the MachineDeployment of the dynamic
+// workerset with the given name
+func CreateMachineDeployment(s *state.State, name string) error {
+	if s.DynamicClient == nil {
+		return errors.New("kubernetes dynamic client in not initialized")
+	}
+
+	for _, workerset := range s.Cluster.DynamicWorkers {
+		if workerset.Name != name {
+			continue
+		}
+
+		machinedeployment, err := createMachineDeployment(s.Cluster, workerset)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate MachineDeployment")
+		}
+
+		return errors.Wrap(clientutil.CreateOrUpdate(s.Context, s.DynamicClient, machinedeployment), "failed to ensure MachineDeployment")
+	}
+
+	return errors.Errorf("dynamic workerset %q not found", name)
+}
+
 // GenerateMachineDeploymentsManifest generates YAML manifests containing
 // all MachineDeployments present in the state.
 func GenerateMachineDeploymentsManifest(s *state.State) (string, error) {
@@ -124,9 +155,17 @@ func createMachineDeployment(cluster *kubeoneapi.KubeOneCluster, workerset kubeo
 		maxUnavailable = intstr.FromInt(1)
 	}
 
+	annotations := workerset.Config.Annotations
+	if workerset.Autoscaling != nil {
+		annotations = labels.Merge(annotations, map[string]string{
+			autoscalerMinSizeAnnotation: strconv.Itoa(workerset.Autoscaling.MinReplicas),
+			autoscalerMaxSizeAnnotation: strconv.Itoa(workerset.Autoscaling.MaxReplicas),
+		})
+	}
+
 	return &clusterv1alpha1.MachineDeployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Annotations: workerset.Config.Annotations,
+			Annotations: annotations,
 			Namespace:   metav1.NamespaceSystem,
 			Name:        workerset.Name,
 		},
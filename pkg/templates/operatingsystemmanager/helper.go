@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatingsystemmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8c.io/kubeone/pkg/addons"
+	"k8c.io/kubeone/pkg/clientutil"
+	"k8c.io/kubeone/pkg/state"
+	"k8c.io/kubeone/pkg/templates/resources"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const appLabelKey = "app"
+
+// Ensure install/update operating-system-manager
+func Ensure(s *state.State) error {
+	s.Logger.Infoln("Installing operating-system-manager...")
+
+	err := addons.EnsureAddonByName(s, resources.AddonOperatingSystemManager)
+	return errors.Wrap(err, "failed to deploy operating-system-manager")
+}
+
+// WaitReady waits for operating-system-manager and its webhook to became ready
+func WaitReady(s *state.State) error {
+	if !s.Cluster.OperatingSystemManagerEnabled() {
+		return nil
+	}
+
+	s.Logger.Infoln("Waiting for operating-system-manager to come up...")
+
+	if err := waitForPods(s.Context, s.DynamicClient, resources.OperatingSystemManagerWebhookName); err != nil {
+		return errors.Wrap(err, "operating-system-manager-webhook did not come up")
+	}
+
+	if err := waitForPods(s.Context, s.DynamicClient, resources.OperatingSystemManagerName); err != nil {
+		return errors.Wrap(err, "operating-system-manager did not come up")
+	}
+
+	condFn := clientutil.CRDsReadyCondition(s.Context, s.DynamicClient, CRDNames())
+	if err := wait.Poll(5*time.Second, 3*time.Minute, condFn); err != nil {
+		return errors.Wrap(err, "operating-system-manager CRDs did not come up")
+	}
+
+	return nil
+}
+
+// CRDNames returns names of the CRDs deployed by operating-system-manager
+func CRDNames() []string {
+	return []string{
+		"operatingsystemconfigs.operatingsystemmanager.k8c.io",
+		"operatingsystemprofiles.operatingsystemmanager.k8c.io",
+	}
+}
+
+// waitForPods waits for pods with the given app label to become running
+func waitForPods(ctx context.Context, client dynclient.Client, app string) error {
+	condFn := clientutil.PodsReadyCondition(ctx, client, dynclient.ListOptions{
+		Namespace: resources.OperatingSystemManagerNamespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			appLabelKey: app,
+		}),
+	})
+
+	return wait.Poll(5*time.Second, 3*time.Minute, condFn)
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package resources
 
 import (
+	"net"
+	"strings"
+
+	kubeoneapi "k8c.io/kubeone/pkg/apis/kubeone"
 	"k8c.io/kubeone/pkg/certificate/cabundle"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,37 +28,114 @@ import (
 
 // Names of the internal addons
 const (
-	AddonCCMAzure           = "ccm-azure"
-	AddonCCMDigitalOcean    = "ccm-digitalocean"
-	AddonCCMHetzner         = "ccm-hetzner"
-	AddonCCMOpenStack       = "ccm-openstack"
-	AddonCCMPacket          = "ccm-packet"
-	AddonCCMVsphere         = "ccm-vsphere"
-	AddonCSIHetnzer         = "csi-hetzner"
-	AddonCSIOpenStackCinder = "csi-openstack-cinder"
-	AddonCSIVsphere         = "csi-vsphere"
-	AddonCNICanal           = "cni-canal"
-	AddonCNIWeavenet        = "cni-weavenet"
-	AddonMachineController  = "machinecontroller"
-	AddonMetricsServer      = "metrics-server"
-	AddonNodeLocalDNS       = "nodelocaldns"
+	AddonCCMAws                 = "ccm-aws"
+	AddonCCMAzure               = "ccm-azure"
+	AddonCCMDigitalOcean        = "ccm-digitalocean"
+	AddonCCMHetzner             = "ccm-hetzner"
+	AddonCCMKubeVirt            = "ccm-kubevirt"
+	AddonCCMNutanix             = "ccm-nutanix"
+	AddonCCMOpenStack           = "ccm-openstack"
+	AddonCCMPacket              = "ccm-packet"
+	AddonCCMVsphere             = "ccm-vsphere"
+	AddonCCMVMwareCloudDirector = "ccm-vmware-cloud-director"
+	AddonCSIAwsEBS              = "csi-aws-ebs"
+	AddonCSIAzureDisk           = "csi-azuredisk"
+	AddonCSIAzureFile           = "csi-azurefile"
+	AddonCSIHetnzer             = "csi-hetzner"
+	AddonCSIKubeVirt            = "csi-kubevirt"
+	AddonCSINutanix             = "csi-nutanix"
+	AddonCSIOpenStackCinder     = "csi-openstack-cinder"
+	AddonCSIVsphere             = "csi-vsphere"
+	AddonCSIVMwareCloudDirector = "csi-vmware-cloud-director"
+	AddonCNICalico              = "cni-calico"
+	AddonCNICanal               = "cni-canal"
+	AddonCNICilium              = "cni-cilium"
+	AddonCNIWeavenet            = "cni-weavenet"
+	AddonClusterAutoscaler      = "cluster-autoscaler"
+	AddonGatekeeper             = "gatekeeper"
+	AddonGatekeeperLibrary      = "gatekeeper-library"
+	AddonKonnectivityAgent      = "konnectivity-agent"
+	AddonMachineController      = "machinecontroller"
+	AddonMetricsServer          = "metrics-server"
+	AddonNodeLocalDNS           = "nodelocaldns"
+	AddonOperatingSystemManager = "operating-system-manager"
+	AddonSRIOV                  = "sriov"
 )
 
 const (
+	// NodeLocalDNSVirtualIP is used if the NodeLocalDNS virtual IP is not
+	// configured
 	NodeLocalDNSVirtualIP = "169.254.20.10"
+	// NodeLocalDNSVirtualIPv6 is used in clusters with the IPv6 service
+	// subnet, in addition to the IPv4 address in dual-stack clusters, if the
+	// NodeLocalDNS virtual IPv6 is not configured
+	NodeLocalDNSVirtualIPv6 = "fd00::10"
 )
 
+// NodeLocalDNSVirtualIPs returns the addresses NodeLocalDNS binds to, one per
+// IP family of the comma-separated service subnets, ordered as the subnets.
+// The IPv4 address is returned if no subnet can be parsed.
+func NodeLocalDNSVirtualIPs(serviceSubnets string, nodeLocalDNS *kubeoneapi.NodeLocalDNS) []string {
+	vip4, vip6 := NodeLocalDNSVirtualIP, NodeLocalDNSVirtualIPv6
+	if nodeLocalDNS != nil {
+		if nodeLocalDNS.Config.VirtualIP != "" {
+			vip4 = nodeLocalDNS.Config.VirtualIP
+		}
+		if nodeLocalDNS.Config.VirtualIPv6 != "" {
+			vip6 = nodeLocalDNS.Config.VirtualIPv6
+		}
+	}
+
+	var ips []string
+	seen := map[string]bool{}
+
+	for _, subnet := range strings.Split(serviceSubnets, ",") {
+		ip, _, err := net.ParseCIDR(strings.TrimSpace(subnet))
+		if err != nil {
+			continue
+		}
+
+		vip := vip4
+		if ip.To4() == nil {
+			vip = vip6
+		}
+
+		if !seen[vip] {
+			seen[vip] = true
+			ips = append(ips, vip)
+		}
+	}
+
+	if len(ips) == 0 {
+		return []string{vip4}
+	}
+
+	return ips
+}
+
 const (
 	// names used for deployments/labels/etc
 	MachineControllerName        = "machine-controller"
 	MachineControllerNameSpace   = metav1.NamespaceSystem
 	MachineControllerWebhookName = "machine-controller-webhook"
 
+	OperatingSystemManagerName        = "operating-system-manager"
+	OperatingSystemManagerNamespace   = metav1.NamespaceSystem
+	OperatingSystemManagerWebhookName = "operating-system-manager-webhook"
+
 	MetricsServerName      = "metrics-server"
 	MetricsServerNamespace = metav1.NamespaceSystem
 
 	VsphereCSIWebhookName      = "vsphere-webhook-svc"
 	VsphereCSIWebhookNamespace = metav1.NamespaceSystem
+
+	// SRIOVNodeLabel is applied to nodes with SR-IOV virtual functions
+	// configured, to schedule the device plugin and CNI plugin
+	SRIOVNodeLabel = "v1.kubeone.io/sriov"
+
+	// StaticWorkerPoolNodeLabel is applied to nodes of static workers in a
+	// pool, with the pool name as the value
+	StaticWorkerPoolNodeLabel = "v1.kubeone.io/static-worker-pool"
 )
 
 const (
@@ -73,6 +154,7 @@ func All() map[string]string {
 		"MachineControllerNameSpace":   MachineControllerNameSpace,
 		"MachineControllerWebhookName": MachineControllerWebhookName,
 		"KubeletImageRepository":       KubeletImageRepository,
+		"SRIOVNodeLabel":               SRIOVNodeLabel,
 		"NodeLocalDNSVirtualIP":        NodeLocalDNSVirtualIP,
 		"CABundleSSLCertFilePath":      cabundle.SSLCertFilePath,
 	}
@@ -62,20 +62,30 @@ type controlPlane struct {
 	LeaderIP      string  `json:"leader_ip"`
 	Untaint       bool    `json:"untaint"`
 	NetworkID     string  `json:"network_id"`
+	VAppName      string  `json:"vapp_name"`
 	hostsSpec
 }
 
 type hostsSpec struct {
-	PublicAddress     []string `json:"public_address"`
-	PrivateAddress    []string `json:"private_address"`
-	Hostnames         []string `json:"hostnames"`
-	SSHUser           string   `json:"ssh_user"`
-	SSHPort           int      `json:"ssh_port"`
-	SSHPrivateKeyFile string   `json:"ssh_private_key_file"`
-	SSHAgentSocket    string   `json:"ssh_agent_socket"`
-	Bastion           string   `json:"bastion"`
-	BastionPort       int      `json:"bastion_port"`
-	BastionUser       string   `json:"bastion_user"`
+	PublicAddress     []string      `json:"public_address"`
+	PrivateAddress    []string      `json:"private_address"`
+	Hostnames         []string      `json:"hostnames"`
+	SSHUser           string        `json:"ssh_user"`
+	SSHPort           int           `json:"ssh_port"`
+	SSHPrivateKeyFile string        `json:"ssh_private_key_file"`
+	SSHCertFile       string        `json:"ssh_cert_file"`
+	SSHAgentSocket    string        `json:"ssh_agent_socket"`
+	Bastion           string        `json:"bastion"`
+	BastionPort       int           `json:"bastion_port"`
+	BastionUser       string        `json:"bastion_user"`
+	Bastions          []bastionSpec `json:"bastions"`
+}
+
+type bastionSpec struct {
+	Host              string `json:"host"`
+	Port              int    `json:"port"`
+	User              string `json:"user"`
+	SSHPrivateKeyFile string `json:"ssh_private_key_file"`
 }
 
 type hostConfigsOpts func([]kubeonev1beta1.HostConfig)
@@ -216,6 +226,11 @@ func (c *Config) Apply(cluster *kubeonev1beta1.KubeOneCluster) error {
 		cluster.CloudProvider.Hetzner.NetworkID = cp.NetworkID
 	}
 
+	if len(cp.VAppName) > 0 && cluster.CloudProvider.VMwareCloudDirector != nil && cluster.CloudProvider.VMwareCloudDirector.VApp == "" {
+		// VAppName is used only for VMware Cloud Director
+		cluster.CloudProvider.VMwareCloudDirector.VApp = cp.VAppName
+	}
+
 	// Walk through all configued workersets from terraform and apply their config
 	// by either merging it into an existing workerSet or creating a new one
 	for workersetName, workersetValue := range c.KubeOneWorkers.Value {
@@ -254,12 +269,18 @@ func (c *Config) Apply(cluster *kubeonev1beta1.KubeOneCluster) error {
 			err = c.updateGCEWorkerset(existingWorkerSet, workersetValue.Config.CloudProviderSpec)
 		case cluster.CloudProvider.Hetzner != nil:
 			err = c.updateHetznerWorkerset(existingWorkerSet, workersetValue.Config.CloudProviderSpec)
+		case cluster.CloudProvider.KubeVirt != nil:
+			err = c.updateKubeVirtWorkerset(existingWorkerSet, workersetValue.Config.CloudProviderSpec)
+		case cluster.CloudProvider.Nutanix != nil:
+			err = c.updateNutanixWorkerset(existingWorkerSet, workersetValue.Config.CloudProviderSpec)
 		case cluster.CloudProvider.Openstack != nil:
 			err = c.updateOpenStackWorkerset(existingWorkerSet, workersetValue.Config.CloudProviderSpec)
 		case cluster.CloudProvider.Packet != nil:
 			err = c.updatePacketWorkerset(existingWorkerSet, workersetValue.Config.CloudProviderSpec)
 		case cluster.CloudProvider.Vsphere != nil:
 			err = c.updateVSphereWorkerset(existingWorkerSet, workersetValue.Config.CloudProviderSpec)
+		case cluster.CloudProvider.VMwareCloudDirector != nil:
+			err = c.updateVMwareCloudDirectorWorkerset(existingWorkerSet, workersetValue.Config.CloudProviderSpec)
 		default:
 			return errors.Errorf("unknown provider")
 		}
@@ -272,15 +293,32 @@ func (c *Config) Apply(cluster *kubeonev1beta1.KubeOneCluster) error {
 	return nil
 }
 
+func newBastionConfigs(specs []bastionSpec) []kubeonev1beta1.BastionConfig {
+	var bastions []kubeonev1beta1.BastionConfig
+
+	for _, b := range specs {
+		bastions = append(bastions, kubeonev1beta1.BastionConfig{
+			Host:              b.Host,
+			Port:              b.Port,
+			User:              b.User,
+			SSHPrivateKeyFile: b.SSHPrivateKeyFile,
+		})
+	}
+
+	return bastions
+}
+
 func newHostConfig(publicIP, privateIP, hostname string, hs *hostsSpec) kubeonev1beta1.HostConfig {
 	return kubeonev1beta1.HostConfig{
 		Bastion:           hs.Bastion,
 		BastionPort:       hs.BastionPort,
 		BastionUser:       hs.BastionUser,
+		Bastions:          newBastionConfigs(hs.Bastions),
 		Hostname:          hostname,
 		PrivateAddress:    privateIP,
 		PublicAddress:     publicIP,
 		SSHAgentSocket:    hs.SSHAgentSocket,
+		SSHCertFile:       hs.SSHCertFile,
 		SSHPrivateKeyFile: hs.SSHPrivateKeyFile,
 		SSHUsername:       hs.SSHUser,
 		SSHPort:           hs.SSHPort,
@@ -438,6 +476,56 @@ func (c *Config) updateHetznerWorkerset(existingWorkerSet *kubeonev1beta1.Dynami
 	return nil
 }
 
+func (c *Config) updateKubeVirtWorkerset(existingWorkerSet *kubeonev1beta1.DynamicWorkerConfig, cfg json.RawMessage) error {
+	var kubevirtConfig machinecontroller.KubeVirtSpec
+
+	if err := json.Unmarshal(cfg, &kubevirtConfig); err != nil {
+		return err
+	}
+
+	flags := []cloudProviderFlags{
+		{key: "clusterName", value: kubevirtConfig.ClusterName},
+		{key: "virtualMachine", value: kubevirtConfig.VirtualMachine},
+	}
+
+	for _, flag := range flags {
+		if err := setWorkersetFlag(existingWorkerSet, flag.key, flag.value); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) updateNutanixWorkerset(existingWorkerSet *kubeonev1beta1.DynamicWorkerConfig, cfg json.RawMessage) error {
+	var nutanixConfig machinecontroller.NutanixSpec
+
+	if err := json.Unmarshal(cfg, &nutanixConfig); err != nil {
+		return err
+	}
+
+	flags := []cloudProviderFlags{
+		{key: "clusterName", value: nutanixConfig.ClusterName},
+		{key: "projectName", value: nutanixConfig.ProjectName},
+		{key: "subnetName", value: nutanixConfig.SubnetName},
+		{key: "imageName", value: nutanixConfig.ImageName},
+		{key: "cpus", value: nutanixConfig.CPUs},
+		{key: "cpuCores", value: nutanixConfig.CPUCores},
+		{key: "cpuPassthrough", value: nutanixConfig.CPUPassthrough},
+		{key: "memoryMB", value: nutanixConfig.MemoryMB},
+		{key: "diskSize", value: nutanixConfig.DiskSize},
+		{key: "categories", value: nutanixConfig.Categories},
+	}
+
+	for _, flag := range flags {
+		if err := setWorkersetFlag(existingWorkerSet, flag.key, flag.value); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
 func (c *Config) updateOpenStackWorkerset(existingWorkerSet *kubeonev1beta1.DynamicWorkerConfig, cfg json.RawMessage) error {
 	var openstackConfig machinecontroller.OpenStackSpec
 
@@ -523,6 +611,38 @@ func (c *Config) updateVSphereWorkerset(existingWorkerSet *kubeonev1beta1.Dynami
 	return nil
 }
 
+func (c *Config) updateVMwareCloudDirectorWorkerset(existingWorkerSet *kubeonev1beta1.DynamicWorkerConfig, cfg json.RawMessage) error {
+	var vcdConfig machinecontroller.VMwareCloudDirectorSpec
+
+	if err := json.Unmarshal(cfg, &vcdConfig); err != nil {
+		return err
+	}
+
+	flags := []cloudProviderFlags{
+		{key: "organization", value: vcdConfig.Organization},
+		{key: "vdc", value: vcdConfig.VDC},
+		{key: "vapp", value: vcdConfig.VApp},
+		{key: "catalog", value: vcdConfig.Catalog},
+		{key: "template", value: vcdConfig.Template},
+		{key: "network", value: vcdConfig.Network},
+		{key: "ipAllocationMode", value: vcdConfig.IPAllocationMode},
+		{key: "cpus", value: vcdConfig.CPUs},
+		{key: "cpuCores", value: vcdConfig.CPUCores},
+		{key: "memoryMB", value: vcdConfig.MemoryMB},
+		{key: "diskSizeGB", value: vcdConfig.DiskSizeGB},
+		{key: "storageProfile", value: vcdConfig.StorageProfile},
+		{key: "metadata", value: vcdConfig.Metadata},
+	}
+
+	for _, flag := range flags {
+		if err := setWorkersetFlag(existingWorkerSet, flag.key, flag.value); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
 func setWorkersetFlag(w *kubeonev1beta1.DynamicWorkerConfig, name string, value interface{}) error {
 	// ignore empty values (i.e. not set in terraform output)
 	switch s := value.(type) {
@@ -560,6 +680,7 @@ func setWorkersetFlag(w *kubeonev1beta1.DynamicWorkerConfig, name string, value
 			return nil
 		}
 	case machinecontroller.AzureImagePlan:
+	case machinecontroller.KubeVirtVirtualMachine:
 	case *machinecontroller.AzureImagePlan:
 		if s == nil {
 			return nil
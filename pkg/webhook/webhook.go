@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook exposes the KubeOneCluster defaulting and validation as a
+// Kubernetes validating admission webhook. The same code path as for the
+// manifests given to the kubeone CLI is used, see config.BytesToKubeOneCluster.
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"k8c.io/kubeone/pkg/apis/kubeone/config"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ValidateKubeOneClusterPath is the path the KubeOneCluster validating webhook is served at
+	ValidateKubeOneClusterPath = "/validate-kubeone-io-kubeonecluster"
+)
+
+// ValidateKubeOneCluster defaults and validates the KubeOneCluster object from
+// the admission request
+func ValidateKubeOneCluster(req *admissionv1.AdmissionRequest, logger logrus.FieldLogger) *admissionv1.AdmissionResponse {
+	resp := &admissionv1.AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+
+	if req.Operation == admissionv1.Delete {
+		return resp
+	}
+
+	if len(req.Object.Raw) == 0 {
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Code:    http.StatusBadRequest,
+			Message: "admission request doesn't contain the object",
+		}
+
+		return resp
+	}
+
+	if _, err := config.BytesToKubeOneCluster(req.Object.Raw, nil, nil, logger); err != nil {
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Code:    http.StatusForbidden,
+			Reason:  metav1.StatusReasonForbidden,
+			Message: err.Error(),
+		}
+	}
+
+	return resp
+}
+
+// NewHandler returns the HTTP handler serving AdmissionReview requests for
+// the KubeOneCluster validating webhook
+func NewHandler(logger logrus.FieldLogger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			http.Error(w, "expected application/json content type", http.StatusUnsupportedMediaType)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "unable to read the request body", http.StatusBadRequest)
+			return
+		}
+
+		review := &admissionv1.AdmissionReview{}
+		if err = json.Unmarshal(body, review); err != nil || review.Request == nil {
+			http.Error(w, "unable to decode AdmissionReview request", http.StatusBadRequest)
+			return
+		}
+
+		review.Response = ValidateKubeOneCluster(review.Request, logger)
+		review.Request = nil
+
+		w.Header().Set("Content-Type", "application/json")
+		if err = json.NewEncoder(w).Encode(review); err != nil {
+			logger.Errorf("Failed to encode AdmissionReview response: %v", err)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The KubeOne Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const validCluster = `{
+  "apiVersion": "kubeone.io/v1beta1",
+  "kind": "KubeOneCluster",
+  "name": "demo",
+  "versions": {"kubernetes": "1.22.2"},
+  "cloudProvider": {"none": {}},
+  "controlPlane": {
+    "hosts": [{"publicAddress": "192.168.0.10", "privateAddress": "10.0.0.10"}]
+  }
+}`
+
+const invalidCluster = `{
+  "apiVersion": "kubeone.io/v1beta1",
+  "kind": "KubeOneCluster",
+  "versions": {"kubernetes": "1.22.2"},
+  "cloudProvider": {"none": {}}
+}`
+
+func TestValidateKubeOneCluster(t *testing.T) {
+	tests := []struct {
+		name         string
+		operation    admissionv1.Operation
+		object       string
+		expectedCode int32
+		allowed      bool
+	}{
+		{
+			name:      "valid cluster",
+			operation: admissionv1.Create,
+			object:    validCluster,
+			allowed:   true,
+		},
+		{
+			name:         "invalid cluster",
+			operation:    admissionv1.Update,
+			object:       invalidCluster,
+			expectedCode: http.StatusForbidden,
+			allowed:      false,
+		},
+		{
+			name:      "delete is always allowed",
+			operation: admissionv1.Delete,
+			allowed:   true,
+		},
+		{
+			name:         "missing object",
+			operation:    admissionv1.Create,
+			expectedCode: http.StatusBadRequest,
+			allowed:      false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := &admissionv1.AdmissionRequest{
+				UID:       types.UID("test"),
+				Operation: tc.operation,
+				Object:    runtime.RawExtension{Raw: []byte(tc.object)},
+			}
+
+			resp := ValidateKubeOneCluster(req, logrus.New())
+			if resp.UID != req.UID {
+				t.Errorf("expected response UID %q, but got %q", req.UID, resp.UID)
+			}
+			if resp.Allowed != tc.allowed {
+				t.Errorf("expected allowed = %v, but got %v (%v)", tc.allowed, resp.Allowed, resp.Result)
+			}
+			if tc.expectedCode != 0 && (resp.Result == nil || resp.Result.Code != tc.expectedCode) {
+				t.Errorf("expected code %d, but got %v", tc.expectedCode, resp.Result)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	review := admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "admission.k8s.io/v1",
+			Kind:       "AdmissionReview",
+		},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       types.UID("test"),
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(invalidCluster)},
+		},
+	}
+
+	body, err := json.Marshal(review)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, ValidateKubeOneClusterPath, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	NewHandler(logrus.New()).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, but got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	got := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Kind != "AdmissionReview" || got.Response == nil {
+		t.Fatalf("expected AdmissionReview with response, but got %+v", got)
+	}
+	if got.Response.Allowed {
+		t.Errorf("expected invalid cluster to be denied")
+	}
+	if got.Response.UID != review.Request.UID {
+		t.Errorf("expected response UID %q, but got %q", review.Request.UID, got.Response.UID)
+	}
+}